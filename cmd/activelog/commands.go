@@ -0,0 +1,404 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/valentinesamuel/activelog/pkg/client"
+	"golang.org/x/term"
+)
+
+func runLogin(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("login")
+	email := fs.String("email", a.config.Email, "email to log in with (prompted for when empty)")
+	apiKey := fs.Bool("api-key", false, "log in with an API key instead of a password; unlike a password login it doesn't expire")
+	passwordStdin := fs.Bool("password-stdin", false, "read the password or API key from stdin instead of prompting")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *apiKey {
+		return loginWithAPIKey(ctx, a, *passwordStdin)
+	}
+
+	if *email == "" {
+		fmt.Fprint(a.stdout, "Email: ")
+		line, err := a.readLine()
+		if err != nil {
+			return err
+		}
+		*email = line
+	}
+	password, err := a.readSecret("Password: ", *passwordStdin)
+	if err != nil {
+		return err
+	}
+
+	baseURL := a.baseURL()
+	result, err := client.New(baseURL).LoginUser(ctx, &client.LoginUserRequest{Email: *email, Password: password})
+	if err != nil {
+		return fmt.Errorf("log in: %w", err)
+	}
+	if result["token"] == "" {
+		return errors.New("log in: the API returned no token")
+	}
+
+	a.config.URL, a.config.Email, a.config.Token = baseURL, *email, result["token"]
+	if err := a.config.save(a.configPath); err != nil {
+		return fmt.Errorf("save session: %w", err)
+	}
+	fmt.Fprintf(a.stdout, "Logged in to %s as %s\n", baseURL, *email)
+	return nil
+}
+
+// loginWithAPIKey saves an API key (created with POST
+// /api/v1/users/me/api-keys) after checking it with the API
+func loginWithAPIKey(ctx context.Context, a *app, fromStdin bool) error {
+	key, err := a.readSecret("API key: ", fromStdin)
+	if err != nil {
+		return err
+	}
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return errors.New("log in: no API key given")
+	}
+
+	baseURL := a.baseURL()
+	profile, err := client.New(baseURL, client.WithToken(key)).GetProfile(ctx)
+	if err != nil {
+		return fmt.Errorf("log in: %w", err)
+	}
+
+	a.config.URL, a.config.Email, a.config.Token = baseURL, profile.Email, key
+	if err := a.config.save(a.configPath); err != nil {
+		return fmt.Errorf("save session: %w", err)
+	}
+	fmt.Fprintf(a.stdout, "Logged in to %s as %s with an API key\n", baseURL, profile.Email)
+	return nil
+}
+
+func runLogout(ctx context.Context, a *app, args []string) error {
+	if err := a.flagSet("logout").Parse(args); err != nil {
+		return err
+	}
+	a.config.Token = ""
+	if err := a.config.save(a.configPath); err != nil {
+		return err
+	}
+	fmt.Fprintln(a.stdout, "Logged out")
+	return nil
+}
+
+func runLog(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("log")
+	title := fs.String("title", "", "title (default: the activity type)")
+	description := fs.String("description", "Logged with the activelog CLI", "description")
+	duration := fs.String("duration", "", "duration, e.g. 30m or 1h15m (required)")
+	distance := fs.String("distance", "", "distance, e.g. 5km, 800m or 3.1mi")
+	tags := fs.String("tags", "", "comma-separated tags, e.g. cardio,outdoor")
+	at := fs.String("at", "", "when it took place, e.g. 2026-03-01 07:30 (default: now)")
+	rpe := fs.Int("rpe", 0, "perceived exertion, 1-10")
+	notes := fs.String("notes", "", "notes")
+
+	// The type comes first (activelog log run -duration 30m), which the flag
+	// package would take as the end of the flags
+	if len(args) == 0 || strings.TrimSpace(args[0]) == "" || strings.HasPrefix(args[0], "-") {
+		fs.Usage()
+		return errors.New("log needs an activity type, e.g. activelog log run -duration 30m")
+	}
+	activityType := strings.TrimSpace(args[0])
+	if alias, ok := typeAliases[activityType]; ok {
+		activityType = alias
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+
+	if *duration == "" {
+		return errors.New("-duration is required, e.g. -duration 30m")
+	}
+	minutes, err := parseDurationMinutes(*duration)
+	if err != nil {
+		return err
+	}
+	req := &client.CreateActivityRequest{
+		ActivityType:    activityType,
+		Title:           *title,
+		Description:     *description,
+		DurationMinutes: minutes,
+	}
+	if req.Title == "" {
+		req.Title = capitalize(activityType)
+	}
+	if *distance != "" {
+		if req.DistanceKm, err = parseDistanceKm(*distance); err != nil {
+			return err
+		}
+	}
+	when, err := parseWhen(*at, a.now())
+	if err != nil {
+		return err
+	}
+	req.ActivityDate = when.Format(time.RFC3339)
+	for _, tag := range strings.Split(*tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			req.Tags = append(req.Tags, tag)
+		}
+	}
+	if *rpe != 0 {
+		req.RPE = client.Ptr(*rpe)
+	}
+	if *notes != "" {
+		req.Notes = notes
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	activity, err := c.CreateActivity(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "Logged %s: %s, %s (%s)\n", activity.ActivityType, activity.Title, summary(activity), activity.PublicID)
+	return nil
+}
+
+// listFilters are the -filter keys of `activelog list`
+var listFilters = map[string]func(p *client.ListActivitiesParams, value string) error{
+	"activity_type": func(p *client.ListActivitiesParams, value string) error {
+		p.FilterActivityType = &value
+		return nil
+	},
+	"tag": func(p *client.ListActivitiesParams, value string) error {
+		p.FilterTagsName = &value
+		return nil
+	},
+	"mood": func(p *client.ListActivitiesParams, value string) error {
+		mood, err := strconv.Atoi(value)
+		p.FilterMood = &mood
+		return err
+	},
+	"min_rpe": func(p *client.ListActivitiesParams, value string) error {
+		rpe, err := strconv.Atoi(value)
+		p.FilterRPEGte = &rpe
+		return err
+	},
+}
+
+// stringList is a flag that can be given several times
+type stringList []string
+
+func (l *stringList) String() string     { return strings.Join(*l, ",") }
+func (l *stringList) Set(v string) error { *l = append(*l, v); return nil }
+
+func runList(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("list")
+	var filters stringList
+	fs.Var(&filters, "filter", "key=value filter, repeatable; keys: activity_type, tag, mood, min_rpe")
+	since := fs.String("since", "", "only activities since, e.g. 7d, 2w, 12h or 2026-03-01")
+	search := fs.String("search", "", "only activities whose title contains this")
+	page := fs.Int("page", 1, "page to show")
+	limit := fs.Int("limit", 20, "activities per page (max 100)")
+	asJSON := fs.Bool("json", false, "print the page as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	params := &client.ListActivitiesParams{
+		OrderActivityDate: client.Ptr("DESC"),
+		Include:           client.Ptr("tags"),
+		Page:              page,
+		Limit:             limit,
+	}
+	for _, filter := range filters {
+		key, value, ok := strings.Cut(filter, "=")
+		set, known := listFilters[key]
+		if !ok || !known {
+			return fmt.Errorf("invalid -filter %q: use key=value with key activity_type, tag, mood or min_rpe", filter)
+		}
+		if err := set(params, value); err != nil {
+			return fmt.Errorf("invalid -filter %q: %w", filter, err)
+		}
+	}
+	if *since != "" {
+		from, err := parseSince(*since, a.now())
+		if err != nil {
+			return err
+		}
+		params.FilterActivityDateGte = client.Ptr(from.Format(time.RFC3339))
+	}
+	if *search != "" {
+		params.SearchTitle = search
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	result, err := c.ListActivities(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(a.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	tw := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DATE\tTYPE\tTITLE\tDURATION\tDISTANCE\tTAGS\tID")
+	for _, activity := range result.Data {
+		tags := make([]string, 0, len(activity.Tags))
+		for _, tag := range activity.Tags {
+			tags = append(tags, tag.Name)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			localDate(activity.ActivityDate), activity.ActivityType, activity.Title,
+			formatMinutes(activity.DurationMinutes), formatKm(activity.DistanceKm),
+			strings.Join(tags, ","), activity.PublicID)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	meta := result.Meta
+	if meta.PageCount > 0 {
+		fmt.Fprintf(a.stdout, "\nPage %d of %d (%d activities)\n", meta.Page, meta.PageCount, meta.TotalRecords)
+	}
+	return nil
+}
+
+func runStats(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("stats")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.Arg(0) != "weekly" {
+		return errors.New("usage: activelog stats weekly")
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	stats, err := c.GetWeeklyStats(ctx)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Last 7 days")
+	fmt.Fprintf(tw, "  Activities\t%d\n", stats.TotalActivities)
+	fmt.Fprintf(tw, "  Duration\t%s\n", formatMinutes(stats.TotalDurationMinutes))
+	fmt.Fprintf(tw, "  Distance\t%s\n", formatKm(stats.TotalDistanceKm))
+	fmt.Fprintf(tw, "  Avg duration\t%s\n", formatMinutes(int(stats.AvgDurationMinutes+0.5)))
+	if stats.AvgRPE > 0 {
+		fmt.Fprintf(tw, "  Avg RPE\t%.1f\n", stats.AvgRPE)
+	}
+	if trend := stats.WeightTrend; trend != nil {
+		fmt.Fprintf(tw, "  Weight\t%.1f kg (%+.1f kg)\n", trend.LatestKg, trend.ChangeKg)
+	}
+	return tw.Flush()
+}
+
+func runExport(ctx context.Context, a *app, args []string) (err error) {
+	fs := a.flagSet("export")
+	format := fs.String("format", "csv", "export format; csv is the only one for now")
+	out := fs.String("o", "", "file to write to (default: stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "csv" {
+		return fmt.Errorf("unsupported -format %q: only csv is supported", *format)
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		return c.ExportCSV(ctx, a.stdout)
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		// Don't leave a truncated export behind
+		if err != nil {
+			os.Remove(*out)
+		}
+	}()
+	return c.ExportCSV(ctx, f)
+}
+
+// readLine reads a line from stdin
+func (a *app) readLine() (string, error) {
+	line, err := a.in.ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("read stdin: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// readSecret prompts for a password or API key without echoing it when stdin
+// is a terminal, and otherwise reads it from the first line of stdin
+func (a *app) readSecret(prompt string, fromStdin bool) (string, error) {
+	if f, ok := a.stdin.(*os.File); ok && !fromStdin && term.IsTerminal(int(f.Fd())) {
+		fmt.Fprint(a.stdout, prompt)
+		secret, err := term.ReadPassword(int(f.Fd()))
+		fmt.Fprintln(a.stdout)
+		return string(secret), err
+	}
+	return a.readLine()
+}
+
+// capitalize returns s with its first letter in title case
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToTitle(r)) + s[size:]
+}
+
+// summary describes the duration and distance of an activity
+func summary(activity *client.Activity) string {
+	if activity.DistanceKm == 0 {
+		return formatMinutes(activity.DurationMinutes)
+	}
+	return formatMinutes(activity.DurationMinutes) + ", " + formatKm(activity.DistanceKm)
+}
+
+func formatKm(km float64) string {
+	if km == 0 {
+		return "-"
+	}
+	return strconv.FormatFloat(math.Round(km*100)/100, 'f', -1, 64) + " km"
+}
+
+// localDate formats an RFC 3339 time as a local date and time
+func localDate(s string) string {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return s
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestApp returns an app talking to the API handler serves, authenticated
+// with a token, and what it prints
+func newTestApp(t *testing.T, handler http.HandlerFunc) (*app, *bytes.Buffer) {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	env := map[string]string{
+		"ACTIVELOG_URL":    srv.URL,
+		"ACTIVELOG_TOKEN":  "token",
+		"ACTIVELOG_CONFIG": filepath.Join(t.TempDir(), "config.json"),
+	}
+	var out bytes.Buffer
+	return &app{
+		stdin:  strings.NewReader(""),
+		in:     bufio.NewReader(strings.NewReader("")),
+		stdout: &out,
+		getenv: func(key string) string { return env[key] },
+		now:    func() time.Time { return time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC) },
+	}, &out
+}
+
+func respond(w http.ResponseWriter, status int, result string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write([]byte(`{"statusCode":` + strconv.Itoa(status) + `,"success":true,"result":` + result + `}`))
+}
+
+func TestLogin(t *testing.T) {
+	a, out := newTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		var creds map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&creds))
+		assert.Equal(t, map[string]string{"email": "me@example.com", "password": "secret"}, creds)
+		respond(w, http.StatusOK, `{"token":"jwt","email":"me@example.com"}`)
+	})
+	a.in = bufio.NewReader(strings.NewReader("secret\n"))
+
+	err := a.run(context.Background(), []string{"login", "-email", "me@example.com", "-password-stdin"})
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "Logged in")
+
+	cfg, err := loadConfig(a.getenv("ACTIVELOG_CONFIG"))
+	require.NoError(t, err)
+	assert.Equal(t, "jwt", cfg.Token)
+	assert.Equal(t, "me@example.com", cfg.Email)
+}
+
+func TestLogin_APIKey(t *testing.T) {
+	a, out := newTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET /api/v1/users/me", r.Method+" "+r.URL.Path)
+		assert.Equal(t, "Bearer alk_key", r.Header.Get("Authorization"))
+		respond(w, http.StatusOK, `{"id":7,"email":"me@example.com"}`)
+	})
+	a.in = bufio.NewReader(strings.NewReader("alk_key\n"))
+
+	err := a.run(context.Background(), []string{"login", "-api-key", "-password-stdin"})
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "Logged in")
+
+	cfg, err := loadConfig(a.getenv("ACTIVELOG_CONFIG"))
+	require.NoError(t, err)
+	assert.Equal(t, "alk_key", cfg.Token)
+	assert.Equal(t, "me@example.com", cfg.Email)
+}
+
+func TestLogin_APIKeyRejected(t *testing.T) {
+	a, _ := newTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		respond(w, http.StatusUnauthorized, `null`)
+	})
+	a.in = bufio.NewReader(strings.NewReader("alk_revoked\n"))
+
+	require.Error(t, a.run(context.Background(), []string{"login", "-api-key", "-password-stdin"}))
+
+	cfg, err := loadConfig(a.getenv("ACTIVELOG_CONFIG"))
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Token, "nothing is saved")
+}
+
+func TestLog(t *testing.T) {
+	var body map[string]interface{}
+	a, out := newTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST /api/v1/activities", r.Method+" "+r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		respond(w, http.StatusCreated, `{"publicId":"01J","activityType":"running","title":"Running","durationMinutes":30,"distanceKm":5}`)
+	})
+
+	err := a.run(context.Background(), []string{"log", "run", "--duration", "30m", "--distance", "5km", "--tags", "cardio, outdoor"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "running", body["activityType"])
+	assert.Equal(t, "Running", body["title"])
+	assert.Equal(t, float64(30), body["durationMinutes"])
+	assert.Equal(t, float64(5), body["distanceKm"])
+	assert.Equal(t, "2026-03-15T12:00:00Z", body["activityDate"])
+	assert.Equal(t, []interface{}{"cardio", "outdoor"}, body["tags"])
+	assert.NotContains(t, body, "rpe", "unset options are left out")
+	assert.Equal(t, "Logged running: Running, 30m, 5 km (01J)\n", out.String())
+}
+
+func TestLog_RequiresDuration(t *testing.T) {
+	a, _ := newTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request expected")
+	})
+	assert.ErrorContains(t, a.run(context.Background(), []string{"log", "run", "-distance", "5km"}), "-duration is required")
+}
+
+func TestLog_Type(t *testing.T) {
+	t.Run("missing type", func(t *testing.T) {
+		a, _ := newTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Error("no request expected")
+		})
+		for _, activityType := range []string{"", "  "} {
+			err := a.run(context.Background(), []string{"log", activityType, "-duration", "30m"})
+			assert.ErrorContains(t, err, "log needs an activity type", "%q", activityType)
+		}
+	})
+
+	t.Run("title capitalizes the first letter", func(t *testing.T) {
+		var body map[string]interface{}
+		a, _ := newTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			respond(w, http.StatusCreated, `{"publicId":"01J","activityType":"élan","title":"Élan","durationMinutes":30}`)
+		})
+
+		require.NoError(t, a.run(context.Background(), []string{"log", "élan", "-duration", "30m"}))
+		assert.Equal(t, "Élan", body["title"])
+	})
+}
+
+func TestCapitalize(t *testing.T) {
+	assert.Equal(t, "Running", capitalize("running"))
+	assert.Equal(t, "Élan", capitalize("élan"))
+	assert.Equal(t, "ǅudo", capitalize("ǆudo"), "title case, not upper case")
+	assert.Equal(t, "", capitalize(""))
+}
+
+func TestList(t *testing.T) {
+	a, out := newTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, "running", q.Get("filter[activity_type]"))
+		assert.Equal(t, "2026-03-08T12:00:00Z", q.Get("filter[activity_date][gte]"))
+		assert.Equal(t, "DESC", q.Get("order[activity_date]"))
+		assert.Equal(t, "20", q.Get("limit"))
+		respond(w, http.StatusOK, `{"data":[{"publicId":"01J","activityDate":"2026-03-14T07:30:00Z","activityType":"running",
+			"title":"Tempo","durationMinutes":45,"distanceKm":9.5,"tags":[{"name":"cardio"}]}],
+			"meta":{"page":1,"limit":20,"count":1,"pageCount":1,"totalRecords":1}}`)
+	})
+
+	err := a.run(context.Background(), []string{"list", "--filter", "activity_type=running", "--since", "7d"})
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "Tempo")
+	assert.Contains(t, out.String(), "9.5 km")
+	assert.Contains(t, out.String(), "cardio")
+	assert.Contains(t, out.String(), "Page 1 of 1 (1 activities)")
+
+	assert.ErrorContains(t, a.run(context.Background(), []string{"list", "-filter", "color=red"}), "invalid -filter")
+}
+
+func TestStatsWeekly(t *testing.T) {
+	a, out := newTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/stats/weekly", r.URL.Path)
+		respond(w, http.StatusOK, `{"totalActivities":4,"totalDurationMinutes":190,"totalDistanceKm":31.2,"avgDurationMinutes":47.5}`)
+	})
+
+	require.NoError(t, a.run(context.Background(), []string{"stats", "weekly"}))
+	assert.Contains(t, out.String(), "Activities    4")
+	assert.Contains(t, out.String(), "3h10m")
+	assert.NotContains(t, out.String(), "RPE", "no average without rated activities")
+}
+
+func TestUnauthenticated(t *testing.T) {
+	a, _ := newTestApp(t, func(w http.ResponseWriter, r *http.Request) {})
+	env := a.getenv
+	a.getenv = func(key string) string {
+		if key == "ACTIVELOG_TOKEN" {
+			return ""
+		}
+		return env(key)
+	}
+	assert.ErrorContains(t, a.run(context.Background(), []string{"stats", "weekly"}), "not logged in")
+}
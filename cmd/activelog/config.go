@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// config is what login saves between runs
+type config struct {
+	URL   string `json:"url,omitempty"`
+	Email string `json:"email,omitempty"`
+	Token string `json:"token,omitempty"`
+}
+
+// configPath returns ACTIVELOG_CONFIG, or activelog/config.json in the user
+// config directory (e.g. ~/.config on Linux)
+func configPath(getenv func(string) string) (string, error) {
+	if path := getenv("ACTIVELOG_CONFIG"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locate config directory (or set ACTIVELOG_CONFIG): %w", err)
+	}
+	return filepath.Join(dir, "activelog", "config.json"), nil
+}
+
+// loadConfig reads the config at path; a missing file is an empty config
+func loadConfig(path string) (*config, error) {
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &config{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cfg config
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// save writes the config to path, readable only by the user as it holds the
+// token
+func (c *config) save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	raw, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, raw, 0o600)
+}
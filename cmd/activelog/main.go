@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/valentinesamuel/activelog/pkg/client"
+)
+
+// defaultURL is the API the CLI talks to when neither -url, ACTIVELOG_URL
+// nor a login says otherwise
+const defaultURL = "http://localhost:8080"
+
+// command is an activelog subcommand
+type command struct {
+	usage       string
+	description string
+	run         func(ctx context.Context, a *app, args []string) error
+}
+
+var commands map[string]command
+
+// The commands are set in init as their usage refers back to them
+func init() {
+	commands = map[string]command{
+		"login":  {"login [-email address | -api-key] [-password-stdin]", "log in and save the session", runLogin},
+		"logout": {"logout", "forget the saved session", runLogout},
+		"log":    {"log <type> -duration 30m [-distance 5km] [-tags cardio,outdoor] [flags]", "log an activity", runLog},
+		"list":   {"list [-filter key=value]... [-since 7d] [flags]", "list activities, newest first", runList},
+		"stats":  {"stats weekly", "show the totals of the last 7 days", runStats},
+		"export": {"export [-format csv] [-o file]", "export every activity", runExport},
+	}
+}
+
+// activelog is a command-line client for the ActiveLog API, built on
+// pkg/client:
+//
+//	activelog login -email me@example.com
+//	activelog login -api-key
+//	activelog log run -duration 30m -distance 5km -tags cardio,outdoor
+//	activelog list -filter activity_type=running -since 7d
+//	activelog stats weekly
+//	activelog export -format csv -o activities.csv
+//
+// login saves a token for the API at -url (default: ACTIVELOG_URL, else
+// http://localhost:8080) in the config file (ACTIVELOG_CONFIG, else
+// activelog/config.json in the user config directory). A password login's
+// token expires after an hour; an API key, created with POST
+// /api/v1/users/me/api-keys, lasts until revoked. ACTIVELOG_TOKEN overrides
+// the saved token, e.g. in scripts and CI, and may be an API key too.
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	a := &app{
+		stdin:  os.Stdin,
+		in:     bufio.NewReader(os.Stdin),
+		stdout: os.Stdout,
+		getenv: os.Getenv,
+		now:    time.Now,
+	}
+	if err := a.run(ctx, os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(2)
+		}
+		if errors.Is(err, client.ErrUnauthorized) {
+			err = fmt.Errorf("%w (run `activelog login` again)", err)
+		}
+		fmt.Fprintln(os.Stderr, "activelog:", err)
+		os.Exit(1)
+	}
+}
+
+// app holds what the commands share: the streams, the environment and the
+// saved config
+type app struct {
+	stdin  io.Reader
+	in     *bufio.Reader // stdin, for reading lines
+	stdout io.Writer
+	getenv func(string) string
+	now    func() time.Time
+
+	url        string // -url
+	configPath string
+	config     *config
+}
+
+func (a *app) run(ctx context.Context, args []string) error {
+	global := flag.NewFlagSet("activelog", flag.ContinueOnError)
+	global.StringVar(&a.url, "url", "", "API base URL (default: ACTIVELOG_URL, the URL logged in to, or "+defaultURL+")")
+	global.Usage = func() { a.usage(global) }
+	if err := global.Parse(args); err != nil {
+		return err
+	}
+	if global.NArg() == 0 {
+		a.usage(global)
+		return flag.ErrHelp
+	}
+
+	cmd, ok := commands[global.Arg(0)]
+	if !ok {
+		return fmt.Errorf("unknown command %q (run `activelog -h` for the list)", global.Arg(0))
+	}
+
+	path, err := configPath(a.getenv)
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(path)
+	if err != nil {
+		return err
+	}
+	a.configPath, a.config = path, cfg
+
+	return cmd.run(ctx, a, global.Args()[1:])
+}
+
+func (a *app) usage(global *flag.FlagSet) {
+	out := global.Output()
+	fmt.Fprintf(out, "Usage: activelog [-url URL] <command> [flags]\n\nCommands:\n")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(out, "  %-8s %s\n", name, commands[name].description)
+	}
+	fmt.Fprintln(out)
+	global.PrintDefaults()
+}
+
+// baseURL returns the API to talk to: -url, ACTIVELOG_URL, the URL logged in
+// to, or defaultURL
+func (a *app) baseURL() string {
+	for _, url := range []string{a.url, a.getenv("ACTIVELOG_URL"), a.config.URL} {
+		if url != "" {
+			return url
+		}
+	}
+	return defaultURL
+}
+
+// client returns a client authenticated with ACTIVELOG_TOKEN or the saved
+// token
+func (a *app) client() (*client.Client, error) {
+	token := a.getenv("ACTIVELOG_TOKEN")
+	if token == "" {
+		token = a.config.Token
+	}
+	if token == "" {
+		return nil, errors.New("not logged in; run `activelog login` or set ACTIVELOG_TOKEN")
+	}
+	return client.New(a.baseURL(), client.WithToken(token)), nil
+}
+
+// flagSet returns the FlagSet of the command name, printing its usage on -h
+func (a *app) flagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: activelog %s\n\n", commands[name].usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// typeAliases are the short names `activelog log` accepts for the built-in
+// activity types
+var typeAliases = map[string]string{
+	"run":  "running",
+	"walk": "walking",
+	"hike": "hiking",
+	"ride": "cycling",
+	"bike": "cycling",
+	"swim": "swimming",
+	"row":  "rowing",
+}
+
+// distanceUnits converts distances to kilometres
+var distanceUnits = map[string]float64{
+	"":   1,
+	"k":  1,
+	"km": 1,
+	"m":  0.001,
+	"mi": 1.609344,
+}
+
+// parseDistanceKm parses a distance like 5km, 5k, 800m or 3.1mi into
+// kilometres; a bare number is in kilometres
+func parseDistanceKm(s string) (float64, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	number := strings.TrimRightFunc(s, func(r rune) bool { return r >= 'a' && r <= 'z' })
+	factor, ok := distanceUnits[strings.TrimSpace(s[len(number):])]
+	if !ok {
+		return 0, fmt.Errorf("invalid distance %q: use km, m or mi, e.g. 5km", s)
+	}
+	value, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid distance %q, e.g. 5km", s)
+	}
+	return value * factor, nil
+}
+
+// parseDurationMinutes parses a duration like 30m or 1h15m into whole
+// minutes; a bare number is in minutes
+func parseDurationMinutes(s string) (int, error) {
+	if minutes, err := strconv.Atoi(s); err == nil {
+		return minutes, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q, e.g. 30m or 1h15m", s)
+	}
+	return int(d.Round(time.Minute) / time.Minute), nil
+}
+
+// parseSince parses how far back to look, like 7d, 2w or 12h, or a date
+// like 2026-03-01, into the time to look back to
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation(time.DateOnly, s, now.Location()); err == nil {
+		return t, nil
+	}
+	if n, unit := strings.TrimRight(s, "dw"), strings.TrimLeft(s, "0123456789"); unit == "d" || unit == "w" {
+		days, err := strconv.Atoi(n)
+		if err == nil {
+			if unit == "w" {
+				days *= 7
+			}
+			return now.AddDate(0, 0, -days), nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid -since %q, e.g. 7d, 2w, 12h or 2026-03-01", s)
+	}
+	return now.Add(-d), nil
+}
+
+// whenLayouts are the layouts -at accepts, in local time unless they carry
+// an offset
+var whenLayouts = []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02 15:04", time.DateOnly}
+
+// parseWhen parses when an activity took place; empty is now
+func parseWhen(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return now, nil
+	}
+	for _, layout := range whenLayouts {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid -at %q, e.g. 2026-03-01 07:30", s)
+}
+
+// formatMinutes formats minutes like 45m or 1h05m
+func formatMinutes(minutes int) string {
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh%02dm", minutes/60, minutes%60)
+}
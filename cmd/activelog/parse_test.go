@@ -0,0 +1,80 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDistanceKm(t *testing.T) {
+	tests := map[string]float64{
+		"5km":   5,
+		"5k":    5,
+		"5":     5,
+		"800m":  0.8,
+		"3.1mi": 4.9889664,
+		"10 KM": 10,
+	}
+	for in, want := range tests {
+		got, err := parseDistanceKm(in)
+		require.NoError(t, err, in)
+		assert.InDelta(t, want, got, 1e-9, in)
+	}
+
+	for _, in := range []string{"", "km", "5 miles", "-3km"} {
+		_, err := parseDistanceKm(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestParseDurationMinutes(t *testing.T) {
+	tests := map[string]int{"30m": 30, "1h15m": 75, "45": 45, "90s": 2}
+	for in, want := range tests {
+		got, err := parseDurationMinutes(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	_, err := parseDurationMinutes("half an hour")
+	assert.Error(t, err)
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	tests := map[string]time.Time{
+		"7d":         time.Date(2026, 3, 8, 12, 0, 0, 0, time.UTC),
+		"2w":         time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		"12h":        time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+		"2026-03-01": time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for in, want := range tests {
+		got, err := parseSince(in, now)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	_, err := parseSince("last week", now)
+	assert.Error(t, err)
+}
+
+func TestParseWhen(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	got, err := parseWhen("", now)
+	require.NoError(t, err)
+	assert.Equal(t, now, got)
+
+	got, err = parseWhen("2026-03-01 07:30", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 1, 7, 30, 0, 0, time.UTC), got)
+
+	_, err = parseWhen("yesterday", now)
+	assert.Error(t, err)
+}
+
+func TestFormatMinutes(t *testing.T) {
+	assert.Equal(t, "45m", formatMinutes(45))
+	assert.Equal(t, "1h05m", formatMinutes(65))
+}
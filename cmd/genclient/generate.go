@@ -0,0 +1,561 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// methodOrder is the order operations on the same path are generated in
+var methodOrder = []string{"get", "post", "put", "patch", "delete"}
+
+// successOrder picks the response an operation's method returns when several
+// are documented: a created or empty response wins over the dry-run previews
+// some mutations also document
+var successOrder = []string{"201", "204", "200", "202", "207"}
+
+// skippedParams are query parameters the client leaves out: format selects
+// CSV or XLSX, which the client can't decode
+var skippedParams = map[string]bool{"format": true}
+
+var initialisms = map[string]bool{"API": true, "HR": true, "HTTP": true, "ID": true, "IP": true, "JSON": true, "RPE": true, "URI": true, "URL": true, "UTC": true}
+
+// clientOp is an operation the client calls
+type clientOp struct {
+	name   string
+	method string
+	path   string
+	op     *operation
+}
+
+type generator struct {
+	spec      *spec
+	body      bytes.Buffer
+	typeNames map[string]string // definition -> Go type name
+	used      map[string]bool   // definitions the operations use
+	requests  map[string]bool   // definitions sent in request bodies
+	imports   map[string]bool
+}
+
+// generate returns the formatted source of the client for s. handlers maps
+// "METHOD /path" to the handler method serving it and names the operations
+// without an @ID.
+func generate(s *spec, handlers map[string]string) ([]byte, error) {
+	g := &generator{
+		spec:      s,
+		typeNames: make(map[string]string),
+		used:      make(map[string]bool),
+		requests:  make(map[string]bool),
+		imports:   map[string]bool{"context": true},
+	}
+	if err := g.nameTypes(); err != nil {
+		return nil, err
+	}
+	ops, err := g.operations(handlers)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range ops {
+		if err := g.writeOperation(op); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", strings.ToUpper(op.method), op.path, err)
+		}
+	}
+
+	defs := make([]string, 0, len(g.used))
+	for def := range g.used {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool { return g.typeNames[defs[i]] < g.typeNames[defs[j]] })
+	for _, def := range defs {
+		if err := g.writeType(def); err != nil {
+			return nil, fmt.Errorf("definition %s: %w", def, err)
+		}
+	}
+
+	var file bytes.Buffer
+	file.WriteString("// Code generated by cmd/genclient from docs/swagger.json. DO NOT EDIT.\n\npackage client\n\nimport (\n")
+	// Standard library imports first, then the module's
+	imports := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		imports = append(imports, path)
+	}
+	sort.Slice(imports, func(i, j int) bool {
+		iStd, jStd := !strings.Contains(imports[i], "."), !strings.Contains(imports[j], ".")
+		if iStd != jStd {
+			return iStd
+		}
+		return imports[i] < imports[j]
+	})
+	for i, path := range imports {
+		if i > 0 && strings.Contains(path, ".") && !strings.Contains(imports[i-1], ".") {
+			file.WriteString("\n")
+		}
+		fmt.Fprintf(&file, "\t%q\n", path)
+	}
+	file.WriteString(")\n")
+	file.Write(g.body.Bytes())
+
+	code, err := format.Source(file.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated client: %w", err)
+	}
+	return code, nil
+}
+
+// nameTypes names the Go type of every definition after its Go type, without
+// the package (models.Activity -> Activity)
+func (g *generator) nameTypes() error {
+	owners := make(map[string]string)
+	for def := range g.spec.Definitions {
+		name := goName(def[strings.LastIndex(def, ".")+1:])
+		if other, ok := owners[name]; ok {
+			return fmt.Errorf("definitions %s and %s would both be named %s", other, def, name)
+		}
+		owners[name] = def
+		g.typeNames[def] = name
+	}
+	return nil
+}
+
+// operations returns the operations the client calls, named and ordered by
+// path: the documented /api/v1 operations exchanging JSON
+func (g *generator) operations(handlers map[string]string) ([]clientOp, error) {
+	paths := make([]string, 0, len(g.spec.Paths))
+	for path := range g.spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var ops []clientOp
+	names := make(map[string]string)
+	for _, path := range paths {
+		for _, method := range methodOrder {
+			op, ok := g.spec.Paths[path][method]
+			if !ok || !callable(path, op) {
+				continue
+			}
+
+			key := strings.ToUpper(method) + " " + path
+			name := op.OperationID
+			if name == "" {
+				name = handlers[key]
+			}
+			if name == "" || !unicode.IsUpper(rune(name[0])) {
+				return nil, fmt.Errorf("%s has no exported handler method to be named after; add an @ID annotation", key)
+			}
+			if other, ok := names[name]; ok {
+				return nil, fmt.Errorf("%s and %s would both be named %s; add an @ID annotation", other, key, name)
+			}
+			names[name] = key
+			ops = append(ops, clientOp{name: name, method: method, path: path, op: op})
+		}
+	}
+	return ops, nil
+}
+
+// callable reports whether the client calls op: a /api/v1 operation with a
+// success response that takes and returns JSON (not uploads, event streams
+// or redirects)
+func callable(path string, op *operation) bool {
+	if !strings.HasPrefix(path, "/api/v1/") {
+		return false
+	}
+	for _, consumes := range op.Consumes {
+		if consumes == "multipart/form-data" {
+			return false
+		}
+	}
+	if len(op.Produces) > 0 && !contains(op.Produces, "application/json") {
+		return false
+	}
+	return success(op) != ""
+}
+
+// success returns the status code of the response op's method returns
+func success(op *operation) string {
+	for _, status := range successOrder {
+		if _, ok := op.Responses[status]; ok {
+			return status
+		}
+	}
+	return ""
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.body, format, args...)
+}
+
+func (g *generator) writeOperation(op clientOp) error {
+	var pathExpr, args []string
+	literal := ""
+	for _, segment := range strings.Split(op.path, "/")[1:] {
+		literal += "/"
+		if !strings.HasPrefix(segment, "{") {
+			literal += segment
+			continue
+		}
+		name := strings.Trim(segment, "{}")
+		param := findParam(op.op, "path", name)
+		if param == nil {
+			return fmt.Errorf("path parameter %s is not documented", name)
+		}
+		typ, err := scalarType(param.Type)
+		if err != nil {
+			return fmt.Errorf("path parameter %s: %w", name, err)
+		}
+		pathExpr = append(pathExpr, strconv.Quote(literal), "pathParam("+paramName(name)+")")
+		args = append(args, paramName(name)+" "+typ)
+		literal = ""
+	}
+	if literal != "" {
+		pathExpr = append(pathExpr, strconv.Quote(literal))
+	}
+
+	bodyArg := "nil"
+	if body := findParam(op.op, "body", ""); body != nil {
+		typ := "any"
+		if ref := body.Schema.refName(); ref != "" {
+			g.use(body.Schema, true)
+			typ = "*" + g.typeNames[ref]
+		}
+		args = append(args, "body "+typ)
+		bodyArg = "body"
+	}
+
+	queryArg := "nil"
+	var query []parameter
+	for _, param := range op.op.Parameters {
+		if param.In == "query" && !skippedParams[param.Name] {
+			query = append(query, param)
+		}
+	}
+	if len(query) > 0 {
+		if err := g.writeParams(op.name, query); err != nil {
+			return err
+		}
+		args = append(args, "params *"+op.name+"Params")
+		queryArg = "params.values()"
+	}
+
+	result, pointer, err := g.resultType(op.op.Responses[success(op.op)].Schema)
+	if err != nil {
+		return err
+	}
+
+	call := fmt.Sprintf("c.do(ctx, %q, %s, %s, %s", strings.ToUpper(op.method), strings.Join(pathExpr, " + "), queryArg, bodyArg)
+	signature := strings.Join(append([]string{"ctx context.Context"}, args...), ", ")
+
+	g.printf("\n// %s calls %s %s", op.name, strings.ToUpper(op.method), op.path)
+	if op.op.Summary != "" {
+		g.printf(": %s", op.op.Summary)
+	}
+	g.printf("\n")
+	switch {
+	case result == "":
+		g.printf("func (c *Client) %s(%s) error {\n\treturn %s, nil)\n}\n", op.name, signature, call)
+	case pointer:
+		g.printf("func (c *Client) %s(%s) (*%s, error) {\n\tvar out %s\n", op.name, signature, result, result)
+		g.printf("\tif err := %s, &out); err != nil {\n\t\treturn nil, err\n\t}\n\treturn &out, nil\n}\n", call)
+	default:
+		g.printf("func (c *Client) %s(%s) (%s, error) {\n\tvar out %s\n", op.name, signature, result, result)
+		g.printf("\terr := %s, &out)\n\treturn out, err\n}\n", call)
+	}
+	return nil
+}
+
+// writeParams writes the <name>Params struct of an operation's query
+// parameters and the method encoding them
+func (g *generator) writeParams(name string, params []parameter) error {
+	g.imports["net/url"] = true
+	fields := make(map[string]bool)
+
+	g.printf("\n// %sParams are the query parameters of %s; nil fields are left out\ntype %sParams struct {\n", name, name, name)
+	for _, param := range params {
+		field := goName(param.Name)
+		if fields[field] {
+			return fmt.Errorf("query parameters would share the field %s", field)
+		}
+		fields[field] = true
+
+		typ, err := scalarType(param.Type)
+		if err != nil {
+			return fmt.Errorf("query parameter %s: %w", param.Name, err)
+		}
+		g.writeComment("\t", param.Description)
+		g.printf("\t%s *%s\n", field, typ)
+	}
+	g.printf("}\n\nfunc (p *%sParams) values() url.Values {\n\tvalues := url.Values{}\n\tif p == nil {\n\t\treturn values\n\t}\n", name)
+	for _, param := range params {
+		g.printf("\tsetQuery(values, %q, p.%s)\n", param.Name, goName(param.Name))
+	}
+	g.printf("\treturn values\n}\n")
+	return nil
+}
+
+// resultType returns the Go type a response decodes into, and whether the
+// method returns it by pointer; "" means the response has no body
+func (g *generator) resultType(s *schema) (string, bool, error) {
+	if s == nil {
+		return "", false, nil
+	}
+	if elem := g.pageElem(s); elem != nil {
+		g.use(elem, false)
+		g.imports["github.com/valentinesamuel/activelog/pkg/query"] = true
+		typ, err := g.elemType(elem)
+		return "query.Page[" + typ + "]", true, err
+	}
+	g.use(s, false)
+	if ref := s.refName(); ref != "" && isStruct(g.spec.Definitions[ref]) {
+		return g.typeNames[ref], true, nil
+	}
+	typ, err := g.goType(s, false)
+	return typ, false, err
+}
+
+// pageElem returns the item schema of a paginated list response,
+// documented as paginatedResponse{data=[]T}: the allOf of a definition whose
+// meta is a query.PaginationMeta and the type of its data
+func (g *generator) pageElem(s *schema) *schema {
+	if len(s.AllOf) != 2 {
+		return nil
+	}
+	base := g.spec.Definitions[s.AllOf[0].refName()]
+	if base == nil || base.Properties["meta"].refName() != "query.PaginationMeta" {
+		return nil
+	}
+	data := s.AllOf[1].Properties["data"]
+	if data == nil || data.Type != "array" {
+		return nil
+	}
+	return data.Items
+}
+
+// use marks the definitions s refers to, and theirs, as used. Definitions
+// used in a request body get pointer fields, so zero values can be sent.
+func (g *generator) use(s *schema, request bool) {
+	if s == nil {
+		return
+	}
+	if ref := s.refName(); ref != "" {
+		if g.used[ref] && (!request || g.requests[ref]) {
+			return
+		}
+		g.used[ref] = true
+		if request {
+			g.requests[ref] = true
+		}
+		s = g.spec.Definitions[ref]
+	}
+	for _, property := range s.Properties {
+		g.use(property, request)
+	}
+	for _, member := range s.AllOf {
+		g.use(member, request)
+	}
+	g.use(s.Items, request)
+	g.use(s.additional(), request)
+}
+
+func (g *generator) writeType(def string) error {
+	s := g.spec.Definitions[def]
+	name := g.typeNames[def]
+
+	g.printf("\n// %s mirrors %s\n", name, def)
+	switch {
+	case isStruct(s):
+		g.printf("type %s struct {\n", name)
+		properties := make([]string, 0, len(s.Properties))
+		for property := range s.Properties {
+			properties = append(properties, property)
+		}
+		sort.Strings(properties)
+		for _, property := range properties {
+			prop := s.Properties[property]
+			required := s.isRequired(property)
+			typ, err := g.goType(prop, g.requests[def] && !required)
+			if err != nil {
+				return fmt.Errorf("property %s: %w", property, err)
+			}
+			tag := property
+			if !required {
+				tag += ",omitempty"
+			}
+			g.writeComment("\t", prop.Description)
+			g.printf("\t%s %s `json:%q`\n", goName(property), typ, tag)
+		}
+		g.printf("}\n")
+	case s.Type == "object":
+		typ, err := g.goType(s, false)
+		if err != nil {
+			return err
+		}
+		g.printf("type %s %s\n", name, typ)
+	default:
+		typ, err := scalarType(s.Type)
+		if err != nil {
+			return err
+		}
+		g.printf("type %s %s\n", name, typ)
+		if len(s.EnumVarNames) == len(s.Enum) && len(s.Enum) > 0 {
+			g.printf("\nconst (\n")
+			for i, value := range s.Enum {
+				g.printf("\t%s %s = %#v\n", s.EnumVarNames[i], name, value)
+			}
+			g.printf(")\n")
+		}
+	}
+	return nil
+}
+
+// goType returns the Go type of s. Struct definitions are referred to by
+// pointer, as are scalars when pointer is set.
+func (g *generator) goType(s *schema, pointer bool) (string, error) {
+	if ref := s.refName(); ref != "" {
+		def, ok := g.spec.Definitions[ref]
+		if !ok {
+			return "", fmt.Errorf("unknown definition %s", ref)
+		}
+		if isStruct(def) || (pointer && def.Type != "object") {
+			return "*" + g.typeNames[ref], nil
+		}
+		return g.typeNames[ref], nil
+	}
+
+	switch s.Type {
+	case "":
+		return "any", nil
+	case "array":
+		if s.Items == nil {
+			return "[]any", nil
+		}
+		elem, err := g.elemType(s.Items)
+		return "[]" + elem, err
+	case "object":
+		additional := s.additional()
+		if additional == nil {
+			return "map[string]any", nil
+		}
+		elem, err := g.elemType(additional)
+		return "map[string]" + elem, err
+	}
+
+	typ, err := scalarType(s.Type)
+	if pointer {
+		typ = "*" + typ
+	}
+	return typ, err
+}
+
+// elemType returns the Go type of slice and map elements, which hold
+// definitions by value
+func (g *generator) elemType(s *schema) (string, error) {
+	if ref := s.refName(); ref != "" {
+		return g.typeNames[ref], nil
+	}
+	return g.goType(s, false)
+}
+
+func (g *generator) writeComment(indent, text string) {
+	if text == "" {
+		return
+	}
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		g.printf("%s// %s\n", indent, strings.TrimSpace(line))
+	}
+}
+
+func isStruct(s *schema) bool {
+	return s != nil && s.Type == "object" && len(s.Properties) > 0
+}
+
+func scalarType(typ string) (string, error) {
+	switch typ {
+	case "string":
+		return "string", nil
+	case "integer":
+		return "int", nil
+	case "number":
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	}
+	return "", fmt.Errorf("unsupported type %q", typ)
+}
+
+func findParam(op *operation, in, name string) *parameter {
+	for i, param := range op.Parameters {
+		if param.In == in && (name == "" || param.Name == name) {
+			return &op.Parameters[i]
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// goName turns a JSON or query name into an exported Go name:
+// created_at -> CreatedAt, filter[tags.name] -> FilterTagsName, userId -> UserID
+func goName(s string) string {
+	var b strings.Builder
+	for _, word := range words(s) {
+		if upper := strings.ToUpper(word); initialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// paramName turns a path parameter into a Go parameter name: shareId -> shareID
+func paramName(s string) string {
+	name := goName(s)
+	lowered := strings.ToLower(name[:1]) + name[1:]
+	for initialism := range initialisms {
+		if strings.HasPrefix(name, initialism) {
+			lowered = strings.ToLower(initialism) + name[len(initialism):]
+			break
+		}
+	}
+	if token.IsKeyword(lowered) {
+		lowered += "_"
+	}
+	return lowered
+}
+
+// words splits s at non-alphanumerics and lower-to-upper case changes
+func words(s string) []string {
+	var words []string
+	var current []rune
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(current) > 0 {
+				words = append(words, string(current))
+				current = nil
+			}
+			continue
+		}
+		if unicode.IsUpper(r) && len(current) > 0 && !unicode.IsUpper(current[len(current)-1]) {
+			words = append(words, string(current))
+			current = nil
+		}
+		current = append(current, r)
+	}
+	if len(current) > 0 {
+		words = append(words, string(current))
+	}
+	return words
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGeneratedClientIsUpToDate regenerates the client from the spec and
+// compares it to pkg/client/generated.go
+func TestGeneratedClientIsUpToDate(t *testing.T) {
+	want, err := generateFile("../../docs/swagger.json")
+	if err != nil {
+		t.Fatalf("failed to generate client: %v", err)
+	}
+
+	got, err := os.ReadFile("../../pkg/client/generated.go")
+	if err != nil {
+		t.Fatalf("failed to read generated client: %v", err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Fatal("pkg/client/generated.go is out of date with docs/swagger.json; run `go generate ./pkg/client`")
+	}
+}
+
+func TestGoName(t *testing.T) {
+	tests := map[string]string{
+		"created_at":            "CreatedAt",
+		"filter[tags.name]":     "FilterTagsName",
+		"filter[ends_on][gte]":  "FilterEndsOnGte",
+		"userId":                "UserID",
+		"hrZoneSeconds":         "HRZoneSeconds",
+		"dryRunResponse":        "DryRunResponse",
+		"CreateActivityRequest": "CreateActivityRequest",
+		"previousPage":          "PreviousPage",
+		"avatar_url":            "AvatarURL",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, goName(in), in)
+	}
+}
+
+func TestParamName(t *testing.T) {
+	assert.Equal(t, "shareID", paramName("shareId"))
+	assert.Equal(t, "id", paramName("id"))
+	assert.Equal(t, "athleteID", paramName("athleteId"))
+	assert.Equal(t, "type_", paramName("type"))
+}
+
+func TestGenerate_RejectsDuplicateNames(t *testing.T) {
+	op := func() *operation {
+		return &operation{Responses: map[string]*response{"204": {}}}
+	}
+	s := &spec{Paths: map[string]map[string]*operation{
+		"/api/v1/a": {"delete": op()},
+		"/api/v1/b": {"delete": op()},
+	}}
+
+	_, err := generate(s, map[string]string{
+		"DELETE /api/v1/a": "Delete",
+		"DELETE /api/v1/b": "Delete",
+	})
+	assert.ErrorContains(t, err, "add an @ID annotation")
+}
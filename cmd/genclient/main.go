@@ -0,0 +1,74 @@
+// Command genclient generates the typed Go client in pkg/client from the
+// OpenAPI spec (docs/swagger.json) and the route registry.
+//
+// Every documented /api/v1 operation that exchanges JSON becomes a Client
+// method, named after its @ID annotation or else the handler method serving
+// the route (e.g. ListWorkouts). Its query parameters become a typed
+// <Method>Params struct, and the definitions it uses become Go types.
+// Paginated lists (documented as paginatedResponse{data=[]T}) return
+// *query.Page[T].
+//
+// Usage (from the repository root, or via go generate ./pkg/client):
+//
+//	go run ./cmd/genclient -spec docs/swagger.json -out pkg/client/generated.go
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"reflect"
+	"runtime"
+	"strings"
+
+	"github.com/valentinesamuel/activelog/internal/routes"
+)
+
+func main() {
+	specPath := flag.String("spec", "docs/swagger.json", "OpenAPI spec to generate the client from")
+	out := flag.String("out", "pkg/client/generated.go", "file to write the client to")
+	flag.Parse()
+
+	code, err := generateFile(*specPath)
+	if err != nil {
+		log.Fatalf("genclient: %v", err)
+	}
+	if err := os.WriteFile(*out, code, 0o644); err != nil {
+		log.Fatalf("genclient: %v", err)
+	}
+}
+
+// generateFile generates the client for the spec at path
+func generateFile(path string) ([]byte, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s spec
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return generate(&s, handlerNames())
+}
+
+// handlerNames maps "METHOD /path" to the name of the handler method serving
+// it, from the route registry
+func handlerNames() map[string]string {
+	passthrough := func(next http.Handler) http.Handler { return next }
+
+	names := make(map[string]string)
+	for _, route := range routes.API(routes.Handlers{}, passthrough).Routes() {
+		fn := reflect.ValueOf(route.Handler)
+		if fn.Kind() != reflect.Func {
+			continue
+		}
+		// Method values are named like pkg.(*Handler).Method-fm
+		name := runtime.FuncForPC(fn.Pointer()).Name()
+		name = strings.TrimSuffix(name[strings.LastIndex(name, ".")+1:], "-fm")
+		names[route.Method+" "+route.Path] = name
+	}
+	return names
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// spec is the part of the Swagger 2.0 document swag generates that the
+// client is built from
+type spec struct {
+	Paths       map[string]map[string]*operation `json:"paths"`
+	Definitions map[string]*schema               `json:"definitions"`
+}
+
+type operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Consumes    []string             `json:"consumes"`
+	Produces    []string             `json:"produces"`
+	Parameters  []parameter          `json:"parameters"`
+	Responses   map[string]*response `json:"responses"`
+}
+
+type parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Type        string  `json:"type"`
+	Description string  `json:"description"`
+	Required    bool    `json:"required"`
+	Schema      *schema `json:"schema"`
+}
+
+type response struct {
+	Schema *schema `json:"schema"`
+}
+
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Description          string             `json:"description"`
+	Items                *schema            `json:"items"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties"`
+	AllOf                []*schema          `json:"allOf"`
+	Enum                 []interface{}      `json:"enum"`
+	EnumVarNames         []string           `json:"x-enum-varnames"`
+}
+
+// refName returns the definition name s refers to, directly or as the only
+// member of an allOf (swag's way of attaching a description to a $ref)
+func (s *schema) refName() string {
+	if s == nil {
+		return ""
+	}
+	if s.Ref != "" {
+		return strings.TrimPrefix(s.Ref, "#/definitions/")
+	}
+	if len(s.AllOf) == 1 {
+		return s.AllOf[0].refName()
+	}
+	return ""
+}
+
+// additional returns the schema of s's additionalProperties, or nil when
+// they are unspecified or just true
+func (s *schema) additional() *schema {
+	if len(s.AdditionalProperties) == 0 || s.AdditionalProperties[0] != '{' {
+		return nil
+	}
+	var additional schema
+	if err := json.Unmarshal(s.AdditionalProperties, &additional); err != nil {
+		return nil
+	}
+	return &additional
+}
+
+func (s *schema) isRequired(property string) bool {
+	for _, name := range s.Required {
+		if name == property {
+			return true
+		}
+	}
+	return false
+}
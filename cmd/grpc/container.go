@@ -0,0 +1,40 @@
+package main
+
+import (
+	cacheRegister "github.com/valentinesamuel/activelog/internal/adapters/cache/di"
+	grpcRegister "github.com/valentinesamuel/activelog/internal/adapters/grpcapi/di"
+	activityUsecases "github.com/valentinesamuel/activelog/internal/application/activity/usecases/di"
+	"github.com/valentinesamuel/activelog/internal/application/broker/di"
+	statsUsecases "github.com/valentinesamuel/activelog/internal/application/stats/usecases/di"
+	tagUsecases "github.com/valentinesamuel/activelog/internal/application/tag/usecases/di"
+	"github.com/valentinesamuel/activelog/internal/platform/container"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	repositoryRegister "github.com/valentinesamuel/activelog/internal/repository/di"
+	serviceRegister "github.com/valentinesamuel/activelog/internal/service/di"
+	"github.com/valentinesamuel/activelog/pkg/query"
+)
+
+// setupContainer wires the subset of the API container the gRPC services need
+// Registration order: Core → Cache → Repositories → Services → Broker → UseCases → gRPC
+func setupContainer(db repository.DBConn) *container.Container {
+	c := container.New()
+
+	c.RegisterSingleton(repositoryRegister.CoreDBKey, db)
+	c.RegisterSingleton(di.CoreRawDBKey, db.GetRawDB())
+	c.RegisterSingleton(repositoryRegister.CoreRegistryManagerKey, query.NewRegistryManager())
+	c.RegisterSingleton(repositoryRegister.CoreValidationRegistryKey, query.NewValidationConfigRegistry())
+
+	cacheRegister.RegisterCacheAdapter(c)
+
+	repositoryRegister.RegisterRepositories(c)
+	serviceRegister.RegisterServices(c)
+	di.RegisterBroker(c)
+
+	activityUsecases.RegisterActivityUseCases(c)
+	tagUsecases.RegisterTagUseCases(c)
+	statsUsecases.RegisterStatsUseCases(c)
+
+	grpcRegister.RegisterGRPCServices(c)
+
+	return c
+}
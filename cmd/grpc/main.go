@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	grpcRegister "github.com/valentinesamuel/activelog/internal/adapters/grpcapi/di"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	"github.com/valentinesamuel/activelog/internal/platform/container"
+	"github.com/valentinesamuel/activelog/pkg/database"
+	"google.golang.org/grpc"
+)
+
+// The gRPC server exposes ActivityService, TagService and StatsService
+// (see proto/activelog/v1) alongside the REST API, backed by the same use
+// cases and broker. Reflection is enabled, so grpcurl works without the
+// .proto files.
+func main() {
+	fmt.Println("Starting ActiveLog gRPC server...")
+
+	if err := run(); err != nil {
+		log.Fatalf("gRPC server error: %v", err)
+	}
+}
+
+func run() error {
+	config.MustLoad()
+
+	db, err := database.Connect(config.Database.URL, database.PoolConfig{
+		MaxConns:               int32(config.Database.MaxConnections),
+		MinConns:               int32(config.Database.MinConnections),
+		MaxConnLifetime:        config.Database.MaxConnLifetime,
+		MaxConnIdleTime:        config.Database.MaxConnIdleTime,
+		StatementCacheCapacity: config.Database.StatementCacheCapacity,
+		QueryCacheSize:         config.Database.QueryCacheSize,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	c := setupContainer(db)
+	server := container.MustResolve[*grpc.Server](c, grpcRegister.ServerKey)
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", config.Common.GRPCPort))
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %d: %w", config.Common.GRPCPort, err)
+	}
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+
+	serverErrors := make(chan error, 1)
+	go func() {
+		log.Printf("gRPC server listening on port %d", config.Common.GRPCPort)
+		serverErrors <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-serverErrors:
+		return fmt.Errorf("gRPC server failed: %w", err)
+	case sig := <-quit:
+		log.Printf("Received signal: %v. Shutting down gRPC server...", sig)
+		server.GracefulStop()
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/valentinesamuel/activelog/internal/loadtest"
+)
+
+// loadtest runs the scenarios of internal/loadtest against a running API and
+// prints the p50/p95/p99 latency and error rate of every operation:
+//
+//	go run ./cmd/loadtest -email user@example.com -password secret -scenarios list,stats
+//
+// -ci runs a short, low-concurrency pass as a throwaway user and exits with
+// status 1 when an operation breaks the thresholds, for catching performance
+// regressions in CI.
+func main() {
+	var (
+		baseURL     = flag.String("url", "http://localhost:8080", "API base URL")
+		token       = flag.String("token", "", "bearer token to send (instead of -email/-password)")
+		email       = flag.String("email", "", "log in as this user")
+		password    = flag.String("password", "", "password of -email")
+		register    = flag.Bool("register", false, "register a new user to run as (implied by -ci)")
+		scenarios   = flag.String("scenarios", "list,create,stats", "comma-separated scenarios to run, in order")
+		concurrency = flag.Int("concurrency", 10, "workers per scenario")
+		duration    = flag.Duration("duration", 30*time.Second, "how long each scenario runs")
+		seed        = flag.Uint64("seed", 1, "random seed, so a run can be repeated")
+		ci          = flag.Bool("ci", false, "CI-sized run: 4 workers for 10s per scenario, as a new user, with default thresholds")
+		maxP95      = flag.Duration("max-p95", 0, "fail when an operation's p95 latency exceeds this (0: no limit)")
+		maxP99      = flag.Duration("max-p99", 0, "fail when an operation's p99 latency exceeds this (0: no limit)")
+		maxErrors   = flag.Float64("max-error-rate", 0, "fail when an operation's error rate exceeds this fraction, e.g. 0.01 (0: no limit)")
+		jsonOut     = flag.String("json", "", "also write the reports as JSON to this file, e.g. to compare runs")
+	)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: loadtest [flags]\n\nScenarios:\n")
+		for _, name := range scenarioNames() {
+			fmt.Fprintf(flag.CommandLine.Output(), "  %-8s %s\n", name, loadtest.Scenarios[name].Description)
+		}
+		fmt.Fprintln(flag.CommandLine.Output())
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	cfg := loadtest.Config{Concurrency: *concurrency, Duration: *duration, Seed: *seed}
+	thresholds := loadtest.Thresholds{MaxP95: *maxP95, MaxP99: *maxP99, MaxErrorRate: *maxErrors}
+	if *ci {
+		cfg.Concurrency, cfg.Duration = 4, 10*time.Second
+		*register = true
+		if thresholds == (loadtest.Thresholds{}) {
+			thresholds = loadtest.Thresholds{MaxP95: 500 * time.Millisecond, MaxErrorRate: 0.01}
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	violations, err := run(ctx, *baseURL, *scenarios, cfg, thresholds, *jsonOut, func(client *loadtest.Client) error {
+		switch {
+		case *token != "":
+			client.SetToken(*token)
+			return nil
+		case *register:
+			name := fmt.Sprintf("lt%d", time.Now().UnixNano()%1e12)
+			return client.Register(ctx, name, name+"@example.test", "loadtest-password")
+		case *email != "":
+			return client.Login(ctx, *email, *password)
+		}
+		return fmt.Errorf("one of -token, -email/-password, -register or -ci is required")
+	})
+	if err != nil {
+		log.Fatalf("loadtest error: %v", err)
+	}
+	if len(violations) > 0 {
+		fmt.Println("\nThresholds exceeded:")
+		for _, violation := range violations {
+			fmt.Println("  " + violation)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(
+	ctx context.Context,
+	baseURL, scenarioList string,
+	cfg loadtest.Config,
+	thresholds loadtest.Thresholds,
+	jsonOut string,
+	authenticate func(*loadtest.Client) error,
+) ([]string, error) {
+	var selected []loadtest.Scenario
+	for _, name := range strings.Split(scenarioList, ",") {
+		scenario, ok := loadtest.Scenarios[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown scenario %q (available: %s)", name, strings.Join(scenarioNames(), ", "))
+		}
+		selected = append(selected, scenario)
+	}
+
+	client := loadtest.NewClient(baseURL, nil)
+	if err := authenticate(client); err != nil {
+		return nil, fmt.Errorf("failed to authenticate: %w", err)
+	}
+	token := client.Token()
+
+	var reports []*loadtest.Report
+	var violations []string
+	for _, scenario := range selected {
+		log.Printf("running %s: %d workers for %s", scenario.Name, cfg.Concurrency, cfg.Duration)
+		report, err := loadtest.Run(ctx, baseURL, token, scenario, cfg)
+		if err != nil {
+			return nil, err
+		}
+		report.Print(os.Stdout)
+		reports = append(reports, report)
+		violations = append(violations, report.Check(thresholds)...)
+	}
+
+	if jsonOut != "" {
+		data, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(jsonOut, data, 0o644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", jsonOut, err)
+		}
+	}
+	return violations, nil
+}
+
+// scenarioNames returns the names of the built-in scenarios, sorted
+func scenarioNames() []string {
+	names := make([]string, 0, len(loadtest.Scenarios))
+	for name := range loadtest.Scenarios {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
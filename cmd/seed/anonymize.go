@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/valentinesamuel/activelog/pkg/password"
+)
+
+// anonymizeOptions configures runAnonymize
+type anonymizeOptions struct {
+	// Confirm must name the connected database, so a mistyped URL can't
+	// scrub production
+	Confirm  string
+	Password string
+}
+
+// anonymizeStep scrubs one table. Queries that set passwords get the hash
+// of anonymizeOptions.Password as $1.
+type anonymizeStep struct {
+	Table        string
+	Query        string
+	SetsPassword bool
+}
+
+// anonymizeSteps replace everything that identifies a person or reaches
+// outside the database, keeping row counts, dates, types and numbers intact
+// so staging behaves like production. Locations are rounded to about 10 km.
+// Files in storage (photos, imports, exports) and job or event payloads are
+// not copied to staging, so their rows are dropped.
+var anonymizeSteps = []anonymizeStep{
+	{Table: "users", Query: `
+		UPDATE users SET
+			email = 'user' || id || '@example.invalid',
+			username = 'user' || id,
+			password_hash = $1,
+			display_name = CASE WHEN display_name IS NULL THEN NULL ELSE 'User ' || id END,
+			bio = NULL,
+			avatar_key = NULL,
+			deletion_token_hash = NULL,
+			deletion_token_expires_at = NULL`, SetsPassword: true},
+	{Table: "user_identities", Query: `UPDATE user_identities SET subject = 'anonymized-' || id, email = NULL`},
+	{Table: "activities", Query: anonymizeActivitiesQuery("activities")},
+	{Table: "activities_archive", Query: anonymizeActivitiesQuery("activities_archive")},
+	{Table: "comments", Query: `UPDATE comments SET content = 'Comment ' || id`},
+	{Table: "groups", Query: `UPDATE groups SET name = 'Group ' || id, description = NULL`},
+	{Table: "body_metrics", Query: `UPDATE body_metrics SET notes = NULL WHERE notes IS NOT NULL`},
+	{Table: "webhooks", Query: `
+		UPDATE webhooks SET
+			url = 'https://example.invalid/webhooks/' || id,
+			secret = md5(random()::text),
+			active = false`},
+	{Table: "activity_photos", Query: `DELETE FROM activity_photos`},
+	{Table: "jobs, events, exports, imports", Query: `
+		TRUNCATE webhook_deliveries, jobs, inbox_event, outbox_event, processed_messages, exports, imports`},
+}
+
+// anonymizeActivitiesQuery scrubs the free text and client metadata and rounds
+// the coordinates of table, which is activities or activities_archive
+func anonymizeActivitiesQuery(table string) string {
+	return fmt.Sprintf(`
+		UPDATE %s SET
+			title = INITCAP(activity_type),
+			description = NULL,
+			notes = NULL,
+			location_name = NULL,
+			metadata = '{}',
+			start_lat = ROUND(start_lat::numeric, 1),
+			start_lng = ROUND(start_lng::numeric, 1),
+			end_lat = ROUND(end_lat::numeric, 1),
+			end_lng = ROUND(end_lng::numeric, 1)`, table)
+}
+
+// runAnonymize applies anonymizeSteps in one transaction, so a failure leaves
+// the data untouched rather than half scrubbed
+func runAnonymize(ctx context.Context, db *sql.DB, opts anonymizeOptions) error {
+	var name string
+	if err := db.QueryRowContext(ctx, `SELECT current_database()`).Scan(&name); err != nil {
+		return fmt.Errorf("failed to read database name: %w", err)
+	}
+	if opts.Confirm != name {
+		return fmt.Errorf("refusing to anonymize %q: pass -confirm %s to confirm it is not production", name, name)
+	}
+
+	hasher, err := password.New(password.DefaultParams())
+	if err != nil {
+		return err
+	}
+	passwordHash, err := hasher.Hash(opts.Password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, step := range anonymizeSteps {
+		var args []interface{}
+		if step.SetsPassword {
+			args = append(args, passwordHash)
+		}
+		result, err := tx.ExecContext(ctx, step.Query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to anonymize %s: %w", step.Table, err)
+		}
+		rows, _ := result.RowsAffected()
+		log.Printf("%s: %d rows", step.Table, rows)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	log.Printf("anonymized %s; every user's password is %q", name, opts.Password)
+	return nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunAnonymize(t *testing.T) {
+	t.Run("scrubs every table in one transaction", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(`SELECT current_database\(\)`).
+			WillReturnRows(sqlmock.NewRows([]string{"current_database"}).AddRow("activelog_staging"))
+		mock.ExpectBegin()
+		for _, step := range anonymizeSteps {
+			exec := mock.ExpectExec(regexp.QuoteMeta(step.Query))
+			if step.SetsPassword {
+				exec.WithArgs(hashArg{})
+			} else {
+				exec.WithoutArgs()
+			}
+			exec.WillReturnResult(sqlmock.NewResult(0, 3))
+		}
+		mock.ExpectCommit()
+
+		err = runAnonymize(context.Background(), db, anonymizeOptions{Confirm: "activelog_staging", Password: "staging"})
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("refuses another database", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(`SELECT current_database\(\)`).
+			WillReturnRows(sqlmock.NewRows([]string{"current_database"}).AddRow("activelog"))
+
+		err = runAnonymize(context.Background(), db, anonymizeOptions{Confirm: "activelog_staging", Password: "staging"})
+		assert.ErrorContains(t, err, `refusing to anonymize "activelog"`)
+		require.NoError(t, mock.ExpectationsWereMet(), "nothing is changed")
+	})
+
+	t.Run("rolls back when a step fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(`SELECT current_database\(\)`).
+			WillReturnRows(sqlmock.NewRows([]string{"current_database"}).AddRow("activelog_staging"))
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE users SET`).WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(`UPDATE user_identities`).WillReturnError(errors.New(`relation "user_identities" does not exist`))
+		mock.ExpectRollback()
+
+		err = runAnonymize(context.Background(), db, anonymizeOptions{Confirm: "activelog_staging", Password: "staging"})
+		assert.ErrorContains(t, err, "failed to anonymize user_identities")
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// Personal data must not survive: every column that can hold it is
+// overwritten by some step
+func TestAnonymizeSteps_ScrubPersonalData(t *testing.T) {
+	queries := make(map[string]string)
+	for _, step := range anonymizeSteps {
+		queries[step.Table] = step.Query
+	}
+
+	for table, columns := range map[string][]string{
+		"users":              {"email", "username", "password_hash", "display_name", "bio", "avatar_key"},
+		"user_identities":    {"subject", "email"},
+		"activities":         {"title", "description", "notes", "location_name", "start_lat", "start_lng", "end_lat", "end_lng"},
+		"activities_archive": {"title", "description", "notes", "location_name", "start_lat", "start_lng", "end_lat", "end_lng"},
+		"webhooks":           {"url", "secret"},
+	} {
+		require.Contains(t, queries, table)
+		for _, column := range columns {
+			assert.Contains(t, queries[table], column+" = ", "%s.%s", table, column)
+		}
+	}
+	assert.True(t, strings.HasPrefix(strings.TrimSpace(queries["activity_photos"]), "DELETE FROM activity_photos"))
+}
+
+// hashArg matches a password hash, never the plain password
+type hashArg struct{}
+
+func (hashArg) Match(v driver.Value) bool {
+	hash, ok := v.(string)
+	return ok && hash != "staging" && strings.HasPrefix(hash, "$")
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/valentinesamuel/activelog/pkg/database"
+)
+
+// seed fills a database with realistic fake users, activities, tags and body
+// metrics, e.g. to load test pagination and indexes:
+//
+//	go run ./cmd/seed -users 500 -activities 100000
+//
+// With -anonymize it instead scrubs the personal data out of a restored
+// production dump, so it can be used in staging:
+//
+//	go run ./cmd/seed -anonymize -confirm activelog_staging
+//
+// The target is DATABASE_URL (or -database-url) and must be migrated.
+func main() {
+	_ = godotenv.Load()
+
+	var opts seedOptions
+	var anon anonymizeOptions
+	var databaseURL string
+	var anonymize bool
+
+	flag.StringVar(&databaseURL, "database-url", os.Getenv("DATABASE_URL"), "database to seed or anonymize (default: DATABASE_URL)")
+	flag.IntVar(&opts.Users, "users", 50, "number of users to create")
+	flag.IntVar(&opts.Activities, "activities", 10000, "number of activities to create, spread unevenly across the users")
+	flag.IntVar(&opts.Tags, "tags", 30, "number of distinct tag names to use")
+	flag.IntVar(&opts.Days, "days", 365, "spread activities over this many days up to now")
+	flag.Int64Var(&opts.Seed, "seed", 1, "random seed, so a run can be repeated")
+	flag.StringVar(&opts.Password, "password", "password123", "password of every seeded user")
+	flag.BoolVar(&anonymize, "anonymize", false, "scrub personal data instead of seeding")
+	flag.StringVar(&anon.Confirm, "confirm", "", "name of the database to anonymize, as a safeguard (required with -anonymize)")
+	flag.StringVar(&anon.Password, "anonymized-password", "password123", "password every anonymized user gets")
+	flag.Parse()
+
+	if databaseURL == "" {
+		log.Fatal("DATABASE_URL or -database-url is required")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, databaseURL, anonymize, opts, anon); err != nil {
+		log.Fatalf("seed error: %v", err)
+	}
+}
+
+func run(ctx context.Context, databaseURL string, anonymize bool, opts seedOptions, anon anonymizeOptions) error {
+	db, err := database.Connect(databaseURL, database.PoolConfig{MaxConns: 4, MinConns: 1})
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+	// Connect logs every statement; the seeder runs hundreds of thousands
+	quiet := database.NewLoggingDB(db.GetRawDB(), log.New(io.Discard, "", 0))
+
+	start := time.Now()
+	if anonymize {
+		if err := runAnonymize(ctx, quiet.GetRawDB(), anon); err != nil {
+			return err
+		}
+		log.Printf("anonymized in %s", time.Since(start).Round(time.Millisecond))
+		return nil
+	}
+
+	if err := runSeed(ctx, quiet, opts); err != nil {
+		return err
+	}
+	log.Printf("seeded in %s", time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// analyze refreshes the planner statistics of tables, so queries against the
+// new rows are planned as they would be in production
+func analyze(ctx context.Context, db *sql.DB, tables ...string) error {
+	for _, table := range tables {
+		if _, err := db.ExecContext(ctx, "ANALYZE "+table); err != nil {
+			return fmt.Errorf("failed to analyze %s: %w", table, err)
+		}
+	}
+	return nil
+}
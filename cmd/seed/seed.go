@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/pkg/database"
+	"github.com/valentinesamuel/activelog/pkg/password"
+)
+
+// seedOptions configures runSeed
+type seedOptions struct {
+	Users      int
+	Activities int
+	Tags       int
+	Days       int
+	Seed       int64
+	Password   string
+}
+
+// activityProfile describes how one activity type is generated. Types with a
+// speed get a distance; calories follow from the duration.
+type activityProfile struct {
+	Type              string
+	Weight            int
+	MinMinutes        int
+	MaxMinutes        int
+	SpeedKmh          float64
+	CaloriesPerMinute float64
+	Titles            []string
+}
+
+// activityProfiles are roughly the mix of types logged in production
+var activityProfiles = []activityProfile{
+	{Type: "running", Weight: 30, MinMinutes: 20, MaxMinutes: 120, SpeedKmh: 10, CaloriesPerMinute: 11, Titles: []string{"Morning run", "Easy run", "Tempo run", "Long run", "Intervals"}},
+	{Type: "walking", Weight: 20, MinMinutes: 15, MaxMinutes: 90, SpeedKmh: 5, CaloriesPerMinute: 5, Titles: []string{"Walk", "Lunch walk", "Evening walk"}},
+	{Type: "cycling", Weight: 15, MinMinutes: 30, MaxMinutes: 240, SpeedKmh: 22, CaloriesPerMinute: 9, Titles: []string{"Commute", "Road ride", "Gravel ride"}},
+	{Type: "strength", Weight: 10, MinMinutes: 30, MaxMinutes: 90, CaloriesPerMinute: 6, Titles: []string{"Upper body", "Leg day", "Full body"}},
+	{Type: "swimming", Weight: 8, MinMinutes: 20, MaxMinutes: 75, SpeedKmh: 2.5, CaloriesPerMinute: 9, Titles: []string{"Pool swim", "Open water"}},
+	{Type: "yoga", Weight: 7, MinMinutes: 20, MaxMinutes: 75, CaloriesPerMinute: 3, Titles: []string{"Yoga", "Stretching"}},
+	{Type: "hiking", Weight: 5, MinMinutes: 60, MaxMinutes: 360, SpeedKmh: 4, CaloriesPerMinute: 7, Titles: []string{"Hike", "Trail hike"}},
+	{Type: "hiit", Weight: 5, MinMinutes: 15, MaxMinutes: 45, CaloriesPerMinute: 12, Titles: []string{"HIIT", "Circuit"}},
+}
+
+// tagNames are used before numbered tags are made up
+var tagNames = []string{
+	"morning", "evening", "outdoor", "indoor", "easy", "tempo", "intervals", "long",
+	"recovery", "race", "commute", "trail", "treadmill", "hills", "with-friends", "solo",
+	"rain", "heat", "travel", "personal-best",
+}
+
+// seedBatchSize is the number of users or body metrics inserted per statement
+const seedBatchSize = 1000
+
+// runSeed creates the users, then their activities with BulkImport, then
+// their weight history
+func runSeed(ctx context.Context, db *database.LoggingDB, opts seedOptions) error {
+	if opts.Users <= 0 || opts.Activities < 0 || opts.Days <= 0 {
+		return fmt.Errorf("-users and -days must be positive and -activities not negative")
+	}
+	rng := rand.New(rand.NewPCG(uint64(opts.Seed), uint64(opts.Seed)>>32|1))
+
+	hasher, err := password.New(password.DefaultParams())
+	if err != nil {
+		return err
+	}
+	// Hashing is deliberately slow, so every user shares one hash
+	passwordHash, err := hasher.Hash(opts.Password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	userIDs, err := seedUsers(ctx, db.GetRawDB(), rng, opts, passwordHash)
+	if err != nil {
+		return err
+	}
+	log.Printf("created %d users (password %q)", len(userIDs), opts.Password)
+
+	activities := generateActivities(rng, userIDs, opts)
+	activityRepo := repository.NewActivityRepository(db, repository.NewTagRepository(db))
+	result, err := activityRepo.BulkImport(ctx, activities, repository.BulkImportOptions{
+		ChunkSize: 5000,
+		OnProgress: func(p repository.BulkImportProgress) {
+			log.Printf("activities: %d/%d", p.Processed, p.Total)
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to import activities: %w", err)
+	}
+	for _, chunkErr := range result.Errors {
+		log.Printf("activities %d-%d failed: %v", chunkErr.FirstRow, chunkErr.LastRow, chunkErr.Err)
+	}
+	log.Printf("created %d activities (%d failed)", result.Imported, result.Failed)
+
+	metrics, err := seedWeights(ctx, db.GetRawDB(), rng, userIDs, opts.Days)
+	if err != nil {
+		return err
+	}
+	log.Printf("created %d body metrics", metrics)
+
+	return analyze(ctx, db.GetRawDB(), "users", "activities", "tags", "activity_tags", "body_metrics")
+}
+
+// seedUsers inserts opts.Users users and returns their IDs. Emails and
+// usernames carry the run's start time, so seeding again adds new users.
+func seedUsers(ctx context.Context, db *sql.DB, rng *rand.Rand, opts seedOptions, passwordHash string) ([]int, error) {
+	run := strconv.FormatInt(time.Now().Unix(), 36)
+	timezones := []string{"UTC", "Europe/London", "Europe/Berlin", "America/New_York", "America/Los_Angeles", "Africa/Lagos", "Asia/Tokyo"}
+
+	ids := make([]int, 0, opts.Users)
+	for start := 0; start < opts.Users; start += seedBatchSize {
+		end := min(start+seedBatchSize, opts.Users)
+
+		var emails, usernames, zones []string
+		var createdAt []time.Time
+		for i := start; i < end; i++ {
+			username := fmt.Sprintf("seed_%s_%d", run, i+1)
+			usernames = append(usernames, username)
+			emails = append(emails, username+"@example.test")
+			zones = append(zones, timezones[rng.IntN(len(timezones))])
+			// Accounts are at least as old as their first activity
+			createdAt = append(createdAt, time.Now().AddDate(0, 0, -opts.Days-rng.IntN(180)))
+		}
+
+		rows, err := db.QueryContext(ctx, `
+			INSERT INTO users (email, username, password_hash, timezone, created_at, updated_at)
+			SELECT email, username, $3, timezone, created_at, created_at
+			FROM unnest($1::text[], $2::text[], $4::text[], $5::timestamp[]) AS u(email, username, timezone, created_at)
+			RETURNING id`,
+			emails, usernames, passwordHash, zones, createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to insert users: %w", err)
+		}
+		for rows.Next() {
+			var id int
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan user id: %w", err)
+			}
+			ids = append(ids, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("failed to insert users: %w", err)
+		}
+	}
+	return ids, nil
+}
+
+// generateActivities makes opts.Activities activities. How active a user is
+// follows an exponential distribution, so a few users have most of them - as
+// in production, where pagination is stressed by the heaviest users.
+func generateActivities(rng *rand.Rand, userIDs []int, opts seedOptions) []*models.Activity {
+	// Running sums of the users' weights, searched to pick a user
+	cumulative := make([]float64, len(userIDs))
+	var total float64
+	for i := range cumulative {
+		total += rng.ExpFloat64()
+		cumulative[i] = total
+	}
+	profileWeight := 0
+	for _, profile := range activityProfiles {
+		profileWeight += profile.Weight
+	}
+	tags := seedTagNames(opts.Tags)
+
+	now := time.Now().UTC()
+	activities := make([]*models.Activity, 0, opts.Activities)
+	for range opts.Activities {
+		pick := min(sort.SearchFloat64s(cumulative, rng.Float64()*total), len(userIDs)-1)
+		userID := userIDs[pick]
+		profile := pickProfile(rng, profileWeight)
+
+		// Durations cluster around the middle of the profile's range
+		spread := float64(profile.MaxMinutes - profile.MinMinutes)
+		minutes := int(math.Round(float64(profile.MinMinutes) + spread/2 + rng.NormFloat64()*spread/5))
+		minutes = max(profile.MinMinutes, min(profile.MaxMinutes, minutes))
+
+		// Between 6:00 and 21:00 on a random day of the range
+		date := now.AddDate(0, 0, -rng.IntN(opts.Days)).Truncate(24 * time.Hour).
+			Add(time.Duration(6*60+rng.IntN(15*60)) * time.Minute)
+
+		activity := &models.Activity{
+			UserID:          userID,
+			ActivityType:    profile.Type,
+			Title:           profile.Titles[rng.IntN(len(profile.Titles))],
+			DurationMinutes: minutes,
+			CaloriesBurned:  int(float64(minutes) * profile.CaloriesPerMinute * (0.8 + rng.Float64()*0.4)),
+			ActivityDate:    date,
+		}
+		if profile.SpeedKmh > 0 {
+			speed := profile.SpeedKmh * (0.75 + rng.Float64()*0.5)
+			activity.DistanceKm = math.Round(speed*float64(minutes)/60*100) / 100
+		}
+		for range rng.IntN(4) {
+			activity.Tags = append(activity.Tags, &models.Tag{Name: tags[rng.IntN(len(tags))]})
+		}
+		activities = append(activities, activity)
+	}
+	return activities
+}
+
+// seedWeights logs a drifting body weight every few days for about half the
+// users and returns the number of entries
+func seedWeights(ctx context.Context, db *sql.DB, rng *rand.Rand, userIDs []int, days int) (int, error) {
+	var users []int
+	var values []float64
+	var dates []time.Time
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	for _, userID := range userIDs {
+		if rng.IntN(2) == 0 {
+			continue
+		}
+		weight := 55 + rng.Float64()*40
+		for day := days; day >= 0; day -= 1 + rng.IntN(4) {
+			weight += rng.NormFloat64() * 0.3
+			users = append(users, userID)
+			values = append(values, math.Round(weight*10)/10)
+			dates = append(dates, today.AddDate(0, 0, -day))
+		}
+	}
+
+	created := 0
+	for start := 0; start < len(users); start += seedBatchSize {
+		end := min(start+seedBatchSize, len(users))
+		result, err := db.ExecContext(ctx, `
+			INSERT INTO body_metrics (user_id, metric_type, value, recorded_on)
+			SELECT user_id, 'weight_kg', value, recorded_on
+			FROM unnest($1::int[], $2::numeric[], $3::date[]) AS m(user_id, value, recorded_on)
+			ON CONFLICT (user_id, metric_type, recorded_on) DO NOTHING`,
+			users[start:end], values[start:end], dates[start:end])
+		if err != nil {
+			return created, fmt.Errorf("failed to insert body metrics: %w", err)
+		}
+		rows, _ := result.RowsAffected()
+		created += int(rows)
+	}
+	return created, nil
+}
+
+// seedTagNames returns n tag names, numbering them once tagNames run out
+func seedTagNames(n int) []string {
+	n = max(n, 1)
+	names := make([]string, 0, n)
+	for i := range n {
+		if i < len(tagNames) {
+			names = append(names, tagNames[i])
+		} else {
+			names = append(names, fmt.Sprintf("tag-%d", i+1))
+		}
+	}
+	return names
+}
+
+// pickProfile returns an activity profile with probability Weight/total
+func pickProfile(rng *rand.Rand, total int) activityProfile {
+	target := rng.IntN(total)
+	for _, profile := range activityProfiles {
+		target -= profile.Weight
+		if target < 0 {
+			return profile
+		}
+	}
+	return activityProfiles[len(activityProfiles)-1]
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateActivities(t *testing.T) {
+	userIDs := make([]int, 100)
+	for i := range userIDs {
+		userIDs[i] = 1000 + i
+	}
+	opts := seedOptions{Activities: 10000, Tags: 25, Days: 90, Seed: 7}
+
+	activities := generateActivities(rand.New(rand.NewPCG(7, 7)), userIDs, opts)
+	require.Len(t, activities, opts.Activities)
+
+	profiles := make(map[string]activityProfile)
+	for _, profile := range activityProfiles {
+		profiles[profile.Type] = profile
+	}
+	tags := seedTagNames(opts.Tags)
+	oldest := time.Now().UTC().AddDate(0, 0, -opts.Days-1)
+	perUser := make(map[int]int)
+	perType := make(map[string]int)
+
+	for i, a := range activities {
+		profile, ok := profiles[a.ActivityType]
+		require.True(t, ok, "activity %d has type %q", i, a.ActivityType)
+		perType[a.ActivityType]++
+		perUser[a.UserID]++
+
+		assert.Contains(t, userIDs, a.UserID)
+		assert.Contains(t, profile.Titles, a.Title)
+		assert.GreaterOrEqual(t, a.DurationMinutes, profile.MinMinutes, "activity %d", i)
+		assert.LessOrEqual(t, a.DurationMinutes, profile.MaxMinutes, "activity %d", i)
+		assert.Positive(t, a.CaloriesBurned, "activity %d", i)
+		if profile.SpeedKmh > 0 {
+			assert.Positive(t, a.DistanceKm, "activity %d", i)
+		} else {
+			assert.Zero(t, a.DistanceKm, "%s has no distance", a.ActivityType)
+		}
+
+		assert.True(t, a.ActivityDate.After(oldest), "activity %d is older than -days", i)
+		assert.False(t, a.ActivityDate.After(time.Now().UTC().Add(24*time.Hour)), "activity %d is in the future", i)
+		minute := a.ActivityDate.Hour()*60 + a.ActivityDate.Minute()
+		assert.True(t, minute >= 6*60 && minute < 21*60, "activity %d starts at %s", i, a.ActivityDate.Format("15:04"))
+
+		assert.LessOrEqual(t, len(a.Tags), 3)
+		for _, tag := range a.Tags {
+			assert.Contains(t, tags, tag.Name)
+		}
+	}
+
+	// Every type shows up, the most common ones most often
+	assert.Len(t, perType, len(activityProfiles))
+	assert.Greater(t, perType["running"], perType["walking"])
+	assert.Greater(t, perType["walking"], perType["hiit"])
+
+	// A few users log far more than the average of 100
+	counts := make([]int, 0, len(perUser))
+	for _, count := range perUser {
+		counts = append(counts, count)
+	}
+	assert.Greater(t, slices.Max(counts), 3*opts.Activities/len(userIDs))
+}
+
+func TestGenerateActivities_Repeatable(t *testing.T) {
+	opts := seedOptions{Activities: 200, Tags: 10, Days: 30}
+	first := generateActivities(rand.New(rand.NewPCG(3, 3)), []int{1, 2, 3}, opts)
+	second := generateActivities(rand.New(rand.NewPCG(3, 3)), []int{1, 2, 3}, opts)
+	assert.Equal(t, first, second)
+}
+
+func TestSeedTagNames(t *testing.T) {
+	assert.Equal(t, []string{"morning"}, seedTagNames(0))
+	assert.Equal(t, []string{"morning", "evening", "outdoor"}, seedTagNames(3))
+
+	names := seedTagNames(22)
+	assert.Len(t, names, 22)
+	assert.Equal(t, []string{"personal-best", "tag-21", "tag-22"}, names[19:])
+}
+
+func TestRunSeed_InvalidOptions(t *testing.T) {
+	for name, opts := range map[string]seedOptions{
+		"no users":            {Users: 0, Activities: 10, Days: 30},
+		"negative activities": {Users: 1, Activities: -1, Days: 30},
+		"no days":             {Users: 1, Activities: 10, Days: 0},
+	} {
+		// Rejected before the database is touched
+		err := runSeed(context.Background(), nil, opts)
+		assert.ErrorContains(t, err, "must be positive", name)
+	}
+}
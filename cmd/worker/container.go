@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log"
+	"time"
+
+	emailRegister "github.com/valentinesamuel/activelog/internal/adapters/email/di"
+	geocodingRegister "github.com/valentinesamuel/activelog/internal/adapters/geocoding/di"
+	queueRegister "github.com/valentinesamuel/activelog/internal/adapters/queue/di"
+	queueTypes "github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	searchRegister "github.com/valentinesamuel/activelog/internal/adapters/search/di"
+	storageRegister "github.com/valentinesamuel/activelog/internal/adapters/storage/di"
+	weatherRegister "github.com/valentinesamuel/activelog/internal/adapters/weather/di"
+	webhookRegister "github.com/valentinesamuel/activelog/internal/adapters/webhook/di"
+	accountRegister "github.com/valentinesamuel/activelog/internal/application/account/usecases/di"
+	brokerRegister "github.com/valentinesamuel/activelog/internal/application/broker/di"
+	clockRegister "github.com/valentinesamuel/activelog/internal/platform/clock/di"
+	"github.com/valentinesamuel/activelog/internal/platform/container"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	repositoryRegister "github.com/valentinesamuel/activelog/internal/repository/di"
+	"github.com/valentinesamuel/activelog/pkg/query"
+)
+
+// setupContainer wires the repositories and adapters job handlers depend on
+// Registration order: Core → Clock → Storage → Email → Repositories → Webhooks → Weather → Geocoding → Search → Broker → UseCases
+func setupContainer(db repository.DBConn) *container.Container {
+	c := container.New()
+
+	c.RegisterSingleton(repositoryRegister.CoreDBKey, db)
+	c.RegisterSingleton(brokerRegister.CoreRawDBKey, db.GetRawDB())
+	if closer, ok := db.(io.Closer); ok {
+		c.OnStop(repositoryRegister.CoreDBKey, func(context.Context) error {
+			return closer.Close()
+		})
+	}
+	c.RegisterSingleton(repositoryRegister.CoreRegistryManagerKey, query.NewRegistryManager())
+	c.RegisterSingleton(repositoryRegister.CoreValidationRegistryKey, query.NewValidationConfigRegistry())
+
+	clockRegister.RegisterClock(c)
+	storageRegister.RegisterStorage(c)
+	emailRegister.RegisterEmail(c)
+	repositoryRegister.RegisterRepositories(c)
+	webhookRegister.RegisterWebhookDelivery(c)
+	webhookRegister.RegisterRetryWorker(c)
+	weatherRegister.RegisterWeather(c)
+	geocodingRegister.RegisterGeocoding(c)
+	searchRegister.RegisterSearch(c)
+	brokerRegister.RegisterBroker(c)
+	accountRegister.RegisterAccountUseCases(c)
+
+	return c
+}
+
+// registerQueue adds the worker's queue client to the container, so it is
+// closed on shutdown before the database
+func registerQueue(c *container.Container, queue queueTypes.QueueProvider) {
+	c.RegisterSingleton(queueRegister.QueueProviderKey, queue)
+	if closer, ok := queue.(io.Closer); ok {
+		c.OnStop(queueRegister.QueueProviderKey, func(context.Context) error {
+			return closer.Close()
+		})
+	}
+}
+
+// stopContainer runs the shutdown hooks, giving them 30 seconds in total
+func stopContainer(c *container.Container) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := c.Stop(ctx); err != nil {
+		log.Printf("Error stopping services: %v", err)
+	}
+}
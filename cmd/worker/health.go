@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	queueTypes "github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/platform/jobs"
+	"github.com/valentinesamuel/activelog/internal/repository"
+)
+
+const (
+	// pingTimeout bounds the queue check of /health and of each heartbeat
+	pingTimeout = 2 * time.Second
+
+	// heartbeatRetention is how long the heartbeat of a worker that stopped
+	// without deleting it (it crashed) is listed as dead before it is pruned
+	heartbeatRetention = 24 * time.Hour
+)
+
+// workerStatus reports the status of this worker process
+type workerStatus struct {
+	id        string
+	hostname  string
+	provider  string
+	startedAt time.Time
+	queue     queueTypes.QueueProvider
+	factory   *jobs.HandlerFactory
+}
+
+func newWorkerStatus(provider string, queue queueTypes.QueueProvider, factory *jobs.HandlerFactory) *workerStatus {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	if provider == "" {
+		provider = "memory"
+	}
+	return &workerStatus{
+		id:        fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		hostname:  hostname,
+		provider:  provider,
+		startedAt: time.Now(),
+		queue:     queue,
+		factory:   factory,
+	}
+}
+
+// check pings the queue, if it has a broker, and returns the worker's status
+func (s *workerStatus) check(ctx context.Context) models.WorkerHeartbeat {
+	stats := s.factory.Stats()
+	hb := models.WorkerHeartbeat{
+		ID:            s.id,
+		Hostname:      s.hostname,
+		PID:           os.Getpid(),
+		QueueProvider: s.provider,
+		Handlers:      s.factory.Handlers(),
+		InFlight:      stats.InFlight,
+		Processed:     stats.Processed,
+		Failed:        stats.Failed,
+		StartedAt:     s.startedAt,
+	}
+	if pinger, ok := s.queue.(queueTypes.Pinger); ok {
+		ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+		defer cancel()
+		if err := pinger.Ping(ctx); err != nil {
+			msg := err.Error()
+			hb.QueueError = &msg
+		}
+	}
+	return hb
+}
+
+// healthReport is the response of the worker's /health
+type healthReport struct {
+	Status        string  `json:"status"`
+	Service       string  `json:"service"`
+	WorkerID      string  `json:"worker_id"`
+	QueueProvider string  `json:"queue_provider"`
+	QueueError    *string `json:"queue_error,omitempty"`
+	Handlers      int     `json:"handlers"`
+	InFlight      int64   `json:"in_flight"`
+	Processed     int64   `json:"processed"`
+	Failed        int64   `json:"failed"`
+}
+
+// serveHealth handles /health. The worker is unhealthy (503) while its queue
+// can't be reached.
+func (s *workerStatus) serveHealth(w http.ResponseWriter, r *http.Request) {
+	hb := s.check(r.Context())
+	report := healthReport{
+		Status:        "healthy",
+		Service:       "activelog-worker",
+		WorkerID:      hb.ID,
+		QueueProvider: hb.QueueProvider,
+		QueueError:    hb.QueueError,
+		Handlers:      hb.Handlers,
+		InFlight:      hb.InFlight,
+		Processed:     hb.Processed,
+		Failed:        hb.Failed,
+	}
+	status := http.StatusOK
+	if hb.QueueError != nil {
+		report.Status, status = "unhealthy", http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
+
+// serveHTTP exposes the worker's /health and Prometheus /metrics on addr
+func serveHTTP(addr string, status *workerStatus) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", status.serveHealth)
+	mux.Handle("/metrics", promhttp.Handler())
+	srv := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("worker HTTP server error: %v", err)
+		}
+	}()
+	log.Printf("worker health and metrics listening on %s", addr)
+	return srv
+}
+
+// startHeartbeat records the worker's status every interval until the
+// returned stop is called, which deletes it
+func startHeartbeat(status *workerStatus, repo *repository.WorkerHeartbeatRepository, interval time.Duration) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			hb := status.check(ctx)
+			if err := repo.Beat(ctx, &hb); err != nil && ctx.Err() == nil {
+				log.Printf("worker heartbeat: %v", err)
+			}
+			if _, err := repo.Prune(ctx, heartbeatRetention); err != nil && ctx.Err() == nil {
+				log.Printf("worker heartbeat: %v", err)
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		wg.Wait()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := repo.Delete(ctx, status.id); err != nil {
+			log.Printf("worker heartbeat: %v", err)
+		}
+	}
+}
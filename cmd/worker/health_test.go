@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/memory"
+	queueTypes "github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	"github.com/valentinesamuel/activelog/internal/platform/jobs"
+)
+
+// unreachableQueue is a queue whose broker is down
+type unreachableQueue struct{}
+
+func (unreachableQueue) Enqueue(context.Context, queueTypes.QueueName, queueTypes.JobPayload) (string, error) {
+	return "", errors.New("down")
+}
+
+func (unreachableQueue) Ping(context.Context) error {
+	return errors.New("dial tcp: connection refused")
+}
+
+func getHealth(t *testing.T, status *workerStatus) (int, healthReport) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	status.serveHealth(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+	var report healthReport
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	return w.Code, report
+}
+
+func TestServeHealth(t *testing.T) {
+	factory := jobs.NewHandlerFactory()
+	factory.Register(queueTypes.EventWelcomeEmail, func(context.Context, queueTypes.JobPayload) error { return nil })
+	require.NoError(t, factory.Dispatch(context.Background(), queueTypes.JobPayload{Event: queueTypes.EventWelcomeEmail}))
+
+	code, report := getHealth(t, newWorkerStatus("", memory.New(1), factory))
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "healthy", report.Status)
+	assert.Equal(t, "memory", report.QueueProvider)
+	assert.Equal(t, 1, report.Handlers)
+	assert.Equal(t, int64(1), report.Processed)
+	assert.Nil(t, report.QueueError)
+
+	code, report = getHealth(t, newWorkerStatus("nats", unreachableQueue{}, factory))
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "unhealthy", report.Status)
+	require.NotNil(t, report.QueueError)
+	assert.Contains(t, *report.QueueError, "connection refused")
+}
@@ -7,14 +7,40 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
+	"time"
 
 	"github.com/hibiken/asynq"
+	clockRegister "github.com/valentinesamuel/activelog/internal/platform/clock/di"
 	"github.com/valentinesamuel/activelog/internal/platform/config"
+	"github.com/valentinesamuel/activelog/internal/platform/container"
 	"github.com/valentinesamuel/activelog/internal/platform/jobs"
+	emailRegister "github.com/valentinesamuel/activelog/internal/adapters/email/di"
+	emailTypes "github.com/valentinesamuel/activelog/internal/adapters/email/types"
+	geocodingRegister "github.com/valentinesamuel/activelog/internal/adapters/geocoding/di"
+	geocodingTypes "github.com/valentinesamuel/activelog/internal/adapters/geocoding/types"
 	internalAsynq "github.com/valentinesamuel/activelog/internal/adapters/queue/asynq"
 	"github.com/valentinesamuel/activelog/internal/adapters/queue/memory"
+	natsQueue "github.com/valentinesamuel/activelog/internal/adapters/queue/nats"
+	sqsQueue "github.com/valentinesamuel/activelog/internal/adapters/queue/sqs"
 	queueTypes "github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	searchRegister "github.com/valentinesamuel/activelog/internal/adapters/search/di"
+	searchTypes "github.com/valentinesamuel/activelog/internal/adapters/search/types"
+	storageRegister "github.com/valentinesamuel/activelog/internal/adapters/storage/di"
+	storageTypes "github.com/valentinesamuel/activelog/internal/adapters/storage/types"
+	weatherRegister "github.com/valentinesamuel/activelog/internal/adapters/weather/di"
+	weatherTypes "github.com/valentinesamuel/activelog/internal/adapters/weather/types"
+	"github.com/valentinesamuel/activelog/internal/adapters/webhook"
+	"github.com/valentinesamuel/activelog/internal/events"
+	webhookRegister "github.com/valentinesamuel/activelog/internal/adapters/webhook/di"
+	accountUsecases "github.com/valentinesamuel/activelog/internal/application/account/usecases"
+	accountRegister "github.com/valentinesamuel/activelog/internal/application/account/usecases/di"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	repositoryRegister "github.com/valentinesamuel/activelog/internal/repository/di"
+	"github.com/valentinesamuel/activelog/internal/service"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+	"github.com/valentinesamuel/activelog/pkg/database"
 )
 
 func main() {
@@ -28,11 +54,159 @@ func main() {
 func run() error {
 	config.MustLoad()
 
+	db, err := database.Connect(config.Database.URL, database.PoolConfig{
+		MaxConns:               int32(config.Database.MaxConnections),
+		MinConns:               int32(config.Database.MinConnections),
+		MaxConnLifetime:        config.Database.MaxConnLifetime,
+		MaxConnIdleTime:        config.Database.MaxConnIdleTime,
+		StatementCacheCapacity: config.Database.StatementCacheCapacity,
+		QueryCacheSize:         config.Database.QueryCacheSize,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	c := setupContainer(db)
+	// Runs after the workers stop: closes the queue client, then the database
+	defer stopContainer(c)
+
+	var queue queueTypes.QueueProvider
+	switch config.Queue.Provider {
+	case "asynq":
+		if queue, err = internalAsynq.New(); err != nil {
+			return fmt.Errorf("failed to create asynq client: %w", err)
+		}
+	case "nats":
+		if queue, err = natsQueue.New(); err != nil {
+			return fmt.Errorf("failed to create nats queue: %w", err)
+		}
+	case "sqs":
+		if queue, err = sqsQueue.New(); err != nil {
+			return fmt.Errorf("failed to create sqs queue: %w", err)
+		}
+	default:
+		if dir := config.Queue.MemoryJournalDir; dir != "" {
+			if queue, err = memory.Open(100, filepath.Join(dir, "worker.journal")); err != nil {
+				return fmt.Errorf("failed to open memory queue journal: %w", err)
+			}
+		} else {
+			queue = memory.New(100)
+		}
+	}
+	registerQueue(c, queue)
+
+	// Every handler skips redelivered messages and reports the status of tracked jobs
 	factory := jobs.NewHandlerFactory()
+	factory.UseMessageStore(container.MustResolve[*repository.ProcessedMessageRepository](c, repositoryRegister.ProcessedMsgRepoKey))
+	factory.UseJobTracker(container.MustResolve[*repository.JobRepository](c, repositoryRegister.JobRepoKey))
+	if config.Queue.UserConcurrency > 0 {
+		factory.UseUserLimiter(jobs.NewUserLimiter(config.Queue.UserConcurrency))
+	}
 	factory.Register(queueTypes.EventWelcomeEmail, jobs.HandleWelcomeEmail)
-	factory.Register(queueTypes.EventWeeklySummary, jobs.HandleWeeklySummary)
+	// The email provider is nil if SMTP failed to initialise; summaries then fail and are retried
+	emailProvider, _ := c.MustResolve(emailRegister.EmailProviderKey).(emailTypes.EmailProvider)
+	factory.Register(queueTypes.EventWeeklySummary, jobs.NewWeeklySummaryHandler(jobs.WeeklySummaryDeps{
+		Profiles: container.MustResolve[*repository.ProfileRepository](c, repositoryRegister.ProfileRepoKey),
+		Stats:    container.MustResolve[repository.StatsRepositoryInterface](c, repositoryRegister.StatsRepoKey),
+		Email:    emailProvider,
+		Workouts: container.MustResolve[*repository.WorkoutRepository](c, repositoryRegister.WorkoutRepoKey),
+		Clock:    container.MustResolve[clock.Clock](c, clockRegister.ClockKey),
+	}))
 	factory.Register(queueTypes.EventGenerateExport, jobs.HandleGenerateExport)
 	factory.Register(queueTypes.EventRefreshRateLimitConfig, jobs.HandleRefreshRateLimitConfig)
+	factory.Register(queueTypes.EventImportActivities, jobs.NewImportActivitiesHandler(jobs.ImportActivitiesDeps{
+		ActivityRepo: container.MustResolve[repository.ActivityRepositoryInterface](c, repositoryRegister.ActivityRepoKey),
+		ImportRepo:   container.MustResolve[*repository.ImportRepository](c, repositoryRegister.ImportRepoKey),
+		Storage:      container.MustResolve[storageTypes.StorageProvider](c, storageRegister.StorageProviderKey),
+		ProfileRepo:  container.MustResolve[*repository.ProfileRepository](c, repositoryRegister.ProfileRepoKey),
+		Queue:        queue,
+		Clock:        container.MustResolve[clock.Clock](c, clockRegister.ClockKey),
+	}))
+	factory.Register(queueTypes.EventRenderMapThumbnails, jobs.NewRenderMapThumbnailsHandler(jobs.RenderMapThumbnailsDeps{
+		Tracks:  container.MustResolve[*repository.ActivityTrackRepository](c, repositoryRegister.ActivityTrackRepoKey),
+		Zones:   container.MustResolve[*repository.PrivacyZoneRepository](c, repositoryRegister.PrivacyZoneRepoKey),
+		Storage: container.MustResolve[storageTypes.StorageProvider](c, storageRegister.StorageProviderKey),
+	}))
+	factory.Register(queueTypes.EventExportUserData, jobs.NewExportUserDataHandler(jobs.ExportUserDataDeps{
+		AccountRepo:  container.MustResolve[*repository.AccountRepository](c, repositoryRegister.AccountRepoKey),
+		ActivityRepo: container.MustResolve[repository.ActivityRepositoryInterface](c, repositoryRegister.ActivityRepoKey),
+		ExportRepo:   container.MustResolve[*repository.ExportRepository](c, repositoryRegister.ExportRepoKey),
+		Storage:      container.MustResolve[storageTypes.StorageProvider](c, storageRegister.StorageProviderKey),
+		Clock:        container.MustResolve[clock.Clock](c, clockRegister.ClockKey),
+	}))
+	factory.Register(queueTypes.EventRecalculateUserMetrics, jobs.NewRecalculateUserMetricsHandler(
+		container.MustResolve[*repository.ActivityRepository](c, repositoryRegister.ActivityRepoKey)))
+	if config.Weather.Enabled() {
+		factory.Register(queueTypes.EventEnrichWeather, jobs.NewEnrichWeatherHandler(
+			container.MustResolve[*repository.ActivityRepository](c, repositoryRegister.ActivityRepoKey),
+			container.MustResolve[weatherTypes.WeatherProvider](c, weatherRegister.WeatherProviderKey)))
+	}
+	if config.Geocoding.Enabled() {
+		// Geocoded activities get their weather looked up too
+		var weatherQueue queueTypes.QueueProvider
+		if config.Weather.Enabled() {
+			weatherQueue = queue
+		}
+		factory.Register(queueTypes.EventGeocodeActivity, jobs.NewGeocodeActivityHandler(
+			container.MustResolve[*repository.ActivityRepository](c, repositoryRegister.ActivityRepoKey),
+			container.MustResolve[geocodingTypes.Geocoder](c, geocodingRegister.GeocoderKey),
+			weatherQueue))
+	}
+
+	// Outbox jobs run the async subscribers of the domain events the API
+	// publishes. An embedded (memory) search index is updated by the API itself.
+	subscribers := events.Subscribers{
+		Queue:        queue,
+		Achievements: service.NewAchievementEngine(container.MustResolve[*repository.AchievementRepository](c, repositoryRegister.AchievementRepoKey)),
+	}
+	if config.Search.Enabled() && config.Search.Provider != "memory" {
+		indexer := container.MustResolve[searchTypes.SearchProvider](c, searchRegister.SearchProviderKey)
+		subscribers.Search, subscribers.SearchAsync = indexer, true
+		factory.Register(queueTypes.EventReindexSearch, jobs.NewReindexSearchHandler(
+			container.MustResolve[*repository.ActivityRepository](c, repositoryRegister.ActivityRepoKey), indexer))
+	}
+	bus := events.NewBus(queue)
+	events.Register(bus, subscribers)
+	for _, job := range events.Jobs() {
+		factory.Register(job, bus.JobHandler())
+	}
+
+	clk := container.MustResolve[clock.Clock](c, clockRegister.ClockKey)
+
+	// Scheduled jobs: every entry in jobs.Schedule is wrapped with its jitter and overlap guard
+	scheduled := map[queueTypes.EventType]jobs.HandlerFunc{
+		queueTypes.EventScheduleWeeklySummaries: jobs.NewScheduleWeeklySummariesHandler(
+			container.MustResolve[*repository.UserRepository](c, repositoryRegister.UserRepoKey), queue, clk),
+		queueTypes.EventPurgeSoftDeleted: jobs.NewPurgeSoftDeletedHandler(service.NewCleanupService(db.GetRawDB())),
+		queueTypes.EventWebhookRetrySweep: jobs.NewWebhookRetrySweepHandler(
+			container.MustResolve[*webhook.RetryWorker](c, webhookRegister.RetryWorkerKey)),
+		queueTypes.EventMaintainPartitions: jobs.NewMaintainPartitionsHandler(
+			container.MustResolve[*repository.PartitionRepository](c, repositoryRegister.PartitionRepoKey),
+			jobs.PartitionPolicy{
+				MonthsAhead:     config.Activity.PartitionMonthsAhead,
+				RetentionMonths: config.Activity.PartitionRetentionMonths,
+			}, clk),
+		queueTypes.EventPurgeDeletedAccounts: jobs.NewPurgeDeletedAccountsHandler(
+			container.MustResolve[*repository.AccountRepository](c, repositoryRegister.AccountRepoKey),
+			container.MustResolve[*accountUsecases.AccountDeletion](c, accountRegister.AccountDeletionKey), clk),
+		queueTypes.EventBackfillActivityMetrics: jobs.NewBackfillMetricsHandler(
+			container.MustResolve[*repository.ActivityRepository](c, repositoryRegister.ActivityRepoKey)),
+		queueTypes.EventRefreshStatsSummaries: jobs.NewRefreshStatsSummariesHandler(
+			container.MustResolve[*repository.StatsSummaryRepository](c, repositoryRegister.StatsSummaryRepoKey),
+			config.Stats.ReadFromSummaries),
+		queueTypes.EventRefreshChallengeProgress: jobs.NewRefreshChallengeProgressHandler(jobs.ChallengeProgressDeps{
+			Challenges: container.MustResolve[*repository.ChallengeRepository](c, repositoryRegister.ChallengeRepoKey),
+			Email:      emailProvider,
+			Clock:      clk,
+		}),
+	}
+	for _, job := range jobs.Schedule {
+		handler, ok := scheduled[job.Event]
+		if !ok {
+			return fmt.Errorf("no handler for scheduled job %q", job.Name)
+		}
+		factory.Register(job.Event, job.Guard(handler))
+	}
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
@@ -40,16 +214,39 @@ func run() error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	if config.Queue.Provider == "asynq" {
-		return runAsynqWorker(ctx, factory, quit)
+	if err := c.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start services: %w", err)
 	}
 
-	return runMemoryWorker(ctx, factory, quit)
+	status := newWorkerStatus(config.Queue.Provider, queue, factory)
+	if config.Queue.WorkerHTTPAddr != "" {
+		srv := serveHTTP(config.Queue.WorkerHTTPAddr, status)
+		defer srv.Close()
+	}
+	// Stops before the container closes the database
+	stopHeartbeat := startHeartbeat(status,
+		container.MustResolve[*repository.WorkerHeartbeatRepository](c, repositoryRegister.WorkerHeartbeatRepoKey),
+		max(config.Queue.HeartbeatInterval, time.Second))
+	defer stopHeartbeat()
+
+	switch provider := queue.(type) {
+	case *memory.Provider:
+		return runMemoryWorker(ctx, provider, factory.Dispatch, quit)
+	case pollingQueue:
+		return runPollingWorker(ctx, config.Queue.Provider, provider, factory.Dispatch, quit)
+	}
+
+	return runAsynqWorker(ctx, factory.Dispatch, quit)
 }
 
-func runAsynqWorker(_ context.Context, factory *jobs.HandlerFactory, quit <-chan os.Signal) error {
+func runAsynqWorker(_ context.Context, dispatch jobs.HandlerFunc, quit <-chan os.Signal) error {
 	redisAddr := config.GetEnv("REDIS_ADDRESS", "localhost:6379")
-	srv := internalAsynq.NewWorkerServer(redisAddr, 10)
+	srv := internalAsynq.NewWorkerServer(redisAddr, queueSettings(), config.Queue.StrictPriority)
+
+	scheduler, err := internalAsynq.NewScheduler(redisAddr, jobs.PeriodicTasks())
+	if err != nil {
+		return err
+	}
 
 	mux := asynq.NewServeMux()
 	handler := func(_ context.Context, t *asynq.Task) error {
@@ -57,7 +254,7 @@ func runAsynqWorker(_ context.Context, factory *jobs.HandlerFactory, quit <-chan
 		if err := json.Unmarshal(t.Payload(), &payload); err != nil {
 			return fmt.Errorf("worker: unmarshal payload: %w", err)
 		}
-		return factory.Dispatch(context.Background(), payload)
+		return dispatch(context.Background(), payload)
 	}
 
 	for _, event := range []queueTypes.EventType{
@@ -67,27 +264,43 @@ func runAsynqWorker(_ context.Context, factory *jobs.HandlerFactory, quit <-chan
 		queueTypes.EventSendVerificationEmail,
 		queueTypes.EventActivityCreated,
 		queueTypes.EventActivityDeleted,
+		queueTypes.EventActivityUpdated,
 		queueTypes.EventRefreshRateLimitConfig,
+		queueTypes.EventImportActivities,
+		queueTypes.EventExportUserData,
+		queueTypes.EventEnrichWeather,
+		queueTypes.EventGeocodeActivity,
+		queueTypes.EventRecalculateUserMetrics,
+		queueTypes.EventReindexSearch,
+		queueTypes.EventRenderMapThumbnails,
 	} {
 		mux.HandleFunc(string(event), handler)
 	}
+	for _, job := range jobs.Schedule {
+		mux.HandleFunc(string(job.Event), handler)
+	}
 
 	log.Println("asynq worker started")
 	if err := srv.Start(mux); err != nil {
 		return fmt.Errorf("asynq worker failed to start: %w", err)
 	}
+	if err := scheduler.Start(); err != nil {
+		srv.Shutdown()
+		return fmt.Errorf("asynq scheduler failed to start: %w", err)
+	}
+	log.Printf("asynq scheduler started (%d periodic jobs)", len(jobs.Schedule))
 
 	<-quit
 	log.Println("Shutting down asynq worker...")
+	scheduler.Shutdown()
 	srv.Shutdown()
 	return nil
 }
 
-func runMemoryWorker(ctx context.Context, factory *jobs.HandlerFactory, quit <-chan os.Signal) error {
-	mem := memory.New(100)
-
-	for _, queue := range []queueTypes.QueueName{queueTypes.InboxQueue, queueTypes.OutboxQueue} {
-		mem.StartWorking(ctx, queue, factory.Dispatch)
+func runMemoryWorker(ctx context.Context, mem *memory.Provider, dispatch jobs.HandlerFunc, quit <-chan os.Signal) error {
+	mem.StartWorkers(ctx, queueSettings(), config.Queue.StrictPriority, dispatch)
+	if err := mem.StartScheduler(ctx, jobs.PeriodicTasks()); err != nil {
+		return err
 	}
 
 	log.Println("memory worker started")
@@ -95,3 +308,38 @@ func runMemoryWorker(ctx context.Context, factory *jobs.HandlerFactory, quit <-c
 	log.Println("Shutting down memory worker...")
 	return nil
 }
+
+// pollingQueue is a queue provider that pulls jobs for its own worker pool
+// and runs its own scheduler (NATS, SQS)
+type pollingQueue interface {
+	StartWorkers(ctx context.Context, queues []queueTypes.QueueSettings, strictPriority bool, handler func(context.Context, queueTypes.JobPayload) error) error
+	StartScheduler(ctx context.Context, tasks []queueTypes.PeriodicTask) error
+}
+
+// runPollingWorker serves the queues of provider until quit. Jobs still
+// running at shutdown get to finish when the container closes the provider.
+func runPollingWorker(ctx context.Context, name string, provider pollingQueue, dispatch jobs.HandlerFunc, quit <-chan os.Signal) error {
+	ctx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	if err := provider.StartWorkers(ctx, queueSettings(), config.Queue.StrictPriority, dispatch); err != nil {
+		return err
+	}
+	if err := provider.StartScheduler(ctx, jobs.PeriodicTasks()); err != nil {
+		return err
+	}
+
+	log.Printf("%s worker started (%d periodic jobs)", name, len(jobs.Schedule))
+	<-quit
+	log.Printf("Shutting down %s worker...", name)
+	return nil
+}
+
+// queueSettings returns the configured worker queues
+func queueSettings() []queueTypes.QueueSettings {
+	return []queueTypes.QueueSettings{
+		{Name: queueTypes.CriticalQueue, Concurrency: config.Queue.Critical.Concurrency, Priority: config.Queue.Critical.Priority},
+		{Name: queueTypes.DefaultQueue, Concurrency: config.Queue.Default.Concurrency, Priority: config.Queue.Default.Priority},
+		{Name: queueTypes.LowQueue, Concurrency: config.Queue.Low.Concurrency, Priority: config.Queue.Low.Priority},
+	}
+}
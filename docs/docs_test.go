@@ -0,0 +1,42 @@
+package docs
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/swaggo/swag"
+)
+
+// TestSwaggerSpecIsUpToDate regenerates the spec from the annotations with
+// the same settings as the go:generate step and compares it to swagger.json
+func TestSwaggerSpecIsUpToDate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping spec generation in short mode")
+	}
+
+	parser := swag.New(
+		swag.ParseUsingGoList(true),
+		swag.SetCollectionFormat("csv"),
+	)
+	parser.ParseInternal = true
+
+	if err := parser.ParseAPIMultiSearchDir([]string{"../"}, "cmd/api/main.go", 100); err != nil {
+		t.Fatalf("failed to parse API annotations: %v", err)
+	}
+
+	want, err := json.MarshalIndent(parser.GetSwagger(), "", "    ")
+	if err != nil {
+		t.Fatalf("failed to marshal spec: %v", err)
+	}
+
+	got, err := os.ReadFile("swagger.json")
+	if err != nil {
+		t.Fatalf("failed to read swagger.json: %v", err)
+	}
+
+	if !bytes.Equal(bytes.TrimSpace(got), bytes.TrimSpace(want)) {
+		t.Fatal("docs/swagger.json is out of date with the API annotations; run `go generate ./docs`")
+	}
+}
@@ -0,0 +1,6 @@
+package docs
+
+// The OpenAPI spec is generated from the swag annotations on cmd/api/main.go
+// and the handlers. TestSwaggerSpecIsUpToDate fails when it drifts.
+
+//go:generate go run github.com/swaggo/swag/cmd/swag@v1.8.12 init -d ../ -g cmd/api/main.go -o ../docs --parseInternal
@@ -0,0 +1,761 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: activelog/v1/activity.proto
+
+package activelogv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Activity struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId          int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ActivityType    string                 `protobuf:"bytes,3,opt,name=activity_type,json=activityType,proto3" json:"activity_type,omitempty"`
+	Title           string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	Description     string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	DurationMinutes int32                  `protobuf:"varint,6,opt,name=duration_minutes,json=durationMinutes,proto3" json:"duration_minutes,omitempty"`
+	DistanceKm      float64                `protobuf:"fixed64,7,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
+	CaloriesBurned  int32                  `protobuf:"varint,8,opt,name=calories_burned,json=caloriesBurned,proto3" json:"calories_burned,omitempty"`
+	Notes           string                 `protobuf:"bytes,9,opt,name=notes,proto3" json:"notes,omitempty"`
+	ActivityDate    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=activity_date,json=activityDate,proto3" json:"activity_date,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Tags            []*Tag                 `protobuf:"bytes,13,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Activity) Reset() {
+	*x = Activity{}
+	mi := &file_activelog_v1_activity_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Activity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Activity) ProtoMessage() {}
+
+func (x *Activity) ProtoReflect() protoreflect.Message {
+	mi := &file_activelog_v1_activity_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Activity.ProtoReflect.Descriptor instead.
+func (*Activity) Descriptor() ([]byte, []int) {
+	return file_activelog_v1_activity_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Activity) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Activity) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Activity) GetActivityType() string {
+	if x != nil {
+		return x.ActivityType
+	}
+	return ""
+}
+
+func (x *Activity) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Activity) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Activity) GetDurationMinutes() int32 {
+	if x != nil {
+		return x.DurationMinutes
+	}
+	return 0
+}
+
+func (x *Activity) GetDistanceKm() float64 {
+	if x != nil {
+		return x.DistanceKm
+	}
+	return 0
+}
+
+func (x *Activity) GetCaloriesBurned() int32 {
+	if x != nil {
+		return x.CaloriesBurned
+	}
+	return 0
+}
+
+func (x *Activity) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *Activity) GetActivityDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ActivityDate
+	}
+	return nil
+}
+
+func (x *Activity) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Activity) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Activity) GetTags() []*Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type GetActivityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetActivityRequest) Reset() {
+	*x = GetActivityRequest{}
+	mi := &file_activelog_v1_activity_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetActivityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActivityRequest) ProtoMessage() {}
+
+func (x *GetActivityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activelog_v1_activity_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActivityRequest.ProtoReflect.Descriptor instead.
+func (*GetActivityRequest) Descriptor() ([]byte, []int) {
+	return file_activelog_v1_activity_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetActivityRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListActivitiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *FilterRequest         `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListActivitiesRequest) Reset() {
+	*x = ListActivitiesRequest{}
+	mi := &file_activelog_v1_activity_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListActivitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListActivitiesRequest) ProtoMessage() {}
+
+func (x *ListActivitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activelog_v1_activity_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListActivitiesRequest.ProtoReflect.Descriptor instead.
+func (*ListActivitiesRequest) Descriptor() ([]byte, []int) {
+	return file_activelog_v1_activity_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListActivitiesRequest) GetFilter() *FilterRequest {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type ListActivitiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Activities    []*Activity            `protobuf:"bytes,1,rep,name=activities,proto3" json:"activities,omitempty"`
+	Meta          *PaginationMeta        `protobuf:"bytes,2,opt,name=meta,proto3" json:"meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListActivitiesResponse) Reset() {
+	*x = ListActivitiesResponse{}
+	mi := &file_activelog_v1_activity_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListActivitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListActivitiesResponse) ProtoMessage() {}
+
+func (x *ListActivitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_activelog_v1_activity_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListActivitiesResponse.ProtoReflect.Descriptor instead.
+func (*ListActivitiesResponse) Descriptor() ([]byte, []int) {
+	return file_activelog_v1_activity_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListActivitiesResponse) GetActivities() []*Activity {
+	if x != nil {
+		return x.Activities
+	}
+	return nil
+}
+
+func (x *ListActivitiesResponse) GetMeta() *PaginationMeta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+type CreateActivityRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ActivityType    string                 `protobuf:"bytes,1,opt,name=activity_type,json=activityType,proto3" json:"activity_type,omitempty"`
+	Title           string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description     string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	DurationMinutes int32                  `protobuf:"varint,4,opt,name=duration_minutes,json=durationMinutes,proto3" json:"duration_minutes,omitempty"`
+	DistanceKm      float64                `protobuf:"fixed64,5,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
+	CaloriesBurned  int32                  `protobuf:"varint,6,opt,name=calories_burned,json=caloriesBurned,proto3" json:"calories_burned,omitempty"`
+	Notes           string                 `protobuf:"bytes,7,opt,name=notes,proto3" json:"notes,omitempty"`
+	ActivityDate    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=activity_date,json=activityDate,proto3" json:"activity_date,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateActivityRequest) Reset() {
+	*x = CreateActivityRequest{}
+	mi := &file_activelog_v1_activity_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateActivityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateActivityRequest) ProtoMessage() {}
+
+func (x *CreateActivityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activelog_v1_activity_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateActivityRequest.ProtoReflect.Descriptor instead.
+func (*CreateActivityRequest) Descriptor() ([]byte, []int) {
+	return file_activelog_v1_activity_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateActivityRequest) GetActivityType() string {
+	if x != nil {
+		return x.ActivityType
+	}
+	return ""
+}
+
+func (x *CreateActivityRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateActivityRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateActivityRequest) GetDurationMinutes() int32 {
+	if x != nil {
+		return x.DurationMinutes
+	}
+	return 0
+}
+
+func (x *CreateActivityRequest) GetDistanceKm() float64 {
+	if x != nil {
+		return x.DistanceKm
+	}
+	return 0
+}
+
+func (x *CreateActivityRequest) GetCaloriesBurned() int32 {
+	if x != nil {
+		return x.CaloriesBurned
+	}
+	return 0
+}
+
+func (x *CreateActivityRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *CreateActivityRequest) GetActivityDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ActivityDate
+	}
+	return nil
+}
+
+// UpdateActivityRequest is a partial update: unset fields are left unchanged.
+type UpdateActivityRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ActivityType    *string                `protobuf:"bytes,2,opt,name=activity_type,json=activityType,proto3,oneof" json:"activity_type,omitempty"`
+	Title           *string                `protobuf:"bytes,3,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Description     *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	DurationMinutes *int32                 `protobuf:"varint,5,opt,name=duration_minutes,json=durationMinutes,proto3,oneof" json:"duration_minutes,omitempty"`
+	DistanceKm      *float64               `protobuf:"fixed64,6,opt,name=distance_km,json=distanceKm,proto3,oneof" json:"distance_km,omitempty"`
+	CaloriesBurned  *int32                 `protobuf:"varint,7,opt,name=calories_burned,json=caloriesBurned,proto3,oneof" json:"calories_burned,omitempty"`
+	Notes           *string                `protobuf:"bytes,8,opt,name=notes,proto3,oneof" json:"notes,omitempty"`
+	ActivityDate    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=activity_date,json=activityDate,proto3" json:"activity_date,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateActivityRequest) Reset() {
+	*x = UpdateActivityRequest{}
+	mi := &file_activelog_v1_activity_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateActivityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateActivityRequest) ProtoMessage() {}
+
+func (x *UpdateActivityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activelog_v1_activity_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateActivityRequest.ProtoReflect.Descriptor instead.
+func (*UpdateActivityRequest) Descriptor() ([]byte, []int) {
+	return file_activelog_v1_activity_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateActivityRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateActivityRequest) GetActivityType() string {
+	if x != nil && x.ActivityType != nil {
+		return *x.ActivityType
+	}
+	return ""
+}
+
+func (x *UpdateActivityRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *UpdateActivityRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateActivityRequest) GetDurationMinutes() int32 {
+	if x != nil && x.DurationMinutes != nil {
+		return *x.DurationMinutes
+	}
+	return 0
+}
+
+func (x *UpdateActivityRequest) GetDistanceKm() float64 {
+	if x != nil && x.DistanceKm != nil {
+		return *x.DistanceKm
+	}
+	return 0
+}
+
+func (x *UpdateActivityRequest) GetCaloriesBurned() int32 {
+	if x != nil && x.CaloriesBurned != nil {
+		return *x.CaloriesBurned
+	}
+	return 0
+}
+
+func (x *UpdateActivityRequest) GetNotes() string {
+	if x != nil && x.Notes != nil {
+		return *x.Notes
+	}
+	return ""
+}
+
+func (x *UpdateActivityRequest) GetActivityDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ActivityDate
+	}
+	return nil
+}
+
+type DeleteActivityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteActivityRequest) Reset() {
+	*x = DeleteActivityRequest{}
+	mi := &file_activelog_v1_activity_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteActivityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteActivityRequest) ProtoMessage() {}
+
+func (x *DeleteActivityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activelog_v1_activity_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteActivityRequest.ProtoReflect.Descriptor instead.
+func (*DeleteActivityRequest) Descriptor() ([]byte, []int) {
+	return file_activelog_v1_activity_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteActivityRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteActivityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       bool                   `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteActivityResponse) Reset() {
+	*x = DeleteActivityResponse{}
+	mi := &file_activelog_v1_activity_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteActivityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteActivityResponse) ProtoMessage() {}
+
+func (x *DeleteActivityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_activelog_v1_activity_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteActivityResponse.ProtoReflect.Descriptor instead.
+func (*DeleteActivityResponse) Descriptor() ([]byte, []int) {
+	return file_activelog_v1_activity_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteActivityResponse) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *DeleteActivityResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+var File_activelog_v1_activity_proto protoreflect.FileDescriptor
+
+const file_activelog_v1_activity_proto_rawDesc = "" +
+	"\n" +
+	"\x1bactivelog/v1/activity.proto\x12\factivelog.v1\x1a\x19activelog/v1/common.proto\x1a\x16activelog/v1/tag.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf9\x03\n" +
+	"\bActivity\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12#\n" +
+	"\ractivity_type\x18\x03 \x01(\tR\factivityType\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12)\n" +
+	"\x10duration_minutes\x18\x06 \x01(\x05R\x0fdurationMinutes\x12\x1f\n" +
+	"\vdistance_km\x18\a \x01(\x01R\n" +
+	"distanceKm\x12'\n" +
+	"\x0fcalories_burned\x18\b \x01(\x05R\x0ecaloriesBurned\x12\x14\n" +
+	"\x05notes\x18\t \x01(\tR\x05notes\x12?\n" +
+	"\ractivity_date\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\factivityDate\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12%\n" +
+	"\x04tags\x18\r \x03(\v2\x11.activelog.v1.TagR\x04tags\"$\n" +
+	"\x12GetActivityRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"L\n" +
+	"\x15ListActivitiesRequest\x123\n" +
+	"\x06filter\x18\x01 \x01(\v2\x1b.activelog.v1.FilterRequestR\x06filter\"\x82\x01\n" +
+	"\x16ListActivitiesResponse\x126\n" +
+	"\n" +
+	"activities\x18\x01 \x03(\v2\x16.activelog.v1.ActivityR\n" +
+	"activities\x120\n" +
+	"\x04meta\x18\x02 \x01(\v2\x1c.activelog.v1.PaginationMetaR\x04meta\"\xc0\x02\n" +
+	"\x15CreateActivityRequest\x12#\n" +
+	"\ractivity_type\x18\x01 \x01(\tR\factivityType\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12)\n" +
+	"\x10duration_minutes\x18\x04 \x01(\x05R\x0fdurationMinutes\x12\x1f\n" +
+	"\vdistance_km\x18\x05 \x01(\x01R\n" +
+	"distanceKm\x12'\n" +
+	"\x0fcalories_burned\x18\x06 \x01(\x05R\x0ecaloriesBurned\x12\x14\n" +
+	"\x05notes\x18\a \x01(\tR\x05notes\x12?\n" +
+	"\ractivity_date\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\factivityDate\"\xe2\x03\n" +
+	"\x15UpdateActivityRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12(\n" +
+	"\ractivity_type\x18\x02 \x01(\tH\x00R\factivityType\x88\x01\x01\x12\x19\n" +
+	"\x05title\x18\x03 \x01(\tH\x01R\x05title\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x02R\vdescription\x88\x01\x01\x12.\n" +
+	"\x10duration_minutes\x18\x05 \x01(\x05H\x03R\x0fdurationMinutes\x88\x01\x01\x12$\n" +
+	"\vdistance_km\x18\x06 \x01(\x01H\x04R\n" +
+	"distanceKm\x88\x01\x01\x12,\n" +
+	"\x0fcalories_burned\x18\a \x01(\x05H\x05R\x0ecaloriesBurned\x88\x01\x01\x12\x19\n" +
+	"\x05notes\x18\b \x01(\tH\x06R\x05notes\x88\x01\x01\x12?\n" +
+	"\ractivity_date\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\factivityDateB\x10\n" +
+	"\x0e_activity_typeB\b\n" +
+	"\x06_titleB\x0e\n" +
+	"\f_descriptionB\x13\n" +
+	"\x11_duration_minutesB\x0e\n" +
+	"\f_distance_kmB\x12\n" +
+	"\x10_calories_burnedB\b\n" +
+	"\x06_notes\"'\n" +
+	"\x15DeleteActivityRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"B\n" +
+	"\x16DeleteActivityResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\bR\adeleted\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id2\xb2\x03\n" +
+	"\x0fActivityService\x12G\n" +
+	"\vGetActivity\x12 .activelog.v1.GetActivityRequest\x1a\x16.activelog.v1.Activity\x12[\n" +
+	"\x0eListActivities\x12#.activelog.v1.ListActivitiesRequest\x1a$.activelog.v1.ListActivitiesResponse\x12M\n" +
+	"\x0eCreateActivity\x12#.activelog.v1.CreateActivityRequest\x1a\x16.activelog.v1.Activity\x12M\n" +
+	"\x0eUpdateActivity\x12#.activelog.v1.UpdateActivityRequest\x1a\x16.activelog.v1.Activity\x12[\n" +
+	"\x0eDeleteActivity\x12#.activelog.v1.DeleteActivityRequest\x1a$.activelog.v1.DeleteActivityResponseBCZAgithub.com/valentinesamuel/activelog/gen/activelog/v1;activelogv1b\x06proto3"
+
+var (
+	file_activelog_v1_activity_proto_rawDescOnce sync.Once
+	file_activelog_v1_activity_proto_rawDescData []byte
+)
+
+func file_activelog_v1_activity_proto_rawDescGZIP() []byte {
+	file_activelog_v1_activity_proto_rawDescOnce.Do(func() {
+		file_activelog_v1_activity_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_activelog_v1_activity_proto_rawDesc), len(file_activelog_v1_activity_proto_rawDesc)))
+	})
+	return file_activelog_v1_activity_proto_rawDescData
+}
+
+var file_activelog_v1_activity_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_activelog_v1_activity_proto_goTypes = []any{
+	(*Activity)(nil),               // 0: activelog.v1.Activity
+	(*GetActivityRequest)(nil),     // 1: activelog.v1.GetActivityRequest
+	(*ListActivitiesRequest)(nil),  // 2: activelog.v1.ListActivitiesRequest
+	(*ListActivitiesResponse)(nil), // 3: activelog.v1.ListActivitiesResponse
+	(*CreateActivityRequest)(nil),  // 4: activelog.v1.CreateActivityRequest
+	(*UpdateActivityRequest)(nil),  // 5: activelog.v1.UpdateActivityRequest
+	(*DeleteActivityRequest)(nil),  // 6: activelog.v1.DeleteActivityRequest
+	(*DeleteActivityResponse)(nil), // 7: activelog.v1.DeleteActivityResponse
+	(*timestamppb.Timestamp)(nil),  // 8: google.protobuf.Timestamp
+	(*Tag)(nil),                    // 9: activelog.v1.Tag
+	(*FilterRequest)(nil),          // 10: activelog.v1.FilterRequest
+	(*PaginationMeta)(nil),         // 11: activelog.v1.PaginationMeta
+}
+var file_activelog_v1_activity_proto_depIdxs = []int32{
+	8,  // 0: activelog.v1.Activity.activity_date:type_name -> google.protobuf.Timestamp
+	8,  // 1: activelog.v1.Activity.created_at:type_name -> google.protobuf.Timestamp
+	8,  // 2: activelog.v1.Activity.updated_at:type_name -> google.protobuf.Timestamp
+	9,  // 3: activelog.v1.Activity.tags:type_name -> activelog.v1.Tag
+	10, // 4: activelog.v1.ListActivitiesRequest.filter:type_name -> activelog.v1.FilterRequest
+	0,  // 5: activelog.v1.ListActivitiesResponse.activities:type_name -> activelog.v1.Activity
+	11, // 6: activelog.v1.ListActivitiesResponse.meta:type_name -> activelog.v1.PaginationMeta
+	8,  // 7: activelog.v1.CreateActivityRequest.activity_date:type_name -> google.protobuf.Timestamp
+	8,  // 8: activelog.v1.UpdateActivityRequest.activity_date:type_name -> google.protobuf.Timestamp
+	1,  // 9: activelog.v1.ActivityService.GetActivity:input_type -> activelog.v1.GetActivityRequest
+	2,  // 10: activelog.v1.ActivityService.ListActivities:input_type -> activelog.v1.ListActivitiesRequest
+	4,  // 11: activelog.v1.ActivityService.CreateActivity:input_type -> activelog.v1.CreateActivityRequest
+	5,  // 12: activelog.v1.ActivityService.UpdateActivity:input_type -> activelog.v1.UpdateActivityRequest
+	6,  // 13: activelog.v1.ActivityService.DeleteActivity:input_type -> activelog.v1.DeleteActivityRequest
+	0,  // 14: activelog.v1.ActivityService.GetActivity:output_type -> activelog.v1.Activity
+	3,  // 15: activelog.v1.ActivityService.ListActivities:output_type -> activelog.v1.ListActivitiesResponse
+	0,  // 16: activelog.v1.ActivityService.CreateActivity:output_type -> activelog.v1.Activity
+	0,  // 17: activelog.v1.ActivityService.UpdateActivity:output_type -> activelog.v1.Activity
+	7,  // 18: activelog.v1.ActivityService.DeleteActivity:output_type -> activelog.v1.DeleteActivityResponse
+	14, // [14:19] is the sub-list for method output_type
+	9,  // [9:14] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_activelog_v1_activity_proto_init() }
+func file_activelog_v1_activity_proto_init() {
+	if File_activelog_v1_activity_proto != nil {
+		return
+	}
+	file_activelog_v1_common_proto_init()
+	file_activelog_v1_tag_proto_init()
+	file_activelog_v1_activity_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_activelog_v1_activity_proto_rawDesc), len(file_activelog_v1_activity_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_activelog_v1_activity_proto_goTypes,
+		DependencyIndexes: file_activelog_v1_activity_proto_depIdxs,
+		MessageInfos:      file_activelog_v1_activity_proto_msgTypes,
+	}.Build()
+	File_activelog_v1_activity_proto = out.File
+	file_activelog_v1_activity_proto_goTypes = nil
+	file_activelog_v1_activity_proto_depIdxs = nil
+}
@@ -0,0 +1,377 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: activelog/v1/common.proto
+
+package activelogv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// FilterCondition is an operator-based filter (eq, ne, gt, gte, lt, lte).
+// Mirrors the REST filter[column][operator]=value syntax.
+type FilterCondition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Column        string                 `protobuf:"bytes,1,opt,name=column,proto3" json:"column,omitempty"`
+	Operator      string                 `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"`
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FilterCondition) Reset() {
+	*x = FilterCondition{}
+	mi := &file_activelog_v1_common_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilterCondition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterCondition) ProtoMessage() {}
+
+func (x *FilterCondition) ProtoReflect() protoreflect.Message {
+	mi := &file_activelog_v1_common_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterCondition.ProtoReflect.Descriptor instead.
+func (*FilterCondition) Descriptor() ([]byte, []int) {
+	return file_activelog_v1_common_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FilterCondition) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+func (x *FilterCondition) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *FilterCondition) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// FilterRequest carries the same pagination, filtering, search and ordering
+// options as the REST query string and maps onto query.QueryOptions.
+type FilterRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Page  int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Limit int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// AND conditions, e.g. {"activity_type": "running"}
+	Filter     map[string]string  `protobuf:"bytes,3,rep,name=filter,proto3" json:"filter,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Conditions []*FilterCondition `protobuf:"bytes,4,rep,name=conditions,proto3" json:"conditions,omitempty"`
+	// OR conditions, e.g. {"activity_type": "running"}
+	FilterOr map[string]string `protobuf:"bytes,5,rep,name=filter_or,json=filterOr,proto3" json:"filter_or,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// ILIKE conditions, e.g. {"title": "morning"}
+	Search map[string]string `protobuf:"bytes,6,rep,name=search,proto3" json:"search,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// column -> ASC|DESC
+	Order         map[string]string `protobuf:"bytes,7,rep,name=order,proto3" json:"order,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FilterRequest) Reset() {
+	*x = FilterRequest{}
+	mi := &file_activelog_v1_common_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterRequest) ProtoMessage() {}
+
+func (x *FilterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activelog_v1_common_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterRequest.ProtoReflect.Descriptor instead.
+func (*FilterRequest) Descriptor() ([]byte, []int) {
+	return file_activelog_v1_common_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FilterRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *FilterRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *FilterRequest) GetFilter() map[string]string {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *FilterRequest) GetConditions() []*FilterCondition {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
+func (x *FilterRequest) GetFilterOr() map[string]string {
+	if x != nil {
+		return x.FilterOr
+	}
+	return nil
+}
+
+func (x *FilterRequest) GetSearch() map[string]string {
+	if x != nil {
+		return x.Search
+	}
+	return nil
+}
+
+func (x *FilterRequest) GetOrder() map[string]string {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+// PaginationMeta mirrors query.PaginationMeta. previous_page and next_page
+// are 0 when there is no such page.
+type PaginationMeta struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	PreviousPage  int32                  `protobuf:"varint,4,opt,name=previous_page,json=previousPage,proto3" json:"previous_page,omitempty"`
+	NextPage      int32                  `protobuf:"varint,5,opt,name=next_page,json=nextPage,proto3" json:"next_page,omitempty"`
+	PageCount     int32                  `protobuf:"varint,6,opt,name=page_count,json=pageCount,proto3" json:"page_count,omitempty"`
+	TotalRecords  int32                  `protobuf:"varint,7,opt,name=total_records,json=totalRecords,proto3" json:"total_records,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaginationMeta) Reset() {
+	*x = PaginationMeta{}
+	mi := &file_activelog_v1_common_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaginationMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaginationMeta) ProtoMessage() {}
+
+func (x *PaginationMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_activelog_v1_common_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaginationMeta.ProtoReflect.Descriptor instead.
+func (*PaginationMeta) Descriptor() ([]byte, []int) {
+	return file_activelog_v1_common_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PaginationMeta) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *PaginationMeta) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *PaginationMeta) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *PaginationMeta) GetPreviousPage() int32 {
+	if x != nil {
+		return x.PreviousPage
+	}
+	return 0
+}
+
+func (x *PaginationMeta) GetNextPage() int32 {
+	if x != nil {
+		return x.NextPage
+	}
+	return 0
+}
+
+func (x *PaginationMeta) GetPageCount() int32 {
+	if x != nil {
+		return x.PageCount
+	}
+	return 0
+}
+
+func (x *PaginationMeta) GetTotalRecords() int32 {
+	if x != nil {
+		return x.TotalRecords
+	}
+	return 0
+}
+
+var File_activelog_v1_common_proto protoreflect.FileDescriptor
+
+const file_activelog_v1_common_proto_rawDesc = "" +
+	"\n" +
+	"\x19activelog/v1/common.proto\x12\factivelog.v1\"[\n" +
+	"\x0fFilterCondition\x12\x16\n" +
+	"\x06column\x18\x01 \x01(\tR\x06column\x12\x1a\n" +
+	"\boperator\x18\x02 \x01(\tR\boperator\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\"\xed\x04\n" +
+	"\rFilterRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12?\n" +
+	"\x06filter\x18\x03 \x03(\v2'.activelog.v1.FilterRequest.FilterEntryR\x06filter\x12=\n" +
+	"\n" +
+	"conditions\x18\x04 \x03(\v2\x1d.activelog.v1.FilterConditionR\n" +
+	"conditions\x12F\n" +
+	"\tfilter_or\x18\x05 \x03(\v2).activelog.v1.FilterRequest.FilterOrEntryR\bfilterOr\x12?\n" +
+	"\x06search\x18\x06 \x03(\v2'.activelog.v1.FilterRequest.SearchEntryR\x06search\x12<\n" +
+	"\x05order\x18\a \x03(\v2&.activelog.v1.FilterRequest.OrderEntryR\x05order\x1a9\n" +
+	"\vFilterEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a;\n" +
+	"\rFilterOrEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a9\n" +
+	"\vSearchEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a8\n" +
+	"\n" +
+	"OrderEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd6\x01\n" +
+	"\x0ePaginationMeta\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\x12#\n" +
+	"\rprevious_page\x18\x04 \x01(\x05R\fpreviousPage\x12\x1b\n" +
+	"\tnext_page\x18\x05 \x01(\x05R\bnextPage\x12\x1d\n" +
+	"\n" +
+	"page_count\x18\x06 \x01(\x05R\tpageCount\x12#\n" +
+	"\rtotal_records\x18\a \x01(\x05R\ftotalRecordsBCZAgithub.com/valentinesamuel/activelog/gen/activelog/v1;activelogv1b\x06proto3"
+
+var (
+	file_activelog_v1_common_proto_rawDescOnce sync.Once
+	file_activelog_v1_common_proto_rawDescData []byte
+)
+
+func file_activelog_v1_common_proto_rawDescGZIP() []byte {
+	file_activelog_v1_common_proto_rawDescOnce.Do(func() {
+		file_activelog_v1_common_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_activelog_v1_common_proto_rawDesc), len(file_activelog_v1_common_proto_rawDesc)))
+	})
+	return file_activelog_v1_common_proto_rawDescData
+}
+
+var file_activelog_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_activelog_v1_common_proto_goTypes = []any{
+	(*FilterCondition)(nil), // 0: activelog.v1.FilterCondition
+	(*FilterRequest)(nil),   // 1: activelog.v1.FilterRequest
+	(*PaginationMeta)(nil),  // 2: activelog.v1.PaginationMeta
+	nil,                     // 3: activelog.v1.FilterRequest.FilterEntry
+	nil,                     // 4: activelog.v1.FilterRequest.FilterOrEntry
+	nil,                     // 5: activelog.v1.FilterRequest.SearchEntry
+	nil,                     // 6: activelog.v1.FilterRequest.OrderEntry
+}
+var file_activelog_v1_common_proto_depIdxs = []int32{
+	3, // 0: activelog.v1.FilterRequest.filter:type_name -> activelog.v1.FilterRequest.FilterEntry
+	0, // 1: activelog.v1.FilterRequest.conditions:type_name -> activelog.v1.FilterCondition
+	4, // 2: activelog.v1.FilterRequest.filter_or:type_name -> activelog.v1.FilterRequest.FilterOrEntry
+	5, // 3: activelog.v1.FilterRequest.search:type_name -> activelog.v1.FilterRequest.SearchEntry
+	6, // 4: activelog.v1.FilterRequest.order:type_name -> activelog.v1.FilterRequest.OrderEntry
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_activelog_v1_common_proto_init() }
+func file_activelog_v1_common_proto_init() {
+	if File_activelog_v1_common_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_activelog_v1_common_proto_rawDesc), len(file_activelog_v1_common_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_activelog_v1_common_proto_goTypes,
+		DependencyIndexes: file_activelog_v1_common_proto_depIdxs,
+		MessageInfos:      file_activelog_v1_common_proto_msgTypes,
+	}.Build()
+	File_activelog_v1_common_proto = out.File
+	file_activelog_v1_common_proto_goTypes = nil
+	file_activelog_v1_common_proto_depIdxs = nil
+}
@@ -0,0 +1,562 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: activelog/v1/stats.proto
+
+package activelogv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetWeeklyStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWeeklyStatsRequest) Reset() {
+	*x = GetWeeklyStatsRequest{}
+	mi := &file_activelog_v1_stats_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWeeklyStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWeeklyStatsRequest) ProtoMessage() {}
+
+func (x *GetWeeklyStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activelog_v1_stats_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWeeklyStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetWeeklyStatsRequest) Descriptor() ([]byte, []int) {
+	return file_activelog_v1_stats_proto_rawDescGZIP(), []int{0}
+}
+
+type WeeklyStats struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	TotalActivities      int32                  `protobuf:"varint,1,opt,name=total_activities,json=totalActivities,proto3" json:"total_activities,omitempty"`
+	TotalDurationMinutes int32                  `protobuf:"varint,2,opt,name=total_duration_minutes,json=totalDurationMinutes,proto3" json:"total_duration_minutes,omitempty"`
+	TotalDistanceKm      float64                `protobuf:"fixed64,3,opt,name=total_distance_km,json=totalDistanceKm,proto3" json:"total_distance_km,omitempty"`
+	AvgDurationMinutes   float64                `protobuf:"fixed64,4,opt,name=avg_duration_minutes,json=avgDurationMinutes,proto3" json:"avg_duration_minutes,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *WeeklyStats) Reset() {
+	*x = WeeklyStats{}
+	mi := &file_activelog_v1_stats_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WeeklyStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeeklyStats) ProtoMessage() {}
+
+func (x *WeeklyStats) ProtoReflect() protoreflect.Message {
+	mi := &file_activelog_v1_stats_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeeklyStats.ProtoReflect.Descriptor instead.
+func (*WeeklyStats) Descriptor() ([]byte, []int) {
+	return file_activelog_v1_stats_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WeeklyStats) GetTotalActivities() int32 {
+	if x != nil {
+		return x.TotalActivities
+	}
+	return 0
+}
+
+func (x *WeeklyStats) GetTotalDurationMinutes() int32 {
+	if x != nil {
+		return x.TotalDurationMinutes
+	}
+	return 0
+}
+
+func (x *WeeklyStats) GetTotalDistanceKm() float64 {
+	if x != nil {
+		return x.TotalDistanceKm
+	}
+	return 0
+}
+
+func (x *WeeklyStats) GetAvgDurationMinutes() float64 {
+	if x != nil {
+		return x.AvgDurationMinutes
+	}
+	return 0
+}
+
+type GetMonthlyStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMonthlyStatsRequest) Reset() {
+	*x = GetMonthlyStatsRequest{}
+	mi := &file_activelog_v1_stats_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMonthlyStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMonthlyStatsRequest) ProtoMessage() {}
+
+func (x *GetMonthlyStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activelog_v1_stats_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMonthlyStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetMonthlyStatsRequest) Descriptor() ([]byte, []int) {
+	return file_activelog_v1_stats_proto_rawDescGZIP(), []int{2}
+}
+
+type MonthlyStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// activity_type -> count over the last 30 days
+	Counts        map[string]int32 `protobuf:"bytes,1,rep,name=counts,proto3" json:"counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MonthlyStats) Reset() {
+	*x = MonthlyStats{}
+	mi := &file_activelog_v1_stats_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MonthlyStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonthlyStats) ProtoMessage() {}
+
+func (x *MonthlyStats) ProtoReflect() protoreflect.Message {
+	mi := &file_activelog_v1_stats_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonthlyStats.ProtoReflect.Descriptor instead.
+func (*MonthlyStats) Descriptor() ([]byte, []int) {
+	return file_activelog_v1_stats_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MonthlyStats) GetCounts() map[string]int32 {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+type GetActivityCountByTypeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetActivityCountByTypeRequest) Reset() {
+	*x = GetActivityCountByTypeRequest{}
+	mi := &file_activelog_v1_stats_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetActivityCountByTypeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActivityCountByTypeRequest) ProtoMessage() {}
+
+func (x *GetActivityCountByTypeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activelog_v1_stats_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActivityCountByTypeRequest.ProtoReflect.Descriptor instead.
+func (*GetActivityCountByTypeRequest) Descriptor() ([]byte, []int) {
+	return file_activelog_v1_stats_proto_rawDescGZIP(), []int{4}
+}
+
+type ActivityCountByType struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Counts        map[string]int32       `protobuf:"bytes,1,rep,name=counts,proto3" json:"counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActivityCountByType) Reset() {
+	*x = ActivityCountByType{}
+	mi := &file_activelog_v1_stats_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActivityCountByType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivityCountByType) ProtoMessage() {}
+
+func (x *ActivityCountByType) ProtoReflect() protoreflect.Message {
+	mi := &file_activelog_v1_stats_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivityCountByType.ProtoReflect.Descriptor instead.
+func (*ActivityCountByType) Descriptor() ([]byte, []int) {
+	return file_activelog_v1_stats_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ActivityCountByType) GetCounts() map[string]int32 {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+func (x *ActivityCountByType) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type GetTopTagsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Defaults to 10, max 50
+	Limit         int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTopTagsRequest) Reset() {
+	*x = GetTopTagsRequest{}
+	mi := &file_activelog_v1_stats_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTopTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopTagsRequest) ProtoMessage() {}
+
+func (x *GetTopTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activelog_v1_stats_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopTagsRequest.ProtoReflect.Descriptor instead.
+func (*GetTopTagsRequest) Descriptor() ([]byte, []int) {
+	return file_activelog_v1_stats_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetTopTagsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type TagUsage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TagName       string                 `protobuf:"bytes,1,opt,name=tag_name,json=tagName,proto3" json:"tag_name,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagUsage) Reset() {
+	*x = TagUsage{}
+	mi := &file_activelog_v1_stats_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagUsage) ProtoMessage() {}
+
+func (x *TagUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_activelog_v1_stats_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagUsage.ProtoReflect.Descriptor instead.
+func (*TagUsage) Descriptor() ([]byte, []int) {
+	return file_activelog_v1_stats_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TagUsage) GetTagName() string {
+	if x != nil {
+		return x.TagName
+	}
+	return ""
+}
+
+func (x *TagUsage) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type TopTags struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []*TagUsage            `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopTags) Reset() {
+	*x = TopTags{}
+	mi := &file_activelog_v1_stats_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopTags) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopTags) ProtoMessage() {}
+
+func (x *TopTags) ProtoReflect() protoreflect.Message {
+	mi := &file_activelog_v1_stats_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopTags.ProtoReflect.Descriptor instead.
+func (*TopTags) Descriptor() ([]byte, []int) {
+	return file_activelog_v1_stats_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TopTags) GetTags() []*TagUsage {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *TopTags) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *TopTags) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+var File_activelog_v1_stats_proto protoreflect.FileDescriptor
+
+const file_activelog_v1_stats_proto_rawDesc = "" +
+	"\n" +
+	"\x18activelog/v1/stats.proto\x12\factivelog.v1\"\x17\n" +
+	"\x15GetWeeklyStatsRequest\"\xcc\x01\n" +
+	"\vWeeklyStats\x12)\n" +
+	"\x10total_activities\x18\x01 \x01(\x05R\x0ftotalActivities\x124\n" +
+	"\x16total_duration_minutes\x18\x02 \x01(\x05R\x14totalDurationMinutes\x12*\n" +
+	"\x11total_distance_km\x18\x03 \x01(\x01R\x0ftotalDistanceKm\x120\n" +
+	"\x14avg_duration_minutes\x18\x04 \x01(\x01R\x12avgDurationMinutes\"\x18\n" +
+	"\x16GetMonthlyStatsRequest\"\x89\x01\n" +
+	"\fMonthlyStats\x12>\n" +
+	"\x06counts\x18\x01 \x03(\v2&.activelog.v1.MonthlyStats.CountsEntryR\x06counts\x1a9\n" +
+	"\vCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\x1f\n" +
+	"\x1dGetActivityCountByTypeRequest\"\xb8\x01\n" +
+	"\x13ActivityCountByType\x12E\n" +
+	"\x06counts\x18\x01 \x03(\v2-.activelog.v1.ActivityCountByType.CountsEntryR\x06counts\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\x1a9\n" +
+	"\vCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\")\n" +
+	"\x11GetTopTagsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\";\n" +
+	"\bTagUsage\x12\x19\n" +
+	"\btag_name\x18\x01 \x01(\tR\atagName\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"a\n" +
+	"\aTopTags\x12*\n" +
+	"\x04tags\x18\x01 \x03(\v2\x16.activelog.v1.TagUsageR\x04tags\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count2\xe5\x02\n" +
+	"\fStatsService\x12P\n" +
+	"\x0eGetWeeklyStats\x12#.activelog.v1.GetWeeklyStatsRequest\x1a\x19.activelog.v1.WeeklyStats\x12S\n" +
+	"\x0fGetMonthlyStats\x12$.activelog.v1.GetMonthlyStatsRequest\x1a\x1a.activelog.v1.MonthlyStats\x12h\n" +
+	"\x16GetActivityCountByType\x12+.activelog.v1.GetActivityCountByTypeRequest\x1a!.activelog.v1.ActivityCountByType\x12D\n" +
+	"\n" +
+	"GetTopTags\x12\x1f.activelog.v1.GetTopTagsRequest\x1a\x15.activelog.v1.TopTagsBCZAgithub.com/valentinesamuel/activelog/gen/activelog/v1;activelogv1b\x06proto3"
+
+var (
+	file_activelog_v1_stats_proto_rawDescOnce sync.Once
+	file_activelog_v1_stats_proto_rawDescData []byte
+)
+
+func file_activelog_v1_stats_proto_rawDescGZIP() []byte {
+	file_activelog_v1_stats_proto_rawDescOnce.Do(func() {
+		file_activelog_v1_stats_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_activelog_v1_stats_proto_rawDesc), len(file_activelog_v1_stats_proto_rawDesc)))
+	})
+	return file_activelog_v1_stats_proto_rawDescData
+}
+
+var file_activelog_v1_stats_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_activelog_v1_stats_proto_goTypes = []any{
+	(*GetWeeklyStatsRequest)(nil),         // 0: activelog.v1.GetWeeklyStatsRequest
+	(*WeeklyStats)(nil),                   // 1: activelog.v1.WeeklyStats
+	(*GetMonthlyStatsRequest)(nil),        // 2: activelog.v1.GetMonthlyStatsRequest
+	(*MonthlyStats)(nil),                  // 3: activelog.v1.MonthlyStats
+	(*GetActivityCountByTypeRequest)(nil), // 4: activelog.v1.GetActivityCountByTypeRequest
+	(*ActivityCountByType)(nil),           // 5: activelog.v1.ActivityCountByType
+	(*GetTopTagsRequest)(nil),             // 6: activelog.v1.GetTopTagsRequest
+	(*TagUsage)(nil),                      // 7: activelog.v1.TagUsage
+	(*TopTags)(nil),                       // 8: activelog.v1.TopTags
+	nil,                                   // 9: activelog.v1.MonthlyStats.CountsEntry
+	nil,                                   // 10: activelog.v1.ActivityCountByType.CountsEntry
+}
+var file_activelog_v1_stats_proto_depIdxs = []int32{
+	9,  // 0: activelog.v1.MonthlyStats.counts:type_name -> activelog.v1.MonthlyStats.CountsEntry
+	10, // 1: activelog.v1.ActivityCountByType.counts:type_name -> activelog.v1.ActivityCountByType.CountsEntry
+	7,  // 2: activelog.v1.TopTags.tags:type_name -> activelog.v1.TagUsage
+	0,  // 3: activelog.v1.StatsService.GetWeeklyStats:input_type -> activelog.v1.GetWeeklyStatsRequest
+	2,  // 4: activelog.v1.StatsService.GetMonthlyStats:input_type -> activelog.v1.GetMonthlyStatsRequest
+	4,  // 5: activelog.v1.StatsService.GetActivityCountByType:input_type -> activelog.v1.GetActivityCountByTypeRequest
+	6,  // 6: activelog.v1.StatsService.GetTopTags:input_type -> activelog.v1.GetTopTagsRequest
+	1,  // 7: activelog.v1.StatsService.GetWeeklyStats:output_type -> activelog.v1.WeeklyStats
+	3,  // 8: activelog.v1.StatsService.GetMonthlyStats:output_type -> activelog.v1.MonthlyStats
+	5,  // 9: activelog.v1.StatsService.GetActivityCountByType:output_type -> activelog.v1.ActivityCountByType
+	8,  // 10: activelog.v1.StatsService.GetTopTags:output_type -> activelog.v1.TopTags
+	7,  // [7:11] is the sub-list for method output_type
+	3,  // [3:7] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_activelog_v1_stats_proto_init() }
+func file_activelog_v1_stats_proto_init() {
+	if File_activelog_v1_stats_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_activelog_v1_stats_proto_rawDesc), len(file_activelog_v1_stats_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_activelog_v1_stats_proto_goTypes,
+		DependencyIndexes: file_activelog_v1_stats_proto_depIdxs,
+		MessageInfos:      file_activelog_v1_stats_proto_msgTypes,
+	}.Build()
+	File_activelog_v1_stats_proto = out.File
+	file_activelog_v1_stats_proto_goTypes = nil
+	file_activelog_v1_stats_proto_depIdxs = nil
+}
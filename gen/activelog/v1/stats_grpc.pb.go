@@ -0,0 +1,239 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: activelog/v1/stats.proto
+
+package activelogv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	StatsService_GetWeeklyStats_FullMethodName         = "/activelog.v1.StatsService/GetWeeklyStats"
+	StatsService_GetMonthlyStats_FullMethodName        = "/activelog.v1.StatsService/GetMonthlyStats"
+	StatsService_GetActivityCountByType_FullMethodName = "/activelog.v1.StatsService/GetActivityCountByType"
+	StatsService_GetTopTags_FullMethodName             = "/activelog.v1.StatsService/GetTopTags"
+)
+
+// StatsServiceClient is the client API for StatsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// StatsService exposes the authenticated user's activity statistics.
+type StatsServiceClient interface {
+	GetWeeklyStats(ctx context.Context, in *GetWeeklyStatsRequest, opts ...grpc.CallOption) (*WeeklyStats, error)
+	GetMonthlyStats(ctx context.Context, in *GetMonthlyStatsRequest, opts ...grpc.CallOption) (*MonthlyStats, error)
+	GetActivityCountByType(ctx context.Context, in *GetActivityCountByTypeRequest, opts ...grpc.CallOption) (*ActivityCountByType, error)
+	GetTopTags(ctx context.Context, in *GetTopTagsRequest, opts ...grpc.CallOption) (*TopTags, error)
+}
+
+type statsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStatsServiceClient(cc grpc.ClientConnInterface) StatsServiceClient {
+	return &statsServiceClient{cc}
+}
+
+func (c *statsServiceClient) GetWeeklyStats(ctx context.Context, in *GetWeeklyStatsRequest, opts ...grpc.CallOption) (*WeeklyStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WeeklyStats)
+	err := c.cc.Invoke(ctx, StatsService_GetWeeklyStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statsServiceClient) GetMonthlyStats(ctx context.Context, in *GetMonthlyStatsRequest, opts ...grpc.CallOption) (*MonthlyStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MonthlyStats)
+	err := c.cc.Invoke(ctx, StatsService_GetMonthlyStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statsServiceClient) GetActivityCountByType(ctx context.Context, in *GetActivityCountByTypeRequest, opts ...grpc.CallOption) (*ActivityCountByType, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActivityCountByType)
+	err := c.cc.Invoke(ctx, StatsService_GetActivityCountByType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statsServiceClient) GetTopTags(ctx context.Context, in *GetTopTagsRequest, opts ...grpc.CallOption) (*TopTags, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TopTags)
+	err := c.cc.Invoke(ctx, StatsService_GetTopTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StatsServiceServer is the server API for StatsService service.
+// All implementations must embed UnimplementedStatsServiceServer
+// for forward compatibility.
+//
+// StatsService exposes the authenticated user's activity statistics.
+type StatsServiceServer interface {
+	GetWeeklyStats(context.Context, *GetWeeklyStatsRequest) (*WeeklyStats, error)
+	GetMonthlyStats(context.Context, *GetMonthlyStatsRequest) (*MonthlyStats, error)
+	GetActivityCountByType(context.Context, *GetActivityCountByTypeRequest) (*ActivityCountByType, error)
+	GetTopTags(context.Context, *GetTopTagsRequest) (*TopTags, error)
+	mustEmbedUnimplementedStatsServiceServer()
+}
+
+// UnimplementedStatsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStatsServiceServer struct{}
+
+func (UnimplementedStatsServiceServer) GetWeeklyStats(context.Context, *GetWeeklyStatsRequest) (*WeeklyStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWeeklyStats not implemented")
+}
+func (UnimplementedStatsServiceServer) GetMonthlyStats(context.Context, *GetMonthlyStatsRequest) (*MonthlyStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMonthlyStats not implemented")
+}
+func (UnimplementedStatsServiceServer) GetActivityCountByType(context.Context, *GetActivityCountByTypeRequest) (*ActivityCountByType, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetActivityCountByType not implemented")
+}
+func (UnimplementedStatsServiceServer) GetTopTags(context.Context, *GetTopTagsRequest) (*TopTags, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTopTags not implemented")
+}
+func (UnimplementedStatsServiceServer) mustEmbedUnimplementedStatsServiceServer() {}
+func (UnimplementedStatsServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeStatsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StatsServiceServer will
+// result in compilation errors.
+type UnsafeStatsServiceServer interface {
+	mustEmbedUnimplementedStatsServiceServer()
+}
+
+func RegisterStatsServiceServer(s grpc.ServiceRegistrar, srv StatsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedStatsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StatsService_ServiceDesc, srv)
+}
+
+func _StatsService_GetWeeklyStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWeeklyStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsServiceServer).GetWeeklyStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatsService_GetWeeklyStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsServiceServer).GetWeeklyStats(ctx, req.(*GetWeeklyStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatsService_GetMonthlyStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMonthlyStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsServiceServer).GetMonthlyStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatsService_GetMonthlyStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsServiceServer).GetMonthlyStats(ctx, req.(*GetMonthlyStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatsService_GetActivityCountByType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetActivityCountByTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsServiceServer).GetActivityCountByType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatsService_GetActivityCountByType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsServiceServer).GetActivityCountByType(ctx, req.(*GetActivityCountByTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatsService_GetTopTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTopTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsServiceServer).GetTopTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatsService_GetTopTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsServiceServer).GetTopTags(ctx, req.(*GetTopTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StatsService_ServiceDesc is the grpc.ServiceDesc for StatsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StatsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "activelog.v1.StatsService",
+	HandlerType: (*StatsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetWeeklyStats",
+			Handler:    _StatsService_GetWeeklyStats_Handler,
+		},
+		{
+			MethodName: "GetMonthlyStats",
+			Handler:    _StatsService_GetMonthlyStats_Handler,
+		},
+		{
+			MethodName: "GetActivityCountByType",
+			Handler:    _StatsService_GetActivityCountByType_Handler,
+		},
+		{
+			MethodName: "GetTopTags",
+			Handler:    _StatsService_GetTopTags_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "activelog/v1/stats.proto",
+}
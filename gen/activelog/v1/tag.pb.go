@@ -0,0 +1,258 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: activelog/v1/tag.proto
+
+package activelogv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Tag struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tag) Reset() {
+	*x = Tag{}
+	mi := &file_activelog_v1_tag_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tag) ProtoMessage() {}
+
+func (x *Tag) ProtoReflect() protoreflect.Message {
+	mi := &file_activelog_v1_tag_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tag.ProtoReflect.Descriptor instead.
+func (*Tag) Descriptor() ([]byte, []int) {
+	return file_activelog_v1_tag_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Tag) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Tag) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tag) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *FilterRequest         `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsRequest) Reset() {
+	*x = ListTagsRequest{}
+	mi := &file_activelog_v1_tag_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsRequest) ProtoMessage() {}
+
+func (x *ListTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activelog_v1_tag_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsRequest.ProtoReflect.Descriptor instead.
+func (*ListTagsRequest) Descriptor() ([]byte, []int) {
+	return file_activelog_v1_tag_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListTagsRequest) GetFilter() *FilterRequest {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type ListTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []*Tag                 `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	Meta          *PaginationMeta        `protobuf:"bytes,2,opt,name=meta,proto3" json:"meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsResponse) Reset() {
+	*x = ListTagsResponse{}
+	mi := &file_activelog_v1_tag_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsResponse) ProtoMessage() {}
+
+func (x *ListTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_activelog_v1_tag_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsResponse.ProtoReflect.Descriptor instead.
+func (*ListTagsResponse) Descriptor() ([]byte, []int) {
+	return file_activelog_v1_tag_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListTagsResponse) GetTags() []*Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListTagsResponse) GetMeta() *PaginationMeta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+var File_activelog_v1_tag_proto protoreflect.FileDescriptor
+
+const file_activelog_v1_tag_proto_rawDesc = "" +
+	"\n" +
+	"\x16activelog/v1/tag.proto\x12\factivelog.v1\x1a\x19activelog/v1/common.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"d\n" +
+	"\x03Tag\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"F\n" +
+	"\x0fListTagsRequest\x123\n" +
+	"\x06filter\x18\x01 \x01(\v2\x1b.activelog.v1.FilterRequestR\x06filter\"k\n" +
+	"\x10ListTagsResponse\x12%\n" +
+	"\x04tags\x18\x01 \x03(\v2\x11.activelog.v1.TagR\x04tags\x120\n" +
+	"\x04meta\x18\x02 \x01(\v2\x1c.activelog.v1.PaginationMetaR\x04meta2W\n" +
+	"\n" +
+	"TagService\x12I\n" +
+	"\bListTags\x12\x1d.activelog.v1.ListTagsRequest\x1a\x1e.activelog.v1.ListTagsResponseBCZAgithub.com/valentinesamuel/activelog/gen/activelog/v1;activelogv1b\x06proto3"
+
+var (
+	file_activelog_v1_tag_proto_rawDescOnce sync.Once
+	file_activelog_v1_tag_proto_rawDescData []byte
+)
+
+func file_activelog_v1_tag_proto_rawDescGZIP() []byte {
+	file_activelog_v1_tag_proto_rawDescOnce.Do(func() {
+		file_activelog_v1_tag_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_activelog_v1_tag_proto_rawDesc), len(file_activelog_v1_tag_proto_rawDesc)))
+	})
+	return file_activelog_v1_tag_proto_rawDescData
+}
+
+var file_activelog_v1_tag_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_activelog_v1_tag_proto_goTypes = []any{
+	(*Tag)(nil),                   // 0: activelog.v1.Tag
+	(*ListTagsRequest)(nil),       // 1: activelog.v1.ListTagsRequest
+	(*ListTagsResponse)(nil),      // 2: activelog.v1.ListTagsResponse
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+	(*FilterRequest)(nil),         // 4: activelog.v1.FilterRequest
+	(*PaginationMeta)(nil),        // 5: activelog.v1.PaginationMeta
+}
+var file_activelog_v1_tag_proto_depIdxs = []int32{
+	3, // 0: activelog.v1.Tag.created_at:type_name -> google.protobuf.Timestamp
+	4, // 1: activelog.v1.ListTagsRequest.filter:type_name -> activelog.v1.FilterRequest
+	0, // 2: activelog.v1.ListTagsResponse.tags:type_name -> activelog.v1.Tag
+	5, // 3: activelog.v1.ListTagsResponse.meta:type_name -> activelog.v1.PaginationMeta
+	1, // 4: activelog.v1.TagService.ListTags:input_type -> activelog.v1.ListTagsRequest
+	2, // 5: activelog.v1.TagService.ListTags:output_type -> activelog.v1.ListTagsResponse
+	5, // [5:6] is the sub-list for method output_type
+	4, // [4:5] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_activelog_v1_tag_proto_init() }
+func file_activelog_v1_tag_proto_init() {
+	if File_activelog_v1_tag_proto != nil {
+		return
+	}
+	file_activelog_v1_common_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_activelog_v1_tag_proto_rawDesc), len(file_activelog_v1_tag_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_activelog_v1_tag_proto_goTypes,
+		DependencyIndexes: file_activelog_v1_tag_proto_depIdxs,
+		MessageInfos:      file_activelog_v1_tag_proto_msgTypes,
+	}.Build()
+	File_activelog_v1_tag_proto = out.File
+	file_activelog_v1_tag_proto_goTypes = nil
+	file_activelog_v1_tag_proto_depIdxs = nil
+}
@@ -0,0 +1,145 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/cache/types"
+)
+
+// ErrNotFound is returned by Get for a missing or expired key
+var ErrNotFound = errors.New("memory cache: key not found")
+
+type entry struct {
+	value     string
+	expiresAt time.Time // zero: never expires
+}
+
+func (e entry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// Adapter implements both CacheAdapter and RateLimitCacheProvider in process.
+// Suitable for tests and local development (no Redis required); entries are
+// not shared between instances of the API.
+type Adapter struct {
+	mu      sync.Mutex
+	entries map[string]entry // "<db>:<partition>:<key>" → entry
+	now     func() time.Time
+}
+
+// New creates an empty Adapter.
+func New() *Adapter {
+	return &Adapter{
+		entries: make(map[string]entry),
+		now:     time.Now,
+	}
+}
+
+// buildKey namespaces key by DB and partition, like the Redis adapter's
+// separate databases
+func buildKey(opts types.CacheOptions, key string) string {
+	return string(opts.DB) + ":" + string(opts.PartitionKey) + ":" + key
+}
+
+// lookup returns the live entry for k, dropping it if it has expired.
+// Callers hold a.mu.
+func (a *Adapter) lookup(k string) (entry, bool) {
+	e, ok := a.entries[k]
+	if ok && e.expired(a.now()) {
+		delete(a.entries, k)
+		return entry{}, false
+	}
+	return e, ok
+}
+
+// expiry returns the expiry time of a ttl; zero means no expiry
+func (a *Adapter) expiry(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return a.now().Add(ttl)
+}
+
+// Get retrieves a value from the cache.
+func (a *Adapter) Get(_ context.Context, key string, opts types.CacheOptions) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	e, ok := a.lookup(buildKey(opts, key))
+	if !ok {
+		return "", ErrNotFound
+	}
+	return e.value, nil
+}
+
+// Set stores a value in the cache with the given TTL.
+func (a *Adapter) Set(_ context.Context, key string, value string, ttl time.Duration, opts types.CacheOptions) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.entries[buildKey(opts, key)] = entry{value: value, expiresAt: a.expiry(ttl)}
+	return nil
+}
+
+// Del removes a value from the cache.
+func (a *Adapter) Del(_ context.Context, key string, opts types.CacheOptions) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.entries, buildKey(opts, key))
+	return nil
+}
+
+// Increment atomically increments the counter for the given key. Like Redis
+// INCR, a missing key starts at 0 and keeps no TTL.
+func (a *Adapter) Increment(_ context.Context, key string, opts types.CacheOptions) (int64, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	k := buildKey(opts, key)
+	e, _ := a.lookup(k)
+	var n int64
+	if e.value != "" {
+		var err error
+		if n, err = strconv.ParseInt(e.value, 10, 64); err != nil {
+			return 0, errors.New("memory cache: value is not an integer")
+		}
+	}
+	n++
+	e.value = strconv.FormatInt(n, 10)
+	a.entries[k] = e
+	return n, nil
+}
+
+// Expire sets the TTL for the given key. It reports false if the key does
+// not exist.
+func (a *Adapter) Expire(_ context.Context, key string, ttl time.Duration, opts types.CacheOptions) (bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	k := buildKey(opts, key)
+	e, ok := a.lookup(k)
+	if !ok {
+		return false, nil
+	}
+	e.expiresAt = a.expiry(ttl)
+	a.entries[k] = e
+	return true, nil
+}
+
+// SetNX sets the value only if the key does not already exist.
+func (a *Adapter) SetNX(_ context.Context, key string, value string, ttl time.Duration, opts types.CacheOptions) (bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	k := buildKey(opts, key)
+	if _, ok := a.lookup(k); ok {
+		return false, nil
+	}
+	a.entries[k] = entry{value: value, expiresAt: a.expiry(ttl)}
+	return true, nil
+}
@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valentinesamuel/activelog/internal/adapters/cache/types"
+)
+
+func TestAdapter_CountersExpire(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := New()
+	a.now = func() time.Time { return now }
+	opts := types.CacheOptions{DB: types.CacheDBRateLimits, PartitionKey: types.CachePartitionRateLimitCounters}
+
+	for want := int64(1); want <= 3; want++ {
+		n, err := a.Increment(ctx, "user:1", opts)
+		require.NoError(t, err)
+		assert.Equal(t, want, n)
+	}
+	ok, err := a.Expire(ctx, "user:1", time.Minute, opts)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// Keys are namespaced by DB and partition
+	_, err = a.Get(ctx, "user:1", types.CacheOptions{DB: types.CacheDBStats, PartitionKey: types.CachePartitionRateLimitCounters})
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	set, err := a.SetNX(ctx, "user:1", "x", 0, opts)
+	require.NoError(t, err)
+	assert.False(t, set, "key exists")
+
+	now = now.Add(time.Minute)
+	_, err = a.Get(ctx, "user:1", opts)
+	assert.ErrorIs(t, err, ErrNotFound)
+	n, err := a.Increment(ctx, "user:1", opts)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n, "counter restarts after expiry")
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 
+	memoryadapter "github.com/valentinesamuel/activelog/internal/adapters/cache/adapter/memory"
 	redisadapter "github.com/valentinesamuel/activelog/internal/adapters/cache/adapter/redis"
 	"github.com/valentinesamuel/activelog/internal/platform/config"
 	"github.com/valentinesamuel/activelog/internal/platform/container"
@@ -17,6 +18,9 @@ func RegisterCacheAdapter(c *container.Container) {
 			adapter := redisadapter.New()
 			log.Printf("Cache adapter initialized: Redis multi-DB")
 			return adapter, nil
+		case "memory":
+			log.Printf("Cache adapter initialized: in-memory")
+			return memoryadapter.New(), nil
 		default:
 			return nil, fmt.Errorf("unsupported cache provider for adapter: %s", config.Cache.Provider)
 		}
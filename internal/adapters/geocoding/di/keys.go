@@ -0,0 +1,7 @@
+package di
+
+// Container registration keys for geocoding
+const (
+	// GeocoderKey is the key for the active geocoding provider
+	GeocoderKey = "Geocoder"
+)
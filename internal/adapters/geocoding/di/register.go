@@ -0,0 +1,31 @@
+package di
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/geocoding/google"
+	"github.com/valentinesamuel/activelog/internal/adapters/geocoding/nominatim"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	"github.com/valentinesamuel/activelog/internal/platform/container"
+)
+
+// RegisterGeocoding registers the geocoding provider in the DI container.
+// Resolving it fails when geocoding is disabled (GEOCODING_PROVIDER=none).
+func RegisterGeocoding(c *container.Container) {
+	c.Register(GeocoderKey, func(c *container.Container) (interface{}, error) {
+		switch config.Geocoding.Provider {
+		case "nominatim":
+			log.Printf("Geocoding provider initialized: nominatim (%d requests/min)", config.Geocoding.RatePerMinute)
+			return nominatim.New(), nil
+		case "google":
+			if config.Geocoding.GoogleAPIKey == "" {
+				return nil, fmt.Errorf("geocoding: GEOCODING_GOOGLE_API_KEY is required for the google provider")
+			}
+			log.Printf("Geocoding provider initialized: google (%d requests/min)", config.Geocoding.RatePerMinute)
+			return google.New(), nil
+		default:
+			return nil, fmt.Errorf("geocoding: no provider configured (GEOCODING_PROVIDER=%s)", config.Geocoding.Provider)
+		}
+	})
+}
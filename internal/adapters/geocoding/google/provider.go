@@ -0,0 +1,102 @@
+package google
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/time/rate"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/geocoding/types"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	"github.com/valentinesamuel/activelog/pkg/httpclient"
+)
+
+// geocodeURL is the Google Maps Geocoding API endpoint
+const geocodeURL = "https://maps.googleapis.com/maps/api/geocode/json"
+
+// Provider geocodes with the Google Maps Geocoding API.
+// Requests are rate limited per process (GEOCODING_RATE_PER_MINUTE).
+type Provider struct {
+	client   *http.Client
+	limiter  *rate.Limiter
+	endpoint string
+	apiKey   string
+}
+
+// New creates a Provider from the global geocoding config.
+func New() *Provider {
+	cfg := config.Geocoding
+	return &Provider{
+		client:   httpclient.New(httpclient.Config{Name: "geocoding_google", Timeout: cfg.Timeout}),
+		limiter:  rate.NewLimiter(rate.Limit(float64(cfg.RatePerMinute)/60), 1),
+		endpoint: geocodeURL,
+		apiKey:   cfg.GoogleAPIKey,
+	}
+}
+
+// geocodeResponse is the part of a geocode response we read
+type geocodeResponse struct {
+	Status       string `json:"status"`
+	ErrorMessage string `json:"error_message"`
+	Results      []struct {
+		FormattedAddress string `json:"formatted_address"`
+		Geometry         struct {
+			Location struct {
+				Lat float64 `json:"lat"`
+				Lng float64 `json:"lng"`
+			} `json:"location"`
+		} `json:"geometry"`
+	} `json:"results"`
+}
+
+// Geocode returns the best match for query
+func (p *Provider) Geocode(ctx context.Context, query string) (*types.Place, error) {
+	if err := p.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	params := url.Values{
+		"address": {query},
+		"key":     {p.apiKey},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("google geocoding: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("google geocoding: unexpected status %d", resp.StatusCode)
+	}
+
+	var body geocodeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("google geocoding: decode response: %w", err)
+	}
+
+	switch body.Status {
+	case "OK":
+	case "ZERO_RESULTS":
+		return nil, types.ErrNotFound
+	default:
+		return nil, fmt.Errorf("google geocoding: %s %s", body.Status, body.ErrorMessage)
+	}
+	if len(body.Results) == 0 {
+		return nil, types.ErrNotFound
+	}
+
+	result := body.Results[0]
+	return &types.Place{
+		Lat:         result.Geometry.Location.Lat,
+		Lng:         result.Geometry.Location.Lng,
+		DisplayName: result.FormattedAddress,
+	}, nil
+}
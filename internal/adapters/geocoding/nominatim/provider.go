@@ -0,0 +1,90 @@
+package nominatim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"golang.org/x/time/rate"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/geocoding/types"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	"github.com/valentinesamuel/activelog/pkg/httpclient"
+)
+
+// Provider geocodes with a Nominatim (OpenStreetMap) instance.
+// Requests are rate limited per process (GEOCODING_RATE_PER_MINUTE).
+type Provider struct {
+	client    *http.Client
+	limiter   *rate.Limiter
+	baseURL   string
+	userAgent string
+}
+
+// New creates a Provider from the global geocoding config.
+func New() *Provider {
+	cfg := config.Geocoding
+	return &Provider{
+		client:    httpclient.New(httpclient.Config{Name: "nominatim", Timeout: cfg.Timeout}),
+		limiter:   rate.NewLimiter(rate.Limit(float64(cfg.RatePerMinute)/60), 1),
+		baseURL:   cfg.NominatimURL,
+		userAgent: cfg.UserAgent,
+	}
+}
+
+// searchResult is the part of a /search result we read; coordinates are strings
+type searchResult struct {
+	Lat         string `json:"lat"`
+	Lon         string `json:"lon"`
+	DisplayName string `json:"display_name"`
+}
+
+// Geocode returns the best match for query
+func (p *Provider) Geocode(ctx context.Context, query string) (*types.Place, error) {
+	if err := p.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	params := url.Values{
+		"q":      {query},
+		"format": {"jsonv2"},
+		"limit":  {"1"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/search?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", p.userAgent)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("nominatim: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("nominatim: unexpected status %d", resp.StatusCode)
+	}
+
+	var results []searchResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("nominatim: decode response: %w", err)
+	}
+	if len(results) == 0 {
+		return nil, types.ErrNotFound
+	}
+
+	lat, err := strconv.ParseFloat(results[0].Lat, 64)
+	if err != nil {
+		return nil, fmt.Errorf("nominatim: invalid latitude %q", results[0].Lat)
+	}
+	lng, err := strconv.ParseFloat(results[0].Lon, 64)
+	if err != nil {
+		return nil, fmt.Errorf("nominatim: invalid longitude %q", results[0].Lon)
+	}
+
+	return &types.Place{Lat: lat, Lng: lng, DisplayName: results[0].DisplayName}, nil
+}
@@ -0,0 +1,40 @@
+package nominatim
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/geocoding/types"
+)
+
+func TestGeocode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "activelog-test", r.Header.Get("User-Agent"))
+		if r.URL.Query().Get("q") != "Hyde Park, London" {
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.Write([]byte(`[{"lat":"51.5074","lon":"-0.1657","display_name":"Hyde Park, London, UK"}]`))
+	}))
+	defer server.Close()
+
+	provider := &Provider{
+		client:    server.Client(),
+		limiter:   rate.NewLimiter(rate.Inf, 1),
+		baseURL:   server.URL,
+		userAgent: "activelog-test",
+	}
+
+	place, err := provider.Geocode(context.Background(), "Hyde Park, London")
+	require.NoError(t, err)
+	assert.Equal(t, &types.Place{Lat: 51.5074, Lng: -0.1657, DisplayName: "Hyde Park, London, UK"}, place)
+
+	_, err = provider.Geocode(context.Background(), "Nowhere")
+	assert.ErrorIs(t, err, types.ErrNotFound)
+}
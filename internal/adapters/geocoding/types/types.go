@@ -0,0 +1,22 @@
+package types
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned when the provider cannot resolve the query
+var ErrNotFound = errors.New("geocoding: location not found")
+
+// Place is a geocoded location
+type Place struct {
+	Lat         float64
+	Lng         float64
+	DisplayName string
+}
+
+// Geocoder is the interface all geocoding backends must implement.
+type Geocoder interface {
+	// Geocode resolves a free-text location name to its best match
+	Geocode(ctx context.Context, query string) (*Place, error)
+}
@@ -0,0 +1,161 @@
+package grpcapi
+
+import (
+	"context"
+
+	activelogv1 "github.com/valentinesamuel/activelog/gen/activelog/v1"
+	"github.com/valentinesamuel/activelog/internal/application/activity/usecases"
+	"github.com/valentinesamuel/activelog/internal/application/broker"
+	"github.com/valentinesamuel/activelog/internal/platform/validator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ActivityService implements activelogv1.ActivityServiceServer on top of the
+// same use cases and broker as the REST ActivityHandler
+type ActivityService struct {
+	activelogv1.UnimplementedActivityServiceServer
+
+	broker           *broker.Broker
+	getActivityUC    *usecases.GetActivityUseCase
+	listActivitiesUC *usecases.ListActivitiesUseCase
+	createActivityUC *usecases.CreateActivityUseCase
+	updateActivityUC *usecases.UpdateActivityUseCase
+	deleteActivityUC *usecases.DeleteActivityUseCase
+}
+
+// ActivityServiceDeps holds the use cases ActivityService delegates to
+type ActivityServiceDeps struct {
+	GetActivityUC    *usecases.GetActivityUseCase
+	ListActivitiesUC *usecases.ListActivitiesUseCase
+	CreateActivityUC *usecases.CreateActivityUseCase
+	UpdateActivityUC *usecases.UpdateActivityUseCase
+	DeleteActivityUC *usecases.DeleteActivityUseCase
+}
+
+func NewActivityService(brokerInstance *broker.Broker, deps ActivityServiceDeps) *ActivityService {
+	return &ActivityService{
+		broker:           brokerInstance,
+		getActivityUC:    deps.GetActivityUC,
+		listActivitiesUC: deps.ListActivitiesUC,
+		createActivityUC: deps.CreateActivityUC,
+		updateActivityUC: deps.UpdateActivityUC,
+		deleteActivityUC: deps.DeleteActivityUC,
+	}
+}
+
+// GetActivity returns one of the caller's activities. Activities owned by
+// other users are reported as NotFound so ids cannot be probed.
+func (s *ActivityService) GetActivity(ctx context.Context, req *activelogv1.GetActivityRequest) (*activelogv1.Activity, error) {
+	user, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := broker.RunUseCase(s.broker, ctx, s.getActivityUC, usecases.GetActivityInput{
+		ActivityID: req.GetId(),
+	})
+	if err != nil {
+		return nil, toStatus(err, "failed to fetch activity")
+	}
+	if result.Activity.UserID != user.Id {
+		return nil, status.Error(codes.NotFound, "resource not found")
+	}
+
+	return toProtoActivity(result.Activity), nil
+}
+
+func (s *ActivityService) ListActivities(ctx context.Context, req *activelogv1.ListActivitiesRequest) (*activelogv1.ListActivitiesResponse, error) {
+	user, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := toQueryOptions(req.GetFilter(), activityQueryConfig)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result, err := broker.RunUseCase(s.broker, ctx, s.listActivitiesUC, usecases.ListActivitiesInput{
+		UserID:       user.Id,
+		QueryOptions: opts,
+	})
+	if err != nil {
+		return nil, toStatus(err, "failed to fetch activities")
+	}
+
+	activities := result.Result.Data
+	resp := &activelogv1.ListActivitiesResponse{
+		Activities: make([]*activelogv1.Activity, 0, len(activities)),
+		Meta:       toPaginationMeta(result.Result.Meta),
+	}
+	for _, a := range activities {
+		resp.Activities = append(resp.Activities, toProtoActivity(a))
+	}
+	return resp, nil
+}
+
+func (s *ActivityService) CreateActivity(ctx context.Context, req *activelogv1.CreateActivityRequest) (*activelogv1.Activity, error) {
+	user, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	createReq := toCreateActivityRequest(req)
+	if err := validator.Validate(createReq); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result, err := broker.RunUseCase(s.broker, ctx, s.createActivityUC, usecases.CreateActivityInput{
+		UserID:  user.Id,
+		Request: createReq,
+	})
+	if err != nil {
+		return nil, toStatus(err, "failed to create activity")
+	}
+
+	return toProtoActivity(result.Activity), nil
+}
+
+func (s *ActivityService) UpdateActivity(ctx context.Context, req *activelogv1.UpdateActivityRequest) (*activelogv1.Activity, error) {
+	user, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updateReq := toUpdateActivityRequest(req)
+	if err := validator.Validate(updateReq); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result, err := broker.RunUseCase(s.broker, ctx, s.updateActivityUC, usecases.UpdateActivityInput{
+		UserID:     user.Id,
+		ActivityID: int(req.GetId()),
+		Request:    updateReq,
+	})
+	if err != nil {
+		return nil, toStatus(err, "failed to update activity")
+	}
+
+	return toProtoActivity(result.Activity), nil
+}
+
+func (s *ActivityService) DeleteActivity(ctx context.Context, req *activelogv1.DeleteActivityRequest) (*activelogv1.DeleteActivityResponse, error) {
+	user, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := broker.RunUseCase(s.broker, ctx, s.deleteActivityUC, usecases.DeleteActivityInput{
+		UserID:     user.Id,
+		ActivityID: int(req.GetId()),
+	})
+	if err != nil {
+		return nil, toStatus(err, "failed to delete activity")
+	}
+
+	return &activelogv1.DeleteActivityResponse{
+		Deleted: result.Deleted,
+		Id:      int64(result.ActivityID),
+	}, nil
+}
@@ -0,0 +1,111 @@
+package grpcapi
+
+import (
+	"strconv"
+	"time"
+
+	activelogv1 "github.com/valentinesamuel/activelog/gen/activelog/v1"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toProtoActivity(a *models.Activity) *activelogv1.Activity {
+	if a == nil {
+		return nil
+	}
+
+	tags := make([]*activelogv1.Tag, 0, len(a.Tags))
+	for _, t := range a.Tags {
+		tags = append(tags, toProtoTag(t))
+	}
+
+	return &activelogv1.Activity{
+		Id:              a.ID,
+		UserId:          int32(a.UserID),
+		ActivityType:    a.ActivityType,
+		Title:           a.Title,
+		Description:     a.Description,
+		DurationMinutes: int32(a.DurationMinutes),
+		DistanceKm:      a.DistanceKm,
+		CaloriesBurned:  int32(a.CaloriesBurned),
+		Notes:           a.Notes,
+		ActivityDate:    timestamp(a.ActivityDate),
+		CreatedAt:       timestamp(a.CreatedAt),
+		UpdatedAt:       timestamp(a.UpdatedAt),
+		Tags:            tags,
+	}
+}
+
+func toProtoTag(t *models.Tag) *activelogv1.Tag {
+	if t == nil {
+		return nil
+	}
+	return &activelogv1.Tag{
+		Id:        t.ID,
+		Name:      t.Name,
+		CreatedAt: timestamp(t.CreatedAt),
+	}
+}
+
+// toCreateActivityRequest converts req and sanitizes its free-text fields, as
+// the REST handlers do when decoding a body
+func toCreateActivityRequest(req *activelogv1.CreateActivityRequest) *models.CreateActivityRequest {
+	create := &models.CreateActivityRequest{
+		ActivityType:    req.GetActivityType(),
+		Title:           req.GetTitle(),
+		Description:     req.GetDescription(),
+		DurationMinutes: int(req.GetDurationMinutes()),
+		DistanceKm:      req.GetDistanceKm(),
+		CaloriesBurned:  int(req.GetCaloriesBurned()),
+		Notes:           req.GetNotes(),
+		ActivityDate:    fromTimestamp(req.GetActivityDate()),
+	}
+	create.Sanitize()
+	return create
+}
+
+// toUpdateActivityRequest keeps proto3 optional semantics: only fields the
+// caller set are copied, matching the REST PATCH body
+func toUpdateActivityRequest(req *activelogv1.UpdateActivityRequest) *models.UpdateActivityRequest {
+	update := &models.UpdateActivityRequest{
+		ActivityType: req.ActivityType,
+		Title:        req.Title,
+		Description:  req.Description,
+		DistanceKm:   req.DistanceKm,
+		Notes:        req.Notes,
+	}
+
+	if req.DurationMinutes != nil {
+		v := int(req.GetDurationMinutes())
+		update.DurationMinutes = &v
+	}
+	if req.CaloriesBurned != nil {
+		v := int(req.GetCaloriesBurned())
+		update.CaloriesBurned = &v
+	}
+	if req.ActivityDate != nil {
+		v := req.GetActivityDate().AsTime()
+		update.ActivityDate = &v
+	}
+
+	update.Sanitize()
+	return update
+}
+
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func fromTimestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func itoa(n int32) string {
+	return strconv.Itoa(int(n))
+}
@@ -0,0 +1,9 @@
+package di
+
+// Container registration keys for gRPC services
+const (
+	ActivityServiceKey = "grpcActivityService"
+	TagServiceKey      = "grpcTagService"
+	StatsServiceKey    = "grpcStatsService"
+	ServerKey          = "grpcServer"
+)
@@ -0,0 +1,56 @@
+package di
+
+import (
+	"github.com/valentinesamuel/activelog/internal/adapters/grpcapi"
+	activityUsecases "github.com/valentinesamuel/activelog/internal/application/activity/usecases"
+	activityUsecasesDI "github.com/valentinesamuel/activelog/internal/application/activity/usecases/di"
+	"github.com/valentinesamuel/activelog/internal/application/broker"
+	brokerDI "github.com/valentinesamuel/activelog/internal/application/broker/di"
+	statsUsecases "github.com/valentinesamuel/activelog/internal/application/stats/usecases"
+	statsUsecasesDI "github.com/valentinesamuel/activelog/internal/application/stats/usecases/di"
+	tagUsecases "github.com/valentinesamuel/activelog/internal/application/tag/usecases"
+	tagUsecasesDI "github.com/valentinesamuel/activelog/internal/application/tag/usecases/di"
+	clockDI "github.com/valentinesamuel/activelog/internal/platform/clock/di"
+	"github.com/valentinesamuel/activelog/internal/platform/container"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+)
+
+// RegisterGRPCServices registers the gRPC service implementations and server
+// Dependencies: Requires the broker, the clock and activity, tag and stats use cases
+func RegisterGRPCServices(c *container.Container) {
+	c.Register(ActivityServiceKey, func(c *container.Container) (interface{}, error) {
+		brokerInstance := container.MustResolve[*broker.Broker](c, brokerDI.BrokerKey)
+		return grpcapi.NewActivityService(brokerInstance, grpcapi.ActivityServiceDeps{
+			GetActivityUC:    container.MustResolve[*activityUsecases.GetActivityUseCase](c, activityUsecasesDI.GetActivityUCKey),
+			ListActivitiesUC: container.MustResolve[*activityUsecases.ListActivitiesUseCase](c, activityUsecasesDI.ListActivitiesUCKey),
+			CreateActivityUC: container.MustResolve[*activityUsecases.CreateActivityUseCase](c, activityUsecasesDI.CreateActivityUCKey),
+			UpdateActivityUC: container.MustResolve[*activityUsecases.UpdateActivityUseCase](c, activityUsecasesDI.UpdateActivityUCKey),
+			DeleteActivityUC: container.MustResolve[*activityUsecases.DeleteActivityUseCase](c, activityUsecasesDI.DeleteActivityUCKey),
+		}), nil
+	})
+
+	c.Register(TagServiceKey, func(c *container.Container) (interface{}, error) {
+		brokerInstance := container.MustResolve[*broker.Broker](c, brokerDI.BrokerKey)
+		listTagsUC := container.MustResolve[*tagUsecases.ListTagsUseCase](c, tagUsecasesDI.ListTagsUCKey)
+		return grpcapi.NewTagService(brokerInstance, listTagsUC), nil
+	})
+
+	c.Register(StatsServiceKey, func(c *container.Container) (interface{}, error) {
+		brokerInstance := container.MustResolve[*broker.Broker](c, brokerDI.BrokerKey)
+		return grpcapi.NewStatsService(brokerInstance, grpcapi.StatsServiceDeps{
+			GetWeeklyStatsUC:         container.MustResolve[*statsUsecases.GetWeeklyStatsUseCase](c, statsUsecasesDI.GetWeeklyStatsUCKey),
+			GetMonthlyStatsUC:        container.MustResolve[*statsUsecases.GetMonthlyStatsUseCase](c, statsUsecasesDI.GetMonthlyStatsUCKey),
+			GetActivityCountByTypeUC: container.MustResolve[*statsUsecases.GetActivityCountByTypeUseCase](c, statsUsecasesDI.GetActivityCountByTypeUCKey),
+			GetTopTagsUC:             container.MustResolve[*statsUsecases.GetTopTagsUseCase](c, statsUsecasesDI.GetTopTagsUCKey),
+		}), nil
+	})
+
+	c.Register(ServerKey, func(c *container.Container) (interface{}, error) {
+		return grpcapi.NewServer(
+			container.MustResolve[clock.Clock](c, clockDI.ClockKey),
+			container.MustResolve[*grpcapi.ActivityService](c, ActivityServiceKey),
+			container.MustResolve[*grpcapi.TagService](c, TagServiceKey),
+			container.MustResolve[*grpcapi.StatsService](c, StatsServiceKey),
+		), nil
+	})
+}
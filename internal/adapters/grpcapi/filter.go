@@ -0,0 +1,139 @@
+package grpcapi
+
+import (
+	"net/url"
+
+	activelogv1 "github.com/valentinesamuel/activelog/gen/activelog/v1"
+	"github.com/valentinesamuel/activelog/pkg/query"
+)
+
+// maxPageSize caps FilterRequest.limit the same way the REST list endpoints do
+const maxPageSize = 100
+
+// activityQueryConfig whitelists the columns and operators a FilterRequest may
+// use against activities. Kept in step with ActivityHandler.ListActivities.
+var activityQueryConfig = &query.ValidationConfig{
+	AllowedFilters: []string{
+		"activity_type",
+		"source",
+		"visibility",
+		"duration_minutes",
+		"distance_km",
+		"calories_burned",
+		"activity_date",
+		"created_at",
+		"updated_at",
+		"tags.name",
+		"tags.id",
+	},
+	AllowedSearch: []string{"title", "description", "notes", "tags.name"},
+	AllowedOrder: []string{
+		"created_at",
+		"updated_at",
+		"activity_date",
+		"duration_minutes",
+		"distance_km",
+		"calories_burned",
+		"tags.name",
+	},
+	OperatorWhitelists: query.OperatorWhitelist{
+		"activity_date":    query.ComparisonOperators(),
+		"distance_km":      query.ComparisonOperators(),
+		"duration_minutes": query.ComparisonOperators(),
+		"calories_burned":  query.ComparisonOperators(),
+		"created_at":       query.ComparisonOperators(),
+		"updated_at":       query.ComparisonOperators(),
+		"activity_type":    query.EqualityOperators(),
+		"source":           query.EqualityOperators(),
+		"visibility":       query.EqualityOperators(),
+		"tags.name":        query.EqualityOperators(),
+		"tags.id":          query.StrictEqualityOnly(),
+	},
+	MaxPageSize: maxPageSize,
+}
+
+// tagQueryConfig whitelists the columns a FilterRequest may use against tags.
+// Kept in step with TagHandler.ListTags.
+var tagQueryConfig = &query.ValidationConfig{
+	AllowedFilters: []string{"name", "created_at"},
+	AllowedSearch:  []string{"name"},
+	AllowedOrder:   []string{"name", "created_at"},
+	OperatorWhitelists: query.OperatorWhitelist{
+		"name":       query.EqualityOperators(),
+		"created_at": query.ComparisonOperators(),
+	},
+	MaxPageSize: maxPageSize,
+}
+
+// toQueryOptions maps a FilterRequest onto QueryOptions and validates it.
+//
+// The request is rendered into the REST query-string form and run through
+// query.ParseQueryParams, so gRPC and REST callers get identical defaults and
+// value coercion ("true", "null", "[a,b]", numbers).
+func toQueryOptions(req *activelogv1.FilterRequest, cfg *query.ValidationConfig) (*query.QueryOptions, error) {
+	opts, err := query.ParseQueryParams(filterValues(req))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.ValidateWithConfig(opts, cfg); err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+// filterValues renders a FilterRequest as url.Values in the
+// filter[col], filter[col][op], filterOr[col], search[col], order[col] syntax
+func filterValues(req *activelogv1.FilterRequest) url.Values {
+	values := url.Values{}
+	if req == nil {
+		return values
+	}
+
+	if req.GetPage() > 0 {
+		values.Set("page", itoa(req.GetPage()))
+	}
+	if req.GetLimit() > 0 {
+		values.Set("limit", itoa(req.GetLimit()))
+	}
+
+	for column, value := range req.GetFilter() {
+		values.Set("filter["+column+"]", value)
+	}
+	for _, cond := range req.GetConditions() {
+		values.Set("filter["+cond.GetColumn()+"]["+cond.GetOperator()+"]", cond.GetValue())
+	}
+	for column, value := range req.GetFilterOr() {
+		values.Set("filterOr["+column+"]", value)
+	}
+	for column, term := range req.GetSearch() {
+		values.Set("search["+column+"]", term)
+	}
+	for column, direction := range req.GetOrder() {
+		values.Set("order["+column+"]", direction)
+	}
+
+	return values
+}
+
+// toPaginationMeta converts query.PaginationMeta into the protobuf form, where
+// a previous/next page of 0 means none
+func toPaginationMeta(meta query.PaginationMeta) *activelogv1.PaginationMeta {
+	return &activelogv1.PaginationMeta{
+		Page:         int32(meta.Page),
+		Limit:        int32(meta.Limit),
+		Count:        int32(meta.Count),
+		PreviousPage: pageNumber(meta.PreviousPage),
+		NextPage:     pageNumber(meta.NextPage),
+		PageCount:    int32(meta.PageCount),
+		TotalRecords: int32(meta.TotalRecords),
+	}
+}
+
+func pageNumber(page *int) int32 {
+	if page == nil {
+		return 0
+	}
+	return int32(*page)
+}
@@ -0,0 +1,102 @@
+package grpcapi
+
+import (
+	"testing"
+
+	activelogv1 "github.com/valentinesamuel/activelog/gen/activelog/v1"
+	"github.com/valentinesamuel/activelog/pkg/query"
+)
+
+func TestToQueryOptions_MapsFilterRequest(t *testing.T) {
+	req := &activelogv1.FilterRequest{
+		Page:   2,
+		Limit:  25,
+		Filter: map[string]string{"activity_type": "running"},
+		Conditions: []*activelogv1.FilterCondition{
+			{Column: "distance_km", Operator: "gte", Value: "5"},
+		},
+		Search: map[string]string{"title": "morning"},
+		Order:  map[string]string{"activity_date": "desc"},
+	}
+
+	opts, err := toQueryOptions(req, activityQueryConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if opts.Page != 2 || opts.Limit != 25 {
+		t.Errorf("page/limit = %d/%d, want 2/25", opts.Page, opts.Limit)
+	}
+	if opts.Filter["activity_type"] != "running" {
+		t.Errorf("filter activity_type = %v, want running", opts.Filter["activity_type"])
+	}
+	if opts.Search["title"] != "morning" {
+		t.Errorf("search title = %v, want morning", opts.Search["title"])
+	}
+	if direction, _ := opts.Order.Get("activity_date"); direction != "DESC" {
+		t.Errorf("order activity_date = %q, want DESC", direction)
+	}
+
+	var found bool
+	for _, cond := range opts.FilterConditions {
+		if cond.Column == "distance_km" && cond.Operator == "gte" {
+			found = true
+			if _, ok := cond.Value.(string); ok {
+				t.Errorf("distance_km value was not coerced: %#v", cond.Value)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("distance_km gte condition missing: %+v", opts.FilterConditions)
+	}
+}
+
+func TestToQueryOptions_Defaults(t *testing.T) {
+	opts, err := toQueryOptions(nil, activityQueryConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Page != 1 || opts.Limit != 10 {
+		t.Errorf("page/limit = %d/%d, want defaults 1/10", opts.Page, opts.Limit)
+	}
+}
+
+func TestToQueryOptions_RejectsUnlistedColumns(t *testing.T) {
+	tests := map[string]*activelogv1.FilterRequest{
+		"filter":   {Filter: map[string]string{"user_id": "7"}},
+		"operator": {Conditions: []*activelogv1.FilterCondition{{Column: "activity_type", Operator: "gt", Value: "a"}}},
+		"order":    {Order: map[string]string{"password_hash": "ASC"}},
+		"limit":    {Limit: maxPageSize + 1},
+	}
+
+	for name, req := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := toQueryOptions(req, activityQueryConfig); err == nil {
+				t.Error("expected validation error, got nil")
+			}
+		})
+	}
+}
+
+func TestToPaginationMeta(t *testing.T) {
+	next := 2
+	meta := toPaginationMeta(query.PaginationMeta{
+		Page:         1,
+		Limit:        10,
+		Count:        10,
+		NextPage:     &next,
+		HasNext:      true,
+		PageCount:    3,
+		TotalRecords: 25,
+	})
+
+	if meta.GetPreviousPage() != 0 {
+		t.Errorf("previous page = %d, want 0", meta.GetPreviousPage())
+	}
+	if meta.GetNextPage() != 2 {
+		t.Errorf("next page = %d, want 2", meta.GetNextPage())
+	}
+	if meta.GetTotalRecords() != 25 {
+		t.Errorf("total records = %d, want 25", meta.GetTotalRecords())
+	}
+}
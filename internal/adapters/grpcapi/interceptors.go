@@ -0,0 +1,101 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/pkg/auth"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryAuthInterceptor is the gRPC counterpart of middleware.AuthMiddleware.
+// It reads "authorization: Bearer <token>" from the call metadata, verifies
+// the JWT on clk and stores the user in the request context for the services.
+func UnaryAuthInterceptor(clk clock.Clock) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "Unauthorized request")
+		}
+
+		values := md.Get("authorization")
+		if len(values) == 0 || values[0] == "" {
+			return nil, status.Error(codes.Unauthenticated, "Unauthorized request")
+		}
+
+		tokenString := strings.TrimPrefix(values[0], "Bearer ")
+
+		claims, err := auth.VerifyToken(tokenString, clk.Now())
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "Unauthorized request")
+		}
+
+		ctx = requestcontext.NewContext(ctx, &requestcontext.User{
+			Id:    claims.UserID,
+			Email: claims.Email,
+		})
+		return handler(ctx, req)
+	}
+}
+
+// UnaryLoggingInterceptor logs each call with its method, status code and duration
+func UnaryLoggingInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+
+	code := status.Code(err)
+	event := log.Info()
+	if code != codes.OK {
+		event = log.Warn().Err(err)
+	}
+	event.
+		Str("method", info.FullMethod).
+		Str("code", code.String()).
+		Dur("duration", time.Since(start)).
+		Msg("gRPC request")
+
+	return resp, err
+}
+
+// userFromContext returns the authenticated user set by UnaryAuthInterceptor
+func userFromContext(ctx context.Context) (*requestcontext.User, error) {
+	user, ok := requestcontext.FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "Unauthorized request")
+	}
+	return user, nil
+}
+
+// toStatus maps application errors onto gRPC status codes, mirroring the HTTP
+// status codes the REST handlers use for the same errors
+func toStatus(err error, msg string) error {
+	var validationErr *appErrors.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return status.Error(codes.InvalidArgument, validationErr.Field+": "+validationErr.Message)
+	case errors.Is(err, appErrors.ErrNotFound):
+		return status.Error(codes.NotFound, "resource not found")
+	case errors.Is(err, appErrors.ErrUnauthorized):
+		return status.Error(codes.PermissionDenied, "you do not own this resource")
+	case errors.Is(err, appErrors.ErrAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, appErrors.ErrInvalidInput):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, msg)
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, msg)
+	}
+
+	log.Error().Err(err).Msg(msg)
+	return status.Error(codes.Internal, msg)
+}
@@ -0,0 +1,30 @@
+package grpcapi
+
+import (
+	activelogv1 "github.com/valentinesamuel/activelog/gen/activelog/v1"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// NewServer builds a gRPC server with the logging and auth interceptors, the
+// latter checking JWT expiry on clk, and registers the ActiveLog services. Server reflection is enabled so the API
+// can be explored with grpcurl:
+//
+//	grpcurl -plaintext localhost:9090 list
+//	grpcurl -plaintext -H "authorization: Bearer $TOKEN" localhost:9090 activelog.v1.StatsService/GetWeeklyStats
+func NewServer(clk clock.Clock, activities *ActivityService, tags *TagService, stats *StatsService) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			UnaryLoggingInterceptor,
+			UnaryAuthInterceptor(clk),
+		),
+	)
+
+	activelogv1.RegisterActivityServiceServer(server, activities)
+	activelogv1.RegisterTagServiceServer(server, tags)
+	activelogv1.RegisterStatsServiceServer(server, stats)
+	reflection.Register(server)
+
+	return server
+}
@@ -0,0 +1,126 @@
+package grpcapi
+
+import (
+	"context"
+
+	activelogv1 "github.com/valentinesamuel/activelog/gen/activelog/v1"
+	"github.com/valentinesamuel/activelog/internal/application/broker"
+	"github.com/valentinesamuel/activelog/internal/application/stats/usecases"
+)
+
+// StatsService implements activelogv1.StatsServiceServer
+type StatsService struct {
+	activelogv1.UnimplementedStatsServiceServer
+
+	broker                   *broker.Broker
+	getWeeklyStatsUC         *usecases.GetWeeklyStatsUseCase
+	getMonthlyStatsUC        *usecases.GetMonthlyStatsUseCase
+	getActivityCountByTypeUC *usecases.GetActivityCountByTypeUseCase
+	getTopTagsUC             *usecases.GetTopTagsUseCase
+}
+
+// StatsServiceDeps holds the use cases StatsService delegates to
+type StatsServiceDeps struct {
+	GetWeeklyStatsUC         *usecases.GetWeeklyStatsUseCase
+	GetMonthlyStatsUC        *usecases.GetMonthlyStatsUseCase
+	GetActivityCountByTypeUC *usecases.GetActivityCountByTypeUseCase
+	GetTopTagsUC             *usecases.GetTopTagsUseCase
+}
+
+func NewStatsService(brokerInstance *broker.Broker, deps StatsServiceDeps) *StatsService {
+	return &StatsService{
+		broker:                   brokerInstance,
+		getWeeklyStatsUC:         deps.GetWeeklyStatsUC,
+		getMonthlyStatsUC:        deps.GetMonthlyStatsUC,
+		getActivityCountByTypeUC: deps.GetActivityCountByTypeUC,
+		getTopTagsUC:             deps.GetTopTagsUC,
+	}
+}
+
+func (s *StatsService) GetWeeklyStats(ctx context.Context, _ *activelogv1.GetWeeklyStatsRequest) (*activelogv1.WeeklyStats, error) {
+	user, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := broker.RunUseCase(s.broker, ctx, s.getWeeklyStatsUC, usecases.GetWeeklyStatsInput{UserID: user.Id})
+	if err != nil {
+		return nil, toStatus(err, "failed to fetch weekly stats")
+	}
+
+	stats := result.WeeklyStats
+	return &activelogv1.WeeklyStats{
+		TotalActivities:      int32(stats.TotalActivities),
+		TotalDurationMinutes: int32(stats.TotalDuration),
+		TotalDistanceKm:      stats.TotalDistance,
+		AvgDurationMinutes:   stats.AvgDuration,
+	}, nil
+}
+
+func (s *StatsService) GetMonthlyStats(ctx context.Context, _ *activelogv1.GetMonthlyStatsRequest) (*activelogv1.MonthlyStats, error) {
+	user, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := broker.RunUseCase(s.broker, ctx, s.getMonthlyStatsUC, usecases.GetMonthlyStatsInput{UserID: user.Id})
+	if err != nil {
+		return nil, toStatus(err, "failed to fetch monthly stats")
+	}
+
+	var counts map[string]int
+	if result.MonthlyStats != nil {
+		counts = *result.MonthlyStats
+	}
+	return &activelogv1.MonthlyStats{Counts: toInt32Map(counts)}, nil
+}
+
+func (s *StatsService) GetActivityCountByType(ctx context.Context, _ *activelogv1.GetActivityCountByTypeRequest) (*activelogv1.ActivityCountByType, error) {
+	user, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := broker.RunUseCase(s.broker, ctx, s.getActivityCountByTypeUC, usecases.GetActivityCountByTypeInput{UserID: user.Id})
+	if err != nil {
+		return nil, toStatus(err, "failed to fetch activity counts")
+	}
+
+	return &activelogv1.ActivityCountByType{
+		Counts:     toInt32Map(result.CountByType),
+		TotalCount: int32(result.TotalCount),
+	}, nil
+}
+
+func (s *StatsService) GetTopTags(ctx context.Context, req *activelogv1.GetTopTagsRequest) (*activelogv1.TopTags, error) {
+	user, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := broker.RunUseCase(s.broker, ctx, s.getTopTagsUC, usecases.GetTopTagsInput{
+		UserID: user.Id,
+		Limit:  int(req.GetLimit()),
+	})
+	if err != nil {
+		return nil, toStatus(err, "failed to fetch top tags")
+	}
+
+	resp := &activelogv1.TopTags{
+		Tags:  make([]*activelogv1.TagUsage, 0, len(result.Tags)),
+		Limit: int32(result.Limit),
+		Count: int32(result.Count),
+	}
+	for _, t := range result.Tags {
+		resp.Tags = append(resp.Tags, &activelogv1.TagUsage{TagName: t.TagName, Count: int32(t.Count)})
+	}
+	return resp, nil
+}
+
+func toInt32Map(m map[string]int) map[string]int32 {
+	out := make(map[string]int32, len(m))
+	for k, v := range m {
+		out[k] = int32(v)
+	}
+	return out
+}
@@ -0,0 +1,59 @@
+package grpcapi
+
+import (
+	"context"
+
+	activelogv1 "github.com/valentinesamuel/activelog/gen/activelog/v1"
+	"github.com/valentinesamuel/activelog/internal/application/broker"
+	"github.com/valentinesamuel/activelog/internal/application/tag/usecases"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TagService implements activelogv1.TagServiceServer
+type TagService struct {
+	activelogv1.UnimplementedTagServiceServer
+
+	broker     *broker.Broker
+	listTagsUC *usecases.ListTagsUseCase
+}
+
+func NewTagService(brokerInstance *broker.Broker, listTagsUC *usecases.ListTagsUseCase) *TagService {
+	return &TagService{
+		broker:     brokerInstance,
+		listTagsUC: listTagsUC,
+	}
+}
+
+func (s *TagService) ListTags(ctx context.Context, req *activelogv1.ListTagsRequest) (*activelogv1.ListTagsResponse, error) {
+	user, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := toQueryOptions(req.GetFilter(), tagQueryConfig)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Soft-deleted tags are never listed
+	opts.Filter["deleted_at"] = nil
+
+	result, err := broker.RunUseCase(s.broker, ctx, s.listTagsUC, usecases.ListTagsInput{
+		UserID:       user.Id,
+		QueryOptions: opts,
+	})
+	if err != nil {
+		return nil, toStatus(err, "failed to fetch tags")
+	}
+
+	tags := result.Result.Data
+	resp := &activelogv1.ListTagsResponse{
+		Tags: make([]*activelogv1.Tag, 0, len(tags)),
+		Meta: toPaginationMeta(result.Result.Meta),
+	}
+	for _, t := range tags {
+		resp.Tags = append(resp.Tags, toProtoTag(t))
+	}
+	return resp, nil
+}
@@ -0,0 +1,7 @@
+package di
+
+// Container registration keys for identity providers
+const (
+	// IdentityProvidersKey is the key for the registry of configured providers
+	IdentityProvidersKey = "IdentityProviders"
+)
@@ -0,0 +1,40 @@
+package di
+
+import (
+	"log"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/identity/apple"
+	"github.com/valentinesamuel/activelog/internal/adapters/identity/github"
+	"github.com/valentinesamuel/activelog/internal/adapters/identity/google"
+	"github.com/valentinesamuel/activelog/internal/adapters/identity/types"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	"github.com/valentinesamuel/activelog/internal/platform/container"
+)
+
+// RegisterIdentity registers the identity provider registry in the DI
+// container. Providers without a client ID are left out; the registry may
+// be empty.
+func RegisterIdentity(c *container.Container) {
+	c.Register(IdentityProvidersKey, func(c *container.Container) (interface{}, error) {
+		var providers []types.Provider
+		if config.OAuth.Google.ClientID != "" {
+			providers = append(providers, google.New())
+		}
+		if config.OAuth.GitHub.ClientID != "" {
+			providers = append(providers, github.New())
+		}
+		if config.OAuth.Apple.ClientID != "" {
+			p, err := apple.New()
+			if err != nil {
+				return nil, err
+			}
+			providers = append(providers, p)
+		}
+
+		registry := types.NewRegistry(providers...)
+		if names := registry.Names(); len(names) > 0 {
+			log.Printf("Identity providers initialized: %v", names)
+		}
+		return registry, nil
+	})
+}
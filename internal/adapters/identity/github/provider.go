@@ -0,0 +1,104 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/identity/oauth2"
+	"github.com/valentinesamuel/activelog/internal/adapters/identity/types"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	"github.com/valentinesamuel/activelog/pkg/httpclient"
+)
+
+// GitHub's OAuth app endpoints
+var endpoint = oauth2.Endpoint{
+	AuthURL:  "https://github.com/login/oauth/authorize",
+	TokenURL: "https://github.com/login/oauth/access_token",
+}
+
+const apiURL = "https://api.github.com"
+
+// Provider signs users in with their GitHub account
+type Provider struct {
+	client *oauth2.Client
+	secret string
+	apiURL string
+}
+
+// New creates a Provider from the global OAuth config.
+func New() *Provider {
+	cfg := config.OAuth
+	return &Provider{
+		client: &oauth2.Client{
+			ClientID:    cfg.GitHub.ClientID,
+			Endpoint:    endpoint,
+			RedirectURL: cfg.CallbackURL(types.ProviderGitHub),
+			Scopes:      []string{"read:user", "user:email"},
+			HTTP:        httpclient.New(httpclient.Config{Name: "github", Timeout: cfg.Timeout}),
+		},
+		secret: cfg.GitHub.ClientSecret,
+		apiURL: apiURL,
+	}
+}
+
+// Name returns "github"
+func (p *Provider) Name() string {
+	return types.ProviderGitHub
+}
+
+// AuthCodeURL returns GitHub's consent page URL
+func (p *Provider) AuthCodeURL(state string) string {
+	return p.client.AuthCodeURL(state)
+}
+
+type user struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+	Name  string `json:"name"`
+}
+
+type email struct {
+	Email    string `json:"email"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
+
+// Exchange redeems code and reads the user and their primary email. The
+// profile email is public and unverified, so /user/emails is used instead.
+func (p *Provider) Exchange(ctx context.Context, code string) (*types.Identity, error) {
+	token, err := p.client.Exchange(ctx, code, p.secret)
+	if err != nil {
+		return nil, fmt.Errorf("github: %w", err)
+	}
+
+	var u user
+	if err := p.client.GetJSON(ctx, p.apiURL+"/user", token.AccessToken, &u); err != nil {
+		return nil, fmt.Errorf("github: %w", err)
+	}
+	if u.ID == 0 {
+		return nil, fmt.Errorf("github: %w: no user ID", types.ErrExchangeFailed)
+	}
+
+	var emails []email
+	if err := p.client.GetJSON(ctx, p.apiURL+"/user/emails", token.AccessToken, &emails); err != nil {
+		return nil, fmt.Errorf("github: %w", err)
+	}
+
+	identity := &types.Identity{
+		Provider: types.ProviderGitHub,
+		Subject:  strconv.FormatInt(u.ID, 10),
+		Name:     u.Name,
+	}
+	if identity.Name == "" {
+		identity.Name = u.Login
+	}
+	for _, e := range emails {
+		if e.Primary {
+			identity.Email = e.Email
+			identity.EmailVerified = e.Verified
+			break
+		}
+	}
+	return identity, nil
+}
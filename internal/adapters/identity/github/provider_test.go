@@ -0,0 +1,79 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/identity/oauth2"
+	"github.com/valentinesamuel/activelog/internal/adapters/identity/types"
+)
+
+func newTestProvider(server *httptest.Server) *Provider {
+	return &Provider{
+		client: &oauth2.Client{
+			ClientID:    "client-id",
+			Endpoint:    oauth2.Endpoint{AuthURL: server.URL + "/authorize", TokenURL: server.URL + "/token"},
+			RedirectURL: "http://localhost/api/v1/auth/github/callback",
+			HTTP:        server.Client(),
+		},
+		secret: "client-secret",
+		apiURL: server.URL,
+	}
+}
+
+func TestExchange(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		if r.PostForm.Get("code") != "good-code" || r.PostForm.Get("client_secret") != "client-secret" {
+			// GitHub reports errors with a 200
+			w.Write([]byte(`{"error":"bad_verification_code"}`))
+			return
+		}
+		w.Write([]byte(`{"access_token":"gho_token","token_type":"bearer"}`))
+	})
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer gho_token", r.Header.Get("Authorization"))
+		w.Write([]byte(`{"id":42,"login":"octocat","name":"","email":"public@example.com"}`))
+	})
+	mux.HandleFunc("/user/emails", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+			{"email":"old@example.com","primary":false,"verified":true},
+			{"email":"octo@example.com","primary":true,"verified":true}
+		]`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	provider := newTestProvider(server)
+
+	identity, err := provider.Exchange(context.Background(), "good-code")
+	require.NoError(t, err)
+	assert.Equal(t, &types.Identity{
+		Provider:      types.ProviderGitHub,
+		Subject:       "42",
+		Email:         "octo@example.com",
+		EmailVerified: true,
+		Name:          "octocat",
+	}, identity)
+
+	_, err = provider.Exchange(context.Background(), "bad-code")
+	assert.ErrorIs(t, err, types.ErrExchangeFailed)
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	provider := newTestProvider(server)
+	provider.client.Scopes = []string{"read:user", "user:email"}
+
+	assert.Equal(t,
+		server.URL+"/authorize?client_id=client-id&redirect_uri=http%3A%2F%2Flocalhost%2Fapi%2Fv1%2Fauth%2Fgithub%2Fcallback&response_type=code&scope=read%3Auser+user%3Aemail&state=xyz",
+		provider.AuthCodeURL("xyz"))
+}
@@ -0,0 +1,84 @@
+package google
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/identity/oauth2"
+	"github.com/valentinesamuel/activelog/internal/adapters/identity/types"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	"github.com/valentinesamuel/activelog/pkg/httpclient"
+)
+
+// Google's OpenID Connect endpoints
+var endpoint = oauth2.Endpoint{
+	AuthURL:  "https://accounts.google.com/o/oauth2/v2/auth",
+	TokenURL: "https://oauth2.googleapis.com/token",
+}
+
+const userInfoURL = "https://openidconnect.googleapis.com/v1/userinfo"
+
+// Provider signs users in with their Google account
+type Provider struct {
+	client      *oauth2.Client
+	secret      string
+	userInfoURL string
+}
+
+// New creates a Provider from the global OAuth config.
+func New() *Provider {
+	cfg := config.OAuth
+	return &Provider{
+		client: &oauth2.Client{
+			ClientID:    cfg.Google.ClientID,
+			Endpoint:    endpoint,
+			RedirectURL: cfg.CallbackURL(types.ProviderGoogle),
+			Scopes:      []string{"openid", "email", "profile"},
+			HTTP:        httpclient.New(httpclient.Config{Name: "google", Timeout: cfg.Timeout}),
+		},
+		secret:      cfg.Google.ClientSecret,
+		userInfoURL: userInfoURL,
+	}
+}
+
+// Name returns "google"
+func (p *Provider) Name() string {
+	return types.ProviderGoogle
+}
+
+// AuthCodeURL returns Google's consent page URL
+func (p *Provider) AuthCodeURL(state string) string {
+	return p.client.AuthCodeURL(state)
+}
+
+// userInfo is the part of the OpenID Connect userinfo response we read
+type userInfo struct {
+	Sub           string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+}
+
+// Exchange redeems code and reads the user from the userinfo endpoint
+func (p *Provider) Exchange(ctx context.Context, code string) (*types.Identity, error) {
+	token, err := p.client.Exchange(ctx, code, p.secret)
+	if err != nil {
+		return nil, fmt.Errorf("google: %w", err)
+	}
+
+	var info userInfo
+	if err := p.client.GetJSON(ctx, p.userInfoURL, token.AccessToken, &info); err != nil {
+		return nil, fmt.Errorf("google: %w", err)
+	}
+	if info.Sub == "" {
+		return nil, fmt.Errorf("google: %w: no subject", types.ErrExchangeFailed)
+	}
+
+	return &types.Identity{
+		Provider:      types.ProviderGoogle,
+		Subject:       info.Sub,
+		Email:         info.Email,
+		EmailVerified: info.EmailVerified,
+		Name:          info.Name,
+	}, nil
+}
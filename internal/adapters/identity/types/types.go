@@ -0,0 +1,75 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+// Provider names, used in URLs and stored in user_identities.provider
+const (
+	ProviderGoogle = "google"
+	ProviderGitHub = "github"
+	ProviderApple  = "apple"
+)
+
+// ErrUnknownProvider is returned for providers that aren't configured
+var ErrUnknownProvider = errors.New("identity: unknown provider")
+
+// ErrExchangeFailed is returned when the provider rejects the authorization
+// code or returns an unusable identity
+var ErrExchangeFailed = errors.New("identity: code exchange failed")
+
+// Identity is a user as known to an identity provider
+type Identity struct {
+	Provider string
+	// Subject is the provider's stable user ID; emails can change
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Provider is the interface all identity providers must implement.
+type Provider interface {
+	// Name returns the provider name used in URLs
+	Name() string
+	// AuthCodeURL returns the provider's consent page URL for an
+	// authorization code flow carrying state
+	AuthCodeURL(state string) string
+	// Exchange redeems an authorization code for the user's identity
+	Exchange(ctx context.Context, code string) (*Identity, error)
+}
+
+// Registry holds the configured identity providers by name
+type Registry struct {
+	providers map[string]Provider
+}
+
+// NewRegistry creates a Registry of providers
+func NewRegistry(providers ...Provider) *Registry {
+	r := &Registry{providers: make(map[string]Provider, len(providers))}
+	for _, p := range providers {
+		r.providers[p.Name()] = p
+	}
+	return r
+}
+
+// Get returns the provider called name
+func (r *Registry) Get(name string) (Provider, error) {
+	p, ok := r.providers[name]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+	return p, nil
+}
+
+// Names returns the names of the configured providers, sorted
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
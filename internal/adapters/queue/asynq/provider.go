@@ -3,7 +3,9 @@ package asynq
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hibiken/asynq"
 	"github.com/valentinesamuel/activelog/internal/platform/config"
@@ -15,7 +17,7 @@ type Provider struct {
 	client *asynq.Client
 }
 
-// New creates an asynq Provider. The client is NOT closed here.
+// New creates an asynq Provider. Close releases the client's Redis connections.
 func New() (*Provider, error) {
 	address := config.GetEnv("REDIS_ADDRESS", "localhost:6379")
 	client := asynq.NewClient(asynq.RedisClientOpt{Addr: address})
@@ -23,7 +25,12 @@ func New() (*Provider, error) {
 }
 
 // Enqueue marshals the payload and submits a task to the given queue.
+// The message ID doubles as the asynq task ID, so enqueueing a message that is
+// still queued is a no-op.
 func (p *Provider) Enqueue(ctx context.Context, queue types.QueueName, payload types.JobPayload) (string, error) {
+	payload.EnsureMessageID()
+	payload.EnsureEnqueuedAt()
+
 	data, err := json.Marshal(payload)
 	if err != nil {
 		return "", fmt.Errorf("asynq: marshal payload: %w", err)
@@ -33,7 +40,11 @@ func (p *Provider) Enqueue(ctx context.Context, queue types.QueueName, payload t
 	info, err := p.client.EnqueueContext(ctx, task,
 		asynq.Queue(string(queue)),
 		asynq.MaxRetry(3),
+		asynq.TaskID(payload.MessageID),
 	)
+	if errors.Is(err, asynq.ErrTaskIDConflict) {
+		return payload.MessageID, nil
+	}
 	if err != nil {
 		return "", fmt.Errorf("asynq: enqueue task: %w", err)
 	}
@@ -41,17 +52,76 @@ func (p *Provider) Enqueue(ctx context.Context, queue types.QueueName, payload t
 	return info.ID, nil
 }
 
+// Ping checks Redis can be reached
+func (p *Provider) Ping(_ context.Context) error {
+	if err := p.client.Ping(); err != nil {
+		return fmt.Errorf("asynq: ping: %w", err)
+	}
+	return nil
+}
+
+// Close closes the asynq client
+func (p *Provider) Close() error {
+	return p.client.Close()
+}
+
 // NewWorkerServer creates an asynq server for processing jobs.
-func NewWorkerServer(redisAddr string, concurrency int) *asynq.Server {
+// Concurrency is the sum of the per-queue concurrency; each queue's Priority
+// becomes its asynq weight, or its rank when strictPriority is set. A task
+// failing with types.ErrThrottled is retried after types.ThrottleDelay
+// without using up one of its retries.
+func NewWorkerServer(redisAddr string, queues []types.QueueSettings, strictPriority bool) *asynq.Server {
+	weights := make(map[string]int, len(queues))
+	concurrency := 0
+	for _, q := range queues {
+		weights[string(q.Name)] = max(q.Priority, 1)
+		concurrency += q.Concurrency
+	}
+
 	return asynq.NewServer(
 		asynq.RedisClientOpt{Addr: redisAddr},
 		asynq.Config{
-			Queues: map[string]int{
-				"critical": 6,
-				"default":  3,
-				"low":      1,
+			Queues:         weights,
+			StrictPriority: strictPriority,
+			Concurrency:    max(concurrency, 1),
+			IsFailure: func(err error) bool {
+				return !errors.Is(err, types.ErrThrottled)
+			},
+			RetryDelayFunc: func(n int, err error, task *asynq.Task) time.Duration {
+				if errors.Is(err, types.ErrThrottled) {
+					return types.ThrottleDelay
+				}
+				return asynq.DefaultRetryDelayFunc(n, err, task)
 			},
-			Concurrency: concurrency,
 		},
 	)
 }
+
+// NewScheduler creates an asynq scheduler that enqueues tasks on their cron
+// schedule (UTC). Each task goes to its event's queue and holds a uniqueness
+// lock for task.Unique, so a slow run is never overlapped by the next one even
+// when several schedulers are running.
+func NewScheduler(redisAddr string, tasks []types.PeriodicTask) (*asynq.Scheduler, error) {
+	scheduler := asynq.NewScheduler(
+		asynq.RedisClientOpt{Addr: redisAddr},
+		&asynq.SchedulerOpts{Location: time.UTC},
+	)
+
+	for _, task := range tasks {
+		data, err := json.Marshal(types.JobPayload{Event: task.Event})
+		if err != nil {
+			return nil, fmt.Errorf("asynq: marshal periodic task %q: %w", task.Event, err)
+		}
+
+		opts := []asynq.Option{asynq.Queue(string(types.QueueFor(task.Event)))}
+		if task.Unique > 0 {
+			opts = append(opts, asynq.Unique(task.Unique))
+		}
+
+		if _, err := scheduler.Register(task.Spec, asynq.NewTask(string(task.Event), data), opts...); err != nil {
+			return nil, fmt.Errorf("asynq: register periodic task %q: %w", task.Event, err)
+		}
+	}
+
+	return scheduler, nil
+}
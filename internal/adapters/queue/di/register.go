@@ -1,19 +1,30 @@
 package di
 
 import (
+	"context"
+	"io"
 	"log"
+	"path/filepath"
 
 	"github.com/valentinesamuel/activelog/internal/platform/config"
 	"github.com/valentinesamuel/activelog/internal/platform/container"
 	"github.com/valentinesamuel/activelog/internal/adapters/queue/asynq"
 	"github.com/valentinesamuel/activelog/internal/adapters/queue/memory"
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/nats"
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/sqs"
 	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
 )
 
 // RegisterQueue registers the queue provider in the DI container.
 func RegisterQueue(c *container.Container) {
 	c.Register(QueueProviderKey, func(c *container.Container) (interface{}, error) {
-		return createProvider(), nil
+		provider := createProvider()
+		if closer, ok := provider.(io.Closer); ok {
+			c.OnStop(QueueProviderKey, func(context.Context) error {
+				return closer.Close()
+			})
+		}
+		return provider, nil
 	})
 }
 
@@ -29,7 +40,33 @@ func createProvider() types.QueueProvider {
 		log.Printf("Queue provider initialized: asynq")
 		return provider
 
+	case "nats":
+		provider, err := nats.New()
+		if err != nil {
+			log.Printf("Warning: Failed to initialize NATS queue provider: %v. Queue operations will fail.", err)
+			return nil
+		}
+		log.Printf("Queue provider initialized: nats (jetstream)")
+		return provider
+
+	case "sqs":
+		provider, err := sqs.New()
+		if err != nil {
+			log.Printf("Warning: Failed to initialize SQS queue provider: %v. Queue operations will fail.", err)
+			return nil
+		}
+		log.Printf("Queue provider initialized: sqs (fifo=%t)", config.Queue.SQS.FIFO)
+		return provider
+
 	default:
+		if dir := config.Queue.MemoryJournalDir; dir != "" {
+			provider, err := memory.Open(100, filepath.Join(dir, "api.journal"))
+			if err == nil {
+				log.Printf("Queue provider initialized: memory (buffer=100, journal=%s)", dir)
+				return provider
+			}
+			log.Printf("Warning: Failed to open memory queue journal: %v. Jobs will not survive restarts.", err)
+		}
 		log.Printf("Queue provider initialized: memory (buffer=100)")
 		return memory.New(100)
 	}
@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+)
+
+// Journal operations
+const (
+	opEnqueue = "enqueue"
+	opDone    = "done"
+)
+
+// journalRecord is a line of the journal: a job was enqueued, or the job
+// with Seq finished
+type journalRecord struct {
+	Op    string            `json:"op"`
+	Seq   uint64            `json:"seq"`
+	Queue types.QueueName   `json:"queue,omitempty"`
+	Job   *types.JobPayload `json:"job,omitempty"`
+	At    time.Time         `json:"at,omitzero"`
+}
+
+// journal is an append-only file of JSON lines recording the jobs enqueued
+// and finished. Lines are written without fsync: the journal survives the
+// process stopping or crashing, not the machine.
+type journal struct {
+	file *os.File
+	enc  *json.Encoder
+}
+
+// openJournal reads the journal at path and returns the jobs it holds as
+// unfinished, in enqueue order. The journal is then rewritten with only
+// those jobs, so it only grows with the jobs of one run.
+func openJournal(path string) (*journal, []journalRecord, error) {
+	unfinished, err := readJournal(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, nil, fmt.Errorf("memory: create journal directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".journal-*")
+	if err != nil {
+		return nil, nil, fmt.Errorf("memory: compact journal: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	enc := json.NewEncoder(tmp)
+	for i := range unfinished {
+		if err := enc.Encode(&unfinished[i]); err != nil {
+			tmp.Close()
+			return nil, nil, fmt.Errorf("memory: compact journal: %w", err)
+		}
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, nil, fmt.Errorf("memory: compact journal: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, nil, fmt.Errorf("memory: compact journal: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, nil, fmt.Errorf("memory: open journal: %w", err)
+	}
+	return &journal{file: file, enc: json.NewEncoder(file)}, unfinished, nil
+}
+
+// readJournal returns the enqueue records of the journal at path that have
+// no done record. A missing journal is empty, and a torn last line (the
+// process died while writing it) is skipped.
+func readJournal(path string) ([]journalRecord, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("memory: read journal: %w", err)
+	}
+
+	unfinished := make(map[uint64]journalRecord)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for line := 1; scanner.Scan(); line++ {
+		var rec journalRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			log.Printf("memory: skipping unreadable journal line %d: %v", line, err)
+			continue
+		}
+		switch rec.Op {
+		case opEnqueue:
+			if rec.Job != nil {
+				unfinished[rec.Seq] = rec
+			}
+		case opDone:
+			delete(unfinished, rec.Seq)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("memory: read journal: %w", err)
+	}
+
+	records := make([]journalRecord, 0, len(unfinished))
+	for _, rec := range unfinished {
+		records = append(records, rec)
+	}
+	slices.SortFunc(records, func(a, b journalRecord) int {
+		return cmp.Compare(a.Seq, b.Seq)
+	})
+	return records, nil
+}
+
+// enqueued appends the enqueue record of a job
+func (j *journal) enqueued(seq uint64, queue types.QueueName, payload types.JobPayload, at time.Time) error {
+	return j.enc.Encode(journalRecord{Op: opEnqueue, Seq: seq, Queue: queue, Job: &payload, At: at})
+}
+
+// done appends the record of a finished job
+func (j *journal) done(seq uint64) error {
+	return j.enc.Encode(journalRecord{Op: opDone, Seq: seq})
+}
+
+func (j *journal) close() error {
+	return j.file.Close()
+}
@@ -1,64 +1,315 @@
 package memory
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
 )
 
 // Provider is an in-process queue backed by buffered channels.
-// Suitable for tests and local development (no Redis required).
+// Suitable for tests and local development (no Redis required). A provider
+// made by Open journals its jobs to a file, so unfinished jobs survive a
+// restart.
 type Provider struct {
 	mu      sync.Mutex
-	jobs    map[types.QueueName]chan types.JobPayload
+	jobs    map[types.QueueName]chan entry
 	bufSize int
+
+	// seq numbers the jobs in enqueue order
+	seq uint64
+	// unfinished holds the jobs that are pending or running, by seq
+	unfinished map[uint64]*QueuedJob
+	counters   map[types.QueueName]*QueueStats
+
+	journal *journal // nil without persistence
+}
+
+// entry is a job in a queue's channel
+type entry struct {
+	seq     uint64
+	payload types.JobPayload
+}
+
+// Job states, as listed by Jobs
+const (
+	JobPending = "pending"
+	JobRunning = "running"
+)
+
+// QueuedJob is a job that is pending or running
+type QueuedJob struct {
+	Seq        uint64          `json:"seq"`
+	Queue      types.QueueName `json:"queue"`
+	Event      types.EventType `json:"event"`
+	MessageID  string          `json:"messageId"`
+	JobID      string          `json:"jobId,omitempty"`
+	Data       json.RawMessage `json:"data,omitempty" swaggertype:"object"`
+	State      string          `json:"state" enums:"pending,running"`
+	EnqueuedAt time.Time       `json:"enqueuedAt"`
+	StartedAt  *time.Time      `json:"startedAt,omitempty"`
+}
+
+// QueueStats are the depth and counters of a queue
+type QueueStats struct {
+	Queue     types.QueueName `json:"queue"`
+	Pending   int             `json:"pending"`
+	Running   int             `json:"running"`
+	Processed int64           `json:"processed"` // jobs that succeeded
+	Failed    int64           `json:"failed"`
 }
 
 // New creates a Provider with a per-queue buffer of bufferSize.
 func New(bufferSize int) *Provider {
 	return &Provider{
-		jobs:    make(map[types.QueueName]chan types.JobPayload),
-		bufSize: bufferSize,
+		jobs:       make(map[types.QueueName]chan entry),
+		bufSize:    bufferSize,
+		unfinished: make(map[uint64]*QueuedJob),
+		counters:   make(map[types.QueueName]*QueueStats),
 	}
 }
 
+// Open creates a Provider that journals its jobs to the file at path. The
+// jobs the journal holds as unfinished, those pending or running when the
+// last process stopped, are enqueued again first; handlers skip the ones that
+// did complete (see jobs.HandlerFactory.UseMessageStore). Close closes the
+// journal.
+func Open(bufferSize int, path string) (*Provider, error) {
+	p := New(bufferSize)
+	j, unfinished, err := openJournal(path)
+	if err != nil {
+		return nil, err
+	}
+	p.journal = j
+
+	// Replayed jobs fit however many there are
+	depth := make(map[types.QueueName]int)
+	for _, rec := range unfinished {
+		depth[rec.Queue]++
+	}
+	for queue, n := range depth {
+		p.jobs[queue] = make(chan entry, max(bufferSize, n))
+	}
+	for _, rec := range unfinished {
+		p.seq = max(p.seq, rec.Seq)
+		p.track(rec.Seq, rec.Queue, *rec.Job, rec.At)
+		p.jobs[rec.Queue] <- entry{seq: rec.Seq, payload: *rec.Job}
+	}
+	if len(unfinished) > 0 {
+		log.Printf("memory: replayed %d unfinished jobs from %s", len(unfinished), path)
+	}
+	return p, nil
+}
+
 // Enqueue sends the payload to the queue's channel non-blocking.
 func (p *Provider) Enqueue(_ context.Context, queue types.QueueName, payload types.JobPayload) (string, error) {
-	ch := p.channel(queue)
+	payload.EnsureMessageID()
+	payload.EnsureEnqueuedAt()
+
+	// Workers wait on the lock to start the job, so it is tracked first
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.seq++
 	select {
-	case ch <- payload:
-		return fmt.Sprintf("mem-%s-%d", queue, len(ch)), nil
+	case p.channelLocked(queue) <- entry{seq: p.seq, payload: payload}:
 	default:
 		return "", fmt.Errorf("memory: queue %q is full (buffer=%d)", queue, p.bufSize)
 	}
+
+	now := time.Now()
+	p.track(p.seq, queue, payload, now)
+	if p.journal != nil {
+		if err := p.journal.enqueued(p.seq, queue, payload, now); err != nil {
+			log.Printf("memory: journal enqueue of %s: %v", payload.MessageID, err)
+		}
+	}
+	return payload.MessageID, nil
 }
 
-// StartWorking drains the queue in a background goroutine until ctx is cancelled.
-func (p *Provider) StartWorking(ctx context.Context, queue types.QueueName, handler func(context.Context, types.JobPayload) error) {
-	ch := p.channel(queue)
-	go func() {
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case job := <-ch:
-				if err := handler(ctx, job); err != nil {
-					log.Printf("memory: handler error for event %q: %v", job.Event, err)
+// StartWorkers runs a shared pool of workers over queues until ctx is cancelled.
+// It mirrors the asynq worker: the pool size is the sum of the per-queue
+// concurrency, and each worker picks its next queue by priority. With
+// strictPriority a lower queue is only served while every higher one is empty;
+// otherwise queues are tried in a random order weighted by Priority.
+//
+// A job the handler turns away with types.ErrThrottled is sent back to its
+// queue after types.ThrottleDelay.
+func (p *Provider) StartWorkers(ctx context.Context, queues []types.QueueSettings, strictPriority bool, handler func(context.Context, types.JobPayload) error) {
+	pending := make(map[types.QueueName]chan entry, len(queues))
+	for _, q := range queues {
+		pending[q.Name] = p.channel(q.Name)
+	}
+
+	concurrency := 0
+	for _, q := range queues {
+		concurrency += q.Concurrency
+	}
+
+	for i := 0; i < max(concurrency, 1); i++ {
+		go func() {
+			for {
+				job, ok := types.NextJob(ctx, queues, pending, strictPriority)
+				if !ok {
+					return
 				}
+				p.started(job.seq)
+				err := handler(ctx, job.payload)
+				if errors.Is(err, types.ErrThrottled) {
+					p.requeue(ctx, job)
+					continue
+				}
+				if err != nil {
+					log.Printf("memory: handler error for event %q: %v", job.payload.Event, err)
+				}
+				p.finished(job.seq, err)
 			}
+		}()
+	}
+}
+
+// Stats returns the depth and counters of every queue in use, by name
+func (p *Provider) Stats() []QueueStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := make([]QueueStats, 0, len(p.counters))
+	for _, counters := range p.counters {
+		stats = append(stats, *counters)
+	}
+	slices.SortFunc(stats, func(a, b QueueStats) int {
+		return cmp.Compare(a.Queue, b.Queue)
+	})
+	return stats
+}
+
+// Jobs returns the pending and running jobs in enqueue order
+func (p *Provider) Jobs() []QueuedJob {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	jobs := make([]QueuedJob, 0, len(p.unfinished))
+	for _, job := range p.unfinished {
+		jobs = append(jobs, *job)
+	}
+	slices.SortFunc(jobs, func(a, b QueuedJob) int {
+		return cmp.Compare(a.Seq, b.Seq)
+	})
+	return jobs
+}
+
+// Close closes the journal, if any. Jobs still pending or running are
+// replayed by the next Open.
+func (p *Provider) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.journal == nil {
+		return nil
+	}
+	err := p.journal.close()
+	p.journal = nil
+	return err
+}
+
+// track records an enqueued job as pending. p.mu must be held (or p not yet
+// shared).
+func (p *Provider) track(seq uint64, queue types.QueueName, payload types.JobPayload, at time.Time) {
+	p.unfinished[seq] = &QueuedJob{
+		Seq:        seq,
+		Queue:      queue,
+		Event:      payload.Event,
+		MessageID:  payload.MessageID,
+		JobID:      payload.JobID,
+		Data:       payload.Data,
+		State:      JobPending,
+		EnqueuedAt: at,
+	}
+	p.countersLocked(queue).Pending++
+}
+
+// started moves a job from pending to running
+func (p *Provider) started(seq uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	job := p.unfinished[seq]
+	now := time.Now()
+	job.State, job.StartedAt = JobRunning, &now
+	counters := p.countersLocked(job.Queue)
+	counters.Pending--
+	counters.Running++
+}
+
+// requeue moves a throttled job back to pending and sends it to its queue
+// after types.ThrottleDelay, unless ctx is cancelled first
+func (p *Provider) requeue(ctx context.Context, e entry) {
+	p.mu.Lock()
+	job := p.unfinished[e.seq]
+	job.State, job.StartedAt = JobPending, nil
+	counters := p.countersLocked(job.Queue)
+	counters.Running--
+	counters.Pending++
+	ch := p.channelLocked(job.Queue)
+	p.mu.Unlock()
+
+	time.AfterFunc(types.ThrottleDelay, func() {
+		select {
+		case ch <- e:
+		case <-ctx.Done():
+		}
+	})
+}
+
+// finished forgets a job that ran, counting it as processed or failed.
+// Failed jobs are not retried, so either way the job is done.
+func (p *Provider) finished(seq uint64, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	job := p.unfinished[seq]
+	delete(p.unfinished, seq)
+	counters := p.countersLocked(job.Queue)
+	counters.Running--
+	if err != nil {
+		counters.Failed++
+	} else {
+		counters.Processed++
+	}
+
+	if p.journal != nil {
+		if err := p.journal.done(seq); err != nil {
+			log.Printf("memory: journal completion of %s: %v", job.MessageID, err)
 		}
-	}()
+	}
 }
 
 // channel returns (or creates) the buffered channel for the given queue.
-func (p *Provider) channel(queue types.QueueName) chan types.JobPayload {
+func (p *Provider) channel(queue types.QueueName) chan entry {
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	return p.channelLocked(queue)
+}
+
+// channelLocked is channel for callers holding p.mu
+func (p *Provider) channelLocked(queue types.QueueName) chan entry {
 	if _, ok := p.jobs[queue]; !ok {
-		p.jobs[queue] = make(chan types.JobPayload, p.bufSize)
+		p.jobs[queue] = make(chan entry, p.bufSize)
+		p.countersLocked(queue)
 	}
 	return p.jobs[queue]
 }
+
+// countersLocked returns (or creates) the counters of queue; p.mu must be held
+func (p *Provider) countersLocked(queue types.QueueName) *QueueStats {
+	if _, ok := p.counters[queue]; !ok {
+		p.counters[queue] = &QueueStats{Queue: queue}
+	}
+	return p.counters[queue]
+}
@@ -0,0 +1,163 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+)
+
+func TestStartWorkers_StrictPriorityDrainsHigherQueuesFirst(t *testing.T) {
+	p := New(10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Enqueue before starting so the single worker sees both queues populated
+	for _, q := range []types.QueueName{types.LowQueue, types.LowQueue, types.CriticalQueue, types.CriticalQueue} {
+		_, err := p.Enqueue(ctx, q, types.JobPayload{Event: types.EventType(q)})
+		require.NoError(t, err)
+	}
+
+	handled := make(chan types.EventType, 4)
+	p.StartWorkers(ctx, []types.QueueSettings{
+		{Name: types.LowQueue, Concurrency: 0, Priority: 1},
+		{Name: types.CriticalQueue, Concurrency: 1, Priority: 6},
+	}, true, func(_ context.Context, job types.JobPayload) error {
+		handled <- job.Event
+		return nil
+	})
+
+	var got []types.EventType
+	for range 4 {
+		select {
+		case e := <-handled:
+			got = append(got, e)
+		case <-time.After(time.Second):
+			t.Fatalf("timed out after %v", got)
+		}
+	}
+
+	assert.Equal(t, []types.EventType{"critical", "critical", "low", "low"}, got)
+}
+
+func TestOpen_ReplaysUnfinishedJobs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.journal")
+	ctx := context.Background()
+
+	p, err := Open(10, path)
+	require.NoError(t, err)
+	for _, id := range []string{"a", "b", "c"} {
+		_, err := p.Enqueue(ctx, types.DefaultQueue, types.JobPayload{Event: types.EventWelcomeEmail, MessageID: id})
+		require.NoError(t, err)
+	}
+	// a finishes, b is running when the process stops
+	for _, finish := range []bool{true, false} {
+		job := <-p.channel(types.DefaultQueue)
+		p.started(job.seq)
+		if finish {
+			p.finished(job.seq, nil)
+		}
+	}
+	require.NoError(t, p.Close())
+
+	// A torn last line, as left by a crash mid-write, is skipped
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"op":"done","se`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	p, err = Open(10, path)
+	require.NoError(t, err)
+	defer p.Close()
+	var ids []string
+	for _, job := range p.Jobs() {
+		ids = append(ids, job.MessageID)
+		assert.Equal(t, JobPending, job.State)
+	}
+	assert.Equal(t, []string{"b", "c"}, ids)
+	assert.Equal(t, []QueueStats{{Queue: types.DefaultQueue, Pending: 2}}, p.Stats())
+
+	_, err = p.Enqueue(ctx, types.DefaultQueue, types.JobPayload{Event: types.EventWelcomeEmail, MessageID: "d"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), p.Jobs()[2].Seq, "numbering continues after the replayed jobs")
+	assert.Equal(t, "b", (<-p.channel(types.DefaultQueue)).payload.MessageID, "replayed jobs run first")
+}
+
+func TestStats(t *testing.T) {
+	p := New(10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	release := make(chan struct{})
+	p.StartWorkers(ctx, []types.QueueSettings{
+		{Name: types.CriticalQueue, Concurrency: 1, Priority: 6},
+		{Name: types.LowQueue, Concurrency: 1, Priority: 1},
+	}, true, func(_ context.Context, job types.JobPayload) error {
+		switch job.MessageID {
+		case "fails":
+			return errors.New("failed")
+		case "blocks":
+			<-release
+		}
+		return nil
+	})
+	for _, id := range []string{"ok", "fails", "blocks"} {
+		_, err := p.Enqueue(ctx, types.CriticalQueue, types.JobPayload{Event: types.EventWelcomeEmail, MessageID: id})
+		require.NoError(t, err)
+	}
+
+	want := []QueueStats{
+		{Queue: types.CriticalQueue, Running: 1, Processed: 1, Failed: 1},
+		{Queue: types.LowQueue},
+	}
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual(want, p.Stats())
+	}, time.Second, 10*time.Millisecond)
+	jobs := p.Jobs()
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "blocks", jobs[0].MessageID)
+	assert.Equal(t, JobRunning, jobs[0].State)
+	assert.NotNil(t, jobs[0].StartedAt)
+
+	close(release)
+	assert.Eventually(t, func() bool {
+		return len(p.Jobs()) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestStartWorkers_RequeuesThrottledJobs(t *testing.T) {
+	p := New(10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var attempts atomic.Int32
+	p.StartWorkers(ctx, []types.QueueSettings{{Name: types.DefaultQueue, Concurrency: 1, Priority: 1}}, false,
+		func(context.Context, types.JobPayload) error {
+			if attempts.Add(1) == 1 {
+				return fmt.Errorf("busy user: %w", types.ErrThrottled)
+			}
+			return nil
+		})
+	_, err := p.Enqueue(ctx, types.DefaultQueue, types.JobPayload{Event: types.EventGenerateExport})
+	require.NoError(t, err)
+
+	// Back to pending until the delay is over
+	assert.Eventually(t, func() bool {
+		jobs := p.Jobs()
+		return attempts.Load() == 1 && len(jobs) == 1 && jobs[0].State == JobPending && jobs[0].StartedAt == nil
+	}, time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return len(p.Jobs()) == 0
+	}, 2*types.ThrottleDelay, 50*time.Millisecond)
+	assert.Equal(t, int32(2), attempts.Load())
+	assert.Equal(t, []QueueStats{{Queue: types.DefaultQueue, Processed: 1}}, p.Stats())
+}
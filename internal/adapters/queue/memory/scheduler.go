@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+)
+
+// StartScheduler enqueues each task on its cron schedule (UTC) until ctx is cancelled.
+// It is the in-process counterpart of the asynq scheduler. Overlap protection
+// comes from the handlers (jobs.ScheduledJob.Guard) since there is no shared lock.
+func (p *Provider) StartScheduler(ctx context.Context, tasks []types.PeriodicTask) error {
+	c := cron.New(cron.WithLocation(time.UTC))
+
+	for _, task := range tasks {
+		event := task.Event
+		_, err := c.AddFunc(task.Spec, func() {
+			payload := types.JobPayload{Event: event}
+			if _, err := p.Enqueue(ctx, types.QueueFor(event), payload); err != nil {
+				log.Printf("memory: scheduler enqueue error for event %q: %v", event, err)
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("memory: register periodic task %q: %w", event, err)
+		}
+	}
+
+	c.Start()
+	go func() {
+		<-ctx.Done()
+		c.Stop()
+	}()
+	return nil
+}
@@ -0,0 +1,304 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+)
+
+const (
+	subjectPrefix = "activelog.jobs."
+	fetchMaxWait  = 5 * time.Second
+
+	// duplicateWindow is how long JetStream remembers message IDs, making
+	// Enqueue idempotent within it
+	duplicateWindow = 2 * time.Minute
+
+	// shutdownTimeout is how long Close waits for running jobs; jobs still
+	// running after it are redelivered once their ack wait passes
+	shutdownTimeout = 10 * time.Second
+)
+
+// Provider is a NATS JetStream queue. Each queue is a subject of one
+// work-queue stream with a durable pull consumer, so a job is removed once a
+// worker acks it and survives worker restarts until then.
+type Provider struct {
+	nc *nats.Conn
+	js nats.JetStreamContext
+
+	stream     string
+	maxDeliver int
+	ackWait    time.Duration
+
+	running sync.WaitGroup
+}
+
+// New connects to config.Queue.NATS.URL and creates the jobs stream if it
+// doesn't exist. Close drains the connection.
+func New() (*Provider, error) {
+	cfg := config.Queue.NATS
+	nc, err := nats.Connect(cfg.URL, nats.Name("activelog-queue"))
+	if err != nil {
+		return nil, fmt.Errorf("nats: connect: %w", err)
+	}
+
+	js, err := nc.JetStream()
+	if err != nil {
+		nc.Close()
+		return nil, fmt.Errorf("nats: jetstream: %w", err)
+	}
+
+	_, err = js.AddStream(&nats.StreamConfig{
+		Name:       cfg.Stream,
+		Subjects:   []string{subjectPrefix + ">"},
+		Retention:  nats.WorkQueuePolicy,
+		Duplicates: duplicateWindow,
+	})
+	if errors.Is(err, nats.ErrStreamNameAlreadyInUse) {
+		// The stream exists, maybe with settings changed by an operator
+		_, err = js.StreamInfo(cfg.Stream)
+	}
+	if err != nil {
+		nc.Close()
+		return nil, fmt.Errorf("nats: create stream %q: %w", cfg.Stream, err)
+	}
+
+	return &Provider{
+		nc:         nc,
+		js:         js,
+		stream:     cfg.Stream,
+		maxDeliver: max(cfg.MaxDeliver, 1),
+		ackWait:    max(cfg.AckWait, time.Second),
+	}, nil
+}
+
+// Enqueue publishes the payload to the queue's subject.
+// The message ID doubles as the JetStream message ID, so enqueueing the same
+// message again within the duplicate window is a no-op.
+func (p *Provider) Enqueue(ctx context.Context, queue types.QueueName, payload types.JobPayload) (string, error) {
+	payload.EnsureMessageID()
+	payload.EnsureEnqueuedAt()
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("nats: marshal payload: %w", err)
+	}
+
+	if _, err := p.js.Publish(subject(queue), data, nats.MsgId(payload.MessageID), nats.Context(ctx)); err != nil {
+		return "", fmt.Errorf("nats: publish job: %w", err)
+	}
+	return payload.MessageID, nil
+}
+
+// StartWorkers runs a shared pool of workers over queues until ctx is cancelled.
+// Like the asynq and memory workers, the pool size is the sum of the per-queue
+// concurrency and each worker picks its next queue by priority.
+//
+// A failed job is nak'ed with a growing delay and redelivered until it has
+// been attempted MaxDeliver times. Running jobs are kept from redelivery by
+// in-progress acks, and a job that can't be decoded is terminated. A
+// throttled job is published again, so it isn't counted as an attempt.
+func (p *Provider) StartWorkers(ctx context.Context, queues []types.QueueSettings, strictPriority bool, handler func(context.Context, types.JobPayload) error) error {
+	pending := make(map[types.QueueName]chan *nats.Msg, len(queues))
+	for _, q := range queues {
+		sub, err := p.subscribe(q.Name)
+		if err != nil {
+			return err
+		}
+		pending[q.Name] = make(chan *nats.Msg)
+		go p.fetch(ctx, sub, pending[q.Name])
+	}
+
+	concurrency := 0
+	for _, q := range queues {
+		concurrency += q.Concurrency
+	}
+
+	for i := 0; i < max(concurrency, 1); i++ {
+		p.running.Add(1)
+		go func() {
+			defer p.running.Done()
+			for {
+				msg, ok := types.NextJob(ctx, queues, pending, strictPriority)
+				if !ok {
+					return
+				}
+				// Jobs that started finish even when the worker is shutting down
+				p.handle(context.WithoutCancel(ctx), msg, handler)
+			}
+		}()
+	}
+	return nil
+}
+
+// subscribe creates or updates the queue's durable consumer and binds a pull
+// subscription to it
+func (p *Provider) subscribe(queue types.QueueName) (*nats.Subscription, error) {
+	consumer := &nats.ConsumerConfig{
+		Durable:       "worker-" + string(queue),
+		FilterSubject: subject(queue),
+		AckPolicy:     nats.AckExplicitPolicy,
+		AckWait:       p.ackWait,
+		MaxDeliver:    p.maxDeliver,
+	}
+	if _, err := p.js.AddConsumer(p.stream, consumer); err != nil {
+		if !errors.Is(err, nats.ErrConsumerNameAlreadyInUse) {
+			return nil, fmt.Errorf("nats: create consumer %q: %w", consumer.Durable, err)
+		}
+		if _, err := p.js.UpdateConsumer(p.stream, consumer); err != nil {
+			return nil, fmt.Errorf("nats: update consumer %q: %w", consumer.Durable, err)
+		}
+	}
+
+	sub, err := p.js.PullSubscribe(consumer.FilterSubject, consumer.Durable, nats.Bind(p.stream, consumer.Durable))
+	if err != nil {
+		return nil, fmt.Errorf("nats: subscribe to %q: %w", consumer.Durable, err)
+	}
+	return sub, nil
+}
+
+// fetch pulls one job at a time from the queue's consumer and hands it to
+// the first free worker, until ctx is cancelled
+func (p *Provider) fetch(ctx context.Context, sub *nats.Subscription, out chan<- *nats.Msg) {
+	defer sub.Unsubscribe()
+
+	for ctx.Err() == nil {
+		fetchCtx, cancel := context.WithTimeout(ctx, fetchMaxWait)
+		msgs, err := sub.Fetch(1, nats.Context(fetchCtx))
+		cancel()
+		if err != nil {
+			if ctx.Err() == nil && !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, nats.ErrTimeout) {
+				log.Printf("nats: fetch from %q: %v", sub.Subject, err)
+				time.Sleep(time.Second)
+			}
+			continue
+		}
+
+		for _, msg := range msgs {
+			p.handOver(ctx, msg, out)
+		}
+	}
+}
+
+// handOver waits for a worker to take msg, keeping it from redelivery while
+// every worker is busy. On shutdown msg is nak'ed for another worker.
+func (p *Provider) handOver(ctx context.Context, msg *nats.Msg, out chan<- *nats.Msg) {
+	ticker := time.NewTicker(p.ackWait / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case out <- msg:
+			return
+		case <-ticker.C:
+			msg.InProgress()
+		case <-ctx.Done():
+			msg.Nak()
+			return
+		}
+	}
+}
+
+// handle runs the handler on msg and acks, naks or terminates it
+func (p *Provider) handle(ctx context.Context, msg *nats.Msg, handler func(context.Context, types.JobPayload) error) {
+	var payload types.JobPayload
+	if err := json.Unmarshal(msg.Data, &payload); err != nil {
+		log.Printf("nats: unmarshal job, dropping: %v", err)
+		msg.Term()
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(p.ackWait / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				msg.InProgress()
+			case <-done:
+				return
+			}
+		}
+	}()
+	err := handler(ctx, payload)
+	close(done)
+
+	if err == nil {
+		msg.Ack()
+		return
+	}
+
+	meta, metaErr := msg.Metadata()
+	if errors.Is(err, types.ErrThrottled) && metaErr == nil {
+		p.requeue(msg, payload, meta.Sequence.Stream)
+		return
+	}
+
+	var attempt uint64 = 1
+	if metaErr == nil {
+		attempt = meta.NumDelivered
+	}
+	if attempt >= uint64(p.maxDeliver) {
+		log.Printf("nats: handler error for event %q, giving up after %d attempts: %v", payload.Event, attempt, err)
+		msg.Term()
+		return
+	}
+	log.Printf("nats: handler error for event %q (attempt %d of %d): %v", payload.Event, attempt, p.maxDeliver, err)
+	msg.NakWithDelay(types.RetryDelay(attempt))
+}
+
+// requeue publishes a throttled job again after types.ThrottleDelay (at most
+// half the ack wait, during which msg is held) and acks msg. The copy is a
+// new message to JetStream: its ID is the message ID and the stream sequence
+// of msg, and its deliveries are counted from zero.
+func (p *Provider) requeue(msg *nats.Msg, payload types.JobPayload, seq uint64) {
+	p.running.Add(1)
+	time.AfterFunc(min(types.ThrottleDelay, p.ackWait/2), func() {
+		defer p.running.Done()
+		id := fmt.Sprintf("%s#%d", payload.MessageID, seq)
+		if _, err := p.js.Publish(msg.Subject, msg.Data, nats.MsgId(id)); err != nil {
+			log.Printf("nats: requeue throttled event %q: %v", payload.Event, err)
+			msg.Nak()
+			return
+		}
+		msg.Ack()
+	})
+}
+
+// Ping checks the jobs stream can be reached
+func (p *Provider) Ping(ctx context.Context) error {
+	if _, err := p.js.StreamInfo(p.stream, nats.Context(ctx)); err != nil {
+		return fmt.Errorf("nats: stream %q: %w", p.stream, err)
+	}
+	return nil
+}
+
+// Close waits for running jobs, up to shutdownTimeout, and drains the
+// connection
+func (p *Provider) Close() error {
+	stopped := make(chan struct{})
+	go func() {
+		p.running.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		log.Printf("nats: jobs still running after %s, they will be redelivered", shutdownTimeout)
+	}
+	return p.nc.Drain()
+}
+
+// subject returns the subject jobs of queue are published on
+func subject(queue types.QueueName) string {
+	return subjectPrefix + string(queue)
+}
@@ -0,0 +1,40 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+)
+
+// StartScheduler enqueues each task on its cron schedule (UTC) until ctx is cancelled.
+// Every worker runs a scheduler, and each run's message ID is the event and
+// the minute it is due, so JetStream keeps one copy of the job however many
+// workers enqueue it. Overlap protection comes from the handlers
+// (jobs.ScheduledJob.Guard).
+func (p *Provider) StartScheduler(ctx context.Context, tasks []types.PeriodicTask) error {
+	c := cron.New(cron.WithLocation(time.UTC))
+
+	for _, task := range tasks {
+		event := task.Event
+		_, err := c.AddFunc(task.Spec, func() {
+			payload := types.JobPayload{Event: event, MessageID: types.ScheduledMessageID(event, time.Now())}
+			if _, err := p.Enqueue(ctx, types.QueueFor(event), payload); err != nil {
+				log.Printf("nats: scheduler enqueue error for event %q: %v", event, err)
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("nats: register periodic task %q: %w", event, err)
+		}
+	}
+
+	c.Start()
+	go func() {
+		<-ctx.Done()
+		c.Stop()
+	}()
+	return nil
+}
@@ -0,0 +1,413 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+)
+
+const (
+	// waitTime is how long a receive long-polls for jobs (the SQS maximum)
+	waitTime = 20
+
+	// shutdownTimeout is how long Close waits for running jobs; jobs still
+	// running after it are received again once their visibility timeout ends
+	shutdownTimeout = 10 * time.Second
+)
+
+// queueNames are the worker queues, each an SQS queue
+var queueNames = []types.QueueName{types.CriticalQueue, types.DefaultQueue, types.LowQueue}
+
+// Provider is an AWS SQS queue. Each worker queue is its own SQS queue, with
+// a dead-letter queue taking the jobs that failed MaxReceiveCount times.
+type Provider struct {
+	client *sqs.Client
+	urls   map[types.QueueName]string
+
+	// redrive reports the queues with a dead-letter queue; jobs that failed
+	// every attempt on the others are deleted
+	redrive map[types.QueueName]bool
+
+	fifo              bool
+	visibilityTimeout time.Duration
+	maxReceiveCount   int
+	retryDelay        func(attempt uint64) time.Duration
+
+	running sync.WaitGroup
+}
+
+// job is a received message and the queue it came from
+type job struct {
+	queue types.QueueName
+	msg   sqstypes.Message
+}
+
+// New creates an SQS Provider from config.Queue.SQS, looking up the queue
+// URLs (or creating the queues when CreateQueues is set)
+func New() (*Provider, error) {
+	cfg := config.Queue.SQS
+	ctx := context.Background()
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(cfg.Region))
+	if err != nil {
+		return nil, fmt.Errorf("sqs: load AWS config: %w", err)
+	}
+
+	var opts []func(*sqs.Options)
+	// Custom endpoint for LocalStack
+	if cfg.Endpoint != "" {
+		opts = append(opts, func(o *sqs.Options) {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+		})
+	}
+
+	p := &Provider{
+		client:            sqs.NewFromConfig(awsCfg, opts...),
+		urls:              make(map[types.QueueName]string, len(queueNames)),
+		redrive:           make(map[types.QueueName]bool, len(queueNames)),
+		fifo:              cfg.FIFO,
+		visibilityTimeout: max(cfg.VisibilityTimeout, 2*time.Second),
+		maxReceiveCount:   max(cfg.MaxReceiveCount, 1),
+		retryDelay:        types.RetryDelay,
+	}
+	for _, queue := range queueNames {
+		if err := p.resolve(ctx, cfg, queue); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// resolve looks up (or creates) the SQS queue of queue and whether it has a
+// dead-letter queue
+func (p *Provider) resolve(ctx context.Context, cfg config.QueueSQSConfig, queue types.QueueName) error {
+	name := queueName(cfg.QueuePrefix+string(queue), cfg.FIFO)
+
+	var url *string
+	if cfg.CreateQueues {
+		out, err := p.createQueue(ctx, name, cfg)
+		if err != nil {
+			return fmt.Errorf("sqs: create queue %q: %w", name, err)
+		}
+		url = out
+	} else {
+		out, err := p.client.GetQueueUrl(ctx, &sqs.GetQueueUrlInput{QueueName: aws.String(name)})
+		if err != nil {
+			return fmt.Errorf("sqs: get queue URL of %q: %w", name, err)
+		}
+		url = out.QueueUrl
+	}
+
+	attrs, err := p.client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl:       url,
+		AttributeNames: []sqstypes.QueueAttributeName{sqstypes.QueueAttributeNameRedrivePolicy},
+	})
+	if err != nil {
+		return fmt.Errorf("sqs: get attributes of %q: %w", name, err)
+	}
+
+	p.urls[queue] = *url
+	p.redrive[queue] = attrs.Attributes[string(sqstypes.QueueAttributeNameRedrivePolicy)] != ""
+	if !p.redrive[queue] {
+		log.Printf("sqs: queue %q has no dead-letter queue, jobs failing %d times are deleted", name, p.maxReceiveCount)
+	}
+	return nil
+}
+
+// createQueue creates the queue name with a dead-letter queue, name-dlq,
+// that receives its jobs after MaxReceiveCount attempts. Creating a queue
+// that exists with the same attributes is a no-op.
+func (p *Provider) createQueue(ctx context.Context, name string, cfg config.QueueSQSConfig) (*string, error) {
+	attrs := map[string]string{}
+	if cfg.FIFO {
+		attrs[string(sqstypes.QueueAttributeNameFifoQueue)] = "true"
+	}
+
+	dlq, err := p.client.CreateQueue(ctx, &sqs.CreateQueueInput{
+		QueueName:  aws.String(queueName(strings.TrimSuffix(name, ".fifo")+"-dlq", cfg.FIFO)),
+		Attributes: attrs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	dlqAttrs, err := p.client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl:       dlq.QueueUrl,
+		AttributeNames: []sqstypes.QueueAttributeName{sqstypes.QueueAttributeNameQueueArn},
+	})
+	if err != nil {
+		return nil, err
+	}
+	redrivePolicy, err := json.Marshal(map[string]string{
+		"deadLetterTargetArn": dlqAttrs.Attributes[string(sqstypes.QueueAttributeNameQueueArn)],
+		"maxReceiveCount":     strconv.Itoa(p.maxReceiveCount),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	attrs[string(sqstypes.QueueAttributeNameVisibilityTimeout)] = strconv.Itoa(int(p.visibilityTimeout / time.Second))
+	attrs[string(sqstypes.QueueAttributeNameRedrivePolicy)] = string(redrivePolicy)
+	out, err := p.client.CreateQueue(ctx, &sqs.CreateQueueInput{QueueName: aws.String(name), Attributes: attrs})
+	if err != nil {
+		return nil, err
+	}
+	return out.QueueUrl, nil
+}
+
+// Enqueue sends the payload to the queue's SQS queue.
+// On FIFO queues the message ID is the deduplication ID, so enqueueing the
+// same message again within five minutes is a no-op, and the job's user is
+// its message group (see types.GroupKey).
+func (p *Provider) Enqueue(ctx context.Context, queue types.QueueName, payload types.JobPayload) (string, error) {
+	payload.EnsureMessageID()
+	payload.EnsureEnqueuedAt()
+
+	url, ok := p.urls[queue]
+	if !ok {
+		return "", fmt.Errorf("sqs: unknown queue %q", queue)
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("sqs: marshal payload: %w", err)
+	}
+
+	input := &sqs.SendMessageInput{QueueUrl: aws.String(url), MessageBody: aws.String(string(data))}
+	if p.fifo {
+		input.MessageGroupId = aws.String(types.GroupKey(payload))
+		input.MessageDeduplicationId = aws.String(payload.MessageID)
+	}
+	if _, err := p.client.SendMessage(ctx, input); err != nil {
+		return "", fmt.Errorf("sqs: send message: %w", err)
+	}
+	return payload.MessageID, nil
+}
+
+// StartWorkers runs a shared pool of workers over queues until ctx is cancelled.
+// Like the other providers' workers, the pool size is the sum of the
+// per-queue concurrency and each worker picks its next queue by priority.
+//
+// A running job's visibility timeout is extended until it finishes. A failed
+// job becomes visible again after a growing delay, and moves to the
+// dead-letter queue once it has been received MaxReceiveCount times. A
+// throttled job is sent again with a delay, so it isn't counted as an
+// attempt; on FIFO queues, which can't reorder or delay single jobs, it is
+// made visible again after the delay like a failed job.
+func (p *Provider) StartWorkers(ctx context.Context, queues []types.QueueSettings, strictPriority bool, handler func(context.Context, types.JobPayload) error) error {
+	pending := make(map[types.QueueName]chan job, len(queues))
+	for _, q := range queues {
+		if _, ok := p.urls[q.Name]; !ok {
+			return fmt.Errorf("sqs: unknown queue %q", q.Name)
+		}
+		pending[q.Name] = make(chan job)
+		go p.receive(ctx, q.Name, pending[q.Name])
+	}
+
+	concurrency := 0
+	for _, q := range queues {
+		concurrency += q.Concurrency
+	}
+
+	for i := 0; i < max(concurrency, 1); i++ {
+		p.running.Add(1)
+		go func() {
+			defer p.running.Done()
+			for {
+				j, ok := types.NextJob(ctx, queues, pending, strictPriority)
+				if !ok {
+					return
+				}
+				// Jobs that started finish even when the worker is shutting down
+				p.handle(context.WithoutCancel(ctx), j, handler)
+			}
+		}()
+	}
+	return nil
+}
+
+// receive long-polls the queue for one job at a time and hands it to the
+// first free worker, until ctx is cancelled
+func (p *Provider) receive(ctx context.Context, queue types.QueueName, out chan<- job) {
+	for ctx.Err() == nil {
+		resp, err := p.client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:                    aws.String(p.urls[queue]),
+			MaxNumberOfMessages:         1,
+			WaitTimeSeconds:             waitTime,
+			VisibilityTimeout:           int32(p.visibilityTimeout / time.Second),
+			MessageSystemAttributeNames: []sqstypes.MessageSystemAttributeName{sqstypes.MessageSystemAttributeNameApproximateReceiveCount},
+		})
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("sqs: receive from %q: %v", queue, err)
+				time.Sleep(time.Second)
+			}
+			continue
+		}
+
+		for _, msg := range resp.Messages {
+			p.handOver(ctx, job{queue: queue, msg: msg}, out)
+		}
+	}
+}
+
+// handOver waits for a worker to take j, keeping it hidden while every
+// worker is busy. On shutdown j is made visible for another worker.
+func (p *Provider) handOver(ctx context.Context, j job, out chan<- job) {
+	ticker := time.NewTicker(p.visibilityTimeout / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case out <- j:
+			return
+		case <-ticker.C:
+			p.setVisibility(ctx, j, p.visibilityTimeout)
+		case <-ctx.Done():
+			p.setVisibility(context.WithoutCancel(ctx), j, 0)
+			return
+		}
+	}
+}
+
+// handle runs the handler on j and deletes it, or makes it visible again
+// for a retry
+func (p *Provider) handle(ctx context.Context, j job, handler func(context.Context, types.JobPayload) error) {
+	var payload types.JobPayload
+	if err := json.Unmarshal([]byte(aws.ToString(j.msg.Body)), &payload); err != nil {
+		log.Printf("sqs: unmarshal job, dropping: %v", err)
+		p.delete(ctx, j)
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(p.visibilityTimeout / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.setVisibility(ctx, j, p.visibilityTimeout)
+			case <-done:
+				return
+			}
+		}
+	}()
+	err := handler(ctx, payload)
+	close(done)
+
+	if err == nil {
+		p.delete(ctx, j)
+		return
+	}
+	if errors.Is(err, types.ErrThrottled) {
+		p.requeue(ctx, j, payload)
+		return
+	}
+
+	attempt, parseErr := strconv.ParseUint(j.msg.Attributes[string(sqstypes.MessageSystemAttributeNameApproximateReceiveCount)], 10, 64)
+	if parseErr != nil {
+		attempt = 1
+	}
+	switch {
+	case attempt < uint64(p.maxReceiveCount):
+		log.Printf("sqs: handler error for event %q (attempt %d of %d): %v", payload.Event, attempt, p.maxReceiveCount, err)
+		p.setVisibility(ctx, j, p.retryDelay(attempt))
+	case p.redrive[j.queue]:
+		// SQS moves the job to the dead-letter queue when it is next received
+		log.Printf("sqs: handler error for event %q, moving to the dead-letter queue after %d attempts: %v", payload.Event, attempt, err)
+		p.setVisibility(ctx, j, 0)
+	default:
+		log.Printf("sqs: handler error for event %q, giving up after %d attempts: %v", payload.Event, attempt, err)
+		p.delete(ctx, j)
+	}
+}
+
+// requeue puts a throttled job back in its queue for types.ThrottleDelay
+func (p *Provider) requeue(ctx context.Context, j job, payload types.JobPayload) {
+	if p.fifo {
+		p.setVisibility(ctx, j, types.ThrottleDelay)
+		return
+	}
+
+	_, err := p.client.SendMessage(ctx, &sqs.SendMessageInput{
+		QueueUrl:     aws.String(p.urls[j.queue]),
+		MessageBody:  j.msg.Body,
+		DelaySeconds: int32(types.ThrottleDelay / time.Second),
+	})
+	if err != nil {
+		log.Printf("sqs: requeue throttled event %q: %v", payload.Event, err)
+		p.setVisibility(ctx, j, types.ThrottleDelay)
+		return
+	}
+	p.delete(ctx, j)
+}
+
+// setVisibility hides j from receives for timeout
+func (p *Provider) setVisibility(ctx context.Context, j job, timeout time.Duration) {
+	_, err := p.client.ChangeMessageVisibility(ctx, &sqs.ChangeMessageVisibilityInput{
+		QueueUrl:          aws.String(p.urls[j.queue]),
+		ReceiptHandle:     j.msg.ReceiptHandle,
+		VisibilityTimeout: int32(timeout / time.Second),
+	})
+	if err != nil {
+		log.Printf("sqs: change visibility of message %s: %v", aws.ToString(j.msg.MessageId), err)
+	}
+}
+
+// delete removes a handled job from its queue
+func (p *Provider) delete(ctx context.Context, j job) {
+	_, err := p.client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(p.urls[j.queue]),
+		ReceiptHandle: j.msg.ReceiptHandle,
+	})
+	if err != nil {
+		log.Printf("sqs: delete message %s: %v", aws.ToString(j.msg.MessageId), err)
+	}
+}
+
+// Ping checks the default queue can be reached
+func (p *Provider) Ping(ctx context.Context) error {
+	_, err := p.client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl:       aws.String(p.urls[types.DefaultQueue]),
+		AttributeNames: []sqstypes.QueueAttributeName{sqstypes.QueueAttributeNameApproximateNumberOfMessages},
+	})
+	if err != nil {
+		return fmt.Errorf("sqs: ping: %w", err)
+	}
+	return nil
+}
+
+// Close waits for running jobs, up to shutdownTimeout
+func (p *Provider) Close() error {
+	stopped := make(chan struct{})
+	go func() {
+		p.running.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		log.Printf("sqs: jobs still running after %s, they will be received again", shutdownTimeout)
+	}
+	return nil
+}
+
+// queueName adds the .fifo suffix SQS requires of FIFO queues
+func queueName(name string, fifo bool) string {
+	if fifo {
+		return name + ".fifo"
+	}
+	return name
+}
@@ -0,0 +1,111 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+)
+
+// TestProvider_LocalStack runs jobs through FIFO queues on LocalStack: jobs
+// of a user run in order, a duplicate enqueue is dropped, and a job that
+// keeps failing ends up in the dead-letter queue.
+func TestProvider_LocalStack(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts a LocalStack container")
+	}
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+	ctx := context.Background()
+
+	localstack, err := testcontainers.Run(ctx, "localstack/localstack:3",
+		testcontainers.WithExposedPorts("4566/tcp"),
+		testcontainers.WithEnv(map[string]string{"SERVICES": "sqs"}),
+		testcontainers.WithWaitStrategy(wait.ForHTTP("/_localstack/health").WithPort("4566/tcp").WithStartupTimeout(2*time.Minute)),
+	)
+	testcontainers.CleanupContainer(t, localstack)
+	require.NoError(t, err)
+	endpoint, err := localstack.PortEndpoint(ctx, "4566/tcp", "http")
+	require.NoError(t, err)
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	config.Queue = &config.QueueConfigType{SQS: config.QueueSQSConfig{
+		Region:            "us-east-1",
+		Endpoint:          endpoint,
+		QueuePrefix:       "test-",
+		FIFO:              true,
+		CreateQueues:      true,
+		VisibilityTimeout: 10 * time.Second,
+		MaxReceiveCount:   2,
+	}}
+	p, err := New()
+	require.NoError(t, err)
+	p.retryDelay = func(uint64) time.Duration { return 0 }
+
+	for i, step := range []string{"first", "second", "third"} {
+		_, err := p.Enqueue(ctx, types.DefaultQueue, types.JobPayload{
+			Event:     types.EventGenerateExport,
+			Data:      json.RawMessage(`{"user_id":1,"step":"` + step + `"}`),
+			MessageID: "export-" + step,
+		})
+		require.NoError(t, err, i)
+	}
+	_, err = p.Enqueue(ctx, types.DefaultQueue, types.JobPayload{Event: types.EventGenerateExport, MessageID: "export-first"})
+	require.NoError(t, err, "a duplicate is accepted and dropped")
+	_, err = p.Enqueue(ctx, types.LowQueue, types.JobPayload{Event: types.EventImportActivities, MessageID: "import-broken"})
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var steps []string
+	attempts := 0
+	workerCtx, stop := context.WithCancel(ctx)
+	defer stop()
+	err = p.StartWorkers(workerCtx, []types.QueueSettings{
+		{Name: types.DefaultQueue, Concurrency: 2, Priority: 3},
+		{Name: types.LowQueue, Concurrency: 1, Priority: 1},
+	}, false, func(_ context.Context, job types.JobPayload) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if job.Event == types.EventImportActivities {
+			attempts++
+			return errors.New("broken import")
+		}
+		var data struct{ Step string }
+		assert.NoError(t, json.Unmarshal(job.Data, &data))
+		steps = append(steps, data.Step)
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(steps) == 3 && attempts == 2
+	}, 30*time.Second, 100*time.Millisecond)
+	mu.Lock()
+	assert.Equal(t, []string{"first", "second", "third"}, steps)
+	mu.Unlock()
+
+	// The failed job is moved once it is received after its last attempt
+	dlq, err := p.client.GetQueueUrl(ctx, &sqs.GetQueueUrlInput{QueueName: aws.String("test-low-dlq.fifo")})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		out, err := p.client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{QueueUrl: dlq.QueueUrl, WaitTimeSeconds: 1})
+		return err == nil && len(out.Messages) == 1 && strings.Contains(aws.ToString(out.Messages[0].Body), "import-broken")
+	}, 30*time.Second, 100*time.Millisecond)
+
+	stop()
+	require.NoError(t, p.Close())
+}
@@ -0,0 +1,40 @@
+package sqs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+)
+
+// StartScheduler enqueues each task on its cron schedule (UTC) until ctx is cancelled.
+// Every worker runs a scheduler and enqueues each run under the same message
+// ID (types.ScheduledMessageID): FIFO queues drop the copies, and on standard
+// queues the workers skip them as already processed messages. Overlap
+// protection comes from the handlers (jobs.ScheduledJob.Guard).
+func (p *Provider) StartScheduler(ctx context.Context, tasks []types.PeriodicTask) error {
+	c := cron.New(cron.WithLocation(time.UTC))
+
+	for _, task := range tasks {
+		event := task.Event
+		_, err := c.AddFunc(task.Spec, func() {
+			payload := types.JobPayload{Event: event, MessageID: types.ScheduledMessageID(event, time.Now())}
+			if _, err := p.Enqueue(ctx, types.QueueFor(event), payload); err != nil {
+				log.Printf("sqs: scheduler enqueue error for event %q: %v", event, err)
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("sqs: register periodic task %q: %w", event, err)
+		}
+	}
+
+	c.Start()
+	go func() {
+		<-ctx.Done()
+		c.Stop()
+	}()
+	return nil
+}
@@ -3,14 +3,24 @@ package types
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 // QueueName identifies which queue a job should go into
+// Workers serve queues by priority (see QueueSettings)
 type QueueName string
 
 const (
-	InboxQueue  QueueName = "inbox"
-	OutboxQueue QueueName = "outbox"
+	CriticalQueue QueueName = "critical"
+	DefaultQueue  QueueName = "default"
+	LowQueue      QueueName = "low"
 )
 
 // EventType identifies which handler should process a job
@@ -18,26 +28,241 @@ type EventType string
 
 // Inbox events
 const (
-	EventWelcomeEmail             EventType = "welcome_email"
-	EventWeeklySummary            EventType = "weekly_summary"
-	EventGenerateExport           EventType = "generate_export"
-	EventSendVerificationEmail    EventType = "send_verification_email"
-	EventRefreshRateLimitConfig   EventType = "refresh_rate_limit_config"
+	EventWelcomeEmail           EventType = "welcome_email"
+	EventWeeklySummary          EventType = "weekly_summary"
+	EventGenerateExport         EventType = "generate_export"
+	EventSendVerificationEmail  EventType = "send_verification_email"
+	EventRefreshRateLimitConfig EventType = "refresh_rate_limit_config"
+	EventImportActivities       EventType = "import_activities"
+	EventExportUserData         EventType = "export_user_data"
+	EventEnrichWeather          EventType = "enrich_weather"
+	EventGeocodeActivity        EventType = "geocode_activity"
+	EventRecalculateUserMetrics EventType = "recalculate_user_metrics"
+	EventReindexSearch          EventType = "reindex_search"
+	EventRenderMapThumbnails    EventType = "render_map_thumbnails"
+)
+
+// Scheduled events (see jobs.Schedule)
+const (
+	EventScheduleWeeklySummaries  EventType = "schedule_weekly_summaries"
+	EventPurgeSoftDeleted         EventType = "purge_soft_deleted"
+	EventWebhookRetrySweep        EventType = "webhook_retry_sweep"
+	EventMaintainPartitions       EventType = "maintain_partitions"
+	EventPurgeDeletedAccounts     EventType = "purge_deleted_accounts"
+	EventBackfillActivityMetrics  EventType = "backfill_activity_metrics"
+	EventRefreshStatsSummaries    EventType = "refresh_stats_summaries"
+	EventRefreshChallengeProgress EventType = "refresh_challenge_progress"
 )
 
 // Outbox events
 const (
 	EventActivityCreated EventType = "activity_created"
 	EventActivityDeleted EventType = "activity_deleted"
+	EventActivityUpdated EventType = "activity_updated"
 )
 
-// JobPayload is the envelope for every queued job
+// EventQueues maps each event type to the queue it is enqueued on
+// Events missing from the map go to DefaultQueue
+var EventQueues = map[EventType]QueueName{
+	EventSendVerificationEmail:    CriticalQueue,
+	EventRefreshRateLimitConfig:   CriticalQueue,
+	EventWelcomeEmail:             DefaultQueue,
+	EventGenerateExport:           DefaultQueue,
+	EventActivityCreated:          DefaultQueue,
+	EventActivityDeleted:          DefaultQueue,
+	EventActivityUpdated:          DefaultQueue,
+	EventWeeklySummary:            LowQueue,
+	EventImportActivities:         LowQueue,
+	EventExportUserData:           LowQueue,
+	EventEnrichWeather:            LowQueue,
+	EventGeocodeActivity:          LowQueue,
+	EventRecalculateUserMetrics:   LowQueue,
+	EventReindexSearch:            LowQueue,
+	EventRenderMapThumbnails:      LowQueue,
+	EventWebhookRetrySweep:        DefaultQueue,
+	EventScheduleWeeklySummaries:  LowQueue,
+	EventPurgeSoftDeleted:         LowQueue,
+	EventMaintainPartitions:       LowQueue,
+	EventPurgeDeletedAccounts:     LowQueue,
+	EventBackfillActivityMetrics:  LowQueue,
+	EventRefreshStatsSummaries:    LowQueue,
+	EventRefreshChallengeProgress: LowQueue,
+}
+
+// QueueFor returns the queue an event should be enqueued on
+func QueueFor(event EventType) QueueName {
+	if queue, ok := EventQueues[event]; ok {
+		return queue
+	}
+	return DefaultQueue
+}
+
+// QueueSettings configures how workers serve a queue.
+// Concurrency is the number of workers the queue contributes to the shared
+// pool; Priority is its weight when picking the next job (or its rank when
+// strict priority is enabled).
+type QueueSettings struct {
+	Name        QueueName
+	Concurrency int
+	Priority    int
+}
+
+// PollOrder returns the order in which a worker polls queues for its next job.
+// Strict: highest priority first. Weighted: each position is drawn at random
+// with probability proportional to Priority among the queues not yet drawn.
+func PollOrder(queues []QueueSettings, strictPriority bool) []QueueSettings {
+	order := slices.Clone(queues)
+	if strictPriority {
+		slices.SortStableFunc(order, func(a, b QueueSettings) int {
+			return b.Priority - a.Priority
+		})
+		return order
+	}
+
+	for i := range order {
+		total := 0
+		for _, q := range order[i:] {
+			total += max(q.Priority, 1)
+		}
+		pick := rand.IntN(total)
+		for j := i; j < len(order); j++ {
+			pick -= max(order[j].Priority, 1)
+			if pick < 0 {
+				order[i], order[j] = order[j], order[i]
+				break
+			}
+		}
+	}
+	return order
+}
+
+// NextJob returns the next job of pending by queue priority (see PollOrder),
+// blocking until one is available. Returns false once ctx is cancelled. It
+// is the worker loop of providers that hand jobs to workers over a channel
+// per queue.
+func NextJob[T any](ctx context.Context, queues []QueueSettings, pending map[QueueName]chan T, strictPriority bool) (T, bool) {
+	for _, q := range PollOrder(queues, strictPriority) {
+		select {
+		case job := <-pending[q.Name]:
+			return job, true
+		default:
+		}
+	}
+
+	// Every queue is empty: wait for whichever receives a job first
+	cases := make([]reflect.SelectCase, 0, len(queues)+1)
+	cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())})
+	for _, q := range queues {
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(pending[q.Name])})
+	}
+
+	var none T
+	chosen, value, _ := reflect.Select(cases)
+	if chosen == 0 {
+		return none, false
+	}
+	return value.Interface().(T), true
+}
+
+// RetryDelay is how long a failed job waits before its next attempt on
+// providers that don't back off themselves: 10s after the first failure,
+// doubling up to 10 minutes
+func RetryDelay(attempt uint64) time.Duration {
+	delay := 10 * time.Second
+	for i := uint64(1); i < attempt && delay < 10*time.Minute; i++ {
+		delay *= 2
+	}
+	return min(delay, 10*time.Minute)
+}
+
+// PeriodicTask is a job enqueued on a cron schedule by the worker's scheduler.
+// Unique keeps a second copy from being enqueued while one is still pending
+// or running, which protects against overlapping runs.
+type PeriodicTask struct {
+	Spec   string // cron expression, evaluated in UTC
+	Event  EventType
+	Unique time.Duration
+}
+
+// JobPayload is the envelope for every queued job.
+// JobID is set when the job has a row in the jobs table whose status and
+// progress the worker should keep up to date.
+//
+// MessageID identifies the message across redeliveries so handlers can run at
+// most once per message. Providers assign a random one on Enqueue if it is
+// empty; callers set a stable one (e.g. the export ID) to make Enqueue itself
+// idempotent.
 type JobPayload struct {
-	Event EventType       `json:"event"`
-	Data  json.RawMessage `json:"data"`
+	Event     EventType       `json:"event"`
+	Data      json.RawMessage `json:"data"`
+	JobID     string          `json:"job_id,omitempty"`
+	MessageID string          `json:"message_id,omitempty"`
+
+	// EnqueuedAt is when the job was first enqueued; retries keep it
+	EnqueuedAt time.Time `json:"enqueued_at,omitzero"`
+}
+
+// ScheduledMessageID identifies the run of a periodic event due at the minute
+// of now. Workers that each run a scheduler enqueue a run under the same
+// message ID, so it is handled once.
+func ScheduledMessageID(event EventType, now time.Time) string {
+	return fmt.Sprintf("%s@%s", event, now.UTC().Truncate(time.Minute).Format(time.RFC3339))
+}
+
+// EnsureMessageID assigns a random MessageID if none is set
+func (p *JobPayload) EnsureMessageID() {
+	if p.MessageID == "" {
+		p.MessageID = uuid.NewString()
+	}
+}
+
+// EnsureEnqueuedAt sets EnqueuedAt to now if it is not set
+func (p *JobPayload) EnsureEnqueuedAt() {
+	if p.EnqueuedAt.IsZero() {
+		p.EnqueuedAt = time.Now()
+	}
+}
+
+// JobUser returns the ID of the user a job is for, or 0 for jobs without a
+// user. Jobs hold the user as user_id and outbox events as userId.
+func JobUser(payload JobPayload) int {
+	var data struct {
+		UserID      int `json:"user_id"`
+		EventUserID int `json:"userId"`
+	}
+	if json.Unmarshal(payload.Data, &data) != nil {
+		return 0
+	}
+	if data.UserID != 0 {
+		return data.UserID
+	}
+	return data.EventUserID
+}
+
+// GroupKey groups the jobs of a user ("user-7"), so they can be ordered or
+// limited per user. Jobs without a user are grouped by event.
+func GroupKey(payload JobPayload) string {
+	if user := JobUser(payload); user != 0 {
+		return fmt.Sprintf("user-%d", user)
+	}
+	return "event-" + string(payload.Event)
 }
 
+// ErrThrottled is returned by the worker's dispatch for a job it didn't run
+// because the job's user has as many jobs running as they may. Providers put
+// the job back in its queue for ThrottleDelay, without counting an attempt.
+var ErrThrottled = errors.New("job throttled: its user is at the concurrency limit")
+
+// ThrottleDelay is how long a throttled job waits before it is run again
+const ThrottleDelay = 2 * time.Second
+
 // QueueProvider is the interface all queue backends must implement
 type QueueProvider interface {
 	Enqueue(ctx context.Context, queue QueueName, payload JobPayload) (taskID string, err error)
 }
+
+// Pinger is implemented by queue providers backed by a broker: Ping checks
+// the broker can be reached. The memory provider has nothing to check.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
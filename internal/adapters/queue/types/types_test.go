@@ -0,0 +1,83 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPollOrder_WeightedFavoursHigherPriority(t *testing.T) {
+	queues := []QueueSettings{
+		{Name: LowQueue, Priority: 1},
+		{Name: CriticalQueue, Priority: 9},
+	}
+
+	first := map[QueueName]int{}
+	for range 1000 {
+		first[PollOrder(queues, false)[0].Name]++
+	}
+
+	assert.Greater(t, first[CriticalQueue], 800)
+	assert.Greater(t, first[LowQueue], 0)
+}
+
+func TestQueueFor(t *testing.T) {
+	assert.Equal(t, CriticalQueue, QueueFor(EventSendVerificationEmail))
+	assert.Equal(t, LowQueue, QueueFor(EventImportActivities))
+	assert.Equal(t, DefaultQueue, QueueFor("unknown_event"))
+}
+
+// Schedulers on different workers fire a few seconds apart; their runs must
+// share a message ID so JetStream drops the duplicates
+func TestScheduledMessageID(t *testing.T) {
+	due := time.Date(2026, 3, 2, 6, 0, 0, 0, time.UTC)
+
+	first := ScheduledMessageID(EventPurgeSoftDeleted, due.Add(200*time.Millisecond))
+	assert.Equal(t, "purge_soft_deleted@2026-03-02T06:00:00Z", first)
+	assert.Equal(t, first, ScheduledMessageID(EventPurgeSoftDeleted, due.Add(3*time.Second).In(time.FixedZone("WAT", 3600))))
+	assert.NotEqual(t, first, ScheduledMessageID(EventPurgeSoftDeleted, due.Add(time.Hour)))
+	assert.NotEqual(t, first, ScheduledMessageID(EventMaintainPartitions, due))
+}
+
+func TestNextJob(t *testing.T) {
+	queues := []QueueSettings{{Name: LowQueue, Priority: 1}, {Name: CriticalQueue, Priority: 6}}
+	pending := map[QueueName]chan string{LowQueue: make(chan string), CriticalQueue: make(chan string)}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() { pending[LowQueue] <- "low" }()
+	job, ok := NextJob(ctx, queues, pending, true)
+	assert.True(t, ok)
+	assert.Equal(t, "low", job, "waits on every queue when all are empty")
+
+	cancel()
+	_, ok = NextJob(ctx, queues, pending, true)
+	assert.False(t, ok)
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, 10*time.Second, RetryDelay(1))
+	assert.Equal(t, 20*time.Second, RetryDelay(2))
+	assert.Equal(t, 40*time.Second, RetryDelay(3))
+	assert.Equal(t, 10*time.Minute, RetryDelay(20))
+}
+
+func TestGroupKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload JobPayload
+		want    string
+	}{
+		{name: "job", payload: JobPayload{Event: EventGenerateExport, Data: json.RawMessage(`{"user_id":7,"format":"csv"}`)}, want: "user-7"},
+		{name: "outbox event", payload: JobPayload{Event: EventActivityCreated, Data: json.RawMessage(`{"userId":7,"activity":{}}`)}, want: "user-7"},
+		{name: "no user", payload: JobPayload{Event: EventPurgeSoftDeleted}, want: "event-purge_soft_deleted"},
+		{name: "not an object", payload: JobPayload{Event: EventReindexSearch, Data: json.RawMessage(`[1,2]`)}, want: "event-reindex_search"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, GroupKey(tt.payload))
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package di
+
+// Container registration keys for search
+const (
+	// SearchProviderKey is the key for the active search provider
+	SearchProviderKey = "SearchProvider"
+)
@@ -0,0 +1,60 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/search"
+	"github.com/valentinesamuel/activelog/internal/adapters/search/elasticsearch"
+	"github.com/valentinesamuel/activelog/internal/adapters/search/memory"
+	"github.com/valentinesamuel/activelog/internal/adapters/search/types"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	"github.com/valentinesamuel/activelog/internal/platform/container"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	repoDI "github.com/valentinesamuel/activelog/internal/repository/di"
+)
+
+// RegisterSearch registers the search provider in the DI container.
+// Resolving it fails when search is disabled (SEARCH_PROVIDER=none).
+func RegisterSearch(c *container.Container) {
+	c.Register(SearchProviderKey, func(c *container.Container) (interface{}, error) {
+		switch config.Search.Provider {
+		case "memory":
+			log.Printf("Search provider initialized: memory")
+			return types.SearchProvider(memory.New()), nil
+		case "elasticsearch":
+			log.Printf("Search provider initialized: elasticsearch (%s, index %s)", config.Search.ElasticsearchURL, config.Search.ElasticsearchIndex)
+			return types.SearchProvider(elasticsearch.New()), nil
+		default:
+			return nil, fmt.Errorf("search: no provider configured (SEARCH_PROVIDER=%s)", config.Search.Provider)
+		}
+	})
+}
+
+// BuildMemoryIndex fills the memory provider's index from the database in
+// the background when the container starts; it starts out empty in every
+// process. Other providers keep their index and are left alone.
+func BuildMemoryIndex(c *container.Container) {
+	c.OnStart(SearchProviderKey, func(context.Context) error {
+		if config.Search.Provider != "memory" {
+			return nil
+		}
+		provider, err := container.Resolve[types.SearchProvider](c, SearchProviderKey)
+		if err != nil {
+			return err
+		}
+		store := container.MustResolve[*repository.ActivityRepository](c, repoDI.ActivityRepoKey)
+
+		// The build outlives the start hook, so it gets its own context
+		go func() {
+			indexed, err := search.Reindex(context.Background(), store, provider)
+			if err != nil {
+				log.Printf("Warning: Failed to build the search index: %v", err)
+				return
+			}
+			log.Printf("Search index built: %d activities", indexed)
+		}()
+		return nil
+	})
+}
@@ -0,0 +1,212 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/search/types"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	"github.com/valentinesamuel/activelog/pkg/httpclient"
+)
+
+// textFields are the searched fields with their boosts
+var textFields = []string{"title^3", "tags^2", "locationName^1.5", "description", "notes"}
+
+// mappings is the index mapping: text fields are analyzed, filter fields
+// are exact
+var mappings = map[string]any{
+	"properties": map[string]any{
+		"id":           map[string]any{"type": "long"},
+		"userId":       map[string]any{"type": "integer"},
+		"activityType": map[string]any{"type": "keyword"},
+		"title":        map[string]any{"type": "text"},
+		"description":  map[string]any{"type": "text"},
+		"notes":        map[string]any{"type": "text"},
+		"locationName": map[string]any{"type": "text"},
+		"tags":         map[string]any{"type": "text"},
+		"activityDate": map[string]any{"type": "date"},
+	},
+}
+
+// Provider indexes and searches activities in an Elasticsearch index
+// through its REST API
+type Provider struct {
+	client  *http.Client
+	baseURL string
+	index   string
+	apiKey  string
+}
+
+// New creates a Provider from the global search config.
+func New() *Provider {
+	cfg := config.Search
+	return &Provider{
+		client:  httpclient.New(httpclient.Config{Name: "elasticsearch", Timeout: cfg.Timeout}),
+		baseURL: cfg.ElasticsearchURL,
+		index:   cfg.ElasticsearchIndex,
+		apiKey:  cfg.ElasticsearchAPIKey,
+	}
+}
+
+// EnsureIndex creates the index with its mapping unless it exists
+func (p *Provider) EnsureIndex(ctx context.Context) error {
+	status, err := p.do(ctx, http.MethodPut, "", map[string]any{"mappings": mappings}, nil)
+	if status == http.StatusBadRequest {
+		// resource_already_exists_exception; other mapping errors surface on Index
+		return nil
+	}
+	return err
+}
+
+// Index adds or replaces doc
+func (p *Provider) Index(ctx context.Context, doc types.Document) error {
+	_, err := p.do(ctx, http.MethodPut, "/_doc/"+strconv.FormatInt(doc.ID, 10), doc, nil)
+	return err
+}
+
+// Delete removes the document of activity id
+func (p *Provider) Delete(ctx context.Context, id int64) error {
+	status, err := p.do(ctx, http.MethodDelete, "/_doc/"+strconv.FormatInt(id, 10), nil, nil)
+	if status == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+// searchResponse is the part of a _search response we read
+type searchResponse struct {
+	Hits struct {
+		Total struct {
+			Value int `json:"value"`
+		} `json:"total"`
+		Hits []struct {
+			ID        string              `json:"_id"`
+			Score     *float64            `json:"_score"`
+			Highlight map[string][]string `json:"highlight"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+// Search returns the user's documents matching every term of the query, the
+// last one as a prefix
+func (p *Provider) Search(ctx context.Context, query types.Query) (*types.Result, error) {
+	var resp searchResponse
+	if _, err := p.do(ctx, http.MethodPost, "/_search", searchRequest(query), &resp); err != nil {
+		return nil, err
+	}
+
+	result := &types.Result{Total: resp.Hits.Total.Value}
+	for _, hit := range resp.Hits.Hits {
+		id, err := strconv.ParseInt(hit.ID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("elasticsearch: invalid document id %q", hit.ID)
+		}
+		h := types.Hit{ID: id, Highlights: hit.Highlight}
+		if hit.Score != nil {
+			h.Score = *hit.Score
+		}
+		result.Hits = append(result.Hits, h)
+	}
+	return result, nil
+}
+
+// searchRequest returns the _search body of query
+func searchRequest(query types.Query) map[string]any {
+	filters := []any{
+		map[string]any{"term": map[string]any{"userId": query.UserID}},
+	}
+	if query.ActivityType != "" {
+		filters = append(filters, map[string]any{"term": map[string]any{"activityType": query.ActivityType}})
+	}
+	if query.From != nil || query.To != nil {
+		dates := map[string]any{}
+		if query.From != nil {
+			dates["gte"] = query.From.Format(time.RFC3339)
+		}
+		if query.To != nil {
+			dates["lte"] = query.To.Format(time.RFC3339)
+		}
+		filters = append(filters, map[string]any{"range": map[string]any{"activityDate": dates}})
+	}
+
+	wholeField := map[string]any{"number_of_fragments": 0}
+	return map[string]any{
+		"from":             query.Offset,
+		"size":             query.Limit,
+		"track_total_hits": true,
+		"_source":          false,
+		"query": map[string]any{
+			"bool": map[string]any{
+				"must": []any{map[string]any{
+					"multi_match": map[string]any{
+						"query":    query.Text,
+						"type":     "bool_prefix",
+						"operator": "and",
+						"fields":   textFields,
+					},
+				}},
+				"filter": filters,
+			},
+		},
+		"sort": []any{"_score", map[string]any{"activityDate": "desc"}},
+		"highlight": map[string]any{
+			"pre_tags":  []string{types.HighlightPre},
+			"post_tags": []string{types.HighlightPost},
+			"fields": map[string]any{
+				"title":        wholeField,
+				"tags":         wholeField,
+				"locationName": wholeField,
+				"description":  map[string]any{},
+				"notes":        map[string]any{},
+			},
+		},
+	}
+}
+
+// do sends a request to path under the index and decodes the response into
+// out, if set. It returns the status code along with errors for non-2xx
+// responses.
+func (p *Provider) do(ctx context.Context, method, path string, body, out any) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, p.baseURL+"/"+url.PathEscape(p.index)+path, reader)
+	if err != nil {
+		return 0, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "ApiKey "+p.apiKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("elasticsearch: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("elasticsearch: %s %s: unexpected status %d", method, path, resp.StatusCode)
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp.StatusCode, fmt.Errorf("elasticsearch: decode response: %w", err)
+		}
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,58 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/search/types"
+)
+
+func TestSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/activities/_search", r.URL.Path)
+		assert.Equal(t, "ApiKey secret", r.Header.Get("Authorization"))
+
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		filters := body["query"].(map[string]any)["bool"].(map[string]any)["filter"].([]any)
+		assert.Equal(t, map[string]any{"term": map[string]any{"userId": float64(7)}}, filters[0])
+		assert.Len(t, filters, 2, "user and activity type")
+		assert.Equal(t, float64(10), body["size"])
+
+		w.Write([]byte(`{"hits":{"total":{"value":1},"hits":[
+			{"_id":"42","_score":2.5,"highlight":{"title":["<mark>Hill</mark> repeats"]}}]}}`))
+	}))
+	defer server.Close()
+
+	provider := &Provider{client: server.Client(), baseURL: server.URL, index: "activities", apiKey: "secret"}
+	result, err := provider.Search(context.Background(), types.Query{UserID: 7, Text: "hill", ActivityType: "running", Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, &types.Result{
+		Total: 1,
+		Hits:  []types.Hit{{ID: 42, Score: 2.5, Highlights: map[string][]string{"title": {"<mark>Hill</mark> repeats"}}}},
+	}, result)
+}
+
+func TestIndexAndDelete(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	provider := &Provider{client: server.Client(), baseURL: server.URL, index: "activities"}
+	require.NoError(t, provider.Index(context.Background(), types.Document{ID: 3, UserID: 1, Title: "Tempo"}))
+	require.NoError(t, provider.Delete(context.Background(), 3), "a missing document is already deleted")
+	assert.Equal(t, []string{"PUT /activities/_doc/3", "DELETE /activities/_doc/3"}, requests)
+}
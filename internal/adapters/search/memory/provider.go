@@ -0,0 +1,298 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/search/types"
+)
+
+// fragmentSize is the length, in runes, above which a highlighted field is
+// cut to the text around its first match
+const fragmentSize = 160
+
+// field is a searchable text field of a document, with its weight in scores
+type field struct {
+	name   string
+	weight float64
+	values func(doc *types.Document) []string
+}
+
+var fields = []field{
+	{"title", 3, func(doc *types.Document) []string { return []string{doc.Title} }},
+	{"tags", 2, func(doc *types.Document) []string { return doc.Tags }},
+	{"locationName", 1.5, func(doc *types.Document) []string { return []string{doc.LocationName} }},
+	{"description", 1, func(doc *types.Document) []string { return []string{doc.Description} }},
+	{"notes", 1, func(doc *types.Document) []string { return []string{doc.Notes} }},
+}
+
+// Provider is a search index embedded in the process. It takes the place of
+// an embedded Bleve index, which the module doesn't depend on, and unlike
+// Bleve it keeps nothing on disk: every document is held in memory, split by
+// user, and the index is empty after a restart.
+//
+// Rebuilding it is the cost of that. Each API start runs search.Reindex in
+// the background, which reads every live activity in the database in pages
+// of 500, so startup costs grow with the whole activities table, and until it
+// finishes searches miss the activities not reached yet. Memory grows with
+// the indexed text, and every API instance holds and rebuilds its own copy.
+// It suits a single instance with a moderate number of activities; beyond
+// that use the Elasticsearch provider.
+type Provider struct {
+	mu    sync.RWMutex
+	users map[int]map[int64]*entry
+	owner map[int64]int
+}
+
+// entry is an indexed document with the terms of its fields
+type entry struct {
+	doc   types.Document
+	terms map[string][]string
+}
+
+// New creates an empty Provider
+func New() *Provider {
+	return &Provider{
+		users: make(map[int]map[int64]*entry),
+		owner: make(map[int64]int),
+	}
+}
+
+// Index adds or replaces doc
+func (p *Provider) Index(_ context.Context, doc types.Document) error {
+	e := &entry{doc: doc, terms: make(map[string][]string, len(fields))}
+	for _, f := range fields {
+		for _, value := range f.values(&doc) {
+			e.terms[f.name] = append(e.terms[f.name], tokenize(value)...)
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.remove(doc.ID)
+	if p.users[doc.UserID] == nil {
+		p.users[doc.UserID] = make(map[int64]*entry)
+	}
+	p.users[doc.UserID][doc.ID] = e
+	p.owner[doc.ID] = doc.UserID
+	return nil
+}
+
+// Delete removes the document of activity id
+func (p *Provider) Delete(_ context.Context, id int64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.remove(id)
+	return nil
+}
+
+// remove deletes a document; the caller holds the write lock
+func (p *Provider) remove(id int64) {
+	userID, ok := p.owner[id]
+	if !ok {
+		return
+	}
+	delete(p.users[userID], id)
+	delete(p.owner, id)
+}
+
+// match is a document matching a search
+type match struct {
+	entry *entry
+	score float64
+}
+
+// Search returns the user's documents matching every term of the query
+func (p *Provider) Search(_ context.Context, query types.Query) (*types.Result, error) {
+	terms := tokenize(query.Text)
+	if len(terms) == 0 {
+		return &types.Result{}, nil
+	}
+
+	p.mu.RLock()
+	var matches []match
+	for _, e := range p.users[query.UserID] {
+		if !filtered(&e.doc, query) {
+			continue
+		}
+		if score, ok := scoreEntry(e, terms); ok {
+			matches = append(matches, match{entry: e, score: score})
+		}
+	}
+	p.mu.RUnlock()
+
+	sort.Slice(matches, func(i, j int) bool {
+		x, y := matches[i], matches[j]
+		if x.score != y.score {
+			return x.score > y.score
+		}
+		if !x.entry.doc.ActivityDate.Equal(y.entry.doc.ActivityDate) {
+			return x.entry.doc.ActivityDate.After(y.entry.doc.ActivityDate)
+		}
+		return x.entry.doc.ID > y.entry.doc.ID
+	})
+
+	result := &types.Result{Total: len(matches)}
+	if query.Offset >= len(matches) {
+		return result, nil
+	}
+	matches = matches[query.Offset:]
+	if query.Limit > 0 && len(matches) > query.Limit {
+		matches = matches[:query.Limit]
+	}
+	for _, m := range matches {
+		result.Hits = append(result.Hits, types.Hit{
+			ID:         m.entry.doc.ID,
+			Score:      m.score,
+			Highlights: highlights(&m.entry.doc, terms),
+		})
+	}
+	return result, nil
+}
+
+// filtered reports whether doc passes the query's filters
+func filtered(doc *types.Document, query types.Query) bool {
+	if query.ActivityType != "" && doc.ActivityType != query.ActivityType {
+		return false
+	}
+	if query.From != nil && doc.ActivityDate.Before(*query.From) {
+		return false
+	}
+	if query.To != nil && doc.ActivityDate.After(*query.To) {
+		return false
+	}
+	return true
+}
+
+// scoreEntry sums the weighted occurrences of the terms in the entry's
+// fields; ok is false when a term occurs in none of them
+func scoreEntry(e *entry, terms []string) (float64, bool) {
+	var score float64
+	for i, term := range terms {
+		prefix := i == len(terms)-1
+		found := false
+		for _, f := range fields {
+			for _, candidate := range e.terms[f.name] {
+				if termMatches(candidate, term, prefix) {
+					score += f.weight
+					found = true
+				}
+			}
+		}
+		if !found {
+			return 0, false
+		}
+	}
+	return score, true
+}
+
+// termMatches reports whether a document term matches a query term
+func termMatches(candidate, term string, prefix bool) bool {
+	if prefix {
+		return strings.HasPrefix(candidate, term)
+	}
+	return candidate == term
+}
+
+// tokenize splits text into lowercase terms of letters and digits
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// highlights returns the fragments of the document's fields that contain a
+// term, by field name
+func highlights(doc *types.Document, terms []string) map[string][]string {
+	result := map[string][]string{}
+	for _, f := range fields {
+		for _, value := range f.values(doc) {
+			if fragment, ok := highlight(value, terms); ok {
+				result[f.name] = append(result[f.name], fragment)
+			}
+		}
+	}
+	return result
+}
+
+// highlight wraps the words of text matching a term in HighlightPre and
+// HighlightPost, cutting long text to the part around the first match. ok
+// is false when nothing matches.
+func highlight(text string, terms []string) (string, bool) {
+	runes := []rune(text)
+	first := -1
+	for _, w := range words(runes) {
+		if wordMatches(strings.ToLower(string(runes[w[0]:w[1]])), terms) {
+			first = w[0]
+			break
+		}
+	}
+	if first < 0 {
+		return "", false
+	}
+	if len(runes) <= fragmentSize {
+		return mark(runes, terms), true
+	}
+
+	begin := max(first-fragmentSize/4, 0)
+	end := min(begin+fragmentSize, len(runes))
+	return ellipsis(begin > 0) + mark(runes[begin:end], terms) + ellipsis(end < len(runes)), true
+}
+
+// words returns the [start, end) rune offsets of the words of text
+func words(text []rune) [][2]int {
+	var spans [][2]int
+	start := -1
+	for i, r := range text {
+		inWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case inWord && start < 0:
+			start = i
+		case !inWord && start >= 0:
+			spans = append(spans, [2]int{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, [2]int{start, len(text)})
+	}
+	return spans
+}
+
+// mark wraps the words of text matching a term in HighlightPre and HighlightPost
+func mark(text []rune, terms []string) string {
+	var b strings.Builder
+	last := 0
+	for _, w := range words(text) {
+		word := string(text[w[0]:w[1]])
+		if !wordMatches(strings.ToLower(word), terms) {
+			continue
+		}
+		b.WriteString(string(text[last:w[0]]))
+		b.WriteString(types.HighlightPre + word + types.HighlightPost)
+		last = w[1]
+	}
+	b.WriteString(string(text[last:]))
+	return b.String()
+}
+
+// wordMatches reports whether a lowercase word matches one of the terms
+func wordMatches(word string, terms []string) bool {
+	for i, term := range terms {
+		if termMatches(word, term, i == len(terms)-1) {
+			return true
+		}
+	}
+	return false
+}
+
+// ellipsis marks text cut from a fragment
+func ellipsis(cut bool) string {
+	if cut {
+		return "…"
+	}
+	return ""
+}
@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/search/types"
+)
+
+func indexDocs(t *testing.T, p *Provider, docs ...types.Document) {
+	t.Helper()
+	for _, doc := range docs {
+		require.NoError(t, p.Index(context.Background(), doc))
+	}
+}
+
+func hitIDs(result *types.Result) []int64 {
+	ids := make([]int64, len(result.Hits))
+	for i, hit := range result.Hits {
+		ids[i] = hit.ID
+	}
+	return ids
+}
+
+func TestSearch(t *testing.T) {
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	p := New()
+	indexDocs(t, p,
+		types.Document{ID: 1, UserID: 1, ActivityType: "running", Title: "Hill repeats", Notes: "Legs heavy after the hills", ActivityDate: day},
+		types.Document{ID: 2, UserID: 1, ActivityType: "cycling", Title: "Coffee ride", Description: "Easy spin to the hill cafe", ActivityDate: day.AddDate(0, 0, 1)},
+		types.Document{ID: 3, UserID: 1, ActivityType: "running", Title: "Tempo", Tags: []string{"hill"}, ActivityDate: day.AddDate(0, 0, 2)},
+		types.Document{ID: 4, UserID: 2, ActivityType: "running", Title: "Hill repeats", ActivityDate: day},
+	)
+	ctx := context.Background()
+
+	result, err := p.Search(ctx, types.Query{UserID: 1, Text: "hill"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Total)
+	assert.Equal(t, []int64{1, 3, 2}, hitIDs(result), "title matches rank first, other users are never matched")
+	assert.Equal(t, []string{"<mark>Hill</mark> repeats"}, result.Hits[0].Highlights["title"])
+	assert.Equal(t, []string{"Legs heavy after the <mark>hills</mark>"}, result.Hits[0].Highlights["notes"])
+
+	result, err = p.Search(ctx, types.Query{UserID: 1, Text: "hill caf"})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2}, hitIDs(result), "every term must match, the last as a prefix")
+
+	result, err = p.Search(ctx, types.Query{UserID: 1, Text: "hill", ActivityType: "running", To: &day})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, hitIDs(result))
+
+	result, err = p.Search(ctx, types.Query{UserID: 1, Text: "hill", Limit: 1, Offset: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Total)
+	assert.Equal(t, []int64{3}, hitIDs(result))
+}
+
+func TestIndexReplacesAndDeleteRemoves(t *testing.T) {
+	p := New()
+	ctx := context.Background()
+	indexDocs(t, p, types.Document{ID: 1, UserID: 1, Title: "Morning run"})
+	indexDocs(t, p, types.Document{ID: 1, UserID: 1, Title: "Evening run"})
+
+	result, err := p.Search(ctx, types.Query{UserID: 1, Text: "morning"})
+	require.NoError(t, err)
+	assert.Empty(t, result.Hits)
+
+	require.NoError(t, p.Delete(ctx, 1))
+	require.NoError(t, p.Delete(ctx, 1), "deleting a missing document is not an error")
+	result, err = p.Search(ctx, types.Query{UserID: 1, Text: "evening"})
+	require.NoError(t, err)
+	assert.Zero(t, result.Total)
+}
+
+func TestHighlightCutsLongText(t *testing.T) {
+	text := ""
+	for len(text) < 400 {
+		text += "steady easy miles "
+	}
+	text += "then a fartlek finish"
+
+	fragment, ok := highlight(text, []string{"fartlek"})
+	require.True(t, ok)
+	assert.Contains(t, fragment, "<mark>fartlek</mark>")
+	assert.True(t, len([]rune(fragment)) < len([]rune(text)))
+	assert.Equal(t, "…", string([]rune(fragment)[0]))
+}
@@ -0,0 +1,54 @@
+package search
+
+import (
+	"context"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/search/types"
+	"github.com/valentinesamuel/activelog/internal/models"
+)
+
+// reindexBatchSize is how many activities are read per query when reindexing
+const reindexBatchSize = 500
+
+// Store lists the activities to index (repository.ActivityRepository)
+type Store interface {
+	ListForSearch(ctx context.Context, afterID int64, limit int) ([]*models.Activity, error)
+}
+
+// indexCreator is an Indexer whose index must exist before documents are added
+type indexCreator interface {
+	EnsureIndex(ctx context.Context) error
+}
+
+// Reindex indexes every live activity and returns how many it indexed.
+// Documents are replaced in place, so searches keep working meanwhile.
+// Documents of activities deleted while their events were lost stay in the
+// index; searches drop them when they load the activities.
+func Reindex(ctx context.Context, store Store, indexer types.Indexer) (int, error) {
+	if creator, ok := indexer.(indexCreator); ok {
+		if err := creator.EnsureIndex(ctx); err != nil {
+			return 0, err
+		}
+	}
+
+	var (
+		afterID int64
+		indexed int
+	)
+	for {
+		activities, err := store.ListForSearch(ctx, afterID, reindexBatchSize)
+		if err != nil {
+			return indexed, err
+		}
+		for _, activity := range activities {
+			afterID = activity.ID
+			if err := indexer.Index(ctx, types.NewDocument(activity)); err != nil {
+				return indexed, err
+			}
+			indexed++
+		}
+		if len(activities) < reindexBatchSize {
+			return indexed, nil
+		}
+	}
+}
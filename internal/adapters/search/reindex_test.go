@@ -0,0 +1,49 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/search/memory"
+	"github.com/valentinesamuel/activelog/internal/adapters/search/types"
+	"github.com/valentinesamuel/activelog/internal/models"
+)
+
+// fakeStore pages through activities by id like ActivityRepository.ListForSearch
+type fakeStore struct {
+	activities []*models.Activity
+	calls      int
+}
+
+func (s *fakeStore) ListForSearch(_ context.Context, afterID int64, limit int) ([]*models.Activity, error) {
+	s.calls++
+	var page []*models.Activity
+	for _, activity := range s.activities {
+		if activity.ID > afterID && len(page) < limit {
+			page = append(page, activity)
+		}
+	}
+	return page, nil
+}
+
+func TestReindex(t *testing.T) {
+	store := &fakeStore{}
+	for id := int64(1); id <= reindexBatchSize+1; id++ {
+		activity := &models.Activity{UserID: 1, Title: "Run"}
+		activity.ID = id
+		store.activities = append(store.activities, activity)
+	}
+	index := memory.New()
+
+	indexed, err := Reindex(context.Background(), store, index)
+	require.NoError(t, err)
+	assert.Equal(t, reindexBatchSize+1, indexed)
+	assert.Equal(t, 2, store.calls)
+
+	result, err := index.Search(context.Background(), types.Query{UserID: 1, Text: "run"})
+	require.NoError(t, err)
+	assert.Equal(t, reindexBatchSize+1, result.Total)
+}
@@ -0,0 +1,99 @@
+package types
+
+import (
+	"context"
+	"time"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+)
+
+// HighlightPre and HighlightPost wrap the matched terms of highlights
+const (
+	HighlightPre  = "<mark>"
+	HighlightPost = "</mark>"
+)
+
+// Document is an activity as the search index stores it: its text fields
+// and the fields searches filter by
+type Document struct {
+	ID           int64     `json:"id"`
+	UserID       int       `json:"userId"`
+	ActivityType string    `json:"activityType"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description,omitempty"`
+	Notes        string    `json:"notes,omitempty"`
+	LocationName string    `json:"locationName,omitempty"`
+	Tags         []string  `json:"tags,omitempty"`
+	ActivityDate time.Time `json:"activityDate"`
+}
+
+// NewDocument returns the Document indexing activity
+func NewDocument(activity *models.Activity) Document {
+	doc := Document{
+		ID:           activity.ID,
+		UserID:       activity.UserID,
+		ActivityType: activity.ActivityType,
+		Title:        activity.Title,
+		Description:  activity.Description,
+		Notes:        activity.Notes,
+		Tags:         activity.TagNames,
+		ActivityDate: activity.ActivityDate,
+	}
+	if activity.LocationName != nil {
+		doc.LocationName = *activity.LocationName
+	}
+	if doc.Tags == nil {
+		for _, tag := range activity.Tags {
+			doc.Tags = append(doc.Tags, tag.Name)
+		}
+	}
+	return doc
+}
+
+// Query is a full-text search over one user's activities. Every term of Text
+// must match one of the text fields; the last one may be a prefix, so
+// results follow what is being typed.
+type Query struct {
+	UserID int
+	Text   string
+
+	// Optional filters
+	ActivityType string
+	From         *time.Time
+	To           *time.Time
+
+	Limit  int
+	Offset int
+}
+
+// Hit is a matching activity, with the fragments of its text fields that
+// matched, the terms wrapped in HighlightPre and HighlightPost
+type Hit struct {
+	ID         int64               `json:"id"`
+	Score      float64             `json:"score"`
+	Highlights map[string][]string `json:"highlights,omitempty"`
+}
+
+// Result is a page of hits, best first, and the number of matches
+type Result struct {
+	Hits  []Hit
+	Total int
+}
+
+// Searcher searches the index
+type Searcher interface {
+	Search(ctx context.Context, query Query) (*Result, error)
+}
+
+// Indexer keeps the index up to date. Index adds or replaces a document;
+// deleting a document that isn't indexed is not an error.
+type Indexer interface {
+	Index(ctx context.Context, doc Document) error
+	Delete(ctx context.Context, id int64) error
+}
+
+// SearchProvider is the interface all search backends must implement
+type SearchProvider interface {
+	Searcher
+	Indexer
+}
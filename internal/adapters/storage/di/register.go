@@ -3,22 +3,25 @@ package di
 import (
 	"log"
 
+	clockDI "github.com/valentinesamuel/activelog/internal/platform/clock/di"
 	"github.com/valentinesamuel/activelog/internal/platform/config"
 	"github.com/valentinesamuel/activelog/internal/platform/container"
+	"github.com/valentinesamuel/activelog/internal/adapters/storage/local"
 	"github.com/valentinesamuel/activelog/internal/adapters/storage/s3"
 	"github.com/valentinesamuel/activelog/internal/adapters/storage/types"
+	"github.com/valentinesamuel/activelog/pkg/clock"
 )
 
 // RegisterStorage registers the storage provider in the DI container
 // The provider is selected based on the STORAGE_PROVIDER configuration
 func RegisterStorage(c *container.Container) {
 	c.Register(StorageProviderKey, func(c *container.Container) (interface{}, error) {
-		return createProvider(), nil
+		return createProvider(c), nil
 	})
 }
 
 // createProvider creates the appropriate storage provider based on configuration
-func createProvider() types.StorageProvider {
+func createProvider(c *container.Container) types.StorageProvider {
 	switch config.Storage.Provider {
 	case "s3":
 		provider, err := s3.New()
@@ -30,8 +33,14 @@ func createProvider() types.StorageProvider {
 		return provider
 
 	case "local":
-		log.Printf("Warning: Local storage provider not yet implemented")
-		return nil
+		provider, err := local.New()
+		if err != nil {
+			log.Printf("Warning: Failed to initialize local storage provider: %v. Storage operations will fail.", err)
+			return nil
+		}
+		log.Printf("💾 Storage provider initialized: local (directory: %s)", config.Storage.Local.Dir)
+		// Its URLs are checked by the files handler, on the same clock
+		return provider.WithClock(container.MustResolve[clock.Clock](c, clockDI.ClockKey))
 
 	case "supabase":
 		log.Printf("Warning: Supabase storage provider not yet implemented")
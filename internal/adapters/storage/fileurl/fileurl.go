@@ -0,0 +1,88 @@
+// Package fileurl builds and checks the signed URLs of the files handler
+// (GET /api/v1/files/{key}), through which the API hands out stored photos
+// and exports. The signature covers the key, the download filename and the
+// expiry, so none of them can be changed without invalidating the URL.
+package fileurl
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+)
+
+// Path is the route of the files handler; the storage key follows it,
+// base64url-encoded so that it is a single path segment
+const Path = "/api/v1/files/"
+
+var (
+	// ErrInvalidSignature is returned for a URL that wasn't signed by the
+	// API, or whose key, filename or expiry was changed
+	ErrInvalidSignature = errors.New("invalid file URL signature")
+
+	// ErrExpired is returned for a correctly signed URL past its expiry
+	ErrExpired = errors.New("file URL has expired")
+)
+
+// For returns a signed URL serving key for config.Storage.FileURLTTL from
+// now. With a filename the file downloads under that name; without one
+// browsers display it inline.
+func For(key, filename string, now time.Time) string {
+	return Build(key, filename, now.Add(config.Storage.FileURLTTL))
+}
+
+// Build returns a signed URL serving key until expires
+func Build(key, filename string, expires time.Time) string {
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires.Unix(), 10))
+	if filename != "" {
+		query.Set("filename", filename)
+	}
+	query.Set("signature", sign(key, filename, expires.Unix()))
+	return strings.TrimSuffix(config.Storage.FilesBaseURL, "/") + Path + EncodeKey(key) + "?" + query.Encode()
+}
+
+// Verify checks the query of a files URL for key, and returns when it
+// expires and the filename to download the file as
+func Verify(key string, query url.Values, now time.Time) (expires time.Time, filename string, err error) {
+	unix, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil {
+		return time.Time{}, "", ErrInvalidSignature
+	}
+	filename = query.Get("filename")
+	if strings.ContainsRune(key+filename, 0) || !hmac.Equal([]byte(query.Get("signature")), []byte(sign(key, filename, unix))) {
+		return time.Time{}, "", ErrInvalidSignature
+	}
+	expires = time.Unix(unix, 0)
+	if now.After(expires) {
+		return time.Time{}, "", ErrExpired
+	}
+	return expires, filename, nil
+}
+
+// EncodeKey encodes a storage key as the path segment of a files URL
+func EncodeKey(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+// DecodeKey decodes the path segment of a files URL into the storage key
+func DecodeKey(segment string) (string, error) {
+	key, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil || len(key) == 0 {
+		return "", ErrInvalidSignature
+	}
+	return string(key), nil
+}
+
+func sign(key, filename string, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(config.Common.Auth.JWTSecret))
+	// Verify rejects keys and filenames with NUL, so fields can't be shifted
+	mac.Write([]byte("file:" + key + "\x00" + filename + "\x00" + strconv.FormatInt(expires, 10)))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,253 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/storage/fileurl"
+	"github.com/valentinesamuel/activelog/internal/adapters/storage/types"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+)
+
+// Provider implements the StorageProvider interface on the local disk, for
+// development without S3. Keys are paths below the root directory, and
+// presigned GET URLs are signed URLs of the API's files handler, which
+// serves the files itself.
+type Provider struct {
+	root  string
+	clock clock.Clock
+}
+
+// New creates a local storage provider rooted at config.Storage.Local.Dir
+func New() (*Provider, error) {
+	return NewAt(config.Storage.Local.Dir)
+}
+
+// NewAt creates a local storage provider rooted at dir, creating it if needed
+func NewAt(dir string) (*Provider, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("%w: local storage directory not configured", types.ErrProviderNotConfigured)
+	}
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	return &Provider{root: root, clock: clock.Real{}}, nil
+}
+
+// WithClock sets the clock the signed URLs expire by
+func (p *Provider) WithClock(c clock.Clock) *Provider {
+	p.clock = c
+	return p
+}
+
+// path returns the file of key, rejecting keys that would escape the root
+func (p *Provider) path(key string) (string, error) {
+	if key == "" || strings.ContainsRune(key, 0) || path.IsAbs(key) {
+		return "", types.ErrInvalidKey
+	}
+	clean := path.Clean(key)
+	if clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", types.ErrInvalidKey
+	}
+	return filepath.Join(p.root, filepath.FromSlash(clean)), nil
+}
+
+// Upload stores a file, replacing any file with the same key
+func (p *Provider) Upload(ctx context.Context, input *types.UploadInput) (*types.UploadOutput, error) {
+	file, err := p.path(input.Key)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0o750); err != nil {
+		return nil, fmt.Errorf("%w: %v", types.ErrUploadFailed, err)
+	}
+
+	// Write to a temporary file first so readers never see a partial file
+	tmp, err := os.CreateTemp(filepath.Dir(file), ".upload-*")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", types.ErrUploadFailed, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, input.Body); err != nil {
+		tmp.Close()
+		return nil, fmt.Errorf("%w: %v", types.ErrUploadFailed, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("%w: %v", types.ErrUploadFailed, err)
+	}
+	if err := os.Rename(tmp.Name(), file); err != nil {
+		return nil, fmt.Errorf("%w: %v", types.ErrUploadFailed, err)
+	}
+
+	return &types.UploadOutput{
+		Key:        input.Key,
+		URL:        fileurl.For(input.Key, "", p.clock.Now()),
+		UploadedAt: p.clock.Now(),
+	}, nil
+}
+
+// Download retrieves a file by key
+func (p *Provider) Download(ctx context.Context, key string) (io.ReadCloser, *types.FileMetadata, error) {
+	return p.Open(ctx, key)
+}
+
+// Open opens a file by key for serving, with range requests
+func (p *Provider) Open(ctx context.Context, key string) (io.ReadSeekCloser, *types.FileMetadata, error) {
+	file, err := p.path(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := os.Open(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, types.ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		f.Close()
+		return nil, nil, types.ErrNotFound
+	}
+	return f, metadata(key, info), nil
+}
+
+// Delete removes a file by key; a missing file is not an error
+func (p *Provider) Delete(ctx context.Context, key string) error {
+	file, err := p.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(file); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
+
+// DeleteMultiple removes files by key, returning the failures by key
+func (p *Provider) DeleteMultiple(ctx context.Context, keys []string) (map[string]error, error) {
+	errs := make(map[string]error)
+	for _, key := range keys {
+		if err := p.Delete(ctx, key); err != nil {
+			errs[key] = err
+		}
+	}
+	if len(errs) > 0 {
+		return errs, nil
+	}
+	return nil, nil
+}
+
+// List returns the files whose key starts with the prefix, in key order.
+// Marker is the last key of the previous page.
+func (p *Provider) List(ctx context.Context, input *types.ListInput) (*types.ListOutput, error) {
+	var files []types.FileMetadata
+	err := filepath.WalkDir(p.root, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".upload-") {
+			return nil
+		}
+		rel, err := filepath.Rel(p.root, file)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, input.Prefix) || (input.Marker != "" && key <= input.Marker) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files = append(files, *metadata(key, info))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Key < files[j].Key })
+
+	output := &types.ListOutput{Files: files}
+	if input.MaxKeys > 0 && len(files) > input.MaxKeys {
+		output.Files = files[:input.MaxKeys]
+		output.IsTruncated = true
+		output.NextMarker = output.Files[input.MaxKeys-1].Key
+	}
+	return output, nil
+}
+
+// Exists checks if a file exists
+func (p *Provider) Exists(ctx context.Context, key string) (bool, error) {
+	if _, err := p.GetMetadata(ctx, key); err != nil {
+		if errors.Is(err, types.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// GetPresignedURL returns a signed URL of the files handler for GET; the
+// local disk can't take uploads from presigned PUT URLs
+func (p *Provider) GetPresignedURL(ctx context.Context, input *types.PresignedURLInput) (string, error) {
+	if _, err := p.path(input.Key); err != nil {
+		return "", err
+	}
+	if input.Operation != types.PresignGet {
+		return "", fmt.Errorf("unsupported presign operation for local storage: %s", input.Operation)
+	}
+
+	expiry := input.ExpiresIn
+	if expiry == 0 {
+		expiry = 15 * time.Minute // Default expiry
+	}
+	return fileurl.Build(input.Key, input.Filename, p.clock.Now().Add(expiry)), nil
+}
+
+// GetMetadata retrieves file metadata without opening the file
+func (p *Provider) GetMetadata(ctx context.Context, key string) (*types.FileMetadata, error) {
+	file, err := p.path(key)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(file)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && info.IsDir()) {
+		return nil, types.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file metadata: %w", err)
+	}
+	return metadata(key, info), nil
+}
+
+// metadata describes a file; the content type follows from the key's
+// extension, as every key the API writes has one
+func metadata(key string, info fs.FileInfo) *types.FileMetadata {
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return &types.FileMetadata{
+		Key:          key,
+		Size:         info.Size(),
+		ContentType:  contentType,
+		LastModified: info.ModTime(),
+	}
+}
@@ -0,0 +1,66 @@
+package local
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/storage/types"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+)
+
+func TestProvider(t *testing.T) {
+	config.Common = &config.CommonConfig{Auth: config.AuthConfig{JWTSecret: "test-secret"}}
+	config.Storage = &config.StorageConfigType{FilesBaseURL: "http://api.test", FileURLTTL: 15 * time.Minute}
+	ctx := context.Background()
+	p, err := NewAt(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"avatars/1/a.png", "exports/1/b.csv", "exports/1/c.zip", "exports/2/d.csv"} {
+		_, err := p.Upload(ctx, &types.UploadInput{Key: key, Body: strings.NewReader("data of " + key)})
+		require.NoError(t, err)
+	}
+
+	f, meta, err := p.Open(ctx, "exports/1/b.csv")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(f)
+	f.Close()
+	assert.Equal(t, "data of exports/1/b.csv", string(body))
+	assert.Equal(t, "text/csv; charset=utf-8", meta.ContentType)
+	assert.Equal(t, int64(len(body)), meta.Size)
+
+	page, err := p.List(ctx, &types.ListInput{Prefix: "exports/", MaxKeys: 2})
+	require.NoError(t, err)
+	assert.True(t, page.IsTruncated)
+	assert.Equal(t, []string{"exports/1/b.csv", "exports/1/c.zip"}, []string{page.Files[0].Key, page.Files[1].Key})
+	page, err = p.List(ctx, &types.ListInput{Prefix: "exports/", MaxKeys: 2, Marker: page.NextMarker})
+	require.NoError(t, err)
+	assert.False(t, page.IsTruncated)
+	require.Len(t, page.Files, 1)
+	assert.Equal(t, "exports/2/d.csv", page.Files[0].Key)
+
+	require.NoError(t, p.Delete(ctx, "exports/1/b.csv"))
+	require.NoError(t, p.Delete(ctx, "exports/1/b.csv"), "deleting twice is not an error")
+	exists, err := p.Exists(ctx, "exports/1/b.csv")
+	require.NoError(t, err)
+	assert.False(t, exists)
+	_, _, err = p.Open(ctx, "exports/1/b.csv")
+	assert.ErrorIs(t, err, types.ErrNotFound)
+}
+
+func TestProvider_RejectsKeysOutsideRoot(t *testing.T) {
+	p, err := NewAt(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "../secret", "exports/../../secret", "/etc/passwd", "a\x00b"} {
+		_, _, err := p.Open(context.Background(), key)
+		assert.ErrorIs(t, err, types.ErrInvalidKey, key)
+	}
+	_, _, err = p.Open(context.Background(), "exports/../avatars/1/a.png")
+	assert.ErrorIs(t, err, types.ErrNotFound, "keys that stay inside the root are allowed")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"mime"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -287,10 +288,14 @@ func (p *Provider) GetPresignedURL(ctx context.Context, input *types.PresignedUR
 
 	switch input.Operation {
 	case types.PresignGet:
-		result, presignErr := p.presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
+		getInput := &s3.GetObjectInput{
 			Bucket: aws.String(p.bucket),
 			Key:    aws.String(input.Key),
-		}, s3.WithPresignExpires(expiry))
+		}
+		if input.Filename != "" {
+			getInput.ResponseContentDisposition = aws.String(mime.FormatMediaType("attachment", map[string]string{"filename": input.Filename}))
+		}
+		result, presignErr := p.presignClient.PresignGetObject(ctx, getInput, s3.WithPresignExpires(expiry))
 		if presignErr != nil {
 			err = presignErr
 		} else {
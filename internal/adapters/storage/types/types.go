@@ -59,6 +59,7 @@ type PresignedURLInput struct {
 	Key       string
 	ExpiresIn time.Duration
 	Operation PresignOperation
+	Filename  string // GET only: download under this name (Content-Disposition: attachment)
 }
 
 // StorageProvider defines the interface for object storage operations
@@ -91,3 +92,12 @@ type StorageProvider interface {
 	// GetMetadata retrieves file metadata without downloading the file
 	GetMetadata(ctx context.Context, key string) (*FileMetadata, error)
 }
+
+// FileOpener is implemented by providers whose files the API serves itself
+// (the local disk), as opposed to redirecting to a presigned URL. The
+// returned file is seekable, for range requests.
+type FileOpener interface {
+	// Open opens a file by key
+	// Caller is responsible for closing the returned ReadSeekCloser
+	Open(ctx context.Context, key string) (io.ReadSeekCloser, *FileMetadata, error)
+}
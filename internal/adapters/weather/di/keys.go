@@ -0,0 +1,7 @@
+package di
+
+// Container registration keys for weather
+const (
+	// WeatherProviderKey is the key for the active weather provider
+	WeatherProviderKey = "WeatherProvider"
+)
@@ -0,0 +1,24 @@
+package di
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/weather/openmeteo"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	"github.com/valentinesamuel/activelog/internal/platform/container"
+)
+
+// RegisterWeather registers the weather provider in the DI container.
+// Resolving it fails when enrichment is disabled (WEATHER_PROVIDER=none).
+func RegisterWeather(c *container.Container) {
+	c.Register(WeatherProviderKey, func(c *container.Container) (interface{}, error) {
+		switch config.Weather.Provider {
+		case "openmeteo":
+			log.Printf("Weather provider initialized: openmeteo (%d requests/min)", config.Weather.RatePerMinute)
+			return openmeteo.New(), nil
+		default:
+			return nil, fmt.Errorf("weather: no provider configured (WEATHER_PROVIDER=%s)", config.Weather.Provider)
+		}
+	})
+}
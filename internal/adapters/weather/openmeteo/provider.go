@@ -0,0 +1,148 @@
+package openmeteo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/weather/types"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	"github.com/valentinesamuel/activelog/pkg/httpclient"
+)
+
+// archiveLag is how far behind the archive API runs; more recent weather
+// comes from the forecast API, which also serves the past few months
+const archiveLag = 7 * 24 * time.Hour
+
+// hourLayout is the format of the hourly timestamps in responses (GMT)
+const hourLayout = "2006-01-02T15:04"
+
+// Provider looks up historical weather with the Open-Meteo API.
+// Requests are rate limited per process (WEATHER_RATE_PER_MINUTE).
+type Provider struct {
+	client      *http.Client
+	limiter     *rate.Limiter
+	archiveURL  string
+	forecastURL string
+	apiKey      string
+	now         func() time.Time
+}
+
+// New creates a Provider from the global weather config.
+func New() *Provider {
+	cfg := config.Weather
+	return &Provider{
+		client:      httpclient.New(httpclient.Config{Name: "openmeteo", Timeout: cfg.Timeout}),
+		limiter:     rate.NewLimiter(rate.Limit(float64(cfg.RatePerMinute)/60), 1),
+		archiveURL:  cfg.ArchiveURL,
+		forecastURL: cfg.ForecastURL,
+		apiKey:      cfg.APIKey,
+		now:         time.Now,
+	}
+}
+
+// hourlyResponse is the part of an Open-Meteo response we read
+type hourlyResponse struct {
+	Hourly struct {
+		Time          []string   `json:"time"`
+		Temperature2m []*float64 `json:"temperature_2m"`
+		WeatherCode   []*int     `json:"weather_code"`
+	} `json:"hourly"`
+}
+
+// Historical returns the weather for the hour containing at
+func (p *Provider) Historical(ctx context.Context, lat, lng float64, at time.Time) (*types.Observation, error) {
+	if err := p.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	at = at.UTC()
+	endpoint := p.archiveURL
+	if p.now().Sub(at) < archiveLag {
+		endpoint = p.forecastURL
+	}
+
+	day := at.Format(time.DateOnly)
+	params := url.Values{
+		"latitude":   {strconv.FormatFloat(lat, 'f', 4, 64)},
+		"longitude":  {strconv.FormatFloat(lng, 'f', 4, 64)},
+		"start_date": {day},
+		"end_date":   {day},
+		"hourly":     {"temperature_2m,weather_code"},
+		"timezone":   {"GMT"},
+	}
+	if p.apiKey != "" {
+		params.Set("apikey", p.apiKey)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("openmeteo: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// 400 means the date is outside what the endpoint serves
+	if resp.StatusCode == http.StatusBadRequest {
+		return nil, types.ErrNoData
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("openmeteo: unexpected status %d", resp.StatusCode)
+	}
+
+	var body hourlyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("openmeteo: decode response: %w", err)
+	}
+
+	hour := at.Truncate(time.Hour).Format(hourLayout)
+	hourly := body.Hourly
+	for i, t := range hourly.Time {
+		if t != hour {
+			continue
+		}
+		if i >= len(hourly.Temperature2m) || i >= len(hourly.WeatherCode) ||
+			hourly.Temperature2m[i] == nil || hourly.WeatherCode[i] == nil {
+			return nil, types.ErrNoData
+		}
+		return &types.Observation{
+			TemperatureC: *hourly.Temperature2m[i],
+			Conditions:   Conditions(*hourly.WeatherCode[i]),
+		}, nil
+	}
+
+	return nil, types.ErrNoData
+}
+
+// Conditions describes a WMO weather interpretation code
+func Conditions(code int) string {
+	switch {
+	case code == 0:
+		return "clear"
+	case code <= 2:
+		return "partly cloudy"
+	case code == 3:
+		return "overcast"
+	case code == 45 || code == 48:
+		return "fog"
+	case code >= 51 && code <= 57:
+		return "drizzle"
+	case code >= 61 && code <= 67, code >= 80 && code <= 82:
+		return "rain"
+	case code >= 71 && code <= 77, code == 85 || code == 86:
+		return "snow"
+	case code >= 95:
+		return "thunderstorm"
+	default:
+		return "unknown"
+	}
+}
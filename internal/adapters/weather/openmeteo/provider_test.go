@@ -0,0 +1,58 @@
+package openmeteo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/weather/types"
+)
+
+func newTestProvider(t *testing.T, handler http.HandlerFunc) *Provider {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return &Provider{
+		client:      server.Client(),
+		limiter:     rate.NewLimiter(rate.Inf, 1),
+		archiveURL:  server.URL + "/archive",
+		forecastURL: server.URL + "/forecast",
+		now:         func() time.Time { return time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC) },
+	}
+}
+
+func TestHistorical(t *testing.T) {
+	provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/archive", r.URL.Path)
+		assert.Equal(t, "2026-03-01", r.URL.Query().Get("start_date"))
+		assert.Equal(t, "51.5072", r.URL.Query().Get("latitude"))
+		w.Write([]byte(`{"hourly":{
+			"time":["2026-03-01T06:00","2026-03-01T07:00"],
+			"temperature_2m":[3.2,4.1],
+			"weather_code":[3,61]
+		}}`))
+	})
+
+	at := time.Date(2026, time.March, 1, 7, 45, 0, 0, time.UTC)
+	obs, err := provider.Historical(context.Background(), 51.5072, -0.1276, at)
+	require.NoError(t, err)
+	assert.Equal(t, 4.1, obs.TemperatureC)
+	assert.Equal(t, "rain", obs.Conditions)
+}
+
+func TestHistorical_RecentUsesForecast(t *testing.T) {
+	provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/forecast", r.URL.Path)
+		w.Write([]byte(`{"hourly":{"time":["2026-03-30T07:00"],"temperature_2m":[null],"weather_code":[null]}}`))
+	})
+
+	_, err := provider.Historical(context.Background(), 0, 0, time.Date(2026, time.March, 30, 7, 0, 0, 0, time.UTC))
+	assert.ErrorIs(t, err, types.ErrNoData)
+}
@@ -0,0 +1,22 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNoData is returned when the provider has no weather for the place and time
+var ErrNoData = errors.New("weather: no data for this place and time")
+
+// Observation is the weather at a place and time
+type Observation struct {
+	TemperatureC float64
+	Conditions   string // short description, e.g. "rain" or "partly cloudy"
+}
+
+// WeatherProvider is the interface all weather backends must implement.
+type WeatherProvider interface {
+	// Historical returns the weather at lat/lng at the given time
+	Historical(ctx context.Context, lat, lng float64, at time.Time) (*Observation, error)
+}
@@ -12,8 +12,9 @@ import (
 	"net/http"
 	"time"
 
-	"github.com/valentinesamuel/activelog/internal/repository"
 	webhookTypes "github.com/valentinesamuel/activelog/internal/adapters/webhook/types"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/pkg/httpclient"
 )
 
 var retryDelays = []time.Duration{
@@ -36,7 +37,12 @@ type Delivery struct {
 func NewDelivery(webhookRepo *repository.WebhookRepository) *Delivery {
 	return &Delivery{
 		webhookRepo: webhookRepo,
-		httpClient:  &http.Client{Timeout: 10 * time.Second},
+		httpClient: httpclient.New(httpclient.Config{
+			Name:    "webhook",
+			Timeout: 10 * time.Second,
+			// Failed deliveries are retried on the retryDelays schedule
+			MaxAttempts: 1,
+		}),
 	}
 }
 
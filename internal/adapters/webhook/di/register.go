@@ -1,6 +1,7 @@
 package di
 
 import (
+	"context"
 	"log"
 
 	"github.com/valentinesamuel/activelog/internal/platform/config"
@@ -24,7 +25,7 @@ func RegisterWebhookBus(c *container.Container) {
 // RegisterWebhookDelivery registers the webhook delivery handler in the DI container
 func RegisterWebhookDelivery(c *container.Container) {
 	c.Register(WebhookDeliveryKey, func(c *container.Container) (interface{}, error) {
-		repo := c.MustResolve(repoDI.WebhookRepoKey).(*repository.WebhookRepository)
+		repo := container.MustResolve[*repository.WebhookRepository](c, repoDI.WebhookRepoKey)
 		return webhook.NewDelivery(repo), nil
 	})
 }
@@ -32,9 +33,27 @@ func RegisterWebhookDelivery(c *container.Container) {
 // RegisterRetryWorker registers the webhook retry worker in the DI container
 func RegisterRetryWorker(c *container.Container) {
 	c.Register(RetryWorkerKey, func(c *container.Container) (interface{}, error) {
-		repo := c.MustResolve(repoDI.WebhookRepoKey).(*repository.WebhookRepository)
-		delivery := c.MustResolve(WebhookDeliveryKey).(*webhook.Delivery)
-		return webhook.NewRetryWorker(repo, delivery), nil
+		repo := container.MustResolve[*repository.WebhookRepository](c, repoDI.WebhookRepoKey)
+		delivery := container.MustResolve[*webhook.Delivery](c, WebhookDeliveryKey)
+		worker := webhook.NewRetryWorker(repo, delivery)
+		// Stopping a worker that was never started is a no-op; see PollWebhookRetries
+		c.OnStop(RetryWorkerKey, worker.Stop)
+		return worker, nil
+	})
+}
+
+// PollWebhookRetries starts the retry worker's polling loop when the container
+// starts. Processes that only sweep retries on a schedule (the worker) don't
+// call it.
+func PollWebhookRetries(c *container.Container) {
+	c.OnStart(RetryWorkerKey, func(context.Context) error {
+		worker, err := container.Resolve[*webhook.RetryWorker](c, RetryWorkerKey)
+		if err != nil {
+			return err
+		}
+		// The loop outlives the start hook, so it gets its own context
+		worker.Start(context.Background())
+		return nil
 	})
 }
 
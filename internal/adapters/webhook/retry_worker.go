@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/valentinesamuel/activelog/internal/repository"
@@ -16,6 +17,8 @@ const retryPollInterval = 30 * time.Second
 type RetryWorker struct {
 	webhookRepo *repository.WebhookRepository
 	delivery    *Delivery
+	cancel      context.CancelFunc
+	done        chan struct{}
 }
 
 // NewRetryWorker creates a new RetryWorker
@@ -23,9 +26,29 @@ func NewRetryWorker(repo *repository.WebhookRepository, delivery *Delivery) *Ret
 	return &RetryWorker{webhookRepo: repo, delivery: delivery}
 }
 
-// Start launches the retry polling loop in a goroutine
+// Start launches the retry polling loop in a goroutine. The loop runs until
+// ctx is done or Stop is called.
 func (w *RetryWorker) Start(ctx context.Context) {
-	go w.run(ctx)
+	ctx, w.cancel = context.WithCancel(ctx)
+	w.done = make(chan struct{})
+	go func() {
+		defer close(w.done)
+		w.run(ctx)
+	}()
+}
+
+// Stop ends the polling loop and waits for it to return or ctx to expire
+func (w *RetryWorker) Stop(ctx context.Context) error {
+	if w.cancel == nil {
+		return nil
+	}
+	w.cancel()
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 func (w *RetryWorker) run(ctx context.Context) {
@@ -42,6 +65,20 @@ func (w *RetryWorker) run(ctx context.Context) {
 	}
 }
 
+// Sweep retries every delivery that is due and waits for the attempts to finish
+// Used by the scheduled webhook retry sweep job to catch anything the poller missed
+func (w *RetryWorker) Sweep(ctx context.Context) error {
+	deliveries, err := w.webhookRepo.ListPendingRetries(ctx, 100)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	w.dispatch(ctx, deliveries, &wg)
+	wg.Wait()
+	return nil
+}
+
 func (w *RetryWorker) poll(ctx context.Context) {
 	deliveries, err := w.webhookRepo.ListPendingRetries(ctx, 100)
 	if err != nil {
@@ -49,6 +86,11 @@ func (w *RetryWorker) poll(ctx context.Context) {
 		return
 	}
 
+	w.dispatch(ctx, deliveries, nil)
+}
+
+// dispatch starts a retry for each delivery; wg, if non-nil, tracks the attempts
+func (w *RetryWorker) dispatch(ctx context.Context, deliveries []*webhookTypes.WebhookDelivery, wg *sync.WaitGroup) {
 	for _, d := range deliveries {
 		wh, err := w.webhookRepo.GetByID(ctx, d.WebhookID)
 		if err != nil {
@@ -62,7 +104,15 @@ func (w *RetryWorker) poll(ctx context.Context) {
 			continue
 		}
 
-		go w.retryDelivery(ctx, wh, d, event)
+		if wg == nil {
+			go w.retryDelivery(ctx, wh, d, event)
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.retryDelivery(ctx, wh, d, event)
+		}()
 	}
 }
 
@@ -25,6 +25,9 @@ const (
 	EventActivityCreated = "activity.created"
 	EventActivityDeleted = "activity.deleted"
 	EventActivityUpdated = "activity.updated"
+
+	// EventReactionCreated is sent to the activity owner when someone else reacts
+	EventReactionCreated = "reaction.created"
 )
 
 // Webhook represents a registered webhook endpoint
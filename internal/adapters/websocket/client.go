@@ -3,6 +3,7 @@ package websocket
 import (
 	"encoding/json"
 	"log"
+	"sync"
 	"time"
 
 	gorillaws "github.com/gorilla/websocket"
@@ -13,6 +14,13 @@ const (
 	pongWait       = 60 * time.Second
 	pingPeriod     = 54 * time.Second // must be less than pongWait
 	maxMessageSize = 512
+
+	// sendBufferSize is the number of messages queued for the write pump
+	sendBufferSize = 256
+
+	// maxUnacked is the number of channel messages a client may leave
+	// unacknowledged; further messages are dropped until it catches up
+	maxUnacked = 100
 )
 
 // Client is a middleman between the WebSocket connection and the hub
@@ -21,23 +29,143 @@ type Client struct {
 	conn   *gorillaws.Conn
 	userID int
 	send   chan Message
+
+	mu            sync.Mutex
+	subscriptions map[Channel]bool
+	seq           uint64 // last Seq handed out
+	acked         uint64 // highest Seq the client has acked
+	dropped       int    // messages dropped since the last resync
+	closed        bool
 }
 
-// newClient creates a new Client
+// newClient creates a new Client subscribed to ChannelNotifications
 func newClient(hub *Hub, conn *gorillaws.Conn, userID int) *Client {
 	return &Client{
 		hub:    hub,
 		conn:   conn,
 		userID: userID,
-		send:   make(chan Message, 256),
+		send:   make(chan Message, sendBufferSize),
+		subscriptions: map[Channel]bool{
+			ChannelNotifications: true,
+		},
+	}
+}
+
+// deliver queues a channel message if the client is subscribed and within its
+// ack window. Messages that don't fit are counted and reported with a resync
+// once the client acks again, so a slow client never blocks the hub.
+func (c *Client) deliver(msg Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed || !c.subscriptions[msg.Channel] {
+		return
+	}
+	if c.seq-c.acked >= maxUnacked {
+		c.dropped++
+		return
+	}
+
+	msg.Seq = c.seq + 1
+	select {
+	case c.send <- msg:
+		c.seq++
+	default:
+		c.dropped++
+	}
+}
+
+// reply queues a control message (no Seq, not subject to the ack window)
+func (c *Client) reply(msgType string, payload any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.replyLocked(msgType, payload)
+}
+
+func (c *Client) replyLocked(msgType string, payload any) {
+	if c.closed {
+		return
+	}
+	select {
+	case c.send <- Message{Type: msgType, UserID: c.userID, Payload: payload, Timestamp: time.Now().UTC()}:
+	default:
+		// Client buffer full; drop reply
+	}
+}
+
+// close stops delivery and closes the send channel (called by the hub once)
+func (c *Client) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.closed {
+		c.closed = true
+		close(c.send)
+	}
+}
+
+// handleMessage applies one message read from the connection
+func (c *Client) handleMessage(data []byte) {
+	var msg ClientMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		c.reply(MsgError, map[string]string{"error": "invalid message"})
+		return
+	}
+
+	switch msg.Type {
+	case ClientSubscribe, ClientUnsubscribe:
+		c.mu.Lock()
+		for _, ch := range msg.Channels {
+			if !validChannel(ch) {
+				c.replyLocked(MsgError, map[string]string{"error": "unknown channel", "channel": string(ch)})
+				continue
+			}
+			c.subscriptions[ch] = msg.Type == ClientSubscribe
+		}
+		replyType := MsgSubscribed
+		if msg.Type == ClientUnsubscribe {
+			replyType = MsgUnsubscribed
+		}
+		c.replyLocked(replyType, map[string]any{"channels": c.channelsLocked()})
+		c.mu.Unlock()
+
+	case ClientPing:
+		c.reply(MsgPong, map[string]any{"id": msg.ID})
+
+	case ClientAck:
+		c.mu.Lock()
+		if msg.Seq > c.acked && msg.Seq <= c.seq {
+			c.acked = msg.Seq
+		}
+		if c.dropped > 0 && c.seq-c.acked < maxUnacked {
+			c.replyLocked(MsgResync, map[string]int{"dropped": c.dropped})
+			c.dropped = 0
+		}
+		c.mu.Unlock()
+
+	default:
+		c.reply(MsgError, map[string]string{"error": "unknown message type", "type": msg.Type})
+	}
+}
+
+// channelsLocked lists the channels the client is subscribed to
+func (c *Client) channelsLocked() []Channel {
+	channels := make([]Channel, 0, len(c.subscriptions))
+	for _, ch := range []Channel{ChannelActivities, ChannelNotifications} {
+		if c.subscriptions[ch] {
+			channels = append(channels, ch)
+		}
 	}
+	return channels
 }
 
-// readPump pumps messages from the WebSocket connection to the hub.
+// readPump pumps messages from the WebSocket connection to the client.
 // The application runs readPump in a goroutine per connection.
 func (c *Client) readPump() {
 	defer func() {
-		c.hub.unregister <- c
+		select {
+		case c.hub.unregister <- c:
+		case <-c.hub.done:
+		}
 		c.conn.Close()
 	}()
 
@@ -49,13 +177,17 @@ func (c *Client) readPump() {
 	})
 
 	for {
-		_, _, err := c.conn.ReadMessage()
+		_, data, err := c.conn.ReadMessage()
 		if err != nil {
 			if gorillaws.IsUnexpectedCloseError(err, gorillaws.CloseGoingAway, gorillaws.CloseAbnormalClosure) {
 				log.Printf("websocket read error for user %d: %v", c.userID, err)
 			}
 			break
 		}
+
+		// Any client message proves the connection is alive
+		c.conn.SetReadDeadline(time.Now().Add(pongWait))
+		c.handleMessage(data)
 	}
 }
 
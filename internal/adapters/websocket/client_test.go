@@ -0,0 +1,74 @@
+package websocket
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// drain returns every message queued for the client
+func drain(c *Client) []Message {
+	var msgs []Message
+	for {
+		select {
+		case msg := <-c.send:
+			msgs = append(msgs, msg)
+		default:
+			return msgs
+		}
+	}
+}
+
+func TestClient_SubscribeAndDeliver(t *testing.T) {
+	c := newClient(NewHub(), nil, 1)
+
+	// Not subscribed to activities yet
+	c.deliver(Message{Type: MsgActivityCreated, Channel: ChannelActivities})
+	assert.Empty(t, drain(c))
+
+	c.handleMessage([]byte(`{"type":"subscribe","channels":["activities"]}`))
+	msgs := drain(c)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, MsgSubscribed, msgs[0].Type)
+
+	c.deliver(Message{Type: MsgActivityCreated, Channel: ChannelActivities})
+	c.deliver(Message{Type: MsgActivityLiked, Channel: ChannelNotifications})
+	msgs = drain(c)
+	require.Len(t, msgs, 2)
+	assert.Equal(t, uint64(1), msgs[0].Seq)
+	assert.Equal(t, uint64(2), msgs[1].Seq)
+}
+
+func TestClient_PingPong(t *testing.T) {
+	c := newClient(NewHub(), nil, 1)
+
+	c.handleMessage([]byte(`{"type":"ping","id":"42"}`))
+
+	msgs := drain(c)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, MsgPong, msgs[0].Type)
+	assert.Zero(t, msgs[0].Seq)
+}
+
+func TestClient_BackpressureDropsAndResyncs(t *testing.T) {
+	c := newClient(NewHub(), nil, 1)
+
+	for i := 0; i < maxUnacked+5; i++ {
+		c.deliver(Message{Type: MsgActivityLiked, Channel: ChannelNotifications})
+	}
+	assert.Len(t, drain(c), maxUnacked)
+
+	c.handleMessage([]byte(`{"type":"ack","seq":100}`))
+
+	msgs := drain(c)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, MsgResync, msgs[0].Type)
+	assert.Equal(t, map[string]int{"dropped": 5}, msgs[0].Payload)
+
+	// Window is open again
+	c.deliver(Message{Type: MsgActivityLiked, Channel: ChannelNotifications})
+	msgs = drain(c)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, uint64(maxUnacked+1), msgs[0].Seq)
+}
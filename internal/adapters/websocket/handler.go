@@ -3,6 +3,7 @@ package websocket
 import (
 	"log"
 	"net/http"
+	"strings"
 
 	gorillaws "github.com/gorilla/websocket"
 	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
@@ -28,7 +29,9 @@ func NewHandler(hub *Hub) *Handler {
 	return &Handler{hub: hub}
 }
 
-// ServeWS upgrades an HTTP connection to WebSocket and registers the client
+// ServeWS upgrades an HTTP connection to WebSocket and registers the client.
+// The user is authenticated before the upgrade (AuthMiddleware). An optional
+// ?channels=activities,notifications replaces the default subscriptions.
 func (h *Handler) ServeWS(w http.ResponseWriter, r *http.Request) {
 	user, ok := requestcontext.FromContext(r.Context())
 	if !ok {
@@ -43,7 +46,21 @@ func (h *Handler) ServeWS(w http.ResponseWriter, r *http.Request) {
 	}
 
 	client := newClient(h.hub, conn, user.Id)
-	h.hub.register <- client
+	if channels := r.URL.Query().Get("channels"); channels != "" {
+		client.subscriptions = make(map[Channel]bool)
+		for _, ch := range strings.Split(channels, ",") {
+			if ch := Channel(strings.TrimSpace(ch)); validChannel(ch) {
+				client.subscriptions[ch] = true
+			}
+		}
+	}
+	select {
+	case h.hub.register <- client:
+	case <-h.hub.done:
+		// Shutting down
+		conn.Close()
+		return
+	}
 
 	go client.writePump()
 	go client.readPump()
@@ -1,53 +1,77 @@
 package websocket
 
 import (
+	"context"
+	"strings"
 	"sync"
 	"time"
+
+	webhookTypes "github.com/valentinesamuel/activelog/internal/adapters/webhook/types"
 )
 
-// Hub maintains the set of active clients and broadcasts messages to them
+// Hub maintains the set of active clients and routes messages to them.
+// A user may have several connections (one per device); each gets its own copy.
 type Hub struct {
-	clients    map[int]*Client
+	clients    map[int]map[*Client]bool
 	broadcast  chan Message
 	register   chan *Client
 	unregister chan *Client
+	done       chan struct{}
+	stopOnce   sync.Once
 	mu         sync.RWMutex
 }
 
 // NewHub creates a new Hub instance
 func NewHub() *Hub {
 	return &Hub{
-		clients:    make(map[int]*Client),
+		clients:    make(map[int]map[*Client]bool),
 		broadcast:  make(chan Message, 256),
 		register:   make(chan *Client),
 		unregister: make(chan *Client),
+		done:       make(chan struct{}),
 	}
 }
 
 // Run starts the hub event loop. It must be run in its own goroutine.
+// It returns once Stop is called.
 func (h *Hub) Run() {
 	for {
 		select {
+		case <-h.done:
+			h.mu.Lock()
+			for userID, conns := range h.clients {
+				for client := range conns {
+					client.close()
+				}
+				delete(h.clients, userID)
+			}
+			h.mu.Unlock()
+			return
+
 		case client := <-h.register:
 			h.mu.Lock()
-			h.clients[client.userID] = client
+			if h.clients[client.userID] == nil {
+				h.clients[client.userID] = make(map[*Client]bool)
+			}
+			h.clients[client.userID][client] = true
 			h.mu.Unlock()
 
 		case client := <-h.unregister:
 			h.mu.Lock()
-			if _, ok := h.clients[client.userID]; ok {
-				delete(h.clients, client.userID)
-				close(client.send)
+			if conns, ok := h.clients[client.userID]; ok && conns[client] {
+				delete(conns, client)
+				if len(conns) == 0 {
+					delete(h.clients, client.userID)
+				}
+				client.close()
 			}
 			h.mu.Unlock()
 
 		case msg := <-h.broadcast:
 			h.mu.RLock()
-			for _, client := range h.clients {
-				select {
-				case client.send <- msg:
-				default:
-					// Client buffer full; drop message
+			for _, conns := range h.clients {
+				for client := range conns {
+					client.deliver(msg)
 				}
 			}
 			h.mu.RUnlock()
@@ -55,26 +79,43 @@ func (h *Hub) Run() {
 	}
 }
 
-// SendToUser delivers a message to a specific connected user
-func (h *Hub) SendToUser(userID int, msgType string, payload any) {
-	h.mu.RLock()
-	client, ok := h.clients[userID]
-	h.mu.RUnlock()
-
-	if !ok {
-		return
-	}
+// Stop ends the event loop and closes every client connection's send
+// channel, which makes its write pump close the connection
+func (h *Hub) Stop() {
+	h.stopOnce.Do(func() { close(h.done) })
+}
 
+// Publish delivers a message on channel to every connection of userID that is
+// subscribed to it
+func (h *Hub) Publish(userID int, channel Channel, msgType string, payload any) {
 	msg := Message{
 		Type:      msgType,
+		Channel:   channel,
 		UserID:    userID,
 		Payload:   payload,
 		Timestamp: time.Now().UTC(),
 	}
 
-	select {
-	case client.send <- msg:
-	default:
-		// Client buffer full; drop message
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for client := range h.clients[userID] {
+		client.deliver(msg)
+	}
+}
+
+// SendToUser delivers a notification to a specific connected user
+func (h *Hub) SendToUser(userID int, msgType string, payload any) {
+	h.Publish(userID, ChannelNotifications, msgType, payload)
+}
+
+// HandleEvent pushes activity events from the webhook bus to the owner's
+// connections on ChannelActivities, and reactions to ChannelNotifications.
+// Subscribe it to the bus alongside webhook delivery.
+func (h *Hub) HandleEvent(_ context.Context, event webhookTypes.WebhookEvent) {
+	switch {
+	case strings.HasPrefix(event.EventType, "activity."):
+		h.Publish(event.UserID, ChannelActivities, event.EventType, event.Payload)
+	case event.EventType == webhookTypes.EventReactionCreated:
+		h.SendToUser(event.UserID, MsgActivityLiked, event.Payload)
 	}
 }
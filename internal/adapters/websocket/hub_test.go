@@ -0,0 +1,26 @@
+package websocket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	webhookTypes "github.com/valentinesamuel/activelog/internal/adapters/webhook/types"
+)
+
+func TestHub_HandleEventRoutesReactionsToNotifications(t *testing.T) {
+	hub := NewHub()
+	c := newClient(hub, nil, 7)
+	hub.clients[7] = map[*Client]bool{c: true}
+
+	// Not subscribed to activities, so only the reaction arrives
+	hub.HandleEvent(context.Background(), webhookTypes.WebhookEvent{EventType: webhookTypes.EventActivityCreated, UserID: 7})
+	hub.HandleEvent(context.Background(), webhookTypes.WebhookEvent{EventType: webhookTypes.EventReactionCreated, UserID: 7})
+	hub.HandleEvent(context.Background(), webhookTypes.WebhookEvent{EventType: webhookTypes.EventReactionCreated, UserID: 8})
+
+	msgs := drain(c)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, MsgActivityLiked, msgs[0].Type)
+	assert.Equal(t, ChannelNotifications, msgs[0].Channel)
+}
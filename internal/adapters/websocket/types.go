@@ -1,19 +1,79 @@
 package websocket
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Message type constants
 const (
-	MsgFriendRequest  = "friend_request"
-	MsgFriendAccepted = "friend_accepted"
-	MsgActivityLiked  = "activity_liked"
+	MsgFriendRequest   = "friend_request"
+	MsgFriendAccepted  = "friend_accepted"
+	MsgActivityLiked   = "activity_liked"
 	MsgActivityComment = "activity_comment"
+
+	// Activity sync events pushed on ChannelActivities
+	MsgActivityCreated = "activity.created"
+	MsgActivityUpdated = "activity.updated"
+	MsgActivityDeleted = "activity.deleted"
+
+	// Control messages sent in reply to client messages; they carry no Seq
+	MsgSubscribed   = "subscribed"
+	MsgUnsubscribed = "unsubscribed"
+	MsgPong         = "pong"
+	MsgError        = "error"
+
+	// MsgResync tells the client that events were dropped because it fell
+	// behind on acks, so it should refetch instead of relying on the stream
+	MsgResync = "resync"
+)
+
+// Channel groups the messages a client can subscribe to
+type Channel string
+
+const (
+	// ChannelActivities carries create/update/delete events for the user's activities
+	ChannelActivities Channel = "activities"
+
+	// ChannelNotifications carries social notifications (likes, comments, friends).
+	// Clients are subscribed to it on connect.
+	ChannelNotifications Channel = "notifications"
 )
 
-// Message is the payload sent to WebSocket clients
+// validChannel reports whether clients may subscribe to ch
+func validChannel(ch Channel) bool {
+	return ch == ChannelActivities || ch == ChannelNotifications
+}
+
+// Message is the payload sent to WebSocket clients.
+// Seq numbers channel messages per connection; clients ack them (cumulatively)
+// with a ClientMessage of type "ack".
 type Message struct {
 	Type      string    `json:"type"`
+	Channel   Channel   `json:"channel,omitempty"`
+	Seq       uint64    `json:"seq,omitempty"`
 	UserID    int       `json:"user_id"`
 	Payload   any       `json:"payload"`
 	Timestamp time.Time `json:"timestamp"`
 }
+
+// Client message types
+const (
+	ClientSubscribe   = "subscribe"
+	ClientUnsubscribe = "unsubscribe"
+	ClientPing        = "ping"
+	ClientAck         = "ack"
+)
+
+// ClientMessage is a message sent by a client:
+//
+//	{"type": "subscribe", "channels": ["activities"]}
+//	{"type": "unsubscribe", "channels": ["notifications"]}
+//	{"type": "ping", "id": "42"}   -> {"type": "pong", "payload": {"id": "42"}}
+//	{"type": "ack", "seq": 17}     acknowledges every message up to seq 17
+type ClientMessage struct {
+	Type     string          `json:"type"`
+	Channels []Channel       `json:"channels,omitempty"`
+	ID       json.RawMessage `json:"id,omitempty"`
+	Seq      uint64          `json:"seq,omitempty"`
+}
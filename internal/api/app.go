@@ -0,0 +1,384 @@
+// Package api assembles the HTTP API: the DI container, the handlers it
+// resolves and the router serving them. cmd/api runs it behind an
+// http.Server; pkg/testkit boots it in process for black-box tests.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/valentinesamuel/activelog/docs"
+	cacheDI "github.com/valentinesamuel/activelog/internal/adapters/cache/di"
+	cacheTypes "github.com/valentinesamuel/activelog/internal/adapters/cache/types"
+	queueDI "github.com/valentinesamuel/activelog/internal/adapters/queue/di"
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/memory"
+	queueTypes "github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	"github.com/valentinesamuel/activelog/internal/adapters/webhook"
+	webhookDI "github.com/valentinesamuel/activelog/internal/adapters/webhook/di"
+	webhookTypes "github.com/valentinesamuel/activelog/internal/adapters/webhook/types"
+	appwebsocket "github.com/valentinesamuel/activelog/internal/adapters/websocket"
+	"github.com/valentinesamuel/activelog/internal/application/broker"
+	"github.com/valentinesamuel/activelog/internal/application/broker/di"
+	"github.com/valentinesamuel/activelog/internal/handlers"
+	handlerDI "github.com/valentinesamuel/activelog/internal/handlers/di"
+	"github.com/valentinesamuel/activelog/internal/middleware"
+	clockDI "github.com/valentinesamuel/activelog/internal/platform/clock/di"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	"github.com/valentinesamuel/activelog/internal/platform/container"
+	"github.com/valentinesamuel/activelog/internal/platform/featureflags"
+	"github.com/valentinesamuel/activelog/internal/platform/scheduler"
+	schedulerDI "github.com/valentinesamuel/activelog/internal/platform/scheduler/di"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	repositoryRegister "github.com/valentinesamuel/activelog/internal/repository/di"
+	"github.com/valentinesamuel/activelog/internal/routes"
+	"github.com/valentinesamuel/activelog/internal/service"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+	"github.com/valentinesamuel/activelog/pkg/database"
+	"github.com/valentinesamuel/activelog/pkg/query"
+)
+
+// Application holds all dependencies
+type Application struct {
+	DB                  repository.DBConn
+	Container           *container.Container    // DI container
+	Broker              *broker.Broker          // Use case orchestrator
+	Scheduler           *scheduler.Scheduler    // Cron scheduler
+	RateLimiter         *middleware.RateLimiter // Rate limiting middleware
+	Flags               *featureflags.FeatureFlags
+	FlagMiddleware      *featureflags.Middleware
+	WSHub               *appwebsocket.Hub
+	WSHandler           *appwebsocket.Handler
+	HealthHandler       *handlers.HealthHandler
+	ActivityHandler     *handlers.ActivityHandler
+	UserHandler         *handlers.UserHandler
+	StatsHandler        *handlers.StatsHandler
+	PhotoHandler        *handlers.ActivityPhotoHandler
+	ExportHandler       *handlers.ExportHandler
+	ImportHandler       *handlers.ImportHandler
+	JobHandler          *handlers.JobHandler
+	SyncHandler         *handlers.SyncHandler
+	AccountHandler      *handlers.AccountHandler
+	FeaturesHandler     *handlers.FeaturesHandler
+	WebhookHandler      *handlers.WebhookHandler
+	GroupHandler        *handlers.GroupHandler
+	ShareHandler        *handlers.ShareHandler
+	TagHandler          *handlers.TagHandler
+	ProfileHandler      *handlers.ProfileHandler
+	PrivacyZoneHandler  *handlers.PrivacyZoneHandler
+	APIKeyHandler       *handlers.APIKeyHandler
+	TrackHandler        *handlers.ActivityTrackHandler
+	ReactionHandler     *handlers.ReactionHandler
+	BodyMetricHandler   *handlers.BodyMetricHandler
+	WorkoutHandler      *handlers.WorkoutHandler
+	CoachHandler        *handlers.CoachHandler
+	ChallengeHandler    *handlers.ChallengeHandler
+	AchievementHandler  *handlers.AchievementHandler
+	IdentityHandler     *handlers.IdentityHandler
+	ActivityTypeHandler *handlers.ActivityTypeHandler
+	IndexAdvisor        *query.IndexAdvisor // nil outside development
+	IndexAdvisorHandler *handlers.IndexAdvisorHandler
+	QueueDebugHandler   *handlers.QueueDebugHandler
+	SearchHandler       *handlers.SearchHandler
+	FileHandler         *handlers.FileHandler
+	WorkerHandler       *handlers.WorkerHandler
+	WebhookBus          webhookTypes.WebhookBusProvider
+	WebhookDelivery     *webhook.Delivery
+	WebhookRetryWorker  *webhook.RetryWorker
+	StatsSummaries      *service.StatsSummaryUpdater // nil unless STATS_READ_FROM_SUMMARIES
+}
+
+// New builds the application on db: it loads the feature flags, registers
+// and verifies every dependency and resolves the handlers. Configuration must
+// be loaded first. Background services don't run until Start.
+func New(db repository.DBConn) (*Application, error) {
+	app := &Application{
+		DB: db,
+	}
+	if err := app.setupDependencies(); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+// cacheRateLimitConfig writes the in-memory rate limit config to Redis at
+// startup with a 48-hour TTL, then reads it back to verify it was stored.
+func (app *Application) cacheRateLimitConfig(adapter cacheTypes.CacheAdapter) {
+	ctx := context.Background()
+	opts := cacheTypes.CacheOptions{
+		DB:           cacheTypes.CacheDBRateLimits,
+		PartitionKey: cacheTypes.CachePartitionRateLimitConfig,
+	}
+
+	cached := struct {
+		CachedAt time.Time               `json:"cached_at"`
+		Config   *config.RateLimitConfig `json:"config"`
+	}{
+		CachedAt: time.Now(),
+		Config:   config.RateLimit,
+	}
+
+	data, err := json.Marshal(cached)
+	if err != nil {
+		log.Printf("Warning: Failed to marshal rate limit config: %v", err)
+		return
+	}
+
+	if err := adapter.Set(ctx, "config", string(data), 48*time.Hour, opts); err != nil {
+		log.Printf("Warning: Failed to cache rate limit config to Redis: %v", err)
+		return
+	}
+	log.Printf("Rate limit config cached to Redis (DB %d)", config.Cache.DBs.RateLimits)
+
+	// Verify by reading back
+	if val, err := adapter.Get(ctx, "config", opts); err == nil && val != "" {
+		log.Printf("Rate limit config verified from Redis")
+	} else {
+		log.Printf("Warning: Rate limit config not found in Redis after write, using in-memory fallback")
+	}
+}
+
+// setupDependencies initializes all repositories and handlers using DI container
+// All dependencies are registered and resolved through the centralized container
+func (app *Application) setupDependencies() error {
+	// Load feature flags
+	app.Flags = featureflags.Load()
+	app.FlagMiddleware = featureflags.NewMiddleware(app.Flags)
+	app.FeaturesHandler = handlers.NewFeaturesHandler(app.Flags)
+
+	// Create WebSocket hub
+	app.WSHub = appwebsocket.NewHub()
+	app.WSHandler = appwebsocket.NewHandler(app.WSHub)
+
+	// Initialize container with all dependencies
+	c, err := NewContainer(app.DB, app.WSHub)
+	if err != nil {
+		return err
+	}
+	app.Container = c
+
+	// Resolve core dependencies from container
+	app.Broker = container.MustResolve[*broker.Broker](app.Container, di.BrokerKey)
+
+	// Setup rate limiter using the multi-DB cache adapter
+	resolvedAdapter := app.Container.MustResolve(cacheDI.CacheAdapterKey)
+	cacheAdapter := resolvedAdapter.(cacheTypes.CacheAdapter)
+	rlCacheProvider := resolvedAdapter.(cacheTypes.RateLimitCacheProvider)
+	queueProvider := container.MustResolve[queueTypes.QueueProvider](app.Container, queueDI.QueueProviderKey)
+
+	// Write rate limit config to Redis at startup with 48h TTL
+	app.cacheRateLimitConfig(cacheAdapter)
+
+	app.RateLimiter = middleware.NewRateLimiter(rlCacheProvider, cacheAdapter, queueProvider, config.RateLimit)
+
+	// Resolve scheduler from container
+	app.Scheduler = container.MustResolve[*scheduler.Scheduler](app.Container, schedulerDI.SchedulerKey)
+
+	// Resolve handlers from container
+	app.HealthHandler = container.MustResolve[*handlers.HealthHandler](app.Container, handlerDI.HealthHandlerKey)
+	app.ActivityHandler = container.MustResolve[*handlers.ActivityHandler](app.Container, handlerDI.ActivityHandlerKey)
+	app.UserHandler = container.MustResolve[*handlers.UserHandler](app.Container, handlerDI.UserHandlerKey)
+	app.StatsHandler = container.MustResolve[*handlers.StatsHandler](app.Container, handlerDI.StatsHandlerKey)
+	app.PhotoHandler = container.MustResolve[*handlers.ActivityPhotoHandler](app.Container, handlerDI.ActivityPhotoHandlerKey)
+	app.ExportHandler = container.MustResolve[*handlers.ExportHandler](app.Container, handlerDI.ExportHandlerKey)
+	app.ImportHandler = container.MustResolve[*handlers.ImportHandler](app.Container, handlerDI.ImportHandlerKey)
+	app.JobHandler = container.MustResolve[*handlers.JobHandler](app.Container, handlerDI.JobHandlerKey)
+	app.SyncHandler = container.MustResolve[*handlers.SyncHandler](app.Container, handlerDI.SyncHandlerKey)
+	app.AccountHandler = container.MustResolve[*handlers.AccountHandler](app.Container, handlerDI.AccountHandlerKey)
+	app.WebhookHandler = container.MustResolve[*handlers.WebhookHandler](app.Container, handlerDI.WebhookHandlerKey)
+	app.GroupHandler = container.MustResolve[*handlers.GroupHandler](app.Container, handlerDI.GroupHandlerKey)
+	app.ShareHandler = container.MustResolve[*handlers.ShareHandler](app.Container, handlerDI.ShareHandlerKey)
+	app.TagHandler = container.MustResolve[*handlers.TagHandler](app.Container, handlerDI.TagHandlerKey)
+	app.ProfileHandler = container.MustResolve[*handlers.ProfileHandler](app.Container, handlerDI.ProfileHandlerKey)
+	app.PrivacyZoneHandler = container.MustResolve[*handlers.PrivacyZoneHandler](app.Container, handlerDI.PrivacyZoneHandlerKey)
+	app.APIKeyHandler = container.MustResolve[*handlers.APIKeyHandler](app.Container, handlerDI.APIKeyHandlerKey)
+	app.TrackHandler = container.MustResolve[*handlers.ActivityTrackHandler](app.Container, handlerDI.ActivityTrackHandlerKey)
+	app.ReactionHandler = container.MustResolve[*handlers.ReactionHandler](app.Container, handlerDI.ReactionHandlerKey)
+	app.BodyMetricHandler = container.MustResolve[*handlers.BodyMetricHandler](app.Container, handlerDI.BodyMetricHandlerKey)
+	app.WorkoutHandler = container.MustResolve[*handlers.WorkoutHandler](app.Container, handlerDI.WorkoutHandlerKey)
+	app.CoachHandler = container.MustResolve[*handlers.CoachHandler](app.Container, handlerDI.CoachHandlerKey)
+	app.ChallengeHandler = container.MustResolve[*handlers.ChallengeHandler](app.Container, handlerDI.ChallengeHandlerKey)
+	app.AchievementHandler = container.MustResolve[*handlers.AchievementHandler](app.Container, handlerDI.AchievementHandlerKey)
+	app.IdentityHandler = container.MustResolve[*handlers.IdentityHandler](app.Container, handlerDI.IdentityHandlerKey)
+	app.ActivityTypeHandler = container.MustResolve[*handlers.ActivityTypeHandler](app.Container, handlerDI.ActivityTypeHandlerKey)
+	app.SearchHandler = container.MustResolve[*handlers.SearchHandler](app.Container, handlerDI.SearchHandlerKey)
+	app.FileHandler = container.MustResolve[*handlers.FileHandler](app.Container, handlerDI.FileHandlerKey)
+	app.WorkerHandler = container.MustResolve[*handlers.WorkerHandler](app.Container, handlerDI.WorkerHandlerKey)
+
+	// The index advisor observes list queries in development only
+	var slowQueries *database.SlowQueryLog
+	if db, ok := app.DB.(*database.LoggingDB); ok {
+		slowQueries = db.SlowQueryLog()
+	}
+	if config.Common.IsDevelopment {
+		app.IndexAdvisor = query.NewIndexAdvisor()
+	}
+	app.IndexAdvisorHandler = handlers.NewIndexAdvisorHandler(app.IndexAdvisor,
+		container.MustResolve[*repository.IndexRepository](app.Container, repositoryRegister.IndexRepoKey), slowQueries)
+
+	// So is the memory queue listing
+	var memoryQueue *memory.Provider
+	if config.Common.IsDevelopment {
+		memoryQueue, _ = container.MustResolve[queueTypes.QueueProvider](app.Container, queueDI.QueueProviderKey).(*memory.Provider)
+	}
+	app.QueueDebugHandler = handlers.NewQueueDebugHandler(memoryQueue)
+
+	// Resolve webhook bus, delivery, and retry worker from container
+	app.WebhookDelivery = container.MustResolve[*webhook.Delivery](app.Container, webhookDI.WebhookDeliveryKey)
+	app.WebhookRetryWorker = container.MustResolve[*webhook.RetryWorker](app.Container, webhookDI.RetryWorkerKey)
+	app.WebhookBus = container.MustResolve[webhookTypes.WebhookBusProvider](app.Container, webhookDI.WebhookBusKey)
+
+	if config.Stats.ReadFromSummaries {
+		app.StatsSummaries = service.NewStatsSummaryUpdater(
+			container.MustResolve[*repository.StatsSummaryRepository](app.Container, repositoryRegister.StatsSummaryRepoKey))
+	}
+	return nil
+}
+
+// Routes configures all application routes and middleware
+// Routes and their group middleware are declared in internal/routes
+func (app *Application) Routes() http.Handler {
+	router := mux.NewRouter()
+
+	// Global middleware; rate limiting, auth and RBAC belong to route groups
+	router.Use(middleware.TimingMiddleware)
+	router.Use(middleware.MetricsMiddleware)
+	router.Use(middleware.RequestID)
+	router.Use(middleware.Locale)
+	router.Use(middleware.RequestScope(app.Container))
+	router.Use(middleware.LoggingMiddleware)
+	router.Use(middleware.CORS(config.Security.CORS))
+	router.Use(middleware.SecurityHeaders(config.Security.Headers))
+	router.Use(middleware.Compress(*config.Compression))
+	router.Use(middleware.BodyLimit(config.Common.MaxBodyBytes, config.Common.MaxUploadBytes))
+	router.Use(middleware.Timeout(requestTimeouts()))
+	if config.Common.Auth.CookieSessions {
+		router.Use(middleware.CSRF)
+	}
+	if app.IndexAdvisor != nil {
+		router.Use(middleware.IndexAdvisor(app.IndexAdvisor))
+	}
+
+	routes.API(app.routeHandlers(), app.RateLimiter.Middleware).Mount(router)
+
+	return router
+}
+
+// routeHandlers collects the handlers the route table dispatches to
+func (app *Application) routeHandlers() routes.Handlers {
+	return routes.Handlers{
+		Root:         app.handleRoot,
+		OpenAPISpec:  app.handleOpenAPISpec,
+		Health:       app.HealthHandler,
+		Activity:     app.ActivityHandler,
+		User:         app.UserHandler,
+		Identity:     app.IdentityHandler,
+		Stats:        app.StatsHandler,
+		Photo:        app.PhotoHandler,
+		Export:       app.ExportHandler,
+		Import:       app.ImportHandler,
+		Job:          app.JobHandler,
+		Sync:         app.SyncHandler,
+		Account:      app.AccountHandler,
+		Features:     app.FeaturesHandler,
+		Webhook:      app.WebhookHandler,
+		Group:        app.GroupHandler,
+		Share:        app.ShareHandler,
+		Tag:          app.TagHandler,
+		Profile:      app.ProfileHandler,
+		PrivacyZone:  app.PrivacyZoneHandler,
+		APIKey:       app.APIKeyHandler,
+		Track:        app.TrackHandler,
+		Reaction:     app.ReactionHandler,
+		BodyMetric:   app.BodyMetricHandler,
+		Workout:      app.WorkoutHandler,
+		Coach:        app.CoachHandler,
+		Challenge:    app.ChallengeHandler,
+		Achievement:  app.AchievementHandler,
+		ActivityType: app.ActivityTypeHandler,
+		IndexAdvisor: app.IndexAdvisorHandler,
+		QueueDebug:   app.QueueDebugHandler,
+		Search:       app.SearchHandler,
+		File:         app.FileHandler,
+		Worker:       app.WorkerHandler,
+		WebSocket:    app.WSHandler,
+
+		ActivityIDs: container.MustResolve[*repository.ActivityRepository](app.Container, repositoryRegister.ActivityRepoKey).IDByPublicID,
+		ShareIDs:    container.MustResolve[*repository.ShareRepository](app.Container, repositoryRegister.ShareRepoKey).IDByPublicID,
+		UserIDs:     container.MustResolve[*repository.UserRepository](app.Container, repositoryRegister.UserRepoKey).IDByPublicID,
+
+		CoachAccess: container.MustResolve[*repository.CoachRepository](app.Container, repositoryRegister.CoachRepoKey).HasAccess,
+
+		APIKeys: container.MustResolve[*repository.APIKeyRepository](app.Container, repositoryRegister.APIKeyRepoKey).Authenticate,
+
+		Clock: container.MustResolve[clock.Clock](app.Container, clockDI.ClockKey),
+	}
+}
+
+// requestTimeouts assigns the configured deadlines to route groups.
+// Streaming routes (job events, WebSocket) stay open and get none.
+func requestTimeouts() middleware.RequestTimeouts {
+	timeouts := config.Common.RequestTimeouts
+	return middleware.RequestTimeouts{
+		Default: timeouts.Default,
+		Routes: map[string]time.Duration{
+			"/api/v1/stats":                  timeouts.Stats,
+			"/api/v1/activities/stats":       timeouts.Stats,
+			"/api/v1/users/me/stats":         timeouts.Stats,
+			"/api/v1/users/me/summary":       timeouts.Stats,
+			"/api/v1/users/me/tags/top":      timeouts.Stats,
+			"/api/v1/coaching/dashboard":     timeouts.Stats,
+			"/api/v1/activities/batch":       timeouts.Transfer,
+			"/api/v1/activities/import":      timeouts.Transfer,
+			"/api/v1/activities/export":      timeouts.Transfer,
+			"/api/v1/activities/{id}/photos": timeouts.Transfer,
+			"/api/v1/users/me/avatar":        timeouts.Transfer,
+			"/api/v1/jobs/{jobId}/events":    0,
+			"/ws":                            0,
+		},
+	}
+}
+
+// handleRoot handles the root endpoint
+func (app *Application) handleRoot(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(`{"message": "🪵 ActiveLog API v1", "version": "0.1.0"}`))
+}
+
+// handleOpenAPISpec serves the generated OpenAPI document
+func (app *Application) handleOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(docs.SwaggerInfo.ReadDoc()))
+}
+
+// Start subscribes the webhook delivery, WebSocket sync and stats summaries
+// to the webhook bus, then starts the WebSocket hub, scheduler and webhook
+// retry poller. Subscriptions end when ctx is cancelled.
+func (app *Application) Start(ctx context.Context) error {
+	// One subscription fans out to all: the redis/nats buses share a consumer
+	// group, so separate subscriptions would split events between them.
+	if err := app.WebhookBus.Subscribe(ctx, func(ctx context.Context, event webhookTypes.WebhookEvent) {
+		app.WebhookDelivery.Handle(ctx, event)
+		app.WSHub.HandleEvent(ctx, event)
+		if app.StatsSummaries != nil {
+			app.StatsSummaries.HandleEvent(ctx, event)
+		}
+	}); err != nil {
+		log.Printf("Warning: Failed to subscribe webhook delivery: %v", err)
+	}
+
+	if err := app.Container.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start services: %w", err)
+	}
+	return nil
+}
+
+// Stop stops services in reverse dependency order (scheduler and workers
+// first, database connections last)
+func (app *Application) Stop(ctx context.Context) error {
+	return app.Container.Stop(ctx)
+}
@@ -1,32 +1,42 @@
-package main
+package api
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+
+	cacheRegister "github.com/valentinesamuel/activelog/internal/adapters/cache/di"
+	emailRegister "github.com/valentinesamuel/activelog/internal/adapters/email/di"
+	identityRegister "github.com/valentinesamuel/activelog/internal/adapters/identity/di"
+	queueRegister "github.com/valentinesamuel/activelog/internal/adapters/queue/di"
+	searchRegister "github.com/valentinesamuel/activelog/internal/adapters/search/di"
+	storageRegister "github.com/valentinesamuel/activelog/internal/adapters/storage/di"
+	webhookRegister "github.com/valentinesamuel/activelog/internal/adapters/webhook/di"
+	"github.com/valentinesamuel/activelog/internal/adapters/websocket"
 	activityUsecases "github.com/valentinesamuel/activelog/internal/application/activity/usecases/di"
 	photoUsecases "github.com/valentinesamuel/activelog/internal/application/activityPhoto/usecases/di"
 	"github.com/valentinesamuel/activelog/internal/application/broker/di"
 	statsUsecases "github.com/valentinesamuel/activelog/internal/application/stats/usecases/di"
 	tagUsecases "github.com/valentinesamuel/activelog/internal/application/tag/usecases/di"
-	cacheRegister "github.com/valentinesamuel/activelog/internal/adapters/cache/di"
-	"github.com/valentinesamuel/activelog/internal/platform/container"
-	emailRegister "github.com/valentinesamuel/activelog/internal/adapters/email/di"
+	eventsRegister "github.com/valentinesamuel/activelog/internal/events/di"
 	handlerRegister "github.com/valentinesamuel/activelog/internal/handlers/di"
-	queueRegister "github.com/valentinesamuel/activelog/internal/adapters/queue/di"
+	clockRegister "github.com/valentinesamuel/activelog/internal/platform/clock/di"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	"github.com/valentinesamuel/activelog/internal/platform/container"
+	schedulerRegister "github.com/valentinesamuel/activelog/internal/platform/scheduler/di"
 	"github.com/valentinesamuel/activelog/internal/repository"
 	repositoryRegister "github.com/valentinesamuel/activelog/internal/repository/di"
-	schedulerRegister "github.com/valentinesamuel/activelog/internal/platform/scheduler/di"
 	serviceRegister "github.com/valentinesamuel/activelog/internal/service/di"
-	storageRegister "github.com/valentinesamuel/activelog/internal/adapters/storage/di"
-	webhookRegister "github.com/valentinesamuel/activelog/internal/adapters/webhook/di"
-	"github.com/valentinesamuel/activelog/internal/adapters/websocket"
 	"github.com/valentinesamuel/activelog/pkg/query"
 )
 
 const WebSocketHubKey = "WebSocketHub"
 
-// setupContainer creates and configures the DI container
+// NewContainer creates and configures the DI container
 // All dependencies are registered here following Clean Architecture layering
 // Registration order: Core → Storage → Repositories → Services → Broker → UseCases → Handlers
-func setupContainer(db repository.DBConn, hub *websocket.Hub) *container.Container {
+func NewContainer(db repository.DBConn, hub *websocket.Hub) (*container.Container, error) {
 	c := container.New()
 
 	// Register core singletons (must be first)
@@ -37,9 +47,14 @@ func setupContainer(db repository.DBConn, hub *websocket.Hub) *container.Contain
 	cacheRegister.RegisterCacheAdapter(c)
 	queueRegister.RegisterQueue(c)
 	emailRegister.RegisterEmail(c)
+	identityRegister.RegisterIdentity(c)
 	webhookRegister.RegisterWebhookBus(c)
+	searchRegister.RegisterSearch(c)
+	searchRegister.BuildMemoryIndex(c)
+	eventsRegister.RegisterEventBus(c)
 	webhookRegister.RegisterWebhookDelivery(c)
 	webhookRegister.RegisterRetryWorker(c)
+	webhookRegister.PollWebhookRetries(c)
 
 	// Eagerly resolve dependedncies
 	c.MustResolve(storageRegister.StorageProviderKey)
@@ -67,16 +82,44 @@ func setupContainer(db repository.DBConn, hub *websocket.Hub) *container.Contain
 	// Register handlers (depends on everything above)
 	handlerRegister.RegisterHandlers(c)
 
-	return c
+	// Self-check: build every registration now so a missing or mistyped
+	// dependency fails the boot instead of the first request that needs it
+	graph, err := c.Verify()
+	if err != nil {
+		return nil, fmt.Errorf("container self-check failed: %w", err)
+	}
+	log.Printf("Container self-check passed: %d services", len(graph))
+	if config.Common.IsDevelopment {
+		log.Printf("Dependency graph:\n%s", graph)
+	}
+
+	return c, nil
 }
 
 // registerCoreDependencies registers core singletons like database connection
-// These must be registered before any other dependencies
+// These must be registered before any other dependencies, so the database
+// is the last service stopped on shutdown
 func registerCoreDependencies(c *container.Container, db repository.DBConn, hub *websocket.Hub) {
 	c.RegisterSingleton(repositoryRegister.CoreDBKey, db)
 	c.RegisterSingleton(di.CoreRawDBKey, db.GetRawDB())
 	c.RegisterSingleton(repositoryRegister.CoreRegistryManagerKey, setupRegistryManager())
+	c.RegisterSingleton(repositoryRegister.CoreValidationRegistryKey, query.NewValidationConfigRegistry())
 	c.RegisterSingleton(WebSocketHubKey, hub)
+	clockRegister.RegisterClock(c)
+
+	if closer, ok := db.(io.Closer); ok {
+		c.OnStop(repositoryRegister.CoreDBKey, func(context.Context) error {
+			return closer.Close()
+		})
+	}
+	c.OnStart(WebSocketHubKey, func(context.Context) error {
+		go hub.Run()
+		return nil
+	})
+	c.OnStop(WebSocketHubKey, func(context.Context) error {
+		hub.Stop()
+		return nil
+	})
 }
 
 // setupRegistryManager creates and configures the global RegistryManager (v3.0)
@@ -0,0 +1,67 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/valentinesamuel/activelog/internal/application/broker"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+)
+
+// ConfirmDeletionDueInput defines the typed input for ConfirmDeletionDueUseCase
+type ConfirmDeletionDueInput struct {
+	UserID int
+}
+
+// ConfirmDeletionDueOutput defines the typed output for ConfirmDeletionDueUseCase
+type ConfirmDeletionDueOutput struct{}
+
+// ConfirmDeletionDueUseCase checks that an account is still due for deletion
+// right before the first step that can't be undone. If the account was
+// restored meanwhile it aborts the deletion saga, which compensates the steps
+// that already ran.
+type ConfirmDeletionDueUseCase struct {
+	accounts *repository.AccountRepository
+	clock    clock.Clock
+}
+
+// NewConfirmDeletionDueUseCase creates a new instance; a nil clock uses the
+// real one
+func NewConfirmDeletionDueUseCase(accounts *repository.AccountRepository, clk clock.Clock) *ConfirmDeletionDueUseCase {
+	if clk == nil {
+		clk = clock.Real{}
+	}
+	return &ConfirmDeletionDueUseCase{
+		accounts: accounts,
+		clock:    clk,
+	}
+}
+
+// RequiresTransaction returns false - read operations don't need transactions
+func (uc *ConfirmDeletionDueUseCase) RequiresTransaction() bool {
+	return false
+}
+
+// Idempotent returns true - reads are safe to retry
+func (uc *ConfirmDeletionDueUseCase) Idempotent() bool {
+	return true
+}
+
+// Execute returns an error wrapping broker.ErrAbortSaga unless the account is
+// still disabled and past its grace period
+func (uc *ConfirmDeletionDueUseCase) Execute(
+	ctx context.Context,
+	_ *sql.Tx,
+	input ConfirmDeletionDueInput,
+) (ConfirmDeletionDueOutput, error) {
+	due, err := uc.accounts.IsDueForPurge(ctx, input.UserID, uc.clock.Now())
+	if err != nil {
+		return ConfirmDeletionDueOutput{}, err
+	}
+	if !due {
+		return ConfirmDeletionDueOutput{}, fmt.Errorf("account %d is no longer scheduled for deletion: %w", input.UserID, broker.ErrAbortSaga)
+	}
+	return ConfirmDeletionDueOutput{}, nil
+}
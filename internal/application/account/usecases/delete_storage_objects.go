@@ -0,0 +1,79 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	storageTypes "github.com/valentinesamuel/activelog/internal/adapters/storage/types"
+	"github.com/valentinesamuel/activelog/internal/repository"
+)
+
+// deleteBatchSize is the most keys sent in one DeleteMultiple call (the S3 limit)
+const deleteBatchSize = 1000
+
+// DeleteStorageObjectsInput defines the typed input for DeleteStorageObjectsUseCase
+type DeleteStorageObjectsInput struct {
+	UserID int
+}
+
+// DeleteStorageObjectsOutput defines the typed output for DeleteStorageObjectsUseCase
+type DeleteStorageObjectsOutput struct {
+	Deleted int
+}
+
+// DeleteStorageObjectsUseCase deletes every object a user owns in object
+// storage: photos, thumbnails, exports, imports and the avatar. The rows that
+// reference them are left for PurgeAccountUseCase, so a failed run can list
+// the same keys again.
+type DeleteStorageObjectsUseCase struct {
+	accounts *repository.AccountRepository
+	storage  storageTypes.StorageProvider
+}
+
+// NewDeleteStorageObjectsUseCase creates a new instance
+func NewDeleteStorageObjectsUseCase(accounts *repository.AccountRepository, storage storageTypes.StorageProvider) *DeleteStorageObjectsUseCase {
+	return &DeleteStorageObjectsUseCase{
+		accounts: accounts,
+		storage:  storage,
+	}
+}
+
+// RequiresTransaction returns false - the objects live outside the database
+func (uc *DeleteStorageObjectsUseCase) RequiresTransaction() bool {
+	return false
+}
+
+// Idempotent returns true - objects that are already gone count as deleted
+func (uc *DeleteStorageObjectsUseCase) Idempotent() bool {
+	return true
+}
+
+// Execute deletes the user's objects in batches. Any object that can't be
+// deleted fails the run, so no object is orphaned by the purge.
+func (uc *DeleteStorageObjectsUseCase) Execute(
+	ctx context.Context,
+	_ *sql.Tx,
+	input DeleteStorageObjectsInput,
+) (DeleteStorageObjectsOutput, error) {
+	keys, err := uc.accounts.ListStorageKeys(ctx, input.UserID)
+	if err != nil {
+		return DeleteStorageObjectsOutput{}, err
+	}
+
+	for start := 0; start < len(keys); start += deleteBatchSize {
+		batch := keys[start:min(start+deleteBatchSize, len(keys))]
+		failed, err := uc.storage.DeleteMultiple(ctx, batch)
+		if err != nil {
+			return DeleteStorageObjectsOutput{}, fmt.Errorf("delete storage objects: %w", err)
+		}
+		for key, err := range failed {
+			if err != nil && !errors.Is(err, storageTypes.ErrNotFound) {
+				return DeleteStorageObjectsOutput{}, fmt.Errorf("delete %s: %w", key, err)
+			}
+		}
+	}
+
+	return DeleteStorageObjectsOutput{Deleted: len(keys)}, nil
+}
@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/valentinesamuel/activelog/internal/repository"
+)
+
+// DeleteWebhooksInput defines the typed input for DeleteWebhooksUseCase
+type DeleteWebhooksInput struct {
+	UserID int
+}
+
+// DeleteWebhooksOutput defines the typed output for DeleteWebhooksUseCase
+type DeleteWebhooksOutput struct{}
+
+// DeleteWebhooksUseCase deletes a user's webhooks and their pending
+// deliveries, so nothing more is sent to their endpoints
+type DeleteWebhooksUseCase struct {
+	webhooks *repository.WebhookRepository
+}
+
+// NewDeleteWebhooksUseCase creates a new instance
+func NewDeleteWebhooksUseCase(webhooks *repository.WebhookRepository) *DeleteWebhooksUseCase {
+	return &DeleteWebhooksUseCase{
+		webhooks: webhooks,
+	}
+}
+
+// RequiresTransaction returns false - a single DELETE is atomic on its own
+func (uc *DeleteWebhooksUseCase) RequiresTransaction() bool {
+	return false
+}
+
+// Idempotent returns true - deleting again finds nothing to delete
+func (uc *DeleteWebhooksUseCase) Idempotent() bool {
+	return true
+}
+
+// Execute deletes the user's webhooks
+func (uc *DeleteWebhooksUseCase) Execute(
+	ctx context.Context,
+	_ *sql.Tx,
+	input DeleteWebhooksInput,
+) (DeleteWebhooksOutput, error) {
+	return DeleteWebhooksOutput{}, uc.webhooks.DeleteByUserID(ctx, input.UserID)
+}
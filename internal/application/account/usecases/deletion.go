@@ -0,0 +1,114 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	searchTypes "github.com/valentinesamuel/activelog/internal/adapters/search/types"
+	storageTypes "github.com/valentinesamuel/activelog/internal/adapters/storage/types"
+	"github.com/valentinesamuel/activelog/internal/application/broker"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+)
+
+// deletionStepTimeout bounds each step of an account deletion; deleting the
+// storage objects of a large account takes longer than the broker default
+const deletionStepTimeout = 10 * time.Minute
+
+// Compile-time checks that each account use case satisfies the typed broker
+// contract for its own Input/Output pair
+var (
+	_ broker.TransactionalTypedUseCase[RemoveSearchEntriesInput, RemoveSearchEntriesOutput]   = (*RemoveSearchEntriesUseCase)(nil)
+	_ broker.TransactionalTypedUseCase[IndexUserActivitiesInput, IndexUserActivitiesOutput]   = (*IndexUserActivitiesUseCase)(nil)
+	_ broker.TransactionalTypedUseCase[ConfirmDeletionDueInput, ConfirmDeletionDueOutput]     = (*ConfirmDeletionDueUseCase)(nil)
+	_ broker.TransactionalTypedUseCase[DeleteWebhooksInput, DeleteWebhooksOutput]             = (*DeleteWebhooksUseCase)(nil)
+	_ broker.TransactionalTypedUseCase[DeleteStorageObjectsInput, DeleteStorageObjectsOutput] = (*DeleteStorageObjectsUseCase)(nil)
+	_ broker.TransactionalTypedUseCase[PurgeAccountInput, PurgeAccountOutput]                 = (*PurgeAccountUseCase)(nil)
+)
+
+// AccountDeletionDeps contains the dependencies for AccountDeletion.
+type AccountDeletionDeps struct {
+	Broker     *broker.Broker
+	Sagas      broker.SagaStore
+	Accounts   *repository.AccountRepository
+	Activities repository.ActivityRepositoryInterface
+	Webhooks   *repository.WebhookRepository
+	Storage    storageTypes.StorageProvider
+	Search     searchTypes.Indexer // nil without a search index this process can update
+	Clock      clock.Clock         // decides whether the grace period has ended; nil uses the real clock
+}
+
+// AccountDeletion permanently deletes a disabled account across Postgres,
+// object storage and the search index, as a saga:
+//
+//  1. remove_search_entries: delete the user's search documents
+//     (compensated by indexing their activities again)
+//  2. confirm_deletion_due: abort, and compensate, if the account was
+//     restored meanwhile; the steps after it can't be undone
+//  3. delete_webhooks: stop deliveries to the user's endpoints
+//  4. delete_storage_objects: photos, thumbnails, exports, imports, avatar
+//  5. purge_account: the user's rows, including activities and comments
+//
+// Progress is saved after every step, so a deletion interrupted by a worker
+// restart resumes at the step it stopped at. Sessions are stateless JWTs, so
+// there is nothing to clear: login is refused once the account is disabled.
+type AccountDeletion struct {
+	broker *broker.Broker
+	sagas  broker.SagaStore
+
+	removeSearchEntries *RemoveSearchEntriesUseCase
+	indexActivities     *IndexUserActivitiesUseCase
+	confirmDue          *ConfirmDeletionDueUseCase
+	deleteWebhooks      *DeleteWebhooksUseCase
+	deleteStorage       *DeleteStorageObjectsUseCase
+	purge               *PurgeAccountUseCase
+}
+
+// NewAccountDeletion creates a new AccountDeletion with the given dependencies.
+func NewAccountDeletion(deps AccountDeletionDeps) *AccountDeletion {
+	d := &AccountDeletion{
+		broker:         deps.Broker,
+		sagas:          deps.Sagas,
+		confirmDue:     NewConfirmDeletionDueUseCase(deps.Accounts, deps.Clock),
+		deleteWebhooks: NewDeleteWebhooksUseCase(deps.Webhooks),
+		deleteStorage:  NewDeleteStorageObjectsUseCase(deps.Accounts, deps.Storage),
+		purge:          NewPurgeAccountUseCase(deps.Accounts),
+	}
+	if deps.Search != nil {
+		d.removeSearchEntries = NewRemoveSearchEntriesUseCase(deps.Accounts, deps.Search)
+		d.indexActivities = NewIndexUserActivitiesUseCase(deps.Activities, deps.Search)
+	}
+	return d
+}
+
+// DeletionSagaID returns the ID under which the deletion of userID's account
+// saves its progress
+func DeletionSagaID(userID int) string {
+	return fmt.Sprintf("account_deletion:%d", userID)
+}
+
+// Saga returns the deletion saga of userID's account
+func (d *AccountDeletion) Saga(userID int) broker.Saga {
+	var steps []broker.SagaStep
+	if d.removeSearchEntries != nil {
+		steps = append(steps, broker.SagaUseCase("remove_search_entries", d.removeSearchEntries, RemoveSearchEntriesInput{UserID: userID}).
+			CompensateWith(broker.SagaUseCase("index_activities", d.indexActivities, IndexUserActivitiesInput{UserID: userID})))
+	}
+	steps = append(steps,
+		broker.SagaUseCase("confirm_deletion_due", d.confirmDue, ConfirmDeletionDueInput{UserID: userID}),
+		broker.SagaUseCase("delete_webhooks", d.deleteWebhooks, DeleteWebhooksInput{UserID: userID}),
+		broker.SagaUseCase("delete_storage_objects", d.deleteStorage, DeleteStorageObjectsInput{UserID: userID}),
+		broker.SagaUseCase("purge_account", d.purge, PurgeAccountInput{UserID: userID}),
+	)
+
+	return broker.Saga{ID: DeletionSagaID(userID), Steps: steps, Store: d.sagas}
+}
+
+// DeleteAccount runs, or resumes, the deletion of userID's account and
+// returns its progress. An error wrapping broker.ErrAbortSaga means the
+// account was restored and the deletion undone.
+func (d *AccountDeletion) DeleteAccount(ctx context.Context, userID int) (*models.Saga, error) {
+	return broker.RunSaga(d.broker, ctx, d.Saga(userID), broker.WithTimeout(deletionStepTimeout))
+}
@@ -0,0 +1,6 @@
+package di
+
+// Container registration keys for account use cases
+const (
+	AccountDeletionKey = "accountDeletion"
+)
@@ -0,0 +1,39 @@
+package di
+
+import (
+	searchDI "github.com/valentinesamuel/activelog/internal/adapters/search/di"
+	searchTypes "github.com/valentinesamuel/activelog/internal/adapters/search/types"
+	storageDI "github.com/valentinesamuel/activelog/internal/adapters/storage/di"
+	storageTypes "github.com/valentinesamuel/activelog/internal/adapters/storage/types"
+	"github.com/valentinesamuel/activelog/internal/application/account/usecases"
+	"github.com/valentinesamuel/activelog/internal/application/broker"
+	brokerDI "github.com/valentinesamuel/activelog/internal/application/broker/di"
+	clockDI "github.com/valentinesamuel/activelog/internal/platform/clock/di"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	"github.com/valentinesamuel/activelog/internal/platform/container"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	repoDI "github.com/valentinesamuel/activelog/internal/repository/di"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+)
+
+// RegisterAccountUseCases registers the account deletion saga
+// Dependencies: Requires the broker, repositories, storage and search to be registered first
+func RegisterAccountUseCases(c *container.Container) {
+	container.RegisterTyped(c, AccountDeletionKey, func(c *container.Container) (*usecases.AccountDeletion, error) {
+		deps := usecases.AccountDeletionDeps{
+			Broker:     container.MustResolve[*broker.Broker](c, brokerDI.BrokerKey),
+			Sagas:      container.MustResolve[*repository.SagaRepository](c, repoDI.SagaRepoKey),
+			Accounts:   container.MustResolve[*repository.AccountRepository](c, repoDI.AccountRepoKey),
+			Activities: container.MustResolve[repository.ActivityRepositoryInterface](c, repoDI.ActivityRepoKey),
+			Webhooks:   container.MustResolve[*repository.WebhookRepository](c, repoDI.WebhookRepoKey),
+			Storage:    container.MustResolve[storageTypes.StorageProvider](c, storageDI.StorageProviderKey),
+			Clock:      container.MustResolve[clock.Clock](c, clockDI.ClockKey),
+		}
+		// Deletions run on the worker, which can't reach an index embedded
+		// in the API process; that one is rebuilt without the user on restart
+		if config.Search.Enabled() && config.Search.Provider != "memory" {
+			deps.Search = container.MustResolve[searchTypes.SearchProvider](c, searchDI.SearchProviderKey)
+		}
+		return usecases.NewAccountDeletion(deps), nil
+	})
+}
@@ -0,0 +1,65 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	searchTypes "github.com/valentinesamuel/activelog/internal/adapters/search/types"
+	"github.com/valentinesamuel/activelog/internal/repository"
+)
+
+// IndexUserActivitiesInput defines the typed input for IndexUserActivitiesUseCase
+type IndexUserActivitiesInput struct {
+	UserID int
+}
+
+// IndexUserActivitiesOutput defines the typed output for IndexUserActivitiesUseCase
+type IndexUserActivitiesOutput struct {
+	Indexed int
+}
+
+// IndexUserActivitiesUseCase adds a user's activities back to the search
+// index. It compensates RemoveSearchEntriesUseCase when a deletion is aborted.
+type IndexUserActivitiesUseCase struct {
+	activities repository.ActivityRepositoryInterface
+	indexer    searchTypes.Indexer
+}
+
+// NewIndexUserActivitiesUseCase creates a new instance
+func NewIndexUserActivitiesUseCase(activities repository.ActivityRepositoryInterface, indexer searchTypes.Indexer) *IndexUserActivitiesUseCase {
+	return &IndexUserActivitiesUseCase{
+		activities: activities,
+		indexer:    indexer,
+	}
+}
+
+// RequiresTransaction returns false - activities are only read
+func (uc *IndexUserActivitiesUseCase) RequiresTransaction() bool {
+	return false
+}
+
+// Idempotent returns true - indexing replaces existing documents
+func (uc *IndexUserActivitiesUseCase) Idempotent() bool {
+	return true
+}
+
+// Execute indexes every live activity of the user
+func (uc *IndexUserActivitiesUseCase) Execute(
+	ctx context.Context,
+	_ *sql.Tx,
+	input IndexUserActivitiesInput,
+) (IndexUserActivitiesOutput, error) {
+	activities, err := uc.activities.ListByUser(ctx, input.UserID)
+	if err != nil {
+		return IndexUserActivitiesOutput{}, fmt.Errorf("failed to list activities: %w", err)
+	}
+
+	for _, activity := range activities {
+		if err := uc.indexer.Index(ctx, searchTypes.NewDocument(activity)); err != nil {
+			return IndexUserActivitiesOutput{}, fmt.Errorf("failed to index activity %d: %w", activity.ID, err)
+		}
+	}
+
+	return IndexUserActivitiesOutput{Indexed: len(activities)}, nil
+}
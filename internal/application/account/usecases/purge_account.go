@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/valentinesamuel/activelog/internal/repository"
+)
+
+// PurgeAccountInput defines the typed input for PurgeAccountUseCase
+type PurgeAccountInput struct {
+	UserID int
+}
+
+// PurgeAccountOutput defines the typed output for PurgeAccountUseCase
+type PurgeAccountOutput struct{}
+
+// PurgeAccountUseCase deletes the account's rows: the user, their
+// activities, comments, identities and everything else that references them
+type PurgeAccountUseCase struct {
+	accounts *repository.AccountRepository
+}
+
+// NewPurgeAccountUseCase creates a new instance
+func NewPurgeAccountUseCase(accounts *repository.AccountRepository) *PurgeAccountUseCase {
+	return &PurgeAccountUseCase{
+		accounts: accounts,
+	}
+}
+
+// RequiresTransaction returns false - AccountRepository.Purge runs its own
+// transaction
+func (uc *PurgeAccountUseCase) RequiresTransaction() bool {
+	return false
+}
+
+// Idempotent returns true - the purge is all or nothing
+func (uc *PurgeAccountUseCase) Idempotent() bool {
+	return true
+}
+
+// Execute purges the account
+func (uc *PurgeAccountUseCase) Execute(
+	ctx context.Context,
+	_ *sql.Tx,
+	input PurgeAccountInput,
+) (PurgeAccountOutput, error) {
+	return PurgeAccountOutput{}, uc.accounts.Purge(ctx, input.UserID)
+}
@@ -0,0 +1,65 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	searchTypes "github.com/valentinesamuel/activelog/internal/adapters/search/types"
+	"github.com/valentinesamuel/activelog/internal/repository"
+)
+
+// RemoveSearchEntriesInput defines the typed input for RemoveSearchEntriesUseCase
+type RemoveSearchEntriesInput struct {
+	UserID int
+}
+
+// RemoveSearchEntriesOutput defines the typed output for RemoveSearchEntriesUseCase
+type RemoveSearchEntriesOutput struct {
+	Removed int
+}
+
+// RemoveSearchEntriesUseCase removes a user's activities from the search index
+// The index lives outside Postgres, so this does NOT require a transaction
+type RemoveSearchEntriesUseCase struct {
+	accounts *repository.AccountRepository
+	indexer  searchTypes.Indexer
+}
+
+// NewRemoveSearchEntriesUseCase creates a new instance
+func NewRemoveSearchEntriesUseCase(accounts *repository.AccountRepository, indexer searchTypes.Indexer) *RemoveSearchEntriesUseCase {
+	return &RemoveSearchEntriesUseCase{
+		accounts: accounts,
+		indexer:  indexer,
+	}
+}
+
+// RequiresTransaction returns false - nothing is written to the database
+func (uc *RemoveSearchEntriesUseCase) RequiresTransaction() bool {
+	return false
+}
+
+// Idempotent returns true - deleting a document that isn't indexed is not an error
+func (uc *RemoveSearchEntriesUseCase) Idempotent() bool {
+	return true
+}
+
+// Execute deletes the search document of every activity the user owns
+func (uc *RemoveSearchEntriesUseCase) Execute(
+	ctx context.Context,
+	_ *sql.Tx,
+	input RemoveSearchEntriesInput,
+) (RemoveSearchEntriesOutput, error) {
+	ids, err := uc.accounts.ListActivityIDs(ctx, input.UserID)
+	if err != nil {
+		return RemoveSearchEntriesOutput{}, err
+	}
+
+	for _, id := range ids {
+		if err := uc.indexer.Delete(ctx, id); err != nil {
+			return RemoveSearchEntriesOutput{}, fmt.Errorf("failed to remove activity %d from the search index: %w", id, err)
+		}
+	}
+
+	return RemoveSearchEntriesOutput{Removed: len(ids)}, nil
+}
@@ -0,0 +1,81 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	cacheTypes "github.com/valentinesamuel/activelog/internal/adapters/cache/types"
+	"github.com/valentinesamuel/activelog/internal/application/broker"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/service"
+	"github.com/valentinesamuel/activelog/pkg/query"
+)
+
+// BulkDeleteActivitiesInput defines the typed input for BulkDeleteActivitiesUseCase
+// QueryOptions must already be validated against the bulk filter whitelist
+type BulkDeleteActivitiesInput struct {
+	UserID       int
+	QueryOptions *query.QueryOptions
+}
+
+// BulkDeleteActivitiesOutput defines the typed output for BulkDeleteActivitiesUseCase
+type BulkDeleteActivitiesOutput struct {
+	Affected int64
+}
+
+// BulkDeleteActivitiesUseCase soft-deletes every activity matched by a filter
+type BulkDeleteActivitiesUseCase struct {
+	service service.ActivityServiceInterface
+	repo    repository.ActivityRepositoryInterface
+	cache   cacheTypes.CacheAdapter
+	maxRows int
+}
+
+// NewBulkDeleteActivitiesUseCase creates a new instance
+// maxRows caps how many activities a single request may delete (0 disables the cap)
+func NewBulkDeleteActivitiesUseCase(
+	svc service.ActivityServiceInterface,
+	repo repository.ActivityRepositoryInterface,
+	cache cacheTypes.CacheAdapter,
+	maxRows int,
+) *BulkDeleteActivitiesUseCase {
+	return &BulkDeleteActivitiesUseCase{
+		service: svc,
+		repo:    repo,
+		cache:   cache,
+		maxRows: maxRows,
+	}
+}
+
+// RequiresTransaction indicates this use case needs a transaction
+// The row cap is enforced after the UPDATE runs, so exceeding it must roll back
+func (uc *BulkDeleteActivitiesUseCase) RequiresTransaction() bool {
+	return true
+}
+
+// Execute runs a single filter-targeted soft delete for the user's activities
+func (uc *BulkDeleteActivitiesUseCase) Execute(
+	ctx context.Context,
+	tx *sql.Tx,
+	input BulkDeleteActivitiesInput,
+) (BulkDeleteActivitiesOutput, error) {
+	if input.QueryOptions == nil {
+		return BulkDeleteActivitiesOutput{}, fmt.Errorf("query_options is required")
+	}
+
+	// DECISION: Use repo directly instead of the service
+	// Same reasoning as bulk update - one statement keeps scoping and the row cap atomic
+	affected, err := uc.repo.DeleteByFilter(ctx, tx, input.UserID, input.QueryOptions, uc.maxRows)
+	if err != nil {
+		return BulkDeleteActivitiesOutput{}, fmt.Errorf("failed to bulk delete activities: %w", err)
+	}
+
+	// Nothing was committed on a dry run, so cached lists are still accurate
+	if uc.cache != nil && affected > 0 && !broker.IsDryRun(ctx) {
+		uc.cache.Del(ctx, fmt.Sprintf("user:%d", input.UserID), activityCacheOpts)
+		uc.cache.Del(ctx, fmt.Sprintf("activity:%d", input.UserID), activityCacheOpts)
+	}
+
+	return BulkDeleteActivitiesOutput{Affected: affected}, nil
+}
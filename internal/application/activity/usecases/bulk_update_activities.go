@@ -0,0 +1,147 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	cacheTypes "github.com/valentinesamuel/activelog/internal/adapters/cache/types"
+	"github.com/valentinesamuel/activelog/internal/application/broker"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/service"
+	"github.com/valentinesamuel/activelog/pkg/query"
+)
+
+// BulkUpdateActivitiesInput defines the typed input for BulkUpdateActivitiesUseCase
+// QueryOptions must already be validated against the bulk filter whitelist
+type BulkUpdateActivitiesInput struct {
+	UserID       int
+	QueryOptions *query.QueryOptions
+	Request      *models.UpdateActivityRequest
+}
+
+// BulkUpdateActivitiesOutput defines the typed output for BulkUpdateActivitiesUseCase
+type BulkUpdateActivitiesOutput struct {
+	Affected int64
+}
+
+// BulkUpdateActivitiesUseCase applies the same partial update to every activity matched by a filter
+type BulkUpdateActivitiesUseCase struct {
+	service service.ActivityServiceInterface
+	repo    repository.ActivityRepositoryInterface
+	cache   cacheTypes.CacheAdapter
+	maxRows int
+}
+
+// NewBulkUpdateActivitiesUseCase creates a new instance
+// maxRows caps how many activities a single request may change (0 disables the cap)
+func NewBulkUpdateActivitiesUseCase(
+	svc service.ActivityServiceInterface,
+	repo repository.ActivityRepositoryInterface,
+	cache cacheTypes.CacheAdapter,
+	maxRows int,
+) *BulkUpdateActivitiesUseCase {
+	return &BulkUpdateActivitiesUseCase{
+		service: svc,
+		repo:    repo,
+		cache:   cache,
+		maxRows: maxRows,
+	}
+}
+
+// RequiresTransaction indicates this use case needs a transaction
+// The row cap is enforced after the UPDATE runs, so exceeding it must roll back
+func (uc *BulkUpdateActivitiesUseCase) RequiresTransaction() bool {
+	return true
+}
+
+// Execute runs a single filter-targeted UPDATE for the user's activities
+func (uc *BulkUpdateActivitiesUseCase) Execute(
+	ctx context.Context,
+	tx *sql.Tx,
+	input BulkUpdateActivitiesInput,
+) (BulkUpdateActivitiesOutput, error) {
+	if input.QueryOptions == nil {
+		return BulkUpdateActivitiesOutput{}, fmt.Errorf("query_options is required")
+	}
+	if input.Request == nil {
+		return BulkUpdateActivitiesOutput{}, fmt.Errorf("request is required")
+	}
+
+	changes := updateRequestColumns(input.Request)
+	if len(changes) == 0 {
+		return BulkUpdateActivitiesOutput{}, fmt.Errorf("at least one field to update is required")
+	}
+
+	// DECISION: Use service to resolve the type - the registry is a business rule
+	if input.Request.ActivityType != nil {
+		activityType, err := uc.service.ResolveActivityType(ctx, input.UserID, *input.Request.ActivityType)
+		if err != nil {
+			return BulkUpdateActivitiesOutput{}, err
+		}
+		changes["activity_type"] = activityType
+	}
+	if err := service.ValidateMetadata(input.Request.Metadata); err != nil {
+		return BulkUpdateActivitiesOutput{}, err
+	}
+
+	// DECISION: Use repo directly instead of the service
+	// The service updates one activity at a time; a bulk edit must be a single
+	// UPDATE ... WHERE so the row cap and user scoping apply to the whole set atomically
+	affected, err := uc.repo.UpdateByFilter(ctx, tx, input.UserID, input.QueryOptions, changes, uc.maxRows)
+	if err != nil {
+		return BulkUpdateActivitiesOutput{}, fmt.Errorf("failed to bulk update activities: %w", err)
+	}
+
+	// Nothing was committed on a dry run, so cached lists are still accurate
+	if uc.cache != nil && affected > 0 && !broker.IsDryRun(ctx) {
+		uc.cache.Del(ctx, fmt.Sprintf("user:%d", input.UserID), activityCacheOpts)
+		uc.cache.Del(ctx, fmt.Sprintf("activity:%d", input.UserID), activityCacheOpts)
+	}
+
+	return BulkUpdateActivitiesOutput{Affected: affected}, nil
+}
+
+// updateRequestColumns maps the non-nil fields of an update request to their columns
+// The request struct doubles as the whitelist of fields a bulk update may set
+func updateRequestColumns(req *models.UpdateActivityRequest) map[string]interface{} {
+	changes := map[string]interface{}{}
+	if req.ActivityType != nil {
+		changes["activity_type"] = *req.ActivityType
+	}
+	if req.Title != nil {
+		changes["title"] = *req.Title
+	}
+	if req.Description != nil {
+		changes["description"] = *req.Description
+	}
+	if req.DurationMinutes != nil {
+		changes["duration_minutes"] = *req.DurationMinutes
+	}
+	if req.DistanceKm != nil {
+		changes["distance_km"] = *req.DistanceKm
+	}
+	if req.CaloriesBurned != nil {
+		changes["calories_burned"] = *req.CaloriesBurned
+	}
+	if req.Notes != nil {
+		changes["notes"] = *req.Notes
+	}
+	if req.ActivityDate != nil {
+		changes["activity_date"] = *req.ActivityDate
+	}
+	if req.RPE != nil {
+		changes["rpe"] = *req.RPE
+	}
+	if req.Mood != nil {
+		changes["mood"] = *req.Mood
+	}
+	if req.Metadata != nil {
+		changes["metadata"] = req.Metadata
+	}
+	if req.Visibility != nil {
+		changes["visibility"] = *req.Visibility
+	}
+	return changes
+}
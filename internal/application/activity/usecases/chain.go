@@ -0,0 +1,30 @@
+package usecases
+
+import "github.com/valentinesamuel/activelog/internal/application/broker"
+
+// Bag keys for activity use case outputs in broker.RunUseCases chains.
+// A step reads an earlier step's output with broker.Get / broker.Require
+// instead of asserting values out of a map.
+var (
+	CreatedActivityKey     = broker.NewKey[CreateActivityOutput]("activity.created")
+	UpdatedActivityKey     = broker.NewKey[UpdateActivityOutput]("activity.updated")
+	DeletedActivityKey     = broker.NewKey[DeleteActivityOutput]("activity.deleted")
+	ActivityKey            = broker.NewKey[GetActivityOutput]("activity")
+	ActivityListKey        = broker.NewKey[ListActivitiesOutput]("activity.list")
+	ActivityStatsKey       = broker.NewKey[GetActivityStatsOutput]("activity.stats")
+	BulkUpdatedActivityKey = broker.NewKey[BulkUpdateActivitiesOutput]("activity.bulk_updated")
+	BulkDeletedActivityKey = broker.NewKey[BulkDeleteActivitiesOutput]("activity.bulk_deleted")
+)
+
+// Compile-time checks that each activity use case satisfies the typed broker
+// contract for its own Input/Output pair
+var (
+	_ broker.TransactionalTypedUseCase[CreateActivityInput, CreateActivityOutput]             = (*CreateActivityUseCase)(nil)
+	_ broker.TransactionalTypedUseCase[UpdateActivityInput, UpdateActivityOutput]             = (*UpdateActivityUseCase)(nil)
+	_ broker.TransactionalTypedUseCase[DeleteActivityInput, DeleteActivityOutput]             = (*DeleteActivityUseCase)(nil)
+	_ broker.TransactionalTypedUseCase[GetActivityInput, GetActivityOutput]                   = (*GetActivityUseCase)(nil)
+	_ broker.TransactionalTypedUseCase[ListActivitiesInput, ListActivitiesOutput]             = (*ListActivitiesUseCase)(nil)
+	_ broker.TransactionalTypedUseCase[GetActivityStatsInput, GetActivityStatsOutput]         = (*GetActivityStatsUseCase)(nil)
+	_ broker.TransactionalTypedUseCase[BulkUpdateActivitiesInput, BulkUpdateActivitiesOutput] = (*BulkUpdateActivitiesUseCase)(nil)
+	_ broker.TransactionalTypedUseCase[BulkDeleteActivitiesInput, BulkDeleteActivitiesOutput] = (*BulkDeleteActivitiesUseCase)(nil)
+)
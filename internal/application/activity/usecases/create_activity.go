@@ -3,42 +3,55 @@ package usecases
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/valentinesamuel/activelog/internal/models"
 	"github.com/valentinesamuel/activelog/internal/repository"
 	"github.com/valentinesamuel/activelog/internal/service"
+	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
 )
 
 // CreateActivityInput defines the typed input for CreateActivityUseCase
 type CreateActivityInput struct {
-	UserID  int
-	Request *models.CreateActivityRequest
+	UserID         int
+	Request        *models.CreateActivityRequest
+	AllowDuplicate bool // Skip the duplicate check (e.g. ?allow_duplicate=true)
+
+	// ClientMutationID is the sync client's ID for this create. A create
+	// replayed with an ID seen before returns the activity it made.
+	ClientMutationID string
 }
 
 // CreateActivityOutput defines the typed output for CreateActivityUseCase
 type CreateActivityOutput struct {
 	Activity   *models.Activity
 	ActivityID int64
+	Replayed   bool // ClientMutationID was seen before; nothing was created
 }
 
 // CreateActivityUseCase handles activity creation
 // Has access to both service (for business logic) and repository (for simple operations)
 // The use case decides which one to use based on the operation's needs
 type CreateActivityUseCase struct {
-	service service.ActivityServiceInterface       // For operations requiring business logic
-	repo    repository.ActivityRepositoryInterface // For simple operations or when service not needed
+	service      service.ActivityServiceInterface       // For operations requiring business logic
+	repo         repository.ActivityRepositoryInterface // For simple operations or when service not needed
+	dedupeWindow time.Duration                          // Tolerance around activity_date; 0 disables dedupe
 }
 
 // NewCreateActivityUseCase creates a new instance with both service and repository
 // The use case will decide which one to use based on what it needs
+// dedupeWindow is the activity_date tolerance for duplicate detection (0 disables it)
 func NewCreateActivityUseCase(
 	svc service.ActivityServiceInterface,
 	repo repository.ActivityRepositoryInterface,
+	dedupeWindow time.Duration,
 ) *CreateActivityUseCase {
 	return &CreateActivityUseCase{
-		service: svc,
-		repo:    repo,
+		service:      svc,
+		repo:         repo,
+		dedupeWindow: dedupeWindow,
 	}
 }
 
@@ -58,6 +71,47 @@ func (uc *CreateActivityUseCase) Execute(
 		return CreateActivityOutput{}, fmt.Errorf("request is required")
 	}
 
+	// DECISION: Claim the client mutation ID first, in the same transaction as the
+	// insert, so a replay either sees the committed activity or waits for it
+	if input.ClientMutationID != "" {
+		existingID, err := uc.repo.ClaimClientMutation(ctx, tx, input.UserID, input.ClientMutationID)
+		if err != nil {
+			return CreateActivityOutput{}, fmt.Errorf("failed to claim client mutation: %w", err)
+		}
+		if existingID != 0 {
+			output := CreateActivityOutput{ActivityID: existingID, Replayed: true}
+			// The activity may have been deleted since; the ID is still what the create made
+			if existing, err := uc.repo.GetByID(ctx, existingID); err == nil {
+				output.Activity = existing
+			}
+			return output, nil
+		}
+	}
+
+	// DECISION: Use repo directly for the duplicate lookup - it's a plain read with no business rules
+	// Runs inside the same transaction so the check and the insert see the same snapshot
+	if uc.dedupeWindow > 0 && !input.AllowDuplicate {
+		candidate := &models.Activity{
+			UserID:          input.UserID,
+			ActivityType:    input.Request.ActivityType,
+			DurationMinutes: input.Request.DurationMinutes,
+			DistanceKm:      input.Request.DistanceKm,
+			ActivityDate:    input.Request.ActivityDate,
+		}
+
+		existing, err := uc.repo.FindDuplicate(ctx, tx, candidate, uc.dedupeWindow)
+		if err != nil && !errors.Is(err, appErrors.ErrNotFound) {
+			return CreateActivityOutput{}, fmt.Errorf("failed to check for duplicate activity: %w", err)
+		}
+		if existing != nil {
+			return CreateActivityOutput{}, &appErrors.DuplicateError{
+				Resource:         "activity",
+				ExistingID:       existing.ID,
+				ExistingPublicID: existing.PublicID,
+			}
+		}
+	}
+
 	// DECISION: Use service to create operations because we need business logic validation
 	// - Validates date not in future
 	// - Validates duration is reasonable
@@ -68,6 +122,12 @@ func (uc *CreateActivityUseCase) Execute(
 		return CreateActivityOutput{}, fmt.Errorf("failed to create activity: %w", err)
 	}
 
+	if input.ClientMutationID != "" {
+		if err := uc.repo.RecordClientMutation(ctx, tx, input.UserID, input.ClientMutationID, activity.ID); err != nil {
+			return CreateActivityOutput{}, fmt.Errorf("failed to record client mutation: %w", err)
+		}
+	}
+
 	return CreateActivityOutput{
 		Activity:   activity,
 		ActivityID: activity.ID,
@@ -0,0 +1,119 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/repository/mocks"
+	"github.com/valentinesamuel/activelog/internal/service"
+	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
+)
+
+// fakeActivityService creates activities with the next ID and counts the calls
+type fakeActivityService struct {
+	service.ActivityServiceInterface
+	created int
+}
+
+func (s *fakeActivityService) CreateActivity(_ context.Context, _ repository.TxConn, userID int, req *models.CreateActivityRequest) (*models.Activity, error) {
+	s.created++
+	return &models.Activity{
+		BaseEntity:   models.BaseEntity{ID: int64(100 + s.created)},
+		UserID:       userID,
+		ActivityType: req.ActivityType,
+		ActivityDate: req.ActivityDate,
+	}, nil
+}
+
+func TestCreateActivityUseCase_Duplicates(t *testing.T) {
+	date := time.Date(2026, 5, 1, 7, 0, 0, 0, time.UTC)
+	req := &models.CreateActivityRequest{
+		ActivityType:    "running",
+		Title:           "Morning run",
+		DurationMinutes: 30,
+		DistanceKm:      5,
+		ActivityDate:    date,
+	}
+	existing := &models.Activity{BaseEntity: models.BaseEntity{ID: 42}, PublicID: "01HZY3V5J6X7Q8R9S0T1V2W3X4"}
+
+	// candidate matches the activity the duplicate lookup gets
+	candidate := gomock.Cond(func(x any) bool {
+		a, ok := x.(*models.Activity)
+		return ok && a.UserID == 7 && a.ActivityType == "running" && a.DurationMinutes == 30 &&
+			a.DistanceKm == 5 && a.ActivityDate.Equal(date)
+	})
+
+	t.Run("duplicate found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockActivityRepositoryInterface(ctrl)
+		svc := &fakeActivityService{}
+		repo.EXPECT().FindDuplicate(gomock.Any(), gomock.Any(), candidate, 2*time.Minute).Return(existing, nil)
+
+		_, err := NewCreateActivityUseCase(svc, repo, 2*time.Minute).Execute(context.Background(), nil, CreateActivityInput{UserID: 7, Request: req})
+
+		var dupErr *appErrors.DuplicateError
+		require.ErrorAs(t, err, &dupErr)
+		assert.Equal(t, int64(42), dupErr.ExistingID)
+		assert.Equal(t, existing.PublicID, dupErr.ExistingPublicID)
+		assert.ErrorIs(t, err, appErrors.ErrAlreadyExists)
+		assert.Zero(t, svc.created, "nothing is created")
+	})
+
+	t.Run("no duplicate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockActivityRepositoryInterface(ctrl)
+		svc := &fakeActivityService{}
+		repo.EXPECT().FindDuplicate(gomock.Any(), gomock.Any(), candidate, 2*time.Minute).Return(nil, appErrors.ErrNotFound)
+
+		out, err := NewCreateActivityUseCase(svc, repo, 2*time.Minute).Execute(context.Background(), nil, CreateActivityInput{UserID: 7, Request: req})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(101), out.ActivityID)
+		assert.Equal(t, 1, svc.created)
+	})
+
+	t.Run("allow_duplicate skips the check", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockActivityRepositoryInterface(ctrl)
+		svc := &fakeActivityService{}
+
+		out, err := NewCreateActivityUseCase(svc, repo, 2*time.Minute).Execute(context.Background(), nil, CreateActivityInput{UserID: 7, Request: req, AllowDuplicate: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(101), out.ActivityID)
+	})
+
+	t.Run("disabled without a window", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockActivityRepositoryInterface(ctrl)
+		svc := &fakeActivityService{}
+
+		_, err := NewCreateActivityUseCase(svc, repo, 0).Execute(context.Background(), nil, CreateActivityInput{UserID: 7, Request: req})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, svc.created)
+	})
+
+	t.Run("lookup fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockActivityRepositoryInterface(ctrl)
+		svc := &fakeActivityService{}
+		dbErr := &appErrors.DatabaseError{Op: "SELECT", Table: "activities", Err: errors.New("connection reset")}
+		repo.EXPECT().FindDuplicate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
+
+		_, err := NewCreateActivityUseCase(svc, repo, 2*time.Minute).Execute(context.Background(), nil, CreateActivityInput{UserID: 7, Request: req})
+
+		assert.ErrorIs(t, err, dbErr)
+		var dupErr *appErrors.DuplicateError
+		assert.False(t, errors.As(err, &dupErr))
+		assert.Zero(t, svc.created)
+	})
+}
@@ -13,12 +13,17 @@ import (
 type DeleteActivityInput struct {
 	UserID     int
 	ActivityID int
+
+	// ExpectedVersion, if set, rejects the delete with a *errors.VersionConflictError
+	// unless the activity is still at this version (offline sync)
+	ExpectedVersion *int
 }
 
 // DeleteActivityOutput defines the typed output for DeleteActivityUseCase
 type DeleteActivityOutput struct {
 	Deleted    bool
 	ActivityID int
+	PublicID   string
 }
 
 // DeleteActivityUseCase handles activity deletion
@@ -47,6 +52,11 @@ func (uc *DeleteActivityUseCase) RequiresTransaction() bool {
 	return true
 }
 
+// Idempotent returns true - the retried delete starts from the rolled-back state
+func (uc *DeleteActivityUseCase) Idempotent() bool {
+	return true
+}
+
 // Execute deletes an activity (typed version)
 // Decision: Use service for business logic checks, repo is available if needed
 func (uc *DeleteActivityUseCase) Execute(
@@ -59,7 +69,19 @@ func (uc *DeleteActivityUseCase) Execute(
 	// - Business policy checks (e.g., preventing deletion of old activities)
 	// - Cascade deletion handling
 	// Alternative: Could use repo directly for simple hard deletes without checks
-	err := uc.service.DeleteActivity(ctx, tx, input.UserID, input.ActivityID)
+	if input.ExpectedVersion != nil {
+		if err := checkVersion(ctx, uc.repo, tx, input.UserID, input.ActivityID, *input.ExpectedVersion); err != nil {
+			return DeleteActivityOutput{}, err
+		}
+	}
+
+	// The public ID identifies the deleted activity in the activity.deleted webhook
+	activity, err := uc.repo.GetByID(ctx, int64(input.ActivityID))
+	if err != nil {
+		return DeleteActivityOutput{}, fmt.Errorf("failed to delete activity: %w", err)
+	}
+
+	err = uc.service.DeleteActivity(ctx, tx, input.UserID, input.ActivityID)
 	if err != nil {
 		return DeleteActivityOutput{}, fmt.Errorf("failed to delete activity: %w", err)
 	}
@@ -67,5 +89,6 @@ func (uc *DeleteActivityUseCase) Execute(
 	return DeleteActivityOutput{
 		Deleted:    true,
 		ActivityID: input.ActivityID,
+		PublicID:   activity.PublicID,
 	}, nil
 }
@@ -2,10 +2,15 @@ package di
 
 // Container registration keys for activity use cases
 const (
-	CreateActivityUCKey   = "createActivityUC"
-	UpdateActivityUCKey   = "updateActivityUC"
-	DeleteActivityUCKey   = "deleteActivityUC"
-	GetActivityUCKey      = "getActivityUC"
-	ListActivitiesUCKey   = "listActivitiesUC"
-	GetActivityStatsUCKey = "getActivityStatsUC"
+	CreateActivityUCKey       = "createActivityUC"
+	UpdateActivityUCKey       = "updateActivityUC"
+	DeleteActivityUCKey       = "deleteActivityUC"
+	GetActivityUCKey          = "getActivityUC"
+	ListActivitiesUCKey       = "listActivitiesUC"
+	GetActivityStatsUCKey     = "getActivityStatsUC"
+	BulkUpdateActivitiesUCKey = "bulkUpdateActivitiesUC"
+	BulkDeleteActivitiesUCKey = "bulkDeleteActivitiesUC"
+	MergeActivityTypesUCKey   = "mergeActivityTypesUC"
+	MergeActivitiesUCKey      = "mergeActivitiesUC"
+	FindDuplicatesUCKey       = "findDuplicatesUC"
 )
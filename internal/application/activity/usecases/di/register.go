@@ -1,14 +1,19 @@
 package di
 
 import (
+	"time"
+
 	"github.com/valentinesamuel/activelog/internal/application/activity/usecases"
 	cacheDI "github.com/valentinesamuel/activelog/internal/adapters/cache/di"
 	cacheTypes "github.com/valentinesamuel/activelog/internal/adapters/cache/types"
+	clockDI "github.com/valentinesamuel/activelog/internal/platform/clock/di"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
 	"github.com/valentinesamuel/activelog/internal/platform/container"
 	"github.com/valentinesamuel/activelog/internal/repository"
 	repoDI "github.com/valentinesamuel/activelog/internal/repository/di"
 	"github.com/valentinesamuel/activelog/internal/service"
 	serviceDI "github.com/valentinesamuel/activelog/internal/service/di"
+	"github.com/valentinesamuel/activelog/pkg/clock"
 )
 
 // RegisterActivityUseCases registers all activity-related use case factories
@@ -18,14 +23,18 @@ func RegisterActivityUseCases(c *container.Container) {
 	// Write operations (transactional)
 	// These typically use service for business logic but have repo available if needed
 	c.Register(CreateActivityUCKey, func(c *container.Container) (interface{}, error) {
-		svc := c.MustResolve(serviceDI.ActivityServiceKey).(service.ActivityServiceInterface)
-		repo := c.MustResolve(repoDI.ActivityRepoKey).(repository.ActivityRepositoryInterface)
-		return usecases.NewCreateActivityUseCase(svc, repo), nil
+		svc := container.MustResolve[service.ActivityServiceInterface](c, serviceDI.ActivityServiceKey)
+		repo := container.MustResolve[repository.ActivityRepositoryInterface](c, repoDI.ActivityRepoKey)
+		var dedupeWindow time.Duration
+		if config.Activity != nil && config.Activity.DedupeEnabled {
+			dedupeWindow = config.Activity.DedupeWindow
+		}
+		return usecases.NewCreateActivityUseCase(svc, repo, dedupeWindow), nil
 	})
 
 	c.Register(UpdateActivityUCKey, func(c *container.Container) (interface{}, error) {
-		svc := c.MustResolve(serviceDI.ActivityServiceKey).(service.ActivityServiceInterface)
-		repo := c.MustResolve(repoDI.ActivityRepoKey).(repository.ActivityRepositoryInterface)
+		svc := container.MustResolve[service.ActivityServiceInterface](c, serviceDI.ActivityServiceKey)
+		repo := container.MustResolve[repository.ActivityRepositoryInterface](c, repoDI.ActivityRepoKey)
 		var cacheAdapter cacheTypes.CacheAdapter
 		if resolved := c.MustResolve(cacheDI.CacheAdapterKey); resolved != nil {
 			cacheAdapter = resolved.(cacheTypes.CacheAdapter)
@@ -34,22 +43,62 @@ func RegisterActivityUseCases(c *container.Container) {
 	})
 
 	c.Register(DeleteActivityUCKey, func(c *container.Container) (interface{}, error) {
-		svc := c.MustResolve(serviceDI.ActivityServiceKey).(service.ActivityServiceInterface)
-		repo := c.MustResolve(repoDI.ActivityRepoKey).(repository.ActivityRepositoryInterface)
+		svc := container.MustResolve[service.ActivityServiceInterface](c, serviceDI.ActivityServiceKey)
+		repo := container.MustResolve[repository.ActivityRepositoryInterface](c, repoDI.ActivityRepoKey)
 		return usecases.NewDeleteActivityUseCase(svc, repo), nil
 	})
 
+	c.Register(BulkUpdateActivitiesUCKey, func(c *container.Container) (interface{}, error) {
+		svc := container.MustResolve[service.ActivityServiceInterface](c, serviceDI.ActivityServiceKey)
+		repo := container.MustResolve[repository.ActivityRepositoryInterface](c, repoDI.ActivityRepoKey)
+		var cacheAdapter cacheTypes.CacheAdapter
+		if resolved := c.MustResolve(cacheDI.CacheAdapterKey); resolved != nil {
+			cacheAdapter = resolved.(cacheTypes.CacheAdapter)
+		}
+		return usecases.NewBulkUpdateActivitiesUseCase(svc, repo, cacheAdapter, bulkMaxRows()), nil
+	})
+
+	c.Register(BulkDeleteActivitiesUCKey, func(c *container.Container) (interface{}, error) {
+		svc := container.MustResolve[service.ActivityServiceInterface](c, serviceDI.ActivityServiceKey)
+		repo := container.MustResolve[repository.ActivityRepositoryInterface](c, repoDI.ActivityRepoKey)
+		var cacheAdapter cacheTypes.CacheAdapter
+		if resolved := c.MustResolve(cacheDI.CacheAdapterKey); resolved != nil {
+			cacheAdapter = resolved.(cacheTypes.CacheAdapter)
+		}
+		return usecases.NewBulkDeleteActivitiesUseCase(svc, repo, cacheAdapter, bulkMaxRows()), nil
+	})
+
+	c.Register(MergeActivityTypesUCKey, func(c *container.Container) (interface{}, error) {
+		svc := container.MustResolve[service.ActivityServiceInterface](c, serviceDI.ActivityServiceKey)
+		typeRepo := container.MustResolve[*repository.ActivityTypeRepository](c, repoDI.ActivityTypeRepoKey)
+		var cacheAdapter cacheTypes.CacheAdapter
+		if resolved := c.MustResolve(cacheDI.CacheAdapterKey); resolved != nil {
+			cacheAdapter = resolved.(cacheTypes.CacheAdapter)
+		}
+		return usecases.NewMergeActivityTypesUseCase(svc, typeRepo, cacheAdapter), nil
+	})
+
+	c.Register(MergeActivitiesUCKey, func(c *container.Container) (interface{}, error) {
+		svc := container.MustResolve[service.ActivityServiceInterface](c, serviceDI.ActivityServiceKey)
+		repo := container.MustResolve[repository.ActivityRepositoryInterface](c, repoDI.ActivityRepoKey)
+		var cacheAdapter cacheTypes.CacheAdapter
+		if resolved := c.MustResolve(cacheDI.CacheAdapterKey); resolved != nil {
+			cacheAdapter = resolved.(cacheTypes.CacheAdapter)
+		}
+		return usecases.NewMergeActivitiesUseCase(svc, repo, cacheAdapter), nil
+	})
+
 	// Read operations (non-transactional)
 	// These typically use repo directly for performance but have service available for enrichment
 	c.Register(GetActivityUCKey, func(c *container.Container) (interface{}, error) {
-		svc := c.MustResolve(serviceDI.ActivityServiceKey).(service.ActivityServiceInterface)
-		repo := c.MustResolve(repoDI.ActivityRepoKey).(repository.ActivityRepositoryInterface)
+		svc := container.MustResolve[service.ActivityServiceInterface](c, serviceDI.ActivityServiceKey)
+		repo := container.MustResolve[repository.ActivityRepositoryInterface](c, repoDI.ActivityRepoKey)
 		return usecases.NewGetActivityUseCase(svc, repo), nil
 	})
 
 	c.Register(ListActivitiesUCKey, func(c *container.Container) (interface{}, error) {
-		svc := c.MustResolve(serviceDI.ActivityServiceKey).(service.ActivityServiceInterface)
-		repo := c.MustResolve(repoDI.ActivityRepoKey).(repository.ActivityRepositoryInterface)
+		svc := container.MustResolve[service.ActivityServiceInterface](c, serviceDI.ActivityServiceKey)
+		repo := container.MustResolve[repository.ActivityRepositoryInterface](c, repoDI.ActivityRepoKey)
 		// Cache adapter may be nil if not configured — handle gracefully
 		var cacheAdapter cacheTypes.CacheAdapter
 		if resolved := c.MustResolve(cacheDI.CacheAdapterKey); resolved != nil {
@@ -59,8 +108,33 @@ func RegisterActivityUseCases(c *container.Container) {
 	})
 
 	c.Register(GetActivityStatsUCKey, func(c *container.Container) (interface{}, error) {
-		statsSvc := c.MustResolve(serviceDI.StatsServiceKey).(service.StatsServiceInterface)
-		repo := c.MustResolve(repoDI.ActivityRepoKey).(repository.ActivityRepositoryInterface)
-		return usecases.NewGetActivityStatsUseCase(statsSvc, repo), nil
+		statsSvc := container.MustResolve[service.StatsServiceInterface](c, serviceDI.StatsServiceKey)
+		repo := container.MustResolve[repository.ActivityRepositoryInterface](c, repoDI.ActivityRepoKey)
+		clk := container.MustResolve[clock.Clock](c, clockDI.ClockKey)
+		return usecases.NewGetActivityStatsUseCase(statsSvc, repo).WithClock(clk), nil
+	})
+
+	c.Register(FindDuplicatesUCKey, func(c *container.Container) (interface{}, error) {
+		svc := container.MustResolve[service.ActivityServiceInterface](c, serviceDI.ActivityServiceKey)
+		repo := container.MustResolve[repository.ActivityRepositoryInterface](c, repoDI.ActivityRepoKey)
+		return usecases.NewFindDuplicateActivitiesUseCase(svc, repo, duplicateCriteria()), nil
 	})
 }
+
+// duplicateCriteria returns the configured near-duplicate criteria
+func duplicateCriteria() repository.DuplicateCriteria {
+	criteria := repository.DuplicateCriteria{Slack: 5 * time.Minute, DistanceTolerance: 0.1}
+	if config.Activity != nil {
+		criteria.Slack = config.Activity.DuplicateSlack
+		criteria.DistanceTolerance = float64(config.Activity.DuplicateDistancePct) / 100
+	}
+	return criteria
+}
+
+// bulkMaxRows returns the configured cap for filter-targeted mutations
+func bulkMaxRows() int {
+	if config.Activity != nil {
+		return config.Activity.BulkMaxRows
+	}
+	return 500
+}
@@ -0,0 +1,112 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/service"
+)
+
+// maxDuplicatePairs caps the pairs a duplicate search looks at, so a user
+// who imported the same history twice gets the newest clusters first
+const maxDuplicatePairs = 500
+
+// FindDuplicateActivitiesInput defines the typed input for FindDuplicateActivitiesUseCase
+type FindDuplicateActivitiesInput struct {
+	UserID int
+}
+
+// FindDuplicateActivitiesOutput defines the typed output for FindDuplicateActivitiesUseCase
+type FindDuplicateActivitiesOutput struct {
+	Clusters []*models.DuplicateCluster
+}
+
+// FindDuplicateActivitiesUseCase groups the user's activities that look like
+// the same workout, for the user to merge (see MergeActivitiesUseCase)
+type FindDuplicateActivitiesUseCase struct {
+	service  service.ActivityServiceInterface
+	repo     repository.ActivityRepositoryInterface
+	criteria repository.DuplicateCriteria
+}
+
+// NewFindDuplicateActivitiesUseCase creates a new instance
+// criteria.Limit defaults to maxDuplicatePairs
+func NewFindDuplicateActivitiesUseCase(
+	svc service.ActivityServiceInterface,
+	repo repository.ActivityRepositoryInterface,
+	criteria repository.DuplicateCriteria,
+) *FindDuplicateActivitiesUseCase {
+	if criteria.Limit <= 0 {
+		criteria.Limit = maxDuplicatePairs
+	}
+	return &FindDuplicateActivitiesUseCase{
+		service:  svc,
+		repo:     repo,
+		criteria: criteria,
+	}
+}
+
+// RequiresTransaction returns false - this is a read-only operation
+func (uc *FindDuplicateActivitiesUseCase) RequiresTransaction() bool {
+	return false
+}
+
+// Execute finds the duplicate pairs and groups them into clusters, newest
+// first, each with the activity a merge would keep
+func (uc *FindDuplicateActivitiesUseCase) Execute(
+	ctx context.Context,
+	_ *sql.Tx,
+	input FindDuplicateActivitiesInput,
+) (FindDuplicateActivitiesOutput, error) {
+	// DECISION: Use repo directly - the pairs come from one self-join, and
+	// clustering and scoring are pure functions of the result
+	pairs, err := uc.repo.FindDuplicatePairs(ctx, input.UserID, uc.criteria)
+	if err != nil {
+		return FindDuplicateActivitiesOutput{}, fmt.Errorf("failed to find duplicate activities: %w", err)
+	}
+	clusters := service.ClusterDuplicates(pairs)
+	if len(clusters) == 0 {
+		return FindDuplicateActivitiesOutput{Clusters: []*models.DuplicateCluster{}}, nil
+	}
+
+	var ids []int64
+	for _, cluster := range clusters {
+		ids = append(ids, cluster...)
+	}
+	activities, err := uc.repo.ListByIDs(ctx, input.UserID, ids)
+	if err != nil {
+		return FindDuplicateActivitiesOutput{}, fmt.Errorf("failed to load duplicate activities: %w", err)
+	}
+	attachments, err := uc.repo.CountAttachments(ctx, ids)
+	if err != nil {
+		return FindDuplicateActivitiesOutput{}, fmt.Errorf("failed to count attachments: %w", err)
+	}
+
+	byID := make(map[int64]*models.Activity, len(activities))
+	for _, a := range activities {
+		byID[a.ID] = a
+	}
+
+	out := make([]*models.DuplicateCluster, 0, len(clusters))
+	for _, cluster := range clusters {
+		members := make([]*models.Activity, 0, len(cluster))
+		for _, id := range cluster {
+			if a, ok := byID[id]; ok {
+				members = append(members, a)
+			}
+		}
+		// An activity deleted since the search leaves no cluster behind
+		if len(members) < 2 {
+			continue
+		}
+		out = append(out, &models.DuplicateCluster{
+			KeepID:     service.RichestActivity(members, attachments).ID,
+			Activities: members,
+		})
+	}
+
+	return FindDuplicateActivitiesOutput{Clusters: out}, nil
+}
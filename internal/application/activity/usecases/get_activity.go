@@ -13,6 +13,9 @@ import (
 // GetActivityInput defines the typed input for GetActivityUseCase
 type GetActivityInput struct {
 	ActivityID int64
+
+	// IncludeSplits loads the activity's splits into Activity.Splits
+	IncludeSplits bool
 }
 
 // GetActivityOutput defines the typed output for GetActivityUseCase
@@ -45,6 +48,11 @@ func (uc *GetActivityUseCase) RequiresTransaction() bool {
 	return false
 }
 
+// Idempotent returns true - reads are safe to retry
+func (uc *GetActivityUseCase) Idempotent() bool {
+	return true
+}
+
 // Execute retrieves a single activity (typed version)
 // Decision: Use repo directly for simple reads (no business logic needed)
 func (uc *GetActivityUseCase) Execute(
@@ -59,5 +67,12 @@ func (uc *GetActivityUseCase) Execute(
 		return GetActivityOutput{}, fmt.Errorf("failed to get activity: %w", err)
 	}
 
+	if input.IncludeSplits {
+		activity.Splits, err = uc.repo.GetSplits(ctx, input.ActivityID)
+		if err != nil {
+			return GetActivityOutput{}, fmt.Errorf("failed to get activity splits: %w", err)
+		}
+	}
+
 	return GetActivityOutput{Activity: activity}, nil
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/valentinesamuel/activelog/internal/repository"
 	"github.com/valentinesamuel/activelog/internal/service"
+	"github.com/valentinesamuel/activelog/pkg/clock"
 )
 
 // GetActivityStatsInput defines the typed input for GetActivityStatsUseCase
@@ -30,6 +31,7 @@ type GetActivityStatsOutput struct {
 type GetActivityStatsUseCase struct {
 	service service.StatsServiceInterface          // For operations requiring enrichment (activity level, insights)
 	repo    repository.ActivityRepositoryInterface // For simple statistical queries
+	clock   clock.Clock                            // Ends the default date range
 }
 
 // NewGetActivityStatsUseCase creates a new instance with both service and repository
@@ -41,14 +43,26 @@ func NewGetActivityStatsUseCase(
 	return &GetActivityStatsUseCase{
 		service: svc,
 		repo:    repo,
+		clock:   clock.Real{},
 	}
 }
 
+// WithClock sets the clock the default date range ends at
+func (uc *GetActivityStatsUseCase) WithClock(c clock.Clock) *GetActivityStatsUseCase {
+	uc.clock = c
+	return uc
+}
+
 // RequiresTransaction returns false - read operations don't need transactions
 func (uc *GetActivityStatsUseCase) RequiresTransaction() bool {
 	return false
 }
 
+// Idempotent returns true - reads are safe to retry
+func (uc *GetActivityStatsUseCase) Idempotent() bool {
+	return true
+}
+
 // Execute retrieves activity statistics (typed version)
 // Decision: Use repo for simple stats, service available for enrichment
 func (uc *GetActivityStatsUseCase) Execute(
@@ -61,19 +75,19 @@ func (uc *GetActivityStatsUseCase) Execute(
 	endDate := input.EndDate
 
 	if startDate == nil {
-		defaultStart := time.Now().AddDate(0, 0, -30)
+		defaultStart := uc.clock.Now().AddDate(0, 0, -30)
 		startDate = &defaultStart
 	}
 
 	if endDate == nil {
-		defaultEnd := time.Now()
+		defaultEnd := uc.clock.Now()
 		endDate = &defaultEnd
 	}
 
 	// DECISION: Use repo directly for simple stats retrieval - no business logic needed
 	// Alternative: Could use service to enrich stats with activity level determination,
 	// insights, or additional analytics (e.g., uc.service.EnrichStats(stats))
-	stats, err := uc.repo.GetStats(input.UserID, startDate, endDate)
+	stats, err := uc.repo.GetStats(ctx, input.UserID, startDate, endDate)
 	if err != nil {
 		return GetActivityStatsOutput{}, fmt.Errorf("failed to get activity stats: %w", err)
 	}
@@ -9,6 +9,7 @@ import (
 
 	cacheTypes "github.com/valentinesamuel/activelog/internal/adapters/cache/types"
 	"github.com/valentinesamuel/activelog/internal/middleware"
+	"github.com/valentinesamuel/activelog/internal/models"
 	"github.com/valentinesamuel/activelog/internal/repository"
 	"github.com/valentinesamuel/activelog/internal/service"
 	"github.com/valentinesamuel/activelog/pkg/query"
@@ -26,7 +27,7 @@ type CacheMeta struct {
 }
 
 type ListActivitiesOutput struct {
-	Result *query.PaginatedResult
+	Result *query.Page[models.Activity]
 	Cache  CacheMeta
 }
 
@@ -52,6 +53,11 @@ func (uc *ListActivitiesUseCase) RequiresTransaction() bool {
 	return false
 }
 
+// Idempotent returns true - reads are safe to retry
+func (uc *ListActivitiesUseCase) Idempotent() bool {
+	return true
+}
+
 const cacheTTL = 2 * time.Minute
 
 var activityCacheOpts = cacheTypes.CacheOptions{
@@ -77,7 +83,7 @@ func (uc *ListActivitiesUseCase) Execute(
 	// Try cache first
 	if uc.cache != nil {
 		if cached, err := uc.cache.Get(ctx, cacheKey, activityCacheOpts); err == nil && cached != "" {
-			var result query.PaginatedResult
+			var result query.Page[models.Activity]
 			if err := json.Unmarshal([]byte(cached), &result); err == nil {
 				middleware.CacheHitsTotal.Inc()
 				return ListActivitiesOutput{
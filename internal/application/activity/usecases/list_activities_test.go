@@ -0,0 +1,72 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	cacheTypes "github.com/valentinesamuel/activelog/internal/adapters/cache/types"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository/mocks"
+	"github.com/valentinesamuel/activelog/pkg/query"
+)
+
+// memoryCache is a CacheAdapter over a map
+type memoryCache map[string]string
+
+func (c memoryCache) Get(_ context.Context, key string, _ cacheTypes.CacheOptions) (string, error) {
+	return c[key], nil
+}
+
+func (c memoryCache) Set(_ context.Context, key string, value string, _ time.Duration, _ cacheTypes.CacheOptions) error {
+	c[key] = value
+	return nil
+}
+
+func (c memoryCache) Del(_ context.Context, key string, _ cacheTypes.CacheOptions) error {
+	delete(c, key)
+	return nil
+}
+
+func TestListActivitiesUseCase_CachedPage(t *testing.T) {
+	date := time.Date(2026, 5, 1, 7, 0, 0, 0, time.UTC)
+	page := &query.Page[models.Activity]{
+		Data: []*models.Activity{{
+			BaseEntity:   models.BaseEntity{ID: 42},
+			PublicID:     "01HZY3V5J6X7Q8R9S0T1V2W3X4",
+			UserID:       7,
+			ActivityType: "running",
+			Title:        "Morning run",
+			ActivityDate: date,
+		}},
+		Meta: query.PaginationMeta{Page: 1, Limit: 10, Count: 1, TotalRecords: 1, PageCount: 1},
+	}
+
+	repo := mocks.NewMockActivityRepositoryInterface(gomock.NewController(t))
+	repo.EXPECT().ListActivitiesWithQuery(gomock.Any(), gomock.Any()).Return(page, nil).Times(1)
+	uc := NewListActivitiesUseCase(nil, repo, memoryCache{})
+	input := func() ListActivitiesInput {
+		return ListActivitiesInput{UserID: 7, QueryOptions: query.NewQueryOptions()}
+	}
+
+	miss, err := uc.Execute(context.Background(), nil, input())
+	require.NoError(t, err)
+	assert.False(t, miss.Cache.Hit)
+	assert.Same(t, page, miss.Result)
+
+	// The second call is served from the cache, decoded back into typed activities
+	hit, err := uc.Execute(context.Background(), nil, input())
+	require.NoError(t, err)
+	assert.True(t, hit.Cache.Hit)
+	require.Len(t, hit.Result.Data, 1)
+	activity := hit.Result.Data[0]
+	assert.Equal(t, int64(42), activity.ID)
+	assert.Equal(t, "01HZY3V5J6X7Q8R9S0T1V2W3X4", activity.PublicID)
+	assert.Equal(t, "Morning run", activity.Title)
+	assert.True(t, activity.ActivityDate.Equal(date))
+	assert.Equal(t, page.Meta, hit.Result.Meta)
+}
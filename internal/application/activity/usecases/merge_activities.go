@@ -0,0 +1,133 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+
+	cacheTypes "github.com/valentinesamuel/activelog/internal/adapters/cache/types"
+	"github.com/valentinesamuel/activelog/internal/application/broker"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/service"
+	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
+)
+
+// MergeActivitiesInput defines the typed input for MergeActivitiesUseCase
+type MergeActivitiesInput struct {
+	UserID  int
+	Request *models.MergeActivitiesRequest
+}
+
+// MergeActivitiesOutput defines the typed output for MergeActivitiesUseCase
+type MergeActivitiesOutput struct {
+	KeptID int64
+
+	// Merged are the activities folded into KeptID, now deleted
+	Merged []*models.Activity
+
+	// Counts of what moved to KeptID
+	Tags, Photos, Comments int64
+}
+
+// MergeActivitiesUseCase folds a cluster of duplicate activities into one,
+// moving their tags, photos and comments to the activity kept
+type MergeActivitiesUseCase struct {
+	service service.ActivityServiceInterface
+	repo    repository.ActivityRepositoryInterface
+	cache   cacheTypes.CacheAdapter
+}
+
+// NewMergeActivitiesUseCase creates a new instance
+func NewMergeActivitiesUseCase(
+	svc service.ActivityServiceInterface,
+	repo repository.ActivityRepositoryInterface,
+	cache cacheTypes.CacheAdapter,
+) *MergeActivitiesUseCase {
+	return &MergeActivitiesUseCase{
+		service: svc,
+		repo:    repo,
+		cache:   cache,
+	}
+}
+
+// RequiresTransaction indicates this use case needs a transaction
+// The attachments must move and the duplicates go together, or not at all
+func (uc *MergeActivitiesUseCase) RequiresTransaction() bool {
+	return true
+}
+
+// Execute locks the activities, picks the one to keep and merges the rest
+// into it
+func (uc *MergeActivitiesUseCase) Execute(
+	ctx context.Context,
+	tx *sql.Tx,
+	input MergeActivitiesInput,
+) (MergeActivitiesOutput, error) {
+	if input.Request == nil {
+		return MergeActivitiesOutput{}, fmt.Errorf("request is required")
+	}
+	ids := slices.Clone(input.Request.ActivityIDs)
+	slices.Sort(ids)
+
+	keepID := int64(0)
+	if input.Request.KeepID != nil {
+		keepID = *input.Request.KeepID
+		if !slices.Contains(ids, keepID) {
+			return MergeActivitiesOutput{}, &appErrors.ValidationError{
+				Field:   "keepId",
+				Message: "must be one of activityIds",
+			}
+		}
+	}
+
+	// DECISION: Use repo directly - locking in ID order keeps two merges of
+	// overlapping clusters from deadlocking, and the move is a handful of
+	// UPDATEs over the attachment tables
+	for _, id := range ids {
+		if _, err := uc.repo.LockVersion(ctx, tx, int(id), input.UserID); err != nil {
+			return MergeActivitiesOutput{}, fmt.Errorf("failed to lock activity %d: %w", id, err)
+		}
+	}
+
+	activities, err := uc.repo.ListByIDs(ctx, input.UserID, ids)
+	if err != nil {
+		return MergeActivitiesOutput{}, fmt.Errorf("failed to load activities: %w", err)
+	}
+	if keepID == 0 {
+		attachments, err := uc.repo.CountAttachments(ctx, ids)
+		if err != nil {
+			return MergeActivitiesOutput{}, fmt.Errorf("failed to count attachments: %w", err)
+		}
+		keepID = service.RichestActivity(activities, attachments).ID
+	}
+
+	var mergeIDs []int64
+	var merged []*models.Activity
+	for _, a := range activities {
+		if a.ID != keepID {
+			mergeIDs = append(mergeIDs, a.ID)
+			merged = append(merged, a)
+		}
+	}
+
+	result, err := uc.repo.MergeInto(ctx, tx, input.UserID, keepID, mergeIDs)
+	if err != nil {
+		return MergeActivitiesOutput{}, fmt.Errorf("failed to merge activities: %w", err)
+	}
+
+	// Nothing was committed on a dry run, so cached lists are still accurate
+	if uc.cache != nil && !broker.IsDryRun(ctx) {
+		uc.cache.Del(ctx, fmt.Sprintf("user:%d", input.UserID), activityCacheOpts)
+		uc.cache.Del(ctx, fmt.Sprintf("activity:%d", input.UserID), activityCacheOpts)
+	}
+
+	return MergeActivitiesOutput{
+		KeptID:   keepID,
+		Merged:   merged,
+		Tags:     result.Tags,
+		Photos:   result.Photos,
+		Comments: result.Comments,
+	}, nil
+}
@@ -0,0 +1,96 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	cacheTypes "github.com/valentinesamuel/activelog/internal/adapters/cache/types"
+	"github.com/valentinesamuel/activelog/internal/application/broker"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/service"
+	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
+)
+
+// MergeActivityTypesInput defines the typed input for MergeActivityTypesUseCase
+type MergeActivityTypesInput struct {
+	UserID  int
+	Request *models.MergeActivityTypesRequest
+}
+
+// MergeActivityTypesOutput defines the typed output for MergeActivityTypesUseCase
+type MergeActivityTypesOutput struct {
+	Into     string
+	Remapped int64
+}
+
+// MergeActivityTypesUseCase remaps a user's activities from several types to
+// one registered type, e.g. "run" and "jog" into "running"
+type MergeActivityTypesUseCase struct {
+	service service.ActivityServiceInterface
+	types   *repository.ActivityTypeRepository
+	cache   cacheTypes.CacheAdapter
+}
+
+// NewMergeActivityTypesUseCase creates a new instance
+func NewMergeActivityTypesUseCase(
+	svc service.ActivityServiceInterface,
+	types *repository.ActivityTypeRepository,
+	cache cacheTypes.CacheAdapter,
+) *MergeActivityTypesUseCase {
+	return &MergeActivityTypesUseCase{
+		service: svc,
+		types:   types,
+		cache:   cache,
+	}
+}
+
+// RequiresTransaction indicates this use case needs a transaction
+// The remap and the removal of the merged types must apply together
+func (uc *MergeActivityTypesUseCase) RequiresTransaction() bool {
+	return true
+}
+
+// Execute remaps the user's activities and removes their merged types
+func (uc *MergeActivityTypesUseCase) Execute(
+	ctx context.Context,
+	tx *sql.Tx,
+	input MergeActivityTypesInput,
+) (MergeActivityTypesOutput, error) {
+	if input.Request == nil {
+		return MergeActivityTypesOutput{}, fmt.Errorf("request is required")
+	}
+
+	// DECISION: Use repo directly to resolve the target - it must be registered
+	// even when unregistered types are otherwise allowed (see
+	// ActivityService.ResolveActivityType), or the merge would just move the
+	// fragmentation
+	target, err := uc.types.Resolve(ctx, input.UserID, input.Request.Into)
+	if errors.Is(err, appErrors.ErrNotFound) {
+		return MergeActivityTypesOutput{}, &appErrors.ValidationError{
+			Field:   "into",
+			Message: fmt.Sprintf("unknown activity type '%s'", input.Request.Into),
+		}
+	}
+	if err != nil {
+		return MergeActivityTypesOutput{}, fmt.Errorf("failed to resolve merge target: %w", err)
+	}
+	into := target.Name
+
+	// DECISION: Use repo directly - a merge is a single UPDATE ... WHERE over
+	// the user's activities, like the bulk edits
+	remapped, err := uc.types.Merge(ctx, tx, input.UserID, input.Request.From, into)
+	if err != nil {
+		return MergeActivityTypesOutput{}, fmt.Errorf("failed to merge activity types: %w", err)
+	}
+
+	// Nothing was committed on a dry run, so cached lists are still accurate
+	if uc.cache != nil && remapped > 0 && !broker.IsDryRun(ctx) {
+		uc.cache.Del(ctx, fmt.Sprintf("user:%d", input.UserID), activityCacheOpts)
+		uc.cache.Del(ctx, fmt.Sprintf("activity:%d", input.UserID), activityCacheOpts)
+	}
+
+	return MergeActivityTypesOutput{Into: into, Remapped: remapped}, nil
+}
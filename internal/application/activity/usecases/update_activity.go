@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	cacheTypes "github.com/valentinesamuel/activelog/internal/adapters/cache/types"
+	"github.com/valentinesamuel/activelog/internal/application/broker"
 	"github.com/valentinesamuel/activelog/internal/models"
 	"github.com/valentinesamuel/activelog/internal/repository"
 	"github.com/valentinesamuel/activelog/internal/service"
@@ -15,6 +16,10 @@ type UpdateActivityInput struct {
 	UserID     int
 	ActivityID int
 	Request    *models.UpdateActivityRequest
+
+	// ExpectedVersion, if set, rejects the update with a *errors.VersionConflictError
+	// unless the activity is still at this version (offline sync)
+	ExpectedVersion *int
 }
 
 type UpdateActivityOutput struct {
@@ -44,6 +49,11 @@ func (uc *UpdateActivityUseCase) RequiresTransaction() bool {
 	return true
 }
 
+// Idempotent returns true - retrying after a rollback writes the same values again
+func (uc *UpdateActivityUseCase) Idempotent() bool {
+	return true
+}
+
 func (uc *UpdateActivityUseCase) Execute(
 	ctx context.Context,
 	tx *sql.Tx,
@@ -53,13 +63,19 @@ func (uc *UpdateActivityUseCase) Execute(
 		return UpdateActivityOutput{}, fmt.Errorf("request is required")
 	}
 
+	if input.ExpectedVersion != nil {
+		if err := checkVersion(ctx, uc.repo, tx, input.UserID, input.ActivityID, *input.ExpectedVersion); err != nil {
+			return UpdateActivityOutput{}, err
+		}
+	}
+
 	activity, err := uc.service.UpdateActivity(ctx, tx, input.UserID, input.ActivityID, input.Request)
 
 	if err != nil {
 		return UpdateActivityOutput{}, fmt.Errorf("failed to update activity: %w", err)
 	}
 
-	if uc.cache != nil {
+	if uc.cache != nil && !broker.IsDryRun(ctx) {
 		opts := cacheTypes.CacheOptions{
 			DB:           cacheTypes.CacheDBActivityData,
 			PartitionKey: cacheTypes.CachePartitionActivities,
@@ -0,0 +1,30 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/valentinesamuel/activelog/internal/repository"
+	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
+)
+
+// checkVersion locks the activity for the rest of tx and fails with a
+// *errors.VersionConflictError unless it is still at expected. The lock keeps
+// a concurrent writer from bumping the version between the check and the write.
+func checkVersion(ctx context.Context, repo repository.ActivityRepositoryInterface, tx *sql.Tx, userID, activityID, expected int) error {
+	current, err := repo.LockVersion(ctx, tx, activityID, userID)
+	if err != nil {
+		return err
+	}
+
+	if current != expected {
+		return &appErrors.VersionConflictError{
+			Resource:        "activity",
+			ID:              int64(activityID),
+			ExpectedVersion: expected,
+			CurrentVersion:  current,
+		}
+	}
+
+	return nil
+}
@@ -15,8 +15,8 @@ import (
 // Dependencies: Requires services, repositories, and storage to be registered first
 func RegisterActivityPhotoUseCases(c *container.Container) {
 	c.Register(UploadActivityPhotosUCKey, func(c *container.Container) (interface{}, error) {
-		svc := c.MustResolve(di2.ActivityServiceKey).(service.ActivityServiceInterface)
-		repo := c.MustResolve(di.ActivityPhotoRepoKey).(repository.ActivityPhotoRepositoryInterface)
+		svc := container.MustResolve[service.ActivityServiceInterface](c, di2.ActivityServiceKey)
+		repo := container.MustResolve[repository.ActivityPhotoRepositoryInterface](c, di.ActivityPhotoRepoKey)
 
 		// Storage provider may be nil if not configured - handle gracefully
 		var storageProvider types.StorageProvider
@@ -28,8 +28,8 @@ func RegisterActivityPhotoUseCases(c *container.Container) {
 	})
 
 	c.Register(GetActivityPhotosUCKey, func(c *container.Container) (interface{}, error) {
-		svc := c.MustResolve(di2.ActivityServiceKey).(service.ActivityServiceInterface)
-		repo := c.MustResolve(di.ActivityPhotoRepoKey).(repository.ActivityPhotoRepositoryInterface)
+		svc := container.MustResolve[service.ActivityServiceInterface](c, di2.ActivityServiceKey)
+		repo := container.MustResolve[repository.ActivityPhotoRepositoryInterface](c, di.ActivityPhotoRepoKey)
 
 		return usecases.NewGetActivityPhotoUseCase(svc, repo), nil
 	})
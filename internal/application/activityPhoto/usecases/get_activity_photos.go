@@ -42,6 +42,11 @@ func (uc *GetActivityPhotoUseCase) RequiresTransaction() bool {
 	return false
 }
 
+// Idempotent returns true - reads are safe to retry
+func (uc *GetActivityPhotoUseCase) Idempotent() bool {
+	return true
+}
+
 // Execute retrieves photos for an activity (typed version)
 func (uc *GetActivityPhotoUseCase) Execute(
 	ctx context.Context,
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 )
 
@@ -23,6 +24,7 @@ type Broker struct {
 	db                    *sql.DB
 	defaultTimeout        time.Duration
 	defaultIsolationLevel sql.IsolationLevel
+	defaultRetry          RetryPolicy
 	logger                *log.Logger
 }
 
@@ -32,6 +34,7 @@ func NewBroker(db *sql.DB) *Broker {
 		db:                    db,
 		defaultTimeout:        60 * time.Second,
 		defaultIsolationLevel: sql.LevelReadCommitted,
+		defaultRetry:          DefaultRetryPolicy,
 		logger:                log.Default(),
 	}
 }
@@ -40,8 +43,11 @@ func NewBroker(db *sql.DB) *Broker {
 type Option func(*executionConfig)
 
 type executionConfig struct {
-	timeout        time.Duration
-	isolationLevel sql.IsolationLevel
+	timeout            time.Duration
+	isolationLevel     sql.IsolationLevel
+	isolationRequested bool // set by WithIsolationLevel; checked against use case declarations
+	dryRun             bool
+	retry              RetryPolicy
 }
 
 // WithTimeout sets execution timeout
@@ -51,13 +57,36 @@ func WithTimeout(d time.Duration) Option {
 	}
 }
 
-// WithIsolationLevel sets transaction isolation level
+// WithIsolationLevel sets the transaction isolation level: sql.LevelReadCommitted,
+// sql.LevelRepeatableRead or sql.LevelSerializable. It fails the run if a use
+// case declares a stricter level (see IsolatedUseCase). REPEATABLE READ and
+// SERIALIZABLE always run in a transaction.
 func WithIsolationLevel(level sql.IsolationLevel) Option {
 	return func(c *executionConfig) {
 		c.isolationLevel = level
+		c.isolationRequested = true
 	}
 }
 
+// WithDryRun runs the use case inside a transaction that is always rolled back.
+// Non-transactional use cases are given a transaction too, so every write they make
+// through tx is discarded. The output is returned as if the changes had been committed.
+func WithDryRun() Option {
+	return func(c *executionConfig) {
+		c.dryRun = true
+	}
+}
+
+type dryRunKey struct{}
+
+// IsDryRun reports whether ctx belongs to a dry-run execution.
+// Use cases check this to skip side effects that live outside the transaction
+// (cache invalidation, queue jobs, webhooks).
+func IsDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return dryRun
+}
+
 // WithLogger sets custom logger
 func (b *Broker) WithLogger(logger *log.Logger) *Broker {
 	b.logger = logger
@@ -77,6 +106,7 @@ func (b *Broker) WithLogger(logger *log.Logger) *Broker {
 //
 // For use cases that require transactions, the function checks if the use case
 // implements TransactionalTypedUseCase and handles transaction management automatically.
+// It is the single-step form of RunUseCases and shares its execution path.
 func RunUseCase[I, O any](
 	b *Broker,
 	ctx context.Context,
@@ -85,75 +115,162 @@ func RunUseCase[I, O any](
 	opts ...Option,
 ) (O, error) {
 	var zero O
+	var output O
+
+	err := b.execute(ctx, useCaseInfo(uc), opts, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		output, err = uc.Execute(ctx, tx, input)
+		return err
+	})
+	if err != nil {
+		return zero, err
+	}
+
+	return output, nil
+}
+
+// requiresTransaction reports whether uc declared it needs a transaction
+// Use cases without RequiresTransaction are non-transactional
+func requiresTransaction(uc any) bool {
+	if txUC, ok := uc.(TransactionalUseCase); ok {
+		return txUC.RequiresTransaction()
+	}
+	return false
+}
+
+// timeoutError is returned when a run outlives its timeout or the caller's deadline
+type timeoutError struct {
+	timeout time.Duration
+	cause   error
+}
+
+func (e *timeoutError) Error() string {
+	return fmt.Sprintf("use case timed out after %v", e.timeout)
+}
+
+// Unwrap lets errors.Is(err, context.DeadlineExceeded) match broker timeouts
+func (e *timeoutError) Unwrap() error {
+	return e.cause
+}
 
+// runInfo describes what a broker execution runs
+type runInfo struct {
+	name       string // use case type name(s), for logs and metrics
+	requiresTx bool
+	idempotent bool
+	isolation  sql.IsolationLevel // strictest level declared by the use case(s)
+}
+
+// useCaseInfo describes a single use case from its declarations
+func useCaseInfo(uc any) runInfo {
+	return runInfo{
+		name:       strings.TrimPrefix(fmt.Sprintf("%T", uc), "*"),
+		requiresTx: requiresTransaction(uc),
+		idempotent: isIdempotent(uc),
+		isolation:  declaredIsolation(uc),
+	}
+}
+
+// execute applies opts, enforces the timeout and runs fn, inside a transaction
+// when the run requires one or is a dry run. Serialization failures are
+// retried per the retry policy.
+func (b *Broker) execute(
+	ctx context.Context,
+	run runInfo,
+	opts []Option,
+	fn func(ctx context.Context, tx *sql.Tx) error,
+) error {
 	// Apply options
 	config := &executionConfig{
 		timeout:        b.defaultTimeout,
 		isolationLevel: b.defaultIsolationLevel,
+		retry:          b.defaultRetry,
 	}
 	for _, opt := range opts {
 		opt(config)
 	}
 
+	isolation, err := resolveIsolation(run, config)
+	if err != nil {
+		return err
+	}
+	if isolation != b.defaultIsolationLevel {
+		b.logger.Printf("%s: running at %s isolation", run.name, isolation)
+	}
+	config.isolationLevel = isolation
+
+	if config.dryRun {
+		ctx = context.WithValue(ctx, dryRunKey{}, true)
+	}
+
 	// Create timeout context
 	timeoutCtx, cancel := context.WithTimeout(ctx, config.timeout)
 	defer cancel()
 
-	// Check if use case requires transaction
-	needsTx := false
-	if txUC, ok := any(uc).(TransactionalUseCase); ok {
-		needsTx = txUC.RequiresTransaction()
-	}
+	// Dry runs always need a transaction so there is something to roll back,
+	// and isolation above READ COMMITTED only means something inside one
+	needsTx := run.requiresTx || config.dryRun ||
+		isolationStrength(isolation) > isolationStrength(sql.LevelReadCommitted)
 
 	// Execute with timeout
-	type result struct {
-		output O
-		err    error
-	}
-	resultChan := make(chan result, 1)
-
+	resultChan := make(chan error, 1)
 	go func() {
-		var tx *sql.Tx
-		var err error
+		resultChan <- b.runWithRetry(timeoutCtx, run, needsTx, config, fn)
+	}()
 
-		// Start transaction if needed
-		if needsTx {
-			tx, err = b.db.BeginTx(timeoutCtx, &sql.TxOptions{
-				Isolation: config.isolationLevel,
-			})
-			if err != nil {
-				resultChan <- result{zero, fmt.Errorf("failed to begin transaction: %w", err)}
-				return
-			}
-		}
+	select {
+	case <-timeoutCtx.Done():
+		return &timeoutError{timeout: config.timeout, cause: timeoutCtx.Err()}
+	case err := <-resultChan:
+		return err
+	}
+}
+
+// runInTransaction runs fn, wrapping it in a transaction when needsTx is set.
+// The transaction is rolled back on error and for dry runs, committed otherwise.
+func (b *Broker) runInTransaction(
+	ctx context.Context,
+	needsTx bool,
+	config *executionConfig,
+	fn func(ctx context.Context, tx *sql.Tx) error,
+) error {
+	var tx *sql.Tx
+	var err error
 
-		// Execute use case
-		output, err := uc.Execute(timeoutCtx, tx, input)
+	// Start transaction if needed
+	if needsTx {
+		tx, err = b.db.BeginTx(ctx, &sql.TxOptions{
+			Isolation: config.isolationLevel,
+		})
 		if err != nil {
-			if tx != nil {
-				if rbErr := tx.Rollback(); rbErr != nil {
-					b.logger.Printf("failed to rollback transaction: %v", rbErr)
-				}
-			}
-			resultChan <- result{zero, err}
-			return
+			return fmt.Errorf("failed to begin transaction: %w", err)
 		}
+	}
 
-		// Commit transaction if needed
+	// Execute use case(s)
+	if err := fn(ctx, tx); err != nil {
 		if tx != nil {
-			if err := tx.Commit(); err != nil {
-				resultChan <- result{zero, fmt.Errorf("failed to commit transaction: %w", err)}
-				return
+			if rbErr := tx.Rollback(); rbErr != nil {
+				b.logger.Printf("failed to rollback transaction: %v", rbErr)
 			}
 		}
+		return err
+	}
 
-		resultChan <- result{output, nil}
-	}()
+	// Dry run: discard everything the use case wrote but keep its output
+	if config.dryRun {
+		if err := tx.Rollback(); err != nil {
+			return fmt.Errorf("failed to roll back dry run: %w", err)
+		}
+		return nil
+	}
 
-	select {
-	case <-timeoutCtx.Done():
-		return zero, fmt.Errorf("use case timed out after %v", config.timeout)
-	case res := <-resultChan:
-		return res.output, res.err
+	// Commit transaction if needed
+	if tx != nil {
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
 	}
+
+	return nil
 }
@@ -274,6 +274,51 @@ func TestRunUseCase_WithOptions(t *testing.T) {
 	}
 }
 
+func TestRunUseCase_DryRun_RollsBack(t *testing.T) {
+	broker, mock, cleanup := setupTestBroker(t)
+	defer cleanup()
+
+	// Dry runs open a transaction even for non-transactional use cases and never commit
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	var sawDryRun bool
+	var sawTx bool
+	useCase := &mockTypedUseCase{
+		requiresTx: false,
+		executeFn: func(ctx context.Context, tx *sql.Tx, input mockTypedInput) (mockTypedOutput, error) {
+			sawDryRun = IsDryRun(ctx)
+			sawTx = tx != nil
+			return mockTypedOutput{Result: "would-create", Success: true}, nil
+		},
+	}
+
+	result, err := RunUseCase(broker, context.Background(), useCase, mockTypedInput{UserID: 1}, WithDryRun())
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.Result != "would-create" {
+		t.Errorf("expected dry run output to be returned, got %q", result.Result)
+	}
+	if !sawDryRun {
+		t.Error("expected IsDryRun to report true inside the use case")
+	}
+	if !sawTx {
+		t.Error("expected a transaction to be passed to the use case")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestIsDryRun_DefaultFalse(t *testing.T) {
+	if IsDryRun(context.Background()) {
+		t.Error("expected IsDryRun to be false for a plain context")
+	}
+}
+
 // Benchmark tests
 func BenchmarkRunUseCase_NonTransactional(b *testing.B) {
 	db, _, err := sqlmock.New()
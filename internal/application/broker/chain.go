@@ -0,0 +1,251 @@
+package broker
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Key identifies a typed value in a Bag. Two keys are equal only when both
+// their name and their type match, so a Key[int]("id") never reads a value
+// stored under Key[string]("id").
+//
+// Example:
+//
+//	var CreatedActivityKey = broker.NewKey[usecases.CreateActivityOutput]("activity.created")
+type Key[T any] struct {
+	name string
+}
+
+// NewKey creates a typed bag key
+func NewKey[T any](name string) Key[T] {
+	return Key[T]{name: name}
+}
+
+// String returns the key name
+func (k Key[T]) String() string {
+	return k.name
+}
+
+// Bag is the keyed context shared by the use cases of a chain.
+// Each step stores its output under a typed Key and later steps read it back
+// with Get, so no step deals with map[string]interface{} or type assertions.
+type Bag struct {
+	values   map[any]any
+	failures []StepFailure
+}
+
+// NewBag creates an empty bag
+func NewBag() *Bag {
+	return &Bag{values: make(map[any]any)}
+}
+
+// Failures returns the steps that failed under SkipAndContinue or
+// CompensateAndContinue, in the order they ran
+func (bag *Bag) Failures() []StepFailure {
+	return bag.failures
+}
+
+// Put stores value under key, replacing any previous value
+func Put[T any](bag *Bag, key Key[T], value T) {
+	bag.values[key] = value
+}
+
+// Get returns the value stored under key and whether it was present
+func Get[T any](bag *Bag, key Key[T]) (T, bool) {
+	value, ok := bag.values[key].(T)
+	return value, ok
+}
+
+// Require returns the value stored under key, or an error naming the missing
+// key. Use it in step inputs that depend on an earlier step's output.
+func Require[T any](bag *Bag, key Key[T]) (T, error) {
+	value, ok := Get(bag, key)
+	if !ok {
+		return value, fmt.Errorf("broker: %q not set by an earlier use case", key.name)
+	}
+	return value, nil
+}
+
+// FailurePolicy decides what happens to a chain when one of its steps fails
+type FailurePolicy int
+
+const (
+	// AbortChain rolls back the whole chain and returns the error (default)
+	AbortChain FailurePolicy = iota
+
+	// SkipAndContinue rolls the failed step back to its savepoint and carries
+	// on with the next step. Use it for non-critical work such as stats
+	// denormalization that must not undo the core write.
+	SkipAndContinue
+
+	// CompensateAndContinue rolls the failed step back to its savepoint, runs
+	// the step's compensation and carries on. If the compensation itself
+	// fails the chain is aborted.
+	CompensateAndContinue
+)
+
+// Compensation undoes or records the effects of a failed step.
+// It runs inside the chain transaction after the step's savepoint was rolled back.
+type Compensation func(ctx context.Context, tx *sql.Tx, bag *Bag, cause error) error
+
+// StepFailure records a step that failed without aborting its chain
+type StepFailure struct {
+	Step string
+	Err  error
+}
+
+// Step is one use case in a RunUseCases chain. Build steps with Bind.
+type Step struct {
+	name       string
+	requiresTx bool
+	idempotent bool
+	isolation  sql.IsolationLevel
+	run        func(ctx context.Context, tx *sql.Tx, bag *Bag) error
+	policy     FailurePolicy
+	compensate Compensation
+}
+
+// OnFailure returns a copy of the step with the given failure policy.
+// Non-default policies wrap the step in a SAVEPOINT when the chain runs in a transaction.
+func (s Step) OnFailure(policy FailurePolicy) Step {
+	s.policy = policy
+	return s
+}
+
+// CompensateWith returns a copy of the step that runs fn after a failure and
+// then continues the chain (CompensateAndContinue)
+func (s Step) CompensateWith(fn Compensation) Step {
+	s.policy = CompensateAndContinue
+	s.compensate = fn
+	return s
+}
+
+// Bind adapts a typed use case into a chain step. input builds the use case
+// input, usually from values earlier steps put in the bag; the output is put
+// in the bag under out.
+//
+// Existing TypedUseCase implementations need no changes to take part in a chain.
+func Bind[I, O any](uc TypedUseCase[I, O], input func(bag *Bag) (I, error), out Key[O]) Step {
+	return Step{
+		name:       fmt.Sprintf("%T", uc),
+		requiresTx: requiresTransaction(uc),
+		idempotent: isIdempotent(uc),
+		isolation:  declaredIsolation(uc),
+		run: func(ctx context.Context, tx *sql.Tx, bag *Bag) error {
+			in, err := input(bag)
+			if err != nil {
+				return err
+			}
+
+			output, err := uc.Execute(ctx, tx, in)
+			if err != nil {
+				return err
+			}
+
+			Put(bag, out, output)
+			return nil
+		},
+	}
+}
+
+// Input returns an input builder for steps whose input does not depend on the bag
+func Input[I any](input I) func(*Bag) (I, error) {
+	return func(*Bag) (I, error) {
+		return input, nil
+	}
+}
+
+// RunUseCases executes steps in order and returns the bag holding their outputs.
+// When any step requires a transaction, all steps share one transaction: an
+// error from a step rolls back the whole chain and the remaining steps are
+// skipped, unless the step opted into SkipAndContinue or CompensateAndContinue:
+// those steps run inside their own SAVEPOINT, so a failure only rolls back that
+// step and the chain goes on. Options apply to the chain as a whole; a chain is
+// retried after a serialization failure only if every step is idempotent, and
+// runs at the strictest isolation level any step declares.
+//
+// Example:
+//
+//	bag, err := broker.RunUseCases(b, ctx, []broker.Step{
+//	    broker.Bind(createUC, broker.Input(createInput), usecases.CreatedActivityKey),
+//	    broker.Bind(getUC, func(bag *broker.Bag) (usecases.GetActivityInput, error) {
+//	        created, err := broker.Require(bag, usecases.CreatedActivityKey)
+//	        return usecases.GetActivityInput{ActivityID: created.ActivityID}, err
+//	    }, usecases.ActivityKey),
+//	})
+func RunUseCases(b *Broker, ctx context.Context, steps []Step, opts ...Option) (*Bag, error) {
+	run := runInfo{idempotent: len(steps) > 0}
+	names := make([]string, len(steps))
+	for i, step := range steps {
+		names[i] = strings.TrimPrefix(step.name, "*")
+		run.requiresTx = run.requiresTx || step.requiresTx
+		run.idempotent = run.idempotent && step.idempotent
+		if isolationStrength(step.isolation) < 0 {
+			return nil, fmt.Errorf("broker: use case %d (%s) declares unsupported isolation level %s", i+1, names[i], step.isolation)
+		}
+		run.isolation = stricterIsolation(run.isolation, step.isolation)
+	}
+	run.name = strings.Join(names, ",")
+
+	var bag *Bag
+	err := b.execute(ctx, run, opts, func(ctx context.Context, tx *sql.Tx) error {
+		// A retried chain starts over with an empty bag
+		bag = NewBag()
+		for i, step := range steps {
+			if err := b.runStep(ctx, tx, bag, i+1, step); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return bag, nil
+}
+
+// runStep runs a single chain step and applies its failure policy
+func (b *Broker) runStep(ctx context.Context, tx *sql.Tx, bag *Bag, position int, step Step) error {
+	name := strings.TrimPrefix(step.name, "*")
+	savepoint := fmt.Sprintf("broker_step_%d", position)
+	useSavepoint := tx != nil && step.policy != AbortChain
+
+	if useSavepoint {
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT "+savepoint); err != nil {
+			return fmt.Errorf("failed to create savepoint for use case %d (%s): %w", position, name, err)
+		}
+	}
+
+	err := step.run(ctx, tx, bag)
+	if err == nil {
+		if useSavepoint {
+			if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+savepoint); err != nil {
+				return fmt.Errorf("failed to release savepoint for use case %d (%s): %w", position, name, err)
+			}
+		}
+		return nil
+	}
+
+	if step.policy == AbortChain {
+		return fmt.Errorf("use case %d (%s) failed: %w", position, name, err)
+	}
+
+	if useSavepoint {
+		if _, rbErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+savepoint); rbErr != nil {
+			return fmt.Errorf("failed to roll back use case %d (%s) to its savepoint: %w", position, name, rbErr)
+		}
+	}
+
+	if step.policy == CompensateAndContinue && step.compensate != nil {
+		if compErr := step.compensate(ctx, tx, bag, err); compErr != nil {
+			return fmt.Errorf("compensation for use case %d (%s) failed: %w", position, name, compErr)
+		}
+	}
+
+	b.logger.Printf("use case %d (%s) failed, continuing chain: %v", position, name, err)
+	bag.failures = append(bag.failures, StepFailure{Step: name, Err: err})
+	return nil
+}
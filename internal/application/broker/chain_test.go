@@ -0,0 +1,225 @@
+package broker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+var (
+	firstOutputKey  = NewKey[mockTypedOutput]("first")
+	secondOutputKey = NewKey[mockTypedOutput]("second")
+)
+
+func TestBag_TypedKeys(t *testing.T) {
+	bag := NewBag()
+	intKey := NewKey[int]("id")
+	stringKey := NewKey[string]("id")
+
+	Put(bag, intKey, 42)
+
+	if v, ok := Get(bag, intKey); !ok || v != 42 {
+		t.Errorf("Get(intKey) = %v, %v; want 42, true", v, ok)
+	}
+	if _, ok := Get(bag, stringKey); ok {
+		t.Error("expected a key with the same name but a different type to miss")
+	}
+	if _, err := Require(bag, stringKey); err == nil {
+		t.Error("expected Require to fail for a missing key")
+	}
+}
+
+func TestRunUseCases_PassesOutputsThroughBag(t *testing.T) {
+	broker, mock, cleanup := setupTestBroker(t)
+	defer cleanup()
+
+	first := &mockTypedUseCase{output: mockTypedOutput{Result: "created", Success: true}, requiresTx: true}
+	second := &mockTypedUseCase{
+		executeFn: func(ctx context.Context, tx *sql.Tx, input mockTypedInput) (mockTypedOutput, error) {
+			if tx == nil {
+				t.Error("expected the chain transaction to be shared with every step")
+			}
+			return mockTypedOutput{Result: input.Name + "+read"}, nil
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	bag, err := RunUseCases(broker, context.Background(), []Step{
+		Bind(first, Input(mockTypedInput{UserID: 1}), firstOutputKey),
+		Bind(second, func(bag *Bag) (mockTypedInput, error) {
+			out, err := Require(bag, firstOutputKey)
+			return mockTypedInput{Name: out.Result}, err
+		}, secondOutputKey),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if out, _ := Get(bag, secondOutputKey); out.Result != "created+read" {
+		t.Errorf("second output = %q, want created+read", out.Result)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRunUseCases_ErrorRollsBackChain(t *testing.T) {
+	broker, mock, cleanup := setupTestBroker(t)
+	defer cleanup()
+
+	errBoom := errors.New("boom")
+	third := &mockTypedUseCase{
+		executeFn: func(ctx context.Context, tx *sql.Tx, input mockTypedInput) (mockTypedOutput, error) {
+			t.Error("steps after a failure must not run")
+			return mockTypedOutput{}, nil
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	_, err := RunUseCases(broker, context.Background(), []Step{
+		Bind(&mockTypedUseCase{requiresTx: true}, Input(mockTypedInput{}), firstOutputKey),
+		Bind(&mockTypedUseCase{err: errBoom}, Input(mockTypedInput{}), secondOutputKey),
+		Bind(third, Input(mockTypedInput{}), NewKey[mockTypedOutput]("third")),
+	})
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("expected wrapped errBoom, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRunUseCases_SkipAndContinue_RollsBackToSavepoint(t *testing.T) {
+	broker, mock, cleanup := setupTestBroker(t)
+	defer cleanup()
+
+	errStats := errors.New("stats denormalization failed")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SAVEPOINT broker_step_2")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("ROLLBACK TO SAVEPOINT broker_step_2")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	bag, err := RunUseCases(broker, context.Background(), []Step{
+		Bind(&mockTypedUseCase{output: mockTypedOutput{Result: "created"}, requiresTx: true}, Input(mockTypedInput{}), firstOutputKey),
+		Bind(&mockTypedUseCase{err: errStats}, Input(mockTypedInput{}), secondOutputKey).OnFailure(SkipAndContinue),
+	})
+	if err != nil {
+		t.Fatalf("expected the chain to commit, got %v", err)
+	}
+
+	if out, _ := Get(bag, firstOutputKey); out.Result != "created" {
+		t.Errorf("core output = %q, want created", out.Result)
+	}
+	if _, ok := Get(bag, secondOutputKey); ok {
+		t.Error("failed step must not store an output")
+	}
+	if failures := bag.Failures(); len(failures) != 1 || !errors.Is(failures[0].Err, errStats) {
+		t.Errorf("failures = %+v, want one stats failure", failures)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRunUseCases_SavepointReleasedOnSuccess(t *testing.T) {
+	broker, mock, cleanup := setupTestBroker(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SAVEPOINT broker_step_1")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("RELEASE SAVEPOINT broker_step_1")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	_, err := RunUseCases(broker, context.Background(), []Step{
+		Bind(&mockTypedUseCase{requiresTx: true}, Input(mockTypedInput{}), firstOutputKey).OnFailure(SkipAndContinue),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRunUseCases_CompensateAndContinue(t *testing.T) {
+	broker, mock, cleanup := setupTestBroker(t)
+	defer cleanup()
+
+	errStep := errors.New("step failed")
+	var compensated error
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SAVEPOINT broker_step_1")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("ROLLBACK TO SAVEPOINT broker_step_1")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	step := Bind(&mockTypedUseCase{err: errStep, requiresTx: true}, Input(mockTypedInput{}), firstOutputKey).
+		CompensateWith(func(ctx context.Context, tx *sql.Tx, bag *Bag, cause error) error {
+			compensated = cause
+			return nil
+		})
+
+	if _, err := RunUseCases(broker, context.Background(), []Step{step}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !errors.Is(compensated, errStep) {
+		t.Errorf("compensation got %v, want the step error", compensated)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRunUseCases_FailedCompensationAbortsChain(t *testing.T) {
+	broker, mock, cleanup := setupTestBroker(t)
+	defer cleanup()
+
+	errComp := errors.New("compensation failed")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SAVEPOINT broker_step_1")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("ROLLBACK TO SAVEPOINT broker_step_1")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	step := Bind(&mockTypedUseCase{err: errors.New("step failed"), requiresTx: true}, Input(mockTypedInput{}), firstOutputKey).
+		CompensateWith(func(ctx context.Context, tx *sql.Tx, bag *Bag, cause error) error {
+			return errComp
+		})
+
+	if _, err := RunUseCases(broker, context.Background(), []Step{step}); !errors.Is(err, errComp) {
+		t.Fatalf("expected compensation error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func BenchmarkBag_Get(b *testing.B) {
+	bag := NewBag()
+	Put(bag, firstOutputKey, mockTypedOutput{Result: "success"})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out, _ := Get(bag, firstOutputKey)
+		_ = out.Result
+	}
+}
+
+func BenchmarkMapInterface_Get(b *testing.B) {
+	values := map[string]interface{}{"first": mockTypedOutput{Result: "success"}}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out, _ := values["first"].(mockTypedOutput)
+		_ = out.Result
+	}
+}
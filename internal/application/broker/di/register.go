@@ -4,6 +4,7 @@ import (
 	"database/sql"
 
 	"github.com/valentinesamuel/activelog/internal/application/broker"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
 	"github.com/valentinesamuel/activelog/internal/platform/container"
 )
 
@@ -14,7 +15,11 @@ const CoreRawDBKey = "rawDB"
 // Dependencies: Requires "rawDB" to be registered first
 func RegisterBroker(c *container.Container) {
 	c.Register(BrokerKey, func(c *container.Container) (interface{}, error) {
-		rawDB := c.MustResolve(CoreRawDBKey).(*sql.DB)
-		return broker.NewBroker(rawDB), nil
+		rawDB := container.MustResolve[*sql.DB](c, CoreRawDBKey)
+		return broker.NewBroker(rawDB).WithRetryPolicy(broker.RetryPolicy{
+			MaxAttempts: config.Database.TxRetryMaxAttempts,
+			BaseDelay:   config.Database.TxRetryBaseDelay,
+			MaxDelay:    config.Database.TxRetryMaxDelay,
+		}), nil
 	})
 }
@@ -0,0 +1,72 @@
+package broker
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// IsolatedUseCase is an optional interface for use cases that need a
+// stronger isolation level than the broker default, e.g. SERIALIZABLE for
+// read-check-write logic. The declared level is a minimum: a chain runs at
+// the strictest level any of its steps declares. Declaring REPEATABLE READ or
+// SERIALIZABLE also makes the use case run in a transaction.
+//
+// Default behavior: Use cases WITHOUT this method accept any isolation level.
+type IsolatedUseCase interface {
+	IsolationLevel() sql.IsolationLevel
+}
+
+// isolationStrength orders the supported isolation levels; 0 means "no
+// preference" and -1 marks levels the broker doesn't support
+func isolationStrength(level sql.IsolationLevel) int {
+	switch level {
+	case sql.LevelDefault:
+		return 0
+	case sql.LevelReadCommitted:
+		return 1
+	case sql.LevelRepeatableRead:
+		return 2
+	case sql.LevelSerializable:
+		return 3
+	default:
+		return -1
+	}
+}
+
+// declaredIsolation returns the isolation level uc declared, or LevelDefault
+func declaredIsolation(uc any) sql.IsolationLevel {
+	if isoUC, ok := uc.(IsolatedUseCase); ok {
+		return isoUC.IsolationLevel()
+	}
+	return sql.LevelDefault
+}
+
+// stricterIsolation returns the stronger of a and b
+func stricterIsolation(a, b sql.IsolationLevel) sql.IsolationLevel {
+	if isolationStrength(b) > isolationStrength(a) {
+		return b
+	}
+	return a
+}
+
+// resolveIsolation picks the isolation level for a run. An explicit
+// WithIsolationLevel wins but must be at least as strict as the run's
+// declarations; otherwise the stricter of the broker default and the
+// declarations is used.
+func resolveIsolation(run runInfo, config *executionConfig) (sql.IsolationLevel, error) {
+	if isolationStrength(run.isolation) < 0 {
+		return 0, fmt.Errorf("broker: %s declares unsupported isolation level %s", run.name, run.isolation)
+	}
+	if isolationStrength(config.isolationLevel) < 0 {
+		return 0, fmt.Errorf("broker: unsupported isolation level %s requested for %s", config.isolationLevel, run.name)
+	}
+
+	if !config.isolationRequested {
+		return stricterIsolation(config.isolationLevel, run.isolation), nil
+	}
+	if isolationStrength(config.isolationLevel) < isolationStrength(run.isolation) {
+		return 0, fmt.Errorf("broker: %s requires %s isolation, but %s was requested",
+			run.name, run.isolation, config.isolationLevel)
+	}
+	return config.isolationLevel, nil
+}
@@ -0,0 +1,98 @@
+package broker
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+)
+
+// serializableUseCase declares that the wrapped mock needs SERIALIZABLE
+type serializableUseCase struct {
+	*mockTypedUseCase
+}
+
+func (serializableUseCase) IsolationLevel() sql.IsolationLevel {
+	return sql.LevelSerializable
+}
+
+func TestResolveIsolation(t *testing.T) {
+	tests := []struct {
+		name      string
+		declared  sql.IsolationLevel
+		requested *sql.IsolationLevel
+		want      sql.IsolationLevel
+		wantErr   bool
+	}{
+		{name: "broker default", declared: sql.LevelDefault, want: sql.LevelReadCommitted},
+		{name: "declaration raises the default", declared: sql.LevelRepeatableRead, want: sql.LevelRepeatableRead},
+		{name: "request stricter than declaration", declared: sql.LevelRepeatableRead, requested: ptr(sql.LevelSerializable), want: sql.LevelSerializable},
+		{name: "request weaker than declaration", declared: sql.LevelSerializable, requested: ptr(sql.LevelReadCommitted), wantErr: true},
+		{name: "unsupported request", requested: ptr(sql.LevelSnapshot), wantErr: true},
+		{name: "unsupported declaration", declared: sql.LevelLinearizable, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &executionConfig{isolationLevel: sql.LevelReadCommitted}
+			if tt.requested != nil {
+				WithIsolationLevel(*tt.requested)(config)
+			}
+
+			got, err := resolveIsolation(runInfo{name: "uc", isolation: tt.declared}, config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunUseCase_DeclaredIsolationStartsTransaction(t *testing.T) {
+	broker, mock, cleanup := setupTestBroker(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	var gotTx *sql.Tx
+	useCase := serializableUseCase{&mockTypedUseCase{
+		executeFn: func(ctx context.Context, tx *sql.Tx, input mockTypedInput) (mockTypedOutput, error) {
+			gotTx = tx
+			return mockTypedOutput{Success: true}, nil
+		},
+	}}
+
+	if _, err := RunUseCase(broker, context.Background(), useCase, mockTypedInput{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if gotTx == nil {
+		t.Error("expected a SERIALIZABLE use case to get a transaction")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRunUseCases_RejectsWeakerRequestedIsolation(t *testing.T) {
+	broker, mock, cleanup := setupTestBroker(t)
+	defer cleanup()
+
+	_, err := RunUseCases(broker, context.Background(), []Step{
+		Bind(&mockTypedUseCase{requiresTx: true}, Input(mockTypedInput{}), firstOutputKey),
+		Bind(serializableUseCase{&mockTypedUseCase{}}, Input(mockTypedInput{}), secondOutputKey),
+	}, WithIsolationLevel(sql.LevelRepeatableRead))
+	if err == nil {
+		t.Fatal("expected an isolation conflict error")
+	}
+
+	// Nothing may run: no transaction was expected
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
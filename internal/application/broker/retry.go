@@ -0,0 +1,131 @@
+package broker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/valentinesamuel/activelog/pkg/dberr"
+)
+
+// IdempotentUseCase is an optional marker interface for use cases that can
+// safely run again after their transaction was rolled back, i.e. they have no
+// side effects outside the transaction that must happen only once.
+//
+// Default behavior: Use cases WITHOUT this method are NOT retried.
+type IdempotentUseCase interface {
+	Idempotent() bool
+}
+
+// RetryPolicy controls how serialization failures and deadlocks are retried.
+// A run is retried only when every use case in it is idempotent.
+type RetryPolicy struct {
+	MaxAttempts int           // total attempts including the first; 1 or less disables retries
+	BaseDelay   time.Duration // backoff before the first retry, doubled for each further retry
+	MaxDelay    time.Duration // cap on the backoff
+}
+
+// DefaultRetryPolicy is used by brokers created with NewBroker
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   50 * time.Millisecond,
+	MaxDelay:    time.Second,
+}
+
+// backoff returns the jittered delay before retry number attempt (1-based)
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	// Jitter over the upper half so concurrent losers don't collide again
+	return delay/2 + rand.N(delay/2+1)
+}
+
+var (
+	retriesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "broker_retries_total",
+			Help: "Total number of use case runs retried after a serialization failure or deadlock",
+		},
+		[]string{"use_case"},
+	)
+
+	retriesExhaustedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "broker_retries_exhausted_total",
+			Help: "Total number of use case runs that still failed with a serialization failure or deadlock after the last retry",
+		},
+		[]string{"use_case"},
+	)
+)
+
+// WithRetry overrides the broker's retry policy for one execution
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *executionConfig) {
+		c.retry = policy
+	}
+}
+
+// WithRetryPolicy sets the default retry policy
+func (b *Broker) WithRetryPolicy(policy RetryPolicy) *Broker {
+	b.defaultRetry = policy
+	return b
+}
+
+// isIdempotent reports whether uc declared it is safe to retry
+func isIdempotent(uc any) bool {
+	if idemUC, ok := uc.(IdempotentUseCase); ok {
+		return idemUC.Idempotent()
+	}
+	return false
+}
+
+// isRetryable reports whether err is a serialization failure or deadlock
+func isRetryable(err error) bool {
+	return errors.Is(dberr.Translate(err), dberr.ErrSerializationFailure)
+}
+
+// runWithRetry runs fn through runInTransaction, retrying serialization
+// failures and deadlocks with backoff when the run is idempotent
+func (b *Broker) runWithRetry(
+	ctx context.Context,
+	run runInfo,
+	needsTx bool,
+	config *executionConfig,
+	fn func(ctx context.Context, tx *sql.Tx) error,
+) error {
+	for attempt := 1; ; attempt++ {
+		err := b.runInTransaction(ctx, needsTx, config, fn)
+		if err == nil || !isRetryable(err) {
+			return err
+		}
+		if !run.idempotent {
+			return err
+		}
+		if attempt >= config.retry.MaxAttempts {
+			if attempt > 1 {
+				retriesExhaustedTotal.WithLabelValues(run.name).Inc()
+			}
+			return err
+		}
+
+		delay := config.retry.backoff(attempt)
+		retriesTotal.WithLabelValues(run.name).Inc()
+		b.logger.Printf("%s: attempt %d/%d hit a serialization failure, retrying in %v: %v",
+			run.name, attempt, config.retry.MaxAttempts, delay, err)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package broker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// idempotentUseCase declares the wrapped mock safe to retry
+type idempotentUseCase struct {
+	*mockTypedUseCase
+}
+
+func (idempotentUseCase) Idempotent() bool {
+	return true
+}
+
+var fastRetry = WithRetry(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+
+// failingFirst returns a use case that fails its first `failures` runs with err
+func failingFirst(failures int, err error) (*mockTypedUseCase, *int) {
+	calls := 0
+	return &mockTypedUseCase{
+		requiresTx: true,
+		executeFn: func(ctx context.Context, tx *sql.Tx, input mockTypedInput) (mockTypedOutput, error) {
+			calls++
+			if calls <= failures {
+				return mockTypedOutput{}, err
+			}
+			return mockTypedOutput{Result: "ok", Success: true}, nil
+		},
+	}, &calls
+}
+
+func TestRunUseCase_RetriesSerializationFailure(t *testing.T) {
+	broker, mock, cleanup := setupTestBroker(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	uc, calls := failingFirst(1, &pgconn.PgError{Code: "40001"})
+	result, err := RunUseCase(broker, context.Background(), idempotentUseCase{uc}, mockTypedInput{}, fastRetry)
+	if err != nil {
+		t.Fatalf("expected retry to succeed, got %v", err)
+	}
+	if !result.Success || *calls != 2 {
+		t.Errorf("expected success after 2 calls, got %+v after %d", result, *calls)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRunUseCase_NoRetryWhenNotIdempotent(t *testing.T) {
+	broker, mock, cleanup := setupTestBroker(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	uc, calls := failingFirst(1, &pgconn.PgError{Code: "40P01"})
+	if _, err := RunUseCase(broker, context.Background(), uc, mockTypedInput{}, fastRetry); err == nil {
+		t.Fatal("expected the deadlock to be returned")
+	}
+	if *calls != 1 {
+		t.Errorf("expected 1 call, got %d", *calls)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRunUseCase_RetryGivesUpAfterMaxAttempts(t *testing.T) {
+	broker, mock, cleanup := setupTestBroker(t)
+	defer cleanup()
+
+	for i := 0; i < 3; i++ {
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+	}
+
+	pgErr := &pgconn.PgError{Code: "40001"}
+	uc, calls := failingFirst(5, pgErr)
+	_, err := RunUseCase(broker, context.Background(), idempotentUseCase{uc}, mockTypedInput{}, fastRetry)
+	if !errors.Is(err, pgErr) {
+		t.Fatalf("expected the serialization failure, got %v", err)
+	}
+	if *calls != 3 {
+		t.Errorf("expected 3 calls, got %d", *calls)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRunUseCases_RetriesOnlyIdempotentChains(t *testing.T) {
+	broker, mock, cleanup := setupTestBroker(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	first, _ := failingFirst(0, nil)
+	second, calls := failingFirst(1, &pgconn.PgError{Code: "40001"})
+	_, err := RunUseCases(broker, context.Background(), []Step{
+		Bind(idempotentUseCase{first}, Input(mockTypedInput{}), firstOutputKey),
+		Bind(second, Input(mockTypedInput{}), secondOutputKey),
+	}, fastRetry)
+	if err == nil {
+		t.Fatal("expected the chain to fail without a retry")
+	}
+	if *calls != 1 {
+		t.Errorf("expected 1 call, got %d", *calls)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
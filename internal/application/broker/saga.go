@@ -0,0 +1,182 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+)
+
+// ErrAbortSaga marks a step failure that running the step again won't fix.
+// Wrap it in a step's error to make RunSaga compensate the completed steps
+// instead of leaving the saga to be resumed.
+var ErrAbortSaga = errors.New("saga aborted")
+
+// SagaStep is one step of a saga. Build steps with SagaUseCase.
+type SagaStep struct {
+	name       string
+	run        func(ctx context.Context, b *Broker, opts []Option) error
+	compensate *SagaStep
+}
+
+// SagaUseCase adapts a typed use case into a saga step. Unlike a chain
+// step, each saga step runs in its own broker execution (and transaction,
+// if the use case requires one), so a saga can span work outside Postgres.
+//
+// name identifies the step in the saga's saved progress and must stay the
+// same across releases. The output is discarded: a resumed saga skips the
+// steps that already ran, so no step may depend on another's output.
+func SagaUseCase[I, O any](name string, uc TypedUseCase[I, O], input I) SagaStep {
+	return SagaStep{
+		name: name,
+		run: func(ctx context.Context, b *Broker, opts []Option) error {
+			_, err := RunUseCase(b, ctx, uc, input, opts...)
+			return err
+		},
+	}
+}
+
+// Name returns the step name
+func (s SagaStep) Name() string {
+	return s.name
+}
+
+// CompensateWith returns a copy of the step that compensation undoes by
+// running the given step. Steps without one, such as deleting files, can't
+// be undone: put them after the last step that may abort.
+func (s SagaStep) CompensateWith(compensation SagaStep) SagaStep {
+	s.compensate = &compensation
+	return s
+}
+
+// SagaStore saves the progress of sagas
+type SagaStore interface {
+	// LoadSaga returns the saved progress of saga id, or nil if it never ran
+	LoadSaga(ctx context.Context, id string) (*models.Saga, error)
+	// SaveSaga creates or replaces the saved progress of a saga
+	SaveSaga(ctx context.Context, saga *models.Saga) error
+}
+
+// Saga is a sequence of steps that can't share one transaction, e.g.
+// because some of them change object storage or a search index
+type Saga struct {
+	ID    string // identifies the saga's progress in Store, e.g. "account_deletion:42"
+	Steps []SagaStep
+	Store SagaStore
+}
+
+// RunSaga runs the steps of saga in order, saving its progress after every
+// step, and returns that progress.
+//
+// When a step fails the saga stops there and returns the error; running it
+// again resumes at the failed step, so a saga interrupted by a restart or a
+// transient error finishes on the next attempt. A step may therefore run
+// again after it succeeded but before its progress was saved, and must be
+// idempotent.
+//
+// When a step fails with ErrAbortSaga, the completed steps are compensated
+// instead, newest first, and the returned error wraps ErrAbortSaga. A
+// compensation that fails is resumed the same way. A completed saga doesn't
+// run again; a compensated one starts over. Options apply to every step.
+func RunSaga(b *Broker, ctx context.Context, saga Saga, opts ...Option) (*models.Saga, error) {
+	state, err := saga.Store.LoadSaga(ctx, saga.ID)
+	if err != nil {
+		return nil, fmt.Errorf("broker: failed to load saga %s: %w", saga.ID, err)
+	}
+
+	switch {
+	case state == nil || state.Status == models.SagaStatusCompensated:
+		state = &models.Saga{ID: saga.ID, Status: models.SagaStatusRunning}
+	case state.Status == models.SagaStatusCompleted:
+		return state, nil
+	case state.Status == models.SagaStatusCompensating:
+		if err := b.compensateSaga(ctx, saga, state, opts); err != nil {
+			return state, err
+		}
+		return state, fmt.Errorf("saga %s: %w", saga.ID, ErrAbortSaga)
+	}
+	state.TotalSteps = len(saga.Steps)
+
+	done := make(map[string]bool, len(state.CompletedSteps))
+	for _, name := range state.CompletedSteps {
+		done[name] = true
+	}
+
+	for _, step := range saga.Steps {
+		if done[step.name] {
+			continue
+		}
+
+		state.CurrentStep = step.name
+		if err := step.run(ctx, b, opts); err != nil {
+			state.LastError = err.Error()
+			if !errors.Is(err, ErrAbortSaga) {
+				if saveErr := saga.Store.SaveSaga(ctx, state); saveErr != nil {
+					b.logger.Printf("saga %s: failed to save progress: %v", saga.ID, saveErr)
+				}
+				return state, fmt.Errorf("saga %s: step %s failed: %w", saga.ID, step.name, err)
+			}
+
+			b.logger.Printf("saga %s: step %s aborted the saga, compensating: %v", saga.ID, step.name, err)
+			state.Status = models.SagaStatusCompensating
+			if err := b.compensateSaga(ctx, saga, state, opts); err != nil {
+				return state, err
+			}
+			return state, fmt.Errorf("saga %s: step %s failed: %w", saga.ID, step.name, err)
+		}
+
+		state.CompletedSteps = append(state.CompletedSteps, step.name)
+		state.LastError = ""
+		if err := saga.Store.SaveSaga(ctx, state); err != nil {
+			return state, fmt.Errorf("broker: failed to save saga %s: %w", saga.ID, err)
+		}
+	}
+
+	state.Status = models.SagaStatusCompleted
+	state.CurrentStep = ""
+	if err := saga.Store.SaveSaga(ctx, state); err != nil {
+		return state, fmt.Errorf("broker: failed to save saga %s: %w", saga.ID, err)
+	}
+	return state, nil
+}
+
+// compensateSaga undoes the completed steps of a saga, newest first, and
+// marks it compensated. Completed steps without a compensation, or no longer
+// part of the saga, are skipped.
+func (b *Broker) compensateSaga(ctx context.Context, saga Saga, state *models.Saga, opts []Option) error {
+	steps := make(map[string]SagaStep, len(saga.Steps))
+	for _, step := range saga.Steps {
+		steps[step.name] = step
+	}
+
+	if err := saga.Store.SaveSaga(ctx, state); err != nil {
+		return fmt.Errorf("broker: failed to save saga %s: %w", saga.ID, err)
+	}
+
+	for len(state.CompletedSteps) > 0 {
+		last := state.CompletedSteps[len(state.CompletedSteps)-1]
+		if step, ok := steps[last]; ok && step.compensate != nil {
+			state.CurrentStep = step.compensate.name
+			if err := step.compensate.run(ctx, b, opts); err != nil {
+				state.LastError = err.Error()
+				if saveErr := saga.Store.SaveSaga(ctx, state); saveErr != nil {
+					b.logger.Printf("saga %s: failed to save progress: %v", saga.ID, saveErr)
+				}
+				return fmt.Errorf("saga %s: compensation %s failed: %w", saga.ID, step.compensate.name, err)
+			}
+		}
+
+		state.CompletedSteps = state.CompletedSteps[:len(state.CompletedSteps)-1]
+		if err := saga.Store.SaveSaga(ctx, state); err != nil {
+			return fmt.Errorf("broker: failed to save saga %s: %w", saga.ID, err)
+		}
+	}
+
+	state.Status = models.SagaStatusCompensated
+	state.CurrentStep = ""
+	if err := saga.Store.SaveSaga(ctx, state); err != nil {
+		return fmt.Errorf("broker: failed to save saga %s: %w", saga.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package broker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"reflect"
+	"testing"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+)
+
+// memorySagaStore keeps saga progress in a map, copying on every save
+type memorySagaStore struct {
+	sagas map[string]models.Saga
+}
+
+func newMemorySagaStore() *memorySagaStore {
+	return &memorySagaStore{sagas: make(map[string]models.Saga)}
+}
+
+func (s *memorySagaStore) LoadSaga(_ context.Context, id string) (*models.Saga, error) {
+	saga, ok := s.sagas[id]
+	if !ok {
+		return nil, nil
+	}
+	saga.CompletedSteps = append([]string(nil), saga.CompletedSteps...)
+	return &saga, nil
+}
+
+func (s *memorySagaStore) SaveSaga(_ context.Context, saga *models.Saga) error {
+	saved := *saga
+	saved.CompletedSteps = append([]string(nil), saga.CompletedSteps...)
+	s.sagas[saga.ID] = saved
+	return nil
+}
+
+// recordingStep is a non-transactional use case that records its runs and
+// fails while failures are left
+type recordingStep struct {
+	name     string
+	runs     *[]string
+	failures []error
+}
+
+func (uc *recordingStep) Execute(_ context.Context, _ *sql.Tx, _ struct{}) (struct{}, error) {
+	*uc.runs = append(*uc.runs, uc.name)
+	if len(uc.failures) > 0 {
+		err := uc.failures[0]
+		uc.failures = uc.failures[1:]
+		return struct{}{}, err
+	}
+	return struct{}{}, nil
+}
+
+func sagaStep(name string, runs *[]string, failures ...error) SagaStep {
+	return SagaUseCase(name, &recordingStep{name: name, runs: runs, failures: failures}, struct{}{})
+}
+
+func newSagaBroker() *Broker {
+	return NewBroker(nil).WithLogger(log.New(io.Discard, "", 0))
+}
+
+func TestRunSaga_RunsStepsInOrder(t *testing.T) {
+	var runs []string
+	store := newMemorySagaStore()
+	saga := Saga{ID: "test:1", Store: store, Steps: []SagaStep{
+		sagaStep("first", &runs),
+		sagaStep("second", &runs),
+	}}
+
+	state, err := RunSaga(newSagaBroker(), context.Background(), saga)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(runs, []string{"first", "second"}) {
+		t.Errorf("runs = %v", runs)
+	}
+	if state.Status != models.SagaStatusCompleted || state.Progress() != 100 {
+		t.Errorf("state = %+v, want completed", state)
+	}
+
+	// A completed saga doesn't run again
+	if _, err := RunSaga(newSagaBroker(), context.Background(), saga); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(runs) != 2 {
+		t.Errorf("completed saga ran again: %v", runs)
+	}
+}
+
+func TestRunSaga_ResumesAtTheFailedStep(t *testing.T) {
+	var runs []string
+	store := newMemorySagaStore()
+	unavailable := errors.New("storage unavailable")
+	saga := Saga{ID: "test:1", Store: store, Steps: []SagaStep{
+		sagaStep("first", &runs),
+		sagaStep("second", &runs, unavailable),
+		sagaStep("third", &runs),
+	}}
+
+	state, err := RunSaga(newSagaBroker(), context.Background(), saga)
+	if !errors.Is(err, unavailable) {
+		t.Fatalf("error = %v, want %v", err, unavailable)
+	}
+	saved := store.sagas["test:1"]
+	if saved.Status != models.SagaStatusRunning || saved.CurrentStep != "second" || saved.LastError == "" {
+		t.Errorf("saved = %+v, want running at second with an error", saved)
+	}
+	if state.Progress() != 33 {
+		t.Errorf("progress = %d, want 33", state.Progress())
+	}
+
+	// The next run (e.g. after a worker restart) skips the first step
+	if _, err := RunSaga(newSagaBroker(), context.Background(), saga); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(runs, []string{"first", "second", "second", "third"}) {
+		t.Errorf("runs = %v", runs)
+	}
+	if store.sagas["test:1"].Status != models.SagaStatusCompleted {
+		t.Errorf("status = %s, want completed", store.sagas["test:1"].Status)
+	}
+}
+
+func TestRunSaga_AbortCompensatesCompletedSteps(t *testing.T) {
+	var runs []string
+	store := newMemorySagaStore()
+	saga := Saga{ID: "test:1", Store: store, Steps: []SagaStep{
+		sagaStep("first", &runs).CompensateWith(sagaStep("undo_first", &runs)),
+		sagaStep("second", &runs),
+		sagaStep("check", &runs, fmt.Errorf("account restored: %w", ErrAbortSaga)),
+		sagaStep("last", &runs),
+	}}
+
+	state, err := RunSaga(newSagaBroker(), context.Background(), saga)
+	if !errors.Is(err, ErrAbortSaga) {
+		t.Fatalf("error = %v, want ErrAbortSaga", err)
+	}
+	if !reflect.DeepEqual(runs, []string{"first", "second", "check", "undo_first"}) {
+		t.Errorf("runs = %v", runs)
+	}
+	if state.Status != models.SagaStatusCompensated || len(state.CompletedSteps) != 0 {
+		t.Errorf("state = %+v, want compensated", state)
+	}
+
+	// A compensated saga starts over
+	runs = nil
+	if _, err := RunSaga(newSagaBroker(), context.Background(), saga); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(runs, []string{"first", "second", "check", "last"}) {
+		t.Errorf("runs = %v", runs)
+	}
+}
+
+func TestRunSaga_ResumesAFailedCompensation(t *testing.T) {
+	var runs []string
+	store := newMemorySagaStore()
+	saga := Saga{ID: "test:1", Store: store, Steps: []SagaStep{
+		sagaStep("first", &runs).CompensateWith(sagaStep("undo_first", &runs)),
+		sagaStep("second", &runs).CompensateWith(sagaStep("undo_second", &runs, errors.New("index unavailable"))),
+		sagaStep("check", &runs, ErrAbortSaga),
+	}}
+
+	_, err := RunSaga(newSagaBroker(), context.Background(), saga)
+	if err == nil || errors.Is(err, ErrAbortSaga) {
+		t.Fatalf("error = %v, want the compensation failure", err)
+	}
+	saved := store.sagas["test:1"]
+	if saved.Status != models.SagaStatusCompensating || !reflect.DeepEqual(saved.CompletedSteps, []string{"first", "second"}) {
+		t.Errorf("saved = %+v, want compensating with both steps left", saved)
+	}
+
+	_, err = RunSaga(newSagaBroker(), context.Background(), saga)
+	if !errors.Is(err, ErrAbortSaga) {
+		t.Fatalf("error = %v, want ErrAbortSaga", err)
+	}
+	if !reflect.DeepEqual(runs, []string{"first", "second", "check", "undo_second", "undo_second", "undo_first"}) {
+		t.Errorf("runs = %v", runs)
+	}
+	if store.sagas["test:1"].Status != models.SagaStatusCompensated {
+		t.Errorf("status = %s, want compensated", store.sagas["test:1"].Status)
+	}
+}
@@ -16,7 +16,7 @@ import (
 //	}
 //
 //	type ListActivitiesOutput struct {
-//	    Result *query.PaginatedResult
+//	    Result *query.Page[models.Activity]
 //	}
 //
 //	func (uc *ListActivitiesUseCase) Execute(
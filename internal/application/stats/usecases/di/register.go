@@ -12,27 +12,27 @@ import (
 func RegisterStatsUseCases(c *container.Container) {
 	// All stats operations are read-only (non-transactional)
 	c.Register(GetWeeklyStatsUCKey, func(c *container.Container) (interface{}, error) {
-		repo := c.MustResolve(di.StatsRepoKey).(repository.StatsRepositoryInterface)
+		repo := container.MustResolve[repository.StatsRepositoryInterface](c, di.StatsRepoKey)
 		return usecases.NewGetWeeklyStatsUseCase(repo), nil
 	})
 
 	c.Register(GetMonthlyStatsUCKey, func(c *container.Container) (interface{}, error) {
-		repo := c.MustResolve(di.StatsRepoKey).(repository.StatsRepositoryInterface)
+		repo := container.MustResolve[repository.StatsRepositoryInterface](c, di.StatsRepoKey)
 		return usecases.NewGetMonthlyStatsUseCase(repo), nil
 	})
 
 	c.Register(GetUserSummaryUCKey, func(c *container.Container) (interface{}, error) {
-		repo := c.MustResolve(di.StatsRepoKey).(repository.StatsRepositoryInterface)
+		repo := container.MustResolve[repository.StatsRepositoryInterface](c, di.StatsRepoKey)
 		return usecases.NewGetUserSummaryUseCase(repo), nil
 	})
 
 	c.Register(GetTopTagsUCKey, func(c *container.Container) (interface{}, error) {
-		repo := c.MustResolve(di.StatsRepoKey).(repository.StatsRepositoryInterface)
+		repo := container.MustResolve[repository.StatsRepositoryInterface](c, di.StatsRepoKey)
 		return usecases.NewGetTopTagsUseCase(repo), nil
 	})
 
 	c.Register(GetActivityCountByTypeUCKey, func(c *container.Container) (interface{}, error) {
-		repo := c.MustResolve(di.StatsRepoKey).(repository.StatsRepositoryInterface)
+		repo := container.MustResolve[repository.StatsRepositoryInterface](c, di.StatsRepoKey)
 		return usecases.NewGetActivityCountByTypeUseCase(repo), nil
 	})
 }
@@ -35,6 +35,11 @@ func (uc *GetActivityCountByTypeUseCase) RequiresTransaction() bool {
 	return false
 }
 
+// Idempotent returns true - reads are safe to retry
+func (uc *GetActivityCountByTypeUseCase) Idempotent() bool {
+	return true
+}
+
 // Execute retrieves activity count by type (typed version)
 func (uc *GetActivityCountByTypeUseCase) Execute(
 	ctx context.Context,
@@ -34,6 +34,11 @@ func (uc *GetMonthlyStatsUseCase) RequiresTransaction() bool {
 	return false
 }
 
+// Idempotent returns true - reads are safe to retry
+func (uc *GetMonthlyStatsUseCase) Idempotent() bool {
+	return true
+}
+
 // Execute retrieves monthly statistics (typed version)
 func (uc *GetMonthlyStatsUseCase) Execute(
 	ctx context.Context,
@@ -37,6 +37,11 @@ func (uc *GetTopTagsUseCase) RequiresTransaction() bool {
 	return false
 }
 
+// Idempotent returns true - reads are safe to retry
+func (uc *GetTopTagsUseCase) Idempotent() bool {
+	return true
+}
+
 // Execute retrieves top N tags (typed version)
 func (uc *GetTopTagsUseCase) Execute(
 	ctx context.Context,
@@ -34,6 +34,11 @@ func (uc *GetUserSummaryUseCase) RequiresTransaction() bool {
 	return false
 }
 
+// Idempotent returns true - reads are safe to retry
+func (uc *GetUserSummaryUseCase) Idempotent() bool {
+	return true
+}
+
 // Execute retrieves user activity summary (typed version)
 func (uc *GetUserSummaryUseCase) Execute(
 	ctx context.Context,
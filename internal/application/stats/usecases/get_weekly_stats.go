@@ -34,6 +34,11 @@ func (uc *GetWeeklyStatsUseCase) RequiresTransaction() bool {
 	return false
 }
 
+// Idempotent returns true - reads are safe to retry
+func (uc *GetWeeklyStatsUseCase) Idempotent() bool {
+	return true
+}
+
 // Execute retrieves weekly statistics (typed version)
 func (uc *GetWeeklyStatsUseCase) Execute(
 	ctx context.Context,
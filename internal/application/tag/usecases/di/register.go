@@ -13,7 +13,7 @@ func RegisterTagUseCases(c *container.Container) {
 	// Read operations (non-transactional)
 	// Tags are typically read-only operations with dynamic filtering
 	c.Register(ListTagsUCKey, func(c *container.Container) (interface{}, error) {
-		repo := c.MustResolve(di.TagRepoKey).(repository.TagRepositoryInterface)
+		repo := container.MustResolve[repository.TagRepositoryInterface](c, di.TagRepoKey)
 		return usecases.NewListTagsUseCase(repo), nil
 	})
 }
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 
+	"github.com/valentinesamuel/activelog/internal/models"
 	"github.com/valentinesamuel/activelog/internal/repository"
 	"github.com/valentinesamuel/activelog/pkg/query"
 )
@@ -17,7 +18,7 @@ type ListTagsInput struct {
 
 // ListTagsOutput defines the typed output for ListTagsUseCase
 type ListTagsOutput struct {
-	Result *query.PaginatedResult
+	Result *query.Page[models.Tag]
 }
 
 // ListTagsUseCase handles fetching tags with dynamic filtering
@@ -38,6 +39,11 @@ func (uc *ListTagsUseCase) RequiresTransaction() bool {
 	return false
 }
 
+// Idempotent returns true - reads are safe to retry
+func (uc *ListTagsUseCase) Idempotent() bool {
+	return true
+}
+
 // Execute retrieves tags with dynamic filtering using QueryOptions (typed version)
 func (uc *ListTagsUseCase) Execute(
 	ctx context.Context,
@@ -0,0 +1,152 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	queueTypes "github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+)
+
+// Handler handles a published event
+type Handler func(ctx context.Context, event Event) error
+
+// decoder turns the data of an outbox job back into its event
+type decoder func(data json.RawMessage) (Event, error)
+
+// Bus delivers domain events to the subscribers registered for them, so
+// publishers don't know who reacts to an event:
+//
+//   - Subscribe handlers run in-process, in registration order, before
+//     Publish returns
+//   - SubscribeAsync handlers run on the worker: Publish enqueues the event on
+//     its outbox job once, and the worker's JobHandler runs them
+//
+// Register subscribers at startup, before publishing.
+type Bus struct {
+	queue queueTypes.QueueProvider
+
+	mu       sync.RWMutex
+	sync     map[string][]Handler
+	async    map[string][]Handler
+	decoders map[string]decoder
+}
+
+// NewBus creates a Bus; queue carries the events of async subscribers and
+// may be nil when there are none
+func NewBus(queue queueTypes.QueueProvider) *Bus {
+	return &Bus{
+		queue:    queue,
+		sync:     make(map[string][]Handler),
+		async:    make(map[string][]Handler),
+		decoders: make(map[string]decoder),
+	}
+}
+
+// Subscribe registers handler to run in-process whenever an E is published
+func Subscribe[E Event](b *Bus, handler func(ctx context.Context, event E) error) {
+	var zero E
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sync[zero.Name()] = append(b.sync[zero.Name()], typed(handler))
+}
+
+// SubscribeAsync registers handler to run on the worker whenever an E is
+// published. The publishing process and the worker must both register it:
+// the first to enqueue the event, the second to run handler. Panics for
+// events without an outbox job.
+func SubscribeAsync[E Event](b *Bus, handler func(ctx context.Context, event E) error) {
+	var zero E
+	name := zero.Name()
+	if _, ok := outboxJobs[name]; !ok {
+		panic(fmt.Sprintf("events: %s has no outbox job for async subscribers", name))
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.async[name] = append(b.async[name], typed(handler))
+	b.decoders[name] = func(data json.RawMessage) (Event, error) {
+		var event E
+		err := json.Unmarshal(data, &event)
+		return event, err
+	}
+}
+
+// typed adapts a handler of one event type to a Handler
+func typed[E Event](handler func(ctx context.Context, event E) error) Handler {
+	return func(ctx context.Context, event Event) error {
+		return handler(ctx, event.(E))
+	}
+}
+
+// Publish runs the in-process subscribers of event and enqueues it for the
+// async ones. A failing subscriber doesn't stop the others; the failures are
+// returned joined.
+func (b *Bus) Publish(ctx context.Context, event Event) error {
+	name := event.Name()
+	b.mu.RLock()
+	handlers := b.sync[name]
+	async := len(b.async[name]) > 0
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s subscriber: %w", name, err))
+		}
+	}
+	if async {
+		if err := b.enqueue(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: enqueue for async subscribers: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// enqueue puts event on its outbox job
+func (b *Bus) enqueue(ctx context.Context, event Event) error {
+	if b.queue == nil {
+		return errors.New("no queue provider")
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	job := outboxJobs[event.Name()]
+	_, err = b.queue.Enqueue(ctx, queueTypes.QueueFor(job), queueTypes.JobPayload{Event: job, Data: data})
+	return err
+}
+
+// JobHandler returns the worker handler of the outbox jobs (see Jobs): it
+// decodes the event and runs its async subscribers. Any failure fails the
+// job, so it is retried with every async subscriber.
+func (b *Bus) JobHandler() func(ctx context.Context, payload queueTypes.JobPayload) error {
+	return func(ctx context.Context, payload queueTypes.JobPayload) error {
+		name, ok := eventForJob(payload.Event)
+		if !ok {
+			return fmt.Errorf("events: %s is not an outbox job", payload.Event)
+		}
+
+		b.mu.RLock()
+		handlers := b.async[name]
+		decode := b.decoders[name]
+		b.mu.RUnlock()
+		if len(handlers) == 0 {
+			return nil
+		}
+
+		event, err := decode(payload.Data)
+		if err != nil {
+			return fmt.Errorf("events: unmarshal %s: %w", name, err)
+		}
+		var errs []error
+		for _, handler := range handlers {
+			if err := handler(ctx, event); err != nil {
+				errs = append(errs, fmt.Errorf("%s async subscriber: %w", name, err))
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
@@ -0,0 +1,188 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	queueTypes "github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	"github.com/valentinesamuel/activelog/internal/adapters/search/memory"
+	searchTypes "github.com/valentinesamuel/activelog/internal/adapters/search/types"
+	webhookTypes "github.com/valentinesamuel/activelog/internal/adapters/webhook/types"
+	"github.com/valentinesamuel/activelog/internal/models"
+)
+
+type recordingQueue struct {
+	payloads []queueTypes.JobPayload
+}
+
+func (q *recordingQueue) Enqueue(_ context.Context, _ queueTypes.QueueName, payload queueTypes.JobPayload) (string, error) {
+	q.payloads = append(q.payloads, payload)
+	return payload.MessageID, nil
+}
+
+type recordingWebhooks struct {
+	events []webhookTypes.WebhookEvent
+}
+
+func (w *recordingWebhooks) Publish(_ context.Context, event webhookTypes.WebhookEvent) error {
+	w.events = append(w.events, event)
+	return nil
+}
+
+func (w *recordingWebhooks) Subscribe(context.Context, func(context.Context, webhookTypes.WebhookEvent)) error {
+	return nil
+}
+
+func activityWithID(id int64) *models.Activity {
+	activity := &models.Activity{}
+	activity.ID = id
+	return activity
+}
+
+func TestBus_PublishRunsSubscribersInOrder(t *testing.T) {
+	bus := NewBus(nil)
+	var calls []string
+	Subscribe(bus, func(_ context.Context, event ActivityCreated) error {
+		calls = append(calls, "first")
+		assert.Equal(t, int64(7), event.Activity.ID)
+		return nil
+	})
+	Subscribe(bus, func(context.Context, ActivityCreated) error {
+		calls = append(calls, "second")
+		return nil
+	})
+	Subscribe(bus, func(context.Context, ActivityDeleted) error {
+		calls = append(calls, "deleted")
+		return nil
+	})
+
+	err := bus.Publish(context.Background(), ActivityCreated{UserID: 1, Activity: activityWithID(7)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second"}, calls)
+}
+
+func TestBus_PublishRunsEverySubscriberWhenOneFails(t *testing.T) {
+	bus := NewBus(nil)
+	failure := errors.New("index unavailable")
+	ran := false
+	Subscribe(bus, func(context.Context, ActivityDeleted) error { return failure })
+	Subscribe(bus, func(context.Context, ActivityDeleted) error {
+		ran = true
+		return nil
+	})
+
+	err := bus.Publish(context.Background(), ActivityDeleted{UserID: 1, ActivityID: 3})
+	assert.ErrorIs(t, err, failure)
+	assert.True(t, ran)
+}
+
+func TestBus_AsyncSubscribersRunFromTheOutboxJob(t *testing.T) {
+	queue := &recordingQueue{}
+	api := NewBus(queue)
+	worker := NewBus(nil)
+	var got []ActivityDeleted
+	for _, bus := range []*Bus{api, worker} {
+		SubscribeAsync(bus, func(_ context.Context, event ActivityDeleted) error {
+			got = append(got, event)
+			return nil
+		})
+	}
+
+	require.NoError(t, api.Publish(context.Background(), ActivityDeleted{UserID: 2, ActivityID: 9}))
+	assert.Empty(t, got, "async subscribers don't run in the publishing process")
+	require.Len(t, queue.payloads, 1)
+	assert.Equal(t, queueTypes.EventActivityDeleted, queue.payloads[0].Event)
+
+	require.NoError(t, worker.JobHandler()(context.Background(), queue.payloads[0]))
+	assert.Equal(t, []ActivityDeleted{{UserID: 2, ActivityID: 9}}, got)
+}
+
+func TestBus_PublishWithoutAsyncSubscribersEnqueuesNothing(t *testing.T) {
+	queue := &recordingQueue{}
+	bus := NewBus(queue)
+	Subscribe(bus, func(context.Context, ActivityCreated) error { return nil })
+
+	require.NoError(t, bus.Publish(context.Background(), ActivityCreated{Activity: &models.Activity{}}))
+	assert.Empty(t, queue.payloads)
+}
+
+func TestBus_JobHandlerRejectsOtherJobs(t *testing.T) {
+	err := NewBus(nil).JobHandler()(context.Background(), queueTypes.JobPayload{Event: queueTypes.EventWelcomeEmail})
+	assert.Error(t, err)
+}
+
+func TestJobs(t *testing.T) {
+	assert.Equal(t, []queueTypes.EventType{queueTypes.EventActivityCreated, queueTypes.EventActivityDeleted, queueTypes.EventActivityUpdated}, Jobs())
+}
+
+func TestRegister_ForwardsActivityEventsToWebhooks(t *testing.T) {
+	webhooks := &recordingWebhooks{}
+	bus := NewBus(nil)
+	Register(bus, Subscribers{Webhooks: webhooks})
+
+	const publicID = "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+	activity := activityWithID(5)
+	activity.UserID = 4
+	activity.PublicID = publicID
+	require.NoError(t, bus.Publish(context.Background(), ActivityCreated{UserID: 4, Activity: activity}))
+	require.NoError(t, bus.Publish(context.Background(), ActivityDeleted{UserID: 4, ActivityID: 5, PublicID: publicID}))
+
+	require.Len(t, webhooks.events, 2)
+	assert.Equal(t, webhookTypes.EventActivityCreated, webhooks.events[0].EventType)
+	assert.Equal(t, 4, webhooks.events[0].UserID)
+	var created map[string]any
+	require.NoError(t, json.Unmarshal(webhooks.events[0].Payload, &created))
+	assert.Equal(t, publicID, created["id"])
+	assert.NotContains(t, created, "userId")
+	assert.NotContains(t, created, "publicId")
+	assert.Equal(t, webhookTypes.EventActivityDeleted, webhooks.events[1].EventType)
+	assert.JSONEq(t, `{"id":"`+publicID+`"}`, string(webhooks.events[1].Payload))
+}
+
+func TestRegister_KeepsTheSearchIndexInStep(t *testing.T) {
+	index := memory.New()
+	bus := NewBus(nil)
+	Register(bus, Subscribers{Search: index})
+
+	activity := activityWithID(5)
+	activity.UserID = 4
+	activity.Title = "Hill repeats"
+	require.NoError(t, bus.Publish(context.Background(), ActivityCreated{UserID: 4, Activity: activity}))
+	result, err := index.Search(context.Background(), searchTypes.Query{UserID: 4, Text: "hill"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Total)
+
+	require.NoError(t, bus.Publish(context.Background(), ActivityDeleted{UserID: 4, ActivityID: 5}))
+	result, err = index.Search(context.Background(), searchTypes.Query{UserID: 4, Text: "hill"})
+	require.NoError(t, err)
+	assert.Zero(t, result.Total)
+}
+
+type recordingEvaluator struct {
+	userIDs []int
+}
+
+func (e *recordingEvaluator) Evaluate(_ context.Context, userID int) ([]string, error) {
+	e.userIDs = append(e.userIDs, userID)
+	return nil, nil
+}
+
+func TestRegister_EvaluatesAchievementsOnTheWorker(t *testing.T) {
+	queue := &recordingQueue{}
+	api := NewBus(queue)
+	Register(api, Subscribers{Achievements: &recordingEvaluator{}})
+	evaluator := &recordingEvaluator{}
+	worker := NewBus(nil)
+	Register(worker, Subscribers{Achievements: evaluator})
+
+	require.NoError(t, api.Publish(context.Background(), ActivityCreated{UserID: 4, Activity: activityWithID(5)}))
+	require.NoError(t, api.Publish(context.Background(), ActivityDeleted{UserID: 4, ActivityID: 5}))
+	require.Len(t, queue.payloads, 1, "deleting an activity doesn't take achievements away")
+
+	require.NoError(t, worker.JobHandler()(context.Background(), queue.payloads[0]))
+	assert.Equal(t, []int{4}, evaluator.userIDs)
+}
@@ -0,0 +1,4 @@
+package di
+
+// EventBusKey is the DI container key for the domain event bus
+const EventBusKey = "EventBus"
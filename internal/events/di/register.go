@@ -0,0 +1,39 @@
+package di
+
+import (
+	queueDI "github.com/valentinesamuel/activelog/internal/adapters/queue/di"
+	queueTypes "github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	searchDI "github.com/valentinesamuel/activelog/internal/adapters/search/di"
+	searchTypes "github.com/valentinesamuel/activelog/internal/adapters/search/types"
+	webhookDI "github.com/valentinesamuel/activelog/internal/adapters/webhook/di"
+	webhookTypes "github.com/valentinesamuel/activelog/internal/adapters/webhook/types"
+	"github.com/valentinesamuel/activelog/internal/events"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	"github.com/valentinesamuel/activelog/internal/platform/container"
+	"github.com/valentinesamuel/activelog/internal/service"
+	serviceDI "github.com/valentinesamuel/activelog/internal/service/di"
+)
+
+// RegisterEventBus registers the domain event bus, with the application's
+// subscribers, in the DI container. It depends on the queue provider, the
+// webhook bus, the achievement engine and, when search is enabled, the search
+// provider.
+func RegisterEventBus(c *container.Container) {
+	c.Register(EventBusKey, func(c *container.Container) (interface{}, error) {
+		queue := container.MustResolve[queueTypes.QueueProvider](c, queueDI.QueueProviderKey)
+		deps := events.Subscribers{
+			Webhooks: container.MustResolve[webhookTypes.WebhookBusProvider](c, webhookDI.WebhookBusKey),
+			Queue:    queue,
+			// Only enqueues here: achievements are evaluated on the worker
+			Achievements: container.MustResolve[*service.AchievementEngine](c, serviceDI.AchievementEngineKey),
+		}
+		if config.Search.Enabled() {
+			deps.Search = container.MustResolve[searchTypes.SearchProvider](c, searchDI.SearchProviderKey)
+			deps.SearchAsync = config.Search.Provider != "memory"
+		}
+
+		bus := events.NewBus(queue)
+		events.Register(bus, deps)
+		return bus, nil
+	})
+}
@@ -0,0 +1,79 @@
+package events
+
+import (
+	"sort"
+
+	queueTypes "github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	"github.com/valentinesamuel/activelog/internal/models"
+)
+
+// Event is a domain event: something that happened, published on a Bus once
+// the change is committed
+type Event interface {
+	// Name identifies the event to subscribers, e.g. "activity.created"
+	Name() string
+}
+
+// Domain event names
+const (
+	NameActivityCreated = "activity.created"
+	NameActivityUpdated = "activity.updated"
+	NameActivityDeleted = "activity.deleted"
+)
+
+// ActivityCreated is published when a user creates an activity
+type ActivityCreated struct {
+	UserID   int              `json:"userId"`
+	Activity *models.Activity `json:"activity"`
+}
+
+// Name implements Event
+func (ActivityCreated) Name() string { return NameActivityCreated }
+
+// ActivityUpdated is published when a user changes an activity
+type ActivityUpdated struct {
+	UserID   int              `json:"userId"`
+	Activity *models.Activity `json:"activity"`
+}
+
+// Name implements Event
+func (ActivityUpdated) Name() string { return NameActivityUpdated }
+
+// ActivityDeleted is published when a user deletes an activity
+type ActivityDeleted struct {
+	UserID     int    `json:"userId"`
+	ActivityID int64  `json:"activityId"`
+	PublicID   string `json:"publicId"`
+}
+
+// Name implements Event
+func (ActivityDeleted) Name() string { return NameActivityDeleted }
+
+// outboxJobs maps the events async subscribers can listen to to the queue job
+// that carries them to the worker
+var outboxJobs = map[string]queueTypes.EventType{
+	NameActivityCreated: queueTypes.EventActivityCreated,
+	NameActivityUpdated: queueTypes.EventActivityUpdated,
+	NameActivityDeleted: queueTypes.EventActivityDeleted,
+}
+
+// Jobs returns the outbox jobs, sorted; the worker routes them to
+// Bus.JobHandler
+func Jobs() []queueTypes.EventType {
+	jobs := make([]queueTypes.EventType, 0, len(outboxJobs))
+	for _, job := range outboxJobs {
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i] < jobs[j] })
+	return jobs
+}
+
+// eventForJob returns the name of the event an outbox job carries
+func eventForJob(job queueTypes.EventType) (string, bool) {
+	for name, outbox := range outboxJobs {
+		if outbox == job {
+			return name, true
+		}
+	}
+	return "", false
+}
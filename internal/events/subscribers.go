@@ -0,0 +1,134 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	queueTypes "github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	searchTypes "github.com/valentinesamuel/activelog/internal/adapters/search/types"
+	webhookTypes "github.com/valentinesamuel/activelog/internal/adapters/webhook/types"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	"github.com/valentinesamuel/activelog/internal/platform/jobs"
+)
+
+// Subscribers are the dependencies of the application's subscribers; the
+// subscribers of a nil one are left out
+type Subscribers struct {
+	Webhooks webhookTypes.WebhookBusProvider // activity.* webhooks and WebSocket sync
+	Queue    queueTypes.QueueProvider        // weather and geocoding of new activities
+	Search   searchTypes.Indexer             // the search index
+
+	// Achievements are evaluated on the worker after every new or changed
+	// activity
+	Achievements AchievementEvaluator
+
+	// SearchAsync indexes on the worker rather than in the request; an index
+	// embedded in the API process must be updated in-process
+	SearchAsync bool
+}
+
+// AchievementEvaluator awards a user the achievements their activities
+// earned, returning the new ones; it must be safe to run more than once
+type AchievementEvaluator interface {
+	Evaluate(ctx context.Context, userID int) ([]string, error)
+}
+
+// Register subscribes the application's handlers to bus. The API and the
+// worker both call it, so that async subscribers are enqueued by one and run
+// by the other.
+func Register(bus *Bus, deps Subscribers) {
+	if deps.Webhooks != nil {
+		Subscribe(bus, func(ctx context.Context, event ActivityCreated) error {
+			return publishWebhook(ctx, deps.Webhooks, webhookTypes.EventActivityCreated, event.UserID, newActivityPayload(event.Activity))
+		})
+		Subscribe(bus, func(ctx context.Context, event ActivityUpdated) error {
+			return publishWebhook(ctx, deps.Webhooks, webhookTypes.EventActivityUpdated, event.UserID, newActivityPayload(event.Activity))
+		})
+		Subscribe(bus, func(ctx context.Context, event ActivityDeleted) error {
+			return publishWebhook(ctx, deps.Webhooks, webhookTypes.EventActivityDeleted, event.UserID, map[string]string{"id": event.PublicID})
+		})
+	}
+	if deps.Queue != nil {
+		Subscribe(bus, func(ctx context.Context, event ActivityCreated) error {
+			return enqueueEnrichment(ctx, deps.Queue, event.Activity)
+		})
+	}
+	if deps.Search != nil {
+		subscribeIndexer(bus, deps.Search, deps.SearchAsync)
+	}
+	if deps.Achievements != nil {
+		evaluate := func(ctx context.Context, userID int) error {
+			awarded, err := deps.Achievements.Evaluate(ctx, userID)
+			if err == nil && len(awarded) > 0 {
+				log.Printf("[events] achievements -> userID=%d awarded=%v", userID, awarded)
+			}
+			return err
+		}
+		SubscribeAsync(bus, func(ctx context.Context, event ActivityCreated) error { return evaluate(ctx, event.UserID) })
+		SubscribeAsync(bus, func(ctx context.Context, event ActivityUpdated) error { return evaluate(ctx, event.UserID) })
+	}
+}
+
+// subscribeIndexer keeps the search index in step with the activities
+func subscribeIndexer(bus *Bus, indexer searchTypes.Indexer, async bool) {
+	index := func(ctx context.Context, activity *models.Activity) error {
+		return indexer.Index(ctx, searchTypes.NewDocument(activity))
+	}
+	onCreated := func(ctx context.Context, event ActivityCreated) error { return index(ctx, event.Activity) }
+	onUpdated := func(ctx context.Context, event ActivityUpdated) error { return index(ctx, event.Activity) }
+	onDeleted := func(ctx context.Context, event ActivityDeleted) error { return indexer.Delete(ctx, event.ActivityID) }
+
+	if async {
+		SubscribeAsync(bus, onCreated)
+		SubscribeAsync(bus, onUpdated)
+		SubscribeAsync(bus, onDeleted)
+		return
+	}
+	Subscribe(bus, onCreated)
+	Subscribe(bus, onUpdated)
+	Subscribe(bus, onDeleted)
+}
+
+// activityPayload is an activity as webhooks see it: identified by its public
+// ID, with the serial IDs left out
+type activityPayload struct {
+	*models.Activity
+	ID       string `json:"id"`
+	PublicID string `json:"publicId,omitempty"`
+	UserID   int    `json:"userId,omitempty"`
+}
+
+func newActivityPayload(activity *models.Activity) activityPayload {
+	return activityPayload{Activity: activity, ID: activity.PublicID}
+}
+
+// publishWebhook forwards an event to the webhook bus, which delivers it to
+// the user's webhooks and WebSocket connections
+func publishWebhook(ctx context.Context, webhooks webhookTypes.WebhookBusProvider, eventType string, userID int, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return webhooks.Publish(ctx, webhookTypes.WebhookEvent{
+		EventType: eventType,
+		UserID:    userID,
+		Payload:   data,
+		Timestamp: time.Now().UTC(),
+	})
+}
+
+// enqueueEnrichment schedules the background lookups for a new activity:
+// weather when it has start coordinates, geocoding when it only has a location
+// name (the geocode job enqueues weather itself once it has coordinates)
+func enqueueEnrichment(ctx context.Context, queue queueTypes.QueueProvider, activity *models.Activity) error {
+	switch {
+	case activity.StartLat != nil && config.Weather.Enabled():
+		return jobs.EnqueueEnrichWeather(ctx, queue, activity.ID)
+	case activity.StartLat == nil && activity.LocationName != nil && config.Geocoding.Enabled():
+		return jobs.EnqueueGeocodeActivity(ctx, queue, activity.ID)
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	queueTypes "github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/platform/jobs"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/platform/validator"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// AccountHandler serves the GDPR endpoints: exporting all of a user's data and
+// deleting their account
+type AccountHandler struct {
+	accountRepo   *repository.AccountRepository
+	exportRepo    *repository.ExportRepository
+	jobRepo       *repository.JobRepository
+	queueProvider queueTypes.QueueProvider
+	tokenTTL      time.Duration
+	gracePeriod   time.Duration
+	clock         clock.Clock
+}
+
+// AccountHandlerDeps contains the dependencies for AccountHandler.
+type AccountHandlerDeps struct {
+	AccountRepo   *repository.AccountRepository
+	ExportRepo    *repository.ExportRepository
+	JobRepo       *repository.JobRepository
+	QueueProvider queueTypes.QueueProvider
+	TokenTTL      time.Duration // how long a deletion confirmation token is valid
+	GracePeriod   time.Duration // how long a deleted account stays disabled before it is purged
+	Clock         clock.Clock   // expires confirmation tokens and schedules the purge; nil uses the real clock
+}
+
+// NewAccountHandler creates a new AccountHandler with the given dependencies.
+func NewAccountHandler(deps AccountHandlerDeps) *AccountHandler {
+	clk := deps.Clock
+	if clk == nil {
+		clk = clock.Real{}
+	}
+	return &AccountHandler{
+		accountRepo:   deps.AccountRepo,
+		exportRepo:    deps.ExportRepo,
+		jobRepo:       deps.JobRepo,
+		queueProvider: deps.QueueProvider,
+		tokenTTL:      deps.TokenTTL,
+		gracePeriod:   deps.GracePeriod,
+		clock:         clk,
+	}
+}
+
+// ExportData handles POST /api/v1/users/me/export
+// @Summary Export all my data
+// @Description Enqueues a job that bundles everything stored about the user (profile, activities, tags, photos, comments, shares, reactions, group memberships and the audit trail) into a ZIP of JSON and CSV files. Track it with /api/v1/jobs/{jobId}/status and fetch it with /api/v1/jobs/{jobId}/download.
+// @Tags Users
+// @Produce json
+// @Success 202 {object} map[string]string "Job ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/users/me/export [post]
+func (h *AccountHandler) ExportData(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	record := &models.ExportRecord{
+		UserID: user.Id,
+		Format: models.FormatZIP,
+		Status: models.StatusPending,
+	}
+	if err := h.exportRepo.Create(ctx, record); err != nil {
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to create export record")
+		return
+	}
+
+	// Track the job under the export ID so both status endpoints accept it
+	job := &models.Job{
+		ID:     record.ID,
+		UserID: user.Id,
+		Type:   string(queueTypes.EventExportUserData),
+	}
+	if err := h.jobRepo.Create(ctx, job); err != nil {
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to create job")
+		return
+	}
+
+	data, err := json.Marshal(jobs.ExportUserDataPayload{
+		ExportID: record.ID,
+		UserID:   user.Id,
+	})
+	if err != nil {
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to marshal job payload")
+		return
+	}
+
+	jobPayload := queueTypes.JobPayload{
+		Event:     queueTypes.EventExportUserData,
+		Data:      data,
+		JobID:     job.ID,
+		MessageID: record.ID,
+	}
+	if _, err := h.queueProvider.Enqueue(ctx, queueTypes.QueueFor(jobPayload.Event), jobPayload); err != nil {
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to enqueue export job")
+		return
+	}
+
+	response.Success(w, r, http.StatusAccepted, map[string]string{
+		"job_id": record.ID,
+	})
+}
+
+// DeleteAccount handles DELETE /api/v1/users/me
+// @Summary Delete my account
+// @Description Deletion takes two calls. Without a body it returns a short-lived confirmation token. Sending that token back disables the account immediately; the account and all its data (including stored files) are permanently deleted when the grace period ends.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param request body models.DeleteAccountRequest false "Confirmation token from the first call"
+// @Success 200 {object} models.DeletionConfirmation "Confirmation token issued"
+// @Success 202 {object} models.DeletionScheduled "Account disabled and scheduled for deletion"
+// @Failure 400 {object} map[string]interface{} "Invalid or expired confirmation token"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Account not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/users/me [delete]
+func (h *AccountHandler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	var req models.DeleteAccountRequest
+	if !decodeOptionalJSON(w, r, &req) {
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
+		return
+	}
+
+	if req.ConfirmationToken == "" {
+		h.requestDeletion(w, r, user.Id)
+		return
+	}
+
+	// The purge job compares this with the same clock
+	now := h.clock.Now()
+	scheduledAt := now.Add(h.gracePeriod)
+	if err := h.accountRepo.ConfirmDeletion(ctx, user.Id, hashDeletionToken(req.ConfirmationToken), now, scheduledAt); err != nil {
+		if errors.Is(err, appErrors.ErrInvalidInput) {
+			response.Fail(w, r, http.StatusBadRequest, "Invalid or expired confirmation token")
+			return
+		}
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to confirm account deletion")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to delete account")
+		return
+	}
+
+	log.Info().Int("userID", user.Id).Time("scheduledAt", scheduledAt).Msg("Account disabled and scheduled for deletion")
+	response.Success(w, r, http.StatusAccepted, models.DeletionScheduled{DeletionScheduledAt: scheduledAt})
+}
+
+// requestDeletion issues a new confirmation token; only its hash is stored
+func (h *AccountHandler) requestDeletion(w http.ResponseWriter, r *http.Request, userID int) {
+	token, err := generateSecret()
+	if err != nil {
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to generate confirmation token")
+		return
+	}
+
+	expiresAt := h.clock.Now().Add(h.tokenTTL)
+	if err := h.accountRepo.RequestDeletion(r.Context(), userID, hashDeletionToken(token), expiresAt); err != nil {
+		if errors.Is(err, appErrors.ErrNotFound) {
+			response.Fail(w, r, http.StatusNotFound, "Account not found")
+			return
+		}
+		log.Error().Err(err).Int("userID", userID).Msg("Failed to request account deletion")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to request account deletion")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, models.DeletionConfirmation{
+		ConfirmationToken: token,
+		ExpiresAt:         expiresAt,
+	})
+}
+
+func hashDeletionToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
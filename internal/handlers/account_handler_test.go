@@ -0,0 +1,90 @@
+package handlers_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valentinesamuel/activelog/internal/handlers"
+	"github.com/valentinesamuel/activelog/internal/models"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/repository/testhelpers"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+)
+
+// The clock is years behind the system clock, so expiries only come out
+// right when they are taken from it
+var accountNow = time.Date(2020, 6, 1, 9, 0, 0, 0, time.UTC)
+
+func newAccountHandler(t *testing.T) (*handlers.AccountHandler, sqlmock.Sqlmock) {
+	db, mock := testhelpers.SetupMockDB(t)
+	h := handlers.NewAccountHandler(handlers.AccountHandlerDeps{
+		AccountRepo: repository.NewAccountRepository(db),
+		TokenTTL:    15 * time.Minute,
+		GracePeriod: 30 * 24 * time.Hour,
+		Clock:       clock.NewFake(accountNow),
+	})
+	return h, mock
+}
+
+func deleteAccountRequest(body string) *http.Request {
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/users/me", strings.NewReader(body))
+	return req.WithContext(requestcontext.NewContext(req.Context(), &requestcontext.User{Id: 7}))
+}
+
+func TestAccountHandler_DeleteAccount(t *testing.T) {
+	t.Run("confirmation token expires by the clock", func(t *testing.T) {
+		h, mock := newAccountHandler(t)
+		mock.ExpectExec(`SET deletion_token_hash = \$2, deletion_token_expires_at = \$3`).
+			WithArgs(7, sqlmock.AnyArg(), accountNow.Add(15*time.Minute)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		w := httptest.NewRecorder()
+		h.DeleteAccount(w, deleteAccountRequest(""))
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var body struct{ Result models.DeletionConfirmation }
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.True(t, accountNow.Add(15*time.Minute).Equal(body.Result.ExpiresAt))
+		assert.Len(t, body.Result.ConfirmationToken, 64)
+	})
+
+	t.Run("confirmation token is checked on the clock", func(t *testing.T) {
+		h, mock := newAccountHandler(t)
+		token := strings.Repeat("ab", 32)
+		sum := sha256.Sum256([]byte(token))
+		mock.ExpectExec(`AND deletion_token_hash = \$2\s+AND deletion_token_expires_at > \$4`).
+			WithArgs(7, hex.EncodeToString(sum[:]), accountNow.Add(30*24*time.Hour), accountNow).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		w := httptest.NewRecorder()
+		h.DeleteAccount(w, deleteAccountRequest(`{"confirmation_token":"`+token+`"}`))
+
+		require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+		var body struct{ Result models.DeletionScheduled }
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.True(t, accountNow.Add(30*24*time.Hour).Equal(body.Result.DeletionScheduledAt))
+	})
+
+	t.Run("expired confirmation token", func(t *testing.T) {
+		h, mock := newAccountHandler(t)
+		mock.ExpectExec(`AND deletion_token_expires_at > \$4`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		w := httptest.NewRecorder()
+		h.DeleteAccount(w, deleteAccountRequest(`{"confirmation_token":"`+strings.Repeat("cd", 32)+`"}`))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Invalid or expired confirmation token")
+	})
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/service"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// AchievementHandler serves the achievements users earn with their
+// activities. They are awarded on the worker (see service.AchievementEngine).
+type AchievementHandler struct {
+	engine *service.AchievementEngine
+}
+
+// NewAchievementHandler creates a new AchievementHandler
+func NewAchievementHandler(engine *service.AchievementEngine) *AchievementHandler {
+	return &AchievementHandler{engine: engine}
+}
+
+// ListAchievements handles GET /api/v1/achievements
+// @Summary List achievements
+// @Description Returns every achievement with whether the caller earned it and when. Achievements are awarded shortly after the activity that earns them is logged, and are kept when activities are deleted.
+// @Tags Achievements
+// @Produce json
+// @Success 200 {array} models.Achievement "Achievements"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/achievements [get]
+func (h *AchievementHandler) ListAchievements(w http.ResponseWriter, r *http.Request) {
+	user, _ := requestcontext.FromContext(r.Context())
+
+	achievements, err := h.engine.List(r.Context(), user.Id)
+	if err != nil {
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to list achievements")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to fetch achievements")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, achievements)
+}
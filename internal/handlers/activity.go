@@ -1,23 +1,32 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/rs/zerolog/log"
+	"github.com/valentinesamuel/activelog/internal/adapters/storage/fileurl"
+	webhookTypes "github.com/valentinesamuel/activelog/internal/adapters/webhook/types"
 	"github.com/valentinesamuel/activelog/internal/application/activity/usecases"
 	"github.com/valentinesamuel/activelog/internal/application/broker"
+	"github.com/valentinesamuel/activelog/internal/events"
+	"github.com/valentinesamuel/activelog/internal/middleware"
 	"github.com/valentinesamuel/activelog/internal/models"
 	"github.com/valentinesamuel/activelog/internal/repository"
 	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
 	"github.com/valentinesamuel/activelog/internal/platform/validator"
+	"github.com/valentinesamuel/activelog/pkg/clock"
 	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
 	"github.com/valentinesamuel/activelog/pkg/query"
 	"github.com/valentinesamuel/activelog/pkg/response"
+	"github.com/valentinesamuel/activelog/pkg/tabular"
 	"github.com/valentinesamuel/activelog/pkg/workers"
 )
 
@@ -32,6 +41,16 @@ type ActivityHandler struct {
 	updateActivityUC   *usecases.UpdateActivityUseCase
 	deleteActivityUC   *usecases.DeleteActivityUseCase
 	getActivityStatsUC *usecases.GetActivityStatsUseCase
+	bulkUpdateUC       *usecases.BulkUpdateActivitiesUseCase
+	bulkDeleteUC       *usecases.BulkDeleteActivitiesUseCase
+	mergeActivitiesUC  *usecases.MergeActivitiesUseCase
+	findDuplicatesUC   *usecases.FindDuplicateActivitiesUseCase
+	reactionRepo       *repository.ReactionRepository
+	typeRepo           *repository.ActivityTypeRepository
+	trackRepo          *repository.ActivityTrackRepository
+	eventBus           *events.Bus
+	validation         *query.EntityValidation
+	clock              clock.Clock
 }
 
 type ActivityHandlerDeps struct {
@@ -43,12 +62,26 @@ type ActivityHandlerDeps struct {
 	UpdateActivityUC   *usecases.UpdateActivityUseCase
 	DeleteActivityUC   *usecases.DeleteActivityUseCase
 	GetActivityStatsUC *usecases.GetActivityStatsUseCase
+	BulkUpdateUC       *usecases.BulkUpdateActivitiesUseCase
+	BulkDeleteUC       *usecases.BulkDeleteActivitiesUseCase
+	MergeActivitiesUC  *usecases.MergeActivitiesUseCase
+	FindDuplicatesUC   *usecases.FindDuplicateActivitiesUseCase
+	ReactionRepo       *repository.ReactionRepository      // optional; adds reaction counts to list responses
+	TypeRepo           *repository.ActivityTypeRepository  // optional; adds type metadata to list responses
+	TrackRepo          *repository.ActivityTrackRepository // optional; adds map thumbnail URLs to list responses
+	EventBus           *events.Bus                         // optional; receives the activity events
+	Validation         *query.EntityValidation             // list query whitelist (see ActivityRepository.GetValidation)
+	Clock              clock.Clock                         // thumbnail URLs expire by it; nil uses the real clock
 }
 
 // NewActivityHandler creates a handler with broker pattern
 func NewActivityHandler(
 	deps ActivityHandlerDeps,
 ) *ActivityHandler {
+	clk := deps.Clock
+	if clk == nil {
+		clk = clock.Real{}
+	}
 	return &ActivityHandler{
 		broker:             deps.Broker,
 		repo:               deps.Repo,
@@ -58,19 +91,65 @@ func NewActivityHandler(
 		updateActivityUC:   deps.UpdateActivityUC,
 		deleteActivityUC:   deps.DeleteActivityUC,
 		getActivityStatsUC: deps.GetActivityStatsUC,
+		bulkUpdateUC:       deps.BulkUpdateUC,
+		bulkDeleteUC:       deps.BulkDeleteUC,
+		mergeActivitiesUC:  deps.MergeActivitiesUC,
+		findDuplicatesUC:   deps.FindDuplicatesUC,
+		reactionRepo:       deps.ReactionRepo,
+		typeRepo:           deps.TypeRepo,
+		trackRepo:          deps.TrackRepo,
+		eventBus:           deps.EventBus,
+		validation:         deps.Validation,
+		clock:              clk,
+	}
+}
+
+// publishDomainEvent publishes event on bus, which may be nil, once a change
+// is committed. Failures are logged; the request already succeeded.
+func publishDomainEvent(ctx context.Context, bus *events.Bus, event events.Event) {
+	if bus == nil {
+		return
+	}
+	if err := bus.Publish(ctx, event); err != nil {
+		log.Error().Err(err).Str("event", event.Name()).Msg("Failed to publish domain event")
+	}
+}
+
+// publishActivityEvent publishes an activity-related webhook event
+// (reaction.*) on events, which may be nil
+func publishActivityEvent(ctx context.Context, events webhookTypes.WebhookBusProvider, eventType string, userID int, payload any) {
+	if events == nil {
+		return
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Error().Err(err).Str("event", eventType).Msg("Failed to marshal activity event")
+		return
+	}
+	if err := events.Publish(ctx, webhookTypes.WebhookEvent{
+		EventType: eventType,
+		UserID:    userID,
+		Payload:   data,
+		Timestamp: time.Now().UTC(),
+	}); err != nil {
+		log.Error().Err(err).Str("event", eventType).Msg("Failed to publish activity event")
 	}
 }
 
 // CreateActivity handles activity creation using broker pattern
 // @Summary Create a new activity
-// @Description Creates a new activity for the authenticated user
+// @Description Creates a new activity for the authenticated user. A locationName given without startLat/startLng is geocoded into start coordinates in the background (when geocoding is enabled). For activities with start coordinates the temperature and conditions are then looked up and added (when weather enrichment is enabled).
 // @Tags Activities
 // @Accept json
 // @Produce json
 // @Param request body models.CreateActivityRequest true "Activity creation request"
+// @Param allow_duplicate query bool false "Skip duplicate detection (default: false)"
+// @Param dry_run query bool false "Validate and preview the result without saving (default: false)"
 // @Success 201 {object} models.Activity "Created activity"
+// @Success 200 {object} dryRunResponse "Dry-run preview"
 // @Failure 400 {object} map[string]interface{} "Validation error"
 // @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 409 {object} map[string]string "Duplicate activity (Location header points to the existing record)"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Security BearerAuth
 // @Router /api/v1/activities [post]
@@ -79,15 +158,14 @@ func (h *ActivityHandler) CreateActivity(w http.ResponseWriter, r *http.Request)
 	requestUser, _ := requestcontext.FromContext(ctx)
 	var req models.CreateActivityRequest
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Fail(w, r, http.StatusBadRequest, "Invalid request body")
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
 	// Validate request
 	err := validator.Validate(&req)
 	if err != nil {
-		response.ValidationFail(w, r, validator.FormatValidationErrors(err))
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
 		return
 	}
 
@@ -97,18 +175,35 @@ func (h *ActivityHandler) CreateActivity(w http.ResponseWriter, r *http.Request)
 		ctx,
 		h.createActivityUC,
 		usecases.CreateActivityInput{
-			UserID:  requestUser.Id,
-			Request: &req,
+			UserID:         requestUser.Id,
+			Request:        &req,
+			AllowDuplicate: r.URL.Query().Get("allow_duplicate") == "true",
 		},
+		brokerOptions(r)...,
 	)
 
 	if err != nil {
+		var dupErr *appErrors.DuplicateError
+		if errors.As(err, &dupErr) {
+			w.Header().Set("Location", "/api/v1/activities/"+dupErr.ExistingPublicID)
+			response.Fail(w, r, http.StatusConflict, "Duplicate of existing activity "+dupErr.ExistingPublicID)
+			return
+		}
+		if failValidationError(w, r, err) || failDBError(w, r, err, "Activity") {
+			return
+		}
 		log.Error().Err(err).Msg("Failed to create activity")
 		response.Fail(w, r, http.StatusInternalServerError, "Failed to create activity")
 		return
 	}
 
+	if isDryRun(r) {
+		respondDryRun(w, r, result.Activity)
+		return
+	}
+
 	log.Info().Int64("activityId", result.ActivityID).Msg("Activity Created")
+	publishDomainEvent(ctx, h.eventBus, events.ActivityCreated{UserID: requestUser.Id, Activity: result.Activity})
 	response.Success(w, r, http.StatusCreated, result.Activity)
 }
 
@@ -117,8 +212,11 @@ func (h *ActivityHandler) CreateActivity(w http.ResponseWriter, r *http.Request)
 // @Description Returns a single activity by its ID
 // @Tags Activities
 // @Produce json
-// @Param id path int true "Activity ID"
+// @Param id path string true "Activity public ID or serial ID"
+// @Param include query string false "Related resources to embed: splits"
+// @Param If-None-Match header string false "ETag from a previous response"
 // @Success 200 {object} models.Activity "Activity found"
+// @Success 304 "Not modified"
 // @Failure 400 {object} map[string]string "Invalid activity ID"
 // @Failure 401 {object} map[string]string "Unauthorized"
 // @Failure 404 {object} map[string]string "Activity not found"
@@ -135,19 +233,31 @@ func (h *ActivityHandler) GetActivity(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	includeSplits := false
+	for _, include := range strings.Split(r.URL.Query().Get("include"), ",") {
+		switch strings.TrimSpace(include) {
+		case "":
+		case "splits":
+			includeSplits = true
+		default:
+			response.Fail(w, r, http.StatusBadRequest, "Unsupported include: "+include)
+			return
+		}
+	}
+
 	// Execute typed use case through broker
 	result, err := broker.RunUseCase(
 		h.broker,
 		ctx,
 		h.getActivityUC,
 		usecases.GetActivityInput{
-			ActivityID: int64(id),
+			ActivityID:    int64(id),
+			IncludeSplits: includeSplits,
 		},
 	)
 
 	if err != nil {
-		if errors.Is(err, appErrors.ErrNotFound) {
-			response.Fail(w, r, http.StatusNotFound, "Activity not found")
+		if failDBError(w, r, err, "Activity") {
 			return
 		}
 
@@ -156,26 +266,76 @@ func (h *ActivityHandler) GetActivity(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// ConditionalGET middleware answers 304 when this matches If-None-Match.
+	// Splits never change after create, so only whether they're included counts.
+	etagParts := []interface{}{"activity", result.Activity.ID, result.Activity.UpdatedAt}
+	if includeSplits {
+		etagParts = append(etagParts, "splits")
+	}
+	w.Header().Set("ETag", middleware.WeakETag(etagParts...))
 	response.Success(w, r, http.StatusOK, result.Activity)
 }
 
+// activityColumns are the fields available to CSV/XLSX list output, in default order
+var activityColumns = []string{
+	"id",
+	"activityType",
+	"title",
+	"description",
+	"durationMinutes",
+	"distanceKm",
+	"caloriesBurned",
+	"paceMinPerKm",
+	"avgSpeedKmh",
+	"rpe",
+	"mood",
+	"notes",
+	"activityDate",
+	"created_at",
+	"updated_at",
+}
+
+// activityGeoPoints are the virtual point columns activities can be filtered on
+// with filter[column][within]
+var activityGeoPoints = map[string]query.GeoPoint{
+	"location": {Lat: "start_lat", Lng: "start_lng"},
+}
+
 // ListActivities fetches activities using dynamic filtering with QueryOptions
 // @Summary List activities
-// @Description Returns a paginated list of activities for the authenticated user with filtering, searching, and sorting
+// @Description Returns a paginated list of activities for the authenticated user with filtering, searching, and sorting. Expensive queries (many relationship filters, wide searches, long IN lists) get a smaller page, reported in X-Query-Degraded, or a 422 (see QUERY_COST_*).
 // @Tags Activities
-// @Produce json
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Param filter[activity_type] query string false "Filter by activity type"
 // @Param filter[tags.name] query string false "Filter by tag name"
+// @Param filter[tags][contains] query string false "Activities having every tag of the list, e.g. [cardio,outdoor], without a JOIN (also [overlaps]: any of them, [any]: the one tag)"
+// @Param filter[location][within] query string false "Only activities starting inside the box lat1,lng1,lat2,lng2"
+// @Param filter[rpe][gte] query int false "Activities with a perceived exertion (1-10) of at least this"
+// @Param filter[mood] query int false "Filter by mood (1-5)"
+// @Param filter[source] query string false "Filter by where activities came from: manual, or an import source (json, strava, gpx, apple_health, google_fit)"
+// @Param filter[visibility] query string false "Filter by who may see activities: private, followers or public"
+// @Param filter[activity_date][gte] query string false "Activities on or after this time, RFC3339 (also [gt], [lte], [lt])"
+// @Param filter[metadata.shoe] query string false "Filter by a metadata key (any key: filter[metadata.<key>], nested: filter[metadata.<key>.<key>])"
 // @Param search[title] query string false "Search in title (case-insensitive)"
 // @Param search[description] query string false "Search in description (case-insensitive)"
 // @Param order[created_at] query string false "Sort by created_at (ASC or DESC)"
 // @Param order[activity_date] query string false "Sort by activity_date (ASC or DESC)"
+// @Param order[rpe] query string false "Sort by perceived exertion (ASC or DESC)"
 // @Param page query int false "Page number (default: 1)"
 // @Param limit query int false "Items per page (default: 10, max: 100)"
-// @Success 200 {object} map[string]interface{} "Paginated activities with metadata"
+// @Param count query string false "How totalRecords is computed: exact (default), estimated, or none (skips counting)" Enums(exact, estimated, none)
+// @Param format query string false "Response format: json, csv or xlsx (overrides Accept)"
+// @Param fields query string false "Comma-separated columns for csv/xlsx output"
+// @Param include query string false "Related resources to embed: tags, tags.parent, photos, user"
+// @Param fields[tags] query string false "Comma-separated columns of included tags (also fields[photos], fields[user])"
+// @Param If-None-Match header string false "ETag from a previous response"
+// @Success 200 {object} paginatedResponse{data=[]models.Activity} "Paginated activities with metadata"
+// @Header 200 {string} X-Query-Degraded "Reduced page size, e.g. limit=40, when the query was over its cost budget"
 // @Failure 400 {object} map[string]string "Invalid query parameters"
 // @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 422 {object} map[string]string "Query too expensive"
 // @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 503 {object} map[string]string "Query canceled by its statement timeout"
 // @Security BearerAuth
 // @Router /api/v1/activities [get]
 func (h *ActivityHandler) ListActivities(w http.ResponseWriter, r *http.Request) {
@@ -188,106 +348,55 @@ func (h *ActivityHandler) ListActivities(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Parse query parameters into QueryOptions
-	queryOpts, err := query.ParseQueryParams(r.URL.Query())
+	format := tabular.Negotiate(r)
+
+	// Parse query parameters into QueryOptions; API field names map to columns
+	queryOpts, err := query.ParseQueryParamsWithFields(r.URL.Query(), h.validation.Fields())
 	if err != nil {
 		response.Fail(w, r, http.StatusBadRequest, "Invalid query parameters")
 		return
 	}
 
-	// Define whitelists for security (CRITICAL: Only allow safe columns)
-	allowedFilters := []string{
-		// Direct columns (main table)
-		"activity_type",
-		"duration_minutes",
-		"distance_km",
-		"calories_burned",
-		"activity_date",
-		"created_at",
-		"updated_at",
-
-		// Relationship columns (natural names - auto-JOINs!)
-		"tags.name", // Filter by tag name - automatically JOINs tags table
-		"tags.id",   // Filter by tag ID
-
-		// Cross-registry: activities → users (Feature 2)
-		"users.id",
-		"users.username",
-		"users.email",
-
-		// Deep nesting: activities → tags → parent tag (Feature 3)
-		"tags.parent.name",
-	}
-
-	allowedSearch := []string{
-		// Direct columns
-		"title",
-		"description",
-		"notes",
-
-		// Relationship columns (natural names - auto-JOINs!)
-		"tags.name", // Search tag names
-
-		// Cross-registry search (Feature 2)
-		"users.username",
-		"users.email",
-
-		// Deep nesting search (Feature 3)
-		"tags.parent.name",
+	// Validate against the activities whitelist (CRITICAL: only safe columns
+	// and operators; aliases and camelCase names are normalized first)
+	if err := h.validation.Validate(queryOpts); err != nil {
+		log.Warn().Err(err).Msg("Invalid query parameters")
+		response.Fail(w, r, http.StatusBadRequest, err.Error())
+		return
 	}
-
-	allowedOrder := []string{
-		// Direct columns
-		"created_at",
-		"updated_at",
-		"activity_date",
-		"duration_minutes",
-		"distance_km",
-		"calories_burned",
-
-		// Relationship columns (natural names - auto-JOINs!)
-		"tags.name", // Order by tag name alphabetically
+	if err := query.ResolveGeoFilters(queryOpts, activityGeoPoints); err != nil {
+		response.Fail(w, r, http.StatusBadRequest, err.Error())
+		return
 	}
-
-	// Operator whitelisting (v1.1.0+)
-	// Define which operators are allowed for each column
-	operatorWhitelists := query.OperatorWhitelist{
-		// Direct columns - comparison operators
-		"activity_date":    query.ComparisonOperators(), // All 6: eq, ne, gt, gte, lt, lte
-		"distance_km":      query.ComparisonOperators(),
-		"duration_minutes": query.ComparisonOperators(),
-		"calories_burned":  query.ComparisonOperators(),
-		"created_at":       query.ComparisonOperators(),
-
-		// Direct columns - equality only
-		"activity_type": query.EqualityOperators(), // eq, ne only
-
-		// Relationship columns
-		"tags.name": query.EqualityOperators(),  // eq, ne for tag names
-		"tags.id":   query.StrictEqualityOnly(), // eq only for tag IDs
-
-		// Cross-registry columns (Feature 2)
-		"users.id":       query.StrictEqualityOnly(),
-		"users.email":    query.StrictEqualityOnly(),
-		"users.username": query.EqualityOperators(),
-
-		// Deep nesting columns (Feature 3)
-		"tags.parent.name": query.EqualityOperators(),
+	if err := query.ResolveJSONFilters(queryOpts, "metadata"); err != nil {
+		response.Fail(w, r, http.StatusBadRequest, err.Error())
+		return
 	}
 
-	// Validate query options against whitelists
-	if err := query.ValidateQueryOptions(queryOpts, allowedFilters, allowedSearch, allowedOrder); err != nil {
-		log.Warn().Err(err).Msg("Invalid query parameters")
+	// Relations to embed (include=tags,photos,user), checked like filters
+	includes := query.ParseIncludes(r.URL.Query(), h.validation.Fields())
+	if err := h.validation.ValidateIncludes(includes); err != nil {
 		response.Fail(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// Validate operator-based filters (v1.1.0+)
-	if err := query.ValidateFilterConditions(queryOpts, allowedFilters, operatorWhitelists); err != nil {
-		log.Warn().Err(err).Msg("Invalid filter operator")
-		response.Fail(w, r, http.StatusBadRequest, err.Error())
+	// Collection ETag: cheap COUNT/MAX(updated_at) over the same filters, so an
+	// unchanged list is answered with 304 before the page itself is fetched.
+	// Included relations change on their own, so those pages get no ETag.
+	queryOpts.Filter["user_id"] = requestUser.Id
+	if !enforceQueryCost(w, r, queryOpts) {
 		return
 	}
+	if len(includes) == 0 {
+		if etag, ok := h.collectionETag(ctx, r, format, queryOpts); ok {
+			w.Header().Set("ETag", etag)
+			w.Header().Set("Vary", "Accept")
+			if middleware.ETagMatches(r, etag) {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+	}
 
 	// Execute typed use case through broker
 	result, err := broker.RunUseCase(
@@ -301,11 +410,34 @@ func (h *ActivityHandler) ListActivities(w http.ResponseWriter, r *http.Request)
 	)
 
 	if err != nil {
+		if failQueryTimeout(w, r, err) {
+			return
+		}
 		log.Error().Err(err).Msg("Failed to list activities")
 		response.Fail(w, r, http.StatusInternalServerError, "Failed to fetch activities")
 		return
 	}
 
+	if format != tabular.FormatJSON {
+		respondTabular(w, r, format, "activities", result.Result, activityColumns)
+		return
+	}
+
+	activities := result.Result.Data
+	h.attachReactionCounts(ctx, activities)
+	h.attachTypeInfo(ctx, requestUser.Id, activities)
+	h.attachThumbnailURLs(ctx, activities)
+
+	var data interface{} = activities
+	if len(includes) > 0 {
+		data, err = h.preloadIncludes(ctx, activities, includes)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to preload activity relations")
+			response.Fail(w, r, http.StatusInternalServerError, "Failed to fetch activities")
+			return
+		}
+	}
+
 	// Set cache status headers
 	if result.Cache.Hit {
 		w.Header().Set("X-Cache-Status", "HIT")
@@ -316,20 +448,111 @@ func (h *ActivityHandler) ListActivities(w http.ResponseWriter, r *http.Request)
 
 	// Return standardized response with pagination metadata
 	response.Success(w, r, http.StatusOK, map[string]interface{}{
-		"data": result.Result.Data,
-		"meta": result.Result.Meta,
+		"data": data,
+		"meta": paginationMeta(w, r, result.Result.Meta),
 	})
 }
 
+// preloadIncludes batch-loads the included relations of activities and
+// returns the activities with them embedded, in the same order
+func (h *ActivityHandler) preloadIncludes(ctx context.Context, activities []*models.Activity, includes []query.Include) ([]query.Preloaded, error) {
+	ids := make([]int64, len(activities))
+	for i, activity := range activities {
+		ids[i] = activity.ID
+	}
+
+	relations, err := h.repo.LoadIncludes(ctx, ids, includes)
+	if err != nil {
+		return nil, err
+	}
+
+	preloaded := make([]query.Preloaded, len(activities))
+	for i, activity := range activities {
+		preloaded[i] = query.Preloaded{Resource: activity, Relations: relations[activity.ID]}
+	}
+	return preloaded, nil
+}
+
+// attachReactionCounts fills in ReactionCounts on activities. Counts are read
+// fresh rather than cached with the page, so reactions show up immediately;
+// on failure the page is served without them.
+func (h *ActivityHandler) attachReactionCounts(ctx context.Context, activities []*models.Activity) {
+	if h.reactionRepo == nil || len(activities) == 0 {
+		return
+	}
+
+	ids := make([]int64, len(activities))
+	for i, activity := range activities {
+		ids[i] = activity.ID
+	}
+	counts, err := h.reactionRepo.CountsByActivity(ctx, ids)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load reaction counts")
+		return
+	}
+	for _, activity := range activities {
+		activity.ReactionCounts = counts[activity.ID]
+	}
+}
+
+// attachTypeInfo fills in TypeInfo on activities from the user's type
+// registry. Activities of a type that is no longer registered are left
+// without it, as is the whole page if the registry can't be read.
+func (h *ActivityHandler) attachTypeInfo(ctx context.Context, userID int, activities []*models.Activity) {
+	if h.typeRepo == nil || len(activities) == 0 {
+		return
+	}
+
+	types, err := h.typeRepo.ListForUser(ctx, userID)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load activity types")
+		return
+	}
+	// The user's own types come last and win over a default of the same name
+	byName := make(map[string]*models.ActivityTypeInfo, len(types))
+	for _, activityType := range types {
+		byName[strings.ToLower(activityType.Name)] = activityType
+	}
+	for _, activity := range activities {
+		activity.TypeInfo = byName[strings.ToLower(activity.ActivityType)]
+	}
+}
+
+// attachThumbnailURLs fills in ThumbnailURL on activities that have a map
+// thumbnail. The URLs are signed and expire, so like reaction counts they
+// aren't cached with the page; on failure the page is served without them.
+func (h *ActivityHandler) attachThumbnailURLs(ctx context.Context, activities []*models.Activity) {
+	if h.trackRepo == nil || len(activities) == 0 {
+		return
+	}
+
+	ids := make([]int64, len(activities))
+	for i, activity := range activities {
+		ids[i] = activity.ID
+	}
+	keys, err := h.trackRepo.ThumbnailKeys(ctx, ids)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load map thumbnails")
+		return
+	}
+	now := h.clock.Now()
+	for _, activity := range activities {
+		if key, ok := keys[activity.ID]; ok {
+			activity.ThumbnailURL = fileurl.For(key, "", now)
+		}
+	}
+}
+
 // UpdateActivity handles activity updates using broker pattern
 // @Summary Update an activity
 // @Description Updates an existing activity by ID (partial update supported)
 // @Tags Activities
 // @Accept json
 // @Produce json
-// @Param id path int true "Activity ID"
+// @Param id path string true "Activity public ID or serial ID"
 // @Param request body models.UpdateActivityRequest true "Activity update request"
-// @Success 200 {object} models.Activity "Updated activity"
+// @Param dry_run query bool false "Validate and preview the result without saving (default: false)"
+// @Success 200 {object} models.Activity "Updated activity (wrapped in dryRunResponse when dry_run=true)"
 // @Failure 400 {object} map[string]interface{} "Validation error"
 // @Failure 401 {object} map[string]string "Unauthorized"
 // @Failure 500 {object} map[string]string "Internal server error"
@@ -347,14 +570,13 @@ func (h *ActivityHandler) UpdateActivity(w http.ResponseWriter, r *http.Request)
 	}
 
 	var req models.UpdateActivityRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Fail(w, r, http.StatusBadRequest, "Invalid JSON")
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
 	// Validate
 	if err := validator.Validate(&req); err != nil {
-		response.ValidationFail(w, r, validator.FormatValidationErrors(err))
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
 		return
 	}
 
@@ -368,6 +590,7 @@ func (h *ActivityHandler) UpdateActivity(w http.ResponseWriter, r *http.Request)
 			ActivityID: id,
 			Request:    &req,
 		},
+		brokerOptions(r)...,
 	)
 
 	if err != nil {
@@ -375,8 +598,7 @@ func (h *ActivityHandler) UpdateActivity(w http.ResponseWriter, r *http.Request)
 			response.Fail(w, r, http.StatusForbidden, "You do not own this activity")
 			return
 		}
-		if errors.Is(err, appErrors.ErrNotFound) {
-			response.Fail(w, r, http.StatusNotFound, "Activity not found")
+		if failValidationError(w, r, err) || failDBError(w, r, err, "Activity") {
 			return
 		}
 		log.Error().Err(err).Msg("Failed to update activity")
@@ -384,6 +606,12 @@ func (h *ActivityHandler) UpdateActivity(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if isDryRun(r) {
+		respondDryRun(w, r, result.Activity)
+		return
+	}
+
+	publishDomainEvent(ctx, h.eventBus, events.ActivityUpdated{UserID: requestUser.Id, Activity: result.Activity})
 	response.Success(w, r, http.StatusOK, result.Activity)
 }
 
@@ -391,8 +619,10 @@ func (h *ActivityHandler) UpdateActivity(w http.ResponseWriter, r *http.Request)
 // @Summary Delete an activity
 // @Description Deletes an activity by ID
 // @Tags Activities
-// @Param id path int true "Activity ID"
+// @Param id path string true "Activity public ID or serial ID"
+// @Param dry_run query bool false "Check the delete would succeed without applying it (default: false)"
 // @Success 204 "Activity deleted successfully"
+// @Success 200 {object} dryRunResponse "Dry-run preview"
 // @Failure 400 {object} map[string]string "Invalid activity ID"
 // @Failure 401 {object} map[string]string "Unauthorized"
 // @Failure 404 {object} map[string]string "Activity not found"
@@ -411,7 +641,7 @@ func (h *ActivityHandler) DeleteActivity(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Execute typed use case through broker
-	_, err = broker.RunUseCase(
+	result, err := broker.RunUseCase(
 		h.broker,
 		ctx,
 		h.deleteActivityUC,
@@ -419,6 +649,7 @@ func (h *ActivityHandler) DeleteActivity(w http.ResponseWriter, r *http.Request)
 			UserID:     requestUser.Id,
 			ActivityID: id,
 		},
+		brokerOptions(r)...,
 	)
 
 	if err != nil {
@@ -426,8 +657,7 @@ func (h *ActivityHandler) DeleteActivity(w http.ResponseWriter, r *http.Request)
 			response.Fail(w, r, http.StatusForbidden, "You do not own this activity")
 			return
 		}
-		if errors.Is(err, appErrors.ErrNotFound) {
-			response.Fail(w, r, http.StatusNotFound, "Activity not found")
+		if failDBError(w, r, err, "Activity") {
 			return
 		}
 		log.Error().Err(err).Int("id", id).Msg("Failed to delete activity")
@@ -435,6 +665,12 @@ func (h *ActivityHandler) DeleteActivity(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if isDryRun(r) {
+		respondDryRun(w, r, batchDeleteResult{ID: result.ActivityID, Success: result.Deleted})
+		return
+	}
+
+	publishDomainEvent(ctx, h.eventBus, events.ActivityDeleted{UserID: requestUser.Id, ActivityID: int64(result.ActivityID), PublicID: result.PublicID})
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -460,6 +696,8 @@ type batchDeleteResult struct {
 // @Accept json
 // @Produce json
 // @Param request body object true "Batch create request with activities array (max 50)"
+// @Param allow_duplicate query bool false "Skip duplicate detection (default: false)"
+// @Param dry_run query bool false "Validate and preview each item without saving (default: false)"
 // @Success 207 {array} batchActivityResult "Per-item results"
 // @Failure 400 {object} map[string]string "Invalid request"
 // @Failure 401 {object} map[string]string "Unauthorized"
@@ -472,8 +710,7 @@ func (h *ActivityHandler) BatchCreateActivities(w http.ResponseWriter, r *http.R
 	var req struct {
 		Activities []models.CreateActivityRequest `json:"activities"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Fail(w, r, http.StatusBadRequest, "Invalid request body")
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 	if len(req.Activities) == 0 || len(req.Activities) > 50 {
@@ -486,9 +723,12 @@ func (h *ActivityHandler) BatchCreateActivities(w http.ResponseWriter, r *http.R
 		req   models.CreateActivityRequest
 	}
 
+	allowDuplicate := r.URL.Query().Get("allow_duplicate") == "true"
+
 	pool := workers.New[job, batchActivityResult](5)
 	jobs := make([]job, len(req.Activities))
 	for i, a := range req.Activities {
+		a.Sanitize()
 		jobs[i] = job{index: i, req: a}
 	}
 
@@ -498,7 +738,8 @@ func (h *ActivityHandler) BatchCreateActivities(w http.ResponseWriter, r *http.R
 			h.broker,
 			ctx,
 			h.createActivityUC,
-			usecases.CreateActivityInput{UserID: requestUser.Id, Request: &a},
+			usecases.CreateActivityInput{UserID: requestUser.Id, Request: &a, AllowDuplicate: allowDuplicate},
+			brokerOptions(r)...,
 		)
 		if err != nil {
 			log.Error().Err(err).Int("index", j.index).Msg("BatchCreate item failed")
@@ -507,6 +748,11 @@ func (h *ActivityHandler) BatchCreateActivities(w http.ResponseWriter, r *http.R
 		return batchActivityResult{Index: j.index, Success: true, Activity: out.Activity}
 	})
 
+	if isDryRun(r) {
+		respondDryRun(w, r, results)
+		return
+	}
+
 	response.Success(w, r, http.StatusMultiStatus, results)
 }
 
@@ -517,6 +763,7 @@ func (h *ActivityHandler) BatchCreateActivities(w http.ResponseWriter, r *http.R
 // @Accept json
 // @Produce json
 // @Param request body object true "Batch delete request with ids array (max 50)"
+// @Param dry_run query bool false "Check each delete would succeed without applying it (default: false)"
 // @Success 207 {array} batchDeleteResult "Per-item results"
 // @Failure 400 {object} map[string]string "Invalid request"
 // @Failure 401 {object} map[string]string "Unauthorized"
@@ -529,8 +776,7 @@ func (h *ActivityHandler) BatchDeleteActivities(w http.ResponseWriter, r *http.R
 	var req struct {
 		IDs []int `json:"ids"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Fail(w, r, http.StatusBadRequest, "Invalid request body")
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 	if len(req.IDs) == 0 || len(req.IDs) > 50 {
@@ -554,6 +800,7 @@ func (h *ActivityHandler) BatchDeleteActivities(w http.ResponseWriter, r *http.R
 			ctx,
 			h.deleteActivityUC,
 			usecases.DeleteActivityInput{UserID: requestUser.Id, ActivityID: j.activityID},
+			brokerOptions(r)...,
 		)
 		if err != nil {
 			log.Error().Err(err).Int("activityID", j.activityID).Msg("BatchDelete item failed")
@@ -562,9 +809,235 @@ func (h *ActivityHandler) BatchDeleteActivities(w http.ResponseWriter, r *http.R
 		return batchDeleteResult{ID: j.activityID, Success: true}
 	})
 
+	if isDryRun(r) {
+		respondDryRun(w, r, results)
+		return
+	}
+
 	response.Success(w, r, http.StatusMultiStatus, results)
 }
 
+// bulkActivityFilter is the QueryOptions-style target of a filter-based bulk mutation.
+type bulkActivityFilter struct {
+	Filter           map[string]interface{}  `json:"filter"`
+	FilterConditions []query.FilterCondition `json:"filterConditions"`
+}
+
+// bulkUpdateActivitiesRequest is the body of PATCH /api/v1/activities.
+// Set reuses UpdateActivityRequest, so only its fields can be changed in bulk.
+type bulkUpdateActivitiesRequest struct {
+	bulkActivityFilter
+	Set models.UpdateActivityRequest `json:"set"`
+}
+
+// Sanitize cleans the free-text fields being set
+func (r *bulkUpdateActivitiesRequest) Sanitize() {
+	r.Set.Sanitize()
+}
+
+// bulkMutationResult is the response for filter-based bulk mutations.
+type bulkMutationResult struct {
+	Affected int64 `json:"affected"`
+}
+
+// bulkFilterColumns whitelists the activity columns a bulk mutation can target.
+// Relationship columns are excluded because bulk statements run without JOINs.
+var bulkFilterColumns = []string{
+	"id",
+	"activity_type",
+	"source",
+	"visibility",
+	"duration_minutes",
+	"distance_km",
+	"calories_burned",
+	"activity_date",
+	"created_at",
+	"updated_at",
+}
+
+var bulkFilterOperators = query.OperatorWhitelist{
+	"id":               query.StrictEqualityOnly(),
+	"activity_type":    query.EqualityOperators(),
+	"source":           query.EqualityOperators(),
+	"visibility":       query.EqualityOperators(),
+	"duration_minutes": query.ComparisonOperators(),
+	"distance_km":      query.ComparisonOperators(),
+	"calories_burned":  query.ComparisonOperators(),
+	"activity_date":    query.ComparisonOperators(),
+	"created_at":       query.ComparisonOperators(),
+	"updated_at":       query.ComparisonOperators(),
+}
+
+// toQueryOptions validates the bulk filter and converts it to QueryOptions.
+// An empty filter is rejected so a bare request can never target every activity.
+func (f bulkActivityFilter) toQueryOptions() (*query.QueryOptions, error) {
+	if len(f.Filter) == 0 && len(f.FilterConditions) == 0 {
+		return nil, fmt.Errorf("a filter is required for bulk operations")
+	}
+
+	opts := query.NewQueryOptions()
+	for column, value := range f.Filter {
+		opts.Filter[column] = value
+	}
+	opts.FilterConditions = f.FilterConditions
+
+	if err := query.ValidateQueryOptions(opts, bulkFilterColumns, nil, nil); err != nil {
+		return nil, err
+	}
+	if err := query.ValidateFilterConditions(opts, bulkFilterColumns, bulkFilterOperators); err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+// BulkUpdateActivities applies one partial update to every activity matched by a filter.
+// @Summary Bulk update activities by filter
+// @Description Updates all of the user's activities matching the filter in a single statement. Fails without changes if more than ACTIVITY_BULK_MAX_ROWS rows match.
+// @Tags Activities
+// @Accept json
+// @Produce json
+// @Param request body bulkUpdateActivitiesRequest true "Filter and fields to set"
+// @Param dry_run query bool false "Report how many activities would change without applying it (default: false)"
+// @Success 200 {object} bulkMutationResult "Number of activities updated"
+// @Failure 400 {object} map[string]interface{} "Invalid filter, fields or row limit exceeded"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Security BearerAuth
+// @Router /api/v1/activities [patch]
+func (h *ActivityHandler) BulkUpdateActivities(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestUser, _ := requestcontext.FromContext(ctx)
+
+	var req bulkUpdateActivitiesRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	opts, err := req.toQueryOptions()
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := validator.Validate(&req.Set); err != nil {
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
+		return
+	}
+
+	result, err := broker.RunUseCase(
+		h.broker,
+		ctx,
+		h.bulkUpdateUC,
+		usecases.BulkUpdateActivitiesInput{
+			UserID:       requestUser.Id,
+			QueryOptions: opts,
+			Request:      &req.Set,
+		},
+		brokerOptions(r)...,
+	)
+	if err != nil {
+		h.failBulkMutation(w, r, err, "Failed to update activities")
+		return
+	}
+
+	if isDryRun(r) {
+		respondDryRun(w, r, bulkMutationResult{Affected: result.Affected})
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, bulkMutationResult{Affected: result.Affected})
+}
+
+// BulkDeleteActivitiesByFilter soft-deletes every activity matched by a filter.
+// @Summary Bulk delete activities by filter
+// @Description Deletes all of the user's activities matching the filter in a single statement. Fails without changes if more than ACTIVITY_BULK_MAX_ROWS rows match.
+// @Tags Activities
+// @Accept json
+// @Produce json
+// @Param request body bulkActivityFilter true "Filter selecting the activities to delete"
+// @Param dry_run query bool false "Report how many activities would be deleted without applying it (default: false)"
+// @Success 200 {object} bulkMutationResult "Number of activities deleted"
+// @Failure 400 {object} map[string]interface{} "Invalid filter or row limit exceeded"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Security BearerAuth
+// @Router /api/v1/activities [delete]
+func (h *ActivityHandler) BulkDeleteActivitiesByFilter(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestUser, _ := requestcontext.FromContext(ctx)
+
+	var req bulkActivityFilter
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	opts, err := req.toQueryOptions()
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := broker.RunUseCase(
+		h.broker,
+		ctx,
+		h.bulkDeleteUC,
+		usecases.BulkDeleteActivitiesInput{
+			UserID:       requestUser.Id,
+			QueryOptions: opts,
+		},
+		brokerOptions(r)...,
+	)
+	if err != nil {
+		h.failBulkMutation(w, r, err, "Failed to delete activities")
+		return
+	}
+
+	if isDryRun(r) {
+		respondDryRun(w, r, bulkMutationResult{Affected: result.Affected})
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, bulkMutationResult{Affected: result.Affected})
+}
+
+// failBulkMutation maps bulk use case errors to responses
+func (h *ActivityHandler) failBulkMutation(w http.ResponseWriter, r *http.Request, err error, message string) {
+	var limitErr *appErrors.BulkLimitError
+	if errors.As(err, &limitErr) {
+		response.Fail(w, r, http.StatusBadRequest, fmt.Sprintf(
+			"filter matches %d activities, which exceeds the limit of %d", limitErr.Affected, limitErr.Limit,
+		))
+		return
+	}
+	if errors.Is(err, appErrors.ErrInvalidInput) {
+		response.Fail(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if failValidationError(w, r, err) {
+		return
+	}
+	log.Error().Err(err).Msg(message)
+	response.Fail(w, r, http.StatusInternalServerError, message)
+}
+
+// collectionETag derives a weak ETag for a list request from the matching rows
+// count and latest updated_at. The options themselves are part of the tag so
+// different pages, filters and formats never share a validator.
+// Returns false if the version query fails - the list is then served without an ETag.
+func (h *ActivityHandler) collectionETag(ctx context.Context, r *http.Request, format tabular.Format, opts *query.QueryOptions) (string, bool) {
+	version, err := h.repo.GetCollectionVersion(ctx, opts)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to compute activity list version")
+		return "", false
+	}
+
+	optsKey, err := json.Marshal(opts)
+	if err != nil {
+		return "", false
+	}
+
+	return middleware.WeakETag("activities", format, metaVersion(r), string(optsKey), version.Count, version.LastModified), true
+}
+
 // GetStats fetches activity statistics using broker pattern
 // @Summary Get activity statistics
 // @Description Returns aggregated statistics for the authenticated user's activities
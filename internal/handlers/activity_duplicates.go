@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+	"github.com/valentinesamuel/activelog/internal/application/activity/usecases"
+	"github.com/valentinesamuel/activelog/internal/application/broker"
+	"github.com/valentinesamuel/activelog/internal/events"
+	"github.com/valentinesamuel/activelog/internal/models"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/platform/validator"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// mergeActivitiesResult is the response of POST /api/v1/activities/merge
+type mergeActivitiesResult struct {
+	Activity *models.Activity `json:"activity"`
+	Merged   []int64          `json:"merged"`
+	Moved    struct {
+		Tags     int64 `json:"tags"`
+		Photos   int64 `json:"photos"`
+		Comments int64 `json:"comments"`
+	} `json:"moved"`
+}
+
+// ListDuplicates handles GET /api/v1/activities/duplicates
+// @Summary Find duplicate activities
+// @Description Groups the user's activities that look like the same workout, e.g. one recorded by a watch and one imported from a phone app: activities of the same type whose times overlap (within ACTIVITY_DUPLICATE_SLACK_MINUTES) and whose distances differ by at most ACTIVITY_DUPLICATE_DISTANCE_PCT percent. Clusters come newest first; keepId is the activity a merge keeps by default, the one that records the most.
+// @Tags Activities
+// @ID ListDuplicateActivities
+// @Produce json
+// @Success 200 {array} models.DuplicateCluster "Clusters of duplicate activities"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/activities/duplicates [get]
+func (h *ActivityHandler) ListDuplicates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	result, err := broker.RunUseCase(
+		h.broker,
+		ctx,
+		h.findDuplicatesUC,
+		usecases.FindDuplicateActivitiesInput{UserID: user.Id},
+	)
+	if err != nil {
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to find duplicate activities")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to find duplicate activities")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, result.Clusters)
+}
+
+// MergeActivities handles POST /api/v1/activities/merge
+// @Summary Merge duplicate activities
+// @Description Folds a cluster of duplicate activities (see GET /api/v1/activities/duplicates) into one. The activity kept is keepId, or else the one that records the most; the others' tags, photos and comments move to it, as do their splits and GPS track if it has none, and they are deleted. Everything happens in one transaction.
+// @Tags Activities
+// @ID MergeActivities
+// @Accept json
+// @Produce json
+// @Param request body models.MergeActivitiesRequest true "Activities to merge"
+// @Param dry_run query bool false "Check the merge would succeed without applying it (default: false)"
+// @Success 200 {object} mergeActivitiesResult "The activity kept, the IDs merged into it and what moved"
+// @Failure 400 {object} map[string]interface{} "Validation error"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "An activity was not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/activities/merge [post]
+func (h *ActivityHandler) MergeActivities(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	var req models.MergeActivitiesRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
+		return
+	}
+
+	result, err := broker.RunUseCase(
+		h.broker,
+		ctx,
+		h.mergeActivitiesUC,
+		usecases.MergeActivitiesInput{UserID: user.Id, Request: &req},
+		brokerOptions(r)...,
+	)
+	if err != nil {
+		if failValidationError(w, r, err) || failDBError(w, r, err, "Activity") {
+			return
+		}
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to merge activities")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to merge activities")
+		return
+	}
+
+	out := mergeActivitiesResult{Merged: make([]int64, len(result.Merged))}
+	for i, a := range result.Merged {
+		out.Merged[i] = a.ID
+	}
+	out.Moved.Tags, out.Moved.Photos, out.Moved.Comments = result.Tags, result.Photos, result.Comments
+
+	if isDryRun(r) {
+		respondDryRun(w, r, out)
+		return
+	}
+
+	kept, err := h.repo.GetByID(ctx, result.KeptID)
+	if err != nil {
+		log.Error().Err(err).Int64("activityID", result.KeptID).Msg("Failed to load merged activity")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to load merged activity")
+		return
+	}
+	out.Activity = kept
+
+	for _, a := range result.Merged {
+		publishDomainEvent(ctx, h.eventBus, events.ActivityDeleted{UserID: user.Id, ActivityID: a.ID, PublicID: a.PublicID})
+	}
+	publishDomainEvent(ctx, h.eventBus, events.ActivityUpdated{UserID: user.Id, Activity: kept})
+
+	response.Success(w, r, http.StatusOK, out)
+}
@@ -0,0 +1,117 @@
+package handlers_test
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/valentinesamuel/activelog/internal/application/activity/usecases"
+	"github.com/valentinesamuel/activelog/internal/application/broker"
+	"github.com/valentinesamuel/activelog/internal/handlers"
+	"github.com/valentinesamuel/activelog/internal/models"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/repository/mocks"
+	"github.com/valentinesamuel/activelog/internal/service"
+	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
+)
+
+// createdActivityService creates every activity as activity 101
+type createdActivityService struct {
+	service.ActivityServiceInterface
+}
+
+func (createdActivityService) CreateActivity(_ context.Context, _ repository.TxConn, userID int, req *models.CreateActivityRequest) (*models.Activity, error) {
+	return &models.Activity{
+		BaseEntity:   models.BaseEntity{ID: 101},
+		PublicID:     "01HZY3V5J6X7Q8R9S0T1V2W3X5",
+		UserID:       userID,
+		ActivityType: req.ActivityType,
+		Title:        req.Title,
+		ActivityDate: req.ActivityDate,
+	}, nil
+}
+
+func TestActivityHandler_CreateActivity_Duplicate(t *testing.T) {
+	const body = `{"activityType":"running","title":"Morning run","description":"Easy loop","durationMinutes":30,"distanceKm":5,"activityDate":"2026-05-01T07:00:00Z"}`
+	existing := &models.Activity{BaseEntity: models.BaseEntity{ID: 42}, PublicID: "01HZY3V5J6X7Q8R9S0T1V2W3X4"}
+
+	newHandler := func(t *testing.T) (*handlers.ActivityHandler, *mocks.MockActivityRepositoryInterface, sqlmock.Sqlmock) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			assert.NoError(t, mock.ExpectationsWereMet())
+			db.Close()
+		})
+		repo := mocks.NewMockActivityRepositoryInterface(gomock.NewController(t))
+		return handlers.NewActivityHandler(handlers.ActivityHandlerDeps{
+			Broker:           broker.NewBroker(db).WithLogger(log.New(io.Discard, "", 0)),
+			Repo:             repo,
+			CreateActivityUC: usecases.NewCreateActivityUseCase(createdActivityService{}, repo, 2*time.Minute),
+		}), repo, mock
+	}
+	request := func(target string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+		return req.WithContext(requestcontext.NewContext(req.Context(), &requestcontext.User{Id: 7}))
+	}
+
+	t.Run("409 pointing at the existing activity", func(t *testing.T) {
+		h, repo, mock := newHandler(t)
+		mock.ExpectBegin()
+		repo.EXPECT().FindDuplicate(gomock.Any(), gomock.Any(), gomock.Any(), 2*time.Minute).Return(existing, nil)
+		mock.ExpectRollback()
+
+		w := httptest.NewRecorder()
+		h.CreateActivity(w, request("/api/v1/activities"))
+
+		require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+		assert.Equal(t, "/api/v1/activities/01HZY3V5J6X7Q8R9S0T1V2W3X4", w.Header().Get("Location"))
+		assert.Contains(t, w.Body.String(), "Duplicate of existing activity 01HZY3V5J6X7Q8R9S0T1V2W3X4")
+	})
+
+	t.Run("allow_duplicate creates it anyway", func(t *testing.T) {
+		h, _, mock := newHandler(t)
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+
+		w := httptest.NewRecorder()
+		h.CreateActivity(w, request("/api/v1/activities?allow_duplicate=true"))
+
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), `"publicId":"01HZY3V5J6X7Q8R9S0T1V2W3X5"`)
+	})
+
+	t.Run("allow_duplicate must be true", func(t *testing.T) {
+		h, repo, mock := newHandler(t)
+		mock.ExpectBegin()
+		repo.EXPECT().FindDuplicate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(existing, nil)
+		mock.ExpectRollback()
+
+		w := httptest.NewRecorder()
+		h.CreateActivity(w, request("/api/v1/activities?allow_duplicate=yes"))
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("no duplicate", func(t *testing.T) {
+		h, repo, mock := newHandler(t)
+		mock.ExpectBegin()
+		repo.EXPECT().FindDuplicate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, appErrors.ErrNotFound)
+		mock.ExpectCommit()
+
+		w := httptest.NewRecorder()
+		h.CreateActivity(w, request("/api/v1/activities"))
+
+		assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	})
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/valentinesamuel/activelog/internal/models"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
+	"github.com/valentinesamuel/activelog/pkg/polyline"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// ActivityTrackHandler serves the GPS tracks of activities, simplified for
+// maps
+type ActivityTrackHandler struct {
+	activityRepo repository.ActivityRepositoryInterface
+	trackRepo    *repository.ActivityTrackRepository
+}
+
+// ActivityTrackHandlerDeps contains the dependencies for ActivityTrackHandler
+type ActivityTrackHandlerDeps struct {
+	ActivityRepo repository.ActivityRepositoryInterface
+	TrackRepo    *repository.ActivityTrackRepository
+}
+
+// NewActivityTrackHandler creates a new ActivityTrackHandler
+func NewActivityTrackHandler(deps ActivityTrackHandlerDeps) *ActivityTrackHandler {
+	return &ActivityTrackHandler{
+		activityRepo: deps.ActivityRepo,
+		trackRepo:    deps.TrackRepo,
+	}
+}
+
+// GetTrack handles GET /api/v1/activities/{id}/track
+// @Summary Get an activity's GPS track
+// @Description Returns the GPS track of an activity imported with a GPX file, simplified with the Douglas-Peucker algorithm so map thumbnails load fast. low keeps no point further than 25 m from the recorded track and med 5 m; full is the track as recorded. Each resolution is computed the first time it's asked for and stored (X-Cache-Status: MISS), then served as stored (HIT). The track comes as [lat, lng] pairs, or with polyline=true as one encoded polyline string. Only the activity's owner can see it.
+// @Tags Activities
+// @ID GetActivityTrack
+// @Produce json
+// @Param id path string true "Activity public ID or serial ID"
+// @Param resolution query string false "How far the track is simplified" Enums(low, med, full) default(low)
+// @Param polyline query bool false "Return the track as one encoded polyline string instead of [lat, lng] pairs (default: false)"
+// @Success 200 {object} models.ActivityTrack "The track at the resolution"
+// @Failure 400 {object} map[string]string "Invalid activity ID or resolution"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Activity not found or has no GPS track"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/activities/{id}/track [get]
+func (h *ActivityTrackHandler) GetTrack(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	resolution := models.TrackResolution(r.URL.Query().Get("resolution"))
+	if resolution == "" {
+		resolution = models.TrackResolutionLow
+	}
+	if !slices.Contains(models.TrackResolutions, resolution) {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid resolution: "+string(resolution)+" (low, med or full)")
+		return
+	}
+	encoded := r.URL.Query().Get("polyline") == "true"
+
+	activityID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid activity ID")
+		return
+	}
+
+	activity, err := h.activityRepo.GetByID(ctx, activityID)
+	if err == nil && (activity.UserID != user.Id || activity.DeletedAt != nil) {
+		err = appErrors.ErrNotFound
+	}
+	if err != nil {
+		if errors.Is(err, appErrors.ErrNotFound) {
+			response.Fail(w, r, http.StatusNotFound, "Activity not found")
+			return
+		}
+		log.Error().Err(err).Int64("activityID", activityID).Msg("Failed to fetch activity")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to fetch activity")
+		return
+	}
+
+	track, err := h.trackRepo.Get(ctx, activityID, resolution)
+	if err == nil {
+		w.Header().Set("X-Cache-Status", "HIT")
+	} else if errors.Is(err, appErrors.ErrNotFound) && resolution != models.TrackResolutionFull {
+		track, err = h.simplifyTrack(ctx, activityID, resolution)
+		w.Header().Set("X-Cache-Status", "MISS")
+	}
+	if err != nil {
+		if errors.Is(err, appErrors.ErrNotFound) {
+			response.Fail(w, r, http.StatusNotFound, "Activity has no GPS track")
+			return
+		}
+		log.Error().Err(err).Int64("activityID", activityID).Str("resolution", string(resolution)).Msg("Failed to fetch activity track")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to fetch activity track")
+		return
+	}
+
+	if !encoded {
+		points, err := polyline.Decode(track.Polyline)
+		if err != nil {
+			log.Error().Err(err).Int64("activityID", activityID).Msg("Stored activity track is not a valid polyline")
+			response.Fail(w, r, http.StatusInternalServerError, "Failed to fetch activity track")
+			return
+		}
+		track.Coordinates = make([][2]float64, len(points))
+		for i, p := range points {
+			track.Coordinates[i] = [2]float64{p.Lat, p.Lng}
+		}
+		track.Polyline = ""
+	}
+
+	response.Success(w, r, http.StatusOK, track)
+}
+
+// simplifyTrack computes the activity's track at resolution from the full
+// one and stores it, so it's only computed once
+func (h *ActivityTrackHandler) simplifyTrack(ctx context.Context, activityID int64, resolution models.TrackResolution) (*models.ActivityTrack, error) {
+	full, err := h.trackRepo.Get(ctx, activityID, models.TrackResolutionFull)
+	if err != nil {
+		return nil, err
+	}
+	points, err := polyline.Decode(full.Polyline)
+	if err != nil {
+		return nil, err
+	}
+
+	simplified := polyline.Simplify(points, resolution.ToleranceM())
+	track := &models.ActivityTrack{
+		ActivityID: activityID,
+		Resolution: resolution,
+		Points:     len(simplified),
+		Polyline:   polyline.Encode(simplified),
+	}
+	if err := h.trackRepo.Save(ctx, track); err != nil {
+		return nil, err
+	}
+	return track, nil
+}
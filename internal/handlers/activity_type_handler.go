@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/valentinesamuel/activelog/internal/application/activity/usecases"
+	"github.com/valentinesamuel/activelog/internal/application/broker"
+	"github.com/valentinesamuel/activelog/internal/models"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/platform/validator"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// ActivityTypeHandler serves the activity type registry: the default types,
+// the user's own types, and merging types into one
+type ActivityTypeHandler struct {
+	broker   *broker.Broker
+	typeRepo *repository.ActivityTypeRepository
+	mergeUC  *usecases.MergeActivityTypesUseCase
+}
+
+// ActivityTypeHandlerDeps contains the dependencies for ActivityTypeHandler.
+type ActivityTypeHandlerDeps struct {
+	Broker   *broker.Broker
+	TypeRepo *repository.ActivityTypeRepository
+	MergeUC  *usecases.MergeActivityTypesUseCase
+}
+
+// NewActivityTypeHandler creates a new ActivityTypeHandler with the given dependencies.
+func NewActivityTypeHandler(deps ActivityTypeHandlerDeps) *ActivityTypeHandler {
+	return &ActivityTypeHandler{
+		broker:   deps.Broker,
+		typeRepo: deps.TypeRepo,
+		mergeUC:  deps.MergeUC,
+	}
+}
+
+// mergeActivityTypesResult is the response of MergeActivityTypes
+type mergeActivityTypesResult struct {
+	Into     string `json:"into"`
+	Remapped int64  `json:"remapped"`
+}
+
+// ListActivityTypes handles GET /api/v1/activity-types
+// @Summary List activity types
+// @Description Returns the activity types the user can log: the defaults (without userId) followed by the user's own
+// @Tags ActivityTypes
+// @Produce json
+// @Success 200 {array} models.ActivityTypeInfo "Activity types"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/activity-types [get]
+func (h *ActivityTypeHandler) ListActivityTypes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	types, err := h.typeRepo.ListForUser(ctx, user.Id)
+	if err != nil {
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to list activity types")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to fetch activity types")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, types)
+}
+
+// CreateActivityType handles POST /api/v1/activity-types
+// @Summary Add an activity type
+// @Description Registers a type of the user's own. Names are stored in lower case and must not clash with a default type or another of the user's types.
+// @Tags ActivityTypes
+// @Accept json
+// @Produce json
+// @Param request body models.CreateActivityTypeRequest true "Activity type"
+// @Success 201 {object} models.ActivityTypeInfo "Added type"
+// @Failure 400 {object} map[string]interface{} "Validation error"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 409 {object} map[string]string "Type already exists"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/activity-types [post]
+func (h *ActivityTypeHandler) CreateActivityType(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	var req models.CreateActivityTypeRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
+		return
+	}
+
+	activityType := &models.ActivityTypeInfo{
+		UserID:      &user.Id,
+		Name:        strings.ToLower(strings.TrimSpace(req.Name)),
+		DisplayName: req.DisplayName,
+		Icon:        req.Icon,
+		Color:       req.Color,
+		Metadata:    req.Metadata,
+	}
+	if activityType.DisplayName == "" {
+		activityType.DisplayName = req.Name
+	}
+	if activityType.Metadata == nil {
+		activityType.Metadata = map[string]interface{}{}
+	}
+
+	// The unique indexes only cover the defaults and each user's own types
+	// separately, so a clash with a default is checked here
+	existing, err := h.typeRepo.Resolve(ctx, user.Id, activityType.Name)
+	if err != nil && !errors.Is(err, appErrors.ErrNotFound) {
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to look up activity type")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to add activity type")
+		return
+	}
+	if existing != nil {
+		response.Fail(w, r, http.StatusConflict, "Activity type already exists")
+		return
+	}
+
+	err = h.typeRepo.Create(ctx, activityType)
+	if failDBError(w, r, err, "Activity type") {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to add activity type")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to add activity type")
+		return
+	}
+
+	response.Success(w, r, http.StatusCreated, activityType)
+}
+
+// UpdateActivityType handles PATCH /api/v1/activity-types/{id}
+// @Summary Update an activity type
+// @Description Changes the display name, icon, color or metadata of one of the user's own types. Defaults can't be changed, and a type can't be renamed; merge it into another type instead.
+// @Tags ActivityTypes
+// @Accept json
+// @Produce json
+// @Param id path int true "Activity type ID"
+// @Param request body models.UpdateActivityTypeRequest true "Fields to change"
+// @Success 200 {object} models.ActivityTypeInfo "Updated type"
+// @Failure 400 {object} map[string]interface{} "Validation error"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Activity type not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/activity-types/{id} [patch]
+func (h *ActivityTypeHandler) UpdateActivityType(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid activity type ID")
+		return
+	}
+
+	var req models.UpdateActivityTypeRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
+		return
+	}
+
+	activityType, err := h.typeRepo.GetOwn(ctx, user.Id, id)
+	if failDBError(w, r, err, "Activity type") {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int64("activityTypeID", id).Msg("Failed to get activity type")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to update activity type")
+		return
+	}
+
+	if req.DisplayName != nil {
+		activityType.DisplayName = *req.DisplayName
+	}
+	if req.Icon != nil {
+		activityType.Icon = req.Icon
+	}
+	if req.Color != nil {
+		activityType.Color = req.Color
+	}
+	if req.Metadata != nil {
+		activityType.Metadata = req.Metadata
+	}
+
+	err = h.typeRepo.Update(ctx, activityType)
+	if failDBError(w, r, err, "Activity type") {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int64("activityTypeID", id).Msg("Failed to update activity type")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to update activity type")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, activityType)
+}
+
+// DeleteActivityType handles DELETE /api/v1/activity-types/{id}
+// @Summary Delete an activity type
+// @Description Removes one of the user's own types. Activities of the type keep it; merge the type into another to remap them.
+// @Tags ActivityTypes
+// @Param id path int true "Activity type ID"
+// @Success 204 "Deleted"
+// @Failure 400 {object} map[string]string "Invalid activity type ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Activity type not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/activity-types/{id} [delete]
+func (h *ActivityTypeHandler) DeleteActivityType(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid activity type ID")
+		return
+	}
+
+	err = h.typeRepo.Delete(ctx, user.Id, id)
+	if failDBError(w, r, err, "Activity type") {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int64("activityTypeID", id).Msg("Failed to delete activity type")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to delete activity type")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MergeActivityTypes handles POST /api/v1/activity-types/merge
+// @Summary Merge activity types
+// @Description Remaps the user's activities of the "from" types (matched case-insensitively) to the registered "into" type, and removes the user's own types among "from". Use it to clean up variants like "run" and "jog" into "running".
+// @Tags ActivityTypes
+// @Accept json
+// @Produce json
+// @Param request body models.MergeActivityTypesRequest true "Types to merge"
+// @Param dry_run query bool false "Count the activities that would be remapped without changing them (default: false)"
+// @Success 200 {object} mergeActivityTypesResult "Merge target and number of activities remapped"
+// @Failure 400 {object} map[string]interface{} "Validation error or unknown target type"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/activity-types/merge [post]
+func (h *ActivityTypeHandler) MergeActivityTypes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	var req models.MergeActivityTypesRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
+		return
+	}
+
+	result, err := broker.RunUseCase(
+		h.broker,
+		ctx,
+		h.mergeUC,
+		usecases.MergeActivityTypesInput{UserID: user.Id, Request: &req},
+		brokerOptions(r)...,
+	)
+	if err != nil {
+		if failValidationError(w, r, err) {
+			return
+		}
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to merge activity types")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to merge activity types")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, mergeActivityTypesResult{Into: result.Into, Remapped: result.Remapped})
+}
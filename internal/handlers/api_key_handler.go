@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/valentinesamuel/activelog/internal/models"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/platform/validator"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/pkg/auth"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// apiKeyPrefixLen is how much of a key is kept to tell keys apart: the
+// prefix and a few random characters
+const apiKeyPrefixLen = len(auth.APIKeyPrefix) + 6
+
+// APIKeyHandler serves the user's API keys: long-lived bearer tokens for
+// scripts and the CLI, which unlike JWTs don't expire until revoked
+type APIKeyHandler struct {
+	keyRepo *repository.APIKeyRepository
+}
+
+// APIKeyHandlerDeps contains the dependencies for APIKeyHandler.
+type APIKeyHandlerDeps struct {
+	KeyRepo *repository.APIKeyRepository
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler with the given dependencies.
+func NewAPIKeyHandler(deps APIKeyHandlerDeps) *APIKeyHandler {
+	return &APIKeyHandler{keyRepo: deps.KeyRepo}
+}
+
+// ListKeys handles GET /api/v1/users/me/api-keys
+// @Summary List my API keys
+// @Description Returns the user's API keys, newest first. The keys themselves are only shown when created; prefix tells them apart.
+// @Tags Users
+// @ID ListAPIKeys
+// @Produce json
+// @Success 200 {array} models.APIKey "API keys"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/users/me/api-keys [get]
+func (h *APIKeyHandler) ListKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	keys, err := h.keyRepo.ListByUser(ctx, user.Id)
+	if err != nil {
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to list API keys")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to list API keys")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, keys)
+}
+
+// CreateKey handles POST /api/v1/users/me/api-keys
+// @Summary Create an API key
+// @Description Creates a key for scripts and the CLI (activelog login -api-key). Send it as a bearer token in place of a JWT; it is valid until revoked. The key is only returned in this response.
+// @Tags Users
+// @ID CreateAPIKey
+// @Accept json
+// @Produce json
+// @Param request body models.CreateAPIKeyRequest true "API key"
+// @Success 201 {object} models.CreatedAPIKey "Created API key, with the key"
+// @Failure 400 {object} map[string]interface{} "Validation error"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/users/me/api-keys [post]
+func (h *APIKeyHandler) CreateKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	var req models.CreateAPIKeyRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
+		return
+	}
+
+	secret, err := auth.GenerateAPIKey()
+	if err != nil {
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to generate API key")
+		return
+	}
+
+	key := &models.APIKey{UserID: user.Id, Name: req.Name, Prefix: secret[:apiKeyPrefixLen]}
+	if err := h.keyRepo.Create(ctx, key, auth.HashAPIKey(secret)); err != nil {
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to create API key")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to create API key")
+		return
+	}
+
+	response.Success(w, r, http.StatusCreated, models.CreatedAPIKey{APIKey: key, Key: secret})
+}
+
+// DeleteKey handles DELETE /api/v1/users/me/api-keys/{id}
+// @Summary Revoke an API key
+// @Description Revokes one of the user's API keys; requests made with it fail from then on
+// @Tags Users
+// @ID DeleteAPIKey
+// @Param id path int true "API key ID"
+// @Success 204 "Revoked"
+// @Failure 400 {object} map[string]string "Invalid API key ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "API key not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/users/me/api-keys/{id} [delete]
+func (h *APIKeyHandler) DeleteKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid API key ID")
+		return
+	}
+
+	err = h.keyRepo.Delete(ctx, user.Id, id)
+	if failDBError(w, r, err, "API key") {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int64("keyID", id).Msg("Failed to delete API key")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to revoke API key")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,121 @@
+package handlers_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valentinesamuel/activelog/internal/handlers"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/repository/testhelpers"
+	"github.com/valentinesamuel/activelog/pkg/auth"
+)
+
+func newAPIKeyHandler(t *testing.T) (*handlers.APIKeyHandler, sqlmock.Sqlmock) {
+	db, mock := testhelpers.SetupMockDB(t)
+	return handlers.NewAPIKeyHandler(handlers.APIKeyHandlerDeps{KeyRepo: repository.NewAPIKeyRepository(db)}), mock
+}
+
+func apiKeyRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	return req.WithContext(requestcontext.NewContext(req.Context(), &requestcontext.User{Id: 7}))
+}
+
+// hashOf matches the hash of the API key the handler generates, capturing it
+type hashOf struct{ hash *string }
+
+func (h hashOf) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	*h.hash = s
+	return ok && len(s) == 64
+}
+
+func TestAPIKeyHandler_CreateKey(t *testing.T) {
+	t.Run("returns the key once and stores its hash", func(t *testing.T) {
+		h, mock := newAPIKeyHandler(t)
+		var stored string
+		mock.ExpectQuery(`INSERT INTO api_keys \(user_id, name, prefix, key_hash\)`).
+			WithArgs(7, "Laptop CLI", sqlmock.AnyArg(), hashOf{&stored}).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(3, time.Now()))
+
+		w := httptest.NewRecorder()
+		h.CreateKey(w, apiKeyRequest(http.MethodPost, "/api/v1/users/me/api-keys", `{"name":"Laptop CLI"}`))
+
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var body struct {
+			Result struct {
+				ID     int64  `json:"id"`
+				Name   string `json:"name"`
+				Prefix string `json:"prefix"`
+				Key    string `json:"key"`
+			}
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		created := body.Result
+		assert.Equal(t, int64(3), created.ID)
+		assert.True(t, auth.IsAPIKey(created.Key))
+		assert.True(t, strings.HasPrefix(created.Key, created.Prefix))
+		assert.Less(t, len(created.Prefix), len(created.Key))
+		assert.Equal(t, auth.HashAPIKey(created.Key), stored, "only the hash is stored")
+	})
+
+	t.Run("name is required", func(t *testing.T) {
+		h, _ := newAPIKeyHandler(t)
+
+		w := httptest.NewRecorder()
+		h.CreateKey(w, apiKeyRequest(http.MethodPost, "/api/v1/users/me/api-keys", `{}`))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAPIKeyHandler_ListKeys(t *testing.T) {
+	h, mock := newAPIKeyHandler(t)
+	mock.ExpectQuery(`FROM api_keys\s+WHERE user_id = \$1`).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "prefix", "created_at", "last_used_at"}).
+			AddRow(3, 7, "Laptop CLI", "alk_Ab12Cd", time.Now(), nil))
+
+	w := httptest.NewRecorder()
+	h.ListKeys(w, apiKeyRequest(http.MethodGet, "/api/v1/users/me/api-keys", ""))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"prefix":"alk_Ab12Cd"`)
+	assert.NotContains(t, w.Body.String(), `"key"`)
+}
+
+func TestAPIKeyHandler_DeleteKey(t *testing.T) {
+	t.Run("revoked", func(t *testing.T) {
+		h, mock := newAPIKeyHandler(t)
+		mock.ExpectExec(`DELETE FROM api_keys WHERE id = \$1 AND user_id = \$2`).
+			WithArgs(int64(3), 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		w := httptest.NewRecorder()
+		h.DeleteKey(w, mux.SetURLVars(apiKeyRequest(http.MethodDelete, "/api/v1/users/me/api-keys/3", ""), map[string]string{"id": "3"}))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("someone else's key", func(t *testing.T) {
+		h, mock := newAPIKeyHandler(t)
+		mock.ExpectExec(`DELETE FROM api_keys`).
+			WithArgs(int64(4), 7).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		w := httptest.NewRecorder()
+		h.DeleteKey(w, mux.SetURLVars(apiKeyRequest(http.MethodDelete, "/api/v1/users/me/api-keys/4", ""), map[string]string{"id": "4"}))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
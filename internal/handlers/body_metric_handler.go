@@ -0,0 +1,299 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/valentinesamuel/activelog/internal/models"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/platform/validator"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+	"github.com/valentinesamuel/activelog/pkg/query"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// BodyMetricHandler serves the user's daily body metrics: weight, resting
+// heart rate and sleep
+type BodyMetricHandler struct {
+	metricRepo *repository.BodyMetricRepository
+	validation *query.EntityValidation
+	clock      clock.Clock
+}
+
+// BodyMetricHandlerDeps contains the dependencies for BodyMetricHandler.
+type BodyMetricHandlerDeps struct {
+	MetricRepo *repository.BodyMetricRepository
+	Validation *query.EntityValidation // list query whitelist (see BodyMetricRepository.GetValidation)
+	Clock      clock.Clock             // decides which days are in the future; nil uses the real clock
+}
+
+// NewBodyMetricHandler creates a new BodyMetricHandler with the given dependencies.
+func NewBodyMetricHandler(deps BodyMetricHandlerDeps) *BodyMetricHandler {
+	clk := deps.Clock
+	if clk == nil {
+		clk = clock.Real{}
+	}
+	return &BodyMetricHandler{
+		metricRepo: deps.MetricRepo,
+		validation: deps.Validation,
+		clock:      clk,
+	}
+}
+
+// CreateMetric handles POST /api/v1/metrics
+// @Summary Log a body metric
+// @Description Logs a weight (kg), resting heart rate (bpm) or sleep (hours) measurement for a day. Each metric can be logged once per day; change an existing entry with PATCH.
+// @Tags Metrics
+// @Accept json
+// @Produce json
+// @Param request body models.CreateBodyMetricRequest true "Measurement"
+// @Success 201 {object} models.BodyMetric "Logged measurement"
+// @Failure 400 {object} map[string]interface{} "Validation error"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 409 {object} map[string]string "Already logged for that day"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/metrics [post]
+func (h *BodyMetricHandler) CreateMetric(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	var req models.CreateBodyMetricRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
+		return
+	}
+
+	recordedOn, _ := time.Parse(time.DateOnly, req.RecordedOn)
+	metric := &models.BodyMetric{
+		UserID:     user.Id,
+		MetricType: req.MetricType,
+		Value:      req.Value,
+		RecordedOn: recordedOn,
+		Notes:      req.Notes,
+	}
+	if !h.checkMetric(w, r, metric) {
+		return
+	}
+
+	err := h.metricRepo.Create(ctx, metric)
+	if failDBError(w, r, err, "Metric for that day") {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to log body metric")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to log metric")
+		return
+	}
+
+	response.Success(w, r, http.StatusCreated, metric)
+}
+
+// ListMetrics handles GET /api/v1/metrics
+// @Summary List body metrics
+// @Description Returns a paginated list of the user's measurements, newest first unless ordered otherwise
+// @Tags Metrics
+// @Produce json
+// @Param filter[metric_type] query string false "weight_kg, resting_hr or sleep_hours"
+// @Param filter[recorded_on][gte] query string false "Measurements on or after this day (YYYY-MM-DD)"
+// @Param filter[recorded_on][lte] query string false "Measurements on or before this day (YYYY-MM-DD)"
+// @Param filter[value][gte] query number false "Values at least this"
+// @Param order[recorded_on] query string false "Sort by day (ASC or DESC)"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 10, max: 100)"
+// @Param count query string false "How totalRecords is computed: exact (default), estimated, or none (skips counting)" Enums(exact, estimated, none)
+// @Success 200 {object} paginatedResponse{data=[]models.BodyMetric} "Paginated measurements"
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/metrics [get]
+func (h *BodyMetricHandler) ListMetrics(w http.ResponseWriter, r *http.Request) {
+	user, _ := requestcontext.FromContext(r.Context())
+
+	queryOpts, err := query.ParseQueryParamsWithFields(r.URL.Query(), h.validation.Fields())
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+
+	if err := h.validation.Validate(queryOpts); err != nil {
+		response.Fail(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Users only ever see their own measurements
+	queryOpts.Filter["user_id"] = user.Id
+	if len(queryOpts.Order) == 0 {
+		queryOpts.Order.Set("recorded_on", "DESC")
+	}
+
+	result, err := h.metricRepo.ListBodyMetricsWithQuery(r.Context(), queryOpts)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list body metrics")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to fetch metrics")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, map[string]interface{}{
+		"data": result.Data,
+		"meta": paginationMeta(w, r, result.Meta),
+	})
+}
+
+// GetMetric handles GET /api/v1/metrics/{id}
+// @Summary Get a body metric
+// @Tags Metrics
+// @Produce json
+// @Param id path int true "Metric ID"
+// @Success 200 {object} models.BodyMetric "Measurement"
+// @Failure 400 {object} map[string]string "Invalid metric ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Metric not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/metrics/{id} [get]
+func (h *BodyMetricHandler) GetMetric(w http.ResponseWriter, r *http.Request) {
+	metric, ok := h.loadMetric(w, r)
+	if !ok {
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, metric)
+}
+
+// UpdateMetric handles PATCH /api/v1/metrics/{id}
+// @Summary Update a body metric
+// @Description Changes the value, day or notes of a measurement. Its type can't be changed.
+// @Tags Metrics
+// @Accept json
+// @Produce json
+// @Param id path int true "Metric ID"
+// @Param request body models.UpdateBodyMetricRequest true "Fields to change"
+// @Success 200 {object} models.BodyMetric "Updated measurement"
+// @Failure 400 {object} map[string]interface{} "Validation error"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Metric not found"
+// @Failure 409 {object} map[string]string "Already logged for that day"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/metrics/{id} [patch]
+func (h *BodyMetricHandler) UpdateMetric(w http.ResponseWriter, r *http.Request) {
+	metric, ok := h.loadMetric(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateBodyMetricRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
+		return
+	}
+
+	if req.Value != nil {
+		metric.Value = *req.Value
+	}
+	if req.RecordedOn != nil {
+		metric.RecordedOn, _ = time.Parse(time.DateOnly, *req.RecordedOn)
+	}
+	if req.Notes != nil {
+		metric.Notes = req.Notes
+	}
+	if !h.checkMetric(w, r, metric) {
+		return
+	}
+
+	err := h.metricRepo.Update(r.Context(), metric)
+	if failDBError(w, r, err, "Metric for that day") {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int64("metricID", metric.ID).Msg("Failed to update body metric")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to update metric")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, metric)
+}
+
+// DeleteMetric handles DELETE /api/v1/metrics/{id}
+// @Summary Delete a body metric
+// @Tags Metrics
+// @Param id path int true "Metric ID"
+// @Success 204 "Deleted"
+// @Failure 400 {object} map[string]string "Invalid metric ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Metric not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/metrics/{id} [delete]
+func (h *BodyMetricHandler) DeleteMetric(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid metric ID")
+		return
+	}
+
+	err = h.metricRepo.Delete(ctx, user.Id, id)
+	if failDBError(w, r, err, "Metric") {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int64("metricID", id).Msg("Failed to delete body metric")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to delete metric")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// loadMetric fetches the user's measurement named by the {id} path variable,
+// writing the error response if it can't
+func (h *BodyMetricHandler) loadMetric(w http.ResponseWriter, r *http.Request) (*models.BodyMetric, bool) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid metric ID")
+		return nil, false
+	}
+
+	metric, err := h.metricRepo.GetByID(ctx, user.Id, id)
+	if failDBError(w, r, err, "Metric") {
+		return nil, false
+	}
+	if err != nil {
+		log.Error().Err(err).Int64("metricID", id).Msg("Failed to get body metric")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to get metric")
+		return nil, false
+	}
+	return metric, true
+}
+
+// checkMetric rejects implausible values and days in the future
+func (h *BodyMetricHandler) checkMetric(w http.ResponseWriter, r *http.Request, metric *models.BodyMetric) bool {
+	if err := metric.CheckValue(); err != nil {
+		response.Fail(w, r, http.StatusBadRequest, err.Error())
+		return false
+	}
+	// Allow "today" in any timezone ahead of UTC
+	if metric.RecordedOn.After(h.clock.Now().UTC().AddDate(0, 0, 1)) {
+		response.Fail(w, r, http.StatusBadRequest, "recorded_on cannot be in the future")
+		return false
+	}
+	return true
+}
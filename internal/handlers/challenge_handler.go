@@ -0,0 +1,400 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/valentinesamuel/activelog/internal/models"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/platform/validator"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+	"github.com/valentinesamuel/activelog/pkg/query"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// ChallengeHandler serves time-boxed challenges: creating and joining them,
+// their leaderboards and the badges awarded for completing them. Progress is
+// computed by the refresh_challenge_progress job.
+type ChallengeHandler struct {
+	challengeRepo *repository.ChallengeRepository
+	validation    *query.EntityValidation
+	clock         clock.Clock
+}
+
+// ChallengeHandlerDeps contains the dependencies for ChallengeHandler.
+type ChallengeHandlerDeps struct {
+	ChallengeRepo *repository.ChallengeRepository
+	Validation    *query.EntityValidation // list query whitelist (see ChallengeRepository.GetValidation)
+	Clock         clock.Clock             // decides which challenges are over; nil uses the real clock
+}
+
+// NewChallengeHandler creates a new ChallengeHandler with the given dependencies.
+func NewChallengeHandler(deps ChallengeHandlerDeps) *ChallengeHandler {
+	clk := deps.Clock
+	if clk == nil {
+		clk = clock.Real{}
+	}
+	return &ChallengeHandler{
+		challengeRepo: deps.ChallengeRepo,
+		validation:    deps.Validation,
+		clock:         clk,
+	}
+}
+
+// CreateChallenge handles POST /api/v1/challenges
+// @Summary Create a challenge
+// @Description Creates a time-boxed challenge, e.g. 100 km in March: a target for distance_km, duration_minutes or the number of activities logged between starts_on and ends_on (inclusive). Set activity_type to count one type only. The creator joins it like anyone else.
+// @Tags Challenges
+// @Accept json
+// @Produce json
+// @Param request body models.CreateChallengeRequest true "Challenge"
+// @Success 201 {object} models.Challenge "Created challenge"
+// @Failure 400 {object} map[string]interface{} "Validation error"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/challenges [post]
+func (h *ChallengeHandler) CreateChallenge(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	var req models.CreateChallengeRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
+		return
+	}
+
+	startsOn, _ := time.Parse(time.DateOnly, req.StartsOn)
+	endsOn, _ := time.Parse(time.DateOnly, req.EndsOn)
+	if endsOn.Before(startsOn) {
+		response.Fail(w, r, http.StatusBadRequest, "ends_on must not be before starts_on")
+		return
+	}
+	if h.hasEnded(endsOn) {
+		response.Fail(w, r, http.StatusBadRequest, "ends_on must not be in the past")
+		return
+	}
+
+	challenge := &models.Challenge{
+		CreatorID:    user.Id,
+		Name:         req.Name,
+		Description:  req.Description,
+		ActivityType: req.ActivityType,
+		Metric:       req.Metric,
+		Target:       req.Target,
+		StartsOn:     startsOn,
+		EndsOn:       endsOn,
+	}
+	if err := h.challengeRepo.Create(ctx, challenge); err != nil {
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to create challenge")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to create challenge")
+		return
+	}
+
+	response.Success(w, r, http.StatusCreated, challenge)
+}
+
+// ListChallenges handles GET /api/v1/challenges
+// @Summary List challenges
+// @Description Returns a paginated list of challenges, latest start first unless ordered otherwise. Filter on ends_on to list the running ones, e.g. filter[ends_on][gte]=2026-03-15.
+// @Tags Challenges
+// @Produce json
+// @Param filter[metric] query string false "distance_km, duration_minutes or activities"
+// @Param filter[activity_type] query string false "Challenges for this activity type"
+// @Param filter[ends_on][gte] query string false "Challenges ending on or after this day (YYYY-MM-DD)"
+// @Param order[starts_on] query string false "Sort by start (ASC or DESC)"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 10, max: 100)"
+// @Success 200 {object} paginatedResponse{data=[]models.Challenge} "Paginated challenges"
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/challenges [get]
+func (h *ChallengeHandler) ListChallenges(w http.ResponseWriter, r *http.Request) {
+	queryOpts, err := query.ParseQueryParamsWithFields(r.URL.Query(), h.validation.Fields())
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+
+	if err := h.validation.Validate(queryOpts); err != nil {
+		response.Fail(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if len(queryOpts.Order) == 0 {
+		queryOpts.Order.Set("starts_on", "DESC")
+	}
+
+	result, err := h.challengeRepo.ListChallengesWithQuery(r.Context(), queryOpts)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list challenges")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to fetch challenges")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, map[string]interface{}{
+		"data": result.Data,
+		"meta": paginationMeta(w, r, result.Meta),
+	})
+}
+
+// GetChallenge handles GET /api/v1/challenges/{id}
+// @Summary Get a challenge
+// @Description Returns the challenge with its number of participants and, when the caller joined it, their progress
+// @Tags Challenges
+// @Produce json
+// @Param id path int true "Challenge ID"
+// @Success 200 {object} models.ChallengeDetail "Challenge"
+// @Failure 400 {object} map[string]string "Invalid challenge ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Challenge not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/challenges/{id} [get]
+func (h *ChallengeHandler) GetChallenge(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	id, ok := parseChallengeID(w, r)
+	if !ok {
+		return
+	}
+
+	detail, err := h.challengeRepo.GetDetail(ctx, id, user.Id)
+	if failDBError(w, r, err, "Challenge") {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int64("challengeID", id).Msg("Failed to fetch challenge")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to fetch challenge")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, detail)
+}
+
+// DeleteChallenge handles DELETE /api/v1/challenges/{id}
+// @Summary Delete a challenge
+// @Description Deletes a challenge the caller created, with its participants. Badges already awarded for it are kept.
+// @Tags Challenges
+// @Param id path int true "Challenge ID"
+// @Success 204 "Deleted"
+// @Failure 400 {object} map[string]string "Invalid challenge ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Not a challenge the caller created"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/challenges/{id} [delete]
+func (h *ChallengeHandler) DeleteChallenge(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	id, ok := parseChallengeID(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.challengeRepo.Delete(ctx, user.Id, id)
+	if failDBError(w, r, err, "Challenge") {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int64("challengeID", id).Msg("Failed to delete challenge")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to delete challenge")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// JoinChallenge handles POST /api/v1/challenges/{id}/join
+// @Summary Join a challenge
+// @Description Joins the caller to a challenge that hasn't ended. Their progress starts from the activities they already logged in its date range.
+// @Tags Challenges
+// @Produce json
+// @Param id path int true "Challenge ID"
+// @Success 201 {object} models.ChallengeParticipant "Participation"
+// @Failure 400 {object} map[string]string "Invalid challenge ID, or the challenge has ended"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Challenge not found"
+// @Failure 409 {object} map[string]string "Already joined"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/challenges/{id}/join [post]
+func (h *ChallengeHandler) JoinChallenge(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	id, ok := parseChallengeID(w, r)
+	if !ok {
+		return
+	}
+
+	challenge, err := h.challengeRepo.GetByID(ctx, id)
+	if failDBError(w, r, err, "Challenge") {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int64("challengeID", id).Msg("Failed to fetch challenge")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to join challenge")
+		return
+	}
+	if h.hasEnded(challenge.EndsOn) {
+		response.Fail(w, r, http.StatusBadRequest, "Challenge has ended")
+		return
+	}
+
+	participant, err := h.challengeRepo.Join(ctx, id, user.Id)
+	if failDBError(w, r, err, "Participation") {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int64("challengeID", id).Msg("Failed to join challenge")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to join challenge")
+		return
+	}
+
+	response.Success(w, r, http.StatusCreated, participant)
+}
+
+// LeaveChallenge handles POST /api/v1/challenges/{id}/leave
+// @Summary Leave a challenge
+// @Description Removes the caller from a challenge. A badge already awarded for it is kept.
+// @Tags Challenges
+// @Param id path int true "Challenge ID"
+// @Success 204 "Left"
+// @Failure 400 {object} map[string]string "Invalid challenge ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Not a participant"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/challenges/{id}/leave [post]
+func (h *ChallengeHandler) LeaveChallenge(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	id, ok := parseChallengeID(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.challengeRepo.Leave(ctx, id, user.Id)
+	if failDBError(w, r, err, "Participation") {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int64("challengeID", id).Msg("Failed to leave challenge")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to leave challenge")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetLeaderboard handles GET /api/v1/challenges/{id}/leaderboard
+// @Summary Get a challenge leaderboard
+// @Description Ranks the participants by progress. Participants with equal progress share a rank, listed by who completed first.
+// @Tags Challenges
+// @Produce json
+// @Param id path int true "Challenge ID"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 10, max: 100)"
+// @Success 200 {object} paginatedResponse{data=[]models.ChallengeStanding} "Paginated leaderboard with metadata"
+// @Failure 400 {object} map[string]string "Invalid challenge ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Challenge not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @ID GetChallengeLeaderboard
+// @Router /api/v1/challenges/{id}/leaderboard [get]
+func (h *ChallengeHandler) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, ok := parseChallengeID(w, r)
+	if !ok {
+		return
+	}
+
+	_, err := h.challengeRepo.GetByID(ctx, id)
+	if failDBError(w, r, err, "Challenge") {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int64("challengeID", id).Msg("Failed to fetch challenge")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to get leaderboard")
+		return
+	}
+
+	params := r.URL.Query()
+	page, limit := 1, 10
+	if v, err := strconv.Atoi(params.Get("page")); err == nil && v > 0 {
+		page = v
+	}
+	if v, err := strconv.Atoi(params.Get("limit")); err == nil && v > 0 {
+		if v > 100 {
+			v = 100
+		}
+		limit = v
+	}
+
+	result, err := h.challengeRepo.GetLeaderboard(ctx, id, page, limit)
+	if err != nil {
+		log.Error().Err(err).Int64("challengeID", id).Msg("Failed to get challenge leaderboard")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to get leaderboard")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, map[string]interface{}{
+		"data": result.Data,
+		"meta": paginationMeta(w, r, result.Meta),
+	})
+}
+
+// ListBadges handles GET /api/v1/users/me/badges
+// @Summary List my badges
+// @Description Returns the badges the caller was awarded for completing challenges, newest first
+// @Tags Challenges
+// @Produce json
+// @Success 200 {array} models.Badge "Badges"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/users/me/badges [get]
+func (h *ChallengeHandler) ListBadges(w http.ResponseWriter, r *http.Request) {
+	user, _ := requestcontext.FromContext(r.Context())
+
+	badges, err := h.challengeRepo.ListBadges(r.Context(), user.Id)
+	if err != nil {
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to list badges")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to fetch badges")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, badges)
+}
+
+// hasEnded reports whether a challenge ending on endsOn is over today (UTC)
+func (h *ChallengeHandler) hasEnded(endsOn time.Time) bool {
+	today := h.clock.Now().UTC().Format(time.DateOnly)
+	return endsOn.Format(time.DateOnly) < today
+}
+
+// parseChallengeID parses the {id} path variable, writing a 400 when it
+// isn't an ID
+func parseChallengeID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid challenge ID")
+		return 0, false
+	}
+	return id, true
+}
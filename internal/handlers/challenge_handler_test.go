@@ -0,0 +1,214 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valentinesamuel/activelog/internal/handlers"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/repository/testhelpers"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+)
+
+var challengeRows = []string{"id", "creator_id", "name", "description", "activity_type", "metric", "target",
+	"starts_on", "ends_on", "created_at", "updated_at"}
+
+// newChallengeHandler returns a handler whose repository reads mock and
+// whose clock is stopped at noon on 2026-03-15
+func newChallengeHandler(t *testing.T) (*handlers.ChallengeHandler, sqlmock.Sqlmock) {
+	db, mock := testhelpers.SetupMockDB(t)
+	h := handlers.NewChallengeHandler(handlers.ChallengeHandlerDeps{
+		ChallengeRepo: repository.NewChallengeRepository(db),
+		Clock:         clock.NewFake(time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)),
+	})
+	return h, mock
+}
+
+// expectChallenge expects challenge 4 running March 2026 up to endsOn
+func expectChallenge(mock sqlmock.Sqlmock, endsOn time.Time) {
+	now := time.Now()
+	mock.ExpectQuery(`FROM challenges WHERE id = \$1`).
+		WithArgs(int64(4)).
+		WillReturnRows(sqlmock.NewRows(challengeRows).AddRow(
+			4, 1, "100 km in March", nil, nil, "distance_km", 100.0,
+			time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), endsOn, now, now))
+}
+
+func challengeRequest(method, target string) *http.Request {
+	req := httptest.NewRequest(method, target, nil)
+	req = req.WithContext(requestcontext.NewContext(req.Context(), &requestcontext.User{Id: 7}))
+	return mux.SetURLVars(req, map[string]string{"id": "4"})
+}
+
+func TestChallengeHandler_JoinChallenge(t *testing.T) {
+	marchEnd := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("joins with the progress so far", func(t *testing.T) {
+		h, mock := newChallengeHandler(t)
+		expectChallenge(mock, marchEnd)
+		mock.ExpectExec(`INSERT INTO challenge_participants`).
+			WithArgs(int64(4), 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE challenge_participants cp`).
+			WithArgs(int64(4), 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`FROM challenge_participants\s+WHERE challenge_id = \$1 AND user_id = \$2`).
+			WithArgs(int64(4), 7).
+			WillReturnRows(sqlmock.NewRows([]string{"challenge_id", "user_id", "progress", "joined_at", "completed_at"}).
+				AddRow(4, 7, 12.5, time.Now(), nil))
+
+		w := httptest.NewRecorder()
+		h.JoinChallenge(w, challengeRequest(http.MethodPost, "/api/v1/challenges/4/join"))
+
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), `"progress":12.5`)
+	})
+
+	t.Run("joining twice conflicts", func(t *testing.T) {
+		h, mock := newChallengeHandler(t)
+		expectChallenge(mock, marchEnd)
+		mock.ExpectExec(`INSERT INTO challenge_participants`).
+			WithArgs(int64(4), 7).
+			WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "challenge_participants_pkey"})
+
+		w := httptest.NewRecorder()
+		h.JoinChallenge(w, challengeRequest(http.MethodPost, "/api/v1/challenges/4/join"))
+
+		assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	})
+
+	t.Run("challenge ending today can still be joined", func(t *testing.T) {
+		h, mock := newChallengeHandler(t)
+		expectChallenge(mock, time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC))
+		mock.ExpectExec(`INSERT INTO challenge_participants`).
+			WillReturnError(&pgconn.PgError{Code: "23505"})
+
+		w := httptest.NewRecorder()
+		h.JoinChallenge(w, challengeRequest(http.MethodPost, "/api/v1/challenges/4/join"))
+
+		assert.Equal(t, http.StatusConflict, w.Code, "the join was attempted")
+	})
+
+	t.Run("challenge has ended", func(t *testing.T) {
+		h, mock := newChallengeHandler(t)
+		// Nothing is inserted: the mock fails on an unexpected INSERT
+		expectChallenge(mock, time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC))
+
+		w := httptest.NewRecorder()
+		h.JoinChallenge(w, challengeRequest(http.MethodPost, "/api/v1/challenges/4/join"))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Challenge has ended")
+	})
+
+	t.Run("unknown challenge", func(t *testing.T) {
+		h, mock := newChallengeHandler(t)
+		mock.ExpectQuery(`FROM challenges WHERE id = \$1`).
+			WithArgs(int64(4)).
+			WillReturnRows(sqlmock.NewRows(challengeRows))
+
+		w := httptest.NewRecorder()
+		h.JoinChallenge(w, challengeRequest(http.MethodPost, "/api/v1/challenges/4/join"))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestChallengeHandler_LeaveChallenge(t *testing.T) {
+	t.Run("leaves", func(t *testing.T) {
+		h, mock := newChallengeHandler(t)
+		mock.ExpectExec(`DELETE FROM challenge_participants WHERE challenge_id = \$1 AND user_id = \$2`).
+			WithArgs(int64(4), 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		w := httptest.NewRecorder()
+		h.LeaveChallenge(w, challengeRequest(http.MethodPost, "/api/v1/challenges/4/leave"))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("not a participant", func(t *testing.T) {
+		h, mock := newChallengeHandler(t)
+		mock.ExpectExec(`DELETE FROM challenge_participants`).
+			WithArgs(int64(4), 7).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		w := httptest.NewRecorder()
+		h.LeaveChallenge(w, challengeRequest(http.MethodPost, "/api/v1/challenges/4/leave"))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestChallengeHandler_GetLeaderboard(t *testing.T) {
+	standingRows := []string{"rank", "user_id", "username", "progress", "progress_pct", "completed_at", "total_records"}
+	type leaderboardBody struct {
+		Data []struct {
+			Rank     int    `json:"rank"`
+			UserID   int    `json:"user_id"`
+			Username string `json:"username"`
+		} `json:"data"`
+		Meta struct {
+			Page         int         `json:"page"`
+			Limit        int         `json:"limit"`
+			Count        int         `json:"count"`
+			PageCount    int         `json:"pageCount"`
+			TotalRecords int         `json:"totalRecords"`
+			PreviousPage interface{} `json:"previousPage"`
+			NextPage     interface{} `json:"nextPage"`
+		} `json:"meta"`
+	}
+
+	t.Run("pages with ranks from the whole leaderboard", func(t *testing.T) {
+		h, mock := newChallengeHandler(t)
+		expectChallenge(mock, time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC))
+		// Page 2 of 3: the tie for second place spans the page boundary
+		mock.ExpectQuery(`RANK\(\) OVER \(ORDER BY p.progress DESC\)`).
+			WithArgs(int64(4), 2, 2).
+			WillReturnRows(sqlmock.NewRows(standingRows).
+				AddRow(2, 9, "carol", 80.0, 80.0, nil, 5).
+				AddRow(4, 11, "erin", 40.0, 40.0, nil, 5))
+
+		w := httptest.NewRecorder()
+		h.GetLeaderboard(w, challengeRequest(http.MethodGet, "/api/v1/challenges/4/leaderboard?page=2&limit=2"))
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var body struct{ Result leaderboardBody }
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		lb := body.Result
+
+		require.Len(t, lb.Data, 2)
+		assert.Equal(t, 2, lb.Data[0].Rank, "tied with the last of page 1")
+		assert.Equal(t, "carol", lb.Data[0].Username)
+		assert.Equal(t, 4, lb.Data[1].Rank)
+		assert.Equal(t, 2, lb.Meta.Page)
+		assert.Equal(t, 2, lb.Meta.Limit)
+		assert.Equal(t, 5, lb.Meta.TotalRecords)
+		assert.Equal(t, 3, lb.Meta.PageCount)
+		assert.Equal(t, float64(1), lb.Meta.PreviousPage)
+		assert.Equal(t, float64(3), lb.Meta.NextPage)
+	})
+
+	t.Run("limit is capped at 100", func(t *testing.T) {
+		h, mock := newChallengeHandler(t)
+		expectChallenge(mock, time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC))
+		mock.ExpectQuery(`RANK\(\) OVER`).
+			WithArgs(int64(4), 100, 0).
+			WillReturnRows(sqlmock.NewRows(standingRows))
+
+		w := httptest.NewRecorder()
+		h.GetLeaderboard(w, challengeRequest(http.MethodGet, "/api/v1/challenges/4/leaderboard?limit=500"))
+
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+}
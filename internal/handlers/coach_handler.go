@@ -0,0 +1,484 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/valentinesamuel/activelog/internal/models"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/platform/validator"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
+	"github.com/valentinesamuel/activelog/pkg/query"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// CoachHandler serves coach access: athletes inviting coaches and scoping
+// what they see, coaches accepting and dropping athletes, and the coach's
+// views of their athletes' data. Routes under /coaching/athletes/{athleteId}
+// run behind middleware.RequireCoachAccess, so these handlers don't check
+// grants themselves.
+type CoachHandler struct {
+	coachRepo    *repository.CoachRepository
+	commentRepo  *repository.CommentRepository
+	activityRepo repository.ActivityRepositoryInterface
+	statsRepo    repository.StatsRepositoryInterface
+	zoneRepo     *repository.PrivacyZoneRepository
+	validation   *query.EntityValidation
+	clock        clock.Clock
+}
+
+// CoachHandlerDeps contains the dependencies for CoachHandler.
+type CoachHandlerDeps struct {
+	CoachRepo    *repository.CoachRepository
+	CommentRepo  *repository.CommentRepository
+	ActivityRepo repository.ActivityRepositoryInterface
+	StatsRepo    repository.StatsRepositoryInterface
+	ZoneRepo     *repository.PrivacyZoneRepository // athletes' privacy zones
+	Validation   *query.EntityValidation           // activities list query whitelist (see ActivityRepository.GetValidation)
+	Clock        clock.Clock                       // decides which day the dashboard ends on; nil uses the real clock
+}
+
+// NewCoachHandler creates a new CoachHandler with the given dependencies.
+func NewCoachHandler(deps CoachHandlerDeps) *CoachHandler {
+	clk := deps.Clock
+	if clk == nil {
+		clk = clock.Real{}
+	}
+	return &CoachHandler{
+		coachRepo:    deps.CoachRepo,
+		commentRepo:  deps.CommentRepo,
+		activityRepo: deps.ActivityRepo,
+		statsRepo:    deps.StatsRepo,
+		zoneRepo:     deps.ZoneRepo,
+		validation:   deps.Validation,
+		clock:        clk,
+	}
+}
+
+// InviteCoach handles POST /api/v1/coaching/coaches
+// @Summary Invite a coach
+// @Description Invites a user to coach the caller. Once the coach accepts, they can read the caller's activities and stats, and comment on activities when can_comment is set.
+// @Tags Coaching
+// @Accept json
+// @Produce json
+// @Param request body models.InviteCoachRequest true "Coach and scopes"
+// @Success 201 {object} models.CoachAccess "Pending invitation"
+// @Failure 400 {object} map[string]interface{} "Validation error, or the coach does not exist"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 409 {object} map[string]string "Coach already invited"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/coaching/coaches [post]
+func (h *CoachHandler) InviteCoach(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	var req models.InviteCoachRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
+		return
+	}
+	if req.CoachID == user.Id {
+		response.Fail(w, r, http.StatusBadRequest, "You cannot coach yourself")
+		return
+	}
+
+	access, err := h.coachRepo.Invite(ctx, user.Id, req.CoachID, req.CanComment)
+	if err != nil {
+		if failDBError(w, r, err, "Coach access") {
+			return
+		}
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to invite coach")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to invite coach")
+		return
+	}
+
+	response.Success(w, r, http.StatusCreated, access)
+}
+
+// ListCoaches handles GET /api/v1/coaching/coaches
+// @Summary List my coaches
+// @Description Returns the coaches the caller granted access to, pending invitations included
+// @Tags Coaching
+// @Produce json
+// @Success 200 {array} models.CoachAccess "Coach access grants"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/coaching/coaches [get]
+func (h *CoachHandler) ListCoaches(w http.ResponseWriter, r *http.Request) {
+	user, _ := requestcontext.FromContext(r.Context())
+
+	coaches, err := h.coachRepo.ListCoaches(r.Context(), user.Id)
+	if err != nil {
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to list coaches")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to fetch coaches")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, coaches)
+}
+
+// UpdateCoach handles PATCH /api/v1/coaching/coaches/{coachId}
+// @Summary Change a coach's scopes
+// @Description Grants or withdraws the coach's permission to comment on the caller's activities. Read access comes with every grant.
+// @Tags Coaching
+// @Accept json
+// @Produce json
+// @Param coachId path string true "Coach user ID or public ID"
+// @Param request body models.UpdateCoachAccessRequest true "Scopes"
+// @Success 200 {object} models.CoachAccess "Updated grant"
+// @Failure 400 {object} map[string]interface{} "Validation error"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Not the caller's coach"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/coaching/coaches/{coachId} [patch]
+func (h *CoachHandler) UpdateCoach(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	coachID, ok := parseUserVar(w, r, "coachId")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateCoachAccessRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
+		return
+	}
+
+	if err := h.coachRepo.SetCanComment(ctx, user.Id, coachID, *req.CanComment); err != nil {
+		if failDBError(w, r, err, "Coach") {
+			return
+		}
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to update coach access")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to update coach access")
+		return
+	}
+
+	access, err := h.coachRepo.Get(ctx, user.Id, coachID)
+	if err != nil {
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to fetch coach access")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to update coach access")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, access)
+}
+
+// RevokeCoach handles DELETE /api/v1/coaching/coaches/{coachId}
+// @Summary Revoke a coach
+// @Description Ends the coach's access to the caller's data, or withdraws a pending invitation
+// @Tags Coaching
+// @Param coachId path string true "Coach user ID or public ID"
+// @Success 204 "Revoked"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Not the caller's coach"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/coaching/coaches/{coachId} [delete]
+func (h *CoachHandler) RevokeCoach(w http.ResponseWriter, r *http.Request) {
+	user, _ := requestcontext.FromContext(r.Context())
+
+	coachID, ok := parseUserVar(w, r, "coachId")
+	if !ok {
+		return
+	}
+	h.revoke(w, r, user.Id, coachID, "Coach")
+}
+
+// ListAthletes handles GET /api/v1/coaching/athletes
+// @Summary List my athletes
+// @Description Returns the athletes who granted the caller coach access, pending invitations included
+// @Tags Coaching
+// @Produce json
+// @Success 200 {array} models.CoachAccess "Coach access grants"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/coaching/athletes [get]
+func (h *CoachHandler) ListAthletes(w http.ResponseWriter, r *http.Request) {
+	user, _ := requestcontext.FromContext(r.Context())
+
+	athletes, err := h.coachRepo.ListAthletes(r.Context(), user.Id)
+	if err != nil {
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to list athletes")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to fetch athletes")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, athletes)
+}
+
+// AcceptInvitation handles POST /api/v1/coaching/athletes/{athleteId}/accept
+// @Summary Accept an athlete's invitation
+// @Description Activates the pending invitation the athlete sent the caller, giving the caller access to their data
+// @Tags Coaching
+// @Param athleteId path string true "Athlete user ID or public ID"
+// @Success 204 "Accepted"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "No pending invitation"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/coaching/athletes/{athleteId}/accept [post]
+func (h *CoachHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	athleteID, ok := parseUserVar(w, r, "athleteId")
+	if !ok {
+		return
+	}
+
+	if err := h.coachRepo.Accept(ctx, user.Id, athleteID); err != nil {
+		if errors.Is(err, appErrors.ErrNotFound) {
+			response.Fail(w, r, http.StatusNotFound, "Invitation not found")
+			return
+		}
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to accept coach invitation")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to accept invitation")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DropAthlete handles DELETE /api/v1/coaching/athletes/{athleteId}
+// @Summary Stop coaching an athlete
+// @Description Ends the caller's access to the athlete's data, or declines their pending invitation
+// @Tags Coaching
+// @Param athleteId path string true "Athlete user ID or public ID"
+// @Success 204 "Dropped"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Not the caller's athlete"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/coaching/athletes/{athleteId} [delete]
+func (h *CoachHandler) DropAthlete(w http.ResponseWriter, r *http.Request) {
+	user, _ := requestcontext.FromContext(r.Context())
+
+	athleteID, ok := parseUserVar(w, r, "athleteId")
+	if !ok {
+		return
+	}
+	h.revoke(w, r, athleteID, user.Id, "Athlete")
+}
+
+// revoke deletes the grant between athleteID and coachID; resource names
+// the other side in the 404
+func (h *CoachHandler) revoke(w http.ResponseWriter, r *http.Request, athleteID, coachID int, resource string) {
+	if err := h.coachRepo.Revoke(r.Context(), athleteID, coachID); err != nil {
+		if failDBError(w, r, err, resource) {
+			return
+		}
+		log.Error().Err(err).Int("athleteID", athleteID).Int("coachID", coachID).Msg("Failed to revoke coach access")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to revoke coach access")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetDashboard handles GET /api/v1/coaching/dashboard
+// @Summary Coach dashboard
+// @Description Returns each athlete the caller actively coaches with their last seven days (activities, duration, distance) and training load: the acute (7-day) and chronic (28-day) loads ending today and their ratio, as in /stats/training-load. Private activities are left out.
+// @Tags Coaching
+// @Produce json
+// @Success 200 {array} models.AthleteLoad "Athletes by username"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/coaching/dashboard [get]
+func (h *CoachHandler) GetDashboard(w http.ResponseWriter, r *http.Request) {
+	user, _ := requestcontext.FromContext(r.Context())
+
+	athletes, err := h.coachRepo.GetDashboard(r.Context(), user.Id, h.clock.Now().UTC())
+	if err != nil {
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to build coach dashboard")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to fetch dashboard")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, athletes)
+}
+
+// ListAthleteActivities handles GET /api/v1/coaching/athletes/{athleteId}/activities
+// @Summary List an athlete's activities
+// @Description Returns a paginated list of the athlete's activities, newest first unless ordered otherwise. Takes the filters of GET /activities. Requires the athlete's coach access. Private activities are left out, and start and end points within the athlete's privacy zones are hidden.
+// @Tags Coaching
+// @Produce json
+// @Param athleteId path string true "Athlete user ID or public ID"
+// @Param filter[activity_type] query string false "Filter by activity type"
+// @Param order[activity_date] query string false "Sort by activity_date (ASC or DESC)"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 10, max: 100)"
+// @Success 200 {object} paginatedResponse{data=[]models.Activity} "Paginated activities"
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "No coach access"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/coaching/athletes/{athleteId}/activities [get]
+func (h *CoachHandler) ListAthleteActivities(w http.ResponseWriter, r *http.Request) {
+	athleteID, ok := parseUserVar(w, r, "athleteId")
+	if !ok {
+		return
+	}
+
+	queryOpts, err := query.ParseQueryParamsWithFields(r.URL.Query(), h.validation.Fields())
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+	if err := h.validation.Validate(queryOpts); err != nil {
+		response.Fail(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	queryOpts.Filter["user_id"] = athleteID
+	queryOpts.FilterConditions = append(queryOpts.FilterConditions,
+		query.FilterCondition{Column: "visibility", Operator: "ne", Value: models.VisibilityPrivate})
+	if len(queryOpts.Order) == 0 {
+		queryOpts.Order.Set("activity_date", "DESC")
+	}
+	if !enforceQueryCost(w, r, queryOpts) {
+		return
+	}
+
+	result, err := h.activityRepo.ListActivitiesWithQuery(r.Context(), queryOpts)
+	if err != nil {
+		if failQueryTimeout(w, r, err) {
+			return
+		}
+		log.Error().Err(err).Int("athleteID", athleteID).Msg("Failed to list athlete activities")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to fetch activities")
+		return
+	}
+	if !redactForViewer(w, r, h.zoneRepo, athleteID, result.Data) {
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, map[string]interface{}{
+		"data": result.Data,
+		"meta": paginationMeta(w, r, result.Meta),
+	})
+}
+
+// GetAthleteWeeklyStats handles GET /api/v1/coaching/athletes/{athleteId}/stats/weekly
+// @Summary Get an athlete's weekly stats
+// @Description Returns the athlete's stats for the last seven days, as GET /stats/weekly does for the caller, leaving out private activities. Requires the athlete's coach access.
+// @Tags Coaching
+// @Produce json
+// @Param athleteId path string true "Athlete user ID or public ID"
+// @Success 200 {object} repository.WeeklyStats "Weekly stats"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "No coach access"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/coaching/athletes/{athleteId}/stats/weekly [get]
+func (h *CoachHandler) GetAthleteWeeklyStats(w http.ResponseWriter, r *http.Request) {
+	athleteID, ok := parseUserVar(w, r, "athleteId")
+	if !ok {
+		return
+	}
+
+	stats, err := h.statsRepo.GetSharedWeeklyStats(r.Context(), athleteID)
+	if err != nil {
+		log.Error().Err(err).Int("athleteID", athleteID).Msg("Failed to fetch athlete weekly stats")
+		response.Fail(w, r, http.StatusInternalServerError, "Error fetching weekly stats")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, stats)
+}
+
+// CommentOnActivity handles POST /api/v1/coaching/athletes/{athleteId}/activities/{id}/comments
+// @Summary Comment on an athlete's activity
+// @Description Adds the caller's comment to one of the athlete's activities. Requires coach access with the comment scope. Private activities are not found.
+// @Tags Coaching
+// @Accept json
+// @Produce json
+// @Param athleteId path string true "Athlete user ID or public ID"
+// @Param id path string true "Activity ID or public ID"
+// @Param request body models.CreateCommentRequest true "Comment"
+// @Success 201 {object} models.Comment "Created comment"
+// @Failure 400 {object} map[string]interface{} "Validation error"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "No comment access"
+// @Failure 404 {object} map[string]string "Activity not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/coaching/athletes/{athleteId}/activities/{id}/comments [post]
+func (h *CoachHandler) CommentOnActivity(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	athleteID, ok := parseUserVar(w, r, "athleteId")
+	if !ok {
+		return
+	}
+	activityID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid activity ID")
+		return
+	}
+
+	var req models.CreateCommentRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
+		return
+	}
+
+	// The grant covers the athlete's activities only, private ones excepted
+	activity, err := h.activityRepo.GetByID(ctx, activityID)
+	if err != nil || activity.UserID != athleteID || activity.Visibility == models.VisibilityPrivate {
+		if err == nil || errors.Is(err, appErrors.ErrNotFound) {
+			response.Fail(w, r, http.StatusNotFound, "Activity not found")
+			return
+		}
+		log.Error().Err(err).Int64("activityID", activityID).Msg("Failed to fetch activity")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to add comment")
+		return
+	}
+
+	comment := &models.Comment{
+		UserID:          user.Id,
+		CommentableType: "Activity",
+		CommentableID:   int(activityID),
+		Content:         req.Content,
+	}
+	if err := h.commentRepo.Create(ctx, comment); err != nil {
+		log.Error().Err(err).Int64("activityID", activityID).Msg("Failed to create comment")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to add comment")
+		return
+	}
+
+	response.Success(w, r, http.StatusCreated, comment)
+}
+
+// parseUserVar parses the user ID in path variable name, writing a 400 when
+// it isn't one
+func parseUserVar(w http.ResponseWriter, r *http.Request, name string) (int, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)[name])
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid user ID")
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,44 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/valentinesamuel/activelog/internal/handlers"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/repository/mocks"
+)
+
+func TestCoachHandler_GetAthleteWeeklyStats(t *testing.T) {
+	t.Run("leaves out private activities", func(t *testing.T) {
+		stats := mocks.NewMockStatsRepositoryInterface(gomock.NewController(t))
+		// GetWeeklyStats, which counts private activities, must not be called
+		stats.EXPECT().GetSharedWeeklyStats(gomock.Any(), 8).Return(&repository.WeeklyStats{TotalActivities: 2, TotalDuration: 60}, nil)
+		h := handlers.NewCoachHandler(handlers.CoachHandlerDeps{StatsRepo: stats})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/coaching/athletes/8/stats/weekly", nil)
+		req = req.WithContext(requestcontext.NewContext(req.Context(), &requestcontext.User{Id: 3}))
+		w := httptest.NewRecorder()
+		h.GetAthleteWeeklyStats(w, mux.SetURLVars(req, map[string]string{"athleteId": "8"}))
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), `"totalActivities":2`)
+	})
+
+	t.Run("invalid athlete ID", func(t *testing.T) {
+		h := handlers.NewCoachHandler(handlers.CoachHandlerDeps{})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/coaching/athletes/x/stats/weekly", nil)
+		w := httptest.NewRecorder()
+		h.GetAthleteWeeklyStats(w, mux.SetURLVars(req, map[string]string{"athleteId": "x"}))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/valentinesamuel/activelog/internal/platform/locale"
+	"github.com/valentinesamuel/activelog/pkg/dberr"
+	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// failDBError writes the response for the typed repository errors of
+// pkg/dberr and reports whether err was one of them. resource names the
+// entity in messages ("Activity" gives "Activity not found"). Callers handle
+// their domain errors first and fall back to a 500 when this returns false.
+func failDBError(w http.ResponseWriter, r *http.Request, err error, resource string) bool {
+	var unique *dberr.ErrUniqueViolation
+	var foreignKey *dberr.ErrForeignKeyViolation
+
+	switch {
+	case errors.Is(err, dberr.ErrNotFound):
+		response.Fail(w, r, http.StatusNotFound, resource+" not found")
+	case errors.As(err, &unique):
+		response.Fail(w, r, http.StatusConflict, resource+" already exists")
+	case errors.As(err, &foreignKey):
+		response.Fail(w, r, http.StatusBadRequest, "Related resource does not exist or is still in use")
+	case errors.Is(err, dberr.ErrSerializationFailure):
+		w.Header().Set("Retry-After", "1")
+		response.Fail(w, r, http.StatusConflict, "Conflicting concurrent update, please retry")
+	default:
+		return false
+	}
+	return true
+}
+
+// failValidationError writes a 400 for a *errors.ValidationError raised past
+// request validation (a business rule on one field, e.g. an unregistered
+// activity type) and reports whether err was one
+func failValidationError(w http.ResponseWriter, r *http.Request, err error) bool {
+	var validationErr *appErrors.ValidationError
+	if !errors.As(err, &validationErr) {
+		return false
+	}
+	response.ValidationFail(w, r, []response.ValidationErrorItem{{
+		Field:  validationErr.Field,
+		Errors: []string{locale.FromContext(r.Context()).Localize(validationErr.Message, nil)},
+	}})
+	return true
+}
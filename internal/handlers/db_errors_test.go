@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/valentinesamuel/activelog/pkg/dberr"
+)
+
+func TestFailDBError(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantHandled bool
+		wantStatus  int
+	}{
+		{"not found", fmt.Errorf("get: %w", dberr.ErrNotFound), true, http.StatusNotFound},
+		{"unique violation", dberr.Translate(&pgconn.PgError{Code: "23505"}), true, http.StatusConflict},
+		{"foreign key violation", dberr.Translate(&pgconn.PgError{Code: "23503"}), true, http.StatusBadRequest},
+		{"serialization failure", dberr.Translate(&pgconn.PgError{Code: "40001"}), true, http.StatusConflict},
+		{"other", errors.New("connection refused"), false, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			assert.Equal(t, tt.wantHandled, failDBError(w, r, tt.err, "Activity"))
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// sanitizer is implemented by request models with free-text fields
+// (e.g. models.CreateActivityRequest); decoding cleans them before validation
+type sanitizer interface {
+	Sanitize()
+}
+
+// errTrailingData is returned when a JSON body holds more than one value
+var errTrailingData = errors.New("unexpected data after JSON value")
+
+// decodeJSON strictly decodes the request body into dst. On failure it writes
+// the error response (413 if the body exceeds the size limit, 400 otherwise)
+// and returns false.
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst interface{}) bool {
+	return writeDecodeError(w, r, readJSON(r.Body, dst))
+}
+
+// decodeOptionalJSON is decodeJSON for endpoints where the body may be empty
+func decodeOptionalJSON(w http.ResponseWriter, r *http.Request, dst interface{}) bool {
+	err := readJSON(r.Body, dst)
+	if errors.Is(err, io.EOF) {
+		return true
+	}
+	return writeDecodeError(w, r, err)
+}
+
+// readJSON decodes exactly one JSON value from body into dst, rejecting
+// unknown fields, then sanitizes dst's free-text fields
+func readJSON(body io.Reader, dst interface{}) error {
+	decoder := json.NewDecoder(body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return errTrailingData
+	}
+
+	if s, ok := dst.(sanitizer); ok {
+		s.Sanitize()
+	}
+	return nil
+}
+
+func writeDecodeError(w http.ResponseWriter, r *http.Request, err error) bool {
+	if err == nil {
+		return true
+	}
+
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		response.Fail(w, r, http.StatusRequestEntityTooLarge, "Request body too large")
+		return false
+	}
+
+	// Name the offending field; other decode errors are not worth echoing back
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid request body: unknown field "+field)
+		return false
+	}
+	response.Fail(w, r, http.StatusBadRequest, "Invalid request body")
+	return false
+}
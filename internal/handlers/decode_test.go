@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valentinesamuel/activelog/internal/models"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		limit      int64
+		wantOK     bool
+		wantStatus int
+	}{
+		{name: "valid body", body: `{"name":"Runners"}`, wantOK: true},
+		{name: "unknown field", body: `{"name":"Runners","admin":true}`, wantStatus: http.StatusBadRequest},
+		{name: "trailing data", body: `{"name":"Runners"} {}`, wantStatus: http.StatusBadRequest},
+		{name: "empty body", body: ``, wantStatus: http.StatusBadRequest},
+		{name: "over size limit", body: `{"name":"` + strings.Repeat("a", 64) + `"}`, limit: 32, wantStatus: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			if tt.limit > 0 {
+				r.Body = http.MaxBytesReader(w, r.Body, tt.limit)
+			}
+
+			var req models.CreateGroupRequest
+			ok := decodeJSON(w, r, &req)
+
+			assert.Equal(t, tt.wantOK, ok)
+			if !tt.wantOK {
+				assert.Equal(t, tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestDecodeJSON_Sanitizes(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":" Run\u0000ners ","description":"a\r\nb"}`))
+
+	var req models.CreateGroupRequest
+	assert.True(t, decodeJSON(w, r, &req))
+	assert.Equal(t, "Runners", req.Name)
+	assert.Equal(t, "a\nb", req.Description)
+}
+
+func TestDecodeOptionalJSON_EmptyBody(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodDelete, "/", http.NoBody)
+
+	var req models.DeleteAccountRequest
+	assert.True(t, decodeOptionalJSON(w, r, &req))
+	assert.Empty(t, req.ConfirmationToken)
+}
@@ -8,5 +8,27 @@ const (
 	StatsHandlerKey         = "statsHandler"
 	ActivityPhotoHandlerKey = "activityPhotoHandler"
 	ExportHandlerKey        = "exportHandler"
+	ImportHandlerKey        = "importHandler"
+	JobHandlerKey           = "jobHandler"
+	SyncHandlerKey          = "syncHandler"
+	AccountHandlerKey       = "accountHandler"
 	WebhookHandlerKey      = "webhookHandler"
+	GroupHandlerKey         = "groupHandler"
+	ShareHandlerKey         = "shareHandler"
+	TagHandlerKey           = "tagHandler"
+	ProfileHandlerKey       = "profileHandler"
+	ReactionHandlerKey      = "reactionHandler"
+	BodyMetricHandlerKey    = "bodyMetricHandler"
+	WorkoutHandlerKey       = "workoutHandler"
+	CoachHandlerKey         = "coachHandler"
+	ChallengeHandlerKey     = "challengeHandler"
+	AchievementHandlerKey   = "achievementHandler"
+	IdentityHandlerKey      = "identityHandler"
+	ActivityTypeHandlerKey  = "activityTypeHandler"
+	SearchHandlerKey        = "searchHandler"
+	FileHandlerKey          = "fileHandler"
+	WorkerHandlerKey        = "workerHandler"
+	PrivacyZoneHandlerKey   = "privacyZoneHandler"
+	ActivityTrackHandlerKey = "activityTrackHandler"
+	APIKeyHandlerKey        = "apiKeyHandler"
 )
@@ -1,22 +1,69 @@
 package di
 
 import (
+	"context"
+	"fmt"
+	"log"
+
 	activityUsecases "github.com/valentinesamuel/activelog/internal/application/activity/usecases"
 	activityUsecasesDI "github.com/valentinesamuel/activelog/internal/application/activity/usecases/di"
 	photoUsecases "github.com/valentinesamuel/activelog/internal/application/activityPhoto/usecases"
 	photoUsecasesDI "github.com/valentinesamuel/activelog/internal/application/activityPhoto/usecases/di"
 	"github.com/valentinesamuel/activelog/internal/application/broker"
 	"github.com/valentinesamuel/activelog/internal/application/broker/di"
+	clockDI "github.com/valentinesamuel/activelog/internal/platform/clock/di"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
 	"github.com/valentinesamuel/activelog/internal/platform/container"
 	"github.com/valentinesamuel/activelog/internal/handlers"
+	cacheDI "github.com/valentinesamuel/activelog/internal/adapters/cache/di"
+	cacheTypes "github.com/valentinesamuel/activelog/internal/adapters/cache/types"
 	queueDI "github.com/valentinesamuel/activelog/internal/adapters/queue/di"
 	queueTypes "github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	"github.com/valentinesamuel/activelog/internal/adapters/search"
+	searchDI "github.com/valentinesamuel/activelog/internal/adapters/search/di"
+	searchTypes "github.com/valentinesamuel/activelog/internal/adapters/search/types"
+	"github.com/valentinesamuel/activelog/internal/platform/jobs"
 	"github.com/valentinesamuel/activelog/internal/repository"
 	di2 "github.com/valentinesamuel/activelog/internal/repository/di"
 	storageDI "github.com/valentinesamuel/activelog/internal/adapters/storage/di"
+	webhookDI "github.com/valentinesamuel/activelog/internal/adapters/webhook/di"
+	webhookTypes "github.com/valentinesamuel/activelog/internal/adapters/webhook/types"
+	"github.com/valentinesamuel/activelog/internal/events"
+	eventsDI "github.com/valentinesamuel/activelog/internal/events/di"
+	"github.com/valentinesamuel/activelog/internal/service"
+	serviceDI "github.com/valentinesamuel/activelog/internal/service/di"
 	storageTypes "github.com/valentinesamuel/activelog/internal/adapters/storage/types"
+	identityDI "github.com/valentinesamuel/activelog/internal/adapters/identity/di"
+	identityTypes "github.com/valentinesamuel/activelog/internal/adapters/identity/types"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+	"github.com/valentinesamuel/activelog/pkg/password"
+	"github.com/valentinesamuel/activelog/pkg/query"
 )
 
+// passwordParams maps the password hashing configuration onto pkg/password
+func passwordParams() password.Params {
+	cfg := config.Common.Auth.Password
+	return password.Params{
+		Scheme:            cfg.Scheme,
+		BcryptCost:        cfg.BcryptCost,
+		Argon2Memory:      uint32(cfg.Argon2MemoryKB),
+		Argon2Iterations:  uint32(cfg.Argon2Iterations),
+		Argon2Parallelism: uint8(cfg.Argon2Parallelism),
+	}
+}
+
+// queryValidation returns the list query whitelist registered for table.
+// Repositories register theirs when they are created, so resolve the
+// repository first.
+func queryValidation(c *container.Container, table string) (*query.EntityValidation, error) {
+	validations := container.MustResolve[*query.ValidationConfigRegistry](c, di2.CoreValidationRegistryKey)
+	validation, ok := validations.Get(table)
+	if !ok {
+		return nil, fmt.Errorf("no query validation registered for %s", table)
+	}
+	return validation, nil
+}
+
 // RegisterHandlers registers all HTTP handler factories with the container
 // Dependencies: Requires use cases, broker, and repositories to be registered first
 func RegisterHandlers(c *container.Container) {
@@ -27,70 +74,344 @@ func RegisterHandlers(c *container.Container) {
 
 	// User handler (legacy pattern for now)
 	c.Register(UserHandlerKey, func(c *container.Container) (interface{}, error) {
-		repo := c.MustResolve(di2.UserRepoKey).(*repository.UserRepository)
-		return handlers.NewUserHandler(repo), nil
+		repo := container.MustResolve[*repository.UserRepository](c, di2.UserRepoKey)
+		hasher, err := password.New(passwordParams())
+		if err != nil {
+			return nil, err
+		}
+		clk := container.MustResolve[clock.Clock](c, clockDI.ClockKey)
+		return handlers.NewUserHandler(repo, hasher).WithClock(clk), nil
 	})
 
 	// Activity handler (broker pattern with typed use cases)
 	c.Register(ActivityHandlerKey, func(c *container.Container) (interface{}, error) {
-		brokerInstance := c.MustResolve(di.BrokerKey).(*broker.Broker)
-		repo := c.MustResolve(di2.ActivityRepoKey).(repository.ActivityRepositoryInterface)
+		brokerInstance := container.MustResolve[*broker.Broker](c, di.BrokerKey)
+		repo := container.MustResolve[repository.ActivityRepositoryInterface](c, di2.ActivityRepoKey)
 
 		// Resolve all typed use cases
-		createUC := c.MustResolve(activityUsecasesDI.CreateActivityUCKey).(*activityUsecases.CreateActivityUseCase)
-		getUC := c.MustResolve(activityUsecasesDI.GetActivityUCKey).(*activityUsecases.GetActivityUseCase)
-		listUC := c.MustResolve(activityUsecasesDI.ListActivitiesUCKey).(*activityUsecases.ListActivitiesUseCase)
-		updateUC := c.MustResolve(activityUsecasesDI.UpdateActivityUCKey).(*activityUsecases.UpdateActivityUseCase)
-		deleteUC := c.MustResolve(activityUsecasesDI.DeleteActivityUCKey).(*activityUsecases.DeleteActivityUseCase)
-		getStatsUC := c.MustResolve(activityUsecasesDI.GetActivityStatsUCKey).(*activityUsecases.GetActivityStatsUseCase)
+		createUC := container.MustResolve[*activityUsecases.CreateActivityUseCase](c, activityUsecasesDI.CreateActivityUCKey)
+		getUC := container.MustResolve[*activityUsecases.GetActivityUseCase](c, activityUsecasesDI.GetActivityUCKey)
+		listUC := container.MustResolve[*activityUsecases.ListActivitiesUseCase](c, activityUsecasesDI.ListActivitiesUCKey)
+		updateUC := container.MustResolve[*activityUsecases.UpdateActivityUseCase](c, activityUsecasesDI.UpdateActivityUCKey)
+		deleteUC := container.MustResolve[*activityUsecases.DeleteActivityUseCase](c, activityUsecasesDI.DeleteActivityUCKey)
+		getStatsUC := container.MustResolve[*activityUsecases.GetActivityStatsUseCase](c, activityUsecasesDI.GetActivityStatsUCKey)
+		bulkUpdateUC := container.MustResolve[*activityUsecases.BulkUpdateActivitiesUseCase](c, activityUsecasesDI.BulkUpdateActivitiesUCKey)
+		bulkDeleteUC := container.MustResolve[*activityUsecases.BulkDeleteActivitiesUseCase](c, activityUsecasesDI.BulkDeleteActivitiesUCKey)
+		mergeUC := container.MustResolve[*activityUsecases.MergeActivitiesUseCase](c, activityUsecasesDI.MergeActivitiesUCKey)
+		duplicatesUC := container.MustResolve[*activityUsecases.FindDuplicateActivitiesUseCase](c, activityUsecasesDI.FindDuplicatesUCKey)
+		validation, err := queryValidation(c, "activities")
+		if err != nil {
+			return nil, err
+		}
 
 		return handlers.NewActivityHandler(handlers.ActivityHandlerDeps{
 			Broker:             brokerInstance,
 			Repo:               repo,
+			Validation:         validation,
 			CreateActivityUC:   createUC,
 			GetActivityUC:      getUC,
 			ListActivitiesUC:   listUC,
 			UpdateActivityUC:   updateUC,
 			DeleteActivityUC:   deleteUC,
 			GetActivityStatsUC: getStatsUC,
+			BulkUpdateUC:       bulkUpdateUC,
+			BulkDeleteUC:       bulkDeleteUC,
+			MergeActivitiesUC:  mergeUC,
+			FindDuplicatesUC:   duplicatesUC,
+			ReactionRepo:       container.MustResolve[*repository.ReactionRepository](c, di2.ReactionRepoKey),
+			TypeRepo:           container.MustResolve[*repository.ActivityTypeRepository](c, di2.ActivityTypeRepoKey),
+			TrackRepo:          container.MustResolve[*repository.ActivityTrackRepository](c, di2.ActivityTrackRepoKey),
+			EventBus:           container.MustResolve[*events.Bus](c, eventsDI.EventBusKey),
+			Clock:              container.MustResolve[clock.Clock](c, clockDI.ClockKey),
 		}), nil
 	})
 
 	// Stats handler (legacy pattern for now - will migrate to V2 later)
 	c.Register(StatsHandlerKey, func(c *container.Container) (interface{}, error) {
-		repo := c.MustResolve(di2.StatsRepoKey).(repository.StatsRepositoryInterface)
-		return handlers.NewStatsHandler(repo), nil
+		repo := container.MustResolve[repository.StatsRepositoryInterface](c, di2.StatsRepoKey)
+		var cacheAdapter cacheTypes.CacheAdapter
+		if resolved := c.MustResolve(cacheDI.CacheAdapterKey); resolved != nil {
+			cacheAdapter = resolved.(cacheTypes.CacheAdapter)
+		}
+		clk := container.MustResolve[clock.Clock](c, clockDI.ClockKey)
+		return handlers.NewStatsHandler(repo, cacheAdapter).WithClock(clk), nil
 	})
 
 	// Activity photo handler (typed use cases)
 	c.Register(ActivityPhotoHandlerKey, func(c *container.Container) (interface{}, error) {
-		brokerInstance := c.MustResolve(di.BrokerKey).(*broker.Broker)
-		repo := c.MustResolve(di2.ActivityPhotoRepoKey).(repository.ActivityPhotoRepositoryInterface)
+		brokerInstance := container.MustResolve[*broker.Broker](c, di.BrokerKey)
+		repo := container.MustResolve[repository.ActivityPhotoRepositoryInterface](c, di2.ActivityPhotoRepoKey)
 
 		// Resolve typed use cases
-		uploadActivityPhotoUC := c.MustResolve(photoUsecasesDI.UploadActivityPhotosUCKey).(*photoUsecases.UploadActivityPhotoUseCase)
-		getActivityPhotoUC := c.MustResolve(photoUsecasesDI.GetActivityPhotosUCKey).(*photoUsecases.GetActivityPhotoUseCase)
+		uploadActivityPhotoUC := container.MustResolve[*photoUsecases.UploadActivityPhotoUseCase](c, photoUsecasesDI.UploadActivityPhotosUCKey)
+		getActivityPhotoUC := container.MustResolve[*photoUsecases.GetActivityPhotoUseCase](c, photoUsecasesDI.GetActivityPhotosUCKey)
 
-		return handlers.NewActivityPhotoHandler(brokerInstance, repo, uploadActivityPhotoUC, getActivityPhotoUC), nil
+		clk := container.MustResolve[clock.Clock](c, clockDI.ClockKey)
+		return handlers.NewActivityPhotoHandler(brokerInstance, repo, uploadActivityPhotoUC, getActivityPhotoUC).WithClock(clk), nil
 	})
 
 	// Webhook handler
 	c.Register(WebhookHandlerKey, func(c *container.Container) (interface{}, error) {
-		webhookRepo := c.MustResolve(di2.WebhookRepoKey).(*repository.WebhookRepository)
+		webhookRepo := container.MustResolve[*repository.WebhookRepository](c, di2.WebhookRepoKey)
 		return handlers.NewWebhookHandler(webhookRepo), nil
 	})
 
+	// Group handler
+	c.Register(GroupHandlerKey, func(c *container.Container) (interface{}, error) {
+		groupRepo := container.MustResolve[*repository.GroupRepository](c, di2.GroupRepoKey)
+		return handlers.NewGroupHandler(groupRepo), nil
+	})
+
+	// Tag handler
+	c.Register(TagHandlerKey, func(c *container.Container) (interface{}, error) {
+		tagRepo := container.MustResolve[*repository.TagRepository](c, di2.TagRepoKey)
+		validation, err := queryValidation(c, "tags")
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewTagHandler(tagRepo, validation), nil
+	})
+
+	// Share handler
+	c.Register(ShareHandlerKey, func(c *container.Container) (interface{}, error) {
+		shareRepo := container.MustResolve[*repository.ShareRepository](c, di2.ShareRepoKey)
+		activityRepo := container.MustResolve[repository.ActivityRepositoryInterface](c, di2.ActivityRepoKey)
+		clk := container.MustResolve[clock.Clock](c, clockDI.ClockKey)
+		return handlers.NewShareHandler(shareRepo, activityRepo).WithClock(clk), nil
+	})
+
 	// Export handler
 	c.Register(ExportHandlerKey, func(c *container.Container) (interface{}, error) {
-		activityRepo := c.MustResolve(di2.ActivityRepoKey).(repository.ActivityRepositoryInterface)
-		exportRepo := c.MustResolve(di2.ExportRepoKey).(*repository.ExportRepository)
-		queueProvider := c.MustResolve(queueDI.QueueProviderKey).(queueTypes.QueueProvider)
-		storage := c.MustResolve(storageDI.StorageProviderKey).(storageTypes.StorageProvider)
+		activityRepo := container.MustResolve[repository.ActivityRepositoryInterface](c, di2.ActivityRepoKey)
+		exportRepo := container.MustResolve[*repository.ExportRepository](c, di2.ExportRepoKey)
+		jobRepo := container.MustResolve[*repository.JobRepository](c, di2.JobRepoKey)
+		queueProvider := container.MustResolve[queueTypes.QueueProvider](c, queueDI.QueueProviderKey)
 		return handlers.NewExportHandler(handlers.ExportHandlerDeps{
 			ActivityRepo:  activityRepo,
 			ExportRepo:    exportRepo,
+			JobRepo:       jobRepo,
+			QueueProvider: queueProvider,
+			ZoneRepo:      container.MustResolve[*repository.PrivacyZoneRepository](c, di2.PrivacyZoneRepoKey),
+			Clock:         container.MustResolve[clock.Clock](c, clockDI.ClockKey),
+		}), nil
+	})
+
+	// Import handler
+	c.Register(ImportHandlerKey, func(c *container.Container) (interface{}, error) {
+		importRepo := container.MustResolve[*repository.ImportRepository](c, di2.ImportRepoKey)
+		jobRepo := container.MustResolve[*repository.JobRepository](c, di2.JobRepoKey)
+		queueProvider := container.MustResolve[queueTypes.QueueProvider](c, queueDI.QueueProviderKey)
+		storage := container.MustResolve[storageTypes.StorageProvider](c, storageDI.StorageProviderKey)
+		return handlers.NewImportHandler(handlers.ImportHandlerDeps{
+			ImportRepo:    importRepo,
+			JobRepo:       jobRepo,
 			QueueProvider: queueProvider,
 			Storage:       storage,
 		}), nil
 	})
+
+	// Job handler
+	c.Register(JobHandlerKey, func(c *container.Container) (interface{}, error) {
+		jobRepo := container.MustResolve[*repository.JobRepository](c, di2.JobRepoKey)
+		validation, err := queryValidation(c, "jobs")
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewJobHandler(jobRepo, validation), nil
+	})
+
+	// Sync handler (offline sync; mutations run through the activity use cases)
+	c.Register(SyncHandlerKey, func(c *container.Container) (interface{}, error) {
+		return handlers.NewSyncHandler(handlers.SyncHandlerDeps{
+			Broker:           container.MustResolve[*broker.Broker](c, di.BrokerKey),
+			ActivityRepo:     container.MustResolve[repository.ActivityRepositoryInterface](c, di2.ActivityRepoKey),
+			ChangeLogRepo:    container.MustResolve[*repository.ChangeLogRepository](c, di2.ChangeLogRepoKey),
+			CreateActivityUC: container.MustResolve[*activityUsecases.CreateActivityUseCase](c, activityUsecasesDI.CreateActivityUCKey),
+			UpdateActivityUC: container.MustResolve[*activityUsecases.UpdateActivityUseCase](c, activityUsecasesDI.UpdateActivityUCKey),
+			DeleteActivityUC: container.MustResolve[*activityUsecases.DeleteActivityUseCase](c, activityUsecasesDI.DeleteActivityUCKey),
+			EventBus:         container.MustResolve[*events.Bus](c, eventsDI.EventBusKey),
+		}), nil
+	})
+
+	// Account handler (GDPR data export and account deletion)
+	c.Register(AccountHandlerKey, func(c *container.Container) (interface{}, error) {
+		return handlers.NewAccountHandler(handlers.AccountHandlerDeps{
+			AccountRepo:   container.MustResolve[*repository.AccountRepository](c, di2.AccountRepoKey),
+			ExportRepo:    container.MustResolve[*repository.ExportRepository](c, di2.ExportRepoKey),
+			JobRepo:       container.MustResolve[*repository.JobRepository](c, di2.JobRepoKey),
+			QueueProvider: container.MustResolve[queueTypes.QueueProvider](c, queueDI.QueueProviderKey),
+			TokenTTL:      config.Account.DeletionTokenTTL,
+			GracePeriod:   config.Account.DeletionGracePeriod,
+			Clock:         container.MustResolve[clock.Clock](c, clockDI.ClockKey),
+		}), nil
+	})
+
+	// Profile handler (GET/PATCH /users/me and the avatar)
+	c.Register(ProfileHandlerKey, func(c *container.Container) (interface{}, error) {
+		return handlers.NewProfileHandler(handlers.ProfileHandlerDeps{
+			ProfileRepo:   container.MustResolve[*repository.ProfileRepository](c, di2.ProfileRepoKey),
+			Storage:       container.MustResolve[storageTypes.StorageProvider](c, storageDI.StorageProviderKey),
+			QueueProvider: container.MustResolve[queueTypes.QueueProvider](c, queueDI.QueueProviderKey),
+			Clock:         container.MustResolve[clock.Clock](c, clockDI.ClockKey),
+		}), nil
+	})
+
+	// Privacy zone handler (places whose exact position users keep to themselves)
+	c.Register(PrivacyZoneHandlerKey, func(c *container.Container) (interface{}, error) {
+		return handlers.NewPrivacyZoneHandler(handlers.PrivacyZoneHandlerDeps{
+			ZoneRepo:      container.MustResolve[*repository.PrivacyZoneRepository](c, di2.PrivacyZoneRepoKey),
+			MaxZones:      config.Activity.PrivacyZonesMax,
+			QueueProvider: container.MustResolve[queueTypes.QueueProvider](c, queueDI.QueueProviderKey),
+		}), nil
+	})
+
+	// API key handler (long-lived bearer tokens for scripts and the CLI)
+	c.Register(APIKeyHandlerKey, func(c *container.Container) (interface{}, error) {
+		return handlers.NewAPIKeyHandler(handlers.APIKeyHandlerDeps{
+			KeyRepo: container.MustResolve[*repository.APIKeyRepository](c, di2.APIKeyRepoKey),
+		}), nil
+	})
+
+	// Activity track handler (GPS tracks simplified for maps)
+	c.Register(ActivityTrackHandlerKey, func(c *container.Container) (interface{}, error) {
+		return handlers.NewActivityTrackHandler(handlers.ActivityTrackHandlerDeps{
+			ActivityRepo: container.MustResolve[repository.ActivityRepositoryInterface](c, di2.ActivityRepoKey),
+			TrackRepo:    container.MustResolve[*repository.ActivityTrackRepository](c, di2.ActivityTrackRepoKey),
+		}), nil
+	})
+
+	// Reaction handler (kudos on activities)
+	c.Register(ReactionHandlerKey, func(c *container.Container) (interface{}, error) {
+		return handlers.NewReactionHandler(handlers.ReactionHandlerDeps{
+			ReactionRepo: container.MustResolve[*repository.ReactionRepository](c, di2.ReactionRepoKey),
+			Events:       container.MustResolve[webhookTypes.WebhookBusProvider](c, webhookDI.WebhookBusKey),
+		}), nil
+	})
+
+	// Body metric handler (daily weight, resting heart rate and sleep)
+	c.Register(BodyMetricHandlerKey, func(c *container.Container) (interface{}, error) {
+		metricRepo := container.MustResolve[*repository.BodyMetricRepository](c, di2.BodyMetricRepoKey)
+		validation, err := queryValidation(c, "body_metrics")
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewBodyMetricHandler(handlers.BodyMetricHandlerDeps{
+			MetricRepo: metricRepo,
+			Validation: validation,
+			Clock:      container.MustResolve[clock.Clock](c, clockDI.ClockKey),
+		}), nil
+	})
+
+	// Workout handler (structured workouts, their schedule and compliance)
+	c.Register(WorkoutHandlerKey, func(c *container.Container) (interface{}, error) {
+		workoutRepo := container.MustResolve[*repository.WorkoutRepository](c, di2.WorkoutRepoKey)
+		validation, err := queryValidation(c, "workouts")
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewWorkoutHandler(handlers.WorkoutHandlerDeps{
+			WorkoutRepo: workoutRepo,
+			Validation:  validation,
+			Clock:       container.MustResolve[clock.Clock](c, clockDI.ClockKey),
+		}), nil
+	})
+
+	// Coach handler (coach access and coaches' views of their athletes)
+	c.Register(CoachHandlerKey, func(c *container.Container) (interface{}, error) {
+		validation, err := queryValidation(c, "activities")
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewCoachHandler(handlers.CoachHandlerDeps{
+			CoachRepo:    container.MustResolve[*repository.CoachRepository](c, di2.CoachRepoKey),
+			CommentRepo:  container.MustResolve[*repository.CommentRepository](c, di2.CommentRepoKey),
+			ActivityRepo: container.MustResolve[repository.ActivityRepositoryInterface](c, di2.ActivityRepoKey),
+			StatsRepo:    container.MustResolve[repository.StatsRepositoryInterface](c, di2.StatsRepoKey),
+			ZoneRepo:     container.MustResolve[*repository.PrivacyZoneRepository](c, di2.PrivacyZoneRepoKey),
+			Validation:   validation,
+			Clock:        container.MustResolve[clock.Clock](c, clockDI.ClockKey),
+		}), nil
+	})
+
+	// Challenge handler (challenges, their leaderboards and badges)
+	c.Register(ChallengeHandlerKey, func(c *container.Container) (interface{}, error) {
+		challengeRepo := container.MustResolve[*repository.ChallengeRepository](c, di2.ChallengeRepoKey)
+		validation, err := queryValidation(c, "challenges")
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewChallengeHandler(handlers.ChallengeHandlerDeps{
+			ChallengeRepo: challengeRepo,
+			Validation:    validation,
+			Clock:         container.MustResolve[clock.Clock](c, clockDI.ClockKey),
+		}), nil
+	})
+
+	// File handler (signed URLs of stored photos and exports)
+	c.Register(FileHandlerKey, func(c *container.Container) (interface{}, error) {
+		return handlers.NewFileHandler(
+			container.MustResolve[storageTypes.StorageProvider](c, storageDI.StorageProviderKey)).
+			WithClock(container.MustResolve[clock.Clock](c, clockDI.ClockKey)), nil
+	})
+
+	// Worker handler (worker fleet status from heartbeats)
+	c.Register(WorkerHandlerKey, func(c *container.Container) (interface{}, error) {
+		return handlers.NewWorkerHandler(
+			container.MustResolve[*repository.WorkerHeartbeatRepository](c, di2.WorkerHeartbeatRepoKey),
+			config.Queue.HeartbeatInterval), nil
+	})
+
+	// Achievement handler (achievements earned with activities)
+	c.Register(AchievementHandlerKey, func(c *container.Container) (interface{}, error) {
+		return handlers.NewAchievementHandler(
+			container.MustResolve[*service.AchievementEngine](c, serviceDI.AchievementEngineKey)), nil
+	})
+
+	// Activity type handler (default and user-defined activity types)
+	c.Register(ActivityTypeHandlerKey, func(c *container.Container) (interface{}, error) {
+		return handlers.NewActivityTypeHandler(handlers.ActivityTypeHandlerDeps{
+			Broker:   container.MustResolve[*broker.Broker](c, di.BrokerKey),
+			TypeRepo: container.MustResolve[*repository.ActivityTypeRepository](c, di2.ActivityTypeRepoKey),
+			MergeUC:  container.MustResolve[*activityUsecases.MergeActivityTypesUseCase](c, activityUsecasesDI.MergeActivityTypesUCKey),
+		}), nil
+	})
+
+	// Identity handler (social login and linked identities)
+	c.Register(IdentityHandlerKey, func(c *container.Container) (interface{}, error) {
+		return handlers.NewIdentityHandler(handlers.IdentityHandlerDeps{
+			Providers:    container.MustResolve[*identityTypes.Registry](c, identityDI.IdentityProvidersKey),
+			UserRepo:     container.MustResolve[*repository.UserRepository](c, di2.UserRepoKey),
+			IdentityRepo: container.MustResolve[*repository.IdentityRepository](c, di2.IdentityRepoKey),
+			Clock:        container.MustResolve[clock.Clock](c, clockDI.ClockKey),
+		}), nil
+	})
+
+	// Search handler (full-text activity search; disabled with SEARCH_PROVIDER=none)
+	c.Register(SearchHandlerKey, func(c *container.Container) (interface{}, error) {
+		activityRepo := container.MustResolve[*repository.ActivityRepository](c, di2.ActivityRepoKey)
+		deps := handlers.SearchHandlerDeps{Activities: activityRepo}
+		if !config.Search.Enabled() {
+			return handlers.NewSearchHandler(deps), nil
+		}
+
+		provider := container.MustResolve[searchTypes.SearchProvider](c, searchDI.SearchProviderKey)
+		deps.Searcher = provider
+		if config.Search.Provider == "memory" {
+			// The index lives in this process, so it is rebuilt here
+			deps.Reindex = func(context.Context) error {
+				go func() {
+					if _, err := search.Reindex(context.Background(), activityRepo, provider); err != nil {
+						log.Printf("Warning: Failed to rebuild the search index: %v", err)
+					}
+				}()
+				return nil
+			}
+		} else {
+			queue := container.MustResolve[queueTypes.QueueProvider](c, queueDI.QueueProviderKey)
+			deps.Reindex = func(ctx context.Context) error {
+				return jobs.EnqueueReindexSearch(ctx, queue)
+			}
+		}
+		return handlers.NewSearchHandler(deps), nil
+	})
 }
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/valentinesamuel/activelog/internal/application/broker"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// dryRunResponse wraps the outcome of a mutating request made with ?dry_run=true.
+// Result is what the request would have returned, including computed fields and
+// would-be IDs; none of it was committed.
+type dryRunResponse struct {
+	DryRun bool        `json:"dryRun"`
+	Result interface{} `json:"result"`
+}
+
+// isDryRun reports whether the client asked for a preview instead of a real mutation
+func isDryRun(r *http.Request) bool {
+	return r.URL.Query().Get("dry_run") == "true"
+}
+
+// brokerOptions returns the broker options implied by the request's query parameters
+func brokerOptions(r *http.Request) []broker.Option {
+	if isDryRun(r) {
+		return []broker.Option{broker.WithDryRun()}
+	}
+	return nil
+}
+
+// respondDryRun writes a dry-run preview. Always 200 - nothing was created or deleted.
+func respondDryRun(w http.ResponseWriter, r *http.Request, result interface{}) {
+	w.Header().Set("X-Dry-Run", "true")
+	response.Success(w, r, http.StatusOK, dryRunResponse{DryRun: true, Result: result})
+}
@@ -1,18 +1,27 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/valentinesamuel/activelog/internal/adapters/storage/fileurl"
 	"github.com/valentinesamuel/activelog/internal/models"
 	"github.com/valentinesamuel/activelog/internal/platform/jobs"
+	"github.com/valentinesamuel/activelog/internal/platform/locale"
 	queueTypes "github.com/valentinesamuel/activelog/internal/adapters/queue/types"
 	"github.com/valentinesamuel/activelog/internal/repository"
 	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
 	"github.com/valentinesamuel/activelog/internal/service"
-	storageTypes "github.com/valentinesamuel/activelog/internal/adapters/storage/types"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
 	"github.com/valentinesamuel/activelog/pkg/response"
 )
 
@@ -20,29 +29,48 @@ import (
 type ExportHandler struct {
 	activityRepo  repository.ActivityRepositoryInterface
 	exportRepo    *repository.ExportRepository
+	jobRepo       *repository.JobRepository
 	queueProvider queueTypes.QueueProvider
-	storage       storageTypes.StorageProvider
+	zoneRepo      *repository.PrivacyZoneRepository
+	clock         clock.Clock
 }
 
 // ExportHandlerDeps contains the dependencies for ExportHandler.
 type ExportHandlerDeps struct {
 	ActivityRepo  repository.ActivityRepositoryInterface
 	ExportRepo    *repository.ExportRepository
+	JobRepo       *repository.JobRepository
 	QueueProvider queueTypes.QueueProvider
-	Storage       storageTypes.StorageProvider
+	ZoneRepo      *repository.PrivacyZoneRepository // privacy zones applied to activity files
+	Clock         clock.Clock                       // download URLs expire by it; nil uses the real clock
 }
 
 // NewExportHandler creates a new ExportHandler with the given dependencies.
 func NewExportHandler(deps ExportHandlerDeps) *ExportHandler {
+	clk := deps.Clock
+	if clk == nil {
+		clk = clock.Real{}
+	}
 	return &ExportHandler{
 		activityRepo:  deps.ActivityRepo,
 		exportRepo:    deps.ExportRepo,
+		jobRepo:       deps.JobRepo,
 		queueProvider: deps.QueueProvider,
-		storage:       deps.Storage,
+		zoneRepo:      deps.ZoneRepo,
+		clock:         clk,
 	}
 }
 
 // ExportCSV streams the authenticated user's activities as a CSV download.
+// @Summary Export activities as CSV
+// @Description Streams every activity of the user as a CSV file.
+// @Tags Activities
+// @Produce text/csv
+// @Success 200 {file} file "activities.csv"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/activities/export/csv [get]
 func (h *ExportHandler) ExportCSV(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	user, _ := requestcontext.FromContext(ctx)
@@ -79,10 +107,22 @@ func (h *ExportHandler) EnqueuePDFExport(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Track the job under the export ID so both status endpoints accept it
+	job := &models.Job{
+		ID:     record.ID,
+		UserID: user.Id,
+		Type:   string(queueTypes.EventGenerateExport),
+	}
+	if err := h.jobRepo.Create(ctx, job); err != nil {
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to create job")
+		return
+	}
+
 	// Marshal the job payload data
 	payload := jobs.ExportPayload{
-		UserID: user.Id,
-		Format: string(models.FormatPDF),
+		UserID:   user.Id,
+		Format:   string(models.FormatPDF),
+		Language: locale.FromContext(ctx).Language().String(),
 	}
 	data, err := json.Marshal(payload)
 	if err != nil {
@@ -92,10 +132,12 @@ func (h *ExportHandler) EnqueuePDFExport(w http.ResponseWriter, r *http.Request)
 
 	// Enqueue the job
 	jobPayload := queueTypes.JobPayload{
-		Event: queueTypes.EventGenerateExport,
-		Data:  data,
+		Event:     queueTypes.EventGenerateExport,
+		Data:      data,
+		JobID:     job.ID,
+		MessageID: record.ID,
 	}
-	if _, err := h.queueProvider.Enqueue(ctx, queueTypes.InboxQueue, jobPayload); err != nil {
+	if _, err := h.queueProvider.Enqueue(ctx, queueTypes.QueueFor(jobPayload.Event), jobPayload); err != nil {
 		response.Fail(w, r, http.StatusInternalServerError, "Failed to enqueue export job")
 		return
 	}
@@ -120,14 +162,16 @@ func (h *ExportHandler) GetJobStatus(w http.ResponseWriter, r *http.Request) {
 	response.Success(w, r, http.StatusOK, record)
 }
 
-// GetDownloadURL generates a presigned URL for a completed export.
+// GetDownloadURL returns a signed, expiring URL of the files handler for a
+// completed export, which downloads it as activelog-export-<date>.<format>.
 func (h *ExportHandler) GetDownloadURL(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
 	vars := mux.Vars(r)
 	jobID := vars["jobId"]
 
 	record, err := h.exportRepo.GetByID(ctx, jobID)
-	if err != nil {
+	if err != nil || record.UserID != user.Id {
 		response.Fail(w, r, http.StatusNotFound, "Export job not found")
 		return
 	}
@@ -142,17 +186,82 @@ func (h *ExportHandler) GetDownloadURL(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	url, err := h.storage.GetPresignedURL(ctx, &storageTypes.PresignedURLInput{
-		Key:       *record.S3Key,
-		ExpiresIn: 15 * time.Minute,
-		Operation: storageTypes.PresignGet,
+	filename := fmt.Sprintf("activelog-export-%s.%s", record.CreatedAt.Format(time.DateOnly), record.Format)
+	response.Success(w, r, http.StatusOK, map[string]string{
+		"download_url": fileurl.For(*record.S3Key, filename, h.clock.Now()),
 	})
+}
+
+// activityFileFormats are the file formats of ExportActivity with their
+// writer and content type
+var activityFileFormats = map[string]struct {
+	write       func(context.Context, *models.Activity, io.Writer) error
+	contentType string
+}{
+	service.ActivityFileGPX: {service.ExportActivityGPX, "application/gpx+xml"},
+	service.ActivityFileTCX: {service.ExportActivityTCX, "application/vnd.garmin.tcx+xml"},
+}
+
+// ExportActivity streams one activity as a GPX or TCX file.
+// @Summary Export an activity as GPX or TCX
+// @Description Downloads the activity as a file other fitness platforms (Strava, Garmin Connect) import. GPX holds a track with the start and end positions; TCX holds one lap per split, with its distance, time, calories and average heart rate, and the positions as track points. Activities store no GPS track, so there are no points in between. The file is meant to be uploaded elsewhere, so start and end points within the user's privacy zones are left out.
+// @Tags Activities
+// @Produce application/gpx+xml,application/vnd.garmin.tcx+xml
+// @Param id path string true "Activity public ID or serial ID"
+// @Param format query string false "File format" Enums(gpx, tcx) default(gpx)
+// @Success 200 {file} file "activity-<id>.gpx or .tcx"
+// @Failure 400 {object} map[string]string "Invalid activity ID or format"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Activity not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @ID ExportActivity
+// @Router /api/v1/activities/{id}/export [get]
+func (h *ExportHandler) ExportActivity(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	name := r.URL.Query().Get("format")
+	if name == "" {
+		name = service.ActivityFileGPX
+	}
+	format, ok := activityFileFormats[name]
+	if !ok {
+		response.Fail(w, r, http.StatusBadRequest, "Unsupported format: "+name+" (gpx or tcx)")
+		return
+	}
+
+	activityID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
 	if err != nil {
-		response.Fail(w, r, http.StatusInternalServerError, "Failed to generate download URL")
+		response.Fail(w, r, http.StatusBadRequest, "Invalid activity ID")
 		return
 	}
 
-	response.Success(w, r, http.StatusOK, map[string]string{
-		"download_url": url,
-	})
+	activity, err := h.activityRepo.GetByID(ctx, activityID)
+	if err == nil && (activity.UserID != user.Id || activity.DeletedAt != nil) {
+		err = appErrors.ErrNotFound
+	}
+	if err == nil {
+		activity.Splits, err = h.activityRepo.GetSplits(ctx, activityID)
+	}
+	if err != nil {
+		if errors.Is(err, appErrors.ErrNotFound) {
+			response.Fail(w, r, http.StatusNotFound, "Activity not found")
+			return
+		}
+		log.Error().Err(err).Int64("activityID", activityID).Msg("Failed to fetch activity")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to fetch activity")
+		return
+	}
+	if !redactForViewer(w, r, h.zoneRepo, user.Id, []*models.Activity{activity}) {
+		return
+	}
+
+	w.Header().Set("Content-Type", format.contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="activity-%s.%s"`, activity.PublicID, name))
+
+	if err := format.write(ctx, activity, w); err != nil {
+		// The file has started, so the response can't become an error
+		log.Error().Err(err).Int64("activityID", activityID).Str("format", name).Msg("Failed to write activity file")
+	}
 }
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/valentinesamuel/activelog/internal/adapters/storage/fileurl"
+	storageTypes "github.com/valentinesamuel/activelog/internal/adapters/storage/types"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// FileHandler serves stored photos and exports through the signed, expiring
+// URLs of package fileurl. The signature is the authorization, so the route
+// needs no login and the URLs work in <img> tags and plain downloads.
+type FileHandler struct {
+	storage storageTypes.StorageProvider
+	clock   clock.Clock
+}
+
+// NewFileHandler creates a new FileHandler
+func NewFileHandler(storage storageTypes.StorageProvider) *FileHandler {
+	return &FileHandler{storage: storage, clock: clock.Real{}}
+}
+
+// WithClock sets the clock file URLs expire by; it must be the one they are
+// signed with
+func (h *FileHandler) WithClock(c clock.Clock) *FileHandler {
+	h.clock = c
+	return h
+}
+
+// ServeFile handles GET /api/v1/files/{key}
+// @Summary Download a stored file
+// @Description Serves a photo or export through a signed URL handed out by the API, such as a photo's url or an export's download_url. The URL expires (STORAGE_FILE_URL_TTL_SECONDS) and any change to it invalidates the signature. Local storage serves the file with range requests, so large exports can be resumed; S3 redirects to a presigned URL. URLs with a filename download under that name, the others display inline.
+// @Tags Files
+// @Produce octet-stream
+// @Param key path string true "Storage key, base64url-encoded"
+// @Param expires query int true "Expiry, in Unix seconds"
+// @Param filename query string false "Name to download the file as"
+// @Param signature query string true "Signature"
+// @Success 200 {file} file "The file"
+// @Success 206 {file} file "The requested range of the file"
+// @Success 302 "Redirect to a presigned storage URL"
+// @Failure 403 {object} map[string]string "Invalid or expired signature"
+// @Failure 404 {object} map[string]string "File not found"
+// @Failure 416 "Range not satisfiable"
+// @Failure 503 {object} map[string]string "Storage is not configured"
+// @Router /api/v1/files/{key} [get]
+func (h *FileHandler) ServeFile(w http.ResponseWriter, r *http.Request) {
+	key, err := fileurl.DecodeKey(mux.Vars(r)["key"])
+	if err != nil {
+		response.Fail(w, r, http.StatusForbidden, "Invalid file URL")
+		return
+	}
+	expires, filename, err := fileurl.Verify(key, r.URL.Query(), h.clock.Now())
+	if errors.Is(err, fileurl.ErrExpired) {
+		response.Fail(w, r, http.StatusForbidden, "File URL has expired")
+		return
+	}
+	if err != nil {
+		response.Fail(w, r, http.StatusForbidden, "Invalid file URL")
+		return
+	}
+	if h.storage == nil {
+		response.Fail(w, r, http.StatusServiceUnavailable, "Storage is not configured")
+		return
+	}
+
+	opener, ok := h.storage.(storageTypes.FileOpener)
+	if !ok {
+		h.redirect(w, r, key, filename, expires)
+		return
+	}
+
+	file, meta, err := opener.Open(r.Context(), key)
+	if errors.Is(err, storageTypes.ErrNotFound) {
+		response.Fail(w, r, http.StatusNotFound, "File not found")
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("key", key).Msg("Failed to open file")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to open file")
+		return
+	}
+	defer file.Close()
+
+	disposition := "inline"
+	if filename != "" {
+		disposition = mime.FormatMediaType("attachment", map[string]string{"filename": filename})
+	}
+	w.Header().Set("Content-Type", meta.ContentType)
+	w.Header().Set("Content-Disposition", disposition)
+	// Cacheable by the browser for as long as the URL is valid
+	w.Header().Set("Cache-Control", "private, max-age="+strconv.Itoa(int(expires.Sub(h.clock.Now())/time.Second)))
+	http.ServeContent(w, r, "", meta.LastModified, file)
+}
+
+// redirect sends the client to a presigned URL of the storage provider,
+// valid for what is left of the signed URL
+func (h *FileHandler) redirect(w http.ResponseWriter, r *http.Request, key, filename string, expires time.Time) {
+	url, err := h.storage.GetPresignedURL(r.Context(), &storageTypes.PresignedURLInput{
+		Key:       key,
+		ExpiresIn: max(expires.Sub(h.clock.Now()), time.Minute),
+		Operation: storageTypes.PresignGet,
+		Filename:  filename,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("key", key).Msg("Failed to presign file URL")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to generate download URL")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, url, http.StatusFound)
+}
@@ -0,0 +1,154 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/storage/fileurl"
+	"github.com/valentinesamuel/activelog/internal/adapters/storage/local"
+	storageTypes "github.com/valentinesamuel/activelog/internal/adapters/storage/types"
+	"github.com/valentinesamuel/activelog/internal/handlers"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+)
+
+func setupFileURLs(t *testing.T) {
+	t.Helper()
+	config.Common = &config.CommonConfig{Auth: config.AuthConfig{JWTSecret: "test-secret"}}
+	config.Storage = &config.StorageConfigType{FilesBaseURL: "http://api.test", FileURLTTL: 15 * time.Minute}
+}
+
+// serveFile requests a signed URL through the files handler
+func serveFile(t *testing.T, h *handlers.FileHandler, signedURL string, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	u, err := url.Parse(signedURL)
+	require.NoError(t, err)
+	r := httptest.NewRequest(http.MethodGet, u.RequestURI(), nil)
+	for key, values := range header {
+		r.Header[key] = values
+	}
+	r = mux.SetURLVars(r, map[string]string{"key": strings.TrimPrefix(u.Path, fileurl.Path)})
+	w := httptest.NewRecorder()
+	h.ServeFile(w, r)
+	return w
+}
+
+func TestFileHandler_ServeFile_Local(t *testing.T) {
+	setupFileURLs(t)
+	storage, err := local.NewAt(t.TempDir())
+	require.NoError(t, err)
+	csv := "id,title\n1,Morning run\n2,Evening ride\n"
+	_, err = storage.Upload(context.Background(), &storageTypes.UploadInput{Key: "exports/7/abc.csv", Body: strings.NewReader(csv)})
+	require.NoError(t, err)
+	clk := clock.NewFake(time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC))
+	h := handlers.NewFileHandler(storage).WithClock(clk)
+
+	t.Run("download under the signed filename", func(t *testing.T) {
+		w := serveFile(t, h, fileurl.For("exports/7/abc.csv", "activelog-export-2026-03-01.csv", clk.Now()), nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, csv, w.Body.String())
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename=activelog-export-2026-03-01.csv`, w.Header().Get("Content-Disposition"))
+		assert.Equal(t, "bytes", w.Header().Get("Accept-Ranges"))
+	})
+
+	t.Run("inline without a filename", func(t *testing.T) {
+		w := serveFile(t, h, fileurl.For("exports/7/abc.csv", "", clk.Now()), nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "inline", w.Header().Get("Content-Disposition"))
+	})
+
+	t.Run("range request", func(t *testing.T) {
+		w := serveFile(t, h, fileurl.For("exports/7/abc.csv", "", clk.Now()), http.Header{"Range": {"bytes=9-21"}})
+
+		assert.Equal(t, http.StatusPartialContent, w.Code)
+		assert.Equal(t, "1,Morning run", w.Body.String())
+		assert.Equal(t, "bytes 9-21/38", w.Header().Get("Content-Range"))
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		w := serveFile(t, h, fileurl.For("exports/7/missing.csv", "", clk.Now()), nil)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("expired URL", func(t *testing.T) {
+		w := serveFile(t, h, fileurl.Build("exports/7/abc.csv", "", clk.Now().Add(-time.Second)), nil)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "expired")
+	})
+
+	t.Run("tampered URLs", func(t *testing.T) {
+		signed := fileurl.For("exports/7/abc.csv", "export.csv", clk.Now())
+		tamper := func(change func(u *url.URL, q url.Values)) string {
+			u, err := url.Parse(signed)
+			require.NoError(t, err)
+			q := u.Query()
+			change(u, q)
+			u.RawQuery = q.Encode()
+			return u.String()
+		}
+
+		for name, tampered := range map[string]string{
+			"key":      tamper(func(u *url.URL, q url.Values) { u.Path = fileurl.Path + fileurl.EncodeKey("exports/8/abc.csv") }),
+			"filename": tamper(func(u *url.URL, q url.Values) { q.Set("filename", "other.csv") }),
+			"expiry": tamper(func(u *url.URL, q url.Values) {
+				q.Set("expires", strconv.FormatInt(clk.Now().Add(time.Hour).Unix(), 10))
+			}),
+			"signature": tamper(func(u *url.URL, q url.Values) { q.Del("signature") }),
+		} {
+			w := serveFile(t, h, tampered, nil)
+			assert.Equal(t, http.StatusForbidden, w.Code, name)
+		}
+	})
+
+	t.Run("expires on the clock", func(t *testing.T) {
+		signed := fileurl.For("exports/7/abc.csv", "", clk.Now())
+		clk.Advance(config.Storage.FileURLTTL + time.Second)
+
+		w := serveFile(t, h, signed, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "expired")
+	})
+}
+
+// redirectStorage is a provider that can't serve files itself, like S3
+type redirectStorage struct {
+	storageTypes.StorageProvider
+	input *storageTypes.PresignedURLInput
+}
+
+func (s *redirectStorage) GetPresignedURL(ctx context.Context, input *storageTypes.PresignedURLInput) (string, error) {
+	s.input = input
+	return "https://bucket.s3.test/" + input.Key + "?X-Amz-Signature=abc", nil
+}
+
+func TestFileHandler_ServeFile_RedirectsToPresignedURL(t *testing.T) {
+	setupFileURLs(t)
+	storage := &redirectStorage{}
+	clk := clock.NewFake(time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC))
+	h := handlers.NewFileHandler(storage).WithClock(clk)
+
+	w := serveFile(t, h, fileurl.For("activities/3/photos/p.jpg", "run.jpg", clk.Now()), nil)
+
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "https://bucket.s3.test/activities/3/photos/p.jpg?X-Amz-Signature=abc", w.Header().Get("Location"))
+	require.NotNil(t, storage.input)
+	assert.Equal(t, "run.jpg", storage.input.Filename)
+	assert.Equal(t, storageTypes.PresignGet, storage.input.Operation)
+	assert.Equal(t, 15*time.Minute, storage.input.ExpiresIn)
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+}
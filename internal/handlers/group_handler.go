@@ -0,0 +1,415 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/valentinesamuel/activelog/internal/models"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/platform/validator"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// GroupHandler handles group membership and leaderboard endpoints
+type GroupHandler struct {
+	groupRepo *repository.GroupRepository
+}
+
+// NewGroupHandler creates a new GroupHandler
+func NewGroupHandler(groupRepo *repository.GroupRepository) *GroupHandler {
+	return &GroupHandler{groupRepo: groupRepo}
+}
+
+type addGroupMemberRequest struct {
+	UserID int `json:"user_id"`
+}
+
+// CreateGroup handles POST /api/v1/groups
+// @Summary Create a group
+// @Description Creates a leaderboard group owned by the authenticated user
+// @Tags Groups
+// @Accept json
+// @Produce json
+// @Param request body models.CreateGroupRequest true "Group creation request"
+// @Success 201 {object} models.Group "Created group"
+// @Failure 400 {object} map[string]interface{} "Validation error"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Security BearerAuth
+// @Router /api/v1/groups [post]
+func (h *GroupHandler) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	var req models.CreateGroupRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
+		return
+	}
+
+	group := &models.Group{
+		OwnerID:     user.Id,
+		Name:        req.Name,
+		Description: req.Description,
+		IsPrivate:   req.IsPrivate,
+	}
+	if err := h.groupRepo.Create(ctx, group); err != nil {
+		log.Error().Err(err).Msg("Failed to create group")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to create group")
+		return
+	}
+
+	response.Success(w, r, http.StatusCreated, group)
+}
+
+// ListMyGroups handles GET /api/v1/groups
+// @Summary List my groups
+// @Description Returns every group the authenticated user belongs to
+// @Tags Groups
+// @Produce json
+// @Success 200 {array} models.Group "Groups"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Security BearerAuth
+// @Router /api/v1/groups [get]
+func (h *GroupHandler) ListMyGroups(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	groups, err := h.groupRepo.ListByUser(ctx, user.Id)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list groups")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to list groups")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, groups)
+}
+
+// GetGroup handles GET /api/v1/groups/{id}
+// @Summary Get a group
+// @Description Returns a group; private groups are only visible to their members
+// @Tags Groups
+// @Produce json
+// @Param id path int true "Group ID"
+// @Success 200 {object} models.Group "Group"
+// @Failure 404 {object} map[string]string "Group not found"
+// @Security BearerAuth
+// @Router /api/v1/groups/{id} [get]
+func (h *GroupHandler) GetGroup(w http.ResponseWriter, r *http.Request) {
+	group, _, ok := h.loadVisibleGroup(w, r)
+	if !ok {
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, group)
+}
+
+// ListMembers handles GET /api/v1/groups/{id}/members
+// @Summary List group members
+// @Tags Groups
+// @Produce json
+// @Param id path int true "Group ID"
+// @Success 200 {array} models.GroupMember "Members"
+// @Failure 404 {object} map[string]string "Group not found"
+// @Security BearerAuth
+// @Router /api/v1/groups/{id}/members [get]
+func (h *GroupHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	group, _, ok := h.loadVisibleGroup(w, r)
+	if !ok {
+		return
+	}
+
+	members, err := h.groupRepo.ListMembers(r.Context(), group.ID)
+	if err != nil {
+		log.Error().Err(err).Int64("groupId", group.ID).Msg("Failed to list group members")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to list group members")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, members)
+}
+
+// AddMember handles POST /api/v1/groups/{id}/members
+// An empty body (or the caller's own user_id) joins a public group.
+// Adding anyone else - and joining a private group - requires the group owner.
+// @Summary Join a group or add a member
+// @Tags Groups
+// @Accept json
+// @Produce json
+// @Param id path int true "Group ID"
+// @Param request body addGroupMemberRequest false "User to add (defaults to the caller)"
+// @Success 201 {object} models.GroupMember "Membership"
+// @Failure 403 {object} map[string]string "Only the owner can add members"
+// @Failure 404 {object} map[string]string "Group not found"
+// @Failure 409 {object} map[string]string "Already a member"
+// @Security BearerAuth
+// @Router /api/v1/groups/{id}/members [post]
+func (h *GroupHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	groupID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid group ID")
+		return
+	}
+
+	var req addGroupMemberRequest
+	if r.ContentLength != 0 {
+		if !decodeJSON(w, r, &req) {
+			return
+		}
+	}
+	if req.UserID == 0 {
+		req.UserID = user.Id
+	}
+
+	group, err := h.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		h.failGroupLookup(w, r, err)
+		return
+	}
+
+	isOwner := group.OwnerID == user.Id
+	if (req.UserID != user.Id || group.IsPrivate) && !isOwner {
+		if group.IsPrivate {
+			// Don't reveal private groups to non-members
+			if _, err := h.groupRepo.GetMember(ctx, groupID, user.Id); err != nil {
+				response.Fail(w, r, http.StatusNotFound, "Group not found")
+				return
+			}
+		}
+		response.Fail(w, r, http.StatusForbidden, "Only the group owner can add members")
+		return
+	}
+
+	if err := h.groupRepo.AddMember(ctx, groupID, req.UserID, models.GroupRoleMember); err != nil {
+		if errors.Is(err, appErrors.ErrAlreadyExists) {
+			response.Fail(w, r, http.StatusConflict, "User is already a member of this group")
+			return
+		}
+		if errors.Is(err, appErrors.ErrInvalidInput) {
+			response.Fail(w, r, http.StatusBadRequest, "User does not exist")
+			return
+		}
+		log.Error().Err(err).Int64("groupId", groupID).Msg("Failed to add group member")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to add group member")
+		return
+	}
+
+	member, err := h.groupRepo.GetMember(ctx, groupID, req.UserID)
+	if err != nil {
+		log.Error().Err(err).Int64("groupId", groupID).Msg("Failed to fetch new group member")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to add group member")
+		return
+	}
+
+	response.Success(w, r, http.StatusCreated, member)
+}
+
+// RemoveMember handles DELETE /api/v1/groups/{id}/members/{userId}
+// Members may remove themselves; the owner may remove anyone but themselves.
+// @Summary Leave a group or remove a member
+// @Tags Groups
+// @Param id path int true "Group ID"
+// @Param userId path string true "User public ID or serial ID"
+// @Success 204 "Member removed"
+// @Failure 403 {object} map[string]string "Only the owner can remove other members"
+// @Failure 404 {object} map[string]string "Membership not found"
+// @Failure 409 {object} map[string]string "The owner cannot leave their own group"
+// @Security BearerAuth
+// @Router /api/v1/groups/{id}/members/{userId} [delete]
+func (h *GroupHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+	vars := mux.Vars(r)
+
+	groupID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid group ID")
+		return
+	}
+	targetID, err := strconv.Atoi(vars["userId"])
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	group, err := h.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		h.failGroupLookup(w, r, err)
+		return
+	}
+
+	if targetID == group.OwnerID {
+		response.Fail(w, r, http.StatusConflict, "The owner cannot leave their own group")
+		return
+	}
+	if targetID != user.Id && group.OwnerID != user.Id {
+		response.Fail(w, r, http.StatusForbidden, "Only the group owner can remove other members")
+		return
+	}
+
+	if err := h.groupRepo.RemoveMember(ctx, groupID, targetID); err != nil {
+		if errors.Is(err, appErrors.ErrNotFound) {
+			response.Fail(w, r, http.StatusNotFound, "Membership not found")
+			return
+		}
+		log.Error().Err(err).Int64("groupId", groupID).Msg("Failed to remove group member")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to remove group member")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UpdateMyMembership handles PATCH /api/v1/groups/{id}/members/me
+// @Summary Update leaderboard privacy for a group
+// @Description Hides or shows the caller on the group's leaderboard
+// @Tags Groups
+// @Accept json
+// @Produce json
+// @Param id path int true "Group ID"
+// @Param request body models.UpdateGroupMembershipRequest true "Privacy flags"
+// @Success 200 {object} models.GroupMember "Updated membership"
+// @Failure 404 {object} map[string]string "Membership not found"
+// @Security BearerAuth
+// @Router /api/v1/groups/{id}/members/me [patch]
+func (h *GroupHandler) UpdateMyMembership(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	groupID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid group ID")
+		return
+	}
+
+	var req models.UpdateGroupMembershipRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
+		return
+	}
+
+	if err := h.groupRepo.SetLeaderboardVisibility(ctx, groupID, user.Id, *req.ShowOnLeaderboard); err != nil {
+		if errors.Is(err, appErrors.ErrNotFound) {
+			response.Fail(w, r, http.StatusNotFound, "Membership not found")
+			return
+		}
+		log.Error().Err(err).Int64("groupId", groupID).Msg("Failed to update group membership")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to update membership")
+		return
+	}
+
+	member, err := h.groupRepo.GetMember(ctx, groupID, user.Id)
+	if err != nil {
+		log.Error().Err(err).Int64("groupId", groupID).Msg("Failed to fetch group membership")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to update membership")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, member)
+}
+
+// GetLeaderboard handles GET /api/v1/groups/{id}/leaderboard
+// @Summary Get a group's weekly leaderboard
+// @Description Ranks members by distance or duration for the current week. Members who opted out are hidden from everyone but themselves.
+// @Tags Groups
+// @Produce json
+// @Param id path int true "Group ID"
+// @Param metric query string false "distance or duration (default: distance)"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 10, max: 100)"
+// @Success 200 {object} paginatedResponse{data=[]models.LeaderboardEntry} "Paginated leaderboard with metadata"
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 404 {object} map[string]string "Group not found"
+// @Security BearerAuth
+// @ID GetGroupLeaderboard
+// @Router /api/v1/groups/{id}/leaderboard [get]
+func (h *GroupHandler) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
+	group, user, ok := h.loadVisibleGroup(w, r)
+	if !ok {
+		return
+	}
+
+	params := r.URL.Query()
+	metric := params.Get("metric")
+	if metric == "" {
+		metric = "distance"
+	}
+	if !repository.IsValidLeaderboardMetric(metric) {
+		response.Fail(w, r, http.StatusBadRequest, "metric must be one of: distance, duration")
+		return
+	}
+
+	page, limit := 1, 10
+	if v, err := strconv.Atoi(params.Get("page")); err == nil && v > 0 {
+		page = v
+	}
+	if v, err := strconv.Atoi(params.Get("limit")); err == nil && v > 0 {
+		if v > 100 {
+			v = 100
+		}
+		limit = v
+	}
+
+	result, err := h.groupRepo.GetWeeklyLeaderboard(r.Context(), group.ID, user.Id, metric, page, limit)
+	if err != nil {
+		log.Error().Err(err).Int64("groupId", group.ID).Msg("Failed to get leaderboard")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to get leaderboard")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, map[string]interface{}{
+		"data": result.Data,
+		"meta": paginationMeta(w, r, result.Meta),
+	})
+}
+
+// loadVisibleGroup resolves {id} and enforces that private groups are only visible to members.
+// Writes the failure response itself and returns ok=false when the caller should stop.
+func (h *GroupHandler) loadVisibleGroup(w http.ResponseWriter, r *http.Request) (*models.Group, *requestcontext.User, bool) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	groupID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid group ID")
+		return nil, nil, false
+	}
+
+	group, err := h.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		h.failGroupLookup(w, r, err)
+		return nil, nil, false
+	}
+
+	if group.IsPrivate {
+		if _, err := h.groupRepo.GetMember(ctx, groupID, user.Id); err != nil {
+			// Report private groups as missing so their existence isn't leaked
+			h.failGroupLookup(w, r, err)
+			return nil, nil, false
+		}
+	}
+
+	return group, user, true
+}
+
+func (h *GroupHandler) failGroupLookup(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, appErrors.ErrNotFound) {
+		response.Fail(w, r, http.StatusNotFound, "Group not found")
+		return
+	}
+	log.Error().Err(err).Msg("Failed to fetch group")
+	response.Fail(w, r, http.StatusInternalServerError, "Failed to fetch group")
+}
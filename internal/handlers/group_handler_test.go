@@ -0,0 +1,226 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valentinesamuel/activelog/internal/handlers"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/repository/testhelpers"
+)
+
+var (
+	groupRows       = []string{"id", "owner_id", "name", "description", "is_private", "created_at", "updated_at", "member_count"}
+	groupMemberRows = []string{"group_id", "user_id", "username", "role", "show_on_leaderboard", "joined_at"}
+	rankRows        = []string{"rank", "user_id", "username", "total_distance", "total_duration", "activity_count", "total_records"}
+)
+
+func newGroupHandler(t *testing.T) (*handlers.GroupHandler, sqlmock.Sqlmock) {
+	db, mock := testhelpers.SetupMockDB(t)
+	return handlers.NewGroupHandler(repository.NewGroupRepository(db)), mock
+}
+
+// groupRequest is a request by userID for group 4
+func groupRequest(method, target, body string, userID int) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req = req.WithContext(requestcontext.NewContext(req.Context(), &requestcontext.User{Id: userID}))
+	return mux.SetURLVars(req, map[string]string{"id": "4"})
+}
+
+// expectGroup expects group 4, owned by user 1, to be looked up
+func expectGroup(mock sqlmock.Sqlmock, private bool) {
+	now := time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`FROM groups g\s+WHERE g.id = \$1 AND g.deleted_at IS NULL`).
+		WithArgs(int64(4)).
+		WillReturnRows(sqlmock.NewRows(groupRows).AddRow(4, 1, "Club", "", private, now, now, 3))
+}
+
+// expectMember expects userID's membership of group 4 to be looked up
+func expectMember(mock sqlmock.Sqlmock, userID int, member bool, showOnLeaderboard bool) {
+	rows := sqlmock.NewRows(groupMemberRows)
+	if member {
+		rows.AddRow(4, userID, "runner", "member", showOnLeaderboard, time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC))
+	}
+	mock.ExpectQuery(`FROM group_members gm\s+INNER JOIN users u ON u.id = gm.user_id\s+WHERE gm.group_id = \$1 AND gm.user_id = \$2`).
+		WithArgs(int64(4), userID).
+		WillReturnRows(rows)
+}
+
+func TestGroupHandler_GetLeaderboard(t *testing.T) {
+	t.Run("paginated", func(t *testing.T) {
+		h, mock := newGroupHandler(t)
+		expectGroup(mock, false)
+		mock.ExpectQuery(`ORDER BY total_duration DESC`).
+			WithArgs(int64(4), 7, 2, 2).
+			WillReturnRows(sqlmock.NewRows(rankRows).AddRow(3, 7, "runner", 4.2, 30, 1, 5).AddRow(4, 9, "walker", 2.0, 25, 1, 5))
+
+		w := httptest.NewRecorder()
+		h.GetLeaderboard(w, groupRequest(http.MethodGet, "/api/v1/groups/4/leaderboard?metric=duration&page=2&limit=2", "", 7))
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var body struct {
+			Result struct {
+				Data []struct {
+					Rank   int `json:"rank"`
+					UserID int `json:"user_id"`
+				} `json:"data"`
+				Meta struct {
+					Page         int `json:"page"`
+					PageCount    int `json:"pageCount"`
+					TotalRecords int `json:"totalRecords"`
+					NextPage     any `json:"nextPage"`
+				} `json:"meta"`
+			} `json:"result"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		require.Len(t, body.Result.Data, 2)
+		assert.Equal(t, 3, body.Result.Data[0].Rank)
+		assert.Equal(t, 9, body.Result.Data[1].UserID)
+		assert.Equal(t, 2, body.Result.Meta.Page)
+		assert.Equal(t, 3, body.Result.Meta.PageCount)
+		assert.Equal(t, 5, body.Result.Meta.TotalRecords)
+		assert.Equal(t, float64(3), body.Result.Meta.NextPage)
+	})
+
+	t.Run("defaults and limit cap", func(t *testing.T) {
+		tests := []struct {
+			query     string
+			wantLimit int
+		}{
+			{"", 10},
+			{"?page=0&limit=-5", 10},
+			{"?page=abc&limit=abc", 10},
+			{"?limit=500", 100},
+		}
+		for _, tt := range tests {
+			h, mock := newGroupHandler(t)
+			expectGroup(mock, false)
+			mock.ExpectQuery(`ORDER BY total_distance DESC`).
+				WithArgs(int64(4), 7, tt.wantLimit, 0).
+				WillReturnRows(sqlmock.NewRows(rankRows))
+
+			w := httptest.NewRecorder()
+			h.GetLeaderboard(w, groupRequest(http.MethodGet, "/api/v1/groups/4/leaderboard"+tt.query, "", 7))
+
+			assert.Equal(t, http.StatusOK, w.Code, tt.query)
+		}
+	})
+
+	t.Run("unsupported metric", func(t *testing.T) {
+		h, mock := newGroupHandler(t)
+		expectGroup(mock, false)
+
+		w := httptest.NewRecorder()
+		h.GetLeaderboard(w, groupRequest(http.MethodGet, "/api/v1/groups/4/leaderboard?metric=calories", "", 7))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "metric must be one of: distance, duration")
+	})
+
+	t.Run("member of a private group", func(t *testing.T) {
+		h, mock := newGroupHandler(t)
+		expectGroup(mock, true)
+		expectMember(mock, 7, true, false)
+		// The viewer's ID goes in so a member who opted out still sees themselves
+		mock.ExpectQuery(`AND \(gm.show_on_leaderboard OR gm.user_id = \$2\)`).
+			WithArgs(int64(4), 7, 10, 0).
+			WillReturnRows(sqlmock.NewRows(rankRows).AddRow(1, 7, "runner", 4.2, 30, 1, 1))
+
+		w := httptest.NewRecorder()
+		h.GetLeaderboard(w, groupRequest(http.MethodGet, "/api/v1/groups/4/leaderboard", "", 7))
+
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+
+	t.Run("non-member of a private group", func(t *testing.T) {
+		h, mock := newGroupHandler(t)
+		expectGroup(mock, true)
+		expectMember(mock, 8, false, false)
+
+		w := httptest.NewRecorder()
+		h.GetLeaderboard(w, groupRequest(http.MethodGet, "/api/v1/groups/4/leaderboard", "", 8))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "Group not found")
+	})
+
+	t.Run("invalid group ID", func(t *testing.T) {
+		h, _ := newGroupHandler(t)
+		req := mux.SetURLVars(groupRequest(http.MethodGet, "/api/v1/groups/club/leaderboard", "", 7), map[string]string{"id": "club"})
+
+		w := httptest.NewRecorder()
+		h.GetLeaderboard(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGroupHandler_UpdateMyMembership(t *testing.T) {
+	t.Run("opt out", func(t *testing.T) {
+		h, mock := newGroupHandler(t)
+		mock.ExpectExec(`UPDATE group_members\s+SET show_on_leaderboard = \$3`).
+			WithArgs(int64(4), 7, false).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectMember(mock, 7, true, false)
+
+		w := httptest.NewRecorder()
+		h.UpdateMyMembership(w, groupRequest(http.MethodPatch, "/api/v1/groups/4/members/me", `{"show_on_leaderboard":false}`, 7))
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), `"show_on_leaderboard":false`)
+	})
+
+	t.Run("non-member", func(t *testing.T) {
+		h, mock := newGroupHandler(t)
+		mock.ExpectExec(`UPDATE group_members`).
+			WithArgs(int64(4), 8, false).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		w := httptest.NewRecorder()
+		h.UpdateMyMembership(w, groupRequest(http.MethodPatch, "/api/v1/groups/4/members/me", `{"show_on_leaderboard":false}`, 8))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("flag required", func(t *testing.T) {
+		h, _ := newGroupHandler(t)
+
+		w := httptest.NewRecorder()
+		h.UpdateMyMembership(w, groupRequest(http.MethodPatch, "/api/v1/groups/4/members/me", `{}`, 7))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGroupHandler_AddMember_NonMember(t *testing.T) {
+	t.Run("can't add others to a public group", func(t *testing.T) {
+		h, mock := newGroupHandler(t)
+		expectGroup(mock, false)
+
+		w := httptest.NewRecorder()
+		h.AddMember(w, groupRequest(http.MethodPost, "/api/v1/groups/4/members", `{"user_id":9}`, 8))
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("can't join a private group", func(t *testing.T) {
+		h, mock := newGroupHandler(t)
+		expectGroup(mock, true)
+		expectMember(mock, 8, false, false)
+
+		w := httptest.NewRecorder()
+		h.AddMember(w, groupRequest(http.MethodPost, "/api/v1/groups/4/members", "", 8))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
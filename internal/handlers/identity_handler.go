@@ -0,0 +1,338 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	identityTypes "github.com/valentinesamuel/activelog/internal/adapters/identity/types"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/pkg/auth"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+var (
+	// errNoProviderEmail is returned when the provider didn't share an email
+	errNoProviderEmail = errors.New("identity provider did not share an email address")
+	// errUnverifiedEmail is returned when an existing account uses the
+	// provider's email but the provider hasn't verified that the user owns it
+	errUnverifiedEmail = errors.New("email not verified by identity provider")
+)
+
+// IdentityHandler serves social login (OAuth2 authorization code flow) and
+// the management of the identities linked to a user
+type IdentityHandler struct {
+	providers    *identityTypes.Registry
+	userRepo     *repository.UserRepository
+	identityRepo *repository.IdentityRepository
+	clock        clock.Clock
+}
+
+// IdentityHandlerDeps contains the dependencies for IdentityHandler.
+type IdentityHandlerDeps struct {
+	Providers    *identityTypes.Registry
+	UserRepo     *repository.UserRepository
+	IdentityRepo *repository.IdentityRepository
+	Clock        clock.Clock // login tokens expire by it; nil uses the real clock
+}
+
+// NewIdentityHandler creates a new IdentityHandler with the given dependencies.
+func NewIdentityHandler(deps IdentityHandlerDeps) *IdentityHandler {
+	clk := deps.Clock
+	if clk == nil {
+		clk = clock.Real{}
+	}
+	return &IdentityHandler{
+		providers:    deps.Providers,
+		userRepo:     deps.UserRepo,
+		identityRepo: deps.IdentityRepo,
+		clock:        clk,
+	}
+}
+
+// ListProviders handles GET /api/v1/auth/providers
+// @Summary List social login providers
+// @Description Returns the identity providers users can log in with.
+// @Tags Users
+// @Produce json
+// @Success 200 {object} map[string][]string "Provider names"
+// @Router /api/v1/auth/providers [get]
+func (h *IdentityHandler) ListProviders(w http.ResponseWriter, r *http.Request) {
+	response.Success(w, r, http.StatusOK, map[string]interface{}{
+		"providers": h.providers.Names(),
+	})
+}
+
+// Login handles GET /api/v1/auth/{provider}/login
+// @Summary Start a social login
+// @Description Redirects the browser to the provider's consent page. The provider redirects back to the callback, which logs the user in. session=cookie opens a cookie session (AUTH_COOKIE_SESSIONS) and ends with a redirect to OAUTH_SUCCESS_REDIRECT_URL.
+// @Tags Users
+// @Param provider path string true "Provider" Enums(google, github, apple)
+// @Param session query string false "Session mode" Enums(bearer, cookie)
+// @Success 302 "Redirect to the provider"
+// @Failure 400 {object} map[string]string "Invalid session mode"
+// @Failure 404 {object} map[string]string "Unknown provider"
+// @Router /api/v1/auth/{provider}/login [get]
+func (h *IdentityHandler) Login(w http.ResponseWriter, r *http.Request) {
+	provider, ok := h.provider(w, r)
+	if !ok {
+		return
+	}
+
+	session := r.URL.Query().Get("session")
+	switch session {
+	case "":
+		session = models.SessionBearer
+	case models.SessionBearer:
+	case models.SessionCookie:
+		if !auth.CookieSessionsEnabled() {
+			response.Fail(w, r, http.StatusBadRequest, "Cookie sessions are disabled")
+			return
+		}
+	default:
+		response.Fail(w, r, http.StatusBadRequest, "session must be bearer or cookie")
+		return
+	}
+
+	state, err := auth.StartOAuthLogin(w, provider.Name(), session)
+	if err != nil {
+		log.Error().Err(err).Str("provider", provider.Name()).Msg("Failed to start social login")
+		response.Fail(w, r, http.StatusInternalServerError, "Server error")
+		return
+	}
+
+	http.Redirect(w, r, provider.AuthCodeURL(state), http.StatusFound)
+}
+
+// Callback handles GET and POST /api/v1/auth/{provider}/callback
+// @Summary Finish a social login
+// @Description Redeems the provider's authorization code and logs the user in. Known provider accounts log into their user; otherwise the account is linked to the user with the same verified email, or a new user is created. Apple posts its callback as a form.
+// @Tags Users
+// @Produce json
+// @Param provider path string true "Provider" Enums(google, github, apple)
+// @Param code query string true "Authorization code"
+// @Param state query string true "State from the login redirect"
+// @Success 200 {object} map[string]interface{} "JWT and email (bearer sessions)"
+// @Success 302 "Redirect to OAUTH_SUCCESS_REDIRECT_URL (cookie sessions)"
+// @Failure 400 {object} map[string]string "Invalid state, denied consent or no email shared"
+// @Failure 404 {object} map[string]string "Unknown provider"
+// @Failure 409 {object} map[string]string "Email belongs to an account and is not verified by the provider"
+// @Failure 502 {object} map[string]string "Provider error"
+// @ID FinishSocialLogin
+// @Router /api/v1/auth/{provider}/callback [get]
+func (h *IdentityHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	provider, ok := h.provider(w, r)
+	if !ok {
+		return
+	}
+
+	session, err := auth.FinishOAuthLogin(w, r, provider.Name(), r.FormValue("state"))
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid or expired login, please try again")
+		return
+	}
+	if reason := r.FormValue("error"); reason != "" {
+		response.Fail(w, r, http.StatusBadRequest, "Login was not completed: "+reason)
+		return
+	}
+	code := r.FormValue("code")
+	if code == "" {
+		response.Fail(w, r, http.StatusBadRequest, "code is required")
+		return
+	}
+
+	identity, err := provider.Exchange(ctx, code)
+	if err != nil {
+		log.Warn().Err(err).Str("provider", provider.Name()).Msg("Social login code exchange failed")
+		response.Fail(w, r, http.StatusBadGateway, "Could not log in with "+provider.Name())
+		return
+	}
+
+	user, err := h.resolveUser(ctx, identity)
+	switch {
+	case errors.Is(err, errNoProviderEmail):
+		response.Fail(w, r, http.StatusBadRequest, "Your "+provider.Name()+" account did not share an email address")
+		return
+	case errors.Is(err, errUnverifiedEmail):
+		response.Fail(w, r, http.StatusConflict, "An account with this email already exists; log in with it to link "+provider.Name())
+		return
+	case err != nil:
+		if failDBError(w, r, err, "User") {
+			return
+		}
+		log.Error().Err(err).Str("provider", provider.Name()).Msg("Failed to resolve social login user")
+		response.Fail(w, r, http.StatusInternalServerError, "Server error")
+		return
+	}
+
+	now := h.clock.Now()
+	token, err := auth.GenerateJwtToken(int(user.ID), user.Email, now)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to generate jwt")
+		response.Fail(w, r, http.StatusInternalServerError, "Server error")
+		return
+	}
+
+	if session == models.SessionCookie {
+		auth.SetSessionCookies(w, token, now)
+		http.Redirect(w, r, config.OAuth.SuccessRedirectURL, http.StatusFound)
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, map[string]interface{}{
+		"token": token,
+		"email": user.Email,
+	})
+}
+
+// ListIdentities handles GET /api/v1/users/me/identities
+// @Summary List my linked logins
+// @Description Returns the social login accounts linked to the user.
+// @Tags Users
+// @Produce json
+// @Success 200 {array} models.UserIdentity "Linked identities"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/users/me/identities [get]
+func (h *IdentityHandler) ListIdentities(w http.ResponseWriter, r *http.Request) {
+	user, _ := requestcontext.FromContext(r.Context())
+
+	identities, err := h.identityRepo.ListByUser(r.Context(), user.Id)
+	if err != nil {
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to list identities")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to list identities")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, identities)
+}
+
+// UnlinkIdentity handles DELETE /api/v1/users/me/identities/{provider}
+// @Summary Unlink a login
+// @Description Removes a linked social login. The last way to log in (no password and no other identity) can't be removed.
+// @Tags Users
+// @Param provider path string true "Provider" Enums(google, github, apple)
+// @Success 204 "Unlinked"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Identity not found"
+// @Failure 409 {object} map[string]string "Last login method"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/users/me/identities/{provider} [delete]
+func (h *IdentityHandler) UnlinkIdentity(w http.ResponseWriter, r *http.Request) {
+	user, _ := requestcontext.FromContext(r.Context())
+	provider := mux.Vars(r)["provider"]
+
+	err := h.identityRepo.Unlink(r.Context(), user.Id, provider)
+	if errors.Is(err, repository.ErrLastLoginMethod) {
+		response.Fail(w, r, http.StatusConflict, "Cannot unlink your only way to log in")
+		return
+	}
+	if errors.Is(err, appErrors.ErrNotFound) {
+		response.Fail(w, r, http.StatusNotFound, "Identity not found")
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int("userID", user.Id).Str("provider", provider).Msg("Failed to unlink identity")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to unlink identity")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// provider resolves the {provider} path variable, writing a 404 if it isn't configured
+func (h *IdentityHandler) provider(w http.ResponseWriter, r *http.Request) (identityTypes.Provider, bool) {
+	provider, err := h.providers.Get(mux.Vars(r)["provider"])
+	if err != nil {
+		response.Fail(w, r, http.StatusNotFound, "Unknown login provider")
+		return nil, false
+	}
+	return provider, true
+}
+
+// resolveUser finds the user a provider account logs into: the user it is
+// linked to, else the user with the same email if the provider verified it
+// (linking the account), else a new user
+func (h *IdentityHandler) resolveUser(ctx context.Context, identity *identityTypes.Identity) (*models.User, error) {
+	user, err := h.identityRepo.LoginByIdentity(ctx, identity.Provider, identity.Subject)
+	if !errors.Is(err, appErrors.ErrNotFound) {
+		return user, err
+	}
+	if identity.Email == "" {
+		return nil, errNoProviderEmail
+	}
+
+	link := &models.UserIdentity{
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Email:    &identity.Email,
+	}
+
+	user, err = h.userRepo.FindUserByEmail(ctx, identity.Email)
+	if err == nil {
+		// Linking on an unverified email would let anyone who can register
+		// that address with the provider take over the account
+		if !identity.EmailVerified {
+			return nil, errUnverifiedEmail
+		}
+		link.UserID = int(user.ID)
+		if err := h.identityRepo.Link(ctx, link); err != nil {
+			return nil, err
+		}
+		log.Info().Int64("userID", user.ID).Str("provider", identity.Provider).Msg("Linked identity by email")
+		return user, nil
+	}
+	if !errors.Is(err, appErrors.ErrNotFound) {
+		return nil, err
+	}
+
+	user = &models.User{
+		Email:    identity.Email,
+		Username: socialUsername(identity),
+	}
+	if err := h.identityRepo.CreateUserWithIdentity(ctx, user, link); err != nil {
+		return nil, err
+	}
+	log.Info().Int64("userID", user.ID).Str("provider", identity.Provider).Msg("User created from identity")
+	return user, nil
+}
+
+// socialUsername derives a username for a user signing up with a provider
+// from their name or email, with a random suffix to keep it unique
+func socialUsername(identity *identityTypes.Identity) string {
+	base := identity.Name
+	if base == "" {
+		base, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	var b strings.Builder
+	for _, r := range strings.ToLower(base) {
+		if b.Len() >= 14 {
+			break
+		}
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_') {
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() == 0 {
+		b.WriteString("user")
+	}
+
+	suffix := make([]byte, 3)
+	_, _ = rand.Read(suffix)
+	return b.String() + "_" + hex.EncodeToString(suffix)
+}
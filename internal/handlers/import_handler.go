@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	queueTypes "github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	storageTypes "github.com/valentinesamuel/activelog/internal/adapters/storage/types"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/platform/jobs"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/platform/utils"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// ImportHandler handles bulk activity import endpoints.
+type ImportHandler struct {
+	importRepo    *repository.ImportRepository
+	jobRepo       *repository.JobRepository
+	queueProvider queueTypes.QueueProvider
+	storage       storageTypes.StorageProvider
+}
+
+// ImportHandlerDeps contains the dependencies for ImportHandler.
+type ImportHandlerDeps struct {
+	ImportRepo    *repository.ImportRepository
+	JobRepo       *repository.JobRepository
+	QueueProvider queueTypes.QueueProvider
+	Storage       storageTypes.StorageProvider
+}
+
+// NewImportHandler creates a new ImportHandler with the given dependencies.
+func NewImportHandler(deps ImportHandlerDeps) *ImportHandler {
+	return &ImportHandler{
+		importRepo:    deps.ImportRepo,
+		jobRepo:       deps.JobRepo,
+		queueProvider: deps.QueueProvider,
+		storage:       deps.Storage,
+	}
+}
+
+// importRequest is the body of POST /activities/import.
+// Activities are kept raw: rows are validated by the worker so one bad row
+// doesn't reject the whole file.
+type importRequest struct {
+	Source     models.ImportSource `json:"source"`
+	Activities []json.RawMessage   `json:"activities"`
+}
+
+// EnqueueImport stores the uploaded activities, creates a pending import record and enqueues the import job.
+func (h *ImportHandler) EnqueueImport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	var req importRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if len(req.Activities) == 0 {
+		response.Fail(w, r, http.StatusBadRequest, "No activities to import")
+		return
+	}
+	switch req.Source {
+	case "":
+		req.Source = models.ImportSourceJSON
+	case models.ImportSourceJSON, models.ImportSourceStrava, models.ImportSourceGPX:
+	default:
+		response.Fail(w, r, http.StatusBadRequest, "Unsupported import source")
+		return
+	}
+
+	// Upload the rows for the worker to pick up
+	data, err := json.Marshal(req.Activities)
+	if err != nil {
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to encode import file")
+		return
+	}
+	key := fmt.Sprintf("imports/%d/%s.json", user.Id, uuid.New().String())
+	if _, err := h.storage.Upload(ctx, &storageTypes.UploadInput{
+		Key:         key,
+		Body:        bytes.NewReader(data),
+		ContentType: "application/json",
+		Size:        int64(len(data)),
+		Metadata: map[string]string{
+			"source": string(req.Source),
+		},
+	}); err != nil {
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to store import file")
+		return
+	}
+
+	h.startImport(w, r, &models.ImportRecord{
+		UserID:     user.Id,
+		Source:     req.Source,
+		Status:     models.StatusPending,
+		StorageKey: key,
+		TotalRows:  len(req.Activities),
+	})
+}
+
+// healthExportExtensions are the file extensions of the content types a
+// health export is uploaded as
+var healthExportExtensions = map[string]string{
+	"application/zip": "zip",
+	"text/xml":        "xml",
+}
+
+// UploadImport handles POST /api/v1/activities/import/file
+// @Summary Import a health app export
+// @Description Imports the workouts of an Apple Health export (export.zip, or the export.xml in it) or a Google Fit Takeout archive as activities. The file is read by a background job, which follows the import's progress at GET /imports/{importId}; total_rows grows as workouts are found. Workouts imported before are skipped and counted in skipped_rows, so the same export can be uploaded again.
+// @Tags Activities
+// @Accept multipart/form-data
+// @Produce json
+// @Param source formData string true "Export format" Enums(apple_health, google_fit)
+// @Param file formData file true "Export file"
+// @Success 202 {object} map[string]interface{} "import_id, job_id and total_rows"
+// @Failure 400 {object} map[string]string "Missing file, unsupported source or file type"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 413 {object} map[string]string "Request body too large"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @ID UploadImport
+// @Router /api/v1/activities/import/file [post]
+func (h *ImportHandler) UploadImport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			response.Fail(w, r, http.StatusRequestEntityTooLarge, "Request body too large")
+			return
+		}
+		response.Fail(w, r, http.StatusBadRequest, "Invalid multipart form")
+		return
+	}
+
+	source := models.ImportSource(r.FormValue("source"))
+	if !source.IsHealthExport() {
+		response.Fail(w, r, http.StatusBadRequest, "Unsupported import source (apple_health or google_fit)")
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "file is required")
+		return
+	}
+	defer file.Close()
+
+	contentType, err := utils.DetectFileType(file)
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Cannot read file")
+		return
+	}
+	contentType, _, _ = strings.Cut(contentType, ";")
+	ext, ok := healthExportExtensions[contentType]
+	if !ok {
+		response.Fail(w, r, http.StatusBadRequest, "File must be a zip archive or XML")
+		return
+	}
+
+	key := fmt.Sprintf("imports/%d/%s.%s", user.Id, uuid.New().String(), ext)
+	if _, err := h.storage.Upload(ctx, &storageTypes.UploadInput{
+		Key:         key,
+		Body:        file,
+		ContentType: contentType,
+		Size:        header.Size,
+		Metadata: map[string]string{
+			"source": string(source),
+		},
+	}); err != nil {
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to store import file")
+		return
+	}
+
+	h.startImport(w, r, &models.ImportRecord{
+		UserID:     user.Id,
+		Source:     source,
+		Status:     models.StatusPending,
+		StorageKey: key,
+	})
+}
+
+// startImport creates the import record and its job, enqueues the job and
+// responds with their IDs
+func (h *ImportHandler) startImport(w http.ResponseWriter, r *http.Request, record *models.ImportRecord) {
+	ctx := r.Context()
+
+	if err := h.importRepo.Create(ctx, record); err != nil {
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to create import record")
+		return
+	}
+
+	// Track the job under the import ID so /jobs/{id} and /imports/{id} agree
+	job := &models.Job{
+		ID:     record.ID,
+		UserID: record.UserID,
+		Type:   string(queueTypes.EventImportActivities),
+	}
+	if err := h.jobRepo.Create(ctx, job); err != nil {
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to create job")
+		return
+	}
+
+	// Marshal the job payload data
+	payload, err := json.Marshal(jobs.ImportActivitiesPayload{
+		ImportID:   record.ID,
+		UserID:     record.UserID,
+		StorageKey: record.StorageKey,
+		Source:     record.Source,
+	})
+	if err != nil {
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to marshal job payload")
+		return
+	}
+
+	// Enqueue the job
+	jobPayload := queueTypes.JobPayload{
+		Event:     queueTypes.EventImportActivities,
+		Data:      payload,
+		JobID:     job.ID,
+		MessageID: record.ID,
+	}
+	if _, err := h.queueProvider.Enqueue(ctx, queueTypes.QueueFor(jobPayload.Event), jobPayload); err != nil {
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to enqueue import job")
+		return
+	}
+
+	response.Success(w, r, http.StatusAccepted, map[string]interface{}{
+		"import_id":  record.ID,
+		"job_id":     job.ID,
+		"total_rows": record.TotalRows,
+	})
+}
+
+// GetImportStatus returns the progress and row errors of an import owned by the authenticated user.
+func (h *ImportHandler) GetImportStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+	importID := mux.Vars(r)["importId"]
+
+	record, err := h.importRepo.GetByID(ctx, importID)
+	if err != nil || record.UserID != user.Id {
+		response.Fail(w, r, http.StatusNotFound, "Import not found")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, record)
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/pkg/database"
+	"github.com/valentinesamuel/activelog/pkg/query"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// IndexAdvisorHandler serves the index advisor report: composite indexes
+// suggested for the list queries run so far, given the existing indexes
+type IndexAdvisorHandler struct {
+	advisor *query.IndexAdvisor
+	indexes *repository.IndexRepository
+	slow    *database.SlowQueryLog
+}
+
+// NewIndexAdvisorHandler creates an IndexAdvisorHandler. advisor is nil
+// outside development, where the report is not available; slow may be nil.
+func NewIndexAdvisorHandler(advisor *query.IndexAdvisor, indexes *repository.IndexRepository, slow *database.SlowQueryLog) *IndexAdvisorHandler {
+	return &IndexAdvisorHandler{advisor: advisor, indexes: indexes, slow: slow}
+}
+
+// IndexAdvisorReport is the response of GET /api/v1/admin/index-advisor
+type IndexAdvisorReport struct {
+	// SlowQueryThreshold is the duration above which statements count as
+	// slow, in nanoseconds; 0 when the slow query log is disabled
+	SlowQueryThreshold time.Duration `json:"slowQueryThreshold" swaggertype:"integer"`
+
+	Suggestions     []query.IndexSuggestion `json:"suggestions"`
+	SlowQueries     []database.SlowQuery    `json:"slowQueries"`
+	ExistingIndexes []query.ExistingIndex   `json:"existingIndexes"`
+}
+
+// GetReport handles GET /api/v1/admin/index-advisor
+// @Summary Index advisor report
+// @Description Suggests composite indexes for the list queries observed since startup that no existing index (pg_indexes) serves, ranked by the time the slow query log attributes to them. Suggestions are also written to the log. Development only; reset=true clears the observations after reporting. Admins only.
+// @Tags Admin
+// @Produce json
+// @Param reset query bool false "Clear the observed queries and slow query log after reporting"
+// @Success 200 {object} handlers.IndexAdvisorReport "Index suggestions"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Not an admin"
+// @Failure 404 {object} map[string]string "Index advisor disabled outside development"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @ID GetIndexAdvisorReport
+// @Router /api/v1/admin/index-advisor [get]
+func (h *IndexAdvisorHandler) GetReport(w http.ResponseWriter, r *http.Request) {
+	if h.advisor == nil {
+		response.Fail(w, r, http.StatusNotFound, "Index advisor is only enabled in development")
+		return
+	}
+
+	existing, err := h.indexes.ListIndexes(r.Context(), h.advisor.Tables())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list indexes")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to list indexes")
+		return
+	}
+
+	report := IndexAdvisorReport{ExistingIndexes: existing}
+	slow := map[string]query.SlowStatement{}
+	if h.slow != nil {
+		report.SlowQueryThreshold = h.slow.Threshold()
+		report.SlowQueries = h.slow.Queries()
+		for _, statement := range report.SlowQueries {
+			slow[statement.SQL] = query.SlowStatement{Count: statement.Count, Total: statement.Total}
+		}
+	}
+	report.Suggestions = h.advisor.Report(existing, slow)
+
+	for _, suggestion := range report.Suggestions {
+		log.Info().
+			Str("table", suggestion.Table).
+			Int("queries", suggestion.Queries).
+			Int("slow_queries", suggestion.SlowQueries).
+			Dur("slow_query_time", suggestion.SlowQueryTime).
+			Msg("Index advisor suggests " + suggestion.SQL)
+	}
+
+	if r.URL.Query().Get("reset") == "true" {
+		h.advisor.Reset()
+		if h.slow != nil {
+			h.slow.Reset()
+		}
+	}
+	response.Success(w, r, http.StatusOK, report)
+}
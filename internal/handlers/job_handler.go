@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/pkg/query"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// jobStreamInterval is how often StreamJob polls the job for changes
+const jobStreamInterval = time.Second
+
+// JobHandler exposes the status and progress of background jobs
+type JobHandler struct {
+	jobRepo    *repository.JobRepository
+	validation *query.EntityValidation
+}
+
+// NewJobHandler creates a new JobHandler; validation is the jobs list query
+// whitelist (see JobRepository.GetValidation)
+func NewJobHandler(jobRepo *repository.JobRepository, validation *query.EntityValidation) *JobHandler {
+	return &JobHandler{jobRepo: jobRepo, validation: validation}
+}
+
+// GetJob returns a job owned by the authenticated user
+// @Summary Get job
+// @Description Returns the status, progress, result and error of a background job
+// @Tags Jobs
+// @Produce json
+// @Param jobId path string true "Job ID"
+// @Success 200 {object} models.Job
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Job not found"
+// @Security BearerAuth
+// @Router /api/v1/jobs/{jobId} [get]
+func (h *JobHandler) GetJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	job, err := h.jobRepo.GetByID(ctx, mux.Vars(r)["jobId"])
+	if err != nil || job.UserID != user.Id {
+		response.Fail(w, r, http.StatusNotFound, "Job not found")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, job)
+}
+
+// ListJobs returns the authenticated user's jobs using dynamic filtering with QueryOptions
+// @Summary List jobs
+// @Description Returns a paginated list of the user's background jobs with filtering and sorting
+// @Tags Jobs
+// @Produce json
+// @Param filter[status] query string false "Filter by status (pending, running, completed, failed)"
+// @Param filter[type] query string false "Filter by job type (e.g. generate_export)"
+// @Param filter[created_at][gte] query string false "Jobs created on or after this time"
+// @Param order[created_at] query string false "Sort by created_at (ASC or DESC)"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 10, max: 100)"
+// @Param count query string false "How totalRecords is computed: exact (default), estimated, or none (skips counting)" Enums(exact, estimated, none)
+// @Success 200 {object} paginatedResponse{data=[]models.Job} "Paginated jobs"
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Security BearerAuth
+// @Router /api/v1/jobs [get]
+func (h *JobHandler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	user, _ := requestcontext.FromContext(r.Context())
+
+	queryOpts, err := query.ParseQueryParamsWithFields(r.URL.Query(), h.validation.Fields())
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+
+	if err := h.validation.Validate(queryOpts); err != nil {
+		response.Fail(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Users only ever see their own jobs, newest first unless asked otherwise
+	queryOpts.Filter["user_id"] = user.Id
+	if len(queryOpts.Order) == 0 {
+		queryOpts.Order.Set("created_at", "DESC")
+	}
+
+	result, err := h.jobRepo.ListJobsWithQuery(r.Context(), queryOpts)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list jobs")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to fetch jobs")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, map[string]interface{}{
+		"data": result.Data,
+		"meta": paginationMeta(w, r, result.Meta),
+	})
+}
+
+// StreamJob streams a job's progress as server-sent events until it completes or fails
+// @Summary Stream job progress
+// @Description Server-sent events stream; emits a "progress" event with the job whenever it changes and closes once the job has completed or failed
+// @Tags Jobs
+// @Produce text/event-stream
+// @Param jobId path string true "Job ID"
+// @Success 200 {object} models.Job "progress events"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Job not found"
+// @Security BearerAuth
+// @Router /api/v1/jobs/{jobId}/events [get]
+func (h *JobHandler) StreamJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+	jobID := mux.Vars(r)["jobId"]
+
+	job, err := h.jobRepo.GetByID(ctx, jobID)
+	if err != nil || job.UserID != user.Id {
+		response.Fail(w, r, http.StatusNotFound, "Job not found")
+		return
+	}
+
+	// The stream outlives the server's write timeout
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		log.Warn().Err(err).Msg("Failed to clear write deadline for job stream")
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	ticker := time.NewTicker(jobStreamInterval)
+	defer ticker.Stop()
+
+	var last time.Time
+	for {
+		if !job.UpdatedAt.Equal(last) {
+			last = job.UpdatedAt
+			data, _ := json.Marshal(job)
+			if _, err := fmt.Fprintf(w, "event: progress\ndata: %s\n\n", data); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+		if job.Status.IsTerminal() {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if job, err = h.jobRepo.GetByID(ctx, jobID); err != nil {
+			log.Error().Err(err).Str("job_id", jobID).Msg("Failed to poll job")
+			return
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/valentinesamuel/activelog/pkg/query"
+)
+
+// metaV2MediaType is the Accept media type that asks list endpoints for the
+// typed (query.MetaV2) pagination metadata
+const metaV2MediaType = "application/vnd.activelog.v2+json"
+
+// metaVersion returns the pagination metadata form the client accepts:
+// query.MetaV2 if it lists metaV2MediaType, query.MetaV1 otherwise
+func metaVersion(r *http.Request) query.MetaVersion {
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err == nil && mediaType == metaV2MediaType {
+			return query.MetaV2
+		}
+	}
+	return query.MetaV1
+}
+
+// paginatedResponse documents the result of the paginated list endpoints.
+// Their annotations set the type of data, e.g.
+// paginatedResponse{data=[]models.Workout}. Meta is documented in the MetaV2
+// form, which clients get by accepting metaV2MediaType.
+type paginatedResponse struct {
+	Data interface{}          `json:"data"`
+	Meta query.PaginationMeta `json:"meta"`
+}
+
+// paginationMeta returns meta in the form the client accepts
+func paginationMeta(w http.ResponseWriter, r *http.Request, meta query.PaginationMeta) interface{} {
+	w.Header().Set("Vary", "Accept")
+	return meta.Versioned(metaVersion(r))
+}
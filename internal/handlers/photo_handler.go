@@ -1,18 +1,23 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/valentinesamuel/activelog/internal/adapters/storage/fileurl"
 	"github.com/rs/zerolog/log"
 	"github.com/valentinesamuel/activelog/internal/application/activityPhoto/usecases"
 	"github.com/valentinesamuel/activelog/internal/application/broker"
+	"github.com/valentinesamuel/activelog/internal/models"
 	"github.com/valentinesamuel/activelog/internal/repository"
 	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
 	"github.com/valentinesamuel/activelog/internal/platform/utils"
+	"github.com/valentinesamuel/activelog/pkg/clock"
 	"github.com/valentinesamuel/activelog/pkg/logger"
 	"github.com/valentinesamuel/activelog/pkg/response"
 )
@@ -22,6 +27,7 @@ type ActivityPhotoHandler struct {
 	repo                   repository.ActivityPhotoRepositoryInterface
 	uploadActivityPhotosUC *usecases.UploadActivityPhotoUseCase
 	getActivityPhotosUC    *usecases.GetActivityPhotoUseCase
+	clock                  clock.Clock
 }
 
 func NewActivityPhotoHandler(
@@ -35,9 +41,16 @@ func NewActivityPhotoHandler(
 		repo:                   repo,
 		uploadActivityPhotosUC: uploadActivityPhotosUC,
 		getActivityPhotosUC:    getActivityPhotosUC,
+		clock:                  clock.Real{},
 	}
 }
 
+// WithClock sets the clock photo URLs expire by
+func (h *ActivityPhotoHandler) WithClock(c clock.Clock) *ActivityPhotoHandler {
+	h.clock = c
+	return h
+}
+
 func (h *ActivityPhotoHandler) Upload(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	requestUser, _ := requestcontext.FromContext(ctx)
@@ -56,6 +69,11 @@ func (h *ActivityPhotoHandler) Upload(w http.ResponseWriter, r *http.Request) {
 	err = r.ParseMultipartForm(50 << 20)
 	if err != nil {
 		logger.Error().Err(err).Str("content_type", contentType).Msg("Failed to parse multipart form")
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			response.Fail(w, r, http.StatusRequestEntityTooLarge, "Request body too large")
+			return
+		}
 		response.Fail(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
@@ -138,6 +156,10 @@ func (h *ActivityPhotoHandler) Upload(w http.ResponseWriter, r *http.Request) {
 	}
 
 	log.Info().Int("activityId", result.ActivityID).Msg("Activity Photos Created")
+	now := h.clock.Now()
+	for i := range result.ActivityPhotos {
+		signPhotoURLs(&result.ActivityPhotos[i], now)
+	}
 	response.Success(w, r, http.StatusCreated, result.ActivityPhotos)
 }
 
@@ -169,5 +191,17 @@ func (h *ActivityPhotoHandler) GetActivityPhoto(w http.ResponseWriter, r *http.R
 	}
 
 	log.Info().Int("activityId", id).Int("count", len(result.Photos)).Msg("Activity Photos retrieved")
+	now := h.clock.Now()
+	for _, photo := range result.Photos {
+		signPhotoURLs(photo, now)
+	}
 	response.Success(w, r, http.StatusOK, result.Photos)
 }
+
+// signPhotoURLs fills in the signed URLs of a photo and its thumbnail, valid from now
+func signPhotoURLs(photo *models.ActivityPhoto, now time.Time) {
+	photo.URL = fileurl.For(photo.S3Key, "", now)
+	if photo.ThumbnailKey != "" {
+		photo.ThumbnailURL = fileurl.For(photo.ThumbnailKey, "", now)
+	}
+}
@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	queueTypes "github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/platform/jobs"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/platform/validator"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/service"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// defaultMaxPrivacyZones is how many privacy zones a user may have when
+// PrivacyZoneHandlerDeps.MaxZones is unset
+const defaultMaxPrivacyZones = 10
+
+// PrivacyZoneHandler serves the user's privacy zones: the places, e.g. home,
+// whose exact position is hidden from everyone else
+type PrivacyZoneHandler struct {
+	zoneRepo      *repository.PrivacyZoneRepository
+	maxZones      int
+	queueProvider queueTypes.QueueProvider
+}
+
+// PrivacyZoneHandlerDeps contains the dependencies for PrivacyZoneHandler.
+type PrivacyZoneHandlerDeps struct {
+	ZoneRepo      *repository.PrivacyZoneRepository
+	MaxZones      int                      // zones per user; 0 uses defaultMaxPrivacyZones
+	QueueProvider queueTypes.QueueProvider // optional; renders map thumbnails again after changes
+}
+
+// NewPrivacyZoneHandler creates a new PrivacyZoneHandler with the given dependencies.
+func NewPrivacyZoneHandler(deps PrivacyZoneHandlerDeps) *PrivacyZoneHandler {
+	maxZones := deps.MaxZones
+	if maxZones <= 0 {
+		maxZones = defaultMaxPrivacyZones
+	}
+	return &PrivacyZoneHandler{
+		zoneRepo:      deps.ZoneRepo,
+		maxZones:      maxZones,
+		queueProvider: deps.QueueProvider,
+	}
+}
+
+// ListZones handles GET /api/v1/users/me/privacy-zones
+// @Summary List my privacy zones
+// @Description Returns the places whose exact position the user keeps to themselves, e.g. home. Activity start and end points within a zone's radius are hidden from coaches and left out of exported files, and the parts of GPS tracks within it are left out of map thumbnails.
+// @Tags Users
+// @ID ListPrivacyZones
+// @Produce json
+// @Success 200 {array} models.PrivacyZone "Privacy zones, oldest first"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/users/me/privacy-zones [get]
+func (h *PrivacyZoneHandler) ListZones(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	zones, err := h.zoneRepo.ListByUser(ctx, user.Id)
+	if err != nil {
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to list privacy zones")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to list privacy zones")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, zones)
+}
+
+// CreateZone handles POST /api/v1/users/me/privacy-zones
+// @Summary Add a privacy zone
+// @Description Adds a circle of radius_m meters (100 to 5000) around a place to keep private. It applies to every activity, including those logged before; map thumbnails are rendered again in the background. A user has at most ACTIVITY_PRIVACY_ZONES_MAX zones (10 by default).
+// @Tags Users
+// @ID CreatePrivacyZone
+// @Accept json
+// @Produce json
+// @Param request body models.CreatePrivacyZoneRequest true "Privacy zone"
+// @Success 201 {object} models.PrivacyZone "Created privacy zone"
+// @Failure 400 {object} map[string]interface{} "Validation error, or too many zones"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/users/me/privacy-zones [post]
+func (h *PrivacyZoneHandler) CreateZone(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	var req models.CreatePrivacyZoneRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
+		return
+	}
+
+	zone := &models.PrivacyZone{UserID: user.Id, Name: req.Name, Lat: *req.Lat, Lng: *req.Lng, RadiusM: req.RadiusM}
+	err := h.zoneRepo.Create(ctx, zone, h.maxZones)
+	if errors.Is(err, repository.ErrPrivacyZoneLimit) {
+		response.Fail(w, r, http.StatusBadRequest, fmt.Sprintf("A user can have at most %d privacy zones", h.maxZones))
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to create privacy zone")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to create privacy zone")
+		return
+	}
+	h.renderMapThumbnails(ctx, user.Id)
+
+	response.Success(w, r, http.StatusCreated, zone)
+}
+
+// UpdateZone handles PATCH /api/v1/users/me/privacy-zones/{id}
+// @Summary Update a privacy zone
+// @Description Renames, moves or resizes one of the user's privacy zones. Moving it takes both lat and lng.
+// @Tags Users
+// @ID UpdatePrivacyZone
+// @Accept json
+// @Produce json
+// @Param id path int true "Privacy zone ID"
+// @Param request body models.UpdatePrivacyZoneRequest true "Fields to change"
+// @Success 200 {object} models.PrivacyZone "Updated privacy zone"
+// @Failure 400 {object} map[string]interface{} "Validation error"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Privacy zone not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/users/me/privacy-zones/{id} [patch]
+func (h *PrivacyZoneHandler) UpdateZone(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	id, ok := parseZoneID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.UpdatePrivacyZoneRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
+		return
+	}
+
+	zone, err := h.zoneRepo.GetByID(ctx, user.Id, id)
+	if failDBError(w, r, err, "Privacy zone") {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int64("zoneID", id).Msg("Failed to get privacy zone")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to update privacy zone")
+		return
+	}
+
+	if req.Name != nil {
+		zone.Name = *req.Name
+	}
+	if req.Lat != nil && req.Lng != nil {
+		zone.Lat, zone.Lng = *req.Lat, *req.Lng
+	}
+	if req.RadiusM != nil {
+		zone.RadiusM = *req.RadiusM
+	}
+
+	err = h.zoneRepo.Update(ctx, zone)
+	if failDBError(w, r, err, "Privacy zone") {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int64("zoneID", id).Msg("Failed to update privacy zone")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to update privacy zone")
+		return
+	}
+	h.renderMapThumbnails(ctx, user.Id)
+
+	response.Success(w, r, http.StatusOK, zone)
+}
+
+// DeleteZone handles DELETE /api/v1/users/me/privacy-zones/{id}
+// @Summary Remove a privacy zone
+// @Description Removes one of the user's privacy zones; positions within it are shown again
+// @Tags Users
+// @ID DeletePrivacyZone
+// @Param id path int true "Privacy zone ID"
+// @Success 204 "Deleted"
+// @Failure 400 {object} map[string]string "Invalid privacy zone ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Privacy zone not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/users/me/privacy-zones/{id} [delete]
+func (h *PrivacyZoneHandler) DeleteZone(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	id, ok := parseZoneID(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.zoneRepo.Delete(ctx, user.Id, id)
+	if failDBError(w, r, err, "Privacy zone") {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int64("zoneID", id).Msg("Failed to delete privacy zone")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to delete privacy zone")
+		return
+	}
+	h.renderMapThumbnails(ctx, user.Id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// renderMapThumbnails has the map thumbnails of the user's activities
+// rendered again, as they leave out the privacy zones. Failures are logged;
+// the change already succeeded.
+func (h *PrivacyZoneHandler) renderMapThumbnails(ctx context.Context, userID int) {
+	if h.queueProvider == nil {
+		return
+	}
+	if err := jobs.EnqueueRenderMapThumbnails(ctx, h.queueProvider, userID, nil); err != nil {
+		log.Warn().Err(err).Int("userID", userID).Msg("Failed to enqueue map thumbnail rendering")
+	}
+}
+
+// parseZoneID parses the {id} path variable, writing a 400 when it isn't an ID
+func parseZoneID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid privacy zone ID")
+		return 0, false
+	}
+	return id, true
+}
+
+// redactForViewer hides the positions of ownerID's activities that fall in
+// their privacy zones (see service.RedactPrivacyZones), writing a 500 if the
+// zones can't be loaded. Use it on activities served to anyone but the owner,
+// or written to files that leave the app.
+func redactForViewer(w http.ResponseWriter, r *http.Request, zones *repository.PrivacyZoneRepository, ownerID int, activities []*models.Activity) bool {
+	list, err := zones.ListByUser(r.Context(), ownerID)
+	if err != nil {
+		log.Error().Err(err).Int("ownerID", ownerID).Msg("Failed to load privacy zones")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to load privacy zones")
+		return false
+	}
+	service.RedactPrivacyZones(activities, list)
+	return true
+}
@@ -0,0 +1,201 @@
+package handlers_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valentinesamuel/activelog/internal/handlers"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/repository/testhelpers"
+)
+
+var privacyZoneRows = []string{"id", "user_id", "name", "lat", "lng", "radius_m", "created_at", "updated_at"}
+
+func newPrivacyZoneHandler(t *testing.T, maxZones int) (*handlers.PrivacyZoneHandler, sqlmock.Sqlmock) {
+	db, mock := testhelpers.SetupMockDB(t)
+	return handlers.NewPrivacyZoneHandler(handlers.PrivacyZoneHandlerDeps{
+		ZoneRepo: repository.NewPrivacyZoneRepository(db),
+		MaxZones: maxZones,
+	}), mock
+}
+
+// privacyZoneRequest is a request by userID, with {id} set when zoneID isn't empty
+func privacyZoneRequest(method, zoneID, body string, userID int) *http.Request {
+	target := "/api/v1/users/me/privacy-zones"
+	if zoneID != "" {
+		target += "/" + zoneID
+	}
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req = req.WithContext(requestcontext.NewContext(req.Context(), &requestcontext.User{Id: userID}))
+	if zoneID != "" {
+		req = mux.SetURLVars(req, map[string]string{"id": zoneID})
+	}
+	return req
+}
+
+func TestPrivacyZoneHandler_CreateZone(t *testing.T) {
+	now := time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC)
+
+	t.Run("created", func(t *testing.T) {
+		h, mock := newPrivacyZoneHandler(t, 2)
+		mock.ExpectBegin()
+		mock.ExpectExec(`pg_advisory_xact_lock`).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`INSERT INTO privacy_zones`).
+			WithArgs(7, "Home", 52.52, 13.405, 300, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(1, now, now))
+		mock.ExpectCommit()
+
+		w := httptest.NewRecorder()
+		h.CreateZone(w, privacyZoneRequest(http.MethodPost, "", `{"name":"Home","lat":52.52,"lng":13.405,"radius_m":300}`, 7))
+
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), `"name":"Home"`)
+	})
+
+	t.Run("limit reached", func(t *testing.T) {
+		h, mock := newPrivacyZoneHandler(t, 2)
+		mock.ExpectBegin()
+		mock.ExpectExec(`pg_advisory_xact_lock`).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`INSERT INTO privacy_zones`).
+			WithArgs(7, "Gym", 52.5, 13.4, 200, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}))
+		mock.ExpectRollback()
+
+		w := httptest.NewRecorder()
+		h.CreateZone(w, privacyZoneRequest(http.MethodPost, "", `{"name":"Gym","lat":52.5,"lng":13.4,"radius_m":200}`, 7))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "A user can have at most 2 privacy zones")
+	})
+
+	t.Run("default limit", func(t *testing.T) {
+		h, mock := newPrivacyZoneHandler(t, 0)
+		mock.ExpectBegin()
+		mock.ExpectExec(`pg_advisory_xact_lock`).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`INSERT INTO privacy_zones`).
+			WithArgs(7, "Gym", 52.5, 13.4, 200, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}))
+		mock.ExpectRollback()
+
+		w := httptest.NewRecorder()
+		h.CreateZone(w, privacyZoneRequest(http.MethodPost, "", `{"name":"Gym","lat":52.5,"lng":13.4,"radius_m":200}`, 7))
+
+		assert.Contains(t, w.Body.String(), "A user can have at most 10 privacy zones")
+	})
+
+	t.Run("radius too small", func(t *testing.T) {
+		h, _ := newPrivacyZoneHandler(t, 2)
+
+		w := httptest.NewRecorder()
+		h.CreateZone(w, privacyZoneRequest(http.MethodPost, "", `{"name":"Home","lat":52.52,"lng":13.405,"radius_m":50}`, 7))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestPrivacyZoneHandler_UpdateZone(t *testing.T) {
+	created := time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC)
+	updated := created.Add(24 * time.Hour)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantUpdate []any // name, lat, lng, radius_m saved
+		wantStatus int
+	}{
+		{"rename only", `{"name":"Flat"}`, []any{"Flat", 52.52, 13.405, 300}, http.StatusOK},
+		{"resize only", `{"radius_m":1000}`, []any{"Home", 52.52, 13.405, 1000}, http.StatusOK},
+		{"move", `{"lat":48.85,"lng":2.35}`, []any{"Home", 48.85, 2.35, 300}, http.StatusOK},
+		{"empty patch", `{}`, []any{"Home", 52.52, 13.405, 300}, http.StatusOK},
+		{"lat without lng", `{"lat":48.85}`, nil, http.StatusBadRequest},
+		{"radius too large", `{"radius_m":6000}`, nil, http.StatusBadRequest},
+		{"unknown field", `{"radius":1000}`, nil, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, mock := newPrivacyZoneHandler(t, 10)
+			if tt.wantUpdate != nil {
+				mock.ExpectQuery(`FROM privacy_zones WHERE id = \$1 AND user_id = \$2`).
+					WithArgs(int64(3), 7).
+					WillReturnRows(sqlmock.NewRows(privacyZoneRows).AddRow(3, 7, "Home", 52.52, 13.405, 300, created, created))
+				args := append([]any{int64(3), 7}, tt.wantUpdate...)
+				mock.ExpectQuery(`UPDATE privacy_zones`).
+					WithArgs(toDriverValues(args)...).
+					WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(updated))
+			}
+
+			w := httptest.NewRecorder()
+			h.UpdateZone(w, privacyZoneRequest(http.MethodPatch, "3", tt.body, 7))
+
+			require.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var body struct {
+				Result struct {
+					Name    string  `json:"name"`
+					Lat     float64 `json:"lat"`
+					Lng     float64 `json:"lng"`
+					RadiusM int     `json:"radius_m"`
+				} `json:"result"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, tt.wantUpdate, []any{body.Result.Name, body.Result.Lat, body.Result.Lng, body.Result.RadiusM})
+		})
+	}
+}
+
+func TestPrivacyZoneHandler_UpdateZone_OtherUsersZone(t *testing.T) {
+	h, mock := newPrivacyZoneHandler(t, 10)
+	// Zone 3 is user 7's; the lookup is scoped to user 8, finds nothing, and
+	// nothing is updated
+	mock.ExpectQuery(`FROM privacy_zones WHERE id = \$1 AND user_id = \$2`).
+		WithArgs(int64(3), 8).
+		WillReturnRows(sqlmock.NewRows(privacyZoneRows))
+
+	w := httptest.NewRecorder()
+	h.UpdateZone(w, privacyZoneRequest(http.MethodPatch, "3", `{"name":"Mine now"}`, 8))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestPrivacyZoneHandler_DeleteZone_OtherUsersZone(t *testing.T) {
+	h, mock := newPrivacyZoneHandler(t, 10)
+	mock.ExpectExec(`DELETE FROM privacy_zones WHERE id = \$1 AND user_id = \$2`).
+		WithArgs(int64(3), 8).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	w := httptest.NewRecorder()
+	h.DeleteZone(w, privacyZoneRequest(http.MethodDelete, "3", "", 8))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestPrivacyZoneHandler_InvalidID(t *testing.T) {
+	h, _ := newPrivacyZoneHandler(t, 10)
+
+	w := httptest.NewRecorder()
+	h.UpdateZone(w, privacyZoneRequest(http.MethodPatch, "home", `{"name":"Flat"}`, 7))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// toDriverValues converts args for sqlmock's WithArgs
+func toDriverValues(args []any) []driver.Value {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg
+	}
+	return values
+}
@@ -0,0 +1,346 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	queueTypes "github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	"github.com/valentinesamuel/activelog/internal/adapters/storage/fileurl"
+	storageTypes "github.com/valentinesamuel/activelog/internal/adapters/storage/types"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/platform/jobs"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/platform/utils"
+	"github.com/valentinesamuel/activelog/internal/platform/validator"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// maxAvatarBytes caps the size of an uploaded avatar image
+const maxAvatarBytes = 5 << 20
+
+// avatarExtensions maps the accepted avatar content types to file extensions
+var avatarExtensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/webp": "webp",
+}
+
+// ProfileHandler serves the user's own profile: display name, bio, avatar,
+// timezone, preferences and heart-rate zones
+type ProfileHandler struct {
+	profileRepo   *repository.ProfileRepository
+	storage       storageTypes.StorageProvider
+	queueProvider queueTypes.QueueProvider
+	clock         clock.Clock
+}
+
+// ProfileHandlerDeps contains the dependencies for ProfileHandler.
+type ProfileHandlerDeps struct {
+	ProfileRepo   *repository.ProfileRepository
+	Storage       storageTypes.StorageProvider
+	QueueProvider queueTypes.QueueProvider // optional; recalculates calorie estimates after weight changes
+	Clock         clock.Clock              // avatar URLs expire by it; nil uses the real clock
+}
+
+// NewProfileHandler creates a new ProfileHandler with the given dependencies.
+func NewProfileHandler(deps ProfileHandlerDeps) *ProfileHandler {
+	clk := deps.Clock
+	if clk == nil {
+		clk = clock.Real{}
+	}
+	return &ProfileHandler{
+		profileRepo:   deps.ProfileRepo,
+		storage:       deps.Storage,
+		queueProvider: deps.QueueProvider,
+		clock:         clk,
+	}
+}
+
+// GetProfile handles GET /api/v1/users/me
+// @Summary Get my profile
+// @Description Returns the user's profile and preferences. avatar_url is a signed URL of /api/v1/files, valid for 15 minutes by default.
+// @Tags Users
+// @Produce json
+// @Success 200 {object} models.UserProfile "Profile"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Profile not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/users/me [get]
+func (h *ProfileHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	profile, err := h.profileRepo.GetProfile(ctx, user.Id)
+	if failDBError(w, r, err, "Profile") {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to get profile")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to get profile")
+		return
+	}
+
+	h.respondWithProfile(w, r, http.StatusOK, profile)
+}
+
+// UpdateProfile handles PATCH /api/v1/users/me
+// @Summary Update my profile
+// @Description Updates only the fields present in the body. Preferences are merged into the stored ones, so a single preference can be changed on its own. Changing weight_kg recalculates the calorie estimates of the user's activities in the background.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param request body models.UpdateProfileRequest true "Profile fields to change"
+// @Success 200 {object} models.UserProfile "Updated profile"
+// @Failure 400 {object} map[string]interface{} "Validation error"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Profile not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/users/me [patch]
+func (h *ProfileHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	var req models.UpdateProfileRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
+		return
+	}
+
+	profile, err := h.profileRepo.UpdateProfile(ctx, user.Id, &req)
+	if failDBError(w, r, err, "Profile") {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to update profile")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to update profile")
+		return
+	}
+
+	// Calorie estimates depend on the weight
+	if req.WeightKg != nil && h.queueProvider != nil {
+		if err := jobs.EnqueueRecalculateUserMetrics(ctx, h.queueProvider, user.Id); err != nil {
+			log.Warn().Err(err).Int("userID", user.Id).Msg("Failed to enqueue metrics recalculation")
+		}
+	}
+
+	h.respondWithProfile(w, r, http.StatusOK, profile)
+}
+
+// GetHeartRateZones handles GET /api/v1/users/me/heart-rate-zones
+// @Summary Get my heart-rate zones
+// @Description Returns the lower bound (bpm) of each of the user's five heart-rate zones. Unless custom zones are set they are derived from max_heart_rate (50/60/70/80/90%).
+// @Tags Users
+// @Produce json
+// @Success 200 {object} models.HeartRateZones "Heart-rate zones"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Profile not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/users/me/heart-rate-zones [get]
+func (h *ProfileHandler) GetHeartRateZones(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	zones, err := h.profileRepo.GetHeartRateZones(ctx, user.Id)
+	if failDBError(w, r, err, "Profile") {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to get heart-rate zones")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to get heart-rate zones")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, zones)
+}
+
+// UpdateHeartRateZones handles PUT /api/v1/users/me/heart-rate-zones
+// @Summary Set my heart-rate zones
+// @Description Sets custom zone lower bounds (five strictly increasing values in bpm), a maximum heart rate to derive them from, or both. Sending only max_heart_rate replaces custom zones with derived ones. Zones apply to activities logged afterwards.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param request body models.UpdateHeartRateZonesRequest true "Zones or maximum heart rate"
+// @Success 200 {object} models.HeartRateZones "Updated heart-rate zones"
+// @Failure 400 {object} map[string]interface{} "Validation error"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Profile not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/users/me/heart-rate-zones [put]
+func (h *ProfileHandler) UpdateHeartRateZones(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	var req models.UpdateHeartRateZonesRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
+		return
+	}
+	for i := 1; i < len(req.Zones); i++ {
+		if req.Zones[i] <= req.Zones[i-1] {
+			response.Fail(w, r, http.StatusBadRequest, "zones must be strictly increasing")
+			return
+		}
+	}
+
+	zones, err := h.profileRepo.SetHeartRateZones(ctx, user.Id, req.MaxHeartRate, req.Zones)
+	if failDBError(w, r, err, "Profile") {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to update heart-rate zones")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to update heart-rate zones")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, zones)
+}
+
+// UploadAvatar handles PUT /api/v1/users/me/avatar
+// @Summary Upload my avatar
+// @Description Replaces the user's avatar with the uploaded image (JPEG, PNG or WebP, at most 5 MB). The previous image is deleted.
+// @Tags Users
+// @Accept multipart/form-data
+// @Produce json
+// @Param avatar formData file true "Avatar image"
+// @Success 200 {object} models.UserProfile "Updated profile"
+// @Failure 400 {object} map[string]string "Missing or invalid image"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Profile not found"
+// @Failure 413 {object} map[string]string "Request body too large"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/users/me/avatar [put]
+func (h *ProfileHandler) UploadAvatar(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	if err := r.ParseMultipartForm(maxAvatarBytes); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			response.Fail(w, r, http.StatusRequestEntityTooLarge, "Request body too large")
+			return
+		}
+		response.Fail(w, r, http.StatusBadRequest, "Invalid multipart form")
+		return
+	}
+
+	file, header, err := r.FormFile("avatar")
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "avatar file is required")
+		return
+	}
+	defer file.Close()
+
+	if header.Size > maxAvatarBytes {
+		response.Fail(w, r, http.StatusBadRequest, "Avatar must be at most 5 MB")
+		return
+	}
+	contentType, err := utils.DetectFileType(file)
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Cannot read avatar file")
+		return
+	}
+	ext, ok := avatarExtensions[contentType]
+	if !ok {
+		response.Fail(w, r, http.StatusBadRequest, "Avatar must be a JPEG, PNG or WebP image")
+		return
+	}
+
+	key := fmt.Sprintf("avatars/%d/%s.%s", user.Id, uuid.New().String(), ext)
+	if _, err := h.storage.Upload(ctx, &storageTypes.UploadInput{
+		Key:         key,
+		Body:        file,
+		ContentType: contentType,
+		Size:        header.Size,
+	}); err != nil {
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to upload avatar")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to upload avatar")
+		return
+	}
+
+	if !h.replaceAvatar(w, r, user.Id, &key) {
+		// The new object is unreferenced; don't leave it behind
+		h.deleteAvatarObject(r, key)
+	}
+}
+
+// DeleteAvatar handles DELETE /api/v1/users/me/avatar
+// @Summary Remove my avatar
+// @Tags Users
+// @Produce json
+// @Success 200 {object} models.UserProfile "Updated profile"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Profile not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/users/me/avatar [delete]
+func (h *ProfileHandler) DeleteAvatar(w http.ResponseWriter, r *http.Request) {
+	user, _ := requestcontext.FromContext(r.Context())
+	h.replaceAvatar(w, r, user.Id, nil)
+}
+
+// replaceAvatar points the user's avatar at key (nil removes it), deletes
+// the previous object and responds with the updated profile. It returns
+// false if the avatar could not be changed.
+func (h *ProfileHandler) replaceAvatar(w http.ResponseWriter, r *http.Request, userID int, key *string) bool {
+	ctx := r.Context()
+
+	previous, err := h.profileRepo.SetAvatar(ctx, userID, key)
+	if failDBError(w, r, err, "Profile") {
+		return false
+	}
+	if err != nil {
+		log.Error().Err(err).Int("userID", userID).Msg("Failed to update avatar")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to update avatar")
+		return false
+	}
+	if previous != nil {
+		h.deleteAvatarObject(r, *previous)
+	}
+
+	profile, err := h.profileRepo.GetProfile(ctx, userID)
+	if failDBError(w, r, err, "Profile") {
+		return true
+	}
+	if err != nil {
+		log.Error().Err(err).Int("userID", userID).Msg("Failed to get profile")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to get profile")
+		return true
+	}
+
+	h.respondWithProfile(w, r, http.StatusOK, profile)
+	return true
+}
+
+// deleteAvatarObject removes an avatar that is no longer referenced. Failures
+// are only logged; the account purge removes whatever is left at the end.
+func (h *ProfileHandler) deleteAvatarObject(r *http.Request, key string) {
+	if err := h.storage.Delete(r.Context(), key); err != nil {
+		log.Warn().Err(err).Str("key", key).Msg("Failed to delete avatar object")
+	}
+}
+
+// respondWithProfile fills in the signed avatar URL and writes profile
+func (h *ProfileHandler) respondWithProfile(w http.ResponseWriter, r *http.Request, status int, profile *models.UserProfile) {
+	if profile.AvatarKey != nil {
+		url := fileurl.For(*profile.AvatarKey, "", h.clock.Now())
+		profile.AvatarURL = &url
+	}
+
+	response.Success(w, r, status, profile)
+}
@@ -0,0 +1,249 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/storage/fileurl"
+	storageTypes "github.com/valentinesamuel/activelog/internal/adapters/storage/types"
+	"github.com/valentinesamuel/activelog/internal/handlers"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/repository/testhelpers"
+)
+
+var profileRows = []string{"id", "email", "username", "display_name", "bio", "avatar_key", "timezone", "weight_kg", "preferences", "created_at", "updated_at"}
+
+// pngHeader is enough of a PNG for content sniffing
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// avatarStorage records the avatars uploaded and deleted
+type avatarStorage struct {
+	storageTypes.StorageProvider
+	uploaded    []*storageTypes.UploadInput
+	uploadedLen int
+	deleted     []string
+}
+
+func (s *avatarStorage) Upload(_ context.Context, input *storageTypes.UploadInput) (*storageTypes.UploadOutput, error) {
+	data, err := io.ReadAll(input.Body)
+	if err != nil {
+		return nil, err
+	}
+	s.uploaded = append(s.uploaded, input)
+	s.uploadedLen = len(data)
+	return &storageTypes.UploadOutput{Key: input.Key}, nil
+}
+
+func (s *avatarStorage) Delete(_ context.Context, key string) error {
+	s.deleted = append(s.deleted, key)
+	return nil
+}
+
+func newProfileHandler(t *testing.T) (*handlers.ProfileHandler, sqlmock.Sqlmock, *avatarStorage) {
+	setupFileURLs(t)
+	db, mock := testhelpers.SetupMockDB(t)
+	storage := &avatarStorage{}
+	return handlers.NewProfileHandler(handlers.ProfileHandlerDeps{
+		ProfileRepo: repository.NewProfileRepository(db),
+		Storage:     storage,
+	}), mock, storage
+}
+
+// profileRow is user 7's profile with avatarKey and preferences
+func profileRow(avatarKey any, preferences string) *sqlmock.Rows {
+	created := time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC)
+	return sqlmock.NewRows(profileRows).
+		AddRow(7, "ann@example.com", "ann", "Ann", nil, avatarKey, "Europe/Berlin", 61.5, []byte(preferences), created, created)
+}
+
+// avatarRequest is a multipart upload of content as the avatar field by user 7
+func avatarRequest(t *testing.T, field, filename string, content []byte) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile(field, filename)
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, form.Close())
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/users/me/avatar", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	return req.WithContext(requestcontext.NewContext(req.Context(), &requestcontext.User{Id: 7}))
+}
+
+func TestProfileHandler_UploadAvatar(t *testing.T) {
+	t.Run("replaces the previous avatar", func(t *testing.T) {
+		h, mock, storage := newProfileHandler(t)
+		mock.ExpectQuery(`UPDATE users u\s+SET avatar_key = \$2`).
+			WithArgs(7, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"avatar_key"}).AddRow("avatars/7/old.png"))
+		mock.ExpectQuery(`FROM users WHERE id = \$1 AND deleted_at IS NULL`).
+			WithArgs(7).
+			WillReturnRows(profileRow("avatars/7/new.png", `{}`))
+
+		content := append(pngHeader, make([]byte, 1024)...)
+		w := httptest.NewRecorder()
+		h.UploadAvatar(w, avatarRequest(t, "avatar", "me.png", content))
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Len(t, storage.uploaded, 1)
+		assert.Regexp(t, `^avatars/7/[0-9a-f-]{36}\.png$`, storage.uploaded[0].Key)
+		assert.Equal(t, "image/png", storage.uploaded[0].ContentType)
+		assert.Equal(t, len(content), storage.uploadedLen, "the sniffed bytes are uploaded too")
+		assert.Equal(t, []string{"avatars/7/old.png"}, storage.deleted)
+		assert.Contains(t, w.Body.String(), `"avatar_url":"http://api.test`+fileurl.Path+fileurl.EncodeKey("avatars/7/new.png")+`?`)
+	})
+
+	t.Run("content type", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			filename string
+			content  []byte
+		}{
+			{"text named like a png", "me.png", []byte("definitely not an image")},
+			{"gif", "me.gif", []byte("GIF89a\x01\x00\x01\x00\x00\x00\x00;")},
+			{"pdf", "me.jpg", []byte("%PDF-1.7\n")},
+			{"empty", "me.png", nil},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				h, _, storage := newProfileHandler(t)
+
+				w := httptest.NewRecorder()
+				h.UploadAvatar(w, avatarRequest(t, "avatar", tt.filename, tt.content))
+
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+				assert.Empty(t, storage.uploaded)
+			})
+		}
+	})
+
+	t.Run("larger than 5 MB", func(t *testing.T) {
+		h, _, storage := newProfileHandler(t)
+		content := append(pngHeader, make([]byte, 5<<20)...)
+
+		w := httptest.NewRecorder()
+		h.UploadAvatar(w, avatarRequest(t, "avatar", "me.png", content))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Avatar must be at most 5 MB")
+		assert.Empty(t, storage.uploaded)
+	})
+
+	t.Run("body over the request limit", func(t *testing.T) {
+		h, _, storage := newProfileHandler(t)
+		req := avatarRequest(t, "avatar", "me.png", append(pngHeader, make([]byte, 4096)...))
+
+		w := httptest.NewRecorder()
+		req.Body = http.MaxBytesReader(w, req.Body, 1024)
+		h.UploadAvatar(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Empty(t, storage.uploaded)
+	})
+
+	t.Run("no avatar field", func(t *testing.T) {
+		h, _, _ := newProfileHandler(t)
+
+		w := httptest.NewRecorder()
+		h.UploadAvatar(w, avatarRequest(t, "photo", "me.png", pngHeader))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "avatar file is required")
+	})
+
+	t.Run("not multipart", func(t *testing.T) {
+		h, _, _ := newProfileHandler(t)
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/users/me/avatar", bytes.NewReader(pngHeader))
+		req.Header.Set("Content-Type", "image/png")
+		req = req.WithContext(requestcontext.NewContext(req.Context(), &requestcontext.User{Id: 7}))
+
+		w := httptest.NewRecorder()
+		h.UploadAvatar(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("profile gone after upload", func(t *testing.T) {
+		h, mock, storage := newProfileHandler(t)
+		mock.ExpectQuery(`UPDATE users u`).
+			WithArgs(7, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"avatar_key"}))
+
+		w := httptest.NewRecorder()
+		h.UploadAvatar(w, avatarRequest(t, "avatar", "me.png", pngHeader))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		require.Len(t, storage.uploaded, 1)
+		assert.Equal(t, []string{storage.uploaded[0].Key}, storage.deleted, "the unreferenced upload is removed")
+	})
+}
+
+func TestProfileHandler_UpdateProfile(t *testing.T) {
+	// Arguments of the update: display name, bio, timezone, preferences and
+	// weight; fields missing from the body are passed as NULL and kept
+	tests := []struct {
+		name     string
+		body     string
+		wantArgs []any
+	}{
+		{"display name only", `{"display_name":"Annie"}`, []any{"Annie", nil, nil, "{}", nil}},
+		{"bio only", `{"bio":"Trail runner"}`, []any{nil, "Trail runner", nil, "{}", nil}},
+		{"timezone and weight", `{"timezone":"America/New_York","weight_kg":62}`, []any{nil, nil, "America/New_York", "{}", 62.0}},
+		{"one preference", `{"preferences":{"units":"imperial"}}`, []any{nil, nil, nil, `{"units":"imperial"}`, nil}},
+		{"empty patch", `{}`, []any{nil, nil, nil, "{}", nil}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, mock, _ := newProfileHandler(t)
+			mock.ExpectQuery(`UPDATE users\s+SET display_name = COALESCE\(\$2, display_name\)`).
+				WithArgs(toDriverValues(append([]any{7}, tt.wantArgs...))...).
+				WillReturnRows(profileRow(nil, `{"units":"imperial"}`))
+
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/users/me", strings.NewReader(tt.body))
+			req = req.WithContext(requestcontext.NewContext(req.Context(), &requestcontext.User{Id: 7}))
+			w := httptest.NewRecorder()
+			h.UpdateProfile(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			// Stored preferences come back with the defaults of the unset ones
+			assert.Contains(t, w.Body.String(), `"preferences":{"units":"imperial","default_activity_visibility":"followers","show_on_leaderboards":true,"language":"en"}`)
+		})
+	}
+
+	invalid := []struct {
+		name string
+		body string
+	}{
+		{"display name too long", `{"display_name":"` + strings.Repeat("a", 101) + `"}`},
+		{"unknown timezone", `{"timezone":"Mars/Olympus_Mons"}`},
+		{"weight too high", `{"weight_kg":501}`},
+		{"unknown units", `{"preferences":{"units":"furlongs"}}`},
+		{"unknown field", `{"avatar_url":"https://example.com/me.png"}`},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _, _ := newProfileHandler(t)
+
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/users/me", strings.NewReader(tt.body))
+			req = req.WithContext(requestcontext.NewContext(req.Context(), &requestcontext.User{Id: 7}))
+			w := httptest.NewRecorder()
+			h.UpdateProfile(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	"github.com/valentinesamuel/activelog/pkg/dberr"
+	"github.com/valentinesamuel/activelog/pkg/query"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// queryCostLimits returns the list query budget configured by QUERY_*
+func queryCostLimits() query.CostLimits {
+	limits := query.DefaultCostLimits()
+	limits.Budget = config.Query.CostBudget
+	limits.Degrade = config.Query.CostDegrade
+	limits.MinLimit = config.Query.CostMinLimit
+	limits.MaxJoins = config.Query.MaxJoins
+	limits.MaxInValues = config.Query.MaxInValues
+	return limits
+}
+
+// enforceQueryCost applies the query budget to opts, which must already be
+// scoped to the user. A degraded query runs with a smaller page, announced in
+// the X-Query-Degraded header; a rejected one gets a 422 explaining the cost.
+// Queries that pass run with the configured statement timeout (see
+// failQueryTimeout). Returns false if the response has been written.
+func enforceQueryCost(w http.ResponseWriter, r *http.Request, opts *query.QueryOptions) bool {
+	opts.Hints.Apply(query.WithStatementTimeout(config.Query.StatementTimeout))
+	requested := opts.Limit
+	degraded, err := query.EnforceCost(opts, queryCostLimits())
+
+	var costErr *query.CostError
+	if errors.As(err, &costErr) {
+		log.Warn().Interface("cost", costErr.Cost).Msg("Query rejected by cost limits")
+		response.Fail(w, r, http.StatusUnprocessableEntity, err.Error())
+		return false
+	}
+	if degraded {
+		log.Info().Int("requested", requested).Int("limit", opts.Limit).Msg("Query page reduced by cost limits")
+		w.Header().Set("X-Query-Degraded", "limit="+strconv.Itoa(opts.Limit))
+	}
+	return true
+}
+
+// failQueryTimeout writes a 503 when a list query was canceled by its
+// statement timeout and reports whether it was
+func failQueryTimeout(w http.ResponseWriter, r *http.Request, err error) bool {
+	if !errors.Is(err, dberr.ErrStatementTimeout) {
+		return false
+	}
+	log.Warn().Err(err).Msg("Query canceled by statement timeout")
+	response.Fail(w, r, http.StatusServiceUnavailable, "Query took too long, narrow the filters or the page size")
+	return true
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/memory"
+	queueTypes "github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// QueueDebugHandler lists the jobs of the in-process memory queue, which is
+// otherwise invisible; the other providers have their own tools (asynqmon,
+// the nats CLI, the SQS console)
+type QueueDebugHandler struct {
+	queue *memory.Provider
+}
+
+// NewQueueDebugHandler creates a QueueDebugHandler. queue is nil outside
+// development or with another provider, where the listing is not available.
+func NewQueueDebugHandler(queue *memory.Provider) *QueueDebugHandler {
+	return &QueueDebugHandler{queue: queue}
+}
+
+// QueueDebugReport is the response of GET /api/v1/admin/queue
+type QueueDebugReport struct {
+	Queues []memory.QueueStats `json:"queues"`
+	Jobs   []memory.QueuedJob  `json:"jobs"`
+}
+
+// GetQueue handles GET /api/v1/admin/queue
+// @Summary Memory queue jobs
+// @Description Lists the depth and counters of each queue of the API's memory queue provider, and its pending and running jobs in enqueue order. Development with QUEUE_PROVIDER=memory only. Admins only.
+// @Tags Admin
+// @Produce json
+// @Param queue query string false "Only list the jobs of this queue" Enums(critical, default, low)
+// @Success 200 {object} handlers.QueueDebugReport "Queues and jobs"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Not an admin"
+// @Failure 404 {object} map[string]string "Not using the memory queue in development"
+// @Security BearerAuth
+// @ID GetQueueDebug
+// @Router /api/v1/admin/queue [get]
+func (h *QueueDebugHandler) GetQueue(w http.ResponseWriter, r *http.Request) {
+	if h.queue == nil {
+		response.Fail(w, r, http.StatusNotFound, "The queue listing is only available with the memory queue in development")
+		return
+	}
+
+	report := QueueDebugReport{Queues: h.queue.Stats(), Jobs: h.queue.Jobs()}
+	if queue := queueTypes.QueueName(r.URL.Query().Get("queue")); queue != "" {
+		jobs := report.Jobs[:0]
+		for _, job := range report.Jobs {
+			if job.Queue == queue {
+				jobs = append(jobs, job)
+			}
+		}
+		report.Jobs = jobs
+	}
+	response.Success(w, r, http.StatusOK, report)
+}
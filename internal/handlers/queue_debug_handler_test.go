@@ -0,0 +1,49 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/memory"
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	"github.com/valentinesamuel/activelog/internal/handlers"
+)
+
+func TestQueueDebugHandler_GetQueue(t *testing.T) {
+	queue := memory.New(10)
+	ctx := context.Background()
+	_, err := queue.Enqueue(ctx, types.DefaultQueue, types.JobPayload{Event: types.EventGenerateExport, Data: json.RawMessage(`{"user_id":7}`)})
+	require.NoError(t, err)
+	_, err = queue.Enqueue(ctx, types.LowQueue, types.JobPayload{Event: types.EventPurgeSoftDeleted})
+	require.NoError(t, err)
+
+	h := handlers.NewQueueDebugHandler(queue)
+	w := httptest.NewRecorder()
+	h.GetQueue(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/queue?queue=low", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var body struct {
+		Result handlers.QueueDebugReport `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, []memory.QueueStats{
+		{Queue: types.DefaultQueue, Pending: 1},
+		{Queue: types.LowQueue, Pending: 1},
+	}, body.Result.Queues)
+	require.Len(t, body.Result.Jobs, 1)
+	assert.Equal(t, types.EventPurgeSoftDeleted, body.Result.Jobs[0].Event)
+	assert.Equal(t, memory.JobPending, body.Result.Jobs[0].State)
+}
+
+func TestQueueDebugHandler_GetQueue_Disabled(t *testing.T) {
+	h := handlers.NewQueueDebugHandler(nil)
+	w := httptest.NewRecorder()
+	h.GetQueue(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/queue", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	webhookTypes "github.com/valentinesamuel/activelog/internal/adapters/webhook/types"
+	"github.com/valentinesamuel/activelog/internal/models"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/platform/validator"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// ReactionHandler handles reactions (kudos) on activities
+type ReactionHandler struct {
+	reactionRepo *repository.ReactionRepository
+	events       webhookTypes.WebhookBusProvider
+}
+
+// ReactionHandlerDeps contains the dependencies for ReactionHandler.
+type ReactionHandlerDeps struct {
+	ReactionRepo *repository.ReactionRepository
+	Events       webhookTypes.WebhookBusProvider // optional; receives reaction.created events
+}
+
+// NewReactionHandler creates a new ReactionHandler with the given dependencies.
+func NewReactionHandler(deps ReactionHandlerDeps) *ReactionHandler {
+	return &ReactionHandler{
+		reactionRepo: deps.ReactionRepo,
+		events:       deps.Events,
+	}
+}
+
+// React handles POST /api/v1/activities/{id}/reactions
+// @Summary React to an activity
+// @Description Leaves a reaction on one of your own activities, a public one, or a followers one by a member of a group you belong to or an athlete you coach. A user has one reaction per activity; reacting again replaces it. The activity owner is notified of new reactions from other users.
+// @Tags Activities
+// @Accept json
+// @Produce json
+// @Param id path string true "Activity public ID or serial ID"
+// @Param request body models.ReactRequest true "Reaction"
+// @Success 200 {object} models.ActivityReaction "Reaction changed"
+// @Success 201 {object} models.ActivityReaction "Reaction added"
+// @Failure 400 {object} map[string]interface{} "Validation error"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Activity not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/activities/{id}/reactions [post]
+func (h *ReactionHandler) React(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	activityID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid activity ID")
+		return
+	}
+
+	var req models.ReactRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
+		return
+	}
+
+	reaction := &models.ActivityReaction{
+		UserID:     user.Id,
+		ActivityID: activityID,
+		Reaction:   req.Reaction,
+	}
+	ownerID, created, err := h.reactionRepo.React(ctx, reaction)
+	if failDBError(w, r, err, "Activity") {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int64("activityID", activityID).Msg("Failed to react to activity")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to react to activity")
+		return
+	}
+
+	if !created {
+		response.Success(w, r, http.StatusOK, reaction)
+		return
+	}
+	if ownerID != user.Id {
+		h.notifyOwner(ctx, ownerID, reaction)
+	}
+	response.Success(w, r, http.StatusCreated, reaction)
+}
+
+// RemoveReaction handles DELETE /api/v1/activities/{id}/reactions
+// @Summary Remove my reaction
+// @Tags Activities
+// @Param id path string true "Activity public ID or serial ID"
+// @Success 204 "Removed"
+// @Failure 400 {object} map[string]string "Invalid activity ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Reaction not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/activities/{id}/reactions [delete]
+func (h *ReactionHandler) RemoveReaction(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	activityID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid activity ID")
+		return
+	}
+
+	err = h.reactionRepo.Remove(ctx, user.Id, activityID)
+	if failDBError(w, r, err, "Reaction") {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int64("activityID", activityID).Msg("Failed to remove reaction")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to remove reaction")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// notifyOwner publishes a reaction.created event for the activity owner
+// (webhooks and the WebSocket notifications channel)
+func (h *ReactionHandler) notifyOwner(ctx context.Context, ownerID int, reaction *models.ActivityReaction) {
+	publishActivityEvent(ctx, h.events, webhookTypes.EventReactionCreated, ownerID, reaction)
+}
@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rs/zerolog/log"
+	searchTypes "github.com/valentinesamuel/activelog/internal/adapters/search/types"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+const (
+	// maxSearchQueryLength caps the length of q, in characters
+	maxSearchQueryLength = 200
+
+	// defaultSearchLimit is the page size when limit is not given
+	defaultSearchLimit = 20
+)
+
+// SearchHandler serves full-text search over the user's activities
+type SearchHandler struct {
+	searcher   searchTypes.Searcher
+	activities repository.ActivityRepositoryInterface
+	reindex    func(ctx context.Context) error
+}
+
+type SearchHandlerDeps struct {
+	Searcher   searchTypes.Searcher // nil when search is disabled
+	Activities repository.ActivityRepositoryInterface
+
+	// Reindex starts rebuilding the search index; nil when search is disabled
+	Reindex func(ctx context.Context) error
+}
+
+// NewSearchHandler creates a new SearchHandler
+func NewSearchHandler(deps SearchHandlerDeps) *SearchHandler {
+	return &SearchHandler{
+		searcher:   deps.Searcher,
+		activities: deps.Activities,
+		reindex:    deps.Reindex,
+	}
+}
+
+// Search handles GET /api/v1/search
+// @Summary Search activities
+// @Description Full-text search over the title, description, notes, location name and tags of the user's activities. Every word of q must match; the last one may be the start of a word. Results come best match first, with the matching fragments highlighted in <mark> tags. Activities are read from the database, so results reflect their current state.
+// @Tags Search
+// @Produce json
+// @Param q query string true "Search text (at most 200 characters)"
+// @Param activityType query string false "Only activities of this type"
+// @Param from query string false "Only activities on or after this date (YYYY-MM-DD or RFC3339)"
+// @Param to query string false "Only activities on or before this date (YYYY-MM-DD or RFC3339)"
+// @Param limit query int false "Results per page (default 20, max SEARCH_MAX_RESULTS)"
+// @Param offset query int false "Results to skip"
+// @Success 200 {object} models.SearchResponse
+// @Failure 400 {object} map[string]string "Invalid parameters"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Search is not enabled"
+// @Failure 503 {object} map[string]string "Search index unavailable"
+// @Security BearerAuth
+// @Router /api/v1/search [get]
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	if h.searcher == nil {
+		response.Fail(w, r, http.StatusNotFound, "Search is not enabled")
+		return
+	}
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	q, ok := parseSearchQuery(w, r)
+	if !ok {
+		return
+	}
+	q.UserID = user.Id
+
+	result, err := h.searcher.Search(ctx, q)
+	if err != nil {
+		log.Error().Err(err).Msg("Search failed")
+		response.Fail(w, r, http.StatusServiceUnavailable, "Search is unavailable, try again later")
+		return
+	}
+
+	ids := make([]int64, len(result.Hits))
+	for i, hit := range result.Hits {
+		ids[i] = hit.ID
+	}
+	activities, err := h.activities.ListByIDs(ctx, user.Id, ids)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load search results")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to search activities")
+		return
+	}
+	byID := make(map[int64]*models.Activity, len(activities))
+	for _, activity := range activities {
+		byID[activity.ID] = activity
+	}
+
+	// Hits whose activity is gone were deleted after they were indexed
+	results := make([]models.SearchResult, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		if activity, ok := byID[hit.ID]; ok {
+			results = append(results, models.SearchResult{Activity: activity, Score: hit.Score, Highlights: hit.Highlights})
+		}
+	}
+
+	response.Success(w, r, http.StatusOK, models.SearchResponse{
+		Results: results,
+		Total:   result.Total,
+		Limit:   q.Limit,
+		Offset:  q.Offset,
+	})
+}
+
+// parseSearchQuery reads the search parameters. It writes a 400 and returns
+// false if they are invalid.
+func parseSearchQuery(w http.ResponseWriter, r *http.Request) (searchTypes.Query, bool) {
+	params := r.URL.Query()
+	q := searchTypes.Query{
+		Text:         strings.TrimSpace(params.Get("q")),
+		ActivityType: params.Get("activityType"),
+		Limit:        defaultSearchLimit,
+	}
+	if q.Text == "" {
+		response.Fail(w, r, http.StatusBadRequest, "Query parameter 'q' is required")
+		return q, false
+	}
+	if utf8.RuneCountInString(q.Text) > maxSearchQueryLength {
+		response.Fail(w, r, http.StatusBadRequest, "Query parameter 'q' is too long")
+		return q, false
+	}
+
+	for name, dest := range map[string]**time.Time{"from": &q.From, "to": &q.To} {
+		if value := params.Get(name); value != "" {
+			parsed, err := parseStatsDate(value)
+			if err != nil {
+				response.Fail(w, r, http.StatusBadRequest, "Invalid '"+name+"' date")
+				return q, false
+			}
+			*dest = &parsed
+		}
+	}
+
+	if value := params.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > config.Search.MaxResults {
+			response.Fail(w, r, http.StatusBadRequest, "Invalid 'limit', expected 1 to "+strconv.Itoa(config.Search.MaxResults))
+			return q, false
+		}
+		q.Limit = limit
+	}
+	if value := params.Get("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			response.Fail(w, r, http.StatusBadRequest, "Invalid 'offset'")
+			return q, false
+		}
+		q.Offset = offset
+	}
+	return q, true
+}
+
+// Reindex handles POST /api/v1/admin/search/reindex
+// @Summary Rebuild the search index
+// @Description Starts indexing every activity again, in the background: on the worker for Elasticsearch, in the API process for the memory index. Searches keep working meanwhile. Admins only.
+// @Tags Admin
+// @Produce json
+// @Success 202 {object} map[string]string "Reindex started"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Not an admin"
+// @Failure 404 {object} map[string]string "Search is not enabled"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/admin/search/reindex [post]
+func (h *SearchHandler) Reindex(w http.ResponseWriter, r *http.Request) {
+	if h.reindex == nil {
+		response.Fail(w, r, http.StatusNotFound, "Search is not enabled")
+		return
+	}
+	if err := h.reindex(r.Context()); err != nil {
+		log.Error().Err(err).Msg("Failed to start search reindex")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to start reindex")
+		return
+	}
+	response.Success(w, r, http.StatusAccepted, map[string]string{"status": "reindexing"})
+}
@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/platform/locale"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/platform/validator"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/pkg/auth"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
+	"github.com/valentinesamuel/activelog/pkg/format"
+	"github.com/valentinesamuel/activelog/pkg/i18n"
+	"github.com/valentinesamuel/activelog/pkg/response"
+	"github.com/valentinesamuel/activelog/pkg/ulid"
+)
+
+// ShareHandler handles public share links for activities
+type ShareHandler struct {
+	shareRepo    *repository.ShareRepository
+	activityRepo repository.ActivityRepositoryInterface
+	clock        clock.Clock
+}
+
+// NewShareHandler creates a new ShareHandler
+func NewShareHandler(shareRepo *repository.ShareRepository, activityRepo repository.ActivityRepositoryInterface) *ShareHandler {
+	return &ShareHandler{
+		shareRepo:    shareRepo,
+		activityRepo: activityRepo,
+		clock:        clock.Real{},
+	}
+}
+
+// WithClock sets the clock share links expire by
+func (h *ShareHandler) WithClock(c clock.Clock) *ShareHandler {
+	h.clock = c
+	return h
+}
+
+// CreateShare handles POST /api/v1/activities/{id}/share
+// @Summary Create a share link
+// @Description Creates a signed public link to an activity, optionally expiring after expires_in_hours. Only public activities can be shared; a link stops working while its activity is not public.
+// @Tags Activities
+// @Accept json
+// @Produce json
+// @Param id path string true "Activity public ID or serial ID"
+// @Param request body models.CreateShareRequest false "Share options"
+// @Success 201 {object} models.ActivityShare "Created share link"
+// @Failure 400 {object} map[string]interface{} "Validation error, or the activity is not public"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Activity not found"
+// @Security BearerAuth
+// @Router /api/v1/activities/{id}/share [post]
+func (h *ShareHandler) CreateShare(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	activity, ok := h.loadOwnedActivity(w, r)
+	if !ok {
+		return
+	}
+	if activity.Visibility != models.VisibilityPublic {
+		response.Fail(w, r, http.StatusBadRequest, "Only public activities can be shared; set the activity's visibility to public first")
+		return
+	}
+
+	// The body is optional - an empty request creates a link that never expires
+	var req models.CreateShareRequest
+	if !decodeOptionalJSON(w, r, &req) {
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
+		return
+	}
+
+	share := &models.ActivityShare{
+		ActivityID: activity.ID,
+		UserID:     activity.UserID,
+	}
+	if req.ExpiresInHours > 0 {
+		expiresAt := h.clock.Now().Add(time.Duration(req.ExpiresInHours) * time.Hour)
+		share.ExpiresAt = &expiresAt
+	}
+
+	if err := h.shareRepo.Create(ctx, share); err != nil {
+		log.Error().Err(err).Int64("activityID", share.ActivityID).Msg("Failed to create share link")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to create share link")
+		return
+	}
+
+	withShareURL(share)
+	response.Success(w, r, http.StatusCreated, share)
+}
+
+// ListShares handles GET /api/v1/activities/{id}/shares
+// @Summary List share links
+// @Description Returns every share link created for an activity, including revoked ones
+// @Tags Activities
+// @Produce json
+// @Param id path string true "Activity public ID or serial ID"
+// @Success 200 {array} models.ActivityShare "Share links"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Activity not found"
+// @Security BearerAuth
+// @Router /api/v1/activities/{id}/shares [get]
+func (h *ShareHandler) ListShares(w http.ResponseWriter, r *http.Request) {
+	activity, ok := h.loadOwnedActivity(w, r)
+	if !ok {
+		return
+	}
+
+	shares, err := h.shareRepo.ListByActivity(r.Context(), activity.ID)
+	if err != nil {
+		log.Error().Err(err).Int64("activityID", activity.ID).Msg("Failed to list share links")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to list share links")
+		return
+	}
+
+	for _, share := range shares {
+		withShareURL(share)
+	}
+
+	response.Success(w, r, http.StatusOK, shares)
+}
+
+// RevokeShare handles DELETE /api/v1/activities/{id}/shares/{shareId}
+// @Summary Revoke a share link
+// @Description Disables a share link so it can no longer be viewed
+// @Tags Activities
+// @Param id path string true "Activity public ID or serial ID"
+// @Param shareId path string true "Share public ID or serial ID"
+// @Success 204 "Revoked"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Share link not found"
+// @Security BearerAuth
+// @Router /api/v1/activities/{id}/shares/{shareId} [delete]
+func (h *ShareHandler) RevokeShare(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	activityID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid activity ID")
+		return
+	}
+	shareID, err := strconv.ParseInt(mux.Vars(r)["shareId"], 10, 64)
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid share ID")
+		return
+	}
+
+	if err := h.shareRepo.Revoke(ctx, shareID, activityID, user.Id); err != nil {
+		if errors.Is(err, appErrors.ErrNotFound) {
+			response.Fail(w, r, http.StatusNotFound, "Share link not found")
+			return
+		}
+		log.Error().Err(err).Int64("shareID", shareID).Msg("Failed to revoke share link")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to revoke share link")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetSharedActivity handles GET /share/{token}
+// @Summary View a shared activity
+// @Description Public, unauthenticated read-only view of a shared activity. Each request counts as a view. display holds its duration, distance and pace ready to show: in the owner's units, with numbers in the language of Accept-Language.
+// @Tags Share
+// @Produce json
+// @Param token path string true "Share token"
+// @Success 200 {object} models.SharedActivity "Shared activity"
+// @Failure 404 {object} map[string]string "Share link not found, expired or revoked"
+// @Router /share/{token} [get]
+func (h *ShareHandler) GetSharedActivity(w http.ResponseWriter, r *http.Request) {
+	shareID, err := auth.VerifyShareToken(mux.Vars(r)["token"])
+	if err != nil {
+		// Forged and unknown tokens look the same to the caller
+		response.Fail(w, r, http.StatusNotFound, "Share link not found")
+		return
+	}
+
+	var shared *models.SharedActivity
+	if ulid.Valid(shareID) {
+		shared, err = h.shareRepo.RecordViewByPublicID(r.Context(), ulid.Normalize(shareID), h.clock.Now())
+	} else if serialID, parseErr := strconv.ParseInt(shareID, 10, 64); parseErr == nil {
+		// Tokens issued before shares had public IDs carry the serial ID
+		shared, err = h.shareRepo.RecordView(r.Context(), serialID, h.clock.Now())
+	} else {
+		err = appErrors.ErrNotFound
+	}
+	if err != nil {
+		if errors.Is(err, appErrors.ErrNotFound) {
+			response.Fail(w, r, http.StatusNotFound, "Share link not found")
+			return
+		}
+		log.Error().Err(err).Str("shareID", shareID).Msg("Failed to load shared activity")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to load shared activity")
+		return
+	}
+
+	shared.Display = sharedActivityDisplay(locale.FromContext(r.Context()), shared)
+
+	w.Header().Set("Cache-Control", "no-store")
+	response.Success(w, r, http.StatusOK, shared)
+}
+
+// sharedActivityDisplay formats the measures of a shared activity for the
+// share page (see models.SharedActivityDisplay)
+func sharedActivityDisplay(l *i18n.Localizer, shared *models.SharedActivity) *models.SharedActivityDisplay {
+	units := format.Units(shared.Units)
+	duration := time.Duration(shared.DurationMinutes) * time.Minute
+
+	display := &models.SharedActivityDisplay{}
+	if shared.DurationMinutes > 0 {
+		display.Duration = format.Duration(duration)
+	}
+	if shared.DistanceKm > 0 {
+		display.Distance = format.Distance(l, shared.DistanceKm, units)
+	}
+	display.Pace = format.Pace(duration, shared.DistanceKm, units)
+	return display
+}
+
+// loadOwnedActivity resolves the {id} path variable to an activity owned by the caller
+// Activities belonging to other users are reported as missing
+func (h *ShareHandler) loadOwnedActivity(w http.ResponseWriter, r *http.Request) (*models.Activity, bool) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	activityID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid activity ID")
+		return nil, false
+	}
+
+	activity, err := h.activityRepo.GetByID(ctx, activityID)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrNotFound) {
+			response.Fail(w, r, http.StatusNotFound, "Activity not found")
+			return nil, false
+		}
+		log.Error().Err(err).Int64("activityID", activityID).Msg("Failed to fetch activity")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to fetch activity")
+		return nil, false
+	}
+
+	if activity.UserID != user.Id || activity.DeletedAt != nil {
+		response.Fail(w, r, http.StatusNotFound, "Activity not found")
+		return nil, false
+	}
+
+	return activity, true
+}
+
+// withShareURL fills in the public token and path for a share link
+// Tokens are derived from the share's public ID, so they never need to be stored
+func withShareURL(share *models.ActivityShare) {
+	share.Token = auth.GenerateShareToken(share.PublicID)
+	share.URL = "/share/" + share.Token
+}
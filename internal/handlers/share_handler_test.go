@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	"github.com/valentinesamuel/activelog/internal/platform/locale"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/repository/testhelpers"
+	"github.com/valentinesamuel/activelog/pkg/auth"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+)
+
+func TestSharedActivityDisplay(t *testing.T) {
+	tests := []struct {
+		name   string
+		lang   string
+		shared models.SharedActivity
+		want   models.SharedActivityDisplay
+	}{
+		{
+			name:   "metric run",
+			lang:   "en",
+			shared: models.SharedActivity{DurationMinutes: 65, DistanceKm: 12.5},
+			want:   models.SharedActivityDisplay{Duration: "1h 05m", Distance: "12.5 km", Pace: "5:12 /km"},
+		},
+		{
+			name:   "owner's units, viewer's language",
+			lang:   "fr",
+			shared: models.SharedActivity{DurationMinutes: 65, DistanceKm: 12.5, Units: models.UnitsImperial},
+			want:   models.SharedActivityDisplay{Duration: "1h 05m", Distance: "7,8 mi", Pace: "8:22 /mi"},
+		},
+		{
+			name:   "no distance",
+			lang:   "en",
+			shared: models.SharedActivity{DurationMinutes: 45},
+			want:   models.SharedActivityDisplay{Duration: "45m"},
+		},
+		{
+			name:   "no duration",
+			lang:   "es",
+			shared: models.SharedActivity{DistanceKm: 3.25},
+			want:   models.SharedActivityDisplay{Distance: "3,2 km"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, &tt.want, sharedActivityDisplay(locale.New(tt.lang), &tt.shared))
+		})
+	}
+}
+
+var sharedActivityRows = []string{"activity_type", "title", "description", "duration_minutes", "distance_km",
+	"calories_burned", "activity_date", "view_count", "units"}
+
+func TestShareHandler_GetSharedActivity(t *testing.T) {
+	previous := config.Common
+	config.Common = &config.CommonConfig{Auth: config.AuthConfig{JWTSecret: "test-secret"}}
+	t.Cleanup(func() { config.Common = previous })
+
+	const publicID = "01HZY3V5J6X7Q8R9S0T1V2W3X4"
+	date := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	now := time.Date(2025, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		token      string
+		expect     func(mock sqlmock.Sqlmock)
+		wantStatus int
+		wantViews  int
+	}{
+		{
+			name:  "live link",
+			token: auth.GenerateShareToken(publicID),
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE activity_shares s .* WHERE s.public_id = \$1 .* AND s.revoked_at IS NULL\s+AND \(s.expires_at IS NULL OR s.expires_at > \$2\)`).
+					WithArgs(publicID, now).
+					WillReturnRows(sqlmock.NewRows(sharedActivityRows).AddRow("running", "Parkrun", "", 25, 5.0, 0, date, 3, "metric"))
+			},
+			wantStatus: http.StatusOK,
+			wantViews:  3,
+		},
+		{
+			name:  "revoked or expired link",
+			token: auth.GenerateShareToken(publicID),
+			expect: func(mock sqlmock.Sqlmock) {
+				// The update only matches live links, so nothing is counted
+				mock.ExpectQuery(`UPDATE activity_shares s`).
+					WithArgs(publicID, now).
+					WillReturnRows(sqlmock.NewRows(sharedActivityRows))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:  "revoked link with a serial ID",
+			token: auth.GenerateShareToken("42"),
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE activity_shares s .* WHERE s.id = \$1`).
+					WithArgs(int64(42), now).
+					WillReturnRows(sqlmock.NewRows(sharedActivityRows))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "forged token",
+			token:      publicID + ".c2lnbmF0dXJl",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "signed ID that is neither public nor serial",
+			token:      auth.GenerateShareToken("home"),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:  "database error",
+			token: auth.GenerateShareToken(publicID),
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE activity_shares s`).WithArgs(publicID, now).WillReturnError(errors.New("connection reset"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := testhelpers.SetupMockDB(t)
+			if tt.expect != nil {
+				tt.expect(mock)
+			}
+			h := NewShareHandler(repository.NewShareRepository(db), nil).WithClock(clock.NewFake(now))
+
+			req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/share/"+tt.token, nil), map[string]string{"token": tt.token})
+			w := httptest.NewRecorder()
+			h.GetSharedActivity(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+			var body struct {
+				Result models.SharedActivity `json:"result"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, tt.wantViews, body.Result.ViewCount)
+			assert.Equal(t, &models.SharedActivityDisplay{Duration: "25m", Distance: "5.0 km", Pace: "5:00 /km"}, body.Result.Display)
+		})
+	}
+}
@@ -1,22 +1,64 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/rs/zerolog/log"
+	cacheTypes "github.com/valentinesamuel/activelog/internal/adapters/cache/types"
 	"github.com/valentinesamuel/activelog/internal/repository"
 	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
 	"github.com/valentinesamuel/activelog/pkg/response"
 )
 
+// maxTimeSeriesBuckets caps how many zero-filled buckets a single request may generate
+const maxTimeSeriesBuckets = 366
+
+// Heatmaps of past years only change when old activities are edited or
+// imported; the current year's changes all the time
+const (
+	heatmapCacheTTL     = 5 * time.Minute
+	heatmapPastCacheTTL = 24 * time.Hour
+)
+
+var heatmapCacheOpts = cacheTypes.CacheOptions{
+	DB:           cacheTypes.CacheDBStats,
+	PartitionKey: cacheTypes.CachePartitionStats,
+}
+
 type StatsHandler struct {
-	repo repository.StatsRepositoryInterface
+	repo  repository.StatsRepositoryInterface
+	cache cacheTypes.CacheAdapter // nil disables caching
+	clock clock.Clock
 }
 
-func NewStatsHandler(repo repository.StatsRepositoryInterface) *StatsHandler {
-	return &StatsHandler{repo: repo}
+func NewStatsHandler(repo repository.StatsRepositoryInterface, cache cacheTypes.CacheAdapter) *StatsHandler {
+	return &StatsHandler{repo: repo, cache: cache, clock: clock.Real{}}
 }
 
+// WithClock sets the clock that default date ranges and the current year come from
+func (sh *StatsHandler) WithClock(c clock.Clock) *StatsHandler {
+	sh.clock = c
+	return sh
+}
+
+// GetWeeklyStats returns the totals of the last 7 days
+// @Summary Get weekly stats
+// @Description Returns the number, total duration and distance and average duration and perceived exertion of the activities of the last 7 days, with the weight trend when weight was logged.
+// @Tags Stats
+// @Produce json
+// @Success 200 {object} repository.WeeklyStats
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/stats/weekly [get]
 func (sh *StatsHandler) GetWeeklyStats(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -24,6 +66,20 @@ func (sh *StatsHandler) GetWeeklyStats(w http.ResponseWriter, r *http.Request) {
 
 	userID := requestUser.Id
 
+	compare, ok := parseStatsCompare(w, r)
+	if !ok {
+		return
+	}
+	if compare {
+		comparison, err := sh.repo.GetWeeklyComparison(ctx, userID)
+		if err != nil {
+			response.Fail(w, r, http.StatusInternalServerError, "Error fetching weekly stats")
+			return
+		}
+		response.Success(w, r, http.StatusOK, comparison)
+		return
+	}
+
 	weeklyStats, err := sh.repo.GetWeeklyStats(ctx, userID)
 	if err != nil {
 		response.Fail(w, r, http.StatusInternalServerError, "Error fetching weekly stats")
@@ -37,12 +93,27 @@ func (sh *StatsHandler) GetMonthlyStats(w http.ResponseWriter, r *http.Request)
 	ctx := r.Context()
 	requestUser, _ := requestcontext.FromContext(ctx)
 
+	compare, ok := parseStatsCompare(w, r)
+	if !ok {
+		return
+	}
+	if compare {
+		comparison, err := sh.repo.GetMonthlyComparison(ctx, requestUser.Id)
+		if err != nil {
+			response.Fail(w, r, http.StatusInternalServerError, "Error fetching monthly stats")
+			return
+		}
+		response.Success(w, r, http.StatusOK, comparison)
+		return
+	}
+
 	monthlyStats, err := sh.repo.GetMonthlyStats(ctx, requestUser.Id)
 	if err != nil {
 		response.Fail(w, r, http.StatusInternalServerError, "Error fetching monthly stats")
 		return
 	}
 
+	sh.writeStatsFreshness(w, r, requestUser.Id)
 	response.Success(w, r, http.StatusOK, monthlyStats)
 }
 
@@ -81,6 +152,8 @@ func (sh *StatsHandler) GetTopTags(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	sh.writeStatsFreshness(w, r, requestUser.Id)
+
 	// Create response with tags and total count
 	responseData := map[string]interface{}{
 		"tags":              topTags,
@@ -107,6 +180,8 @@ func (sh *StatsHandler) GetActivityCountByType(w http.ResponseWriter, r *http.Re
 		totalActivities += count
 	}
 
+	sh.writeStatsFreshness(w, r, requestUser.Id)
+
 	// Create response with breakdown and total
 	responseData := map[string]interface{}{
 		"activity_breakdown": activityBreakdown,
@@ -115,3 +190,349 @@ func (sh *StatsHandler) GetActivityCountByType(w http.ResponseWriter, r *http.Re
 
 	response.Success(w, r, http.StatusOK, responseData)
 }
+
+// GetTimeSeries returns a zero-filled, date-bucketed series for a single metric
+// @Summary Get time series stats
+// @Description Returns one bucket per day/week/month between from and to, with empty buckets reported as 0
+// @Tags Stats
+// @Produce json
+// @Param metric query string false "count, distance_km, duration_minutes, calories_burned or rpe (average perceived exertion) (default: distance_km)"
+// @Param interval query string false "day, week or month (default: day)"
+// @Param from query string false "Start date, YYYY-MM-DD or RFC3339 (default: 30 days before to)"
+// @Param to query string false "End date, YYYY-MM-DD or RFC3339 (default: now)"
+// @Success 200 {object} map[string]interface{} "Time series buckets"
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/stats/timeseries [get]
+func (sh *StatsHandler) GetTimeSeries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestUser, _ := requestcontext.FromContext(ctx)
+	params := r.URL.Query()
+
+	metric := params.Get("metric")
+	if metric == "" {
+		metric = "distance_km"
+	}
+	if !repository.IsValidTimeSeriesMetric(metric) {
+		response.Fail(w, r, http.StatusBadRequest, fmt.Sprintf("Unsupported metric '%s'", metric))
+		return
+	}
+
+	interval := params.Get("interval")
+	if interval == "" {
+		interval = "day"
+	}
+	if !repository.IsValidTimeSeriesInterval(interval) {
+		response.Fail(w, r, http.StatusBadRequest, fmt.Sprintf("Unsupported interval '%s'", interval))
+		return
+	}
+
+	from, to, ok := parseStatsRange(w, r, sh.clock.Now(), 30)
+	if !ok {
+		return
+	}
+
+	if estimateBuckets(from, to, interval) > maxTimeSeriesBuckets {
+		response.Fail(w, r, http.StatusBadRequest, fmt.Sprintf("Range too large: at most %d %s buckets allowed", maxTimeSeriesBuckets, interval))
+		return
+	}
+
+	points, err := sh.repo.GetTimeSeries(ctx, requestUser.Id, metric, interval, from, to)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrInvalidInput) {
+			response.Fail(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+		log.Error().Err(err).Int("userID", requestUser.Id).Str("metric", metric).Msg("Failed to get time series")
+		response.Fail(w, r, http.StatusInternalServerError, "Error fetching time series")
+		return
+	}
+
+	responseData := map[string]interface{}{
+		"metric":   metric,
+		"interval": interval,
+		"from":     from,
+		"to":       to,
+		"buckets":  points,
+	}
+
+	response.Success(w, r, http.StatusOK, responseData)
+}
+
+// GetTrainingLoad returns the daily training load with its acute and chronic rolling sums
+// @Summary Get training load
+// @Description Returns one point per day between from and to with that day's training load, the rolling 7-day (acute) and 28-day (chronic) sums, and their acute:chronic ratio (acute load divided by the weekly average of the chronic load). Activities logged with heart-rate data contribute their zone-weighted training load; others contribute their duration in minutes.
+// @Tags Stats
+// @Produce json
+// @Param from query string false "Start date, YYYY-MM-DD or RFC3339 (default: 28 days before to)"
+// @Param to query string false "End date, YYYY-MM-DD or RFC3339 (default: now)"
+// @Success 200 {object} map[string]interface{} "Daily training load"
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/stats/training-load [get]
+func (sh *StatsHandler) GetTrainingLoad(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestUser, _ := requestcontext.FromContext(ctx)
+
+	from, to, ok := parseStatsRange(w, r, sh.clock.Now(), 28)
+	if !ok {
+		return
+	}
+	if estimateBuckets(from, to, "day") > maxTimeSeriesBuckets {
+		response.Fail(w, r, http.StatusBadRequest, fmt.Sprintf("Range too large: at most %d days allowed", maxTimeSeriesBuckets))
+		return
+	}
+
+	points, err := sh.repo.GetTrainingLoad(ctx, requestUser.Id, from, to)
+	if err != nil {
+		log.Error().Err(err).Int("userID", requestUser.Id).Msg("Failed to get training load")
+		response.Fail(w, r, http.StatusInternalServerError, "Error fetching training load")
+		return
+	}
+
+	responseData := map[string]interface{}{
+		"from":   from,
+		"to":     to,
+		"points": points,
+	}
+
+	response.Success(w, r, http.StatusOK, responseData)
+}
+
+// GetHeatmap returns the number and duration of activities on every day of a year
+// @Summary Get activity heatmap
+// @Description Returns one entry per day of the year (UTC) with the number of activities and their total duration, zero-filled for days without activities, for a contribution-graph style calendar. maxCount is the highest daily count. Cached per user and year.
+// @Tags Stats
+// @Produce json
+// @Param year query int false "Calendar year (default: current year)"
+// @Success 200 {object} map[string]interface{} "Daily activity counts"
+// @Failure 400 {object} map[string]string "Invalid year"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/stats/heatmap [get]
+func (sh *StatsHandler) GetHeatmap(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestUser, _ := requestcontext.FromContext(ctx)
+
+	currentYear := sh.clock.Now().UTC().Year()
+	year := currentYear
+	if yearParam := r.URL.Query().Get("year"); yearParam != "" {
+		parsed, err := strconv.Atoi(yearParam)
+		if err != nil || parsed < 1970 || parsed > currentYear {
+			response.Fail(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid year, expected 1970 to %d", currentYear))
+			return
+		}
+		year = parsed
+	}
+
+	cacheKey := fmt.Sprintf("user:%d:heatmap:%d", requestUser.Id, year)
+	days, hit := sh.cachedHeatmap(ctx, cacheKey)
+	if !hit {
+		var err error
+		days, err = sh.repo.GetHeatmap(ctx, requestUser.Id, year)
+		if err != nil {
+			log.Error().Err(err).Int("userID", requestUser.Id).Int("year", year).Msg("Failed to get heatmap")
+			response.Fail(w, r, http.StatusInternalServerError, "Error fetching heatmap")
+			return
+		}
+
+		ttl := heatmapCacheTTL
+		if year < currentYear {
+			ttl = heatmapPastCacheTTL
+		}
+		if sh.cache != nil {
+			if data, err := json.Marshal(days); err == nil {
+				_ = sh.cache.Set(ctx, cacheKey, string(data), ttl, heatmapCacheOpts)
+			}
+		}
+		w.Header().Set("X-Cache-Status", "MISS")
+		w.Header().Set("X-Cache-TTL", strconv.Itoa(int(ttl.Seconds())))
+	} else {
+		w.Header().Set("X-Cache-Status", "HIT")
+	}
+
+	maxCount := 0
+	for _, day := range days {
+		maxCount = max(maxCount, day.Count)
+	}
+
+	responseData := map[string]interface{}{
+		"year":     year,
+		"maxCount": maxCount,
+		"days":     days,
+	}
+
+	response.Success(w, r, http.StatusOK, responseData)
+}
+
+// GetRPEVsDuration relates the perceived exertion of activities to their duration
+// @Summary Get RPE vs duration
+// @Description Correlates the perceived exertion (RPE, 1-10) of the activities between from and to with their duration (Pearson's r, null with fewer than two activities or no variation), and averages the RPE per duration bucket (0-30, 30-60, 60-90, 90-120 and 120+ minutes). Activities logged without an RPE are left out.
+// @Tags Stats
+// @Produce json
+// @Param from query string false "Start date, YYYY-MM-DD or RFC3339 (default: 90 days before to)"
+// @Param to query string false "End date, YYYY-MM-DD or RFC3339 (default: now)"
+// @Success 200 {object} map[string]interface{} "Correlation and RPE per duration bucket"
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/stats/rpe-vs-duration [get]
+func (sh *StatsHandler) GetRPEVsDuration(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestUser, _ := requestcontext.FromContext(ctx)
+
+	from, to, ok := parseStatsRange(w, r, sh.clock.Now(), 90)
+	if !ok {
+		return
+	}
+
+	correlation, err := sh.repo.GetRPEVsDuration(ctx, requestUser.Id, from, to)
+	if err != nil {
+		log.Error().Err(err).Int("userID", requestUser.Id).Msg("Failed to get RPE vs duration")
+		response.Fail(w, r, http.StatusInternalServerError, "Error fetching RPE vs duration")
+		return
+	}
+
+	responseData := map[string]interface{}{
+		"from":        from,
+		"to":          to,
+		"samples":     correlation.Samples,
+		"correlation": correlation.Correlation,
+		"buckets":     correlation.Buckets,
+	}
+
+	response.Success(w, r, http.StatusOK, responseData)
+}
+
+// GetBestSplits returns the user's fastest kilometre and 5k splits
+// @Summary Get best splits
+// @Description Returns the fastest kilometre split and the fastest five consecutive kilometre splits (5k) of one activity, across the user's activities. Splits within 10 m of a kilometre count; each best is null until there is one.
+// @Tags Stats
+// @Produce json
+// @Success 200 {object} repository.BestSplits "Fastest 1k and 5k"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/stats/best-splits [get]
+func (sh *StatsHandler) GetBestSplits(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestUser, _ := requestcontext.FromContext(ctx)
+
+	best, err := sh.repo.GetBestSplits(ctx, requestUser.Id)
+	if err != nil {
+		log.Error().Err(err).Int("userID", requestUser.Id).Msg("Failed to get best splits")
+		response.Fail(w, r, http.StatusInternalServerError, "Error fetching best splits")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, best)
+}
+
+// cachedHeatmap returns the heatmap cached under key, if any
+func (sh *StatsHandler) cachedHeatmap(ctx context.Context, key string) ([]repository.HeatmapDay, bool) {
+	if sh.cache == nil {
+		return nil, false
+	}
+	cached, err := sh.cache.Get(ctx, key, heatmapCacheOpts)
+	if err != nil || cached == "" {
+		return nil, false
+	}
+	var days []repository.HeatmapDay
+	if err := json.Unmarshal([]byte(cached), &days); err != nil {
+		return nil, false
+	}
+	return days, true
+}
+
+// writeStatsFreshness sets X-Stats-Source ("live" or "summary") and, for
+// summaries, X-Stats-Refreshed-At when the repository may serve stats from
+// the summary tables (STATS_READ_FROM_SUMMARIES)
+func (sh *StatsHandler) writeStatsFreshness(w http.ResponseWriter, r *http.Request, userID int) {
+	reporter, ok := sh.repo.(repository.StatsFreshnessReporter)
+	if !ok {
+		return
+	}
+	freshness, err := reporter.StatsFreshness(r.Context(), userID)
+	if err != nil {
+		log.Warn().Err(err).Int("userID", userID).Msg("Failed to get stats freshness")
+		return
+	}
+	w.Header().Set("X-Stats-Source", freshness.Source)
+	if freshness.RefreshedAt != nil {
+		w.Header().Set("X-Stats-Refreshed-At", freshness.RefreshedAt.UTC().Format(time.RFC3339))
+	}
+}
+
+// parseStatsCompare reads the compare query parameter of the weekly and
+// monthly stats; "previous" asks for a comparison with the previous period.
+// It writes a 400 and returns false for any other value.
+func parseStatsCompare(w http.ResponseWriter, r *http.Request) (bool, bool) {
+	switch r.URL.Query().Get("compare") {
+	case "":
+		return false, true
+	case "previous":
+		return true, true
+	default:
+		response.Fail(w, r, http.StatusBadRequest, "Unsupported compare value, expected 'previous'")
+		return false, false
+	}
+}
+
+// parseStatsRange reads the from/to query parameters, defaulting to the
+// defaultDays before now. It writes a 400 and returns false if they are invalid.
+func parseStatsRange(w http.ResponseWriter, r *http.Request, now time.Time, defaultDays int) (time.Time, time.Time, bool) {
+	params := r.URL.Query()
+
+	to := now.UTC()
+	if toParam := params.Get("to"); toParam != "" {
+		parsed, err := parseStatsDate(toParam)
+		if err != nil {
+			response.Fail(w, r, http.StatusBadRequest, "Invalid 'to' date")
+			return time.Time{}, time.Time{}, false
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -defaultDays)
+	if fromParam := params.Get("from"); fromParam != "" {
+		parsed, err := parseStatsDate(fromParam)
+		if err != nil {
+			response.Fail(w, r, http.StatusBadRequest, "Invalid 'from' date")
+			return time.Time{}, time.Time{}, false
+		}
+		from = parsed
+	}
+
+	if from.After(to) {
+		response.Fail(w, r, http.StatusBadRequest, "'from' must be before 'to'")
+		return time.Time{}, time.Time{}, false
+	}
+	return from, to, true
+}
+
+// parseStatsDate accepts either a plain date (YYYY-MM-DD) or a full RFC3339 timestamp
+func parseStatsDate(value string) (time.Time, error) {
+	if parsed, err := time.Parse("2006-01-02", value); err == nil {
+		return parsed, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// estimateBuckets approximates how many buckets generate_series will produce for the range
+func estimateBuckets(from, to time.Time, interval string) int {
+	days := int(to.Sub(from).Hours()/24) + 1
+	switch interval {
+	case "week":
+		return days/7 + 1
+	case "month":
+		return (to.Year()-from.Year())*12 + int(to.Month()-from.Month()) + 1
+	default:
+		return days
+	}
+}
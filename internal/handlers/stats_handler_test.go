@@ -7,11 +7,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
+	cacheTypes "github.com/valentinesamuel/activelog/internal/adapters/cache/types"
 	"github.com/valentinesamuel/activelog/internal/handlers"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
 	"github.com/valentinesamuel/activelog/internal/repository"
 	"github.com/valentinesamuel/activelog/internal/repository/mocks"
 )
@@ -96,7 +99,7 @@ func TestStatsHandler_GetWeeklyStats(t *testing.T) {
 			mockRepo := mocks.NewMockStatsRepositoryInterface(ctrl)
 			tt.setupMock(mockRepo)
 
-			handler := handlers.NewStatsHandler(mockRepo)
+			handler := handlers.NewStatsHandler(mockRepo, nil)
 
 			// Create request with context
 			req := httptest.NewRequest(http.MethodGet, "/api/v1/stats/weekly", nil)
@@ -180,7 +183,7 @@ func TestStatsHandler_GetMonthlyStats(t *testing.T) {
 			mockRepo := mocks.NewMockStatsRepositoryInterface(ctrl)
 			tt.setupMock(mockRepo)
 
-			handler := handlers.NewStatsHandler(mockRepo)
+			handler := handlers.NewStatsHandler(mockRepo, nil)
 
 			req := httptest.NewRequest(http.MethodGet, "/api/v1/stats/monthly", nil)
 			if tt.userID != nil {
@@ -203,6 +206,136 @@ func TestStatsHandler_GetMonthlyStats(t *testing.T) {
 	}
 }
 
+func TestStatsHandler_CompareWithPreviousPeriod(t *testing.T) {
+	change := 50.0
+	comparison := &repository.StatsComparison{
+		Period:  "week",
+		Compare: "previous",
+		Metrics: map[string]repository.MetricComparison{
+			"totalActivities": {Current: 6, Previous: 4, ChangePct: &change},
+			"totalDistanceKm": {Current: 12.5, Previous: 0},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		url            string
+		setupMock      func(*mocks.MockStatsRepositoryInterface)
+		expectedStatus int
+	}{
+		{
+			name: "weekly compare=previous uses the comparison query",
+			url:  "/api/v1/stats/weekly?compare=previous",
+			setupMock: func(m *mocks.MockStatsRepositoryInterface) {
+				m.EXPECT().GetWeeklyComparison(gomock.Any(), 1).Return(comparison, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unsupported compare value",
+			url:            "/api/v1/stats/weekly?compare=lastyear",
+			setupMock:      func(m *mocks.MockStatsRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "comparison query fails",
+			url:  "/api/v1/stats/weekly?compare=previous",
+			setupMock: func(m *mocks.MockStatsRepositoryInterface) {
+				m.EXPECT().GetWeeklyComparison(gomock.Any(), 1).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockStatsRepositoryInterface(ctrl)
+			tt.setupMock(mockRepo)
+
+			handler := handlers.NewStatsHandler(mockRepo, nil)
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			req = req.WithContext(requestcontext.NewContext(req.Context(), &requestcontext.User{Id: 1}))
+
+			w := httptest.NewRecorder()
+			handler.GetWeeklyStats(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var body struct {
+					Result repository.StatsComparison `json:"result"`
+				}
+				err := json.NewDecoder(w.Body).Decode(&body)
+				assert.NoError(t, err)
+				assert.Equal(t, "week", body.Result.Period)
+				if assert.NotNil(t, body.Result.Metrics["totalActivities"].ChangePct) {
+					assert.Equal(t, 50.0, *body.Result.Metrics["totalActivities"].ChangePct)
+				}
+				assert.Nil(t, body.Result.Metrics["totalDistanceKm"].ChangePct)
+			}
+		})
+	}
+}
+
+// summaryStatsRepo is a stats repository that reports serving summaries
+type summaryStatsRepo struct {
+	*mocks.MockStatsRepositoryInterface
+	freshness *repository.StatsFreshness
+}
+
+func (r *summaryStatsRepo) StatsFreshness(ctx context.Context, userID int) (*repository.StatsFreshness, error) {
+	return r.freshness, nil
+}
+
+func TestStatsHandler_StatsFreshnessHeaders(t *testing.T) {
+	refreshedAt := time.Date(2026, time.March, 2, 10, 15, 0, 0, time.UTC)
+
+	tests := []struct {
+		name              string
+		freshness         *repository.StatsFreshness
+		expectedSource    string
+		expectedRefreshed string
+	}{
+		{
+			name:              "served from summaries",
+			freshness:         &repository.StatsFreshness{Source: repository.StatsSourceSummary, RefreshedAt: &refreshedAt},
+			expectedSource:    "summary",
+			expectedRefreshed: "2026-03-02T10:15:00Z",
+		},
+		{
+			name:           "summaries too stale, served live",
+			freshness:      &repository.StatsFreshness{Source: repository.StatsSourceLive},
+			expectedSource: "live",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockStatsRepositoryInterface(ctrl)
+			mockRepo.EXPECT().GetMonthlyStats(gomock.Any(), 1).Return(&repository.MonthlyStats{"running": 3}, nil)
+
+			handler := handlers.NewStatsHandler(&summaryStatsRepo{MockStatsRepositoryInterface: mockRepo, freshness: tt.freshness}, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/stats/monthly", nil)
+			req = req.WithContext(requestcontext.NewContext(req.Context(), &requestcontext.User{Id: 1}))
+
+			w := httptest.NewRecorder()
+			handler.GetMonthlyStats(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.expectedSource, w.Header().Get("X-Stats-Source"))
+			assert.Equal(t, tt.expectedRefreshed, w.Header().Get("X-Stats-Refreshed-At"))
+		})
+	}
+}
+
 func TestStatsHandler_GetTopTags(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -307,7 +440,7 @@ func TestStatsHandler_GetTopTags(t *testing.T) {
 			mockRepo := mocks.NewMockStatsRepositoryInterface(ctrl)
 			tt.setupMock(mockRepo)
 
-			handler := handlers.NewStatsHandler(mockRepo)
+			handler := handlers.NewStatsHandler(mockRepo, nil)
 
 			req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me/tags/top"+tt.queryParams, nil)
 			if tt.userID != nil {
@@ -387,7 +520,7 @@ func TestStatsHandler_GetActivityCountByType(t *testing.T) {
 			mockRepo := mocks.NewMockStatsRepositoryInterface(ctrl)
 			tt.setupMock(mockRepo)
 
-			handler := handlers.NewStatsHandler(mockRepo)
+			handler := handlers.NewStatsHandler(mockRepo, nil)
 
 			req := httptest.NewRequest(http.MethodGet, "/api/v1/stats/by-type", nil)
 			if tt.userID != nil {
@@ -463,7 +596,7 @@ func TestStatsHandler_GetUserActivitySummary(t *testing.T) {
 			mockRepo := mocks.NewMockStatsRepositoryInterface(ctrl)
 			tt.setupMock(mockRepo)
 
-			handler := handlers.NewStatsHandler(mockRepo)
+			handler := handlers.NewStatsHandler(mockRepo, nil)
 
 			req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me/summary", nil)
 			if tt.userID != nil {
@@ -487,3 +620,238 @@ func TestStatsHandler_GetUserActivitySummary(t *testing.T) {
 		})
 	}
 }
+
+func TestStatsHandler_GetTimeSeries(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		setupMock      func(*mocks.MockStatsRepositoryInterface)
+		expectedStatus int
+		expectedCount  int
+	}{
+		{
+			name: "success - returns zero-filled daily buckets",
+			url:  "/api/v1/stats/timeseries?metric=distance_km&interval=day&from=2024-01-01&to=2024-01-03",
+			setupMock: func(m *mocks.MockStatsRepositoryInterface) {
+				from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+				to := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+				m.EXPECT().
+					GetTimeSeries(gomock.Any(), 1, "distance_km", "day", from, to).
+					Return([]repository.TimeSeriesPoint{
+						{Bucket: from, Value: 5.2},
+						{Bucket: from.AddDate(0, 0, 1), Value: 0},
+						{Bucket: to, Value: 10},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedCount:  3,
+		},
+		{
+			name:           "error - unsupported metric",
+			url:            "/api/v1/stats/timeseries?metric=password",
+			setupMock:      func(m *mocks.MockStatsRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "error - unsupported interval",
+			url:            "/api/v1/stats/timeseries?interval=hour",
+			setupMock:      func(m *mocks.MockStatsRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "error - from after to",
+			url:            "/api/v1/stats/timeseries?from=2024-02-01&to=2024-01-01",
+			setupMock:      func(m *mocks.MockStatsRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "error - range exceeds bucket cap",
+			url:            "/api/v1/stats/timeseries?interval=day&from=2020-01-01&to=2024-01-01",
+			setupMock:      func(m *mocks.MockStatsRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error - repository returns error",
+			url:  "/api/v1/stats/timeseries?from=2024-01-01&to=2024-01-31",
+			setupMock: func(m *mocks.MockStatsRepositoryInterface) {
+				m.EXPECT().
+					GetTimeSeries(gomock.Any(), 1, "distance_km", "day", gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockStatsRepositoryInterface(ctrl)
+			tt.setupMock(mockRepo)
+
+			handler := handlers.NewStatsHandler(mockRepo, nil)
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			req = req.WithContext(requestcontext.NewContext(req.Context(), &requestcontext.User{Id: 1}))
+
+			w := httptest.NewRecorder()
+			handler.GetTimeSeries(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var body struct {
+					Result struct {
+						Buckets []repository.TimeSeriesPoint `json:"buckets"`
+					} `json:"result"`
+				}
+				err := json.NewDecoder(w.Body).Decode(&body)
+				assert.NoError(t, err)
+				assert.Len(t, body.Result.Buckets, tt.expectedCount)
+			}
+		})
+	}
+}
+
+// memoryCache is an in-memory cacheTypes.CacheAdapter
+type memoryCache map[string]string
+
+func (c memoryCache) Get(ctx context.Context, key string, opts cacheTypes.CacheOptions) (string, error) {
+	return c[key], nil
+}
+
+func (c memoryCache) Set(ctx context.Context, key string, value string, ttl time.Duration, opts cacheTypes.CacheOptions) error {
+	c[key] = value
+	return nil
+}
+
+func (c memoryCache) Del(ctx context.Context, key string, opts cacheTypes.CacheOptions) error {
+	delete(c, key)
+	return nil
+}
+
+func TestStatsHandler_GetHeatmap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockStatsRepositoryInterface(ctrl)
+	// The second request for the same year is served from the cache
+	mockRepo.EXPECT().GetHeatmap(gomock.Any(), 1, 2025).Return([]repository.HeatmapDay{
+		{Date: "2025-01-01", Count: 0},
+		{Date: "2025-01-02", Count: 2, DurationMinutes: 75},
+	}, nil).Times(1)
+
+	handler := handlers.NewStatsHandler(mockRepo, memoryCache{})
+
+	get := func(url string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		req = req.WithContext(requestcontext.NewContext(req.Context(), &requestcontext.User{Id: 1}))
+		w := httptest.NewRecorder()
+		handler.GetHeatmap(w, req)
+		return w
+	}
+
+	for _, cacheStatus := range []string{"MISS", "HIT"} {
+		w := get("/api/v1/stats/heatmap?year=2025")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, cacheStatus, w.Header().Get("X-Cache-Status"))
+
+		var body struct {
+			Result struct {
+				Year     int                     `json:"year"`
+				MaxCount int                     `json:"maxCount"`
+				Days     []repository.HeatmapDay `json:"days"`
+			} `json:"result"`
+		}
+		assert.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+		assert.Equal(t, 2025, body.Result.Year)
+		assert.Equal(t, 2, body.Result.MaxCount)
+		assert.Len(t, body.Result.Days, 2)
+	}
+
+	for _, year := range []string{"abc", "1969", "3000"} {
+		w := get("/api/v1/stats/heatmap?year=" + year)
+		assert.Equal(t, http.StatusBadRequest, w.Code, "year=%s", year)
+	}
+}
+
+func TestStatsHandler_GetRPEVsDuration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	correlation := 0.82
+	upper := 30
+	mockRepo := mocks.NewMockStatsRepositoryInterface(ctrl)
+	mockRepo.EXPECT().GetRPEVsDuration(gomock.Any(), 1, gomock.Any(), gomock.Any()).Return(&repository.RPEDurationCorrelation{
+		Samples:     3,
+		Correlation: &correlation,
+		Buckets: []repository.RPEDurationBucket{
+			{MinMinutes: 0, MaxMinutes: &upper, Count: 2, AvgRPE: 4.5},
+			{MinMinutes: 120, Count: 1, AvgRPE: 9},
+		},
+	}, nil)
+
+	handler := handlers.NewStatsHandler(mockRepo, nil)
+
+	get := func(url string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		req = req.WithContext(requestcontext.NewContext(req.Context(), &requestcontext.User{Id: 1}))
+		w := httptest.NewRecorder()
+		handler.GetRPEVsDuration(w, req)
+		return w
+	}
+
+	w := get("/api/v1/stats/rpe-vs-duration?from=2025-01-01&to=2025-03-31")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var body struct {
+		Result struct {
+			Samples     int                            `json:"samples"`
+			Correlation *float64                       `json:"correlation"`
+			Buckets     []repository.RPEDurationBucket `json:"buckets"`
+		} `json:"result"`
+	}
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+	assert.Equal(t, 3, body.Result.Samples)
+	assert.Equal(t, &correlation, body.Result.Correlation)
+	assert.Len(t, body.Result.Buckets, 2)
+	assert.Nil(t, body.Result.Buckets[1].MaxMinutes)
+
+	w = get("/api/v1/stats/rpe-vs-duration?from=2025-03-31&to=2025-01-01")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestStatsHandler_GetBestSplits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockStatsRepositoryInterface(ctrl)
+	mockRepo.EXPECT().GetBestSplits(gomock.Any(), 1).Return(&repository.BestSplits{
+		Fastest1K: &repository.BestSplit{
+			ActivityID:      "01ARZ3NDEKTSV4RRFFQ69G5FAV",
+			ActivityDate:    time.Date(2025, 3, 1, 7, 0, 0, 0, time.UTC),
+			FirstSplit:      3,
+			DurationSeconds: 245,
+		},
+	}, nil)
+
+	handler := handlers.NewStatsHandler(mockRepo, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stats/best-splits", nil)
+	req = req.WithContext(requestcontext.NewContext(req.Context(), &requestcontext.User{Id: 1}))
+	w := httptest.NewRecorder()
+	handler.GetBestSplits(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var body struct {
+		Result repository.BestSplits `json:"result"`
+	}
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+	if assert.NotNil(t, body.Result.Fastest1K) {
+		assert.Equal(t, 245, body.Result.Fastest1K.DurationSeconds)
+		assert.Equal(t, 3, body.Result.Fastest1K.FirstSplit)
+	}
+	assert.Nil(t, body.Result.Fastest5K)
+}
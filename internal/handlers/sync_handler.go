@@ -0,0 +1,335 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+	"github.com/valentinesamuel/activelog/internal/application/activity/usecases"
+	"github.com/valentinesamuel/activelog/internal/application/broker"
+	"github.com/valentinesamuel/activelog/internal/events"
+	"github.com/valentinesamuel/activelog/internal/models"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/platform/validator"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+const (
+	// syncEntityActivities is the change_log entity and cursor key for activities
+	syncEntityActivities = "activities"
+
+	// defaultSyncPullLimit is the number of change_log entries read per pull
+	defaultSyncPullLimit = 100
+)
+
+// SyncHandler serves the offline sync API: clients pull the changes made since
+// their cursors and push the mutations they made while offline
+type SyncHandler struct {
+	broker           *broker.Broker
+	activityRepo     repository.ActivityRepositoryInterface
+	changeLogRepo    *repository.ChangeLogRepository
+	createActivityUC *usecases.CreateActivityUseCase
+	updateActivityUC *usecases.UpdateActivityUseCase
+	deleteActivityUC *usecases.DeleteActivityUseCase
+	eventBus         *events.Bus
+}
+
+type SyncHandlerDeps struct {
+	Broker           *broker.Broker
+	ActivityRepo     repository.ActivityRepositoryInterface
+	ChangeLogRepo    *repository.ChangeLogRepository
+	CreateActivityUC *usecases.CreateActivityUseCase
+	UpdateActivityUC *usecases.UpdateActivityUseCase
+	DeleteActivityUC *usecases.DeleteActivityUseCase
+	EventBus         *events.Bus // optional; receives the activity events
+}
+
+// NewSyncHandler creates a new SyncHandler
+func NewSyncHandler(deps SyncHandlerDeps) *SyncHandler {
+	return &SyncHandler{
+		broker:           deps.Broker,
+		activityRepo:     deps.ActivityRepo,
+		changeLogRepo:    deps.ChangeLogRepo,
+		createActivityUC: deps.CreateActivityUC,
+		updateActivityUC: deps.UpdateActivityUC,
+		deleteActivityUC: deps.DeleteActivityUC,
+		eventBus:         deps.EventBus,
+	}
+}
+
+// Pull returns the records created, updated and deleted since the client's cursors
+// @Summary Pull changes
+// @Description Returns the activities created, updated and deleted since the given per-entity cursors, with the cursors to send next time. Cursors are opaque strings. Each pull reads up to limit changes; several changes to one record within them are collapsed into its latest state. When has_more is true, pull again with the returned cursors.
+// @Tags Sync
+// @Accept json
+// @Produce json
+// @Param request body models.SyncPullRequest true "Cursors from the previous pull (empty for a full sync)"
+// @Success 200 {object} models.SyncPullResponse
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @ID SyncPull
+// @Router /api/v1/sync/pull [post]
+func (h *SyncHandler) Pull(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	var req models.SyncPullRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
+		return
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultSyncPullLimit
+	}
+
+	cursor, err := models.ParseChangeCursor(req.Cursors[syncEntityActivities])
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid cursor for "+syncEntityActivities)
+		return
+	}
+
+	page, err := h.changeLogRepo.ListSince(ctx, user.Id, syncEntityActivities, cursor, limit)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list changes")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to pull changes")
+		return
+	}
+
+	activityChanges, err := h.activityChanges(ctx, user.Id, page.Changes)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load changed activities")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to pull changes")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, models.SyncPullResponse{
+		Changes: models.SyncChanges{Activities: activityChanges},
+		Cursors: models.SyncCursors{syncEntityActivities: page.Next.String()},
+		HasMore: page.HasMore,
+	})
+}
+
+// activityChanges sorts collapsed change_log entries into created, updated and deleted activities
+func (h *SyncHandler) activityChanges(ctx context.Context, userID int, changes []*models.Change) (models.ActivityChanges, error) {
+	result := models.ActivityChanges{
+		Created: []*models.Activity{},
+		Updated: []*models.Activity{},
+		Deleted: []int64{},
+	}
+
+	var ids []int64
+	for _, change := range changes {
+		if change.Operation == repository.ChangeDelete {
+			result.Deleted = append(result.Deleted, change.EntityID)
+		} else {
+			ids = append(ids, change.EntityID)
+		}
+	}
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	activities, err := h.activityRepo.ListByIDs(ctx, userID, ids)
+	if err != nil {
+		return result, err
+	}
+	byID := make(map[int64]*models.Activity, len(activities))
+	for _, activity := range activities {
+		byID[activity.ID] = activity
+	}
+
+	// Keep change order. A row missing here was deleted after ListSince read the
+	// feed; its delete is past the cursor and arrives on the next pull.
+	for _, change := range changes {
+		activity, ok := byID[change.EntityID]
+		if !ok || change.Operation == repository.ChangeDelete {
+			continue
+		}
+		if change.Created {
+			result.Created = append(result.Created, activity)
+		} else {
+			result.Updated = append(result.Updated, activity)
+		}
+	}
+
+	return result, nil
+}
+
+// Push applies a batch of mutations made by the client while offline
+// @Summary Push changes
+// @Description Applies client mutations in order, each in its own transaction. Updates and deletes must carry the base_version they were made against; if the record has changed since, the mutation is rejected with status "conflict" and the server's copy so the client can merge and retry. A create replayed with a client_id already applied is reported as applied with the ID it created, without creating it again. A create that duplicate detection matches to an existing activity is reported as applied with that activity's ID.
+// @Tags Sync
+// @Accept json
+// @Produce json
+// @Param request body models.SyncPushRequest true "Mutations (max 100)"
+// @Success 200 {object} models.SyncPushResponse "Per-mutation results"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Security BearerAuth
+// @ID SyncPush
+// @Router /api/v1/sync/push [post]
+func (h *SyncHandler) Push(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	var req models.SyncPushRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
+		return
+	}
+
+	results := make([]models.SyncMutationResult, len(req.Mutations))
+	for i, mutation := range req.Mutations {
+		results[i] = h.applyMutation(ctx, user.Id, mutation)
+	}
+
+	response.Success(w, r, http.StatusOK, models.SyncPushResponse{Results: results})
+}
+
+// applyMutation runs one mutation through the activity use cases and reports the outcome
+func (h *SyncHandler) applyMutation(ctx context.Context, userID int, mutation models.SyncMutation) models.SyncMutationResult {
+	result := models.SyncMutationResult{ClientID: mutation.ClientID, ID: mutation.ID}
+
+	if mutation.Op != models.SyncOpCreate && (mutation.ID <= 0 || mutation.BaseVersion <= 0) {
+		result.Status = models.SyncStatusInvalid
+		result.Error = "id and base_version are required for " + mutation.Op
+		return result
+	}
+
+	switch mutation.Op {
+	case models.SyncOpCreate:
+		var req models.CreateActivityRequest
+		if err := decodeMutationData(mutation.Data, &req); err != nil {
+			return invalidMutation(result, err)
+		}
+
+		output, err := broker.RunUseCase(h.broker, ctx, h.createActivityUC, usecases.CreateActivityInput{
+			UserID:           userID,
+			Request:          &req,
+			ClientMutationID: mutation.ClientID,
+		})
+		if err == nil && output.Replayed {
+			// A create replayed after a lost response; report the record it made
+			result.Status = models.SyncStatusApplied
+			result.ID = output.ActivityID
+			if output.Activity != nil {
+				result.Version = output.Activity.Version
+			}
+			return result
+		}
+
+		var dupErr *appErrors.DuplicateError
+		if errors.As(err, &dupErr) {
+			// The same workout already reached the server another way, e.g. from a watch import
+			result.Status = models.SyncStatusApplied
+			result.ID = dupErr.ExistingID
+			if existing, err := h.activityRepo.GetByID(ctx, dupErr.ExistingID); err == nil {
+				result.Version = existing.Version
+			}
+			return result
+		}
+		if err != nil {
+			return h.failedMutation(ctx, userID, result, err)
+		}
+
+		publishDomainEvent(ctx, h.eventBus, events.ActivityCreated{UserID: userID, Activity: output.Activity})
+		return appliedMutation(result, output.Activity)
+
+	case models.SyncOpUpdate:
+		var req models.UpdateActivityRequest
+		if err := decodeMutationData(mutation.Data, &req); err != nil {
+			return invalidMutation(result, err)
+		}
+
+		output, err := broker.RunUseCase(h.broker, ctx, h.updateActivityUC, usecases.UpdateActivityInput{
+			UserID:          userID,
+			ActivityID:      int(mutation.ID),
+			Request:         &req,
+			ExpectedVersion: &mutation.BaseVersion,
+		})
+		if err != nil {
+			return h.failedMutation(ctx, userID, result, err)
+		}
+
+		publishDomainEvent(ctx, h.eventBus, events.ActivityUpdated{UserID: userID, Activity: output.Activity})
+		return appliedMutation(result, output.Activity)
+
+	default: // models.SyncOpDelete
+		output, err := broker.RunUseCase(h.broker, ctx, h.deleteActivityUC, usecases.DeleteActivityInput{
+			UserID:          userID,
+			ActivityID:      int(mutation.ID),
+			ExpectedVersion: &mutation.BaseVersion,
+		})
+		if err != nil {
+			return h.failedMutation(ctx, userID, result, err)
+		}
+
+		publishDomainEvent(ctx, h.eventBus, events.ActivityDeleted{UserID: userID, ActivityID: mutation.ID, PublicID: output.PublicID})
+		result.Status = models.SyncStatusApplied
+		result.Version = mutation.BaseVersion + 1
+		return result
+	}
+}
+
+// failedMutation maps a use case error to a mutation result. Conflicts carry
+// the server's copy of the activity.
+func (h *SyncHandler) failedMutation(ctx context.Context, userID int, result models.SyncMutationResult, err error) models.SyncMutationResult {
+	var conflict *appErrors.VersionConflictError
+	switch {
+	case errors.As(err, &conflict):
+		result.Status = models.SyncStatusConflict
+		result.Version = conflict.CurrentVersion
+		result.Error = "activity has changed since base_version"
+		if current, err := h.activityRepo.GetByID(ctx, conflict.ID); err == nil && current.UserID == userID {
+			result.Current = current
+		}
+	case errors.Is(err, appErrors.ErrNotFound), errors.Is(err, appErrors.ErrUnauthorized):
+		// Someone else's activity is reported as missing, not forbidden
+		result.Status = models.SyncStatusNotFound
+		result.Error = "activity not found"
+	default:
+		log.Error().Err(err).Str("client_id", result.ClientID).Msg("Failed to apply sync mutation")
+		result.Status = models.SyncStatusInvalid
+		result.Error = "mutation could not be applied"
+	}
+	return result
+}
+
+// decodeMutationData decodes and validates a mutation's data into req
+func decodeMutationData(data json.RawMessage, req interface{}) error {
+	if len(data) == 0 {
+		return errors.New("data is required")
+	}
+	if err := readJSON(bytes.NewReader(data), req); err != nil {
+		return errors.New("data is not valid JSON for this operation")
+	}
+	return validator.Validate(req)
+}
+
+func invalidMutation(result models.SyncMutationResult, err error) models.SyncMutationResult {
+	result.Status = models.SyncStatusInvalid
+	result.Error = err.Error()
+	return result
+}
+
+func appliedMutation(result models.SyncMutationResult, activity *models.Activity) models.SyncMutationResult {
+	result.Status = models.SyncStatusApplied
+	result.ID = activity.ID
+	result.Version = activity.Version
+	return result
+}
@@ -0,0 +1,249 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/valentinesamuel/activelog/internal/application/activity/usecases"
+	"github.com/valentinesamuel/activelog/internal/application/broker"
+	"github.com/valentinesamuel/activelog/internal/handlers"
+	"github.com/valentinesamuel/activelog/internal/models"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/repository/mocks"
+	"github.com/valentinesamuel/activelog/internal/repository/testhelpers"
+)
+
+var syncChangeRows = []string{"txid", "seq", "entity_id", "operation", "version"}
+
+// newSyncHandler returns a handler whose change feed reads feed and whose
+// mutations run in transactions on tx
+func newSyncHandler(t *testing.T) (h *handlers.SyncHandler, repo *mocks.MockActivityRepositoryInterface, feed, tx sqlmock.Sqlmock) {
+	feedDB, feed := testhelpers.SetupMockDB(t)
+	txDB, tx, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, tx.ExpectationsWereMet())
+		txDB.Close()
+	})
+
+	repo = mocks.NewMockActivityRepositoryInterface(gomock.NewController(t))
+	h = handlers.NewSyncHandler(handlers.SyncHandlerDeps{
+		Broker:           broker.NewBroker(txDB).WithLogger(log.New(io.Discard, "", 0)),
+		ActivityRepo:     repo,
+		ChangeLogRepo:    repository.NewChangeLogRepository(feedDB),
+		CreateActivityUC: usecases.NewCreateActivityUseCase(createdActivityService{}, repo, 0),
+		UpdateActivityUC: usecases.NewUpdateActivityUseCase(createdActivityService{}, repo, nil),
+		DeleteActivityUC: usecases.NewDeleteActivityUseCase(createdActivityService{}, repo),
+	})
+	return h, repo, feed, tx
+}
+
+func syncRequest(target, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	return req.WithContext(requestcontext.NewContext(req.Context(), &requestcontext.User{Id: 7}))
+}
+
+func TestSyncHandler_Pull(t *testing.T) {
+	type pullBody struct {
+		Changes struct {
+			Activities struct {
+				Created []struct{ ID int64 } `json:"created"`
+				Updated []struct{ ID int64 } `json:"updated"`
+				Deleted []int64              `json:"deleted"`
+			} `json:"activities"`
+		} `json:"changes"`
+		Cursors map[string]string `json:"cursors"`
+		HasMore bool              `json:"has_more"`
+	}
+	decode := func(t *testing.T, w *httptest.ResponseRecorder) pullBody {
+		t.Helper()
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var body struct{ Result pullBody }
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return body.Result
+	}
+
+	t.Run("pages and collapses changes", func(t *testing.T) {
+		h, repo, feed, _ := newSyncHandler(t)
+		feed.ExpectQuery(`FROM change_log`).
+			WithArgs(7, "activities", int64(900), int64(41), 4).
+			WillReturnRows(sqlmock.NewRows(syncChangeRows).
+				AddRow(902, 42, 5, "create", 1).
+				AddRow(902, 43, 6, "update", 4).
+				AddRow(903, 44, 5, "update", 2).
+				AddRow(904, 45, 8, "delete", 3))
+		repo.EXPECT().ListByIDs(gomock.Any(), 7, []int64{6, 5}).Return([]*models.Activity{
+			{BaseEntity: models.BaseEntity{ID: 5}, UserID: 7, Version: 2},
+			{BaseEntity: models.BaseEntity{ID: 6}, UserID: 7, Version: 4},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		h.Pull(w, syncRequest("/api/v1/sync/pull", `{"cursors":{"activities":"900-41"},"limit":3}`))
+
+		body := decode(t, w)
+		assert.True(t, body.HasMore)
+		assert.Equal(t, "903-44", body.Cursors["activities"], "the cursor is the last change read")
+		require.Len(t, body.Changes.Activities.Created, 1)
+		assert.Equal(t, int64(5), body.Changes.Activities.Created[0].ID)
+		require.Len(t, body.Changes.Activities.Updated, 1)
+		assert.Equal(t, int64(6), body.Changes.Activities.Updated[0].ID)
+		assert.Empty(t, body.Changes.Activities.Deleted, "the delete is on the next page")
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		h, _, feed, _ := newSyncHandler(t)
+		feed.ExpectQuery(`FROM change_log`).
+			WithArgs(7, "activities", int64(903), int64(44), 101).
+			WillReturnRows(sqlmock.NewRows(syncChangeRows).AddRow(904, 45, 8, "delete", 3))
+
+		w := httptest.NewRecorder()
+		h.Pull(w, syncRequest("/api/v1/sync/pull", `{"cursors":{"activities":"903-44"}}`))
+
+		body := decode(t, w)
+		assert.False(t, body.HasMore)
+		assert.Equal(t, "904-45", body.Cursors["activities"])
+		assert.Equal(t, []int64{8}, body.Changes.Activities.Deleted)
+	})
+
+	t.Run("nothing new keeps the cursor", func(t *testing.T) {
+		h, _, feed, _ := newSyncHandler(t)
+		feed.ExpectQuery(`FROM change_log`).
+			WithArgs(7, "activities", int64(904), int64(45), 101).
+			WillReturnRows(sqlmock.NewRows(syncChangeRows))
+
+		w := httptest.NewRecorder()
+		h.Pull(w, syncRequest("/api/v1/sync/pull", `{"cursors":{"activities":"904-45"}}`))
+
+		assert.Equal(t, "904-45", decode(t, w).Cursors["activities"])
+	})
+
+	t.Run("numeric cursors of earlier clients", func(t *testing.T) {
+		h, _, feed, _ := newSyncHandler(t)
+		feed.ExpectQuery(`FROM change_log`).
+			WithArgs(7, "activities", int64(0), int64(17), 101).
+			WillReturnRows(sqlmock.NewRows(syncChangeRows))
+
+		w := httptest.NewRecorder()
+		h.Pull(w, syncRequest("/api/v1/sync/pull", `{"cursors":{"activities":17}}`))
+
+		assert.Equal(t, "0-17", decode(t, w).Cursors["activities"])
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		for _, cursor := range []string{`"17"`, `"a-b"`, `"-1-4"`, `"3-"`} {
+			h, _, _, _ := newSyncHandler(t)
+
+			w := httptest.NewRecorder()
+			h.Pull(w, syncRequest("/api/v1/sync/pull", `{"cursors":{"activities":`+cursor+`}}`))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, cursor)
+		}
+	})
+}
+
+func TestSyncHandler_Push(t *testing.T) {
+	type pushBody struct {
+		Results []struct {
+			ClientID string `json:"client_id"`
+			Status   string `json:"status"`
+			ID       int64  `json:"id"`
+			Version  int    `json:"version"`
+			Current  *struct {
+				ID      int64 `json:"id"`
+				Version int   `json:"version"`
+			} `json:"current"`
+		} `json:"results"`
+	}
+	push := func(t *testing.T, h *handlers.SyncHandler, mutations string) pushBody {
+		t.Helper()
+		w := httptest.NewRecorder()
+		h.Push(w, syncRequest("/api/v1/sync/push", `{"mutations":[`+mutations+`]}`))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var body struct{ Result pushBody }
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return body.Result
+	}
+	const create = `{"client_id":"c-1","entity":"activities","op":"create","data":{"activityType":"running","title":"Morning run","description":"Easy loop","durationMinutes":30,"distanceKm":5,"activityDate":"2026-05-01T07:00:00Z"}}`
+
+	t.Run("create records the client mutation ID", func(t *testing.T) {
+		h, repo, _, tx := newSyncHandler(t)
+		tx.ExpectBegin()
+		repo.EXPECT().ClaimClientMutation(gomock.Any(), gomock.Any(), 7, "c-1").Return(int64(0), nil)
+		repo.EXPECT().RecordClientMutation(gomock.Any(), gomock.Any(), 7, "c-1", int64(101)).Return(nil)
+		tx.ExpectCommit()
+
+		body := push(t, h, create)
+
+		require.Len(t, body.Results, 1)
+		assert.Equal(t, "applied", body.Results[0].Status)
+		assert.Equal(t, int64(101), body.Results[0].ID)
+	})
+
+	t.Run("replayed create returns the activity it made", func(t *testing.T) {
+		h, repo, _, tx := newSyncHandler(t)
+		tx.ExpectBegin()
+		// Nothing is created: RecordClientMutation would fail the test
+		repo.EXPECT().ClaimClientMutation(gomock.Any(), gomock.Any(), 7, "c-1").Return(int64(55), nil)
+		repo.EXPECT().GetByID(gomock.Any(), int64(55)).Return(&models.Activity{BaseEntity: models.BaseEntity{ID: 55}, UserID: 7, Version: 3}, nil)
+		tx.ExpectCommit()
+
+		body := push(t, h, create)
+
+		require.Len(t, body.Results, 1)
+		assert.Equal(t, "c-1", body.Results[0].ClientID)
+		assert.Equal(t, "applied", body.Results[0].Status)
+		assert.Equal(t, int64(55), body.Results[0].ID)
+		assert.Equal(t, 3, body.Results[0].Version)
+	})
+
+	t.Run("update against an old version conflicts", func(t *testing.T) {
+		h, repo, _, tx := newSyncHandler(t)
+		tx.ExpectBegin()
+		repo.EXPECT().LockVersion(gomock.Any(), gomock.Any(), 55, 7).Return(4, nil)
+		tx.ExpectRollback()
+		repo.EXPECT().GetByID(gomock.Any(), int64(55)).Return(&models.Activity{BaseEntity: models.BaseEntity{ID: 55}, UserID: 7, Version: 4}, nil)
+
+		body := push(t, h, `{"client_id":"u-1","entity":"activities","op":"update","id":55,"base_version":3,"data":{"title":"Renamed"}}`)
+
+		require.Len(t, body.Results, 1)
+		result := body.Results[0]
+		assert.Equal(t, "conflict", result.Status)
+		assert.Equal(t, 4, result.Version)
+		require.NotNil(t, result.Current, "the server's copy is returned to merge")
+		assert.Equal(t, 4, result.Current.Version)
+	})
+
+	t.Run("conflict hides someone else's activity", func(t *testing.T) {
+		h, repo, _, tx := newSyncHandler(t)
+		tx.ExpectBegin()
+		repo.EXPECT().LockVersion(gomock.Any(), gomock.Any(), 55, 7).Return(4, nil)
+		tx.ExpectRollback()
+		repo.EXPECT().GetByID(gomock.Any(), int64(55)).Return(&models.Activity{BaseEntity: models.BaseEntity{ID: 55}, UserID: 8, Version: 4}, nil)
+
+		body := push(t, h, `{"client_id":"d-1","entity":"activities","op":"delete","id":55,"base_version":3}`)
+
+		assert.Equal(t, "conflict", body.Results[0].Status)
+		assert.Nil(t, body.Results[0].Current)
+	})
+
+	t.Run("update and delete need a base version", func(t *testing.T) {
+		h, _, _, _ := newSyncHandler(t)
+
+		body := push(t, h, `{"client_id":"u-1","entity":"activities","op":"update","id":55,"data":{"title":"Renamed"}},{"client_id":"d-1","entity":"activities","op":"delete","base_version":2}`)
+
+		require.Len(t, body.Results, 2)
+		assert.Equal(t, "invalid", body.Results[0].Status)
+		assert.Equal(t, "invalid", body.Results[1].Status)
+	})
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+	"github.com/valentinesamuel/activelog/pkg/query"
+	"github.com/valentinesamuel/activelog/pkg/response"
+	"github.com/valentinesamuel/activelog/pkg/tabular"
+)
+
+// respondTabular streams one page of list results as CSV or XLSX.
+// Columns default to everything in available; ?fields=a,b narrows and orders them.
+// Pagination metadata moves to headers since the body has no envelope.
+func respondTabular[T any](
+	w http.ResponseWriter,
+	r *http.Request,
+	format tabular.Format,
+	filename string,
+	result *query.Page[T],
+	available []string,
+) {
+	columns, err := tabular.SelectColumns(r.URL.Query().Get("fields"), available)
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	enc, err := tabular.NewEncoder(format, w)
+	if err != nil {
+		response.Fail(w, r, http.StatusNotAcceptable, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, filename, format))
+	// count=none lists have no totals to report
+	if result.Meta.CountMode != query.CountNone {
+		w.Header().Set("X-Total-Count", strconv.Itoa(result.Meta.TotalRecords))
+		w.Header().Set("X-Page-Count", strconv.Itoa(result.Meta.PageCount))
+	}
+	w.Header().Set("X-Page", strconv.Itoa(result.Meta.Page))
+	w.WriteHeader(http.StatusOK)
+
+	// Headers are already sent, so failures past this point can only be logged
+	if err := tabular.WriteRecords(enc, columns, result.Data); err != nil {
+		log.Error().Err(err).Str("format", string(format)).Msg("Failed to encode tabular response")
+		return
+	}
+	if err := enc.Close(); err != nil {
+		log.Error().Err(err).Str("format", string(format)).Msg("Failed to finish tabular response")
+	}
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+	"github.com/valentinesamuel/activelog/internal/middleware"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/pkg/query"
+	"github.com/valentinesamuel/activelog/pkg/response"
+	"github.com/valentinesamuel/activelog/pkg/tabular"
+)
+
+// TagHandler handles tag listing endpoints
+type TagHandler struct {
+	tagRepo    repository.TagRepositoryInterface
+	validation *query.EntityValidation
+}
+
+// NewTagHandler creates a new TagHandler; validation is the tags list query
+// whitelist (see TagRepository.GetValidation)
+func NewTagHandler(tagRepo repository.TagRepositoryInterface, validation *query.EntityValidation) *TagHandler {
+	return &TagHandler{tagRepo: tagRepo, validation: validation}
+}
+
+// tagColumns are the fields available to CSV/XLSX list output, in default order
+var tagColumns = []string{"id", "name", "created_at"}
+
+// ListTags returns tags using dynamic filtering with QueryOptions
+// @Summary List tags
+// @Description Returns a paginated list of tags with filtering, searching and sorting
+// @Tags Tags
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param filter[name] query string false "Filter by exact tag name"
+// @Param search[name] query string false "Search in tag name (case-insensitive)"
+// @Param order[name] query string false "Sort by name (ASC or DESC)"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 10, max: 100)"
+// @Param count query string false "How totalRecords is computed: exact (default), estimated, or none (skips counting)" Enums(exact, estimated, none)
+// @Param format query string false "Response format: json, csv or xlsx (overrides Accept)"
+// @Param fields query string false "Comma-separated columns for csv/xlsx output"
+// @Param If-None-Match header string false "ETag from a previous response"
+// @Success 200 {object} paginatedResponse{data=[]models.Tag} "Paginated tags"
+// @Success 304 "Not modified"
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Security BearerAuth
+// @Router /api/v1/tags [get]
+func (h *TagHandler) ListTags(w http.ResponseWriter, r *http.Request) {
+	format := tabular.Negotiate(r)
+
+	queryOpts, err := query.ParseQueryParamsWithFields(r.URL.Query(), h.validation.Fields())
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+
+	if err := h.validation.Validate(queryOpts); err != nil {
+		response.Fail(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Soft-deleted tags are never listed
+	queryOpts.Filter["deleted_at"] = nil
+
+	if version, err := h.tagRepo.GetCollectionVersion(r.Context(), queryOpts); err == nil {
+		optsKey, _ := json.Marshal(queryOpts)
+		etag := middleware.WeakETag("tags", format, metaVersion(r), string(optsKey), version.Count, version.LastModified)
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Vary", "Accept")
+		if middleware.ETagMatches(r, etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	} else {
+		log.Warn().Err(err).Msg("Failed to compute tag list version")
+	}
+
+	result, err := h.tagRepo.ListTagsWithQuery(r.Context(), queryOpts)
+	if err != nil {
+		if failDBError(w, r, err, "Tag") {
+			return
+		}
+		log.Error().Err(err).Msg("Failed to list tags")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to fetch tags")
+		return
+	}
+
+	if format != tabular.FormatJSON {
+		respondTabular(w, r, format, "tags", result, tagColumns)
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, map[string]interface{}{
+		"data": result.Data,
+		"meta": paginationMeta(w, r, result.Meta),
+	})
+}
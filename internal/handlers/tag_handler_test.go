@@ -0,0 +1,157 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/valentinesamuel/activelog/internal/handlers"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/repository/mocks"
+	"github.com/valentinesamuel/activelog/pkg/query"
+)
+
+func TestTagHandler_ListTags_Negotiation(t *testing.T) {
+	created := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	page := &query.Page[models.Tag]{
+		Data: []*models.Tag{
+			{BaseEntity: models.BaseEntity{ID: 1, CreatedAt: created}, Name: "cardio"},
+			{BaseEntity: models.BaseEntity{ID: 2, CreatedAt: created}, Name: "hills, steep"},
+		},
+		Meta: query.PaginationMeta{Page: 1, Limit: 10, Count: 2, PageCount: 1, TotalRecords: 2},
+	}
+	page.Meta.SetNextPage(2)
+	version := &repository.CollectionVersion{Count: 2, LastModified: &created}
+
+	tests := []struct {
+		name           string
+		target         string
+		accept         string
+		expectRepoCall bool
+		expectedStatus int
+		expectedType   string
+		expectedBody   string
+		expectedMeta   string
+	}{
+		{
+			name:           "csv via format param with sparse fields",
+			target:         "/api/v1/tags?format=csv&fields=name,id",
+			expectRepoCall: true,
+			expectedStatus: http.StatusOK,
+			expectedType:   "text/csv; charset=utf-8",
+			expectedBody:   "name,id\ncardio,1\n\"hills, steep\",2\n",
+		},
+		{
+			name:           "csv via accept header",
+			target:         "/api/v1/tags",
+			accept:         "text/csv",
+			expectRepoCall: true,
+			expectedStatus: http.StatusOK,
+			expectedType:   "text/csv; charset=utf-8",
+			expectedBody:   "id,name,created_at\n1,cardio,2024-03-01T08:00:00Z\n2,\"hills, steep\",2024-03-01T08:00:00Z\n",
+		},
+		{
+			name:           "json by default",
+			target:         "/api/v1/tags",
+			expectRepoCall: true,
+			expectedStatus: http.StatusOK,
+			expectedType:   "application/json",
+			expectedMeta:   `{"page":1,"limit":10,"count":2,"previousPage":false,"nextPage":2,"pageCount":1,"totalRecords":2}`,
+		},
+		{
+			name:           "typed meta via v2 media type",
+			target:         "/api/v1/tags",
+			accept:         "application/vnd.activelog.v2+json",
+			expectRepoCall: true,
+			expectedStatus: http.StatusOK,
+			expectedType:   "application/json",
+			expectedMeta:   `{"page":1,"limit":10,"count":2,"previousPage":null,"nextPage":2,"hasPrevious":false,"hasNext":true,"pageCount":1,"totalRecords":2}`,
+		},
+		{
+			name:           "unknown field is rejected",
+			target:         "/api/v1/tags?format=csv&fields=secret",
+			expectRepoCall: true,
+			expectedStatus: http.StatusBadRequest,
+			expectedType:   "application/json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockTagRepositoryInterface(ctrl)
+			mockRepo.EXPECT().GetCollectionVersion(gomock.Any(), gomock.Any()).Return(version, nil)
+			if tt.expectRepoCall {
+				mockRepo.EXPECT().ListTagsWithQuery(gomock.Any(), gomock.Any()).Return(page, nil)
+			}
+
+			handler := handlers.NewTagHandler(mockRepo, repository.NewTagRepository(nil).GetValidation())
+
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ListTags(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, tt.expectedType, rr.Header().Get("Content-Type"))
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, rr.Body.String())
+				assert.Equal(t, "2", rr.Header().Get("X-Total-Count"))
+			}
+			if tt.expectedMeta != "" {
+				var body struct {
+					Result struct {
+						Meta json.RawMessage `json:"meta"`
+					} `json:"result"`
+				}
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+				assert.JSONEq(t, tt.expectedMeta, string(body.Result.Meta))
+			}
+		})
+	}
+}
+
+func TestTagHandler_ListTags_NotModified(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	created := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	mockRepo := mocks.NewMockTagRepositoryInterface(ctrl)
+	mockRepo.EXPECT().
+		GetCollectionVersion(gomock.Any(), gomock.Any()).
+		Return(&repository.CollectionVersion{Count: 2, LastModified: &created}, nil).
+		Times(2)
+	// Only the first request reaches the list query
+	mockRepo.EXPECT().
+		ListTagsWithQuery(gomock.Any(), gomock.Any()).
+		Return(&query.Page[models.Tag]{Data: []*models.Tag{}}, nil).
+		Times(1)
+
+	handler := handlers.NewTagHandler(mockRepo, repository.NewTagRepository(nil).GetValidation())
+
+	first := httptest.NewRecorder()
+	handler.ListTags(first, httptest.NewRequest(http.MethodGet, "/api/v1/tags", nil))
+	assert.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tags", nil)
+	req.Header.Set("If-None-Match", etag)
+	second := httptest.NewRecorder()
+	handler.ListTags(second, req)
+
+	assert.Equal(t, http.StatusNotModified, second.Code)
+	assert.Empty(t, second.Body.String())
+}
@@ -1,8 +1,6 @@
 package handlers
 
 import (
-	"encoding/json"
-	"errors"
 	"net/http"
 
 	"github.com/rs/zerolog/log"
@@ -10,33 +8,43 @@ import (
 	"github.com/valentinesamuel/activelog/internal/repository"
 	"github.com/valentinesamuel/activelog/internal/platform/validator"
 	"github.com/valentinesamuel/activelog/pkg/auth"
-	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+	"github.com/valentinesamuel/activelog/pkg/password"
 	"github.com/valentinesamuel/activelog/pkg/response"
 )
 
 type UserHandler struct {
-	repo *repository.UserRepository
+	repo   *repository.UserRepository
+	hasher *password.Hasher
+	clock  clock.Clock
 }
 
-func NewUserHandler(repo *repository.UserRepository) *UserHandler {
+func NewUserHandler(repo *repository.UserRepository, hasher *password.Hasher) *UserHandler {
 	return &UserHandler{
-		repo: repo,
+		repo:   repo,
+		hasher: hasher,
+		clock:  clock.Real{},
 	}
 }
 
+// WithClock sets the clock login tokens and session cookies expire by
+func (ua *UserHandler) WithClock(c clock.Clock) *UserHandler {
+	ua.clock = c
+	return ua
+}
+
 func (ua *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var requestPayload models.CreateUserRequest
 
-	if err := json.NewDecoder(r.Body).Decode(&requestPayload); err != nil {
-		response.Fail(w, r, http.StatusBadRequest, "Invalid request body")
+	if !decodeJSON(w, r, &requestPayload) {
 		return
 	}
 
 	err := validator.Validate(&requestPayload)
 	if err != nil {
-		response.ValidationFail(w, r, validator.FormatValidationErrors(err))
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
 		return
 	}
 
@@ -53,7 +61,7 @@ func (ua *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	encodedHash, err := auth.HashPassword(requestPayload.Password)
+	encodedHash, err := ua.hasher.Hash(requestPayload.Password)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to hash password")
 		response.Fail(w, r, http.StatusInternalServerError, "Invalid password")
@@ -63,8 +71,7 @@ func (ua *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	user.PasswordHash = encodedHash
 
 	if err := ua.repo.CreateUser(ctx, user); err != nil {
-		if errors.Is(err, appErrors.ErrAlreadyExists) {
-			response.Fail(w, r, http.StatusConflict, "User already exists")
+		if failDBError(w, r, err, "User") {
 			return
 		}
 		log.Error().Err(err).Msg("Failed to create user")
@@ -81,27 +88,41 @@ func (ua *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// LoginUser exchanges an email and password for a session
+// @Summary Log in
+// @Description Returns a JWT to send as a Bearer token. session=cookie opens a cookie session instead (AUTH_COOKIE_SESSIONS) and returns the CSRF token.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param request body models.LoginUserRequest true "Credentials"
+// @Success 200 {object} map[string]string "JWT (token) or CSRF token (csrf_token), and email"
+// @Failure 400 {object} map[string]string "Validation error"
+// @Failure 401 {object} map[string]string "Invalid credentials"
+// @Router /api/v1/auth/login [post]
 func (ua *UserHandler) LoginUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var requestPayload models.LoginUserRequest
 
-	if err := json.NewDecoder(r.Body).Decode(&requestPayload); err != nil {
-		response.Fail(w, r, http.StatusBadRequest, "Invalid request body")
+	if !decodeJSON(w, r, &requestPayload) {
 		return
 	}
 
 	err := validator.Validate(&requestPayload)
 	if err != nil {
-		response.ValidationFail(w, r, validator.FormatValidationErrors(err))
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
+		return
+	}
+
+	if requestPayload.Session == models.SessionCookie && !auth.CookieSessionsEnabled() {
+		response.Fail(w, r, http.StatusBadRequest, "Cookie sessions are disabled")
 		return
 	}
 
 	user, err := ua.repo.FindUserByEmail(ctx, requestPayload.Email)
 
 	if err != nil {
-		if errors.Is(err, appErrors.ErrNotFound) {
-			response.Fail(w, r, http.StatusNotFound, "User not found")
+		if failDBError(w, r, err, "User") {
 			return
 		}
 
@@ -110,7 +131,7 @@ func (ua *UserHandler) LoginUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	passwordMatch, err := auth.VerifyPassword(requestPayload.Password, user.PasswordHash)
+	passwordMatch, needsRehash, err := ua.hasher.Verify(requestPayload.Password, user.PasswordHash)
 
 	if err != nil {
 		log.Error().Err(err).Msg("Password comparison failed")
@@ -124,15 +145,52 @@ func (ua *UserHandler) LoginUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := auth.GenerateJwtToken(int(user.ID), user.Email)
+	if needsRehash {
+		ua.rehashPassword(r, user.ID, requestPayload.Password)
+	}
+
+	now := ua.clock.Now()
+	token, err := auth.GenerateJwtToken(int(user.ID), user.Email, now)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to generate jwt")
 		response.Fail(w, r, http.StatusInternalServerError, "Server error")
 		return
 	}
 
+	// Cookie sessions keep the JWT away from scripts; they only get the CSRF token
+	if requestPayload.Session == models.SessionCookie {
+		csrfToken := auth.SetSessionCookies(w, token, now)
+		response.Success(w, r, http.StatusOK, map[string]interface{}{
+			"email":      user.Email,
+			"csrf_token": csrfToken,
+		})
+		return
+	}
+
 	response.Success(w, r, http.StatusOK, map[string]interface{}{
 		"token": token,
 		"email": user.Email,
 	})
 }
+
+// LogoutUser ends a cookie session by expiring its cookies. Bearer tokens are
+// stateless and simply expire.
+func (ua *UserHandler) LogoutUser(w http.ResponseWriter, r *http.Request) {
+	auth.ClearSessionCookies(w)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// rehashPassword upgrades a hash made with an outdated scheme or cost. The
+// login succeeds either way; failures are retried at the next login.
+func (ua *UserHandler) rehashPassword(r *http.Request, userID int64, plain string) {
+	encodedHash, err := ua.hasher.Hash(plain)
+	if err != nil {
+		log.Warn().Err(err).Int64("userID", userID).Msg("Failed to rehash password")
+		return
+	}
+	if err := ua.repo.UpdatePasswordHash(r.Context(), userID, encodedHash); err != nil {
+		log.Warn().Err(err).Int64("userID", userID).Msg("Failed to store rehashed password")
+		return
+	}
+	log.Info().Int64("userID", userID).Msg("Password rehashed with current scheme")
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// missedHeartbeats is how many heartbeats a worker may miss before it is
+// reported dead
+const missedHeartbeats = 3
+
+// WorkerHandler lists the worker processes from the heartbeats they record
+type WorkerHandler struct {
+	heartbeats *repository.WorkerHeartbeatRepository
+	interval   time.Duration
+}
+
+// NewWorkerHandler creates a WorkerHandler for workers recording a heartbeat
+// every interval (config.Queue.HeartbeatInterval)
+func NewWorkerHandler(heartbeats *repository.WorkerHeartbeatRepository, interval time.Duration) *WorkerHandler {
+	return &WorkerHandler{heartbeats: heartbeats, interval: interval}
+}
+
+// ListWorkers handles GET /api/v1/admin/workers
+// @Summary List workers
+// @Description Lists the worker processes with the status they last reported: queue provider and connectivity, registered handlers, and running, succeeded and failed job counts. A worker that missed three heartbeats (QUEUE_WORKER_HEARTBEAT_SECONDS) is not alive; workers that stopped cleanly are not listed. Admins only.
+// @Tags Admin
+// @Produce json
+// @Success 200 {array} models.WorkerHeartbeat "Workers, most recently started first"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Not an admin"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @ID ListWorkers
+// @Router /api/v1/admin/workers [get]
+func (h *WorkerHandler) ListWorkers(w http.ResponseWriter, r *http.Request) {
+	workers, err := h.heartbeats.List(r.Context(), missedHeartbeats*h.interval)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list workers")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to list workers")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, workers)
+}
@@ -0,0 +1,360 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/valentinesamuel/activelog/internal/models"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/internal/platform/validator"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/service"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+	"github.com/valentinesamuel/activelog/pkg/query"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// WorkoutHandler serves the user's structured workouts, the days they are
+// planned for and how closely they were followed
+type WorkoutHandler struct {
+	workoutRepo *repository.WorkoutRepository
+	validation  *query.EntityValidation
+	clock       clock.Clock
+}
+
+// WorkoutHandlerDeps contains the dependencies for WorkoutHandler.
+type WorkoutHandlerDeps struct {
+	WorkoutRepo *repository.WorkoutRepository
+	Validation  *query.EntityValidation // list query whitelist (see WorkoutRepository.GetValidation)
+	Clock       clock.Clock             // decides which planned workouts are due; nil uses the real clock
+}
+
+// NewWorkoutHandler creates a new WorkoutHandler with the given dependencies.
+func NewWorkoutHandler(deps WorkoutHandlerDeps) *WorkoutHandler {
+	clk := deps.Clock
+	if clk == nil {
+		clk = clock.Real{}
+	}
+	return &WorkoutHandler{
+		workoutRepo: deps.WorkoutRepo,
+		validation:  deps.Validation,
+		clock:       clk,
+	}
+}
+
+// CreateWorkout handles POST /api/v1/workouts
+// @Summary Create a workout
+// @Description Defines a structured workout: warmup, interval, recovery and cooldown steps, each targeting a duration and/or distance (optionally a pace and heart-rate range) and repeated `repeat` times. The planned duration and distance are the totals of the steps.
+// @Tags Workouts
+// @Accept json
+// @Produce json
+// @Param request body models.CreateWorkoutRequest true "Workout"
+// @Success 201 {object} models.Workout "Created workout"
+// @Failure 400 {object} map[string]interface{} "Validation error"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/workouts [post]
+func (h *WorkoutHandler) CreateWorkout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	var req models.CreateWorkoutRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
+		return
+	}
+
+	workout := &models.Workout{
+		UserID:       user.Id,
+		Name:         req.Name,
+		ActivityType: req.ActivityType,
+		Description:  req.Description,
+		Steps:        req.Steps,
+	}
+	workout.ComputeTotals()
+
+	if err := h.workoutRepo.Create(ctx, workout); err != nil {
+		log.Error().Err(err).Int("userID", user.Id).Msg("Failed to create workout")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to create workout")
+		return
+	}
+
+	response.Success(w, r, http.StatusCreated, workout)
+}
+
+// ListWorkouts handles GET /api/v1/workouts
+// @Summary List workouts
+// @Description Returns a paginated list of the user's workouts, newest first unless ordered otherwise
+// @Tags Workouts
+// @Produce json
+// @Param filter[activity_type] query string false "Workouts for this activity type"
+// @Param order[name] query string false "Sort by name (ASC or DESC)"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 10, max: 100)"
+// @Success 200 {object} paginatedResponse{data=[]models.Workout} "Paginated workouts"
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/workouts [get]
+func (h *WorkoutHandler) ListWorkouts(w http.ResponseWriter, r *http.Request) {
+	user, _ := requestcontext.FromContext(r.Context())
+
+	queryOpts, err := query.ParseQueryParamsWithFields(r.URL.Query(), h.validation.Fields())
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+
+	if err := h.validation.Validate(queryOpts); err != nil {
+		response.Fail(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Users only ever see their own workouts
+	queryOpts.Filter["user_id"] = user.Id
+	if len(queryOpts.Order) == 0 {
+		queryOpts.Order.Set("created_at", "DESC")
+	}
+
+	result, err := h.workoutRepo.ListWorkoutsWithQuery(r.Context(), queryOpts)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list workouts")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to fetch workouts")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, map[string]interface{}{
+		"data": result.Data,
+		"meta": paginationMeta(w, r, result.Meta),
+	})
+}
+
+// GetWorkout handles GET /api/v1/workouts/{id}
+// @Summary Get a workout
+// @Tags Workouts
+// @Produce json
+// @Param id path int true "Workout ID"
+// @Success 200 {object} models.Workout "Workout"
+// @Failure 400 {object} map[string]string "Invalid workout ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Workout not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/workouts/{id} [get]
+func (h *WorkoutHandler) GetWorkout(w http.ResponseWriter, r *http.Request) {
+	workout, ok := h.loadWorkout(w, r)
+	if !ok {
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, workout)
+}
+
+// DeleteWorkout handles DELETE /api/v1/workouts/{id}
+// @Summary Delete a workout
+// @Description Deletes a workout and the days it was planned for
+// @Tags Workouts
+// @Param id path int true "Workout ID"
+// @Success 204 "Deleted"
+// @Failure 400 {object} map[string]string "Invalid workout ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Workout not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/workouts/{id} [delete]
+func (h *WorkoutHandler) DeleteWorkout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid workout ID")
+		return
+	}
+
+	err = h.workoutRepo.Delete(ctx, user.Id, id)
+	if failDBError(w, r, err, "Workout") {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int64("workoutID", id).Msg("Failed to delete workout")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to delete workout")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ScheduleWorkout handles POST /api/v1/workouts/{id}/schedule
+// @Summary Plan a workout for a day
+// @Description Assigns the workout to a day. An activity of the workout's type logged that day completes it (see GET /api/v1/workouts/{id}/compliance).
+// @Tags Workouts
+// @Accept json
+// @Produce json
+// @Param id path int true "Workout ID"
+// @Param request body models.ScheduleWorkoutRequest true "Day"
+// @Success 201 {object} models.PlannedWorkout "Planned workout"
+// @Failure 400 {object} map[string]interface{} "Validation error"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Workout not found"
+// @Failure 409 {object} map[string]string "Already planned for that day"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/workouts/{id}/schedule [post]
+func (h *WorkoutHandler) ScheduleWorkout(w http.ResponseWriter, r *http.Request) {
+	workout, ok := h.loadWorkout(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.ScheduleWorkoutRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := validator.Validate(&req); err != nil {
+		response.ValidationFail(w, r, validator.FormatValidationErrors(r.Context(), err))
+		return
+	}
+
+	plannedOn, _ := time.Parse(time.DateOnly, req.Date)
+	planned := &models.PlannedWorkout{
+		WorkoutID: workout.ID,
+		UserID:    workout.UserID,
+		PlannedOn: plannedOn,
+	}
+
+	err := h.workoutRepo.Schedule(r.Context(), planned)
+	if failDBError(w, r, err, "Workout for that day") {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int64("workoutID", workout.ID).Msg("Failed to schedule workout")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to schedule workout")
+		return
+	}
+
+	response.Success(w, r, http.StatusCreated, planned)
+}
+
+// UnscheduleWorkout handles DELETE /api/v1/workouts/{id}/schedule/{date}
+// @Summary Unplan a workout
+// @Description Removes the workout from a day
+// @Tags Workouts
+// @Param id path int true "Workout ID"
+// @Param date path string true "Day (YYYY-MM-DD)"
+// @Success 204 "Removed"
+// @Failure 400 {object} map[string]string "Invalid workout ID or date"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Not planned for that day"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/workouts/{id}/schedule/{date} [delete]
+func (h *WorkoutHandler) UnscheduleWorkout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid workout ID")
+		return
+	}
+	day, err := time.Parse(time.DateOnly, vars["date"])
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid date, use YYYY-MM-DD")
+		return
+	}
+
+	err = h.workoutRepo.Unschedule(ctx, user.Id, id, day)
+	if failDBError(w, r, err, "Planned workout") {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Int64("workoutID", id).Msg("Failed to unschedule workout")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to unschedule workout")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetCompliance handles GET /api/v1/workouts/{id}/compliance
+// @Summary Get workout compliance
+// @Description Lists the days the workout is planned for with the activity that completed each: a live activity of the workout's type logged that day, the one closest to the planned duration when there are several. A completed day scores how close it came to the planned duration and distance (100 on target; half or double the target score 50). Past days without an activity are missed and score 0; today and later are upcoming. compliance_pct averages the completed and missed days.
+// @Tags Workouts
+// @Produce json
+// @Param id path int true "Workout ID"
+// @Param from query string false "First day, YYYY-MM-DD (default: all)"
+// @Param to query string false "Last day, YYYY-MM-DD (default: all)"
+// @Success 200 {object} models.WorkoutCompliance "Compliance per planned day"
+// @Failure 400 {object} map[string]string "Invalid workout ID or dates"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Workout not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Security BearerAuth
+// @Router /api/v1/workouts/{id}/compliance [get]
+func (h *WorkoutHandler) GetCompliance(w http.ResponseWriter, r *http.Request) {
+	workout, ok := h.loadWorkout(w, r)
+	if !ok {
+		return
+	}
+
+	filter := repository.SessionFilter{WorkoutID: &workout.ID}
+	bounds := []struct {
+		param string
+		dest  **time.Time
+	}{{"from", &filter.From}, {"to", &filter.To}}
+	for _, bound := range bounds {
+		value := r.URL.Query().Get(bound.param)
+		if value == "" {
+			continue
+		}
+		day, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			response.Fail(w, r, http.StatusBadRequest, "Invalid "+bound.param+" date, use YYYY-MM-DD")
+			return
+		}
+		*bound.dest = &day
+	}
+
+	sessions, err := h.workoutRepo.ListSessions(r.Context(), workout.UserID, filter)
+	if err != nil {
+		log.Error().Err(err).Int64("workoutID", workout.ID).Msg("Failed to list planned workouts")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to fetch compliance")
+		return
+	}
+
+	response.Success(w, r, http.StatusOK, service.ComputeCompliance(sessions, h.clock.Now()))
+}
+
+// loadWorkout fetches the user's workout named by the {id} path variable,
+// writing the error response if it can't
+func (h *WorkoutHandler) loadWorkout(w http.ResponseWriter, r *http.Request) (*models.Workout, bool) {
+	ctx := r.Context()
+	user, _ := requestcontext.FromContext(ctx)
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		response.Fail(w, r, http.StatusBadRequest, "Invalid workout ID")
+		return nil, false
+	}
+
+	workout, err := h.workoutRepo.GetByID(ctx, user.Id, id)
+	if failDBError(w, r, err, "Workout") {
+		return nil, false
+	}
+	if err != nil {
+		log.Error().Err(err).Int64("workoutID", id).Msg("Failed to get workout")
+		response.Fail(w, r, http.StatusInternalServerError, "Failed to get workout")
+		return nil, false
+	}
+	return workout, true
+}
@@ -0,0 +1,125 @@
+// Package loadtest drives the HTTP API with repeatable scenarios and reports
+// the latency percentiles and error rate of every operation, so performance
+// regressions can be caught by comparing runs or by failing on thresholds.
+//
+// Scenarios run against a live server (see cmd/loadtest); the database should
+// be seeded first (see cmd/seed) so lists and stats have realistic data.
+package loadtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Client sends API requests as one user and records each one under an
+// operation name
+type Client struct {
+	baseURL  string
+	token    string
+	http     *http.Client
+	recorder *Recorder
+}
+
+// NewClient creates a Client for the API at baseURL. Requests are recorded
+// in recorder, which may be nil.
+func NewClient(baseURL string, recorder *Recorder) *Client {
+	return &Client{
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		http:     &http.Client{Timeout: 30 * time.Second},
+		recorder: recorder,
+	}
+}
+
+// SetToken sets the bearer token sent with every request
+func (c *Client) SetToken(token string) {
+	c.token = token
+}
+
+// Token returns the bearer token sent with every request
+func (c *Client) Token() string {
+	return c.token
+}
+
+// Register creates a user and logs in as them. Used in CI, where the
+// database starts empty.
+func (c *Client) Register(ctx context.Context, username, email, password string) error {
+	body := map[string]string{"username": username, "email": email, "password": password}
+	if err := c.Do(ctx, "register", http.MethodPost, "/api/v1/auth/register", body, nil); err != nil {
+		return err
+	}
+	return c.Login(ctx, email, password)
+}
+
+// Login logs in and keeps the returned token
+func (c *Client) Login(ctx context.Context, email, password string) error {
+	var result struct {
+		Token string `json:"token"`
+	}
+	body := map[string]string{"email": email, "password": password}
+	if err := c.Do(ctx, "login", http.MethodPost, "/api/v1/auth/login", body, &result); err != nil {
+		return err
+	}
+	if result.Token == "" {
+		return fmt.Errorf("login returned no token")
+	}
+	c.token = result.Token
+	return nil
+}
+
+// Do sends a request and records its latency under op. body is sent as JSON
+// when not nil; the "result" of the response is decoded into out when not
+// nil. Transport errors and statuses of 400 and above are returned as errors
+// and recorded as failures.
+func (c *Client) Do(ctx context.Context, op, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	start := time.Now()
+	resp, err := c.http.Do(req)
+	if err != nil {
+		// Requests cut off by the end of the run are not failures
+		if ctx.Err() == nil {
+			c.recorder.Record(op, time.Since(start), err)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	elapsed := time.Since(start)
+
+	if err == nil && resp.StatusCode >= http.StatusBadRequest {
+		err = fmt.Errorf("%s %s: status %d", method, path, resp.StatusCode)
+	}
+	c.recorder.Record(op, elapsed, err)
+	if err != nil || out == nil {
+		return err
+	}
+
+	envelope := struct {
+		Result interface{} `json:"result"`
+	}{Result: out}
+	return json.Unmarshal(data, &envelope)
+}
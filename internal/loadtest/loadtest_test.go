@@ -0,0 +1,88 @@
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder_Report(t *testing.T) {
+	recorder := NewRecorder()
+	for i := 1; i <= 100; i++ {
+		var err error
+		if i%10 == 0 {
+			err = errors.New("status 500")
+		}
+		recorder.Record("list", time.Duration(i)*time.Millisecond, err)
+	}
+	recorder.Record("create", 5*time.Millisecond, nil)
+
+	report := recorder.Report("mixed", 4, 2*time.Second)
+	require.Len(t, report.Operations, 2)
+	assert.Equal(t, 101, report.Requests)
+	assert.Equal(t, 10, report.Errors)
+	assert.InDelta(t, 50.5, report.Throughput, 0.01)
+
+	list := report.Operations[1]
+	assert.Equal(t, "list", list.Operation)
+	assert.Equal(t, 50*time.Millisecond, list.P50)
+	assert.Equal(t, 95*time.Millisecond, list.P95)
+	assert.Equal(t, 99*time.Millisecond, list.P99)
+	assert.Equal(t, 100*time.Millisecond, list.Max)
+	assert.InDelta(t, 0.1, list.ErrorRate, 0.0001)
+
+	assert.Empty(t, report.Check(Thresholds{MaxP95: 100 * time.Millisecond, MaxErrorRate: 0.2}))
+	violations := report.Check(Thresholds{MaxP95: 90 * time.Millisecond, MaxErrorRate: 0.05})
+	assert.Len(t, violations, 2, "p95 and error rate of list")
+}
+
+func TestRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"result":{}}`))
+	}))
+	defer server.Close()
+
+	setupRan := false
+	scenario := Scenario{
+		Name: "test",
+		Setup: func(ctx context.Context, client *Client) error {
+			setupRan = true
+			return client.Do(ctx, "setup", http.MethodGet, "/ok", nil, nil)
+		},
+		Step: func(ctx context.Context, client *Client, rng *rand.Rand) error {
+			if rng.IntN(2) == 0 {
+				return client.Do(ctx, "ok", http.MethodGet, "/ok", nil, nil)
+			}
+			return client.Do(ctx, "fail", http.MethodGet, "/fail", nil, nil)
+		},
+	}
+
+	report, err := Run(context.Background(), server.URL, "token", scenario, Config{Concurrency: 2, Duration: 200 * time.Millisecond, Seed: 1})
+	require.NoError(t, err)
+	assert.True(t, setupRan)
+	require.Len(t, report.Operations, 2, "setup requests are not reported")
+
+	for _, op := range report.Operations {
+		assert.Positive(t, op.Requests)
+		if op.Operation == "fail" {
+			assert.Equal(t, op.Requests, op.Errors)
+		} else {
+			assert.Zero(t, op.Errors)
+		}
+	}
+}
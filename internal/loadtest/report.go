@@ -0,0 +1,160 @@
+package loadtest
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Recorder collects the latency and outcome of requests by operation. It is
+// safe for concurrent use; a nil Recorder records nothing.
+type Recorder struct {
+	mu         sync.Mutex
+	latencies  map[string][]time.Duration
+	errors     map[string]int
+	lastErrors map[string]string
+}
+
+// NewRecorder creates an empty Recorder
+func NewRecorder() *Recorder {
+	return &Recorder{
+		latencies:  make(map[string][]time.Duration),
+		errors:     make(map[string]int),
+		lastErrors: make(map[string]string),
+	}
+}
+
+// Record adds one request of op that took elapsed and failed with err (nil
+// on success)
+func (r *Recorder) Record(op string, elapsed time.Duration, err error) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.latencies[op] = append(r.latencies[op], elapsed)
+	if err != nil {
+		r.errors[op]++
+		r.lastErrors[op] = err.Error()
+	}
+}
+
+// OperationStats summarises the requests of one operation. Percentiles use
+// the nearest-rank method over all requests, failed ones included.
+type OperationStats struct {
+	Operation string        `json:"operation"`
+	Requests  int           `json:"requests"`
+	Errors    int           `json:"errors"`
+	ErrorRate float64       `json:"errorRate"`
+	P50       time.Duration `json:"p50"`
+	P95       time.Duration `json:"p95"`
+	P99       time.Duration `json:"p99"`
+	Max       time.Duration `json:"max"`
+	LastError string        `json:"lastError,omitempty"`
+}
+
+// Report is the result of one scenario run
+type Report struct {
+	Scenario    string           `json:"scenario"`
+	Concurrency int              `json:"concurrency"`
+	Duration    time.Duration    `json:"duration"`
+	Requests    int              `json:"requests"`
+	Errors      int              `json:"errors"`
+	Throughput  float64          `json:"requestsPerSecond"`
+	Operations  []OperationStats `json:"operations"`
+}
+
+// Report summarises everything recorded so far, by operation name
+func (r *Recorder) Report(scenario string, concurrency int, duration time.Duration) *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := &Report{Scenario: scenario, Concurrency: concurrency, Duration: duration, Operations: []OperationStats{}}
+	for op, latencies := range r.latencies {
+		sorted := append([]time.Duration(nil), latencies...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		stats := OperationStats{
+			Operation: op,
+			Requests:  len(sorted),
+			Errors:    r.errors[op],
+			ErrorRate: float64(r.errors[op]) / float64(len(sorted)),
+			P50:       percentile(sorted, 50),
+			P95:       percentile(sorted, 95),
+			P99:       percentile(sorted, 99),
+			Max:       sorted[len(sorted)-1],
+			LastError: r.lastErrors[op],
+		}
+		report.Operations = append(report.Operations, stats)
+		report.Requests += stats.Requests
+		report.Errors += stats.Errors
+	}
+	sort.Slice(report.Operations, func(i, j int) bool {
+		return report.Operations[i].Operation < report.Operations[j].Operation
+	})
+	if duration > 0 {
+		report.Throughput = float64(report.Requests) / duration.Seconds()
+	}
+	return report
+}
+
+// percentile returns the p-th percentile (nearest rank) of sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+// Thresholds fail a run whose operations are slower or less reliable than
+// allowed. Zero values are not checked.
+type Thresholds struct {
+	MaxP95       time.Duration
+	MaxP99       time.Duration
+	MaxErrorRate float64
+}
+
+// Check returns a description of every operation that exceeds t
+func (report *Report) Check(t Thresholds) []string {
+	var violations []string
+	for _, op := range report.Operations {
+		if t.MaxP95 > 0 && op.P95 > t.MaxP95 {
+			violations = append(violations, fmt.Sprintf("%s/%s: p95 %s exceeds %s", report.Scenario, op.Operation, op.P95, t.MaxP95))
+		}
+		if t.MaxP99 > 0 && op.P99 > t.MaxP99 {
+			violations = append(violations, fmt.Sprintf("%s/%s: p99 %s exceeds %s", report.Scenario, op.Operation, op.P99, t.MaxP99))
+		}
+		if t.MaxErrorRate > 0 && op.ErrorRate > t.MaxErrorRate {
+			violations = append(violations, fmt.Sprintf("%s/%s: error rate %.2f%% exceeds %.2f%% (last error: %s)",
+				report.Scenario, op.Operation, op.ErrorRate*100, t.MaxErrorRate*100, op.LastError))
+		}
+	}
+	return violations
+}
+
+// Print writes the report as a table
+func (report *Report) Print(w io.Writer) {
+	fmt.Fprintf(w, "\n%s: %d requests in %s with %d workers (%.1f req/s, %d errors)\n",
+		report.Scenario, report.Requests, report.Duration.Round(time.Millisecond), report.Concurrency, report.Throughput, report.Errors)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "operation\trequests\terrors\tp50\tp95\tp99\tmax\t")
+	for _, op := range report.Operations {
+		fmt.Fprintf(tw, "%s\t%d\t%.2f%%\t%s\t%s\t%s\t%s\t\n", op.Operation, op.Requests, op.ErrorRate*100,
+			roundLatency(op.P50), roundLatency(op.P95), roundLatency(op.P99), roundLatency(op.Max))
+	}
+	tw.Flush()
+}
+
+// roundLatency rounds d for display
+func roundLatency(d time.Duration) time.Duration {
+	if d < time.Millisecond {
+		return d.Round(time.Microsecond)
+	}
+	return d.Round(100 * time.Microsecond)
+}
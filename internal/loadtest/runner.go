@@ -0,0 +1,88 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Scenario is a repeatable workload. Every worker calls Step in a loop until
+// the run ends; Step sends its requests through the Client, which records
+// them.
+type Scenario struct {
+	Name        string
+	Description string
+
+	// Setup runs once before the workers start, e.g. to create the data Step
+	// reads. Its requests are not part of the report.
+	Setup func(ctx context.Context, client *Client) error
+
+	// Step performs one iteration. Failed requests are already recorded, so
+	// its error only stops the worker when the run is over.
+	Step func(ctx context.Context, client *Client, rng *rand.Rand) error
+}
+
+// Config configures Run
+type Config struct {
+	// Concurrency is the number of workers calling Step at once
+	Concurrency int
+
+	// Duration is how long the workers run
+	Duration time.Duration
+
+	// Seed makes the requests of a run repeatable: worker i uses Seed+i
+	Seed uint64
+}
+
+// Run runs scenario against the API at baseURL as the user token belongs to,
+// and reports the latency of every operation its steps performed
+func Run(ctx context.Context, baseURL, token string, scenario Scenario, cfg Config) (*Report, error) {
+	if cfg.Concurrency <= 0 || cfg.Duration <= 0 {
+		return nil, fmt.Errorf("concurrency and duration must be positive")
+	}
+
+	if scenario.Setup != nil {
+		setup := NewClient(baseURL, nil)
+		setup.SetToken(token)
+		if err := scenario.Setup(ctx, setup); err != nil {
+			return nil, fmt.Errorf("%s setup failed: %w", scenario.Name, err)
+		}
+	}
+
+	recorder := NewRecorder()
+	runCtx, cancel := context.WithTimeout(ctx, cfg.Duration)
+	defer cancel()
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := range cfg.Concurrency {
+		wg.Add(1)
+		go func(worker uint64) {
+			defer wg.Done()
+			client := NewClient(baseURL, recorder)
+			client.SetToken(token)
+			rng := rand.New(rand.NewPCG(cfg.Seed, worker))
+			for runCtx.Err() == nil {
+				_ = scenario.Step(runCtx, client, rng)
+			}
+		}(cfg.Seed + uint64(i))
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return recorder.Report(scenario.Name, cfg.Concurrency, time.Since(start)), nil
+}
+
+// pause waits for d or until ctx is done
+func pause(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
@@ -0,0 +1,165 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Scenarios are the built-in workloads, by name
+var Scenarios = map[string]Scenario{
+	"list": {
+		Name:        "list",
+		Description: "activity lists with heavy filters, sorting, search, includes and deep pages",
+		Setup:       ensureActivities,
+		Step:        listStep,
+	},
+	"create": {
+		Name:        "create",
+		Description: "bursts of activity creates followed by a short pause",
+		Step:        createStep,
+	},
+	"stats": {
+		Name:        "stats",
+		Description: "a dashboard's stats requests, fanned out in parallel",
+		Setup:       ensureActivities,
+		Step:        statsStep,
+	},
+}
+
+// setupActivities is the number of activities ensureActivities makes sure
+// the user has, so lists and stats aren't measured against no data
+const setupActivities = 200
+
+// createBurst is the number of creates createStep sends back to back
+const createBurst = 10
+
+var activityTypes = []string{"running", "walking", "cycling", "swimming", "strength", "yoga"}
+
+// listQueries are the list variants listStep picks from. Each is recorded as
+// its own operation, so a slow filter or index shows up on its own.
+var listQueries = []struct {
+	Op    string
+	Query func(rng *rand.Rand) url.Values
+}{
+	{"list:type+date", func(rng *rand.Rand) url.Values {
+		return url.Values{
+			"filter[activity_type]": {activityTypes[rng.IntN(len(activityTypes))]},
+			"order[activity_date]":  {"DESC"},
+			"limit":                 {"20"},
+		}
+	}},
+	{"list:range+distance", func(rng *rand.Rand) url.Values {
+		return url.Values{
+			"filter[distance_km][gte]":   {fmt.Sprint(rng.IntN(10))},
+			"filter[activity_date][gte]": {time.Now().AddDate(0, 0, -30-rng.IntN(300)).Format("2006-01-02")},
+			"order[distance_km]":         {"DESC"},
+			"limit":                      {"50"},
+		}
+	}},
+	{"list:tags+include", func(rng *rand.Rand) url.Values {
+		// Tags cmd/seed uses
+		return url.Values{
+			"filter[tags.name]": {[]string{"morning", "easy", "long", "recovery"}[rng.IntN(4)]},
+			"include":           {"tags"},
+			"limit":             {"20"},
+		}
+	}},
+	{"list:search", func(rng *rand.Rand) url.Values {
+		return url.Values{
+			"search[title]": {[]string{"run", "ride", "swim", "session"}[rng.IntN(4)]},
+			"limit":         {"20"},
+		}
+	}},
+	{"list:deep-page", func(rng *rand.Rand) url.Values {
+		return url.Values{
+			"order[activity_date]": {"DESC"},
+			"page":                 {fmt.Sprint(5 + rng.IntN(5))},
+			"limit":                {"20"},
+		}
+	}},
+}
+
+func listStep(ctx context.Context, client *Client, rng *rand.Rand) error {
+	variant := listQueries[rng.IntN(len(listQueries))]
+	query := variant.Query(rng)
+	return client.Do(ctx, variant.Op, http.MethodGet, "/api/v1/activities?"+query.Encode(), nil, nil)
+}
+
+func createStep(ctx context.Context, client *Client, rng *rand.Rand) error {
+	for range createBurst {
+		if err := client.Do(ctx, "create", http.MethodPost, "/api/v1/activities?allow_duplicate=true", randomActivity(rng), nil); err != nil && ctx.Err() != nil {
+			return err
+		}
+	}
+	pause(ctx, time.Duration(200+rng.IntN(800))*time.Millisecond)
+	return nil
+}
+
+// statsPaths are the requests a stats dashboard sends when it opens
+var statsPaths = map[string]string{
+	"stats:weekly":        "/api/v1/stats/weekly?compare=previous",
+	"stats:monthly":       "/api/v1/stats/monthly",
+	"stats:by-type":       "/api/v1/stats/by-type",
+	"stats:timeseries":    "/api/v1/stats/timeseries?metric=duration_minutes&interval=week",
+	"stats:training-load": "/api/v1/stats/training-load",
+	"stats:heatmap":       "/api/v1/stats/heatmap",
+	"stats:summary":       "/api/v1/users/me/summary",
+}
+
+func statsStep(ctx context.Context, client *Client, rng *rand.Rand) error {
+	var wg sync.WaitGroup
+	for op, path := range statsPaths {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = client.Do(ctx, op, http.MethodGet, path, nil, nil)
+		}()
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+// ensureActivities tops the user up to setupActivities activities with
+// batch creates
+func ensureActivities(ctx context.Context, client *Client) error {
+	var list struct {
+		Meta struct {
+			TotalRecords int `json:"totalRecords"`
+		} `json:"meta"`
+	}
+	if err := client.Do(ctx, "setup", http.MethodGet, "/api/v1/activities?limit=1", nil, &list); err != nil {
+		return err
+	}
+
+	rng := rand.New(rand.NewPCG(1, 1))
+	for missing := setupActivities - list.Meta.TotalRecords; missing > 0; missing -= 50 {
+		batch := make([]map[string]interface{}, 0, 50)
+		for range min(missing, 50) {
+			batch = append(batch, randomActivity(rng))
+		}
+		body := map[string]interface{}{"activities": batch}
+		if err := client.Do(ctx, "setup", http.MethodPost, "/api/v1/activities/batch?allow_duplicate=true", body, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// randomActivity returns a create request for an activity in the last year
+func randomActivity(rng *rand.Rand) map[string]interface{} {
+	activityType := activityTypes[rng.IntN(len(activityTypes))]
+	minutes := 20 + rng.IntN(100)
+	return map[string]interface{}{
+		"activityType":    activityType,
+		"title":           fmt.Sprintf("Load test %s session", activityType),
+		"description":     "Created by the load test",
+		"durationMinutes": minutes,
+		"distanceKm":      float64(rng.IntN(2000)) / 100,
+		"activityDate":    time.Now().UTC().AddDate(0, 0, -rng.IntN(365)).Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// BodyLimit caps request bodies at maxBytes, or at uploadBytes for multipart
+// uploads. A request whose Content-Length is over the limit is rejected with
+// 413 straight away; otherwise reading past the limit fails with
+// *http.MaxBytesError, which handlers report as 413 as well.
+func BodyLimit(maxBytes, uploadBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			limit := maxBytes
+			if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+				limit = uploadBytes
+			}
+
+			if r.ContentLength > limit {
+				response.Fail(w, r, http.StatusRequestEntityTooLarge, "Request body too large")
+				return
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// CoachAccessChecker reports whether coachID holds an active grant from
+// athleteID covering scope (see models.CoachScopeRead)
+type CoachAccessChecker func(ctx context.Context, coachID, athleteID int, scope string) (bool, error)
+
+// RequireCoachAccess lets the caller through to another user's data only
+// when that user - the athlete in path variable param - granted them scope
+// as their coach. Callers reaching their own data pass without a grant. It
+// must run after AuthMiddleware and after the path variable is resolved to
+// a serial ID (ResolvePublicID).
+func RequireCoachAccess(param, scope string, check CoachAccessChecker) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, ok := requestcontext.FromContext(r.Context())
+			if !ok || user == nil {
+				response.Fail(w, r, http.StatusUnauthorized, "Unauthorized request")
+				return
+			}
+
+			athleteID, err := strconv.Atoi(mux.Vars(r)[param])
+			if err != nil {
+				response.Fail(w, r, http.StatusBadRequest, "Invalid user ID")
+				return
+			}
+			if athleteID == user.Id {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			allowed, err := check(r.Context(), user.Id, athleteID, scope)
+			if err != nil {
+				log.Error().Err(err).Int("coachID", user.Id).Int("athleteID", athleteID).Msg("Failed to check coach access")
+				response.Fail(w, r, http.StatusInternalServerError, "Failed to check access")
+				return
+			}
+			if !allowed {
+				response.Fail(w, r, http.StatusForbidden, "Coach access required")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
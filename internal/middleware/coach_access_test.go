@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+)
+
+func TestRequireCoachAccess(t *testing.T) {
+	// User 1 coaches user 2 (read only); checking user 3 fails
+	check := func(_ context.Context, coachID, athleteID int, scope string) (bool, error) {
+		if athleteID == 3 {
+			return false, errors.New("connection reset")
+		}
+		return coachID == 1 && athleteID == 2 && scope == "read", nil
+	}
+
+	tests := []struct {
+		name       string
+		userID     string
+		scope      string
+		wantStatus int
+	}{
+		{name: "own data needs no grant", userID: "1", scope: "comment", wantStatus: http.StatusOK},
+		{name: "granted scope passes", userID: "2", scope: "read", wantStatus: http.StatusOK},
+		{name: "scope not granted is forbidden", userID: "2", scope: "comment", wantStatus: http.StatusForbidden},
+		{name: "no grant is forbidden", userID: "4", scope: "read", wantStatus: http.StatusForbidden},
+		{name: "failed check is an error", userID: "3", scope: "read", wantStatus: http.StatusInternalServerError},
+		{name: "unresolved id is rejected", userID: "abc", scope: "read", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := mux.NewRouter()
+			router.Handle("/athletes/{userId}", RequireCoachAccess("userId", tt.scope, check)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})))
+
+			req := httptest.NewRequest(http.MethodGet, "/athletes/"+tt.userID, nil)
+			req = req.WithContext(requestcontext.NewContext(req.Context(), &requestcontext.User{Id: 1}))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
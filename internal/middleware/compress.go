@@ -0,0 +1,257 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+)
+
+// codings are the content codings Compress offers, in the order it prefers
+// them when a client weighs them the same: brotli makes smaller bodies
+var codings = []string{"br", "gzip"}
+
+// encoder is what gzip.Writer and brotli.Writer have in common
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// Compress compresses responses with brotli or gzip, whichever the client
+// prefers by its Accept-Encoding q-values. A response is only compressed
+// when its media type is one of cfg.ContentTypes and it reaches
+// cfg.MinSizeBytes; smaller bodies are buffered up to that size and sent as
+// they are. A handler that flushes (NDJSON, long exports) is streaming, so
+// it is compressed from the first flush on and every flush reaches the
+// client. Server-sent events are left alone.
+func Compress(cfg config.CompressionConfigType) func(http.Handler) http.Handler {
+	pools := map[string]*sync.Pool{
+		"br": {New: func() interface{} {
+			return brotli.NewWriterLevel(nil, cfg.BrotliLevel)
+		}},
+		"gzip": {New: func() interface{} {
+			gz, _ := gzip.NewWriterLevel(nil, cfg.Level)
+			return gz
+		}},
+	}
+
+	return func(next http.Handler) http.Handler {
+		if !cfg.Enabled {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+			coding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			if r.Method == http.MethodHead || r.Header.Get("Range") != "" || r.Header.Get("Upgrade") != "" || coding == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressWriter{ResponseWriter: w, cfg: &cfg, coding: coding, pool: pools[coding]}
+			defer cw.close()
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// negotiateEncoding picks the coding of codings with the highest q-value in
+// an Accept-Encoding header, or "" when the client accepts none of them.
+// A coding that isn't listed gets the q-value of *, and q=0 is a refusal.
+func negotiateEncoding(header string) string {
+	weights := map[string]float64{}
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding == "" {
+			continue
+		}
+		weight := 1.0
+		if q, ok := strings.CutPrefix(strings.ReplaceAll(params, " ", ""), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil || parsed < 0 || parsed > 1 {
+				parsed = 0
+			}
+			weight = parsed
+		}
+		weights[coding] = weight
+	}
+
+	best, bestWeight := "", 0.0
+	for _, coding := range codings {
+		weight, listed := weights[coding]
+		if !listed {
+			weight = weights["*"]
+		}
+		if weight > bestWeight {
+			best, bestWeight = coding, weight
+		}
+	}
+	return best
+}
+
+// compressWriter holds the body back until it knows whether to compress it:
+// once the body reaches the minimum size, the handler flushes, or the
+// handler returns
+type compressWriter struct {
+	http.ResponseWriter
+	cfg    *config.CompressionConfigType
+	coding string     // the negotiated coding, br or gzip
+	pool   *sync.Pool // encoders of that coding
+
+	status      int
+	wroteHeader bool // the handler called WriteHeader
+	decided     bool // the header went out, compressed or not
+	buf         []byte
+	enc         encoder
+}
+
+func (cw *compressWriter) WriteHeader(statusCode int) {
+	if cw.wroteHeader || cw.decided {
+		return
+	}
+	cw.wroteHeader = true
+	cw.status = statusCode
+
+	// Content-Type may still be sniffed from the body
+	if !cw.compressible() || (cw.Header().Get("Content-Type") != "" && !cw.compressibleType()) {
+		cw.passThrough()
+	}
+}
+
+func (cw *compressWriter) Write(b []byte) (int, error) {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if cw.decided {
+		if cw.enc != nil {
+			return cw.enc.Write(b)
+		}
+		return cw.ResponseWriter.Write(b)
+	}
+
+	cw.buf = append(cw.buf, b...)
+	if cw.Header().Get("Content-Type") == "" {
+		cw.Header().Set("Content-Type", http.DetectContentType(cw.buf))
+		if !cw.compressibleType() {
+			return len(b), cw.passThrough()
+		}
+	}
+	if len(cw.buf) >= cw.cfg.MinSizeBytes {
+		return len(b), cw.startEncoding()
+	}
+	return len(b), nil
+}
+
+// Flush sends what was written so far. A response that flushes before
+// reaching the minimum size is streaming and is compressed all the same.
+func (cw *compressWriter) Flush() {
+	if !cw.decided {
+		if !cw.wroteHeader {
+			cw.WriteHeader(http.StatusOK)
+		}
+		if !cw.decided {
+			if cw.Header().Get("Content-Type") == "" {
+				cw.passThrough()
+			} else {
+				cw.startEncoding()
+			}
+		}
+	}
+	if cw.enc != nil {
+		cw.enc.Flush()
+	}
+	http.NewResponseController(cw.ResponseWriter).Flush()
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer (write
+// deadlines, hijacking)
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// compressible reports whether the status and headers allow compressing
+func (cw *compressWriter) compressible() bool {
+	switch {
+	case cw.status < http.StatusOK, cw.status == http.StatusNoContent, cw.status == http.StatusPartialContent, cw.status == http.StatusNotModified:
+		return false
+	case cw.Header().Get("Content-Encoding") != "":
+		return false
+	}
+	if length, err := strconv.Atoi(cw.Header().Get("Content-Length")); err == nil && length < cw.cfg.MinSizeBytes {
+		return false
+	}
+	return true
+}
+
+// compressibleType reports whether the media type is in cfg.ContentTypes
+func (cw *compressWriter) compressibleType() bool {
+	mediaType, _, err := mime.ParseMediaType(cw.Header().Get("Content-Type"))
+	if err != nil || mediaType == "text/event-stream" {
+		return false
+	}
+	for _, allowed := range cw.cfg.ContentTypes {
+		if allowed == mediaType || (strings.HasSuffix(allowed, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(allowed, "*"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// passThrough sends the header and the buffered body uncompressed
+func (cw *compressWriter) passThrough() error {
+	cw.decided = true
+	if cw.wroteHeader {
+		cw.ResponseWriter.WriteHeader(cw.status)
+	}
+	if len(cw.buf) == 0 {
+		return nil
+	}
+	_, err := cw.ResponseWriter.Write(cw.buf)
+	cw.buf = nil
+	return err
+}
+
+// startEncoding sends the header for a compressed body and compresses the
+// buffered body
+func (cw *compressWriter) startEncoding() error {
+	cw.decided = true
+	h := cw.Header()
+	h.Set("Content-Encoding", cw.coding)
+	h.Del("Content-Length")
+	// Byte ranges are served uncompressed (Compress skips Range requests)
+	h.Del("Accept-Ranges")
+	// The compressed bytes differ from the identity ones
+	if etag := h.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		h.Set("ETag", "W/"+etag)
+	}
+	cw.ResponseWriter.WriteHeader(cw.status)
+
+	cw.enc = cw.pool.Get().(encoder)
+	cw.enc.Reset(cw.ResponseWriter)
+	_, err := cw.enc.Write(cw.buf)
+	cw.buf = nil
+	return err
+}
+
+// close ends the response once the handler returns: a body still under the
+// minimum size goes out as it is, a compressed one gets its trailer
+func (cw *compressWriter) close() {
+	if !cw.decided {
+		if cw.wroteHeader {
+			cw.passThrough()
+		}
+		return
+	}
+	if cw.enc != nil {
+		cw.enc.Close()
+		cw.pool.Put(cw.enc)
+		cw.enc = nil
+	}
+}
@@ -0,0 +1,256 @@
+package middleware
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+)
+
+func testCompressionConfig() config.CompressionConfigType {
+	return config.CompressionConfigType{
+		Enabled:      true,
+		MinSizeBytes: 1024,
+		Level:        gzip.DefaultCompression,
+		BrotliLevel:  4,
+		ContentTypes: []string{"application/json", "text/*"},
+	}
+}
+
+// decompress reads a response body in its Content-Encoding
+func decompress(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+	var reader io.Reader
+	switch coding := w.Header().Get("Content-Encoding"); coding {
+	case "br":
+		reader = brotli.NewReader(w.Body)
+	case "gzip":
+		gz, err := gzip.NewReader(w.Body)
+		require.NoError(t, err)
+		reader = gz
+	default:
+		t.Fatalf("unexpected Content-Encoding %q", coding)
+	}
+	body, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"gzip, deflate, br", "br"},
+		{"gzip", "gzip"},
+		{"br", "br"},
+		{"*", "br"},
+		{"gzip;q=1.0, br;q=0.8", "gzip"},
+		{"br;q=0.5, gzip;q=0.9", "gzip"},
+		{"br;q=0.9, gzip;q=0.9", "br"},
+		{"BR; q=0.7, GZIP; q=0.3", "br"},
+		{"gzip;q=0, br", "br"},
+		{"br;q=0, gzip;q=0.1", "gzip"},
+		{"*;q=0.5, br;q=0", "gzip"},
+		{"*;q=0, gzip", "gzip"},
+		{"*;q=0", ""},
+		{"br;q=0, gzip;q=0", ""},
+		{"gzip;q=abc", ""},
+		{"deflate, identity", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.want, negotiateEncoding(tt.header))
+		})
+	}
+}
+
+func TestCompress(t *testing.T) {
+	large := strings.Repeat(`{"activity_type":"running"},`, 100)
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		contentType    string
+		status         int
+		body           string
+		wantEncoding   string
+	}{
+		{name: "large JSON", acceptEncoding: "gzip, deflate, br", contentType: "application/json", body: large, wantEncoding: "br"},
+		{name: "gzip only", acceptEncoding: "gzip", contentType: "application/json", body: large, wantEncoding: "gzip"},
+		{name: "gzip preferred", acceptEncoding: "br;q=0.5, gzip", contentType: "application/json", body: large, wantEncoding: "gzip"},
+		{name: "text wildcard", acceptEncoding: "br", contentType: "text/csv; charset=utf-8", body: large, wantEncoding: "br"},
+		{name: "any coding", acceptEncoding: "*", contentType: "application/json", body: large, wantEncoding: "br"},
+		{name: "below minimum size", acceptEncoding: "gzip, br", contentType: "application/json", body: `{"ok":true}`},
+		{name: "gzip refused", acceptEncoding: "gzip;q=0, br", contentType: "application/json", body: large, wantEncoding: "br"},
+		{name: "both refused", acceptEncoding: "gzip;q=0, br;q=0, deflate", contentType: "application/json", body: large},
+		{name: "no Accept-Encoding", contentType: "application/json", body: large},
+		{name: "type not listed", acceptEncoding: "gzip, br", contentType: "image/png", body: large},
+		{name: "server-sent events", acceptEncoding: "gzip, br", contentType: "text/event-stream", body: large},
+		{name: "sniffed type", acceptEncoding: "gzip", body: "plain " + large, wantEncoding: "gzip"},
+		{name: "no content", acceptEncoding: "gzip, br", contentType: "application/json", status: http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.contentType != "" {
+					w.Header().Set("Content-Type", tt.contentType)
+				}
+				if tt.status != 0 {
+					w.WriteHeader(tt.status)
+				}
+				// Several writes, as encoders write in chunks
+				for _, chunk := range strings.SplitAfter(tt.body, ",") {
+					io.WriteString(w, chunk)
+				}
+			})
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/activities", nil)
+			if tt.acceptEncoding != "" {
+				r.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			w := httptest.NewRecorder()
+
+			Compress(testCompressionConfig())(next).ServeHTTP(w, r)
+
+			assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+			assert.Equal(t, tt.wantEncoding, w.Header().Get("Content-Encoding"))
+			if tt.wantEncoding == "" {
+				assert.Equal(t, tt.body, w.Body.String())
+				return
+			}
+			assert.Equal(t, tt.body, decompress(t, w))
+		})
+	}
+}
+
+func TestCompress_Disabled(t *testing.T) {
+	cfg := testCompressionConfig()
+	cfg.Enabled = false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, strings.Repeat("x", 4096))
+	})
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+
+	Compress(cfg)(next).ServeHTTP(w, r)
+
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, 4096, w.Body.Len())
+}
+
+// A streaming handler's lines must reach the client as it flushes them,
+// however small they are
+func TestCompress_StreamsFlushedWrites(t *testing.T) {
+	lines := make(chan string)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		// Send the header before the first line, as streams do
+		require.NoError(t, http.NewResponseController(w).Flush())
+		for line := range lines {
+			fmt.Fprintln(w, line)
+			require.NoError(t, http.NewResponseController(w).Flush())
+		}
+	})
+	server := httptest.NewServer(Compress(testCompressionConfig())(next))
+	defer server.Close()
+
+	// Go's transport asks for gzip and decompresses when the caller doesn't
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.True(t, resp.Uncompressed)
+	reader := bufio.NewReader(resp.Body)
+
+	for _, want := range []string{`{"id":1}`, `{"id":2}`} {
+		lines <- want
+		got := make(chan string, 1)
+		go func() {
+			line, _ := reader.ReadString('\n')
+			got <- strings.TrimSpace(line)
+		}()
+		select {
+		case line := <-got:
+			assert.Equal(t, want, line)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("line %s was not flushed", want)
+		}
+	}
+	close(lines)
+}
+
+// BenchmarkCompress_ActivityList measures what compression costs on a full page of
+// GET /api/v1/activities (100 activities, about 40 KB): the CPU time per
+// response against the bytes sent (out-B/op).
+func BenchmarkCompress_ActivityList(b *testing.B) {
+	activities := make([]map[string]interface{}, 100)
+	for i := range activities {
+		activities[i] = map[string]interface{}{
+			"id": i + 1, "publicId": fmt.Sprintf("01HZX%021d", i), "userId": 7,
+			"activityType": "running", "title": fmt.Sprintf("Morning run %d", i),
+			"description":     "Easy pace along the river, legs felt good",
+			"durationMinutes": 30 + i%40, "distanceKm": 5 + float64(i%10)/2, "caloriesBurned": 300 + i,
+			"notes": "", "rpe": 1 + i%10, "mood": 1 + i%5,
+			"activityDate": time.Date(2026, 3, 1, 7, 30, 0, 0, time.UTC).AddDate(0, 0, -i),
+			"createdAt":    time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC).AddDate(0, 0, -i),
+			"updatedAt":    time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC).AddDate(0, 0, -i),
+			"tags":         []map[string]interface{}{{"id": 1, "name": "cardio"}, {"id": 2, "name": "outdoor"}},
+		}
+	}
+	page, err := json.Marshal(map[string]interface{}{
+		"data": activities,
+		"meta": map[string]interface{}{"page": 1, "limit": 100, "pageCount": 3, "totalRecords": 250},
+	})
+	require.NoError(b, err)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(page)
+	})
+
+	run := func(b *testing.B, handler http.Handler, acceptEncoding string) {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/activities?limit=100", nil)
+		r.Header.Set("Accept-Encoding", acceptEncoding)
+		var out int
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			out = w.Body.Len()
+		}
+		b.ReportMetric(float64(out), "out-B/op")
+	}
+
+	b.Run("identity", func(b *testing.B) {
+		run(b, Compress(testCompressionConfig())(next), "identity")
+	})
+	for _, level := range []int{gzip.BestSpeed, 5, gzip.BestCompression} {
+		b.Run(fmt.Sprintf("gzip-%d", level), func(b *testing.B) {
+			cfg := testCompressionConfig()
+			cfg.Level = level
+			run(b, Compress(cfg)(next), "gzip")
+		})
+	}
+	for _, level := range []int{1, 4, 6} {
+		b.Run(fmt.Sprintf("br-%d", level), func(b *testing.B) {
+			cfg := testCompressionConfig()
+			cfg.BrotliLevel = level
+			run(b, Compress(cfg)(next), "br")
+		})
+	}
+}
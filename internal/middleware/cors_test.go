@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+)
+
+func TestCORS(t *testing.T) {
+	cfg := config.CORSConfig{
+		AllowedOrigins:   []string{"https://app.example.com", "https://*.example.org"},
+		AllowedMethods:   []string{"GET", "POST"},
+		AllowedHeaders:   []string{"Content-Type", "Authorization"},
+		ExposedHeaders:   []string{"X-Request-ID"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	handler := CORS(cfg)(next)
+
+	tests := []struct {
+		name        string
+		method      string
+		origin      string
+		wantAllowed bool
+		wantStatus  int
+	}{
+		{name: "exact origin", method: http.MethodGet, origin: "https://app.example.com", wantAllowed: true, wantStatus: http.StatusNoContent},
+		{name: "origin is case-insensitive", method: http.MethodGet, origin: "https://APP.example.com", wantAllowed: true, wantStatus: http.StatusNoContent},
+		{name: "wildcard subdomain", method: http.MethodGet, origin: "https://eu.admin.example.org", wantAllowed: true, wantStatus: http.StatusNoContent},
+		{name: "wildcard excludes apex", method: http.MethodGet, origin: "https://example.org", wantStatus: http.StatusNoContent},
+		{name: "wildcard needs same scheme", method: http.MethodGet, origin: "http://admin.example.org", wantStatus: http.StatusNoContent},
+		{name: "lookalike domain", method: http.MethodGet, origin: "https://evilexample.org", wantStatus: http.StatusNoContent},
+		{name: "unknown origin", method: http.MethodGet, origin: "https://evil.com", wantStatus: http.StatusNoContent},
+		{name: "preflight", method: http.MethodOptions, origin: "https://app.example.com", wantAllowed: true, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "/api/v1/activities", nil)
+			r.Header.Set("Origin", tt.origin)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, "Origin", w.Header().Get("Vary"))
+			if !tt.wantAllowed {
+				assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+				assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+				return
+			}
+			assert.Equal(t, tt.origin, w.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+			assert.Equal(t, "X-Request-ID", w.Header().Get("Access-Control-Expose-Headers"))
+			if tt.method == http.MethodOptions {
+				assert.Equal(t, "GET, POST", w.Header().Get("Access-Control-Allow-Methods"))
+				assert.Equal(t, "Content-Type, Authorization", w.Header().Get("Access-Control-Allow-Headers"))
+				assert.Equal(t, "600", w.Header().Get("Access-Control-Max-Age"))
+			}
+		})
+	}
+}
+
+func TestSecurityHeaders(t *testing.T) {
+	cfg := config.SecurityHeadersConfig{
+		CSP:                   "default-src 'self'",
+		DocsCSP:               "default-src 'self'; script-src 'self' 'unsafe-inline'",
+		FrameOptions:          "SAMEORIGIN",
+		HSTSMaxAge:            365 * 24 * time.Hour,
+		HSTSIncludeSubdomains: true,
+		HSTSPreload:           true,
+	}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	tests := []struct {
+		path    string
+		wantCSP string
+	}{
+		{path: "/api/v1/activities", wantCSP: cfg.CSP},
+		{path: "/docs/index.html", wantCSP: cfg.DocsCSP},
+		{path: "/swagger/index.html", wantCSP: cfg.DocsCSP},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			SecurityHeaders(cfg)(next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.wantCSP, w.Header().Get("Content-Security-Policy"))
+			assert.Equal(t, "SAMEORIGIN", w.Header().Get("X-Frame-Options"))
+			assert.Equal(t, "max-age=31536000; includeSubDomains; preload", w.Header().Get("Strict-Transport-Security"))
+		})
+	}
+
+	t.Run("HSTS disabled", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		SecurityHeaders(config.SecurityHeadersConfig{FrameOptions: "DENY"})(next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Empty(t, w.Header().Get("Strict-Transport-Security"))
+	})
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	"github.com/valentinesamuel/activelog/pkg/auth"
+)
+
+func TestCSRF(t *testing.T) {
+	config.Common = &config.CommonConfig{Auth: config.AuthConfig{JWTSecret: "test-secret", CookieSessions: true}}
+	session := "session-jwt"
+
+	tests := []struct {
+		name       string
+		method     string
+		cookie     bool
+		bearer     bool
+		csrfToken  string
+		wantStatus int
+	}{
+		{name: "safe method passes", method: http.MethodGet, cookie: true, wantStatus: http.StatusOK},
+		{name: "write without session cookie passes", method: http.MethodPost, wantStatus: http.StatusOK},
+		{name: "bearer request passes", method: http.MethodPost, cookie: true, bearer: true, wantStatus: http.StatusOK},
+		{name: "cookie write without token is rejected", method: http.MethodPost, cookie: true, wantStatus: http.StatusForbidden},
+		{name: "cookie write with wrong token is rejected", method: http.MethodDelete, cookie: true, csrfToken: auth.CSRFToken("other"), wantStatus: http.StatusForbidden},
+		{name: "cookie write with session token passes", method: http.MethodPatch, cookie: true, csrfToken: auth.CSRFToken(session), wantStatus: http.StatusOK},
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "/api/v1/activities", nil)
+			if tt.cookie {
+				r.AddCookie(&http.Cookie{Name: auth.SessionCookieName, Value: session})
+			}
+			if tt.bearer {
+				r.Header.Set("Authorization", "Bearer token")
+			}
+			if tt.csrfToken != "" {
+				r.Header.Set(auth.CSRFHeader, tt.csrfToken)
+			}
+
+			w := httptest.NewRecorder()
+			CSRF(next).ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/valentinesamuel/activelog/pkg/query"
+)
+
+// IndexAdvisor records the list queries of every request with advisor, for
+// the index advisor report (development only)
+func IndexAdvisor(advisor *query.IndexAdvisor) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(query.WithIndexAdvisor(r.Context(), advisor)))
+		})
+	}
+}
@@ -1,45 +1,95 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strings"
 
-	"github.com/golang-jwt/jwt/v5"
-	"github.com/valentinesamuel/activelog/internal/platform/config"
+	"github.com/rs/zerolog/log"
 	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
 	"github.com/valentinesamuel/activelog/pkg/auth"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
 	"github.com/valentinesamuel/activelog/pkg/response"
 )
 
+// APIKeyAuthenticator returns the ID and email of the user an API key
+// belongs to, by the key's hash (auth.HashAPIKey), or errors.ErrNotFound
+// for unknown keys
+type APIKeyAuthenticator func(ctx context.Context, keyHash string) (int, string, error)
+
+// AuthMiddleware authenticates requests by JWT
 func AuthMiddleware(next http.Handler) http.Handler {
+	return Authenticate(nil, nil)(next)
+}
 
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Extract token from Authorization header
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" {
-			response.Fail(w, r, http.StatusUnauthorized, "Unauthorized request")
-			return
-		}
+// Authenticate authenticates requests by JWT or, when keys is set, by API
+// key: bearer tokens starting with auth.APIKeyPrefix are looked up with keys
+// instead of being parsed as JWTs. JWTs expire by clk, the clock they are
+// issued with; nil uses the real clock.
+func Authenticate(keys APIKeyAuthenticator, clk clock.Clock) func(http.Handler) http.Handler {
+	if clk == nil {
+		clk = clock.Real{}
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tokenString := tokenFromRequest(r)
+			if tokenString == "" {
+				response.Fail(w, r, http.StatusUnauthorized, "Unauthorized request")
+				return
+			}
 
-		// Parse "Bearer <token>"
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+			requestUser := &requestcontext.User{}
+			if keys != nil && auth.IsAPIKey(tokenString) {
+				userID, email, err := keys(r.Context(), auth.HashAPIKey(tokenString))
+				if errors.Is(err, appErrors.ErrNotFound) {
+					response.Fail(w, r, http.StatusUnauthorized, "Unauthorized request")
+					return
+				}
+				if err != nil {
+					log.Error().Err(err).Msg("Failed to authenticate API key")
+					response.Fail(w, r, http.StatusInternalServerError, "Failed to authenticate request")
+					return
+				}
+				requestUser.Id, requestUser.Email = userID, email
+			} else {
+				claims, err := auth.VerifyToken(tokenString, clk.Now())
+				if err != nil {
+					response.Fail(w, r, http.StatusUnauthorized, "Unauthorized request")
+					return
+				}
+				requestUser.Id, requestUser.Email = claims.UserID, claims.Email
+			}
 
-		// Validate token
-		claims := &auth.CustomClaims{}
-		token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
-			return []byte(config.Common.Auth.JWTSecret), nil
+			ctx := requestcontext.NewContext(r.Context(), requestUser)
+			setLogUserID(ctx, requestUser.Id)
+			next.ServeHTTP(w, r.WithContext(ctx))
 		})
+	}
+}
 
-		if err != nil || !token.Valid {
-			response.Fail(w, r, http.StatusUnauthorized, "Unauthorized request")
-			return
+// tokenFromRequest extracts the JWT from the Authorization header. Browsers
+// can't set headers on WebSocket upgrades, so those may pass it as ?token=
+// instead; in cookie session mode it falls back to the session cookie (whose
+// state-changing requests are guarded by CSRF).
+func tokenFromRequest(r *http.Request) string {
+	if authHeader := r.Header.Get("Authorization"); authHeader != "" {
+		// Parse "Bearer <token>"
+		return strings.TrimPrefix(authHeader, "Bearer ")
+	}
+	if isWebSocketUpgrade(r) && r.URL.Query().Get("token") != "" {
+		return r.URL.Query().Get("token")
+	}
+	if auth.CookieSessionsEnabled() {
+		if cookie, err := r.Cookie(auth.SessionCookieName); err == nil {
+			return cookie.Value
 		}
+	}
+	return ""
+}
 
-		requestUser := &requestcontext.User{
-			Id:    claims.UserID,
-			Email: claims.Email,
-		}
-		ctx := requestcontext.NewContext(r.Context(), requestUser)
-		next.ServeHTTP(w, r.WithContext(ctx))
-	})
+// isWebSocketUpgrade reports whether r asks to upgrade to the WebSocket protocol
+func isWebSocketUpgrade(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
 }
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/pkg/auth"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
+)
+
+func TestAuthenticate(t *testing.T) {
+	previous := config.Common
+	config.Common = &config.CommonConfig{Auth: config.AuthConfig{JWTSecret: "test-secret"}}
+	t.Cleanup(func() { config.Common = previous })
+
+	const goodKey, brokenKey = auth.APIKeyPrefix + "good", auth.APIKeyPrefix + "broken"
+	keys := func(_ context.Context, keyHash string) (int, string, error) {
+		switch keyHash {
+		case auth.HashAPIKey(goodKey):
+			return 8, "script@example.com", nil
+		case auth.HashAPIKey(brokenKey):
+			return 0, "", errors.New("connection reset")
+		}
+		return 0, "", appErrors.ErrNotFound
+	}
+	// Behind the system clock, so JWTs only pass when checked on this clock
+	clk := clock.NewFake(time.Date(2020, 6, 1, 9, 0, 0, 0, time.UTC))
+	jwt, err := auth.GenerateJwtToken(5, "me@example.com", clk.Now())
+	require.NoError(t, err)
+	expired, err := auth.GenerateJwtToken(5, "me@example.com", clk.Now().Add(-auth.TokenTTL-time.Minute))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		keys       APIKeyAuthenticator
+		token      string
+		wantStatus int
+		wantUser   int
+	}{
+		{name: "JWT", keys: keys, token: jwt, wantStatus: http.StatusOK, wantUser: 5},
+		{name: "expired JWT", keys: keys, token: expired, wantStatus: http.StatusUnauthorized},
+		{name: "API key", keys: keys, token: goodKey, wantStatus: http.StatusOK, wantUser: 8},
+		{name: "unknown API key", keys: keys, token: auth.APIKeyPrefix + "revoked", wantStatus: http.StatusUnauthorized},
+		{name: "failed lookup", keys: keys, token: brokenKey, wantStatus: http.StatusInternalServerError},
+		{name: "API keys not accepted", token: goodKey, wantStatus: http.StatusUnauthorized},
+		{name: "no token", keys: keys, wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotUser int
+			handler := Authenticate(tt.keys, clk)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				user, _ := requestcontext.FromContext(r.Context())
+				gotUser = user.Id
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/activities", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantUser, gotUser)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/valentinesamuel/activelog/internal/platform/locale"
+	"github.com/valentinesamuel/activelog/pkg/i18n"
+)
+
+// Locale negotiates the language of the response from the Accept-Language
+// header, falling back to English, and stores its localizer in the request
+// context for response messages and validation errors. The language is
+// echoed in Content-Language, and Vary tells caches responses differ by it.
+func Locale(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l := locale.New(r.Header.Get("Accept-Language"))
+
+		w.Header().Set("Content-Language", l.Language().String())
+		w.Header().Add("Vary", "Accept-Language")
+		next.ServeHTTP(w, r.WithContext(i18n.NewContext(r.Context(), l)))
+	})
+}
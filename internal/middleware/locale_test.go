@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+func TestLocale(t *testing.T) {
+	handler := Locale(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response.Fail(w, r, http.StatusNotFound, "Activity not found")
+	}))
+
+	tests := []struct {
+		acceptLanguage string
+		wantLanguage   string
+		wantMessage    string
+	}{
+		{"fr-FR,fr;q=0.9,en;q=0.8", "fr", "Activité introuvable"},
+		{"de, es;q=0.5", "es", "Actividad no encontrada"},
+		{"de", "en", "Activity not found"},
+		{"", "en", "Activity not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.acceptLanguage, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/activities/1", nil)
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantLanguage, rec.Header().Get("Content-Language"))
+			assert.Equal(t, "Accept-Language", rec.Header().Get("Vary"))
+
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tt.wantMessage, body["message"])
+		})
+	}
+}
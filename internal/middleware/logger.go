@@ -1,15 +1,28 @@
 package middleware
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"math/rand/v2"
+	"mime"
+	"net"
 	"net/http"
 	"time"
 
-	"github.com/rs/zerolog/log"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/pkg/logger"
 )
 
 type responseWriter struct {
 	http.ResponseWriter
 	statusCode int
+	bytes      int64
+	body       *cappedBuffer // captures the response body in debug mode
 }
 
 func (rw *responseWriter) WriteHeader(code int) {
@@ -17,21 +30,179 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
+func (rw *responseWriter) Write(p []byte) (int, error) {
+	n, err := rw.ResponseWriter.Write(p)
+	rw.bytes += int64(n)
+	if rw.body != nil {
+		rw.body.Write(p[:n])
+	}
+	return n, err
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer (Flush,
+// write deadlines) for streaming responses
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// Hijack lets WebSocket upgrades take over the connection
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	rw.statusCode = http.StatusSwitchingProtocols
+	return http.NewResponseController(rw.ResponseWriter).Hijack()
+}
+
+// countingBody counts the request body bytes the handler reads
+type countingBody struct {
+	io.ReadCloser
+	bytes int64
+	body  *cappedBuffer // captures the request body in debug mode
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.bytes += int64(n)
+	if b.body != nil {
+		b.body.Write(p[:n])
+	}
+	return n, err
+}
+
+// cappedBuffer keeps the first max bytes written to it and notes whether
+// anything was dropped
+type cappedBuffer struct {
+	buf       bytes.Buffer
+	max       int
+	truncated bool
+}
+
+func (b *cappedBuffer) Write(p []byte) {
+	if room := b.max - b.buf.Len(); len(p) > room {
+		p = p[:max(room, 0)]
+		b.truncated = true
+	}
+	b.buf.Write(p)
+}
+
+// requestLog collects fields that are only known further down the chain
+type requestLog struct {
+	userID int
+}
+
+type requestLogKey struct{}
+
+// setLogUserID records the authenticated user on the request's log line
+func setLogUserID(ctx context.Context, userID int) {
+	if entry, ok := ctx.Value(requestLogKey{}).(*requestLog); ok {
+		entry.userID = userID
+	}
+}
+
+// LoggingMiddleware writes one structured log line per request: method, route
+// template, status, latency, user, request ID and byte counts. Successful,
+// fast requests are sampled (LOG_SAMPLE_PERCENT); errors and slow requests are
+// always logged. At debug level, JSON request and response bodies are logged
+// too, with password, token and secret fields redacted.
 func LoggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
+			debug := zerolog.GlobalLevel() <= zerolog.DebugLevel
+
+			entry := &requestLog{}
+			r = r.WithContext(context.WithValue(r.Context(), requestLogKey{}, entry))
 
 			rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			var reqBody *countingBody
+			if r.Body != nil && r.Body != http.NoBody {
+				reqBody = &countingBody{ReadCloser: r.Body}
+				r.Body = reqBody
+			}
+			if debug {
+				rw.body = &cappedBuffer{max: config.Logging.BodyMaxBytes}
+				if reqBody != nil {
+					reqBody.body = &cappedBuffer{max: config.Logging.BodyMaxBytes}
+				}
+			}
 
 			next.ServeHTTP(rw, r)
 
-			log.Info().
+			latency := time.Since(start)
+			if !shouldLogRequest(rw.statusCode, latency) {
+				return
+			}
+
+			event := logEventFor(rw.statusCode).
 				Str("method", r.Method).
+				Str("route", routeTemplate(r)).
 				Str("path", r.URL.Path).
 				Int("status", rw.statusCode).
-				Dur("duration", time.Since(start)).
-				Msg("HTTP request")
+				Dur("latency", latency).
+				Str("request_id", requestcontext.RequestIDFromContext(r.Context())).
+				Int64("bytes_out", rw.bytes)
+			if reqBody != nil {
+				event = event.Int64("bytes_in", reqBody.bytes)
+			}
+			if entry.userID != 0 {
+				event = event.Int("user_id", entry.userID)
+			}
+			if debug {
+				if reqBody != nil {
+					event = logBody(event, "request_body", r.Header.Get("Content-Type"), reqBody.body)
+				}
+				event = logBody(event, "response_body", rw.Header().Get("Content-Type"), rw.body)
+			}
+
+			event.Msg("HTTP request")
 		},
 	)
 }
+
+// shouldLogRequest applies sampling to successful requests that weren't slow
+func shouldLogRequest(status int, latency time.Duration) bool {
+	if status >= http.StatusBadRequest || latency >= config.Logging.SlowRequest {
+		return true
+	}
+	return rand.IntN(100) < config.Logging.SamplePercent
+}
+
+func logEventFor(status int) *zerolog.Event {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return logger.Error()
+	case status >= http.StatusBadRequest:
+		return logger.Warn()
+	default:
+		return logger.Info()
+	}
+}
+
+// routeTemplate returns the matched route's path template (/activities/{id})
+// so log lines group by endpoint rather than by ID
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+	return r.URL.Path
+}
+
+// logBody adds a captured JSON body with sensitive fields redacted. Other
+// content types and truncated bodies can't be redacted reliably and are left out.
+func logBody(event *zerolog.Event, field, contentType string, body *cappedBuffer) *zerolog.Event {
+	if body.buf.Len() == 0 {
+		return event
+	}
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType != "application/json" {
+		return event.Str(field, "[omitted: "+contentType+"]")
+	}
+	if body.truncated {
+		return event.Str(field, "[omitted: over LOG_BODY_MAX_BYTES]")
+	}
+
+	redacted, ok := logger.RedactJSON(body.buf.Bytes())
+	if !ok {
+		return event.Str(field, "[omitted: invalid JSON]")
+	}
+	return event.RawJSON(field, redacted)
+}
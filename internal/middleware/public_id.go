@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
+	"github.com/valentinesamuel/activelog/pkg/response"
+	"github.com/valentinesamuel/activelog/pkg/ulid"
+)
+
+// PublicIDResolver returns the serial ID of the record with the given public
+// ID, or errors.ErrNotFound
+type PublicIDResolver func(ctx context.Context, publicID string) (int64, error)
+
+// ResolvePublicID lets the path variable param hold either identifier of a
+// record. A public ID (a ULID) is replaced by the serial ID it resolves to, so
+// handlers keep parsing serial IDs; a serial ID passes through unchanged.
+//
+// The two never overlap: a ULID is 26 characters and always contains a
+// letter or more digits than an int64 has, so anything else is a 400.
+func ResolvePublicID(param string, resolve PublicIDResolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			vars := mux.Vars(r)
+			value, ok := vars[param]
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !ulid.Valid(value) {
+				response.Fail(w, r, http.StatusBadRequest, "Invalid ID")
+				return
+			}
+
+			id, err := resolve(r.Context(), ulid.Normalize(value))
+			if err != nil {
+				if errors.Is(err, appErrors.ErrNotFound) {
+					response.Fail(w, r, http.StatusNotFound, "Not found")
+					return
+				}
+				log.Error().Err(err).Str("publicId", value).Msg("Failed to resolve public ID")
+				response.Fail(w, r, http.StatusInternalServerError, "Failed to resolve ID")
+				return
+			}
+
+			resolved := make(map[string]string, len(vars))
+			for k, v := range vars {
+				resolved[k] = v
+			}
+			resolved[param] = strconv.FormatInt(id, 10)
+			next.ServeHTTP(w, mux.SetURLVars(r, resolved))
+		})
+	}
+}
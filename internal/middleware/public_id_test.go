@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
+)
+
+func TestResolvePublicID(t *testing.T) {
+	const publicID = "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+	resolve := func(_ context.Context, id string) (int64, error) {
+		if id == publicID {
+			return 42, nil
+		}
+		return 0, appErrors.ErrNotFound
+	}
+
+	tests := []struct {
+		name       string
+		id         string
+		wantStatus int
+		wantID     string
+	}{
+		{name: "serial id passes through", id: "7", wantStatus: http.StatusOK, wantID: "7"},
+		{name: "public id is resolved", id: publicID, wantStatus: http.StatusOK, wantID: "42"},
+		{name: "lower case public id is resolved", id: "01arz3ndektsv4rrffq69g5fav", wantStatus: http.StatusOK, wantID: "42"},
+		{name: "unknown public id is not found", id: "01ARZ3NDEKTSV4RRFFQ69G5FAW", wantStatus: http.StatusNotFound},
+		{name: "anything else is rejected", id: "abc", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotID string
+			router := mux.NewRouter()
+			router.Handle("/activities/{id}", ResolvePublicID("id", resolve)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotID = mux.Vars(r)["id"]
+				w.WriteHeader(http.StatusOK)
+			})))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/activities/"+tt.id, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantID, gotID)
+		})
+	}
+}
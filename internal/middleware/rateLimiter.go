@@ -125,7 +125,7 @@ func (rl *RateLimiter) tryEnqueueRefresh() {
 	}
 
 	payload := queueTypes.JobPayload{Event: queueTypes.EventRefreshRateLimitConfig}
-	if _, err := rl.queue.Enqueue(ctx, queueTypes.InboxQueue, payload); err != nil {
+	if _, err := rl.queue.Enqueue(ctx, queueTypes.QueueFor(payload.Event), payload); err != nil {
 		log.Printf("Warning: failed to enqueue rate limit config refresh: %v", err)
 	}
 }
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	requestcontext "github.com/valentinesamuel/activelog/internal/platform/requestcontext"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// RequireAdmin lets only admins (AUTH_ADMIN_EMAILS) through. It must run
+// after AuthMiddleware, which puts the user in the request context.
+func RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := requestcontext.FromContext(r.Context())
+		if !ok || user == nil {
+			response.Fail(w, r, http.StatusUnauthorized, "Unauthorized request")
+			return
+		}
+		if !isAdmin(user.Email) {
+			response.Fail(w, r, http.StatusForbidden, "Admin access required")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func isAdmin(email string) bool {
+	for _, admin := range config.Common.Auth.AdminEmails {
+		if strings.EqualFold(admin, email) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+	"github.com/valentinesamuel/activelog/internal/platform/container"
+)
+
+// RequestScope begins a DI scope for every request so scoped services
+// (container.RegisterScoped) resolve once per request. Handlers get the scope
+// with container.ScopeFromContext; it is closed, disposing its services, when
+// the request ends.
+func RequestScope(c *container.Container) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scope := c.BeginScope(r.Context())
+			defer func() {
+				// Dispose even if the client went away mid-request
+				if err := scope.Close(context.WithoutCancel(r.Context())); err != nil {
+					log.Error().Err(err).Str("path", r.URL.Path).Msg("Failed to dispose request scope")
+				}
+			}()
+
+			next.ServeHTTP(w, r.WithContext(scope.Context()))
+		})
+	}
+}
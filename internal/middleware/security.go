@@ -2,33 +2,60 @@ package middleware
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/valentinesamuel/activelog/internal/platform/config"
 )
 
-func SecurityHeaders(next http.Handler) http.Handler {
-	return http.HandlerFunc(
-		func(w http.ResponseWriter, r *http.Request) {
-			// prevent MIME type sniffing
-			w.Header().Set("X-Content-Type-Options", "nosniff")
+// docsPrefixes are the paths served with cfg.DocsCSP
+var docsPrefixes = []string{"/swagger/", "/docs/"}
+
+func SecurityHeaders(cfg config.SecurityHeadersConfig) func(http.Handler) http.Handler {
+	hsts := ""
+	if cfg.HSTSMaxAge > 0 {
+		hsts = "max-age=" + strconv.Itoa(int(cfg.HSTSMaxAge/time.Second))
+		if cfg.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+		if cfg.HSTSPreload {
+			hsts += "; preload"
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				// prevent MIME type sniffing
+				w.Header().Set("X-Content-Type-Options", "nosniff")
 
-			// prevent click jacking attacks
-			w.Header().Set("X-Frame-Options", "DENY")
+				// prevent click jacking attacks
+				w.Header().Set("X-Frame-Options", cfg.FrameOptions)
 
-			// Enable XSS protection (older browsers)
-			w.Header().Set("X-XSS-Protection", "1; mode=block")
+				// Enable XSS protection (older browsers)
+				w.Header().Set("X-XSS-Protection", "1; mode=block")
 
-			// Force HTTPS for 1 year (only enable when using HTTPS!)
-			// w.Header().Set("Strict-Transport-Security", "max-age=31536000; includeSubDomains")
+				// Force HTTPS (only enabled when serving over HTTPS)
+				if hsts != "" {
+					w.Header().Set("Strict-Transport-Security", hsts)
+				}
 
-			// Content Security Policy
-			// Swagger UI requires inline scripts and styles to function
-			if strings.HasPrefix(r.URL.Path, "/swagger/") {
-				w.Header().Set("Content-Security-Policy", "default-src 'self'; script-src 'self' 'unsafe-inline'; style-src 'self' 'unsafe-inline'; img-src 'self' data:")
-			} else {
-				w.Header().Set("Content-Security-Policy", "default-src 'self'")
-			}
+				// Content Security Policy
+				// Swagger UI requires inline scripts and styles to function
+				csp := cfg.CSP
+				for _, prefix := range docsPrefixes {
+					if strings.HasPrefix(r.URL.Path, prefix) {
+						csp = cfg.DocsCSP
+						break
+					}
+				}
+				if csp != "" {
+					w.Header().Set("Content-Security-Policy", csp)
+				}
 
-			next.ServeHTTP(w, r)
-		},
-	)
+				next.ServeHTTP(w, r)
+			},
+		)
+	}
 }
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RequestTimeouts maps route template prefixes (e.g. "/api/v1/stats") to the
+// deadline given to matching requests. The longest matching prefix wins,
+// Default applies to everything else and zero means no deadline.
+type RequestTimeouts struct {
+	Default time.Duration
+	Routes  map[string]time.Duration
+}
+
+// forRoute returns the deadline for a route template
+func (t RequestTimeouts) forRoute(template string) time.Duration {
+	timeout, longest := t.Default, -1
+	for prefix, d := range t.Routes {
+		if len(prefix) > longest && (template == prefix || strings.HasPrefix(template, prefix+"/")) {
+			timeout, longest = d, len(prefix)
+		}
+	}
+	return timeout
+}
+
+// Timeout gives each request a context deadline, so the queries of a slow
+// request are cancelled instead of running on past the server's write
+// timeout. Handlers that fail because the deadline passed answer 504 (see
+// response.Fail).
+func Timeout(timeouts RequestTimeouts) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			timeout := timeouts.forRoute(routeTemplate(r))
+			if timeout <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeout(t *testing.T) {
+	timeouts := RequestTimeouts{
+		Default: time.Second,
+		Routes: map[string]time.Duration{
+			"/api/v1/stats":                  time.Minute,
+			"/api/v1/activities/{id}/photos": time.Hour,
+			"/api/v1/jobs/{jobId}/events":    0,
+		},
+	}
+
+	tests := []struct {
+		path         string
+		wantDeadline time.Duration // 0 for no deadline
+	}{
+		{"/api/v1/activities/1", time.Second},
+		{"/api/v1/stats/weekly", time.Minute},
+		{"/api/v1/statsx", time.Second},
+		{"/api/v1/activities/1/photos", time.Hour},
+		{"/api/v1/jobs/abc/events", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			var remaining time.Duration
+			var hasDeadline bool
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				var deadline time.Time
+				deadline, hasDeadline = r.Context().Deadline()
+				remaining = time.Until(deadline)
+			}
+
+			router := mux.NewRouter()
+			router.Use(Timeout(timeouts))
+			for _, template := range []string{"/api/v1/activities/{id}", "/api/v1/stats/weekly", "/api/v1/statsx",
+				"/api/v1/activities/{id}/photos", "/api/v1/jobs/{jobId}/events"} {
+				router.HandleFunc(template, handler)
+			}
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if tt.wantDeadline == 0 {
+				assert.False(t, hasDeadline)
+				return
+			}
+			assert.True(t, hasDeadline)
+			assert.InDelta(t, tt.wantDeadline, remaining, float64(100*time.Millisecond))
+		})
+	}
+}
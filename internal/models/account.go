@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// DeleteAccountRequest confirms an account deletion. Without a token the
+// request only issues one (see DeletionConfirmation).
+type DeleteAccountRequest struct {
+	ConfirmationToken string `json:"confirmation_token,omitempty" validate:"omitempty,len=64,hexadecimal"`
+}
+
+// DeletionConfirmation is returned by the first DELETE /users/me; send the
+// token back before ExpiresAt to confirm the deletion
+type DeletionConfirmation struct {
+	ConfirmationToken string    `json:"confirmation_token"`
+	ExpiresAt         time.Time `json:"expires_at"`
+}
+
+// DeletionScheduled is returned once a deletion is confirmed. The account is
+// disabled now and permanently deleted, with all its data, at DeletionScheduledAt.
+type DeletionScheduled struct {
+	DeletionScheduledAt time.Time `json:"deletion_scheduled_at"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Achievement metrics: the lifetime totals achievement rules test
+const (
+	AchievementMetricActivities = "activities"
+	AchievementMetricDistance   = "distance_km"
+	AchievementMetricStreak     = "streak_days"
+)
+
+// AchievementRule declares an achievement: it is earned once Metric reaches
+// Threshold. Code identifies it and must never change once awarded.
+type AchievementRule struct {
+	Code        string
+	Name        string
+	Description string
+	Metric      string
+	Threshold   float64
+}
+
+// Achievement is an achievement as a user sees it. AwardedAt is nil until
+// they earn it.
+type Achievement struct {
+	Code        string     `json:"code"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Earned      bool       `json:"earned"`
+	AwardedAt   *time.Time `json:"awarded_at,omitempty"`
+}
@@ -4,10 +4,15 @@ import (
 	"time"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/valentinesamuel/activelog/pkg/polyline"
+	"github.com/valentinesamuel/activelog/pkg/sanitize"
 )
 
 type Activity struct {
 	BaseEntity
+	// PublicID is the ULID that identifies the activity in URLs, webhooks
+	// and exports; ID stays internal
+	PublicID        string    `json:"publicId" `
 	UserID          int       `json:"userId" `
 	ActivityType    string    `json:"activityType" `
 	Title           string    `json:"title" `
@@ -17,9 +22,84 @@ type Activity struct {
 	CaloriesBurned  int       `json:"caloriesBurned,omitempty" `
 	Notes           string    `json:"notes,omitempty" `
 	ActivityDate    time.Time `json:"activityDate" `
+	Version         int       `json:"version" `
 	Tags            []*Tag    `json:"tags,omitempty" `
+
+	// TagNames is the activities.tags column: the sorted names of the live
+	// tags, kept in step with activity_tags by database triggers so lists can
+	// filter on tags without a JOIN. Tags holds the tags when they are loaded.
+	TagNames []string `json:"-"`
+
+	// Where the activity started; the weather there at ActivityDate is filled
+	// in by a background job after creation
+	StartLat          *float64 `json:"startLat,omitempty" `
+	StartLng          *float64 `json:"startLng,omitempty" `
+	TemperatureC      *float64 `json:"temperatureC,omitempty" `
+	WeatherConditions *string  `json:"weatherConditions,omitempty" `
+
+	// EndLat/EndLng is where the activity finished. A LocationName given
+	// without start coordinates is geocoded into StartLat/StartLng.
+	EndLat       *float64 `json:"endLat,omitempty" `
+	EndLng       *float64 `json:"endLng,omitempty" `
+	LocationName *string  `json:"locationName,omitempty" `
+
+	// Derived metrics, computed on create/update (see service.ApplyMetrics).
+	// CaloriesEstimated is set when CaloriesBurned was estimated rather than logged.
+	PaceMinPerKm      *float64 `json:"paceMinPerKm,omitempty" `
+	AvgSpeedKmh       *float64 `json:"avgSpeedKmh,omitempty" `
+	CaloriesEstimated bool     `json:"caloriesEstimated" `
+	MetricsVersion    int      `json:"-"`
+
+	// Heart-rate metrics, computed from the samples sent on create (see
+	// service.ApplyHeartRate). HRZoneSeconds[i] is the time spent in zone i+1.
+	AvgHeartRate  *int     `json:"avgHeartRate,omitempty" `
+	HRZoneSeconds []int    `json:"hrZoneSeconds,omitempty" `
+	TrainingLoad  *float64 `json:"trainingLoad,omitempty" `
+
+	// How the activity felt: RPE is the rating of perceived exertion (1-10)
+	// and Mood how the user felt afterwards (1 = very bad, 5 = great)
+	RPE  *int `json:"rpe,omitempty" `
+	Mood *int `json:"mood,omitempty" `
+
+	// Metadata is client-specific data, e.g. {"shoe": "pegasus"}
+	Metadata ActivityMetadata `json:"metadata,omitempty" `
+
+	// Source is where the activity came from: ActivitySourceManual, or the
+	// import source (e.g. strava, apple_health). ExternalID is the ID the
+	// other app gave it; a user has one activity per source and external ID,
+	// so importing it again skips it.
+	Source     string  `json:"source" `
+	ExternalID *string `json:"externalId,omitempty" `
+
+	// Visibility is who besides the owner may see the activity:
+	// VisibilityPrivate, VisibilityFollowers or VisibilityPublic
+	Visibility string `json:"visibility" `
+
+	// ReactionCounts maps reaction type to count; only set in list responses
+	ReactionCounts map[string]int `json:"reactionCounts,omitempty" `
+
+	// TypeInfo is the registry entry of ActivityType (display name, icon,
+	// color); only set in list responses
+	TypeInfo *ActivityTypeInfo `json:"typeInfo,omitempty" `
+
+	// ThumbnailURL is a signed URL of a PNG map of the activity's GPS track,
+	// with the owner's privacy zones left out; only set in list responses,
+	// for activities imported with a track
+	ThumbnailURL string `json:"thumbnailUrl,omitempty" `
+
+	// Splits are the laps of the activity; only set when asked for with
+	// include=splits
+	Splits []*ActivitySplit `json:"splits,omitempty" `
+
+	// Track is the recorded GPS track of an imported activity; it is served
+	// simplified by GET /activities/{id}/track rather than with the activity
+	Track []polyline.Point `json:"-"`
 }
 
+// ActivitySourceManual is the Source of activities logged through the API
+// rather than imported
+const ActivitySourceManual = "manual"
+
 type CreateActivityRequest struct {
 	ActivityType    string    `json:"activityType" validate:"required,min=2,max=50"`
 	Title           string    `json:"title" validate:"required,max=255"`
@@ -29,6 +109,59 @@ type CreateActivityRequest struct {
 	CaloriesBurned  int       `json:"caloriesBurned" validate:"omitempty,min=0"`
 	Notes           string    `json:"notes" validate:"max=2000"`
 	ActivityDate    time.Time `json:"activityDate" validate:"required"`
+	StartLat        *float64  `json:"startLat" validate:"required_with=StartLng,omitempty,latitude"`
+	StartLng        *float64  `json:"startLng" validate:"required_with=StartLat,omitempty,longitude"`
+	EndLat          *float64  `json:"endLat" validate:"required_with=EndLng,omitempty,latitude"`
+	EndLng          *float64  `json:"endLng" validate:"required_with=EndLat,omitempty,longitude"`
+	LocationName    *string   `json:"locationName" validate:"omitempty,max=255"`
+	RPE             *int      `json:"rpe" validate:"omitempty,min=1,max=10"`
+	Mood            *int      `json:"mood" validate:"omitempty,min=1,max=5"`
+
+	// Visibility defaults to the user's default_activity_visibility preference
+	Visibility string `json:"visibility" validate:"omitempty,oneof=private followers public"`
+
+	// Metadata is stored as sent, within the ActivityMetadata limits
+	Metadata ActivityMetadata `json:"metadata"`
+
+	// HeartRate samples recorded during the activity, if any
+	HeartRate []HeartRateSample `json:"heartRate" validate:"omitempty,max=86400,dive"`
+
+	// Splits are the laps of the activity in the order they were run
+	Splits []ActivitySplit `json:"splits" validate:"omitempty,max=1000,dive"`
+
+	// Tags are the names of the tags to label the activity with; new ones
+	// are created
+	Tags []string `json:"tags" validate:"omitempty,max=20,dive,required,max=50"`
+}
+
+// HeartRateSample is a heart-rate reading taken OffsetSeconds after the start
+type HeartRateSample struct {
+	OffsetSeconds int `json:"offsetSeconds" validate:"min=0,max=86400"`
+	BPM           int `json:"bpm" validate:"min=20,max=250"`
+}
+
+// ActivitySplit is one lap of an activity. Index numbers the splits from 1 in
+// the order they were run; it is assigned on create, so requests can leave
+// it out.
+type ActivitySplit struct {
+	Index           int     `json:"index"`
+	DistanceKm      float64 `json:"distanceKm" validate:"gt=0,max=1000"`
+	DurationSeconds int     `json:"durationSeconds" validate:"min=1,max=86400"`
+	AvgHeartRate    *int    `json:"avgHeartRate,omitempty" validate:"omitempty,min=20,max=250"`
+}
+
+// NumberSplits returns splits numbered from 1 in the order given
+func NumberSplits(splits []ActivitySplit) []*ActivitySplit {
+	if len(splits) == 0 {
+		return nil
+	}
+	numbered := make([]*ActivitySplit, len(splits))
+	for i := range splits {
+		split := splits[i]
+		split.Index = i + 1
+		numbered[i] = &split
+	}
+	return numbered
 }
 
 type UpdateActivityRequest struct {
@@ -40,9 +173,46 @@ type UpdateActivityRequest struct {
 	CaloriesBurned  *int       `json:"caloriesBurned" validate:"omitempty,min=0"`
 	Notes           *string    `json:"notes" validate:"omitempty,max=2000"`
 	ActivityDate    *time.Time `json:"activityDate"`
+	RPE             *int       `json:"rpe" validate:"omitempty,min=1,max=10"`
+	Mood            *int       `json:"mood" validate:"omitempty,min=1,max=5"`
+	Visibility      *string    `json:"visibility" validate:"omitempty,oneof=private followers public"`
+
+	// Metadata replaces the whole document when set; {} clears it
+	Metadata ActivityMetadata `json:"metadata"`
 }
 
 func (r *CreateActivityRequest) Validate() error {
 	validate := validator.New()
 	return validate.Struct(r)
 }
+
+// Sanitize cleans the free-text fields (see sanitize.Text)
+func (r *CreateActivityRequest) Sanitize() {
+	r.Title = sanitize.Text(r.Title)
+	r.Description = sanitize.Text(r.Description)
+	r.Notes = sanitize.Text(r.Notes)
+	r.LocationName = sanitize.TextPtr(r.LocationName)
+}
+
+// Sanitize cleans the free-text fields that are set (see sanitize.Text)
+func (r *UpdateActivityRequest) Sanitize() {
+	r.Title = sanitize.TextPtr(r.Title)
+	r.Description = sanitize.TextPtr(r.Description)
+	r.Notes = sanitize.TextPtr(r.Notes)
+}
+
+// DuplicateCluster is a group of the user's activities that look like the
+// same workout, e.g. recorded by a watch and imported from a phone app.
+// KeepID is the activity a merge keeps unless told otherwise: the one that
+// records the most.
+type DuplicateCluster struct {
+	KeepID     int64       `json:"keepId"`
+	Activities []*Activity `json:"activities"`
+}
+
+// MergeActivitiesRequest is the body of POST /api/v1/activities/merge.
+// KeepID defaults to the activity that records the most.
+type MergeActivitiesRequest struct {
+	ActivityIDs []int64 `json:"activityIds" validate:"required,min=2,max=20,unique,dive,min=1"`
+	KeepID      *int64  `json:"keepId" validate:"omitempty,min=1"`
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// Limits of ActivityMetadata. The document is schema-less, so only its
+// size is bounded.
+const (
+	MaxMetadataKeys      = 50
+	MaxMetadataBytes     = 4096
+	MaxMetadataKeyLength = 64
+)
+
+// metadataKey matches the keys lists can filter on (filter[metadata.key])
+var metadataKey = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// ActivityMetadata is a JSON object of data a client keeps about an
+// activity, such as the gear used. Values can be any JSON, including nested
+// objects.
+type ActivityMetadata map[string]interface{}
+
+// Validate checks the metadata against the limits: at most MaxMetadataKeys
+// top-level keys of letters, digits and underscores (up to
+// MaxMetadataKeyLength characters), and at most MaxMetadataBytes as JSON
+func (m ActivityMetadata) Validate() error {
+	if len(m) > MaxMetadataKeys {
+		return fmt.Errorf("must have at most %d keys", MaxMetadataKeys)
+	}
+	for key := range m {
+		if len(key) > MaxMetadataKeyLength || !metadataKey.MatchString(key) {
+			return fmt.Errorf("key '%s' must be 1-%d letters, digits or underscores", key, MaxMetadataKeyLength)
+		}
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("must be a JSON object: %w", err)
+	}
+	if len(data) > MaxMetadataBytes {
+		return fmt.Errorf("must be at most %d bytes as JSON", MaxMetadataBytes)
+	}
+	return nil
+}
+
+// Value implements driver.Valuer, encoding the metadata for its JSONB
+// column; nil is stored as {}
+func (m ActivityMetadata) Value() (driver.Value, error) {
+	if m == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(m)
+}
+
+// Scan implements sql.Scanner, decoding the JSONB column
+func (m *ActivityMetadata) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into ActivityMetadata", src)
+	}
+	metadata := ActivityMetadata{}
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+	*m = metadata
+	return nil
+}
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// TrackResolution is how far an activity's GPS track is simplified for
+// display. Lower resolutions keep fewer points, so map thumbnails in list
+// views load fast.
+type TrackResolution string
+
+const (
+	TrackResolutionLow  TrackResolution = "low"
+	TrackResolutionMed  TrackResolution = "med"
+	TrackResolutionFull TrackResolution = "full"
+)
+
+// TrackResolutions are the valid resolutions, lowest first
+var TrackResolutions = []TrackResolution{TrackResolutionLow, TrackResolutionMed, TrackResolutionFull}
+
+// ToleranceM is the Douglas-Peucker tolerance of the resolution in metres:
+// no recorded point lies further than this from the simplified track. The
+// full track isn't simplified.
+func (r TrackResolution) ToleranceM() float64 {
+	switch r {
+	case TrackResolutionLow:
+		return 25
+	case TrackResolutionMed:
+		return 5
+	default:
+		return 0
+	}
+}
+
+// ActivityTrack is an activity's GPS track at one resolution, as an encoded
+// polyline (https://developers.google.com/maps/documentation/utilities/polylinealgorithm)
+type ActivityTrack struct {
+	ActivityID int64           `json:"-"`
+	Resolution TrackResolution `json:"resolution"`
+	Points     int             `json:"points"`
+	Polyline   string          `json:"polyline,omitempty"`
+
+	// Coordinates are the points as [lat, lng] pairs, set instead of
+	// Polyline unless the track is asked for with polyline=true
+	Coordinates [][2]float64 `json:"coordinates,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
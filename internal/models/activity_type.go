@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"github.com/valentinesamuel/activelog/pkg/sanitize"
+)
+
+// ActivityTypeInfo is an entry of the activity type registry: a default
+// (UserID nil) or a type the user added. Activities store its Name.
+type ActivityTypeInfo struct {
+	ID          int64                  `json:"id"`
+	UserID      *int                   `json:"userId,omitempty"`
+	Name        string                 `json:"name"`
+	DisplayName string                 `json:"displayName"`
+	Icon        *string                `json:"icon,omitempty"`
+	Color       *string                `json:"color,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata"`
+	CreatedAt   time.Time              `json:"createdAt"`
+	UpdatedAt   time.Time              `json:"updatedAt"`
+}
+
+// IsDefault reports whether the type is one of the defaults every user has
+func (t *ActivityTypeInfo) IsDefault() bool {
+	return t.UserID == nil
+}
+
+// CreateActivityTypeRequest registers a type of the user's own
+type CreateActivityTypeRequest struct {
+	Name        string                 `json:"name" validate:"required,min=2,max=50"`
+	DisplayName string                 `json:"displayName" validate:"omitempty,max=100"`
+	Icon        *string                `json:"icon" validate:"omitempty,max=50"`
+	Color       *string                `json:"color" validate:"omitempty,len=7,hexcolor"`
+	Metadata    map[string]interface{} `json:"metadata"`
+}
+
+// UpdateActivityTypeRequest is a partial update; the name can't change (merge
+// the type into a new one instead)
+type UpdateActivityTypeRequest struct {
+	DisplayName *string                `json:"displayName" validate:"omitempty,min=1,max=100"`
+	Icon        *string                `json:"icon" validate:"omitempty,max=50"`
+	Color       *string                `json:"color" validate:"omitempty,len=7,hexcolor"`
+	Metadata    map[string]interface{} `json:"metadata"`
+}
+
+// MergeActivityTypesRequest remaps the user's activities of the From types to
+// Into, e.g. "run" and "jog" into "running"
+type MergeActivityTypesRequest struct {
+	From []string `json:"from" validate:"required,min=1,max=20,dive,min=2,max=50"`
+	Into string   `json:"into" validate:"required,min=2,max=50"`
+}
+
+// Sanitize cleans the free-text fields (see sanitize.Text)
+func (r *CreateActivityTypeRequest) Sanitize() {
+	r.Name = sanitize.Text(r.Name)
+	r.DisplayName = sanitize.Text(r.DisplayName)
+}
+
+// Sanitize cleans the free-text fields that are set (see sanitize.Text)
+func (r *UpdateActivityTypeRequest) Sanitize() {
+	r.DisplayName = sanitize.TextPtr(r.DisplayName)
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/valentinesamuel/activelog/pkg/sanitize"
+)
+
+// APIKey is a long-lived key a user created for scripts and the CLI. The key
+// itself is only returned once, in CreatedAPIKey; Prefix is its first
+// characters, enough to tell keys apart.
+type APIKey struct {
+	ID         int64      `json:"id"`
+	UserID     int        `json:"-"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// CreatedAPIKey is a new API key with the key to authenticate with
+type CreatedAPIKey struct {
+	*APIKey
+	Key string `json:"key"`
+}
+
+// CreateAPIKeyRequest is the body of POST /users/me/api-keys
+type CreateAPIKeyRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+// Sanitize cleans the free-text fields (see sanitize.Text)
+func (r *CreateAPIKeyRequest) Sanitize() {
+	r.Name = sanitize.Text(r.Name)
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/valentinesamuel/activelog/pkg/sanitize"
+)
+
+// Body metric types a user can log
+const (
+	BodyMetricWeight     = "weight_kg"
+	BodyMetricRestingHR  = "resting_hr"
+	BodyMetricSleepHours = "sleep_hours"
+)
+
+// bodyMetricRanges are the plausible values of each metric type
+var bodyMetricRanges = map[string][2]float64{
+	BodyMetricWeight:     {20, 500},
+	BodyMetricRestingHR:  {20, 250},
+	BodyMetricSleepHours: {0.5, 24},
+}
+
+// BodyMetric is a single daily measurement: body weight (kg), resting heart
+// rate (bpm) or hours slept. There is at most one per user, type and day.
+type BodyMetric struct {
+	ID         int64     `json:"id"`
+	UserID     int       `json:"user_id"`
+	MetricType string    `json:"metric_type"`
+	Value      float64   `json:"value"`
+	RecordedOn time.Time `json:"recorded_on"`
+	Notes      *string   `json:"notes,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// CheckValue returns an error if Value is implausible for MetricType
+func (m *BodyMetric) CheckValue() error {
+	bounds, ok := bodyMetricRanges[m.MetricType]
+	if !ok {
+		return fmt.Errorf("unknown metric type '%s'", m.MetricType)
+	}
+	if m.Value < bounds[0] || m.Value > bounds[1] {
+		return fmt.Errorf("%s must be between %g and %g", m.MetricType, bounds[0], bounds[1])
+	}
+	return nil
+}
+
+// CreateBodyMetricRequest logs a measurement for a day (YYYY-MM-DD)
+type CreateBodyMetricRequest struct {
+	MetricType string  `json:"metric_type" validate:"required,oneof=weight_kg resting_hr sleep_hours"`
+	Value      float64 `json:"value" validate:"required,gt=0"`
+	RecordedOn string  `json:"recorded_on" validate:"required,datetime=2006-01-02"`
+	Notes      *string `json:"notes" validate:"omitempty,max=500"`
+}
+
+// UpdateBodyMetricRequest is a partial update; the metric type can't change
+type UpdateBodyMetricRequest struct {
+	Value      *float64 `json:"value" validate:"omitempty,gt=0"`
+	RecordedOn *string  `json:"recorded_on" validate:"omitempty,datetime=2006-01-02"`
+	Notes      *string  `json:"notes" validate:"omitempty,max=500"`
+}
+
+// Sanitize cleans the free-text fields (see sanitize.Text)
+func (r *CreateBodyMetricRequest) Sanitize() {
+	r.Notes = sanitize.TextPtr(r.Notes)
+}
+
+// Sanitize cleans the free-text fields that are set (see sanitize.Text)
+func (r *UpdateBodyMetricRequest) Sanitize() {
+	r.Notes = sanitize.TextPtr(r.Notes)
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"time"
+
+	"github.com/valentinesamuel/activelog/pkg/sanitize"
+)
+
+// Challenge metrics: what a challenge's target counts
+const (
+	ChallengeMetricDistance   = "distance_km"
+	ChallengeMetricDuration   = "duration_minutes"
+	ChallengeMetricActivities = "activities"
+)
+
+// Challenge is a time-boxed target, e.g. 100 km in March: participants
+// progress with the activities they log between StartsOn and EndsOn
+// (inclusive), counting only ActivityType when it is set
+type Challenge struct {
+	ID           int64     `json:"id"`
+	CreatorID    int       `json:"creator_id"`
+	Name         string    `json:"name"`
+	Description  *string   `json:"description,omitempty"`
+	ActivityType *string   `json:"activity_type,omitempty"`
+	Metric       string    `json:"metric"`
+	Target       float64   `json:"target"`
+	StartsOn     time.Time `json:"starts_on"`
+	EndsOn       time.Time `json:"ends_on"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// ChallengeDetail is a challenge with its participant count and, when the
+// caller joined it, their participation
+type ChallengeDetail struct {
+	*Challenge
+	ParticipantCount int                   `json:"participant_count"`
+	Participation    *ChallengeParticipant `json:"participation,omitempty"`
+}
+
+// ChallengeParticipant is a user taking part in a challenge. Progress is in
+// the challenge's metric; CompletedAt is set when it first reached the target.
+type ChallengeParticipant struct {
+	ChallengeID int64      `json:"challenge_id"`
+	UserID      int        `json:"user_id"`
+	Progress    float64    `json:"progress"`
+	JoinedAt    time.Time  `json:"joined_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// ChallengeStanding is a participant's place on a challenge leaderboard.
+// ProgressPct is the progress as a percentage of the target.
+type ChallengeStanding struct {
+	Rank        int        `json:"rank"`
+	UserID      int        `json:"user_id"`
+	Username    string     `json:"username"`
+	Progress    float64    `json:"progress"`
+	ProgressPct float64    `json:"progress_pct"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// Badge is awarded for completing a challenge. ChallengeID is nil once the
+// challenge was deleted.
+type Badge struct {
+	ID          int64     `json:"id"`
+	ChallengeID *int64    `json:"challenge_id,omitempty"`
+	Name        string    `json:"name"`
+	AwardedAt   time.Time `json:"awarded_at"`
+}
+
+// CreateChallengeRequest defines a challenge; the dates are YYYY-MM-DD
+type CreateChallengeRequest struct {
+	Name         string  `json:"name" validate:"required,max=100"`
+	Description  *string `json:"description" validate:"omitempty,max=1000"`
+	ActivityType *string `json:"activity_type" validate:"omitempty,min=2,max=50"`
+	Metric       string  `json:"metric" validate:"required,oneof=distance_km duration_minutes activities"`
+	Target       float64 `json:"target" validate:"required,gt=0,max=1000000"`
+	StartsOn     string  `json:"starts_on" validate:"required,datetime=2006-01-02"`
+	EndsOn       string  `json:"ends_on" validate:"required,datetime=2006-01-02"`
+}
+
+// Sanitize cleans the free-text fields (see sanitize.Text)
+func (r *CreateChallengeRequest) Sanitize() {
+	r.Name = sanitize.Text(r.Name)
+	r.Description = sanitize.TextPtr(r.Description)
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// Coach access statuses: an athlete's invitation stays pending until the
+// coach accepts it
+const (
+	CoachAccessPending = "pending"
+	CoachAccessActive  = "active"
+)
+
+// Coach access scopes. Every active grant includes read; comment is granted
+// per coach (CoachAccess.CanComment).
+const (
+	CoachScopeRead    = "read"
+	CoachScopeComment = "comment"
+)
+
+// CoachAccess is an athlete's grant to a coach: read access to the athlete's
+// activities and stats and, with CanComment, comments on their activities.
+// It only applies once the coach accepted it (Status active).
+type CoachAccess struct {
+	AthleteID       int        `json:"athlete_id"`
+	AthleteUsername string     `json:"athlete_username,omitempty"`
+	CoachID         int        `json:"coach_id"`
+	CoachUsername   string     `json:"coach_username,omitempty"`
+	CanComment      bool       `json:"can_comment"`
+	Status          string     `json:"status"`
+	CreatedAt       time.Time  `json:"created_at"`
+	AcceptedAt      *time.Time `json:"accepted_at,omitempty"`
+}
+
+// InviteCoachRequest invites a user to coach the caller
+type InviteCoachRequest struct {
+	CoachID    int  `json:"coach_id" validate:"required,min=1"`
+	CanComment bool `json:"can_comment"`
+}
+
+// UpdateCoachAccessRequest changes the scopes granted to a coach
+type UpdateCoachAccessRequest struct {
+	CanComment *bool `json:"can_comment" validate:"required"`
+}
+
+// AthleteLoad is one athlete's row on a coach's dashboard: their last seven
+// days and the training load behind them. AcuteLoad and ChronicLoad are the
+// 7- and 28-day sums ending today, and Ratio compares the acute load with
+// the weekly average of the chronic one; it is nil without a chronic load.
+type AthleteLoad struct {
+	AthleteID       int      `json:"athlete_id"`
+	PublicID        string   `json:"public_id"`
+	Username        string   `json:"username"`
+	Activities      int      `json:"activities"`
+	DurationMinutes int      `json:"duration_minutes"`
+	DistanceKm      float64  `json:"distance_km"`
+	AcuteLoad       float64  `json:"acute_load"`
+	ChronicLoad     float64  `json:"chronic_load"`
+	Ratio           *float64 `json:"acute_chronic_ratio"`
+}
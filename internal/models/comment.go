@@ -1,5 +1,7 @@
 package models
 
+import "github.com/valentinesamuel/activelog/pkg/sanitize"
+
 // Comment represents a polymorphic comment that can belong to any commentable entity.
 // The CommentableType field (e.g., "Activity", "Tag") determines which table is JOINed
 // when filtering via the polymorphic relationship.
@@ -10,3 +12,13 @@ type Comment struct {
 	CommentableID   int    `json:"commentable_id"`
 	Content         string `json:"content"`
 }
+
+// CreateCommentRequest is the body of a new comment
+type CreateCommentRequest struct {
+	Content string `json:"content" validate:"required,max=2000"`
+}
+
+// Sanitize cleans the free-text fields (see sanitize.Text)
+func (r *CreateCommentRequest) Sanitize() {
+	r.Content = sanitize.Text(r.Content)
+}
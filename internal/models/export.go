@@ -8,6 +8,7 @@ type ExportFormat string
 const (
 	FormatCSV ExportFormat = "csv"
 	FormatPDF ExportFormat = "pdf"
+	FormatZIP ExportFormat = "zip" // GDPR data export bundle
 )
 
 // ExportStatus represents the current state of an export job.
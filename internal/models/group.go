@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"github.com/valentinesamuel/activelog/pkg/sanitize"
+)
+
+// GroupRole represents a member's role within a group.
+type GroupRole string
+
+const (
+	GroupRoleOwner  GroupRole = "owner"
+	GroupRoleMember GroupRole = "member"
+)
+
+// Group is a set of users who opt in to comparing activity on a shared leaderboard.
+// Private groups only expose their members and leaderboard to members.
+type Group struct {
+	BaseEntity
+	OwnerID     int    `json:"owner_id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	IsPrivate   bool   `json:"is_private"`
+	MemberCount int    `json:"member_count"`
+}
+
+// GroupMember represents a row in the group_members table.
+// ShowOnLeaderboard is the member's privacy flag: when false they are hidden
+// from everyone else's view of the leaderboard.
+type GroupMember struct {
+	GroupID           int64     `json:"group_id"`
+	UserID            int       `json:"user_id"`
+	Username          string    `json:"username,omitempty"`
+	Role              GroupRole `json:"role"`
+	ShowOnLeaderboard bool      `json:"show_on_leaderboard"`
+	JoinedAt          time.Time `json:"joined_at"`
+}
+
+// LeaderboardEntry is a single ranked member on a group leaderboard.
+type LeaderboardEntry struct {
+	Rank            int     `json:"rank"`
+	UserID          int     `json:"user_id"`
+	Username        string  `json:"username"`
+	TotalDistanceKm float64 `json:"total_distance_km"`
+	TotalDuration   int     `json:"total_duration_minutes"`
+	ActivityCount   int     `json:"activity_count"`
+}
+
+type CreateGroupRequest struct {
+	Name        string `json:"name" validate:"required,min=3,max=100"`
+	Description string `json:"description" validate:"max=1000"`
+	IsPrivate   bool   `json:"is_private"`
+}
+
+// Sanitize cleans the free-text fields (see sanitize.Text)
+func (r *CreateGroupRequest) Sanitize() {
+	r.Name = sanitize.Text(r.Name)
+	r.Description = sanitize.Text(r.Description)
+}
+
+type UpdateGroupMembershipRequest struct {
+	ShowOnLeaderboard *bool `json:"show_on_leaderboard" validate:"required"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// UserIdentity is an external login method (Google, GitHub, Apple) linked to
+// a user. Subject is the provider's ID for the user.
+type UserIdentity struct {
+	ID          int64      `json:"id"`
+	UserID      int        `json:"user_id"`
+	Provider    string     `json:"provider"`
+	Subject     string     `json:"-"`
+	Email       *string    `json:"email,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+}
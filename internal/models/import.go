@@ -0,0 +1,145 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/valentinesamuel/activelog/pkg/gpx"
+	"github.com/valentinesamuel/activelog/pkg/polyline"
+)
+
+// ImportSource identifies where imported activities came from.
+type ImportSource string
+
+const (
+	ImportSourceJSON   ImportSource = "json"
+	ImportSourceStrava ImportSource = "strava"
+	ImportSourceGPX    ImportSource = "gpx"
+
+	// Health app exports, uploaded as the file the app exports (see
+	// pkg/healthexport) rather than as JSON rows
+	ImportSourceAppleHealth ImportSource = "apple_health"
+	ImportSourceGoogleFit   ImportSource = "google_fit"
+)
+
+// IsHealthExport reports whether the source is a health app export
+func (s ImportSource) IsHealthExport() bool {
+	return s == ImportSourceAppleHealth || s == ImportSourceGoogleFit
+}
+
+// ImportError describes a range of rows that could not be imported.
+// Rows are 1-based positions in the uploaded file.
+type ImportError struct {
+	FirstRow int    `json:"first_row"`
+	LastRow  int    `json:"last_row"`
+	Message  string `json:"message"`
+}
+
+// ImportRecord represents a row in the imports table.
+// Status reuses the export job lifecycle (pending → processing → completed/failed).
+type ImportRecord struct {
+	ID            string        `json:"id"`
+	UserID        int           `json:"user_id"`
+	Source        ImportSource  `json:"source"`
+	Status        ExportStatus  `json:"status"`
+	StorageKey    string        `json:"-"`
+	TotalRows     int           `json:"total_rows"`
+	ProcessedRows int           `json:"processed_rows"`
+	ImportedRows  int           `json:"imported_rows"`
+	FailedRows    int           `json:"failed_rows"`
+	SkippedRows   int           `json:"skipped_rows"` // imported before
+	Errors        []ImportError `json:"errors"`
+	ErrorMessage  *string       `json:"error_message,omitempty"`
+	CreatedAt     time.Time     `json:"created_at"`
+	CompletedAt   *time.Time    `json:"completed_at,omitempty"`
+}
+
+// ImportActivityRequest is one activity in an import file.
+// Tags are attached by name and created if they don't exist yet.
+type ImportActivityRequest struct {
+	CreateActivityRequest
+	Tags []string `json:"tags,omitempty" validate:"omitempty,dive,min=1,max=50"`
+
+	// GPX is the recorded track as a GPX document. The track is stored with
+	// the activity, and rows without Splits get per-kilometre splits derived
+	// from it (see ParseGPX).
+	GPX string `json:"gpx,omitempty" validate:"omitempty,max=10485760"`
+
+	// Track is the GPX track, set by ParseGPX
+	Track []polyline.Point `json:"-"`
+
+	// ExternalID is the activity's ID in the app it was exported from, e.g.
+	// a Strava activity ID. Rows whose ID the user already has are skipped.
+	ExternalID string `json:"externalId,omitempty" validate:"omitempty,max=255"`
+}
+
+// ParseGPX reads the row's GPX track, when it has one, into Track and, when
+// the row has no splits of its own, into per-kilometre Splits
+func (r *ImportActivityRequest) ParseGPX() error {
+	if r.GPX == "" {
+		return nil
+	}
+
+	points, err := gpx.Parse(strings.NewReader(r.GPX))
+	if err != nil {
+		return err
+	}
+	r.Track = make([]polyline.Point, len(points))
+	for i, p := range points {
+		r.Track[i] = polyline.Point{Lat: p.Lat, Lng: p.Lng}
+	}
+
+	if len(r.Splits) > 0 {
+		return nil
+	}
+	for _, split := range gpx.Splits(points, 1) {
+		s := ActivitySplit{DistanceKm: split.DistanceKm, DurationSeconds: split.DurationSeconds}
+		if split.AvgHeartRate > 0 {
+			hr := split.AvgHeartRate
+			s.AvgHeartRate = &hr
+		}
+		r.Splits = append(r.Splits, s)
+	}
+	return nil
+}
+
+// Validate validates the activity and its tag names
+func (r *ImportActivityRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// ValidateWorkout validates a row read from a health app export. Workouts
+// such as strength training have no distance, so unlike Validate it allows a
+// distance of 0.
+func (r *ImportActivityRequest) ValidateWorkout() error {
+	validate := validator.New()
+	return validate.StructExcept(r, "CreateActivityRequest.DistanceKm")
+}
+
+// ToActivity converts the request into an Activity owned by userID and
+// imported from source
+func (r *ImportActivityRequest) ToActivity(userID int, source ImportSource) *Activity {
+	activity := &Activity{
+		UserID:          userID,
+		Source:          string(source),
+		ActivityType:    r.ActivityType,
+		Title:           r.Title,
+		Description:     r.Description,
+		DurationMinutes: r.DurationMinutes,
+		DistanceKm:      r.DistanceKm,
+		CaloriesBurned:  r.CaloriesBurned,
+		Notes:           r.Notes,
+		ActivityDate:    r.ActivityDate,
+		Splits:          NumberSplits(r.Splits),
+		Track:           r.Track,
+	}
+	if r.ExternalID != "" {
+		activity.ExternalID = &r.ExternalID
+	}
+	for _, name := range r.Tags {
+		activity.Tags = append(activity.Tags, &Tag{Name: name})
+	}
+	return activity
+}
@@ -10,4 +10,9 @@ type ActivityPhoto struct {
 	ContentType  string    `json:"content_type,omitempty" `
 	FileSize     int64     `json:"file_size,omitempty" validate:"required,min=2,max=2457600" `
 	UploadedAt   time.Time `json:"uploaded_at" `
+
+	// URL and ThumbnailURL are short-lived signed URLs of the files handler,
+	// filled in when photos are served
+	URL          string `json:"url,omitempty"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
 }
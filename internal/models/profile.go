@@ -0,0 +1,161 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/valentinesamuel/activelog/pkg/sanitize"
+)
+
+// Unit systems a user can choose to see distances in
+const (
+	UnitsMetric   = "metric"
+	UnitsImperial = "imperial"
+)
+
+// DefaultLanguage is used for emails and exports when the user has not
+// chosen a language (see package locale for the supported ones)
+const DefaultLanguage = "en"
+
+// Activity visibility levels: who besides the owner may see an activity.
+// Followers are the people the owner trains with: their active coaches and
+// the members of their groups. Only public activities can be shared by link.
+const (
+	VisibilityPrivate   = "private"
+	VisibilityFollowers = "followers"
+	VisibilityPublic    = "public"
+)
+
+// UserPreferences are stored as a JSONB document on the user row.
+// Unset fields fall back to the defaults applied by WithDefaults.
+type UserPreferences struct {
+	Units                     string `json:"units,omitempty" validate:"omitempty,oneof=metric imperial"`
+	DefaultActivityVisibility string `json:"default_activity_visibility,omitempty" validate:"omitempty,oneof=private followers public"`
+	ShowOnLeaderboards        *bool  `json:"show_on_leaderboards,omitempty"`
+	Language                  string `json:"language,omitempty" validate:"omitempty,oneof=en fr es"`
+}
+
+// WithDefaults returns p with every unset preference filled in
+func (p UserPreferences) WithDefaults() UserPreferences {
+	if p.Units == "" {
+		p.Units = UnitsMetric
+	}
+	if p.DefaultActivityVisibility == "" {
+		p.DefaultActivityVisibility = VisibilityFollowers
+	}
+	if p.ShowOnLeaderboards == nil {
+		show := true
+		p.ShowOnLeaderboards = &show
+	}
+	if p.Language == "" {
+		p.Language = DefaultLanguage
+	}
+	return p
+}
+
+// UserProfile is the user-editable part of an account, served by /users/me.
+// AvatarURL is a short-lived signed URL of AvatarKey (see package fileurl).
+type UserProfile struct {
+	ID          int             `json:"id"`
+	Email       string          `json:"email"`
+	Username    string          `json:"username"`
+	DisplayName *string         `json:"display_name"`
+	Bio         *string         `json:"bio"`
+	AvatarKey   *string         `json:"-"`
+	AvatarURL   *string         `json:"avatar_url"`
+	Timezone    string          `json:"timezone"`
+	WeightKg    *float64        `json:"weight_kg"`
+	Preferences UserPreferences `json:"preferences"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   *time.Time      `json:"updated_at"`
+}
+
+// UpdateProfileRequest is a partial update: only the fields present are
+// changed, and preferences are merged into the stored ones
+type UpdateProfileRequest struct {
+	DisplayName *string          `json:"display_name" validate:"omitempty,max=100"`
+	Bio         *string          `json:"bio" validate:"omitempty,max=500"`
+	Timezone    *string          `json:"timezone" validate:"omitempty,timezone"`
+	WeightKg    *float64         `json:"weight_kg" validate:"omitempty,gt=0,lte=500"`
+	Preferences *UserPreferences `json:"preferences"`
+}
+
+// Sanitize cleans the free-text fields (see sanitize.Text)
+func (r *UpdateProfileRequest) Sanitize() {
+	r.DisplayName = sanitize.TextPtr(r.DisplayName)
+	r.Bio = sanitize.TextPtr(r.Bio)
+}
+
+// DefaultMaxHeartRate is assumed when the user has not set theirs
+const DefaultMaxHeartRate = 190
+
+// heartRateZoneFractions are the lower bounds of the five zones as a share of
+// the maximum heart rate
+var heartRateZoneFractions = [5]float64{0.5, 0.6, 0.7, 0.8, 0.9}
+
+// HeartRateZones are the user's five training zones. Zones holds the lower
+// bound (bpm) of each zone; Custom is false when they are derived from
+// MaxHeartRate.
+type HeartRateZones struct {
+	MaxHeartRate int   `json:"max_heart_rate"`
+	Zones        []int `json:"zones"`
+	Custom       bool  `json:"custom"`
+}
+
+// DeriveHeartRateZones returns the default zone bounds for maxHeartRate
+func DeriveHeartRateZones(maxHeartRate int) []int {
+	zones := make([]int, len(heartRateZoneFractions))
+	for i, fraction := range heartRateZoneFractions {
+		zones[i] = int(math.Round(float64(maxHeartRate) * fraction))
+	}
+	return zones
+}
+
+// UpdateHeartRateZonesRequest sets either explicit zone bounds or a maximum
+// heart rate to derive them from. Sending only max_heart_rate clears any
+// custom zones.
+type UpdateHeartRateZonesRequest struct {
+	MaxHeartRate *int  `json:"max_heart_rate" validate:"required_without=Zones,omitempty,min=100,max=250"`
+	Zones        []int `json:"zones" validate:"omitempty,len=5,dive,min=30,max=250"`
+}
+
+// PrivacyZone is a place whose exact position the user keeps to themselves,
+// e.g. home. Activity start and end points within RadiusM meters of it are
+// hidden from everyone but the user.
+type PrivacyZone struct {
+	ID        int64     `json:"id"`
+	UserID    int       `json:"-"`
+	Name      string    `json:"name"`
+	Lat       float64   `json:"lat"`
+	Lng       float64   `json:"lng"`
+	RadiusM   int       `json:"radius_m"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreatePrivacyZoneRequest is the body of POST /users/me/privacy-zones
+type CreatePrivacyZoneRequest struct {
+	Name    string   `json:"name" validate:"required,max=100"`
+	Lat     *float64 `json:"lat" validate:"required,latitude"`
+	Lng     *float64 `json:"lng" validate:"required,longitude"`
+	RadiusM int      `json:"radius_m" validate:"required,min=100,max=5000"`
+}
+
+// Sanitize cleans the free-text fields (see sanitize.Text)
+func (r *CreatePrivacyZoneRequest) Sanitize() {
+	r.Name = sanitize.Text(r.Name)
+}
+
+// UpdatePrivacyZoneRequest is a partial update of a privacy zone; the center
+// moves only with both lat and lng
+type UpdatePrivacyZoneRequest struct {
+	Name    *string  `json:"name" validate:"omitempty,min=1,max=100"`
+	Lat     *float64 `json:"lat" validate:"required_with=Lng,omitempty,latitude"`
+	Lng     *float64 `json:"lng" validate:"required_with=Lat,omitempty,longitude"`
+	RadiusM *int     `json:"radius_m" validate:"omitempty,min=100,max=5000"`
+}
+
+// Sanitize cleans the free-text fields that are set (see sanitize.Text)
+func (r *UpdatePrivacyZoneRequest) Sanitize() {
+	r.Name = sanitize.TextPtr(r.Name)
+}
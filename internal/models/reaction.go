@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Reaction types a user can leave on an activity
+const (
+	ReactionKudos = "kudos"
+	ReactionFire  = "fire"
+	ReactionClap  = "clap"
+	ReactionHeart = "heart"
+)
+
+// ActivityReaction is one user's reaction to an activity. A user has at most
+// one reaction per activity; reacting again replaces its type.
+type ActivityReaction struct {
+	ID         int64     `json:"id"`
+	UserID     int       `json:"user_id"`
+	ActivityID int64     `json:"activity_id"`
+	Reaction   string    `json:"reaction"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ReactRequest is the body of POST /activities/{id}/reactions
+type ReactRequest struct {
+	Reaction string `json:"reaction" validate:"required,oneof=kudos fire clap heart"`
+}
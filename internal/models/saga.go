@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// SagaStatus represents the lifecycle state of a saga.
+type SagaStatus string
+
+const (
+	SagaStatusRunning      SagaStatus = "running"
+	SagaStatusCompensating SagaStatus = "compensating"
+	SagaStatusCompleted    SagaStatus = "completed"
+	SagaStatusCompensated  SagaStatus = "compensated"
+)
+
+// Saga represents a row in the sagas table: the progress of a multi-step
+// operation run by broker.RunSaga, so it can resume where it stopped.
+type Saga struct {
+	ID             string     `json:"id"`
+	Status         SagaStatus `json:"status"`
+	CompletedSteps []string   `json:"completed_steps"`
+	TotalSteps     int        `json:"total_steps"`
+	CurrentStep    string     `json:"current_step,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// Progress returns the completion percentage of the saga
+func (s *Saga) Progress() int {
+	if s.Status == SagaStatusCompleted || s.TotalSteps == 0 {
+		return 100
+	}
+	return min(len(s.CompletedSteps)*100/s.TotalSteps, 100)
+}
@@ -0,0 +1,19 @@
+package models
+
+// SearchResult is an activity matching a search, with its relevance score
+// and the fragments of its text fields that matched, the terms wrapped in
+// <mark> tags, by field (title, description, notes, locationName, tags)
+type SearchResult struct {
+	Activity   *Activity           `json:"activity"`
+	Score      float64             `json:"score"`
+	Highlights map[string][]string `json:"highlights,omitempty"`
+}
+
+// SearchResponse is a page of search results, best match first. Total is
+// the number of matches in the search index.
+type SearchResponse struct {
+	Results []SearchResult `json:"results"`
+	Total   int            `json:"total"`
+	Limit   int            `json:"limit"`
+	Offset  int            `json:"offset"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// ActivityShare is a public, read-only link to a single activity.
+// The link stays valid until it expires or its owner revokes it.
+type ActivityShare struct {
+	ID           int64      `json:"id"`
+	PublicID     string     `json:"public_id"`
+	ActivityID   int64      `json:"activity_id"`
+	UserID       int        `json:"-"`
+	Token        string     `json:"token,omitempty"`
+	URL          string     `json:"url,omitempty"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+	ViewCount    int        `json:"view_count"`
+	LastViewedAt *time.Time `json:"last_viewed_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// CreateShareRequest is the body of POST /activities/{id}/share.
+// Omitting ExpiresInHours creates a link that never expires.
+type CreateShareRequest struct {
+	ExpiresInHours int `json:"expires_in_hours" validate:"omitempty,min=1,max=8760"`
+}
+
+// SharedActivity is the sanitized view of an activity served to anonymous
+// viewers of a share link. It deliberately carries no user or record IDs.
+type SharedActivity struct {
+	ActivityType    string                 `json:"activityType"`
+	Title           string                 `json:"title"`
+	Description     string                 `json:"description,omitempty"`
+	DurationMinutes int                    `json:"durationMinutes,omitempty"`
+	DistanceKm      float64                `json:"distanceKm,omitempty"`
+	CaloriesBurned  int                    `json:"caloriesBurned,omitempty"`
+	ActivityDate    time.Time              `json:"activityDate"`
+	ViewCount       int                    `json:"viewCount"`
+	Units           string                 `json:"-"` // the owner's units preference, which Display follows
+	Display         *SharedActivityDisplay `json:"display,omitempty"`
+}
+
+// SharedActivityDisplay is a shared activity's measures formatted for the
+// share page: in the owner's units, with numbers in the viewer's language.
+// A field is empty when the activity lacks the measure (e.g. no distance,
+// so no pace).
+type SharedActivityDisplay struct {
+	Duration string `json:"duration,omitempty"`
+	Distance string `json:"distance,omitempty"`
+	Pace     string `json:"pace,omitempty"`
+}
@@ -0,0 +1,164 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Change is the net change to one entity within a page of the change feed
+type Change struct {
+	Entity    string
+	EntityID  int64
+	Operation string // latest operation: create, update or delete
+	Version   int
+	Created   bool // the entity was created within the page
+}
+
+// ChangeCursor is a position in the change feed: the transaction that wrote a
+// change and its seq. Changes are paged in commit-safe (TxID, Seq) order; the
+// zero cursor is the start of the feed.
+type ChangeCursor struct {
+	TxID int64
+	Seq  int64
+}
+
+// String encodes the cursor as sent to clients, e.g. "1834-52"
+func (c ChangeCursor) String() string {
+	return fmt.Sprintf("%d-%d", c.TxID, c.Seq)
+}
+
+// ParseChangeCursor decodes a cursor from ChangeCursor.String. An empty
+// string is the start of the feed.
+func ParseChangeCursor(s string) (ChangeCursor, error) {
+	if s == "" {
+		return ChangeCursor{}, nil
+	}
+	txID, seq, ok := strings.Cut(s, "-")
+	if !ok {
+		return ChangeCursor{}, fmt.Errorf("invalid cursor %q", s)
+	}
+	var c ChangeCursor
+	var err error
+	if c.TxID, err = strconv.ParseInt(txID, 10, 64); err != nil || c.TxID < 0 {
+		return ChangeCursor{}, fmt.Errorf("invalid cursor %q", s)
+	}
+	if c.Seq, err = strconv.ParseInt(seq, 10, 64); err != nil || c.Seq < 0 {
+		return ChangeCursor{}, fmt.Errorf("invalid cursor %q", s)
+	}
+	return c, nil
+}
+
+// ChangePage is one page of the change feed, collapsed to one Change per entity
+// in the order of their latest change. Next is the cursor to continue from and
+// HasMore is set when more changes follow it.
+type ChangePage struct {
+	Changes []*Change
+	Next    ChangeCursor
+	HasMore bool
+}
+
+// SyncCursors maps an entity name (e.g. "activities") to the opaque cursor the
+// last pull returned for it. A missing entity syncs from the beginning.
+type SyncCursors map[string]string
+
+// UnmarshalJSON accepts the numeric seq cursors of earlier clients as well.
+// Changes recorded before cursors became (txid, seq) pairs have txid 0, so
+// seq N continues from "0-N".
+func (c *SyncCursors) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	cursors := make(SyncCursors, len(raw))
+	for entity, value := range raw {
+		var cursor string
+		if err := json.Unmarshal(value, &cursor); err == nil {
+			cursors[entity] = cursor
+			continue
+		}
+		var seq int64
+		if err := json.Unmarshal(value, &seq); err != nil {
+			return fmt.Errorf("cursor for %s must be a string", entity)
+		}
+		cursors[entity] = ChangeCursor{Seq: seq}.String()
+	}
+	*c = cursors
+	return nil
+}
+
+// SyncPullRequest asks for the changes made since cursors
+type SyncPullRequest struct {
+	Cursors SyncCursors `json:"cursors"`
+	Limit   int         `json:"limit,omitempty" validate:"omitempty,min=1,max=500"`
+}
+
+// ActivityChanges lists the activities created, updated and deleted since a cursor.
+// Updated may include activities the client has never seen, so clients should upsert.
+type ActivityChanges struct {
+	Created []*Activity `json:"created"`
+	Updated []*Activity `json:"updated"`
+	Deleted []int64     `json:"deleted"`
+}
+
+// SyncChanges groups the changes of a pull by entity
+type SyncChanges struct {
+	Activities ActivityChanges `json:"activities"`
+}
+
+// SyncPullResponse returns changes and the cursors to send on the next pull.
+// HasMore is set when a page limit was hit and the client should pull again.
+type SyncPullResponse struct {
+	Changes SyncChanges `json:"changes"`
+	Cursors SyncCursors `json:"cursors"`
+	HasMore bool        `json:"has_more"`
+}
+
+// Sync mutation operations
+const (
+	SyncOpCreate = "create"
+	SyncOpUpdate = "update"
+	SyncOpDelete = "delete"
+)
+
+// SyncMutation is one change made by the client while offline.
+// BaseVersion is the version the client edited; update and delete are
+// rejected with a conflict if the server has moved on since.
+type SyncMutation struct {
+	ClientID    string          `json:"client_id" validate:"required,max=100"`
+	Entity      string          `json:"entity" validate:"required,oneof=activities"`
+	Op          string          `json:"op" validate:"required,oneof=create update delete"`
+	ID          int64           `json:"id,omitempty"`
+	BaseVersion int             `json:"base_version,omitempty"`
+	Data        json.RawMessage `json:"data,omitempty" swaggertype:"object"`
+}
+
+// SyncPushRequest is a batch of client mutations, applied in order
+type SyncPushRequest struct {
+	Mutations []SyncMutation `json:"mutations" validate:"required,min=1,max=100,dive"`
+}
+
+// Sync mutation result statuses
+const (
+	SyncStatusApplied  = "applied"
+	SyncStatusConflict = "conflict"
+	SyncStatusNotFound = "not_found"
+	SyncStatusInvalid  = "invalid"
+)
+
+// SyncMutationResult reports how one mutation was handled. On a conflict
+// Current holds the server's copy so the client can merge and retry.
+type SyncMutationResult struct {
+	ClientID string    `json:"client_id"`
+	Status   string    `json:"status"`
+	ID       int64     `json:"id,omitempty"`
+	Version  int       `json:"version,omitempty"`
+	Error    string    `json:"error,omitempty"`
+	Current  *Activity `json:"current,omitempty"`
+}
+
+// SyncPushResponse returns one result per mutation, in request order
+type SyncPushResponse struct {
+	Results []SyncMutationResult `json:"results"`
+}
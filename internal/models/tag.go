@@ -2,5 +2,6 @@ package models
 
 type Tag struct {
 	BaseEntity
-	Name string `json:"name" `
+	PublicID string `json:"public_id,omitempty" `
+	Name     string `json:"name" `
 }
@@ -2,6 +2,7 @@ package models
 
 type User struct {
 	BaseEntity
+	PublicID     string `json:"public_id,omitempty" `
 	Email        string `json:"email,omitempty" `
 	Username     string `json:"username,omitempty" `
 	PasswordHash string `json:"password_hash,omitempty" `
@@ -15,7 +16,14 @@ type CreateUserRequest struct {
 	Email    string `json:"email" validate:"required,min=4"`
 }
 
+// Login session modes
+const (
+	SessionBearer = "bearer" // the JWT is returned in the body (default)
+	SessionCookie = "cookie" // the JWT is set as an HttpOnly cookie (AUTH_COOKIE_SESSIONS)
+)
+
 type LoginUserRequest struct {
 	Email    string `json:"email" validate:"required,min=4"`
 	Password string `json:"password" validate:"required,min=4"`
+	Session  string `json:"session,omitempty" validate:"omitempty,oneof=bearer cookie"`
 }
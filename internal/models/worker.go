@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// WorkerHeartbeat is the last status a worker process reported. Alive is
+// false once the worker missed its heartbeats, e.g. because it crashed.
+type WorkerHeartbeat struct {
+	ID            string    `json:"id"`
+	Hostname      string    `json:"hostname"`
+	PID           int       `json:"pid"`
+	QueueProvider string    `json:"queue_provider"`
+	QueueError    *string   `json:"queue_error,omitempty"` // set while the queue can't be reached
+	Handlers      int       `json:"handlers"`
+	InFlight      int64     `json:"in_flight"`
+	Processed     int64     `json:"processed"`
+	Failed        int64     `json:"failed"`
+	StartedAt     time.Time `json:"started_at"`
+	LastSeenAt    time.Time `json:"last_seen_at"`
+	Alive         bool      `json:"alive"`
+}
@@ -0,0 +1,177 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/valentinesamuel/activelog/pkg/sanitize"
+)
+
+// Workout step kinds
+const (
+	WorkoutStepWarmup   = "warmup"
+	WorkoutStepInterval = "interval"
+	WorkoutStepRecovery = "recovery"
+	WorkoutStepCooldown = "cooldown"
+)
+
+// Planned workout statuses, as reported by compliance
+const (
+	WorkoutCompleted = "completed"
+	WorkoutMissed    = "missed"
+	WorkoutUpcoming  = "upcoming"
+)
+
+// Workout is a structured session a user plans to do, made of steps with
+// targets. PlannedDurationMinutes and PlannedDistanceKm are the totals of the
+// steps that set a duration or distance (see ComputeTotals).
+type Workout struct {
+	ID                     int64        `json:"id"`
+	UserID                 int          `json:"user_id"`
+	Name                   string       `json:"name"`
+	ActivityType           string       `json:"activity_type"`
+	Description            *string      `json:"description,omitempty"`
+	Steps                  WorkoutSteps `json:"steps"`
+	PlannedDurationMinutes int          `json:"planned_duration_minutes"`
+	PlannedDistanceKm      *float64     `json:"planned_distance_km,omitempty"`
+	CreatedAt              time.Time    `json:"created_at"`
+	UpdatedAt              time.Time    `json:"updated_at"`
+}
+
+// WorkoutStep is one part of a workout, run Repeat times. It targets a
+// duration, a distance or both, and optionally a pace and heart-rate range.
+type WorkoutStep struct {
+	Kind               string   `json:"kind" validate:"required,oneof=warmup interval recovery cooldown"`
+	Repeat             int      `json:"repeat" validate:"omitempty,min=1,max=100"`
+	DurationSeconds    *int     `json:"duration_seconds,omitempty" validate:"required_without=DistanceKm,omitempty,min=1,max=86400"`
+	DistanceKm         *float64 `json:"distance_km,omitempty" validate:"required_without=DurationSeconds,omitempty,gt=0,max=1000"`
+	TargetPaceMinPerKm *float64 `json:"target_pace_min_per_km,omitempty" validate:"omitempty,gt=0,max=60"`
+	TargetHRMin        *int     `json:"target_hr_min,omitempty" validate:"omitempty,min=20,max=250"`
+	TargetHRMax        *int     `json:"target_hr_max,omitempty" validate:"omitempty,min=20,max=250"`
+}
+
+// WorkoutSteps is the steps JSONB column of workouts
+type WorkoutSteps []WorkoutStep
+
+// Value implements driver.Valuer, encoding the steps as JSON
+func (s WorkoutSteps) Value() (driver.Value, error) {
+	if s == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(s)
+}
+
+// Scan implements sql.Scanner, decoding the JSONB column
+func (s *WorkoutSteps) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into WorkoutSteps", src)
+	}
+	steps := WorkoutSteps{}
+	if err := json.Unmarshal(data, &steps); err != nil {
+		return fmt.Errorf("failed to decode workout steps: %w", err)
+	}
+	*s = steps
+	return nil
+}
+
+// ComputeTotals sets the planned totals from the steps. Steps without a
+// repeat count run once.
+func (w *Workout) ComputeTotals() {
+	var seconds int
+	var distance float64
+	hasDistance := false
+	for i := range w.Steps {
+		step := &w.Steps[i]
+		if step.Repeat == 0 {
+			step.Repeat = 1
+		}
+		if step.DurationSeconds != nil {
+			seconds += step.Repeat * *step.DurationSeconds
+		}
+		if step.DistanceKm != nil {
+			distance += float64(step.Repeat) * *step.DistanceKm
+			hasDistance = true
+		}
+	}
+
+	w.PlannedDurationMinutes = int(math.Round(float64(seconds) / 60))
+	w.PlannedDistanceKm = nil
+	if hasDistance {
+		distance = math.Round(distance*1000) / 1000
+		w.PlannedDistanceKm = &distance
+	}
+}
+
+// CreateWorkoutRequest defines a workout
+type CreateWorkoutRequest struct {
+	Name         string        `json:"name" validate:"required,max=100"`
+	ActivityType string        `json:"activity_type" validate:"required,min=2,max=50"`
+	Description  *string       `json:"description" validate:"omitempty,max=1000"`
+	Steps        []WorkoutStep `json:"steps" validate:"required,min=1,max=50,dive"`
+}
+
+// Sanitize cleans the free-text fields (see sanitize.Text)
+func (r *CreateWorkoutRequest) Sanitize() {
+	r.Name = sanitize.Text(r.Name)
+	r.Description = sanitize.TextPtr(r.Description)
+}
+
+// ScheduleWorkoutRequest assigns a workout to a day (YYYY-MM-DD)
+type ScheduleWorkoutRequest struct {
+	Date string `json:"date" validate:"required,datetime=2006-01-02"`
+}
+
+// PlannedWorkout is a workout assigned to a day. There is at most one per
+// workout and day.
+type PlannedWorkout struct {
+	ID        int64     `json:"id"`
+	WorkoutID int64     `json:"workout_id"`
+	UserID    int       `json:"user_id"`
+	PlannedOn time.Time `json:"planned_on"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WorkoutMatch is the activity that completed a planned workout: a live
+// activity of the workout's type on the planned day. ActivityID is its
+// public ID.
+type WorkoutMatch struct {
+	ActivityID      string  `json:"activity_id"`
+	DurationMinutes int     `json:"duration_minutes"`
+	DistanceKm      float64 `json:"distance_km"`
+}
+
+// WorkoutCompliance summarises how closely planned workouts were followed.
+// CompliancePct averages the sessions that are due (completed or missed); it
+// is nil when none are.
+type WorkoutCompliance struct {
+	Completed     int                 `json:"completed"`
+	Missed        int                 `json:"missed"`
+	Upcoming      int                 `json:"upcoming"`
+	CompliancePct *float64            `json:"compliance_pct"`
+	Sessions      []SessionCompliance `json:"sessions"`
+}
+
+// SessionCompliance is the compliance of one planned workout. DurationPct and
+// DistancePct compare what was done with the plan (100 is on target);
+// CompliancePct scores the session from 0 (missed) to 100.
+type SessionCompliance struct {
+	WorkoutID     int64         `json:"workout_id"`
+	PlannedOn     string        `json:"planned_on"` // YYYY-MM-DD
+	Status        string        `json:"status"`
+	Match         *WorkoutMatch `json:"match,omitempty"`
+	DurationPct   *float64      `json:"duration_pct,omitempty"`
+	DistancePct   *float64      `json:"distance_pct,omitempty"`
+	CompliancePct *float64      `json:"compliance_pct,omitempty"`
+}
@@ -0,0 +1,4 @@
+package di
+
+// ClockKey is the DI container key for the application clock.
+const ClockKey = "Clock"
@@ -0,0 +1,22 @@
+package di
+
+import (
+	"log"
+
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	"github.com/valentinesamuel/activelog/internal/platform/container"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+)
+
+// RegisterClock registers the application clock: the system clock, shifted
+// by CLOCK_OFFSET_HOURS if set. Tests register a *clock.Fake under ClockKey
+// instead to control the time.
+func RegisterClock(c *container.Container) {
+	container.RegisterTyped(c, ClockKey, func(c *container.Container) (clock.Clock, error) {
+		if config.Clock == nil || config.Clock.Offset == 0 {
+			return clock.Real{}, nil
+		}
+		log.Printf("Clock is offset by %v from the system clock", config.Clock.Offset)
+		return clock.NewOffset(config.Clock.Offset), nil
+	})
+}
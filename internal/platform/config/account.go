@@ -0,0 +1,22 @@
+package config
+
+import "time"
+
+// AccountConfigType holds account lifecycle configuration
+type AccountConfigType struct {
+	// DELETE /users/me confirmation tokens expire after DeletionTokenTTL; a
+	// confirmed deletion keeps the account disabled for DeletionGracePeriod
+	// before the worker purges it
+	DeletionTokenTTL    time.Duration
+	DeletionGracePeriod time.Duration
+}
+
+// Account is the loaded account configuration
+var Account *AccountConfigType
+
+func loadAccount() *AccountConfigType {
+	return &AccountConfigType{
+		DeletionTokenTTL:    time.Duration(GetEnvInt("ACCOUNT_DELETION_TOKEN_TTL_MINUTES", 15)) * time.Minute,
+		DeletionGracePeriod: time.Duration(GetEnvInt("ACCOUNT_DELETION_GRACE_DAYS", 30)) * 24 * time.Hour,
+	}
+}
@@ -0,0 +1,47 @@
+package config
+
+import "time"
+
+// ActivityConfigType holds activity domain configuration
+type ActivityConfigType struct {
+	DedupeEnabled bool
+	DedupeWindow  time.Duration
+	BulkMaxRows   int
+
+	// Near-duplicate detection (GET /activities/duplicates): activities of
+	// the same type whose time spans are within DuplicateSlack of overlapping
+	// and whose distances differ by at most DuplicateDistancePct percent
+	DuplicateSlack       time.Duration
+	DuplicateDistancePct int
+
+	// PrivacyZonesMax is how many privacy zones a user may have
+	PrivacyZonesMax int
+
+	// TypesStrict rejects activity types that aren't in the registry (the
+	// defaults and the user's own); otherwise they are stored as given
+	TypesStrict bool
+
+	// Partitioning of the activities table by activity_date (see jobs.PartitionPolicy)
+	PartitionMonthsAhead     int
+	PartitionRetentionMonths int
+}
+
+// Activity is the loaded activity configuration
+var Activity *ActivityConfigType
+
+func loadActivity() *ActivityConfigType {
+	return &ActivityConfigType{
+		DedupeEnabled: GetEnvBool("ACTIVITY_DEDUPE_ENABLED", true),
+		DedupeWindow:  time.Duration(GetEnvInt("ACTIVITY_DEDUPE_WINDOW_MINUTES", 10)) * time.Minute,
+		BulkMaxRows:   GetEnvInt("ACTIVITY_BULK_MAX_ROWS", 500),
+		TypesStrict:   GetEnvBool("ACTIVITY_TYPES_STRICT", true),
+
+		DuplicateSlack:       time.Duration(GetEnvInt("ACTIVITY_DUPLICATE_SLACK_MINUTES", 5)) * time.Minute,
+		DuplicateDistancePct: GetEnvInt("ACTIVITY_DUPLICATE_DISTANCE_PCT", 10),
+
+		PrivacyZonesMax: GetEnvInt("ACTIVITY_PRIVACY_ZONES_MAX", 10),
+
+		PartitionMonthsAhead:     GetEnvInt("ACTIVITY_PARTITION_MONTHS_AHEAD", 3),
+		PartitionRetentionMonths: GetEnvInt("ACTIVITY_PARTITION_RETENTION_MONTHS", 0),
+	}
+}
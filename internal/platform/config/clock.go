@@ -0,0 +1,31 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// ClockConfigType holds the configuration of the application clock
+type ClockConfigType struct {
+	// Offset shifts the time the app sees (streaks, weekly summaries,
+	// deletion grace periods, share link expiry) from the wall clock, so
+	// staging can be tested days ahead or behind. It must be 0 in production.
+	Offset time.Duration
+}
+
+// Clock is the loaded clock configuration
+var Clock *ClockConfigType
+
+func loadClock() *ClockConfigType {
+	return &ClockConfigType{
+		Offset: time.Duration(GetEnvInt("CLOCK_OFFSET_HOURS", 0)) * time.Hour,
+	}
+}
+
+// validateClock rejects a shifted clock in production
+func validateClock(clock *ClockConfigType, environment string) error {
+	if clock.Offset != 0 && environment == "production" {
+		return fmt.Errorf("CLOCK_OFFSET_HOURS must be 0 in production, got %v", clock.Offset)
+	}
+	return nil
+}
@@ -1,18 +1,57 @@
 package config
 
+import "time"
+
 // CommonConfig holds common application configuration
 type CommonConfig struct {
 	Port               int
+	GRPCPort           int
 	AppName            string
 	Environment        string
 	IsDevelopment      bool
 	EnableQueryLogging bool
 	Auth               AuthConfig
+
+	// Request body limits; multipart uploads get MaxUploadBytes
+	MaxBodyBytes   int64
+	MaxUploadBytes int64
+
+	RequestTimeouts RequestTimeoutConfig
+}
+
+// RequestTimeoutConfig holds the per-request context deadlines by route group.
+// Zero disables the deadline for that group.
+type RequestTimeoutConfig struct {
+	Default  time.Duration
+	Stats    time.Duration // aggregate statistics and summaries
+	Transfer time.Duration // uploads, imports, exports and batch writes
 }
 
 // AuthConfig holds authentication configuration
 type AuthConfig struct {
 	JWTSecret string
+
+	// Cookie sessions for browser clients (see auth.SetSessionCookies);
+	// bearer tokens are accepted either way
+	CookieSessions bool
+	CookieSecure   bool
+	CookieDomain   string
+	CookieSameSite string // lax, strict or none
+
+	// AdminEmails lists the users allowed on the admin routes
+	AdminEmails []string
+
+	Password PasswordHashConfig
+}
+
+// PasswordHashConfig selects how new password hashes are made (see pkg/password).
+// Stored hashes made with another scheme or lower costs are rehashed at login.
+type PasswordHashConfig struct {
+	Scheme            string // argon2id or bcrypt
+	BcryptCost        int
+	Argon2MemoryKB    int
+	Argon2Iterations  int
+	Argon2Parallelism int
 }
 
 // Common is the global common configuration instance
@@ -24,12 +63,32 @@ func loadCommon() *CommonConfig {
 
 	return &CommonConfig{
 		Port:               GetEnvInt("PORT", 8080),
+		GRPCPort:           GetEnvInt("GRPC_PORT", 9090),
 		AppName:            GetEnv("APP_NAME", "ActiveLog"),
 		Environment:        env,
 		IsDevelopment:      env == "development",
 		EnableQueryLogging: GetEnvBool("ENABLE_QUERY_LOGGING", true),
 		Auth: AuthConfig{
-			JWTSecret: GetEnv("JWT_SECRET", ""),
+			JWTSecret:      GetEnv("JWT_SECRET", ""),
+			CookieSessions: GetEnvBool("AUTH_COOKIE_SESSIONS", false),
+			CookieSecure:   GetEnvBool("AUTH_COOKIE_SECURE", true),
+			CookieDomain:   GetEnv("AUTH_COOKIE_DOMAIN", ""),
+			CookieSameSite: GetEnv("AUTH_COOKIE_SAMESITE", "lax"),
+			AdminEmails:    GetEnvList("AUTH_ADMIN_EMAILS"),
+			Password: PasswordHashConfig{
+				Scheme:            GetEnv("PASSWORD_HASH_SCHEME", "argon2id"),
+				BcryptCost:        GetEnvInt("PASSWORD_BCRYPT_COST", 12),
+				Argon2MemoryKB:    GetEnvInt("PASSWORD_ARGON2_MEMORY_KB", 64*1024),
+				Argon2Iterations:  GetEnvInt("PASSWORD_ARGON2_ITERATIONS", 2),
+				Argon2Parallelism: GetEnvInt("PASSWORD_ARGON2_PARALLELISM", 4),
+			},
+		},
+		MaxBodyBytes:   int64(GetEnvInt("MAX_BODY_BYTES", 1<<20)),
+		MaxUploadBytes: int64(GetEnvInt("MAX_UPLOAD_BYTES", 50<<20)),
+		RequestTimeouts: RequestTimeoutConfig{
+			Default:  time.Duration(GetEnvInt("REQUEST_TIMEOUT_MS", 10000)) * time.Millisecond,
+			Stats:    time.Duration(GetEnvInt("REQUEST_TIMEOUT_STATS_MS", 20000)) * time.Millisecond,
+			Transfer: time.Duration(GetEnvInt("REQUEST_TIMEOUT_TRANSFER_MS", 40000)) * time.Millisecond,
 		},
 	}
 }
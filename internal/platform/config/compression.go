@@ -0,0 +1,57 @@
+package config
+
+import (
+	"compress/gzip"
+	"fmt"
+
+	"github.com/andybalholm/brotli"
+)
+
+// CompressionConfigType holds the configuration of response compression
+type CompressionConfigType struct {
+	Enabled bool
+
+	// MinSizeBytes is the smallest response worth compressing; below it the
+	// framing and CPU cost outweigh the bytes saved
+	MinSizeBytes int
+
+	// Level is the gzip level, from 1 (fastest) to 9 (smallest)
+	Level int
+
+	// BrotliLevel is the brotli quality, from 0 (fastest) to 11 (smallest).
+	// The top levels are meant for static files, far too slow per request.
+	BrotliLevel int
+
+	// ContentTypes are the media types that are compressed, e.g.
+	// application/json; text/* matches every text type. Server-sent events
+	// are never compressed, as proxies may hold compressed events back.
+	ContentTypes []string
+}
+
+// Compression is the loaded compression configuration
+var Compression *CompressionConfigType
+
+func loadCompression() *CompressionConfigType {
+	contentTypes := GetEnvList("COMPRESSION_CONTENT_TYPES")
+	if contentTypes == nil {
+		contentTypes = []string{"application/json", "application/vnd.activelog.v2+json", "application/x-ndjson", "text/csv", "text/plain", "text/html", "application/javascript", "text/css"}
+	}
+	return &CompressionConfigType{
+		Enabled:      GetEnvBool("COMPRESSION_ENABLED", true),
+		MinSizeBytes: GetEnvInt("COMPRESSION_MIN_SIZE_BYTES", 1024),
+		Level:        GetEnvInt("COMPRESSION_LEVEL", 5),
+		BrotliLevel:  GetEnvInt("COMPRESSION_BROTLI_LEVEL", 4),
+		ContentTypes: contentTypes,
+	}
+}
+
+// validateCompression rejects levels gzip and brotli don't have
+func validateCompression(compression *CompressionConfigType) error {
+	if compression.Level < gzip.BestSpeed || compression.Level > gzip.BestCompression {
+		return fmt.Errorf("COMPRESSION_LEVEL must be between %d and %d, got %d", gzip.BestSpeed, gzip.BestCompression, compression.Level)
+	}
+	if compression.BrotliLevel < brotli.BestSpeed || compression.BrotliLevel > brotli.BestCompression {
+		return fmt.Errorf("COMPRESSION_BROTLI_LEVEL must be between %d and %d, got %d", brotli.BestSpeed, brotli.BestCompression, compression.BrotliLevel)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package config
+
+import "time"
+
+// GeocodingConfigType holds location name geocoding configuration
+type GeocodingConfigType struct {
+	// Provider is "nominatim", "google", or "none" to disable geocoding
+	Provider string
+
+	// NominatimURL is the Nominatim base URL; its usage policy requires an
+	// identifying UserAgent
+	NominatimURL string
+	UserAgent    string
+
+	// GoogleAPIKey is the Google Maps Geocoding API key
+	GoogleAPIKey string
+
+	// RatePerMinute caps provider requests per worker process (the public
+	// Nominatim instance allows one per second)
+	RatePerMinute int
+	Timeout       time.Duration
+}
+
+// Geocoding is the loaded geocoding configuration
+var Geocoding *GeocodingConfigType
+
+// Enabled reports whether location names should be geocoded
+func (c *GeocodingConfigType) Enabled() bool {
+	return c.Provider != "none"
+}
+
+func loadGeocoding() *GeocodingConfigType {
+	return &GeocodingConfigType{
+		Provider:      GetEnv("GEOCODING_PROVIDER", "none"),
+		NominatimURL:  GetEnv("GEOCODING_NOMINATIM_URL", "https://nominatim.openstreetmap.org"),
+		UserAgent:     GetEnv("GEOCODING_USER_AGENT", "activelog"),
+		GoogleAPIKey:  GetEnv("GEOCODING_GOOGLE_API_KEY", ""),
+		RatePerMinute: GetEnvInt("GEOCODING_RATE_PER_MINUTE", 60),
+		Timeout:       time.Duration(GetEnvInt("GEOCODING_TIMEOUT_MS", 5000)) * time.Millisecond,
+	}
+}
@@ -29,6 +29,7 @@ func LoadWithValidation() error {
 
 	// Load all config modules
 	Common = loadCommon()
+	Logging = loadLogging()
 	Database = loadDatabase()
 	Storage = loadStorage()
 	Email = loadEmail()
@@ -36,6 +37,23 @@ func LoadWithValidation() error {
 	RateLimit = loadRateLimit()
 	Queue = loadQueue()
 	Webhook = loadWebhook()
+	Activity = loadActivity()
+	Query = loadQuery()
+	Stats = loadStats()
+	Account = loadAccount()
+	Weather = loadWeather()
+	Geocoding = loadGeocoding()
+	Search = loadSearch()
+	OAuth = loadOAuth()
+	Clock = loadClock()
+	Security = loadSecurity(Common.Environment)
+	Compression = loadCompression()
 
-	return nil
+	if err := validateClock(Clock, Common.Environment); err != nil {
+		return err
+	}
+	if err := validateCompression(Compression); err != nil {
+		return err
+	}
+	return validateSecurity(Security, Common.Environment)
 }
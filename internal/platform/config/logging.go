@@ -0,0 +1,28 @@
+package config
+
+import "time"
+
+// LoggingConfigType holds request logging configuration
+type LoggingConfigType struct {
+	Level string // debug, info, warn or error; debug also logs request and response bodies
+
+	// Successful, fast requests are logged at SamplePercent; errors (4xx/5xx)
+	// and requests slower than SlowRequest are always logged
+	SamplePercent int
+	SlowRequest   time.Duration
+
+	// BodyMaxBytes caps how much of each body is logged in debug mode
+	BodyMaxBytes int
+}
+
+// Logging is the loaded logging configuration
+var Logging *LoggingConfigType
+
+func loadLogging() *LoggingConfigType {
+	return &LoggingConfigType{
+		Level:         GetEnv("LOG_LEVEL", "info"),
+		SamplePercent: GetEnvInt("LOG_SAMPLE_PERCENT", 100),
+		SlowRequest:   time.Duration(GetEnvInt("LOG_SLOW_REQUEST_MS", 1000)) * time.Millisecond,
+		BodyMaxBytes:  GetEnvInt("LOG_BODY_MAX_BYTES", 4096),
+	}
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+// OAuthConfigType holds social login configuration. A provider is enabled
+// when its client ID is set.
+type OAuthConfigType struct {
+	// CallbackBaseURL is the public API origin providers redirect back to;
+	// callbacks land on <CallbackBaseURL>/api/v1/auth/{provider}/callback
+	CallbackBaseURL string
+
+	// SuccessRedirectURL is where browsers go after a cookie session login
+	SuccessRedirectURL string
+
+	// StateTTL bounds how long a user may take at the provider
+	StateTTL time.Duration
+	Timeout  time.Duration
+
+	Google OAuthClientConfig
+	GitHub OAuthClientConfig
+	Apple  AppleOAuthConfig
+}
+
+// OAuthClientConfig holds the credentials of an OAuth2 client
+type OAuthClientConfig struct {
+	ClientID     string
+	ClientSecret string
+}
+
+// AppleOAuthConfig holds Sign in with Apple credentials. Apple has no static
+// client secret; it is a JWT signed with the private key (PEM, PKCS#8).
+type AppleOAuthConfig struct {
+	ClientID   string
+	TeamID     string
+	KeyID      string
+	PrivateKey string
+}
+
+// OAuth is the loaded social login configuration
+var OAuth *OAuthConfigType
+
+// CallbackURL returns the redirect URI registered with provider
+func (c *OAuthConfigType) CallbackURL(provider string) string {
+	return strings.TrimRight(c.CallbackBaseURL, "/") + "/api/v1/auth/" + provider + "/callback"
+}
+
+func loadOAuth() *OAuthConfigType {
+	return &OAuthConfigType{
+		CallbackBaseURL:    GetEnv("OAUTH_CALLBACK_BASE_URL", "http://localhost:8080"),
+		SuccessRedirectURL: GetEnv("OAUTH_SUCCESS_REDIRECT_URL", "/"),
+		StateTTL:           time.Duration(GetEnvInt("OAUTH_STATE_TTL_SECONDS", 600)) * time.Second,
+		Timeout:            time.Duration(GetEnvInt("OAUTH_TIMEOUT_MS", 10000)) * time.Millisecond,
+		Google: OAuthClientConfig{
+			ClientID:     GetEnv("OAUTH_GOOGLE_CLIENT_ID", ""),
+			ClientSecret: GetEnv("OAUTH_GOOGLE_CLIENT_SECRET", ""),
+		},
+		GitHub: OAuthClientConfig{
+			ClientID:     GetEnv("OAUTH_GITHUB_CLIENT_ID", ""),
+			ClientSecret: GetEnv("OAUTH_GITHUB_CLIENT_SECRET", ""),
+		},
+		Apple: AppleOAuthConfig{
+			ClientID: GetEnv("OAUTH_APPLE_CLIENT_ID", ""),
+			TeamID:   GetEnv("OAUTH_APPLE_TEAM_ID", ""),
+			KeyID:    GetEnv("OAUTH_APPLE_KEY_ID", ""),
+			// Env files can't hold newlines, so they may be written as \n
+			PrivateKey: strings.ReplaceAll(GetEnv("OAUTH_APPLE_PRIVATE_KEY", ""), `\n`, "\n"),
+		},
+	}
+}
@@ -0,0 +1,37 @@
+package config
+
+import "time"
+
+// QueryConfigType holds the limits on dynamic list queries (see query.CostLimits)
+type QueryConfigType struct {
+	// CostBudget is the highest estimated cost a list query may have; 0 disables it
+	CostBudget int
+
+	// CostDegrade shrinks the page of an over-budget query instead of
+	// rejecting it, down to CostMinLimit rows
+	CostDegrade  bool
+	CostMinLimit int
+
+	// Hard caps rejected regardless of the budget
+	MaxJoins    int
+	MaxInValues int
+
+	// StatementTimeout cancels a list query at the database once it runs
+	// this long; 0 disables it
+	StatementTimeout time.Duration
+}
+
+// Query is the loaded query configuration
+var Query *QueryConfigType
+
+func loadQuery() *QueryConfigType {
+	return &QueryConfigType{
+		CostBudget:   GetEnvInt("QUERY_COST_BUDGET", 200),
+		CostDegrade:  GetEnvBool("QUERY_COST_DEGRADE", true),
+		CostMinLimit: GetEnvInt("QUERY_COST_MIN_LIMIT", 10),
+		MaxJoins:     GetEnvInt("QUERY_MAX_JOINS", 6),
+		MaxInValues:  GetEnvInt("QUERY_MAX_IN_VALUES", 100),
+
+		StatementTimeout: time.Duration(GetEnvInt("QUERY_STATEMENT_TIMEOUT_MS", 5000)) * time.Millisecond,
+	}
+}
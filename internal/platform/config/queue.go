@@ -1,13 +1,119 @@
 package config
 
+import "time"
+
+// QueueTierConfig configures one worker queue
+type QueueTierConfig struct {
+	Concurrency int // workers this queue adds to the shared pool
+	Priority    int // weight (or rank when StrictPriority is set)
+}
+
 type QueueConfigType struct {
 	Provider string
+
+	// StrictPriority always drains higher-priority queues first instead of
+	// picking queues at random weighted by Priority
+	StrictPriority bool
+
+	Critical QueueTierConfig
+	Default  QueueTierConfig
+	Low      QueueTierConfig
+
+	// UserConcurrency is how many jobs of one user a worker runs at once;
+	// the user's other jobs are put back in their queue. 0 disables the limit.
+	UserConcurrency int
+
+	// WorkerHTTPAddr is where the worker serves /health and /metrics (empty
+	// disables them)
+	WorkerHTTPAddr string
+
+	// HeartbeatInterval is how often each worker records its status for the
+	// API's worker list; a worker that missed three is reported dead
+	HeartbeatInterval time.Duration
+
+	// MemoryJournalDir is where the memory provider journals its jobs so they
+	// survive restarts (api.journal, worker.journal); empty keeps them in
+	// memory only
+	MemoryJournalDir string
+
+	NATS QueueNATSConfig
+	SQS  QueueSQSConfig
+}
+
+// QueueNATSConfig configures the NATS JetStream queue provider
+type QueueNATSConfig struct {
+	URL    string
+	Stream string
+
+	// MaxDeliver is how many times a job is attempted before it is dropped
+	MaxDeliver int
+
+	// AckWait is how long a worker has to ack a job before it is redelivered;
+	// workers extend it while a job runs
+	AckWait time.Duration
+}
+
+// QueueSQSConfig configures the AWS SQS queue provider. Each worker queue is
+// an SQS queue named QueuePrefix + queue name, e.g. activelog-critical.
+type QueueSQSConfig struct {
+	Region   string
+	Endpoint string // For LocalStack
+
+	QueuePrefix string
+
+	// FIFO uses FIFO queues (name suffix .fifo), which run a user's jobs in
+	// the order they were enqueued
+	FIFO bool
+
+	// CreateQueues creates missing queues and their dead-letter queues at
+	// startup, for LocalStack and development; in AWS they are provisioned
+	CreateQueues bool
+
+	// VisibilityTimeout is how long a received job stays hidden from other
+	// workers; workers extend it while a job runs
+	VisibilityTimeout time.Duration
+
+	// MaxReceiveCount is how many times a job is attempted before it moves to
+	// the dead-letter queue
+	MaxReceiveCount int
 }
 
 var Queue *QueueConfigType
 
 func loadQueue() *QueueConfigType {
 	return &QueueConfigType{
-		Provider: GetEnv("QUEUE_PROVIDER", ""),
+		Provider:       GetEnv("QUEUE_PROVIDER", ""),
+		StrictPriority: GetEnvBool("QUEUE_STRICT_PRIORITY", false),
+		Critical: QueueTierConfig{
+			Concurrency: GetEnvInt("QUEUE_CRITICAL_CONCURRENCY", 5),
+			Priority:    GetEnvInt("QUEUE_CRITICAL_PRIORITY", 6),
+		},
+		Default: QueueTierConfig{
+			Concurrency: GetEnvInt("QUEUE_DEFAULT_CONCURRENCY", 3),
+			Priority:    GetEnvInt("QUEUE_DEFAULT_PRIORITY", 3),
+		},
+		Low: QueueTierConfig{
+			Concurrency: GetEnvInt("QUEUE_LOW_CONCURRENCY", 2),
+			Priority:    GetEnvInt("QUEUE_LOW_PRIORITY", 1),
+		},
+		UserConcurrency:   GetEnvInt("QUEUE_USER_CONCURRENCY", 3),
+		WorkerHTTPAddr:    GetEnv("QUEUE_WORKER_HTTP_ADDR", GetEnv("QUEUE_WORKER_METRICS_ADDR", ":9091")),
+		HeartbeatInterval: time.Duration(GetEnvInt("QUEUE_WORKER_HEARTBEAT_SECONDS", 15)) * time.Second,
+		MemoryJournalDir:  GetEnv("QUEUE_MEMORY_JOURNAL_DIR", ""),
+		NATS: QueueNATSConfig{
+			URL:        GetEnv("QUEUE_NATS_URL", GetEnv("NATS_URL", "nats://localhost:4222")),
+			Stream:     GetEnv("QUEUE_NATS_STREAM", "ACTIVELOG_JOBS"),
+			MaxDeliver: GetEnvInt("QUEUE_NATS_MAX_DELIVER", 4),
+			AckWait:    time.Duration(GetEnvInt("QUEUE_NATS_ACK_WAIT_SECONDS", 60)) * time.Second,
+		},
+		SQS: QueueSQSConfig{
+			Region:            GetEnv("QUEUE_SQS_REGION", GetEnv("AWS_REGION", "us-east-1")),
+			Endpoint:          GetEnv("QUEUE_SQS_ENDPOINT", ""),
+			QueuePrefix:       GetEnv("QUEUE_SQS_QUEUE_PREFIX", "activelog-"),
+			FIFO:              GetEnvBool("QUEUE_SQS_FIFO", false),
+			CreateQueues:      GetEnvBool("QUEUE_SQS_CREATE_QUEUES", false),
+			VisibilityTimeout: time.Duration(GetEnvInt("QUEUE_SQS_VISIBILITY_TIMEOUT_SECONDS", 60)) * time.Second,
+			MaxReceiveCount:   GetEnvInt("QUEUE_SQS_MAX_RECEIVE_COUNT", 4),
+		},
 	}
 }
@@ -20,16 +20,55 @@ type EnvVar struct {
 var Schema = []EnvVar{
 	// Common
 	{Key: "PORT", Required: false, DefaultValue: "8080", Type: "int"},
+	{Key: "GRPC_PORT", Required: false, DefaultValue: "9090", Type: "int"},
 	{Key: "APP_NAME", Required: false, DefaultValue: "ActiveLog", Type: "string"},
 	{Key: "NODE_ENV", Required: false, DefaultValue: "development", Type: "string", ValidValues: []string{"development", "staging", "production"}},
 	{Key: "JWT_SECRET", Required: true, Type: "string"},
+	{Key: "AUTH_COOKIE_SESSIONS", Required: false, DefaultValue: "false", Type: "bool"},
+	{Key: "AUTH_COOKIE_SECURE", Required: false, DefaultValue: "true", Type: "bool"},
+	{Key: "AUTH_COOKIE_DOMAIN", Required: false, DefaultValue: "", Type: "string"},
+	{Key: "AUTH_COOKIE_SAMESITE", Required: false, DefaultValue: "lax", Type: "string", ValidValues: []string{"lax", "strict", "none"}},
+	{Key: "AUTH_ADMIN_EMAILS", Required: false, DefaultValue: "", Type: "string"},
+	{Key: "PASSWORD_HASH_SCHEME", Required: false, DefaultValue: "argon2id", Type: "string", ValidValues: []string{"argon2id", "bcrypt"}},
+	{Key: "PASSWORD_BCRYPT_COST", Required: false, DefaultValue: "12", Type: "int"},
+	{Key: "PASSWORD_ARGON2_MEMORY_KB", Required: false, DefaultValue: "65536", Type: "int"},
+	{Key: "PASSWORD_ARGON2_ITERATIONS", Required: false, DefaultValue: "2", Type: "int"},
+	{Key: "PASSWORD_ARGON2_PARALLELISM", Required: false, DefaultValue: "4", Type: "int"},
 	{Key: "ENABLE_QUERY_LOGGING", Required: false, DefaultValue: "true", Type: "bool"},
+	{Key: "MAX_BODY_BYTES", Required: false, DefaultValue: "1048576", Type: "int"},
+	{Key: "MAX_UPLOAD_BYTES", Required: false, DefaultValue: "52428800", Type: "int"},
+	{Key: "REQUEST_TIMEOUT_MS", Required: false, DefaultValue: "10000", Type: "int"},
+	{Key: "REQUEST_TIMEOUT_STATS_MS", Required: false, DefaultValue: "20000", Type: "int"},
+	{Key: "REQUEST_TIMEOUT_TRANSFER_MS", Required: false, DefaultValue: "40000", Type: "int"},
+	{Key: "CLOCK_OFFSET_HOURS", Required: false, DefaultValue: "0", Type: "int"},
+
+	// Logging
+	{Key: "LOG_LEVEL", Required: false, DefaultValue: "info", Type: "string", ValidValues: []string{"debug", "info", "warn", "error"}},
+	{Key: "LOG_SAMPLE_PERCENT", Required: false, DefaultValue: "100", Type: "int"},
+	{Key: "LOG_SLOW_REQUEST_MS", Required: false, DefaultValue: "1000", Type: "int"},
+	{Key: "LOG_BODY_MAX_BYTES", Required: false, DefaultValue: "4096", Type: "int"},
 
 	// Database
 	{Key: "DATABASE_URL", Required: true, Type: "string"},
+	{Key: "DATABASE_MAX_CONNECTIONS", Required: false, DefaultValue: "25", Type: "int"},
+	{Key: "DATABASE_MIN_CONNECTIONS", Required: false, DefaultValue: "5", Type: "int"},
+	{Key: "DATABASE_MAX_CONN_LIFETIME_MINUTES", Required: false, DefaultValue: "5", Type: "int"},
+	{Key: "DATABASE_MAX_CONN_IDLE_MINUTES", Required: false, DefaultValue: "2", Type: "int"},
+	{Key: "DATABASE_STATEMENT_CACHE_CAPACITY", Required: false, DefaultValue: "512", Type: "int"},
+	{Key: "DATABASE_QUERY_CACHE_SIZE", Required: false, DefaultValue: "128", Type: "int"},
+	{Key: "DATABASE_SLOW_QUERY_MS", Required: false, DefaultValue: "100", Type: "int"},
+	{Key: "DATABASE_TX_RETRY_MAX_ATTEMPTS", Required: false, DefaultValue: "3", Type: "int"},
+	{Key: "DATABASE_TX_RETRY_BASE_DELAY_MS", Required: false, DefaultValue: "50", Type: "int"},
+	{Key: "DATABASE_TX_RETRY_MAX_DELAY_MS", Required: false, DefaultValue: "1000", Type: "int"},
+
+	// Cache
+	{Key: "CACHE_PROVIDER", Required: false, DefaultValue: "redis", Type: "string", ValidValues: []string{"redis", "memory"}},
 
 	// Storage
 	{Key: "STORAGE_PROVIDER", Required: false, DefaultValue: "s3", Type: "string", ValidValues: []string{"s3", "local", "supabase", "azure"}},
+	{Key: "STORAGE_LOCAL_DIR", Required: false, DefaultValue: "./data/storage", Type: "string"},
+	{Key: "STORAGE_FILES_BASE_URL", Required: false, DefaultValue: "http://localhost:8080", Type: "string"},
+	{Key: "STORAGE_FILE_URL_TTL_SECONDS", Required: false, DefaultValue: "900", Type: "int"},
 
 	// Email
 	{Key: "EMAIL_PROVIDER", Required: false, DefaultValue: "noop", Type: "string", ValidValues: []string{"smtp", "noop"}},
@@ -45,6 +84,118 @@ var Schema = []EnvVar{
 	{Key: "WEBHOOK_RETRY_POLL_SECONDS", Required: false, DefaultValue: "30", Type: "int"},
 	{Key: "NATS_URL", Required: false, DefaultValue: "nats://localhost:4222", Type: "string"},
 
+	// Queue
+	{Key: "QUEUE_PROVIDER", Required: false, DefaultValue: "", Type: "string", ValidValues: []string{"memory", "asynq", "nats", "sqs"}},
+	{Key: "QUEUE_STRICT_PRIORITY", Required: false, DefaultValue: "false", Type: "bool"},
+	{Key: "QUEUE_CRITICAL_CONCURRENCY", Required: false, DefaultValue: "5", Type: "int"},
+	{Key: "QUEUE_CRITICAL_PRIORITY", Required: false, DefaultValue: "6", Type: "int"},
+	{Key: "QUEUE_DEFAULT_CONCURRENCY", Required: false, DefaultValue: "3", Type: "int"},
+	{Key: "QUEUE_DEFAULT_PRIORITY", Required: false, DefaultValue: "3", Type: "int"},
+	{Key: "QUEUE_LOW_CONCURRENCY", Required: false, DefaultValue: "2", Type: "int"},
+	{Key: "QUEUE_LOW_PRIORITY", Required: false, DefaultValue: "1", Type: "int"},
+	{Key: "QUEUE_USER_CONCURRENCY", Required: false, DefaultValue: "3", Type: "int"},
+	{Key: "QUEUE_WORKER_HTTP_ADDR", Required: false, DefaultValue: ":9091", Type: "string"},
+	{Key: "QUEUE_WORKER_METRICS_ADDR", Required: false, DefaultValue: "", Type: "string"},
+	{Key: "QUEUE_WORKER_HEARTBEAT_SECONDS", Required: false, DefaultValue: "15", Type: "int"},
+	{Key: "QUEUE_MEMORY_JOURNAL_DIR", Required: false, DefaultValue: "", Type: "string"},
+	{Key: "QUEUE_NATS_URL", Required: false, DefaultValue: "", Type: "string"},
+	{Key: "QUEUE_NATS_STREAM", Required: false, DefaultValue: "ACTIVELOG_JOBS", Type: "string"},
+	{Key: "QUEUE_NATS_MAX_DELIVER", Required: false, DefaultValue: "4", Type: "int"},
+	{Key: "QUEUE_NATS_ACK_WAIT_SECONDS", Required: false, DefaultValue: "60", Type: "int"},
+	{Key: "QUEUE_SQS_REGION", Required: false, DefaultValue: "", Type: "string"},
+	{Key: "QUEUE_SQS_ENDPOINT", Required: false, DefaultValue: "", Type: "string"},
+	{Key: "QUEUE_SQS_QUEUE_PREFIX", Required: false, DefaultValue: "activelog-", Type: "string"},
+	{Key: "QUEUE_SQS_FIFO", Required: false, DefaultValue: "false", Type: "bool"},
+	{Key: "QUEUE_SQS_CREATE_QUEUES", Required: false, DefaultValue: "false", Type: "bool"},
+	{Key: "QUEUE_SQS_VISIBILITY_TIMEOUT_SECONDS", Required: false, DefaultValue: "60", Type: "int"},
+	{Key: "QUEUE_SQS_MAX_RECEIVE_COUNT", Required: false, DefaultValue: "4", Type: "int"},
+
+	// Activity
+	{Key: "ACTIVITY_DEDUPE_ENABLED", Required: false, DefaultValue: "true", Type: "bool"},
+	{Key: "ACTIVITY_DEDUPE_WINDOW_MINUTES", Required: false, DefaultValue: "10", Type: "int"},
+	{Key: "ACTIVITY_BULK_MAX_ROWS", Required: false, DefaultValue: "500", Type: "int"},
+	{Key: "ACTIVITY_DUPLICATE_SLACK_MINUTES", Required: false, DefaultValue: "5", Type: "int"},
+	{Key: "ACTIVITY_DUPLICATE_DISTANCE_PCT", Required: false, DefaultValue: "10", Type: "int"},
+	{Key: "ACTIVITY_PRIVACY_ZONES_MAX", Required: false, DefaultValue: "10", Type: "int"},
+	{Key: "ACTIVITY_TYPES_STRICT", Required: false, DefaultValue: "true", Type: "bool"},
+	{Key: "ACTIVITY_PARTITION_MONTHS_AHEAD", Required: false, DefaultValue: "3", Type: "int"},
+	{Key: "ACTIVITY_PARTITION_RETENTION_MONTHS", Required: false, DefaultValue: "0", Type: "int"},
+
+	// Query limits
+	{Key: "QUERY_COST_BUDGET", Required: false, DefaultValue: "200", Type: "int"},
+	{Key: "QUERY_COST_DEGRADE", Required: false, DefaultValue: "true", Type: "bool"},
+	{Key: "QUERY_COST_MIN_LIMIT", Required: false, DefaultValue: "10", Type: "int"},
+	{Key: "QUERY_MAX_JOINS", Required: false, DefaultValue: "6", Type: "int"},
+	{Key: "QUERY_MAX_IN_VALUES", Required: false, DefaultValue: "100", Type: "int"},
+	{Key: "QUERY_STATEMENT_TIMEOUT_MS", Required: false, DefaultValue: "5000", Type: "int"},
+
+	// Stats
+	{Key: "STATS_READ_FROM_SUMMARIES", Required: false, DefaultValue: "false", Type: "bool"},
+	{Key: "STATS_SUMMARY_MAX_STALENESS_MINUTES", Required: false, DefaultValue: "120", Type: "int"},
+
+	// Account
+	{Key: "ACCOUNT_DELETION_TOKEN_TTL_MINUTES", Required: false, DefaultValue: "15", Type: "int"},
+	{Key: "ACCOUNT_DELETION_GRACE_DAYS", Required: false, DefaultValue: "30", Type: "int"},
+
+	// Weather enrichment
+	{Key: "WEATHER_PROVIDER", Required: false, DefaultValue: "none", Type: "string", ValidValues: []string{"openmeteo", "none"}},
+	{Key: "WEATHER_OPENMETEO_ARCHIVE_URL", Required: false, DefaultValue: "https://archive-api.open-meteo.com/v1/archive", Type: "string"},
+	{Key: "WEATHER_OPENMETEO_FORECAST_URL", Required: false, DefaultValue: "https://api.open-meteo.com/v1/forecast", Type: "string"},
+	{Key: "WEATHER_API_KEY", Required: false, DefaultValue: "", Type: "string"},
+	{Key: "WEATHER_RATE_PER_MINUTE", Required: false, DefaultValue: "60", Type: "int"},
+	{Key: "WEATHER_TIMEOUT_MS", Required: false, DefaultValue: "5000", Type: "int"},
+
+	// Geocoding
+	{Key: "GEOCODING_PROVIDER", Required: false, DefaultValue: "none", Type: "string", ValidValues: []string{"nominatim", "google", "none"}},
+	{Key: "GEOCODING_NOMINATIM_URL", Required: false, DefaultValue: "https://nominatim.openstreetmap.org", Type: "string"},
+	{Key: "GEOCODING_USER_AGENT", Required: false, DefaultValue: "activelog", Type: "string"},
+	{Key: "GEOCODING_GOOGLE_API_KEY", Required: false, DefaultValue: "", Type: "string"},
+	{Key: "GEOCODING_RATE_PER_MINUTE", Required: false, DefaultValue: "60", Type: "int"},
+	{Key: "GEOCODING_TIMEOUT_MS", Required: false, DefaultValue: "5000", Type: "int"},
+
+	// Search
+	{Key: "SEARCH_PROVIDER", Required: false, DefaultValue: "none", Type: "string", ValidValues: []string{"memory", "elasticsearch", "none"}},
+	{Key: "SEARCH_ELASTICSEARCH_URL", Required: false, DefaultValue: "http://localhost:9200", Type: "string"},
+	{Key: "SEARCH_ELASTICSEARCH_INDEX", Required: false, DefaultValue: "activities", Type: "string"},
+	{Key: "SEARCH_ELASTICSEARCH_API_KEY", Required: false, DefaultValue: "", Type: "string"},
+	{Key: "SEARCH_MAX_RESULTS", Required: false, DefaultValue: "100", Type: "int"},
+	{Key: "SEARCH_TIMEOUT_MS", Required: false, DefaultValue: "3000", Type: "int"},
+
+	// Social login
+	{Key: "OAUTH_CALLBACK_BASE_URL", Required: false, DefaultValue: "http://localhost:8080", Type: "string"},
+	{Key: "OAUTH_SUCCESS_REDIRECT_URL", Required: false, DefaultValue: "/", Type: "string"},
+	{Key: "OAUTH_STATE_TTL_SECONDS", Required: false, DefaultValue: "600", Type: "int"},
+	{Key: "OAUTH_TIMEOUT_MS", Required: false, DefaultValue: "10000", Type: "int"},
+	{Key: "OAUTH_GOOGLE_CLIENT_ID", Required: false, DefaultValue: "", Type: "string"},
+	{Key: "OAUTH_GOOGLE_CLIENT_SECRET", Required: false, DefaultValue: "", Type: "string"},
+	{Key: "OAUTH_GITHUB_CLIENT_ID", Required: false, DefaultValue: "", Type: "string"},
+	{Key: "OAUTH_GITHUB_CLIENT_SECRET", Required: false, DefaultValue: "", Type: "string"},
+	{Key: "OAUTH_APPLE_CLIENT_ID", Required: false, DefaultValue: "", Type: "string"},
+	{Key: "OAUTH_APPLE_TEAM_ID", Required: false, DefaultValue: "", Type: "string"},
+	{Key: "OAUTH_APPLE_KEY_ID", Required: false, DefaultValue: "", Type: "string"},
+	{Key: "OAUTH_APPLE_PRIVATE_KEY", Required: false, DefaultValue: "", Type: "string"},
+
+	// CORS and security headers; empty values take the NODE_ENV profile
+	{Key: "CORS_ALLOWED_ORIGINS", Required: false, DefaultValue: "", Type: "string"},
+	{Key: "CORS_ALLOWED_METHODS", Required: false, DefaultValue: "", Type: "string"},
+	{Key: "CORS_ALLOWED_HEADERS", Required: false, DefaultValue: "", Type: "string"},
+	{Key: "CORS_EXPOSED_HEADERS", Required: false, DefaultValue: "", Type: "string"},
+	{Key: "CORS_ALLOW_CREDENTIALS", Required: false, DefaultValue: "true", Type: "bool"},
+	{Key: "CORS_MAX_AGE_SECONDS", Required: false, DefaultValue: "600", Type: "int"},
+	{Key: "SECURITY_CSP", Required: false, DefaultValue: "", Type: "string"},
+	{Key: "SECURITY_DOCS_CSP", Required: false, DefaultValue: "", Type: "string"},
+	{Key: "SECURITY_FRAME_OPTIONS", Required: false, DefaultValue: "DENY", Type: "string", ValidValues: []string{"DENY", "SAMEORIGIN"}},
+	{Key: "SECURITY_HSTS_MAX_AGE_SECONDS", Required: false, DefaultValue: "", Type: "int"},
+	{Key: "SECURITY_HSTS_INCLUDE_SUBDOMAINS", Required: false, DefaultValue: "", Type: "bool"},
+	{Key: "SECURITY_HSTS_PRELOAD", Required: false, DefaultValue: "false", Type: "bool"},
+
+	// Response compression
+	{Key: "COMPRESSION_ENABLED", Required: false, DefaultValue: "true", Type: "bool"},
+	{Key: "COMPRESSION_MIN_SIZE_BYTES", Required: false, DefaultValue: "1024", Type: "int"},
+	{Key: "COMPRESSION_LEVEL", Required: false, DefaultValue: "5", Type: "int"},
+	{Key: "COMPRESSION_BROTLI_LEVEL", Required: false, DefaultValue: "4", Type: "int"},
+	{Key: "COMPRESSION_CONTENT_TYPES", Required: false, DefaultValue: "", Type: "string"},
+
 	// AWS S3
 	{Key: "AWS_S3_BUCKET", Required: false, DefaultValue: "", Type: "string"},
 	{Key: "AWS_REGION", Required: false, DefaultValue: "us-east-1", Type: "string"},
@@ -168,3 +319,15 @@ func GetEnvBool(key string, defaultValue bool) bool {
 	}
 	return defaultValue
 }
+
+// GetEnvList retrieves a comma-separated environment variable as a list,
+// trimming spaces and dropping empty items
+func GetEnvList(key string) []string {
+	var list []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,39 @@
+package config
+
+import "time"
+
+// SearchConfigType holds full-text activity search configuration
+type SearchConfigType struct {
+	// Provider is "memory" (an index embedded in the API process, rebuilt at
+	// startup), "elasticsearch", or "none" to disable search
+	Provider string
+
+	// ElasticsearchURL is the cluster URL; ElasticsearchIndex the index the
+	// activities are stored in, created on the first reindex
+	ElasticsearchURL    string
+	ElasticsearchIndex  string
+	ElasticsearchAPIKey string
+
+	// MaxResults caps the limit of a search request
+	MaxResults int
+	Timeout    time.Duration
+}
+
+// Search is the loaded search configuration
+var Search *SearchConfigType
+
+// Enabled reports whether activities are indexed and searchable
+func (c *SearchConfigType) Enabled() bool {
+	return c.Provider != "none"
+}
+
+func loadSearch() *SearchConfigType {
+	return &SearchConfigType{
+		Provider:            GetEnv("SEARCH_PROVIDER", "none"),
+		ElasticsearchURL:    GetEnv("SEARCH_ELASTICSEARCH_URL", "http://localhost:9200"),
+		ElasticsearchIndex:  GetEnv("SEARCH_ELASTICSEARCH_INDEX", "activities"),
+		ElasticsearchAPIKey: GetEnv("SEARCH_ELASTICSEARCH_API_KEY", ""),
+		MaxResults:          GetEnvInt("SEARCH_MAX_RESULTS", 100),
+		Timeout:             time.Duration(GetEnvInt("SEARCH_TIMEOUT_MS", 3000)) * time.Millisecond,
+	}
+}
@@ -0,0 +1,208 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SecurityConfigType holds the CORS policy and the security headers of every
+// response
+type SecurityConfigType struct {
+	CORS    CORSConfig
+	Headers SecurityHeadersConfig
+}
+
+// CORSConfig holds which browser origins may call the API
+type CORSConfig struct {
+	// AllowedOrigins are exact origins (https://app.example.com), wildcard
+	// subdomains (https://*.example.com matches any subdomain, not
+	// example.com itself) or * for any origin
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+
+	// MaxAge is how long browsers may cache a preflight response
+	MaxAge time.Duration
+}
+
+// SecurityHeadersConfig holds the security headers set on every response
+type SecurityHeadersConfig struct {
+	// CSP is the Content-Security-Policy of API responses; DocsCSP that of
+	// the Swagger UI, which needs inline scripts and styles
+	CSP     string
+	DocsCSP string
+
+	FrameOptions string // X-Frame-Options: DENY or SAMEORIGIN
+
+	// HSTSMaxAge enables Strict-Transport-Security when above 0; only
+	// enable it when the API is served over HTTPS
+	HSTSMaxAge            time.Duration
+	HSTSIncludeSubdomains bool
+	HSTSPreload           bool
+}
+
+// Security is the loaded security configuration
+var Security *SecurityConfigType
+
+// securityProfiles are the defaults of each NODE_ENV, which the CORS_* and
+// SECURITY_* variables override. Staging and production allow no origins
+// until CORS_ALLOWED_ORIGINS lists the frontends.
+var securityProfiles = map[string]SecurityConfigType{
+	"development": {
+		CORS: corsDefaults([]string{"http://localhost:3000", "http://localhost:5173"}),
+		Headers: SecurityHeadersConfig{
+			CSP:          "default-src 'self'",
+			DocsCSP:      "default-src 'self'; script-src 'self' 'unsafe-inline'; style-src 'self' 'unsafe-inline'; img-src 'self' data:",
+			FrameOptions: "DENY",
+		},
+	},
+	"staging": {
+		CORS: corsDefaults(nil),
+		Headers: SecurityHeadersConfig{
+			CSP:          "default-src 'self'",
+			DocsCSP:      "default-src 'self'; script-src 'self' 'unsafe-inline'; style-src 'self' 'unsafe-inline'; img-src 'self' data:",
+			FrameOptions: "DENY",
+			HSTSMaxAge:   24 * time.Hour,
+		},
+	},
+	"production": {
+		CORS: corsDefaults(nil),
+		Headers: SecurityHeadersConfig{
+			CSP:                   "default-src 'self'",
+			DocsCSP:               "default-src 'self'; script-src 'self' 'unsafe-inline'; style-src 'self' 'unsafe-inline'; img-src 'self' data:",
+			FrameOptions:          "DENY",
+			HSTSMaxAge:            365 * 24 * time.Hour,
+			HSTSIncludeSubdomains: true,
+		},
+	},
+}
+
+func corsDefaults(origins []string) CORSConfig {
+	return CORSConfig{
+		AllowedOrigins:   origins,
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Content-Type", "Authorization", "X-CSRF-Token", "X-Request-ID", "If-None-Match", "If-Match"},
+		ExposedHeaders:   []string{"X-Request-ID", "ETag"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	}
+}
+
+func loadSecurity(environment string) *SecurityConfigType {
+	profile, ok := securityProfiles[environment]
+	if !ok {
+		profile = securityProfiles["development"]
+	}
+
+	cfg := &SecurityConfigType{
+		CORS: CORSConfig{
+			AllowedOrigins:   getEnvListOr("CORS_ALLOWED_ORIGINS", profile.CORS.AllowedOrigins),
+			AllowedMethods:   getEnvListOr("CORS_ALLOWED_METHODS", profile.CORS.AllowedMethods),
+			AllowedHeaders:   getEnvListOr("CORS_ALLOWED_HEADERS", profile.CORS.AllowedHeaders),
+			ExposedHeaders:   getEnvListOr("CORS_EXPOSED_HEADERS", profile.CORS.ExposedHeaders),
+			AllowCredentials: GetEnvBool("CORS_ALLOW_CREDENTIALS", profile.CORS.AllowCredentials),
+			MaxAge:           time.Duration(GetEnvInt("CORS_MAX_AGE_SECONDS", int(profile.CORS.MaxAge/time.Second))) * time.Second,
+		},
+		Headers: SecurityHeadersConfig{
+			CSP:                   GetEnv("SECURITY_CSP", profile.Headers.CSP),
+			DocsCSP:               GetEnv("SECURITY_DOCS_CSP", profile.Headers.DocsCSP),
+			FrameOptions:          GetEnv("SECURITY_FRAME_OPTIONS", profile.Headers.FrameOptions),
+			HSTSMaxAge:            time.Duration(GetEnvInt("SECURITY_HSTS_MAX_AGE_SECONDS", int(profile.Headers.HSTSMaxAge/time.Second))) * time.Second,
+			HSTSIncludeSubdomains: GetEnvBool("SECURITY_HSTS_INCLUDE_SUBDOMAINS", profile.Headers.HSTSIncludeSubdomains),
+			HSTSPreload:           GetEnvBool("SECURITY_HSTS_PRELOAD", profile.Headers.HSTSPreload),
+		},
+	}
+	for i, method := range cfg.CORS.AllowedMethods {
+		cfg.CORS.AllowedMethods[i] = strings.ToUpper(method)
+	}
+	return cfg
+}
+
+// getEnvListOr is GetEnvList, defaulting to a copy of defaults when unset
+func getEnvListOr(key string, defaults []string) []string {
+	if list := GetEnvList(key); list != nil {
+		return list
+	}
+	return append([]string(nil), defaults...)
+}
+
+// AllowsOrigin reports whether a request from origin may read responses
+func (c *CORSConfig) AllowsOrigin(origin string) bool {
+	origin = strings.ToLower(origin)
+	for _, allowed := range c.AllowedOrigins {
+		allowed = strings.ToLower(allowed)
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+		// https://*.example.com: same scheme, a host ending in .example.com
+		scheme, host, ok := strings.Cut(allowed, "://*.")
+		if !ok || !strings.HasPrefix(origin, scheme+"://") {
+			continue
+		}
+		if subdomain, found := strings.CutSuffix(strings.TrimPrefix(origin, scheme+"://"), "."+host); found && subdomain != "" && !strings.Contains(subdomain, "/") {
+			return true
+		}
+	}
+	return false
+}
+
+// validateSecurity rejects malformed origins in every environment, and in
+// production the combinations that weaken the API: any-origin CORS, origins
+// over plain HTTP, wildcards without a registrable domain, no HSTS and no CSP
+func validateSecurity(cfg *SecurityConfigType, environment string) error {
+	var errs ValidationErrors
+	production := environment == "production"
+
+	for _, origin := range cfg.CORS.AllowedOrigins {
+		if origin == "*" {
+			if cfg.CORS.AllowCredentials {
+				errs = append(errs, ValidationError{Key: "CORS_ALLOWED_ORIGINS", Message: "* can't be combined with CORS_ALLOW_CREDENTIALS=true"})
+			}
+			if production {
+				errs = append(errs, ValidationError{Key: "CORS_ALLOWED_ORIGINS", Message: "* is not allowed in production; list the origins"})
+			}
+			continue
+		}
+
+		u, err := url.Parse(strings.Replace(origin, "://*.", "://wildcard.", 1))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || (u.Path != "" && u.Path != "/") || u.RawQuery != "" {
+			errs = append(errs, ValidationError{Key: "CORS_ALLOWED_ORIGINS", Message: fmt.Sprintf("%q is not an origin like https://app.example.com or https://*.example.com", origin)})
+			continue
+		}
+		if strings.Contains(strings.Replace(origin, "://*.", "", 1), "*") {
+			errs = append(errs, ValidationError{Key: "CORS_ALLOWED_ORIGINS", Message: fmt.Sprintf("%q: a wildcard may only replace the leftmost subdomain", origin)})
+			continue
+		}
+		if !production {
+			continue
+		}
+		if u.Scheme == "http" {
+			errs = append(errs, ValidationError{Key: "CORS_ALLOWED_ORIGINS", Message: fmt.Sprintf("%q must use https in production", origin)})
+		}
+		if strings.Contains(origin, "://*.") && !strings.Contains(strings.TrimPrefix(u.Hostname(), "wildcard."), ".") {
+			errs = append(errs, ValidationError{Key: "CORS_ALLOWED_ORIGINS", Message: fmt.Sprintf("%q matches every subdomain of a top-level domain", origin)})
+		}
+	}
+
+	if cfg.Headers.HSTSPreload && (cfg.Headers.HSTSMaxAge < 365*24*time.Hour || !cfg.Headers.HSTSIncludeSubdomains) {
+		errs = append(errs, ValidationError{Key: "SECURITY_HSTS_PRELOAD", Message: "preload needs SECURITY_HSTS_MAX_AGE_SECONDS of a year or more and SECURITY_HSTS_INCLUDE_SUBDOMAINS=true"})
+	}
+
+	if production {
+		if cfg.Headers.HSTSMaxAge <= 0 {
+			errs = append(errs, ValidationError{Key: "SECURITY_HSTS_MAX_AGE_SECONDS", Message: "HSTS must be enabled in production"})
+		}
+		if strings.TrimSpace(cfg.Headers.CSP) == "" {
+			errs = append(errs, ValidationError{Key: "SECURITY_CSP", Message: "a Content-Security-Policy is required in production"})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSecurity(t *testing.T) {
+	secure := func() *SecurityConfigType {
+		cfg := securityProfiles["production"]
+		cfg.CORS.AllowedOrigins = []string{"https://app.example.com", "https://*.example.com"}
+		return &cfg
+	}
+
+	tests := []struct {
+		name        string
+		environment string
+		modify      func(cfg *SecurityConfigType)
+		wantKeys    []string
+	}{
+		{name: "production profile with https origins", environment: "production", modify: func(cfg *SecurityConfigType) {}},
+		{name: "any origin in production", environment: "production", modify: func(cfg *SecurityConfigType) {
+			cfg.CORS.AllowedOrigins = []string{"*"}
+			cfg.CORS.AllowCredentials = false
+		}, wantKeys: []string{"CORS_ALLOWED_ORIGINS"}},
+		{name: "any origin with credentials", environment: "development", modify: func(cfg *SecurityConfigType) {
+			cfg.CORS.AllowedOrigins = []string{"*"}
+		}, wantKeys: []string{"CORS_ALLOWED_ORIGINS"}},
+		{name: "any origin without credentials in development", environment: "development", modify: func(cfg *SecurityConfigType) {
+			cfg.CORS.AllowedOrigins = []string{"*"}
+			cfg.CORS.AllowCredentials = false
+		}},
+		{name: "http origin in production", environment: "production", modify: func(cfg *SecurityConfigType) {
+			cfg.CORS.AllowedOrigins = []string{"http://app.example.com"}
+		}, wantKeys: []string{"CORS_ALLOWED_ORIGINS"}},
+		{name: "http origin in development", environment: "development", modify: func(cfg *SecurityConfigType) {
+			cfg.CORS.AllowedOrigins = []string{"http://localhost:3000"}
+		}},
+		{name: "wildcard over a top-level domain", environment: "production", modify: func(cfg *SecurityConfigType) {
+			cfg.CORS.AllowedOrigins = []string{"https://*.com"}
+		}, wantKeys: []string{"CORS_ALLOWED_ORIGINS"}},
+		{name: "malformed origins", environment: "development", modify: func(cfg *SecurityConfigType) {
+			cfg.CORS.AllowedOrigins = []string{"app.example.com", "https://app.example.com/path", "https://app.*.example.com"}
+		}, wantKeys: []string{"CORS_ALLOWED_ORIGINS", "CORS_ALLOWED_ORIGINS", "CORS_ALLOWED_ORIGINS"}},
+		{name: "no HSTS or CSP in production", environment: "production", modify: func(cfg *SecurityConfigType) {
+			cfg.Headers.HSTSMaxAge = 0
+			cfg.Headers.CSP = ""
+		}, wantKeys: []string{"SECURITY_HSTS_MAX_AGE_SECONDS", "SECURITY_CSP"}},
+		{name: "preload with a short max-age", environment: "staging", modify: func(cfg *SecurityConfigType) {
+			cfg.Headers.HSTSMaxAge = time.Hour
+			cfg.Headers.HSTSPreload = true
+		}, wantKeys: []string{"SECURITY_HSTS_PRELOAD"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := secure()
+			tt.modify(cfg)
+
+			err := validateSecurity(cfg, tt.environment)
+
+			if len(tt.wantKeys) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var keys []string
+			for _, e := range err.(ValidationErrors) {
+				keys = append(keys, e.Key)
+			}
+			assert.Equal(t, tt.wantKeys, keys)
+		})
+	}
+}
+
+func TestLoadSecurity_Profiles(t *testing.T) {
+	t.Setenv("CORS_ALLOWED_ORIGINS", "")
+
+	assert.Equal(t, []string{"http://localhost:3000", "http://localhost:5173"}, loadSecurity("development").CORS.AllowedOrigins)
+	assert.Zero(t, loadSecurity("development").Headers.HSTSMaxAge)
+	assert.Empty(t, loadSecurity("production").CORS.AllowedOrigins)
+	assert.Equal(t, 365*24*time.Hour, loadSecurity("production").Headers.HSTSMaxAge)
+
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://app.example.com, https://*.example.com")
+	t.Setenv("CORS_ALLOWED_METHODS", "get,post")
+	t.Setenv("SECURITY_HSTS_MAX_AGE_SECONDS", "60")
+	cfg := loadSecurity("production")
+	assert.Equal(t, []string{"https://app.example.com", "https://*.example.com"}, cfg.CORS.AllowedOrigins)
+	assert.Equal(t, []string{"GET", "POST"}, cfg.CORS.AllowedMethods)
+	assert.Equal(t, time.Minute, cfg.Headers.HSTSMaxAge)
+}
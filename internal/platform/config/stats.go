@@ -0,0 +1,25 @@
+package config
+
+import "time"
+
+// StatsConfigType holds the configuration of the stats endpoints
+type StatsConfigType struct {
+	// ReadFromSummaries serves monthly-by-type, by-type and top tags stats
+	// from the summary tables the worker refreshes instead of aggregating
+	// activities on every request (see repository.SummaryStatsRepository)
+	ReadFromSummaries bool
+
+	// MaxStaleness is the age beyond which a user's summaries are bypassed
+	// for the live queries; 0 never bypasses them
+	MaxStaleness time.Duration
+}
+
+// Stats is the loaded stats configuration
+var Stats *StatsConfigType
+
+func loadStats() *StatsConfigType {
+	return &StatsConfigType{
+		ReadFromSummaries: GetEnvBool("STATS_READ_FROM_SUMMARIES", false),
+		MaxStaleness:      time.Duration(GetEnvInt("STATS_SUMMARY_MAX_STALENESS_MINUTES", 120)) * time.Minute,
+	}
+}
@@ -1,9 +1,18 @@
 package config
 
+import "time"
+
 // StorageConfigType holds storage configuration
 type StorageConfigType struct {
 	Provider string
 	S3       S3ConfigType
+	Local    LocalStorageConfigType
+
+	// FilesBaseURL is the public URL of the API, which signed file URLs
+	// (/api/v1/files/...) start with
+	FilesBaseURL string
+	// FileURLTTL is how long signed file URLs stay valid
+	FileURLTTL time.Duration
 	// Add other providers as needed:
 	// Azure AzureConfig
 	// Supabase SupabaseConfig
@@ -19,6 +28,12 @@ type S3ConfigType struct {
 	UsePathStyle    bool   // For S3-compatible services
 }
 
+// LocalStorageConfigType holds the configuration of the local disk provider,
+// meant for development
+type LocalStorageConfigType struct {
+	Dir string
+}
+
 // Storage is the global storage configuration instance
 var Storage *StorageConfigType
 
@@ -34,5 +49,10 @@ func loadStorage() *StorageConfigType {
 			Endpoint:        GetEnv("AWS_S3_ENDPOINT", ""),
 			UsePathStyle:    GetEnvBool("AWS_S3_PATH_STYLE", false),
 		},
+		Local: LocalStorageConfigType{
+			Dir: GetEnv("STORAGE_LOCAL_DIR", "./data/storage"),
+		},
+		FilesBaseURL: GetEnv("STORAGE_FILES_BASE_URL", "http://localhost:8080"),
+		FileURLTTL:   time.Duration(GetEnvInt("STORAGE_FILE_URL_TTL_SECONDS", 900)) * time.Second,
 	}
 }
@@ -0,0 +1,37 @@
+package config
+
+import "time"
+
+// WeatherConfigType holds weather enrichment configuration
+type WeatherConfigType struct {
+	// Provider is "openmeteo", or "none" to disable enrichment
+	Provider string
+
+	// OpenMeteo endpoints; APIKey is only needed for the commercial API
+	ArchiveURL  string
+	ForecastURL string
+	APIKey      string
+
+	// RatePerMinute caps provider requests per worker process
+	RatePerMinute int
+	Timeout       time.Duration
+}
+
+// Weather is the loaded weather configuration
+var Weather *WeatherConfigType
+
+// Enabled reports whether activities with coordinates should be enriched
+func (c *WeatherConfigType) Enabled() bool {
+	return c.Provider != "none"
+}
+
+func loadWeather() *WeatherConfigType {
+	return &WeatherConfigType{
+		Provider:      GetEnv("WEATHER_PROVIDER", "none"),
+		ArchiveURL:    GetEnv("WEATHER_OPENMETEO_ARCHIVE_URL", "https://archive-api.open-meteo.com/v1/archive"),
+		ForecastURL:   GetEnv("WEATHER_OPENMETEO_FORECAST_URL", "https://api.open-meteo.com/v1/forecast"),
+		APIKey:        GetEnv("WEATHER_API_KEY", ""),
+		RatePerMinute: GetEnvInt("WEATHER_RATE_PER_MINUTE", 60),
+		Timeout:       time.Duration(GetEnvInt("WEATHER_TIMEOUT_MS", 5000)) * time.Millisecond,
+	}
+}
@@ -1,16 +1,41 @@
 package container
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 	"sync"
+	"time"
 )
 
 // Container is a simple dependency injection container
 // Provides thread-safe singleton management with factory-based registration
+// Scoped services live in child containers created by BeginScope (see scope.go)
 type Container struct {
-	services  map[string]interface{} // Instantiated singletons
-	factories map[string]Factory     // Factory functions for lazy instantiation
-	mu        sync.RWMutex           // Thread-safe access
+	services  map[string]interface{}     // Instantiated singletons (scoped instances in a scope)
+	factories map[string]Factory         // Factory functions for lazy instantiation
+	scoped    map[string]Factory         // Factories of scoped services, created once per scope
+	types     map[string]reflect.Type    // Declared types of RegisterTyped services
+	deps      map[string]map[string]bool // Services each factory resolved (see Verify)
+	mu        sync.RWMutex               // Thread-safe access
+
+	// Set on the container handed to a factory, which records the factory's
+	// dependencies on owner (see tracer)
+	owner     *Container
+	dependent string
+
+	// Scope state; parent is nil for the root container
+	parent  *Container
+	ctx     context.Context
+	created []string // services in creation order; a scope disposes them in reverse
+	closed  bool
+
+	// Lifecycle state of the root container (see lifecycle.go)
+	hooks       map[string]*lifecycle
+	hookTimeout time.Duration
+	running     []string // services whose OnStart hooks ran, in start order
+	started     bool
+	stopped     bool
 }
 
 // Factory is a function that creates a service instance
@@ -20,8 +45,13 @@ type Factory func(c *Container) (interface{}, error)
 // New creates a new empty container
 func New() *Container {
 	return &Container{
-		services:  make(map[string]interface{}),
-		factories: make(map[string]Factory),
+		services:    make(map[string]interface{}),
+		factories:   make(map[string]Factory),
+		scoped:      make(map[string]Factory),
+		types:       make(map[string]reflect.Type),
+		deps:        make(map[string]map[string]bool),
+		hooks:       make(map[string]*lifecycle),
+		hookTimeout: DefaultHookTimeout,
 	}
 }
 
@@ -29,16 +59,25 @@ func New() *Container {
 // The service will be created lazily when first resolved
 // All subsequent resolutions return the same instance (singleton pattern)
 func (c *Container) Register(name string, factory Factory) {
+	c = c.root()
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.factories[name] = factory
+	// A plain registration replaces a typed one, and its declared type
+	delete(c.types, name)
 }
 
 // RegisterSingleton registers an already-instantiated singleton
 // Useful for registering primitive types or pre-configured instances
+// On a scope the instance is only visible to that scope and its children
+// (e.g. the request's user principal)
 func (c *Container) RegisterSingleton(name string, instance interface{}) {
+	c = c.unwrap()
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	if _, exists := c.services[name]; !exists && c.parent == nil {
+		c.created = append(c.created, name)
+	}
 	c.services[name] = instance
 }
 
@@ -47,6 +86,14 @@ func (c *Container) RegisterSingleton(name string, instance interface{}) {
 // If not, calls the factory to create it, caches it, and returns it
 // Returns an error if the service is not registered or if the factory fails
 func (c *Container) Resolve(name string) (interface{}, error) {
+	if c.owner != nil {
+		c.owner.recordDependency(c.dependent, name)
+		return c.owner.Resolve(name)
+	}
+	if c.parent != nil {
+		return c.resolveInScope(name)
+	}
+
 	// Check if already instantiated
 	c.mu.RLock()
 	if service, exists := c.services[name]; exists {
@@ -61,11 +108,14 @@ func (c *Container) Resolve(name string) (interface{}, error) {
 	c.mu.RUnlock()
 
 	if !exists {
+		if c.isScoped(name) {
+			return nil, fmt.Errorf("%w: %s", ErrNoScope, name)
+		}
 		return nil, fmt.Errorf("service not registered: %s", name)
 	}
 
 	// Create instance (outside of lock to prevent deadlock if factory resolves other services)
-	instance, err := factory(c)
+	instance, err := factory(c.tracer(name))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create service %s: %w", name, err)
 	}
@@ -78,6 +128,7 @@ func (c *Container) Resolve(name string) (interface{}, error) {
 		return existingService, nil
 	}
 	c.services[name] = instance
+	c.created = append(c.created, name)
 	c.mu.Unlock()
 
 	return instance, nil
@@ -96,28 +147,42 @@ func (c *Container) MustResolve(name string) interface{} {
 
 // Has checks if a service is registered (either as factory or singleton)
 func (c *Container) Has(name string) bool {
+	c = c.unwrap()
 	c.mu.RLock()
-	defer c.mu.RUnlock()
-
 	_, inServices := c.services[name]
 	_, inFactories := c.factories[name]
+	_, inScoped := c.scoped[name]
+	c.mu.RUnlock()
 
-	return inServices || inFactories
+	if inServices || inFactories || inScoped {
+		return true
+	}
+	return c.parent != nil && c.parent.Has(name)
 }
 
 // Clear removes all registered services and factories
 // Useful for testing or resetting the container
 func (c *Container) Clear() {
+	c = c.unwrap()
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	c.services = make(map[string]interface{})
 	c.factories = make(map[string]Factory)
+	c.scoped = make(map[string]Factory)
+	c.types = make(map[string]reflect.Type)
+	c.deps = make(map[string]map[string]bool)
+	c.hooks = make(map[string]*lifecycle)
+	c.created = nil
+	c.running = nil
+	c.started = false
+	c.stopped = false
 }
 
 // List returns the names of all registered services (both factories and singletons)
 // Useful for debugging and introspection
 func (c *Container) List() []string {
+	c = c.unwrap()
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
@@ -131,6 +196,10 @@ func (c *Container) List() []string {
 		names[name] = true
 	}
 
+	for name := range c.scoped {
+		names[name] = true
+	}
+
 	result := make([]string, 0, len(names))
 	for name := range names {
 		result = append(result, name)
@@ -0,0 +1,197 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DefaultHookTimeout bounds each lifecycle hook unless SetHookTimeout changes it
+const DefaultHookTimeout = 10 * time.Second
+
+// ErrAlreadyStarted is returned when Start is called twice
+var ErrAlreadyStarted = errors.New("container already started")
+
+// Hook is a lifecycle callback of a service. Its context expires after the
+// hook timeout, so an OnStart hook must not hand it to work that outlives
+// the start (background loops need their own context).
+type Hook func(ctx context.Context) error
+
+// lifecycle holds the hooks attached to one service
+type lifecycle struct {
+	onStart []Hook
+	onStop  []Hook
+}
+
+// OnStart attaches a hook that Start runs for service name. Factories attach
+// hooks for the instance they build, so only services that were created are
+// started.
+func (c *Container) OnStart(name string, hook Hook) {
+	c = c.root()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lifecycleOf(name).onStart = append(c.lifecycleOf(name).onStart, hook)
+}
+
+// OnStop attaches a hook that Stop runs for service name, e.g. closing a
+// connection pool
+func (c *Container) OnStop(name string, hook Hook) {
+	c = c.root()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lifecycleOf(name).onStop = append(c.lifecycleOf(name).onStop, hook)
+}
+
+// SetHookTimeout sets how long each lifecycle hook may run
+func (c *Container) SetHookTimeout(d time.Duration) {
+	c = c.root()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hookTimeout = d
+}
+
+// Start runs the OnStart hooks in dependency order: services are started in
+// the order they were created, and a factory only finishes after the
+// services it resolved, so dependencies start first. Services with hooks are
+// resolved first if they haven't been. If a hook fails, the services already
+// started are stopped and the errors are returned joined.
+func (c *Container) Start(ctx context.Context) error {
+	c = c.root()
+
+	c.mu.RLock()
+	hooked := make([]string, 0, len(c.hooks))
+	for name := range c.hooks {
+		hooked = append(hooked, name)
+	}
+	c.mu.RUnlock()
+
+	for _, name := range hooked {
+		if _, err := c.Resolve(name); err != nil {
+			return fmt.Errorf("start %s: %w", name, err)
+		}
+	}
+
+	c.mu.Lock()
+	if c.started {
+		c.mu.Unlock()
+		return ErrAlreadyStarted
+	}
+	c.started = true
+	names := c.inCreationOrder(hooked)
+	c.mu.Unlock()
+
+	for i, name := range names {
+		for _, hook := range c.hooksOf(name).onStart {
+			if err := c.runHook(ctx, hook); err != nil {
+				err = fmt.Errorf("start %s: %w", name, err)
+				c.mu.Lock()
+				c.stopped = true
+				c.mu.Unlock()
+				return errors.Join(err, c.stopAll(ctx, names[:i]))
+			}
+		}
+		c.mu.Lock()
+		c.running = names[:i+1]
+		c.mu.Unlock()
+	}
+	return nil
+}
+
+// Stop runs the OnStop hooks in reverse dependency order, so a service stops
+// before the services it depends on (handlers before repositories before the
+// database). Every hook runs even if others fail or time out; the errors are
+// returned joined. Without a Start it stops every created service with hooks.
+// Stopping twice is a no-op.
+func (c *Container) Stop(ctx context.Context) error {
+	c = c.root()
+
+	c.mu.Lock()
+	if c.stopped {
+		c.mu.Unlock()
+		return nil
+	}
+	c.stopped = true
+	names := c.running
+	if !c.started {
+		hooked := make([]string, 0, len(c.hooks))
+		for name := range c.hooks {
+			if _, created := c.services[name]; created {
+				hooked = append(hooked, name)
+			}
+		}
+		names = c.inCreationOrder(hooked)
+	}
+	c.mu.Unlock()
+
+	return c.stopAll(ctx, names)
+}
+
+// stopAll runs the OnStop hooks of names, last name first
+func (c *Container) stopAll(ctx context.Context, names []string) error {
+	var errs []error
+	for i := len(names) - 1; i >= 0; i-- {
+		hooks := c.hooksOf(names[i]).onStop
+		for j := len(hooks) - 1; j >= 0; j-- {
+			if err := c.runHook(ctx, hooks[j]); err != nil {
+				errs = append(errs, fmt.Errorf("stop %s: %w", names[i], err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// runHook runs hook with the hook timeout. A hook that ignores its context
+// is abandoned when the timeout expires.
+func (c *Container) runHook(ctx context.Context, hook Hook) error {
+	c.mu.RLock()
+	timeout := c.hookTimeout
+	c.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- hook(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// lifecycleOf returns the hooks of name, creating them; c.mu must be held
+func (c *Container) lifecycleOf(name string) *lifecycle {
+	if c.hooks[name] == nil {
+		c.hooks[name] = &lifecycle{}
+	}
+	return c.hooks[name]
+}
+
+// hooksOf returns a copy of the hooks of name
+func (c *Container) hooksOf(name string) lifecycle {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if hooks := c.hooks[name]; hooks != nil {
+		return *hooks
+	}
+	return lifecycle{}
+}
+
+// inCreationOrder sorts names by when the services were created; c.mu must
+// be held
+func (c *Container) inCreationOrder(names []string) []string {
+	position := make(map[string]int, len(c.created))
+	for i, name := range c.created {
+		position[name] = i
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return position[names[i]] < position[names[j]]
+	})
+	return names
+}
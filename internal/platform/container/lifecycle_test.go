@@ -0,0 +1,110 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLifecycle_Order(t *testing.T) {
+	c := New()
+	var events []string
+	record := func(event string) Hook {
+		return func(context.Context) error {
+			events = append(events, event)
+			return nil
+		}
+	}
+
+	c.RegisterSingleton("db", &TestDependency{Name: "db"})
+	c.OnStop("db", record("stop db"))
+	c.Register("scheduler", func(c *Container) (interface{}, error) {
+		c.MustResolve("repo")
+		c.OnStart("scheduler", record("start scheduler"))
+		c.OnStop("scheduler", record("stop scheduler"))
+		return &TestService{}, nil
+	})
+	c.Register("repo", func(c *Container) (interface{}, error) {
+		c.MustResolve("db")
+		c.OnStart("repo", record("start repo"))
+		c.OnStop("repo", record("stop repo"))
+		return &TestService{}, nil
+	})
+	c.MustResolve("scheduler")
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if err := c.Start(context.Background()); !errors.Is(err, ErrAlreadyStarted) {
+		t.Errorf("Expected ErrAlreadyStarted, got %v", err)
+	}
+	if err := c.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+	if err := c.Stop(context.Background()); err != nil {
+		t.Errorf("Second Stop should be a no-op, got %v", err)
+	}
+
+	want := []string{"start repo", "start scheduler", "stop scheduler", "stop repo", "stop db"}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("Expected %v, got %v", want, events)
+	}
+}
+
+func TestLifecycle_StartFailureStopsStarted(t *testing.T) {
+	c := New()
+	var stopped []string
+	errBoom := errors.New("boom")
+
+	c.RegisterSingleton("db", &TestDependency{})
+	c.OnStop("db", func(context.Context) error {
+		stopped = append(stopped, "db")
+		return nil
+	})
+	c.RegisterSingleton("queue", &TestDependency{})
+	c.OnStart("queue", func(context.Context) error { return errBoom })
+	c.OnStop("queue", func(context.Context) error {
+		stopped = append(stopped, "queue")
+		return nil
+	})
+
+	if err := c.Start(context.Background()); !errors.Is(err, errBoom) {
+		t.Fatalf("Expected start error, got %v", err)
+	}
+	if !reflect.DeepEqual(stopped, []string{"db"}) {
+		t.Errorf("Expected only db to be stopped, got %v", stopped)
+	}
+	if err := c.Stop(context.Background()); err != nil || len(stopped) != 1 {
+		t.Errorf("Stop after a failed start should be a no-op, got %v, %v", err, stopped)
+	}
+}
+
+func TestLifecycle_StopTimeoutAndErrors(t *testing.T) {
+	c := New()
+	c.SetHookTimeout(20 * time.Millisecond)
+	errClose := errors.New("close failed")
+	closed := false
+
+	c.RegisterSingleton("db", &TestDependency{})
+	c.OnStop("db", func(context.Context) error {
+		closed = true
+		return errClose
+	})
+	c.RegisterSingleton("worker", &TestDependency{})
+	c.OnStop("worker", func(context.Context) error {
+		// Ignores its context
+		time.Sleep(time.Second)
+		return nil
+	})
+
+	// Without Start, Stop stops every created service
+	err := c.Stop(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, errClose) {
+		t.Errorf("Expected timeout and close errors joined, got %v", err)
+	}
+	if !closed {
+		t.Error("db should be stopped after the worker timed out")
+	}
+}
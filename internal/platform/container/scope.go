@@ -0,0 +1,183 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrNoScope is returned when a scoped service is resolved from the root
+// container. Singletons are built from the root, so this also stops a
+// singleton from capturing a per-request component.
+var ErrNoScope = errors.New("scoped service resolved outside a scope")
+
+// ErrScopeClosed is returned when resolving from a scope that was closed
+var ErrScopeClosed = errors.New("scope is closed")
+
+// Disposer is implemented by scoped services that release resources when
+// their scope closes. Services implementing io.Closer are closed instead.
+type Disposer interface {
+	Dispose(ctx context.Context) error
+}
+
+type scopeKey struct{}
+
+// RegisterScoped registers a factory for a service created once per scope
+// (e.g. once per request). The factory receives the scope, so it can
+// resolve other scoped services and read the scope's Context.
+func (c *Container) RegisterScoped(name string, factory Factory) {
+	c = c.root()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.scoped[name] = factory
+}
+
+// BeginScope creates a child container tied to ctx. Scoped services resolve
+// once per scope; everything else resolves from the parent. Close the scope
+// to dispose its scoped services.
+func (c *Container) BeginScope(ctx context.Context) *Container {
+	c = c.unwrap()
+	scope := &Container{
+		services:  make(map[string]interface{}),
+		factories: make(map[string]Factory),
+		scoped:    make(map[string]Factory),
+		parent:    c,
+	}
+	scope.ctx = context.WithValue(ctx, scopeKey{}, scope)
+	return scope
+}
+
+// Context returns the context the scope was begun with, carrying the scope
+// (see ScopeFromContext). The root container returns context.Background().
+func (c *Container) Context() context.Context {
+	c = c.unwrap()
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// ScopeFromContext returns the scope stored in ctx by BeginScope, if any
+func ScopeFromContext(ctx context.Context) (*Container, bool) {
+	scope, ok := ctx.Value(scopeKey{}).(*Container)
+	return scope, ok
+}
+
+// Close disposes the scoped services created by this scope in reverse
+// creation order and returns their errors joined. Instances added with
+// RegisterSingleton belong to the caller and are left alone. Closing twice
+// is a no-op.
+func (c *Container) Close(ctx context.Context) error {
+	c = c.unwrap()
+	if c.parent == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	created := c.created
+	services := c.services
+	c.created = nil
+	c.mu.Unlock()
+
+	var errs []error
+	for i := len(created) - 1; i >= 0; i-- {
+		name := created[i]
+		if err := dispose(ctx, services[name]); err != nil {
+			errs = append(errs, fmt.Errorf("dispose %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func dispose(ctx context.Context, instance interface{}) error {
+	switch d := instance.(type) {
+	case Disposer:
+		return d.Dispose(ctx)
+	case io.Closer:
+		return d.Close()
+	}
+	return nil
+}
+
+// root returns the root container of a scope chain
+func (c *Container) root() *Container {
+	for {
+		switch {
+		case c.owner != nil:
+			c = c.owner
+		case c.parent != nil:
+			c = c.parent
+		default:
+			return c
+		}
+	}
+}
+
+// unwrap returns the container a factory's tracer stands for
+func (c *Container) unwrap() *Container {
+	for c.owner != nil {
+		c = c.owner
+	}
+	return c
+}
+
+// isScoped reports whether name is registered as a scoped service
+func (c *Container) isScoped(name string) bool {
+	root := c.root()
+	root.mu.RLock()
+	defer root.mu.RUnlock()
+	_, ok := root.scoped[name]
+	return ok
+}
+
+// resolveInScope resolves name in a scope: instances of this scope first,
+// then scoped services (created here), then the parent chain
+func (c *Container) resolveInScope(name string) (interface{}, error) {
+	c.mu.RLock()
+	service, exists := c.services[name]
+	closed := c.closed
+	c.mu.RUnlock()
+
+	if closed {
+		return nil, fmt.Errorf("%w: resolving %s", ErrScopeClosed, name)
+	}
+	if exists {
+		return service, nil
+	}
+
+	root := c.root()
+	root.mu.RLock()
+	factory, scoped := root.scoped[name]
+	root.mu.RUnlock()
+	if !scoped {
+		return c.parent.Resolve(name)
+	}
+
+	// Create outside of the lock; the factory may resolve other scoped services
+	instance, err := factory(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scoped service %s: %w", name, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		// The scope closed while the instance was created; don't leak it
+		_ = dispose(context.Background(), instance)
+		return nil, fmt.Errorf("%w: resolving %s", ErrScopeClosed, name)
+	}
+	if existing, exists := c.services[name]; exists {
+		// Another goroutine won the race; keep its instance
+		_ = dispose(context.Background(), instance)
+		return existing, nil
+	}
+	c.services[name] = instance
+	c.created = append(c.created, name)
+	return instance, nil
+}
@@ -0,0 +1,161 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// unitOfWork is a scoped test service that records its disposal
+type unitOfWork struct {
+	id       int
+	disposed *[]int
+	err      error
+}
+
+func (u *unitOfWork) Dispose(ctx context.Context) error {
+	*u.disposed = append(*u.disposed, u.id)
+	return u.err
+}
+
+func TestScope_ResolvesScopedOncePerScope(t *testing.T) {
+	c := New()
+	c.RegisterScoped("uow", func(c *Container) (interface{}, error) {
+		return &TestService{}, nil
+	})
+
+	first := c.BeginScope(context.Background())
+	second := c.BeginScope(context.Background())
+
+	a1, err := first.Resolve("uow")
+	if err != nil {
+		t.Fatalf("Failed to resolve scoped service: %v", err)
+	}
+	a2, _ := first.Resolve("uow")
+	b, _ := second.Resolve("uow")
+
+	if a1 != a2 {
+		t.Error("Scoped service should resolve to the same instance within a scope")
+	}
+	if a1 == b {
+		t.Error("Scoped service should resolve to a new instance per scope")
+	}
+}
+
+func TestScope_RootCannotResolveScoped(t *testing.T) {
+	c := New()
+	c.RegisterScoped("uow", func(c *Container) (interface{}, error) {
+		return &TestService{}, nil
+	})
+	c.Register("singleton", func(c *Container) (interface{}, error) {
+		// A singleton must not capture a per-request service
+		_, err := c.Resolve("uow")
+		return &TestService{}, err
+	})
+
+	if _, err := c.Resolve("uow"); !errors.Is(err, ErrNoScope) {
+		t.Errorf("Expected ErrNoScope from root, got %v", err)
+	}
+	if _, err := c.BeginScope(context.Background()).Resolve("singleton"); !errors.Is(err, ErrNoScope) {
+		t.Errorf("Expected ErrNoScope for singleton depending on scoped service, got %v", err)
+	}
+}
+
+func TestScope_SharesSingletonsWithParent(t *testing.T) {
+	c := New()
+	c.Register("db", func(c *Container) (interface{}, error) {
+		return &TestDependency{Name: "db"}, nil
+	})
+	c.RegisterScoped("repo", func(s *Container) (interface{}, error) {
+		db, err := s.Resolve("db")
+		if err != nil {
+			return nil, err
+		}
+		return &TestServiceWithDep{Dependency: db.(*TestDependency)}, nil
+	})
+
+	scope := c.BeginScope(context.Background())
+	scope.RegisterSingleton("principal", &TestService{Value: "user-1"})
+
+	repo, err := scope.Resolve("repo")
+	if err != nil {
+		t.Fatalf("Failed to resolve scoped service: %v", err)
+	}
+	db := c.MustResolve("db")
+	if repo.(*TestServiceWithDep).Dependency != db {
+		t.Error("Scoped service should receive the root singleton")
+	}
+
+	child := scope.BeginScope(context.Background())
+	if principal, err := child.Resolve("principal"); err != nil || principal.(*TestService).Value != "user-1" {
+		t.Errorf("Child scope should see instances of its parent scope, got %v, %v", principal, err)
+	}
+	if !c.Has("repo") || c.Has("principal") {
+		t.Error("Scope instances must not leak into the root container")
+	}
+}
+
+func TestScope_CloseDisposesInReverseOrder(t *testing.T) {
+	c := New()
+	var disposed []int
+	failure := errors.New("rollback failed")
+	c.RegisterScoped("first", func(s *Container) (interface{}, error) {
+		return &unitOfWork{id: 1, disposed: &disposed, err: failure}, nil
+	})
+	c.RegisterScoped("second", func(s *Container) (interface{}, error) {
+		// Depends on first, so first is created before it and disposed after it
+		if _, err := s.Resolve("first"); err != nil {
+			return nil, err
+		}
+		return &unitOfWork{id: 2, disposed: &disposed}, nil
+	})
+
+	scope := c.BeginScope(context.Background())
+	if got, ok := ScopeFromContext(scope.Context()); !ok || got != scope {
+		t.Error("Scope context should carry the scope")
+	}
+	if _, err := scope.Resolve("second"); err != nil {
+		t.Fatalf("Failed to resolve scoped service: %v", err)
+	}
+
+	err := scope.Close(context.Background())
+	if !errors.Is(err, failure) {
+		t.Errorf("Expected disposal error to be returned, got %v", err)
+	}
+	if len(disposed) != 2 || disposed[0] != 2 || disposed[1] != 1 {
+		t.Errorf("Expected disposal order [2 1], got %v", disposed)
+	}
+
+	if err := scope.Close(context.Background()); err != nil {
+		t.Errorf("Closing twice should be a no-op, got %v", err)
+	}
+	if _, err := scope.Resolve("first"); !errors.Is(err, ErrScopeClosed) {
+		t.Errorf("Expected ErrScopeClosed after close, got %v", err)
+	}
+}
+
+func TestScope_ConcurrentResolve(t *testing.T) {
+	c := New()
+	c.RegisterScoped("uow", func(s *Container) (interface{}, error) {
+		return &TestService{}, nil
+	})
+	scope := c.BeginScope(context.Background())
+
+	var wg sync.WaitGroup
+	results := make([]interface{}, 50)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = scope.Resolve("uow")
+		}(i)
+	}
+	wg.Wait()
+
+	for _, r := range results {
+		if r != results[0] {
+			t.Fatal("Concurrent resolution in one scope should return a single instance")
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrWrongType is returned when a service isn't of the requested type
+var ErrWrongType = errors.New("service has the wrong type")
+
+// RegisterTyped registers a factory whose result type is checked by the
+// compiler. The declared type is recorded, so Resolve[T] rejects a mismatched
+// T before building anything and Verify can report it. Use an interface T to
+// register an implementation under the interface it is resolved as.
+func RegisterTyped[T any](c *Container, name string, factory func(c *Container) (T, error)) {
+	c.Register(name, func(c *Container) (interface{}, error) {
+		return factory(c)
+	})
+
+	c = c.root()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.types[name] = reflect.TypeFor[T]()
+}
+
+// Resolve resolves name as a T. Unlike a type assertion on the result of
+// Container.Resolve it returns ErrWrongType instead of panicking.
+func Resolve[T any](c *Container, name string) (T, error) {
+	var zero T
+	want := reflect.TypeFor[T]()
+
+	if declared, ok := c.declaredType(name); ok && !declared.AssignableTo(want) {
+		return zero, fmt.Errorf("%w: %s is registered as %s, not %s", ErrWrongType, name, declared, want)
+	}
+
+	service, err := c.Resolve(name)
+	if err != nil {
+		return zero, err
+	}
+	typed, ok := service.(T)
+	if !ok {
+		return zero, fmt.Errorf("%w: %s is %T, not %s", ErrWrongType, name, service, want)
+	}
+	return typed, nil
+}
+
+// MustResolve resolves name as a T or panics, naming the service and both
+// types. Like Container.MustResolve it is meant for application startup.
+func MustResolve[T any](c *Container, name string) T {
+	service, err := Resolve[T](c, name)
+	if err != nil {
+		panic(fmt.Sprintf("container: failed to resolve %s: %v", name, err))
+	}
+	return service
+}
+
+func (c *Container) declaredType(name string) (reflect.Type, bool) {
+	root := c.root()
+	root.mu.RLock()
+	defer root.mu.RUnlock()
+	t, ok := root.types[name]
+	return t, ok
+}
+
+// Graph maps each service to the services its factory resolved, sorted.
+// Scoped services are listed without dependencies.
+type Graph map[string][]string
+
+// String renders the graph one service per line, e.g. "userHandler -> userRepo"
+func (g Graph) String() string {
+	names := make([]string, 0, len(g))
+	for name := range g {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name)
+		if deps := g[name]; len(deps) > 0 {
+			b.WriteString(" -> ")
+			b.WriteString(strings.Join(deps, ", "))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Verify is a startup self-check: it resolves every registered singleton,
+// so missing registrations, failing factories and type mismatches surface
+// at boot instead of on the first request using them. It returns the
+// dependency graph and all failures joined.
+//
+// Scoped services can depend on per-request state and are only listed.
+// Services resolved before Verify keep the dependencies recorded back then.
+func (c *Container) Verify() (Graph, error) {
+	c = c.root()
+
+	c.mu.RLock()
+	names := make([]string, 0, len(c.factories)+len(c.services))
+	for name := range c.factories {
+		names = append(names, name)
+	}
+	for name := range c.services {
+		if _, ok := c.factories[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	scoped := make([]string, 0, len(c.scoped))
+	for name := range c.scoped {
+		scoped = append(scoped, name)
+	}
+	c.mu.RUnlock()
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		service, err := c.Resolve(name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if declared, ok := c.declaredType(name); ok && !reflect.TypeOf(service).AssignableTo(declared) {
+			errs = append(errs, fmt.Errorf("%w: %s is %T, registered as %s", ErrWrongType, name, service, declared))
+		}
+	}
+
+	graph := Graph{}
+	c.mu.RLock()
+	for _, name := range append(names, scoped...) {
+		deps := make([]string, 0, len(c.deps[name]))
+		for dep := range c.deps[name] {
+			deps = append(deps, dep)
+		}
+		sort.Strings(deps)
+		graph[name] = deps
+	}
+	c.mu.RUnlock()
+
+	return graph, errors.Join(errs...)
+}
+
+// tracer returns the container handed to the factory of name: it resolves
+// through c and records what the factory depends on
+func (c *Container) tracer(name string) *Container {
+	return &Container{owner: c, dependent: name}
+}
+
+// recordDependency notes that the factory of dependent resolved name
+func (c *Container) recordDependency(dependent, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.deps[dependent] == nil {
+		c.deps[dependent] = make(map[string]bool)
+	}
+	c.deps[dependent][name] = true
+}
@@ -0,0 +1,88 @@
+package container
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type greeter interface {
+	Greet() string
+}
+
+func (s *TestService) Greet() string { return "hello " + s.Value }
+
+func TestResolve_Typed(t *testing.T) {
+	c := New()
+	RegisterTyped(c, "greeter", func(c *Container) (greeter, error) {
+		return &TestService{Value: "world"}, nil
+	})
+	c.Register("untyped", func(c *Container) (interface{}, error) {
+		return &TestDependency{Name: "dep"}, nil
+	})
+
+	g, err := Resolve[greeter](c, "greeter")
+	if err != nil {
+		t.Fatalf("Failed to resolve typed service: %v", err)
+	}
+	if g.Greet() != "hello world" {
+		t.Errorf("Unexpected greeting %q", g.Greet())
+	}
+
+	// The declared type is checked before the factory runs
+	if _, err := Resolve[*TestDependency](c, "greeter"); !errors.Is(err, ErrWrongType) {
+		t.Errorf("Expected ErrWrongType for mismatched declared type, got %v", err)
+	}
+	if c.services["greeter"] == nil {
+		t.Error("Typed service should be cached after resolution")
+	}
+
+	// Untyped registrations are checked on the instance
+	if dep := MustResolve[*TestDependency](c, "untyped"); dep.Name != "dep" {
+		t.Errorf("Unexpected dependency %q", dep.Name)
+	}
+	if _, err := Resolve[*TestService](c, "untyped"); !errors.Is(err, ErrWrongType) {
+		t.Errorf("Expected ErrWrongType for wrong instance type, got %v", err)
+	}
+
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "service not registered: typo") {
+			t.Errorf("Expected panic naming the missing service, got %v", r)
+		}
+	}()
+	MustResolve[*TestService](c, "typo")
+}
+
+func TestVerify(t *testing.T) {
+	c := New()
+	c.RegisterSingleton("config", &TestDependency{Name: "config"})
+	c.Register("repo", func(c *Container) (interface{}, error) {
+		return &TestServiceWithDep{Dependency: c.MustResolve("config").(*TestDependency)}, nil
+	})
+	RegisterTyped(c, "handler", func(c *Container) (*TestService, error) {
+		if _, err := Resolve[*TestServiceWithDep](c, "repo"); err != nil {
+			return nil, err
+		}
+		c.MustResolve("config")
+		return &TestService{}, nil
+	})
+	c.RegisterScoped("uow", func(c *Container) (interface{}, error) {
+		return &TestService{}, nil
+	})
+
+	graph, err := c.Verify()
+	if err != nil {
+		t.Fatalf("Expected healthy container, got %v", err)
+	}
+	want := "config\nhandler -> config, repo\nrepo -> config\nuow\n"
+	if graph.String() != want {
+		t.Errorf("Unexpected graph:\n%s\nwant:\n%s", graph, want)
+	}
+
+	c.Register("broken", func(c *Container) (interface{}, error) {
+		return c.Resolve("missing")
+	})
+	if _, err := c.Verify(); err == nil || !strings.Contains(err.Error(), "service not registered: missing") {
+		t.Errorf("Expected Verify to report the missing dependency, got %v", err)
+	}
+}
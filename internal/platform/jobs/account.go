@@ -0,0 +1,220 @@
+package jobs
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"time"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	storageTypes "github.com/valentinesamuel/activelog/internal/adapters/storage/types"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/service"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+)
+
+// purgeBatchSize caps how many accounts one purge run deletes
+const purgeBatchSize = 100
+
+// ExportUserDataDeps contains the dependencies for the data export job handler.
+type ExportUserDataDeps struct {
+	AccountRepo  *repository.AccountRepository
+	ActivityRepo repository.ActivityRepositoryInterface
+	ExportRepo   *repository.ExportRepository
+	Storage      storageTypes.StorageProvider
+	Clock        clock.Clock // exports are completed at its time; nil uses the real clock
+}
+
+// now returns the time on deps.Clock
+func (deps ExportUserDataDeps) now() time.Time {
+	if deps.Clock == nil {
+		return time.Now()
+	}
+	return deps.Clock.Now()
+}
+
+// NewExportUserDataHandler returns the handler for EventExportUserData.
+// It bundles everything stored about the user into a ZIP (one JSON file per
+// section, activities.csv and the photo files), uploads it and completes the
+// export record so it can be downloaded like any other export.
+func NewExportUserDataHandler(deps ExportUserDataDeps) HandlerFunc {
+	return func(ctx context.Context, payload types.JobPayload) error {
+		var p ExportUserDataPayload
+		if err := json.Unmarshal(payload.Data, &p); err != nil {
+			return fmt.Errorf("HandleExportUserData: unmarshal: %w", err)
+		}
+
+		if err := exportUserData(ctx, deps, p); err != nil {
+			msg := err.Error()
+			if uerr := deps.ExportRepo.UpdateStatus(ctx, p.ExportID, models.StatusFailed, nil, &msg, deps.now()); uerr != nil {
+				log.Printf("[job] user data export %s: failed to mark as failed: %v", p.ExportID, uerr)
+			}
+			return fmt.Errorf("HandleExportUserData: %w", err)
+		}
+		return nil
+	}
+}
+
+// exportUserData runs one data export end to end
+func exportUserData(ctx context.Context, deps ExportUserDataDeps, p ExportUserDataPayload) error {
+	if err := deps.ExportRepo.UpdateStatus(ctx, p.ExportID, models.StatusProcessing, nil, nil, deps.now()); err != nil {
+		return err
+	}
+
+	sections, err := deps.AccountRepo.ExportData(ctx, p.UserID)
+	if err != nil {
+		return err
+	}
+	activities, err := deps.ActivityRepo.ListByUser(ctx, p.UserID)
+	if err != nil {
+		return fmt.Errorf("list activities: %w", err)
+	}
+	photoKeys, err := deps.AccountRepo.ListPhotoKeys(ctx, p.UserID)
+	if err != nil {
+		return err
+	}
+
+	// Photos can make the bundle large, so build it on disk rather than in memory
+	file, err := os.CreateTemp("", "user-data-*.zip")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	zw := zip.NewWriter(file)
+	if err := writeUserData(ctx, zw, sections, activities); err != nil {
+		return err
+	}
+	ReportProgress(ctx, 20)
+
+	for i, key := range photoKeys {
+		if err := addStorageFile(ctx, zw, deps.Storage, "photos/"+path.Base(key), key); err != nil {
+			return err
+		}
+		ReportProgress(ctx, 20+(i+1)*70/len(photoKeys))
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("close archive: %w", err)
+	}
+
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("measure archive: %w", err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("rewind archive: %w", err)
+	}
+
+	key := fmt.Sprintf("exports/%d/%s.zip", p.UserID, p.ExportID)
+	if _, err := deps.Storage.Upload(ctx, &storageTypes.UploadInput{
+		Key:         key,
+		Body:        file,
+		ContentType: "application/zip",
+		Size:        size,
+	}); err != nil {
+		return fmt.Errorf("upload %s: %w", key, err)
+	}
+
+	log.Printf("[job] user data export %s -> userID=%d sections=%d photos=%d bytes=%d",
+		p.ExportID, p.UserID, len(sections), len(photoKeys), size)
+	if err := SetResult(ctx, map[string]string{"export_id": p.ExportID}); err != nil {
+		return err
+	}
+	return deps.ExportRepo.UpdateStatus(ctx, p.ExportID, models.StatusCompleted, &key, nil, deps.now())
+}
+
+// writeUserData writes one indented <section>.json file per section and
+// activities.csv to zw
+func writeUserData(ctx context.Context, zw *zip.Writer, sections []repository.UserDataSection, activities []*models.Activity) error {
+	for _, section := range sections {
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, section.Data, "", "  "); err != nil {
+			return fmt.Errorf("format %s: %w", section.Name, err)
+		}
+
+		w, err := zw.Create(section.Name + ".json")
+		if err != nil {
+			return fmt.Errorf("add %s: %w", section.Name, err)
+		}
+		if _, err := buf.WriteTo(w); err != nil {
+			return fmt.Errorf("write %s: %w", section.Name, err)
+		}
+	}
+
+	w, err := zw.Create("activities.csv")
+	if err != nil {
+		return fmt.Errorf("add activities.csv: %w", err)
+	}
+	if err := service.ExportActivitiesCSV(ctx, activities, w); err != nil {
+		return fmt.Errorf("write activities.csv: %w", err)
+	}
+	return nil
+}
+
+// addStorageFile copies the storage object key into zw as name.
+// Objects missing from storage are skipped; the photos section still lists them.
+func addStorageFile(ctx context.Context, zw *zip.Writer, storage storageTypes.StorageProvider, name, key string) error {
+	body, _, err := storage.Download(ctx, key)
+	if errors.Is(err, storageTypes.ErrNotFound) {
+		log.Printf("[job] user data export: skipping missing object %s", key)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("download %s: %w", key, err)
+	}
+	defer body.Close()
+
+	w, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("add %s: %w", name, err)
+	}
+	if _, err := io.Copy(w, body); err != nil {
+		return fmt.Errorf("copy %s: %w", key, err)
+	}
+	return nil
+}
+
+// AccountDeleter permanently deletes a disabled account, resuming an
+// earlier attempt that stopped partway (see usecases.AccountDeletion)
+type AccountDeleter interface {
+	DeleteAccount(ctx context.Context, userID int) (*models.Saga, error)
+}
+
+// NewPurgeDeletedAccountsHandler returns the handler for EventPurgeDeletedAccounts.
+// It hard-deletes accounts whose deletion grace period has ended. An account
+// whose deletion fails partway is left for the next run, which resumes it.
+func NewPurgeDeletedAccountsHandler(accounts *repository.AccountRepository, deleter AccountDeleter, clk clock.Clock) HandlerFunc {
+	return func(ctx context.Context, _ types.JobPayload) error {
+		userIDs, err := accounts.ListDueForPurge(ctx, clk.Now(), purgeBatchSize)
+		if err != nil {
+			return fmt.Errorf("HandlePurgeDeletedAccounts: %w", err)
+		}
+
+		purged := 0
+		for i, userID := range userIDs {
+			saga, err := deleter.DeleteAccount(ctx, userID)
+			if err != nil {
+				log.Printf("[job] purge deleted accounts: userID=%d: %v", userID, err)
+			} else {
+				purged++
+			}
+			if saga != nil && saga.Status != models.SagaStatusCompleted {
+				log.Printf("[job] purge deleted accounts: userID=%d %s at %d%% (%d/%d steps)",
+					userID, saga.Status, saga.Progress(), len(saga.CompletedSteps), saga.TotalSteps)
+			}
+			ReportProgress(ctx, (i+1)*100/len(userIDs))
+		}
+
+		log.Printf("[job] purge deleted accounts -> due=%d purged=%d", len(userIDs), purged)
+		return nil
+	}
+}
@@ -0,0 +1,52 @@
+package jobs
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+)
+
+func TestWriteUserData(t *testing.T) {
+	sections := []repository.UserDataSection{
+		{Name: "profile", Data: json.RawMessage(`{"id":7,"email":"runner@example.com"}`)},
+		{Name: "comments", Data: json.RawMessage(`[]`)},
+	}
+	activities := []*models.Activity{{
+		ActivityType:    "running",
+		Title:           "Morning Run",
+		DurationMinutes: 30,
+		ActivityDate:    time.Date(2026, time.March, 1, 7, 0, 0, 0, time.UTC),
+	}}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	require.NoError(t, writeUserData(context.Background(), zw, sections, activities))
+	require.NoError(t, zw.Close())
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = string(content)
+	}
+
+	assert.Len(t, files, 3)
+	assert.JSONEq(t, `{"id":7,"email":"runner@example.com"}`, files["profile.json"])
+	assert.JSONEq(t, `[]`, files["comments.json"])
+	assert.Contains(t, files["activities.csv"], "Morning Run")
+}
@@ -0,0 +1,77 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	emailTypes "github.com/valentinesamuel/activelog/internal/adapters/email/types"
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	"github.com/valentinesamuel/activelog/internal/platform/locale"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+	"github.com/valentinesamuel/activelog/pkg/i18n"
+)
+
+// ChallengeProgressDeps contains the dependencies for the challenge progress job handler.
+type ChallengeProgressDeps struct {
+	Challenges *repository.ChallengeRepository
+	Email      emailTypes.EmailProvider // nil awards badges without emailing
+	Clock      clock.Clock              // nil uses the real clock
+}
+
+// NewRefreshChallengeProgressHandler returns the handler for EventRefreshChallengeProgress.
+// It recomputes the progress of everyone in a running challenge from their
+// activities, so edits and deletions are picked up too, then awards a badge to
+// each participant who reached the target and emails them. A failed email is
+// logged rather than retried: the completion is already recorded.
+func NewRefreshChallengeProgressHandler(deps ChallengeProgressDeps) HandlerFunc {
+	clk := deps.Clock
+	if clk == nil {
+		clk = clock.Real{}
+	}
+	return func(ctx context.Context, _ types.JobPayload) error {
+		updated, err := deps.Challenges.RefreshProgress(ctx, clk.Now().UTC())
+		if err != nil {
+			return fmt.Errorf("HandleRefreshChallengeProgress: refresh: %w", err)
+		}
+
+		completions, err := deps.Challenges.CompleteReached(ctx)
+		if err != nil {
+			return fmt.Errorf("HandleRefreshChallengeProgress: complete: %w", err)
+		}
+
+		for _, completion := range completions {
+			if deps.Email == nil {
+				continue
+			}
+			l := locale.New(completion.Language)
+			if err := deps.Email.Send(ctx, emailTypes.SendEmailInput{
+				To:       completion.Email,
+				From:     config.Email.From,
+				Subject:  l.Localize("ChallengeCompletedSubject", map[string]any{"Challenge": completion.ChallengeName}),
+				TextBody: renderChallengeCompleted(l, completion),
+			}); err != nil {
+				log.Printf("[job] challenge progress: email userID=%d challengeID=%d: %v",
+					completion.UserID, completion.ChallengeID, err)
+			}
+		}
+
+		log.Printf("[job] challenge progress -> updated=%d completed=%d", updated, len(completions))
+		return nil
+	}
+}
+
+// renderChallengeCompleted writes the plain-text body of the email sent when
+// a participant completes a challenge, in their language
+func renderChallengeCompleted(l *i18n.Localizer, completion repository.ChallengeCompletion) string {
+	challenge := map[string]any{"Challenge": completion.ChallengeName}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", l.Localize("EmailGreeting", map[string]any{"Name": completion.Username}))
+	fmt.Fprintf(&b, "%s\n", l.Localize("ChallengeCompletedBody", challenge))
+	fmt.Fprintf(&b, "%s\n", l.Localize("ChallengeCompletedLeaderboard", nil))
+	fmt.Fprintf(&b, "\n%s\n", l.Localize("ChallengeCompletedSignOff", nil))
+	return b.String()
+}
@@ -0,0 +1,28 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valentinesamuel/activelog/internal/platform/locale"
+	"github.com/valentinesamuel/activelog/internal/repository"
+)
+
+func TestRenderChallengeCompleted(t *testing.T) {
+	body := renderChallengeCompleted(english, repository.ChallengeCompletion{
+		ChallengeID:   7,
+		ChallengeName: "100km in March",
+		UserID:        3,
+		Username:      "sam",
+	})
+
+	assert.Contains(t, body, "Hi sam,")
+	assert.Contains(t, body, "You reached the target of 100km in March and earned its badge.")
+}
+
+func TestRenderChallengeCompleted_Localized(t *testing.T) {
+	body := renderChallengeCompleted(locale.New("es"), repository.ChallengeCompletion{ChallengeName: "100km en marzo", Username: "sam"})
+
+	assert.Contains(t, body, "Hola sam:")
+	assert.Contains(t, body, "Alcanzaste el objetivo de 100km en marzo y ganaste su insignia.")
+}
@@ -3,16 +3,43 @@ package jobs
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
 )
 
+var jobsInFlight = promauto.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "job_handlers_in_flight",
+		Help: "Number of jobs the worker's handlers are running",
+	},
+)
+
 // HandlerFunc is the signature every job handler must implement.
 type HandlerFunc func(ctx context.Context, payload types.JobPayload) error
 
 // HandlerFactory routes incoming jobs to the correct handler based on EventType.
+// Every handler is wrapped with WithIdempotency and Track once a MessageStore
+// and JobTracker are configured, and limited per user with a UserLimiter.
 type HandlerFactory struct {
 	handlers map[types.EventType]HandlerFunc
+	messages MessageStore
+	tracker  JobTracker
+	limiter  *UserLimiter
+
+	inFlight  atomic.Int64
+	processed atomic.Int64
+	failed    atomic.Int64
+}
+
+// DispatchStats counts the jobs Dispatch ran since the factory was created.
+// Throttled jobs are not counted.
+type DispatchStats struct {
+	InFlight  int64 // jobs running now
+	Processed int64 // jobs that succeeded
+	Failed    int64 // jobs that failed, including those with no handler
 }
 
 // NewHandlerFactory creates an empty HandlerFactory.
@@ -27,11 +54,71 @@ func (f *HandlerFactory) Register(event types.EventType, handler HandlerFunc) {
 	f.handlers[event] = handler
 }
 
+// UseMessageStore makes every handler skip messages that were already handled.
+func (f *HandlerFactory) UseMessageStore(store MessageStore) {
+	f.messages = store
+}
+
+// UseJobTracker makes every handler report the status of tracked jobs.
+func (f *HandlerFactory) UseJobTracker(tracker JobTracker) {
+	f.tracker = tracker
+}
+
+// UseUserLimiter makes Dispatch return types.ErrThrottled for the jobs of a
+// user already running as many jobs as the limiter allows.
+func (f *HandlerFactory) UseUserLimiter(limiter *UserLimiter) {
+	f.limiter = limiter
+}
+
+// Handlers returns the number of events with a registered handler.
+func (f *HandlerFactory) Handlers() int {
+	return len(f.handlers)
+}
+
+// Stats returns the number of running, succeeded and failed jobs.
+func (f *HandlerFactory) Stats() DispatchStats {
+	return DispatchStats{
+		InFlight:  f.inFlight.Load(),
+		Processed: f.processed.Load(),
+		Failed:    f.failed.Load(),
+	}
+}
+
 // Dispatch finds the handler for payload.Event and calls it.
+// Duplicates are filtered before tracking so a redelivered message can't
+// overwrite the status of a job that already finished. Throttled jobs are
+// turned away before either, so they are neither claimed nor started.
 func (f *HandlerFactory) Dispatch(ctx context.Context, payload types.JobPayload) error {
 	handler, ok := f.handlers[payload.Event]
 	if !ok {
+		f.failed.Add(1)
 		return fmt.Errorf("factory: no handler registered for event %q", payload.Event)
 	}
-	return handler(ctx, payload)
+	if f.limiter != nil {
+		release, ok := f.limiter.acquire(payload)
+		if !ok {
+			jobsThrottledTotal.WithLabelValues(userLabel(payload)).Inc()
+			return fmt.Errorf("factory: event %q of user %d: %w", payload.Event, types.JobUser(payload), types.ErrThrottled)
+		}
+		defer release()
+	}
+	observeQueueWait(payload)
+	if f.tracker != nil {
+		handler = Track(f.tracker, handler)
+	}
+	if f.messages != nil {
+		handler = WithIdempotency(f.messages, handler)
+	}
+
+	f.inFlight.Add(1)
+	jobsInFlight.Inc()
+	err := handler(ctx, payload)
+	jobsInFlight.Dec()
+	f.inFlight.Add(-1)
+	if err != nil {
+		f.failed.Add(1)
+	} else {
+		f.processed.Add(1)
+	}
+	return err
 }
@@ -0,0 +1,37 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+)
+
+func TestHandlerFactory_Stats(t *testing.T) {
+	f := NewHandlerFactory()
+	started, release := make(chan struct{}), make(chan struct{})
+	f.Register(types.EventWelcomeEmail, func(context.Context, types.JobPayload) error {
+		close(started)
+		<-release
+		return nil
+	})
+	f.Register(types.EventGenerateExport, func(context.Context, types.JobPayload) error {
+		return errors.New("export failed")
+	})
+	assert.Equal(t, 2, f.Handlers())
+
+	done := make(chan error)
+	go func() {
+		done <- f.Dispatch(context.Background(), types.JobPayload{Event: types.EventWelcomeEmail})
+	}()
+	<-started
+	assert.Equal(t, DispatchStats{InFlight: 1}, f.Stats())
+	close(release)
+	assert.NoError(t, <-done)
+
+	assert.Error(t, f.Dispatch(context.Background(), types.JobPayload{Event: types.EventGenerateExport}))
+	assert.Error(t, f.Dispatch(context.Background(), types.JobPayload{Event: types.EventReindexSearch}), "no handler")
+	assert.Equal(t, DispatchStats{Processed: 1, Failed: 2}, f.Stats())
+}
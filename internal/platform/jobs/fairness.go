@@ -0,0 +1,86 @@
+package jobs
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+)
+
+var (
+	queueWaitSeconds = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "job_queue_wait_seconds",
+			Help:    "Time from a job's first enqueue until a worker starts it, by user (\"none\" for jobs without a user)",
+			Buckets: []float64{0.1, 0.5, 1, 5, 15, 60, 300, 900, 3600},
+		},
+		[]string{"user"},
+	)
+
+	jobsThrottledTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "job_throttled_total",
+			Help: "Total number of jobs put back in their queue because their user was at the concurrency limit",
+		},
+		[]string{"user"},
+	)
+)
+
+// UserLimiter caps how many jobs of one user run at once, so a user with a
+// burst of jobs (a large import) can't take every worker while other users'
+// jobs wait. Jobs without a user are not limited.
+type UserLimiter struct {
+	limit int
+
+	mu      sync.Mutex
+	running map[int]int
+}
+
+// NewUserLimiter creates a UserLimiter allowing limit running jobs per user.
+func NewUserLimiter(limit int) *UserLimiter {
+	return &UserLimiter{limit: max(limit, 1), running: make(map[int]int)}
+}
+
+// acquire takes a slot for the job's user. It returns false, without
+// waiting, if the user has no slot free; otherwise release frees the slot.
+func (l *UserLimiter) acquire(payload types.JobPayload) (release func(), ok bool) {
+	user := types.JobUser(payload)
+	if user == 0 {
+		return func() {}, true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.running[user] >= l.limit {
+		return nil, false
+	}
+	l.running[user]++
+
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.running[user]--; l.running[user] == 0 {
+			delete(l.running, user)
+		}
+	}, true
+}
+
+// userLabel is the metrics label of the job's user
+func userLabel(payload types.JobPayload) string {
+	if user := types.JobUser(payload); user != 0 {
+		return strconv.Itoa(user)
+	}
+	return "none"
+}
+
+// observeQueueWait records how long the job waited to start. Jobs enqueued
+// before EnqueuedAt existed, and periodic jobs, have no enqueue time.
+func observeQueueWait(payload types.JobPayload) {
+	if payload.EnqueuedAt.IsZero() {
+		return
+	}
+	queueWaitSeconds.WithLabelValues(userLabel(payload)).Observe(time.Since(payload.EnqueuedAt).Seconds())
+}
@@ -0,0 +1,44 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+)
+
+func TestHandlerFactory_UserLimiter(t *testing.T) {
+	f := NewHandlerFactory()
+	f.UseUserLimiter(NewUserLimiter(1))
+	started, release := make(chan struct{}), make(chan struct{})
+	f.Register(types.EventImportActivities, func(context.Context, types.JobPayload) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	})
+	f.Register(types.EventGenerateExport, func(context.Context, types.JobPayload) error { return nil })
+	f.Register(types.EventPurgeSoftDeleted, func(context.Context, types.JobPayload) error { return nil })
+
+	job := func(event types.EventType, data string) types.JobPayload {
+		return types.JobPayload{Event: event, Data: json.RawMessage(data)}
+	}
+	done := make(chan error)
+	go func() {
+		done <- f.Dispatch(context.Background(), job(types.EventImportActivities, `{"user_id":1}`))
+	}()
+	<-started
+
+	// User 1 is at the limit; user 2 and jobs without a user are not
+	err := f.Dispatch(context.Background(), job(types.EventGenerateExport, `{"user_id":1}`))
+	assert.ErrorIs(t, err, types.ErrThrottled)
+	assert.NoError(t, f.Dispatch(context.Background(), job(types.EventGenerateExport, `{"user_id":2}`)))
+	assert.NoError(t, f.Dispatch(context.Background(), job(types.EventPurgeSoftDeleted, `{}`)))
+	assert.Equal(t, DispatchStats{InFlight: 1, Processed: 2}, f.Stats())
+
+	close(release)
+	require.NoError(t, <-done)
+	assert.NoError(t, f.Dispatch(context.Background(), job(types.EventGenerateExport, `{"user_id":1}`)), "the slot is free again")
+}
@@ -0,0 +1,89 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	geocodingTypes "github.com/valentinesamuel/activelog/internal/adapters/geocoding/types"
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	"github.com/valentinesamuel/activelog/internal/models"
+	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
+)
+
+// EnqueueGeocodeActivity schedules geocoding of an activity's location name.
+// The message ID is derived from the activity, so it is enqueued at most once.
+func EnqueueGeocodeActivity(ctx context.Context, queue types.QueueProvider, activityID int64) error {
+	data, err := json.Marshal(GeocodeActivityPayload{ActivityID: activityID})
+	if err != nil {
+		return err
+	}
+	payload := types.JobPayload{
+		Event:     types.EventGeocodeActivity,
+		Data:      data,
+		MessageID: fmt.Sprintf("geocode-%d", activityID),
+	}
+	_, err = queue.Enqueue(ctx, types.QueueFor(payload.Event), payload)
+	return err
+}
+
+// LocationStore reads activities and records geocoded start coordinates
+// (repository.ActivityRepository).
+type LocationStore interface {
+	GetByID(ctx context.Context, id int64) (*models.Activity, error)
+	SetStartLocation(ctx context.Context, id int64, lat, lng float64) (bool, error)
+}
+
+// NewGeocodeActivityHandler returns the handler for EventGeocodeActivity.
+// It resolves the activity's location name to start coordinates. Activities
+// that are gone, have no name or already have coordinates are skipped, so
+// redeliveries are harmless; provider errors other than ErrNotFound are
+// returned so the job is retried. When weatherQueue is set, weather
+// enrichment is enqueued once the coordinates are stored.
+func NewGeocodeActivityHandler(store LocationStore, geocoder geocodingTypes.Geocoder, weatherQueue types.QueueProvider) HandlerFunc {
+	return func(ctx context.Context, payload types.JobPayload) error {
+		var p GeocodeActivityPayload
+		if err := json.Unmarshal(payload.Data, &p); err != nil {
+			return fmt.Errorf("HandleGeocodeActivity: unmarshal: %w", err)
+		}
+
+		activity, err := store.GetByID(ctx, p.ActivityID)
+		if errors.Is(err, appErrors.ErrNotFound) {
+			log.Printf("[job] geocode: activity %d no longer exists, skipping", p.ActivityID)
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("HandleGeocodeActivity: %w", err)
+		}
+		if activity.LocationName == nil || activity.StartLat != nil {
+			return nil
+		}
+
+		place, err := geocoder.Geocode(ctx, *activity.LocationName)
+		if errors.Is(err, geocodingTypes.ErrNotFound) {
+			log.Printf("[job] geocode: no match for activity %d (%q)", p.ActivityID, *activity.LocationName)
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("HandleGeocodeActivity: %w", err)
+		}
+
+		updated, err := store.SetStartLocation(ctx, p.ActivityID, place.Lat, place.Lng)
+		if err != nil {
+			return fmt.Errorf("HandleGeocodeActivity: %w", err)
+		}
+		if !updated {
+			return nil
+		}
+		log.Printf("[job] geocode: activity %d at %.5f,%.5f (%s)", p.ActivityID, place.Lat, place.Lng, place.DisplayName)
+
+		if weatherQueue != nil {
+			if err := EnqueueEnrichWeather(ctx, weatherQueue, p.ActivityID); err != nil {
+				log.Printf("[job] geocode: activity %d: failed to enqueue weather enrichment: %v", p.ActivityID, err)
+			}
+		}
+		return nil
+	}
+}
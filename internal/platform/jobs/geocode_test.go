@@ -0,0 +1,68 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	geocodingTypes "github.com/valentinesamuel/activelog/internal/adapters/geocoding/types"
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	"github.com/valentinesamuel/activelog/internal/models"
+)
+
+type fakeLocationStore struct {
+	activity *models.Activity
+}
+
+func (s *fakeLocationStore) GetByID(_ context.Context, _ int64) (*models.Activity, error) {
+	return s.activity, nil
+}
+
+func (s *fakeLocationStore) SetStartLocation(_ context.Context, _ int64, lat, lng float64) (bool, error) {
+	if s.activity.StartLat != nil {
+		return false, nil
+	}
+	s.activity.StartLat, s.activity.StartLng = &lat, &lng
+	return true, nil
+}
+
+type fakeGeocoder struct {
+	calls int
+}
+
+func (g *fakeGeocoder) Geocode(_ context.Context, _ string) (*geocodingTypes.Place, error) {
+	g.calls++
+	return &geocodingTypes.Place{Lat: 51.5074, Lng: -0.1657}, nil
+}
+
+type recordingQueue struct {
+	payloads []types.JobPayload
+}
+
+func (q *recordingQueue) Enqueue(_ context.Context, _ types.QueueName, payload types.JobPayload) (string, error) {
+	q.payloads = append(q.payloads, payload)
+	return payload.MessageID, nil
+}
+
+func TestGeocodeActivityHandler(t *testing.T) {
+	name := "Hyde Park, London"
+	store := &fakeLocationStore{activity: &models.Activity{LocationName: &name}}
+	geocoder := &fakeGeocoder{}
+	queue := &recordingQueue{}
+	handler := NewGeocodeActivityHandler(store, geocoder, queue)
+
+	data, err := json.Marshal(GeocodeActivityPayload{ActivityID: 9})
+	require.NoError(t, err)
+	payload := types.JobPayload{Event: types.EventGeocodeActivity, Data: data}
+
+	require.NoError(t, handler(context.Background(), payload))
+	require.NoError(t, handler(context.Background(), payload))
+
+	assert.Equal(t, 1, geocoder.calls, "activities with coordinates are not geocoded again")
+	assert.Equal(t, 51.5074, *store.activity.StartLat)
+	require.Len(t, queue.payloads, 1)
+	assert.Equal(t, types.EventEnrichWeather, queue.payloads[0].Event)
+	assert.Equal(t, "weather-9", queue.payloads[0].MessageID)
+}
@@ -24,23 +24,13 @@ func HandleWelcomeEmail(_ context.Context, payload types.JobPayload) error {
 	return nil
 }
 
-// HandleWeeklySummary processes a weekly summary email job.
-func HandleWeeklySummary(_ context.Context, payload types.JobPayload) error {
-	var p WeeklySummaryPayload
-	if err := json.Unmarshal(payload.Data, &p); err != nil {
-		return fmt.Errorf("HandleWeeklySummary: unmarshal: %w", err)
-	}
-	log.Printf("[job] weekly summary -> userID=%d", p.UserID)
-	return nil
-}
-
 // HandleGenerateExport processes a CSV/PDF export generation job.
 func HandleGenerateExport(_ context.Context, payload types.JobPayload) error {
 	var p ExportPayload
 	if err := json.Unmarshal(payload.Data, &p); err != nil {
 		return fmt.Errorf("HandleGenerateExport: unmarshal: %w", err)
 	}
-	log.Printf("[job] generate export -> userID=%d format=%s", p.UserID, p.Format)
+	log.Printf("[job] generate export -> userID=%d format=%s language=%s", p.UserID, p.Format, p.Language)
 	return nil
 }
 
@@ -0,0 +1,232 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+
+	storageTypes "github.com/valentinesamuel/activelog/internal/adapters/storage/types"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/pkg/healthexport"
+)
+
+// healthSourceNames are the app names of the health export sources
+var healthSourceNames = map[models.ImportSource]string{
+	models.ImportSourceAppleHealth: "Apple Health",
+	models.ImportSourceGoogleFit:   "Google Fit",
+}
+
+// healthImport is the state of a health export import: the workouts read
+// and not yet imported, and the counters of the rows so far. Rows are the
+// workouts in file order, from 1.
+type healthImport struct {
+	deps       ImportActivitiesDeps
+	p          ImportActivitiesPayload
+	chunkSize  int
+	visibility string // of the activities imported (see defaultVisibility)
+
+	pending []healthRow
+	seen    map[string]bool // external IDs imported by this import
+
+	rows, imported, failed, skipped int
+	errors                          []models.ImportError
+}
+
+// healthRow is a workout waiting to be imported
+type healthRow struct {
+	row     int
+	workout healthexport.Workout
+}
+
+// importHealthExport imports the workouts of an Apple Health or Google Fit
+// export. The file is read one workout at a time and imported in chunks, so
+// total_rows grows as workouts are found. Workouts the user imported before,
+// found by their source ID (the activity's external ID), are skipped: an import that failed half way can
+// be run again.
+func importHealthExport(ctx context.Context, deps ImportActivitiesDeps, p ImportActivitiesPayload) error {
+	file, size, err := downloadToTemp(ctx, deps.Storage, p.StorageKey)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	imp := &healthImport{
+		deps:      deps,
+		p:         p,
+		chunkSize: deps.ChunkSize,
+		seen:      make(map[string]bool),
+	}
+	if imp.visibility, err = defaultVisibility(ctx, deps, p.UserID); err != nil {
+		return err
+	}
+	if imp.chunkSize <= 0 {
+		imp.chunkSize = repository.DefaultBulkImportChunkSize
+	}
+
+	progress := &readProgress{r: file, size: size}
+	err = healthexport.Read(progress, size, string(p.Source), func(w healthexport.Workout, err error) error {
+		imp.rows++
+		if err != nil {
+			imp.fail(imp.rows, imp.rows, err)
+			return nil
+		}
+		imp.pending = append(imp.pending, healthRow{row: imp.rows, workout: w})
+		if len(imp.pending) < imp.chunkSize {
+			return nil
+		}
+		return imp.flush(ctx, progress.percent())
+	})
+	if err != nil {
+		return fmt.Errorf("read export: %w", err)
+	}
+	if err := imp.flush(ctx, 100); err != nil {
+		return err
+	}
+
+	log.Printf("[job] import %s -> userID=%d source=%s imported=%d skipped=%d failed=%d",
+		p.ImportID, p.UserID, p.Source, imp.imported, imp.skipped, imp.failed)
+	if err := SetResult(ctx, map[string]int{
+		"imported": imp.imported,
+		"skipped":  imp.skipped,
+		"failed":   imp.failed,
+	}); err != nil {
+		return err
+	}
+	return deps.ImportRepo.Complete(ctx, p.ImportID, models.StatusCompleted, imp.errors, nil, deps.now())
+}
+
+// fail records rows first to last as failed with err
+func (imp *healthImport) fail(first, last int, err error) {
+	imp.failed += last - first + 1
+	imp.errors = append(imp.errors, models.ImportError{FirstRow: first, LastRow: last, Message: err.Error()})
+}
+
+// flush imports the pending workouts, skipping those imported before, and
+// records the progress of the import
+func (imp *healthImport) flush(ctx context.Context, percent int) error {
+	source := string(imp.p.Source)
+	sourceIDs := make([]string, len(imp.pending))
+	for i, pending := range imp.pending {
+		sourceIDs[i] = pending.workout.SourceID
+	}
+	existing, err := imp.deps.ActivityRepo.ExistingExternalIDs(ctx, imp.p.UserID, source, sourceIDs)
+	if err != nil {
+		return err
+	}
+
+	// rowOf maps positions in activities back to rows
+	var (
+		activities []*models.Activity
+		rowOf      []int
+	)
+	for _, pending := range imp.pending {
+		sourceID := pending.workout.SourceID
+		if existing[sourceID] || imp.seen[sourceID] {
+			imp.skipped++
+			continue
+		}
+		imp.seen[sourceID] = true
+
+		req := healthWorkoutRequest(pending.workout, imp.p.Source)
+		req.Sanitize()
+		if err := req.ValidateWorkout(); err != nil {
+			imp.fail(pending.row, pending.row, err)
+			continue
+		}
+		activity := req.ToActivity(imp.p.UserID, imp.p.Source)
+		activity.Visibility = imp.visibility
+		activities = append(activities, activity)
+		rowOf = append(rowOf, pending.row)
+	}
+	imp.pending = imp.pending[:0]
+
+	if len(activities) > 0 {
+		result, err := imp.deps.ActivityRepo.BulkImport(ctx, activities, repository.BulkImportOptions{
+			ChunkSize: imp.chunkSize,
+		})
+		if err != nil {
+			return fmt.Errorf("bulk import: %w", err)
+		}
+		imp.imported += result.Imported
+		for _, chunkErr := range result.Errors {
+			imp.fail(rowOf[chunkErr.FirstRow], rowOf[chunkErr.LastRow], chunkErr.Err)
+		}
+	}
+
+	if err := imp.deps.ImportRepo.UpdateProgress(ctx, imp.p.ImportID, imp.rows, imp.rows,
+		imp.imported, imp.failed, imp.skipped); err != nil {
+		return err
+	}
+	ReportProgress(ctx, percent)
+	return nil
+}
+
+// healthWorkoutRequest maps a workout of a health export to an import row
+func healthWorkoutRequest(w healthexport.Workout, source models.ImportSource) models.ImportActivityRequest {
+	description := "Imported from " + healthSourceNames[source]
+	if w.Device != "" {
+		description += " (" + w.Device + ")"
+	}
+	return models.ImportActivityRequest{
+		CreateActivityRequest: models.CreateActivityRequest{
+			ActivityType:    w.ActivityType,
+			Title:           w.Name,
+			Description:     description,
+			DurationMinutes: max(int(math.Round(w.Duration.Minutes())), 1),
+			DistanceKm:      math.Round(w.DistanceKm*1000) / 1000,
+			CaloriesBurned:  int(math.Round(w.Calories)),
+			ActivityDate:    w.Start,
+		},
+		ExternalID: w.SourceID,
+	}
+}
+
+// downloadToTemp copies the object at key into a temporary file, since zip
+// archives are read at random. The caller closes and removes the file.
+func downloadToTemp(ctx context.Context, storage storageTypes.StorageProvider, key string) (*os.File, int64, error) {
+	body, _, err := storage.Download(ctx, key)
+	if err != nil {
+		return nil, 0, fmt.Errorf("download %s: %w", key, err)
+	}
+	defer body.Close()
+
+	file, err := os.CreateTemp("", "import-*")
+	if err != nil {
+		return nil, 0, fmt.Errorf("create temp file: %w", err)
+	}
+	size, err := io.Copy(file, body)
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, 0, fmt.Errorf("download %s: %w", key, err)
+	}
+	return file, size, nil
+}
+
+// readProgress counts the bytes read through it, as a rough measure of how
+// far reading a file has got. An archive's files that aren't read count for
+// nothing, so the percentage stops short of 100.
+type readProgress struct {
+	r    io.ReaderAt
+	size int64
+	read int64
+}
+
+func (p *readProgress) ReadAt(b []byte, off int64) (int, error) {
+	n, err := p.r.ReadAt(b, off)
+	p.read += int64(n)
+	return n, err
+}
+
+// percent returns the share of the file read, up to 99
+func (p *readProgress) percent() int {
+	if p.size == 0 {
+		return 0
+	}
+	return int(min(p.read*100/p.size, 99))
+}
@@ -0,0 +1,118 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/repository/mocks"
+	"github.com/valentinesamuel/activelog/pkg/healthexport"
+	"go.uber.org/mock/gomock"
+)
+
+// sqlConn adapts a *sql.DB to repository.DBConn
+type sqlConn struct{ *sql.DB }
+
+func (c sqlConn) GetRawDB() *sql.DB { return c.DB }
+
+func TestHealthWorkoutRequest(t *testing.T) {
+	start := time.Date(2026, 5, 1, 7, 30, 0, 0, time.UTC)
+	req := healthWorkoutRequest(healthexport.Workout{
+		SourceID:     "abc",
+		Name:         "Running",
+		ActivityType: "running",
+		Start:        start,
+		Duration:     30*time.Minute + 40*time.Second,
+		DistanceKm:   5.01234,
+		Calories:     320.6,
+		Device:       "Apple Watch",
+	}, models.ImportSourceAppleHealth)
+
+	assert.Equal(t, "running", req.ActivityType)
+	assert.Equal(t, "Running", req.Title)
+	assert.Equal(t, "Imported from Apple Health (Apple Watch)", req.Description)
+	assert.Equal(t, 31, req.DurationMinutes)
+	assert.Equal(t, 5.012, req.DistanceKm)
+	assert.Equal(t, 321, req.CaloriesBurned)
+	assert.Equal(t, start, req.ActivityDate)
+	assert.Equal(t, "abc", req.ExternalID)
+	assert.NoError(t, req.ValidateWorkout())
+
+	short := healthWorkoutRequest(healthexport.Workout{Name: "Yoga", ActivityType: "yoga", Start: start, Duration: 10 * time.Second},
+		models.ImportSourceGoogleFit)
+	assert.Equal(t, 1, short.DurationMinutes, "at least a minute")
+	assert.Equal(t, "Imported from Google Fit", short.Description)
+	assert.NoError(t, short.ValidateWorkout(), "no distance")
+	assert.Error(t, short.Validate())
+}
+
+func TestHealthImportFlush(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	activityRepo := mocks.NewMockActivityRepositoryInterface(ctrl)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	start := time.Date(2026, 5, 1, 7, 0, 0, 0, time.UTC)
+	workout := func(id string, minutes int) healthexport.Workout {
+		return healthexport.Workout{SourceID: id, Name: "Running", ActivityType: "running", Start: start,
+			Duration: time.Duration(minutes) * time.Minute}
+	}
+	imp := &healthImport{
+		deps: ImportActivitiesDeps{
+			ActivityRepo: activityRepo,
+			ImportRepo:   repository.NewImportRepository(sqlConn{db}),
+		},
+		p:         ImportActivitiesPayload{ImportID: "imp-1", UserID: 7, Source: models.ImportSourceAppleHealth},
+		chunkSize: 10,
+		seen:      make(map[string]bool),
+		rows:      5,
+		pending: []healthRow{
+			{row: 1, workout: workout("old", 30)},
+			{row: 2, workout: workout("new", 30)},
+			{row: 3, workout: workout("new", 30)},
+			{row: 4, workout: workout("too-long", 2000)},
+			{row: 5, workout: workout("other", 45)},
+		},
+	}
+
+	activityRepo.EXPECT().
+		ExistingExternalIDs(gomock.Any(), 7, "apple_health", []string{"old", "new", "new", "too-long", "other"}).
+		Return(map[string]bool{"old": true}, nil)
+	activityRepo.EXPECT().
+		BulkImport(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, activities []*models.Activity, _ repository.BulkImportOptions) (*repository.BulkImportResult, error) {
+			require.Len(t, activities, 2)
+			assert.Equal(t, "new", *activities[0].ExternalID)
+			assert.Equal(t, "apple_health", activities[0].Source)
+			assert.Equal(t, 7, activities[0].UserID)
+			return &repository.BulkImportResult{
+				Imported: 1,
+				Failed:   1,
+				Errors:   []repository.BulkImportChunkError{{FirstRow: 1, LastRow: 1, Err: assert.AnError}},
+			}, nil
+		})
+	mock.ExpectExec("UPDATE imports").
+		WithArgs(models.StatusProcessing, 5, 5, 1, 2, 2, "imp-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, imp.flush(context.Background(), 50))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// The known and repeated workouts are skipped; the invalid one and the
+	// one whose chunk failed are reported by row
+	assert.Equal(t, 1, imp.imported)
+	assert.Equal(t, 2, imp.skipped)
+	assert.Equal(t, 2, imp.failed)
+	require.Len(t, imp.errors, 2)
+	assert.Equal(t, 4, imp.errors[0].FirstRow)
+	assert.Equal(t, 5, imp.errors[1].FirstRow)
+	assert.Empty(t, imp.pending)
+}
@@ -0,0 +1,68 @@
+package jobs
+
+import (
+	"context"
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+)
+
+var (
+	messagesProcessedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "job_messages_processed_total",
+			Help: "Total number of queue messages claimed and handled by the worker",
+		},
+		[]string{"event"},
+	)
+
+	messagesDuplicateTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "job_messages_duplicate_total",
+			Help: "Total number of redelivered queue messages skipped as duplicates",
+		},
+		[]string{"event"},
+	)
+)
+
+// MessageStore remembers which messages have been handled.
+// It is implemented by repository.ProcessedMessageRepository.
+type MessageStore interface {
+	Claim(ctx context.Context, messageID string, event string) (bool, error)
+	Release(ctx context.Context, messageID string) error
+}
+
+// WithIdempotency wraps next so each message ID is handled at most once.
+//
+// The message is claimed before next runs, so concurrent deliveries of the same
+// message can't both proceed. If next fails the claim is released and the
+// queue's retry handles it again. Payloads without a MessageID (e.g. periodic
+// tasks) are passed straight through.
+func WithIdempotency(store MessageStore, next HandlerFunc) HandlerFunc {
+	return func(ctx context.Context, payload types.JobPayload) error {
+		if payload.MessageID == "" {
+			return next(ctx, payload)
+		}
+
+		claimed, err := store.Claim(ctx, payload.MessageID, string(payload.Event))
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			messagesDuplicateTotal.WithLabelValues(string(payload.Event)).Inc()
+			log.Printf("[job] %s %s: duplicate message, skipping", payload.Event, payload.MessageID)
+			return nil
+		}
+		messagesProcessedTotal.WithLabelValues(string(payload.Event)).Inc()
+
+		if err := next(ctx, payload); err != nil {
+			if rerr := store.Release(ctx, payload.MessageID); rerr != nil {
+				log.Printf("[job] %s %s: failed to release message: %v", payload.Event, payload.MessageID, rerr)
+			}
+			return err
+		}
+		return nil
+	}
+}
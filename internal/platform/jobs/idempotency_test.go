@@ -0,0 +1,83 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+)
+
+// memoryMessageStore is an in-memory MessageStore
+type memoryMessageStore struct {
+	mu      sync.Mutex
+	claimed map[string]bool
+}
+
+func (s *memoryMessageStore) Claim(_ context.Context, messageID string, _ string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.claimed[messageID] {
+		return false, nil
+	}
+	s.claimed[messageID] = true
+	return true, nil
+}
+
+func (s *memoryMessageStore) Release(_ context.Context, messageID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.claimed, messageID)
+	return nil
+}
+
+func TestWithIdempotency_SkipsDuplicates(t *testing.T) {
+	store := &memoryMessageStore{claimed: map[string]bool{}}
+	runs := 0
+	handler := WithIdempotency(store, func(context.Context, types.JobPayload) error {
+		runs++
+		return nil
+	})
+
+	payload := types.JobPayload{Event: types.EventGenerateExport, MessageID: "msg-1"}
+	assert.NoError(t, handler(context.Background(), payload))
+	assert.NoError(t, handler(context.Background(), payload))
+
+	assert.Equal(t, 1, runs)
+}
+
+func TestWithIdempotency_ReleasesFailedMessages(t *testing.T) {
+	store := &memoryMessageStore{claimed: map[string]bool{}}
+	runs := 0
+	handler := WithIdempotency(store, func(context.Context, types.JobPayload) error {
+		runs++
+		if runs == 1 {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	payload := types.JobPayload{Event: types.EventGenerateExport, MessageID: "msg-1"}
+	assert.Error(t, handler(context.Background(), payload))
+	assert.NoError(t, handler(context.Background(), payload))
+
+	assert.Equal(t, 2, runs)
+}
+
+func TestWithIdempotency_PassesThroughWithoutMessageID(t *testing.T) {
+	store := &memoryMessageStore{claimed: map[string]bool{}}
+	runs := 0
+	handler := WithIdempotency(store, func(context.Context, types.JobPayload) error {
+		runs++
+		return nil
+	})
+
+	payload := types.JobPayload{Event: types.EventPurgeSoftDeleted}
+	assert.NoError(t, handler(context.Background(), payload))
+	assert.NoError(t, handler(context.Background(), payload))
+
+	assert.Equal(t, 2, runs)
+	assert.Empty(t, store.claimed)
+}
@@ -0,0 +1,205 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	storageTypes "github.com/valentinesamuel/activelog/internal/adapters/storage/types"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+)
+
+// ImportActivitiesDeps contains the dependencies for the import job handler.
+type ImportActivitiesDeps struct {
+	ActivityRepo repository.ActivityRepositoryInterface
+	ImportRepo   *repository.ImportRepository
+	Storage      storageTypes.StorageProvider
+	ProfileRepo  *repository.ProfileRepository // the user's default visibility; nil imports as followers
+	ChunkSize    int                           // 0 uses repository.DefaultBulkImportChunkSize
+	Queue        types.QueueProvider           // renders map thumbnails of imported tracks; nil skips them
+	Clock        clock.Clock                   // imports are completed at its time; nil uses the real clock
+}
+
+// now returns the time on deps.Clock
+func (deps ImportActivitiesDeps) now() time.Time {
+	if deps.Clock == nil {
+		return time.Now()
+	}
+	return deps.Clock.Now()
+}
+
+// NewImportActivitiesHandler returns the handler for EventImportActivities.
+// It reads the uploaded file from storage, validates every row, COPYs the valid
+// ones in chunks and records progress and per-row errors on the import record.
+func NewImportActivitiesHandler(deps ImportActivitiesDeps) HandlerFunc {
+	return func(ctx context.Context, payload types.JobPayload) error {
+		var p ImportActivitiesPayload
+		if err := json.Unmarshal(payload.Data, &p); err != nil {
+			return fmt.Errorf("HandleImportActivities: unmarshal: %w", err)
+		}
+
+		if err := importActivities(ctx, deps, p); err != nil {
+			msg := err.Error()
+			if cerr := deps.ImportRepo.Complete(ctx, p.ImportID, models.StatusFailed, nil, &msg, deps.now()); cerr != nil {
+				log.Printf("[job] import %s: failed to mark as failed: %v", p.ImportID, cerr)
+			}
+			return fmt.Errorf("HandleImportActivities: %w", err)
+		}
+		return nil
+	}
+}
+
+// importActivities runs one import job end to end
+func importActivities(ctx context.Context, deps ImportActivitiesDeps, p ImportActivitiesPayload) error {
+	if p.Source.IsHealthExport() {
+		return importHealthExport(ctx, deps, p)
+	}
+
+	body, _, err := deps.Storage.Download(ctx, p.StorageKey)
+	if err != nil {
+		return fmt.Errorf("download %s: %w", p.StorageKey, err)
+	}
+	defer body.Close()
+
+	var rows []models.ImportActivityRequest
+	if err := json.NewDecoder(body).Decode(&rows); err != nil {
+		return fmt.Errorf("decode import file: %w", err)
+	}
+	source := p.Source
+	if source == "" {
+		source = models.ImportSourceJSON
+	}
+	existing, err := existingExternalIDs(ctx, deps.ActivityRepo, p.UserID, source, rows)
+	if err != nil {
+		return err
+	}
+	visibility, err := defaultVisibility(ctx, deps, p.UserID)
+	if err != nil {
+		return err
+	}
+
+	// Validate up front; rowIndex maps positions in activities back to file
+	// rows. Rows the user imported before, or that repeat an earlier row, are
+	// skipped by their external ID.
+	var (
+		activities []*models.Activity
+		rowIndex   []int
+		rowErrors  []models.ImportError
+		skipped    int
+	)
+	for i := range rows {
+		if existing[rows[i].ExternalID] {
+			skipped++
+			continue
+		}
+		rows[i].Sanitize()
+		err := rows[i].ParseGPX()
+		if err == nil {
+			err = rows[i].Validate()
+		}
+		if err != nil {
+			rowErrors = append(rowErrors, models.ImportError{FirstRow: i + 1, LastRow: i + 1, Message: err.Error()})
+			continue
+		}
+		activity := rows[i].ToActivity(p.UserID, source)
+		activity.Visibility = visibility
+		activities = append(activities, activity)
+		rowIndex = append(rowIndex, i)
+		if id := rows[i].ExternalID; id != "" {
+			existing[id] = true
+		}
+	}
+	invalid := len(rowErrors)
+
+	if err := deps.ImportRepo.UpdateProgress(ctx, p.ImportID, len(rows), invalid+skipped, 0, invalid, skipped); err != nil {
+		return err
+	}
+
+	result, err := deps.ActivityRepo.BulkImport(ctx, activities, repository.BulkImportOptions{
+		ChunkSize: deps.ChunkSize,
+		OnProgress: func(progress repository.BulkImportProgress) {
+			if err := deps.ImportRepo.UpdateProgress(ctx, p.ImportID, len(rows),
+				invalid+skipped+progress.Processed, progress.Imported, invalid+progress.Failed, skipped); err != nil {
+				log.Printf("[job] import %s: failed to record progress: %v", p.ImportID, err)
+			}
+			ReportProgress(ctx, (invalid+skipped+progress.Processed)*100/len(rows))
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("bulk import: %w", err)
+	}
+
+	for _, chunkErr := range result.Errors {
+		rowErrors = append(rowErrors, models.ImportError{
+			FirstRow: rowIndex[chunkErr.FirstRow] + 1,
+			LastRow:  rowIndex[chunkErr.LastRow] + 1,
+			Message:  chunkErr.Err.Error(),
+		})
+	}
+
+	log.Printf("[job] import %s -> userID=%d imported=%d skipped=%d failed=%d",
+		p.ImportID, p.UserID, result.Imported, skipped, invalid+result.Failed)
+	enqueueMapThumbnails(ctx, deps, p, activities)
+	if err := SetResult(ctx, map[string]int{
+		"imported": result.Imported,
+		"skipped":  skipped,
+		"failed":   invalid + result.Failed,
+	}); err != nil {
+		return err
+	}
+	return deps.ImportRepo.Complete(ctx, p.ImportID, models.StatusCompleted, rowErrors, nil, deps.now())
+}
+
+// enqueueMapThumbnails has map thumbnails rendered for the imported
+// activities that have a GPS track. Activities of failed chunks have no ID
+// and are left out. Failures are logged; the import already succeeded.
+func enqueueMapThumbnails(ctx context.Context, deps ImportActivitiesDeps, p ImportActivitiesPayload, activities []*models.Activity) {
+	if deps.Queue == nil {
+		return
+	}
+	var ids []int64
+	for _, a := range activities {
+		if a.ID != 0 && len(a.Track) > 0 {
+			ids = append(ids, a.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	if err := EnqueueRenderMapThumbnails(ctx, deps.Queue, p.UserID, ids); err != nil {
+		log.Printf("[job] import %s: failed to enqueue map thumbnails: %v", p.ImportID, err)
+	}
+}
+
+// defaultVisibility returns the visibility the user's imported activities
+// get, as if they were logged through the API
+func defaultVisibility(ctx context.Context, deps ImportActivitiesDeps, userID int) (string, error) {
+	if deps.ProfileRepo == nil {
+		return models.VisibilityFollowers, nil
+	}
+	visibility, err := deps.ProfileRepo.GetDefaultVisibility(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("look up default visibility: %w", err)
+	}
+	return visibility, nil
+}
+
+// existingExternalIDs returns the external IDs of rows that the user already
+// has an activity of source with
+func existingExternalIDs(ctx context.Context, repo repository.ActivityRepositoryInterface, userID int, source models.ImportSource, rows []models.ImportActivityRequest) (map[string]bool, error) {
+	var ids []string
+	for _, row := range rows {
+		if row.ExternalID != "" {
+			ids = append(ids, row.ExternalID)
+		}
+	}
+	if len(ids) == 0 {
+		return make(map[string]bool), nil
+	}
+	return repo.ExistingExternalIDs(ctx, userID, string(source), ids)
+}
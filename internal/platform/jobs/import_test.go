@@ -0,0 +1,123 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	storageTypes "github.com/valentinesamuel/activelog/internal/adapters/storage/types"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/repository/mocks"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+	"go.uber.org/mock/gomock"
+)
+
+func (s *fakeStorage) Download(_ context.Context, key string) (io.ReadCloser, *storageTypes.FileMetadata, error) {
+	data, ok := s.files[key]
+	if !ok {
+		return nil, nil, fmt.Errorf("no file %s", key)
+	}
+	return io.NopCloser(bytes.NewReader(data)), &storageTypes.FileMetadata{Key: key}, nil
+}
+
+// importErrorsArg matches the errors column written by ImportRepository.Complete
+type importErrorsArg struct {
+	t    *testing.T
+	want []models.ImportError
+}
+
+func (a importErrorsArg) Match(v driver.Value) bool {
+	data, ok := v.([]byte)
+	if !ok {
+		return false
+	}
+	var got []models.ImportError
+	require.NoError(a.t, json.Unmarshal(data, &got))
+	return assert.Equal(a.t, a.want, got)
+}
+
+func importRow(externalID, title string) map[string]any {
+	return map[string]any{
+		"activityType":    "running",
+		"title":           title,
+		"description":     "Easy run",
+		"durationMinutes": 30,
+		"distanceKm":      5,
+		"activityDate":    "2025-03-01T07:00:00Z",
+		"externalId":      externalID,
+	}
+}
+
+// The import reports rows by their line in the file, although invalid and
+// skipped rows never reach BulkImport, whose chunk errors index the rows it
+// got
+func TestImportActivities_RowErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	activityRepo := mocks.NewMockActivityRepositoryInterface(ctrl)
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	file, err := json.Marshal([]map[string]any{
+		importRow("a", "Run 1"),
+		importRow("bad", ""), // no title
+		importRow("old", "Run 3"),
+		importRow("b", "Run 4"),
+		importRow("c", "Run 5"),
+		importRow("d", "Run 6"),
+	})
+	require.NoError(t, err)
+	storage := &fakeStorage{files: map[string][]byte{"imports/imp-1.json": file}}
+	completedAt := time.Date(2026, 5, 1, 7, 0, 0, 0, time.UTC)
+
+	activityRepo.EXPECT().
+		ExistingExternalIDs(gomock.Any(), 7, "json", []string{"a", "bad", "old", "b", "c", "d"}).
+		Return(map[string]bool{"old": true}, nil)
+	activityRepo.EXPECT().
+		BulkImport(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, activities []*models.Activity, opts repository.BulkImportOptions) (*repository.BulkImportResult, error) {
+			require.Len(t, activities, 4)
+			assert.Equal(t, 2, opts.ChunkSize)
+			// The second chunk, the fifth and sixth rows, fails
+			activities[0].ID, activities[1].ID = 101, 102
+			opts.OnProgress(repository.BulkImportProgress{Total: 4, Processed: 2, Imported: 2})
+			opts.OnProgress(repository.BulkImportProgress{Total: 4, Processed: 4, Imported: 2, Failed: 2})
+			return &repository.BulkImportResult{
+				Imported: 2,
+				Failed:   2,
+				Errors:   []repository.BulkImportChunkError{{Chunk: 1, FirstRow: 2, LastRow: 3, Err: assert.AnError}},
+			}, nil
+		})
+
+	// total, processed, imported, failed and skipped rows
+	for _, progress := range [][]driver.Value{{6, 2, 0, 1, 1}, {6, 4, 2, 1, 1}, {6, 6, 2, 3, 1}} {
+		mock.ExpectExec("UPDATE imports SET status").
+			WithArgs(append([]driver.Value{models.StatusProcessing}, append(progress, "imp-1")...)...).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectExec("UPDATE imports SET status .* errors").
+		WithArgs(models.StatusCompleted, importErrorsArg{t: t, want: []models.ImportError{
+			{FirstRow: 2, LastRow: 2, Message: "Key: 'ImportActivityRequest.CreateActivityRequest.Title' Error:Field validation for 'Title' failed on the 'required' tag"},
+			{FirstRow: 5, LastRow: 6, Message: assert.AnError.Error()},
+		}}, nil, completedAt, "imp-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = importActivities(context.Background(), ImportActivitiesDeps{
+		ActivityRepo: activityRepo,
+		ImportRepo:   repository.NewImportRepository(sqlConn{db}),
+		Storage:      storage,
+		ChunkSize:    2,
+		Clock:        clock.NewFake(completedAt),
+	}, ImportActivitiesPayload{ImportID: "imp-1", UserID: 7, StorageKey: "imports/imp-1.json"})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,144 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	storageTypes "github.com/valentinesamuel/activelog/internal/adapters/storage/types"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/service"
+	"github.com/valentinesamuel/activelog/pkg/polyline"
+	"github.com/valentinesamuel/activelog/pkg/staticmap"
+)
+
+// mapThumbnailSize is the width and height of map thumbnails in pixels
+const mapThumbnailSize = 256
+
+// MapThumbnailStore reads activity tracks and records their map thumbnails
+// (repository.ActivityTrackRepository).
+type MapThumbnailStore interface {
+	ListTrackedActivityIDs(ctx context.Context, userID int, activityIDs []int64) ([]int64, error)
+	Get(ctx context.Context, activityID int64, resolution models.TrackResolution) (*models.ActivityTrack, error)
+	SetThumbnail(ctx context.Context, activityID int64, storageKey string) error
+	DeleteThumbnail(ctx context.Context, activityID int64) (string, error)
+}
+
+// PrivacyZoneLister lists a user's privacy zones (repository.PrivacyZoneRepository).
+type PrivacyZoneLister interface {
+	ListByUser(ctx context.Context, userID int) ([]*models.PrivacyZone, error)
+}
+
+// RenderMapThumbnailsDeps contains the dependencies for the map thumbnail job handler.
+type RenderMapThumbnailsDeps struct {
+	Tracks  MapThumbnailStore
+	Zones   PrivacyZoneLister
+	Storage storageTypes.StorageProvider
+}
+
+// EnqueueRenderMapThumbnails schedules rendering the map thumbnails of the
+// user's activities in activityIDs, or of all of them when it's empty (e.g.
+// after their privacy zones change). There is no message ID: every change
+// needs a fresh rendering.
+func EnqueueRenderMapThumbnails(ctx context.Context, queue types.QueueProvider, userID int, activityIDs []int64) error {
+	data, err := json.Marshal(RenderMapThumbnailsPayload{UserID: userID, ActivityIDs: activityIDs})
+	if err != nil {
+		return err
+	}
+	payload := types.JobPayload{Event: types.EventRenderMapThumbnails, Data: data}
+	_, err = queue.Enqueue(ctx, types.QueueFor(payload.Event), payload)
+	return err
+}
+
+// NewRenderMapThumbnailsHandler returns the handler for
+// EventRenderMapThumbnails. It draws each activity's GPS track, with the
+// parts within the user's privacy zones cut out, as a PNG (see
+// pkg/staticmap) and stores it at a fixed key per activity, replacing the
+// previous rendering. An activity whose whole track lies within zones loses
+// its thumbnail. Reruns render the same images, so retries are harmless.
+func NewRenderMapThumbnailsHandler(deps RenderMapThumbnailsDeps) HandlerFunc {
+	return func(ctx context.Context, payload types.JobPayload) error {
+		var p RenderMapThumbnailsPayload
+		if err := json.Unmarshal(payload.Data, &p); err != nil {
+			return fmt.Errorf("HandleRenderMapThumbnails: unmarshal: %w", err)
+		}
+		if p.UserID == 0 {
+			return fmt.Errorf("HandleRenderMapThumbnails: missing user_id")
+		}
+
+		// Only the user's own activities that still have a track
+		ids, err := deps.Tracks.ListTrackedActivityIDs(ctx, p.UserID, p.ActivityIDs)
+		if err != nil {
+			return fmt.Errorf("HandleRenderMapThumbnails: %w", err)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		zones, err := deps.Zones.ListByUser(ctx, p.UserID)
+		if err != nil {
+			return fmt.Errorf("HandleRenderMapThumbnails: list privacy zones: %w", err)
+		}
+
+		rendered := 0
+		for _, id := range ids {
+			ok, err := renderMapThumbnail(ctx, deps, id, zones)
+			if err != nil {
+				return fmt.Errorf("HandleRenderMapThumbnails: activity %d: %w", id, err)
+			}
+			if ok {
+				rendered++
+			}
+		}
+		log.Printf("[job] map thumbnails: rendered %d of %d activities of user %d", rendered, len(ids), p.UserID)
+		return nil
+	}
+}
+
+// renderMapThumbnail renders and stores the thumbnail of one activity, or
+// removes it when nothing of the track is left to draw. It reports whether
+// a thumbnail was stored.
+func renderMapThumbnail(ctx context.Context, deps RenderMapThumbnailsDeps, activityID int64, zones []*models.PrivacyZone) (bool, error) {
+	track, err := deps.Tracks.Get(ctx, activityID, models.TrackResolutionFull)
+	if err != nil {
+		return false, err
+	}
+	points, err := polyline.Decode(track.Polyline)
+	if err != nil {
+		return false, err
+	}
+
+	// Trim before simplifying, so no simplified line cuts across a zone
+	var segments [][]polyline.Point
+	for _, segment := range service.TrimPrivacyZones(points, zones) {
+		segments = append(segments, polyline.Simplify(segment, models.TrackResolutionLow.ToleranceM()))
+	}
+	if len(segments) == 0 {
+		key, err := deps.Tracks.DeleteThumbnail(ctx, activityID)
+		if err != nil || key == "" {
+			return false, err
+		}
+		return false, deps.Storage.Delete(ctx, key)
+	}
+
+	image, err := staticmap.RenderPNG(segments, mapThumbnailSize)
+	if err != nil {
+		return false, err
+	}
+	key := fmt.Sprintf("activities/%d/map.png", activityID)
+	if _, err := deps.Storage.Upload(ctx, &storageTypes.UploadInput{
+		Key:         key,
+		Body:        bytes.NewReader(image),
+		ContentType: "image/png",
+		Size:        int64(len(image)),
+		Metadata: map[string]string{
+			"activity_id": fmt.Sprintf("%d", activityID),
+			"type":        "map_thumbnail",
+		},
+	}); err != nil {
+		return false, fmt.Errorf("upload %s: %w", key, err)
+	}
+	return true, deps.Tracks.SetThumbnail(ctx, activityID, key)
+}
@@ -0,0 +1,110 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	storageTypes "github.com/valentinesamuel/activelog/internal/adapters/storage/types"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/pkg/polyline"
+)
+
+type fakeThumbnailStore struct {
+	tracks     map[int64]string // activity ID -> full track polyline
+	thumbnails map[int64]string
+}
+
+func (s *fakeThumbnailStore) ListTrackedActivityIDs(_ context.Context, _ int, activityIDs []int64) ([]int64, error) {
+	var ids []int64
+	for id := range s.tracks {
+		if len(activityIDs) == 0 || slices.Contains(activityIDs, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (s *fakeThumbnailStore) Get(_ context.Context, activityID int64, resolution models.TrackResolution) (*models.ActivityTrack, error) {
+	return &models.ActivityTrack{ActivityID: activityID, Resolution: resolution, Polyline: s.tracks[activityID]}, nil
+}
+
+func (s *fakeThumbnailStore) SetThumbnail(_ context.Context, activityID int64, storageKey string) error {
+	s.thumbnails[activityID] = storageKey
+	return nil
+}
+
+func (s *fakeThumbnailStore) DeleteThumbnail(_ context.Context, activityID int64) (string, error) {
+	key := s.thumbnails[activityID]
+	delete(s.thumbnails, activityID)
+	return key, nil
+}
+
+type fakeZoneLister struct {
+	zones []*models.PrivacyZone
+}
+
+func (l *fakeZoneLister) ListByUser(_ context.Context, _ int) ([]*models.PrivacyZone, error) {
+	return l.zones, nil
+}
+
+// fakeStorage keeps uploads in memory; other methods aren't used
+type fakeStorage struct {
+	storageTypes.StorageProvider
+	files map[string][]byte
+}
+
+func (s *fakeStorage) Upload(_ context.Context, input *storageTypes.UploadInput) (*storageTypes.UploadOutput, error) {
+	data, err := io.ReadAll(input.Body)
+	if err != nil {
+		return nil, err
+	}
+	s.files[input.Key] = data
+	return &storageTypes.UploadOutput{Key: input.Key}, nil
+}
+
+func (s *fakeStorage) Delete(_ context.Context, key string) error {
+	delete(s.files, key)
+	return nil
+}
+
+func TestRenderMapThumbnailsHandler(t *testing.T) {
+	// A run from home (~220 m out) and a walk around home
+	run := polyline.Encode([]polyline.Point{{Lat: 51.5000, Lng: -0.12}, {Lat: 51.5020, Lng: -0.12}, {Lat: 51.5100, Lng: -0.12}})
+	walk := polyline.Encode([]polyline.Point{{Lat: 51.5000, Lng: -0.12}, {Lat: 51.5005, Lng: -0.12}})
+	store := &fakeThumbnailStore{tracks: map[int64]string{1: run, 2: walk}, thumbnails: map[int64]string{}}
+	storage := &fakeStorage{files: map[string][]byte{}}
+	zones := &fakeZoneLister{}
+	handler := NewRenderMapThumbnailsHandler(RenderMapThumbnailsDeps{Tracks: store, Zones: zones, Storage: storage})
+
+	render := func(activityIDs ...int64) {
+		t.Helper()
+		data, err := json.Marshal(RenderMapThumbnailsPayload{UserID: 7, ActivityIDs: activityIDs})
+		require.NoError(t, err)
+		require.NoError(t, handler(context.Background(), types.JobPayload{Event: types.EventRenderMapThumbnails, Data: data}))
+	}
+
+	render(1)
+	assert.Equal(t, map[int64]string{1: "activities/1/map.png"}, store.thumbnails)
+	assert.Equal(t, "\x89PNG", string(storage.files["activities/1/map.png"][:4]))
+
+	// Adding a zone around home renders everything again; the walk is
+	// entirely within it, so it has no thumbnail
+	zones.zones = []*models.PrivacyZone{{Lat: 51.5000, Lng: -0.12, RadiusM: 100}}
+	store.thumbnails[2] = "activities/2/map.png"
+	storage.files["activities/2/map.png"] = []byte("stale")
+	render()
+	assert.Equal(t, map[int64]string{1: "activities/1/map.png"}, store.thumbnails)
+	assert.NotContains(t, storage.files, "activities/2/map.png")
+}
+
+func TestRenderMapThumbnailsHandler_MissingUser(t *testing.T) {
+	handler := NewRenderMapThumbnailsHandler(RenderMapThumbnailsDeps{})
+	err := handler(context.Background(), types.JobPayload{Event: types.EventRenderMapThumbnails, Data: []byte(`{}`)})
+	assert.Error(t, err)
+}
@@ -0,0 +1,120 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/service"
+)
+
+// metricsBatchSize is how many activities are read per query when recomputing metrics
+const metricsBatchSize = 500
+
+// MetricsStore reads activities with their owner's weight and stores derived
+// metrics (repository.ActivityRepository).
+type MetricsStore interface {
+	ListMetricsRows(ctx context.Context, userID int, belowVersion int, afterID int64, limit int) ([]repository.MetricsRow, error)
+	SaveMetrics(ctx context.Context, activity *models.Activity) error
+}
+
+// EnqueueRecalculateUserMetrics schedules recomputing the derived metrics of
+// all of the user's activities.
+func EnqueueRecalculateUserMetrics(ctx context.Context, queue types.QueueProvider, userID int) error {
+	data, err := json.Marshal(RecalculateUserMetricsPayload{UserID: userID})
+	if err != nil {
+		return err
+	}
+	payload := types.JobPayload{Event: types.EventRecalculateUserMetrics, Data: data}
+	_, err = queue.Enqueue(ctx, types.QueueFor(payload.Event), payload)
+	return err
+}
+
+// NewBackfillMetricsHandler returns the handler for EventBackfillActivityMetrics.
+// It recomputes the metrics of every activity stored with an older formula
+// version than service.MetricsVersion (or reset by a bulk update).
+func NewBackfillMetricsHandler(store MetricsStore) HandlerFunc {
+	return func(ctx context.Context, _ types.JobPayload) error {
+		updated, err := recalculateMetrics(ctx, store, 0)
+		if err != nil {
+			return fmt.Errorf("HandleBackfillActivityMetrics: %w", err)
+		}
+		log.Printf("[job] metrics backfill: updated %d activities", updated)
+		return nil
+	}
+}
+
+// NewRecalculateUserMetricsHandler returns the handler for
+// EventRecalculateUserMetrics. It recomputes the metrics of all of the user's
+// activities, which changes calorie estimates after a weight change.
+func NewRecalculateUserMetricsHandler(store MetricsStore) HandlerFunc {
+	return func(ctx context.Context, payload types.JobPayload) error {
+		var p RecalculateUserMetricsPayload
+		if err := json.Unmarshal(payload.Data, &p); err != nil {
+			return fmt.Errorf("HandleRecalculateUserMetrics: unmarshal: %w", err)
+		}
+		if p.UserID == 0 {
+			return fmt.Errorf("HandleRecalculateUserMetrics: missing user_id")
+		}
+
+		updated, err := recalculateMetrics(ctx, store, p.UserID)
+		if err != nil {
+			return fmt.Errorf("HandleRecalculateUserMetrics: %w", err)
+		}
+		log.Printf("[job] metrics: updated %d activities of user %d", updated, p.UserID)
+		return nil
+	}
+}
+
+// recalculateMetrics recomputes the metrics of the user's activities (userID
+// 0: all stale ones) and returns how many changed. Unchanged rows are not
+// written, so reruns are cheap.
+func recalculateMetrics(ctx context.Context, store MetricsStore, userID int) (int, error) {
+	var (
+		afterID int64
+		updated int
+	)
+	for {
+		rows, err := store.ListMetricsRows(ctx, userID, service.MetricsVersion, afterID, metricsBatchSize)
+		if err != nil {
+			return updated, err
+		}
+
+		for _, row := range rows {
+			afterID = row.Activity.ID
+			before := *row.Activity
+			service.ApplyMetrics(row.Activity, row.WeightKg)
+			if metricsEqual(&before, row.Activity) {
+				continue
+			}
+			if err := store.SaveMetrics(ctx, row.Activity); err != nil {
+				return updated, err
+			}
+			updated++
+		}
+
+		if len(rows) < metricsBatchSize {
+			return updated, nil
+		}
+	}
+}
+
+// metricsEqual reports whether a and b have the same derived metrics
+func metricsEqual(a, b *models.Activity) bool {
+	return a.CaloriesBurned == b.CaloriesBurned &&
+		a.CaloriesEstimated == b.CaloriesEstimated &&
+		a.MetricsVersion == b.MetricsVersion &&
+		equalFloatPtr(a.PaceMinPerKm, b.PaceMinPerKm) &&
+		equalFloatPtr(a.AvgSpeedKmh, b.AvgSpeedKmh)
+}
+
+func equalFloatPtr(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,90 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+)
+
+// PartitionMaintainer creates and archives the monthly partitions of the
+// activities table (repository.PartitionRepository).
+type PartitionMaintainer interface {
+	ListActivityPartitions(ctx context.Context) ([]repository.ActivityPartition, error)
+	CreateActivityPartition(ctx context.Context, partition repository.ActivityPartition) error
+	ArchiveActivityPartition(ctx context.Context, partition repository.ActivityPartition) (int64, error)
+}
+
+// PartitionPolicy says which activity partitions should exist.
+type PartitionPolicy struct {
+	// MonthsAhead is the number of future months to create partitions for
+	MonthsAhead int
+
+	// RetentionMonths is how many months before the current one stay live;
+	// older partitions are archived. 0 disables archiving.
+	RetentionMonths int
+}
+
+// NewMaintainPartitionsHandler returns the handler for EventMaintainPartitions.
+// It creates the partitions the policy calls for and archives expired ones;
+// clk decides the current month.
+func NewMaintainPartitionsHandler(partitions PartitionMaintainer, policy PartitionPolicy, clk clock.Clock) HandlerFunc {
+	return func(ctx context.Context, _ types.JobPayload) error {
+		existing, err := partitions.ListActivityPartitions(ctx)
+		if err != nil {
+			return fmt.Errorf("HandleMaintainPartitions: %w", err)
+		}
+
+		create, archive := planPartitions(clk.Now(), existing, policy)
+
+		for _, partition := range create {
+			if err := partitions.CreateActivityPartition(ctx, partition); err != nil {
+				return fmt.Errorf("HandleMaintainPartitions: %w", err)
+			}
+			log.Printf("[job] partitions: created %s", partition.Name)
+		}
+
+		for _, partition := range archive {
+			archived, err := partitions.ArchiveActivityPartition(ctx, partition)
+			if err != nil {
+				return fmt.Errorf("HandleMaintainPartitions: %w", err)
+			}
+			log.Printf("[job] partitions: archived %s (%d activities)", partition.Name, archived)
+		}
+
+		return nil
+	}
+}
+
+// planPartitions returns the partitions to create (the current month and
+// MonthsAhead after it, where missing) and the existing ones to archive
+// (those ending before the retention window), oldest first.
+func planPartitions(now time.Time, existing []repository.ActivityPartition, policy PartitionPolicy) (create, archive []repository.ActivityPartition) {
+	current := repository.ActivityPartitionFor(now.UTC())
+
+	have := make(map[string]bool, len(existing))
+	for _, partition := range existing {
+		have[partition.Name] = true
+	}
+	for i := 0; i <= policy.MonthsAhead; i++ {
+		partition := repository.ActivityPartitionFor(current.From.AddDate(0, i, 0))
+		if !have[partition.Name] {
+			create = append(create, partition)
+		}
+	}
+
+	if policy.RetentionMonths > 0 {
+		cutoff := current.From.AddDate(0, -policy.RetentionMonths, 0)
+		for _, partition := range existing {
+			if !partition.To.After(cutoff) {
+				archive = append(archive, partition)
+			}
+		}
+	}
+
+	return create, archive
+}
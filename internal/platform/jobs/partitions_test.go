@@ -0,0 +1,77 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+)
+
+func TestPlanPartitions(t *testing.T) {
+	now := time.Date(2026, time.March, 31, 12, 0, 0, 0, time.UTC)
+	month := func(year int, m time.Month) repository.ActivityPartition {
+		return repository.ActivityPartitionFor(time.Date(year, m, 1, 0, 0, 0, 0, time.UTC))
+	}
+
+	existing := []repository.ActivityPartition{
+		month(2025, time.January),
+		month(2025, time.February),
+		month(2025, time.March),
+		month(2026, time.March),
+		month(2026, time.April),
+	}
+
+	create, archive := planPartitions(now, existing, PartitionPolicy{MonthsAhead: 2, RetentionMonths: 12})
+
+	assert.Equal(t, []repository.ActivityPartition{month(2026, time.May)}, create)
+	assert.Equal(t, []repository.ActivityPartition{month(2025, time.January), month(2025, time.February)}, archive)
+	assert.Equal(t, "activities_p2026_05", create[0].Name)
+}
+
+func TestPlanPartitions_RetentionDisabled(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	existing := []repository.ActivityPartition{repository.ActivityPartitionFor(time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC))}
+
+	create, archive := planPartitions(now, existing, PartitionPolicy{})
+
+	assert.Equal(t, []repository.ActivityPartition{repository.ActivityPartitionFor(now)}, create)
+	assert.Empty(t, archive)
+}
+
+// fakePartitions records the partitions created and archived
+type fakePartitions struct {
+	existing []repository.ActivityPartition
+	created  []repository.ActivityPartition
+	archived []repository.ActivityPartition
+}
+
+func (f *fakePartitions) ListActivityPartitions(context.Context) ([]repository.ActivityPartition, error) {
+	return f.existing, nil
+}
+
+func (f *fakePartitions) CreateActivityPartition(_ context.Context, partition repository.ActivityPartition) error {
+	f.created = append(f.created, partition)
+	return nil
+}
+
+func (f *fakePartitions) ArchiveActivityPartition(_ context.Context, partition repository.ActivityPartition) (int64, error) {
+	f.archived = append(f.archived, partition)
+	return 0, nil
+}
+
+func TestMaintainPartitionsHandler_UsesClock(t *testing.T) {
+	partitions := &fakePartitions{}
+	clk := clock.NewFake(time.Date(2030, time.November, 15, 0, 0, 0, 0, time.UTC))
+
+	handler := NewMaintainPartitionsHandler(partitions, PartitionPolicy{MonthsAhead: 1}, clk)
+	require.NoError(t, handler(context.Background(), types.JobPayload{}))
+
+	require.Len(t, partitions.created, 2)
+	assert.Equal(t, "activities_p2030_11", partitions.created[0].Name)
+	assert.Equal(t, "activities_p2030_12", partitions.created[1].Name)
+}
@@ -0,0 +1,137 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+)
+
+// ScheduledJob declares a periodic job run by the worker's scheduler.
+type ScheduledJob struct {
+	Name  string
+	Spec  string // cron expression, evaluated in UTC
+	Event types.EventType
+
+	// Jitter delays each run by a random duration up to Jitter so that
+	// instances and jobs sharing a schedule don't hit the database at once
+	Jitter time.Duration
+
+	// MaxRuntime bounds a run. It is also how long the queue refuses a second
+	// copy of the job, so runs never overlap.
+	MaxRuntime time.Duration
+}
+
+// Schedule is the registry of every periodic job.
+//
+// The weekly summary runs hourly from Saturday to Monday UTC; each run picks
+// the timezone cohort for which it is currently Sunday 20:00, so every user
+// gets their summary on Sunday night local time.
+var Schedule = []ScheduledJob{
+	{
+		Name:       "weekly-summary",
+		Spec:       "0 * * * 6,0,1",
+		Event:      types.EventScheduleWeeklySummaries,
+		Jitter:     5 * time.Minute,
+		MaxRuntime: 50 * time.Minute,
+	},
+	{
+		Name:       "purge-soft-deleted",
+		Spec:       "0 2 * * *",
+		Event:      types.EventPurgeSoftDeleted,
+		Jitter:     10 * time.Minute,
+		MaxRuntime: time.Hour,
+	},
+	{
+		Name:       "webhook-retry-sweep",
+		Spec:       "0 * * * *",
+		Event:      types.EventWebhookRetrySweep,
+		Jitter:     2 * time.Minute,
+		MaxRuntime: 30 * time.Minute,
+	},
+	{
+		Name:       "maintain-partitions",
+		Spec:       "30 3 * * *",
+		Event:      types.EventMaintainPartitions,
+		Jitter:     10 * time.Minute,
+		MaxRuntime: time.Hour,
+	},
+	{
+		Name:       "purge-deleted-accounts",
+		Spec:       "0 4 * * *",
+		Event:      types.EventPurgeDeletedAccounts,
+		Jitter:     10 * time.Minute,
+		MaxRuntime: time.Hour,
+	},
+	{
+		Name:       "backfill-activity-metrics",
+		Spec:       "0 5 * * *",
+		Event:      types.EventBackfillActivityMetrics,
+		Jitter:     10 * time.Minute,
+		MaxRuntime: time.Hour,
+	},
+	{
+		Name:       "refresh-stats-summaries",
+		Spec:       "*/15 * * * *",
+		Event:      types.EventRefreshStatsSummaries,
+		Jitter:     time.Minute,
+		MaxRuntime: 10 * time.Minute,
+	},
+	{
+		Name:       "refresh-challenge-progress",
+		Spec:       "*/15 * * * *",
+		Event:      types.EventRefreshChallengeProgress,
+		Jitter:     time.Minute,
+		MaxRuntime: 10 * time.Minute,
+	},
+}
+
+// PeriodicTasks converts Schedule into the tasks registered with the queue scheduler.
+func PeriodicTasks() []types.PeriodicTask {
+	tasks := make([]types.PeriodicTask, 0, len(Schedule))
+	for _, job := range Schedule {
+		tasks = append(tasks, types.PeriodicTask{
+			Spec:   job.Spec,
+			Event:  job.Event,
+			Unique: job.Jitter + job.MaxRuntime,
+		})
+	}
+	return tasks
+}
+
+// Guard wraps handler with the job's jitter, runtime limit and in-process
+// overlap protection: a run that starts while the previous one is still going
+// is skipped. Across instances the queue's uniqueness lock does the same.
+func (j ScheduledJob) Guard(handler HandlerFunc) HandlerFunc {
+	var running atomic.Bool
+
+	return func(ctx context.Context, payload types.JobPayload) error {
+		if !running.CompareAndSwap(false, true) {
+			log.Printf("[schedule] %s: previous run still in progress, skipping", j.Name)
+			return nil
+		}
+		defer running.Store(false)
+
+		if j.Jitter > 0 {
+			select {
+			case <-time.After(rand.N(j.Jitter)):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if j.MaxRuntime > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, j.MaxRuntime)
+			defer cancel()
+		}
+
+		start := time.Now()
+		err := handler(ctx, payload)
+		log.Printf("[schedule] %s finished in %v (err=%v)", j.Name, time.Since(start), err)
+		return err
+	}
+}
@@ -0,0 +1,117 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/service"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+)
+
+// weeklySummaryHour is the local hour on Sunday at which weekly summaries go out.
+const weeklySummaryHour = 20
+
+// NewScheduleWeeklySummariesHandler returns the handler for EventScheduleWeeklySummaries.
+// It enqueues a WeeklySummary job for every user whose local time is currently
+// Sunday between 20:00 and 20:59 by clk.
+func NewScheduleWeeklySummariesHandler(users *repository.UserRepository, queue types.QueueProvider, clk clock.Clock) HandlerFunc {
+	return func(ctx context.Context, _ types.JobPayload) error {
+		timezones, err := users.ListTimezones(ctx)
+		if err != nil {
+			return fmt.Errorf("HandleScheduleWeeklySummaries: list timezones: %w", err)
+		}
+
+		cohort := weeklySummaryCohort(clk.Now(), timezones)
+		if len(cohort) == 0 {
+			return nil
+		}
+
+		userIDs, err := users.ListIDsByTimezones(ctx, cohort)
+		if err != nil {
+			return fmt.Errorf("HandleScheduleWeeklySummaries: list users: %w", err)
+		}
+
+		for _, userID := range userIDs {
+			data, err := json.Marshal(WeeklySummaryPayload{UserID: userID})
+			if err != nil {
+				return fmt.Errorf("HandleScheduleWeeklySummaries: marshal: %w", err)
+			}
+			payload := types.JobPayload{Event: types.EventWeeklySummary, Data: data}
+			if _, err := queue.Enqueue(ctx, types.QueueFor(payload.Event), payload); err != nil {
+				return fmt.Errorf("HandleScheduleWeeklySummaries: enqueue userID=%d: %w", userID, err)
+			}
+		}
+
+		log.Printf("[job] weekly summaries -> timezones=%v users=%d", cohort, len(userIDs))
+		return nil
+	}
+}
+
+// weeklySummaryCohort returns the timezones in which now falls on Sunday during weeklySummaryHour.
+// Unknown zone names are skipped.
+func weeklySummaryCohort(now time.Time, timezones []string) []string {
+	var cohort []string
+	for _, name := range timezones {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			log.Printf("[job] weekly summaries: skipping unknown timezone %q", name)
+			continue
+		}
+		local := now.In(loc)
+		if local.Weekday() == time.Sunday && local.Hour() == weeklySummaryHour {
+			cohort = append(cohort, name)
+		}
+	}
+	return cohort
+}
+
+// NewPurgeSoftDeletedHandler returns the handler for EventPurgeSoftDeleted.
+func NewPurgeSoftDeletedHandler(cleanup *service.CleanupService) HandlerFunc {
+	return func(ctx context.Context, _ types.JobPayload) error {
+		if err := cleanup.DeleteOldData(ctx); err != nil {
+			return fmt.Errorf("HandlePurgeSoftDeleted: %w", err)
+		}
+		return nil
+	}
+}
+
+// WebhookSweeper retries webhook deliveries that are due (webhook.RetryWorker).
+type WebhookSweeper interface {
+	Sweep(ctx context.Context) error
+}
+
+// NewWebhookRetrySweepHandler returns the handler for EventWebhookRetrySweep.
+func NewWebhookRetrySweepHandler(sweeper WebhookSweeper) HandlerFunc {
+	return func(ctx context.Context, _ types.JobPayload) error {
+		if err := sweeper.Sweep(ctx); err != nil {
+			return fmt.Errorf("HandleWebhookRetrySweep: %w", err)
+		}
+		return nil
+	}
+}
+
+// StatsSummaryRefresher rebuilds the stats summary tables
+// (repository.StatsSummaryRepository).
+type StatsSummaryRefresher interface {
+	RefreshAll(ctx context.Context) error
+}
+
+// NewRefreshStatsSummariesHandler returns the handler for
+// EventRefreshStatsSummaries. Runs are skipped unless enabled
+// (STATS_READ_FROM_SUMMARIES), since nothing reads the summaries then.
+func NewRefreshStatsSummariesHandler(summaries StatsSummaryRefresher, enabled bool) HandlerFunc {
+	return func(ctx context.Context, _ types.JobPayload) error {
+		if !enabled {
+			return nil
+		}
+		if err := summaries.RefreshAll(ctx); err != nil {
+			return fmt.Errorf("HandleRefreshStatsSummaries: %w", err)
+		}
+		return nil
+	}
+}
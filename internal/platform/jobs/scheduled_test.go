@@ -0,0 +1,69 @@
+package jobs
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+)
+
+func TestWeeklySummaryCohort(t *testing.T) {
+	// Sunday 20:30 in New York (UTC-4 in June) is Monday 00:30 UTC
+	now := time.Date(2026, time.June, 15, 0, 30, 0, 0, time.UTC)
+
+	cohort := weeklySummaryCohort(now, []string{"UTC", "America/New_York", "Europe/Berlin", "Not/AZone"})
+
+	assert.Equal(t, []string{"America/New_York"}, cohort)
+}
+
+func TestScheduledJobGuard_SkipsOverlappingRuns(t *testing.T) {
+	job := ScheduledJob{Name: "test"}
+	release := make(chan struct{})
+	var runs atomic.Int32
+
+	guarded := job.Guard(func(ctx context.Context, _ types.JobPayload) error {
+		runs.Add(1)
+		<-release
+		return nil
+	})
+
+	done := make(chan struct{})
+	go func() {
+		_ = guarded(context.Background(), types.JobPayload{})
+		close(done)
+	}()
+	assert.Eventually(t, func() bool { return runs.Load() == 1 }, time.Second, time.Millisecond)
+
+	// Second run while the first is still going is skipped
+	assert.NoError(t, guarded(context.Background(), types.JobPayload{}))
+	assert.Equal(t, int32(1), runs.Load())
+
+	close(release)
+	<-done
+
+	// Once the first run has finished the next one goes ahead
+	assert.NoError(t, guarded(context.Background(), types.JobPayload{}))
+	assert.Equal(t, int32(2), runs.Load())
+}
+
+type fakeSummaryRefresher struct {
+	runs int
+}
+
+func (f *fakeSummaryRefresher) RefreshAll(ctx context.Context) error {
+	f.runs++
+	return nil
+}
+
+func TestRefreshStatsSummaries_SkippedWhenDisabled(t *testing.T) {
+	refresher := &fakeSummaryRefresher{}
+
+	assert.NoError(t, NewRefreshStatsSummariesHandler(refresher, false)(context.Background(), types.JobPayload{}))
+	assert.Equal(t, 0, refresher.runs)
+
+	assert.NoError(t, NewRefreshStatsSummariesHandler(refresher, true)(context.Background(), types.JobPayload{}))
+	assert.Equal(t, 1, refresher.runs)
+}
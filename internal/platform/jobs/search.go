@@ -0,0 +1,32 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	"github.com/valentinesamuel/activelog/internal/adapters/search"
+	searchTypes "github.com/valentinesamuel/activelog/internal/adapters/search/types"
+)
+
+// EnqueueReindexSearch schedules rebuilding the search index from the database
+func EnqueueReindexSearch(ctx context.Context, queue types.QueueProvider) error {
+	payload := types.JobPayload{Event: types.EventReindexSearch, Data: []byte("{}")}
+	_, err := queue.Enqueue(ctx, types.QueueFor(payload.Event), payload)
+	return err
+}
+
+// NewReindexSearchHandler returns the handler for EventReindexSearch. It
+// indexes every live activity again, which repairs an index that missed
+// events or was lost.
+func NewReindexSearchHandler(store search.Store, indexer searchTypes.Indexer) HandlerFunc {
+	return func(ctx context.Context, _ types.JobPayload) error {
+		indexed, err := search.Reindex(ctx, store, indexer)
+		if err != nil {
+			return fmt.Errorf("HandleReindexSearch: %w", err)
+		}
+		log.Printf("[job] search reindex: indexed %d activities", indexed)
+		return nil
+	}
+}
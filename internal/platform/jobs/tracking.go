@@ -0,0 +1,87 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+)
+
+// JobTracker records the client-visible status of a job.
+// It is implemented by repository.JobRepository.
+type JobTracker interface {
+	Start(ctx context.Context, id string) error
+	UpdateProgress(ctx context.Context, id string, progress int) error
+	Complete(ctx context.Context, id string, result []byte) error
+	Fail(ctx context.Context, id string, errMsg string) error
+}
+
+type trackedRunKey struct{}
+
+// trackedRun is stored in the handler context so ReportProgress and SetResult
+// can reach the tracker without every handler depending on it
+type trackedRun struct {
+	tracker JobTracker
+	id      string
+	result  []byte
+}
+
+// Track wraps next so that payloads carrying a JobID have their job marked
+// running, then completed or failed when next returns. Payloads without a
+// JobID are passed straight through.
+//
+// Tracking errors are logged and never fail the job itself.
+func Track(tracker JobTracker, next HandlerFunc) HandlerFunc {
+	return func(ctx context.Context, payload types.JobPayload) error {
+		if payload.JobID == "" {
+			return next(ctx, payload)
+		}
+
+		run := &trackedRun{tracker: tracker, id: payload.JobID}
+		if err := tracker.Start(ctx, run.id); err != nil {
+			log.Printf("[job] %s: failed to mark as running: %v", run.id, err)
+		}
+
+		err := next(context.WithValue(ctx, trackedRunKey{}, run), payload)
+		if err != nil {
+			if ferr := tracker.Fail(ctx, run.id, err.Error()); ferr != nil {
+				log.Printf("[job] %s: failed to mark as failed: %v", run.id, ferr)
+			}
+			return err
+		}
+
+		if cerr := tracker.Complete(ctx, run.id, run.result); cerr != nil {
+			log.Printf("[job] %s: failed to mark as completed: %v", run.id, cerr)
+		}
+		return nil
+	}
+}
+
+// ReportProgress records the completion percentage of the tracked job running
+// in ctx. It is a no-op for untracked jobs.
+func ReportProgress(ctx context.Context, percent int) {
+	run, ok := ctx.Value(trackedRunKey{}).(*trackedRun)
+	if !ok {
+		return
+	}
+	if err := run.tracker.UpdateProgress(ctx, run.id, percent); err != nil {
+		log.Printf("[job] %s: failed to record progress: %v", run.id, err)
+	}
+}
+
+// SetResult sets the JSON result stored when the tracked job running in ctx
+// completes. It is a no-op for untracked jobs.
+func SetResult(ctx context.Context, v interface{}) error {
+	run, ok := ctx.Value(trackedRunKey{}).(*trackedRun)
+	if !ok {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("SetResult: marshal: %w", err)
+	}
+	run.result = data
+	return nil
+}
@@ -0,0 +1,78 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+)
+
+// recordingTracker records every tracker call as "<method>:<arg>"
+type recordingTracker struct {
+	calls  []string
+	result []byte
+}
+
+func (t *recordingTracker) Start(_ context.Context, id string) error {
+	t.calls = append(t.calls, "start:"+id)
+	return nil
+}
+
+func (t *recordingTracker) UpdateProgress(_ context.Context, _ string, progress int) error {
+	t.calls = append(t.calls, "progress:"+strconv.Itoa(progress))
+	return nil
+}
+
+func (t *recordingTracker) Complete(_ context.Context, id string, result []byte) error {
+	t.calls = append(t.calls, "complete:"+id)
+	t.result = result
+	return nil
+}
+
+func (t *recordingTracker) Fail(_ context.Context, _ string, errMsg string) error {
+	t.calls = append(t.calls, "fail:"+errMsg)
+	return nil
+}
+
+func TestTrack_CompletesWithProgressAndResult(t *testing.T) {
+	tracker := &recordingTracker{}
+
+	handler := Track(tracker, func(ctx context.Context, _ types.JobPayload) error {
+		ReportProgress(ctx, 50)
+		return SetResult(ctx, map[string]int{"imported": 3})
+	})
+
+	err := handler(context.Background(), types.JobPayload{JobID: "job-1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"start:job-1", "progress:50", "complete:job-1"}, tracker.calls)
+	assert.JSONEq(t, `{"imported":3}`, string(tracker.result))
+}
+
+func TestTrack_FailsWithHandlerError(t *testing.T) {
+	tracker := &recordingTracker{}
+
+	handler := Track(tracker, func(context.Context, types.JobPayload) error {
+		return errors.New("boom")
+	})
+
+	err := handler(context.Background(), types.JobPayload{JobID: "job-1"})
+
+	assert.EqualError(t, err, "boom")
+	assert.Equal(t, []string{"start:job-1", "fail:boom"}, tracker.calls)
+}
+
+func TestTrack_UntrackedPayloadPassesThrough(t *testing.T) {
+	tracker := &recordingTracker{}
+
+	handler := Track(tracker, func(ctx context.Context, _ types.JobPayload) error {
+		ReportProgress(ctx, 50)
+		return SetResult(ctx, "ignored")
+	})
+
+	assert.NoError(t, handler(context.Background(), types.JobPayload{}))
+	assert.Empty(t, tracker.calls)
+}
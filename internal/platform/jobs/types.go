@@ -1,5 +1,7 @@
 package jobs
 
+import "github.com/valentinesamuel/activelog/internal/models"
+
 // WelcomeEmailPayload is the data for sending a welcome email.
 type WelcomeEmailPayload struct {
 	UserID int    `json:"user_id"`
@@ -14,6 +16,48 @@ type WeeklySummaryPayload struct {
 
 // ExportPayload is the data for generating a CSV/PDF export.
 type ExportPayload struct {
-	UserID int    `json:"user_id"`
-	Format string `json:"format"` // "csv" or "pdf"
+	UserID   int    `json:"user_id"`
+	Format   string `json:"format"`             // "csv" or "pdf"
+	Language string `json:"language,omitempty"` // language of the PDF report, negotiated from the request
+}
+
+// ImportActivitiesPayload is the data for a bulk activity import.
+// The uploaded file (a JSON array of models.ImportActivityRequest, or the
+// export of a health app for those sources) lives in storage under StorageKey.
+type ImportActivitiesPayload struct {
+	ImportID   string              `json:"import_id"`
+	UserID     int                 `json:"user_id"`
+	StorageKey string              `json:"storage_key"`
+	Source     models.ImportSource `json:"source,omitempty"`
+}
+
+// ExportUserDataPayload is the data for a GDPR data export.
+// The ZIP bundle is recorded on the export record ExportID.
+type ExportUserDataPayload struct {
+	ExportID string `json:"export_id"`
+	UserID   int    `json:"user_id"`
+}
+
+// EnrichWeatherPayload is the data for looking up the weather during an activity.
+type EnrichWeatherPayload struct {
+	ActivityID int64 `json:"activity_id"`
+}
+
+// GeocodeActivityPayload is the data for resolving an activity's location name.
+type GeocodeActivityPayload struct {
+	ActivityID int64 `json:"activity_id"`
+}
+
+// RenderMapThumbnailsPayload is the data for rendering the map thumbnails of
+// a user's activities: those in ActivityIDs, or all that have a GPS track
+// when it's empty.
+type RenderMapThumbnailsPayload struct {
+	UserID      int     `json:"user_id"`
+	ActivityIDs []int64 `json:"activity_ids,omitempty"`
+}
+
+// RecalculateUserMetricsPayload is the data for recomputing the derived
+// metrics of all of a user's activities (e.g. after a weight change).
+type RecalculateUserMetricsPayload struct {
+	UserID int `json:"user_id"`
 }
@@ -0,0 +1,78 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	weatherTypes "github.com/valentinesamuel/activelog/internal/adapters/weather/types"
+	"github.com/valentinesamuel/activelog/internal/models"
+	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
+)
+
+// EnqueueEnrichWeather schedules weather enrichment for an activity. The
+// message ID is derived from the activity, so it is enqueued at most once.
+func EnqueueEnrichWeather(ctx context.Context, queue types.QueueProvider, activityID int64) error {
+	data, err := json.Marshal(EnrichWeatherPayload{ActivityID: activityID})
+	if err != nil {
+		return err
+	}
+	payload := types.JobPayload{
+		Event:     types.EventEnrichWeather,
+		Data:      data,
+		MessageID: fmt.Sprintf("weather-%d", activityID),
+	}
+	_, err = queue.Enqueue(ctx, types.QueueFor(payload.Event), payload)
+	return err
+}
+
+// WeatherStore reads activities and records their weather
+// (repository.ActivityRepository).
+type WeatherStore interface {
+	GetByID(ctx context.Context, id int64) (*models.Activity, error)
+	SetWeather(ctx context.Context, id int64, temperatureC float64, conditions string) (bool, error)
+}
+
+// NewEnrichWeatherHandler returns the handler for EventEnrichWeather.
+// It looks up the weather at the activity's start location and time and
+// stores it on the activity. Activities that are gone, have no location or
+// already have weather are skipped, so redeliveries are harmless; provider
+// errors other than ErrNoData are returned so the job is retried.
+func NewEnrichWeatherHandler(store WeatherStore, provider weatherTypes.WeatherProvider) HandlerFunc {
+	return func(ctx context.Context, payload types.JobPayload) error {
+		var p EnrichWeatherPayload
+		if err := json.Unmarshal(payload.Data, &p); err != nil {
+			return fmt.Errorf("HandleEnrichWeather: unmarshal: %w", err)
+		}
+
+		activity, err := store.GetByID(ctx, p.ActivityID)
+		if errors.Is(err, appErrors.ErrNotFound) {
+			log.Printf("[job] weather: activity %d no longer exists, skipping", p.ActivityID)
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("HandleEnrichWeather: %w", err)
+		}
+		if activity.StartLat == nil || activity.StartLng == nil || activity.TemperatureC != nil {
+			return nil
+		}
+
+		obs, err := provider.Historical(ctx, *activity.StartLat, *activity.StartLng, activity.ActivityDate)
+		if errors.Is(err, weatherTypes.ErrNoData) {
+			log.Printf("[job] weather: no data for activity %d", p.ActivityID)
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("HandleEnrichWeather: %w", err)
+		}
+
+		if _, err := store.SetWeather(ctx, p.ActivityID, obs.TemperatureC, obs.Conditions); err != nil {
+			return fmt.Errorf("HandleEnrichWeather: %w", err)
+		}
+		log.Printf("[job] weather: activity %d %.1f°C %s", p.ActivityID, obs.TemperatureC, obs.Conditions)
+		return nil
+	}
+}
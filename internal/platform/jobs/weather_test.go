@@ -0,0 +1,76 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	weatherTypes "github.com/valentinesamuel/activelog/internal/adapters/weather/types"
+	"github.com/valentinesamuel/activelog/internal/models"
+)
+
+type fakeWeatherStore struct {
+	activity *models.Activity
+	set      int
+}
+
+func (s *fakeWeatherStore) GetByID(_ context.Context, _ int64) (*models.Activity, error) {
+	return s.activity, nil
+}
+
+func (s *fakeWeatherStore) SetWeather(_ context.Context, _ int64, temperatureC float64, conditions string) (bool, error) {
+	s.set++
+	s.activity.TemperatureC = &temperatureC
+	s.activity.WeatherConditions = &conditions
+	return true, nil
+}
+
+type fakeWeatherProvider struct {
+	calls int
+	err   error
+}
+
+func (p *fakeWeatherProvider) Historical(_ context.Context, _, _ float64, _ time.Time) (*weatherTypes.Observation, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &weatherTypes.Observation{TemperatureC: 12.5, Conditions: "clear"}, nil
+}
+
+func weatherPayload(t *testing.T, id int64) types.JobPayload {
+	data, err := json.Marshal(EnrichWeatherPayload{ActivityID: id})
+	require.NoError(t, err)
+	return types.JobPayload{Event: types.EventEnrichWeather, Data: data}
+}
+
+func TestEnrichWeatherHandler(t *testing.T) {
+	lat, lng := 51.5, -0.12
+	store := &fakeWeatherStore{activity: &models.Activity{StartLat: &lat, StartLng: &lng}}
+	provider := &fakeWeatherProvider{}
+	handler := NewEnrichWeatherHandler(store, provider)
+
+	require.NoError(t, handler(context.Background(), weatherPayload(t, 1)))
+	require.NoError(t, handler(context.Background(), weatherPayload(t, 1)))
+
+	assert.Equal(t, 1, provider.calls, "weather already recorded is not looked up again")
+	assert.Equal(t, 1, store.set)
+	assert.Equal(t, 12.5, *store.activity.TemperatureC)
+}
+
+func TestEnrichWeatherHandler_Errors(t *testing.T) {
+	lat, lng := 51.5, -0.12
+	store := &fakeWeatherStore{activity: &models.Activity{StartLat: &lat, StartLng: &lng}}
+
+	noData := NewEnrichWeatherHandler(store, &fakeWeatherProvider{err: weatherTypes.ErrNoData})
+	assert.NoError(t, noData(context.Background(), weatherPayload(t, 1)))
+
+	failing := NewEnrichWeatherHandler(store, &fakeWeatherProvider{err: errors.New("timeout")})
+	assert.Error(t, failing(context.Background(), weatherPayload(t, 1)), "transient errors are retried")
+	assert.Zero(t, store.set)
+}
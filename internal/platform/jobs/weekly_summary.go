@@ -0,0 +1,143 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	emailTypes "github.com/valentinesamuel/activelog/internal/adapters/email/types"
+	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+	"github.com/valentinesamuel/activelog/internal/platform/locale"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/service"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
+	"github.com/valentinesamuel/activelog/pkg/format"
+	"github.com/valentinesamuel/activelog/pkg/i18n"
+)
+
+// WeeklySummaryDeps contains the dependencies for the weekly summary job handler.
+type WeeklySummaryDeps struct {
+	Profiles *repository.ProfileRepository
+	Stats    repository.StatsRepositoryInterface
+	Email    emailTypes.EmailProvider
+	Workouts *repository.WorkoutRepository // nil leaves planned workouts out
+	Clock    clock.Clock                   // nil uses the real clock
+}
+
+// NewWeeklySummaryHandler returns the handler for EventWeeklySummary.
+// It emails the user their totals for the last 7 days and, if they logged
+// their weight, how it changed, and if they planned workouts, how closely
+// they followed them. Accounts deleted since the job was queued are skipped.
+func NewWeeklySummaryHandler(deps WeeklySummaryDeps) HandlerFunc {
+	return func(ctx context.Context, payload types.JobPayload) error {
+		var p WeeklySummaryPayload
+		if err := json.Unmarshal(payload.Data, &p); err != nil {
+			return fmt.Errorf("HandleWeeklySummary: unmarshal: %w", err)
+		}
+		if deps.Email == nil {
+			return fmt.Errorf("HandleWeeklySummary: no email provider configured")
+		}
+
+		profile, err := deps.Profiles.GetProfile(ctx, p.UserID)
+		if errors.Is(err, appErrors.ErrNotFound) {
+			log.Printf("[job] weekly summary: userID=%d no longer exists, skipping", p.UserID)
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("HandleWeeklySummary: get profile: %w", err)
+		}
+
+		stats, err := deps.Stats.GetWeeklyStats(ctx, p.UserID)
+		if err != nil {
+			return fmt.Errorf("HandleWeeklySummary: get stats: %w", err)
+		}
+
+		clk := deps.Clock
+		if clk == nil {
+			clk = clock.Real{}
+		}
+		today := clk.Now().UTC()
+		from := today.AddDate(0, 0, -6)
+
+		var workouts *models.WorkoutCompliance
+		if deps.Workouts != nil {
+			sessions, err := deps.Workouts.ListSessions(ctx, p.UserID, repository.SessionFilter{From: &from, To: &today})
+			if err != nil {
+				return fmt.Errorf("HandleWeeklySummary: list planned workouts: %w", err)
+			}
+			workouts = service.ComputeCompliance(sessions, today)
+		}
+
+		l := locale.New(profile.Preferences.Language)
+		if err := deps.Email.Send(ctx, emailTypes.SendEmailInput{
+			To:       profile.Email,
+			From:     config.Email.From,
+			Subject:  l.Localize("WeeklySummarySubject", nil),
+			TextBody: renderWeeklySummary(l, profile, stats, workouts, from, today),
+		}); err != nil {
+			return fmt.Errorf("HandleWeeklySummary: send: %w", err)
+		}
+
+		log.Printf("[job] weekly summary -> userID=%d activities=%d", p.UserID, stats.TotalActivities)
+		return nil
+	}
+}
+
+// renderWeeklySummary writes the plain-text body of the weekly summary email
+// for the week from..to, in the user's language and the units they prefer.
+// workouts is the compliance of the workouts planned for the week, if any.
+func renderWeeklySummary(l *i18n.Localizer, profile *models.UserProfile, stats *repository.WeeklyStats, workouts *models.WorkoutCompliance, from, to time.Time) string {
+	units := format.Units(profile.Preferences.Units)
+
+	name := profile.Username
+	if profile.DisplayName != nil && *profile.DisplayName != "" {
+		name = *profile.DisplayName
+	}
+
+	var b strings.Builder
+	line := func(id string, data map[string]any) {
+		b.WriteString(l.Localize(id, data))
+		b.WriteString("\n")
+	}
+
+	dateLayout := l.Localize("DateLong", nil)
+	line("EmailGreeting", map[string]any{"Name": name})
+	b.WriteString("\n")
+	line("WeeklySummaryIntro", map[string]any{"From": l.FormatDate(from, dateLayout), "To": l.FormatDate(to, dateLayout)})
+	b.WriteString("\n")
+	if stats.TotalActivities == 0 {
+		line("WeeklySummaryNoActivities", nil)
+	} else {
+		line("WeeklySummaryActivities", map[string]any{"Count": l.FormatNumber(float64(stats.TotalActivities), 0)})
+		line("WeeklySummaryTime", map[string]any{"Duration": format.Minutes(stats.TotalDuration)})
+		line("WeeklySummaryDistance", map[string]any{"Distance": format.Distance(l, stats.TotalDistance, units)})
+	}
+
+	if trend := stats.WeightTrend; trend != nil {
+		b.WriteString(l.Localize("WeeklySummaryWeight", map[string]any{"Weight": format.Weight(l, trend.LatestKg, units)}))
+		if trend.ChangeKg != nil {
+			b.WriteString(" ")
+			b.WriteString(l.Localize("WeeklySummaryWeightChange", map[string]any{"Change": format.WeightChange(l, *trend.ChangeKg, units)}))
+		}
+		b.WriteString("\n")
+	}
+
+	if workouts != nil && workouts.CompliancePct != nil {
+		line("WeeklySummaryWorkouts", map[string]any{
+			"Completed": workouts.Completed,
+			"Due":       workouts.Completed + workouts.Missed,
+			"Percent":   format.Percent(l, *workouts.CompliancePct),
+		})
+	}
+
+	b.WriteString("\n")
+	line("WeeklySummarySignOff", nil)
+	return b.String()
+}
@@ -0,0 +1,78 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/platform/locale"
+	"github.com/valentinesamuel/activelog/internal/repository"
+)
+
+var (
+	english            = locale.New("en")
+	weekStart, weekEnd = time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC), time.Date(2025, time.March, 9, 0, 0, 0, 0, time.UTC)
+)
+
+func TestRenderWeeklySummary_WeightTrend(t *testing.T) {
+	change := -0.8
+	stats := &repository.WeeklyStats{
+		TotalActivities: 3,
+		TotalDuration:   150,
+		TotalDistance:   21.5,
+		WeightTrend:     &repository.WeightTrend{LatestKg: 71.2, ChangeKg: &change, Entries: 4},
+	}
+
+	body := renderWeeklySummary(english, &models.UserProfile{Username: "sam"}, stats, nil, weekStart, weekEnd)
+	assert.Contains(t, body, "Hi sam,")
+	assert.Contains(t, body, "Here is your week on ActiveLog, March 3, 2025 to March 9, 2025.")
+	assert.Contains(t, body, "Time: 2h 30m")
+	assert.Contains(t, body, "Distance: 21.5 km")
+	assert.Contains(t, body, "Weight: 71.2 kg (-0.8 kg since last week)")
+
+	profile := &models.UserProfile{Username: "sam", Preferences: models.UserPreferences{Units: models.UnitsImperial}}
+	imperial := renderWeeklySummary(english, profile, stats, nil, weekStart, weekEnd)
+	assert.Contains(t, imperial, "Distance: 13.4 mi")
+	assert.Contains(t, imperial, "Weight: 157.0 lb (-1.8 lb since last week)")
+}
+
+func TestRenderWeeklySummary_NoWeight(t *testing.T) {
+	body := renderWeeklySummary(english, &models.UserProfile{Username: "sam"}, &repository.WeeklyStats{}, nil, weekStart, weekEnd)
+
+	assert.Contains(t, body, "You didn't log any activities this week.")
+	assert.NotContains(t, body, "Weight:")
+	assert.NotContains(t, body, "Planned workouts:")
+}
+
+func TestRenderWeeklySummary_PlannedWorkouts(t *testing.T) {
+	pct := 78.4
+	workouts := &models.WorkoutCompliance{Completed: 2, Missed: 1, Upcoming: 1, CompliancePct: &pct}
+
+	body := renderWeeklySummary(english, &models.UserProfile{Username: "sam"}, &repository.WeeklyStats{}, workouts, weekStart, weekEnd)
+	assert.Contains(t, body, "Planned workouts: 2 of 3 done (78% compliance)")
+
+	// Nothing due yet
+	body = renderWeeklySummary(english, &models.UserProfile{Username: "sam"}, &repository.WeeklyStats{}, &models.WorkoutCompliance{Upcoming: 2}, weekStart, weekEnd)
+	assert.NotContains(t, body, "Planned workouts:")
+}
+
+func TestRenderWeeklySummary_Localized(t *testing.T) {
+	change := 1.25
+	stats := &repository.WeeklyStats{
+		TotalActivities: 3,
+		TotalDuration:   1250,
+		TotalDistance:   21.5,
+		WeightTrend:     &repository.WeightTrend{LatestKg: 71.2, ChangeKg: &change, Entries: 4},
+	}
+	pct := 78.4
+	workouts := &models.WorkoutCompliance{Completed: 2, Missed: 1, CompliancePct: &pct}
+
+	body := renderWeeklySummary(locale.New("fr"), &models.UserProfile{Username: "sam"}, stats, workouts, weekStart, weekEnd)
+	assert.Contains(t, body, "Bonjour sam,")
+	assert.Contains(t, body, "du 3 mars 2025 au 9 mars 2025.")
+	assert.Contains(t, body, "Durée : 20h 50m")
+	assert.Contains(t, body, "Distance : 21,5 km")
+	assert.Contains(t, body, "Poids : 71,2 kg (+1,2 kg depuis la semaine dernière)")
+	assert.Contains(t, body, "Entraînements prévus : 2 sur 3 réalisés (78\u00a0% de suivi)")
+}
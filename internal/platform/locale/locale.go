@@ -0,0 +1,56 @@
+// Package locale holds the message catalogs of the API and its emails and
+// exports (catalogs/*.json, one per language) and hands out localizers for
+// them. English is the default language. API error messages use their
+// English text as message ID, so a message only needs a catalog entry in the
+// languages it is translated to.
+package locale
+
+import (
+	"context"
+	"embed"
+
+	"github.com/valentinesamuel/activelog/pkg/i18n"
+	"golang.org/x/text/language"
+)
+
+//go:embed catalogs/*.json
+var catalogs embed.FS
+
+var bundle = mustLoadBundle()
+
+func mustLoadBundle() *i18n.Bundle {
+	b := i18n.NewBundle(language.English)
+	if err := b.LoadFS(catalogs, "catalogs/*.json"); err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Bundle returns the bundle of every catalog
+func Bundle() *i18n.Bundle {
+	return bundle
+}
+
+// Supported lists the codes of the languages with a catalog, English first
+func Supported() []string {
+	var codes []string
+	for _, tag := range bundle.LanguageTags() {
+		codes = append(codes, tag.String())
+	}
+	return codes
+}
+
+// New returns the localizer of the supported language that best matches
+// langs (language codes or Accept-Language values, most preferred first)
+func New(langs ...string) *i18n.Localizer {
+	return i18n.NewLocalizer(bundle, langs...)
+}
+
+// FromContext returns the localizer negotiated for the request (see
+// middleware.Locale), or an English one outside of requests
+func FromContext(ctx context.Context) *i18n.Localizer {
+	if l := i18n.FromContext(ctx); l != nil {
+		return l
+	}
+	return New()
+}
@@ -0,0 +1,70 @@
+package locale
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valentinesamuel/activelog/pkg/i18n"
+)
+
+func readCatalog(t *testing.T, lang string) map[string]json.RawMessage {
+	t.Helper()
+	data, err := catalogs.ReadFile("catalogs/" + lang + ".json")
+	require.NoError(t, err)
+	var catalog map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &catalog))
+	return catalog
+}
+
+var templateFields = regexp.MustCompile(`{{\.(\w+)}}`)
+
+// fields lists the template fields a message uses
+func fields(t *testing.T, raw json.RawMessage) []string {
+	var m i18n.Message
+	require.NoError(t, json.Unmarshal(raw, &m))
+	var names []string
+	for _, match := range templateFields.FindAllStringSubmatch(m.One+m.Other, -1) {
+		names = append(names, match[1])
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// Every language translates every named message with the same template
+// fields, and the same English-text messages as the others
+func TestCatalogsComplete(t *testing.T) {
+	assert.Equal(t, []string{"en", "es", "fr"}, slices.Sorted(slices.Values(Supported())))
+
+	english := readCatalog(t, "en")
+	french := readCatalog(t, "fr")
+	for _, lang := range Supported()[1:] {
+		catalog := readCatalog(t, lang)
+		for id, raw := range english {
+			if assert.Contains(t, catalog, id, "%s lacks %s", lang, id) {
+				assert.Equal(t, fields(t, raw), fields(t, catalog[id]), "%s: %s", lang, id)
+			}
+		}
+		for id := range french {
+			assert.Contains(t, catalog, id, "%s lacks %s", lang, id)
+		}
+		for id := range catalog {
+			assert.Contains(t, french, id, "fr lacks %s", id)
+		}
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	assert.Equal(t, "en", FromContext(context.Background()).Language().String())
+
+	ctx := i18n.NewContext(context.Background(), New("es-MX,es;q=0.9"))
+	l := FromContext(ctx)
+	assert.Equal(t, "es", l.Language().String())
+	assert.Equal(t, "Actividad no encontrada", l.Localize("Activity not found", nil))
+	assert.Equal(t, "3 de marzo de 2025", l.FormatDate(time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC), l.Localize("DateLong", nil)))
+}
@@ -1,26 +1,40 @@
 package di
 
 import (
+	"context"
 	"database/sql"
 
 	brokerDI "github.com/valentinesamuel/activelog/internal/application/broker/di"
+	clockDI "github.com/valentinesamuel/activelog/internal/platform/clock/di"
 	"github.com/valentinesamuel/activelog/internal/platform/container"
 	queueDI "github.com/valentinesamuel/activelog/internal/adapters/queue/di"
 	"github.com/valentinesamuel/activelog/internal/adapters/queue/types"
 	"github.com/valentinesamuel/activelog/internal/platform/scheduler"
 	"github.com/valentinesamuel/activelog/internal/service"
+	"github.com/valentinesamuel/activelog/pkg/clock"
 )
 
 // RegisterScheduler registers the Scheduler in the DI container.
 // Depends on: rawDB (broker/di.CoreRawDBKey) and QueueProvider.
 func RegisterScheduler(c *container.Container) {
 	c.Register(SchedulerKey, func(c *container.Container) (interface{}, error) {
-		rawDB := c.MustResolve(brokerDI.CoreRawDBKey).(*sql.DB)
-		queue := c.MustResolve(queueDI.QueueProviderKey).(types.QueueProvider)
+		rawDB := container.MustResolve[*sql.DB](c, brokerDI.CoreRawDBKey)
+		queue := container.MustResolve[types.QueueProvider](c, queueDI.QueueProviderKey)
 
-		statsCalc := service.NewStatsCalculator(rawDB)
-		cleanup := service.NewCleanupService(rawDB)
+		clk := container.MustResolve[clock.Clock](c, clockDI.ClockKey)
 
-		return scheduler.New(statsCalc, cleanup, queue), nil
+		statsCalc := service.NewStatsCalculator(rawDB).WithClock(clk)
+		s := scheduler.New(statsCalc, queue)
+
+		// Cron runs from container start until shutdown, waiting for running jobs
+		c.OnStart(SchedulerKey, func(context.Context) error {
+			s.Start()
+			return nil
+		})
+		c.OnStop(SchedulerKey, func(context.Context) error {
+			s.Stop()
+			return nil
+		})
+		return s, nil
 	})
 }
@@ -15,26 +15,25 @@ import (
 type Scheduler struct {
 	cron      *cron.Cron
 	statsCalc *service.StatsCalculator
-	cleanup   *service.CleanupService
 	queue     types.QueueProvider
 }
 
 // New creates a UTC-based Scheduler.
 func New(
 	statsCalc *service.StatsCalculator,
-	cleanup *service.CleanupService,
 	queue types.QueueProvider,
 ) *Scheduler {
 	c := cron.New(cron.WithLocation(time.UTC))
 	return &Scheduler{
 		cron:      c,
 		statsCalc: statsCalc,
-		cleanup:   cleanup,
 		queue:     queue,
 	}
 }
 
 // Start registers all cron jobs and starts the scheduler.
+// Weekly summaries, the soft-delete purge and the webhook retry sweep run in
+// the worker instead (see jobs.Schedule).
 func (s *Scheduler) Start() {
 	// Daily stats calculation at midnight UTC
 	s.cron.AddFunc("0 0 * * *", func() {
@@ -44,24 +43,11 @@ func (s *Scheduler) Start() {
 		}
 	})
 
-	// Weekly summary emails every Sunday at 09:00 UTC
-	s.cron.AddFunc("0 9 * * 0", func() {
-		s.enqueueWeeklySummaries()
-	})
-
 	// Monthly report generation on the 1st of each month at midnight UTC
 	s.cron.AddFunc("0 0 1 * *", func() {
 		s.enqueueMonthlyReports()
 	})
 
-	// Cleanup stale soft-deleted records every day at 02:00 UTC
-	s.cron.AddFunc("0 2 * * *", func() {
-		ctx := context.Background()
-		if err := s.cleanup.DeleteOldData(ctx); err != nil {
-			log.Printf("[scheduler] DeleteOldData error: %v", err)
-		}
-	})
-
 	s.cron.Start()
 	log.Println("[scheduler] started (UTC)")
 }
@@ -73,20 +59,6 @@ func (s *Scheduler) Stop() {
 	log.Println("[scheduler] stopped")
 }
 
-// enqueueWeeklySummaries enqueues a WeeklySummary job for every active user.
-// For now it enqueues a placeholder job; the real user list will come from the
-// user repository once it exposes a ListActiveUsers method.
-func (s *Scheduler) enqueueWeeklySummaries() {
-	ctx := context.Background()
-	log.Println("[scheduler] enqueue weekly summaries (placeholder – no active users yet)")
-
-	// Example: iterate active user IDs and enqueue per user.
-	// for _, userID := range activeUserIDs {
-	//     s.enqueueJob(ctx, types.InboxQueue, types.EventWeeklySummary, map[string]int{"user_id": userID})
-	// }
-	_ = ctx
-}
-
 // enqueueMonthlyReports enqueues a GenerateExport job for every active user.
 func (s *Scheduler) enqueueMonthlyReports() {
 	ctx := context.Background()
@@ -95,13 +67,15 @@ func (s *Scheduler) enqueueMonthlyReports() {
 }
 
 // enqueueJob is a helper that marshals data and enqueues a job.
-func (s *Scheduler) enqueueJob(ctx context.Context, queue types.QueueName, event types.EventType, data any) {
+// The queue is picked from the event type (see types.QueueFor).
+func (s *Scheduler) enqueueJob(ctx context.Context, event types.EventType, data any) {
 	raw, err := json.Marshal(data)
 	if err != nil {
 		log.Printf("[scheduler] marshal error for event %s: %v", event, err)
 		return
 	}
 
+	queue := types.QueueFor(event)
 	taskID, err := s.queue.Enqueue(ctx, queue, types.JobPayload{
 		Event: event,
 		Data:  raw,
@@ -1,10 +1,11 @@
 package validator
 
 import (
-	"fmt"
+	"context"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/valentinesamuel/activelog/internal/platform/locale"
 	"github.com/valentinesamuel/activelog/pkg/response"
 )
 
@@ -18,25 +19,29 @@ func Validate(i interface{}) error {
 	return validate.Struct(i)
 }
 
-func FormatValidationErrors(err error) []response.ValidationErrorItem {
+// FormatValidationErrors turns the errors of Validate into one item per
+// field, with messages in the request's language (see package locale)
+func FormatValidationErrors(ctx context.Context, err error) []response.ValidationErrorItem {
+	l := locale.FromContext(ctx)
 	accumulator := make(map[string][]string)
 	order := []string{}
 
 	if validationErrors, ok := err.(validator.ValidationErrors); ok {
 		for _, e := range validationErrors {
 			field := strings.ToLower(e.Field())
+			data := map[string]any{"Field": field, "Param": e.Param()}
 			var msg string
 			switch e.Tag() {
 			case "required":
-				msg = fmt.Sprintf("%s should not be empty", field)
+				msg = l.Localize("ValidationRequired", data)
 			case "min":
-				msg = fmt.Sprintf("%s must be at least %s", field, e.Param())
+				msg = l.Localize("ValidationMin", data)
 			case "max":
-				msg = fmt.Sprintf("%s must be at most %s characters", field, e.Param())
+				msg = l.Localize("ValidationMax", data)
 			case "email":
-				msg = fmt.Sprintf("%s must be a valid email", field)
+				msg = l.Localize("ValidationEmail", data)
 			default:
-				msg = fmt.Sprintf("%s is invalid", field)
+				msg = l.Localize("ValidationInvalid", data)
 			}
 			if _, exists := accumulator[field]; !exists {
 				order = append(order, field)
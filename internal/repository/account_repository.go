@@ -0,0 +1,310 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/valentinesamuel/activelog/pkg/errors"
+)
+
+// userActivityIDs selects the IDs of every activity a user owns, live or
+// archived. Queries using it bind the user ID to $1.
+const userActivityIDs = `SELECT id FROM activities WHERE user_id = $1
+	UNION ALL SELECT id FROM activities_archive WHERE user_id = $1`
+
+// UserDataSection is one named part of a user's data export (e.g. "activities")
+type UserDataSection struct {
+	Name string
+	Data json.RawMessage
+}
+
+// userDataSections lists what goes into a data export. Each query binds the
+// user ID to $1 and returns a single JSON value. Users, activities, tags and
+// shares appear under their public IDs, never their serial IDs.
+var userDataSections = []struct {
+	name  string
+	query string
+}{
+	{"profile", `SELECT to_jsonb(u) - 'password_hash' - 'deletion_token_hash' - 'public_id' || jsonb_build_object('id', u.public_id)
+		FROM users u WHERE u.id = $1`},
+	{"activities", jsonArrayOf(publicActivity, `SELECT * FROM activities WHERE user_id = $1`, "activity_date, id")},
+	{"archived_activities", jsonArrayOf(publicActivity, `SELECT * FROM activities_archive WHERE user_id = $1`, "activity_date, id")},
+	{"tags", jsonArray(`
+		SELECT `+activityPublicID("at.activity_id")+` AS activity_id, t.public_id AS tag_id, t.name
+		FROM activity_tags at
+		JOIN tags t ON t.id = at.tag_id
+		WHERE at.activity_id IN (`+userActivityIDs+`)`, "activity_id, tag_id")},
+	{"photos", jsonArrayOf(`to_jsonb(s) || jsonb_build_object('activity_id', `+activityPublicID("s.activity_id")+`)`,
+		`SELECT * FROM activity_photos WHERE activity_id IN (`+userActivityIDs+`)`, "id")},
+	{"splits", jsonArrayOf(`to_jsonb(s) || jsonb_build_object('activity_id', `+activityPublicID("s.activity_id")+`)`,
+		`SELECT * FROM activity_splits WHERE activity_id IN (`+userActivityIDs+`)`, "activity_id, split_index")},
+	// Simplified tracks are derived from the full one, so only it is exported
+	{"tracks", jsonArrayOf(`to_jsonb(s) || jsonb_build_object('activity_id', `+activityPublicID("s.activity_id")+`)`,
+		`SELECT * FROM activity_tracks WHERE activity_id IN (`+userActivityIDs+`) AND resolution = 'full'`, "activity_id")},
+	{"comments", jsonArray(`SELECT * FROM comments WHERE user_id = $1`, "id")},
+	{"shares", jsonArrayOf(`to_jsonb(s) - 'public_id' - 'user_id' || jsonb_build_object('id', s.public_id, 'activity_id', `+activityPublicID("s.activity_id")+`)`,
+		`SELECT * FROM activity_shares WHERE user_id = $1`, "id")},
+	{"reactions", jsonArrayOf(`to_jsonb(s) - 'user_id' || jsonb_build_object('activity_id', `+activityPublicID("s.activity_id")+`)`,
+		`SELECT * FROM activity_reactions WHERE user_id = $1`, "id")},
+	{"body_metrics", jsonArray(`SELECT * FROM body_metrics WHERE user_id = $1`, "recorded_on, id")},
+	{"privacy_zones", jsonArray(`SELECT * FROM privacy_zones WHERE user_id = $1`, "id")},
+	{"workouts", jsonArray(`SELECT * FROM workouts WHERE user_id = $1`, "id")},
+	{"planned_workouts", jsonArray(`SELECT * FROM planned_workouts WHERE user_id = $1`, "planned_on, id")},
+	{"identities", jsonArray(`SELECT id, provider, email, created_at, last_login_at FROM user_identities WHERE user_id = $1`, "id")},
+	{"coach_access", jsonArray(`SELECT * FROM coach_access WHERE athlete_id = $1 OR coach_id = $1`, "created_at")},
+	{"challenges", jsonArray(`SELECT * FROM challenges WHERE creator_id = $1`, "id")},
+	{"challenge_participations", jsonArray(`SELECT * FROM challenge_participants WHERE user_id = $1`, "joined_at")},
+	{"badges", jsonArray(`SELECT * FROM badges WHERE user_id = $1`, "awarded_at, id")},
+	{"achievements", jsonArray(`SELECT code, awarded_at FROM user_achievements WHERE user_id = $1`, "awarded_at, code")},
+	{"group_memberships", jsonArray(`SELECT * FROM group_members WHERE user_id = $1`, "joined_at")},
+	{"audit_trail", jsonArray(`SELECT * FROM change_log WHERE user_id = $1`, "seq")},
+}
+
+// publicActivity is an activity row s as exported: identified by its public ID
+const publicActivity = `to_jsonb(s) - 'public_id' - 'user_id' || jsonb_build_object('id', s.public_id)`
+
+// activityPublicID selects the public ID of the live or archived activity
+// whose serial ID is col
+func activityPublicID(col string) string {
+	return fmt.Sprintf(`(SELECT public_id FROM activities WHERE id = %[1]s
+		UNION ALL SELECT public_id FROM activities_archive WHERE id = %[1]s LIMIT 1)`, col)
+}
+
+// jsonArray aggregates the rows of query into a JSON array ordered by order
+func jsonArray(query, order string) string {
+	return jsonArrayOf("to_jsonb(s)", query, order)
+}
+
+// jsonArrayOf is jsonArray with each row s turned into JSON by expr
+func jsonArrayOf(expr, query, order string) string {
+	return fmt.Sprintf(`SELECT COALESCE(jsonb_agg(%s ORDER BY %s), '[]') FROM (%s) s`, expr, order, query)
+}
+
+// AccountRepository handles data export and staged deletion of user accounts
+type AccountRepository struct {
+	db DBConn
+}
+
+// NewAccountRepository creates a new AccountRepository
+func NewAccountRepository(db DBConn) *AccountRepository {
+	return &AccountRepository{db: db}
+}
+
+// ExportData returns everything stored about a user, one section per kind of data
+func (r *AccountRepository) ExportData(ctx context.Context, userID int) ([]UserDataSection, error) {
+	sections := make([]UserDataSection, 0, len(userDataSections))
+	for _, section := range userDataSections {
+		var data []byte
+		if err := r.db.QueryRowContext(ctx, section.query, userID).Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to export %s: %w", section.name, err)
+		}
+		sections = append(sections, UserDataSection{Name: section.name, Data: data})
+	}
+
+	return sections, nil
+}
+
+// ListPhotoKeys returns the storage keys of the user's photos (not thumbnails)
+func (r *AccountRepository) ListPhotoKeys(ctx context.Context, userID int) ([]string, error) {
+	return r.listKeys(ctx, `
+		SELECT s3_key FROM activity_photos
+		WHERE activity_id IN (`+userActivityIDs+`)
+		ORDER BY id`, userID)
+}
+
+// ListStorageKeys returns every storage object owned by the user: photos,
+// thumbnails, map thumbnails, export files, uploaded import files and the
+// avatar
+func (r *AccountRepository) ListStorageKeys(ctx context.Context, userID int) ([]string, error) {
+	return r.listKeys(ctx, `
+		SELECT s3_key FROM activity_photos WHERE activity_id IN (`+userActivityIDs+`)
+		UNION
+		SELECT thumbnail_key FROM activity_photos
+		WHERE activity_id IN (`+userActivityIDs+`) AND thumbnail_key IS NOT NULL
+		UNION
+		SELECT storage_key FROM activity_map_thumbnails WHERE activity_id IN (`+userActivityIDs+`)
+		UNION
+		SELECT s3_key FROM exports WHERE user_id = $1 AND s3_key IS NOT NULL
+		UNION
+		SELECT storage_key FROM imports WHERE user_id = $1
+		UNION
+		SELECT avatar_key FROM users WHERE id = $1 AND avatar_key IS NOT NULL`, userID)
+}
+
+func (r *AccountRepository) listKeys(ctx context.Context, query string, userID int) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list storage keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("failed to scan storage key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+// RequestDeletion stores the hash of a deletion confirmation token valid
+// until expiresAt, replacing any earlier token. It returns ErrNotFound if
+// the account is missing or already disabled.
+func (r *AccountRepository) RequestDeletion(ctx context.Context, userID int, tokenHash string, expiresAt time.Time) error {
+	query := `
+		UPDATE users
+		SET deletion_token_hash = $2, deletion_token_expires_at = $3
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, userID, tokenHash, expiresAt)
+	if err != nil {
+		return &errors.DatabaseError{Op: "UPDATE", Table: "users", Err: err}
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+// ConfirmDeletion disables the account and schedules its hard delete for
+// scheduledAt, provided tokenHash matches a confirmation token that hasn't
+// expired by now. It returns ErrInvalidInput if it doesn't.
+func (r *AccountRepository) ConfirmDeletion(ctx context.Context, userID int, tokenHash string, now, scheduledAt time.Time) error {
+	query := `
+		UPDATE users
+		SET deleted_at = CURRENT_TIMESTAMP,
+			deletion_scheduled_at = $3,
+			deletion_token_hash = NULL,
+			deletion_token_expires_at = NULL
+		WHERE id = $1
+			AND deleted_at IS NULL
+			AND deletion_token_hash = $2
+			AND deletion_token_expires_at > $4`
+
+	result, err := r.db.ExecContext(ctx, query, userID, tokenHash, scheduledAt, now)
+	if err != nil {
+		return &errors.DatabaseError{Op: "UPDATE", Table: "users", Err: err}
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.ErrInvalidInput
+	}
+
+	return nil
+}
+
+// ListDueForPurge returns up to limit disabled accounts whose grace period ended before now
+func (r *AccountRepository) ListDueForPurge(ctx context.Context, now time.Time, limit int) ([]int, error) {
+	query := `
+		SELECT id FROM users
+		WHERE deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= $1
+		ORDER BY deletion_scheduled_at
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, now, limit)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "users", Err: err}
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// IsDueForPurge reports whether the account is still disabled and its grace
+// period ended before now
+func (r *AccountRepository) IsDueForPurge(ctx context.Context, userID int, now time.Time) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM users
+			WHERE id = $1 AND deleted_at IS NOT NULL
+				AND deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= $2
+		)`
+
+	var due bool
+	if err := r.db.QueryRowContext(ctx, query, userID, now).Scan(&due); err != nil {
+		return false, &errors.DatabaseError{Op: "SELECT", Table: "users", Err: err}
+	}
+	return due, nil
+}
+
+// ListActivityIDs returns the IDs of every activity the user owns, live or archived
+func (r *AccountRepository) ListActivityIDs(ctx context.Context, userID int) ([]int64, error) {
+	rows, err := r.db.QueryContext(ctx, userActivityIDs, userID)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "activities", Err: err}
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// Purge permanently deletes a disabled account and everything it owns.
+// Foreign keys cascade from users; archived activities, and comments and
+// reactions left on the user's activities by others, have no foreign key and
+// are deleted here.
+// Storage objects must be removed beforehand (see ListStorageKeys).
+func (r *AccountRepository) Purge(ctx context.Context, userID int) error {
+	return WithTransaction(ctx, r.db, func(tx TxConn) error {
+		statements := []string{
+			`DELETE FROM comments WHERE commentable_type = 'Activity' AND commentable_id IN (` + userActivityIDs + `)`,
+			`DELETE FROM activity_tags WHERE activity_id IN (SELECT id FROM activities_archive WHERE user_id = $1)`,
+			`DELETE FROM activity_photos WHERE activity_id IN (SELECT id FROM activities_archive WHERE user_id = $1)`,
+			`DELETE FROM activity_shares WHERE activity_id IN (SELECT id FROM activities_archive WHERE user_id = $1)`,
+			`DELETE FROM activity_reactions WHERE activity_id IN (SELECT id FROM activities_archive WHERE user_id = $1)`,
+			`DELETE FROM activity_splits WHERE activity_id IN (SELECT id FROM activities_archive WHERE user_id = $1)`,
+			`DELETE FROM activity_tracks WHERE activity_id IN (SELECT id FROM activities_archive WHERE user_id = $1)`,
+			`DELETE FROM activity_map_thumbnails WHERE activity_id IN (SELECT id FROM activities_archive WHERE user_id = $1)`,
+			`DELETE FROM activities_archive WHERE user_id = $1`,
+		}
+		for _, stmt := range statements {
+			if _, err := tx.ExecContext(ctx, stmt, userID); err != nil {
+				return &errors.DatabaseError{Op: "DELETE", Table: "users", Err: err}
+			}
+		}
+
+		result, err := tx.ExecContext(ctx,
+			`DELETE FROM users WHERE id = $1 AND deletion_scheduled_at IS NOT NULL`, userID)
+		if err != nil {
+			return &errors.DatabaseError{Op: "DELETE", Table: "users", Err: err}
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return errors.ErrNotFound
+		}
+		return nil
+	})
+}
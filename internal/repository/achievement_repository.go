@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/valentinesamuel/activelog/pkg/errors"
+)
+
+// AchievementTotals are the lifetime figures achievement rules are tested
+// against. LongestStreakDays is the longest run of consecutive days with at
+// least one activity.
+type AchievementTotals struct {
+	Activities        int
+	DistanceKm        float64
+	LongestStreakDays int
+}
+
+// AchievementRepository handles database operations for the achievements
+// users earned
+type AchievementRepository struct {
+	db DBConn
+}
+
+// NewAchievementRepository creates a new AchievementRepository
+func NewAchievementRepository(db DBConn) *AchievementRepository {
+	return &AchievementRepository{db: db}
+}
+
+// GetTotals returns userID's AchievementTotals over their live activities
+func (r *AchievementRepository) GetTotals(ctx context.Context, userID int) (*AchievementTotals, error) {
+	query := `
+		WITH days AS (
+			SELECT DISTINCT activity_date::date AS day
+			FROM activities
+			WHERE user_id = $1 AND deleted_at IS NULL
+		), streaks AS (
+			SELECT COUNT(*) AS length
+			FROM (SELECT day, day - ROW_NUMBER() OVER (ORDER BY day)::int AS run FROM days) d
+			GROUP BY run
+		)
+		SELECT
+			COUNT(*),
+			COALESCE(SUM(distance_km), 0)::float8,
+			(SELECT COALESCE(MAX(length), 0) FROM streaks)
+		FROM activities
+		WHERE user_id = $1 AND deleted_at IS NULL`
+
+	totals := &AchievementTotals{}
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&totals.Activities, &totals.DistanceKm, &totals.LongestStreakDays)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "AGGREGATE", Table: "activities", Err: err}
+	}
+	return totals, nil
+}
+
+// Award records the achievements in codes for userID and returns the codes
+// that were new. Codes already awarded are skipped, so awarding is
+// idempotent.
+func (r *AchievementRepository) Award(ctx context.Context, userID int, codes []string) ([]string, error) {
+	if len(codes) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		INSERT INTO user_achievements (user_id, code)
+		SELECT $1, code FROM unnest($2::text[]) AS code
+		ON CONFLICT (user_id, code) DO NOTHING
+		RETURNING code`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, codes)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "INSERT", Table: "user_achievements", Err: err}
+	}
+	defer rows.Close()
+
+	var awarded []string
+	for rows.Next() {
+		var code string
+		if err := rows.Scan(&code); err != nil {
+			return nil, &errors.DatabaseError{Op: "SCAN", Table: "user_achievements", Err: err}
+		}
+		awarded = append(awarded, code)
+	}
+	return awarded, rows.Err()
+}
+
+// ListAwarded returns when userID earned each of their achievements, by code
+func (r *AchievementRepository) ListAwarded(ctx context.Context, userID int) (map[string]time.Time, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT code, awarded_at FROM user_achievements WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "user_achievements", Err: err}
+	}
+	defer rows.Close()
+
+	awarded := map[string]time.Time{}
+	for rows.Next() {
+		var code string
+		var at time.Time
+		if err := rows.Scan(&code, &at); err != nil {
+			return nil, &errors.DatabaseError{Op: "SCAN", Table: "user_achievements", Err: err}
+		}
+		awarded[code] = at
+	}
+	return awarded, rows.Err()
+}
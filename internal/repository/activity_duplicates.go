@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/valentinesamuel/activelog/pkg/dberr"
+	"github.com/valentinesamuel/activelog/pkg/errors"
+)
+
+// DuplicateCriteria decides when two activities of the same type are likely
+// the same workout, e.g. one recorded by a watch and one imported from an app
+type DuplicateCriteria struct {
+	// Slack is how far apart the time spans may be and still count as
+	// overlapping, for clocks and start buttons that disagree
+	Slack time.Duration
+
+	// DistanceTolerance is how much the distances may differ, as a share of
+	// the longer one
+	DistanceTolerance float64
+
+	// Limit caps the number of pairs returned
+	Limit int
+}
+
+// DuplicatePair is two activities that match a DuplicateCriteria; A < B
+type DuplicatePair struct {
+	A, B int64
+}
+
+// ActivityAttachments counts the records attached to an activity
+type ActivityAttachments struct {
+	Tags     int
+	Photos   int
+	Comments int
+	Splits   int
+}
+
+// MergeResult counts what a merge moved onto the kept activity
+type MergeResult struct {
+	Merged   int64
+	Tags     int64
+	Photos   int64
+	Comments int64
+}
+
+// FindDuplicatePairs returns the pairs of the user's live activities of the
+// same type whose time spans overlap and whose distances are within the
+// tolerance, newest first. Unlike FindDuplicate, which stops an exact repeat
+// being created, it finds the near-duplicates that several import sources
+// leave behind.
+func (ar *ActivityRepository) FindDuplicatePairs(ctx context.Context, userID int, criteria DuplicateCriteria) ([]DuplicatePair, error) {
+	query := `
+		SELECT a.id, b.id
+		FROM activities a
+		JOIN activities b
+			ON b.user_id = a.user_id
+			AND b.activity_type = a.activity_type
+			AND b.id > a.id
+			AND b.deleted_at IS NULL
+		WHERE a.user_id = $1
+			AND a.deleted_at IS NULL
+			AND a.activity_date < b.activity_date + make_interval(mins => b.duration_minutes, secs => $2)
+			AND b.activity_date < a.activity_date + make_interval(mins => a.duration_minutes, secs => $2)
+			AND ABS(a.distance_km - b.distance_km) <= GREATEST(a.distance_km, b.distance_km) * $3
+		ORDER BY GREATEST(a.activity_date, b.activity_date) DESC, a.id, b.id
+		LIMIT $4
+	`
+
+	rows, err := ar.db.QueryContext(ctx, query, userID, criteria.Slack.Seconds(), criteria.DistanceTolerance, criteria.Limit)
+	if err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{Op: "SELECT", Table: "activities", Err: err})
+	}
+	defer rows.Close()
+
+	var pairs []DuplicatePair
+	for rows.Next() {
+		var pair DuplicatePair
+		if err := rows.Scan(&pair.A, &pair.B); err != nil {
+			return nil, &errors.DatabaseError{Op: "SCAN", Table: "activities", Err: err}
+		}
+		pairs = append(pairs, pair)
+	}
+	return pairs, rows.Err()
+}
+
+// CountAttachments returns the tags, photos, comments and splits of each of
+// ids; activities with none are left out
+func (ar *ActivityRepository) CountAttachments(ctx context.Context, ids []int64) (map[int64]ActivityAttachments, error) {
+	query := `
+		SELECT id,
+			(SELECT COUNT(*) FROM activity_tags t WHERE t.activity_id = a.id AND t.deleted_at IS NULL),
+			(SELECT COUNT(*) FROM activity_photos p WHERE p.activity_id = a.id AND p.deleted_at IS NULL),
+			(SELECT COUNT(*) FROM comments c WHERE c.commentable_type = 'Activity' AND c.commentable_id = a.id),
+			(SELECT COUNT(*) FROM activity_splits s WHERE s.activity_id = a.id)
+		FROM unnest($1::bigint[]) AS a(id)
+	`
+
+	rows, err := ar.db.QueryContext(ctx, query, ids)
+	if err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{Op: "SELECT", Table: "activities", Err: err})
+	}
+	defer rows.Close()
+
+	counts := make(map[int64]ActivityAttachments, len(ids))
+	for rows.Next() {
+		var id int64
+		var c ActivityAttachments
+		if err := rows.Scan(&id, &c.Tags, &c.Photos, &c.Comments, &c.Splits); err != nil {
+			return nil, &errors.DatabaseError{Op: "SCAN", Table: "activities", Err: err}
+		}
+		if c != (ActivityAttachments{}) {
+			counts[id] = c
+		}
+	}
+	return counts, rows.Err()
+}
+
+// MergeInto folds the user's activities mergeIDs into keepID: their tags,
+// photos and comments move to keepID, as do the splits and the GPS track of
+// the one with the most when keepID has none, and a reaction per user who
+// hasn't reacted to keepID. The merged activities are then soft deleted. The caller locks the
+// activities first (see LockVersion).
+func (ar *ActivityRepository) MergeInto(ctx context.Context, tx TxConn, userID int, keepID int64, mergeIDs []int64) (*MergeResult, error) {
+	result := &MergeResult{}
+
+	// The tags trigger refreshes activities.tags of keepID
+	res, err := ExecInTx(ctx, tx, ar.db, `
+		INSERT INTO activity_tags (activity_id, tag_id)
+		SELECT DISTINCT $1::int, tag_id
+		FROM activity_tags
+		WHERE activity_id = ANY($2) AND deleted_at IS NULL
+		ON CONFLICT (activity_id, tag_id) DO UPDATE SET deleted_at = NULL
+		WHERE activity_tags.deleted_at IS NOT NULL
+	`, keepID, mergeIDs)
+	if err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{Op: "INSERT", Table: "activity_tags", Err: err})
+	}
+	result.Tags, _ = res.RowsAffected()
+
+	res, err = ExecInTx(ctx, tx, ar.db, `
+		UPDATE activity_photos SET activity_id = $1
+		WHERE activity_id = ANY($2) AND deleted_at IS NULL
+	`, keepID, mergeIDs)
+	if err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{Op: "UPDATE", Table: "activity_photos", Err: err})
+	}
+	result.Photos, _ = res.RowsAffected()
+
+	res, err = ExecInTx(ctx, tx, ar.db, `
+		UPDATE comments SET commentable_id = $1
+		WHERE commentable_type = 'Activity' AND commentable_id = ANY($2)
+	`, keepID, mergeIDs)
+	if err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{Op: "UPDATE", Table: "comments", Err: err})
+	}
+	result.Comments, _ = res.RowsAffected()
+
+	// Splits describe one recording, so they aren't combined
+	if _, err := ExecInTx(ctx, tx, ar.db, `
+		UPDATE activity_splits SET activity_id = $1
+		WHERE activity_id = (
+			SELECT activity_id FROM activity_splits
+			WHERE activity_id = ANY($2)
+			GROUP BY activity_id
+			ORDER BY COUNT(*) DESC, activity_id
+			LIMIT 1
+		)
+		AND NOT EXISTS (SELECT 1 FROM activity_splits WHERE activity_id = $1)
+	`, keepID, mergeIDs); err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{Op: "UPDATE", Table: "activity_splits", Err: err})
+	}
+
+	// Likewise the track, with every resolution computed from it and its map
+	// thumbnail
+	for _, table := range []string{"activity_map_thumbnails", "activity_tracks"} {
+		if _, err := ExecInTx(ctx, tx, ar.db, `
+			UPDATE `+table+` SET activity_id = $1
+			WHERE activity_id = (
+				SELECT activity_id FROM activity_tracks
+				WHERE activity_id = ANY($2) AND resolution = 'full'
+				ORDER BY points DESC, activity_id
+				LIMIT 1
+			)
+			AND NOT EXISTS (SELECT 1 FROM activity_tracks WHERE activity_id = $1)
+		`, keepID, mergeIDs); err != nil {
+			return nil, dberr.Translate(&errors.DatabaseError{Op: "UPDATE", Table: table, Err: err})
+		}
+	}
+
+	// A user reacts once per activity, so only their earliest reaction moves
+	if _, err := ExecInTx(ctx, tx, ar.db, `
+		UPDATE activity_reactions SET activity_id = $1
+		WHERE id IN (
+			SELECT DISTINCT ON (user_id) id
+			FROM activity_reactions
+			WHERE activity_id = ANY($2)
+				AND user_id NOT IN (SELECT user_id FROM activity_reactions WHERE activity_id = $1)
+			ORDER BY user_id, created_at, id
+		)
+	`, keepID, mergeIDs); err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{Op: "UPDATE", Table: "activity_reactions", Err: err})
+	}
+
+	deleteQuery := withChangeLog("activities", ChangeDelete, `
+		UPDATE activities SET deleted_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE id = ANY($1) AND user_id = $2 AND deleted_at IS NULL
+		RETURNING id, user_id, version
+	`, "COUNT(*)")
+	if err := QueryRowInTx(ctx, tx, ar.db, deleteQuery, mergeIDs, userID).Scan(&result.Merged); err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{Op: "DELETE", Table: "activities", Err: err})
+	}
+	if result.Merged != int64(len(mergeIDs)) {
+		return nil, fmt.Errorf("merged %d of %d activities: %w", result.Merged, len(mergeIDs), errors.ErrNotFound)
+	}
+
+	// The kept activity gained attachments, so clients syncing it refetch it
+	updateQuery := withChangeLog("activities", ChangeUpdate, `
+		UPDATE activities SET version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
+		RETURNING id, user_id, version
+	`, "id")
+	var updatedID int64
+	if err := QueryRowInTx(ctx, tx, ar.db, updateQuery, keepID, userID).Scan(&updatedID); err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{Op: "UPDATE", Table: "activities", Err: err})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,84 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/repository/testhelpers"
+)
+
+// loggedUpdate matches an activities UPDATE that bumps the version and is
+// recorded in change_log by the same statement
+const loggedUpdate = `WITH changed AS \(\s+UPDATE activities\s+SET .*version = version \+ 1.*RETURNING id, user_id, version\s+\),\s+logged AS \(\s+INSERT INTO change_log .*'activities', id, 'update', version FROM changed\s+\)\s+SELECT COUNT\(\*\) FROM changed`
+
+func TestActivityRepository_SetWeather(t *testing.T) {
+	t.Run("bumps the version and logs the change", func(t *testing.T) {
+		db, mock := testhelpers.SetupMockDB(t)
+		mock.ExpectQuery(loggedUpdate).
+			WithArgs(int64(42), 12.5, "rain").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		updated, err := repository.NewActivityRepository(db, nil).SetWeather(context.Background(), 42, 12.5, "rain")
+		require.NoError(t, err)
+		assert.True(t, updated)
+	})
+
+	t.Run("weather already recorded", func(t *testing.T) {
+		db, mock := testhelpers.SetupMockDB(t)
+		mock.ExpectQuery(`WHERE id = \$1 AND temperature_c IS NULL AND deleted_at IS NULL`).
+			WithArgs(int64(42), 12.5, "rain").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		updated, err := repository.NewActivityRepository(db, nil).SetWeather(context.Background(), 42, 12.5, "rain")
+		require.NoError(t, err)
+		assert.False(t, updated)
+	})
+}
+
+func TestActivityRepository_SetStartLocation(t *testing.T) {
+	t.Run("bumps the version and logs the change", func(t *testing.T) {
+		db, mock := testhelpers.SetupMockDB(t)
+		mock.ExpectQuery(loggedUpdate).
+			WithArgs(int64(42), 51.5, -0.12).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		updated, err := repository.NewActivityRepository(db, nil).SetStartLocation(context.Background(), 42, 51.5, -0.12)
+		require.NoError(t, err)
+		assert.True(t, updated)
+	})
+
+	t.Run("coordinates already set", func(t *testing.T) {
+		db, mock := testhelpers.SetupMockDB(t)
+		mock.ExpectQuery(`WHERE id = \$1 AND start_lat IS NULL AND deleted_at IS NULL`).
+			WithArgs(int64(42), 51.5, -0.12).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		updated, err := repository.NewActivityRepository(db, nil).SetStartLocation(context.Background(), 42, 51.5, -0.12)
+		require.NoError(t, err)
+		assert.False(t, updated)
+	})
+}
+
+func TestActivityRepository_SaveMetrics(t *testing.T) {
+	db, mock := testhelpers.SetupMockDB(t)
+	pace, speed := 6.0, 10.0
+	mock.ExpectQuery(loggedUpdate).
+		WithArgs(int64(42), 6.0, 10.0, 310, true, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	err := repository.NewActivityRepository(db, nil).SaveMetrics(context.Background(), &models.Activity{
+		BaseEntity:        models.BaseEntity{ID: 42},
+		PaceMinPerKm:      &pace,
+		AvgSpeedKmh:       &speed,
+		CaloriesBurned:    310,
+		CaloriesEstimated: true,
+		MetricsVersion:    2,
+	})
+	require.NoError(t, err)
+}
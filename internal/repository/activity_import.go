@@ -0,0 +1,321 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/pkg/ulid"
+)
+
+// DefaultBulkImportChunkSize is the number of activities copied per transaction
+const DefaultBulkImportChunkSize = 1000
+
+// ErrBulkImportUnsupported is returned when the connection isn't backed by pgx,
+// e.g. a sqlmock or lib/pq connection in tests
+var ErrBulkImportUnsupported = errors.New("bulk import requires a pgx database connection")
+
+// BulkImportOptions configures ActivityRepository.BulkImport
+type BulkImportOptions struct {
+	// ChunkSize is the number of activities per COPY transaction (default 1000)
+	ChunkSize int
+
+	// OnProgress is called after every chunk, whether it succeeded or not
+	OnProgress func(BulkImportProgress)
+}
+
+// BulkImportProgress reports how far a bulk import has got
+type BulkImportProgress struct {
+	Total     int
+	Processed int
+	Imported  int
+	Failed    int
+}
+
+// BulkImportChunkError describes a chunk that was rolled back.
+// Rows are 0-based indexes into the slice passed to BulkImport.
+type BulkImportChunkError struct {
+	Chunk    int
+	FirstRow int
+	LastRow  int
+	Err      error
+}
+
+// BulkImportResult summarises a finished bulk import
+type BulkImportResult struct {
+	Imported int
+	Failed   int
+	Errors   []BulkImportChunkError
+}
+
+// BulkImport inserts activities and their tag links with PostgreSQL COPY.
+//
+// Activities are split into chunks, each copied in its own transaction: a bad
+// row fails only its chunk, which is reported in the result while the
+// remaining chunks carry on. IDs are reserved from the activities sequence up
+// front so activity_tags can be copied in the same transaction, and they are
+// set on the activities of successful chunks.
+//
+// Tags are matched by name (activity.Tags[i].Name) and created if missing.
+// Callers are expected to validate activities beforehand; the returned error is
+// only non-nil if the import could not run at all (or ctx was cancelled).
+func (ar *ActivityRepository) BulkImport(
+	ctx context.Context,
+	activities []*models.Activity,
+	opts BulkImportOptions,
+) (*BulkImportResult, error) {
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultBulkImportChunkSize
+	}
+
+	conn, err := ar.db.GetRawDB().Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	result := &BulkImportResult{}
+	err = conn.Raw(func(driverConn any) error {
+		stdConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return ErrBulkImportUnsupported
+		}
+		pgxConn := stdConn.Conn()
+
+		for start, chunk := 0, 0; start < len(activities); start, chunk = start+chunkSize, chunk+1 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			end := min(start+chunkSize, len(activities))
+			batch := activities[start:end]
+
+			if err := copyActivityChunk(ctx, pgxConn, batch); err != nil {
+				result.Failed += len(batch)
+				result.Errors = append(result.Errors, BulkImportChunkError{
+					Chunk:    chunk,
+					FirstRow: start,
+					LastRow:  end - 1,
+					Err:      err,
+				})
+			} else {
+				result.Imported += len(batch)
+			}
+
+			if opts.OnProgress != nil {
+				opts.OnProgress(BulkImportProgress{
+					Total:     len(activities),
+					Processed: end,
+					Imported:  result.Imported,
+					Failed:    result.Failed,
+				})
+			}
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// copyActivityChunk copies one chunk of activities, their splits, tracks and
+// tag links in a single transaction
+func copyActivityChunk(ctx context.Context, conn *pgx.Conn, activities []*models.Activity) error {
+	ids := make([]int64, 0, len(activities))
+
+	err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		// 1. Reserve IDs so tag links can reference the rows we are about to copy
+		rows, err := tx.Query(ctx,
+			`SELECT nextval(pg_get_serial_sequence('activities', 'id')) FROM generate_series(1, $1)`,
+			len(activities))
+		if err != nil {
+			return fmt.Errorf("failed to reserve activity ids: %w", err)
+		}
+		ids, err = pgx.CollectRows(rows, pgx.RowTo[int64])
+		if err != nil {
+			return fmt.Errorf("failed to reserve activity ids: %w", err)
+		}
+
+		// 2. COPY activities
+		_, err = tx.CopyFrom(ctx,
+			pgx.Identifier{"activities"},
+			[]string{"id", "public_id", "user_id", "activity_type", "title", "description", "duration_minutes",
+				"distance_km", "calories_burned", "notes", "activity_date", "source", "external_id", "visibility"},
+			pgx.CopyFromSlice(len(activities), func(i int) ([]any, error) {
+				a := activities[i]
+				withPublicID(a)
+				withSource(a)
+				withVisibility(a)
+				return []any{ids[i], a.PublicID, a.UserID, a.ActivityType, a.Title, a.Description, a.DurationMinutes,
+					a.DistanceKm, a.CaloriesBurned, a.Notes, a.ActivityDate, a.Source, a.ExternalID, a.Visibility}, nil
+			}),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to copy activities: %w", err)
+		}
+
+		// 3. Claim the external IDs, whose unique key activities can't have as
+		// it is partitioned by date: an activity imported before fails the chunk
+		if err := copyExternalIDs(ctx, tx, activities, ids); err != nil {
+			return err
+		}
+
+		// 4. Record the new rows in the change feed (COPY can't use withChangeLog)
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO change_log (user_id, entity, entity_id, operation, version)
+			SELECT user_id, 'activities', id, $2, version FROM activities WHERE id = ANY($1)`,
+			ids, ChangeCreate); err != nil {
+			return fmt.Errorf("failed to record activity changes: %w", err)
+		}
+
+		// 5. COPY activity_splits and activity_tracks
+		if err := copySplits(ctx, tx, activities, ids); err != nil {
+			return err
+		}
+		if err := copyTracks(ctx, tx, activities, ids); err != nil {
+			return err
+		}
+
+		// 6. Get or create every tag referenced by the chunk
+		tagIDs, err := upsertTagNames(ctx, tx, activities)
+		if err != nil {
+			return err
+		}
+		if len(tagIDs) == 0 {
+			return nil
+		}
+
+		// 7. COPY activity_tags
+		var links [][]any
+		for i, a := range activities {
+			seen := make(map[int64]bool, len(a.Tags))
+			for _, tag := range a.Tags {
+				tagID := tagIDs[tag.Name]
+				if seen[tagID] {
+					continue
+				}
+				seen[tagID] = true
+				links = append(links, []any{ids[i], tagID})
+			}
+		}
+
+		if _, err := tx.CopyFrom(ctx,
+			pgx.Identifier{"activity_tags"},
+			[]string{"activity_id", "tag_id"},
+			pgx.CopyFromRows(links),
+		); err != nil {
+			return fmt.Errorf("failed to copy activity tags: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, a := range activities {
+		a.ID = ids[i]
+	}
+	return nil
+}
+
+// copyExternalIDs records the external IDs of the activities with one in
+// activity_external_ids
+func copyExternalIDs(ctx context.Context, tx pgx.Tx, activities []*models.Activity, ids []int64) error {
+	var keys [][]any
+	for i, a := range activities {
+		if a.ExternalID != nil && *a.ExternalID != "" {
+			keys = append(keys, []any{a.UserID, a.Source, *a.ExternalID, ids[i]})
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	if _, err := tx.CopyFrom(ctx,
+		pgx.Identifier{"activity_external_ids"},
+		[]string{"user_id", "source", "external_id", "activity_id"},
+		pgx.CopyFromRows(keys),
+	); err != nil {
+		return fmt.Errorf("failed to record external ids: %w", err)
+	}
+	return nil
+}
+
+// ExistingExternalIDs returns the external IDs among externalIDs that the
+// user already has an activity of source with. Deleted and archived
+// activities count, so importing an export again doesn't bring back what was
+// deleted.
+func (ar *ActivityRepository) ExistingExternalIDs(ctx context.Context, userID int, source string, externalIDs []string) (map[string]bool, error) {
+	query := `
+		SELECT external_id FROM activity_external_ids
+		WHERE user_id = $1 AND source = $2 AND external_id = ANY($3)`
+
+	rows, err := ar.db.QueryContext(ctx, query, userID, source, externalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up external ids: %w", err)
+	}
+	defer rows.Close()
+
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan external id: %w", err)
+		}
+		existing[id] = true
+	}
+	return existing, rows.Err()
+}
+
+// upsertTagNames returns the IDs of all tag names used by activities, creating missing tags
+func upsertTagNames(ctx context.Context, tx pgx.Tx, activities []*models.Activity) (map[string]int64, error) {
+	var names []string
+	seen := make(map[string]bool)
+	for _, a := range activities {
+		for _, tag := range a.Tags {
+			if !seen[tag.Name] {
+				seen[tag.Name] = true
+				names = append(names, tag.Name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	// Existing tags keep their public ID
+	publicIDs := make([]string, len(names))
+	for i := range names {
+		publicIDs[i] = ulid.New()
+	}
+
+	rows, err := tx.Query(ctx, `
+		INSERT INTO tags (name, public_id)
+		SELECT * FROM unnest($1::text[], $2::text[])
+		ON CONFLICT (name) DO UPDATE
+		SET name = EXCLUDED.name
+		RETURNING id, name`, names, publicIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert tags: %w", err)
+	}
+	defer rows.Close()
+
+	tagIDs := make(map[string]int64, len(names))
+	for rows.Next() {
+		var id int64
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tagIDs[name] = id
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to upsert tags: %w", err)
+	}
+
+	return tagIDs, nil
+}
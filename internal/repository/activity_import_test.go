@@ -0,0 +1,259 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/repository/testhelpers"
+	"github.com/valentinesamuel/activelog/pkg/database"
+)
+
+// createTestUser creates a user named username
+func createTestUser(t *testing.T, db *database.LoggingDB, username string) int {
+	t.Helper()
+	var userID int
+	err := db.QueryRowContext(context.Background(), `
+		INSERT INTO users (email, username, password_hash)
+		VALUES ($1, $2, 'x')
+		RETURNING id`, username+"@example.com", username).Scan(&userID)
+	require.NoError(t, err)
+	return userID
+}
+
+// importedActivity is a Strava run of userID with externalID on date
+func importedActivity(userID int, externalID string, date time.Time) *models.Activity {
+	return &models.Activity{
+		UserID:          userID,
+		ActivityType:    "running",
+		Title:           "Morning run",
+		DurationMinutes: 30,
+		DistanceKm:      5,
+		ActivityDate:    date,
+		Source:          string(models.ImportSourceStrava),
+		ExternalID:      &externalID,
+	}
+}
+
+// countActivities counts the user's activities with externalID
+func countActivities(t *testing.T, db *database.LoggingDB, userID int, externalID string) int {
+	t.Helper()
+	var count int
+	err := db.QueryRowContext(context.Background(),
+		`SELECT COUNT(*) FROM activities WHERE user_id = $1 AND external_id = $2`, userID, externalID).Scan(&count)
+	require.NoError(t, err)
+	return count
+}
+
+func TestActivityRepository_BulkImport_ExternalIDs(t *testing.T) {
+	db, cleanup := testhelpers.SetupIntegrationDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := repository.NewActivityRepository(db, repository.NewTagRepository(db))
+	userID := createTestUser(t, db, "importer")
+	otherID := createTestUser(t, db, "other")
+	march := time.Date(2025, 3, 1, 7, 0, 0, 0, time.UTC)
+	july := time.Date(2025, 7, 1, 7, 0, 0, 0, time.UTC)
+
+	result, err := repo.BulkImport(ctx, []*models.Activity{importedActivity(userID, "strava-1", march)}, repository.BulkImportOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Imported)
+
+	t.Run("same external id with a different date", func(t *testing.T) {
+		// Lands in another partition, so the unique index on activities
+		// alone wouldn't catch it
+		result, err := repo.BulkImport(ctx, []*models.Activity{importedActivity(userID, "strava-1", july)}, repository.BulkImportOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Imported)
+		assert.Equal(t, 1, result.Failed)
+		require.Len(t, result.Errors, 1)
+		assert.ErrorContains(t, result.Errors[0].Err, "external ids")
+		assert.Equal(t, 1, countActivities(t, db, userID, "strava-1"))
+	})
+
+	t.Run("same external id twice in one import", func(t *testing.T) {
+		result, err := repo.BulkImport(ctx, []*models.Activity{
+			importedActivity(userID, "strava-2", march),
+			importedActivity(userID, "strava-2", july),
+		}, repository.BulkImportOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Failed)
+		assert.Equal(t, 0, countActivities(t, db, userID, "strava-2"))
+	})
+
+	t.Run("same external id of another source or user", func(t *testing.T) {
+		gpx := importedActivity(userID, "strava-1", july)
+		gpx.Source = string(models.ImportSourceGPX)
+		result, err := repo.BulkImport(ctx, []*models.Activity{gpx, importedActivity(otherID, "strava-1", july)}, repository.BulkImportOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Imported)
+	})
+
+	t.Run("existing external ids", func(t *testing.T) {
+		existing, err := repo.ExistingExternalIDs(ctx, userID, string(models.ImportSourceStrava), []string{"strava-1", "strava-2", "strava-3"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]bool{"strava-1": true}, existing)
+	})
+}
+
+func TestActivityRepository_BulkImport_Unsupported(t *testing.T) {
+	db, _ := testhelpers.SetupMockDB(t)
+	repo := repository.NewActivityRepository(db, repository.NewTagRepository(db))
+
+	_, err := repo.BulkImport(context.Background(), []*models.Activity{{Title: "Run"}}, repository.BulkImportOptions{})
+	assert.ErrorIs(t, err, repository.ErrBulkImportUnsupported)
+}
+
+func TestActivityRepository_BulkImport_Chunks(t *testing.T) {
+	db, cleanup := testhelpers.SetupIntegrationDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := repository.NewActivityRepository(db, repository.NewTagRepository(db))
+	userID := createTestUser(t, db, "chunks")
+	date := time.Date(2025, 3, 1, 7, 0, 0, 0, time.UTC)
+
+	activities := func(n int, prefix string) []*models.Activity {
+		list := make([]*models.Activity, n)
+		for i := range list {
+			list[i] = importedActivity(userID, fmt.Sprintf("%s-%d", prefix, i), date.AddDate(0, 0, i))
+			list[i].Tags = []*models.Tag{{Name: "imported"}}
+		}
+		return list
+	}
+
+	tests := []struct {
+		name      string
+		count     int
+		chunkSize int
+		bad       int // index of a row the database rejects; -1 for none
+		want      repository.BulkImportResult
+		progress  []repository.BulkImportProgress
+	}{
+		{
+			name:      "exact chunks",
+			count:     4,
+			chunkSize: 2,
+			bad:       -1,
+			want:      repository.BulkImportResult{Imported: 4},
+			progress: []repository.BulkImportProgress{
+				{Total: 4, Processed: 2, Imported: 2},
+				{Total: 4, Processed: 4, Imported: 4},
+			},
+		},
+		{
+			name:      "short last chunk",
+			count:     5,
+			chunkSize: 2,
+			bad:       -1,
+			want:      repository.BulkImportResult{Imported: 5},
+			progress: []repository.BulkImportProgress{
+				{Total: 5, Processed: 2, Imported: 2},
+				{Total: 5, Processed: 4, Imported: 4},
+				{Total: 5, Processed: 5, Imported: 5},
+			},
+		},
+		{
+			name:      "one chunk",
+			count:     3,
+			chunkSize: 0, // DefaultBulkImportChunkSize
+			bad:       -1,
+			want:      repository.BulkImportResult{Imported: 3},
+			progress:  []repository.BulkImportProgress{{Total: 3, Processed: 3, Imported: 3}},
+		},
+		{
+			name:      "failed middle chunk",
+			count:     5,
+			chunkSize: 2,
+			bad:       3,
+			want: repository.BulkImportResult{Imported: 3, Failed: 2, Errors: []repository.BulkImportChunkError{
+				{Chunk: 1, FirstRow: 2, LastRow: 3},
+			}},
+			progress: []repository.BulkImportProgress{
+				{Total: 5, Processed: 2, Imported: 2},
+				{Total: 5, Processed: 4, Imported: 2, Failed: 2},
+				{Total: 5, Processed: 5, Imported: 3, Failed: 2},
+			},
+		},
+		{
+			name:      "failed last chunk",
+			count:     5,
+			chunkSize: 2,
+			bad:       4,
+			want: repository.BulkImportResult{Imported: 4, Failed: 1, Errors: []repository.BulkImportChunkError{
+				{Chunk: 2, FirstRow: 4, LastRow: 4},
+			}},
+			progress: []repository.BulkImportProgress{
+				{Total: 5, Processed: 2, Imported: 2},
+				{Total: 5, Processed: 4, Imported: 4},
+				{Total: 5, Processed: 5, Imported: 4, Failed: 1},
+			},
+		},
+		{
+			name:      "nothing to import",
+			count:     0,
+			chunkSize: 2,
+			bad:       -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batch := activities(tt.count, tt.name)
+			if tt.bad >= 0 {
+				// Longer than activities.title allows
+				batch[tt.bad].Title = strings.Repeat("x", 300)
+			}
+
+			var progress []repository.BulkImportProgress
+			result, err := repo.BulkImport(ctx, batch, repository.BulkImportOptions{
+				ChunkSize:  tt.chunkSize,
+				OnProgress: func(p repository.BulkImportProgress) { progress = append(progress, p) },
+			})
+			require.NoError(t, err)
+
+			for i := range result.Errors {
+				assert.Error(t, result.Errors[i].Err)
+				result.Errors[i].Err = nil
+			}
+			assert.Equal(t, tt.want, *result)
+			assert.Equal(t, tt.progress, progress)
+
+			// Activities of successful chunks get the IDs reserved for them,
+			// those of failed chunks none, and no row exists for the IDs a
+			// failed chunk reserved
+			failed := make(map[int]bool)
+			for _, chunkErr := range tt.want.Errors {
+				for row := chunkErr.FirstRow; row <= chunkErr.LastRow; row++ {
+					failed[row] = true
+				}
+			}
+			seen := make(map[int64]bool)
+			for i, a := range batch {
+				if failed[i] {
+					assert.Zero(t, a.ID, "row %d", i)
+					assert.Equal(t, 0, countActivities(t, db, userID, *a.ExternalID), "row %d", i)
+					continue
+				}
+				require.NotZero(t, a.ID, "row %d", i)
+				assert.False(t, seen[a.ID], "row %d has a duplicate ID", i)
+				seen[a.ID] = true
+
+				var externalID string
+				var tags int
+				require.NoError(t, db.QueryRowContext(ctx, `
+					SELECT a.external_id, (SELECT COUNT(*) FROM activity_tags t WHERE t.activity_id = a.id)
+					FROM activities a WHERE a.id = $1`, a.ID).Scan(&externalID, &tags))
+				assert.Equal(t, *a.ExternalID, externalID, "row %d", i)
+				assert.Equal(t, 1, tags, "row %d", i)
+			}
+		})
+	}
+}
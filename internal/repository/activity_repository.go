@@ -7,14 +7,17 @@ import (
 	"time"
 
 	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/pkg/dberr"
 	"github.com/valentinesamuel/activelog/pkg/errors"
 	"github.com/valentinesamuel/activelog/pkg/query"
+	"github.com/valentinesamuel/activelog/pkg/ulid"
 )
 
 type ActivityRepository struct {
-	db       DBConn
-	tagRepo  *TagRepository
-	registry *query.RelationshipRegistry
+	db         DBConn
+	tagRepo    *TagRepository
+	registry   *query.RelationshipRegistry
+	validation *query.EntityValidation
 }
 
 type ActivityStats struct {
@@ -50,10 +53,21 @@ func NewActivityRepository(db DBConn, tagRepo *TagRepository) *ActivityRepositor
 		"user_id", // FK in activities table
 	))
 
+	// Register One-to-Many relationship: activities -> activity_photos
+	// Used by include=photos; soft-deleted photos are excluded
+	registry.Register(query.OneToManyRelationship(
+		"photos",          // Relationship name (include=photos)
+		"activity_photos", // Target table
+		"activity_id",     // FK in activity_photos table
+	).WithConditions(
+		query.AdditionalCondition{Column: "activity_photos.deleted_at", Operator: "eq", Value: nil},
+	))
+
 	return &ActivityRepository{
-		db:       db,
-		tagRepo:  tagRepo,
-		registry: registry,
+		db:         db,
+		tagRepo:    tagRepo,
+		registry:   registry,
+		validation: newActivityValidation(),
 	}
 }
 
@@ -63,25 +77,111 @@ func (ar *ActivityRepository) GetRegistry() *query.RelationshipRegistry {
 	return ar.registry
 }
 
+// GetValidation returns the query whitelist of activities
+// Registered with the ValidationConfigRegistry next to the RelationshipRegistry
+func (ar *ActivityRepository) GetValidation() *query.EntityValidation {
+	return ar.validation
+}
+
+// newActivityValidation defines which activity columns clients may filter,
+// search and order by (CRITICAL: only safe columns)
+func newActivityValidation() *query.EntityValidation {
+	v := query.NewEntityValidation("activities")
+
+	// Direct columns (main table)
+	v.Column("activity_type", query.ColumnRule{Filter: true, Operators: query.EqualityOperators(), Type: query.TypeString})
+	v.Column("activity_date", query.ColumnRule{Filter: true, Order: true, Operators: query.ComparisonOperators(), Type: query.TypeTimestamp})
+	v.Column("duration_minutes", query.ColumnRule{Filter: true, Order: true, Operators: query.ComparisonOperators(), Type: query.TypeInt})
+	v.Column("distance_km", query.ColumnRule{Filter: true, Order: true, Operators: query.ComparisonOperators(), Type: query.TypeFloat})
+	v.Column("calories_burned", query.ColumnRule{Filter: true, Order: true, Operators: query.ComparisonOperators(), Type: query.TypeInt})
+	v.Column("pace_min_per_km", query.ColumnRule{Filter: true, Order: true, Operators: query.ComparisonOperators(), Type: query.TypeFloat})
+	v.Column("avg_speed_kmh", query.ColumnRule{Filter: true, Order: true, Operators: query.ComparisonOperators(), Type: query.TypeFloat})
+	v.Column("rpe", query.ColumnRule{Filter: true, Order: true, Operators: query.ComparisonOperators(), Type: query.TypeInt})
+	v.Column("mood", query.ColumnRule{Filter: true, Order: true, Operators: query.ComparisonOperators(), Type: query.TypeInt})
+	v.Column("created_at", query.ColumnRule{Filter: true, Order: true, Operators: query.ComparisonOperators(), Type: query.TypeTimestamp})
+	v.Column("updated_at", query.ColumnRule{Filter: true, Order: true, Type: query.TypeTimestamp})
+	v.Column("source", query.ColumnRule{Filter: true, Operators: query.EqualityOperators(), Type: query.TypeString})
+	v.Column("external_id", query.ColumnRule{Filter: true, Operators: query.StrictEqualityOnly(), Type: query.TypeString})
+	v.Column("visibility", query.ColumnRule{Filter: true, Operators: query.EqualityOperators(), Type: query.TypeString})
+	v.Column("title", query.ColumnRule{Search: true, Type: query.TypeString})
+	v.Column("description", query.ColumnRule{Search: true, Type: query.TypeString})
+	v.Column("notes", query.ColumnRule{Search: true, Type: query.TypeString})
+
+	// Virtual point column (bounding box: filter[location][within]=lat1,lng1,lat2,lng2)
+	v.Column("location", query.ColumnRule{Filter: true, Operators: query.GeoOperators()})
+
+	// Keys of the metadata document (filter[metadata.shoe]=pegasus), compared as text
+	v.JSON("metadata", query.ColumnRule{Filter: true, Operators: query.EqualityOperators(), Type: query.TypeString})
+
+	// Denormalized tag names, filtered without a JOIN:
+	// filter[tags][contains]=[cardio,outdoor] (all), [overlaps] (any of), [any] (one)
+	v.Column("tags", query.ColumnRule{Filter: true, Operators: query.ArrayOperators(), Type: query.TypeString})
+
+	// Relationship columns (natural names - auto-JOINs!)
+	v.Column("tags.name", query.ColumnRule{Filter: true, Search: true, Order: true, Operators: query.EqualityOperators(), Type: query.TypeString})
+	v.Column("tags.id", query.ColumnRule{Filter: true, Operators: query.StrictEqualityOnly(), Type: query.TypeInt})
+
+	// Cross-registry: activities → users
+	v.Column("users.id", query.ColumnRule{Filter: true, Operators: query.StrictEqualityOnly(), Type: query.TypeInt})
+	v.Column("users.username", query.ColumnRule{Filter: true, Search: true, Operators: query.EqualityOperators(), Type: query.TypeString})
+	v.Column("users.email", query.ColumnRule{Filter: true, Search: true, Operators: query.StrictEqualityOnly(), Type: query.TypeString})
+	v.Deny("users.password_hash")
+
+	// Deep nesting: activities → tags → parent tag
+	v.Column("tags.parent.name", query.ColumnRule{Filter: true, Search: true, Operators: query.EqualityOperators(), Type: query.TypeString})
+
+	// Short names used by the mobile clients
+	v.Alias("type", "activity_type")
+	v.Alias("date", "activity_date")
+	v.Alias("duration", "duration_minutes")
+	v.Alias("distance", "distance_km")
+	v.Alias("calories", "calories_burned")
+	v.Alias("effort", "rpe")
+	v.Alias("tag", "tags.name")
+
+	// Relations clients can preload with include=, and the columns returned
+	v.Include("tags", "id", "name")
+	v.Include("tags.parent", "id", "name")
+	v.Include("users", "id", "username")
+	v.Include("photos", "id", "content_type", "file_size", "uploaded_at")
+	v.Alias("user", "users")
+
+	return v
+}
+
 // Create creates a new activity
 // tx is optional - if nil, uses direct DB connection; if provided, uses the transaction
 func (ar *ActivityRepository) Create(ctx context.Context, tx TxConn, activity *models.Activity) error {
-	query := `
+	query := withChangeLog("activities", ChangeCreate, `
 		INSERT INTO activities
-		(user_id, activity_type, title, description, duration_minutes, distance_km, calories_burned, notes, activity_date)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-		RETURNING id, created_at, updated_at
-	`
+		(user_id, activity_type, title, description, duration_minutes, distance_km, calories_burned, notes, activity_date,
+		 start_lat, start_lng, end_lat, end_lng, location_name,
+		 pace_min_per_km, avg_speed_kmh, calories_estimated, metrics_version,
+		 avg_heart_rate, hr_zone_seconds, training_load, rpe, mood, metadata, public_id, source, external_id, visibility)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28)
+		RETURNING id, user_id, version, created_at, updated_at
+	`, "*")
+
+	withPublicID(activity)
+	withSource(activity)
+	withVisibility(activity)
 
 	// Use helper - automatically chooses tx or db
 	row := QueryRowInTx(ctx, tx, ar.db, query,
 		activity.UserID, activity.ActivityType, activity.Title, activity.Description,
 		activity.DurationMinutes, activity.DistanceKm, activity.CaloriesBurned,
-		activity.Notes, activity.ActivityDate)
+		activity.Notes, activity.ActivityDate, activity.StartLat, activity.StartLng,
+		activity.EndLat, activity.EndLng, activity.LocationName,
+		activity.PaceMinPerKm, activity.AvgSpeedKmh, activity.CaloriesEstimated, activity.MetricsVersion,
+		activity.AvgHeartRate, activity.HRZoneSeconds, activity.TrainingLoad, activity.RPE, activity.Mood, activity.Metadata,
+		activity.PublicID, activity.Source, activity.ExternalID, activity.Visibility)
 
-	err := row.Scan(&activity.ID, &activity.CreatedAt, &activity.UpdatedAt)
+	err := row.Scan(&activity.ID, &activity.UserID, &activity.Version, &activity.CreatedAt, &activity.UpdatedAt)
 	if err != nil {
-		return fmt.Errorf("❌ Error creating activity %w", err)
+		return dberr.Translate(&errors.DatabaseError{Op: "INSERT", Table: "activities", Err: err})
+	}
+	if err := ar.insertSplits(ctx, tx, activity); err != nil {
+		return err
 	}
 
 	fmt.Println("✅ Activity created successfully!")
@@ -90,39 +190,21 @@ func (ar *ActivityRepository) Create(ctx context.Context, tx TxConn, activity *m
 
 func (ar *ActivityRepository) GetByID(ctx context.Context, id int64) (*models.Activity, error) {
 	query := `
-		SELECT id, user_id, activity_type, title, description, duration_minutes, distance_km, calories_burned, notes, activity_date, created_at, updated_at, deleted_at
+		SELECT ` + activityColumns + `
 		FROM activities
 		WHERE id = $1
 	`
 
 	activity := &models.Activity{}
 
-	err := ar.db.QueryRowContext(ctx, query, id).Scan(
-		&activity.ID,
-		&activity.UserID,
-		&activity.ActivityType,
-		&activity.Title,
-		&activity.Description,
-		&activity.DurationMinutes,
-		&activity.DistanceKm,
-		&activity.CaloriesBurned,
-		&activity.Notes,
-		&activity.ActivityDate,
-		&activity.CreatedAt,
-		&activity.UpdatedAt,
-		&activity.DeletedAt,
-	)
-
-	if err == sql.ErrNoRows {
-		return nil, errors.ErrNotFound
-	}
+	err := ar.db.QueryRowContext(ctx, query, id).Scan(activityScanDest(activity)...)
 
 	if err != nil {
-		return nil, &errors.DatabaseError{
+		return nil, dberr.Translate(&errors.DatabaseError{
 			Op:    "SELECT",
 			Table: "activities",
 			Err:   err,
-		}
+		})
 	}
 
 	fmt.Println("✅ Activity fetched successfully!")
@@ -130,10 +212,20 @@ func (ar *ActivityRepository) GetByID(ctx context.Context, id int64) (*models.Ac
 	return activity, nil
 }
 
+// IDByPublicID returns the ID of the activity with the given public ID,
+// or ErrNotFound
+func (ar *ActivityRepository) IDByPublicID(ctx context.Context, publicID string) (int64, error) {
+	var id int64
+	err := ar.db.QueryRowContext(ctx, `SELECT id FROM activities WHERE public_id = $1`, publicID).Scan(&id)
+	if err != nil {
+		return 0, dberr.Translate(&errors.DatabaseError{Op: "SELECT", Table: "activities", Err: err})
+	}
+	return id, nil
+}
+
 func (ar *ActivityRepository) ListByUser(ctx context.Context, UserID int) ([]*models.Activity, error) {
 	query := `
-		SELECT id, user_id, activity_type, title, description, duration_minutes,
-			distance_km, calories_burned, notes, activity_date, created_at, updated_at, deleted_at
+		SELECT ` + activityColumns + `
 		FROM activities
 		WHERE user_id = $1
 		ORDER BY activity_date DESC
@@ -150,21 +242,7 @@ func (ar *ActivityRepository) ListByUser(ctx context.Context, UserID int) ([]*mo
 
 	for rows.Next() {
 		activity := &models.Activity{}
-		err := rows.Scan(
-			&activity.ID,
-			&activity.UserID,
-			&activity.ActivityType,
-			&activity.Title,
-			&activity.Description,
-			&activity.DurationMinutes,
-			&activity.DistanceKm,
-			&activity.CaloriesBurned,
-			&activity.Notes,
-			&activity.ActivityDate,
-			&activity.CreatedAt,
-			&activity.UpdatedAt,
-			&activity.DeletedAt,
-		)
+		err := rows.Scan(activityScanDest(activity)...)
 
 		if err != nil {
 			return nil, fmt.Errorf("❌ Error scanning activity: %w", err)
@@ -182,72 +260,87 @@ func (ar *ActivityRepository) ListByUser(ctx context.Context, UserID int) ([]*mo
 	return activities, nil
 }
 
-func (ar *ActivityRepository) Count(userID int) (int, error) {
+func (ar *ActivityRepository) Count(ctx context.Context, userID int) (int, error) {
 	var count int
 	query := "SELECT COUNT(*) FROM activities WHERE user_id = $1"
-	err := ar.db.QueryRow(query, userID).Scan(&count)
+	err := ar.db.QueryRowContext(ctx, query, userID).Scan(&count)
 	return count, err
 }
 
+// activityUpdateColumns are the columns Update may write; ownership, timestamps
+// and location are not among them
+var activityUpdateColumns = []string{
+	"activity_type", "title", "description", "duration_minutes", "distance_km",
+	"calories_burned", "notes", "activity_date", "pace_min_per_km", "avg_speed_kmh",
+	"calories_estimated", "metrics_version", "rpe", "mood", "metadata", "visibility",
+}
+
 // Update updates an existing activity
 // tx is optional - if nil, uses direct DB connection; if provided, uses the transaction
 func (ar *ActivityRepository) Update(ctx context.Context, tx TxConn, id int, activity *models.Activity) error {
-	query := `
-		UPDATE activities
-		SET activity_type = $1, title = $2, description = $3,
-			duration_minutes = $4, distance_km = $5, calories_burned = $6,
-			notes = $7, activity_date = $8, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $9 AND user_id = $10
-		RETURNING updated_at
-	`
+	stmt, args, err := query.NewUpdateBuilder("activities", activityUpdateColumns...).
+		SetMap(map[string]interface{}{
+			"activity_type":      activity.ActivityType,
+			"title":              activity.Title,
+			"description":        activity.Description,
+			"duration_minutes":   activity.DurationMinutes,
+			"distance_km":        activity.DistanceKm,
+			"calories_burned":    activity.CaloriesBurned,
+			"notes":              activity.Notes,
+			"activity_date":      activity.ActivityDate,
+			"pace_min_per_km":    activity.PaceMinPerKm,
+			"avg_speed_kmh":      activity.AvgSpeedKmh,
+			"calories_estimated": activity.CaloriesEstimated,
+			"metrics_version":    activity.MetricsVersion,
+			"rpe":                activity.RPE,
+			"mood":               activity.Mood,
+			"metadata":           activity.Metadata,
+			"visibility":         activity.Visibility,
+		}).
+		Where(
+			query.FilterCondition{Column: "id", Operator: "eq", Value: id},
+			query.FilterCondition{Column: "user_id", Operator: "eq", Value: activity.UserID},
+		).
+		UpdatedAt("updated_at").
+		Version("version").
+		Returning("id", "user_id", "version", "updated_at").
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to build update: %w", err)
+	}
 
 	// Use helper - automatically chooses tx or db
-	row := QueryRowInTx(ctx, tx, ar.db, query,
-		activity.ActivityType,
-		activity.Title,
-		activity.Description,
-		activity.DurationMinutes,
-		activity.DistanceKm,
-		activity.CaloriesBurned,
-		activity.Notes,
-		activity.ActivityDate,
-		id,
-		activity.UserID,
-	)
+	row := QueryRowInTx(ctx, tx, ar.db, withChangeLog("activities", ChangeUpdate, stmt, "*"), args...)
 
-	err := row.Scan(&activity.UpdatedAt)
-	if err == sql.ErrNoRows {
-		return fmt.Errorf("❌ Activity not found")
+	err = row.Scan(&activity.ID, &activity.UserID, &activity.Version, &activity.UpdatedAt)
+	if err != nil {
+		return dberr.Translate(&errors.DatabaseError{Op: "UPDATE", Table: "activities", Err: err})
 	}
 
-	return err
+	return nil
 }
 
 // Delete deletes an activity
 // tx is optional - if nil, uses direct DB connection; if provided, uses the transaction
 func (ar *ActivityRepository) Delete(ctx context.Context, tx TxConn, id int, userID int) error {
 	// query := "DELETE FROM activities WHERE id = $1 AND user_id = $2"
-	query := "UPDATE activities set deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND user_id = $2"
+	query := withChangeLog("activities", ChangeDelete, `
+		UPDATE activities SET deleted_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
+		RETURNING id, user_id, version
+	`, "id")
 
 	// Use helper - automatically chooses tx or db
-	result, err := ExecInTx(ctx, tx, ar.db, query, id, userID)
+	var deletedID int64
+	err := QueryRowInTx(ctx, tx, ar.db, query, id, userID).Scan(&deletedID)
 	if err != nil {
-		return err
-	}
-
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
-
-	if rows == 0 {
-		return fmt.Errorf("❌ Activity not found")
+		return dberr.Translate(&errors.DatabaseError{Op: "DELETE", Table: "activities", Err: err})
 	}
 
 	return nil
 }
 
-func (r *ActivityRepository) GetStats(userID int, startDate, endDate *time.Time) (*ActivityStats, error) {
+func (r *ActivityRepository) GetStats(ctx context.Context, userID int, startDate, endDate *time.Time) (*ActivityStats, error) {
 
 	query := `
 	SELECT 
@@ -278,7 +371,7 @@ func (r *ActivityRepository) GetStats(userID int, startDate, endDate *time.Time)
 		ActivityTypes: make(map[string]int),
 	}
 
-	err := r.db.QueryRow(query, args...).Scan(
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(
 		&stats.TotalActivities,
 		&stats.TotalDuration,
 		&stats.TotalDistance,
@@ -311,9 +404,9 @@ func (r *ActivityRepository) GetStats(userID int, startDate, endDate *time.Time)
 
 	typeQuery += " GROUP BY activity_type"
 
-	rows, err := r.db.Query(typeQuery, typeArgs...)
+	rows, err := r.db.QueryContext(ctx, typeQuery, typeArgs...)
 	if err != nil {
-		return stats, nil
+		return nil, err
 	}
 
 	defer rows.Close()
@@ -326,7 +419,113 @@ func (r *ActivityRepository) GetStats(userID int, startDate, endDate *time.Time)
 		}
 	}
 
-	return stats, nil
+	return stats, rows.Err()
+}
+
+// FindDuplicate looks for a live activity of the same user and type whose
+// activity_date falls within window of the candidate and whose duration and
+// distance match. Returns errors.ErrNotFound when no duplicate exists.
+// tx is optional - if nil, uses direct DB connection; if provided, uses the transaction
+func (ar *ActivityRepository) FindDuplicate(ctx context.Context, tx TxConn, activity *models.Activity, window time.Duration) (*models.Activity, error) {
+	query := `
+		SELECT ` + activityColumns + `
+		FROM activities
+		WHERE user_id = $1
+			AND activity_type = $2
+			AND deleted_at IS NULL
+			AND activity_date BETWEEN $3 AND $4
+			AND duration_minutes = $5
+			AND distance_km = $6
+		ORDER BY ABS(EXTRACT(EPOCH FROM (activity_date - $7)))
+		LIMIT 1
+	`
+
+	duplicate := &models.Activity{}
+	err := QueryRowInTx(ctx, tx, ar.db, query,
+		activity.UserID,
+		activity.ActivityType,
+		activity.ActivityDate.Add(-window),
+		activity.ActivityDate.Add(window),
+		activity.DurationMinutes,
+		activity.DistanceKm,
+		activity.ActivityDate,
+	).Scan(activityScanDest(duplicate)...)
+
+	if err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{
+			Op:    "SELECT",
+			Table: "activities",
+			Err:   err,
+		})
+	}
+
+	return duplicate, nil
+}
+
+// UpdateByFilter applies changes to every live activity of the user matched by opts
+// in a single UPDATE ... WHERE statement and returns the number of rows changed.
+// The keys of changes are column names and must be whitelisted by the caller.
+//
+// If more than maxRows rows match, a *errors.BulkLimitError is returned. The statement
+// has already run at that point, so tx must be a transaction the caller rolls back on error.
+func (ar *ActivityRepository) UpdateByFilter(ctx context.Context, tx TxConn, userID int, opts *query.QueryOptions, changes map[string]interface{}, maxRows int) (int64, error) {
+	set := make(map[string]interface{}, len(changes)+1)
+	for column, value := range changes {
+		set[column] = value
+	}
+	set["updated_at"] = query.CurrentTimestamp
+	set["version"] = query.Increment("version", 1)
+
+	// Derived metrics are stale now; the metrics backfill recomputes them
+	set["metrics_version"] = 0
+	if _, ok := changes["calories_burned"]; ok {
+		set["calories_estimated"] = false
+	}
+
+	return ar.execByFilter(ctx, tx, userID, opts, set, ChangeUpdate, maxRows)
+}
+
+// DeleteByFilter soft-deletes every live activity of the user matched by opts
+// in a single UPDATE ... WHERE statement and returns the number of rows deleted.
+// Follows the same maxRows contract as UpdateByFilter.
+func (ar *ActivityRepository) DeleteByFilter(ctx context.Context, tx TxConn, userID int, opts *query.QueryOptions, maxRows int) (int64, error) {
+	set := map[string]interface{}{
+		"deleted_at": query.CurrentTimestamp,
+		"version":    query.Increment("version", 1),
+	}
+	return ar.execByFilter(ctx, tx, userID, opts, set, ChangeDelete, maxRows)
+}
+
+// execByFilter scopes opts to the user's live activities and runs the bulk UPDATE,
+// recording every changed row in change_log as operation
+func (ar *ActivityRepository) execByFilter(ctx context.Context, tx TxConn, userID int, opts *query.QueryOptions, set map[string]interface{}, operation string, maxRows int) (int64, error) {
+	// Copy the filter map so scoping never leaks back into the caller's options
+	scoped := *opts
+	scoped.Filter = make(map[string]interface{}, len(opts.Filter)+2)
+	for column, value := range opts.Filter {
+		scoped.Filter[column] = value
+	}
+	scoped.Filter["user_id"] = userID
+	scoped.Filter["deleted_at"] = nil
+
+	sqlQuery, args, err := query.BuildUpdate("activities", &scoped, set)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build bulk update: %w", err)
+	}
+
+	sqlQuery = withChangeLog("activities", operation, sqlQuery+" RETURNING id, user_id, version", "COUNT(*)")
+
+	var affected int64
+	err = QueryRowInTx(ctx, tx, ar.db, sqlQuery, args...).Scan(&affected)
+	if err != nil {
+		return 0, dberr.Translate(&errors.DatabaseError{Op: "UPDATE", Table: "activities", Err: err})
+	}
+
+	if maxRows > 0 && affected > int64(maxRows) {
+		return 0, &errors.BulkLimitError{Limit: maxRows, Affected: affected}
+	}
+
+	return affected, nil
 }
 
 // CreateWithTags creates an activity with associated tags in a transaction
@@ -335,45 +534,54 @@ func (ar *ActivityRepository) CreateWithTags(ctx context.Context, activity *mode
 	// Use WithTransaction helper for automatic commit/rollback
 	return WithTransaction(ctx, ar.db, func(tx TxConn) error {
 		// 1. Insert activity
-		activityQuery := `
+		activityQuery := withChangeLog("activities", ChangeCreate, `
 			INSERT INTO activities
-			(user_id, activity_type, title, description, duration_minutes, distance_km, calories_burned, notes, activity_date)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-			RETURNING id, created_at, updated_at
-		`
+			(user_id, activity_type, title, description, duration_minutes, distance_km, calories_burned, notes, activity_date,
+			 start_lat, start_lng, end_lat, end_lng, location_name,
+			 pace_min_per_km, avg_speed_kmh, calories_estimated, metrics_version,
+			 avg_heart_rate, hr_zone_seconds, training_load, rpe, mood, metadata, public_id, source, external_id, visibility)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28)
+			RETURNING id, user_id, version, created_at, updated_at
+		`, "*")
+		withPublicID(activity)
+		withSource(activity)
+		withVisibility(activity)
 		row := QueryRowInTx(ctx, tx, ar.db, activityQuery,
 			activity.UserID, activity.ActivityType, activity.Title, activity.Description,
 			activity.DurationMinutes, activity.DistanceKm, activity.CaloriesBurned,
-			activity.Notes, activity.ActivityDate)
-
-		if err := row.Scan(&activity.ID, &activity.CreatedAt, &activity.UpdatedAt); err != nil {
-			return fmt.Errorf("failed to insert activity: %w", err)
+			activity.Notes, activity.ActivityDate, activity.StartLat, activity.StartLng,
+			activity.EndLat, activity.EndLng, activity.LocationName,
+			activity.PaceMinPerKm, activity.AvgSpeedKmh, activity.CaloriesEstimated, activity.MetricsVersion,
+			activity.AvgHeartRate, activity.HRZoneSeconds, activity.TrainingLoad, activity.RPE, activity.Mood, activity.Metadata,
+			activity.PublicID, activity.Source, activity.ExternalID, activity.Visibility)
+
+		if err := row.Scan(&activity.ID, &activity.UserID, &activity.Version, &activity.CreatedAt, &activity.UpdatedAt); err != nil {
+			return dberr.Translate(fmt.Errorf("failed to insert activity: %w", err))
+		}
+		if err := ar.insertSplits(ctx, tx, activity); err != nil {
+			return err
 		}
 
 		// 2. Create tags and link them (within the same transaction)
 		for _, tag := range tags {
 			// Get or create tag
-			tagQuery := `
-				INSERT INTO tags (name)
-				VALUES ($1)
-				ON CONFLICT (name) DO UPDATE
-				SET name = EXCLUDED.name
-				RETURNING id
-			`
+			tagQuery, tagArgs, err := upsertTagQuery(tag.Name)
+			if err != nil {
+				return err
+			}
 			var tagID int
-			row := QueryRowInTx(ctx, tx, ar.db, tagQuery, tag.Name)
+			row := QueryRowInTx(ctx, tx, ar.db, tagQuery, tagArgs...)
 			if err := row.Scan(&tagID); err != nil {
-				return fmt.Errorf("failed to create tag: %w", err)
+				return dberr.Translate(fmt.Errorf("failed to create tag: %w", err))
 			}
 
 			// Link activity to tag
-			linkQuery := `
-				INSERT INTO activity_tags (tag_id, activity_id)
-				VALUES ($1, $2)
-				ON CONFLICT (tag_id, activity_id) DO NOTHING
-			`
-			if _, err := ExecInTx(ctx, tx, ar.db, linkQuery, tagID, activity.ID); err != nil {
-				return fmt.Errorf("failed to link activity to tag: %w", err)
+			linkQuery, linkArgs, err := linkActivityTagQuery(int(activity.ID), tagID)
+			if err != nil {
+				return err
+			}
+			if _, err := ExecInTx(ctx, tx, ar.db, linkQuery, linkArgs...); err != nil {
+				return dberr.Translate(fmt.Errorf("failed to link activity to tag: %w", err))
 			}
 		}
 
@@ -385,7 +593,22 @@ func (ar *ActivityRepository) CreateWithTags(ctx context.Context, activity *mode
 // Used by the generic FindAndPaginate function for dynamic filtering
 func (ar *ActivityRepository) scanActivity(rows *sql.Rows) (*models.Activity, error) {
 	activity := &models.Activity{}
-	err := rows.Scan(
+	err := rows.Scan(activityScanDest(activity)...)
+	return activity, err
+}
+
+// activityColumns lists the columns of activities in table order, so it
+// matches both activityScanDest and SELECT activities.*
+const activityColumns = `id, user_id, activity_type, title, description, duration_minutes, distance_km,
+	calories_burned, notes, activity_date, created_at, updated_at, deleted_at, version,
+	start_lat, start_lng, temperature_c, weather_conditions, end_lat, end_lng, location_name,
+	pace_min_per_km, avg_speed_kmh, calories_estimated, metrics_version,
+	avg_heart_rate, hr_zone_seconds, training_load, rpe, mood, metadata, tags, public_id,
+	source, external_id, visibility`
+
+// activityScanDest returns the scan destinations for activityColumns
+func activityScanDest(activity *models.Activity) []interface{} {
+	return []interface{}{
 		&activity.ID,
 		&activity.UserID,
 		&activity.ActivityType,
@@ -399,8 +622,53 @@ func (ar *ActivityRepository) scanActivity(rows *sql.Rows) (*models.Activity, er
 		&activity.CreatedAt,
 		&activity.UpdatedAt,
 		&activity.DeletedAt,
-	)
-	return activity, err
+		&activity.Version,
+		&activity.StartLat,
+		&activity.StartLng,
+		&activity.TemperatureC,
+		&activity.WeatherConditions,
+		&activity.EndLat,
+		&activity.EndLng,
+		&activity.LocationName,
+		&activity.PaceMinPerKm,
+		&activity.AvgSpeedKmh,
+		&activity.CaloriesEstimated,
+		&activity.MetricsVersion,
+		&activity.AvgHeartRate,
+		pgTypes.SQLScanner(&activity.HRZoneSeconds),
+		&activity.TrainingLoad,
+		&activity.RPE,
+		&activity.Mood,
+		&activity.Metadata,
+		pgTypes.SQLScanner(&activity.TagNames),
+		&activity.PublicID,
+		&activity.Source,
+		&activity.ExternalID,
+		&activity.Visibility,
+	}
+}
+
+// withSource marks an activity that doesn't say where it came from as
+// logged manually
+func withSource(activity *models.Activity) {
+	if activity.Source == "" {
+		activity.Source = models.ActivitySourceManual
+	}
+}
+
+// withVisibility gives an activity that doesn't say who may see it the
+// column default, followers
+func withVisibility(activity *models.Activity) {
+	if activity.Visibility == "" {
+		activity.Visibility = models.VisibilityFollowers
+	}
+}
+
+// withPublicID gives a new activity its public ID, unless the caller chose one
+func withPublicID(activity *models.Activity) {
+	if activity.PublicID == "" {
+		activity.PublicID = ulid.New()
+	}
 }
 
 // ListActivitiesWithQuery uses the new dynamic filtering pattern with QueryOptions
@@ -426,16 +694,16 @@ func (ar *ActivityRepository) scanActivity(rows *sql.Rows) (*models.Activity, er
 //	        "title": "morning",
 //	        "tags.name": "run",     // Auto-JOINs for search too!
 //	    },
-//	    Order: map[string]string{
-//	        "created_at": "DESC",
-//	        "tags.name": "ASC",     // Auto-JOINs for ordering!
+//	    Order: query.Order{
+//	        {Column: "created_at", Direction: "DESC"},
+//	        {Column: "tags.name", Direction: "ASC"}, // Auto-JOINs for ordering!
 //	    },
 //	}
 //	result, err := repo.ListActivitiesWithQuery(ctx, opts)
 func (ar *ActivityRepository) ListActivitiesWithQuery(
 	ctx context.Context,
 	opts *query.QueryOptions,
-) (*query.PaginatedResult, error) {
+) (*query.Page[models.Activity], error) {
 	// Auto-generate JOINs based on relationship column names
 	// The registry detects columns like "tags.name" and "user.username"
 	// and automatically generates the appropriate JOINs
@@ -451,3 +719,195 @@ func (ar *ActivityRepository) ListActivitiesWithQuery(
 		joins...,
 	)
 }
+
+// LoadIncludes batch-loads the relations requested with include= for the
+// activities with ids (see Preload)
+func (ar *ActivityRepository) LoadIncludes(ctx context.Context, ids []int64, includes []query.Include) (map[int64]map[string]interface{}, error) {
+	return Preload(ctx, ar.db, ar.registry, ids, includes)
+}
+
+// GetCollectionVersion returns the count and latest updated_at of the activities matched by opts
+// Mirrors ListActivitiesWithQuery, including auto-generated JOINs, so both see the same rows
+func (ar *ActivityRepository) GetCollectionVersion(ctx context.Context, opts *query.QueryOptions) (*CollectionVersion, error) {
+	joins := ar.registry.GenerateJoins(opts)
+	return FindCollectionVersion(ctx, ar.db, "activities", "updated_at", opts, joins...)
+}
+
+// LockVersion locks the user's live activity for the rest of tx and returns its current version.
+// Callers compare it with the version a client based its change on before writing.
+func (ar *ActivityRepository) LockVersion(ctx context.Context, tx TxConn, id int, userID int) (int, error) {
+	query := `
+		SELECT version
+		FROM activities
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
+		FOR UPDATE
+	`
+
+	var version int
+	err := QueryRowInTx(ctx, tx, ar.db, query, id, userID).Scan(&version)
+	if err != nil {
+		return 0, dberr.Translate(&errors.DatabaseError{Op: "SELECT", Table: "activities", Err: err})
+	}
+
+	return version, nil
+}
+
+// ListByIDs returns the user's live activities among ids, in id order
+func (ar *ActivityRepository) ListByIDs(ctx context.Context, userID int, ids []int64) ([]*models.Activity, error) {
+	query := `
+		SELECT ` + activityColumns + `
+		FROM activities
+		WHERE user_id = $1 AND id = ANY($2) AND deleted_at IS NULL
+		ORDER BY id
+	`
+
+	rows, err := ar.db.QueryContext(ctx, query, userID, ids)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "activities", Err: err}
+	}
+	defer rows.Close()
+
+	activities := make([]*models.Activity, 0, len(ids))
+	for rows.Next() {
+		activity, err := ar.scanActivity(rows)
+		if err != nil {
+			return nil, fmt.Errorf("❌ Error scanning activity: %w", err)
+		}
+		activities = append(activities, activity)
+	}
+
+	return activities, rows.Err()
+}
+
+// ListForSearch returns up to limit live activities with id > afterID, in id
+// order, for (re)building the search index
+func (ar *ActivityRepository) ListForSearch(ctx context.Context, afterID int64, limit int) ([]*models.Activity, error) {
+	query := `
+		SELECT ` + activityColumns + `
+		FROM activities
+		WHERE id > $1 AND deleted_at IS NULL
+		ORDER BY id
+		LIMIT $2
+	`
+
+	rows, err := ar.db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{Op: "SELECT", Table: "activities", Err: err})
+	}
+	defer rows.Close()
+
+	activities := make([]*models.Activity, 0, limit)
+	for rows.Next() {
+		activity, err := ar.scanActivity(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan activity: %w", err)
+		}
+		activities = append(activities, activity)
+	}
+
+	return activities, rows.Err()
+}
+
+// SetWeather records the weather observed during an activity. Weather that is
+// already recorded is kept, so repeated enrichment runs are harmless; it
+// returns false in that case. The version is bumped and the change logged like
+// any update, so sync clients pull the weather and an offline edit made
+// against the old version conflicts instead of overwriting it.
+func (ar *ActivityRepository) SetWeather(ctx context.Context, id int64, temperatureC float64, conditions string) (bool, error) {
+	query := withChangeLog("activities", ChangeUpdate, `
+		UPDATE activities
+		SET temperature_c = $2, weather_conditions = $3, version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND temperature_c IS NULL AND deleted_at IS NULL
+		RETURNING id, user_id, version
+	`, "COUNT(*)")
+
+	var updated int64
+	if err := ar.db.QueryRowContext(ctx, query, id, temperatureC, conditions).Scan(&updated); err != nil {
+		return false, dberr.Translate(&errors.DatabaseError{Op: "UPDATE", Table: "activities", Err: err})
+	}
+	return updated > 0, nil
+}
+
+// SetStartLocation records coordinates geocoded from the activity's location
+// name. Coordinates that are already set are kept; it returns false in that case.
+// Like SetWeather it bumps the version and logs the change for sync clients.
+func (ar *ActivityRepository) SetStartLocation(ctx context.Context, id int64, lat, lng float64) (bool, error) {
+	query := withChangeLog("activities", ChangeUpdate, `
+		UPDATE activities
+		SET start_lat = $2, start_lng = $3, version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND start_lat IS NULL AND deleted_at IS NULL
+		RETURNING id, user_id, version
+	`, "COUNT(*)")
+
+	var updated int64
+	if err := ar.db.QueryRowContext(ctx, query, id, lat, lng).Scan(&updated); err != nil {
+		return false, dberr.Translate(&errors.DatabaseError{Op: "UPDATE", Table: "activities", Err: err})
+	}
+	return updated > 0, nil
+}
+
+// MetricsRow is a live activity with its owner's weight, the inputs for
+// recomputing its derived metrics
+type MetricsRow struct {
+	Activity *models.Activity
+	WeightKg *float64
+}
+
+// ListMetricsRows returns up to limit live activities with id > afterID, in id
+// order. With userID set it returns that user's activities; otherwise those
+// whose metrics were computed with a formula older than belowVersion.
+func (ar *ActivityRepository) ListMetricsRows(ctx context.Context, userID int, belowVersion int, afterID int64, limit int) ([]MetricsRow, error) {
+	query := `
+		SELECT a.id, a.user_id, a.activity_type, a.duration_minutes, a.distance_km,
+			a.calories_burned, a.calories_estimated, a.pace_min_per_km, a.avg_speed_kmh,
+			a.metrics_version, u.weight_kg
+		FROM activities a
+		JOIN users u ON u.id = a.user_id
+		WHERE a.deleted_at IS NULL AND a.id > $1
+			AND (($2 <> 0 AND a.user_id = $2) OR ($2 = 0 AND a.metrics_version < $3))
+		ORDER BY a.id
+		LIMIT $4
+	`
+
+	rows, err := ar.db.QueryContext(ctx, query, afterID, userID, belowVersion, limit)
+	if err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{Op: "SELECT", Table: "activities", Err: err})
+	}
+	defer rows.Close()
+
+	var result []MetricsRow
+	for rows.Next() {
+		row := MetricsRow{Activity: &models.Activity{}}
+		a := row.Activity
+		if err := rows.Scan(&a.ID, &a.UserID, &a.ActivityType, &a.DurationMinutes, &a.DistanceKm,
+			&a.CaloriesBurned, &a.CaloriesEstimated, &a.PaceMinPerKm, &a.AvgSpeedKmh,
+			&a.MetricsVersion, &row.WeightKg); err != nil {
+			return nil, fmt.Errorf("failed to scan metrics row: %w", err)
+		}
+		result = append(result, row)
+	}
+
+	return result, rows.Err()
+}
+
+// SaveMetrics stores the derived metrics of an activity. Clients show them,
+// so like SetWeather it bumps the version and logs the change for sync clients.
+// An activity deleted since it was read is left alone.
+func (ar *ActivityRepository) SaveMetrics(ctx context.Context, activity *models.Activity) error {
+	query := withChangeLog("activities", ChangeUpdate, `
+		UPDATE activities
+		SET pace_min_per_km = $2, avg_speed_kmh = $3, calories_burned = $4,
+			calories_estimated = $5, metrics_version = $6,
+			version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING id, user_id, version
+	`, "COUNT(*)")
+
+	var updated int64
+	err := ar.db.QueryRowContext(ctx, query, activity.ID, activity.PaceMinPerKm, activity.AvgSpeedKmh,
+		activity.CaloriesBurned, activity.CaloriesEstimated, activity.MetricsVersion).Scan(&updated)
+	if err != nil {
+		return dberr.Translate(&errors.DatabaseError{Op: "UPDATE", Table: "activities", Err: err})
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/pkg/dberr"
+	"github.com/valentinesamuel/activelog/pkg/errors"
+)
+
+// insertSplits stores the splits of a newly created activity
+func (ar *ActivityRepository) insertSplits(ctx context.Context, tx TxConn, activity *models.Activity) error {
+	if len(activity.Splits) == 0 {
+		return nil
+	}
+
+	indexes := make([]int, len(activity.Splits))
+	distances := make([]float64, len(activity.Splits))
+	durations := make([]int, len(activity.Splits))
+	heartRates := make([]*int, len(activity.Splits))
+	for i, split := range activity.Splits {
+		indexes[i] = split.Index
+		distances[i] = split.DistanceKm
+		durations[i] = split.DurationSeconds
+		heartRates[i] = split.AvgHeartRate
+	}
+
+	query := `
+		INSERT INTO activity_splits (activity_id, split_index, distance_km, duration_seconds, avg_heart_rate)
+		SELECT $1, * FROM unnest($2::int[], $3::float8[], $4::int[], $5::int[])
+	`
+	if _, err := ExecInTx(ctx, tx, ar.db, query, activity.ID, indexes, distances, durations, heartRates); err != nil {
+		return dberr.Translate(&errors.DatabaseError{Op: "INSERT", Table: "activity_splits", Err: err})
+	}
+	return nil
+}
+
+// GetSplits returns the splits of an activity in the order they were run
+func (ar *ActivityRepository) GetSplits(ctx context.Context, activityID int64) ([]*models.ActivitySplit, error) {
+	query := `
+		SELECT split_index, distance_km, duration_seconds, avg_heart_rate
+		FROM activity_splits
+		WHERE activity_id = $1
+		ORDER BY split_index
+	`
+
+	rows, err := ar.db.QueryContext(ctx, query, activityID)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "activity_splits", Err: err}
+	}
+	defer rows.Close()
+
+	splits := []*models.ActivitySplit{}
+	for rows.Next() {
+		split := &models.ActivitySplit{}
+		if err := rows.Scan(&split.Index, &split.DistanceKm, &split.DurationSeconds, &split.AvgHeartRate); err != nil {
+			return nil, &errors.DatabaseError{Op: "SCAN", Table: "activity_splits", Err: err}
+		}
+		splits = append(splits, split)
+	}
+	return splits, rows.Err()
+}
+
+// copySplits COPYs the splits of a chunk of imported activities; ids are the
+// IDs reserved for activities
+func copySplits(ctx context.Context, tx pgx.Tx, activities []*models.Activity, ids []int64) error {
+	var rows [][]any
+	for i, a := range activities {
+		for _, split := range a.Splits {
+			rows = append(rows, []any{ids[i], split.Index, split.DistanceKm, split.DurationSeconds, split.AvgHeartRate})
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	if _, err := tx.CopyFrom(ctx,
+		pgx.Identifier{"activity_splits"},
+		[]string{"activity_id", "split_index", "distance_km", "duration_seconds", "avg_heart_rate"},
+		pgx.CopyFromRows(rows),
+	); err != nil {
+		return fmt.Errorf("failed to copy activity splits: %w", err)
+	}
+	return nil
+}
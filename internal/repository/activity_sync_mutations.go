@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/valentinesamuel/activelog/pkg/dberr"
+	"github.com/valentinesamuel/activelog/pkg/errors"
+)
+
+// ClaimClientMutation reserves a sync client mutation ID for an activity
+// created in tx. It returns 0 when the ID is new, and the activity created
+// for it when the ID was claimed before, so a replayed create can report that
+// activity instead of creating another one. A concurrent claim of the same ID
+// waits for the first transaction and sees its activity once it commits.
+func (ar *ActivityRepository) ClaimClientMutation(ctx context.Context, tx TxConn, userID int, clientID string) (int64, error) {
+	var claimed bool
+	err := QueryRowInTx(ctx, tx, ar.db, `
+		INSERT INTO sync_mutations (user_id, client_id, entity)
+		VALUES ($1, $2, 'activities')
+		ON CONFLICT (user_id, client_id) DO NOTHING
+		RETURNING true
+	`, userID, clientID).Scan(&claimed)
+	if err == nil {
+		return 0, nil
+	}
+	if err != sql.ErrNoRows {
+		return 0, dberr.Translate(&errors.DatabaseError{Op: "INSERT", Table: "sync_mutations", Err: err})
+	}
+
+	var activityID sql.NullInt64
+	err = QueryRowInTx(ctx, tx, ar.db, `
+		SELECT entity_id FROM sync_mutations WHERE user_id = $1 AND client_id = $2
+	`, userID, clientID).Scan(&activityID)
+	if err != nil {
+		return 0, dberr.Translate(&errors.DatabaseError{Op: "SELECT", Table: "sync_mutations", Err: err})
+	}
+
+	return activityID.Int64, nil
+}
+
+// RecordClientMutation stores the activity created for a client mutation ID
+// claimed with ClaimClientMutation in the same tx
+func (ar *ActivityRepository) RecordClientMutation(ctx context.Context, tx TxConn, userID int, clientID string, activityID int64) error {
+	_, err := ExecInTx(ctx, tx, ar.db, `
+		UPDATE sync_mutations SET entity_id = $3 WHERE user_id = $1 AND client_id = $2
+	`, userID, clientID, activityID)
+	if err != nil {
+		return dberr.Translate(&errors.DatabaseError{Op: "UPDATE", Table: "sync_mutations", Err: err})
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package repository_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/repository/testhelpers"
+	"github.com/valentinesamuel/activelog/pkg/database"
+	"github.com/valentinesamuel/activelog/pkg/query"
+)
+
+// benchmarkActivities is how many activities the tag filter benchmarks list
+// from; every third one is tagged cardio, every fifth outdoor
+const benchmarkActivities = 20000
+
+// BenchmarkListActivities_TagFilter compares filtering on the denormalized
+// activities.tags array with the JOIN through activity_tags and tags:
+//
+//	go test ./internal/repository -run '^$' -bench TagFilter
+func BenchmarkListActivities_TagFilter(b *testing.B) {
+	if testing.Short() {
+		b.Skip("starts a PostgreSQL container")
+	}
+
+	db, cleanup := testhelpers.SetupTestDB(b)
+	defer cleanup()
+	userID := seedTaggedActivities(b, db)
+
+	repo := repository.NewActivityRepository(db, repository.NewTagRepository(db))
+	benchmarks := []struct {
+		name   string
+		params url.Values
+	}{
+		{"one_tag/join", url.Values{"filter[tags.name]": {"cardio"}}},
+		{"one_tag/array", url.Values{"filter[tags][contains]": {"cardio"}}},
+		{"any_of/join", url.Values{"filter[tags.name]": {"[cardio,outdoor]"}}},
+		{"any_of/array", url.Values{"filter[tags][overlaps]": {"[cardio,outdoor]"}}},
+		{"all_of/array", url.Values{"filter[tags][contains]": {"[cardio,outdoor]"}}},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			ctx := context.Background()
+			for i := 0; i < b.N; i++ {
+				opts, err := query.ParseQueryParams(bm.params)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if err := repo.GetValidation().Validate(opts); err != nil {
+					b.Fatal(err)
+				}
+				opts.Filter["user_id"] = userID
+				opts.Limit = 20
+				if _, err := repo.ListActivitiesWithQuery(ctx, opts); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// seedTaggedActivities creates a user with benchmarkActivities activities
+// and links the tags; the triggers fill in activities.tags
+func seedTaggedActivities(b *testing.B, db *database.LoggingDB) int {
+	b.Helper()
+	ctx := context.Background()
+
+	var userID int
+	err := db.QueryRowContext(ctx, `
+		INSERT INTO users (email, username, password_hash)
+		VALUES ('bench@example.com', 'bench', 'x')
+		RETURNING id`).Scan(&userID)
+	if err != nil {
+		b.Fatalf("failed to create user: %v", err)
+	}
+
+	seeds := []struct {
+		sql  string
+		args []interface{}
+	}{
+		{`INSERT INTO activities (user_id, activity_type, title, duration_minutes, activity_date)
+		  SELECT $1, 'running', 'Run ' || n, 30, NOW() - n * INTERVAL '1 hour'
+		  FROM generate_series(1, $2::int) n`, []interface{}{userID, benchmarkActivities}},
+		{`INSERT INTO tags (name) VALUES ('cardio'), ('outdoor'), ('easy')`, nil},
+		{`INSERT INTO activity_tags (activity_id, tag_id)
+		  SELECT a.id, t.id FROM activities a
+		  JOIN tags t ON t.name = 'easy'
+		      OR (t.name = 'cardio' AND a.id % 3 = 0)
+		      OR (t.name = 'outdoor' AND a.id % 5 = 0)
+		  WHERE a.user_id = $1`, []interface{}{userID}},
+		{`ANALYZE activities, activity_tags, tags`, nil},
+	}
+	for _, seed := range seeds {
+		if _, err := db.ExecContext(ctx, seed.sql, seed.args...); err != nil {
+			b.Fatalf("failed to seed: %v", err)
+		}
+	}
+	return userID
+}
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/pkg/dberr"
+	"github.com/valentinesamuel/activelog/pkg/errors"
+	"github.com/valentinesamuel/activelog/pkg/polyline"
+)
+
+// ActivityTrackRepository handles database operations for the GPS tracks of
+// activities, stored once per resolution as encoded polylines, and for the
+// map thumbnails rendered from them
+type ActivityTrackRepository struct {
+	db DBConn
+}
+
+// NewActivityTrackRepository creates a new ActivityTrackRepository
+func NewActivityTrackRepository(db DBConn) *ActivityTrackRepository {
+	return &ActivityTrackRepository{db: db}
+}
+
+// Get returns the activity's track at resolution, or ErrNotFound when it
+// hasn't been stored
+func (r *ActivityTrackRepository) Get(ctx context.Context, activityID int64, resolution models.TrackResolution) (*models.ActivityTrack, error) {
+	query := `
+		SELECT activity_id, resolution, points, polyline, created_at
+		FROM activity_tracks
+		WHERE activity_id = $1 AND resolution = $2
+	`
+
+	track := &models.ActivityTrack{}
+	err := r.db.QueryRowContext(ctx, query, activityID, string(resolution)).
+		Scan(&track.ActivityID, &track.Resolution, &track.Points, &track.Polyline, &track.CreatedAt)
+	if err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{Op: "SELECT", Table: "activity_tracks", Err: err})
+	}
+	return track, nil
+}
+
+// Save stores a track computed from the full one. Two requests may compute
+// the same resolution at once; the first stored wins and track is updated to
+// it, so both return the same track.
+func (r *ActivityTrackRepository) Save(ctx context.Context, track *models.ActivityTrack) error {
+	query := `
+		WITH inserted AS (
+			INSERT INTO activity_tracks (activity_id, resolution, points, polyline)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (activity_id, resolution) DO NOTHING
+			RETURNING points, polyline, created_at
+		)
+		SELECT points, polyline, created_at FROM inserted
+		UNION ALL
+		SELECT points, polyline, created_at FROM activity_tracks
+		WHERE activity_id = $1 AND resolution = $2
+		LIMIT 1
+	`
+
+	err := r.db.QueryRowContext(ctx, query, track.ActivityID, string(track.Resolution), track.Points, track.Polyline).
+		Scan(&track.Points, &track.Polyline, &track.CreatedAt)
+	if err != nil {
+		return dberr.Translate(&errors.DatabaseError{Op: "INSERT", Table: "activity_tracks", Err: err})
+	}
+	return nil
+}
+
+// ListTrackedActivityIDs returns the IDs of the user's activities that have
+// a track, of those in activityIDs or, when it's empty, of all of them
+func (r *ActivityTrackRepository) ListTrackedActivityIDs(ctx context.Context, userID int, activityIDs []int64) ([]int64, error) {
+	query := `
+		SELECT t.activity_id
+		FROM activity_tracks t
+		JOIN activities a ON a.id = t.activity_id
+		WHERE a.user_id = $1 AND a.deleted_at IS NULL AND t.resolution = 'full'
+		AND (cardinality($2::bigint[]) = 0 OR t.activity_id = ANY($2))
+		ORDER BY t.activity_id
+	`
+	if activityIDs == nil {
+		activityIDs = []int64{}
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, userID, activityIDs)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "activity_tracks", Err: err}
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, &errors.DatabaseError{Op: "SCAN", Table: "activity_tracks", Err: err}
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// ThumbnailKeys returns the storage keys of the map thumbnails of those of
+// activityIDs that have one
+func (r *ActivityTrackRepository) ThumbnailKeys(ctx context.Context, activityIDs []int64) (map[int64]string, error) {
+	keys := make(map[int64]string)
+	if len(activityIDs) == 0 {
+		return keys, nil
+	}
+
+	query := `SELECT activity_id, storage_key FROM activity_map_thumbnails WHERE activity_id = ANY($1)`
+
+	rows, err := r.db.QueryContext(ctx, query, activityIDs)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "activity_map_thumbnails", Err: err}
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var key string
+		if err := rows.Scan(&id, &key); err != nil {
+			return nil, &errors.DatabaseError{Op: "SCAN", Table: "activity_map_thumbnails", Err: err}
+		}
+		keys[id] = key
+	}
+	return keys, rows.Err()
+}
+
+// SetThumbnail records the storage key of the activity's map thumbnail
+func (r *ActivityTrackRepository) SetThumbnail(ctx context.Context, activityID int64, storageKey string) error {
+	query := `
+		INSERT INTO activity_map_thumbnails (activity_id, storage_key)
+		VALUES ($1, $2)
+		ON CONFLICT (activity_id) DO UPDATE
+		SET storage_key = EXCLUDED.storage_key, rendered_at = CURRENT_TIMESTAMP
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, activityID, storageKey); err != nil {
+		return dberr.Translate(&errors.DatabaseError{Op: "INSERT", Table: "activity_map_thumbnails", Err: err})
+	}
+	return nil
+}
+
+// DeleteThumbnail forgets the activity's map thumbnail and returns its
+// storage key, or "" when it had none
+func (r *ActivityTrackRepository) DeleteThumbnail(ctx context.Context, activityID int64) (string, error) {
+	query := `DELETE FROM activity_map_thumbnails WHERE activity_id = $1 RETURNING storage_key`
+
+	var key string
+	err := r.db.QueryRowContext(ctx, query, activityID).Scan(&key)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", &errors.DatabaseError{Op: "DELETE", Table: "activity_map_thumbnails", Err: err}
+	}
+	return key, nil
+}
+
+// copyTracks COPYs the full tracks of a chunk of imported activities; ids are
+// the IDs reserved for activities, in order
+func copyTracks(ctx context.Context, tx pgx.Tx, activities []*models.Activity, ids []int64) error {
+	var rows [][]any
+	for i, a := range activities {
+		if len(a.Track) == 0 {
+			continue
+		}
+		rows = append(rows, []any{ids[i], string(models.TrackResolutionFull), len(a.Track), polyline.Encode(a.Track)})
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	if _, err := tx.CopyFrom(ctx,
+		pgx.Identifier{"activity_tracks"},
+		[]string{"activity_id", "resolution", "points", "polyline"},
+		pgx.CopyFromRows(rows),
+	); err != nil {
+		return fmt.Errorf("failed to copy activity tracks: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/pkg/dberr"
+	"github.com/valentinesamuel/activelog/pkg/errors"
+)
+
+// ActivityTypeRepository handles database operations for the activity type
+// registry (migration 000032): the defaults every user has, plus each user's
+// own types
+type ActivityTypeRepository struct {
+	db DBConn
+}
+
+// NewActivityTypeRepository creates a new ActivityTypeRepository
+func NewActivityTypeRepository(db DBConn) *ActivityTypeRepository {
+	return &ActivityTypeRepository{db: db}
+}
+
+const activityTypeColumns = `id, user_id, name, display_name, icon, color, metadata, created_at, updated_at`
+
+// ListForUser returns the types the user can pick: the defaults first, then
+// their own, each by name
+func (r *ActivityTypeRepository) ListForUser(ctx context.Context, userID int) ([]*models.ActivityTypeInfo, error) {
+	query := `SELECT ` + activityTypeColumns + `
+		FROM activity_types
+		WHERE user_id IS NULL OR user_id = $1
+		ORDER BY user_id NULLS FIRST, name`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "activity_types", Err: err}
+	}
+	defer rows.Close()
+
+	types := []*models.ActivityTypeInfo{}
+	for rows.Next() {
+		activityType, err := scanActivityType(rows)
+		if err != nil {
+			return nil, &errors.DatabaseError{Op: "SCAN", Table: "activity_types", Err: err}
+		}
+		types = append(types, activityType)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{Op: "ITERATE", Table: "activity_types", Err: err}
+	}
+	return types, nil
+}
+
+// Resolve returns the type the user means by name, matched case-insensitively
+// against their own types and then the defaults, or ErrNotFound
+func (r *ActivityTypeRepository) Resolve(ctx context.Context, userID int, name string) (*models.ActivityTypeInfo, error) {
+	query := `SELECT ` + activityTypeColumns + `
+		FROM activity_types
+		WHERE LOWER(name) = LOWER($2) AND (user_id IS NULL OR user_id = $1)
+		ORDER BY user_id NULLS LAST
+		LIMIT 1`
+
+	activityType, err := scanActivityType(r.db.QueryRowContext(ctx, query, userID, strings.TrimSpace(name)))
+	if err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{Op: "SELECT", Table: "activity_types", Err: err})
+	}
+	return activityType, nil
+}
+
+// GetOwn returns one of the user's own types, or ErrNotFound. Defaults can't
+// be changed, so they are not found here.
+func (r *ActivityTypeRepository) GetOwn(ctx context.Context, userID int, id int64) (*models.ActivityTypeInfo, error) {
+	query := `SELECT ` + activityTypeColumns + ` FROM activity_types WHERE id = $1 AND user_id = $2`
+
+	activityType, err := scanActivityType(r.db.QueryRowContext(ctx, query, id, userID))
+	if err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{Op: "SELECT", Table: "activity_types", Err: err})
+	}
+	return activityType, nil
+}
+
+// Create inserts a type of the user's own. A name the user already has fails
+// with a unique violation.
+func (r *ActivityTypeRepository) Create(ctx context.Context, activityType *models.ActivityTypeInfo) error {
+	metadata, err := marshalActivityTypeMetadata(activityType.Metadata)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO activity_types (user_id, name, display_name, icon, color, metadata)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at`
+
+	err = r.db.QueryRowContext(ctx, query,
+		activityType.UserID,
+		activityType.Name,
+		activityType.DisplayName,
+		activityType.Icon,
+		activityType.Color,
+		metadata,
+	).Scan(&activityType.ID, &activityType.CreatedAt, &activityType.UpdatedAt)
+	if err != nil {
+		return dberr.Translate(&errors.DatabaseError{Op: "INSERT", Table: "activity_types", Err: err})
+	}
+	return nil
+}
+
+// Update saves the display name, icon, color and metadata of one of the
+// user's own types
+func (r *ActivityTypeRepository) Update(ctx context.Context, activityType *models.ActivityTypeInfo) error {
+	metadata, err := marshalActivityTypeMetadata(activityType.Metadata)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE activity_types
+		SET display_name = $3, icon = $4, color = $5, metadata = $6, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_id = $2
+		RETURNING updated_at`
+
+	err = r.db.QueryRowContext(ctx, query,
+		activityType.ID,
+		activityType.UserID,
+		activityType.DisplayName,
+		activityType.Icon,
+		activityType.Color,
+		metadata,
+	).Scan(&activityType.UpdatedAt)
+	if err != nil {
+		return dberr.Translate(&errors.DatabaseError{Op: "UPDATE", Table: "activity_types", Err: err})
+	}
+	return nil
+}
+
+// Delete removes one of the user's own types, or returns ErrNotFound.
+// Activities keep the name; merge them into another type first to remap them.
+func (r *ActivityTypeRepository) Delete(ctx context.Context, userID int, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM activity_types WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return &errors.DatabaseError{Op: "DELETE", Table: "activity_types", Err: err}
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// Merge remaps the user's live activities whose type is one of from (matched
+// case-insensitively) to into, and removes the user's own types among from.
+// Every remapped activity is recorded in change_log. into must already be
+// resolved; it is never removed even if listed in from.
+func (r *ActivityTypeRepository) Merge(ctx context.Context, tx TxConn, userID int, from []string, into string) (int64, error) {
+	names := make([]string, 0, len(from))
+	for _, name := range from {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "" && name != strings.ToLower(into) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return 0, nil
+	}
+
+	remap := withChangeLog("activities", ChangeUpdate, `
+		UPDATE activities
+		SET activity_type = $2, updated_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE user_id = $1 AND deleted_at IS NULL AND LOWER(activity_type) = ANY($3)
+		RETURNING id, user_id, version`, "COUNT(*)")
+
+	var remapped int64
+	err := QueryRowInTx(ctx, tx, r.db, remap, userID, into, names).Scan(&remapped)
+	if err != nil {
+		return 0, dberr.Translate(&errors.DatabaseError{Op: "UPDATE", Table: "activities", Err: err})
+	}
+
+	_, err = ExecInTx(ctx, tx, r.db,
+		`DELETE FROM activity_types WHERE user_id = $1 AND LOWER(name) = ANY($2)`, userID, names)
+	if err != nil {
+		return 0, &errors.DatabaseError{Op: "DELETE", Table: "activity_types", Err: err}
+	}
+
+	return remapped, nil
+}
+
+// rowScanner is a *sql.Row or *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanActivityType scans one row of activityTypeColumns
+func scanActivityType(row rowScanner) (*models.ActivityTypeInfo, error) {
+	activityType := &models.ActivityTypeInfo{}
+	var userID sql.NullInt64
+	var metadata []byte
+	err := row.Scan(
+		&activityType.ID,
+		&userID,
+		&activityType.Name,
+		&activityType.DisplayName,
+		&activityType.Icon,
+		&activityType.Color,
+		&metadata,
+		&activityType.CreatedAt,
+		&activityType.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if userID.Valid {
+		id := int(userID.Int64)
+		activityType.UserID = &id
+	}
+	activityType.Metadata = map[string]interface{}{}
+	if len(metadata) > 0 {
+		if err := json.Unmarshal(metadata, &activityType.Metadata); err != nil {
+			return nil, err
+		}
+	}
+	return activityType, nil
+}
+
+// marshalActivityTypeMetadata encodes metadata for the JSONB column, nil as {}
+func marshalActivityTypeMetadata(metadata map[string]interface{}) ([]byte, error) {
+	if metadata == nil {
+		return []byte(`{}`), nil
+	}
+	return json.Marshal(metadata)
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/pkg/dberr"
+	"github.com/valentinesamuel/activelog/pkg/errors"
+)
+
+// APIKeyRepository handles database operations for API keys, the long-lived
+// bearer tokens users create for scripts and the CLI
+type APIKeyRepository struct {
+	db DBConn
+}
+
+// NewAPIKeyRepository creates a new APIKeyRepository
+func NewAPIKeyRepository(db DBConn) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+// ListByUser returns the user's API keys, newest first
+func (r *APIKeyRepository) ListByUser(ctx context.Context, userID int) ([]*models.APIKey, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, user_id, name, prefix, created_at, last_used_at
+		FROM api_keys
+		WHERE user_id = $1
+		ORDER BY id DESC`, userID)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "api_keys", Err: err}
+	}
+	defer rows.Close()
+
+	keys := []*models.APIKey{}
+	for rows.Next() {
+		key := &models.APIKey{}
+		if err := rows.Scan(&key.ID, &key.UserID, &key.Name, &key.Prefix, &key.CreatedAt, &key.LastUsedAt); err != nil {
+			return nil, &errors.DatabaseError{Op: "SCAN", Table: "api_keys", Err: err}
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// Create stores an API key by its hash (see auth.HashAPIKey)
+func (r *APIKeyRepository) Create(ctx context.Context, key *models.APIKey, keyHash string) error {
+	query := `
+		INSERT INTO api_keys (user_id, name, prefix, key_hash)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(ctx, query, key.UserID, key.Name, key.Prefix, keyHash).Scan(&key.ID, &key.CreatedAt)
+	if err != nil {
+		return dberr.Translate(&errors.DatabaseError{Op: "INSERT", Table: "api_keys", Err: err})
+	}
+	return nil
+}
+
+// Delete revokes one of the user's API keys, or returns ErrNotFound
+func (r *APIKeyRepository) Delete(ctx context.Context, userID int, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM api_keys WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return &errors.DatabaseError{Op: "DELETE", Table: "api_keys", Err: err}
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// Authenticate returns the ID and email of the user an API key belongs to,
+// by the key's hash, or ErrNotFound for unknown keys and disabled accounts.
+// It runs on every request made with a key, so last_used_at is only written
+// when it is more than an hour old.
+func (r *APIKeyRepository) Authenticate(ctx context.Context, keyHash string) (int, string, error) {
+	query := `
+		WITH found AS (
+			SELECT k.id, k.last_used_at, u.id AS user_id, u.email
+			FROM api_keys k
+			INNER JOIN users u ON u.id = k.user_id
+			WHERE k.key_hash = $1 AND u.deleted_at IS NULL
+		), touched AS (
+			UPDATE api_keys k
+			SET last_used_at = CURRENT_TIMESTAMP
+			FROM found
+			WHERE k.id = found.id
+				AND (found.last_used_at IS NULL OR found.last_used_at < CURRENT_TIMESTAMP - INTERVAL '1 hour')
+		)
+		SELECT user_id, email FROM found`
+
+	var userID int
+	var email string
+	if err := r.db.QueryRowContext(ctx, query, keyHash).Scan(&userID, &email); err != nil {
+		return 0, "", dberr.Translate(&errors.DatabaseError{Op: "SELECT", Table: "api_keys", Err: err})
+	}
+	return userID, email, nil
+}
@@ -4,12 +4,43 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/valentinesamuel/activelog/pkg/query"
 )
 
+// cachedQuerier is implemented by connections that can run builder-generated SQL
+// through a prepared statement cache (*database.LoggingDB). Transactions and
+// plain *sql.DB don't implement it and run queries unprepared.
+type cachedQuerier interface {
+	QueryCachedContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowCachedContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// queryBuilt runs a QueryBuilder query, reusing a cached prepared statement when db supports it
+func queryBuilt(ctx context.Context, db DBConn, query string, args ...interface{}) (*sql.Rows, error) {
+	if cq, ok := db.(cachedQuerier); ok {
+		return cq.QueryCachedContext(ctx, query, args...)
+	}
+	return db.QueryContext(ctx, query, args...)
+}
+
+// queryRowBuilt is the single-row counterpart of queryBuilt
+func queryRowBuilt(ctx context.Context, db DBConn, query string, args ...interface{}) *sql.Row {
+	if cq, ok := db.(cachedQuerier); ok {
+		return cq.QueryRowCachedContext(ctx, query, args...)
+	}
+	return db.QueryRowContext(ctx, query, args...)
+}
+
 // FindAndPaginate is a generic function for executing paginated queries on any entity.
 //
+// The queries run with the hints of opts, e.g. a statement timeout (see WithHints).
+//
+// opts.Count picks how the total is computed: COUNT(*) (exact, the default),
+// the planner's estimate (estimated), or not at all (none), in which case one
+// extra row is fetched to tell whether there is a next page.
+//
 // Type Parameters:
 //   - T: The entity type to be returned (e.g., models.Activity, models.Tag)
 //
@@ -22,7 +53,7 @@ import (
 //   - joins: Optional JOIN configurations for relationship filtering
 //
 // Returns:
-//   - *query.PaginatedResult: Contains the data and pagination metadata
+//   - *query.Page[T]: Contains the data and pagination metadata
 //   - error: Any error that occurred during query execution
 //
 // Example Usage:
@@ -53,7 +84,33 @@ func FindAndPaginate[T any](
 	opts *query.QueryOptions,
 	scanFunc func(*sql.Rows) (*T, error),
 	joins ...query.JoinConfig,
-) (*query.PaginatedResult, error) {
+) (*query.Page[T], error) {
+	var result *query.Page[T]
+	err := WithHints(ctx, db, opts.Hints, func(db DBConn) error {
+		var err error
+		result, err = findAndPaginate(ctx, db, tableName, opts, scanFunc, joins...)
+		return err
+	})
+	return result, err
+}
+
+// findAndPaginate is FindAndPaginate on a connection the hints of opts
+// already apply to
+func findAndPaginate[T any](
+	ctx context.Context,
+	db DBConn,
+	tableName string,
+	opts *query.QueryOptions,
+	scanFunc func(*sql.Rows) (*T, error),
+	joins ...query.JoinConfig,
+) (*query.Page[T], error) {
+	switch opts.Count {
+	case query.CountNone:
+		return findPageUncounted(ctx, db, tableName, opts, scanFunc, joins...)
+	case query.CountEstimated:
+		return findPageEstimated(ctx, db, tableName, opts, scanFunc, joins...)
+	}
+
 	// Step 1: Build and execute COUNT query for pagination metadata
 	totalRecords, err := executeCountQuery(ctx, db, tableName, opts, joins...)
 	if err != nil {
@@ -64,18 +121,158 @@ func FindAndPaginate[T any](
 	meta := calculatePaginationMeta(opts.Page, opts.Limit, totalRecords)
 
 	// Step 3: Build and execute data query
-	data, err := executeDataQuery[T](ctx, db, tableName, opts, scanFunc, joins...)
+	data, err := executeDataQuery[T](ctx, db, tableName, opts, scanFunc, false, joins...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch records: %w", err)
 	}
 
 	// Step 4: Return paginated result
-	return &query.PaginatedResult{
+	return &query.Page[T]{
 		Data: data,
 		Meta: meta,
 	}, nil
 }
 
+// findPageUncounted is FindAndPaginate for query.CountNone: the page is
+// fetched with one extra row, whose presence means there is a next page
+func findPageUncounted[T any](
+	ctx context.Context,
+	db DBConn,
+	tableName string,
+	opts *query.QueryOptions,
+	scanFunc func(*sql.Rows) (*T, error),
+	joins ...query.JoinConfig,
+) (*query.Page[T], error) {
+	data, err := executeDataQuery[T](ctx, db, tableName, opts, scanFunc, true, joins...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch records: %w", err)
+	}
+
+	meta := calculatePaginationMeta(opts.Page, opts.Limit, 0)
+	hasNext := len(data) > meta.Limit
+	if hasNext {
+		data = data[:meta.Limit]
+	}
+	meta.Count = len(data)
+	if hasNext {
+		meta.SetNextPage(meta.Page + 1)
+	}
+	meta.CountMode = query.CountNone
+
+	return &query.Page[T]{
+		Data: data,
+		Meta: meta,
+	}, nil
+}
+
+// findPageEstimated is FindAndPaginate for query.CountEstimated. The
+// estimate is corrected with what the page shows: a short page is the last
+// one, so the total is exact there, and a full page means there are at least
+// that many rows.
+func findPageEstimated[T any](
+	ctx context.Context,
+	db DBConn,
+	tableName string,
+	opts *query.QueryOptions,
+	scanFunc func(*sql.Rows) (*T, error),
+	joins ...query.JoinConfig,
+) (*query.Page[T], error) {
+	estimate, err := executeEstimateQuery(ctx, db, tableName, opts, joins...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate records: %w", err)
+	}
+
+	data, err := executeDataQuery[T](ctx, db, tableName, opts, scanFunc, false, joins...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch records: %w", err)
+	}
+
+	meta := calculatePaginationMeta(opts.Page, opts.Limit, 0)
+	seen := (meta.Page-1)*meta.Limit + len(data)
+	switch {
+	case len(data) < meta.Limit && (len(data) > 0 || meta.Page == 1):
+		estimate = seen
+	case len(data) == meta.Limit && estimate <= seen:
+		// A full page: at least one more row may follow
+		estimate = seen + 1
+	}
+
+	meta = calculatePaginationMeta(opts.Page, opts.Limit, estimate)
+	meta.Count = len(data)
+	meta.CountMode = query.CountEstimated
+
+	return &query.Page[T]{
+		Data: data,
+		Meta: meta,
+	}, nil
+}
+
+// CollectionVersion summarises the rows matched by a list query for cache validation.
+// Any insert, delete or update among the matching rows changes Count or LastModified.
+type CollectionVersion struct {
+	Count        int
+	LastModified *time.Time
+}
+
+// FindCollectionVersion returns the row count and latest versionColumn value for the rows
+// matched by opts, using the same JOINs and filters as FindAndPaginate.
+// versionColumn is normally updated_at; append-only tables can pass created_at.
+// It is much cheaper than the list query itself, which lets handlers answer
+// conditional GETs without fetching the page.
+func FindCollectionVersion(
+	ctx context.Context,
+	db DBConn,
+	tableName string,
+	versionColumn string,
+	opts *query.QueryOptions,
+	joins ...query.JoinConfig,
+) (*CollectionVersion, error) {
+	var version *CollectionVersion
+	err := WithHints(ctx, db, opts.Hints, func(db DBConn) error {
+		var err error
+		version, err = findCollectionVersion(ctx, db, tableName, versionColumn, opts, joins...)
+		return err
+	})
+	return version, err
+}
+
+// findCollectionVersion is FindCollectionVersion on a connection the hints
+// of opts already apply to
+func findCollectionVersion(
+	ctx context.Context,
+	db DBConn,
+	tableName string,
+	versionColumn string,
+	opts *query.QueryOptions,
+	joins ...query.JoinConfig,
+) (*CollectionVersion, error) {
+	builder := query.NewQueryBuilder(tableName, opts)
+	if len(joins) > 0 {
+		builder = builder.WithJoins(joins)
+	}
+
+	versionSQL, versionArgs, err := builder.
+		ApplyFilterConditions().
+		ApplyFilters().
+		ApplyFiltersOr().
+		ApplySearch().
+		BuildVersion(versionColumn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build version query: %w", err)
+	}
+
+	version := &CollectionVersion{}
+	var lastModified sql.NullTime
+	if err := queryRowBuilt(ctx, db, versionSQL, versionArgs...).Scan(&version.Count, &lastModified); err != nil {
+		return nil, fmt.Errorf("failed to execute version query: %w", err)
+	}
+	if lastModified.Valid {
+		version.LastModified = &lastModified.Time
+	}
+
+	return version, nil
+}
+
 // executeCountQuery builds and executes the COUNT query for pagination
 func executeCountQuery(
 	ctx context.Context,
@@ -109,7 +306,7 @@ func executeCountQuery(
 
 	// Execute COUNT query
 	var totalRecords int
-	err = db.QueryRowContext(ctx, countSQL, countArgs...).Scan(&totalRecords)
+	err = queryRowBuilt(ctx, db, countSQL, countArgs...).Scan(&totalRecords)
 	if err != nil {
 		return 0, fmt.Errorf("failed to execute count query: %w", err)
 	}
@@ -117,41 +314,73 @@ func executeCountQuery(
 	return totalRecords, nil
 }
 
-// executeDataQuery builds and executes the main SELECT query
-func executeDataQuery[T any](
+// executeEstimateQuery returns the planner's estimate of the rows matched by
+// opts: pg_class.reltuples when nothing filters the table, the EXPLAIN row
+// estimate otherwise or when the table has no statistics yet
+func executeEstimateQuery(
 	ctx context.Context,
 	db DBConn,
 	tableName string,
 	opts *query.QueryOptions,
-	scanFunc func(*sql.Rows) (*T, error),
 	joins ...query.JoinConfig,
-) ([]*T, error) {
-	// Build SELECT query with all filters, order, and pagination
-	builder := query.NewQueryBuilder(tableName, opts)
+) (int, error) {
+	unfiltered := len(joins) == 0 && len(opts.Filter) == 0 && len(opts.FilterConditions) == 0 &&
+		len(opts.FilterOr) == 0 && len(opts.Search) == 0
+	if unfiltered {
+		tableSQL, tableArgs, err := query.BuildTableEstimate(tableName)
+		if err != nil {
+			return 0, fmt.Errorf("failed to build estimate query: %w", err)
+		}
+		var reltuples sql.NullInt64
+		if err := db.QueryRowContext(ctx, tableSQL, tableArgs...).Scan(&reltuples); err != nil && err != sql.ErrNoRows {
+			return 0, fmt.Errorf("failed to execute estimate query: %w", err)
+		}
+		if reltuples.Valid && reltuples.Int64 >= 0 {
+			return int(reltuples.Int64), nil
+		}
+	}
 
-	// Apply JOINs if provided
+	builder := query.NewQueryBuilder(tableName, opts)
 	if len(joins) > 0 {
 		builder = builder.WithJoins(joins)
 	}
 
-	// Use ApplyFilterConditions() for operator support (v1.1.0+)
-	// Note: Parser populates FilterConditions when parsing HTTP requests
-	// ApplyFilters() handles direct Filter map usage (tests, manual QueryOptions)
-	dataSQL, dataArgs, err := builder.
+	explainSQL, explainArgs, err := builder.
 		ApplyFilterConditions().
 		ApplyFilters().
 		ApplyFiltersOr().
 		ApplySearch().
-		ApplyOrder().
-		ApplyPagination().
-		Build()
+		BuildEstimate()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build estimate query: %w", err)
+	}
+
+	var plan []byte
+	if err := db.QueryRowContext(ctx, explainSQL, explainArgs...).Scan(&plan); err != nil {
+		return 0, fmt.Errorf("failed to execute estimate query: %w", err)
+	}
+	return query.ParseEstimate(plan)
+}
 
+// executeDataQuery builds and executes the main SELECT query. lookahead
+// fetches one row past the page (see query.CountNone).
+func executeDataQuery[T any](
+	ctx context.Context,
+	db DBConn,
+	tableName string,
+	opts *query.QueryOptions,
+	scanFunc func(*sql.Rows) (*T, error),
+	lookahead bool,
+	joins ...query.JoinConfig,
+) ([]*T, error) {
+	dataSQL, dataArgs, err := buildDataQuery(tableName, opts, lookahead, joins...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build data query: %w", err)
 	}
+	query.ObserveQuery(ctx, tableName, dataSQL, opts)
 
 	// Execute SELECT query
-	rows, err := db.QueryContext(ctx, dataSQL, dataArgs...)
+	rows, err := queryBuilt(ctx, db, dataSQL, dataArgs...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute data query: %w", err)
 	}
@@ -175,6 +404,40 @@ func executeDataQuery[T any](
 	return results, nil
 }
 
+// buildDataQuery renders the page query of executeDataQuery, through the
+// squirrel-free fast path when the list is simple enough (query.FastListQuery)
+func buildDataQuery(tableName string, opts *query.QueryOptions, lookahead bool, joins ...query.JoinConfig) (string, []interface{}, error) {
+	if len(joins) == 0 {
+		if dataSQL, dataArgs, ok := query.FastListQuery(tableName, query.DefaultPrimaryKey, opts, lookahead); ok {
+			return dataSQL, dataArgs, nil
+		}
+	}
+
+	// Build SELECT query with all filters, order, and pagination
+	builder := query.NewQueryBuilder(tableName, opts)
+
+	// Apply JOINs if provided
+	if len(joins) > 0 {
+		builder = builder.WithJoins(joins)
+	}
+
+	// Use ApplyFilterConditions() for operator support (v1.1.0+)
+	// Note: Parser populates FilterConditions when parsing HTTP requests
+	// ApplyFilters() handles direct Filter map usage (tests, manual QueryOptions)
+	builder = builder.
+		ApplyFilterConditions().
+		ApplyFilters().
+		ApplyFiltersOr().
+		ApplySearch().
+		ApplyOrder()
+	if lookahead {
+		builder = builder.ApplyPaginationLookahead()
+	} else {
+		builder = builder.ApplyPagination()
+	}
+	return builder.Build()
+}
+
 // calculatePaginationMeta computes pagination metadata from query results
 func calculatePaginationMeta(page, limit, totalRecords int) query.PaginationMeta {
 	// Ensure page is at least 1
@@ -203,24 +466,20 @@ func calculatePaginationMeta(page, limit, totalRecords int) query.PaginationMeta
 		count = 0
 	}
 
-	// Calculate previous and next page numbers
-	var previousPage interface{} = false
-	if page > 1 {
-		previousPage = page - 1
-	}
-
-	var nextPage interface{} = false
-	if page < pageCount {
-		nextPage = page + 1
-	}
-
-	return query.PaginationMeta{
+	meta := query.PaginationMeta{
 		Page:         page,
 		Limit:        limit,
 		Count:        count,
-		PreviousPage: previousPage,
-		NextPage:     nextPage,
 		PageCount:    pageCount,
 		TotalRecords: totalRecords,
 	}
+
+	// Calculate previous and next page numbers
+	if page > 1 {
+		meta.SetPreviousPage(page - 1)
+	}
+	if page < pageCount {
+		meta.SetNextPage(page + 1)
+	}
+	return meta
 }
@@ -0,0 +1,155 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/repository/testhelpers"
+	"github.com/valentinesamuel/activelog/pkg/query"
+)
+
+var tagRows = []string{"id", "name", "created_at", "deleted_at", "parent_tag_id", "public_id"}
+
+// goal is a type only the test knows, so FindAndPaginate can't lean on the
+// models it was written for
+type goal struct {
+	ID     int
+	Target float64
+}
+
+func scanGoal(rows *sql.Rows) (*goal, error) {
+	var g goal
+	err := rows.Scan(&g.ID, &g.Target)
+	return &g, err
+}
+
+func TestTagRepository_ListTagsWithQuery_TypedPage(t *testing.T) {
+	created := time.Date(2026, 5, 1, 7, 0, 0, 0, time.UTC)
+
+	db, mock := testhelpers.SetupMockDB(t)
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM tags`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+	mock.ExpectQuery(`SELECT .* FROM tags`).
+		WillReturnRows(sqlmock.NewRows(tagRows).
+			AddRow(3, "cardio", created, nil, nil, "01HZY3V5J6X7Q8R9S0T1V2W3X4").
+			AddRow(4, "strength", created, nil, 3, "01HZY3V5J6X7Q8R9S0T1V2W3X5"))
+
+	opts := query.NewQueryOptions()
+	opts.Page = 2
+	opts.Limit = 2
+	page, err := repository.NewTagRepository(db).ListTagsWithQuery(context.Background(), opts)
+	require.NoError(t, err)
+
+	// Data is []*models.Tag, no type assertion needed
+	require.Len(t, page.Data, 2)
+	assert.Equal(t, "cardio", page.Data[0].Name)
+	assert.Equal(t, "01HZY3V5J6X7Q8R9S0T1V2W3X5", page.Data[1].PublicID)
+	assert.True(t, page.Data[1].CreatedAt.Equal(created))
+
+	assert.Equal(t, 2, page.Meta.Page)
+	assert.Equal(t, 2, page.Meta.Count)
+	assert.Equal(t, 5, page.Meta.TotalRecords)
+	assert.Equal(t, 3, page.Meta.PageCount)
+	assert.True(t, page.Meta.HasPrevious)
+	assert.True(t, page.Meta.HasNext)
+}
+
+func TestFindAndPaginate_CustomType(t *testing.T) {
+	scanErr := errors.New("cannot scan")
+
+	t.Run("exact count", func(t *testing.T) {
+		db, mock := testhelpers.SetupMockDB(t)
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM goals`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(`SELECT .* FROM goals`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "target"}).AddRow(1, 42.5))
+
+		page, err := repository.FindAndPaginate[goal](context.Background(), db, "goals", query.NewQueryOptions(), scanGoal)
+		require.NoError(t, err)
+
+		assert.Equal(t, []*goal{{ID: 1, Target: 42.5}}, page.Data)
+		assert.Equal(t, 1, page.Meta.TotalRecords)
+		assert.False(t, page.Meta.HasNext)
+	})
+
+	t.Run("uncounted page drops the lookahead row", func(t *testing.T) {
+		db, mock := testhelpers.SetupMockDB(t)
+		mock.ExpectQuery(`SELECT .* FROM goals`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "target"}).
+				AddRow(1, 10.0).
+				AddRow(2, 20.0).
+				AddRow(3, 30.0))
+
+		opts := query.NewQueryOptions()
+		opts.Limit = 2
+		opts.Count = query.CountNone
+		page, err := repository.FindAndPaginate[goal](context.Background(), db, "goals", opts, scanGoal)
+		require.NoError(t, err)
+
+		assert.Equal(t, []*goal{{ID: 1, Target: 10}, {ID: 2, Target: 20}}, page.Data)
+		assert.Equal(t, 2, page.Meta.Count)
+		assert.True(t, page.Meta.HasNext)
+		assert.Equal(t, query.CountNone, page.Meta.CountMode)
+	})
+
+	t.Run("uncounted last page", func(t *testing.T) {
+		db, mock := testhelpers.SetupMockDB(t)
+		mock.ExpectQuery(`SELECT .* FROM goals`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "target"}).AddRow(1, 10.0))
+
+		opts := query.NewQueryOptions()
+		opts.Limit = 2
+		opts.Count = query.CountNone
+		page, err := repository.FindAndPaginate[goal](context.Background(), db, "goals", opts, scanGoal)
+		require.NoError(t, err)
+
+		assert.Len(t, page.Data, 1)
+		assert.False(t, page.Meta.HasNext)
+	})
+
+	t.Run("scan error", func(t *testing.T) {
+		db, mock := testhelpers.SetupMockDB(t)
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM goals`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(`SELECT .* FROM goals`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "target"}).AddRow(1, 42.5))
+
+		page, err := repository.FindAndPaginate[goal](context.Background(), db, "goals", query.NewQueryOptions(),
+			func(*sql.Rows) (*goal, error) { return nil, scanErr })
+		assert.ErrorIs(t, err, scanErr)
+		assert.Nil(t, page)
+	})
+}
+
+// A page round-trips through JSON as the same type, which the list cache
+// relies on
+func TestPage_JSONRoundTrip(t *testing.T) {
+	db, mock := testhelpers.SetupMockDB(t)
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM goals`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(`SELECT .* FROM goals`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "target"}).AddRow(1, 10.0).AddRow(2, 20.0))
+
+	page, err := repository.FindAndPaginate[goal](context.Background(), db, "goals", query.NewQueryOptions(), scanGoal)
+	require.NoError(t, err)
+
+	encoded, err := json.Marshal(page)
+	require.NoError(t, err)
+	var decoded query.Page[goal]
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, page, &decoded)
+
+	var tags query.Page[models.Tag]
+	require.NoError(t, json.Unmarshal([]byte(`{"data":[{"name":"cardio"}],"meta":{"page":1}}`), &tags))
+	assert.Equal(t, "cardio", tags.Data[0].Name)
+}
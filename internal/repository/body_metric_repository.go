@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/pkg/dberr"
+	"github.com/valentinesamuel/activelog/pkg/errors"
+	"github.com/valentinesamuel/activelog/pkg/query"
+)
+
+// BodyMetricRepository handles database operations for daily body metrics
+// (weight, resting heart rate and sleep)
+type BodyMetricRepository struct {
+	db         DBConn
+	validation *query.EntityValidation
+}
+
+// NewBodyMetricRepository creates a new BodyMetricRepository
+func NewBodyMetricRepository(db DBConn) *BodyMetricRepository {
+	validation := query.NewEntityValidation("body_metrics")
+	validation.Column("metric_type", query.ColumnRule{Filter: true, Operators: query.EqualityOperators(), Type: query.TypeString})
+	validation.Column("recorded_on", query.ColumnRule{Filter: true, Order: true, Operators: query.ComparisonOperators(), Type: query.TypeDate})
+	validation.Column("value", query.ColumnRule{Filter: true, Order: true, Operators: query.ComparisonOperators(), Type: query.TypeFloat})
+	validation.Column("created_at", query.ColumnRule{Order: true, Type: query.TypeTimestamp})
+	validation.Alias("type", "metric_type")
+	validation.Alias("date", "recorded_on")
+
+	return &BodyMetricRepository{db: db, validation: validation}
+}
+
+// GetValidation returns the query whitelist of body metrics
+func (r *BodyMetricRepository) GetValidation() *query.EntityValidation {
+	return r.validation
+}
+
+const bodyMetricColumns = `id, user_id, metric_type, value, recorded_on, notes, created_at, updated_at`
+
+// Create inserts a measurement. A second entry for the same user, type and
+// day fails with a unique violation.
+func (r *BodyMetricRepository) Create(ctx context.Context, metric *models.BodyMetric) error {
+	query := `
+		INSERT INTO body_metrics (user_id, metric_type, value, recorded_on, notes)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		metric.UserID,
+		metric.MetricType,
+		metric.Value,
+		metric.RecordedOn,
+		metric.Notes,
+	).Scan(&metric.ID, &metric.CreatedAt, &metric.UpdatedAt)
+	if err != nil {
+		return dberr.Translate(&errors.DatabaseError{Op: "INSERT", Table: "body_metrics", Err: err})
+	}
+	return nil
+}
+
+// GetByID returns one of the user's measurements, or ErrNotFound
+func (r *BodyMetricRepository) GetByID(ctx context.Context, userID int, id int64) (*models.BodyMetric, error) {
+	query := `SELECT ` + bodyMetricColumns + ` FROM body_metrics WHERE id = $1 AND user_id = $2`
+
+	metric := &models.BodyMetric{}
+	err := r.db.QueryRowContext(ctx, query, id, userID).Scan(bodyMetricScanDest(metric)...)
+	if err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{Op: "SELECT", Table: "body_metrics", Err: err})
+	}
+	return metric, nil
+}
+
+// Update saves the value, day and notes of one of the user's measurements
+func (r *BodyMetricRepository) Update(ctx context.Context, metric *models.BodyMetric) error {
+	query := `
+		UPDATE body_metrics
+		SET value = $3, recorded_on = $4, notes = $5, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_id = $2
+		RETURNING updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		metric.ID,
+		metric.UserID,
+		metric.Value,
+		metric.RecordedOn,
+		metric.Notes,
+	).Scan(&metric.UpdatedAt)
+	if err != nil {
+		return dberr.Translate(&errors.DatabaseError{Op: "UPDATE", Table: "body_metrics", Err: err})
+	}
+	return nil
+}
+
+// Delete removes one of the user's measurements, or returns ErrNotFound
+func (r *BodyMetricRepository) Delete(ctx context.Context, userID int, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM body_metrics WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return &errors.DatabaseError{Op: "DELETE", Table: "body_metrics", Err: err}
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// ListBodyMetricsWithQuery returns measurements using the dynamic filtering
+// pattern with QueryOptions. Callers scope the list to a user by setting
+// Filter["user_id"].
+func (r *BodyMetricRepository) ListBodyMetricsWithQuery(ctx context.Context, opts *query.QueryOptions) (*query.Page[models.BodyMetric], error) {
+	return FindAndPaginate[models.BodyMetric](
+		ctx,
+		r.db,
+		"body_metrics",
+		opts,
+		r.scanBodyMetric,
+	)
+}
+
+// scanBodyMetric scans a single row from SELECT body_metrics.*
+func (r *BodyMetricRepository) scanBodyMetric(rows *sql.Rows) (*models.BodyMetric, error) {
+	metric := &models.BodyMetric{}
+	err := rows.Scan(bodyMetricScanDest(metric)...)
+	return metric, err
+}
+
+// bodyMetricScanDest returns the scan destinations for bodyMetricColumns
+func bodyMetricScanDest(metric *models.BodyMetric) []interface{} {
+	return []interface{}{
+		&metric.ID,
+		&metric.UserID,
+		&metric.MetricType,
+		&metric.Value,
+		&metric.RecordedOn,
+		&metric.Notes,
+		&metric.CreatedAt,
+		&metric.UpdatedAt,
+	}
+}
@@ -0,0 +1,380 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	stdErrors "errors"
+	"time"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/pkg/dberr"
+	"github.com/valentinesamuel/activelog/pkg/errors"
+	"github.com/valentinesamuel/activelog/pkg/query"
+)
+
+// ChallengeGraceDays is how long after a challenge ends its progress keeps
+// being refreshed, so activities logged late still count
+const ChallengeGraceDays = 7
+
+// ChallengeRepository handles database operations for challenges, their
+// participants and the badges awarded for completing them
+type ChallengeRepository struct {
+	db         DBConn
+	validation *query.EntityValidation
+}
+
+// ChallengeCompletion is a participant who just reached a challenge's target
+type ChallengeCompletion struct {
+	ChallengeID   int64
+	ChallengeName string
+	UserID        int
+	Username      string
+	Email         string
+	Language      string // preferred language of emails, "" if unset
+}
+
+// NewChallengeRepository creates a new ChallengeRepository
+func NewChallengeRepository(db DBConn) *ChallengeRepository {
+	validation := query.NewEntityValidation("challenges")
+	validation.Column("metric", query.ColumnRule{Filter: true, Operators: query.EqualityOperators(), Type: query.TypeString})
+	validation.Column("activity_type", query.ColumnRule{Filter: true, Operators: query.EqualityOperators(), Type: query.TypeString})
+	validation.Column("starts_on", query.ColumnRule{Filter: true, Order: true, Operators: query.ComparisonOperators(), Type: query.TypeDate})
+	validation.Column("ends_on", query.ColumnRule{Filter: true, Order: true, Operators: query.ComparisonOperators(), Type: query.TypeDate})
+	validation.Column("created_at", query.ColumnRule{Order: true, Type: query.TypeTimestamp})
+	validation.Alias("type", "activity_type")
+
+	return &ChallengeRepository{db: db, validation: validation}
+}
+
+// GetValidation returns the query whitelist of challenges
+func (r *ChallengeRepository) GetValidation() *query.EntityValidation {
+	return r.validation
+}
+
+const challengeColumns = `id, creator_id, name, description, activity_type, metric, target::float8,
+	starts_on, ends_on, created_at, updated_at`
+
+// Create inserts a challenge
+func (r *ChallengeRepository) Create(ctx context.Context, challenge *models.Challenge) error {
+	query := `
+		INSERT INTO challenges (creator_id, name, description, activity_type, metric, target, starts_on, ends_on)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		challenge.CreatorID,
+		challenge.Name,
+		challenge.Description,
+		challenge.ActivityType,
+		challenge.Metric,
+		challenge.Target,
+		challenge.StartsOn,
+		challenge.EndsOn,
+	).Scan(&challenge.ID, &challenge.CreatedAt, &challenge.UpdatedAt)
+	if err != nil {
+		return dberr.Translate(&errors.DatabaseError{Op: "INSERT", Table: "challenges", Err: err})
+	}
+	return nil
+}
+
+// GetByID returns a challenge, or ErrNotFound
+func (r *ChallengeRepository) GetByID(ctx context.Context, id int64) (*models.Challenge, error) {
+	query := `SELECT ` + challengeColumns + ` FROM challenges WHERE id = $1`
+
+	challenge := &models.Challenge{}
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(challengeScanDest(challenge)...); err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{Op: "SELECT", Table: "challenges", Err: err})
+	}
+	return challenge, nil
+}
+
+// GetDetail returns a challenge with its participant count and userID's
+// participation, or ErrNotFound
+func (r *ChallengeRepository) GetDetail(ctx context.Context, id int64, userID int) (*models.ChallengeDetail, error) {
+	challenge, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &models.ChallengeDetail{Challenge: challenge}
+	err = r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM challenge_participants WHERE challenge_id = $1`, id).Scan(&detail.ParticipantCount)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "challenge_participants", Err: err}
+	}
+
+	participant, err := r.GetParticipant(ctx, id, userID)
+	switch {
+	case err == nil:
+		detail.Participation = participant
+	case !stdErrors.Is(err, errors.ErrNotFound):
+		return nil, err
+	}
+	return detail, nil
+}
+
+// Delete removes a challenge its creator owns and its participants, or
+// returns ErrNotFound. Badges already awarded are kept.
+func (r *ChallengeRepository) Delete(ctx context.Context, creatorID int, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM challenges WHERE id = $1 AND creator_id = $2`, id, creatorID)
+	if err != nil {
+		return &errors.DatabaseError{Op: "DELETE", Table: "challenges", Err: err}
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// ListChallengesWithQuery returns challenges using the dynamic filtering
+// pattern with QueryOptions
+func (r *ChallengeRepository) ListChallengesWithQuery(ctx context.Context, opts *query.QueryOptions) (*query.Page[models.Challenge], error) {
+	return FindAndPaginate[models.Challenge](
+		ctx,
+		r.db,
+		"challenges",
+		opts,
+		r.scanChallenge,
+	)
+}
+
+// Join adds userID to a challenge with their progress so far. Joining twice
+// fails with a unique violation.
+func (r *ChallengeRepository) Join(ctx context.Context, challengeID int64, userID int) (*models.ChallengeParticipant, error) {
+	query := `
+		INSERT INTO challenge_participants (challenge_id, user_id)
+		VALUES ($1, $2)`
+
+	if _, err := r.db.ExecContext(ctx, query, challengeID, userID); err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{Op: "INSERT", Table: "challenge_participants", Err: err})
+	}
+	if _, err := r.refreshProgress(ctx, `p.challenge_id = $1 AND p.user_id = $2`, challengeID, userID); err != nil {
+		return nil, err
+	}
+	return r.GetParticipant(ctx, challengeID, userID)
+}
+
+// Leave removes userID from a challenge, or returns ErrNotFound
+func (r *ChallengeRepository) Leave(ctx context.Context, challengeID int64, userID int) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM challenge_participants WHERE challenge_id = $1 AND user_id = $2`, challengeID, userID)
+	if err != nil {
+		return &errors.DatabaseError{Op: "DELETE", Table: "challenge_participants", Err: err}
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// GetParticipant returns userID's participation in a challenge, or
+// ErrNotFound
+func (r *ChallengeRepository) GetParticipant(ctx context.Context, challengeID int64, userID int) (*models.ChallengeParticipant, error) {
+	query := `
+		SELECT challenge_id, user_id, progress::float8, joined_at, completed_at
+		FROM challenge_participants
+		WHERE challenge_id = $1 AND user_id = $2`
+
+	p := &models.ChallengeParticipant{}
+	err := r.db.QueryRowContext(ctx, query, challengeID, userID).
+		Scan(&p.ChallengeID, &p.UserID, &p.Progress, &p.JoinedAt, &p.CompletedAt)
+	if err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{Op: "SELECT", Table: "challenge_participants", Err: err})
+	}
+	return p, nil
+}
+
+// GetLeaderboard ranks a challenge's participants by progress; equal
+// progress shares a rank and lists who completed first first. Like
+// GetWeeklyLeaderboard, ranks and the total come from one query, so they
+// stay correct across pages.
+func (r *ChallengeRepository) GetLeaderboard(ctx context.Context, challengeID int64, page, limit int) (*query.Page[models.ChallengeStanding], error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	sqlQuery := `
+		SELECT
+			RANK() OVER (ORDER BY p.progress DESC)::int AS rank,
+			p.user_id,
+			u.username,
+			p.progress::float8,
+			LEAST(ROUND(p.progress / c.target * 100, 1), 100)::float8,
+			p.completed_at,
+			COUNT(*) OVER ()::int AS total_records
+		FROM challenge_participants p
+		INNER JOIN challenges c ON c.id = p.challenge_id
+		INNER JOIN users u ON u.id = p.user_id
+		WHERE p.challenge_id = $1
+		ORDER BY p.progress DESC, p.completed_at ASC NULLS LAST, p.user_id ASC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.QueryContext(ctx, sqlQuery, challengeID, limit, (page-1)*limit)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "challenge_participants", Err: err}
+	}
+	defer rows.Close()
+
+	standings := []*models.ChallengeStanding{}
+	totalRecords := 0
+	for rows.Next() {
+		s := &models.ChallengeStanding{}
+		if err := rows.Scan(&s.Rank, &s.UserID, &s.Username, &s.Progress, &s.ProgressPct, &s.CompletedAt, &totalRecords); err != nil {
+			return nil, &errors.DatabaseError{Op: "SCAN", Table: "challenge_participants", Err: err}
+		}
+		standings = append(standings, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{Op: "ITERATE", Table: "challenge_participants", Err: err}
+	}
+
+	return &query.Page[models.ChallengeStanding]{
+		Data: standings,
+		Meta: calculatePaginationMeta(page, limit, totalRecords),
+	}, nil
+}
+
+// RefreshProgress recomputes the progress of every participant in the
+// challenges running on today, or that ended less than ChallengeGraceDays
+// ago, and returns how many participants changed
+func (r *ChallengeRepository) RefreshProgress(ctx context.Context, today time.Time) (int64, error) {
+	return r.refreshProgress(ctx,
+		`c.starts_on <= $1::date AND c.ends_on >= $1::date - $2::int`, today, ChallengeGraceDays)
+}
+
+// refreshProgress recomputes the progress of the participants matching where
+// (over challenge_participants p and challenges c) from their activities
+func (r *ChallengeRepository) refreshProgress(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	query := `
+		UPDATE challenge_participants cp
+		SET progress = totals.progress
+		FROM (
+			SELECT
+				p.challenge_id,
+				p.user_id,
+				CASE c.metric
+					WHEN 'distance_km' THEN COALESCE(SUM(a.distance_km), 0)
+					WHEN 'duration_minutes' THEN COALESCE(SUM(a.duration_minutes), 0)
+					ELSE COUNT(a.id)
+				END AS progress
+			FROM challenge_participants p
+			INNER JOIN challenges c ON c.id = p.challenge_id
+			LEFT JOIN activities a
+				ON a.user_id = p.user_id
+				AND a.deleted_at IS NULL
+				AND a.activity_date >= c.starts_on
+				AND a.activity_date < c.ends_on + 1
+				AND (c.activity_type IS NULL OR lower(a.activity_type) = lower(c.activity_type))
+			WHERE ` + where + `
+			GROUP BY p.challenge_id, p.user_id, c.metric
+		) AS totals
+		WHERE cp.challenge_id = totals.challenge_id
+			AND cp.user_id = totals.user_id
+			AND cp.progress IS DISTINCT FROM totals.progress`
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, &errors.DatabaseError{Op: "UPDATE", Table: "challenge_participants", Err: err}
+	}
+	return result.RowsAffected()
+}
+
+// CompleteReached marks the participants whose progress reached their
+// challenge's target as completed, awards them the challenge's badge and
+// returns them. Each participant is returned once: later drops in progress,
+// e.g. from deleted activities, don't undo a completion.
+func (r *ChallengeRepository) CompleteReached(ctx context.Context) ([]ChallengeCompletion, error) {
+	query := `
+		WITH completed AS (
+			UPDATE challenge_participants p
+			SET completed_at = CURRENT_TIMESTAMP
+			FROM challenges c
+			WHERE c.id = p.challenge_id
+				AND p.completed_at IS NULL
+				AND p.progress >= c.target
+			RETURNING p.challenge_id, p.user_id, c.name
+		), awarded AS (
+			INSERT INTO badges (user_id, challenge_id, name)
+			SELECT user_id, challenge_id, name FROM completed
+			ON CONFLICT (user_id, challenge_id) DO NOTHING
+		)
+		SELECT completed.challenge_id, completed.name, u.id, u.username, u.email,
+			COALESCE(u.preferences->>'language', '')
+		FROM completed
+		INNER JOIN users u ON u.id = completed.user_id
+		ORDER BY completed.challenge_id, u.id`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "UPDATE", Table: "challenge_participants", Err: err}
+	}
+	defer rows.Close()
+
+	completions := []ChallengeCompletion{}
+	for rows.Next() {
+		var c ChallengeCompletion
+		if err := rows.Scan(&c.ChallengeID, &c.ChallengeName, &c.UserID, &c.Username, &c.Email, &c.Language); err != nil {
+			return nil, &errors.DatabaseError{Op: "SCAN", Table: "challenge_participants", Err: err}
+		}
+		completions = append(completions, c)
+	}
+	return completions, rows.Err()
+}
+
+// ListBadges returns the badges awarded to userID, newest first
+func (r *ChallengeRepository) ListBadges(ctx context.Context, userID int) ([]*models.Badge, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, challenge_id, name, awarded_at
+		FROM badges
+		WHERE user_id = $1
+		ORDER BY awarded_at DESC, id DESC`, userID)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "badges", Err: err}
+	}
+	defer rows.Close()
+
+	badges := []*models.Badge{}
+	for rows.Next() {
+		badge := &models.Badge{}
+		if err := rows.Scan(&badge.ID, &badge.ChallengeID, &badge.Name, &badge.AwardedAt); err != nil {
+			return nil, &errors.DatabaseError{Op: "SCAN", Table: "badges", Err: err}
+		}
+		badges = append(badges, badge)
+	}
+	return badges, rows.Err()
+}
+
+// scanChallenge scans a single row from SELECT challenges.*
+func (r *ChallengeRepository) scanChallenge(rows *sql.Rows) (*models.Challenge, error) {
+	challenge := &models.Challenge{}
+	err := rows.Scan(challengeScanDest(challenge)...)
+	return challenge, err
+}
+
+// challengeScanDest returns the scan destinations for challengeColumns
+func challengeScanDest(challenge *models.Challenge) []interface{} {
+	return []interface{}{
+		&challenge.ID,
+		&challenge.CreatorID,
+		&challenge.Name,
+		&challenge.Description,
+		&challenge.ActivityType,
+		&challenge.Metric,
+		&challenge.Target,
+		&challenge.StartsOn,
+		&challenge.EndsOn,
+		&challenge.CreatedAt,
+		&challenge.UpdatedAt,
+	}
+}
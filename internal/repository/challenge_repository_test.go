@@ -0,0 +1,224 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/repository/testhelpers"
+	"github.com/valentinesamuel/activelog/pkg/database"
+)
+
+// createMarchChallenge creates creatorID's distance challenge for March 2026,
+// counting activityType only when it isn't nil
+func createMarchChallenge(t *testing.T, challenges *repository.ChallengeRepository, creatorID int, activityType *string, target float64) *models.Challenge {
+	t.Helper()
+	challenge := &models.Challenge{
+		CreatorID:    creatorID,
+		Name:         "100 km in March",
+		ActivityType: activityType,
+		Metric:       models.ChallengeMetricDistance,
+		Target:       target,
+		StartsOn:     time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		EndsOn:       time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, challenges.Create(context.Background(), challenge))
+	return challenge
+}
+
+// setProgress sets userID's progress in a challenge, as the refresh job would
+func setProgress(t *testing.T, db *database.LoggingDB, challengeID int64, userID int, progress float64) {
+	t.Helper()
+	_, err := db.ExecContext(context.Background(),
+		`UPDATE challenge_participants SET progress = $3 WHERE challenge_id = $1 AND user_id = $2`,
+		challengeID, userID, progress)
+	require.NoError(t, err)
+}
+
+func TestChallengeRepository_RefreshProgress_Query(t *testing.T) {
+	db, mock := testhelpers.SetupMockDB(t)
+	today := time.Date(2026, 4, 3, 0, 0, 0, 0, time.UTC)
+	mock.ExpectExec(`AND a.activity_date >= c.starts_on\s+AND a.activity_date < c.ends_on \+ 1\s+`+
+		`AND \(c.activity_type IS NULL OR lower\(a.activity_type\) = lower\(c.activity_type\)\)\s+`+
+		`WHERE c.starts_on <= \$1::date AND c.ends_on >= \$1::date - \$2::int`).
+		WithArgs(today, repository.ChallengeGraceDays).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	changed, err := repository.NewChallengeRepository(db).RefreshProgress(context.Background(), today)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), changed)
+}
+
+func TestChallengeRepository_RefreshProgress(t *testing.T) {
+	db, cleanup := testhelpers.SetupIntegrationDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	activities := repository.NewActivityRepository(db, repository.NewTagRepository(db))
+	challenges := repository.NewChallengeRepository(db)
+	userID := createTestUser(t, db, "runner")
+
+	running := "running"
+	anyType := createMarchChallenge(t, challenges, userID, nil, 100)
+	runsOnly := createMarchChallenge(t, challenges, userID, &running, 100)
+	for _, c := range []*models.Challenge{anyType, runsOnly} {
+		_, err := challenges.Join(ctx, c.ID, userID)
+		require.NoError(t, err)
+	}
+
+	for _, a := range []struct {
+		activityType string
+		date         time.Time
+		km           float64
+	}{
+		{"running", time.Date(2026, 2, 28, 23, 59, 0, 0, time.UTC), 1},  // the day before it starts
+		{"running", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), 2},     // first moment
+		{"Running", time.Date(2026, 3, 15, 8, 0, 0, 0, time.UTC), 4},    // type matches case-insensitively
+		{"cycling", time.Date(2026, 3, 20, 8, 0, 0, 0, time.UTC), 8},    // only counts for anyType
+		{"running", time.Date(2026, 3, 31, 23, 59, 0, 0, time.UTC), 16}, // last day, inclusive
+		{"running", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), 32},    // the day after it ends
+	} {
+		require.NoError(t, activities.Create(ctx, nil, &models.Activity{
+			UserID:          userID,
+			ActivityType:    a.activityType,
+			Title:           a.activityType,
+			DurationMinutes: 30,
+			DistanceKm:      a.km,
+			ActivityDate:    a.date,
+		}))
+	}
+
+	changed, err := challenges.RefreshProgress(ctx, time.Date(2026, 4, 3, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), changed)
+
+	p, err := challenges.GetParticipant(ctx, anyType.ID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 30.0, p.Progress)
+
+	p, err = challenges.GetParticipant(ctx, runsOnly.ID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 22.0, p.Progress)
+
+	t.Run("unchanged progress isn't counted", func(t *testing.T) {
+		changed, err := challenges.RefreshProgress(ctx, time.Date(2026, 4, 3, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Zero(t, changed)
+	})
+
+	t.Run("challenges past the grace period are left alone", func(t *testing.T) {
+		require.NoError(t, activities.Create(ctx, nil, &models.Activity{
+			UserID:          userID,
+			ActivityType:    "running",
+			Title:           "late run",
+			DurationMinutes: 30,
+			DistanceKm:      64,
+			ActivityDate:    time.Date(2026, 3, 30, 8, 0, 0, 0, time.UTC),
+		}))
+
+		afterGrace := time.Date(2026, 3, 31+repository.ChallengeGraceDays+1, 0, 0, 0, 0, time.UTC)
+		changed, err := challenges.RefreshProgress(ctx, afterGrace)
+		require.NoError(t, err)
+		assert.Zero(t, changed)
+	})
+}
+
+func TestChallengeRepository_CompleteReached(t *testing.T) {
+	db, cleanup := testhelpers.SetupIntegrationDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	challenges := repository.NewChallengeRepository(db)
+	finisherID := createTestUser(t, db, "finisher")
+	laggardID := createTestUser(t, db, "laggard")
+	challenge := createMarchChallenge(t, challenges, finisherID, nil, 100)
+	for _, userID := range []int{finisherID, laggardID} {
+		_, err := challenges.Join(ctx, challenge.ID, userID)
+		require.NoError(t, err)
+	}
+	setProgress(t, db, challenge.ID, finisherID, 100)
+	setProgress(t, db, challenge.ID, laggardID, 99.5)
+
+	completions, err := challenges.CompleteReached(ctx)
+	require.NoError(t, err)
+	require.Len(t, completions, 1)
+	assert.Equal(t, finisherID, completions[0].UserID)
+	assert.Equal(t, challenge.Name, completions[0].ChallengeName)
+
+	t.Run("a completion is returned once", func(t *testing.T) {
+		setProgress(t, db, challenge.ID, finisherID, 120)
+
+		completions, err := challenges.CompleteReached(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, completions)
+	})
+
+	t.Run("the badge is awarded once", func(t *testing.T) {
+		badges, err := challenges.ListBadges(ctx, finisherID)
+		require.NoError(t, err)
+		require.Len(t, badges, 1)
+		require.NotNil(t, badges[0].ChallengeID)
+		assert.Equal(t, challenge.ID, *badges[0].ChallengeID)
+
+		badges, err = challenges.ListBadges(ctx, laggardID)
+		require.NoError(t, err)
+		assert.Empty(t, badges)
+	})
+
+	t.Run("a drop in progress keeps the completion", func(t *testing.T) {
+		setProgress(t, db, challenge.ID, finisherID, 50)
+
+		p, err := challenges.GetParticipant(ctx, challenge.ID, finisherID)
+		require.NoError(t, err)
+		assert.NotNil(t, p.CompletedAt)
+	})
+}
+
+func TestChallengeRepository_GetLeaderboard(t *testing.T) {
+	db, cleanup := testhelpers.SetupIntegrationDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	challenges := repository.NewChallengeRepository(db)
+	ann := createTestUser(t, db, "ann")
+	bob := createTestUser(t, db, "bob")
+	cat := createTestUser(t, db, "cat")
+	challenge := createMarchChallenge(t, challenges, ann, nil, 100)
+	for _, userID := range []int{ann, bob, cat} {
+		_, err := challenges.Join(ctx, challenge.ID, userID)
+		require.NoError(t, err)
+	}
+	// bob and cat tie on 120 km; cat completed first
+	setProgress(t, db, challenge.ID, cat, 120)
+	_, err := challenges.CompleteReached(ctx)
+	require.NoError(t, err)
+	setProgress(t, db, challenge.ID, bob, 120)
+	_, err = challenges.CompleteReached(ctx)
+	require.NoError(t, err)
+	setProgress(t, db, challenge.ID, ann, 40)
+
+	first, err := challenges.GetLeaderboard(ctx, challenge.ID, 1, 2)
+	require.NoError(t, err)
+	require.Len(t, first.Data, 2)
+	assert.Equal(t, cat, first.Data[0].UserID)
+	assert.Equal(t, 1, first.Data[0].Rank)
+	assert.Equal(t, bob, first.Data[1].UserID)
+	assert.Equal(t, 1, first.Data[1].Rank, "equal progress shares a rank")
+	assert.Equal(t, 100.0, first.Data[0].ProgressPct, "capped at the target")
+	assert.Equal(t, 3, first.Meta.TotalRecords)
+	assert.Equal(t, 2, first.Meta.PageCount)
+
+	second, err := challenges.GetLeaderboard(ctx, challenge.ID, 2, 2)
+	require.NoError(t, err)
+	require.Len(t, second.Data, 1)
+	assert.Equal(t, ann, second.Data[0].UserID)
+	assert.Equal(t, 3, second.Data[0].Rank, "ranked across the whole leaderboard")
+	assert.Equal(t, 40.0, second.Data[0].ProgressPct)
+	assert.Equal(t, 3, second.Meta.TotalRecords)
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+)
+
+// Operations recorded in change_log
+const (
+	ChangeCreate = "create"
+	ChangeUpdate = "update"
+	ChangeDelete = "delete"
+)
+
+// withChangeLog wraps a data-modifying statement so every row it touches is
+// recorded in change_log by the same statement, and therefore in the same
+// transaction. stmt must end with a RETURNING clause that includes id,
+// user_id and version; result is the select list read back from it
+// (e.g. "*" or "COUNT(*)").
+func withChangeLog(entity, operation, stmt, result string) string {
+	return fmt.Sprintf(`
+		WITH changed AS (%s),
+		logged AS (
+			INSERT INTO change_log (user_id, entity, entity_id, operation, version)
+			SELECT user_id, '%s', id, '%s', version FROM changed
+		)
+		SELECT %s FROM changed`, stmt, entity, operation, result)
+}
+
+// ChangeLogRepository reads the per-user change feed used by offline sync
+type ChangeLogRepository struct {
+	db DBConn
+}
+
+// NewChangeLogRepository creates a new ChangeLogRepository
+func NewChangeLogRepository(db DBConn) *ChangeLogRepository {
+	return &ChangeLogRepository{db: db}
+}
+
+// ListSince returns a page of the changes the user made to entity after
+// cursor, reading at most limit change_log rows in (txid, seq) order.
+//
+// Only transactions older than every transaction still running are read
+// (pg_snapshot_xmin): seq and txid are taken when a change is written, so a
+// transaction that is still open may commit changes below ones already
+// visible. Once every transaction before a txid has ended, nothing can commit
+// below it, so the returned cursor never passes a change a later pull would
+// find.
+//
+// The page is read first and collapsed after, one Change per entity in the
+// order of its latest change, so the cursor only passes rows that were read.
+// An entity changed on both sides of a page boundary comes back on both pages.
+func (r *ChangeLogRepository) ListSince(ctx context.Context, userID int, entity string, cursor models.ChangeCursor, limit int) (*models.ChangePage, error) {
+	query := `
+		SELECT txid::text::bigint, seq, entity_id, operation, version
+		FROM change_log
+		WHERE user_id = $1 AND entity = $2
+			AND txid < pg_snapshot_xmin(pg_current_snapshot())
+			AND (txid, seq) > ($3::bigint::text::xid8, $4)
+		ORDER BY txid, seq
+		LIMIT $5`
+
+	// One extra row tells whether another page follows
+	rows, err := r.db.QueryContext(ctx, query, userID, entity, cursor.TxID, cursor.Seq, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changes: %w", err)
+	}
+	defer rows.Close()
+
+	type logged struct {
+		cursor   models.ChangeCursor
+		entityID int64
+		op       string
+		version  int
+	}
+	var read []logged
+	for rows.Next() {
+		var l logged
+		if err := rows.Scan(&l.cursor.TxID, &l.cursor.Seq, &l.entityID, &l.op, &l.version); err != nil {
+			return nil, fmt.Errorf("failed to scan change: %w", err)
+		}
+		read = append(read, l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list changes: %w", err)
+	}
+
+	page := &models.ChangePage{Changes: []*models.Change{}, Next: cursor}
+	if len(read) > limit {
+		read = read[:limit]
+		page.HasMore = true
+	}
+	if len(read) == 0 {
+		return page, nil
+	}
+	page.Next = read[len(read)-1].cursor
+
+	// Each entity is emitted at its latest row, with the operations before it folded in
+	last := make(map[int64]int, len(read))
+	created := make(map[int64]bool)
+	for i, l := range read {
+		last[l.entityID] = i
+		if l.op == ChangeCreate {
+			created[l.entityID] = true
+		}
+	}
+	for i, l := range read {
+		if last[l.entityID] != i {
+			continue
+		}
+		page.Changes = append(page.Changes, &models.Change{
+			Entity:    entity,
+			EntityID:  l.entityID,
+			Operation: l.op,
+			Version:   l.version,
+			Created:   created[l.entityID],
+		})
+	}
+
+	return page, nil
+}
@@ -0,0 +1,116 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/repository/testhelpers"
+)
+
+var changeLogRows = []string{"txid", "seq", "entity_id", "operation", "version"}
+
+func TestChangeLogRepository_ListSince(t *testing.T) {
+	t.Run("reads committed transactions after the cursor", func(t *testing.T) {
+		db, mock := testhelpers.SetupMockDB(t)
+		mock.ExpectQuery(`txid < pg_snapshot_xmin\(pg_current_snapshot\(\)\)\s+AND \(txid, seq\) > \(\$3::bigint::text::xid8, \$4\)\s+ORDER BY txid, seq\s+LIMIT \$5`).
+			WithArgs(7, "activities", int64(900), int64(41), 3).
+			WillReturnRows(sqlmock.NewRows(changeLogRows))
+
+		page, err := repository.NewChangeLogRepository(db).ListSince(context.Background(), 7, "activities", models.ChangeCursor{TxID: 900, Seq: 41}, 2)
+		require.NoError(t, err)
+
+		assert.Empty(t, page.Changes)
+		assert.False(t, page.HasMore)
+		assert.Equal(t, models.ChangeCursor{TxID: 900, Seq: 41}, page.Next, "an empty page keeps the cursor")
+	})
+
+	t.Run("pages before collapsing", func(t *testing.T) {
+		db, mock := testhelpers.SetupMockDB(t)
+		// Activity 1 is created, then updated after the page boundary; the
+		// cursor stops at the last row read, not at activity 1's latest change
+		mock.ExpectQuery(`FROM change_log`).
+			WithArgs(7, "activities", int64(0), int64(0), 4).
+			WillReturnRows(sqlmock.NewRows(changeLogRows).
+				AddRow(900, 40, 1, "create", 1).
+				AddRow(901, 38, 2, "create", 1).
+				AddRow(901, 39, 2, "update", 2).
+				AddRow(905, 42, 1, "update", 2))
+
+		page, err := repository.NewChangeLogRepository(db).ListSince(context.Background(), 7, "activities", models.ChangeCursor{}, 3)
+		require.NoError(t, err)
+
+		assert.True(t, page.HasMore)
+		assert.Equal(t, models.ChangeCursor{TxID: 901, Seq: 39}, page.Next)
+		require.Len(t, page.Changes, 2)
+		assert.Equal(t, models.Change{Entity: "activities", EntityID: 1, Operation: "create", Version: 1, Created: true}, *page.Changes[0])
+		assert.Equal(t, models.Change{Entity: "activities", EntityID: 2, Operation: "update", Version: 2, Created: true}, *page.Changes[1])
+	})
+
+	t.Run("collapses to the latest change per entity", func(t *testing.T) {
+		db, mock := testhelpers.SetupMockDB(t)
+		mock.ExpectQuery(`FROM change_log`).
+			WithArgs(7, "activities", int64(900), int64(40), 101).
+			WillReturnRows(sqlmock.NewRows(changeLogRows).
+				AddRow(903, 41, 1, "update", 2).
+				AddRow(903, 43, 3, "create", 1).
+				AddRow(904, 44, 1, "update", 3).
+				AddRow(904, 45, 3, "delete", 2).
+				AddRow(906, 46, 4, "update", 5))
+
+		page, err := repository.NewChangeLogRepository(db).ListSince(context.Background(), 7, "activities", models.ChangeCursor{TxID: 900, Seq: 40}, 100)
+		require.NoError(t, err)
+
+		assert.False(t, page.HasMore)
+		assert.Equal(t, models.ChangeCursor{TxID: 906, Seq: 46}, page.Next)
+		assert.Equal(t, []*models.Change{
+			{Entity: "activities", EntityID: 1, Operation: "update", Version: 3},
+			{Entity: "activities", EntityID: 3, Operation: "delete", Version: 2, Created: true},
+			{Entity: "activities", EntityID: 4, Operation: "update", Version: 5},
+		}, page.Changes)
+	})
+}
+
+// A change written by a transaction that is still open when a later one
+// commits must not be passed by the cursor
+func TestChangeLogRepository_ListSince_CommitOrder(t *testing.T) {
+	db, cleanup := testhelpers.SetupIntegrationDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	changes := repository.NewChangeLogRepository(db)
+	userID := createTestUser(t, db, "syncer")
+
+	logChange := `INSERT INTO change_log (user_id, entity, entity_id, operation, version) VALUES ($1, 'activities', $2, 'create', 1)`
+
+	// The slow transaction takes its seq first and commits last
+	slow, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer slow.Rollback()
+	_, err = slow.ExecContext(ctx, logChange, userID, 1)
+	require.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, logChange, userID, 2)
+	require.NoError(t, err)
+
+	page, err := changes.ListSince(ctx, userID, "activities", models.ChangeCursor{}, 100)
+	require.NoError(t, err)
+	assert.Empty(t, page.Changes, "activity 2 waits until the slow transaction ends")
+
+	require.NoError(t, slow.Commit())
+
+	page, err = changes.ListSince(ctx, userID, "activities", page.Next, 100)
+	require.NoError(t, err)
+	require.Len(t, page.Changes, 2)
+	assert.Equal(t, int64(1), page.Changes[0].EntityID)
+	assert.Equal(t, int64(2), page.Changes[1].EntityID)
+
+	page, err = changes.ListSince(ctx, userID, "activities", page.Next, 100)
+	require.NoError(t, err)
+	assert.Empty(t, page.Changes)
+}
@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/pkg/dberr"
+	"github.com/valentinesamuel/activelog/pkg/errors"
+)
+
+// CoachRepository handles database operations for coach access: the grants
+// athletes give coaches to their activities and stats
+type CoachRepository struct {
+	db DBConn
+}
+
+// NewCoachRepository creates a new CoachRepository
+func NewCoachRepository(db DBConn) *CoachRepository {
+	return &CoachRepository{db: db}
+}
+
+// Invite records a pending grant from athleteID to coachID. Inviting the
+// same coach twice fails with a unique violation, and an unknown coach with
+// a foreign key violation.
+func (r *CoachRepository) Invite(ctx context.Context, athleteID, coachID int, canComment bool) (*models.CoachAccess, error) {
+	query := `
+		INSERT INTO coach_access (athlete_id, coach_id, can_comment)
+		VALUES ($1, $2, $3)
+		RETURNING status, created_at`
+
+	access := &models.CoachAccess{AthleteID: athleteID, CoachID: coachID, CanComment: canComment}
+	err := r.db.QueryRowContext(ctx, query, athleteID, coachID, canComment).Scan(&access.Status, &access.CreatedAt)
+	if err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{Op: "INSERT", Table: "coach_access", Err: err})
+	}
+	return access, nil
+}
+
+// Accept activates the pending invitation athleteID sent coachID, or returns
+// ErrNotFound when there is none
+func (r *CoachRepository) Accept(ctx context.Context, coachID, athleteID int) error {
+	query := `
+		UPDATE coach_access
+		SET status = 'active', accepted_at = CURRENT_TIMESTAMP
+		WHERE coach_id = $1 AND athlete_id = $2 AND status = 'pending'`
+
+	result, err := r.db.ExecContext(ctx, query, coachID, athleteID)
+	if err != nil {
+		return &errors.DatabaseError{Op: "UPDATE", Table: "coach_access", Err: err}
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// SetCanComment changes whether coachID may comment on athleteID's
+// activities, or returns ErrNotFound when there is no grant
+func (r *CoachRepository) SetCanComment(ctx context.Context, athleteID, coachID int, canComment bool) error {
+	query := `UPDATE coach_access SET can_comment = $3 WHERE athlete_id = $1 AND coach_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, athleteID, coachID, canComment)
+	if err != nil {
+		return &errors.DatabaseError{Op: "UPDATE", Table: "coach_access", Err: err}
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// Revoke ends the grant between athleteID and coachID, pending or active.
+// Athletes revoke coaches and coaches drop athletes through it; it returns
+// ErrNotFound when there is no grant.
+func (r *CoachRepository) Revoke(ctx context.Context, athleteID, coachID int) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM coach_access WHERE athlete_id = $1 AND coach_id = $2`, athleteID, coachID)
+	if err != nil {
+		return &errors.DatabaseError{Op: "DELETE", Table: "coach_access", Err: err}
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// Get returns the grant between athleteID and coachID, or ErrNotFound
+func (r *CoachRepository) Get(ctx context.Context, athleteID, coachID int) (*models.CoachAccess, error) {
+	grants, err := r.list(ctx, `ca.athlete_id = $1 AND ca.coach_id = $2`, athleteID, coachID)
+	if err != nil {
+		return nil, err
+	}
+	if len(grants) == 0 {
+		return nil, errors.ErrNotFound
+	}
+	return grants[0], nil
+}
+
+// ListCoaches returns the grants athleteID gave, pending ones included
+func (r *CoachRepository) ListCoaches(ctx context.Context, athleteID int) ([]*models.CoachAccess, error) {
+	return r.list(ctx, `ca.athlete_id = $1`, athleteID)
+}
+
+// ListAthletes returns the grants coachID received, pending invitations
+// included
+func (r *CoachRepository) ListAthletes(ctx context.Context, coachID int) ([]*models.CoachAccess, error) {
+	return r.list(ctx, `ca.coach_id = $1`, coachID)
+}
+
+func (r *CoachRepository) list(ctx context.Context, where string, args ...interface{}) ([]*models.CoachAccess, error) {
+	query := `
+		SELECT ca.athlete_id, athlete.username, ca.coach_id, coach.username,
+			ca.can_comment, ca.status, ca.created_at, ca.accepted_at
+		FROM coach_access ca
+		INNER JOIN users athlete ON athlete.id = ca.athlete_id
+		INNER JOIN users coach ON coach.id = ca.coach_id
+		WHERE ` + where + `
+			AND athlete.deleted_at IS NULL
+			AND coach.deleted_at IS NULL
+		ORDER BY ca.created_at ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "coach_access", Err: err}
+	}
+	defer rows.Close()
+
+	grants := []*models.CoachAccess{}
+	for rows.Next() {
+		access := &models.CoachAccess{}
+		if err := rows.Scan(
+			&access.AthleteID,
+			&access.AthleteUsername,
+			&access.CoachID,
+			&access.CoachUsername,
+			&access.CanComment,
+			&access.Status,
+			&access.CreatedAt,
+			&access.AcceptedAt,
+		); err != nil {
+			return nil, &errors.DatabaseError{Op: "SCAN", Table: "coach_access", Err: err}
+		}
+		grants = append(grants, access)
+	}
+	return grants, rows.Err()
+}
+
+// HasAccess reports whether coachID holds an active grant from athleteID
+// covering scope (models.CoachScopeRead or models.CoachScopeComment)
+func (r *CoachRepository) HasAccess(ctx context.Context, coachID, athleteID int, scope string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM coach_access ca
+			INNER JOIN users u ON u.id = ca.athlete_id
+			WHERE ca.coach_id = $1
+				AND ca.athlete_id = $2
+				AND ca.status = 'active'
+				AND ($3 <> 'comment' OR ca.can_comment)
+				AND u.deleted_at IS NULL
+		)`
+
+	var ok bool
+	if err := r.db.QueryRowContext(ctx, query, coachID, athleteID, scope).Scan(&ok); err != nil {
+		return false, &errors.DatabaseError{Op: "SELECT", Table: "coach_access", Err: err}
+	}
+	return ok, nil
+}
+
+// GetDashboard returns an AthleteLoad for each athlete coachID has active
+// access to, by username. Loads follow GetTrainingLoad: an activity counts
+// its heart-rate training load, or its duration without heart-rate data, and
+// the windows end on today's date. Private activities are left out.
+func (r *CoachRepository) GetDashboard(ctx context.Context, coachID int, today time.Time) ([]models.AthleteLoad, error) {
+	query := `
+		SELECT
+			u.id,
+			u.public_id,
+			u.username,
+			COUNT(a.id) FILTER (WHERE a.activity_date >= $2::date - 6),
+			COALESCE(SUM(a.duration_minutes) FILTER (WHERE a.activity_date >= $2::date - 6), 0),
+			COALESCE(SUM(a.distance_km) FILTER (WHERE a.activity_date >= $2::date - 6), 0)::float8,
+			COALESCE(SUM(COALESCE(a.training_load, a.duration_minutes)) FILTER (WHERE a.activity_date >= $2::date - 6), 0)::float8,
+			COALESCE(SUM(COALESCE(a.training_load, a.duration_minutes)), 0)::float8
+		FROM coach_access ca
+		INNER JOIN users u ON u.id = ca.athlete_id AND u.deleted_at IS NULL
+		LEFT JOIN activities a
+			ON a.user_id = ca.athlete_id
+			AND a.deleted_at IS NULL
+			AND a.visibility <> 'private'
+			AND a.activity_date >= $2::date - 27
+			AND a.activity_date < $2::date + 1
+		WHERE ca.coach_id = $1 AND ca.status = 'active'
+		GROUP BY u.id, u.public_id, u.username
+		ORDER BY u.username ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, coachID, today)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "AGGREGATE", Table: "activities", Err: err}
+	}
+	defer rows.Close()
+
+	athletes := []models.AthleteLoad{}
+	for rows.Next() {
+		var load models.AthleteLoad
+		if err := rows.Scan(
+			&load.AthleteID,
+			&load.PublicID,
+			&load.Username,
+			&load.Activities,
+			&load.DurationMinutes,
+			&load.DistanceKm,
+			&load.AcuteLoad,
+			&load.ChronicLoad,
+		); err != nil {
+			return nil, &errors.DatabaseError{Op: "SCAN", Table: "activities", Err: err}
+		}
+		if load.ChronicLoad > 0 {
+			ratio := math.Round(load.AcuteLoad/(load.ChronicLoad/4)*100) / 100
+			load.Ratio = &ratio
+		}
+		athletes = append(athletes, load)
+	}
+	return athletes, rows.Err()
+}
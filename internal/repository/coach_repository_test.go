@@ -0,0 +1,68 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/repository/testhelpers"
+)
+
+func TestCoachRepository_GetDashboard_Query(t *testing.T) {
+	db, mock := testhelpers.SetupMockDB(t)
+	today := time.Date(2026, 5, 14, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`LEFT JOIN activities a\s+ON a.user_id = ca.athlete_id\s+AND a.deleted_at IS NULL\s+AND a.visibility <> 'private'`).
+		WithArgs(3, today).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "public_id", "username", "activities", "duration", "distance", "acute", "chronic"}).
+			AddRow(8, "01HZY3V5J6X7Q8R9S0T1V2W3X4", "athlete", 2, 90, 15.5, 90.0, 240.0))
+
+	athletes, err := repository.NewCoachRepository(db).GetDashboard(context.Background(), 3, today)
+	require.NoError(t, err)
+
+	require.Len(t, athletes, 1)
+	assert.Equal(t, 2, athletes[0].Activities)
+	require.NotNil(t, athletes[0].Ratio)
+	assert.Equal(t, 1.5, *athletes[0].Ratio)
+}
+
+func TestCoachRepository_GetDashboard_LeavesOutPrivate(t *testing.T) {
+	db, cleanup := testhelpers.SetupIntegrationDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	activities := repository.NewActivityRepository(db, repository.NewTagRepository(db))
+	coachID := createTestUser(t, db, "coach")
+	athleteID := createTestUser(t, db, "athlete")
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO coach_access (athlete_id, coach_id, status, accepted_at)
+		VALUES ($1, $2, 'active', CURRENT_TIMESTAMP)`, athleteID, coachID)
+	require.NoError(t, err)
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	for _, visibility := range []string{models.VisibilityPublic, models.VisibilityFollowers, models.VisibilityPrivate} {
+		require.NoError(t, activities.Create(ctx, nil, &models.Activity{
+			UserID:          athleteID,
+			ActivityType:    "running",
+			Title:           visibility + " run",
+			DurationMinutes: 30,
+			DistanceKm:      5,
+			ActivityDate:    today.Add(-24 * time.Hour),
+			Visibility:      visibility,
+		}))
+	}
+
+	athletes, err := repository.NewCoachRepository(db).GetDashboard(ctx, coachID, today)
+	require.NoError(t, err)
+
+	require.Len(t, athletes, 1)
+	assert.Equal(t, 2, athletes[0].Activities)
+	assert.Equal(t, 60, athletes[0].DurationMinutes)
+	assert.Equal(t, 10.0, athletes[0].DistanceKm)
+	assert.Equal(t, 60.0, athletes[0].AcuteLoad)
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 
 	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/pkg/errors"
 	"github.com/valentinesamuel/activelog/pkg/query"
 )
 
@@ -73,7 +74,7 @@ func (cr *CommentRepository) scanComment(rows *sql.Rows) (*models.Comment, error
 func (cr *CommentRepository) ListCommentsWithQuery(
 	ctx context.Context,
 	opts *query.QueryOptions,
-) (*query.PaginatedResult, error) {
+) (*query.Page[models.Comment], error) {
 	joins := cr.registry.GenerateJoins(opts)
 
 	return FindAndPaginate[models.Comment](
@@ -85,3 +86,22 @@ func (cr *CommentRepository) ListCommentsWithQuery(
 		joins...,
 	)
 }
+
+// Create inserts a comment
+func (cr *CommentRepository) Create(ctx context.Context, comment *models.Comment) error {
+	query := `
+		INSERT INTO comments (user_id, commentable_type, commentable_id, content)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at`
+
+	err := cr.db.QueryRowContext(ctx, query,
+		comment.UserID,
+		comment.CommentableType,
+		comment.CommentableID,
+		comment.Content,
+	).Scan(&comment.ID, &comment.CreatedAt, &comment.UpdatedAt)
+	if err != nil {
+		return &errors.DatabaseError{Op: "INSERT", Table: "comments", Err: err}
+	}
+	return nil
+}
@@ -2,12 +2,36 @@ package di
 
 // Container registration keys for repositories
 const (
-	TagRepoKey           = "tagRepo"
-	ActivityRepoKey      = "activityRepo"
-	ActivityPhotoRepoKey = "activityPhotoRepo"
-	UserRepoKey          = "userRepo"
-	StatsRepoKey         = "statsRepo"
-	ExportRepoKey        = "exportRepo"
-	WebhookRepoKey       = "webhookRepo"
-	CommentRepoKey       = "commentRepo"
+	TagRepoKey             = "tagRepo"
+	ActivityRepoKey        = "activityRepo"
+	ActivityPhotoRepoKey   = "activityPhotoRepo"
+	UserRepoKey            = "userRepo"
+	StatsRepoKey           = "statsRepo"
+	StatsSummaryRepoKey    = "statsSummaryRepo"
+	ExportRepoKey          = "exportRepo"
+	ImportRepoKey          = "importRepo"
+	JobRepoKey             = "jobRepo"
+	ProcessedMsgRepoKey    = "processedMessageRepo"
+	ChangeLogRepoKey       = "changeLogRepo"
+	PartitionRepoKey       = "partitionRepo"
+	AccountRepoKey         = "accountRepo"
+	WebhookRepoKey         = "webhookRepo"
+	CommentRepoKey         = "commentRepo"
+	GroupRepoKey           = "groupRepo"
+	ShareRepoKey           = "shareRepo"
+	ProfileRepoKey         = "profileRepo"
+	ReactionRepoKey        = "reactionRepo"
+	BodyMetricRepoKey      = "bodyMetricRepo"
+	WorkoutRepoKey         = "workoutRepo"
+	CoachRepoKey           = "coachRepo"
+	ChallengeRepoKey       = "challengeRepo"
+	AchievementRepoKey     = "achievementRepo"
+	IdentityRepoKey        = "identityRepo"
+	ActivityTypeRepoKey    = "activityTypeRepo"
+	IndexRepoKey           = "indexRepo"
+	SagaRepoKey            = "sagaRepo"
+	WorkerHeartbeatRepoKey = "workerHeartbeatRepo"
+	PrivacyZoneRepoKey     = "privacyZoneRepo"
+	ActivityTrackRepoKey   = "activityTrackRepo"
+	APIKeyRepoKey          = "apiKeyRepo"
 )
@@ -1,6 +1,7 @@
 package di
 
 import (
+	"github.com/valentinesamuel/activelog/internal/platform/config"
 	"github.com/valentinesamuel/activelog/internal/platform/container"
 	"github.com/valentinesamuel/activelog/internal/repository"
 	"github.com/valentinesamuel/activelog/pkg/query"
@@ -12,41 +13,48 @@ const CoreDBKey = "db"
 // CoreRegistryManagerKey is the key for the registry manager singleton
 const CoreRegistryManagerKey = "registryManager"
 
+// CoreValidationRegistryKey is the key for the query validation registry singleton
+const CoreValidationRegistryKey = "validationRegistry"
+
 // RegisterRepositories registers all repository factories with the container
-// Dependencies: Requires "db" and "registryManager" to be registered first
+// Dependencies: Requires "db", "registryManager" and "validationRegistry" to be registered first
 func RegisterRepositories(c *container.Container) {
 	// Tag repository (no dependencies besides DB)
-	c.Register(TagRepoKey, func(c *container.Container) (interface{}, error) {
-		db := c.MustResolve(CoreDBKey).(repository.DBConn)
-		manager := c.MustResolve(CoreRegistryManagerKey).(*query.RegistryManager)
+	container.RegisterTyped(c, TagRepoKey, func(c *container.Container) (*repository.TagRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		manager := container.MustResolve[*query.RegistryManager](c, CoreRegistryManagerKey)
+		validations := container.MustResolve[*query.ValidationConfigRegistry](c, CoreValidationRegistryKey)
 
 		tagRepo := repository.NewTagRepository(db)
 
 		// Register tags registry for cross-registry deep nesting (e.g., activities→tags→parent)
 		manager.RegisterTable("tags", tagRepo.GetRegistry())
+		validations.Register(tagRepo.GetValidation())
 
 		return tagRepo, nil
 	})
 
 	// Activity repository (depends on TagRepository and RegistryManager)
-	c.Register(ActivityRepoKey, func(c *container.Container) (interface{}, error) {
-		db := c.MustResolve(CoreDBKey).(repository.DBConn)
-		tagRepo := c.MustResolve(TagRepoKey).(*repository.TagRepository)
-		manager := c.MustResolve(CoreRegistryManagerKey).(*query.RegistryManager)
+	container.RegisterTyped(c, ActivityRepoKey, func(c *container.Container) (*repository.ActivityRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		tagRepo := container.MustResolve[*repository.TagRepository](c, TagRepoKey)
+		manager := container.MustResolve[*query.RegistryManager](c, CoreRegistryManagerKey)
+		validations := container.MustResolve[*query.ValidationConfigRegistry](c, CoreValidationRegistryKey)
 
 		// Create repository with manager support (v3.0)
 		activityRepo := repository.NewActivityRepository(db, tagRepo)
 
 		// Register this repository's registry with the manager for deep nesting
 		manager.RegisterTable("activities", activityRepo.GetRegistry())
+		validations.Register(activityRepo.GetValidation())
 
 		return activityRepo, nil
 	})
 
-	c.Register(ActivityPhotoRepoKey, func(c *container.Container) (interface{}, error) {
-		db := c.MustResolve(CoreDBKey).(repository.DBConn)
-		activityRepo := c.MustResolve(ActivityRepoKey).(*repository.ActivityRepository)
-		manager := c.MustResolve(CoreRegistryManagerKey).(*query.RegistryManager)
+	container.RegisterTyped(c, ActivityPhotoRepoKey, func(c *container.Container) (*repository.ActivityPhotoRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		activityRepo := container.MustResolve[*repository.ActivityRepository](c, ActivityRepoKey)
+		manager := container.MustResolve[*query.RegistryManager](c, CoreRegistryManagerKey)
 
 		// Create repository with manager support (v3.0)
 		activityPhotoRepo := repository.NewActivityPhotoRepository(db, activityRepo)
@@ -58,9 +66,9 @@ func RegisterRepositories(c *container.Container) {
 	})
 
 	// User repository
-	c.Register(UserRepoKey, func(c *container.Container) (interface{}, error) {
-		db := c.MustResolve(CoreDBKey).(repository.DBConn)
-		manager := c.MustResolve(CoreRegistryManagerKey).(*query.RegistryManager)
+	container.RegisterTyped(c, UserRepoKey, func(c *container.Container) (*repository.UserRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		manager := container.MustResolve[*query.RegistryManager](c, CoreRegistryManagerKey)
 
 		userRepo := repository.NewUserRepository(db)
 
@@ -71,9 +79,9 @@ func RegisterRepositories(c *container.Container) {
 	})
 
 	// Comment repository (polymorphic relationship support)
-	c.Register(CommentRepoKey, func(c *container.Container) (interface{}, error) {
-		db := c.MustResolve(CoreDBKey).(repository.DBConn)
-		manager := c.MustResolve(CoreRegistryManagerKey).(*query.RegistryManager)
+	container.RegisterTyped(c, CommentRepoKey, func(c *container.Container) (*repository.CommentRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		manager := container.MustResolve[*query.RegistryManager](c, CoreRegistryManagerKey)
 
 		commentRepo := repository.NewCommentRepository(db)
 
@@ -83,21 +91,185 @@ func RegisterRepositories(c *container.Container) {
 		return commentRepo, nil
 	})
 
-	// Stats repository
-	c.Register(StatsRepoKey, func(c *container.Container) (interface{}, error) {
-		db := c.MustResolve(CoreDBKey).(repository.DBConn)
-		return repository.NewStatsRepository(db), nil
+	// Stats repositories: the heavy stats come from the summary tables when enabled
+	container.RegisterTyped(c, StatsSummaryRepoKey, func(c *container.Container) (*repository.StatsSummaryRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		return repository.NewStatsSummaryRepository(db), nil
+	})
+
+	container.RegisterTyped(c, StatsRepoKey, func(c *container.Container) (repository.StatsRepositoryInterface, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		live := repository.NewStatsRepository(db)
+		if !config.Stats.ReadFromSummaries {
+			return live, nil
+		}
+		summaries := container.MustResolve[*repository.StatsSummaryRepository](c, StatsSummaryRepoKey)
+		return repository.NewSummaryStatsRepository(live, summaries, config.Stats.MaxStaleness), nil
 	})
 
 	// Export repository
-	c.Register(ExportRepoKey, func(c *container.Container) (interface{}, error) {
-		db := c.MustResolve(CoreDBKey).(repository.DBConn)
+	container.RegisterTyped(c, ExportRepoKey, func(c *container.Container) (*repository.ExportRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
 		return repository.NewExportRepository(db), nil
 	})
 
+	// Import repository
+	container.RegisterTyped(c, ImportRepoKey, func(c *container.Container) (*repository.ImportRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		return repository.NewImportRepository(db), nil
+	})
+
+	// Job repository (client-visible status of queued jobs)
+	container.RegisterTyped(c, JobRepoKey, func(c *container.Container) (*repository.JobRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		validations := container.MustResolve[*query.ValidationConfigRegistry](c, CoreValidationRegistryKey)
+
+		jobRepo := repository.NewJobRepository(db)
+		validations.Register(jobRepo.GetValidation())
+		return jobRepo, nil
+	})
+
+	// Processed message repository (job handler idempotency)
+	container.RegisterTyped(c, ProcessedMsgRepoKey, func(c *container.Container) (*repository.ProcessedMessageRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		return repository.NewProcessedMessageRepository(db), nil
+	})
+
+	// Change log repository (offline sync change feed)
+	container.RegisterTyped(c, ChangeLogRepoKey, func(c *container.Container) (*repository.ChangeLogRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		return repository.NewChangeLogRepository(db), nil
+	})
+
+	// Partition repository (activities table partition maintenance)
+	container.RegisterTyped(c, PartitionRepoKey, func(c *container.Container) (*repository.PartitionRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		return repository.NewPartitionRepository(db), nil
+	})
+
+	// Index repository (index advisor)
+	container.RegisterTyped(c, IndexRepoKey, func(c *container.Container) (*repository.IndexRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		return repository.NewIndexRepository(db), nil
+	})
+
+	// Saga repository (progress of multi-step operations such as account deletion)
+	container.RegisterTyped(c, SagaRepoKey, func(c *container.Container) (*repository.SagaRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		return repository.NewSagaRepository(db), nil
+	})
+
+	// Worker heartbeat repository (worker fleet status)
+	container.RegisterTyped(c, WorkerHeartbeatRepoKey, func(c *container.Container) (*repository.WorkerHeartbeatRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		return repository.NewWorkerHeartbeatRepository(db), nil
+	})
+
+	// Account repository (GDPR data export and account deletion)
+	container.RegisterTyped(c, AccountRepoKey, func(c *container.Container) (*repository.AccountRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		return repository.NewAccountRepository(db), nil
+	})
+
 	// Webhook repository
-	c.Register(WebhookRepoKey, func(c *container.Container) (interface{}, error) {
-		db := c.MustResolve(CoreDBKey).(repository.DBConn)
+	container.RegisterTyped(c, WebhookRepoKey, func(c *container.Container) (*repository.WebhookRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
 		return repository.NewWebhookRepository(db), nil
 	})
+
+	// Group repository (memberships and leaderboards)
+	container.RegisterTyped(c, GroupRepoKey, func(c *container.Container) (*repository.GroupRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		return repository.NewGroupRepository(db), nil
+	})
+
+	// Share repository (public activity links)
+	container.RegisterTyped(c, ShareRepoKey, func(c *container.Container) (*repository.ShareRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		return repository.NewShareRepository(db), nil
+	})
+
+	// Profile repository (display name, avatar and preferences)
+	container.RegisterTyped(c, ProfileRepoKey, func(c *container.Container) (*repository.ProfileRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		return repository.NewProfileRepository(db), nil
+	})
+
+	// Privacy zone repository (places whose exact position users keep to themselves)
+	container.RegisterTyped(c, PrivacyZoneRepoKey, func(c *container.Container) (*repository.PrivacyZoneRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		return repository.NewPrivacyZoneRepository(db), nil
+	})
+
+	// API key repository (long-lived bearer tokens for scripts and the CLI)
+	container.RegisterTyped(c, APIKeyRepoKey, func(c *container.Container) (*repository.APIKeyRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		return repository.NewAPIKeyRepository(db), nil
+	})
+
+	// Activity track repository (GPS tracks, stored per resolution)
+	container.RegisterTyped(c, ActivityTrackRepoKey, func(c *container.Container) (*repository.ActivityTrackRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		return repository.NewActivityTrackRepository(db), nil
+	})
+
+	// Reaction repository (kudos on activities)
+	container.RegisterTyped(c, ReactionRepoKey, func(c *container.Container) (*repository.ReactionRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		return repository.NewReactionRepository(db), nil
+	})
+
+	// Body metric repository (daily weight, resting heart rate and sleep)
+	container.RegisterTyped(c, BodyMetricRepoKey, func(c *container.Container) (*repository.BodyMetricRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		validations := container.MustResolve[*query.ValidationConfigRegistry](c, CoreValidationRegistryKey)
+
+		metricRepo := repository.NewBodyMetricRepository(db)
+		validations.Register(metricRepo.GetValidation())
+		return metricRepo, nil
+	})
+
+	// Workout repository (structured workouts and the days they are planned for)
+	container.RegisterTyped(c, WorkoutRepoKey, func(c *container.Container) (*repository.WorkoutRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		validations := container.MustResolve[*query.ValidationConfigRegistry](c, CoreValidationRegistryKey)
+
+		workoutRepo := repository.NewWorkoutRepository(db)
+		validations.Register(workoutRepo.GetValidation())
+		return workoutRepo, nil
+	})
+
+	// Coach repository (coach access grants and the coach dashboard)
+	container.RegisterTyped(c, CoachRepoKey, func(c *container.Container) (*repository.CoachRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		return repository.NewCoachRepository(db), nil
+	})
+
+	// Challenge repository (challenges, their participants and badges)
+	container.RegisterTyped(c, ChallengeRepoKey, func(c *container.Container) (*repository.ChallengeRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		validations := container.MustResolve[*query.ValidationConfigRegistry](c, CoreValidationRegistryKey)
+
+		challengeRepo := repository.NewChallengeRepository(db)
+		validations.Register(challengeRepo.GetValidation())
+		return challengeRepo, nil
+	})
+
+	// Achievement repository (achievements users earned)
+	container.RegisterTyped(c, AchievementRepoKey, func(c *container.Container) (*repository.AchievementRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		return repository.NewAchievementRepository(db), nil
+	})
+
+	// Identity repository (social login accounts linked to users)
+	container.RegisterTyped(c, IdentityRepoKey, func(c *container.Container) (*repository.IdentityRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		return repository.NewIdentityRepository(db), nil
+	})
+
+	// Activity type repository (default and user-defined activity types)
+	container.RegisterTyped(c, ActivityTypeRepoKey, func(c *container.Container) (*repository.ActivityTypeRepository, error) {
+		db := container.MustResolve[repository.DBConn](c, CoreDBKey)
+		return repository.NewActivityTypeRepository(db), nil
+	})
 }
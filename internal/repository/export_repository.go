@@ -39,10 +39,10 @@ func (r *ExportRepository) Create(ctx context.Context, record *models.ExportReco
 }
 
 // UpdateStatus updates the status, s3_key, error_message, and completed_at fields.
-func (r *ExportRepository) UpdateStatus(ctx context.Context, id string, status models.ExportStatus, s3Key *string, errMsg *string) error {
+// Completed and failed exports are completed at now.
+func (r *ExportRepository) UpdateStatus(ctx context.Context, id string, status models.ExportStatus, s3Key *string, errMsg *string, now time.Time) error {
 	var completedAt *time.Time
 	if status == models.StatusCompleted || status == models.StatusFailed {
-		now := time.Now()
 		completedAt = &now
 	}
 
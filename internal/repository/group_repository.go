@@ -0,0 +1,334 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/pkg/dberr"
+	"github.com/valentinesamuel/activelog/pkg/errors"
+	"github.com/valentinesamuel/activelog/pkg/query"
+)
+
+// leaderboardMetrics whitelists the columns a leaderboard can be ranked by
+// Maps the public metric name to the aggregated column in the leaderboard CTE
+var leaderboardMetrics = map[string]string{
+	"distance": "total_distance",
+	"duration": "total_duration",
+}
+
+// IsValidLeaderboardMetric reports whether metric can be passed to GetWeeklyLeaderboard
+func IsValidLeaderboardMetric(metric string) bool {
+	_, ok := leaderboardMetrics[metric]
+	return ok
+}
+
+// GroupRepository handles database operations for groups, memberships and leaderboards
+type GroupRepository struct {
+	db DBConn
+}
+
+// NewGroupRepository creates a new GroupRepository
+func NewGroupRepository(db DBConn) *GroupRepository {
+	return &GroupRepository{db: db}
+}
+
+// Create inserts a group and enrolls its owner as the first member in a single statement
+func (r *GroupRepository) Create(ctx context.Context, group *models.Group) error {
+	query := `
+		WITH new_group AS (
+			INSERT INTO groups (owner_id, name, description, is_private)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id, created_at, updated_at
+		), owner_membership AS (
+			INSERT INTO group_members (group_id, user_id, role)
+			SELECT id, $1, 'owner' FROM new_group
+		)
+		SELECT id, created_at, updated_at FROM new_group`
+
+	err := r.db.QueryRowContext(ctx, query,
+		group.OwnerID,
+		group.Name,
+		group.Description,
+		group.IsPrivate,
+	).Scan(&group.ID, &group.CreatedAt, &group.UpdatedAt)
+	if err != nil {
+		return dberr.Translate(&errors.DatabaseError{Op: "INSERT", Table: "groups", Err: err})
+	}
+
+	group.MemberCount = 1
+	return nil
+}
+
+// GetByID fetches a live group with its member count
+func (r *GroupRepository) GetByID(ctx context.Context, id int64) (*models.Group, error) {
+	query := `
+		SELECT g.id, g.owner_id, g.name, COALESCE(g.description, ''), g.is_private,
+			g.created_at, g.updated_at,
+			(SELECT COUNT(*)::int FROM group_members gm WHERE gm.group_id = g.id) AS member_count
+		FROM groups g
+		WHERE g.id = $1 AND g.deleted_at IS NULL`
+
+	group := &models.Group{}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&group.ID,
+		&group.OwnerID,
+		&group.Name,
+		&group.Description,
+		&group.IsPrivate,
+		&group.CreatedAt,
+		&group.UpdatedAt,
+		&group.MemberCount,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "groups", Err: err}
+	}
+
+	return group, nil
+}
+
+// ListByUser returns every live group the user belongs to
+func (r *GroupRepository) ListByUser(ctx context.Context, userID int) ([]*models.Group, error) {
+	query := `
+		SELECT g.id, g.owner_id, g.name, COALESCE(g.description, ''), g.is_private,
+			g.created_at, g.updated_at,
+			(SELECT COUNT(*)::int FROM group_members c WHERE c.group_id = g.id) AS member_count
+		FROM groups g
+		INNER JOIN group_members gm ON gm.group_id = g.id
+		WHERE gm.user_id = $1 AND g.deleted_at IS NULL
+		ORDER BY g.name ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "groups", Err: err}
+	}
+	defer rows.Close()
+
+	groups := []*models.Group{}
+	for rows.Next() {
+		group := &models.Group{}
+		if err := rows.Scan(
+			&group.ID,
+			&group.OwnerID,
+			&group.Name,
+			&group.Description,
+			&group.IsPrivate,
+			&group.CreatedAt,
+			&group.UpdatedAt,
+			&group.MemberCount,
+		); err != nil {
+			return nil, &errors.DatabaseError{Op: "SCAN", Table: "groups", Err: err}
+		}
+		groups = append(groups, group)
+	}
+
+	return groups, rows.Err()
+}
+
+// GetMember fetches a single membership row
+func (r *GroupRepository) GetMember(ctx context.Context, groupID int64, userID int) (*models.GroupMember, error) {
+	query := `
+		SELECT gm.group_id, gm.user_id, u.username, gm.role, gm.show_on_leaderboard, gm.joined_at
+		FROM group_members gm
+		INNER JOIN users u ON u.id = gm.user_id
+		WHERE gm.group_id = $1 AND gm.user_id = $2`
+
+	member := &models.GroupMember{}
+	err := r.db.QueryRowContext(ctx, query, groupID, userID).Scan(
+		&member.GroupID,
+		&member.UserID,
+		&member.Username,
+		&member.Role,
+		&member.ShowOnLeaderboard,
+		&member.JoinedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "group_members", Err: err}
+	}
+
+	return member, nil
+}
+
+// ListMembers returns the members of a group
+// Members who hid themselves from the leaderboard are still listed - membership itself is not secret
+func (r *GroupRepository) ListMembers(ctx context.Context, groupID int64) ([]*models.GroupMember, error) {
+	query := `
+		SELECT gm.group_id, gm.user_id, u.username, gm.role, gm.show_on_leaderboard, gm.joined_at
+		FROM group_members gm
+		INNER JOIN users u ON u.id = gm.user_id
+		WHERE gm.group_id = $1
+		ORDER BY gm.joined_at ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, groupID)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "group_members", Err: err}
+	}
+	defer rows.Close()
+
+	members := []*models.GroupMember{}
+	for rows.Next() {
+		member := &models.GroupMember{}
+		if err := rows.Scan(
+			&member.GroupID,
+			&member.UserID,
+			&member.Username,
+			&member.Role,
+			&member.ShowOnLeaderboard,
+			&member.JoinedAt,
+		); err != nil {
+			return nil, &errors.DatabaseError{Op: "SCAN", Table: "group_members", Err: err}
+		}
+		members = append(members, member)
+	}
+
+	return members, rows.Err()
+}
+
+// AddMember enrolls a user in a group
+// Returns errors.ErrAlreadyExists if the user is already a member
+func (r *GroupRepository) AddMember(ctx context.Context, groupID int64, userID int, role models.GroupRole) error {
+	query := `
+		INSERT INTO group_members (group_id, user_id, role)
+		VALUES ($1, $2, $3)`
+
+	if _, err := r.db.ExecContext(ctx, query, groupID, userID, role); err != nil {
+		return dberr.Translate(&errors.DatabaseError{Op: "INSERT", Table: "group_members", Err: err})
+	}
+
+	return nil
+}
+
+// RemoveMember removes a user from a group
+func (r *GroupRepository) RemoveMember(ctx context.Context, groupID int64, userID int) error {
+	query := `DELETE FROM group_members WHERE group_id = $1 AND user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, groupID, userID)
+	if err != nil {
+		return &errors.DatabaseError{Op: "DELETE", Table: "group_members", Err: err}
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+// SetLeaderboardVisibility updates a member's privacy flag for the group leaderboard
+func (r *GroupRepository) SetLeaderboardVisibility(ctx context.Context, groupID int64, userID int, visible bool) error {
+	query := `
+		UPDATE group_members
+		SET show_on_leaderboard = $3
+		WHERE group_id = $1 AND user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, groupID, userID, visible)
+	if err != nil {
+		return &errors.DatabaseError{Op: "UPDATE", Table: "group_members", Err: err}
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetWeeklyLeaderboard ranks group members by their activity totals for the current week
+// (Monday 00:00 up to, but excluding, the next Monday in the database time zone)
+//
+// Aggregation, ranking and the total row count all come from a single query:
+// RANK() and COUNT(*) OVER() are evaluated before LIMIT/OFFSET, so ranks stay
+// correct across pages. Members with show_on_leaderboard = false are excluded,
+// except for the viewer who always sees their own position.
+func (r *GroupRepository) GetWeeklyLeaderboard(ctx context.Context, groupID int64, viewerID int, metric string, page, limit int) (*query.Page[models.LeaderboardEntry], error) {
+	orderColumn, ok := leaderboardMetrics[metric]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported leaderboard metric '%s'", errors.ErrInvalidInput, metric)
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	sqlQuery := fmt.Sprintf(`
+		WITH totals AS (
+			SELECT
+				gm.user_id,
+				u.username,
+				COALESCE(SUM(a.distance_km), 0)::float AS total_distance,
+				COALESCE(SUM(a.duration_minutes), 0)::int AS total_duration,
+				COUNT(a.id)::int AS activity_count
+			FROM group_members gm
+			INNER JOIN users u ON u.id = gm.user_id
+			LEFT JOIN activities a
+				ON a.user_id = gm.user_id
+				AND a.deleted_at IS NULL
+				AND a.activity_date >= date_trunc('week', NOW())
+				AND a.activity_date < date_trunc('week', NOW()) + INTERVAL '1 week'
+			WHERE gm.group_id = $1
+				AND (gm.show_on_leaderboard OR gm.user_id = $2)
+			GROUP BY gm.user_id, u.username
+		)
+		SELECT
+			RANK() OVER (ORDER BY %[1]s DESC)::int AS rank,
+			user_id,
+			username,
+			total_distance,
+			total_duration,
+			activity_count,
+			COUNT(*) OVER ()::int AS total_records
+		FROM totals
+		ORDER BY %[1]s DESC, user_id ASC
+		LIMIT $3 OFFSET $4`, orderColumn)
+
+	rows, err := r.db.QueryContext(ctx, sqlQuery, groupID, viewerID, limit, (page-1)*limit)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "AGGREGATE", Table: "group_members", Err: err}
+	}
+	defer rows.Close()
+
+	entries := []*models.LeaderboardEntry{}
+	totalRecords := 0
+	for rows.Next() {
+		entry := &models.LeaderboardEntry{}
+		if err := rows.Scan(
+			&entry.Rank,
+			&entry.UserID,
+			&entry.Username,
+			&entry.TotalDistanceKm,
+			&entry.TotalDuration,
+			&entry.ActivityCount,
+			&totalRecords,
+		); err != nil {
+			return nil, &errors.DatabaseError{Op: "SCAN", Table: "group_members", Err: err}
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{Op: "ITERATE", Table: "group_members", Err: err}
+	}
+
+	return &query.Page[models.LeaderboardEntry]{
+		Data: entries,
+		Meta: calculatePaginationMeta(page, limit, totalRecords),
+	}, nil
+}
@@ -0,0 +1,163 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/repository/testhelpers"
+	"github.com/valentinesamuel/activelog/pkg/errors"
+)
+
+var leaderboardRows = []string{"rank", "user_id", "username", "total_distance", "total_duration", "activity_count", "total_records"}
+
+func TestGroupRepository_GetWeeklyLeaderboard_Query(t *testing.T) {
+	t.Run("page and limit become limit and offset", func(t *testing.T) {
+		db, mock := testhelpers.SetupMockDB(t)
+		mock.ExpectQuery(`ORDER BY total_distance DESC, user_id ASC\s+LIMIT \$3 OFFSET \$4`).
+			WithArgs(int64(4), 7, 2, 2).
+			WillReturnRows(sqlmock.NewRows(leaderboardRows).
+				AddRow(3, 9, "carol", 12.5, 70, 2, 5).
+				AddRow(3, 11, "dave", 12.5, 65, 1, 5))
+
+		page, err := repository.NewGroupRepository(db).GetWeeklyLeaderboard(context.Background(), 4, 7, "distance", 2, 2)
+		require.NoError(t, err)
+
+		require.Len(t, page.Data, 2)
+		assert.Equal(t, models.LeaderboardEntry{Rank: 3, UserID: 9, Username: "carol", TotalDistanceKm: 12.5, TotalDuration: 70, ActivityCount: 2}, *page.Data[0])
+		assert.Equal(t, 3, page.Data[1].Rank, "ties share a rank")
+		assert.Equal(t, 2, page.Meta.Page)
+		assert.Equal(t, 2, page.Meta.Count)
+		assert.Equal(t, 5, page.Meta.TotalRecords)
+		assert.Equal(t, 3, page.Meta.PageCount)
+		assert.True(t, page.Meta.HasPrevious)
+		assert.True(t, page.Meta.HasNext)
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		db, mock := testhelpers.SetupMockDB(t)
+		mock.ExpectQuery(`ORDER BY total_duration DESC`).
+			WithArgs(int64(4), 7, 10, 0).
+			WillReturnRows(sqlmock.NewRows(leaderboardRows))
+
+		page, err := repository.NewGroupRepository(db).GetWeeklyLeaderboard(context.Background(), 4, 7, "duration", 0, 0)
+		require.NoError(t, err)
+		assert.Empty(t, page.Data)
+		assert.Equal(t, 1, page.Meta.Page)
+		assert.Equal(t, 10, page.Meta.Limit)
+		assert.Zero(t, page.Meta.TotalRecords)
+	})
+
+	t.Run("bounded to the current week", func(t *testing.T) {
+		db, mock := testhelpers.SetupMockDB(t)
+		mock.ExpectQuery(`a.activity_date >= date_trunc\('week', NOW\(\)\)\s+AND a.activity_date < date_trunc\('week', NOW\(\)\) \+ INTERVAL '1 week'`).
+			WillReturnRows(sqlmock.NewRows(leaderboardRows))
+
+		_, err := repository.NewGroupRepository(db).GetWeeklyLeaderboard(context.Background(), 4, 7, "distance", 1, 10)
+		require.NoError(t, err)
+	})
+
+	t.Run("unsupported metric", func(t *testing.T) {
+		db, _ := testhelpers.SetupMockDB(t)
+
+		_, err := repository.NewGroupRepository(db).GetWeeklyLeaderboard(context.Background(), 4, 7, "calories; DROP TABLE users", 1, 10)
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+	})
+}
+
+func TestGroupRepository_GetWeeklyLeaderboard(t *testing.T) {
+	db, cleanup := testhelpers.SetupIntegrationDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	groups := repository.NewGroupRepository(db)
+	activities := repository.NewActivityRepository(db, repository.NewTagRepository(db))
+
+	// The week as the database sees it, so the test doesn't depend on the
+	// time zone it runs in
+	var weekStart time.Time
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT date_trunc('week', NOW())::timestamp`).Scan(&weekStart))
+	nextWeek := weekStart.AddDate(0, 0, 7)
+
+	owner := createTestUser(t, db, "lb-owner")
+	runner := createTestUser(t, db, "lb-runner")
+	hidden := createTestUser(t, db, "lb-hidden")
+	idle := createTestUser(t, db, "lb-idle")
+	outsider := createTestUser(t, db, "lb-outsider")
+
+	group := &models.Group{OwnerID: owner, Name: "Club"}
+	require.NoError(t, groups.Create(ctx, group))
+	for _, userID := range []int{runner, hidden, idle} {
+		require.NoError(t, groups.AddMember(ctx, group.ID, userID, models.GroupRoleMember))
+	}
+	require.NoError(t, groups.SetLeaderboardVisibility(ctx, group.ID, hidden, false))
+
+	run := func(userID int, distance float64, duration int, date time.Time) {
+		t.Helper()
+		require.NoError(t, activities.Create(ctx, nil, &models.Activity{
+			UserID:          userID,
+			ActivityType:    "running",
+			Title:           "Run",
+			DurationMinutes: duration,
+			DistanceKm:      distance,
+			ActivityDate:    date,
+		}))
+	}
+	run(owner, 5, 30, weekStart)                     // first instant of the week
+	run(owner, 20, 120, weekStart.Add(-time.Second)) // last week
+	run(owner, 20, 120, nextWeek)                    // next week
+	run(runner, 8, 25, weekStart.Add(36*time.Hour))
+	run(runner, 2, 10, nextWeek.Add(-time.Second)) // last instant of the week
+	run(hidden, 50, 300, weekStart.Add(time.Hour))
+	run(outsider, 100, 600, weekStart.Add(time.Hour))
+
+	entry := func(rank, userID int, username string, distance float64, duration, count int) *models.LeaderboardEntry {
+		return &models.LeaderboardEntry{Rank: rank, UserID: userID, Username: username, TotalDistanceKm: distance, TotalDuration: duration, ActivityCount: count}
+	}
+
+	t.Run("only this week's activities of members count", func(t *testing.T) {
+		page, err := groups.GetWeeklyLeaderboard(ctx, group.ID, owner, "distance", 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []*models.LeaderboardEntry{
+			entry(1, runner, "lb-runner", 10, 35, 2),
+			entry(2, owner, "lb-owner", 5, 30, 1),
+			entry(3, idle, "lb-idle", 0, 0, 0),
+		}, page.Data)
+		assert.Equal(t, 3, page.Meta.TotalRecords)
+	})
+
+	t.Run("ranked by duration", func(t *testing.T) {
+		page, err := groups.GetWeeklyLeaderboard(ctx, group.ID, owner, "duration", 1, 10)
+		require.NoError(t, err)
+		require.Len(t, page.Data, 3)
+		assert.Equal(t, runner, page.Data[0].UserID)
+	})
+
+	t.Run("members who opted out are hidden from everyone but themselves", func(t *testing.T) {
+		page, err := groups.GetWeeklyLeaderboard(ctx, group.ID, hidden, "distance", 1, 10)
+		require.NoError(t, err)
+		require.Len(t, page.Data, 4)
+		assert.Equal(t, entry(1, hidden, "lb-hidden", 50, 300, 1), page.Data[0])
+		assert.Equal(t, 4, page.Meta.TotalRecords)
+	})
+
+	t.Run("pages keep their ranks", func(t *testing.T) {
+		first, err := groups.GetWeeklyLeaderboard(ctx, group.ID, owner, "distance", 1, 2)
+		require.NoError(t, err)
+		second, err := groups.GetWeeklyLeaderboard(ctx, group.ID, owner, "distance", 2, 2)
+		require.NoError(t, err)
+
+		require.Len(t, first.Data, 2)
+		assert.True(t, first.Meta.HasNext)
+		assert.Equal(t, []*models.LeaderboardEntry{entry(3, idle, "lb-idle", 0, 0, 0)}, second.Data)
+		assert.False(t, second.Meta.HasNext)
+		assert.Equal(t, 2, second.Meta.PageCount)
+		assert.Equal(t, 3, second.Meta.TotalRecords)
+	})
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	stdErrors "errors"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/pkg/dberr"
+	"github.com/valentinesamuel/activelog/pkg/errors"
+	"github.com/valentinesamuel/activelog/pkg/query"
+	"github.com/valentinesamuel/activelog/pkg/ulid"
+)
+
+// ErrLastLoginMethod is returned when unlinking the only way a user can log in
+var ErrLastLoginMethod = stdErrors.New("cannot remove the last login method")
+
+// IdentityRepository stores the external identities users log in with
+type IdentityRepository struct {
+	db DBConn
+}
+
+func NewIdentityRepository(db DBConn) *IdentityRepository {
+	return &IdentityRepository{db: db}
+}
+
+// LoginByIdentity returns the user linked to the provider account and records
+// the login. It returns ErrNotFound if the account isn't linked to an active user.
+func (r *IdentityRepository) LoginByIdentity(ctx context.Context, provider, subject string) (*models.User, error) {
+	query := `
+		WITH touched AS (
+			UPDATE user_identities SET last_login_at = CURRENT_TIMESTAMP
+			WHERE provider = $1 AND subject = $2
+			RETURNING user_id
+		)
+		SELECT u.id, u.username, u.email
+		FROM users u
+		JOIN touched t ON t.user_id = u.id
+		WHERE u.deleted_at IS NULL`
+
+	user := &models.User{}
+	err := r.db.QueryRowContext(ctx, query, provider, subject).Scan(&user.ID, &user.Username, &user.Email)
+	if err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{Op: "SELECT", Table: "user_identities", Err: err})
+	}
+	return user, nil
+}
+
+// Link adds identity to an existing user. Linking an account that is already
+// linked to the same user refreshes its email and last login instead. A user
+// links at most one account per provider, and an account belongs to one user,
+// so other conflicts are unique violations.
+func (r *IdentityRepository) Link(ctx context.Context, identity *models.UserIdentity) error {
+	return r.insert(ctx, nil, identity)
+}
+
+// CreateUserWithIdentity creates a user without a password together with
+// the identity they signed up with
+func (r *IdentityRepository) CreateUserWithIdentity(ctx context.Context, user *models.User, identity *models.UserIdentity) error {
+	return WithTransaction(ctx, r.db, func(tx TxConn) error {
+		query := `
+			INSERT INTO users (email, username, public_id)
+			VALUES ($1, $2, $3)
+			RETURNING id, created_at, updated_at`
+
+		user.PublicID = ulid.New()
+		err := tx.QueryRowContext(ctx, query, user.Email, user.Username, user.PublicID).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
+		if err != nil {
+			return dberr.Translate(&errors.DatabaseError{Op: "INSERT", Table: "users", Err: err})
+		}
+
+		identity.UserID = int(user.ID)
+		return r.insert(ctx, tx, identity)
+	})
+}
+
+// insert upserts identity on its provider account. The conflict update only
+// applies to the user's own link, so an account linked to someone else
+// returns no row.
+func (r *IdentityRepository) insert(ctx context.Context, tx TxConn, identity *models.UserIdentity) error {
+	stmt, args, err := query.NewInsertBuilder("user_identities", "user_id", "provider", "subject", "email", "last_login_at").
+		Values(identity.UserID, identity.Provider, identity.Subject, identity.Email, query.CurrentTimestamp).
+		OnConflict("provider", "subject").
+		DoUpdate("email").
+		DoUpdateSet("last_login_at", query.CurrentTimestamp).
+		MatchingExcluded("user_id").
+		Returning("id", "created_at", "last_login_at").
+		Build()
+	if err != nil {
+		return err
+	}
+
+	err = QueryRowInTx(ctx, tx, r.db, stmt, args...).
+		Scan(&identity.ID, &identity.CreatedAt, &identity.LastLoginAt)
+	if stdErrors.Is(err, sql.ErrNoRows) {
+		return &dberr.ErrUniqueViolation{Constraint: "uq_user_identities_provider_subject", Err: err}
+	}
+	if err != nil {
+		return dberr.Translate(&errors.DatabaseError{Op: "INSERT", Table: "user_identities", Err: err})
+	}
+	return nil
+}
+
+// ListByUser returns the identities linked to a user, oldest first
+func (r *IdentityRepository) ListByUser(ctx context.Context, userID int) ([]*models.UserIdentity, error) {
+	query := `
+		SELECT id, user_id, provider, subject, email, created_at, last_login_at
+		FROM user_identities
+		WHERE user_id = $1
+		ORDER BY created_at, id`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "user_identities", Err: err}
+	}
+	defer rows.Close()
+
+	identities := []*models.UserIdentity{}
+	for rows.Next() {
+		identity := &models.UserIdentity{}
+		if err := rows.Scan(&identity.ID, &identity.UserID, &identity.Provider, &identity.Subject,
+			&identity.Email, &identity.CreatedAt, &identity.LastLoginAt); err != nil {
+			return nil, err
+		}
+		identities = append(identities, identity)
+	}
+	return identities, rows.Err()
+}
+
+// Unlink removes the user's identity at provider. It refuses with
+// ErrLastLoginMethod when the user has no password and no other identity.
+func (r *IdentityRepository) Unlink(ctx context.Context, userID int, provider string) error {
+	query := `
+		DELETE FROM user_identities
+		WHERE user_id = $1 AND provider = $2
+		AND (
+			EXISTS (SELECT 1 FROM users WHERE id = $1 AND password_hash IS NOT NULL)
+			OR EXISTS (SELECT 1 FROM user_identities WHERE user_id = $1 AND provider <> $2)
+		)`
+
+	result, err := r.db.ExecContext(ctx, query, userID, provider)
+	if err != nil {
+		return &errors.DatabaseError{Op: "DELETE", Table: "user_identities", Err: err}
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows > 0 {
+		return nil
+	}
+
+	// Nothing deleted: either there is no such identity or it's the last one
+	var exists bool
+	err = r.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM user_identities WHERE user_id = $1 AND provider = $2)`,
+		userID, provider).Scan(&exists)
+	if err != nil {
+		return &errors.DatabaseError{Op: "SELECT", Table: "user_identities", Err: err}
+	}
+	if exists {
+		return ErrLastLoginMethod
+	}
+	return errors.ErrNotFound
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+)
+
+// ImportRepository handles database operations for activity import jobs.
+type ImportRepository struct {
+	db DBConn
+}
+
+// NewImportRepository creates a new ImportRepository.
+func NewImportRepository(db DBConn) *ImportRepository {
+	return &ImportRepository{db: db}
+}
+
+// Create inserts a new import record and sets its ID from RETURNING.
+func (r *ImportRepository) Create(ctx context.Context, record *models.ImportRecord) error {
+	query := `
+		INSERT INTO imports (user_id, source, status, storage_key, total_rows)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		record.UserID,
+		record.Source,
+		record.Status,
+		record.StorageKey,
+		record.TotalRows,
+	).Scan(&record.ID, &record.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create import record: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateProgress records the row counters reported by the worker and marks the import as processing.
+func (r *ImportRepository) UpdateProgress(ctx context.Context, id string, total, processed, imported, failed, skipped int) error {
+	query := `
+		UPDATE imports
+		SET status = $1, total_rows = $2, processed_rows = $3, imported_rows = $4, failed_rows = $5,
+		    skipped_rows = $6
+		WHERE id = $7`
+
+	result, err := r.db.ExecContext(ctx, query, models.StatusProcessing, total, processed, imported, failed, skipped, id)
+	if err != nil {
+		return fmt.Errorf("failed to update import progress: %w", err)
+	}
+
+	return checkImportUpdated(result, id)
+}
+
+// Complete sets the final status, row errors, error_message, and completed_at (now) fields.
+func (r *ImportRepository) Complete(ctx context.Context, id string, status models.ExportStatus, rowErrors []models.ImportError, errMsg *string, now time.Time) error {
+	if rowErrors == nil {
+		rowErrors = []models.ImportError{}
+	}
+	errorsJSON, err := json.Marshal(rowErrors)
+	if err != nil {
+		return fmt.Errorf("failed to marshal import errors: %w", err)
+	}
+
+	query := `
+		UPDATE imports
+		SET status = $1, errors = $2, error_message = $3, completed_at = $4
+		WHERE id = $5`
+
+	result, err := r.db.ExecContext(ctx, query, status, errorsJSON, errMsg, now, id)
+	if err != nil {
+		return fmt.Errorf("failed to complete import: %w", err)
+	}
+
+	return checkImportUpdated(result, id)
+}
+
+// GetByID fetches an import record by UUID string.
+func (r *ImportRepository) GetByID(ctx context.Context, id string) (*models.ImportRecord, error) {
+	query := `
+		SELECT id, user_id, source, status, storage_key, total_rows, processed_rows,
+		       imported_rows, failed_rows, skipped_rows, errors, error_message, created_at, completed_at
+		FROM imports
+		WHERE id = $1`
+
+	record := &models.ImportRecord{}
+	var errorsJSON []byte
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&record.ID,
+		&record.UserID,
+		&record.Source,
+		&record.Status,
+		&record.StorageKey,
+		&record.TotalRows,
+		&record.ProcessedRows,
+		&record.ImportedRows,
+		&record.FailedRows,
+		&record.SkippedRows,
+		&errorsJSON,
+		&record.ErrorMessage,
+		&record.CreatedAt,
+		&record.CompletedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("import record not found: %s", id)
+		}
+		return nil, fmt.Errorf("failed to get import record: %w", err)
+	}
+
+	if err := json.Unmarshal(errorsJSON, &record.Errors); err != nil {
+		return nil, fmt.Errorf("failed to decode import errors: %w", err)
+	}
+
+	return record, nil
+}
+
+// checkImportUpdated returns an error if an UPDATE matched no import row
+func checkImportUpdated(result sql.Result, id string) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("import record not found: %s", id)
+	}
+	return nil
+}
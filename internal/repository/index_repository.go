@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/valentinesamuel/activelog/pkg/query"
+)
+
+// IndexRepository reads the indexes of the schema, for the index advisor
+type IndexRepository struct {
+	db DBConn
+}
+
+// NewIndexRepository creates a new IndexRepository
+func NewIndexRepository(db DBConn) *IndexRepository {
+	return &IndexRepository{db: db}
+}
+
+// ListIndexes returns the indexes on tables in the current schema, by table
+// and name. Partitioned tables list the indexes of the parent table.
+func (r *IndexRepository) ListIndexes(ctx context.Context, tables []string) ([]query.ExistingIndex, error) {
+	sql := `
+		SELECT tablename, indexname, indexdef
+		FROM pg_indexes
+		WHERE schemaname = current_schema() AND tablename = ANY($1)
+		ORDER BY tablename, indexname`
+
+	rows, err := r.db.QueryContext(ctx, sql, tables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list indexes: %w", err)
+	}
+	defer rows.Close()
+
+	var indexes []query.ExistingIndex
+	for rows.Next() {
+		var index query.ExistingIndex
+		if err := rows.Scan(&index.Table, &index.Name, &index.Definition); err != nil {
+			return nil, fmt.Errorf("failed to scan index: %w", err)
+		}
+		indexes = append(indexes, index)
+	}
+	return indexes, rows.Err()
+}
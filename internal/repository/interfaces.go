@@ -11,13 +11,11 @@ import (
 
 // DBConn is an interface that abstracts database operations
 // This allows us to use either *sql.DB or *database.LoggingDB
+// Only context-aware methods are exposed so request deadlines reach every query
 type DBConn interface {
 	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
 	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
 	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
-	Query(query string, args ...interface{}) (*sql.Rows, error)
-	QueryRow(query string, args ...interface{}) *sql.Row
-	Exec(query string, args ...interface{}) (sql.Result, error)
 	GetRawDB() *sql.DB // For broker pattern
 }
 
@@ -34,10 +32,18 @@ type TxConn interface {
 //go:generate mockgen -destination=mocks/mock_stats_repository.go -package=mocks github.com/valentinesamuel/activelog/internal/repository StatsRepositoryInterface
 type StatsRepositoryInterface interface {
 	GetWeeklyStats(ctx context.Context, userID int) (*WeeklyStats, error)
+	GetSharedWeeklyStats(ctx context.Context, userID int) (*WeeklyStats, error)
 	GetMonthlyStats(ctx context.Context, userID int) (*MonthlyStats, error)
+	GetWeeklyComparison(ctx context.Context, userID int) (*StatsComparison, error)
+	GetMonthlyComparison(ctx context.Context, userID int) (*StatsComparison, error)
 	GetActivityCountByType(ctx context.Context, userID int) (map[string]int, error)
 	GetUserActivitySummary(ctx context.Context, userID int) (*UserActivitySummary, error)
 	GetTopTagsByUser(ctx context.Context, userID int, limit int) ([]TagUsage, error)
+	GetTimeSeries(ctx context.Context, userID int, metric, interval string, from, to time.Time) ([]TimeSeriesPoint, error)
+	GetTrainingLoad(ctx context.Context, userID int, from, to time.Time) ([]TrainingLoadPoint, error)
+	GetHeatmap(ctx context.Context, userID int, year int) ([]HeatmapDay, error)
+	GetRPEVsDuration(ctx context.Context, userID int, from, to time.Time) (*RPEDurationCorrelation, error)
+	GetBestSplits(ctx context.Context, userID int) (*BestSplits, error)
 }
 
 //go:generate mockgen -destination=mocks/mock_activity_repository.go -package=mocks github.com/valentinesamuel/activelog/internal/repository ActivityRepositoryInterface
@@ -45,13 +51,28 @@ type ActivityRepositoryInterface interface {
 	Create(ctx context.Context, tx TxConn, activity *models.Activity) error
 	GetByID(ctx context.Context, id int64) (*models.Activity, error)
 	ListByUser(ctx context.Context, UserID int) ([]*models.Activity, error)
-	Count(userID int) (int, error)
+	Count(ctx context.Context, userID int) (int, error)
 	Update(ctx context.Context, tx TxConn, id int, activity *models.Activity) error
 	Delete(ctx context.Context, tx TxConn, id int, userID int) error
-	GetStats(userID int, startDate, endDate *time.Time) (*ActivityStats, error)
+	GetStats(ctx context.Context, userID int, startDate, endDate *time.Time) (*ActivityStats, error)
 	CreateWithTags(ctx context.Context, activity *models.Activity, tags []*models.Tag) error
-	ListActivitiesWithQuery(ctx context.Context, opts *query.QueryOptions) (*query.PaginatedResult, error)
+	BulkImport(ctx context.Context, activities []*models.Activity, opts BulkImportOptions) (*BulkImportResult, error)
+	ExistingExternalIDs(ctx context.Context, userID int, source string, externalIDs []string) (map[string]bool, error)
+	ListActivitiesWithQuery(ctx context.Context, opts *query.QueryOptions) (*query.Page[models.Activity], error)
 	GetRegistry() *query.RelationshipRegistry
+	FindDuplicate(ctx context.Context, tx TxConn, activity *models.Activity, window time.Duration) (*models.Activity, error)
+	UpdateByFilter(ctx context.Context, tx TxConn, userID int, opts *query.QueryOptions, changes map[string]interface{}, maxRows int) (int64, error)
+	DeleteByFilter(ctx context.Context, tx TxConn, userID int, opts *query.QueryOptions, maxRows int) (int64, error)
+	GetCollectionVersion(ctx context.Context, opts *query.QueryOptions) (*CollectionVersion, error)
+	LockVersion(ctx context.Context, tx TxConn, id int, userID int) (int, error)
+	ListByIDs(ctx context.Context, userID int, ids []int64) ([]*models.Activity, error)
+	LoadIncludes(ctx context.Context, ids []int64, includes []query.Include) (map[int64]map[string]interface{}, error)
+	GetSplits(ctx context.Context, activityID int64) ([]*models.ActivitySplit, error)
+	FindDuplicatePairs(ctx context.Context, userID int, criteria DuplicateCriteria) ([]DuplicatePair, error)
+	CountAttachments(ctx context.Context, ids []int64) (map[int64]ActivityAttachments, error)
+	MergeInto(ctx context.Context, tx TxConn, userID int, keepID int64, mergeIDs []int64) (*MergeResult, error)
+	ClaimClientMutation(ctx context.Context, tx TxConn, userID int, clientID string) (int64, error)
+	RecordClientMutation(ctx context.Context, tx TxConn, userID int, clientID string, activityID int64) error
 }
 
 //go:generate mockgen -destination=mocks/mock_user_repository.go -package=mocks github.com/valentinesamuel/activelog/internal/repository UserRepositoryInterface
@@ -65,7 +86,8 @@ type TagRepositoryInterface interface {
 	GetOrCreateTag(ctx context.Context, tx TxConn, name string) (int, error)
 	GetTagsForActivity(ctx context.Context, activityID int) ([]*models.Tag, error)
 	LinkActivityTag(ctx context.Context, tx TxConn, activityID int, tagID int) error
-	ListTagsWithQuery(ctx context.Context, opts *query.QueryOptions) (*query.PaginatedResult, error)
+	ListTagsWithQuery(ctx context.Context, opts *query.QueryOptions) (*query.Page[models.Tag], error)
+	GetCollectionVersion(ctx context.Context, opts *query.QueryOptions) (*CollectionVersion, error)
 }
 
 type ActivityPhotoRepositoryInterface interface {
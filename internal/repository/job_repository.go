@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/pkg/query"
+)
+
+// JobRepository handles database operations for background job status records.
+type JobRepository struct {
+	db         DBConn
+	validation *query.EntityValidation
+}
+
+// NewJobRepository creates a new JobRepository.
+func NewJobRepository(db DBConn) *JobRepository {
+	validation := query.NewEntityValidation("jobs")
+	validation.Column("status", query.ColumnRule{Filter: true, Order: true, Operators: query.EqualityOperators(), Type: query.TypeString})
+	validation.Column("type", query.ColumnRule{Filter: true, Operators: query.EqualityOperators(), Type: query.TypeString})
+	validation.Column("created_at", query.ColumnRule{Filter: true, Order: true, Operators: query.ComparisonOperators(), Type: query.TypeTimestamp})
+	validation.Column("updated_at", query.ColumnRule{Order: true, Type: query.TypeTimestamp})
+
+	return &JobRepository{db: db, validation: validation}
+}
+
+// GetValidation returns the query whitelist of jobs.
+func (r *JobRepository) GetValidation() *query.EntityValidation {
+	return r.validation
+}
+
+// jobColumns is the SELECT list matching scanJob
+const jobColumns = `id, user_id, type, status, progress, result, error, created_at, updated_at, started_at, completed_at`
+
+// Create inserts a pending job and sets its ID from RETURNING.
+// If job.ID is already set it is used as the primary key, so a job can share
+// the ID of the record it produces (e.g. an export or import).
+func (r *JobRepository) Create(ctx context.Context, job *models.Job) error {
+	query := `
+		INSERT INTO jobs (id, user_id, type, status)
+		VALUES (COALESCE(NULLIF($1, '')::uuid, gen_random_uuid()), $2, $3, $4)
+		RETURNING id, created_at, updated_at`
+
+	if job.Status == "" {
+		job.Status = models.JobStatusPending
+	}
+
+	err := r.db.QueryRowContext(ctx, query, job.ID, job.UserID, job.Type, job.Status).
+		Scan(&job.ID, &job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create job: %w", err)
+	}
+
+	return nil
+}
+
+// Start marks a job as running. A retried job is reset to 0% progress.
+func (r *JobRepository) Start(ctx context.Context, id string) error {
+	query := `
+		UPDATE jobs
+		SET status = $1, progress = 0, error = NULL, started_at = NOW(), updated_at = NOW()
+		WHERE id = $2`
+
+	return r.exec(ctx, "start", query, models.JobStatusRunning, id)
+}
+
+// UpdateProgress records the completion percentage (clamped to 0-100) of a running job.
+func (r *JobRepository) UpdateProgress(ctx context.Context, id string, progress int) error {
+	progress = min(max(progress, 0), 100)
+
+	query := `UPDATE jobs SET progress = $1, updated_at = NOW() WHERE id = $2`
+
+	return r.exec(ctx, "update progress of", query, progress, id)
+}
+
+// Complete marks a job as completed with an optional JSON result.
+func (r *JobRepository) Complete(ctx context.Context, id string, result []byte) error {
+	query := `
+		UPDATE jobs
+		SET status = $1, progress = 100, result = $2, updated_at = NOW(), completed_at = NOW()
+		WHERE id = $3`
+
+	var resultArg interface{}
+	if len(result) > 0 {
+		resultArg = string(result)
+	}
+
+	return r.exec(ctx, "complete", query, models.JobStatusCompleted, resultArg, id)
+}
+
+// Fail marks a job as failed with an error message.
+func (r *JobRepository) Fail(ctx context.Context, id string, errMsg string) error {
+	query := `
+		UPDATE jobs
+		SET status = $1, error = $2, updated_at = NOW(), completed_at = NOW()
+		WHERE id = $3`
+
+	return r.exec(ctx, "fail", query, models.JobStatusFailed, errMsg, id)
+}
+
+// GetByID fetches a job by UUID string.
+func (r *JobRepository) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	query := `SELECT ` + jobColumns + ` FROM jobs WHERE id = $1`
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("failed to get job: %w", err)
+		}
+		return nil, fmt.Errorf("job not found: %s", id)
+	}
+
+	return r.scanJob(rows)
+}
+
+// ListJobsWithQuery returns jobs using the dynamic filtering pattern with QueryOptions.
+// Callers scope the list to a user by setting Filter["user_id"].
+func (r *JobRepository) ListJobsWithQuery(ctx context.Context, opts *query.QueryOptions) (*query.Page[models.Job], error) {
+	return FindAndPaginate[models.Job](
+		ctx,
+		r.db,
+		"jobs",
+		opts,
+		r.scanJob,
+	)
+}
+
+// scanJob scans a single job row selected with jobColumns (or SELECT * from jobs)
+func (r *JobRepository) scanJob(rows *sql.Rows) (*models.Job, error) {
+	job := &models.Job{}
+	var result []byte
+	err := rows.Scan(
+		&job.ID,
+		&job.UserID,
+		&job.Type,
+		&job.Status,
+		&job.Progress,
+		&result,
+		&job.Error,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+		&job.StartedAt,
+		&job.CompletedAt,
+	)
+	if len(result) > 0 {
+		job.Result = result
+	}
+	return job, err
+}
+
+// exec runs an UPDATE against a single job and reports a missing row as an error
+func (r *JobRepository) exec(ctx context.Context, op string, query string, args ...interface{}) error {
+	id := args[len(args)-1]
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to %s job: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("job not found: %v", id)
+	}
+
+	return nil
+}
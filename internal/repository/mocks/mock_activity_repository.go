@@ -44,19 +44,64 @@ func (m *MockActivityRepositoryInterface) EXPECT() *MockActivityRepositoryInterf
 	return m.recorder
 }
 
+// BulkImport mocks base method.
+func (m *MockActivityRepositoryInterface) BulkImport(ctx context.Context, activities []*models.Activity, opts repository.BulkImportOptions) (*repository.BulkImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkImport", ctx, activities, opts)
+	ret0, _ := ret[0].(*repository.BulkImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkImport indicates an expected call of BulkImport.
+func (mr *MockActivityRepositoryInterfaceMockRecorder) BulkImport(ctx, activities, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkImport", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).BulkImport), ctx, activities, opts)
+}
+
+// ClaimClientMutation mocks base method.
+func (m *MockActivityRepositoryInterface) ClaimClientMutation(ctx context.Context, tx repository.TxConn, userID int, clientID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimClientMutation", ctx, tx, userID, clientID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimClientMutation indicates an expected call of ClaimClientMutation.
+func (mr *MockActivityRepositoryInterfaceMockRecorder) ClaimClientMutation(ctx, tx, userID, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimClientMutation", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).ClaimClientMutation), ctx, tx, userID, clientID)
+}
+
 // Count mocks base method.
-func (m *MockActivityRepositoryInterface) Count(userID int) (int, error) {
+func (m *MockActivityRepositoryInterface) Count(ctx context.Context, userID int) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Count", userID)
+	ret := m.ctrl.Call(m, "Count", ctx, userID)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Count indicates an expected call of Count.
-func (mr *MockActivityRepositoryInterfaceMockRecorder) Count(userID any) *gomock.Call {
+func (mr *MockActivityRepositoryInterfaceMockRecorder) Count(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).Count), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).Count), ctx, userID)
+}
+
+// CountAttachments mocks base method.
+func (m *MockActivityRepositoryInterface) CountAttachments(ctx context.Context, ids []int64) (map[int64]repository.ActivityAttachments, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAttachments", ctx, ids)
+	ret0, _ := ret[0].(map[int64]repository.ActivityAttachments)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAttachments indicates an expected call of CountAttachments.
+func (mr *MockActivityRepositoryInterfaceMockRecorder) CountAttachments(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAttachments", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).CountAttachments), ctx, ids)
 }
 
 // Create mocks base method.
@@ -101,6 +146,66 @@ func (mr *MockActivityRepositoryInterfaceMockRecorder) Delete(ctx, tx, id, userI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).Delete), ctx, tx, id, userID)
 }
 
+// DeleteByFilter mocks base method.
+func (m *MockActivityRepositoryInterface) DeleteByFilter(ctx context.Context, tx repository.TxConn, userID int, opts *query.QueryOptions, maxRows int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByFilter", ctx, tx, userID, opts, maxRows)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByFilter indicates an expected call of DeleteByFilter.
+func (mr *MockActivityRepositoryInterfaceMockRecorder) DeleteByFilter(ctx, tx, userID, opts, maxRows any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByFilter", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).DeleteByFilter), ctx, tx, userID, opts, maxRows)
+}
+
+// ExistingExternalIDs mocks base method.
+func (m *MockActivityRepositoryInterface) ExistingExternalIDs(ctx context.Context, userID int, source string, externalIDs []string) (map[string]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistingExternalIDs", ctx, userID, source, externalIDs)
+	ret0, _ := ret[0].(map[string]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistingExternalIDs indicates an expected call of ExistingExternalIDs.
+func (mr *MockActivityRepositoryInterfaceMockRecorder) ExistingExternalIDs(ctx, userID, source, externalIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistingExternalIDs", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).ExistingExternalIDs), ctx, userID, source, externalIDs)
+}
+
+// FindDuplicate mocks base method.
+func (m *MockActivityRepositoryInterface) FindDuplicate(ctx context.Context, tx repository.TxConn, activity *models.Activity, window time.Duration) (*models.Activity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDuplicate", ctx, tx, activity, window)
+	ret0, _ := ret[0].(*models.Activity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDuplicate indicates an expected call of FindDuplicate.
+func (mr *MockActivityRepositoryInterfaceMockRecorder) FindDuplicate(ctx, tx, activity, window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDuplicate", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).FindDuplicate), ctx, tx, activity, window)
+}
+
+// FindDuplicatePairs mocks base method.
+func (m *MockActivityRepositoryInterface) FindDuplicatePairs(ctx context.Context, userID int, criteria repository.DuplicateCriteria) ([]repository.DuplicatePair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDuplicatePairs", ctx, userID, criteria)
+	ret0, _ := ret[0].([]repository.DuplicatePair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDuplicatePairs indicates an expected call of FindDuplicatePairs.
+func (mr *MockActivityRepositoryInterfaceMockRecorder) FindDuplicatePairs(ctx, userID, criteria any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDuplicatePairs", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).FindDuplicatePairs), ctx, userID, criteria)
+}
+
 // GetByID mocks base method.
 func (m *MockActivityRepositoryInterface) GetByID(ctx context.Context, id int64) (*models.Activity, error) {
 	m.ctrl.T.Helper()
@@ -116,6 +221,21 @@ func (mr *MockActivityRepositoryInterfaceMockRecorder) GetByID(ctx, id any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).GetByID), ctx, id)
 }
 
+// GetCollectionVersion mocks base method.
+func (m *MockActivityRepositoryInterface) GetCollectionVersion(ctx context.Context, opts *query.QueryOptions) (*repository.CollectionVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollectionVersion", ctx, opts)
+	ret0, _ := ret[0].(*repository.CollectionVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollectionVersion indicates an expected call of GetCollectionVersion.
+func (mr *MockActivityRepositoryInterfaceMockRecorder) GetCollectionVersion(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectionVersion", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).GetCollectionVersion), ctx, opts)
+}
+
 // GetRegistry mocks base method.
 func (m *MockActivityRepositoryInterface) GetRegistry() *query.RelationshipRegistry {
 	m.ctrl.T.Helper()
@@ -130,26 +250,41 @@ func (mr *MockActivityRepositoryInterfaceMockRecorder) GetRegistry() *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegistry", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).GetRegistry))
 }
 
+// GetSplits mocks base method.
+func (m *MockActivityRepositoryInterface) GetSplits(ctx context.Context, activityID int64) ([]*models.ActivitySplit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSplits", ctx, activityID)
+	ret0, _ := ret[0].([]*models.ActivitySplit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSplits indicates an expected call of GetSplits.
+func (mr *MockActivityRepositoryInterfaceMockRecorder) GetSplits(ctx, activityID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSplits", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).GetSplits), ctx, activityID)
+}
+
 // GetStats mocks base method.
-func (m *MockActivityRepositoryInterface) GetStats(userID int, startDate, endDate *time.Time) (*repository.ActivityStats, error) {
+func (m *MockActivityRepositoryInterface) GetStats(ctx context.Context, userID int, startDate, endDate *time.Time) (*repository.ActivityStats, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetStats", userID, startDate, endDate)
+	ret := m.ctrl.Call(m, "GetStats", ctx, userID, startDate, endDate)
 	ret0, _ := ret[0].(*repository.ActivityStats)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetStats indicates an expected call of GetStats.
-func (mr *MockActivityRepositoryInterfaceMockRecorder) GetStats(userID, startDate, endDate any) *gomock.Call {
+func (mr *MockActivityRepositoryInterfaceMockRecorder) GetStats(ctx, userID, startDate, endDate any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).GetStats), userID, startDate, endDate)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).GetStats), ctx, userID, startDate, endDate)
 }
 
 // ListActivitiesWithQuery mocks base method.
-func (m *MockActivityRepositoryInterface) ListActivitiesWithQuery(ctx context.Context, opts *query.QueryOptions) (*query.PaginatedResult, error) {
+func (m *MockActivityRepositoryInterface) ListActivitiesWithQuery(ctx context.Context, opts *query.QueryOptions) (*query.Page[models.Activity], error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListActivitiesWithQuery", ctx, opts)
-	ret0, _ := ret[0].(*query.PaginatedResult)
+	ret0, _ := ret[0].(*query.Page[models.Activity])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -160,6 +295,21 @@ func (mr *MockActivityRepositoryInterfaceMockRecorder) ListActivitiesWithQuery(c
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActivitiesWithQuery", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).ListActivitiesWithQuery), ctx, opts)
 }
 
+// ListByIDs mocks base method.
+func (m *MockActivityRepositoryInterface) ListByIDs(ctx context.Context, userID int, ids []int64) ([]*models.Activity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByIDs", ctx, userID, ids)
+	ret0, _ := ret[0].([]*models.Activity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByIDs indicates an expected call of ListByIDs.
+func (mr *MockActivityRepositoryInterfaceMockRecorder) ListByIDs(ctx, userID, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByIDs", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).ListByIDs), ctx, userID, ids)
+}
+
 // ListByUser mocks base method.
 func (m *MockActivityRepositoryInterface) ListByUser(ctx context.Context, UserID int) ([]*models.Activity, error) {
 	m.ctrl.T.Helper()
@@ -175,6 +325,65 @@ func (mr *MockActivityRepositoryInterfaceMockRecorder) ListByUser(ctx, UserID an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).ListByUser), ctx, UserID)
 }
 
+// LoadIncludes mocks base method.
+func (m *MockActivityRepositoryInterface) LoadIncludes(ctx context.Context, ids []int64, includes []query.Include) (map[int64]map[string]interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadIncludes", ctx, ids, includes)
+	ret0, _ := ret[0].(map[int64]map[string]interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadIncludes indicates an expected call of LoadIncludes.
+func (mr *MockActivityRepositoryInterfaceMockRecorder) LoadIncludes(ctx, ids, includes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadIncludes", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).LoadIncludes), ctx, ids, includes)
+}
+
+// LockVersion mocks base method.
+func (m *MockActivityRepositoryInterface) LockVersion(ctx context.Context, tx repository.TxConn, id, userID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockVersion", ctx, tx, id, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockVersion indicates an expected call of LockVersion.
+func (mr *MockActivityRepositoryInterfaceMockRecorder) LockVersion(ctx, tx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockVersion", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).LockVersion), ctx, tx, id, userID)
+}
+
+// MergeInto mocks base method.
+func (m *MockActivityRepositoryInterface) MergeInto(ctx context.Context, tx repository.TxConn, userID int, keepID int64, mergeIDs []int64) (*repository.MergeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeInto", ctx, tx, userID, keepID, mergeIDs)
+	ret0, _ := ret[0].(*repository.MergeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeInto indicates an expected call of MergeInto.
+func (mr *MockActivityRepositoryInterfaceMockRecorder) MergeInto(ctx, tx, userID, keepID, mergeIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeInto", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).MergeInto), ctx, tx, userID, keepID, mergeIDs)
+}
+
+// RecordClientMutation mocks base method.
+func (m *MockActivityRepositoryInterface) RecordClientMutation(ctx context.Context, tx repository.TxConn, userID int, clientID string, activityID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordClientMutation", ctx, tx, userID, clientID, activityID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordClientMutation indicates an expected call of RecordClientMutation.
+func (mr *MockActivityRepositoryInterfaceMockRecorder) RecordClientMutation(ctx, tx, userID, clientID, activityID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordClientMutation", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).RecordClientMutation), ctx, tx, userID, clientID, activityID)
+}
+
 // Update mocks base method.
 func (m *MockActivityRepositoryInterface) Update(ctx context.Context, tx repository.TxConn, id int, activity *models.Activity) error {
 	m.ctrl.T.Helper()
@@ -188,3 +397,18 @@ func (mr *MockActivityRepositoryInterfaceMockRecorder) Update(ctx, tx, id, activ
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).Update), ctx, tx, id, activity)
 }
+
+// UpdateByFilter mocks base method.
+func (m *MockActivityRepositoryInterface) UpdateByFilter(ctx context.Context, tx repository.TxConn, userID int, opts *query.QueryOptions, changes map[string]interface{}, maxRows int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateByFilter", ctx, tx, userID, opts, changes, maxRows)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateByFilter indicates an expected call of UpdateByFilter.
+func (mr *MockActivityRepositoryInterfaceMockRecorder) UpdateByFilter(ctx, tx, userID, opts, changes, maxRows any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateByFilter", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).UpdateByFilter), ctx, tx, userID, opts, changes, maxRows)
+}
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	repository "github.com/valentinesamuel/activelog/internal/repository"
 	gomock "go.uber.org/mock/gomock"
@@ -56,6 +57,51 @@ func (mr *MockStatsRepositoryInterfaceMockRecorder) GetActivityCountByType(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivityCountByType", reflect.TypeOf((*MockStatsRepositoryInterface)(nil).GetActivityCountByType), ctx, userID)
 }
 
+// GetBestSplits mocks base method.
+func (m *MockStatsRepositoryInterface) GetBestSplits(ctx context.Context, userID int) (*repository.BestSplits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBestSplits", ctx, userID)
+	ret0, _ := ret[0].(*repository.BestSplits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBestSplits indicates an expected call of GetBestSplits.
+func (mr *MockStatsRepositoryInterfaceMockRecorder) GetBestSplits(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBestSplits", reflect.TypeOf((*MockStatsRepositoryInterface)(nil).GetBestSplits), ctx, userID)
+}
+
+// GetHeatmap mocks base method.
+func (m *MockStatsRepositoryInterface) GetHeatmap(ctx context.Context, userID, year int) ([]repository.HeatmapDay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeatmap", ctx, userID, year)
+	ret0, _ := ret[0].([]repository.HeatmapDay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeatmap indicates an expected call of GetHeatmap.
+func (mr *MockStatsRepositoryInterfaceMockRecorder) GetHeatmap(ctx, userID, year any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeatmap", reflect.TypeOf((*MockStatsRepositoryInterface)(nil).GetHeatmap), ctx, userID, year)
+}
+
+// GetMonthlyComparison mocks base method.
+func (m *MockStatsRepositoryInterface) GetMonthlyComparison(ctx context.Context, userID int) (*repository.StatsComparison, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMonthlyComparison", ctx, userID)
+	ret0, _ := ret[0].(*repository.StatsComparison)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMonthlyComparison indicates an expected call of GetMonthlyComparison.
+func (mr *MockStatsRepositoryInterfaceMockRecorder) GetMonthlyComparison(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMonthlyComparison", reflect.TypeOf((*MockStatsRepositoryInterface)(nil).GetMonthlyComparison), ctx, userID)
+}
+
 // GetMonthlyStats mocks base method.
 func (m *MockStatsRepositoryInterface) GetMonthlyStats(ctx context.Context, userID int) (*repository.MonthlyStats, error) {
 	m.ctrl.T.Helper()
@@ -71,6 +117,51 @@ func (mr *MockStatsRepositoryInterfaceMockRecorder) GetMonthlyStats(ctx, userID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMonthlyStats", reflect.TypeOf((*MockStatsRepositoryInterface)(nil).GetMonthlyStats), ctx, userID)
 }
 
+// GetRPEVsDuration mocks base method.
+func (m *MockStatsRepositoryInterface) GetRPEVsDuration(ctx context.Context, userID int, from, to time.Time) (*repository.RPEDurationCorrelation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRPEVsDuration", ctx, userID, from, to)
+	ret0, _ := ret[0].(*repository.RPEDurationCorrelation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRPEVsDuration indicates an expected call of GetRPEVsDuration.
+func (mr *MockStatsRepositoryInterfaceMockRecorder) GetRPEVsDuration(ctx, userID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRPEVsDuration", reflect.TypeOf((*MockStatsRepositoryInterface)(nil).GetRPEVsDuration), ctx, userID, from, to)
+}
+
+// GetSharedWeeklyStats mocks base method.
+func (m *MockStatsRepositoryInterface) GetSharedWeeklyStats(ctx context.Context, userID int) (*repository.WeeklyStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedWeeklyStats", ctx, userID)
+	ret0, _ := ret[0].(*repository.WeeklyStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedWeeklyStats indicates an expected call of GetSharedWeeklyStats.
+func (mr *MockStatsRepositoryInterfaceMockRecorder) GetSharedWeeklyStats(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedWeeklyStats", reflect.TypeOf((*MockStatsRepositoryInterface)(nil).GetSharedWeeklyStats), ctx, userID)
+}
+
+// GetTimeSeries mocks base method.
+func (m *MockStatsRepositoryInterface) GetTimeSeries(ctx context.Context, userID int, metric, interval string, from, to time.Time) ([]repository.TimeSeriesPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeSeries", ctx, userID, metric, interval, from, to)
+	ret0, _ := ret[0].([]repository.TimeSeriesPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimeSeries indicates an expected call of GetTimeSeries.
+func (mr *MockStatsRepositoryInterfaceMockRecorder) GetTimeSeries(ctx, userID, metric, interval, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeSeries", reflect.TypeOf((*MockStatsRepositoryInterface)(nil).GetTimeSeries), ctx, userID, metric, interval, from, to)
+}
+
 // GetTopTagsByUser mocks base method.
 func (m *MockStatsRepositoryInterface) GetTopTagsByUser(ctx context.Context, userID, limit int) ([]repository.TagUsage, error) {
 	m.ctrl.T.Helper()
@@ -86,6 +177,21 @@ func (mr *MockStatsRepositoryInterfaceMockRecorder) GetTopTagsByUser(ctx, userID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopTagsByUser", reflect.TypeOf((*MockStatsRepositoryInterface)(nil).GetTopTagsByUser), ctx, userID, limit)
 }
 
+// GetTrainingLoad mocks base method.
+func (m *MockStatsRepositoryInterface) GetTrainingLoad(ctx context.Context, userID int, from, to time.Time) ([]repository.TrainingLoadPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrainingLoad", ctx, userID, from, to)
+	ret0, _ := ret[0].([]repository.TrainingLoadPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrainingLoad indicates an expected call of GetTrainingLoad.
+func (mr *MockStatsRepositoryInterfaceMockRecorder) GetTrainingLoad(ctx, userID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrainingLoad", reflect.TypeOf((*MockStatsRepositoryInterface)(nil).GetTrainingLoad), ctx, userID, from, to)
+}
+
 // GetUserActivitySummary mocks base method.
 func (m *MockStatsRepositoryInterface) GetUserActivitySummary(ctx context.Context, userID int) (*repository.UserActivitySummary, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +207,21 @@ func (mr *MockStatsRepositoryInterfaceMockRecorder) GetUserActivitySummary(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserActivitySummary", reflect.TypeOf((*MockStatsRepositoryInterface)(nil).GetUserActivitySummary), ctx, userID)
 }
 
+// GetWeeklyComparison mocks base method.
+func (m *MockStatsRepositoryInterface) GetWeeklyComparison(ctx context.Context, userID int) (*repository.StatsComparison, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWeeklyComparison", ctx, userID)
+	ret0, _ := ret[0].(*repository.StatsComparison)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWeeklyComparison indicates an expected call of GetWeeklyComparison.
+func (mr *MockStatsRepositoryInterfaceMockRecorder) GetWeeklyComparison(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWeeklyComparison", reflect.TypeOf((*MockStatsRepositoryInterface)(nil).GetWeeklyComparison), ctx, userID)
+}
+
 // GetWeeklyStats mocks base method.
 func (m *MockStatsRepositoryInterface) GetWeeklyStats(ctx context.Context, userID int) (*repository.WeeklyStats, error) {
 	m.ctrl.T.Helper()
@@ -43,6 +43,21 @@ func (m *MockTagRepositoryInterface) EXPECT() *MockTagRepositoryInterfaceMockRec
 	return m.recorder
 }
 
+// GetCollectionVersion mocks base method.
+func (m *MockTagRepositoryInterface) GetCollectionVersion(ctx context.Context, opts *query.QueryOptions) (*repository.CollectionVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollectionVersion", ctx, opts)
+	ret0, _ := ret[0].(*repository.CollectionVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollectionVersion indicates an expected call of GetCollectionVersion.
+func (mr *MockTagRepositoryInterfaceMockRecorder) GetCollectionVersion(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectionVersion", reflect.TypeOf((*MockTagRepositoryInterface)(nil).GetCollectionVersion), ctx, opts)
+}
+
 // GetOrCreateTag mocks base method.
 func (m *MockTagRepositoryInterface) GetOrCreateTag(ctx context.Context, tx repository.TxConn, name string) (int, error) {
 	m.ctrl.T.Helper()
@@ -88,10 +103,10 @@ func (mr *MockTagRepositoryInterfaceMockRecorder) LinkActivityTag(ctx, tx, activ
 }
 
 // ListTagsWithQuery mocks base method.
-func (m *MockTagRepositoryInterface) ListTagsWithQuery(ctx context.Context, opts *query.QueryOptions) (*query.PaginatedResult, error) {
+func (m *MockTagRepositoryInterface) ListTagsWithQuery(ctx context.Context, opts *query.QueryOptions) (*query.Page[models.Tag], error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListTagsWithQuery", ctx, opts)
-	ret0, _ := ret[0].(*query.PaginatedResult)
+	ret0, _ := ret[0].(*query.Page[models.Tag])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/valentinesamuel/activelog/pkg/query"
+)
+
+// rawQuerier is implemented by connections that log hand-written SQL
+// separately from builder SQL (*database.LoggingDB)
+type rawQuerier interface {
+	QueryRawContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// RunNamedQuery runs a raw SQL template with :named parameters, for queries
+// the QueryBuilder can't express (CTEs, LATERAL joins). Use it instead of
+// db.QueryContext so the template gets the layer's checks (see
+// query.NamedTemplate): a single SELECT scoped by user_id = :user_id, every
+// parameter bound, and at most query.DefaultNamedQueryLimit rows unless the
+// template has its own LIMIT. On a *database.LoggingDB the query is timed and
+// logged as RAW QUERY.
+//
+// Example:
+//
+//	rows, err := RunNamedQuery(ctx, r.db, `
+//	    WITH weekly AS (
+//	        SELECT date_trunc('week', activity_date) AS week, SUM(distance_km) AS km
+//	        FROM activities WHERE user_id = :user_id GROUP BY 1
+//	    )
+//	    SELECT week, km, km - LAG(km) OVER (ORDER BY week) AS change FROM weekly`,
+//	    map[string]interface{}{"user_id": userID})
+func RunNamedQuery(ctx context.Context, db DBConn, sqlTemplate string, params map[string]interface{}) (*sql.Rows, error) {
+	tmpl, err := query.ParseNamed(sqlTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlQuery, args, err := tmpl.Bind(params, query.DefaultNamedQueryLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows *sql.Rows
+	if rq, ok := db.(rawQuerier); ok {
+		rows, err = rq.QueryRawContext(ctx, sqlQuery, args...)
+	} else {
+		rows, err = db.QueryContext(ctx, sqlQuery, args...)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute named query: %w", err)
+	}
+	return rows, nil
+}
+
+// FindNamed runs RunNamedQuery and scans every row with scanFunc, like
+// FindAndPaginate does for builder queries
+func FindNamed[T any](
+	ctx context.Context,
+	db DBConn,
+	sqlTemplate string,
+	params map[string]interface{},
+	scanFunc func(*sql.Rows) (*T, error),
+) ([]*T, error) {
+	rows, err := RunNamedQuery(ctx, db, sqlTemplate, params)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*T
+	for rows.Next() {
+		item, err := scanFunc(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		results = append(results, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	return results, nil
+}
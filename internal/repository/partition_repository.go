@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// activityPartitionLayout is the time layout of the month in partition names
+// (activities_p2024_01 holds January 2024)
+const activityPartitionLayout = "2006_01"
+
+// ActivityPartition is a monthly partition of the activities table covering [From, To)
+type ActivityPartition struct {
+	Name string
+	From time.Time
+	To   time.Time
+}
+
+// ActivityPartitionFor returns the monthly partition that holds activity dates in month t
+func ActivityPartitionFor(t time.Time) ActivityPartition {
+	from := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return ActivityPartition{
+		Name: "activities_p" + from.Format(activityPartitionLayout),
+		From: from,
+		To:   from.AddDate(0, 1, 0),
+	}
+}
+
+// parseActivityPartition parses a partition name; ok is false for names that
+// aren't monthly partitions (e.g. activities_default)
+func parseActivityPartition(name string) (ActivityPartition, bool) {
+	suffix, ok := strings.CutPrefix(name, "activities_p")
+	if !ok {
+		return ActivityPartition{}, false
+	}
+	month, err := time.Parse(activityPartitionLayout, suffix)
+	if err != nil {
+		return ActivityPartition{}, false
+	}
+	return ActivityPartitionFor(month), true
+}
+
+// PartitionRepository maintains the monthly partitions of the activities table.
+// Queries go through the parent table and are unaffected by partitioning.
+type PartitionRepository struct {
+	db DBConn
+}
+
+// NewPartitionRepository creates a new PartitionRepository
+func NewPartitionRepository(db DBConn) *PartitionRepository {
+	return &PartitionRepository{db: db}
+}
+
+// ListActivityPartitions returns the monthly partitions attached to activities, oldest first
+func (r *PartitionRepository) ListActivityPartitions(ctx context.Context) ([]ActivityPartition, error) {
+	query := `
+		SELECT child.relname
+		FROM pg_inherits
+		JOIN pg_class child ON child.oid = pg_inherits.inhrelid
+		WHERE pg_inherits.inhparent = 'activities'::regclass
+		ORDER BY child.relname`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list partitions: %w", err)
+	}
+	defer rows.Close()
+
+	var partitions []ActivityPartition
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan partition: %w", err)
+		}
+		if partition, ok := parseActivityPartition(name); ok {
+			partitions = append(partitions, partition)
+		}
+	}
+
+	return partitions, rows.Err()
+}
+
+// activityDefaultPartition catches activities outside every monthly
+// partition, e.g. dated further ahead than the partitions created so far
+const activityDefaultPartition = "activities_default"
+
+// CreateActivityPartition creates and attaches partition if it doesn't exist
+// yet. Postgres refuses to create a partition while the default partition
+// holds rows in its range, so those are moved into it: the default partition
+// is detached, the rows moved and the default reattached in one transaction.
+// Detaching locks activities until the transaction ends, so that only
+// happens when there are rows to move.
+func (r *PartitionRepository) CreateActivityPartition(ctx context.Context, partition ActivityPartition) error {
+	table := pgx.Identifier{partition.Name}.Sanitize()
+	from, to := partition.From.Format(time.DateOnly), partition.To.Format(time.DateOnly)
+
+	err := WithTransaction(ctx, r.db, func(tx TxConn) error {
+		var exists, stranded bool
+		err := tx.QueryRowContext(ctx, `
+			SELECT
+				to_regclass($1::text) IS NOT NULL,
+				EXISTS (SELECT 1 FROM `+activityDefaultPartition+`
+					WHERE activity_date >= $2::date AND activity_date < $3::date)`,
+			partition.Name, from, to).Scan(&exists, &stranded)
+		if err != nil {
+			return fmt.Errorf("failed to check partition: %w", err)
+		}
+		if exists {
+			return nil
+		}
+
+		if stranded {
+			// Detaching also drops the delete trigger cloned onto the default
+			// partition, so moving rows out of it keeps their tags and photos
+			if _, err := tx.ExecContext(ctx, "ALTER TABLE activities DETACH PARTITION "+activityDefaultPartition); err != nil {
+				return fmt.Errorf("failed to detach default partition: %w", err)
+			}
+		}
+
+		_, err = tx.ExecContext(ctx, fmt.Sprintf(
+			`CREATE TABLE %s PARTITION OF activities FOR VALUES FROM ('%s') TO ('%s')`, table, from, to))
+		if err != nil {
+			return fmt.Errorf("failed to create partition: %w", err)
+		}
+
+		if !stranded {
+			return nil
+		}
+		_, err = tx.ExecContext(ctx, `
+			WITH moved AS (
+				DELETE FROM `+activityDefaultPartition+`
+				WHERE activity_date >= $1::date AND activity_date < $2::date
+				RETURNING `+activityColumns+`
+			)
+			INSERT INTO `+table+` (`+activityColumns+`) SELECT `+activityColumns+` FROM moved`, from, to)
+		if err != nil {
+			return fmt.Errorf("failed to move activities from the default partition: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, "ALTER TABLE activities ATTACH PARTITION "+activityDefaultPartition+" DEFAULT"); err != nil {
+			return fmt.Errorf("failed to reattach default partition: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create partition %s: %w", partition.Name, err)
+	}
+
+	return nil
+}
+
+// ArchiveActivityPartition detaches partition, moves its rows into
+// activities_archive and drops it, all in one transaction. It returns the
+// number of activities archived.
+func (r *PartitionRepository) ArchiveActivityPartition(ctx context.Context, partition ActivityPartition) (int64, error) {
+	table := pgx.Identifier{partition.Name}.Sanitize()
+
+	var archived int64
+	err := WithTransaction(ctx, r.db, func(tx TxConn) error {
+		if _, err := tx.ExecContext(ctx, "ALTER TABLE activities DETACH PARTITION "+table); err != nil {
+			return fmt.Errorf("failed to detach partition: %w", err)
+		}
+
+		// Columns are named because the archive's were added in a different order
+		result, err := tx.ExecContext(ctx,
+			"INSERT INTO activities_archive ("+activityColumns+") SELECT "+activityColumns+" FROM "+table)
+		if err != nil {
+			return fmt.Errorf("failed to archive activities: %w", err)
+		}
+		if archived, err = result.RowsAffected(); err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, "DROP TABLE "+table); err != nil {
+			return fmt.Errorf("failed to drop partition: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to archive partition %s: %w", partition.Name, err)
+	}
+
+	return archived, nil
+}
@@ -0,0 +1,118 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/repository/testhelpers"
+)
+
+func TestPartitionRepository_CreateActivityPartition(t *testing.T) {
+	june := repository.ActivityPartitionFor(time.Date(2027, time.June, 1, 0, 0, 0, 0, time.UTC))
+	checkRows := []string{"exists", "stranded"}
+
+	t.Run("existing partition", func(t *testing.T) {
+		db, mock := testhelpers.SetupMockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`to_regclass\(\$1::text\) IS NOT NULL`).
+			WithArgs("activities_p2027_06", "2027-06-01", "2027-07-01").
+			WillReturnRows(sqlmock.NewRows(checkRows).AddRow(true, false))
+		mock.ExpectCommit()
+
+		require.NoError(t, repository.NewPartitionRepository(db).CreateActivityPartition(context.Background(), june))
+	})
+
+	t.Run("empty default partition", func(t *testing.T) {
+		db, mock := testhelpers.SetupMockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`FROM activities_default\s+WHERE activity_date >= \$2::date AND activity_date < \$3::date`).
+			WithArgs("activities_p2027_06", "2027-06-01", "2027-07-01").
+			WillReturnRows(sqlmock.NewRows(checkRows).AddRow(false, false))
+		mock.ExpectExec(`CREATE TABLE "activities_p2027_06" PARTITION OF activities FOR VALUES FROM \('2027-06-01'\) TO \('2027-07-01'\)`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		require.NoError(t, repository.NewPartitionRepository(db).CreateActivityPartition(context.Background(), june))
+	})
+
+	t.Run("moves activities out of the default partition", func(t *testing.T) {
+		db, mock := testhelpers.SetupMockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`to_regclass`).
+			WillReturnRows(sqlmock.NewRows(checkRows).AddRow(false, true))
+		mock.ExpectExec(`ALTER TABLE activities DETACH PARTITION activities_default`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`CREATE TABLE "activities_p2027_06" PARTITION OF activities`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`DELETE FROM activities_default\s+WHERE activity_date >= \$1::date AND activity_date < \$2::date.*INSERT INTO "activities_p2027_06"`).
+			WithArgs("2027-06-01", "2027-07-01").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(`ALTER TABLE activities ATTACH PARTITION activities_default DEFAULT`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		require.NoError(t, repository.NewPartitionRepository(db).CreateActivityPartition(context.Background(), june))
+	})
+}
+
+func TestPartitionRepository_CreateActivityPartition_FutureActivity(t *testing.T) {
+	db, cleanup := testhelpers.SetupIntegrationDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	activities := repository.NewActivityRepository(db, repository.NewTagRepository(db))
+	userID := createTestUser(t, db, "planner")
+
+	// Further ahead than the partitions created by the migration
+	date := time.Now().UTC().AddDate(2, 0, 0)
+	activity := &models.Activity{
+		UserID:          userID,
+		ActivityType:    "running",
+		Title:           "Race day",
+		DurationMinutes: 180,
+		DistanceKm:      42.2,
+		ActivityDate:    date,
+	}
+	require.NoError(t, activities.Create(ctx, nil, activity))
+
+	partitionOf := func() string {
+		t.Helper()
+		var name string
+		err := db.QueryRowContext(ctx, `SELECT tableoid::regclass::text FROM activities WHERE id = $1`, activity.ID).Scan(&name)
+		require.NoError(t, err)
+		return name
+	}
+	require.Equal(t, "activities_default", partitionOf())
+
+	partition := repository.ActivityPartitionFor(date)
+	partitions := repository.NewPartitionRepository(db)
+	require.NoError(t, partitions.CreateActivityPartition(ctx, partition))
+
+	assert.Equal(t, partition.Name, partitionOf())
+	moved, err := activities.GetByID(ctx, activity.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Race day", moved.Title)
+
+	t.Run("the default partition is attached again", func(t *testing.T) {
+		later := &models.Activity{
+			UserID:          userID,
+			ActivityType:    "running",
+			Title:           "Later race",
+			DurationMinutes: 180,
+			DistanceKm:      42.2,
+			ActivityDate:    date.AddDate(1, 0, 0),
+		}
+		require.NoError(t, activities.Create(ctx, nil, later))
+	})
+
+	t.Run("creating it again does nothing", func(t *testing.T) {
+		require.NoError(t, partitions.CreateActivityPartition(ctx, partition))
+	})
+}
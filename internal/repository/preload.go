@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/valentinesamuel/activelog/pkg/query"
+)
+
+// Preload batch-loads includes for the rows of registry's table with ids, one
+// query per relation and level. The result maps each id to its relations: a
+// list of rows for to-many relations, a row or nil for to-one relations. Rows
+// are column → value maps, with nested includes ("tags.parent") attached to
+// them the same way.
+func Preload(ctx context.Context, db DBConn, registry *query.RelationshipRegistry, ids []int64, includes []query.Include) (map[int64]map[string]interface{}, error) {
+	result := make(map[int64]map[string]interface{}, len(ids))
+	for _, id := range ids {
+		result[id] = make(map[string]interface{})
+	}
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	for _, include := range includes {
+		if strings.Contains(include.Path, ".") {
+			continue
+		}
+		name := include.Path
+
+		rows, err := preloadRows(ctx, db, registry, name, include.Columns, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		// Nested includes load from the related table, keyed by the related rows
+		nested := nestedIncludes(includes, name)
+		if len(nested) > 0 {
+			target, ok := registry.TargetRegistry(name)
+			if !ok {
+				return nil, fmt.Errorf("%w: '%s' has no registry to preload from", query.ErrInvalidInclude, name)
+			}
+			childIDs := make([]int64, 0, len(rows))
+			for _, row := range rows {
+				childIDs = append(childIDs, row.id)
+			}
+			children, err := Preload(ctx, db, target, childIDs, nested)
+			if err != nil {
+				return nil, err
+			}
+			for _, row := range rows {
+				for relation, value := range children[row.id] {
+					row.values[relation] = value
+				}
+			}
+		}
+
+		toOne := registry.ToOne(name)
+		for _, id := range ids {
+			if toOne {
+				result[id][name] = nil
+			} else {
+				result[id][name] = []map[string]interface{}{}
+			}
+		}
+		for _, row := range rows {
+			if toOne {
+				result[row.parentID][name] = row.values
+			} else {
+				result[row.parentID][name] = append(result[row.parentID][name].([]map[string]interface{}), row.values)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// preloadedRow is a related row with the ids it is keyed by
+type preloadedRow struct {
+	parentID int64
+	id       int64
+	values   map[string]interface{}
+}
+
+// preloadRows runs the preload query of relationship name
+func preloadRows(ctx context.Context, db DBConn, registry *query.RelationshipRegistry, name string, columns []string, ids []int64) ([]preloadedRow, error) {
+	sqlQuery, args, err := registry.PreloadQuery(name, columns, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("preload %s: %w", name, err)
+	}
+	defer rows.Close()
+
+	names, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("preload %s: %w", name, err)
+	}
+	keepID := slices.Contains(columns, "id")
+
+	var result []preloadedRow
+	for rows.Next() {
+		values := make([]interface{}, len(names))
+		pointers := make([]interface{}, len(names))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, fmt.Errorf("preload %s: %w", name, err)
+		}
+
+		row := preloadedRow{values: make(map[string]interface{}, len(names))}
+		for i, column := range names {
+			value := values[i]
+			if b, ok := value.([]byte); ok {
+				value = string(b)
+			}
+			switch column {
+			case query.PreloadKey:
+				row.parentID = toInt64(value)
+			case "id":
+				row.id = toInt64(value)
+				if keepID {
+					row.values[column] = value
+				}
+			default:
+				row.values[column] = value
+			}
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("preload %s: %w", name, err)
+	}
+	return result, nil
+}
+
+// nestedIncludes returns the includes under name with the name stripped,
+// e.g. "tags.parent" → "parent" for "tags"
+func nestedIncludes(includes []query.Include, name string) []query.Include {
+	var nested []query.Include
+	for _, include := range includes {
+		if rest, ok := strings.CutPrefix(include.Path, name+"."); ok {
+			nested = append(nested, query.Include{Path: rest, Columns: include.Columns})
+		}
+	}
+	return nested
+}
+
+func toInt64(value interface{}) int64 {
+	switch v := value.(type) {
+	case int64:
+		return v
+	case int32:
+		return int64(v)
+	case int:
+		return int64(v)
+	}
+	return 0
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	stdErrors "errors"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/pkg/dberr"
+	"github.com/valentinesamuel/activelog/pkg/errors"
+)
+
+// ErrPrivacyZoneLimit is returned when a user who has the maximum number of
+// privacy zones adds another
+var ErrPrivacyZoneLimit = stdErrors.New("privacy zone limit reached")
+
+// PrivacyZoneRepository handles database operations for privacy zones, the
+// places whose exact position a user keeps to themselves
+type PrivacyZoneRepository struct {
+	db DBConn
+}
+
+// NewPrivacyZoneRepository creates a new PrivacyZoneRepository
+func NewPrivacyZoneRepository(db DBConn) *PrivacyZoneRepository {
+	return &PrivacyZoneRepository{db: db}
+}
+
+const privacyZoneColumns = `id, user_id, name, lat, lng, radius_m, created_at, updated_at`
+
+// ListByUser returns the user's privacy zones, oldest first
+func (r *PrivacyZoneRepository) ListByUser(ctx context.Context, userID int) ([]*models.PrivacyZone, error) {
+	query := `SELECT ` + privacyZoneColumns + ` FROM privacy_zones WHERE user_id = $1 ORDER BY id`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "privacy_zones", Err: err}
+	}
+	defer rows.Close()
+
+	zones := []*models.PrivacyZone{}
+	for rows.Next() {
+		zone := &models.PrivacyZone{}
+		if err := rows.Scan(privacyZoneScanDest(zone)...); err != nil {
+			return nil, &errors.DatabaseError{Op: "SCAN", Table: "privacy_zones", Err: err}
+		}
+		zones = append(zones, zone)
+	}
+	return zones, rows.Err()
+}
+
+// GetByID returns one of the user's privacy zones, or ErrNotFound
+func (r *PrivacyZoneRepository) GetByID(ctx context.Context, userID int, id int64) (*models.PrivacyZone, error) {
+	query := `SELECT ` + privacyZoneColumns + ` FROM privacy_zones WHERE id = $1 AND user_id = $2`
+
+	zone := &models.PrivacyZone{}
+	err := r.db.QueryRowContext(ctx, query, id, userID).Scan(privacyZoneScanDest(zone)...)
+	if err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{Op: "SELECT", Table: "privacy_zones", Err: err})
+	}
+	return zone, nil
+}
+
+// Create adds a privacy zone unless its user already has maxZones of them,
+// in which case it returns ErrPrivacyZoneLimit
+func (r *PrivacyZoneRepository) Create(ctx context.Context, zone *models.PrivacyZone, maxZones int) error {
+	query := `
+		INSERT INTO privacy_zones (user_id, name, lat, lng, radius_m)
+		SELECT $1, $2, $3, $4, $5
+		WHERE (SELECT COUNT(*) FROM privacy_zones WHERE user_id = $1) < $6
+		RETURNING id, created_at, updated_at`
+
+	return WithTransaction(ctx, r.db, func(tx TxConn) error {
+		// Two creates counting at once could both see room for one more zone;
+		// the lock, held until commit, has the user's creates count in turn
+		if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext('privacy_zones'), $1)`, zone.UserID); err != nil {
+			return &errors.DatabaseError{Op: "LOCK", Table: "privacy_zones", Err: err}
+		}
+
+		err := tx.QueryRowContext(ctx, query, zone.UserID, zone.Name, zone.Lat, zone.Lng, zone.RadiusM, maxZones).
+			Scan(&zone.ID, &zone.CreatedAt, &zone.UpdatedAt)
+		if err == sql.ErrNoRows {
+			return ErrPrivacyZoneLimit
+		}
+		if err != nil {
+			return dberr.Translate(&errors.DatabaseError{Op: "INSERT", Table: "privacy_zones", Err: err})
+		}
+		return nil
+	})
+}
+
+// Update saves the name, center and radius of one of the user's privacy
+// zones, or returns ErrNotFound
+func (r *PrivacyZoneRepository) Update(ctx context.Context, zone *models.PrivacyZone) error {
+	query := `
+		UPDATE privacy_zones
+		SET name = $3, lat = $4, lng = $5, radius_m = $6, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_id = $2
+		RETURNING updated_at`
+
+	err := r.db.QueryRowContext(ctx, query, zone.ID, zone.UserID, zone.Name, zone.Lat, zone.Lng, zone.RadiusM).
+		Scan(&zone.UpdatedAt)
+	if err != nil {
+		return dberr.Translate(&errors.DatabaseError{Op: "UPDATE", Table: "privacy_zones", Err: err})
+	}
+	return nil
+}
+
+// Delete removes one of the user's privacy zones, or returns ErrNotFound
+func (r *PrivacyZoneRepository) Delete(ctx context.Context, userID int, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM privacy_zones WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return &errors.DatabaseError{Op: "DELETE", Table: "privacy_zones", Err: err}
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// privacyZoneScanDest returns the scan destinations for privacyZoneColumns
+func privacyZoneScanDest(zone *models.PrivacyZone) []interface{} {
+	return []interface{}{
+		&zone.ID,
+		&zone.UserID,
+		&zone.Name,
+		&zone.Lat,
+		&zone.Lng,
+		&zone.RadiusM,
+		&zone.CreatedAt,
+		&zone.UpdatedAt,
+	}
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository/testhelpers"
+	"github.com/valentinesamuel/activelog/pkg/dberr"
+)
+
+func TestPrivacyZoneRepository_Create(t *testing.T) {
+	now := time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC)
+
+	t.Run("locks the user's zones before counting them", func(t *testing.T) {
+		db, mock := testhelpers.SetupMockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(`SELECT pg_advisory_xact_lock\(hashtext\('privacy_zones'\), \$1\)`).
+			WithArgs(7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`INSERT INTO privacy_zones .* WHERE \(SELECT COUNT\(\*\) FROM privacy_zones WHERE user_id = \$1\) < \$6`).
+			WithArgs(7, "Home", 52.52, 13.405, 300, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(3, now, now))
+		mock.ExpectCommit()
+
+		zone := &models.PrivacyZone{UserID: 7, Name: "Home", Lat: 52.52, Lng: 13.405, RadiusM: 300}
+		require.NoError(t, NewPrivacyZoneRepository(db).Create(context.Background(), zone, 10))
+		assert.Equal(t, int64(3), zone.ID)
+		assert.Equal(t, now, zone.CreatedAt)
+	})
+
+	t.Run("limit reached", func(t *testing.T) {
+		db, mock := testhelpers.SetupMockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(`pg_advisory_xact_lock`).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`INSERT INTO privacy_zones`).
+			WithArgs(7, "Work", 52.5, 13.4, 500, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}))
+		mock.ExpectRollback()
+
+		zone := &models.PrivacyZone{UserID: 7, Name: "Work", Lat: 52.5, Lng: 13.4, RadiusM: 500}
+		err := NewPrivacyZoneRepository(db).Create(context.Background(), zone, 2)
+		assert.ErrorIs(t, err, ErrPrivacyZoneLimit)
+		assert.Zero(t, zone.ID)
+	})
+
+	t.Run("lock fails", func(t *testing.T) {
+		db, mock := testhelpers.SetupMockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(`pg_advisory_xact_lock`).WithArgs(7).WillReturnError(errors.New("connection reset"))
+		mock.ExpectRollback()
+
+		zone := &models.PrivacyZone{UserID: 7, Name: "Home", RadiusM: 300}
+		err := NewPrivacyZoneRepository(db).Create(context.Background(), zone, 10)
+		assert.ErrorContains(t, err, "connection reset")
+		assert.NotErrorIs(t, err, ErrPrivacyZoneLimit)
+	})
+}
+
+func TestPrivacyZoneRepository_Update(t *testing.T) {
+	updated := time.Date(2026, 5, 2, 9, 0, 0, 0, time.UTC)
+
+	t.Run("own zone", func(t *testing.T) {
+		db, mock := testhelpers.SetupMockDB(t)
+		mock.ExpectQuery(`UPDATE privacy_zones .* WHERE id = \$1 AND user_id = \$2`).
+			WithArgs(int64(3), 7, "Home", 52.52, 13.405, 800).
+			WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(updated))
+
+		zone := &models.PrivacyZone{ID: 3, UserID: 7, Name: "Home", Lat: 52.52, Lng: 13.405, RadiusM: 800}
+		require.NoError(t, NewPrivacyZoneRepository(db).Update(context.Background(), zone))
+		assert.Equal(t, updated, zone.UpdatedAt)
+	})
+
+	t.Run("another user's zone", func(t *testing.T) {
+		db, mock := testhelpers.SetupMockDB(t)
+		mock.ExpectQuery(`UPDATE privacy_zones .* WHERE id = \$1 AND user_id = \$2`).
+			WithArgs(int64(3), 8, "Mine now", 1.0, 2.0, 100).
+			WillReturnRows(sqlmock.NewRows([]string{"updated_at"}))
+
+		zone := &models.PrivacyZone{ID: 3, UserID: 8, Name: "Mine now", Lat: 1, Lng: 2, RadiusM: 100}
+		err := NewPrivacyZoneRepository(db).Update(context.Background(), zone)
+		assert.ErrorIs(t, err, dberr.ErrNotFound)
+	})
+}
+
+func TestPrivacyZoneRepository_GetByID_OtherUser(t *testing.T) {
+	db, mock := testhelpers.SetupMockDB(t)
+	mock.ExpectQuery(`FROM privacy_zones WHERE id = \$1 AND user_id = \$2`).
+		WithArgs(int64(3), 8).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err := NewPrivacyZoneRepository(db).GetByID(context.Background(), 8, 3)
+	assert.ErrorIs(t, err, dberr.ErrNotFound)
+}
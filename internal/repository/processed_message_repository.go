@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/valentinesamuel/activelog/pkg/query"
+)
+
+// ProcessedMessageRepository records which queue messages have been handled,
+// letting job handlers ignore redelivered messages.
+type ProcessedMessageRepository struct {
+	db DBConn
+}
+
+// NewProcessedMessageRepository creates a new ProcessedMessageRepository.
+func NewProcessedMessageRepository(db DBConn) *ProcessedMessageRepository {
+	return &ProcessedMessageRepository{db: db}
+}
+
+// Claim records messageID as processed. It returns false if the message had
+// already been claimed, by this worker or another one.
+func (r *ProcessedMessageRepository) Claim(ctx context.Context, messageID string, event string) (bool, error) {
+	stmt, args, err := query.NewInsertBuilder("processed_messages", "message_id", "event").
+		Values(messageID, event).
+		OnConflict("message_id").DoNothing().
+		Build()
+	if err != nil {
+		return false, err
+	}
+
+	result, err := r.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim message: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to check rows affected: %w", err)
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// Release removes a claim so a message whose handler failed can be retried.
+func (r *ProcessedMessageRepository) Release(ctx context.Context, messageID string) error {
+	query := `DELETE FROM processed_messages WHERE message_id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, messageID); err != nil {
+		return fmt.Errorf("failed to release message: %w", err)
+	}
+
+	return nil
+}
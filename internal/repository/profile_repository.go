@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/pkg/dberr"
+	"github.com/valentinesamuel/activelog/pkg/errors"
+)
+
+// ProfileRepository reads and updates the profile fields and preferences of active users
+type ProfileRepository struct {
+	db DBConn
+}
+
+// NewProfileRepository creates a new ProfileRepository
+func NewProfileRepository(db DBConn) *ProfileRepository {
+	return &ProfileRepository{db: db}
+}
+
+const profileColumns = `id, email, username, display_name, bio, avatar_key, timezone, weight_kg, preferences, created_at, updated_at`
+
+// GetProfile returns the user's profile, or ErrNotFound if the account is
+// missing or disabled
+func (r *ProfileRepository) GetProfile(ctx context.Context, userID int) (*models.UserProfile, error) {
+	query := `SELECT ` + profileColumns + ` FROM users WHERE id = $1 AND deleted_at IS NULL`
+	return r.scanProfile(r.db.QueryRowContext(ctx, query, userID), "SELECT")
+}
+
+// UpdateProfile applies the fields set in req and returns the updated profile.
+// Preferences are merged key by key into the stored document.
+func (r *ProfileRepository) UpdateProfile(ctx context.Context, userID int, req *models.UpdateProfileRequest) (*models.UserProfile, error) {
+	prefs := []byte("{}")
+	if req.Preferences != nil {
+		var err error
+		if prefs, err = json.Marshal(req.Preferences); err != nil {
+			return nil, fmt.Errorf("failed to encode preferences: %w", err)
+		}
+	}
+
+	query := `
+		UPDATE users
+		SET display_name = COALESCE($2, display_name),
+			bio = COALESCE($3, bio),
+			timezone = COALESCE($4, timezone),
+			preferences = preferences || $5::jsonb,
+			weight_kg = COALESCE($6, weight_kg),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING ` + profileColumns
+
+	row := r.db.QueryRowContext(ctx, query, userID, req.DisplayName, req.Bio, req.Timezone, string(prefs), req.WeightKg)
+	return r.scanProfile(row, "UPDATE")
+}
+
+// SetAvatar points the user's avatar at key (nil removes it) and returns the
+// previous key so the caller can delete the old object
+func (r *ProfileRepository) SetAvatar(ctx context.Context, userID int, key *string) (*string, error) {
+	query := `
+		UPDATE users u
+		SET avatar_key = $2, updated_at = CURRENT_TIMESTAMP
+		FROM (SELECT avatar_key FROM users WHERE id = $1 FOR UPDATE) old
+		WHERE u.id = $1 AND u.deleted_at IS NULL
+		RETURNING old.avatar_key`
+
+	var previous sql.NullString
+	if err := r.db.QueryRowContext(ctx, query, userID, key).Scan(&previous); err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{Op: "UPDATE", Table: "users", Err: err})
+	}
+	if !previous.Valid {
+		return nil, nil
+	}
+	return &previous.String, nil
+}
+
+// GetWeightKg returns the user's recorded body weight, or nil if none is set
+func (r *ProfileRepository) GetWeightKg(ctx context.Context, userID int) (*float64, error) {
+	var weight sql.NullFloat64
+	err := r.db.QueryRowContext(ctx, `SELECT weight_kg FROM users WHERE id = $1`, userID).Scan(&weight)
+	if err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{Op: "SELECT", Table: "users", Err: err})
+	}
+	if !weight.Valid {
+		return nil, nil
+	}
+	return &weight.Float64, nil
+}
+
+// GetHeartRateZones returns the user's heart-rate zones, derived from their
+// maximum heart rate (or DefaultMaxHeartRate) unless custom zones are set
+func (r *ProfileRepository) GetHeartRateZones(ctx context.Context, userID int) (*models.HeartRateZones, error) {
+	query := `SELECT max_heart_rate, heart_rate_zones FROM users WHERE id = $1 AND deleted_at IS NULL`
+	return r.scanHeartRateZones(r.db.QueryRowContext(ctx, query, userID), "SELECT")
+}
+
+// SetHeartRateZones stores the user's maximum heart rate and custom zones.
+// A nil maxHeartRate keeps the stored one; nil zones clear the custom zones.
+func (r *ProfileRepository) SetHeartRateZones(ctx context.Context, userID int, maxHeartRate *int, zones []int) (*models.HeartRateZones, error) {
+	query := `
+		UPDATE users
+		SET max_heart_rate = COALESCE($2, max_heart_rate),
+			heart_rate_zones = $3,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING max_heart_rate, heart_rate_zones`
+
+	row := r.db.QueryRowContext(ctx, query, userID, maxHeartRate, zones)
+	return r.scanHeartRateZones(row, "UPDATE")
+}
+
+// GetDefaultVisibility returns the visibility the user's new activities get
+// (the default_activity_visibility preference, or its default)
+func (r *ProfileRepository) GetDefaultVisibility(ctx context.Context, userID int) (string, error) {
+	var visibility sql.NullString
+	err := r.db.QueryRowContext(ctx,
+		`SELECT preferences->>'default_activity_visibility' FROM users WHERE id = $1`, userID).Scan(&visibility)
+	if err != nil {
+		return "", dberr.Translate(&errors.DatabaseError{Op: "SELECT", Table: "users", Err: err})
+	}
+	prefs := models.UserPreferences{DefaultActivityVisibility: visibility.String}.WithDefaults()
+	return prefs.DefaultActivityVisibility, nil
+}
+
+func (r *ProfileRepository) scanHeartRateZones(row *sql.Row, op string) (*models.HeartRateZones, error) {
+	var (
+		maxHeartRate sql.NullInt64
+		zones        []int
+	)
+	if err := row.Scan(&maxHeartRate, pgTypes.SQLScanner(&zones)); err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{Op: op, Table: "users", Err: err})
+	}
+
+	result := &models.HeartRateZones{MaxHeartRate: models.DefaultMaxHeartRate}
+	if maxHeartRate.Valid {
+		result.MaxHeartRate = int(maxHeartRate.Int64)
+	}
+	if len(zones) > 0 {
+		result.Zones = zones
+		result.Custom = true
+	} else {
+		result.Zones = models.DeriveHeartRateZones(result.MaxHeartRate)
+	}
+	return result, nil
+}
+
+func (r *ProfileRepository) scanProfile(row *sql.Row, op string) (*models.UserProfile, error) {
+	var (
+		profile models.UserProfile
+		prefs   []byte
+	)
+	err := row.Scan(
+		&profile.ID, &profile.Email, &profile.Username,
+		&profile.DisplayName, &profile.Bio, &profile.AvatarKey,
+		&profile.Timezone, &profile.WeightKg, &prefs, &profile.CreatedAt, &profile.UpdatedAt,
+	)
+	if err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{Op: op, Table: "users", Err: err})
+	}
+
+	if err := json.Unmarshal(prefs, &profile.Preferences); err != nil {
+		return nil, fmt.Errorf("failed to decode preferences: %w", err)
+	}
+	profile.Preferences = profile.Preferences.WithDefaults()
+
+	return &profile, nil
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/pkg/dberr"
+	"github.com/valentinesamuel/activelog/pkg/errors"
+)
+
+// ReactionRepository handles reactions (kudos) on activities
+type ReactionRepository struct {
+	db DBConn
+}
+
+// NewReactionRepository creates a new ReactionRepository
+func NewReactionRepository(db DBConn) *ReactionRepository {
+	return &ReactionRepository{db: db}
+}
+
+// reactableActivity selects the live activity $2 if user $1 may react to it:
+// it is their own, it is public, or it is for followers and its owner shares
+// a group with them or is coached by them
+const reactableActivity = `
+	SELECT a.id, a.user_id FROM activities a
+	WHERE a.id = $2 AND a.deleted_at IS NULL
+		AND (a.user_id = $1
+			OR a.visibility = 'public'
+			OR (a.visibility = 'followers' AND (
+				EXISTS (
+					SELECT 1 FROM group_members me
+					JOIN group_members owner ON owner.group_id = me.group_id
+					WHERE me.user_id = $1 AND owner.user_id = a.user_id)
+				OR EXISTS (
+					SELECT 1 FROM coach_access ca
+					WHERE ca.athlete_id = a.user_id AND ca.coach_id = $1 AND ca.status = 'active'))))`
+
+// React sets userID's reaction to activityID, replacing any earlier one.
+// It returns the activity owner's ID and whether the reaction is new, or
+// ErrNotFound if the activity is missing or not visible to the user.
+func (r *ReactionRepository) React(ctx context.Context, reaction *models.ActivityReaction) (ownerID int, created bool, err error) {
+	query := `
+		WITH target AS (` + reactableActivity + `),
+		upserted AS (
+			INSERT INTO activity_reactions (user_id, activity_id, reaction)
+			SELECT $1, id, $3 FROM target
+			ON CONFLICT (user_id, activity_id)
+			DO UPDATE SET reaction = EXCLUDED.reaction, created_at = CURRENT_TIMESTAMP
+			RETURNING id, created_at, xmax = 0 AS created
+		)
+		SELECT upserted.id, upserted.created_at, upserted.created, target.user_id
+		FROM upserted, target`
+
+	err = r.db.QueryRowContext(ctx, query, reaction.UserID, reaction.ActivityID, reaction.Reaction).
+		Scan(&reaction.ID, &reaction.CreatedAt, &created, &ownerID)
+	if err != nil {
+		return 0, false, dberr.Translate(&errors.DatabaseError{Op: "INSERT", Table: "activity_reactions", Err: err})
+	}
+
+	return ownerID, created, nil
+}
+
+// Remove deletes userID's reaction to activityID, returning ErrNotFound if there is none
+func (r *ReactionRepository) Remove(ctx context.Context, userID int, activityID int64) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM activity_reactions WHERE user_id = $1 AND activity_id = $2`, userID, activityID)
+	if err != nil {
+		return &errors.DatabaseError{Op: "DELETE", Table: "activity_reactions", Err: err}
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+// CountsByActivity returns the reaction counts of each of activityIDs by type.
+// Activities without reactions are absent from the map.
+func (r *ReactionRepository) CountsByActivity(ctx context.Context, activityIDs []int64) (map[int64]map[string]int, error) {
+	counts := make(map[int64]map[string]int)
+	if len(activityIDs) == 0 {
+		return counts, nil
+	}
+
+	query := `
+		SELECT activity_id, reaction, COUNT(*)
+		FROM activity_reactions
+		WHERE activity_id = ANY($1)
+		GROUP BY activity_id, reaction`
+
+	rows, err := r.db.QueryContext(ctx, query, activityIDs)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "activity_reactions", Err: err}
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			activityID int64
+			reaction   string
+			count      int
+		)
+		if err := rows.Scan(&activityID, &reaction, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan reaction count: %w", err)
+		}
+		if counts[activityID] == nil {
+			counts[activityID] = make(map[string]int)
+		}
+		counts[activityID][reaction] = count
+	}
+
+	return counts, rows.Err()
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/pkg/errors"
+)
+
+// SagaRepository saves the progress of sagas (see broker.RunSaga)
+type SagaRepository struct {
+	db DBConn
+}
+
+// NewSagaRepository creates a new SagaRepository
+func NewSagaRepository(db DBConn) *SagaRepository {
+	return &SagaRepository{db: db}
+}
+
+// LoadSaga returns the saved progress of saga id, or nil if it never ran
+func (r *SagaRepository) LoadSaga(ctx context.Context, id string) (*models.Saga, error) {
+	query := `
+		SELECT id, status, completed_steps, total_steps, COALESCE(current_step, ''),
+			COALESCE(last_error, ''), created_at, updated_at
+		FROM sagas WHERE id = $1`
+
+	saga := &models.Saga{}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&saga.ID, &saga.Status, pgTypes.SQLScanner(&saga.CompletedSteps), &saga.TotalSteps,
+		&saga.CurrentStep, &saga.LastError, &saga.CreatedAt, &saga.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "sagas", Err: err}
+	}
+	return saga, nil
+}
+
+// SaveSaga creates or replaces the saved progress of a saga
+func (r *SagaRepository) SaveSaga(ctx context.Context, saga *models.Saga) error {
+	query := `
+		INSERT INTO sagas (id, status, completed_steps, total_steps, current_step, last_error)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''))
+		ON CONFLICT (id) DO UPDATE SET
+			status = EXCLUDED.status,
+			completed_steps = EXCLUDED.completed_steps,
+			total_steps = EXCLUDED.total_steps,
+			current_step = EXCLUDED.current_step,
+			last_error = EXCLUDED.last_error,
+			updated_at = NOW()
+		RETURNING created_at, updated_at`
+
+	steps := saga.CompletedSteps
+	if steps == nil {
+		steps = []string{}
+	}
+	err := r.db.QueryRowContext(ctx, query,
+		saga.ID, saga.Status, steps, saga.TotalSteps, saga.CurrentStep, saga.LastError,
+	).Scan(&saga.CreatedAt, &saga.UpdatedAt)
+	if err != nil {
+		return &errors.DatabaseError{Op: "INSERT", Table: "sagas", Err: err}
+	}
+	return nil
+}
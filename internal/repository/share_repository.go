@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/pkg/dberr"
+	"github.com/valentinesamuel/activelog/pkg/errors"
+	"github.com/valentinesamuel/activelog/pkg/ulid"
+)
+
+// ShareRepository handles database operations for public activity share links
+type ShareRepository struct {
+	db DBConn
+}
+
+// NewShareRepository creates a new ShareRepository
+func NewShareRepository(db DBConn) *ShareRepository {
+	return &ShareRepository{db: db}
+}
+
+// Create inserts a share link for an activity
+func (r *ShareRepository) Create(ctx context.Context, share *models.ActivityShare) error {
+	query := `
+		INSERT INTO activity_shares (activity_id, user_id, expires_at, public_id)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, view_count, created_at`
+
+	share.PublicID = ulid.New()
+	err := r.db.QueryRowContext(ctx, query,
+		share.ActivityID,
+		share.UserID,
+		share.ExpiresAt,
+		share.PublicID,
+	).Scan(&share.ID, &share.ViewCount, &share.CreatedAt)
+	if err != nil {
+		return dberr.Translate(&errors.DatabaseError{Op: "INSERT", Table: "activity_shares", Err: err})
+	}
+
+	return nil
+}
+
+// ListByActivity returns every share link created for an activity, including revoked ones
+func (r *ShareRepository) ListByActivity(ctx context.Context, activityID int64) ([]*models.ActivityShare, error) {
+	query := `
+		SELECT id, public_id, activity_id, user_id, expires_at, revoked_at, view_count, last_viewed_at, created_at
+		FROM activity_shares
+		WHERE activity_id = $1
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, activityID)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "activity_shares", Err: err}
+	}
+	defer rows.Close()
+
+	shares := []*models.ActivityShare{}
+	for rows.Next() {
+		share := &models.ActivityShare{}
+		if err := rows.Scan(
+			&share.ID,
+			&share.PublicID,
+			&share.ActivityID,
+			&share.UserID,
+			&share.ExpiresAt,
+			&share.RevokedAt,
+			&share.ViewCount,
+			&share.LastViewedAt,
+			&share.CreatedAt,
+		); err != nil {
+			return nil, &errors.DatabaseError{Op: "SCAN", Table: "activity_shares", Err: err}
+		}
+		shares = append(shares, share)
+	}
+
+	return shares, rows.Err()
+}
+
+// Revoke disables a share link owned by the user
+// Returns errors.ErrNotFound if the link doesn't exist, belongs to someone else or is already revoked
+func (r *ShareRepository) Revoke(ctx context.Context, shareID int64, activityID int64, userID int) error {
+	query := `
+		UPDATE activity_shares
+		SET revoked_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND activity_id = $2 AND user_id = $3 AND revoked_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, shareID, activityID, userID)
+	if err != nil {
+		return &errors.DatabaseError{Op: "UPDATE", Table: "activity_shares", Err: err}
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+// IDByPublicID returns the serial ID of the share link with the given public ID
+// Returns errors.ErrNotFound if there is none
+func (r *ShareRepository) IDByPublicID(ctx context.Context, publicID string) (int64, error) {
+	var id int64
+	err := r.db.QueryRowContext(ctx, `SELECT id FROM activity_shares WHERE public_id = $1`, publicID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, errors.ErrNotFound
+	}
+	if err != nil {
+		return 0, &errors.DatabaseError{Op: "SELECT", Table: "activity_shares", Err: err}
+	}
+	return id, nil
+}
+
+// RecordView counts a view of a share link that is live at now and returns
+// the sanitized activity behind it
+//
+// The increment and the validity check happen in one statement so a link that is
+// revoked or expires concurrently is never counted. Revoked, expired and unknown
+// links, as well as links to deleted activities and activities that are no
+// longer public, all return errors.ErrNotFound.
+func (r *ShareRepository) RecordView(ctx context.Context, shareID int64, now time.Time) (*models.SharedActivity, error) {
+	return r.recordView(ctx, "s.id = $1", shareID, now)
+}
+
+// RecordViewByPublicID is RecordView for a link identified by its public ID
+func (r *ShareRepository) RecordViewByPublicID(ctx context.Context, publicID string, now time.Time) (*models.SharedActivity, error) {
+	return r.recordView(ctx, "s.public_id = $1", publicID, now)
+}
+
+func (r *ShareRepository) recordView(ctx context.Context, where string, arg any, now time.Time) (*models.SharedActivity, error) {
+	query := `
+		WITH viewed AS (
+			UPDATE activity_shares s
+			SET view_count = s.view_count + 1, last_viewed_at = $2
+			FROM activities a
+			WHERE ` + where + `
+				AND a.id = s.activity_id
+				AND a.deleted_at IS NULL
+				AND a.visibility = 'public'
+				AND s.revoked_at IS NULL
+				AND (s.expires_at IS NULL OR s.expires_at > $2)
+			RETURNING s.activity_id, s.view_count
+		)
+		SELECT a.activity_type, a.title, COALESCE(a.description, ''), COALESCE(a.duration_minutes, 0),
+			COALESCE(a.distance_km, 0), COALESCE(a.calories_burned, 0), a.activity_date, v.view_count,
+			COALESCE(u.preferences->>'units', '')
+		FROM viewed v
+		INNER JOIN activities a ON a.id = v.activity_id
+		INNER JOIN users u ON u.id = a.user_id`
+
+	shared := &models.SharedActivity{}
+	err := r.db.QueryRowContext(ctx, query, arg, now).Scan(
+		&shared.ActivityType,
+		&shared.Title,
+		&shared.Description,
+		&shared.DurationMinutes,
+		&shared.DistanceKm,
+		&shared.CaloriesBurned,
+		&shared.ActivityDate,
+		&shared.ViewCount,
+		&shared.Units,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "UPDATE", Table: "activity_shares", Err: err}
+	}
+
+	return shared, nil
+}
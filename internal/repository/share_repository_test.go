@@ -0,0 +1,100 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/repository/testhelpers"
+	"github.com/valentinesamuel/activelog/pkg/errors"
+)
+
+func TestShareRepository_RecordView(t *testing.T) {
+	db, cleanup := testhelpers.SetupIntegrationDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	shares := repository.NewShareRepository(db)
+	activities := repository.NewActivityRepository(db, repository.NewTagRepository(db))
+	userID := createTestUser(t, db, "sharer")
+
+	activity := &models.Activity{
+		UserID:          userID,
+		ActivityType:    "running",
+		Title:           "Parkrun",
+		DurationMinutes: 25,
+		DistanceKm:      5,
+		ActivityDate:    time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC),
+		Visibility:      models.VisibilityPublic,
+	}
+	require.NoError(t, activities.Create(ctx, nil, activity))
+
+	share := func(t *testing.T, expiresAt *time.Time) *models.ActivityShare {
+		t.Helper()
+		s := &models.ActivityShare{ActivityID: activity.ID, UserID: userID, ExpiresAt: expiresAt}
+		require.NoError(t, shares.Create(ctx, s))
+		return s
+	}
+	past, future := time.Now().UTC().Add(-24*time.Hour), time.Now().UTC().Add(24*time.Hour)
+
+	t.Run("live link counts views", func(t *testing.T) {
+		s := share(t, &future)
+		for want := 1; want <= 2; want++ {
+			shared, err := shares.RecordViewByPublicID(ctx, s.PublicID, time.Now())
+			require.NoError(t, err)
+			assert.Equal(t, want, shared.ViewCount)
+			assert.Equal(t, "Parkrun", shared.Title)
+		}
+		shared, err := shares.RecordView(ctx, s.ID, time.Now())
+		require.NoError(t, err, "by serial ID")
+		assert.Equal(t, 3, shared.ViewCount)
+	})
+
+	t.Run("revoked link", func(t *testing.T) {
+		s := share(t, nil)
+		require.NoError(t, shares.Revoke(ctx, s.ID, activity.ID, userID))
+
+		_, err := shares.RecordViewByPublicID(ctx, s.PublicID, time.Now())
+		assert.ErrorIs(t, err, errors.ErrNotFound)
+		_, err = shares.RecordView(ctx, s.ID, time.Now())
+		assert.ErrorIs(t, err, errors.ErrNotFound)
+		assert.ErrorIs(t, shares.Revoke(ctx, s.ID, activity.ID, userID), errors.ErrNotFound, "already revoked")
+
+		listed, err := shares.ListByActivity(ctx, activity.ID)
+		require.NoError(t, err)
+		for _, l := range listed {
+			if l.ID == s.ID {
+				assert.Zero(t, l.ViewCount, "views of a revoked link aren't counted")
+				assert.NotNil(t, l.RevokedAt)
+			}
+		}
+	})
+
+	t.Run("expired link", func(t *testing.T) {
+		s := share(t, &past)
+		_, err := shares.RecordViewByPublicID(ctx, s.PublicID, time.Now())
+		assert.ErrorIs(t, err, errors.ErrNotFound)
+	})
+
+	t.Run("unknown link", func(t *testing.T) {
+		_, err := shares.RecordViewByPublicID(ctx, "01HZY3V5J6X7Q8R9S0T1V2W3X4", time.Now())
+		assert.ErrorIs(t, err, errors.ErrNotFound)
+	})
+
+	t.Run("activity no longer public", func(t *testing.T) {
+		s := share(t, nil)
+		_, err := db.ExecContext(ctx, `UPDATE activities SET visibility = 'private' WHERE id = $1`, activity.ID)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_, _ = db.ExecContext(ctx, `UPDATE activities SET visibility = 'public' WHERE id = $1`, activity.ID)
+		})
+
+		_, err = shares.RecordViewByPublicID(ctx, s.PublicID, time.Now())
+		assert.ErrorIs(t, err, errors.ErrNotFound)
+	})
+}
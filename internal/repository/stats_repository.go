@@ -2,7 +2,12 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
 	"github.com/valentinesamuel/activelog/pkg/errors"
 )
 
@@ -17,6 +22,47 @@ type WeeklyStats struct {
 	TotalDuration   int     `json:"totalDurationMinutes"`
 	TotalDistance   float64 `json:"totalDistanceKm"`
 	AvgDuration     float64 `json:"avgDurationMinutes"`
+
+	// AvgRPE is the average perceived exertion of the week's activities that
+	// have one, nil when none has
+	AvgRPE *float64 `json:"avgRpe,omitempty"`
+
+	// WeightTrend is nil when no weight was logged during the week
+	WeightTrend *WeightTrend `json:"weightTrend,omitempty"`
+}
+
+// WeightTrend summarises the body weight logged over the last 7 days.
+// ChangeKg compares the latest entry with the last one before the week and is
+// nil if there is none.
+type WeightTrend struct {
+	LatestKg float64  `json:"latestKg"`
+	ChangeKg *float64 `json:"changeKg,omitempty"`
+	Entries  int      `json:"entries"`
+}
+
+// StatsComparison compares each metric of the current period (the last 7 or
+// 30 days) with the period of the same length before it
+type StatsComparison struct {
+	Period  string                      `json:"period"`
+	Compare string                      `json:"compare"`
+	Metrics map[string]MetricComparison `json:"metrics"`
+}
+
+// MetricComparison is one metric of a StatsComparison. ChangePct is the
+// change from Previous in percent, nil when Previous is 0.
+type MetricComparison struct {
+	Current   float64  `json:"current"`
+	Previous  float64  `json:"previous"`
+	ChangePct *float64 `json:"changePct"`
+}
+
+func newMetricComparison(current, previous float64) MetricComparison {
+	metric := MetricComparison{Current: current, Previous: previous}
+	if previous != 0 {
+		change := math.Round((current-previous)/previous*1000) / 10
+		metric.ChangePct = &change
+	}
+	return metric
 }
 
 type UserActivitySummary struct {
@@ -32,6 +78,100 @@ type TagUsage struct {
 	Count   int    `json:"count"`
 }
 
+// TimeSeriesPoint is a single date bucket in a time series
+type TimeSeriesPoint struct {
+	Bucket time.Time `json:"bucket"`
+	Value  float64   `json:"value"`
+}
+
+// TrainingLoadPoint is the training load on a single day. AcuteLoad and
+// ChronicLoad are the rolling 7- and 28-day sums ending on Date; Ratio compares
+// the acute load with the weekly average of the chronic one and is nil while
+// there is no chronic load.
+type TrainingLoadPoint struct {
+	Date        time.Time `json:"date"`
+	Load        float64   `json:"load"`
+	AcuteLoad   float64   `json:"acuteLoad"`
+	ChronicLoad float64   `json:"chronicLoad"`
+	Ratio       *float64  `json:"acuteChronicRatio"`
+}
+
+// HeatmapDay is one day of a GetHeatmap calendar
+type HeatmapDay struct {
+	Date            string `json:"date"` // YYYY-MM-DD
+	Count           int    `json:"count"`
+	DurationMinutes int    `json:"durationMinutes"`
+}
+
+// RPEDurationCorrelation relates the perceived exertion of activities to
+// their duration. Correlation is Pearson's r over the activities with an RPE,
+// nil with fewer than two of them or when either value never varies.
+type RPEDurationCorrelation struct {
+	Samples     int                 `json:"samples"`
+	Correlation *float64            `json:"correlation"`
+	Buckets     []RPEDurationBucket `json:"buckets"`
+}
+
+// RPEDurationBucket is the average RPE of the activities whose duration is at
+// least MinMinutes and below MaxMinutes (open-ended when nil)
+type RPEDurationBucket struct {
+	MinMinutes int     `json:"minMinutes"`
+	MaxMinutes *int    `json:"maxMinutes"`
+	Count      int     `json:"count"`
+	AvgRPE     float64 `json:"avgRpe"`
+}
+
+// BestSplits are the user's fastest kilometre split and fastest five
+// consecutive kilometre splits of one activity; nil until there is one
+type BestSplits struct {
+	Fastest1K *BestSplit `json:"fastest1k"`
+	Fastest5K *BestSplit `json:"fastest5k"`
+}
+
+// BestSplit is a best effort and the activity it was run in. ActivityID is
+// the activity's public ID; FirstSplit is the index of its first split.
+type BestSplit struct {
+	ActivityID      string    `json:"activityId"`
+	ActivityDate    time.Time `json:"activityDate"`
+	FirstSplit      int       `json:"firstSplit"`
+	DurationSeconds int       `json:"durationSeconds"`
+}
+
+// kmSplitToleranceKm is how far from 1 km a split may be and still count as
+// a kilometre split in GetBestSplits
+const kmSplitToleranceKm = 0.01
+
+// rpeDurationBounds are the lower bounds of the RPEDurationBucket durations
+var rpeDurationBounds = []int{0, 30, 60, 90, 120}
+
+// timeSeriesMetrics whitelists the metrics that can be bucketed
+// Maps the public metric name to its SQL aggregate (never interpolate user input directly)
+var timeSeriesMetrics = map[string]string{
+	"count":            "COUNT(*)",
+	"distance_km":      "SUM(distance_km)",
+	"duration_minutes": "SUM(duration_minutes)",
+	"calories_burned":  "SUM(calories_burned)",
+	"rpe":              "AVG(rpe)",
+}
+
+// timeSeriesIntervals whitelists the date_trunc units that can be used as buckets
+var timeSeriesIntervals = map[string]bool{
+	"day":   true,
+	"week":  true,
+	"month": true,
+}
+
+// IsValidTimeSeriesMetric reports whether metric can be passed to GetTimeSeries
+func IsValidTimeSeriesMetric(metric string) bool {
+	_, ok := timeSeriesMetrics[metric]
+	return ok
+}
+
+// IsValidTimeSeriesInterval reports whether interval can be passed to GetTimeSeries
+func IsValidTimeSeriesInterval(interval string) bool {
+	return timeSeriesIntervals[interval]
+}
+
 func NewStatsRepository(db DBConn) *StatsRepository {
 	return &StatsRepository{
 		db: db,
@@ -123,15 +263,29 @@ func (sr *StatsRepository) GetActivityCountByType(ctx context.Context, userID in
 }
 
 func (sr *StatsRepository) GetWeeklyStats(ctx context.Context, userID int) (*WeeklyStats, error) {
+	return sr.getWeeklyStats(ctx, userID, "")
+}
+
+// GetSharedWeeklyStats is GetWeeklyStats over the activities the user shares
+// with others, leaving private ones out. Coaches are shown these.
+func (sr *StatsRepository) GetSharedWeeklyStats(ctx context.Context, userID int) (*WeeklyStats, error) {
+	return sr.getWeeklyStats(ctx, userID, "AND visibility <> 'private'")
+}
+
+// getWeeklyStats aggregates the last 7 days of the user's activities matching
+// the extra condition, a constant SQL fragment
+func (sr *StatsRepository) getWeeklyStats(ctx context.Context, userID int, condition string) (*WeeklyStats, error) {
 	query := `
 		SELECT
 			COUNT(*)::int AS total_activities,
 			COALESCE(SUM(duration_minutes), 0)::int AS total_duration,
 			COALESCE(SUM(distance_km), 0)::float AS total_distance,
-			COALESCE(AVG(duration_minutes), 0)::float AS avg_duration
+			COALESCE(AVG(duration_minutes), 0)::float AS avg_duration,
+			AVG(rpe)::float AS avg_rpe
 		FROM activities
 		WHERE user_id = $1
 			AND activity_date >= NOW() - INTERVAL '7 days'
+			` + condition + `
 	`
 
 	weeklyStats := &WeeklyStats{}
@@ -143,6 +297,7 @@ func (sr *StatsRepository) GetWeeklyStats(ctx context.Context, userID int) (*Wee
 		&weeklyStats.TotalDuration,
 		&weeklyStats.TotalDistance,
 		&weeklyStats.AvgDuration,
+		&weeklyStats.AvgRPE,
 	)
 
 	if err != nil {
@@ -153,9 +308,166 @@ func (sr *StatsRepository) GetWeeklyStats(ctx context.Context, userID int) (*Wee
 		}
 	}
 
+	weeklyStats.WeightTrend, err = sr.getWeightTrend(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	return weeklyStats, nil
 }
 
+// GetWeeklyComparison compares the GetWeeklyStats totals of the last 7 days
+// with the 7 days before. Both periods are aggregated in one pass over the
+// last 14 days with FILTER clauses.
+func (sr *StatsRepository) GetWeeklyComparison(ctx context.Context, userID int) (*StatsComparison, error) {
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE current)::int,
+			COUNT(*) FILTER (WHERE NOT current)::int,
+			COALESCE(SUM(duration_minutes) FILTER (WHERE current), 0)::int,
+			COALESCE(SUM(duration_minutes) FILTER (WHERE NOT current), 0)::int,
+			COALESCE(SUM(distance_km) FILTER (WHERE current), 0)::float,
+			COALESCE(SUM(distance_km) FILTER (WHERE NOT current), 0)::float,
+			COALESCE(AVG(duration_minutes) FILTER (WHERE current), 0)::float,
+			COALESCE(AVG(duration_minutes) FILTER (WHERE NOT current), 0)::float,
+			COALESCE(AVG(rpe) FILTER (WHERE current), 0)::float,
+			COALESCE(AVG(rpe) FILTER (WHERE NOT current), 0)::float
+		FROM (
+			SELECT
+				duration_minutes,
+				distance_km,
+				rpe,
+				activity_date >= NOW() - INTERVAL '7 days' AS current
+			FROM activities
+			WHERE user_id = $1
+				AND activity_date >= NOW() - INTERVAL '14 days'
+		) AS periods
+	`
+
+	var current, previous WeeklyStats
+	var currentRPE, previousRPE float64
+	err := sr.db.QueryRowContext(ctx, query, userID).Scan(
+		&current.TotalActivities, &previous.TotalActivities,
+		&current.TotalDuration, &previous.TotalDuration,
+		&current.TotalDistance, &previous.TotalDistance,
+		&current.AvgDuration, &previous.AvgDuration,
+		&currentRPE, &previousRPE,
+	)
+	if err != nil {
+		return nil, &errors.DatabaseError{
+			Op:    "AGGREGATE",
+			Table: "activities",
+			Err:   err,
+		}
+	}
+
+	return &StatsComparison{
+		Period:  "week",
+		Compare: "previous",
+		Metrics: map[string]MetricComparison{
+			"totalActivities":      newMetricComparison(float64(current.TotalActivities), float64(previous.TotalActivities)),
+			"totalDurationMinutes": newMetricComparison(float64(current.TotalDuration), float64(previous.TotalDuration)),
+			"totalDistanceKm":      newMetricComparison(current.TotalDistance, previous.TotalDistance),
+			"avgDurationMinutes":   newMetricComparison(current.AvgDuration, previous.AvgDuration),
+			"avgRpe":               newMetricComparison(currentRPE, previousRPE),
+		},
+	}, nil
+}
+
+// GetMonthlyComparison compares the GetMonthlyStats count of each activity
+// type over the last 30 days with the 30 days before, in one pass over the
+// last 60 days. A type logged in only one of the periods is 0 in the other.
+func (sr *StatsRepository) GetMonthlyComparison(ctx context.Context, userID int) (*StatsComparison, error) {
+	query := `
+		SELECT
+			activity_type,
+			COUNT(*) FILTER (WHERE activity_date >= NOW() - INTERVAL '30 days')::int AS current_count,
+			COUNT(*) FILTER (WHERE activity_date < NOW() - INTERVAL '30 days')::int AS previous_count
+		FROM activities
+		WHERE user_id = $1
+			AND activity_date >= NOW() - INTERVAL '60 days'
+		GROUP BY activity_type
+	`
+
+	rows, err := sr.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, &errors.DatabaseError{
+			Op:    "AGGREGATE",
+			Table: "activities",
+			Err:   err,
+		}
+	}
+	defer rows.Close()
+
+	comparison := &StatsComparison{
+		Period:  "month",
+		Compare: "previous",
+		Metrics: map[string]MetricComparison{},
+	}
+	for rows.Next() {
+		var (
+			activityType      string
+			current, previous int
+		)
+		if err := rows.Scan(&activityType, &current, &previous); err != nil {
+			return nil, &errors.DatabaseError{
+				Op:    "SCAN",
+				Table: "activities",
+				Err:   err,
+			}
+		}
+		comparison.Metrics[activityType] = newMetricComparison(float64(current), float64(previous))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{
+			Op:    "ITERATE",
+			Table: "activities",
+			Err:   err,
+		}
+	}
+
+	return comparison, nil
+}
+
+// getWeightTrend summarises the weight entries of the last 7 days, or returns
+// nil if there are none
+func (sr *StatsRepository) getWeightTrend(ctx context.Context, userID int) (*WeightTrend, error) {
+	query := `
+		WITH weights AS (
+			SELECT value::float AS value, recorded_on
+			FROM body_metrics
+			WHERE user_id = $1 AND metric_type = 'weight_kg'
+		)
+		SELECT
+			(SELECT value FROM weights WHERE recorded_on > CURRENT_DATE - 7 ORDER BY recorded_on DESC LIMIT 1),
+			(SELECT value FROM weights WHERE recorded_on <= CURRENT_DATE - 7 ORDER BY recorded_on DESC LIMIT 1),
+			(SELECT COUNT(*)::int FROM weights WHERE recorded_on > CURRENT_DATE - 7)
+	`
+
+	var (
+		latest, previous sql.NullFloat64
+		entries          int
+	)
+	if err := sr.db.QueryRowContext(ctx, query, userID).Scan(&latest, &previous, &entries); err != nil {
+		return nil, &errors.DatabaseError{
+			Op:    "AGGREGATE",
+			Table: "body_metrics",
+			Err:   err,
+		}
+	}
+	if !latest.Valid {
+		return nil, nil
+	}
+
+	trend := &WeightTrend{LatestKg: latest.Float64, Entries: entries}
+	if previous.Valid {
+		change := math.Round((latest.Float64-previous.Float64)*10) / 10
+		trend.ChangeKg = &change
+	}
+	return trend, nil
+}
+
 func (sr *StatsRepository) GetUserActivitySummary(ctx context.Context, userID int) (*UserActivitySummary, error) {
 	query := `
 		SELECT
@@ -247,3 +559,359 @@ func (sr *StatsRepository) GetTopTagsByUser(ctx context.Context, userID int, lim
 
 	return tagUsages, nil
 }
+
+// GetTimeSeries aggregates metric into date_trunc(interval) buckets between from and to (inclusive)
+// Buckets are generated with generate_series and LEFT JOINed, so empty periods come back as 0
+func (sr *StatsRepository) GetTimeSeries(ctx context.Context, userID int, metric, interval string, from, to time.Time) ([]TimeSeriesPoint, error) {
+	aggregate, ok := timeSeriesMetrics[metric]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported metric '%s'", errors.ErrInvalidInput, metric)
+	}
+	if !timeSeriesIntervals[interval] {
+		return nil, fmt.Errorf("%w: unsupported interval '%s'", errors.ErrInvalidInput, interval)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			buckets.bucket,
+			COALESCE(agg.value, 0)::float AS value
+		FROM generate_series(
+			date_trunc($2, $3::timestamp),
+			date_trunc($2, $4::timestamp),
+			('1 ' || $2)::interval
+		) AS buckets(bucket)
+		LEFT JOIN (
+			SELECT
+				date_trunc($2, activity_date) AS bucket,
+				%s AS value
+			FROM activities
+			WHERE user_id = $1
+				AND deleted_at IS NULL
+				AND activity_date >= date_trunc($2, $3::timestamp)
+				AND activity_date < date_trunc($2, $4::timestamp) + ('1 ' || $2)::interval
+			GROUP BY 1
+		) AS agg
+			ON agg.bucket = buckets.bucket
+		ORDER BY buckets.bucket ASC
+	`, aggregate)
+
+	rows, err := sr.db.QueryContext(ctx, query, userID, interval, from, to)
+	if err != nil {
+		return nil, &errors.DatabaseError{
+			Op:    "AGGREGATE",
+			Table: "activities",
+			Err:   err,
+		}
+	}
+	defer rows.Close()
+
+	points := []TimeSeriesPoint{}
+	for rows.Next() {
+		var point TimeSeriesPoint
+		if err := rows.Scan(&point.Bucket, &point.Value); err != nil {
+			return nil, &errors.DatabaseError{
+				Op:    "SCAN",
+				Table: "activities",
+				Err:   err,
+			}
+		}
+		points = append(points, point)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{
+			Op:    "ITERATE",
+			Table: "activities",
+			Err:   err,
+		}
+	}
+
+	return points, nil
+}
+
+// GetHeatmap returns one HeatmapDay for every day of year (UTC), with the
+// number of activities and their total duration. Days come from
+// generate_series and are LEFT JOINed, so days without activities are 0.
+func (sr *StatsRepository) GetHeatmap(ctx context.Context, userID int, year int) ([]HeatmapDay, error) {
+	query := `
+		SELECT
+			to_char(days.day, 'YYYY-MM-DD'),
+			COALESCE(agg.count, 0)::int,
+			COALESCE(agg.duration, 0)::int
+		FROM generate_series(
+			make_date($2, 1, 1),
+			make_date($2, 12, 31),
+			interval '1 day'
+		) AS days(day)
+		LEFT JOIN (
+			SELECT
+				activity_date::date AS day,
+				COUNT(*) AS count,
+				SUM(duration_minutes) AS duration
+			FROM activities
+			WHERE user_id = $1
+				AND deleted_at IS NULL
+				AND activity_date >= make_date($2, 1, 1)
+				AND activity_date < make_date($2 + 1, 1, 1)
+			GROUP BY 1
+		) AS agg
+			ON agg.day = days.day::date
+		ORDER BY days.day ASC
+	`
+
+	rows, err := sr.db.QueryContext(ctx, query, userID, year)
+	if err != nil {
+		return nil, &errors.DatabaseError{
+			Op:    "AGGREGATE",
+			Table: "activities",
+			Err:   err,
+		}
+	}
+	defer rows.Close()
+
+	days := make([]HeatmapDay, 0, 366)
+	for rows.Next() {
+		var day HeatmapDay
+		if err := rows.Scan(&day.Date, &day.Count, &day.DurationMinutes); err != nil {
+			return nil, &errors.DatabaseError{
+				Op:    "SCAN",
+				Table: "activities",
+				Err:   err,
+			}
+		}
+		days = append(days, day)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{
+			Op:    "ITERATE",
+			Table: "activities",
+			Err:   err,
+		}
+	}
+
+	return days, nil
+}
+
+// GetTrainingLoad returns one TrainingLoadPoint per day between from and to
+// (inclusive). An activity's load is its heart-rate training load, or its
+// duration in minutes when it was logged without heart-rate data. The rolling
+// sums are window functions over a zero-filled series that starts 27 days
+// before from, so the first points already cover full windows.
+func (sr *StatsRepository) GetTrainingLoad(ctx context.Context, userID int, from, to time.Time) ([]TrainingLoadPoint, error) {
+	query := `
+		WITH daily AS (
+			SELECT
+				days.day,
+				COALESCE(agg.load, 0)::float AS load
+			FROM generate_series(
+				date_trunc('day', $2::timestamp) - interval '27 days',
+				date_trunc('day', $3::timestamp),
+				interval '1 day'
+			) AS days(day)
+			LEFT JOIN (
+				SELECT
+					date_trunc('day', activity_date) AS day,
+					SUM(COALESCE(training_load, duration_minutes)) AS load
+				FROM activities
+				WHERE user_id = $1
+					AND deleted_at IS NULL
+					AND activity_date >= date_trunc('day', $2::timestamp) - interval '27 days'
+					AND activity_date < date_trunc('day', $3::timestamp) + interval '1 day'
+				GROUP BY 1
+			) AS agg
+				ON agg.day = days.day
+		),
+		rolling AS (
+			SELECT
+				day,
+				load,
+				SUM(load) OVER (ORDER BY day ROWS BETWEEN 6 PRECEDING AND CURRENT ROW) AS acute,
+				SUM(load) OVER (ORDER BY day ROWS BETWEEN 27 PRECEDING AND CURRENT ROW) AS chronic
+			FROM daily
+		)
+		SELECT day, load, acute, chronic
+		FROM rolling
+		WHERE day >= date_trunc('day', $2::timestamp)
+		ORDER BY day ASC
+	`
+
+	rows, err := sr.db.QueryContext(ctx, query, userID, from, to)
+	if err != nil {
+		return nil, &errors.DatabaseError{
+			Op:    "AGGREGATE",
+			Table: "activities",
+			Err:   err,
+		}
+	}
+	defer rows.Close()
+
+	points := []TrainingLoadPoint{}
+	for rows.Next() {
+		var point TrainingLoadPoint
+		if err := rows.Scan(&point.Date, &point.Load, &point.AcuteLoad, &point.ChronicLoad); err != nil {
+			return nil, &errors.DatabaseError{
+				Op:    "SCAN",
+				Table: "activities",
+				Err:   err,
+			}
+		}
+		if point.ChronicLoad > 0 {
+			ratio := math.Round(point.AcuteLoad/(point.ChronicLoad/4)*100) / 100
+			point.Ratio = &ratio
+		}
+		points = append(points, point)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{
+			Op:    "ITERATE",
+			Table: "activities",
+			Err:   err,
+		}
+	}
+
+	return points, nil
+}
+
+// GetRPEVsDuration correlates the perceived exertion of the user's activities
+// between from and to with their duration, and averages the RPE per duration
+// bucket (see rpeDurationBounds). Activities without an RPE are left out.
+func (sr *StatsRepository) GetRPEVsDuration(ctx context.Context, userID int, from, to time.Time) (*RPEDurationCorrelation, error) {
+	query := `
+		SELECT
+			width_bucket(duration_minutes, $4::int[]) AS bucket,
+			COUNT(*)::int,
+			AVG(rpe)::float,
+			SUM(COUNT(*)) OVER ()::int,
+			(SELECT corr(rpe, duration_minutes)
+				FROM activities
+				WHERE user_id = $1
+					AND deleted_at IS NULL
+					AND rpe IS NOT NULL
+					AND activity_date >= $2
+					AND activity_date <= $3)::float
+		FROM activities
+		WHERE user_id = $1
+			AND deleted_at IS NULL
+			AND rpe IS NOT NULL
+			AND activity_date >= $2
+			AND activity_date <= $3
+		GROUP BY 1
+		ORDER BY 1 ASC
+	`
+
+	rows, err := sr.db.QueryContext(ctx, query, userID, from, to, rpeDurationBounds)
+	if err != nil {
+		return nil, &errors.DatabaseError{
+			Op:    "AGGREGATE",
+			Table: "activities",
+			Err:   err,
+		}
+	}
+	defer rows.Close()
+
+	result := &RPEDurationCorrelation{Buckets: []RPEDurationBucket{}}
+	for rows.Next() {
+		var index int
+		var bucket RPEDurationBucket
+		var correlation sql.NullFloat64
+		if err := rows.Scan(&index, &bucket.Count, &bucket.AvgRPE, &result.Samples, &correlation); err != nil {
+			return nil, &errors.DatabaseError{
+				Op:    "SCAN",
+				Table: "activities",
+				Err:   err,
+			}
+		}
+		if correlation.Valid {
+			r := math.Round(correlation.Float64*1000) / 1000
+			result.Correlation = &r
+		}
+
+		// width_bucket numbers the buckets from 1 and returns 0 below the first bound
+		if index < 1 {
+			index = 1
+		}
+		bucket.MinMinutes = rpeDurationBounds[index-1]
+		if index < len(rpeDurationBounds) {
+			upper := rpeDurationBounds[index]
+			bucket.MaxMinutes = &upper
+		}
+		bucket.AvgRPE = math.Round(bucket.AvgRPE*10) / 10
+		result.Buckets = append(result.Buckets, bucket)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{
+			Op:    "ITERATE",
+			Table: "activities",
+			Err:   err,
+		}
+	}
+
+	return result, nil
+}
+
+// GetBestSplits finds the user's fastest kilometre split and fastest five
+// consecutive kilometre splits (5k) across their live activities. Splits
+// within kmSplitToleranceKm of 1 km count as kilometre splits.
+func (sr *StatsRepository) GetBestSplits(ctx context.Context, userID int) (*BestSplits, error) {
+	// The RANGE window only spans consecutive split indexes, so a 5k can't
+	// bridge a split that isn't a kilometre
+	query := `
+		WITH km AS (
+			SELECT a.public_id, a.activity_date, s.split_index, s.duration_seconds,
+				SUM(s.duration_seconds) OVER five AS five_seconds,
+				COUNT(*) OVER five AS five_count
+			FROM activity_splits s
+			JOIN activities a ON a.id = s.activity_id
+			WHERE a.user_id = $1
+				AND a.deleted_at IS NULL
+				AND s.distance_km BETWEEN 1 - $2::numeric AND 1 + $2::numeric
+			WINDOW five AS (PARTITION BY s.activity_id ORDER BY s.split_index RANGE BETWEEN 4 PRECEDING AND CURRENT ROW)
+		)
+		(SELECT '1k', public_id, activity_date, split_index, duration_seconds::int
+			FROM km ORDER BY duration_seconds, activity_date LIMIT 1)
+		UNION ALL
+		(SELECT '5k', public_id, activity_date, split_index - 4, five_seconds::int
+			FROM km WHERE five_count = 5 ORDER BY five_seconds, activity_date LIMIT 1)
+	`
+
+	rows, err := sr.db.QueryContext(ctx, query, userID, kmSplitToleranceKm)
+	if err != nil {
+		return nil, &errors.DatabaseError{
+			Op:    "AGGREGATE",
+			Table: "activity_splits",
+			Err:   err,
+		}
+	}
+	defer rows.Close()
+
+	result := &BestSplits{}
+	for rows.Next() {
+		var kind string
+		best := &BestSplit{}
+		if err := rows.Scan(&kind, &best.ActivityID, &best.ActivityDate, &best.FirstSplit, &best.DurationSeconds); err != nil {
+			return nil, &errors.DatabaseError{
+				Op:    "SCAN",
+				Table: "activity_splits",
+				Err:   err,
+			}
+		}
+		if kind == "1k" {
+			result.Fastest1K = best
+		} else {
+			result.Fastest5K = best
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{
+			Op:    "ITERATE",
+			Table: "activity_splits",
+			Err:   err,
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,64 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/repository/testhelpers"
+)
+
+var weeklyStatsRows = []string{"total_activities", "total_duration", "total_distance", "avg_duration", "avg_rpe"}
+
+func TestStatsRepository_GetSharedWeeklyStats_Query(t *testing.T) {
+	db, mock := testhelpers.SetupMockDB(t)
+	mock.ExpectQuery(`activity_date >= NOW\(\) - INTERVAL '7 days'\s+AND visibility <> 'private'`).
+		WithArgs(8).
+		WillReturnRows(sqlmock.NewRows(weeklyStatsRows).AddRow(2, 60, 10.0, 30.0, nil))
+	mock.ExpectQuery(`FROM body_metrics`).
+		WithArgs(8).
+		WillReturnRows(sqlmock.NewRows([]string{"latest", "previous", "entries"}).AddRow(nil, nil, 0))
+
+	stats, err := repository.NewStatsRepository(db).GetSharedWeeklyStats(context.Background(), 8)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.TotalActivities)
+	assert.Nil(t, stats.WeightTrend)
+}
+
+func TestStatsRepository_GetSharedWeeklyStats(t *testing.T) {
+	db, cleanup := testhelpers.SetupIntegrationDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	activities := repository.NewActivityRepository(db, repository.NewTagRepository(db))
+	stats := repository.NewStatsRepository(db)
+	userID := createTestUser(t, db, "athlete")
+
+	for _, visibility := range []string{models.VisibilityPublic, models.VisibilityPrivate} {
+		require.NoError(t, activities.Create(ctx, nil, &models.Activity{
+			UserID:          userID,
+			ActivityType:    "running",
+			Title:           visibility + " run",
+			DurationMinutes: 30,
+			DistanceKm:      5,
+			ActivityDate:    time.Now().UTC().Add(-24 * time.Hour),
+			Visibility:      visibility,
+		}))
+	}
+
+	own, err := stats.GetWeeklyStats(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, own.TotalActivities)
+
+	shared, err := stats.GetSharedWeeklyStats(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, shared.TotalActivities)
+	assert.Equal(t, 30, shared.TotalDuration)
+	assert.Equal(t, 5.0, shared.TotalDistance)
+}
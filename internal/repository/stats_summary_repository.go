@@ -0,0 +1,260 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/valentinesamuel/activelog/pkg/errors"
+)
+
+// Stats sources reported by StatsFreshness
+const (
+	StatsSourceLive    = "live"
+	StatsSourceSummary = "summary"
+)
+
+// StatsFreshness says where a user's stats are served from and, for
+// summaries, when they were last rebuilt
+type StatsFreshness struct {
+	Source      string
+	RefreshedAt *time.Time
+}
+
+// StatsFreshnessReporter is implemented by stats repositories that may serve
+// stale data (SummaryStatsRepository)
+type StatsFreshnessReporter interface {
+	StatsFreshness(ctx context.Context, userID int) (*StatsFreshness, error)
+}
+
+// summaryRebuilds holds the statements that rebuild the summary tables. Each
+// takes the user to rebuild as $1, or NULL for every user.
+var summaryRebuilds = []string{
+	`DELETE FROM stats_daily_activity WHERE $1::int IS NULL OR user_id = $1`,
+	`INSERT INTO stats_daily_activity (user_id, day, activity_type, activity_count, duration_minutes, distance_km)
+		SELECT
+			user_id,
+			activity_date::date,
+			activity_type,
+			COUNT(*),
+			COALESCE(SUM(duration_minutes), 0),
+			COALESCE(SUM(distance_km), 0)
+		FROM activities
+		WHERE deleted_at IS NULL AND ($1::int IS NULL OR user_id = $1)
+		GROUP BY 1, 2, 3`,
+	`DELETE FROM stats_tag_usage WHERE $1::int IS NULL OR user_id = $1`,
+	`INSERT INTO stats_tag_usage (user_id, tag_id, usage_count)
+		SELECT a.user_id, at.tag_id, COUNT(*)
+		FROM activity_tags at
+		INNER JOIN activities a ON a.id = at.activity_id
+		WHERE a.deleted_at IS NULL AND ($1::int IS NULL OR a.user_id = $1)
+		GROUP BY 1, 2`,
+	`INSERT INTO stats_refreshes (user_id, refreshed_at)
+		SELECT id, NOW() FROM users WHERE $1::int IS NULL OR id = $1
+		ON CONFLICT (user_id) DO UPDATE SET refreshed_at = EXCLUDED.refreshed_at`,
+}
+
+// StatsSummaryRepository maintains and reads the stats summary tables
+// (migration 000031): activity totals per day and type, and tag usage.
+type StatsSummaryRepository struct {
+	db DBConn
+}
+
+// NewStatsSummaryRepository creates a new StatsSummaryRepository
+func NewStatsSummaryRepository(db DBConn) *StatsSummaryRepository {
+	return &StatsSummaryRepository{db: db}
+}
+
+// RefreshAll rebuilds the summaries of every user in one transaction
+func (r *StatsSummaryRepository) RefreshAll(ctx context.Context) error {
+	return r.rebuild(ctx, nil)
+}
+
+// RefreshUser rebuilds the summaries of one user, after their activities changed
+func (r *StatsSummaryRepository) RefreshUser(ctx context.Context, userID int) error {
+	return r.rebuild(ctx, userID)
+}
+
+func (r *StatsSummaryRepository) rebuild(ctx context.Context, userID interface{}) error {
+	return WithTransaction(ctx, r.db, func(tx TxConn) error {
+		for _, statement := range summaryRebuilds {
+			if _, err := tx.ExecContext(ctx, statement, userID); err != nil {
+				return &errors.DatabaseError{Op: "REFRESH", Table: "stats_summaries", Err: err}
+			}
+		}
+		return nil
+	})
+}
+
+// RefreshedAt returns when the user's summaries were last rebuilt, or nil if
+// they never were
+func (r *StatsSummaryRepository) RefreshedAt(ctx context.Context, userID int) (*time.Time, error) {
+	var refreshedAt time.Time
+	err := r.db.QueryRowContext(ctx,
+		`SELECT refreshed_at FROM stats_refreshes WHERE user_id = $1`, userID).Scan(&refreshedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "stats_refreshes", Err: err}
+	}
+	return &refreshedAt, nil
+}
+
+// GetMonthlyStats is StatsRepository.GetMonthlyStats over whole days: the
+// last 30 days counting today
+func (r *StatsSummaryRepository) GetMonthlyStats(ctx context.Context, userID int) (*MonthlyStats, error) {
+	stats := &MonthlyStats{}
+	err := r.scanTypeCounts(ctx, stats, `
+		SELECT COALESCE(json_object_agg(activity_type, activity_count), '{}'::json)
+		FROM (
+			SELECT activity_type, SUM(activity_count)::int AS activity_count
+			FROM stats_daily_activity
+			WHERE user_id = $1 AND day > CURRENT_DATE - 30
+			GROUP BY activity_type
+		) AS activity_stats
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// GetActivityCountByType is StatsRepository.GetActivityCountByType
+func (r *StatsSummaryRepository) GetActivityCountByType(ctx context.Context, userID int) (map[string]int, error) {
+	stats := make(map[string]int)
+	err := r.scanTypeCounts(ctx, &stats, `
+		SELECT COALESCE(json_object_agg(activity_type, activity_count), '{}'::json)
+		FROM (
+			SELECT activity_type, SUM(activity_count)::int AS activity_count
+			FROM stats_daily_activity
+			WHERE user_id = $1
+			GROUP BY activity_type
+		) AS activity_stats
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// scanTypeCounts scans a json_object_agg of activity type counts into dest
+func (r *StatsSummaryRepository) scanTypeCounts(ctx context.Context, dest interface{}, query string, userID int) error {
+	var statsJSON []byte
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&statsJSON); err != nil {
+		return &errors.DatabaseError{Op: "AGGREGATE", Table: "stats_daily_activity", Err: err}
+	}
+	if err := json.Unmarshal(statsJSON, dest); err != nil {
+		return &errors.DatabaseError{Op: "AGGREGATE", Table: "stats_daily_activity", Err: err}
+	}
+	return nil
+}
+
+// GetTopTagsByUser is StatsRepository.GetTopTagsByUser
+func (r *StatsSummaryRepository) GetTopTagsByUser(ctx context.Context, userID int, limit int) ([]TagUsage, error) {
+	query := `
+		SELECT t.name, u.usage_count
+		FROM stats_tag_usage u
+		INNER JOIN tags t ON t.id = u.tag_id
+		WHERE u.user_id = $1
+		ORDER BY u.usage_count DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "stats_tag_usage", Err: err}
+	}
+	defer rows.Close()
+
+	tagUsages := []TagUsage{}
+	for rows.Next() {
+		var tagUsage TagUsage
+		if err := rows.Scan(&tagUsage.TagName, &tagUsage.Count); err != nil {
+			return nil, &errors.DatabaseError{Op: "SCAN", Table: "stats_tag_usage", Err: err}
+		}
+		tagUsages = append(tagUsages, tagUsage)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, &errors.DatabaseError{Op: "ITERATE", Table: "stats_tag_usage", Err: err}
+	}
+
+	return tagUsages, nil
+}
+
+// SummaryStatsRepository serves the heavy stats (monthly by type, counts by
+// type, top tags) from the summary tables, and everything else from the live
+// StatsRepositoryInterface it wraps. A user whose summaries were never built,
+// or were rebuilt longer than maxStaleness ago, is served live.
+type SummaryStatsRepository struct {
+	StatsRepositoryInterface
+	summaries    *StatsSummaryRepository
+	maxStaleness time.Duration
+	now          func() time.Time
+}
+
+// NewSummaryStatsRepository wraps live to read from summaries
+func NewSummaryStatsRepository(live StatsRepositoryInterface, summaries *StatsSummaryRepository, maxStaleness time.Duration) *SummaryStatsRepository {
+	return &SummaryStatsRepository{
+		StatsRepositoryInterface: live,
+		summaries:                summaries,
+		maxStaleness:             maxStaleness,
+		now:                      time.Now,
+	}
+}
+
+// StatsFreshness reports whether the user's heavy stats come from the
+// summaries, and how old those are
+func (r *SummaryStatsRepository) StatsFreshness(ctx context.Context, userID int) (*StatsFreshness, error) {
+	refreshedAt, err := r.summaries.RefreshedAt(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if refreshedAt == nil || (r.maxStaleness > 0 && r.now().Sub(*refreshedAt) > r.maxStaleness) {
+		return &StatsFreshness{Source: StatsSourceLive}, nil
+	}
+	return &StatsFreshness{Source: StatsSourceSummary, RefreshedAt: refreshedAt}, nil
+}
+
+// useSummaries reports whether the user's summaries are fresh enough to read
+func (r *SummaryStatsRepository) useSummaries(ctx context.Context, userID int) (bool, error) {
+	freshness, err := r.StatsFreshness(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return freshness.Source == StatsSourceSummary, nil
+}
+
+func (r *SummaryStatsRepository) GetMonthlyStats(ctx context.Context, userID int) (*MonthlyStats, error) {
+	fromSummaries, err := r.useSummaries(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !fromSummaries {
+		return r.StatsRepositoryInterface.GetMonthlyStats(ctx, userID)
+	}
+	return r.summaries.GetMonthlyStats(ctx, userID)
+}
+
+func (r *SummaryStatsRepository) GetActivityCountByType(ctx context.Context, userID int) (map[string]int, error) {
+	fromSummaries, err := r.useSummaries(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !fromSummaries {
+		return r.StatsRepositoryInterface.GetActivityCountByType(ctx, userID)
+	}
+	return r.summaries.GetActivityCountByType(ctx, userID)
+}
+
+func (r *SummaryStatsRepository) GetTopTagsByUser(ctx context.Context, userID int, limit int) ([]TagUsage, error) {
+	fromSummaries, err := r.useSummaries(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !fromSummaries {
+		return r.StatsRepositoryInterface.GetTopTagsByUser(ctx, userID, limit)
+	}
+	return r.summaries.GetTopTagsByUser(ctx, userID, limit)
+}
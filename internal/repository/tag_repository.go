@@ -5,15 +5,18 @@ import (
 	"database/sql"
 	"fmt"
 
-	_ "github.com/lib/pq"
 	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/pkg/dberr"
+	"github.com/valentinesamuel/activelog/pkg/errors"
 	"github.com/valentinesamuel/activelog/pkg/logger"
 	"github.com/valentinesamuel/activelog/pkg/query"
+	"github.com/valentinesamuel/activelog/pkg/ulid"
 )
 
 type TagRepository struct {
-	db       DBConn
-	registry *query.RelationshipRegistry
+	db         DBConn
+	registry   *query.RelationshipRegistry
+	validation *query.EntityValidation
 }
 
 func NewTagRepository(db DBConn) *TagRepository {
@@ -23,9 +26,14 @@ func NewTagRepository(db DBConn) *TagRepository {
 	// Alias = "parent" so filter key "tags.parent.name" maps to SQL "parent.name"
 	registry.Register(query.SelfReferentialRelationship("parent", "tags", "parent_tag_id", 3))
 
+	validation := query.NewEntityValidation("tags")
+	validation.Column("name", query.ColumnRule{Filter: true, Search: true, Order: true, Operators: query.EqualityOperators(), Type: query.TypeString})
+	validation.Column("created_at", query.ColumnRule{Filter: true, Order: true, Operators: query.ComparisonOperators(), Type: query.TypeTimestamp})
+
 	return &TagRepository{
-		db:       db,
-		registry: registry,
+		db:         db,
+		registry:   registry,
+		validation: validation,
 	}
 }
 
@@ -35,29 +43,22 @@ func (tr *TagRepository) GetRegistry() *query.RelationshipRegistry {
 	return tr.registry
 }
 
-func (tr *TagRepository) GetOrCreateTag(ctx context.Context, tx TxConn, name string) (int, error) {
-	query := `
-		INSERT INTO tags (name)
-		VALUES ($1)
-		ON CONFLICT (name) DO UPDATE
-		SET name = EXCLUDED.name
-		RETURNING id
-	`
-
-	var id int
-	var err error
-
-	// Use transaction if provided, otherwise use db connection
-	if tx != nil {
-		err = tx.QueryRowContext(ctx, query, name).Scan(&id)
-	} else {
-		err = tr.db.QueryRowContext(ctx, query, name).Scan(&id)
-	}
+// GetValidation returns the query whitelist of tags
+func (tr *TagRepository) GetValidation() *query.EntityValidation {
+	return tr.validation
+}
 
+func (tr *TagRepository) GetOrCreateTag(ctx context.Context, tx TxConn, name string) (int, error) {
+	stmt, args, err := upsertTagQuery(name)
 	if err != nil {
 		return 0, err
 	}
 
+	var id int
+	if err := QueryRowInTx(ctx, tx, tr.db, stmt, args...).Scan(&id); err != nil {
+		return 0, dberr.Translate(&errors.DatabaseError{Op: "INSERT", Table: "tags", Err: err})
+	}
+
 	logger.Info().Int("tag_id", id).Msg("✅ Created tag")
 	return id, nil
 }
@@ -67,6 +68,7 @@ func (tr *TagRepository) GetTagsForActivity(ctx context.Context, activityID int)
 	query := `
 		SELECT
 		 tags.id,
+		 tags.public_id,
 		 tags.name,
 		 tags.created_at
 		FROM activity_tags as at
@@ -76,7 +78,7 @@ func (tr *TagRepository) GetTagsForActivity(ctx context.Context, activityID int)
 
 	rows, err := tr.db.QueryContext(ctx, query, activityID)
 	if err != nil {
-		return nil, fmt.Errorf("❌ Error listing activity tags: %w", err)
+		return nil, dberr.Translate(fmt.Errorf("❌ Error listing activity tags: %w", err))
 	}
 
 	defer rows.Close()
@@ -87,6 +89,7 @@ func (tr *TagRepository) GetTagsForActivity(ctx context.Context, activityID int)
 		tag := &models.Tag{}
 		err := rows.Scan(
 			&tag.ID,
+			&tag.PublicID,
 			&tag.Name,
 			&tag.CreatedAt,
 		)
@@ -109,24 +112,13 @@ func (tr *TagRepository) GetTagsForActivity(ctx context.Context, activityID int)
 }
 
 func (tr *TagRepository) LinkActivityTag(ctx context.Context, tx TxConn, activityID int, tagID int) error {
-	query := `
-		INSERT INTO activity_tags
-		(tag_id, activity_id)
-		VALUES ($1, $2)
-		ON CONFLICT (tag_id, activity_id) DO NOTHING;
-	`
-
-	var err error
-
-	// Use transaction if provided, otherwise use db connection
-	if tx != nil {
-		_, err = tx.ExecContext(ctx, query, tagID, activityID)
-	} else {
-		_, err = tr.db.ExecContext(ctx, query, tagID, activityID)
+	stmt, args, err := linkActivityTagQuery(activityID, tagID)
+	if err != nil {
+		return err
 	}
 
-	if err != nil {
-		return fmt.Errorf("❌ Error creating activity tag %w", err)
+	if _, err := ExecInTx(ctx, tx, tr.db, stmt, args...); err != nil {
+		return dberr.Translate(fmt.Errorf("❌ Error creating activity tag %w", err))
 	}
 
 	fmt.Println("✅ Activity tag created successfully!")
@@ -134,8 +126,26 @@ func (tr *TagRepository) LinkActivityTag(ctx context.Context, tx TxConn, activit
 	return nil
 }
 
+// upsertTagQuery inserts the tag called name, or touches the existing one so
+// RETURNING yields its id either way. An existing tag keeps its public ID.
+func upsertTagQuery(name string) (string, []interface{}, error) {
+	return query.NewInsertBuilder("tags", "name", "public_id").
+		Values(name, ulid.New()).
+		OnConflict("name").DoUpdate("name").
+		Returning("id").
+		Build()
+}
+
+// linkActivityTagQuery links a tag to an activity, ignoring existing links
+func linkActivityTagQuery(activityID, tagID int) (string, []interface{}, error) {
+	return query.NewInsertBuilder("activity_tags", "tag_id", "activity_id").
+		Values(tagID, activityID).
+		OnConflict("tag_id", "activity_id").DoNothing().
+		Build()
+}
+
 // scanTag is a reusable function to scan a single tag row
-// Scans all columns from SELECT tags.*: id, name, created_at, deleted_at, parent_tag_id
+// Scans all columns from SELECT tags.*: id, name, created_at, deleted_at, parent_tag_id, public_id
 func (tr *TagRepository) scanTag(rows *sql.Rows) (*models.Tag, error) {
 	tag := &models.Tag{}
 	var parentTagID sql.NullInt64 // parent_tag_id is nullable; not exposed on model yet
@@ -145,6 +155,7 @@ func (tr *TagRepository) scanTag(rows *sql.Rows) (*models.Tag, error) {
 		&tag.CreatedAt,
 		&tag.DeletedAt,
 		&parentTagID,
+		&tag.PublicID,
 	)
 	return tag, err
 }
@@ -163,15 +174,15 @@ func (tr *TagRepository) scanTag(rows *sql.Rows) (*models.Tag, error) {
 //	    Search: map[string]interface{}{
 //	        "name": "run",
 //	    },
-//	    Order: map[string]string{
-//	        "name": "ASC",
+//	    Order: query.Order{
+//	        {Column: "name", Direction: "ASC"},
 //	    },
 //	}
 //	result, err := repo.ListTagsWithQuery(ctx, opts)
 func (tr *TagRepository) ListTagsWithQuery(
 	ctx context.Context,
 	opts *query.QueryOptions,
-) (*query.PaginatedResult, error) {
+) (*query.Page[models.Tag], error) {
 	// Use the generic FindAndPaginate function with our scanTag function
 	return FindAndPaginate[models.Tag](
 		ctx,
@@ -181,3 +192,9 @@ func (tr *TagRepository) ListTagsWithQuery(
 		tr.scanTag,
 	)
 }
+
+// GetCollectionVersion returns the count and latest created_at of the tags matched by opts
+// Tags are never edited in place (only created or soft-deleted), so created_at plus the count is enough
+func (tr *TagRepository) GetCollectionVersion(ctx context.Context, opts *query.QueryOptions) (*CollectionVersion, error) {
+	return FindCollectionVersion(ctx, tr.db, "tags", "created_at", opts)
+}
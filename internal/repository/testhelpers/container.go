@@ -5,14 +5,14 @@ import (
 	"database/sql"
 	"fmt"
 	"io"
+	"io/fs"
 	"log"
-	"os"
-	"path/filepath"
 	"sort"
 	"testing"
 	"time"
 
-	_ "github.com/lib/pq"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/valentinesamuel/activelog/migrations"
 	"github.com/valentinesamuel/activelog/pkg/database"
 
 	"github.com/testcontainers/testcontainers-go"
@@ -50,7 +50,7 @@ func SetupTestDB(t testing.TB) (*database.LoggingDB, func()) {
 	}
 
 	// 3. Connect to database
-	rawDB, err := sql.Open("postgres", connStr)
+	rawDB, err := sql.Open("pgx", connStr)
 	if err != nil {
 		t.Fatalf("❌ Failed to connect to test database: %v", err)
 	}
@@ -65,7 +65,7 @@ func SetupTestDB(t testing.TB) (*database.LoggingDB, func()) {
 	rawDB.SetConnMaxLifetime(5 * time.Minute) // Connections live for 5 minutes max
 
 	// 4. Run migrations
-	if err := runMigrations(t, rawDB); err != nil {
+	if err := RunMigrations(t, rawDB); err != nil {
 		rawDB.Close()
 		postgresContainer.Terminate(ctx)
 		t.Fatalf("❌ Failed to run migrations: %v", err)
@@ -89,68 +89,42 @@ func SetupTestDB(t testing.TB) (*database.LoggingDB, func()) {
 	return db, cleanup
 }
 
-// findProjectRoot walks up the directory tree to find the project root (where go.mod is)
-func findProjectRoot() (string, error) {
-	dir, err := os.Getwd()
-	if err != nil {
-		return "", err
-	}
-
-	// Walk up the directory tree looking for go.mod
-	for {
-		goModPath := filepath.Join(dir, "go.mod")
-		if _, err := os.Stat(goModPath); err == nil {
-			return dir, nil
-		}
-
-		// Move up one directory
-		parent := filepath.Dir(dir)
-		if parent == dir {
-			// Reached root without finding go.mod
-			return "", fmt.Errorf("could not find project root (go.mod not found)")
-		}
-		dir = parent
+// SetupIntegrationDB is SetupTestDB for tests that need PostgreSQL itself,
+// e.g. for COPY or constraints. They are skipped with -short and where Docker
+// isn't available.
+func SetupIntegrationDB(t *testing.T) (*database.LoggingDB, func()) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("starts a PostgreSQL container")
 	}
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+	return SetupTestDB(t)
 }
 
-// runMigrations reads and executes all .up.sql migration files in order
-func runMigrations(t testing.TB, db *sql.DB) error {
+// RunMigrations executes the embedded .up.sql migration files in order
+func RunMigrations(t testing.TB, db *sql.DB) error {
 	t.Helper()
 
-	// Find project root by looking for go.mod
-	projectRoot, err := findProjectRoot()
-	if err != nil {
-		return fmt.Errorf("failed to find project root: %w", err)
-	}
-
-	// Migrations are in the migrations/ directory at project root
-	migrationsDir := filepath.Join(projectRoot, "migrations")
-
-	// Verify migrations directory exists
-	if _, err := os.Stat(migrationsDir); os.IsNotExist(err) {
-		return fmt.Errorf("migrations directory not found at %s", migrationsDir)
-	}
-
 	// Read all migration files
-	files, err := filepath.Glob(filepath.Join(migrationsDir, "*.up.sql"))
+	files, err := fs.Glob(migrations.FS, "*.up.sql")
 	if err != nil {
 		return fmt.Errorf("failed to read migration files: %w", err)
 	}
 
 	if len(files) == 0 {
-		return fmt.Errorf("no migration files found in %s", migrationsDir)
+		return fmt.Errorf("no migration files found")
 	}
 
-	t.Logf("📂 Found %d migration files in %s", len(files), migrationsDir)
+	t.Logf("📂 Found %d migration files", len(files))
 
 	// Sort files to ensure correct order (000001, 000002, etc.)
 	sort.Strings(files)
 
 	// Execute each migration file
 	for _, file := range files {
-		t.Logf("Running migration: %s", filepath.Base(file))
+		t.Logf("Running migration: %s", file)
 
-		content, err := os.ReadFile(file)
+		content, err := fs.ReadFile(migrations.FS, file)
 		if err != nil {
 			return fmt.Errorf("failed to read migration file %s: %w", file, err)
 		}
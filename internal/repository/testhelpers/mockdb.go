@@ -0,0 +1,31 @@
+package testhelpers
+
+import (
+	"io"
+	"log"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/valentinesamuel/activelog/pkg/database"
+)
+
+// SetupMockDB returns a LoggingDB over go-sqlmock, for testing repositories
+// (and the handlers on them) without a PostgreSQL container, and the mock to
+// set query expectations on. Unlike a plain *sql.DB it supports
+// repository.WithTransaction. The test fails if any expectation is unmet.
+func SetupMockDB(t testing.TB) (*database.LoggingDB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	rawDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unmet database expectations: %v", err)
+		}
+		rawDB.Close()
+	})
+
+	return database.NewLoggingDB(rawDB, log.New(io.Discard, "", 0)), mock
+}
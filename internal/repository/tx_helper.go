@@ -3,8 +3,11 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
 
 	"github.com/valentinesamuel/activelog/pkg/database"
+	"github.com/valentinesamuel/activelog/pkg/dberr"
+	"github.com/valentinesamuel/activelog/pkg/query"
 )
 
 // WithTransaction is a helper function to execute multiple repository operations in a transaction
@@ -66,3 +69,65 @@ func QueryInTx(ctx context.Context, tx TxConn, db DBConn, query string, args ...
 	}
 	return db.QueryContext(ctx, query, args...)
 }
+
+// hintedConn is the connection WithHints passes on: its transaction, with
+// the raw database of the connection it was begun on
+type hintedConn struct {
+	TxConn
+	raw *sql.DB
+}
+
+// GetRawDB returns the database the transaction was begun on
+func (c *hintedConn) GetRawDB() *sql.DB {
+	return c.raw
+}
+
+// WithHints runs fn on a connection the hints apply to: a read-only
+// transaction that starts with their SET LOCAL statements (see query.Hints),
+// so they end with fn. Hints are for heavy reads; a write in fn fails. Errors are translated with dberr, so a query cut off by its statement
+// timeout returns dberr.ErrStatementTimeout.
+//
+// fn gets db itself when there are no hints, when db is already hinted, and
+// when db can't begin transactions (plain *sql.DB in tests), in which case
+// the hints are ignored.
+//
+// Usage:
+//
+//	hints := query.NewHints(query.WithStatementTimeout(5 * time.Second))
+//	err := repository.WithHints(ctx, db, hints, func(db DBConn) error {
+//	    rows, err := db.QueryContext(ctx, heavyQuery)
+//	    ...
+//	})
+func WithHints(ctx context.Context, db DBConn, hints query.Hints, fn func(db DBConn) error) error {
+	if hints.IsZero() {
+		return fn(db)
+	}
+	if _, hinted := db.(*hintedConn); hinted {
+		return fn(db)
+	}
+	loggingDB, ok := db.(*database.LoggingDB)
+	if !ok {
+		return fn(db)
+	}
+
+	statements, err := hints.Statements()
+	if err != nil {
+		return err
+	}
+	tx, err := loggingDB.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to apply query hints: %w", err)
+		}
+	}
+
+	if err := fn(&hintedConn{TxConn: tx, raw: loggingDB.GetRawDB()}); err != nil {
+		_ = tx.Rollback()
+		return dberr.Translate(err)
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,46 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/internal/repository/testhelpers"
+	"github.com/valentinesamuel/activelog/pkg/query"
+)
+
+func TestWithHints_AppliesHintsInTransaction(t *testing.T) {
+	db, mock := testhelpers.SetupMockDB(t)
+	mock.ExpectBegin()
+	mock.ExpectExec(`SET LOCAL statement_timeout = 5000`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT 1`).
+		WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(1))
+	mock.ExpectCommit()
+
+	hints := query.NewHints(query.WithStatementTimeout(5 * time.Second))
+	err := repository.WithHints(context.Background(), db, hints, func(db repository.DBConn) error {
+		var n int
+		return db.QueryRowContext(context.Background(), `SELECT 1`).Scan(&n)
+	})
+	require.NoError(t, err)
+}
+
+func TestWithHints_ReadOnly(t *testing.T) {
+	db, cleanup := testhelpers.SetupIntegrationDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	hints := query.NewHints(query.WithStatementTimeout(5 * time.Second))
+	err := repository.WithHints(ctx, db, hints, func(db repository.DBConn) error {
+		_, err := db.ExecContext(ctx, `INSERT INTO users (email, username, password_hash) VALUES ('ro@example.com', 'ro', 'x')`)
+		return err
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "read-only transaction")
+}
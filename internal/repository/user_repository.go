@@ -2,12 +2,13 @@ package repository
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 
 	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/pkg/dberr"
 	"github.com/valentinesamuel/activelog/pkg/errors"
 	"github.com/valentinesamuel/activelog/pkg/query"
+	"github.com/valentinesamuel/activelog/pkg/ulid"
 )
 
 type UserRepository struct {
@@ -33,18 +34,16 @@ func (ur *UserRepository) GetRegistry() *query.RelationshipRegistry {
 func (ar *UserRepository) CreateUser(ctx context.Context, user *models.User) error {
 	query := `
 		INSERT INTO users
-		(email, username, password_hash) 
-		VALUES ($1, $2, $3)
+		(email, username, password_hash, public_id) 
+		VALUES ($1, $2, $3, $4)
 		RETURNING email, created_at, updated_at;
 	`
 
-	err := ar.db.QueryRowContext(ctx, query, user.Email, user.Username, user.PasswordHash).Scan(&user.Email, &user.CreatedAt, &user.UpdatedAt)
+	user.PublicID = ulid.New()
+	err := ar.db.QueryRowContext(ctx, query, user.Email, user.Username, user.PasswordHash, user.PublicID).Scan(&user.Email, &user.CreatedAt, &user.UpdatedAt)
 
 	if err != nil {
-		if mapped := mapPgError(err); mapped != nil {
-			return mapped
-		}
-		return &errors.DatabaseError{Op: "INSERT", Table: "users", Err: err}
+		return dberr.Translate(&errors.DatabaseError{Op: "INSERT", Table: "users", Err: err})
 	}
 
 	fmt.Println("✅ User created successfully!")
@@ -55,31 +54,97 @@ func (ar *UserRepository) CreateUser(ctx context.Context, user *models.User) err
 func (ar *UserRepository) FindUserByEmail(ctx context.Context, email string) (*models.User, error) {
 	query := `
 		SELECT 
-		id, username, email, password_hash
+		id, public_id, username, email, COALESCE(password_hash, '')
 		FROM users
-		WHERE email = $1
+		WHERE email = $1 AND deleted_at IS NULL
 	`
 
 	user := &models.User{}
 
-	err := ar.db.QueryRowContext(ctx, query, email).Scan(&user.ID, &user.Username, &user.Email, &user.PasswordHash)
-
-	if err == sql.ErrNoRows {
-		return nil, errors.ErrNotFound
-	}
+	err := ar.db.QueryRowContext(ctx, query, email).Scan(&user.ID, &user.PublicID, &user.Username, &user.Email, &user.PasswordHash)
 
 	if err != nil {
-		if mapped := mapPgError(err); mapped != nil {
-			return nil, mapped
-		}
-		return nil, &errors.DatabaseError{
+		return nil, dberr.Translate(&errors.DatabaseError{
 			Op:    "SELECT",
-			Table: "user",
+			Table: "users",
 			Err:   err,
-		}
+		})
 	}
 
 	fmt.Println("✅ User found successfully!")
 
 	return user, nil
 }
+
+// IDByPublicID returns the ID of the active user with the given public ID,
+// or ErrNotFound
+func (ur *UserRepository) IDByPublicID(ctx context.Context, publicID string) (int64, error) {
+	var id int64
+	err := ur.db.QueryRowContext(ctx, `SELECT id FROM users WHERE public_id = $1 AND deleted_at IS NULL`, publicID).Scan(&id)
+	if err != nil {
+		return 0, dberr.Translate(&errors.DatabaseError{Op: "SELECT", Table: "users", Err: err})
+	}
+	return id, nil
+}
+
+// UpdatePasswordHash replaces the stored password hash of a user, e.g. after
+// it was upgraded to the current hashing scheme at login
+func (ur *UserRepository) UpdatePasswordHash(ctx context.Context, userID int64, hash string) error {
+	query := `UPDATE users SET password_hash = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := ur.db.ExecContext(ctx, query, userID, hash)
+	if err != nil {
+		return &errors.DatabaseError{Op: "UPDATE", Table: "users", Err: err}
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// ListTimezones returns the distinct timezones of active users
+// Scheduled jobs use it to work out which timezone cohorts are due
+func (ur *UserRepository) ListTimezones(ctx context.Context) ([]string, error) {
+	query := `SELECT DISTINCT timezone FROM users WHERE deleted_at IS NULL`
+
+	rows, err := ur.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "users", Err: err}
+	}
+	defer rows.Close()
+
+	var timezones []string
+	for rows.Next() {
+		var tz string
+		if err := rows.Scan(&tz); err != nil {
+			return nil, err
+		}
+		timezones = append(timezones, tz)
+	}
+	return timezones, rows.Err()
+}
+
+// ListIDsByTimezones returns the IDs of active users in any of the given timezones
+func (ur *UserRepository) ListIDsByTimezones(ctx context.Context, timezones []string) ([]int, error) {
+	query := `SELECT id FROM users WHERE deleted_at IS NULL AND timezone = ANY($1) ORDER BY id`
+
+	rows, err := ur.db.QueryContext(ctx, query, timezones)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "users", Err: err}
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/lib/pq"
+	"github.com/jackc/pgx/v5/pgtype"
 	webhookTypes "github.com/valentinesamuel/activelog/internal/adapters/webhook/types"
 )
 
+// pgTypes decodes Postgres arrays (webhook events) through database/sql scans.
+var pgTypes = pgtype.NewMap()
+
 // WebhookRepository handles database operations for webhooks
 type WebhookRepository struct {
 	db DBConn
@@ -30,7 +33,7 @@ func (r *WebhookRepository) Create(ctx context.Context, wh *webhookTypes.Webhook
 	return r.db.QueryRowContext(ctx, query,
 		wh.UserID,
 		wh.URL,
-		pq.Array(wh.Events),
+		wh.Events,
 		wh.Secret,
 		wh.Active,
 	).Scan(&wh.ID, &wh.CreatedAt)
@@ -53,6 +56,15 @@ func (r *WebhookRepository) Delete(ctx context.Context, id string, userID int) e
 	return nil
 }
 
+// DeleteByUserID removes all of a user's webhooks and, through the foreign
+// key, their deliveries
+func (r *WebhookRepository) DeleteByUserID(ctx context.Context, userID int) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM webhooks WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete webhooks: %w", err)
+	}
+	return nil
+}
+
 // ListByUserID returns all webhooks for a user
 func (r *WebhookRepository) ListByUserID(ctx context.Context, userID int) ([]*webhookTypes.Webhook, error) {
 	query := `
@@ -69,7 +81,7 @@ func (r *WebhookRepository) ListByUserID(ctx context.Context, userID int) ([]*we
 	for rows.Next() {
 		wh := &webhookTypes.Webhook{}
 		if err := rows.Scan(
-			&wh.ID, &wh.UserID, &wh.URL, pq.Array(&wh.Events), &wh.Secret, &wh.Active, &wh.CreatedAt,
+			&wh.ID, &wh.UserID, &wh.URL, pgTypes.SQLScanner(&wh.Events), &wh.Secret, &wh.Active, &wh.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -94,7 +106,7 @@ func (r *WebhookRepository) ListByEvent(ctx context.Context, eventType string) (
 	for rows.Next() {
 		wh := &webhookTypes.Webhook{}
 		if err := rows.Scan(
-			&wh.ID, &wh.UserID, &wh.URL, pq.Array(&wh.Events), &wh.Secret, &wh.Active, &wh.CreatedAt,
+			&wh.ID, &wh.UserID, &wh.URL, pgTypes.SQLScanner(&wh.Events), &wh.Secret, &wh.Active, &wh.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -111,7 +123,7 @@ func (r *WebhookRepository) GetByID(ctx context.Context, id string) (*webhookTyp
 
 	wh := &webhookTypes.Webhook{}
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&wh.ID, &wh.UserID, &wh.URL, pq.Array(&wh.Events), &wh.Secret, &wh.Active, &wh.CreatedAt,
+		&wh.ID, &wh.UserID, &wh.URL, pgTypes.SQLScanner(&wh.Events), &wh.Secret, &wh.Active, &wh.CreatedAt,
 	)
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("webhook not found: %s", id)
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+)
+
+// WorkerHeartbeatRepository stores the heartbeats of worker processes.
+// Timestamps come from the database clock, so hosts with skewed clocks agree
+// on which workers are alive.
+type WorkerHeartbeatRepository struct {
+	db DBConn
+}
+
+// NewWorkerHeartbeatRepository creates a new WorkerHeartbeatRepository.
+func NewWorkerHeartbeatRepository(db DBConn) *WorkerHeartbeatRepository {
+	return &WorkerHeartbeatRepository{db: db}
+}
+
+// Beat records the worker's current status. Alive is ignored.
+func (r *WorkerHeartbeatRepository) Beat(ctx context.Context, hb *models.WorkerHeartbeat) error {
+	query := `
+		INSERT INTO worker_heartbeats (worker_id, hostname, pid, queue_provider, queue_error, handlers, in_flight, processed, failed, started_at, last_seen_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, CURRENT_TIMESTAMP)
+		ON CONFLICT (worker_id) DO UPDATE SET
+			hostname = EXCLUDED.hostname,
+			pid = EXCLUDED.pid,
+			queue_provider = EXCLUDED.queue_provider,
+			queue_error = EXCLUDED.queue_error,
+			handlers = EXCLUDED.handlers,
+			in_flight = EXCLUDED.in_flight,
+			processed = EXCLUDED.processed,
+			failed = EXCLUDED.failed,
+			started_at = EXCLUDED.started_at,
+			last_seen_at = CURRENT_TIMESTAMP`
+
+	_, err := r.db.ExecContext(ctx, query, hb.ID, hb.Hostname, hb.PID, hb.QueueProvider, hb.QueueError,
+		hb.Handlers, hb.InFlight, hb.Processed, hb.Failed, hb.StartedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record worker heartbeat: %w", err)
+	}
+	return nil
+}
+
+// List returns every worker that reported, most recently started first. A
+// worker is alive if it reported within aliveWithin.
+func (r *WorkerHeartbeatRepository) List(ctx context.Context, aliveWithin time.Duration) ([]models.WorkerHeartbeat, error) {
+	query := `
+		SELECT worker_id, hostname, pid, queue_provider, queue_error, handlers, in_flight, processed, failed,
+			started_at, last_seen_at, last_seen_at >= CURRENT_TIMESTAMP - make_interval(secs => $1)
+		FROM worker_heartbeats
+		ORDER BY started_at DESC, worker_id`
+
+	rows, err := r.db.QueryContext(ctx, query, aliveWithin.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list worker heartbeats: %w", err)
+	}
+	defer rows.Close()
+
+	workers := []models.WorkerHeartbeat{}
+	for rows.Next() {
+		var hb models.WorkerHeartbeat
+		if err := rows.Scan(&hb.ID, &hb.Hostname, &hb.PID, &hb.QueueProvider, &hb.QueueError, &hb.Handlers,
+			&hb.InFlight, &hb.Processed, &hb.Failed, &hb.StartedAt, &hb.LastSeenAt, &hb.Alive); err != nil {
+			return nil, fmt.Errorf("failed to scan worker heartbeat: %w", err)
+		}
+		workers = append(workers, hb)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list worker heartbeats: %w", err)
+	}
+	return workers, nil
+}
+
+// Delete removes the worker's heartbeat, when it stops.
+func (r *WorkerHeartbeatRepository) Delete(ctx context.Context, workerID string) error {
+	query := `DELETE FROM worker_heartbeats WHERE worker_id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, workerID); err != nil {
+		return fmt.Errorf("failed to delete worker heartbeat: %w", err)
+	}
+	return nil
+}
+
+// Prune removes the heartbeats of workers silent for longer than olderThan,
+// which crashed without deleting theirs, and returns how many it removed.
+func (r *WorkerHeartbeatRepository) Prune(ctx context.Context, olderThan time.Duration) (int64, error) {
+	query := `DELETE FROM worker_heartbeats WHERE last_seen_at < CURRENT_TIMESTAMP - make_interval(secs => $1)`
+
+	result, err := r.db.ExecContext(ctx, query, olderThan.Seconds())
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune worker heartbeats: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/pkg/dberr"
+	"github.com/valentinesamuel/activelog/pkg/errors"
+	"github.com/valentinesamuel/activelog/pkg/query"
+)
+
+// WorkoutRepository handles database operations for planned workouts: the
+// workouts users define and the days they assign them to
+type WorkoutRepository struct {
+	db         DBConn
+	validation *query.EntityValidation
+}
+
+// PlannedSession is a planned workout with the targets of its workout and
+// the activity matched to it, if any
+type PlannedSession struct {
+	WorkoutID              int64
+	PlannedOn              time.Time
+	PlannedDurationMinutes int
+	PlannedDistanceKm      *float64
+	Match                  *models.WorkoutMatch
+}
+
+// SessionFilter narrows ListSessions to one workout and/or a range of days
+// (inclusive); unset fields don't filter
+type SessionFilter struct {
+	WorkoutID *int64
+	From      *time.Time
+	To        *time.Time
+}
+
+// NewWorkoutRepository creates a new WorkoutRepository
+func NewWorkoutRepository(db DBConn) *WorkoutRepository {
+	validation := query.NewEntityValidation("workouts")
+	validation.Column("activity_type", query.ColumnRule{Filter: true, Operators: query.EqualityOperators(), Type: query.TypeString})
+	validation.Column("name", query.ColumnRule{Filter: true, Order: true, Operators: query.EqualityOperators(), Type: query.TypeString})
+	validation.Column("created_at", query.ColumnRule{Order: true, Type: query.TypeTimestamp})
+	validation.Alias("type", "activity_type")
+
+	return &WorkoutRepository{db: db, validation: validation}
+}
+
+// GetValidation returns the query whitelist of workouts
+func (r *WorkoutRepository) GetValidation() *query.EntityValidation {
+	return r.validation
+}
+
+const workoutColumns = `id, user_id, name, activity_type, description, steps,
+	planned_duration_minutes, planned_distance_km, created_at, updated_at`
+
+// Create inserts a workout; its planned totals must already be computed
+func (r *WorkoutRepository) Create(ctx context.Context, workout *models.Workout) error {
+	query := `
+		INSERT INTO workouts (user_id, name, activity_type, description, steps, planned_duration_minutes, planned_distance_km)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		workout.UserID,
+		workout.Name,
+		workout.ActivityType,
+		workout.Description,
+		workout.Steps,
+		workout.PlannedDurationMinutes,
+		workout.PlannedDistanceKm,
+	).Scan(&workout.ID, &workout.CreatedAt, &workout.UpdatedAt)
+	if err != nil {
+		return dberr.Translate(&errors.DatabaseError{Op: "INSERT", Table: "workouts", Err: err})
+	}
+	return nil
+}
+
+// GetByID returns one of the user's workouts, or ErrNotFound
+func (r *WorkoutRepository) GetByID(ctx context.Context, userID int, id int64) (*models.Workout, error) {
+	query := `SELECT ` + workoutColumns + ` FROM workouts WHERE id = $1 AND user_id = $2`
+
+	workout := &models.Workout{}
+	err := r.db.QueryRowContext(ctx, query, id, userID).Scan(workoutScanDest(workout)...)
+	if err != nil {
+		return nil, dberr.Translate(&errors.DatabaseError{Op: "SELECT", Table: "workouts", Err: err})
+	}
+	return workout, nil
+}
+
+// Delete removes one of the user's workouts and its schedule, or returns
+// ErrNotFound
+func (r *WorkoutRepository) Delete(ctx context.Context, userID int, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM workouts WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return &errors.DatabaseError{Op: "DELETE", Table: "workouts", Err: err}
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// ListWorkoutsWithQuery returns workouts using the dynamic filtering pattern
+// with QueryOptions. Callers scope the list to a user by setting
+// Filter["user_id"].
+func (r *WorkoutRepository) ListWorkoutsWithQuery(ctx context.Context, opts *query.QueryOptions) (*query.Page[models.Workout], error) {
+	return FindAndPaginate[models.Workout](
+		ctx,
+		r.db,
+		"workouts",
+		opts,
+		r.scanWorkout,
+	)
+}
+
+// Schedule assigns a workout to a day. Assigning it to the same day twice
+// fails with a unique violation.
+func (r *WorkoutRepository) Schedule(ctx context.Context, planned *models.PlannedWorkout) error {
+	query := `
+		INSERT INTO planned_workouts (workout_id, user_id, planned_on)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(ctx, query, planned.WorkoutID, planned.UserID, planned.PlannedOn).
+		Scan(&planned.ID, &planned.CreatedAt)
+	if err != nil {
+		return dberr.Translate(&errors.DatabaseError{Op: "INSERT", Table: "planned_workouts", Err: err})
+	}
+	return nil
+}
+
+// Unschedule removes a workout from a day, or returns ErrNotFound
+func (r *WorkoutRepository) Unschedule(ctx context.Context, userID int, workoutID int64, day time.Time) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM planned_workouts WHERE workout_id = $1 AND user_id = $2 AND planned_on = $3`,
+		workoutID, userID, day)
+	if err != nil {
+		return &errors.DatabaseError{Op: "DELETE", Table: "planned_workouts", Err: err}
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// ListSessions returns the user's planned workouts in day order, each with
+// the activity that completed it: a live activity of the workout's type
+// (ignoring case) on the planned day. When there are several, the one whose
+// duration is closest to the plan wins.
+func (r *WorkoutRepository) ListSessions(ctx context.Context, userID int, filter SessionFilter) ([]PlannedSession, error) {
+	query := `
+		SELECT p.workout_id, p.planned_on, w.planned_duration_minutes, w.planned_distance_km::float8,
+			a.public_id, a.duration_minutes, a.distance_km::float8
+		FROM planned_workouts p
+		JOIN workouts w ON w.id = p.workout_id
+		LEFT JOIN LATERAL (
+			SELECT public_id, COALESCE(duration_minutes, 0) AS duration_minutes, COALESCE(distance_km, 0) AS distance_km
+			FROM activities
+			WHERE user_id = p.user_id
+				AND deleted_at IS NULL
+				AND lower(activity_type) = lower(w.activity_type)
+				AND activity_date >= p.planned_on
+				AND activity_date < p.planned_on + 1
+			ORDER BY abs(COALESCE(duration_minutes, 0) - w.planned_duration_minutes), id
+			LIMIT 1
+		) a ON true
+		WHERE p.user_id = $1
+			AND ($2::bigint IS NULL OR p.workout_id = $2)
+			AND ($3::date IS NULL OR p.planned_on >= $3)
+			AND ($4::date IS NULL OR p.planned_on <= $4)
+		ORDER BY p.planned_on, p.workout_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, filter.WorkoutID, filter.From, filter.To)
+	if err != nil {
+		return nil, &errors.DatabaseError{Op: "SELECT", Table: "planned_workouts", Err: err}
+	}
+	defer rows.Close()
+
+	sessions := []PlannedSession{}
+	for rows.Next() {
+		var (
+			session    PlannedSession
+			activityID sql.NullString
+			duration   sql.NullInt64
+			distance   sql.NullFloat64
+		)
+		if err := rows.Scan(&session.WorkoutID, &session.PlannedOn, &session.PlannedDurationMinutes,
+			&session.PlannedDistanceKm, &activityID, &duration, &distance); err != nil {
+			return nil, &errors.DatabaseError{Op: "SCAN", Table: "planned_workouts", Err: err}
+		}
+		if activityID.Valid {
+			session.Match = &models.WorkoutMatch{
+				ActivityID:      activityID.String,
+				DurationMinutes: int(duration.Int64),
+				DistanceKm:      distance.Float64,
+			}
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, rows.Err()
+}
+
+// scanWorkout scans a single row from SELECT workouts.*
+func (r *WorkoutRepository) scanWorkout(rows *sql.Rows) (*models.Workout, error) {
+	workout := &models.Workout{}
+	err := rows.Scan(workoutScanDest(workout)...)
+	return workout, err
+}
+
+// workoutScanDest returns the scan destinations for workoutColumns
+func workoutScanDest(workout *models.Workout) []interface{} {
+	return []interface{}{
+		&workout.ID,
+		&workout.UserID,
+		&workout.Name,
+		&workout.ActivityType,
+		&workout.Description,
+		&workout.Steps,
+		&workout.PlannedDurationMinutes,
+		&workout.PlannedDistanceKm,
+		&workout.CreatedAt,
+		&workout.UpdatedAt,
+	}
+}
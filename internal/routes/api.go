@@ -0,0 +1,330 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	httpSwagger "github.com/swaggo/http-swagger"
+
+	appwebsocket "github.com/valentinesamuel/activelog/internal/adapters/websocket"
+	"github.com/valentinesamuel/activelog/internal/handlers"
+	"github.com/valentinesamuel/activelog/internal/middleware"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+	"github.com/valentinesamuel/activelog/pkg/response"
+)
+
+// Route groups of the API
+const (
+	GroupSystem        = "system"        // probes, metrics and docs; no rate limit
+	GroupPublic        = "public"        // no login required
+	GroupAuthenticated = "authenticated" // valid JWT or session cookie
+	GroupAdmin         = "admin"         // authenticated admins (AUTH_ADMIN_EMAILS)
+)
+
+// Handlers holds the HTTP handlers the API routes to
+type Handlers struct {
+	Root        http.HandlerFunc
+	OpenAPISpec http.HandlerFunc
+
+	Health       *handlers.HealthHandler
+	Activity     *handlers.ActivityHandler
+	User         *handlers.UserHandler
+	Identity     *handlers.IdentityHandler
+	Stats        *handlers.StatsHandler
+	Photo        *handlers.ActivityPhotoHandler
+	Export       *handlers.ExportHandler
+	Import       *handlers.ImportHandler
+	Job          *handlers.JobHandler
+	Sync         *handlers.SyncHandler
+	Account      *handlers.AccountHandler
+	Features     *handlers.FeaturesHandler
+	Webhook      *handlers.WebhookHandler
+	Group        *handlers.GroupHandler
+	Share        *handlers.ShareHandler
+	Tag          *handlers.TagHandler
+	Profile      *handlers.ProfileHandler
+	PrivacyZone  *handlers.PrivacyZoneHandler
+	APIKey       *handlers.APIKeyHandler
+	Track        *handlers.ActivityTrackHandler
+	Reaction     *handlers.ReactionHandler
+	BodyMetric   *handlers.BodyMetricHandler
+	Workout      *handlers.WorkoutHandler
+	Coach        *handlers.CoachHandler
+	Challenge    *handlers.ChallengeHandler
+	Achievement  *handlers.AchievementHandler
+	ActivityType *handlers.ActivityTypeHandler
+	IndexAdvisor *handlers.IndexAdvisorHandler
+	QueueDebug   *handlers.QueueDebugHandler
+	Search       *handlers.SearchHandler
+	File         *handlers.FileHandler
+	Worker       *handlers.WorkerHandler
+	WebSocket    *appwebsocket.Handler
+
+	// Resolve the public IDs accepted in place of serial IDs in paths
+	ActivityIDs middleware.PublicIDResolver
+	ShareIDs    middleware.PublicIDResolver
+	UserIDs     middleware.PublicIDResolver
+
+	// Checks the grants athletes give their coaches
+	CoachAccess middleware.CoachAccessChecker
+
+	// Looks up the users of API keys; nil accepts JWTs only
+	APIKeys middleware.APIKeyAuthenticator
+
+	// Tells when JWTs expire; nil uses the real clock
+	Clock clock.Clock
+}
+
+// API declares every route of the API. rateLimit is the rate limiting
+// middleware; it runs after auth so authenticated requests are counted per
+// user rather than per IP.
+func API(h Handlers, rateLimit mux.MiddlewareFunc) *Registry {
+	reg := NewRegistry()
+
+	auth := Named("auth", middleware.Authenticate(h.APIKeys, h.Clock))
+	limit := Named("rate_limit", rateLimit)
+	admin := Named("require_admin", middleware.RequireAdmin)
+	conditionalGET := Named("conditional_get", middleware.ConditionalGET)
+	activityID := Named("resolve_activity_id", middleware.ResolvePublicID("id", h.ActivityIDs))
+	shareID := Named("resolve_share_id", middleware.ResolvePublicID("shareId", h.ShareIDs))
+	userID := Named("resolve_user_id", middleware.ResolvePublicID("userId", h.UserIDs))
+	coachID := Named("resolve_coach_id", middleware.ResolvePublicID("coachId", h.UserIDs))
+	athleteID := Named("resolve_athlete_id", middleware.ResolvePublicID("athleteId", h.UserIDs))
+	coachRead := Named("require_coach_read", middleware.RequireCoachAccess("athleteId", models.CoachScopeRead, h.CoachAccess))
+	coachComment := Named("require_coach_comment", middleware.RequireCoachAccess("athleteId", models.CoachScopeComment, h.CoachAccess))
+
+	// Health and root endpoints, metrics, and the OpenAPI spec (generated
+	// into docs/ by `go generate ./docs`) with Swagger UI
+	system := reg.Group(GroupSystem, "")
+	system.Handle(http.MethodGet, "/health", h.Health)
+	system.HandleFunc(http.MethodGet, "/", h.Root)
+	system.Handle(http.MethodGet, "/metrics", promhttp.Handler())
+	system.HandleFunc(http.MethodGet, "/api/v1/openapi.json", h.OpenAPISpec)
+	system.Handle(http.MethodGet, "/docs", http.RedirectHandler("/docs/index.html", http.StatusMovedPermanently))
+	system.HandlePrefix("/docs/", httpSwagger.Handler(httpSwagger.URL("/api/v1/openapi.json")))
+	system.HandlePrefix("/swagger/", httpSwagger.WrapHandler)
+
+	public := reg.Group(GroupPublic, "", limit)
+
+	// Public share links
+	public.HandleFunc(http.MethodGet, "/share/{token}", h.Share.GetSharedActivity)
+
+	// Stored photos and exports; the signed URL is the authorization
+	public.HandleFunc(http.MethodGet, "/api/v1/files/{key}", h.File.ServeFile)
+
+	// Auth, including social login; Apple posts its callback
+	authRoutes := public.Group("/api/v1/auth")
+	authRoutes.HandleFunc(http.MethodPost, "/register", h.User.CreateUser)
+	authRoutes.HandleFunc(http.MethodPost, "/login", h.User.LoginUser)
+	authRoutes.HandleFunc(http.MethodPost, "/logout", h.User.LogoutUser)
+	authRoutes.HandleFunc(http.MethodGet, "/providers", h.Identity.ListProviders)
+	authRoutes.HandleFunc(http.MethodGet, "/{provider}/login", h.Identity.Login)
+	authRoutes.HandleFunc(http.MethodGet, "/{provider}/callback", h.Identity.Callback)
+	authRoutes.HandleFunc(http.MethodPost, "/{provider}/callback", h.Identity.Callback)
+
+	authenticated := reg.Group(GroupAuthenticated, "", auth, limit)
+	api := authenticated.Group("/api/v1")
+
+	activities := api.Group("/activities", conditionalGET, activityID, shareID)
+	activities.HandleFunc(http.MethodGet, "", h.Activity.ListActivities)
+	activities.HandleFunc(http.MethodPost, "", h.Activity.CreateActivity)
+	activities.HandleFunc(http.MethodPatch, "", h.Activity.BulkUpdateActivities)
+	activities.HandleFunc(http.MethodDelete, "", h.Activity.BulkDeleteActivitiesByFilter)
+	activities.HandleFunc(http.MethodPost, "/batch", h.Activity.BatchCreateActivities)
+	activities.HandleFunc(http.MethodDelete, "/batch", h.Activity.BatchDeleteActivities)
+	activities.HandleFunc(http.MethodPost, "/import", h.Import.EnqueueImport)
+	activities.HandleFunc(http.MethodPost, "/import/file", h.Import.UploadImport)
+	activities.HandleFunc(http.MethodGet, "/stats", h.Activity.GetStats)
+	activities.HandleFunc(http.MethodGet, "/duplicates", h.Activity.ListDuplicates)
+	activities.HandleFunc(http.MethodPost, "/merge", h.Activity.MergeActivities)
+	activities.HandleFunc(http.MethodGet, "/{id}", h.Activity.GetActivity)
+	activities.HandleFunc(http.MethodPatch, "/{id}", h.Activity.UpdateActivity)
+	activities.HandleFunc(http.MethodDelete, "/{id}", h.Activity.DeleteActivity)
+	activities.HandleFunc(http.MethodGet, "/{id}/export", h.Export.ExportActivity)
+	activities.HandleFunc(http.MethodGet, "/{id}/track", h.Track.GetTrack)
+	activities.HandleFunc(http.MethodPost, "/{id}/photos", h.Photo.Upload)
+	activities.HandleFunc(http.MethodGet, "/{id}/photos", h.Photo.GetActivityPhoto)
+	activities.HandleFunc(http.MethodPost, "/{id}/share", h.Share.CreateShare)
+	activities.HandleFunc(http.MethodGet, "/{id}/shares", h.Share.ListShares)
+	activities.HandleFunc(http.MethodDelete, "/{id}/shares/{shareId}", h.Share.RevokeShare)
+	activities.HandleFunc(http.MethodPost, "/{id}/reactions", h.Reaction.React)
+	activities.HandleFunc(http.MethodDelete, "/{id}/reactions", h.Reaction.RemoveReaction)
+
+	tags := api.Group("/tags", conditionalGET)
+	tags.HandleFunc(http.MethodGet, "", h.Tag.ListTags)
+
+	stats := api.Group("/stats")
+	stats.HandleFunc(http.MethodGet, "/weekly", h.Stats.GetWeeklyStats)
+	stats.HandleFunc(http.MethodGet, "/monthly", h.Stats.GetMonthlyStats)
+	stats.HandleFunc(http.MethodGet, "/by-type", h.Stats.GetActivityCountByType)
+	stats.HandleFunc(http.MethodGet, "/timeseries", h.Stats.GetTimeSeries)
+	stats.HandleFunc(http.MethodGet, "/training-load", h.Stats.GetTrainingLoad)
+	stats.HandleFunc(http.MethodGet, "/heatmap", h.Stats.GetHeatmap)
+	stats.HandleFunc(http.MethodGet, "/rpe-vs-duration", h.Stats.GetRPEVsDuration)
+	stats.HandleFunc(http.MethodGet, "/best-splits", h.Stats.GetBestSplits)
+
+	users := api.Group("/users/me")
+	users.HandleFunc(http.MethodGet, "", h.Profile.GetProfile)
+	users.HandleFunc(http.MethodPatch, "", h.Profile.UpdateProfile)
+	users.HandleFunc(http.MethodPut, "/avatar", h.Profile.UploadAvatar)
+	users.HandleFunc(http.MethodDelete, "/avatar", h.Profile.DeleteAvatar)
+	users.HandleFunc(http.MethodGet, "/heart-rate-zones", h.Profile.GetHeartRateZones)
+	users.HandleFunc(http.MethodPut, "/heart-rate-zones", h.Profile.UpdateHeartRateZones)
+	users.HandleFunc(http.MethodGet, "/privacy-zones", h.PrivacyZone.ListZones)
+	users.HandleFunc(http.MethodPost, "/privacy-zones", h.PrivacyZone.CreateZone)
+	users.HandleFunc(http.MethodPatch, "/privacy-zones/{id}", h.PrivacyZone.UpdateZone)
+	users.HandleFunc(http.MethodDelete, "/privacy-zones/{id}", h.PrivacyZone.DeleteZone)
+	users.HandleFunc(http.MethodGet, "/api-keys", h.APIKey.ListKeys)
+	users.HandleFunc(http.MethodPost, "/api-keys", h.APIKey.CreateKey)
+	users.HandleFunc(http.MethodDelete, "/api-keys/{id}", h.APIKey.DeleteKey)
+	users.HandleFunc(http.MethodGet, "/identities", h.Identity.ListIdentities)
+	users.HandleFunc(http.MethodDelete, "/identities/{provider}", h.Identity.UnlinkIdentity)
+	users.HandleFunc(http.MethodGet, "/summary", h.Stats.GetUserActivitySummary)
+	users.HandleFunc(http.MethodGet, "/tags/top", h.Stats.GetTopTags)
+	users.HandleFunc(http.MethodGet, "/badges", h.Challenge.ListBadges)
+	// User-scoped aliases of the stats endpoints
+	users.HandleFunc(http.MethodGet, "/stats/weekly", h.Stats.GetWeeklyStats)
+	users.HandleFunc(http.MethodGet, "/stats/monthly", h.Stats.GetMonthlyStats)
+	users.HandleFunc(http.MethodGet, "/stats/by-type", h.Stats.GetActivityCountByType)
+	// GDPR: data export and account deletion
+	users.HandleFunc(http.MethodPost, "/export", h.Account.ExportData)
+	users.HandleFunc(http.MethodDelete, "", h.Account.DeleteAccount)
+
+	exports := api.Group("/activities/export")
+	exports.HandleFunc(http.MethodGet, "/csv", h.Export.ExportCSV)
+	exports.HandleFunc(http.MethodPost, "/pdf", h.Export.EnqueuePDFExport)
+
+	jobs := api.Group("/jobs")
+	jobs.HandleFunc(http.MethodGet, "", h.Job.ListJobs)
+	jobs.HandleFunc(http.MethodGet, "/{jobId}", h.Job.GetJob)
+	jobs.HandleFunc(http.MethodGet, "/{jobId}/events", h.Job.StreamJob)
+	jobs.HandleFunc(http.MethodGet, "/{jobId}/status", h.Export.GetJobStatus)
+	jobs.HandleFunc(http.MethodGet, "/{jobId}/download", h.Export.GetDownloadURL)
+
+	// Imports are started with POST /activities/import
+	imports := api.Group("/imports")
+	imports.HandleFunc(http.MethodGet, "/{importId}", h.Import.GetImportStatus)
+
+	api.HandleFunc(http.MethodGet, "/features", h.Features.GetFeatures)
+	api.HandleFunc(http.MethodGet, "/search", h.Search.Search)
+	api.HandleFunc(http.MethodGet, "/achievements", h.Achievement.ListAchievements)
+
+	webhooks := api.Group("/webhooks")
+	webhooks.HandleFunc(http.MethodPost, "", h.Webhook.CreateWebhook)
+	webhooks.HandleFunc(http.MethodGet, "", h.Webhook.ListWebhooks)
+	webhooks.HandleFunc(http.MethodDelete, "/{id}", h.Webhook.DeleteWebhook)
+
+	groups := api.Group("/groups", userID)
+	groups.HandleFunc(http.MethodPost, "", h.Group.CreateGroup)
+	groups.HandleFunc(http.MethodGet, "", h.Group.ListMyGroups)
+	groups.HandleFunc(http.MethodGet, "/{id}", h.Group.GetGroup)
+	groups.HandleFunc(http.MethodGet, "/{id}/leaderboard", h.Group.GetLeaderboard)
+	groups.HandleFunc(http.MethodGet, "/{id}/members", h.Group.ListMembers)
+	groups.HandleFunc(http.MethodPost, "/{id}/members", h.Group.AddMember)
+	groups.HandleFunc(http.MethodPatch, "/{id}/members/me", h.Group.UpdateMyMembership)
+	groups.HandleFunc(http.MethodDelete, "/{id}/members/{userId}", h.Group.RemoveMember)
+
+	metrics := api.Group("/metrics")
+	metrics.HandleFunc(http.MethodPost, "", h.BodyMetric.CreateMetric)
+	metrics.HandleFunc(http.MethodGet, "", h.BodyMetric.ListMetrics)
+	metrics.HandleFunc(http.MethodGet, "/{id}", h.BodyMetric.GetMetric)
+	metrics.HandleFunc(http.MethodPatch, "/{id}", h.BodyMetric.UpdateMetric)
+	metrics.HandleFunc(http.MethodDelete, "/{id}", h.BodyMetric.DeleteMetric)
+
+	workouts := api.Group("/workouts")
+	workouts.HandleFunc(http.MethodPost, "", h.Workout.CreateWorkout)
+	workouts.HandleFunc(http.MethodGet, "", h.Workout.ListWorkouts)
+	workouts.HandleFunc(http.MethodGet, "/{id}", h.Workout.GetWorkout)
+	workouts.HandleFunc(http.MethodDelete, "/{id}", h.Workout.DeleteWorkout)
+	workouts.HandleFunc(http.MethodPost, "/{id}/schedule", h.Workout.ScheduleWorkout)
+	workouts.HandleFunc(http.MethodDelete, "/{id}/schedule/{date}", h.Workout.UnscheduleWorkout)
+	workouts.HandleFunc(http.MethodGet, "/{id}/compliance", h.Workout.GetCompliance)
+
+	// Coach access: athletes manage their coaches, coaches their athletes.
+	// Reading an athlete's data needs their active grant (require_coach_*).
+	coaching := api.Group("/coaching")
+	coaching.HandleFunc(http.MethodGet, "/dashboard", h.Coach.GetDashboard)
+	coaches := coaching.Group("/coaches", coachID)
+	coaches.HandleFunc(http.MethodPost, "", h.Coach.InviteCoach)
+	coaches.HandleFunc(http.MethodGet, "", h.Coach.ListCoaches)
+	coaches.HandleFunc(http.MethodPatch, "/{coachId}", h.Coach.UpdateCoach)
+	coaches.HandleFunc(http.MethodDelete, "/{coachId}", h.Coach.RevokeCoach)
+	athletes := coaching.Group("/athletes", athleteID)
+	athletes.HandleFunc(http.MethodGet, "", h.Coach.ListAthletes)
+	athletes.HandleFunc(http.MethodPost, "/{athleteId}/accept", h.Coach.AcceptInvitation)
+	athletes.HandleFunc(http.MethodDelete, "/{athleteId}", h.Coach.DropAthlete)
+	athleteData := athletes.Group("/{athleteId}", coachRead)
+	athleteData.HandleFunc(http.MethodGet, "/activities", h.Coach.ListAthleteActivities)
+	athleteData.HandleFunc(http.MethodGet, "/stats/weekly", h.Coach.GetAthleteWeeklyStats)
+	athleteData.Group("", activityID, coachComment).HandleFunc(http.MethodPost, "/activities/{id}/comments", h.Coach.CommentOnActivity)
+
+	challenges := api.Group("/challenges")
+	challenges.HandleFunc(http.MethodPost, "", h.Challenge.CreateChallenge)
+	challenges.HandleFunc(http.MethodGet, "", h.Challenge.ListChallenges)
+	challenges.HandleFunc(http.MethodGet, "/{id}", h.Challenge.GetChallenge)
+	challenges.HandleFunc(http.MethodDelete, "/{id}", h.Challenge.DeleteChallenge)
+	challenges.HandleFunc(http.MethodPost, "/{id}/join", h.Challenge.JoinChallenge)
+	challenges.HandleFunc(http.MethodPost, "/{id}/leave", h.Challenge.LeaveChallenge)
+	challenges.HandleFunc(http.MethodGet, "/{id}/leaderboard", h.Challenge.GetLeaderboard)
+
+	activityTypes := api.Group("/activity-types")
+	activityTypes.HandleFunc(http.MethodGet, "", h.ActivityType.ListActivityTypes)
+	activityTypes.HandleFunc(http.MethodPost, "", h.ActivityType.CreateActivityType)
+	activityTypes.HandleFunc(http.MethodPost, "/merge", h.ActivityType.MergeActivityTypes)
+	activityTypes.HandleFunc(http.MethodPatch, "/{id}", h.ActivityType.UpdateActivityType)
+	activityTypes.HandleFunc(http.MethodDelete, "/{id}", h.ActivityType.DeleteActivityType)
+
+	sync := api.Group("/sync")
+	sync.HandleFunc(http.MethodPost, "/pull", h.Sync.Pull)
+	sync.HandleFunc(http.MethodPost, "/push", h.Sync.Push)
+
+	// WebSocket (JWT via query param or header)
+	authenticated.HandleFunc(http.MethodGet, "/ws", h.WebSocket.ServeWS)
+
+	adminRoutes := reg.Group(GroupAdmin, "/api/v1/admin", auth, limit, admin)
+	adminRoutes.HandleFunc(http.MethodGet, "/routes", reg.serveRouteTable)
+	adminRoutes.HandleFunc(http.MethodGet, "/index-advisor", h.IndexAdvisor.GetReport)
+	adminRoutes.HandleFunc(http.MethodGet, "/queue", h.QueueDebug.GetQueue)
+	adminRoutes.HandleFunc(http.MethodGet, "/workers", h.Worker.ListWorkers)
+	adminRoutes.HandleFunc(http.MethodPost, "/search/reindex", h.Search.Reindex)
+
+	return reg
+}
+
+// routeInfo is a route as listed by the admin route table
+type routeInfo struct {
+	Method     string   `json:"method,omitempty"`
+	Path       string   `json:"path"`
+	Group      string   `json:"group"`
+	Middleware []string `json:"middleware"`
+}
+
+// serveRouteTable handles GET /api/v1/admin/routes
+// @Summary List API routes
+// @Description Returns every registered route with its group and middleware chain (outermost first). Admins only.
+// @Tags Admin
+// @Produce json
+// @Success 200 {array} routes.routeInfo "Route table"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Not an admin"
+// @Security BearerAuth
+// @ID ListRoutes
+// @Router /api/v1/admin/routes [get]
+func (reg *Registry) serveRouteTable(w http.ResponseWriter, r *http.Request) {
+	table := make([]routeInfo, 0, len(reg.routes))
+	for _, route := range reg.routes {
+		path := route.Path
+		if route.Prefix {
+			path += "*"
+		}
+		table = append(table, routeInfo{
+			Method:     route.Method,
+			Path:       path,
+			Group:      route.Group,
+			Middleware: route.MiddlewareNames(),
+		})
+	}
+	response.Success(w, r, http.StatusOK, table)
+}
@@ -0,0 +1,125 @@
+// Package routes declares the HTTP API as route groups. Each group carries
+// its middleware chain (auth, rate limiting, RBAC), so the protection of a
+// route is visible where it is declared instead of being spread over mux
+// subrouters. The same table mounts the server (Registry.Mount) and lists the
+// API for tooling and the OpenAPI checks (Registry.Routes).
+package routes
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Middleware is a named middleware, so route tables can report their chains
+type Middleware struct {
+	Name string
+	Func mux.MiddlewareFunc
+}
+
+// Named wraps fn as a Middleware called name
+func Named(name string, fn mux.MiddlewareFunc) Middleware {
+	return Middleware{Name: name, Func: fn}
+}
+
+// Route is one registered endpoint
+type Route struct {
+	// Method is the HTTP method; prefix routes accept any method
+	Method string
+	// Path is the full mux path template, e.g. /api/v1/activities/{id}
+	Path string
+	// Prefix routes match every path below Path
+	Prefix  bool
+	Group   string
+	Handler http.Handler
+	// Middleware is the group chain, outermost first
+	Middleware []Middleware
+}
+
+// MiddlewareNames returns the names of the route's chain, outermost first
+func (r Route) MiddlewareNames() []string {
+	names := make([]string, len(r.Middleware))
+	for i, mw := range r.Middleware {
+		names[i] = mw.Name
+	}
+	return names
+}
+
+// Registry is an ordered route table. Routes are matched in registration
+// order, so declare literal paths before parameterised siblings.
+type Registry struct {
+	routes []Route
+}
+
+// NewRegistry creates an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Group starts a route group under prefix whose routes run behind mw
+func (reg *Registry) Group(name, prefix string, mw ...Middleware) *Group {
+	return &Group{registry: reg, name: name, prefix: prefix, middleware: mw}
+}
+
+// Routes returns the registered routes in registration order
+func (reg *Registry) Routes() []Route {
+	routes := make([]Route, len(reg.routes))
+	copy(routes, reg.routes)
+	return routes
+}
+
+// Mount registers every route on router, each wrapped in its group's chain.
+// Router-wide middleware (router.Use) runs before any group chain.
+func (reg *Registry) Mount(router *mux.Router) {
+	for _, route := range reg.routes {
+		handler := route.Handler
+		for i := len(route.Middleware) - 1; i >= 0; i-- {
+			handler = route.Middleware[i].Func(handler)
+		}
+
+		if route.Prefix {
+			router.PathPrefix(route.Path).Handler(handler)
+			continue
+		}
+		router.Handle(route.Path, handler).Methods(route.Method)
+	}
+}
+
+// Group is a set of routes sharing a path prefix and a middleware chain
+type Group struct {
+	registry   *Registry
+	name       string
+	prefix     string
+	middleware []Middleware
+}
+
+// Group starts a nested group under prefix. It keeps the parent's name and
+// runs mw after the parent's chain.
+func (g *Group) Group(prefix string, mw ...Middleware) *Group {
+	chain := make([]Middleware, 0, len(g.middleware)+len(mw))
+	chain = append(chain, g.middleware...)
+	chain = append(chain, mw...)
+	return &Group{registry: g.registry, name: g.name, prefix: g.prefix + prefix, middleware: chain}
+}
+
+// Handle registers handler for method on the group prefix + path
+func (g *Group) Handle(method, path string, handler http.Handler) {
+	g.add(Route{Method: method, Path: g.prefix + path, Handler: handler})
+}
+
+// HandleFunc registers handler for method on the group prefix + path
+func (g *Group) HandleFunc(method, path string, handler http.HandlerFunc) {
+	g.Handle(method, path, handler)
+}
+
+// HandlePrefix registers handler for every method and every path under the
+// group prefix + path
+func (g *Group) HandlePrefix(path string, handler http.Handler) {
+	g.add(Route{Path: g.prefix + path, Prefix: true, Handler: handler})
+}
+
+func (g *Group) add(route Route) {
+	route.Group = g.name
+	route.Middleware = g.middleware
+	g.registry.routes = append(g.registry.routes, route)
+}
@@ -0,0 +1,93 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder returns a middleware that appends name to calls when it runs
+func recorder(name string, calls *[]string) Middleware {
+	return Named(name, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*calls = append(*calls, name)
+			next.ServeHTTP(w, r)
+		})
+	})
+}
+
+func TestMount_RunsGroupChainsInOrder(t *testing.T) {
+	var calls []string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "handler:"+mux.Vars(r)["id"])
+	}
+
+	reg := NewRegistry()
+	authenticated := reg.Group("authenticated", "/api", recorder("auth", &calls), recorder("rate_limit", &calls))
+	authenticated.Group("/items", recorder("conditional_get", &calls)).HandleFunc(http.MethodGet, "/{id}", handler)
+	reg.Group("public", "").HandleFunc(http.MethodGet, "/open", handler)
+
+	router := mux.NewRouter()
+	reg.Mount(router)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/items/7", nil))
+	assert.Equal(t, []string{"auth", "rate_limit", "conditional_get", "handler:7"}, calls)
+
+	calls = nil
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/open", nil))
+	assert.Equal(t, []string{"handler:"}, calls, "sibling groups don't share chains")
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/items/7", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestAPI_GroupChains(t *testing.T) {
+	passthrough := func(next http.Handler) http.Handler { return next }
+
+	want := map[string][]string{
+		GroupSystem:        {},
+		GroupPublic:        {"rate_limit"},
+		GroupAuthenticated: {"auth", "rate_limit"},
+		GroupAdmin:         {"auth", "rate_limit", "require_admin"},
+	}
+
+	for _, route := range API(Handlers{}, passthrough).Routes() {
+		chain, ok := want[route.Group]
+		require.True(t, ok, "%s %s is in unknown group %q", route.Method, route.Path, route.Group)
+		names := route.MiddlewareNames()
+		require.GreaterOrEqual(t, len(names), len(chain), "%s %s", route.Method, route.Path)
+		assert.Equal(t, chain, names[:len(chain)], "%s %s must start with its group chain", route.Method, route.Path)
+	}
+}
+
+// TestAPI_ServesDocumentedRoutes checks that every operation in the
+// generated OpenAPI spec is registered in the route table
+func TestAPI_ServesDocumentedRoutes(t *testing.T) {
+	raw, err := os.ReadFile("../../docs/swagger.json")
+	require.NoError(t, err)
+
+	var spec struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &spec))
+
+	registered := map[string]bool{}
+	for _, route := range API(Handlers{}, func(next http.Handler) http.Handler { return next }).Routes() {
+		registered[route.Method+" "+route.Path] = true
+	}
+
+	for path, operations := range spec.Paths {
+		for method := range operations {
+			key := strings.ToUpper(method) + " " + path
+			assert.True(t, registered[key], "%s is documented but not routed", key)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+)
+
+// AchievementRules are the achievements users can earn, in the order they
+// are listed. Add rules freely; never change or reuse the code of one that
+// may have been awarded.
+var AchievementRules = []models.AchievementRule{
+	{Code: "first_activity", Name: "First steps", Description: "Log your first activity", Metric: models.AchievementMetricActivities, Threshold: 1},
+	{Code: "activities_10", Name: "Regular", Description: "Log 10 activities", Metric: models.AchievementMetricActivities, Threshold: 10},
+	{Code: "activities_100", Name: "Centurion", Description: "Log 100 activities", Metric: models.AchievementMetricActivities, Threshold: 100},
+	{Code: "streak_7", Name: "7-day streak", Description: "Log an activity 7 days in a row", Metric: models.AchievementMetricStreak, Threshold: 7},
+	{Code: "streak_30", Name: "30-day streak", Description: "Log an activity 30 days in a row", Metric: models.AchievementMetricStreak, Threshold: 30},
+	{Code: "distance_100", Name: "100 km", Description: "Cover 100 km in total", Metric: models.AchievementMetricDistance, Threshold: 100},
+	{Code: "distance_1000", Name: "1000 km", Description: "Cover 1000 km in total", Metric: models.AchievementMetricDistance, Threshold: 1000},
+}
+
+// AchievementEngine awards the achievements in AchievementRules
+type AchievementEngine struct {
+	repo  *repository.AchievementRepository
+	rules []models.AchievementRule
+}
+
+// NewAchievementEngine creates an AchievementEngine for AchievementRules
+func NewAchievementEngine(repo *repository.AchievementRepository) *AchievementEngine {
+	return &AchievementEngine{repo: repo, rules: AchievementRules}
+}
+
+// Evaluate awards userID every achievement their totals meet and returns the
+// codes they didn't have yet. It is safe to run again, e.g. when a job is
+// retried: achievements are only ever awarded once.
+func (e *AchievementEngine) Evaluate(ctx context.Context, userID int) ([]string, error) {
+	totals, err := e.repo.GetTotals(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get totals: %w", err)
+	}
+
+	awarded, err := e.repo.Award(ctx, userID, EarnedAchievements(e.rules, totals))
+	if err != nil {
+		return nil, fmt.Errorf("award: %w", err)
+	}
+	return awarded, nil
+}
+
+// List returns every achievement with whether userID earned it
+func (e *AchievementEngine) List(ctx context.Context, userID int) ([]models.Achievement, error) {
+	awarded, err := e.repo.ListAwarded(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	achievements := make([]models.Achievement, 0, len(e.rules))
+	for _, rule := range e.rules {
+		achievement := models.Achievement{Code: rule.Code, Name: rule.Name, Description: rule.Description}
+		if at, ok := awarded[rule.Code]; ok {
+			achievement.Earned = true
+			achievement.AwardedAt = &at
+		}
+		achievements = append(achievements, achievement)
+	}
+	return achievements, nil
+}
+
+// EarnedAchievements returns the codes of the rules totals meet
+func EarnedAchievements(rules []models.AchievementRule, totals *repository.AchievementTotals) []string {
+	var earned []string
+	for _, rule := range rules {
+		var value float64
+		switch rule.Metric {
+		case models.AchievementMetricActivities:
+			value = float64(totals.Activities)
+		case models.AchievementMetricDistance:
+			value = totals.DistanceKm
+		case models.AchievementMetricStreak:
+			value = float64(totals.LongestStreakDays)
+		default:
+			continue
+		}
+		if value >= rule.Threshold {
+			earned = append(earned, rule.Code)
+		}
+	}
+	return earned
+}
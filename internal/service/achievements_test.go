@@ -0,0 +1,45 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+)
+
+func TestEarnedAchievements(t *testing.T) {
+	tests := []struct {
+		name   string
+		totals repository.AchievementTotals
+		want   []string
+	}{
+		{"nothing logged", repository.AchievementTotals{}, nil},
+		{"first activity", repository.AchievementTotals{Activities: 1, DistanceKm: 5, LongestStreakDays: 1}, []string{"first_activity"}},
+		{"week streak and 100 km", repository.AchievementTotals{Activities: 9, DistanceKm: 100, LongestStreakDays: 7},
+			[]string{"first_activity", "streak_7", "distance_100"}},
+		{"everything", repository.AchievementTotals{Activities: 150, DistanceKm: 1200, LongestStreakDays: 45},
+			[]string{"first_activity", "activities_10", "activities_100", "streak_7", "streak_30", "distance_100", "distance_1000"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, EarnedAchievements(AchievementRules, &tt.totals))
+		})
+	}
+}
+
+func TestAchievementRules_Valid(t *testing.T) {
+	metrics := map[string]bool{
+		models.AchievementMetricActivities: true,
+		models.AchievementMetricDistance:   true,
+		models.AchievementMetricStreak:     true,
+	}
+	seen := map[string]bool{}
+	for _, rule := range AchievementRules {
+		assert.False(t, seen[rule.Code], "duplicate code %s", rule.Code)
+		assert.LessOrEqual(t, len(rule.Code), 50, "code %s is longer than the column", rule.Code)
+		assert.True(t, metrics[rule.Metric], "unknown metric %s", rule.Metric)
+		seen[rule.Code] = true
+	}
+}
@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+)
+
+// Activity file formats, for GET /activities/{id}/export
+const (
+	ActivityFileGPX = "gpx"
+	ActivityFileTCX = "tcx"
+)
+
+const (
+	gpxNamespace = "http://www.topografix.com/GPX/1/1"
+	gpxSchema    = "http://www.topografix.com/GPX/1/1/gpx.xsd"
+	tcxNamespace = "http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2"
+	tcxSchema    = "http://www.garmin.com/xmlschemas/TrainingCenterDatabasev2.xsd"
+	xsiNamespace = "http://www.w3.org/2001/XMLSchema-instance"
+
+	// fileCreator is the creator written to GPX files
+	fileCreator = "ActiveLog"
+)
+
+// trackPoint is a timed position of an activity
+type trackPoint struct {
+	Lat, Lng float64
+	Time     time.Time
+}
+
+// activityEnds returns the positions known of an activity: where it started
+// at ActivityDate and where it ended DurationMinutes later, nil if not
+// logged. Activities store no GPS track, so there is nothing in between.
+func activityEnds(a *models.Activity) (start, end *trackPoint) {
+	if a.StartLat != nil && a.StartLng != nil {
+		start = &trackPoint{Lat: *a.StartLat, Lng: *a.StartLng, Time: a.ActivityDate}
+	}
+	if a.EndLat != nil && a.EndLng != nil {
+		end = &trackPoint{
+			Lat:  *a.EndLat,
+			Lng:  *a.EndLng,
+			Time: a.ActivityDate.Add(time.Duration(a.DurationMinutes) * time.Minute),
+		}
+	}
+	return start, end
+}
+
+// pointsOf returns the non-nil points
+func pointsOf(points ...*trackPoint) []trackPoint {
+	var track []trackPoint
+	for _, p := range points {
+		if p != nil {
+			track = append(track, *p)
+		}
+	}
+	return track
+}
+
+// xmlTime formats t as an xsd:dateTime in UTC
+func xmlTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// xmlWriter streams an XML document, remembering the first error so callers
+// check once at the end
+type xmlWriter struct {
+	enc *xml.Encoder
+	err error
+}
+
+func newXMLWriter(w io.Writer) *xmlWriter {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return &xmlWriter{err: err}
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return &xmlWriter{enc: enc}
+}
+
+func (x *xmlWriter) start(name string, attrs ...xml.Attr) {
+	if x.err == nil {
+		x.err = x.enc.EncodeToken(xml.StartElement{Name: xml.Name{Local: name}, Attr: attrs})
+	}
+}
+
+func (x *xmlWriter) end(name string) {
+	if x.err == nil {
+		x.err = x.enc.EncodeToken(xml.EndElement{Name: xml.Name{Local: name}})
+	}
+}
+
+// element writes <name>value</name>
+func (x *xmlWriter) element(name string, value interface{}) {
+	if x.err == nil {
+		x.err = x.enc.EncodeElement(value, xml.StartElement{Name: xml.Name{Local: name}})
+	}
+}
+
+// close flushes the document and returns the first error
+func (x *xmlWriter) close() error {
+	if x.err == nil {
+		x.err = x.enc.Close()
+	}
+	return x.err
+}
+
+func attr(name, value string) xml.Attr {
+	return xml.Attr{Name: xml.Name{Local: name}, Value: value}
+}
+
+// ExportActivityGPX writes the activity as a GPX 1.1 document with one track.
+// GPX has no laps, so splits are not written.
+func ExportActivityGPX(_ context.Context, a *models.Activity, w io.Writer) error {
+	x := newXMLWriter(w)
+	x.start("gpx",
+		attr("version", "1.1"),
+		attr("creator", fileCreator),
+		attr("xmlns", gpxNamespace),
+		attr("xmlns:xsi", xsiNamespace),
+		attr("xsi:schemaLocation", gpxNamespace+" "+gpxSchema))
+
+	x.start("metadata")
+	x.element("name", a.Title)
+	x.element("time", xmlTime(a.ActivityDate))
+	x.end("metadata")
+
+	x.start("trk")
+	x.element("name", a.Title)
+	if a.Description != "" {
+		x.element("desc", a.Description)
+	}
+	x.element("type", a.ActivityType)
+	if track := pointsOf(activityEnds(a)); len(track) > 0 {
+		x.start("trkseg")
+		for _, p := range track {
+			x.start("trkpt", attr("lat", formatDegrees(p.Lat)), attr("lon", formatDegrees(p.Lng)))
+			x.element("time", xmlTime(p.Time))
+			x.end("trkpt")
+		}
+		x.end("trkseg")
+	}
+	x.end("trk")
+
+	x.end("gpx")
+	if err := x.close(); err != nil {
+		return fmt.Errorf("failed to write GPX: %w", err)
+	}
+	return nil
+}
+
+// tcxLap is a lap of a TCX activity
+type tcxLap struct {
+	Start        time.Time
+	Seconds      float64
+	Meters       float64
+	Calories     int
+	AvgHeartRate *int
+}
+
+// activityLaps returns the splits of the activity as laps run back to back
+// from ActivityDate, or the whole activity as one lap when it has none. The
+// calories burned are shared between laps by duration.
+func activityLaps(a *models.Activity) []tcxLap {
+	if len(a.Splits) == 0 {
+		return []tcxLap{{
+			Start:        a.ActivityDate,
+			Seconds:      float64(a.DurationMinutes * 60),
+			Meters:       a.DistanceKm * 1000,
+			Calories:     a.CaloriesBurned,
+			AvgHeartRate: a.AvgHeartRate,
+		}}
+	}
+
+	total := 0
+	for _, split := range a.Splits {
+		total += split.DurationSeconds
+	}
+
+	laps := make([]tcxLap, len(a.Splits))
+	start, calories := a.ActivityDate, 0
+	for i, split := range a.Splits {
+		laps[i] = tcxLap{
+			Start:        start,
+			Seconds:      float64(split.DurationSeconds),
+			Meters:       split.DistanceKm * 1000,
+			AvgHeartRate: split.AvgHeartRate,
+		}
+		if i == len(a.Splits)-1 {
+			laps[i].Calories = a.CaloriesBurned - calories
+		} else if total > 0 {
+			laps[i].Calories = int(math.Round(float64(a.CaloriesBurned) * float64(split.DurationSeconds) / float64(total)))
+		}
+		calories += laps[i].Calories
+		start = start.Add(time.Duration(split.DurationSeconds) * time.Second)
+	}
+	return laps
+}
+
+// tcxSport maps an activity type to the sports TCX knows
+func tcxSport(activityType string) string {
+	switch activityType {
+	case "running":
+		return "Running"
+	case "cycling":
+		return "Biking"
+	default:
+		return "Other"
+	}
+}
+
+// ExportActivityTCX writes the activity as a Garmin Training Center (TCX v2)
+// document: one lap per split, with the start and end positions as track
+// points of the first and last lap.
+func ExportActivityTCX(_ context.Context, a *models.Activity, w io.Writer) error {
+	laps := activityLaps(a)
+	start, end := activityEnds(a)
+
+	x := newXMLWriter(w)
+	x.start("TrainingCenterDatabase",
+		attr("xmlns", tcxNamespace),
+		attr("xmlns:xsi", xsiNamespace),
+		attr("xsi:schemaLocation", tcxNamespace+" "+tcxSchema))
+	x.start("Activities")
+	x.start("Activity", attr("Sport", tcxSport(a.ActivityType)))
+	x.element("Id", xmlTime(a.ActivityDate))
+
+	for i, lap := range laps {
+		x.start("Lap", attr("StartTime", xmlTime(lap.Start)))
+		x.element("TotalTimeSeconds", lap.Seconds)
+		x.element("DistanceMeters", math.Round(lap.Meters*10)/10)
+		// Calories is an xsd:unsignedShort
+		x.element("Calories", min(max(lap.Calories, 0), math.MaxUint16))
+		if lap.AvgHeartRate != nil {
+			x.start("AverageHeartRateBpm")
+			x.element("Value", *lap.AvgHeartRate)
+			x.end("AverageHeartRateBpm")
+		}
+		x.element("Intensity", "Active")
+		x.element("TriggerMethod", "Manual")
+
+		var first, last *trackPoint
+		if i == 0 {
+			first = start
+		}
+		if i == len(laps)-1 {
+			last = end
+		}
+		if points := pointsOf(first, last); len(points) > 0 {
+			x.start("Track")
+			for _, p := range points {
+				x.start("Trackpoint")
+				x.element("Time", xmlTime(p.Time))
+				x.start("Position")
+				x.element("LatitudeDegrees", p.Lat)
+				x.element("LongitudeDegrees", p.Lng)
+				x.end("Position")
+				x.end("Trackpoint")
+			}
+			x.end("Track")
+		}
+		x.end("Lap")
+	}
+
+	if a.Notes != "" {
+		x.element("Notes", a.Notes)
+	}
+	x.end("Activity")
+	x.end("Activities")
+	x.end("TrainingCenterDatabase")
+	if err := x.close(); err != nil {
+		return fmt.Errorf("failed to write TCX: %w", err)
+	}
+	return nil
+}
+
+// formatDegrees formats a coordinate for a GPX attribute
+func formatDegrees(deg float64) string {
+	return fmt.Sprintf("%.6f", deg)
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/pkg/gpx"
+)
+
+func exportedActivity() *models.Activity {
+	startLat, startLng, endLat, endLng := 51.5, -0.12, 51.51, -0.1
+	hr := 150
+	return &models.Activity{
+		ActivityType:    "running",
+		Title:           "Parkrun & coffee",
+		DurationMinutes: 30,
+		DistanceKm:      5,
+		CaloriesBurned:  300,
+		ActivityDate:    time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC),
+		StartLat:        &startLat,
+		StartLng:        &startLng,
+		EndLat:          &endLat,
+		EndLng:          &endLng,
+		Splits: []*models.ActivitySplit{
+			{Index: 1, DistanceKm: 2.5, DurationSeconds: 800, AvgHeartRate: &hr},
+			{Index: 2, DistanceKm: 2.5, DurationSeconds: 1000},
+		},
+	}
+}
+
+func TestExportActivityGPX(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, ExportActivityGPX(context.Background(), exportedActivity(), &buf))
+
+	// The file reads back as a track from start to end
+	points, err := gpx.Parse(&buf)
+	require.NoError(t, err)
+	require.Len(t, points, 2)
+	assert.Equal(t, gpx.Point{Lat: 51.5, Lng: -0.12, Time: time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)}, points[0])
+	assert.Equal(t, gpx.Point{Lat: 51.51, Lng: -0.1, Time: time.Date(2026, 5, 1, 9, 30, 0, 0, time.UTC)}, points[1])
+}
+
+func TestExportActivityGPX_WithoutPositions(t *testing.T) {
+	activity := exportedActivity()
+	activity.StartLat, activity.StartLng, activity.EndLat, activity.EndLng = nil, nil, nil, nil
+
+	var buf bytes.Buffer
+	require.NoError(t, ExportActivityGPX(context.Background(), activity, &buf))
+	_, err := gpx.Parse(&buf)
+	assert.ErrorIs(t, err, gpx.ErrNoTrack)
+}
+
+func TestExportActivityTCX(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, ExportActivityTCX(context.Background(), exportedActivity(), &buf))
+
+	var doc struct {
+		XMLName  xml.Name `xml:"http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2 TrainingCenterDatabase"`
+		Activity struct {
+			Sport string `xml:"Sport,attr"`
+			ID    string `xml:"Id"`
+			Laps  []struct {
+				StartTime    string  `xml:"StartTime,attr"`
+				Seconds      float64 `xml:"TotalTimeSeconds"`
+				Meters       float64 `xml:"DistanceMeters"`
+				Calories     int     `xml:"Calories"`
+				AvgHeartRate int     `xml:"AverageHeartRateBpm>Value"`
+				Points       []struct {
+					Time string  `xml:"Time"`
+					Lat  float64 `xml:"Position>LatitudeDegrees"`
+				} `xml:"Track>Trackpoint"`
+			} `xml:"Lap"`
+		} `xml:"Activities>Activity"`
+	}
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+
+	assert.Equal(t, "Running", doc.Activity.Sport)
+	assert.Equal(t, "2026-05-01T09:00:00Z", doc.Activity.ID)
+	laps := doc.Activity.Laps
+	require.Len(t, laps, 2)
+	assert.Equal(t, "2026-05-01T09:13:20Z", laps[1].StartTime, "laps run back to back")
+	assert.Equal(t, []float64{800, 1000}, []float64{laps[0].Seconds, laps[1].Seconds})
+	assert.Equal(t, 2500.0, laps[0].Meters)
+	assert.Equal(t, []int{133, 167}, []int{laps[0].Calories, laps[1].Calories}, "shared by duration")
+	assert.Equal(t, []int{150, 0}, []int{laps[0].AvgHeartRate, laps[1].AvgHeartRate})
+	require.Len(t, laps[0].Points, 1)
+	assert.Equal(t, 51.5, laps[0].Points[0].Lat)
+	require.Len(t, laps[1].Points, 1)
+	assert.Equal(t, "2026-05-01T09:30:00Z", laps[1].Points[0].Time)
+}
+
+func TestExportActivityTCX_WithoutSplits(t *testing.T) {
+	activity := exportedActivity()
+	activity.Splits = nil
+	activity.ActivityType = "yoga"
+
+	var buf bytes.Buffer
+	require.NoError(t, ExportActivityTCX(context.Background(), activity, &buf))
+	assert.Contains(t, buf.String(), `<Activity Sport="Other">`)
+	assert.Equal(t, 1, bytes.Count(buf.Bytes(), []byte("<Lap ")))
+	assert.Contains(t, buf.String(), "<TotalTimeSeconds>1800</TotalTimeSeconds>")
+	assert.Contains(t, buf.String(), "<DistanceMeters>5000</DistanceMeters>")
+}
@@ -2,12 +2,13 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/valentinesamuel/activelog/internal/models"
 	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/pkg/clock"
 	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
 )
 
@@ -16,19 +17,55 @@ import (
 type ActivityService struct {
 	activityRepo repository.ActivityRepositoryInterface
 	tagRepo      repository.TagRepositoryInterface
+	profiles     ProfileReader
+	types        ActivityTypeResolver
+	strictTypes  bool
+	clock        clock.Clock
 }
 
-// NewActivityService creates a new activity service instance
+// ProfileReader looks up the profile data used for derived metrics: body
+// weight for calorie estimates and heart-rate zones, and the visibility new
+// activities get (repository.ProfileRepository)
+type ProfileReader interface {
+	GetWeightKg(ctx context.Context, userID int) (*float64, error)
+	GetHeartRateZones(ctx context.Context, userID int) (*models.HeartRateZones, error)
+	GetDefaultVisibility(ctx context.Context, userID int) (string, error)
+}
+
+// ActivityTypeResolver looks up the registered activity type a user means by
+// a name (repository.ActivityTypeRepository)
+type ActivityTypeResolver interface {
+	Resolve(ctx context.Context, userID int, name string) (*models.ActivityTypeInfo, error)
+}
+
+// NewActivityService creates a new activity service instance.
+// profiles may be nil, in which case calories are estimated for a default
+// weight and heart rate is binned into zones derived from the default maximum.
+// types may be nil, in which case activity types are stored as given; with
+// strictTypes, types that aren't registered are rejected.
 func NewActivityService(
 	activityRepo repository.ActivityRepositoryInterface,
 	tagRepo repository.TagRepositoryInterface,
+	profiles ProfileReader,
+	types ActivityTypeResolver,
+	strictTypes bool,
 ) *ActivityService {
 	return &ActivityService{
 		activityRepo: activityRepo,
 		tagRepo:      tagRepo,
+		profiles:     profiles,
+		types:        types,
+		strictTypes:  strictTypes,
+		clock:        clock.Real{},
 	}
 }
 
+// WithClock sets the clock activity dates are checked against
+func (s *ActivityService) WithClock(c clock.Clock) *ActivityService {
+	s.clock = c
+	return s
+}
+
 // CreateActivity handles activity creation with business rules
 func (s *ActivityService) CreateActivity(
 	ctx context.Context,
@@ -37,7 +74,7 @@ func (s *ActivityService) CreateActivity(
 	req *models.CreateActivityRequest,
 ) (*models.Activity, error) {
 	// Business Rule 1: Activity date cannot be in the future
-	if req.ActivityDate.After(time.Now()) {
+	if req.ActivityDate.After(s.clock.Now()) {
 		return nil, fmt.Errorf("activity date cannot be in the future")
 	}
 
@@ -51,10 +88,21 @@ func (s *ActivityService) CreateActivity(
 		return nil, fmt.Errorf("distance must be positive")
 	}
 
+	// Business Rule 4: The type must be registered; it is stored as registered
+	activityType, err := s.ResolveActivityType(ctx, userID, req.ActivityType)
+	if err != nil {
+		return nil, err
+	}
+
+	// Business Rule 5: Metadata must fit the size limits
+	if err := ValidateMetadata(req.Metadata); err != nil {
+		return nil, err
+	}
+
 	// Build activity entity
 	activity := &models.Activity{
 		UserID:          userID,
-		ActivityType:    req.ActivityType,
+		ActivityType:    activityType,
 		Title:           req.Title,
 		Description:     req.Description,
 		DurationMinutes: req.DurationMinutes,
@@ -62,14 +110,47 @@ func (s *ActivityService) CreateActivity(
 		CaloriesBurned:  req.CaloriesBurned,
 		Notes:           req.Notes,
 		ActivityDate:    req.ActivityDate,
+		StartLat:        req.StartLat,
+		StartLng:        req.StartLng,
+		EndLat:          req.EndLat,
+		EndLng:          req.EndLng,
+		LocationName:    req.LocationName,
+		RPE:             req.RPE,
+		Mood:            req.Mood,
+		Visibility:      req.Visibility,
+		Metadata:        req.Metadata,
+		Splits:          models.NumberSplits(req.Splits),
+	}
+	if activity.Visibility == "" {
+		activity.Visibility = s.userVisibility(ctx, userID)
+	}
+	ApplyMetrics(activity, s.userWeight(ctx, userID))
+	if len(req.HeartRate) > 0 {
+		ApplyHeartRate(activity, req.HeartRate, s.userZones(ctx, userID))
 	}
 
-	// Create activity (tags support can be added later when needed)
 	if err := s.activityRepo.Create(ctx, tx, activity); err != nil {
 		log.Error().Err(err).Msg("Failed to create activity")
 		return nil, err
 	}
 
+	// Tags are created on first use and linked in the same transaction
+	linked := make(map[string]bool, len(req.Tags))
+	for _, name := range req.Tags {
+		if linked[name] {
+			continue
+		}
+		tagID, err := s.tagRepo.GetOrCreateTag(ctx, tx, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create tag %q: %w", name, err)
+		}
+		if err := s.tagRepo.LinkActivityTag(ctx, tx, int(activity.ID), tagID); err != nil {
+			return nil, fmt.Errorf("failed to tag activity: %w", err)
+		}
+		linked[name] = true
+		activity.Tags = append(activity.Tags, &models.Tag{BaseEntity: models.BaseEntity{ID: int64(tagID)}, Name: name})
+	}
+
 	log.Info().
 		Int("user_id", userID).
 		Int64("activity_id", activity.ID).
@@ -99,7 +180,7 @@ func (s *ActivityService) UpdateActivity(
 	}
 
 	// Business Rule 3: Activity date cannot be in the future
-	if req.ActivityDate != nil && req.ActivityDate.After(time.Now()) {
+	if req.ActivityDate != nil && req.ActivityDate.After(s.clock.Now()) {
 		return nil, fmt.Errorf("activity date cannot be in the future")
 	}
 
@@ -113,9 +194,19 @@ func (s *ActivityService) UpdateActivity(
 		return nil, fmt.Errorf("distance must be positive")
 	}
 
+	// Business Rule 6: Metadata must fit the size limits
+	if err := ValidateMetadata(req.Metadata); err != nil {
+		return nil, err
+	}
+
 	// Apply partial updates to existing activity
 	if req.ActivityType != nil {
-		existingActivity.ActivityType = *req.ActivityType
+		// Business Rule 7: The type must be registered; it is stored as registered
+		activityType, err := s.ResolveActivityType(ctx, userID, *req.ActivityType)
+		if err != nil {
+			return nil, err
+		}
+		existingActivity.ActivityType = activityType
 	}
 	if req.Title != nil {
 		existingActivity.Title = *req.Title
@@ -131,6 +222,7 @@ func (s *ActivityService) UpdateActivity(
 	}
 	if req.CaloriesBurned != nil {
 		existingActivity.CaloriesBurned = *req.CaloriesBurned
+		existingActivity.CaloriesEstimated = false
 	}
 	if req.Notes != nil {
 		existingActivity.Notes = *req.Notes
@@ -138,6 +230,19 @@ func (s *ActivityService) UpdateActivity(
 	if req.ActivityDate != nil {
 		existingActivity.ActivityDate = *req.ActivityDate
 	}
+	if req.RPE != nil {
+		existingActivity.RPE = req.RPE
+	}
+	if req.Mood != nil {
+		existingActivity.Mood = req.Mood
+	}
+	if req.Metadata != nil {
+		existingActivity.Metadata = req.Metadata
+	}
+	if req.Visibility != nil {
+		existingActivity.Visibility = *req.Visibility
+	}
+	ApplyMetrics(existingActivity, s.userWeight(ctx, userID))
 
 	// Perform update
 	if err := s.activityRepo.Update(ctx, tx, activityID, existingActivity); err != nil {
@@ -179,7 +284,7 @@ func (s *ActivityService) DeleteActivity(
 
 	// Business Rule 3: Prevent deletion of activities older than 1 year (business policy)
 	// This is an example business rule - you may want to remove or modify this
-	oneYearAgo := time.Now().AddDate(-1, 0, 0)
+	oneYearAgo := s.clock.Now().AddDate(-1, 0, 0)
 	if existingActivity.CreatedAt.Before(oneYearAgo) {
 		log.Warn().
 			Int("activity_id", activityID).
@@ -202,3 +307,76 @@ func (s *ActivityService) DeleteActivity(
 
 	return nil
 }
+
+// ResolveActivityType returns the registered name of the activity type the user
+// means by name, matched case-insensitively, or a *ValidationError if they have
+// no such type. Without a registry, or when unregistered types are allowed,
+// name is returned as given.
+func (s *ActivityService) ResolveActivityType(ctx context.Context, userID int, name string) (string, error) {
+	if s.types == nil {
+		return name, nil
+	}
+	activityType, err := s.types.Resolve(ctx, userID, name)
+	if errors.Is(err, appErrors.ErrNotFound) {
+		if !s.strictTypes {
+			return name, nil
+		}
+		return "", &appErrors.ValidationError{
+			Field:   "activityType",
+			Message: fmt.Sprintf("unknown activity type '%s'; add it under /api/v1/activity-types first", name),
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve activity type: %w", err)
+	}
+	return activityType.Name, nil
+}
+
+// ValidateMetadata checks activity metadata against its limits (see
+// models.ActivityMetadata) and reports violations as a validation error
+func ValidateMetadata(metadata models.ActivityMetadata) error {
+	if err := metadata.Validate(); err != nil {
+		return &appErrors.ValidationError{Field: "metadata", Message: "metadata " + err.Error()}
+	}
+	return nil
+}
+
+// userWeight returns the user's weight for calorie estimates, or nil if it is
+// unknown. Lookup failures only cost estimate accuracy, so they are logged.
+func (s *ActivityService) userWeight(ctx context.Context, userID int) *float64 {
+	if s.profiles == nil {
+		return nil
+	}
+	weight, err := s.profiles.GetWeightKg(ctx, userID)
+	if err != nil {
+		log.Warn().Err(err).Int("user_id", userID).Msg("Failed to look up weight for calorie estimate")
+		return nil
+	}
+	return weight
+}
+
+// userVisibility returns the user's default activity visibility, falling
+// back to followers
+func (s *ActivityService) userVisibility(ctx context.Context, userID int) string {
+	if s.profiles != nil {
+		visibility, err := s.profiles.GetDefaultVisibility(ctx, userID)
+		if err == nil {
+			return visibility
+		}
+		log.Warn().Err(err).Int("user_id", userID).Msg("Failed to look up default activity visibility")
+	}
+	return models.VisibilityFollowers
+}
+
+// userZones returns the lower bounds of the user's heart-rate zones, falling
+// back to zones derived from the default maximum heart rate
+func (s *ActivityService) userZones(ctx context.Context, userID int) []int {
+	if s.profiles != nil {
+		zones, err := s.profiles.GetHeartRateZones(ctx, userID)
+		if err == nil {
+			return zones.Zones
+		}
+		log.Warn().Err(err).Int("user_id", userID).Msg("Failed to look up heart-rate zones")
+	}
+	return models.DeriveHeartRateZones(models.DefaultMaxHeartRate)
+}
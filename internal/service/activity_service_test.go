@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository/mocks"
+	"github.com/valentinesamuel/activelog/pkg/clock"
+	appErrors "github.com/valentinesamuel/activelog/pkg/errors"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeTypeRegistry resolves names case-insensitively against a fixed list
+type fakeTypeRegistry []string
+
+func (f fakeTypeRegistry) Resolve(ctx context.Context, userID int, name string) (*models.ActivityTypeInfo, error) {
+	for _, registered := range f {
+		if strings.EqualFold(registered, name) {
+			return &models.ActivityTypeInfo{Name: registered}, nil
+		}
+	}
+	return nil, appErrors.ErrNotFound
+}
+
+func TestResolveActivityType(t *testing.T) {
+	ctx := context.Background()
+	registry := fakeTypeRegistry{"running", "yoga"}
+
+	strict := NewActivityService(nil, nil, nil, registry, true)
+
+	name, err := strict.ResolveActivityType(ctx, 1, "Running")
+	require.NoError(t, err)
+	assert.Equal(t, "running", name, "stored as registered")
+
+	_, err = strict.ResolveActivityType(ctx, 1, "jog")
+	var validationErr *appErrors.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "activityType", validationErr.Field)
+
+	// Unregistered types pass through when not strict, or without a registry
+	lenient := NewActivityService(nil, nil, nil, registry, false)
+	name, err = lenient.ResolveActivityType(ctx, 1, "jog")
+	require.NoError(t, err)
+	assert.Equal(t, "jog", name)
+
+	name, err = NewActivityService(nil, nil, nil, nil, true).ResolveActivityType(ctx, 1, "jog")
+	require.NoError(t, err)
+	assert.Equal(t, "jog", name)
+}
+
+func TestCreateActivity_RejectsDatesAfterTheClock(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc := NewActivityService(nil, nil, nil, nil, false).WithClock(clock.NewFake(now))
+
+	_, err := svc.CreateActivity(context.Background(), nil, 1, &models.CreateActivityRequest{
+		ActivityType: "running",
+		ActivityDate: now.Add(time.Minute),
+	})
+	assert.ErrorContains(t, err, "in the future")
+}
+
+func TestCreateActivity_LinksTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	activities := mocks.NewMockActivityRepositoryInterface(ctrl)
+	tags := mocks.NewMockTagRepositoryInterface(ctrl)
+
+	activities.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, tx interface{}, activity *models.Activity) error {
+			activity.ID = 7
+			return nil
+		})
+	tags.EXPECT().GetOrCreateTag(gomock.Any(), gomock.Any(), "cardio").Return(1, nil)
+	tags.EXPECT().GetOrCreateTag(gomock.Any(), gomock.Any(), "outdoor").Return(2, nil)
+	tags.EXPECT().LinkActivityTag(gomock.Any(), gomock.Any(), 7, 1).Return(nil)
+	tags.EXPECT().LinkActivityTag(gomock.Any(), gomock.Any(), 7, 2).Return(nil)
+
+	svc := NewActivityService(activities, tags, nil, nil, false)
+	activity, err := svc.CreateActivity(context.Background(), nil, 1, &models.CreateActivityRequest{
+		ActivityType:    "running",
+		DurationMinutes: 30,
+		ActivityDate:    time.Now().Add(-time.Hour),
+		Tags:            []string{"cardio", "outdoor", "cardio"},
+	})
+	require.NoError(t, err)
+	require.Len(t, activity.Tags, 2, "a repeated tag is linked once")
+	assert.Equal(t, "outdoor", activity.Tags[1].Name)
+}
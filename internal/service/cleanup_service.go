@@ -6,7 +6,8 @@ import (
 	"log"
 )
 
-// CleanupService hard-deletes soft-deleted records that are older than 30 days.
+// CleanupService hard-deletes soft-deleted records that are older than 30 days
+// and forgets processed queue messages after a week.
 type CleanupService struct {
 	db *sql.DB
 }
@@ -16,7 +17,8 @@ func NewCleanupService(db *sql.DB) *CleanupService {
 	return &CleanupService{db: db}
 }
 
-// DeleteOldData permanently removes records soft-deleted more than 30 days ago.
+// DeleteOldData permanently removes records soft-deleted more than 30 days ago
+// and processed message records older than 7 days.
 func (c *CleanupService) DeleteOldData(ctx context.Context) error {
 	query := `
 		DELETE FROM activities
@@ -31,5 +33,17 @@ func (c *CleanupService) DeleteOldData(ctx context.Context) error {
 
 	rows, _ := result.RowsAffected()
 	log.Printf("[scheduler] cleanup: hard-deleted %d stale activities", rows)
+
+	// Redeliveries happen within minutes; a week of message IDs is plenty
+	result, err = c.db.ExecContext(ctx, `
+		DELETE FROM processed_messages
+		WHERE processed_at < NOW() - INTERVAL '7 days'
+	`)
+	if err != nil {
+		return err
+	}
+
+	rows, _ = result.RowsAffected()
+	log.Printf("[scheduler] cleanup: removed %d processed message records", rows)
 	return nil
 }
@@ -2,6 +2,7 @@ package di
 
 // Container registration keys for services
 const (
-	ActivityServiceKey = "activityService"
-	StatsServiceKey    = "statsService"
+	ActivityServiceKey   = "activityService"
+	StatsServiceKey      = "statsService"
+	AchievementEngineKey = "achievementEngine"
 )
@@ -1,10 +1,13 @@
 package di
 
 import (
+	clockDI "github.com/valentinesamuel/activelog/internal/platform/clock/di"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
 	"github.com/valentinesamuel/activelog/internal/platform/container"
 	"github.com/valentinesamuel/activelog/internal/repository"
 	"github.com/valentinesamuel/activelog/internal/repository/di"
 	"github.com/valentinesamuel/activelog/internal/service"
+	"github.com/valentinesamuel/activelog/pkg/clock"
 )
 
 // RegisterServices registers all service-layer factories with the container
@@ -12,15 +15,25 @@ import (
 func RegisterServices(c *container.Container) {
 	// Activity service (handles activity business logic)
 	c.Register(ActivityServiceKey, func(c *container.Container) (interface{}, error) {
-		activityRepo := c.MustResolve(di.ActivityRepoKey).(repository.ActivityRepositoryInterface)
-		tagRepo := c.MustResolve(di.TagRepoKey).(repository.TagRepositoryInterface)
-		return service.NewActivityService(activityRepo, tagRepo), nil
+		activityRepo := container.MustResolve[repository.ActivityRepositoryInterface](c, di.ActivityRepoKey)
+		tagRepo := container.MustResolve[repository.TagRepositoryInterface](c, di.TagRepoKey)
+		profileRepo := container.MustResolve[*repository.ProfileRepository](c, di.ProfileRepoKey)
+		typeRepo := container.MustResolve[*repository.ActivityTypeRepository](c, di.ActivityTypeRepoKey)
+		strictTypes := config.Activity == nil || config.Activity.TypesStrict
+		clk := container.MustResolve[clock.Clock](c, clockDI.ClockKey)
+		return service.NewActivityService(activityRepo, tagRepo, profileRepo, typeRepo, strictTypes).WithClock(clk), nil
 	})
 
 	// Stats service (handles statistics and analytics logic)
 	c.Register(StatsServiceKey, func(c *container.Container) (interface{}, error) {
-		statsRepo := c.MustResolve(di.StatsRepoKey).(repository.StatsRepositoryInterface)
-		activityRepo := c.MustResolve(di.ActivityRepoKey).(repository.ActivityRepositoryInterface)
-		return service.NewStatsService(statsRepo, activityRepo), nil
+		statsRepo := container.MustResolve[repository.StatsRepositoryInterface](c, di.StatsRepoKey)
+		activityRepo := container.MustResolve[repository.ActivityRepositoryInterface](c, di.ActivityRepoKey)
+		clk := container.MustResolve[clock.Clock](c, clockDI.ClockKey)
+		return service.NewStatsService(statsRepo, activityRepo).WithClock(clk), nil
+	})
+
+	// Achievement engine (awards and lists achievements)
+	container.RegisterTyped(c, AchievementEngineKey, func(c *container.Container) (*service.AchievementEngine, error) {
+		return service.NewAchievementEngine(container.MustResolve[*repository.AchievementRepository](c, di.AchievementRepoKey)), nil
 	})
 }
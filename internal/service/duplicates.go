@@ -0,0 +1,97 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+)
+
+// ClusterDuplicates groups activities linked by duplicate pairs, so that A~B
+// and B~C give one cluster of A, B and C. IDs within a cluster are ascending;
+// clusters come in the order their first pair does.
+func ClusterDuplicates(pairs []repository.DuplicatePair) [][]int64 {
+	parent := make(map[int64]int64)
+	var find func(id int64) int64
+	find = func(id int64) int64 {
+		p, ok := parent[id]
+		if !ok || p == id {
+			parent[id] = id
+			return id
+		}
+		root := find(p)
+		parent[id] = root
+		return root
+	}
+
+	var order []int64 // IDs in the order they were first seen
+	seen := make(map[int64]bool)
+	for _, pair := range pairs {
+		for _, id := range []int64{pair.A, pair.B} {
+			if !seen[id] {
+				seen[id] = true
+				order = append(order, id)
+			}
+		}
+		a, b := find(pair.A), find(pair.B)
+		if a != b {
+			parent[b] = a
+		}
+	}
+
+	index := make(map[int64]int) // root -> position in clusters
+	var clusters [][]int64
+	for _, id := range order {
+		root := find(id)
+		i, ok := index[root]
+		if !ok {
+			i = len(clusters)
+			index[root] = i
+			clusters = append(clusters, nil)
+		}
+		clusters[i] = append(clusters[i], id)
+	}
+	for _, cluster := range clusters {
+		sort.Slice(cluster, func(i, j int) bool { return cluster[i] < cluster[j] })
+	}
+	return clusters
+}
+
+// ActivityRichness scores how much an activity records: a point for each
+// optional detail it has, plus its tags, photos, comments and splits. A merge
+// keeps the richest of the duplicates.
+func ActivityRichness(a *models.Activity, attachments repository.ActivityAttachments) int {
+	score := 0
+	for _, has := range []bool{
+		a.Description != "",
+		a.Notes != "",
+		a.DistanceKm > 0,
+		a.CaloriesBurned > 0 && !a.CaloriesEstimated,
+		a.StartLat != nil,
+		a.EndLat != nil,
+		a.LocationName != nil,
+		a.AvgHeartRate != nil,
+		a.RPE != nil,
+		a.Mood != nil,
+		len(a.Metadata) > 0,
+	} {
+		if has {
+			score++
+		}
+	}
+	return score + attachments.Tags + attachments.Photos + attachments.Comments + attachments.Splits
+}
+
+// RichestActivity returns the activity of activities with the highest
+// ActivityRichness; ties go to the one created first (lowest ID)
+func RichestActivity(activities []*models.Activity, attachments map[int64]repository.ActivityAttachments) *models.Activity {
+	var richest *models.Activity
+	best := -1
+	for _, a := range activities {
+		score := ActivityRichness(a, attachments[a.ID])
+		if score > best || (score == best && a.ID < richest.ID) {
+			richest, best = a, score
+		}
+	}
+	return richest
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+)
+
+func TestClusterDuplicates(t *testing.T) {
+	clusters := ClusterDuplicates([]repository.DuplicatePair{
+		{A: 7, B: 9},
+		{A: 2, B: 3},
+		{A: 3, B: 9}, // joins the first two
+		{A: 4, B: 5},
+	})
+
+	assert.Equal(t, [][]int64{{2, 3, 7, 9}, {4, 5}}, clusters)
+	assert.Empty(t, ClusterDuplicates(nil))
+}
+
+func TestRichestActivity(t *testing.T) {
+	lat, mood := 51.5, 4
+	watch := &models.Activity{DistanceKm: 5.1, CaloriesBurned: 320}
+	watch.ID = 3
+	app := &models.Activity{DistanceKm: 5, Description: "Morning run", StartLat: &lat, Mood: &mood}
+	app.ID = 8
+
+	assert.Equal(t, 2, ActivityRichness(watch, repository.ActivityAttachments{}))
+	assert.Equal(t, 4, ActivityRichness(app, repository.ActivityAttachments{}))
+	assert.Same(t, app, RichestActivity([]*models.Activity{watch, app}, nil))
+
+	// Attachments count too
+	attachments := map[int64]repository.ActivityAttachments{3: {Photos: 1, Splits: 5}}
+	assert.Same(t, watch, RichestActivity([]*models.Activity{watch, app}, attachments))
+
+	// Ties keep the first created
+	twin := &models.Activity{DistanceKm: 5.1, CaloriesBurned: 320}
+	twin.ID = 1
+	assert.Same(t, twin, RichestActivity([]*models.Activity{watch, twin}, nil))
+
+	// Estimated calories aren't a detail the user recorded
+	watch.CaloriesEstimated = true
+	assert.Equal(t, 1, ActivityRichness(watch, repository.ActivityAttachments{}))
+}
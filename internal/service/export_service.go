@@ -6,20 +6,25 @@ import (
 	"encoding/csv"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/go-pdf/fpdf"
 	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/platform/locale"
+	"github.com/valentinesamuel/activelog/pkg/format"
 )
 
 // ExportActivitiesCSV streams activities as CSV to w.
-// It writes a header row followed by one row per activity.
+// It writes a header row followed by one row per activity. Unlike the PDF
+// report, the CSV is the same in every language, so scripts and spreadsheet
+// imports can rely on its columns, dates and decimal points.
 func ExportActivitiesCSV(_ context.Context, activities []*models.Activity, w io.Writer) error {
 	writer := csv.NewWriter(w)
 	defer writer.Flush()
 
 	// Write header row
 	header := []string{
-		"id", "user_id", "activity_type", "title", "description",
+		"id", "activity_type", "title", "description",
 		"duration_minutes", "distance_km", "calories_burned",
 		"notes", "activity_date", "created_at",
 	}
@@ -30,8 +35,7 @@ func ExportActivitiesCSV(_ context.Context, activities []*models.Activity, w io.
 	// Write each activity as a row
 	for _, a := range activities {
 		row := []string{
-			fmt.Sprintf("%d", a.ID),
-			fmt.Sprintf("%d", a.UserID),
+			a.PublicID,
 			a.ActivityType,
 			a.Title,
 			a.Description,
@@ -51,15 +55,23 @@ func ExportActivitiesCSV(_ context.Context, activities []*models.Activity, w io.
 }
 
 // GenerateActivityReport generates a PDF report for the given activities.
-// It includes a summary section and a table of all activities.
-func GenerateActivityReport(_ context.Context, activities []*models.Activity) ([]byte, error) {
+// It includes a summary section and a table of all activities. The report is
+// written in the language of ctx (see locale.FromContext), with its dates and
+// numbers formatted for it, and distances and paces in units.
+func GenerateActivityReport(ctx context.Context, activities []*models.Activity, units format.Units) ([]byte, error) {
+	l := locale.FromContext(ctx)
+	dateLayout := l.Localize("DateShort", nil)
+
 	pdf := fpdf.New("P", "mm", "A4", "")
 	pdf.SetAutoPageBreak(true, 15)
 	pdf.AddPage()
+	// The core fonts are cp1252; translate the UTF-8 text (accents, no-break
+	// spaces in numbers) to it
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
 
 	// Title
 	pdf.SetFont("Arial", "B", 20)
-	pdf.CellFormat(0, 12, "Activity Report", "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 12, tr(l.Localize("ReportTitle", nil)), "", 1, "C", false, 0, "")
 	pdf.Ln(8)
 
 	// Summary section
@@ -72,34 +84,36 @@ func GenerateActivityReport(_ context.Context, activities []*models.Activity) ([
 	}
 
 	pdf.SetFont("Arial", "B", 12)
-	pdf.Cell(0, 8, "Summary")
+	pdf.Cell(0, 8, tr(l.Localize("ReportSummary", nil)))
 	pdf.Ln(8)
 
 	pdf.SetFont("Arial", "", 11)
-	pdf.Cell(0, 7, fmt.Sprintf("Total Activities: %d", totalCount))
+	pdf.Cell(0, 7, tr(l.Localize("ReportTotalActivities", map[string]any{"Count": l.FormatNumber(float64(totalCount), 0)})))
 	pdf.Ln(7)
-	pdf.Cell(0, 7, fmt.Sprintf("Total Duration: %d minutes", totalDuration))
+	pdf.Cell(0, 7, tr(l.Localize("ReportTotalDuration", map[string]any{"Duration": format.Minutes(totalDuration)})))
 	pdf.Ln(7)
-	pdf.Cell(0, 7, fmt.Sprintf("Total Distance: %.2f km", totalDistance))
+	pdf.Cell(0, 7, tr(l.Localize("ReportTotalDistance", map[string]any{"Distance": format.Distance(l, totalDistance, units)})))
 	pdf.Ln(12)
 
 	// Table header
 	pdf.SetFont("Arial", "B", 10)
-	colWidths := []float64{25, 30, 50, 30, 30}
-	headers := []string{"Date", "Type", "Title", "Duration (min)", "Distance (km)"}
+	colWidths := []float64{25, 30, 50, 25, 30, 30}
+	headers := []string{"ReportColumnDate", "ReportColumnType", "ReportColumnTitle", "ReportColumnDuration", "ReportColumnDistance", "ReportColumnPace"}
 	for i, h := range headers {
-		pdf.CellFormat(colWidths[i], 8, h, "1", 0, "C", false, 0, "")
+		pdf.CellFormat(colWidths[i], 8, tr(l.Localize(h, nil)), "1", 0, "C", false, 0, "")
 	}
 	pdf.Ln(-1)
 
 	// Table rows
 	pdf.SetFont("Arial", "", 9)
 	for _, a := range activities {
-		pdf.CellFormat(colWidths[0], 7, a.ActivityDate.Format("2006-01-02"), "1", 0, "C", false, 0, "")
-		pdf.CellFormat(colWidths[1], 7, truncateString(a.ActivityType, 15), "1", 0, "L", false, 0, "")
-		pdf.CellFormat(colWidths[2], 7, truncateString(a.Title, 28), "1", 0, "L", false, 0, "")
-		pdf.CellFormat(colWidths[3], 7, fmt.Sprintf("%d", a.DurationMinutes), "1", 0, "C", false, 0, "")
-		pdf.CellFormat(colWidths[4], 7, fmt.Sprintf("%.2f", a.DistanceKm), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(colWidths[0], 7, l.FormatDate(a.ActivityDate, dateLayout), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(colWidths[1], 7, tr(truncateString(a.ActivityType, 15)), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths[2], 7, tr(truncateString(a.Title, 28)), "1", 0, "L", false, 0, "")
+		duration := time.Duration(a.DurationMinutes) * time.Minute
+		pdf.CellFormat(colWidths[3], 7, format.Duration(duration), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(colWidths[4], 7, tr(format.Distance(l, a.DistanceKm, units)), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(colWidths[5], 7, format.Pace(duration, a.DistanceKm, units), "1", 0, "C", false, 0, "")
 		pdf.Ln(-1)
 	}
 
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/platform/locale"
+	"github.com/valentinesamuel/activelog/pkg/format"
+	"github.com/valentinesamuel/activelog/pkg/i18n"
+)
+
+func TestGenerateActivityReport(t *testing.T) {
+	activities := []*models.Activity{{
+		ActivityType:    "running",
+		Title:           "Course à pied matinale",
+		DurationMinutes: 65,
+		DistanceKm:      12.5,
+		ActivityDate:    time.Date(2025, time.March, 3, 7, 0, 0, 0, time.UTC),
+	}}
+
+	// French text and numbers (accents, no-break spaces) must encode in the
+	// PDF core fonts
+	ctx := i18n.NewContext(context.Background(), locale.New("fr"))
+	report, err := GenerateActivityReport(ctx, activities, format.Imperial)
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF", string(report[:4]))
+}
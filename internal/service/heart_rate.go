@@ -0,0 +1,78 @@
+package service
+
+import (
+	"math"
+	"sort"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+)
+
+// maxSampleGapSeconds caps the time attributed to a single heart-rate sample,
+// so a pause in recording isn't counted as time in the zone of the last reading
+const maxSampleGapSeconds = 60
+
+// ApplyHeartRate fills in the heart-rate metrics of activity from samples:
+// the time spent in each of zones (lower bounds in bpm), the time-weighted
+// average heart rate and the training load. The time between two samples is
+// attributed to the earlier one; time below the first zone counts towards
+// the average but not towards any zone. Fewer than two samples leave the
+// metrics unset.
+func ApplyHeartRate(activity *models.Activity, samples []models.HeartRateSample, zones []int) {
+	activity.AvgHeartRate = nil
+	activity.HRZoneSeconds = nil
+	activity.TrainingLoad = nil
+	if len(samples) < 2 || len(zones) == 0 {
+		return
+	}
+
+	sorted := make([]models.HeartRateSample, len(samples))
+	copy(sorted, samples)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].OffsetSeconds < sorted[j].OffsetSeconds
+	})
+
+	zoneSeconds := make([]int, len(zones))
+	var totalSeconds, weightedBPM int
+	for i := 0; i < len(sorted)-1; i++ {
+		seconds := min(sorted[i+1].OffsetSeconds-sorted[i].OffsetSeconds, maxSampleGapSeconds)
+		if seconds <= 0 {
+			continue
+		}
+		totalSeconds += seconds
+		weightedBPM += seconds * sorted[i].BPM
+		if zone := heartRateZone(sorted[i].BPM, zones); zone >= 0 {
+			zoneSeconds[zone] += seconds
+		}
+	}
+	if totalSeconds == 0 {
+		return
+	}
+
+	avg := int(math.Round(float64(weightedBPM) / float64(totalSeconds)))
+	load := TrainingLoad(zoneSeconds)
+	activity.AvgHeartRate = &avg
+	activity.HRZoneSeconds = zoneSeconds
+	activity.TrainingLoad = &load
+}
+
+// TrainingLoad is the Edwards TRIMP: the minutes spent in each zone weighted
+// by the zone number (1-5)
+func TrainingLoad(zoneSeconds []int) float64 {
+	var load float64
+	for i, seconds := range zoneSeconds {
+		load += float64(seconds) / 60 * float64(i+1)
+	}
+	return math.Round(load*10) / 10
+}
+
+// heartRateZone returns the index of the zone bpm falls in, or -1 if it is
+// below the first zone
+func heartRateZone(bpm int, zones []int) int {
+	zone := -1
+	for i, lower := range zones {
+		if bpm >= lower {
+			zone = i
+		}
+	}
+	return zone
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valentinesamuel/activelog/internal/models"
+)
+
+func TestApplyHeartRate(t *testing.T) {
+	zones := []int{100, 120, 140, 160, 180}
+	samples := []models.HeartRateSample{
+		{OffsetSeconds: 120, BPM: 150},
+		{OffsetSeconds: 0, BPM: 90},
+		{OffsetSeconds: 60, BPM: 130},
+		{OffsetSeconds: 180, BPM: 185}, // recording gap, capped at 60s
+		{OffsetSeconds: 600, BPM: 170},
+		{OffsetSeconds: 660, BPM: 170},
+	}
+	activity := &models.Activity{}
+
+	ApplyHeartRate(activity, samples, zones)
+
+	require.NotNil(t, activity.AvgHeartRate)
+	assert.Equal(t, []int{0, 60, 60, 60, 60}, activity.HRZoneSeconds)
+	assert.Equal(t, 145, *activity.AvgHeartRate)  // (90+130+150+185+170) / 5 minutes
+	assert.Equal(t, 14.0, *activity.TrainingLoad) // 1×2 + 1×3 + 1×4 + 1×5
+}
+
+func TestApplyHeartRate_TooFewSamples(t *testing.T) {
+	activity := &models.Activity{}
+
+	ApplyHeartRate(activity, []models.HeartRateSample{{BPM: 150}}, models.DeriveHeartRateZones(190))
+
+	assert.Nil(t, activity.AvgHeartRate)
+	assert.Nil(t, activity.HRZoneSeconds)
+	assert.Nil(t, activity.TrainingLoad)
+}
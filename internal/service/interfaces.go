@@ -26,6 +26,11 @@ type ActivityServiceInterface interface {
 	// - Validates ownership
 	// - Handles cascade deletions
 	DeleteActivity(ctx context.Context, tx repository.TxConn, userID int, activityID int) error
+
+	// ResolveActivityType maps a submitted activity type to its registered name
+	// - Matches the user's own types, then the defaults, case-insensitively
+	// - Rejects unregistered types with a *errors.ValidationError
+	ResolveActivityType(ctx context.Context, userID int, name string) (string, error)
 }
 
 // StatsServiceInterface defines business logic for statistics operations
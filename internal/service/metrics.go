@@ -0,0 +1,77 @@
+package service
+
+import (
+	"math"
+	"strings"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+)
+
+// MetricsVersion identifies the formulas below. Bump it whenever they change:
+// the backfill_activity_metrics job recomputes every activity stored with an
+// older version.
+const MetricsVersion = 1
+
+// defaultWeightKg is assumed for calorie estimates when the user has not
+// recorded their weight
+const defaultWeightKg = 70.0
+
+// defaultMET is used for activity types missing from metValues
+const defaultMET = 5.0
+
+// metValues are metabolic equivalents (kcal per kg per hour) by activity type,
+// from the Compendium of Physical Activities
+var metValues = map[string]float64{
+	"running":       9.8,
+	"cycling":       7.5,
+	"swimming":      8.0,
+	"walking":       3.5,
+	"hiking":        6.0,
+	"rowing":        7.0,
+	"yoga":          2.5,
+	"gym":           5.0,
+	"strength":      5.0,
+	"weightlifting": 5.0,
+	"basketball":    6.5,
+	"tennis":        7.3,
+	"football":      7.0,
+	"soccer":        7.0,
+}
+
+// ApplyMetrics fills in the derived metrics of activity: pace and average
+// speed when it has a distance, and a calorie estimate when no calories were
+// logged (or the previous value was itself an estimate). weightKg may be nil.
+func ApplyMetrics(activity *models.Activity, weightKg *float64) {
+	activity.PaceMinPerKm = nil
+	activity.AvgSpeedKmh = nil
+	if activity.DistanceKm > 0 && activity.DurationMinutes > 0 {
+		pace := round2(float64(activity.DurationMinutes) / activity.DistanceKm)
+		speed := round2(activity.DistanceKm / (float64(activity.DurationMinutes) / 60))
+		activity.PaceMinPerKm = &pace
+		activity.AvgSpeedKmh = &speed
+	}
+
+	if activity.CaloriesBurned == 0 || activity.CaloriesEstimated {
+		activity.CaloriesBurned = EstimateCalories(activity.ActivityType, activity.DurationMinutes, weightKg)
+		activity.CaloriesEstimated = activity.CaloriesBurned > 0
+	}
+
+	activity.MetricsVersion = MetricsVersion
+}
+
+// EstimateCalories estimates the calories burned as MET × weight × hours
+func EstimateCalories(activityType string, durationMinutes int, weightKg *float64) int {
+	met, ok := metValues[strings.ToLower(activityType)]
+	if !ok {
+		met = defaultMET
+	}
+	weight := defaultWeightKg
+	if weightKg != nil && *weightKg > 0 {
+		weight = *weightKg
+	}
+	return int(math.Round(met * weight * float64(durationMinutes) / 60))
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
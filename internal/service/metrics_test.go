@@ -0,0 +1,38 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valentinesamuel/activelog/internal/models"
+)
+
+func TestApplyMetrics(t *testing.T) {
+	weight := 60.0
+	activity := &models.Activity{ActivityType: "running", DurationMinutes: 50, DistanceKm: 10}
+
+	ApplyMetrics(activity, &weight)
+
+	require.NotNil(t, activity.PaceMinPerKm)
+	assert.Equal(t, 5.0, *activity.PaceMinPerKm)
+	assert.Equal(t, 12.0, *activity.AvgSpeedKmh)
+	assert.Equal(t, 490, activity.CaloriesBurned) // 9.8 MET × 60 kg × 50/60 h
+	assert.True(t, activity.CaloriesEstimated)
+	assert.Equal(t, MetricsVersion, activity.MetricsVersion)
+
+	// A new weight updates the estimate
+	weight = 72
+	ApplyMetrics(activity, &weight)
+	assert.Equal(t, 588, activity.CaloriesBurned)
+}
+
+func TestApplyMetrics_KeepsLoggedCalories(t *testing.T) {
+	activity := &models.Activity{ActivityType: "yoga", DurationMinutes: 60, CaloriesBurned: 200}
+
+	ApplyMetrics(activity, nil)
+
+	assert.Nil(t, activity.PaceMinPerKm, "no distance, no pace")
+	assert.Equal(t, 200, activity.CaloriesBurned)
+	assert.False(t, activity.CaloriesEstimated)
+}
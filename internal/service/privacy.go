@@ -0,0 +1,62 @@
+package service
+
+import (
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/pkg/gpx"
+	"github.com/valentinesamuel/activelog/pkg/polyline"
+)
+
+// RedactPrivacyZones hides the positions of activities that fall within one
+// of their owner's privacy zones: a start point inside a zone is cleared
+// along with the location name, which would give it away, and so is an end
+// point inside one. Activities are changed in place; call it on what is
+// served to anyone but the owner, just before it is written out.
+func RedactPrivacyZones(activities []*models.Activity, zones []*models.PrivacyZone) {
+	if len(zones) == 0 {
+		return
+	}
+	for _, a := range activities {
+		if inPrivacyZone(a.StartLat, a.StartLng, zones) {
+			a.StartLat, a.StartLng, a.LocationName = nil, nil, nil
+		}
+		if inPrivacyZone(a.EndLat, a.EndLng, zones) {
+			a.EndLat, a.EndLng = nil, nil
+		}
+	}
+}
+
+// TrimPrivacyZones cuts the parts of a GPS track within its owner's privacy
+// zones out of it, and returns the rest as the segments between them. Single
+// points left between two zones are dropped, as there is no line to draw.
+func TrimPrivacyZones(track []polyline.Point, zones []*models.PrivacyZone) [][]polyline.Point {
+	var segments [][]polyline.Point
+	var segment []polyline.Point
+	flush := func() {
+		if len(segment) >= 2 {
+			segments = append(segments, segment)
+		}
+		segment = nil
+	}
+	for _, p := range track {
+		if inPrivacyZone(&p.Lat, &p.Lng, zones) {
+			flush()
+			continue
+		}
+		segment = append(segment, p)
+	}
+	flush()
+	return segments
+}
+
+// inPrivacyZone reports whether the point is set and within a zone's radius
+func inPrivacyZone(lat, lng *float64, zones []*models.PrivacyZone) bool {
+	if lat == nil || lng == nil {
+		return false
+	}
+	for _, zone := range zones {
+		if gpx.HaversineKm(*lat, *lng, zone.Lat, zone.Lng)*1000 <= float64(zone.RadiusM) {
+			return true
+		}
+	}
+	return false
+}
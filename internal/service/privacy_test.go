@@ -0,0 +1,62 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/pkg/polyline"
+)
+
+func TestRedactPrivacyZones(t *testing.T) {
+	ptr := func(v float64) *float64 { return &v }
+	home := &models.PrivacyZone{Name: "Home", Lat: 51.5000, Lng: -0.1200, RadiusM: 200}
+	park := "Hyde Park"
+
+	// Starts ~110 m from home, ends ~2 km away
+	fromHome := &models.Activity{
+		StartLat: ptr(51.5010), StartLng: ptr(-0.1200), LocationName: &park,
+		EndLat: ptr(51.5180), EndLng: ptr(-0.1200),
+	}
+	// Starts ~2 km away, ends ~55 m from home
+	toHome := &models.Activity{
+		StartLat: ptr(51.4820), StartLng: ptr(-0.1200), LocationName: &park,
+		EndLat: ptr(51.5005), EndLng: ptr(-0.1200),
+	}
+	noGPS := &models.Activity{LocationName: &park}
+
+	RedactPrivacyZones([]*models.Activity{fromHome, toHome, noGPS}, []*models.PrivacyZone{home})
+
+	assert.Nil(t, fromHome.StartLat)
+	assert.Nil(t, fromHome.StartLng)
+	assert.Nil(t, fromHome.LocationName, "the location name would give the start away")
+	assert.Equal(t, 51.5180, *fromHome.EndLat)
+
+	assert.Equal(t, 51.4820, *toHome.StartLat)
+	assert.Equal(t, &park, toHome.LocationName)
+	assert.Nil(t, toHome.EndLat)
+	assert.Nil(t, toHome.EndLng)
+
+	assert.Equal(t, &park, noGPS.LocationName)
+}
+
+func TestTrimPrivacyZones(t *testing.T) {
+	home := &models.PrivacyZone{Name: "Home", Lat: 51.5000, Lng: -0.1200, RadiusM: 200}
+
+	// North through home, in ~110 m steps
+	track := []polyline.Point{
+		{Lat: 51.4970, Lng: -0.1200},
+		{Lat: 51.4980, Lng: -0.1200},
+		{Lat: 51.4990, Lng: -0.1200}, // in
+		{Lat: 51.5010, Lng: -0.1200}, // in
+		{Lat: 51.5030, Lng: -0.1200},
+		{Lat: 51.5040, Lng: -0.1200},
+	}
+
+	assert.Equal(t, [][]polyline.Point{track[:2], track[4:]}, TrimPrivacyZones(track, []*models.PrivacyZone{home}))
+	assert.Equal(t, [][]polyline.Point{track}, TrimPrivacyZones(track, nil))
+
+	// A lone point between two visits home
+	lone := []polyline.Point{track[2], track[0], track[3]}
+	assert.Empty(t, TrimPrivacyZones(lone, []*models.PrivacyZone{home}))
+}
@@ -4,23 +4,31 @@ import (
 	"context"
 	"database/sql"
 	"log"
-	"time"
+
+	"github.com/valentinesamuel/activelog/pkg/clock"
 )
 
 // StatsCalculator aggregates daily activity stats into the daily_stats table.
 type StatsCalculator struct {
-	db *sql.DB
+	db    *sql.DB
+	clock clock.Clock
 }
 
 // NewStatsCalculator creates a StatsCalculator backed by a raw *sql.DB.
 func NewStatsCalculator(db *sql.DB) *StatsCalculator {
-	return &StatsCalculator{db: db}
+	return &StatsCalculator{db: db, clock: clock.Real{}}
+}
+
+// WithClock sets the clock that decides which day is yesterday
+func (s *StatsCalculator) WithClock(c clock.Clock) *StatsCalculator {
+	s.clock = c
+	return s
 }
 
 // CalculateDailyStats aggregates the previous day's activities for every user
 // and upserts the results into the daily_stats table.
 func (s *StatsCalculator) CalculateDailyStats(ctx context.Context) error {
-	yesterday := time.Now().UTC().AddDate(0, 0, -1).Format("2006-01-02")
+	yesterday := s.clock.Now().UTC().AddDate(0, 0, -1).Format("2006-01-02")
 
 	query := `
 		INSERT INTO daily_stats (user_id, date, total_activities, total_distance_km, total_duration_minutes)
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/valentinesamuel/activelog/internal/repository"
+	"github.com/valentinesamuel/activelog/pkg/clock"
 )
 
 // ConcurrentUserStats holds aggregated stats fetched by parallel goroutines.
@@ -21,6 +22,7 @@ type ConcurrentUserStats struct {
 type StatsService struct {
 	statsRepo    repository.StatsRepositoryInterface
 	activityRepo repository.ActivityRepositoryInterface
+	clock        clock.Clock
 }
 
 // NewStatsService creates a new stats service instance
@@ -31,9 +33,16 @@ func NewStatsService(
 	return &StatsService{
 		statsRepo:    statsRepo,
 		activityRepo: activityRepo,
+		clock:        clock.Real{},
 	}
 }
 
+// WithClock sets the clock default date ranges end at
+func (s *StatsService) WithClock(c clock.Clock) *StatsService {
+	s.clock = c
+	return s
+}
+
 // CalculateActivityStats computes statistics for a date range
 func (s *StatsService) CalculateActivityStats(
 	ctx context.Context,
@@ -57,11 +66,11 @@ func (s *StatsService) CalculateActivityStats(
 
 	// Business logic: Default to last 30 days if not specified
 	if start == nil {
-		defaultStart := time.Now().AddDate(0, 0, -30)
+		defaultStart := s.clock.Now().AddDate(0, 0, -30)
 		start = &defaultStart
 	}
 	if end == nil {
-		defaultEnd := time.Now()
+		defaultEnd := s.clock.Now()
 		end = &defaultEnd
 	}
 
@@ -72,7 +81,7 @@ func (s *StatsService) CalculateActivityStats(
 	}
 
 	// Fetch stats from repository
-	stats, err := s.activityRepo.GetStats(userID, start, end)
+	stats, err := s.activityRepo.GetStats(ctx, userID, start, end)
 	if err != nil {
 		return nil, err
 	}
@@ -95,7 +104,7 @@ func (s *StatsService) CalculateUserStatsConcurrent(ctx context.Context, userID
 	resultCh := make(chan statResult, 4)
 
 	go func() {
-		count, err := s.activityRepo.Count(userID)
+		count, err := s.activityRepo.Count(ctx, userID)
 		resultCh <- statResult{key: "count", value: count, err: err}
 	}()
 
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	webhookTypes "github.com/valentinesamuel/activelog/internal/adapters/webhook/types"
+)
+
+// StatsSummaryRefresher rebuilds one user's stats summaries
+// (repository.StatsSummaryRepository)
+type StatsSummaryRefresher interface {
+	RefreshUser(ctx context.Context, userID int) error
+}
+
+// StatsSummaryUpdater keeps a user's stats summaries current between the
+// worker's scheduled refreshes by rebuilding them after every activity event
+type StatsSummaryUpdater struct {
+	summaries StatsSummaryRefresher
+}
+
+// NewStatsSummaryUpdater creates a new StatsSummaryUpdater
+func NewStatsSummaryUpdater(summaries StatsSummaryRefresher) *StatsSummaryUpdater {
+	return &StatsSummaryUpdater{summaries: summaries}
+}
+
+// HandleEvent rebuilds the summaries of the user of an activity.* event.
+// Failures are logged; the next scheduled refresh catches up.
+func (u *StatsSummaryUpdater) HandleEvent(ctx context.Context, event webhookTypes.WebhookEvent) {
+	if !strings.HasPrefix(event.EventType, "activity.") {
+		return
+	}
+	if err := u.summaries.RefreshUser(ctx, event.UserID); err != nil {
+		log.Printf("Error refreshing stats summaries for user %d: %v", event.UserID, err)
+	}
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+)
+
+// ComputeCompliance scores planned sessions against the activities matched
+// to them. A session with a match is completed; one without is missed once
+// its day is before today, and upcoming until then.
+//
+// A completed session scores how close it came to each target the workout
+// sets, as the smaller of done/planned and planned/done, so overshooting
+// costs as much as falling short; its score is the average over the targets,
+// or 100 when the workout sets none. Missed sessions score 0.
+func ComputeCompliance(sessions []repository.PlannedSession, today time.Time) *models.WorkoutCompliance {
+	today = truncateDay(today)
+	compliance := &models.WorkoutCompliance{Sessions: make([]models.SessionCompliance, 0, len(sessions))}
+
+	var total float64
+	for _, session := range sessions {
+		sc := models.SessionCompliance{
+			WorkoutID: session.WorkoutID,
+			PlannedOn: session.PlannedOn.Format(time.DateOnly),
+			Match:     session.Match,
+		}
+
+		switch {
+		case session.Match != nil:
+			sc.Status = models.WorkoutCompleted
+			compliance.Completed++
+
+			var scores []float64
+			if session.PlannedDurationMinutes > 0 {
+				pct := percentOf(float64(session.Match.DurationMinutes), float64(session.PlannedDurationMinutes))
+				sc.DurationPct = &pct
+				scores = append(scores, closeness(pct))
+			}
+			if session.PlannedDistanceKm != nil && *session.PlannedDistanceKm > 0 {
+				pct := percentOf(session.Match.DistanceKm, *session.PlannedDistanceKm)
+				sc.DistancePct = &pct
+				scores = append(scores, closeness(pct))
+			}
+			score := 100.0
+			if len(scores) > 0 {
+				score = 0
+				for _, s := range scores {
+					score += s
+				}
+				score = roundTenth(score / float64(len(scores)))
+			}
+			sc.CompliancePct = &score
+			total += score
+		case truncateDay(session.PlannedOn).Before(today):
+			sc.Status = models.WorkoutMissed
+			compliance.Missed++
+			score := 0.0
+			sc.CompliancePct = &score
+		default:
+			sc.Status = models.WorkoutUpcoming
+			compliance.Upcoming++
+		}
+
+		compliance.Sessions = append(compliance.Sessions, sc)
+	}
+
+	if due := compliance.Completed + compliance.Missed; due > 0 {
+		pct := roundTenth(total / float64(due))
+		compliance.CompliancePct = &pct
+	}
+	return compliance
+}
+
+// percentOf returns done as a percentage of planned, to one decimal
+func percentOf(done, planned float64) float64 {
+	return roundTenth(done / planned * 100)
+}
+
+// closeness scores a percentage of a target out of 100: on target is 100,
+// and half or double the target are both 50
+func closeness(pct float64) float64 {
+	if pct <= 0 {
+		return 0
+	}
+	return math.Min(pct, 100*100/pct)
+}
+
+func roundTenth(v float64) float64 {
+	return math.Round(v*10) / 10
+}
+
+// truncateDay returns midnight UTC of t's day
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valentinesamuel/activelog/internal/models"
+	"github.com/valentinesamuel/activelog/internal/repository"
+)
+
+func TestComputeCompliance(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 5, d, 0, 0, 0, 0, time.UTC) }
+	tenK := 10.0
+	sessions := []repository.PlannedSession{
+		// 45 of 50 minutes and the full 10 km
+		{WorkoutID: 1, PlannedOn: day(4), PlannedDurationMinutes: 50, PlannedDistanceKm: &tenK,
+			Match: &models.WorkoutMatch{ActivityID: "a", DurationMinutes: 45, DistanceKm: 10}},
+		// Twice as long as planned
+		{WorkoutID: 2, PlannedOn: day(5), PlannedDurationMinutes: 30,
+			Match: &models.WorkoutMatch{ActivityID: "b", DurationMinutes: 60}},
+		{WorkoutID: 1, PlannedOn: day(6), PlannedDurationMinutes: 50},
+		// Today, not done yet
+		{WorkoutID: 1, PlannedOn: day(7), PlannedDurationMinutes: 50},
+	}
+
+	compliance := ComputeCompliance(sessions, time.Date(2026, 5, 7, 18, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, 2, compliance.Completed)
+	assert.Equal(t, 1, compliance.Missed)
+	assert.Equal(t, 1, compliance.Upcoming)
+	require.NotNil(t, compliance.CompliancePct)
+	assert.Equal(t, 48.3, *compliance.CompliancePct) // (95 + 50 + 0) / 3
+
+	require.Len(t, compliance.Sessions, 4)
+	first := compliance.Sessions[0]
+	assert.Equal(t, "2026-05-04", first.PlannedOn)
+	assert.Equal(t, models.WorkoutCompleted, first.Status)
+	assert.Equal(t, 90.0, *first.DurationPct)
+	assert.Equal(t, 100.0, *first.DistancePct)
+	assert.Equal(t, 95.0, *first.CompliancePct)
+
+	assert.Equal(t, 200.0, *compliance.Sessions[1].DurationPct)
+	assert.Equal(t, 50.0, *compliance.Sessions[1].CompliancePct)
+
+	assert.Equal(t, models.WorkoutMissed, compliance.Sessions[2].Status)
+	assert.Equal(t, 0.0, *compliance.Sessions[2].CompliancePct)
+
+	assert.Equal(t, models.WorkoutUpcoming, compliance.Sessions[3].Status)
+	assert.Nil(t, compliance.Sessions[3].CompliancePct)
+}
+
+func TestComputeCompliance_NothingDue(t *testing.T) {
+	sessions := []repository.PlannedSession{
+		{WorkoutID: 1, PlannedOn: time.Date(2026, 5, 8, 0, 0, 0, 0, time.UTC), PlannedDurationMinutes: 50},
+	}
+
+	compliance := ComputeCompliance(sessions, time.Date(2026, 5, 7, 0, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, 1, compliance.Upcoming)
+	assert.Nil(t, compliance.CompliancePct)
+}
+
+func TestWorkout_ComputeTotals(t *testing.T) {
+	minutes := func(m int) *int { s := m * 60; return &s }
+	km := func(d float64) *float64 { return &d }
+	workout := &models.Workout{Steps: models.WorkoutSteps{
+		{Kind: models.WorkoutStepWarmup, DurationSeconds: minutes(10)},
+		{Kind: models.WorkoutStepInterval, Repeat: 6, DistanceKm: km(0.4), DurationSeconds: minutes(2)},
+		{Kind: models.WorkoutStepCooldown, DurationSeconds: minutes(5)},
+	}}
+
+	workout.ComputeTotals()
+
+	assert.Equal(t, 27, workout.PlannedDurationMinutes)
+	require.NotNil(t, workout.PlannedDistanceKm)
+	assert.Equal(t, 2.4, *workout.PlannedDistanceKm)
+	assert.Equal(t, 1, workout.Steps[0].Repeat)
+}
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS group_members;
+DROP TABLE IF EXISTS groups;
+
+COMMIT;
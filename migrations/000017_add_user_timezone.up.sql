@@ -0,0 +1,8 @@
+BEGIN;
+
+-- IANA zone name, used to schedule per-user jobs (e.g. weekly summaries) in local time
+ALTER TABLE users ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+CREATE INDEX idx_users_timezone ON users(timezone) WHERE deleted_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+-- One row per queue message a worker has claimed, so redelivered messages
+-- (at-least-once delivery) are skipped instead of handled twice
+CREATE TABLE processed_messages (
+    message_id VARCHAR(64) PRIMARY KEY,
+    event VARCHAR(50) NOT NULL,
+    processed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_processed_messages_processed_at ON processed_messages(processed_at);
+
+COMMIT;
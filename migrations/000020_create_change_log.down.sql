@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS change_log;
+ALTER TABLE activities DROP COLUMN IF EXISTS version;
+
+COMMIT;
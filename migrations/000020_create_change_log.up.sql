@@ -0,0 +1,19 @@
+BEGIN;
+
+-- Optimistic concurrency for offline sync: bumped on every update/delete
+ALTER TABLE activities ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+-- Append-only feed of changes per user, written by the repositories in the same
+-- statement as the change. Clients sync from the last seq they have seen.
+CREATE TABLE change_log (
+    seq BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    entity VARCHAR(50) NOT NULL,
+    entity_id BIGINT NOT NULL,
+    operation VARCHAR(10) NOT NULL CHECK (operation IN ('create', 'update', 'delete')),
+    version INTEGER NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_change_log_user_entity_seq ON change_log(user_id, entity, seq);
+
+COMMIT;
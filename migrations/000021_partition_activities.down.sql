@@ -0,0 +1,58 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS activities_delete_dependents ON activities;
+DROP FUNCTION IF EXISTS delete_activity_dependents();
+
+ALTER TABLE activities RENAME TO activities_partitioned;
+ALTER SEQUENCE activities_id_seq OWNED BY NONE;
+
+CREATE TABLE activities (
+    id INTEGER NOT NULL DEFAULT nextval('activities_id_seq') PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    activity_type VARCHAR(50) NOT NULL,
+    title VARCHAR(255),
+    description TEXT,
+    duration_minutes INTEGER,
+    distance_km DECIMAL(10, 2),
+    calories_burned INTEGER,
+    notes TEXT,
+    activity_date TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    version INTEGER NOT NULL DEFAULT 1
+);
+ALTER SEQUENCE activities_id_seq OWNED BY activities.id;
+
+-- Archived activities come back into the live table
+INSERT INTO activities
+SELECT id, user_id, activity_type, title, description, duration_minutes, distance_km,
+    calories_burned, notes, activity_date, created_at, updated_at, deleted_at, version
+FROM activities_partitioned
+UNION ALL
+SELECT id, user_id, activity_type, title, description, duration_minutes, distance_km,
+    calories_burned, notes, activity_date, created_at, updated_at, deleted_at, version
+FROM activities_archive;
+
+DROP TABLE activities_partitioned;
+DROP TABLE activities_archive;
+
+CREATE INDEX idx_activities_user_id ON activities(user_id);
+CREATE INDEX idx_activities_date ON activities(activity_date);
+CREATE INDEX idx_activities_type ON activities(activity_type);
+CREATE INDEX idx_activities_user_date ON activities(user_id, activity_date);
+CREATE INDEX idx_activities_deleted_at ON activities(deleted_at) WHERE deleted_at IS NULL;
+
+-- Dependents of activities hard-deleted without the trigger can't be re-linked
+DELETE FROM activity_tags WHERE activity_id NOT IN (SELECT id FROM activities);
+DELETE FROM activity_photos WHERE activity_id NOT IN (SELECT id FROM activities);
+DELETE FROM activity_shares WHERE activity_id NOT IN (SELECT id FROM activities);
+
+ALTER TABLE activity_tags ADD CONSTRAINT activity_tags_activity_id_fkey
+    FOREIGN KEY (activity_id) REFERENCES activities(id) ON DELETE CASCADE;
+ALTER TABLE activity_photos ADD CONSTRAINT fk_activity
+    FOREIGN KEY (activity_id) REFERENCES activities(id) ON DELETE CASCADE;
+ALTER TABLE activity_shares ADD CONSTRAINT activity_shares_activity_id_fkey
+    FOREIGN KEY (activity_id) REFERENCES activities(id) ON DELETE CASCADE;
+
+COMMIT;
@@ -0,0 +1,101 @@
+BEGIN;
+
+-- Range-partition activities by activity_date (one partition per month).
+--
+-- Every unique constraint on a partitioned table must include the partition
+-- key, so the primary key becomes (id, activity_date) and other tables can no
+-- longer reference activities(id) with a foreign key. The cascades those keys
+-- provided are replaced by the delete_activity_dependents trigger below.
+-- ids still come from activities_id_seq, so they stay unique.
+
+ALTER TABLE activity_tags DROP CONSTRAINT IF EXISTS activity_tags_activity_id_fkey;
+ALTER TABLE activity_photos DROP CONSTRAINT IF EXISTS fk_activity;
+ALTER TABLE activity_shares DROP CONSTRAINT IF EXISTS activity_shares_activity_id_fkey;
+
+ALTER TABLE activities RENAME TO activities_unpartitioned;
+ALTER SEQUENCE activities_id_seq OWNED BY NONE;
+
+CREATE TABLE activities (
+    id INTEGER NOT NULL DEFAULT nextval('activities_id_seq'),
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    activity_type VARCHAR(50) NOT NULL,
+    title VARCHAR(255),
+    description TEXT,
+    duration_minutes INTEGER,
+    distance_km DECIMAL(10, 2),
+    calories_burned INTEGER,
+    notes TEXT,
+    activity_date TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    version INTEGER NOT NULL DEFAULT 1,
+    PRIMARY KEY (id, activity_date)
+) PARTITION BY RANGE (activity_date);
+
+-- pg_get_serial_sequence('activities', 'id') is used by bulk import
+ALTER SEQUENCE activities_id_seq OWNED BY activities.id;
+
+CREATE INDEX idx_activities_user_id ON activities(user_id);
+CREATE INDEX idx_activities_date ON activities(activity_date);
+CREATE INDEX idx_activities_type ON activities(activity_type);
+CREATE INDEX idx_activities_user_date ON activities(user_id, activity_date);
+CREATE INDEX idx_activities_deleted_at ON activities(deleted_at) WHERE deleted_at IS NULL;
+
+-- Monthly partitions named activities_pYYYY_MM, from the oldest activity to
+-- three months ahead. The worker's partition maintenance job keeps creating
+-- them ahead of time; the default partition catches anything outside.
+DO $$
+DECLARE
+    month DATE;
+BEGIN
+    FOR month IN
+        SELECT generate_series(
+            date_trunc('month', COALESCE((SELECT MIN(activity_date) FROM activities_unpartitioned), NOW())),
+            date_trunc('month', NOW()) + INTERVAL '3 months',
+            INTERVAL '1 month'
+        )::date
+    LOOP
+        EXECUTE format(
+            'CREATE TABLE %I PARTITION OF activities FOR VALUES FROM (%L) TO (%L)',
+            'activities_p' || to_char(month, 'YYYY_MM'),
+            month,
+            month + INTERVAL '1 month'
+        );
+    END LOOP;
+END $$;
+
+CREATE TABLE activities_default PARTITION OF activities DEFAULT;
+
+INSERT INTO activities (id, user_id, activity_type, title, description, duration_minutes, distance_km,
+    calories_burned, notes, activity_date, created_at, updated_at, deleted_at, version)
+SELECT id, user_id, activity_type, title, description, duration_minutes, distance_km,
+    calories_burned, notes, activity_date, created_at, updated_at, deleted_at, version
+FROM activities_unpartitioned;
+
+DROP TABLE activities_unpartitioned;
+
+-- Activities in partitions past the retention window are moved here by the
+-- worker. Archived rows are read-only history and are not served by the API.
+CREATE TABLE activities_archive (
+    LIKE activities INCLUDING DEFAULTS,
+    archived_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+CREATE INDEX idx_activities_archive_user_date ON activities_archive(user_id, activity_date);
+
+-- Replaces ON DELETE CASCADE from the dropped foreign keys
+CREATE FUNCTION delete_activity_dependents() RETURNS trigger AS $$
+BEGIN
+    DELETE FROM activity_tags WHERE activity_id = OLD.id;
+    DELETE FROM activity_photos WHERE activity_id = OLD.id;
+    DELETE FROM activity_shares WHERE activity_id = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER activities_delete_dependents
+    AFTER DELETE ON activities
+    FOR EACH ROW EXECUTE FUNCTION delete_activity_dependents();
+
+COMMIT;
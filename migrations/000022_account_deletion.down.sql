@@ -0,0 +1,12 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_users_deletion_scheduled_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deletion_scheduled_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deletion_token_expires_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deletion_token_hash;
+
+DELETE FROM exports WHERE format = 'zip';
+ALTER TABLE exports DROP CONSTRAINT exports_format_check;
+ALTER TABLE exports ADD CONSTRAINT exports_format_check CHECK (format IN ('csv', 'pdf'));
+
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN;
+
+-- GDPR data exports are delivered as a ZIP bundle
+ALTER TABLE exports DROP CONSTRAINT exports_format_check;
+ALTER TABLE exports ADD CONSTRAINT exports_format_check CHECK (format IN ('csv', 'pdf', 'zip'));
+
+-- Staged account deletion: DELETE /users/me issues a short-lived confirmation
+-- token; confirming it disables the account (deleted_at) and schedules the
+-- hard delete for the end of the grace period
+ALTER TABLE users ADD COLUMN deletion_token_hash TEXT;
+ALTER TABLE users ADD COLUMN deletion_token_expires_at TIMESTAMP;
+ALTER TABLE users ADD COLUMN deletion_scheduled_at TIMESTAMP;
+
+CREATE INDEX idx_users_deletion_scheduled_at ON users(deletion_scheduled_at) WHERE deletion_scheduled_at IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+-- Public profile fields and per-user preferences (units, privacy defaults).
+-- Preferences are a JSONB document so new settings need no migration; the
+-- API validates its shape (see models.UserPreferences).
+ALTER TABLE users ADD COLUMN display_name VARCHAR(100);
+ALTER TABLE users ADD COLUMN bio TEXT;
+ALTER TABLE users ADD COLUMN avatar_key TEXT;
+ALTER TABLE users ADD COLUMN preferences JSONB NOT NULL DEFAULT '{}';
+
+COMMIT;
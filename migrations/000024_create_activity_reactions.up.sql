@@ -0,0 +1,27 @@
+BEGIN;
+
+-- One reaction per user per activity; reacting again changes its type.
+-- activities is partitioned, so activity_id can't be a foreign key (see
+-- 000021); delete_activity_dependents removes reactions instead.
+CREATE TABLE activity_reactions (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    activity_id INTEGER NOT NULL,
+    reaction VARCHAR(20) NOT NULL CHECK (reaction IN ('kudos', 'fire', 'clap', 'heart')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, activity_id)
+);
+
+CREATE INDEX idx_activity_reactions_activity_id ON activity_reactions(activity_id);
+
+CREATE OR REPLACE FUNCTION delete_activity_dependents() RETURNS trigger AS $$
+BEGIN
+    DELETE FROM activity_tags WHERE activity_id = OLD.id;
+    DELETE FROM activity_photos WHERE activity_id = OLD.id;
+    DELETE FROM activity_shares WHERE activity_id = OLD.id;
+    DELETE FROM activity_reactions WHERE activity_id = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+COMMIT;
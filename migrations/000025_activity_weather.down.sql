@@ -0,0 +1,15 @@
+BEGIN;
+
+ALTER TABLE activities_archive
+    DROP COLUMN IF EXISTS weather_conditions,
+    DROP COLUMN IF EXISTS temperature_c,
+    DROP COLUMN IF EXISTS start_lng,
+    DROP COLUMN IF EXISTS start_lat;
+
+ALTER TABLE activities
+    DROP COLUMN IF EXISTS weather_conditions,
+    DROP COLUMN IF EXISTS temperature_c,
+    DROP COLUMN IF EXISTS start_lng,
+    DROP COLUMN IF EXISTS start_lat;
+
+COMMIT;
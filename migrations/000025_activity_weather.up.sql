@@ -0,0 +1,18 @@
+BEGIN;
+
+-- Optional start coordinates, and the weather at that place and time filled
+-- in afterwards by the enrich_weather job. activities_archive gets the same
+-- columns so archiving keeps them.
+ALTER TABLE activities
+    ADD COLUMN start_lat DOUBLE PRECISION,
+    ADD COLUMN start_lng DOUBLE PRECISION,
+    ADD COLUMN temperature_c NUMERIC(4, 1),
+    ADD COLUMN weather_conditions VARCHAR(50);
+
+ALTER TABLE activities_archive
+    ADD COLUMN start_lat DOUBLE PRECISION,
+    ADD COLUMN start_lng DOUBLE PRECISION,
+    ADD COLUMN temperature_c NUMERIC(4, 1),
+    ADD COLUMN weather_conditions VARCHAR(50);
+
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_activities_start_point;
+
+ALTER TABLE activities_archive
+    DROP COLUMN IF EXISTS location_name,
+    DROP COLUMN IF EXISTS end_lng,
+    DROP COLUMN IF EXISTS end_lat;
+
+ALTER TABLE activities
+    DROP COLUMN IF EXISTS location_name,
+    DROP COLUMN IF EXISTS end_lng,
+    DROP COLUMN IF EXISTS end_lat;
+
+COMMIT;
@@ -0,0 +1,21 @@
+BEGIN;
+
+-- Optional end coordinates and a free-text location name. A name without
+-- coordinates is resolved to start_lat/start_lng by the geocode_activity job.
+ALTER TABLE activities
+    ADD COLUMN end_lat DOUBLE PRECISION,
+    ADD COLUMN end_lng DOUBLE PRECISION,
+    ADD COLUMN location_name VARCHAR(255);
+
+ALTER TABLE activities_archive
+    ADD COLUMN end_lat DOUBLE PRECISION,
+    ADD COLUMN end_lng DOUBLE PRECISION,
+    ADD COLUMN location_name VARCHAR(255);
+
+-- Bounding-box filters (filter[location][within]) test the start point with
+-- point <@ box; the expression must match the one in pkg/query exactly
+CREATE INDEX idx_activities_start_point ON activities
+    USING GIST (point(start_lng, start_lat))
+    WHERE start_lat IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_activities_metrics_version;
+
+ALTER TABLE activities_archive
+    DROP COLUMN IF EXISTS metrics_version,
+    DROP COLUMN IF EXISTS calories_estimated,
+    DROP COLUMN IF EXISTS avg_speed_kmh,
+    DROP COLUMN IF EXISTS pace_min_per_km;
+
+ALTER TABLE activities
+    DROP COLUMN IF EXISTS metrics_version,
+    DROP COLUMN IF EXISTS calories_estimated,
+    DROP COLUMN IF EXISTS avg_speed_kmh,
+    DROP COLUMN IF EXISTS pace_min_per_km;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS weight_kg;
+
+COMMIT;
@@ -0,0 +1,25 @@
+BEGIN;
+
+-- Body weight, used to estimate calories for activities logged without them
+ALTER TABLE users
+    ADD COLUMN weight_kg NUMERIC(5, 1) CHECK (weight_kg > 0);
+
+-- Metrics derived from type, duration, distance and the owner's weight.
+-- metrics_version records the formula they were computed with; the
+-- backfill_activity_metrics job recomputes rows below the current version.
+ALTER TABLE activities
+    ADD COLUMN pace_min_per_km NUMERIC(6, 2),
+    ADD COLUMN avg_speed_kmh NUMERIC(6, 2),
+    ADD COLUMN calories_estimated BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN metrics_version SMALLINT NOT NULL DEFAULT 0;
+
+ALTER TABLE activities_archive
+    ADD COLUMN pace_min_per_km NUMERIC(6, 2),
+    ADD COLUMN avg_speed_kmh NUMERIC(6, 2),
+    ADD COLUMN calories_estimated BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN metrics_version SMALLINT NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_activities_metrics_version ON activities (metrics_version, id)
+    WHERE deleted_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+
+ALTER TABLE activities_archive
+    DROP COLUMN IF EXISTS training_load,
+    DROP COLUMN IF EXISTS hr_zone_seconds,
+    DROP COLUMN IF EXISTS avg_heart_rate;
+
+ALTER TABLE activities
+    DROP COLUMN IF EXISTS training_load,
+    DROP COLUMN IF EXISTS hr_zone_seconds,
+    DROP COLUMN IF EXISTS avg_heart_rate;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS heart_rate_zones,
+    DROP COLUMN IF EXISTS max_heart_rate;
+
+COMMIT;
@@ -0,0 +1,22 @@
+BEGIN;
+
+-- Heart-rate zones: heart_rate_zones holds the lower bound (bpm) of each of
+-- the five zones. When it is NULL the zones are derived from max_heart_rate.
+ALTER TABLE users
+    ADD COLUMN max_heart_rate SMALLINT CHECK (max_heart_rate BETWEEN 100 AND 250),
+    ADD COLUMN heart_rate_zones SMALLINT[] CHECK (cardinality(heart_rate_zones) = 5);
+
+-- Computed from the heart-rate samples sent when the activity is logged.
+-- hr_zone_seconds[i] is the time spent in zone i; training_load is the
+-- zone-weighted minutes (Edwards TRIMP) used by the training-load stats.
+ALTER TABLE activities
+    ADD COLUMN avg_heart_rate SMALLINT,
+    ADD COLUMN hr_zone_seconds INT[],
+    ADD COLUMN training_load NUMERIC(7, 1);
+
+ALTER TABLE activities_archive
+    ADD COLUMN avg_heart_rate SMALLINT,
+    ADD COLUMN hr_zone_seconds INT[],
+    ADD COLUMN training_load NUMERIC(7, 1);
+
+COMMIT;
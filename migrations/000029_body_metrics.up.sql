@@ -0,0 +1,20 @@
+BEGIN;
+
+-- Daily body measurements. A user logs at most one value per metric per day.
+CREATE TABLE body_metrics (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    metric_type VARCHAR(20) NOT NULL CHECK (metric_type IN ('weight_kg', 'resting_hr', 'sleep_hours')),
+    value NUMERIC(6, 2) NOT NULL CHECK (value > 0),
+    recorded_on DATE NOT NULL,
+    notes TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_body_metrics_user_type_date UNIQUE (user_id, metric_type, recorded_on)
+);
+
+-- The unique constraint's index serves per-type trend queries; this one
+-- serves date-range listings across types
+CREATE INDEX idx_body_metrics_user_date ON body_metrics (user_id, recorded_on);
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TABLE IF EXISTS user_identities;
+
+-- Users without a password can't be kept once identities are gone
+DELETE FROM users WHERE password_hash IS NULL;
+ALTER TABLE users ALTER COLUMN password_hash SET NOT NULL;
+
+COMMIT;
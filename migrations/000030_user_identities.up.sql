@@ -0,0 +1,20 @@
+BEGIN;
+
+-- External login methods (Google, GitHub, Apple). A user can link several,
+-- each provider account belongs to at most one user.
+CREATE TABLE user_identities (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(20) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_login_at TIMESTAMP,
+    CONSTRAINT uq_user_identities_provider_subject UNIQUE (provider, subject),
+    CONSTRAINT uq_user_identities_user_provider UNIQUE (user_id, provider)
+);
+
+-- Users who signed up with a provider have no password
+ALTER TABLE users ALTER COLUMN password_hash DROP NOT NULL;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS stats_refreshes;
+DROP TABLE IF EXISTS stats_tag_usage;
+DROP TABLE IF EXISTS stats_daily_activity;
+
+COMMIT;
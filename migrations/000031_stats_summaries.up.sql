@@ -0,0 +1,33 @@
+BEGIN;
+
+-- Pre-aggregated stats, rebuilt by the worker's refresh-stats-summaries job and
+-- per user after activity changes. Read instead of activities when
+-- STATS_READ_FROM_SUMMARIES=true. Deleted activities are left out.
+
+-- Activity totals per user, day and type
+CREATE TABLE stats_daily_activity (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    activity_type VARCHAR(50) NOT NULL,
+    activity_count INTEGER NOT NULL,
+    duration_minutes BIGINT NOT NULL,
+    distance_km DOUBLE PRECISION NOT NULL,
+    PRIMARY KEY (user_id, day, activity_type)
+);
+
+-- Number of activities per user and tag
+CREATE TABLE stats_tag_usage (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    usage_count INTEGER NOT NULL,
+    PRIMARY KEY (user_id, tag_id)
+);
+
+-- When each user's summaries were last rebuilt; users without a row are
+-- served from the live queries
+CREATE TABLE stats_refreshes (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    refreshed_at TIMESTAMPTZ NOT NULL
+);
+
+COMMIT;
@@ -0,0 +1,46 @@
+BEGIN;
+
+-- Registry of activity types. Rows without a user_id are the defaults every
+-- user can pick; users add their own on top. activities.activity_type stores
+-- the registered name, matched case-insensitively when an activity is saved.
+CREATE TABLE activity_types (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    display_name VARCHAR(100) NOT NULL,
+    icon VARCHAR(50),
+    color VARCHAR(7) CHECK (color ~ '^#[0-9A-Fa-f]{6}$'),
+    metadata JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One type per name among the defaults, and per user among their own
+CREATE UNIQUE INDEX uq_activity_types_default_name ON activity_types (LOWER(name)) WHERE user_id IS NULL;
+CREATE UNIQUE INDEX uq_activity_types_user_name ON activity_types (user_id, LOWER(name)) WHERE user_id IS NOT NULL;
+
+INSERT INTO activity_types (name, display_name, icon, color) VALUES
+    ('running', 'Running', 'run', '#E4572E'),
+    ('walking', 'Walking', 'walk', '#76B041'),
+    ('hiking', 'Hiking', 'mountain', '#8C6D46'),
+    ('cycling', 'Cycling', 'bike', '#17BEBB'),
+    ('swimming', 'Swimming', 'swim', '#2E86DE'),
+    ('rowing', 'Rowing', 'rowing', '#3D5A80'),
+    ('yoga', 'Yoga', 'yoga', '#A06CD5'),
+    ('strength', 'Strength Training', 'dumbbell', '#FFC914'),
+    ('hiit', 'HIIT', 'flame', '#F25F5C'),
+    ('other', 'Other', 'activity', '#7D8491');
+
+-- Keep every type already in use valid: register the ones that aren't
+-- defaults as types of the users who logged them
+INSERT INTO activity_types (user_id, name, display_name)
+SELECT DISTINCT ON (a.user_id, LOWER(a.activity_type))
+    a.user_id, a.activity_type, INITCAP(a.activity_type)
+FROM activities a
+WHERE NOT EXISTS (
+    SELECT 1 FROM activity_types t
+    WHERE t.user_id IS NULL AND LOWER(t.name) = LOWER(a.activity_type)
+)
+ORDER BY a.user_id, LOWER(a.activity_type), a.activity_type;
+
+COMMIT;
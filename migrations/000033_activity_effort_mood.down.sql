@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE activities_archive
+    DROP COLUMN IF EXISTS mood,
+    DROP COLUMN IF EXISTS rpe;
+
+ALTER TABLE activities
+    DROP COLUMN IF EXISTS mood,
+    DROP COLUMN IF EXISTS rpe;
+
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+
+-- How the activity felt, as logged by the user: rpe is the rating of
+-- perceived exertion (1 = very easy, 10 = maximal effort) and mood how they
+-- felt afterwards (1 = very bad, 5 = great). Both are optional.
+ALTER TABLE activities
+    ADD COLUMN rpe SMALLINT CHECK (rpe BETWEEN 1 AND 10),
+    ADD COLUMN mood SMALLINT CHECK (mood BETWEEN 1 AND 5);
+
+ALTER TABLE activities_archive
+    ADD COLUMN rpe SMALLINT,
+    ADD COLUMN mood SMALLINT;
+
+COMMIT;
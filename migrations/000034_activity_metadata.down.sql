@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_activities_metadata;
+
+ALTER TABLE activities_archive DROP COLUMN IF EXISTS metadata;
+ALTER TABLE activities DROP COLUMN IF EXISTS metadata;
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+-- Client-specific data about an activity (e.g. the shoe or bike used), as a
+-- JSON object the API only bounds in size (see models.ActivityMetadata).
+-- Lists filter on its keys with filter[metadata.key]; the GIN index serves
+-- containment (@>) lookups on the document.
+ALTER TABLE activities ADD COLUMN metadata JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE activities_archive ADD COLUMN metadata JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_activities_metadata ON activities USING GIN (metadata jsonb_path_ops);
+
+COMMIT;
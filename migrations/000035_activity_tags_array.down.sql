@@ -0,0 +1,13 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS tags_refresh_activity_tags ON tags;
+DROP TRIGGER IF EXISTS activity_tags_refresh_tags ON activity_tags;
+DROP FUNCTION IF EXISTS tags_changed();
+DROP FUNCTION IF EXISTS activity_tags_changed();
+DROP FUNCTION IF EXISTS refresh_activity_tags(INTEGER);
+DROP INDEX IF EXISTS idx_activities_tags;
+
+ALTER TABLE activities_archive DROP COLUMN IF EXISTS tags;
+ALTER TABLE activities DROP COLUMN IF EXISTS tags;
+
+COMMIT;
@@ -0,0 +1,66 @@
+BEGIN;
+
+-- Denormalized, sorted copy of the names of each activity's live tags, so
+-- lists can filter on tags with array operators (filter[tags][contains]=...)
+-- instead of joining activity_tags and tags. activity_tags and tags stay the
+-- source of truth; the triggers below keep the copy in step with them.
+ALTER TABLE activities ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE activities_archive ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+
+-- Serves @> (contains) and && (overlaps)
+CREATE INDEX IF NOT EXISTS idx_activities_tags ON activities USING GIN (tags);
+
+CREATE FUNCTION refresh_activity_tags(target INTEGER) RETURNS void AS $$
+    UPDATE activities SET tags = COALESCE((
+        SELECT array_agg(t.name ORDER BY t.name)
+        FROM activity_tags at
+        JOIN tags t ON t.id = at.tag_id
+        WHERE at.activity_id = target AND at.deleted_at IS NULL AND t.deleted_at IS NULL
+    ), '{}')
+    WHERE id = target;
+$$ LANGUAGE sql;
+
+CREATE FUNCTION activity_tags_changed() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        PERFORM refresh_activity_tags(OLD.activity_id);
+    END IF;
+    IF TG_OP = 'INSERT' OR (TG_OP = 'UPDATE' AND NEW.activity_id <> OLD.activity_id) THEN
+        PERFORM refresh_activity_tags(NEW.activity_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER activity_tags_refresh_tags
+    AFTER INSERT OR UPDATE OR DELETE ON activity_tags
+    FOR EACH ROW EXECUTE FUNCTION activity_tags_changed();
+
+-- Renaming or soft-deleting a tag changes every activity it is linked to
+CREATE FUNCTION tags_changed() RETURNS trigger AS $$
+BEGIN
+    PERFORM refresh_activity_tags(at.activity_id)
+    FROM activity_tags at
+    WHERE at.tag_id = NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tags_refresh_activity_tags
+    AFTER UPDATE OF name, deleted_at ON tags
+    FOR EACH ROW
+    WHEN (NEW.name IS DISTINCT FROM OLD.name OR NEW.deleted_at IS DISTINCT FROM OLD.deleted_at)
+    EXECUTE FUNCTION tags_changed();
+
+-- Backfill
+UPDATE activities a SET tags = linked.names
+FROM (
+    SELECT at.activity_id, array_agg(t.name ORDER BY t.name) AS names
+    FROM activity_tags at
+    JOIN tags t ON t.id = at.tag_id
+    WHERE at.deleted_at IS NULL AND t.deleted_at IS NULL
+    GROUP BY at.activity_id
+) linked
+WHERE a.id = linked.activity_id;
+
+COMMIT;
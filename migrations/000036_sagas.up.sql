@@ -0,0 +1,18 @@
+BEGIN;
+
+-- Progress of multi-step operations that span Postgres and other systems
+-- (e.g. account deletion), so a worker restart resumes them rather than
+-- starting over. completed_steps lists the step names in the order they ran.
+CREATE TABLE sagas (
+    id VARCHAR(255) PRIMARY KEY,
+    status VARCHAR(20) NOT NULL DEFAULT 'running' CHECK (status IN ('running', 'compensating', 'completed', 'compensated')),
+    completed_steps TEXT[] NOT NULL DEFAULT '{}',
+    total_steps SMALLINT NOT NULL DEFAULT 0,
+    current_step VARCHAR(100),
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_sagas_status ON sagas(status) WHERE status IN ('running', 'compensating');
+
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+
+ALTER TABLE activities_archive DROP COLUMN IF EXISTS public_id;
+DROP INDEX IF EXISTS idx_activities_public_id;
+ALTER TABLE activities DROP COLUMN IF EXISTS public_id;
+DROP INDEX IF EXISTS idx_activity_shares_public_id;
+ALTER TABLE activity_shares DROP COLUMN IF EXISTS public_id;
+DROP INDEX IF EXISTS idx_tags_public_id;
+ALTER TABLE tags DROP COLUMN IF EXISTS public_id;
+DROP INDEX IF EXISTS idx_users_public_id;
+ALTER TABLE users DROP COLUMN IF EXISTS public_id;
+DROP FUNCTION IF EXISTS generate_ulid(TIMESTAMPTZ);
+
+COMMIT;
@@ -0,0 +1,50 @@
+BEGIN;
+
+-- Public identifiers (ULIDs) for the rows whose IDs appear in URLs, webhooks
+-- and exports. Serial IDs stay the primary and foreign keys. The repositories
+-- generate public IDs on insert; the column defaults cover rows inserted with
+-- plain SQL, e.g. by seeds and tests.
+CREATE FUNCTION generate_ulid(at TIMESTAMPTZ DEFAULT clock_timestamp()) RETURNS CHAR(26) AS $$
+DECLARE
+    alphabet CONSTANT TEXT := '0123456789ABCDEFGHJKMNPQRSTVWXYZ';
+    ms BIGINT := floor(extract(epoch FROM at) * 1000);
+    id TEXT := '';
+BEGIN
+    FOR i IN REVERSE 9..0 LOOP
+        id := id || substr(alphabet, ((ms >> (i * 5)) & 31)::INT + 1, 1);
+    END LOOP;
+    FOR i IN 1..16 LOOP
+        id := id || substr(alphabet, floor(random() * 32)::INT + 1, 1);
+    END LOOP;
+    RETURN id;
+END;
+$$ LANGUAGE plpgsql VOLATILE;
+
+-- Existing rows get the timestamp of their creation
+ALTER TABLE users ADD COLUMN public_id CHAR(26);
+UPDATE users SET public_id = generate_ulid(COALESCE(created_at, NOW()) AT TIME ZONE 'UTC');
+ALTER TABLE users ALTER COLUMN public_id SET NOT NULL, ALTER COLUMN public_id SET DEFAULT generate_ulid();
+CREATE UNIQUE INDEX idx_users_public_id ON users(public_id);
+
+ALTER TABLE tags ADD COLUMN public_id CHAR(26);
+UPDATE tags SET public_id = generate_ulid(COALESCE(created_at, NOW()) AT TIME ZONE 'UTC');
+ALTER TABLE tags ALTER COLUMN public_id SET NOT NULL, ALTER COLUMN public_id SET DEFAULT generate_ulid();
+CREATE UNIQUE INDEX idx_tags_public_id ON tags(public_id);
+
+ALTER TABLE activity_shares ADD COLUMN public_id CHAR(26);
+UPDATE activity_shares SET public_id = generate_ulid(COALESCE(created_at, NOW()) AT TIME ZONE 'UTC');
+ALTER TABLE activity_shares ALTER COLUMN public_id SET NOT NULL, ALTER COLUMN public_id SET DEFAULT generate_ulid();
+CREATE UNIQUE INDEX idx_activity_shares_public_id ON activity_shares(public_id);
+
+-- A unique index on the partitioned table would have to include
+-- activity_date; the 80 random bits of a ULID make collisions negligible.
+ALTER TABLE activities ADD COLUMN public_id CHAR(26);
+UPDATE activities SET public_id = generate_ulid(COALESCE(created_at, NOW()) AT TIME ZONE 'UTC');
+ALTER TABLE activities ALTER COLUMN public_id SET NOT NULL, ALTER COLUMN public_id SET DEFAULT generate_ulid();
+CREATE INDEX idx_activities_public_id ON activities(public_id);
+
+ALTER TABLE activities_archive ADD COLUMN public_id CHAR(26);
+UPDATE activities_archive SET public_id = generate_ulid(COALESCE(created_at, NOW()) AT TIME ZONE 'UTC');
+ALTER TABLE activities_archive ALTER COLUMN public_id SET NOT NULL;
+
+COMMIT;
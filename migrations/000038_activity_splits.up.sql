@@ -0,0 +1,26 @@
+BEGIN;
+
+-- Laps/splits of an activity, numbered from 1 in the order they were run.
+-- activities is partitioned, so activity_id can't be a foreign key (see
+-- 000021); delete_activity_dependents removes splits instead.
+CREATE TABLE activity_splits (
+    activity_id INTEGER NOT NULL,
+    split_index INTEGER NOT NULL CHECK (split_index > 0),
+    distance_km DECIMAL(10, 3) NOT NULL CHECK (distance_km > 0),
+    duration_seconds INTEGER NOT NULL CHECK (duration_seconds > 0),
+    avg_heart_rate INTEGER,
+    PRIMARY KEY (activity_id, split_index)
+);
+
+CREATE OR REPLACE FUNCTION delete_activity_dependents() RETURNS trigger AS $$
+BEGIN
+    DELETE FROM activity_tags WHERE activity_id = OLD.id;
+    DELETE FROM activity_photos WHERE activity_id = OLD.id;
+    DELETE FROM activity_shares WHERE activity_id = OLD.id;
+    DELETE FROM activity_reactions WHERE activity_id = OLD.id;
+    DELETE FROM activity_splits WHERE activity_id = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+COMMIT;
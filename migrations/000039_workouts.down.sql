@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS planned_workouts;
+DROP TABLE IF EXISTS workouts;
+
+COMMIT;
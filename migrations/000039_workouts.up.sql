@@ -0,0 +1,34 @@
+BEGIN;
+
+-- Structured workouts a user plans: steps (warmup, intervals, recovery,
+-- cooldown) with duration/distance targets. The planned totals are the sums
+-- over the steps, kept here so compliance queries don't unpack steps.
+CREATE TABLE workouts (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    activity_type VARCHAR(50) NOT NULL,
+    description TEXT,
+    steps JSONB NOT NULL DEFAULT '[]',
+    planned_duration_minutes INTEGER NOT NULL DEFAULT 0,
+    planned_distance_km NUMERIC(10, 3),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_workouts_user_id ON workouts (user_id);
+
+-- A workout assigned to a day. Activities are matched to it when compliance
+-- is computed, so editing or deleting an activity is reflected.
+CREATE TABLE planned_workouts (
+    id BIGSERIAL PRIMARY KEY,
+    workout_id BIGINT NOT NULL REFERENCES workouts(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    planned_on DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_planned_workouts_workout_date UNIQUE (workout_id, planned_on)
+);
+
+CREATE INDEX idx_planned_workouts_user_date ON planned_workouts (user_id, planned_on);
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+-- Coaches an athlete shares their data with. The athlete invites a coach,
+-- who must accept before the grant is active; either side can end it. Every
+-- grant allows reading the athlete's activities and stats, and can_comment
+-- additionally allows commenting on their activities.
+CREATE TABLE coach_access (
+    athlete_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    coach_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    can_comment BOOLEAN NOT NULL DEFAULT false,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'active')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    accepted_at TIMESTAMP,
+    PRIMARY KEY (athlete_id, coach_id),
+    CONSTRAINT chk_coach_access_not_self CHECK (athlete_id <> coach_id)
+);
+
+CREATE INDEX idx_coach_access_coach_id ON coach_access (coach_id);
+
+COMMIT;
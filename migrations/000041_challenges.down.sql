@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS badges;
+DROP TABLE IF EXISTS challenge_participants;
+DROP TABLE IF EXISTS challenges;
+
+COMMIT;
@@ -0,0 +1,48 @@
+BEGIN;
+
+-- Time-boxed challenges ("100 km in March"): a target for one metric over
+-- the activities logged between starts_on and ends_on (inclusive),
+-- optionally of one activity type only
+CREATE TABLE challenges (
+    id BIGSERIAL PRIMARY KEY,
+    creator_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    activity_type VARCHAR(50),
+    metric VARCHAR(20) NOT NULL CHECK (metric IN ('distance_km', 'duration_minutes', 'activities')),
+    target NUMERIC(10, 2) NOT NULL CHECK (target > 0),
+    starts_on DATE NOT NULL,
+    ends_on DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_challenges_dates CHECK (ends_on >= starts_on)
+);
+
+CREATE INDEX idx_challenges_ends_on ON challenges (ends_on);
+
+-- progress is recomputed from the activities by the
+-- refresh_challenge_progress job; completed_at is set once, the first time
+-- progress reaches the target
+CREATE TABLE challenge_participants (
+    challenge_id BIGINT NOT NULL REFERENCES challenges(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    progress NUMERIC(12, 2) NOT NULL DEFAULT 0,
+    joined_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP,
+    PRIMARY KEY (challenge_id, user_id)
+);
+
+CREATE INDEX idx_challenge_participants_user_id ON challenge_participants (user_id);
+
+-- Badges awarded for completed challenges. They outlive the challenge, so
+-- they keep its name.
+CREATE TABLE badges (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    challenge_id BIGINT REFERENCES challenges(id) ON DELETE SET NULL,
+    name VARCHAR(100) NOT NULL,
+    awarded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_badges_user_challenge UNIQUE (user_id, challenge_id)
+);
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+-- Achievements users earned. The rules defining them live in code
+-- (service.AchievementRules); code identifies the rule. The primary key
+-- makes awarding idempotent, so a retried evaluation can't award twice.
+CREATE TABLE user_achievements (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code VARCHAR(50) NOT NULL,
+    awarded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, code)
+);
+
+COMMIT;
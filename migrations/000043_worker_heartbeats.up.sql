@@ -0,0 +1,20 @@
+BEGIN;
+
+-- One row per running worker process, upserted every heartbeat interval so
+-- the API can list the worker fleet. A worker deletes its row when it stops
+-- cleanly; rows of crashed workers are pruned by the other workers.
+CREATE TABLE worker_heartbeats (
+    worker_id VARCHAR(255) PRIMARY KEY,
+    hostname VARCHAR(255) NOT NULL,
+    pid INTEGER NOT NULL,
+    queue_provider VARCHAR(20) NOT NULL,
+    queue_error TEXT,
+    handlers INTEGER NOT NULL DEFAULT 0,
+    in_flight INTEGER NOT NULL DEFAULT 0,
+    processed BIGINT NOT NULL DEFAULT 0,
+    failed BIGINT NOT NULL DEFAULT 0,
+    started_at TIMESTAMP NOT NULL,
+    last_seen_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMIT;
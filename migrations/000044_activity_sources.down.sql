@@ -0,0 +1,16 @@
+BEGIN;
+
+ALTER TABLE imports
+    DROP COLUMN IF EXISTS skipped_rows;
+
+ALTER TABLE activities_archive
+    DROP COLUMN IF EXISTS source_id,
+    DROP COLUMN IF EXISTS source;
+
+DROP INDEX IF EXISTS idx_activities_source_id;
+
+ALTER TABLE activities
+    DROP COLUMN IF EXISTS source_id,
+    DROP COLUMN IF EXISTS source;
+
+COMMIT;
@@ -0,0 +1,21 @@
+BEGIN;
+
+-- Where an imported activity came from: the import source (apple_health,
+-- google_fit) and the ID the app gave it there. Importing the same export
+-- again skips the activities already imported, found by this index.
+ALTER TABLE activities
+    ADD COLUMN source VARCHAR(20),
+    ADD COLUMN source_id VARCHAR(255);
+
+CREATE INDEX idx_activities_source_id ON activities(user_id, source, source_id)
+    WHERE source_id IS NOT NULL;
+
+ALTER TABLE activities_archive
+    ADD COLUMN source VARCHAR(20),
+    ADD COLUMN source_id VARCHAR(255);
+
+-- Rows of an import left out because they were imported before
+ALTER TABLE imports
+    ADD COLUMN skipped_rows INTEGER NOT NULL DEFAULT 0;
+
+COMMIT;
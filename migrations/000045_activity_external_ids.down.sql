@@ -0,0 +1,27 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_activities_archive_external_id;
+
+ALTER TABLE activities_archive RENAME COLUMN external_id TO source_id;
+
+ALTER TABLE activities_archive
+    ALTER COLUMN source DROP NOT NULL,
+    ALTER COLUMN source DROP DEFAULT;
+
+UPDATE activities_archive SET source = NULL WHERE source = 'manual';
+
+DROP INDEX IF EXISTS idx_activities_external_id;
+
+ALTER TABLE activities RENAME COLUMN external_id TO source_id;
+
+ALTER TABLE activities
+    DROP CONSTRAINT IF EXISTS chk_activities_source,
+    ALTER COLUMN source DROP NOT NULL,
+    ALTER COLUMN source DROP DEFAULT;
+
+UPDATE activities SET source = NULL WHERE source = 'manual';
+
+CREATE INDEX idx_activities_source_id ON activities(user_id, source, source_id)
+    WHERE source_id IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,33 @@
+BEGIN;
+
+-- Every activity records where it came from: manual when logged through the
+-- API, otherwise the import source. The ID the other app gave it becomes
+-- external_id.
+UPDATE activities SET source = 'manual' WHERE source IS NULL;
+
+ALTER TABLE activities
+    ALTER COLUMN source SET DEFAULT 'manual',
+    ALTER COLUMN source SET NOT NULL,
+    ADD CONSTRAINT chk_activities_source
+        CHECK (source IN ('manual', 'json', 'strava', 'gpx', 'apple_health', 'google_fit'));
+
+ALTER TABLE activities RENAME COLUMN source_id TO external_id;
+
+-- An activity is imported at most once. Unique indexes of a partitioned table
+-- must include the partition key, but a workout imported again has the same
+-- activity_date, so it still collides.
+DROP INDEX IF EXISTS idx_activities_source_id;
+CREATE UNIQUE INDEX idx_activities_external_id ON activities(user_id, source, external_id, activity_date);
+
+UPDATE activities_archive SET source = 'manual' WHERE source IS NULL;
+
+ALTER TABLE activities_archive
+    ALTER COLUMN source SET DEFAULT 'manual',
+    ALTER COLUMN source SET NOT NULL;
+
+ALTER TABLE activities_archive RENAME COLUMN source_id TO external_id;
+
+CREATE INDEX idx_activities_archive_external_id ON activities_archive(user_id, source, external_id)
+    WHERE external_id IS NOT NULL;
+
+COMMIT;
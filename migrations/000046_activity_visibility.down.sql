@@ -0,0 +1,11 @@
+BEGIN;
+
+DROP TABLE IF EXISTS privacy_zones;
+
+ALTER TABLE activities_archive DROP COLUMN IF EXISTS visibility;
+
+ALTER TABLE activities
+    DROP CONSTRAINT IF EXISTS chk_activities_visibility,
+    DROP COLUMN IF EXISTS visibility;
+
+COMMIT;
@@ -0,0 +1,38 @@
+BEGIN;
+
+-- Who may see an activity besides its owner: nobody (private), the people
+-- the owner trains with - active coaches and fellow group members
+-- (followers) - or anyone with a share link (public). Activities logged
+-- before visibility existed were already readable by coaches and groups, so
+-- they become followers, except those with a live share link, which stay
+-- reachable through it.
+ALTER TABLE activities
+    ADD COLUMN visibility VARCHAR(20) NOT NULL DEFAULT 'followers',
+    ADD CONSTRAINT chk_activities_visibility
+        CHECK (visibility IN ('private', 'followers', 'public'));
+
+UPDATE activities SET visibility = 'public'
+WHERE id IN (
+    SELECT activity_id FROM activity_shares
+    WHERE revoked_at IS NULL
+        AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)
+);
+
+ALTER TABLE activities_archive
+    ADD COLUMN visibility VARCHAR(20) NOT NULL DEFAULT 'followers';
+
+-- Places whose exact position a user keeps to themselves, e.g. home. A start
+-- or end point within radius_m of one is hidden from everyone but the owner.
+CREATE TABLE privacy_zones (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    lat DOUBLE PRECISION NOT NULL CHECK (lat BETWEEN -90 AND 90),
+    lng DOUBLE PRECISION NOT NULL CHECK (lng BETWEEN -180 AND 180),
+    radius_m INTEGER NOT NULL CHECK (radius_m BETWEEN 100 AND 5000),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_privacy_zones_user_id ON privacy_zones (user_id);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE privacy_zones DROP COLUMN IF EXISTS updated_at;
+
+COMMIT;
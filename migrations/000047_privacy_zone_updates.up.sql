@@ -0,0 +1,7 @@
+BEGIN;
+
+-- Privacy zones can be edited in place (PATCH /users/me/privacy-zones/{id})
+ALTER TABLE privacy_zones
+    ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN;
+
+CREATE OR REPLACE FUNCTION delete_activity_dependents() RETURNS trigger AS $$
+BEGIN
+    DELETE FROM activity_tags WHERE activity_id = OLD.id;
+    DELETE FROM activity_photos WHERE activity_id = OLD.id;
+    DELETE FROM activity_shares WHERE activity_id = OLD.id;
+    DELETE FROM activity_reactions WHERE activity_id = OLD.id;
+    DELETE FROM activity_splits WHERE activity_id = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TABLE IF EXISTS activity_tracks;
+
+COMMIT;
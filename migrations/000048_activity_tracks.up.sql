@@ -0,0 +1,29 @@
+BEGIN;
+
+-- GPS tracks of activities imported with a GPX file, as encoded polylines.
+-- The full track is stored on import; each simplified resolution is added
+-- the first time it's asked for (GET /activities/{id}/track), so it's
+-- computed once. activities is partitioned, so activity_id can't be a
+-- foreign key (see 000021); delete_activity_dependents removes tracks instead.
+CREATE TABLE activity_tracks (
+    activity_id INTEGER NOT NULL,
+    resolution VARCHAR(10) NOT NULL CHECK (resolution IN ('low', 'med', 'full')),
+    points INTEGER NOT NULL CHECK (points > 0),
+    polyline TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (activity_id, resolution)
+);
+
+CREATE OR REPLACE FUNCTION delete_activity_dependents() RETURNS trigger AS $$
+BEGIN
+    DELETE FROM activity_tags WHERE activity_id = OLD.id;
+    DELETE FROM activity_photos WHERE activity_id = OLD.id;
+    DELETE FROM activity_shares WHERE activity_id = OLD.id;
+    DELETE FROM activity_reactions WHERE activity_id = OLD.id;
+    DELETE FROM activity_splits WHERE activity_id = OLD.id;
+    DELETE FROM activity_tracks WHERE activity_id = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+COMMIT;
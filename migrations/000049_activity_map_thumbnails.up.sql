@@ -0,0 +1,26 @@
+BEGIN;
+
+-- Map thumbnails rendered from activity tracks by the render_map_thumbnails
+-- job. Rendered again when the owner's privacy zones change, which the
+-- thumbnail leaves out. activities is partitioned, so activity_id can't be a
+-- foreign key (see 000021); delete_activity_dependents removes them instead.
+CREATE TABLE activity_map_thumbnails (
+    activity_id INTEGER PRIMARY KEY,
+    storage_key TEXT NOT NULL,
+    rendered_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE OR REPLACE FUNCTION delete_activity_dependents() RETURNS trigger AS $$
+BEGIN
+    DELETE FROM activity_tags WHERE activity_id = OLD.id;
+    DELETE FROM activity_photos WHERE activity_id = OLD.id;
+    DELETE FROM activity_shares WHERE activity_id = OLD.id;
+    DELETE FROM activity_reactions WHERE activity_id = OLD.id;
+    DELETE FROM activity_splits WHERE activity_id = OLD.id;
+    DELETE FROM activity_tracks WHERE activity_id = OLD.id;
+    DELETE FROM activity_map_thumbnails WHERE activity_id = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+COMMIT;
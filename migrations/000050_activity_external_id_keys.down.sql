@@ -0,0 +1,21 @@
+BEGIN;
+
+CREATE OR REPLACE FUNCTION delete_activity_dependents() RETURNS trigger AS $$
+BEGIN
+    DELETE FROM activity_tags WHERE activity_id = OLD.id;
+    DELETE FROM activity_photos WHERE activity_id = OLD.id;
+    DELETE FROM activity_shares WHERE activity_id = OLD.id;
+    DELETE FROM activity_reactions WHERE activity_id = OLD.id;
+    DELETE FROM activity_splits WHERE activity_id = OLD.id;
+    DELETE FROM activity_tracks WHERE activity_id = OLD.id;
+    DELETE FROM activity_map_thumbnails WHERE activity_id = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS idx_activities_external_id;
+CREATE UNIQUE INDEX idx_activities_external_id ON activities(user_id, source, external_id, activity_date);
+
+DROP TABLE IF EXISTS activity_external_ids;
+
+COMMIT;
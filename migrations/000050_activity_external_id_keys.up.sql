@@ -0,0 +1,50 @@
+BEGIN;
+
+-- The external ID of every imported activity, keyed by user and source so an
+-- activity is imported at most once. The unique index on activities has to
+-- include activity_date, the partition key (see 000045), so it lets through
+-- the same workout exported again with a different date. Imports write here
+-- in the transaction that copies the activities. Like activities itself this
+-- keeps soft deleted and archived activities, so importing an export again
+-- doesn't bring them back; delete_activity_dependents frees the ID of an
+-- activity deleted for good.
+CREATE TABLE activity_external_ids (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source VARCHAR(20) NOT NULL,
+    external_id VARCHAR(255) NOT NULL,
+    activity_id INTEGER NOT NULL,
+    CONSTRAINT uq_activity_external_ids UNIQUE (user_id, source, external_id)
+);
+
+CREATE INDEX idx_activity_external_ids_activity ON activity_external_ids(activity_id);
+
+-- The oldest activity keeps the ID where one was imported twice already
+INSERT INTO activity_external_ids (user_id, source, external_id, activity_id)
+SELECT DISTINCT ON (user_id, source, external_id) user_id, source, external_id, id
+FROM (
+    SELECT user_id, source, external_id, id FROM activities WHERE external_id IS NOT NULL
+    UNION ALL
+    SELECT user_id, source, external_id, id FROM activities_archive
+    WHERE external_id IS NOT NULL AND user_id IN (SELECT id FROM users)
+) imported
+ORDER BY user_id, source, external_id, id;
+
+DROP INDEX IF EXISTS idx_activities_external_id;
+CREATE INDEX idx_activities_external_id ON activities(user_id, source, external_id)
+    WHERE external_id IS NOT NULL;
+
+CREATE OR REPLACE FUNCTION delete_activity_dependents() RETURNS trigger AS $$
+BEGIN
+    DELETE FROM activity_tags WHERE activity_id = OLD.id;
+    DELETE FROM activity_photos WHERE activity_id = OLD.id;
+    DELETE FROM activity_shares WHERE activity_id = OLD.id;
+    DELETE FROM activity_reactions WHERE activity_id = OLD.id;
+    DELETE FROM activity_splits WHERE activity_id = OLD.id;
+    DELETE FROM activity_tracks WHERE activity_id = OLD.id;
+    DELETE FROM activity_map_thumbnails WHERE activity_id = OLD.id;
+    DELETE FROM activity_external_ids WHERE activity_id = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TABLE IF EXISTS sync_mutations;
+
+DROP INDEX IF EXISTS idx_change_log_user_entity_txid;
+CREATE INDEX idx_change_log_user_entity_seq ON change_log(user_id, entity, seq);
+ALTER TABLE change_log DROP COLUMN IF EXISTS txid;
+
+COMMIT;
@@ -0,0 +1,27 @@
+BEGIN;
+
+-- seq is taken when a change is written, not when its transaction commits, so
+-- a pull could return seq 102 while seq 101 was still uncommitted and skip it
+-- for good. Every change now records the transaction that wrote it and pulls
+-- page by (txid, seq), returning only transactions older than every one still
+-- running (pg_snapshot_xmin). Existing rows are all committed; txid 0 keeps
+-- them in seq order ahead of everything written from now on.
+ALTER TABLE change_log ADD COLUMN txid xid8 NOT NULL DEFAULT '0';
+ALTER TABLE change_log ALTER COLUMN txid SET DEFAULT pg_current_xact_id();
+
+DROP INDEX IF EXISTS idx_change_log_user_entity_seq;
+CREATE INDEX idx_change_log_user_entity_txid ON change_log(user_id, entity, txid, seq);
+
+-- The client mutation ID of every record created through sync push, so a
+-- create replayed after a lost response returns the record it made instead
+-- of creating it again. Written in the transaction that creates the record.
+CREATE TABLE sync_mutations (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    client_id VARCHAR(100) NOT NULL,
+    entity VARCHAR(50) NOT NULL,
+    entity_id BIGINT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, client_id)
+);
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+-- Long-lived keys for scripts and the CLI, sent as bearer tokens in place of
+-- a JWT. Only the SHA-256 of a key is stored; prefix holds its first
+-- characters so users can tell their keys apart. last_used_at is updated at
+-- most hourly.
+CREATE TABLE api_keys (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(12) NOT NULL,
+    key_hash CHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP
+);
+
+CREATE INDEX idx_api_keys_user_id ON api_keys(user_id);
+
+COMMIT;
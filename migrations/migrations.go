@@ -0,0 +1,10 @@
+// Package migrations embeds the SQL migrations, so tests and tools can apply
+// them without a checkout of the repository (e.g. from another module)
+package migrations
+
+import "embed"
+
+// FS holds the *.up.sql and *.down.sql files of this directory
+//
+//go:embed *.sql
+var FS embed.FS
@@ -8,19 +8,23 @@ import (
 	"github.com/valentinesamuel/activelog/internal/platform/config"
 )
 
+// TokenTTL is how long issued JWTs (and the session cookies holding them) are valid
+const TokenTTL = time.Hour
+
 type CustomClaims struct {
 	UserID int    `json:"user_id"`
 	Email  string `json:"email"`
 	jwt.RegisteredClaims
 }
 
-func GenerateJwtToken(userID int, email string) (string, error) {
+// GenerateJwtToken issues a JWT for the user, valid for TokenTTL from now
+func GenerateJwtToken(userID int, email string, now time.Time) (string, error) {
 	claims := CustomClaims{
 		UserID: userID,
 		Email:  email,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(1 * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(now.Add(TokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
 
@@ -28,7 +32,10 @@ func GenerateJwtToken(userID int, email string) (string, error) {
 	return token.SignedString([]byte(config.Common.Auth.JWTSecret))
 }
 
-func VerifyToken(tokenString string) (*CustomClaims, error) {
+// VerifyToken checks a JWT issued by GenerateJwtToken and returns its
+// claims. now decides whether it has expired, so tokens issued on a clock
+// that runs ahead of or behind the system clock are checked on that clock.
+func VerifyToken(tokenString string, now time.Time) (*CustomClaims, error) {
 	claims := &CustomClaims{}
 
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
@@ -37,8 +44,8 @@ func VerifyToken(tokenString string) (*CustomClaims, error) {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Method.Alg())
 		}
 
-		return config.Common.Auth.JWTSecret, nil
-	})
+		return []byte(config.Common.Auth.JWTSecret), nil
+	}, jwt.WithTimeFunc(func() time.Time { return now }))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse JWT token: %w", err)
@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+)
+
+// OAuthStateCookieName binds a social login to the browser that started it
+const OAuthStateCookieName = "activelog_oauth_state"
+
+// ErrInvalidOAuthState is returned when a callback's state is forged, expired,
+// meant for another provider or arrives in another browser
+var ErrInvalidOAuthState = errors.New("invalid oauth state")
+
+// oauthState is the payload of the state parameter
+type oauthState struct {
+	Provider  string `json:"p"`
+	Session   string `json:"s"`
+	Nonce     string `json:"n"`
+	ExpiresAt int64  `json:"e"`
+}
+
+// StartOAuthLogin returns the signed state for a login with provider and sets
+// the cookie that binds it to this browser. session is the session mode the
+// login will open (see models.SessionBearer).
+func StartOAuthLogin(w http.ResponseWriter, provider, session string) (string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	ttl := config.OAuth.StateTTL
+	payload, err := json.Marshal(oauthState{
+		Provider:  provider,
+		Session:   session,
+		Nonce:     base64.RawURLEncoding.EncodeToString(nonce),
+		ExpiresAt: time.Now().Add(ttl).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	http.SetCookie(w, oauthStateCookie(encoded, int(ttl.Seconds())))
+	return encoded + "." + signOAuthState(encoded), nil
+}
+
+// FinishOAuthLogin verifies the state of a callback from provider, clears the
+// binding cookie and returns the session mode the login was started with
+func FinishOAuthLogin(w http.ResponseWriter, r *http.Request, provider, state string) (string, error) {
+	http.SetCookie(w, oauthStateCookie("", -1))
+
+	encoded, sig, ok := strings.Cut(state, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(signOAuthState(encoded))) {
+		return "", ErrInvalidOAuthState
+	}
+	cookie, err := r.Cookie(OAuthStateCookieName)
+	if err != nil || !hmac.Equal([]byte(cookie.Value), []byte(encoded)) {
+		return "", ErrInvalidOAuthState
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", ErrInvalidOAuthState
+	}
+	var s oauthState
+	if err := json.Unmarshal(payload, &s); err != nil {
+		return "", ErrInvalidOAuthState
+	}
+	if s.Provider != provider || time.Now().Unix() > s.ExpiresAt {
+		return "", ErrInvalidOAuthState
+	}
+	return s.Session, nil
+}
+
+// oauthStateCookie is scoped to the auth routes. Apple posts its callback
+// cross-site, which only carries SameSite=None cookies, so secure deployments
+// use None; the signed state still protects the callback.
+func oauthStateCookie(value string, maxAge int) *http.Cookie {
+	sameSite := http.SameSiteLaxMode
+	if config.Common.Auth.CookieSecure {
+		sameSite = http.SameSiteNoneMode
+	}
+	return &http.Cookie{
+		Name:     OAuthStateCookieName,
+		Value:    value,
+		Path:     "/api/v1/auth/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   config.Common.Auth.CookieSecure,
+		SameSite: sameSite,
+	}
+}
+
+func signOAuthState(encoded string) string {
+	mac := hmac.New(sha256.New, []byte(config.Common.Auth.JWTSecret))
+	mac.Write([]byte("oauth_state:" + encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+)
+
+// Cookie session mode lets browser clients keep the JWT in an HttpOnly cookie
+// instead of handling it in JavaScript. Bearer tokens keep working alongside.
+const (
+	// SessionCookieName holds the JWT; scripts can't read it
+	SessionCookieName = "activelog_session"
+
+	// CSRFCookieName holds the CSRF token for the session. Scripts read it and
+	// echo it in CSRFHeader on state-changing requests (double submit).
+	CSRFCookieName = "activelog_csrf"
+
+	// CSRFHeader carries the CSRF token on cookie-authenticated requests
+	CSRFHeader = "X-CSRF-Token"
+)
+
+// CookieSessionsEnabled reports whether logins may open cookie sessions
+func CookieSessionsEnabled() bool {
+	return config.Common.Auth.CookieSessions
+}
+
+// SetSessionCookies stores token, issued at now, in the session cookie
+// alongside its CSRF cookie and returns the CSRF token
+func SetSessionCookies(w http.ResponseWriter, token string, now time.Time) string {
+	expires := now.Add(TokenTTL)
+	csrfToken := CSRFToken(token)
+
+	http.SetCookie(w, sessionCookie(SessionCookieName, token, expires, true))
+	http.SetCookie(w, sessionCookie(CSRFCookieName, csrfToken, expires, false))
+	return csrfToken
+}
+
+// ClearSessionCookies expires both session cookies
+func ClearSessionCookies(w http.ResponseWriter) {
+	http.SetCookie(w, sessionCookie(SessionCookieName, "", time.Unix(0, 0), true))
+	http.SetCookie(w, sessionCookie(CSRFCookieName, "", time.Unix(0, 0), false))
+}
+
+func sessionCookie(name, value string, expires time.Time, httpOnly bool) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		Domain:   config.Common.Auth.CookieDomain,
+		Expires:  expires,
+		HttpOnly: httpOnly,
+		Secure:   config.Common.Auth.CookieSecure,
+		SameSite: sameSite(config.Common.Auth.CookieSameSite),
+	}
+}
+
+func sameSite(mode string) http.SameSite {
+	switch strings.ToLower(mode) {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteLaxMode
+	}
+}
+
+// CSRFToken derives the CSRF token of a session. Binding it to the session
+// means a token planted in the CSRF cookie by another site is useless.
+func CSRFToken(session string) string {
+	mac := hmac.New(sha256.New, []byte(config.Common.Auth.JWTSecret))
+	mac.Write([]byte("csrf:" + session))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyCSRFToken reports whether token is the CSRF token of session
+func VerifyCSRFToken(session, token string) bool {
+	if session == "" || token == "" {
+		return false
+	}
+	return hmac.Equal([]byte(token), []byte(CSRFToken(session)))
+}
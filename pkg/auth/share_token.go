@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+)
+
+// ErrInvalidShareToken is returned when a share token is malformed or its signature does not match
+var ErrInvalidShareToken = errors.New("invalid share token")
+
+// GenerateShareToken signs a share's public ID into an opaque public token of the form <id>.<signature>
+// Expiry and revocation live in the database, so the token only has to prove the ID wasn't guessed
+func GenerateShareToken(publicID string) string {
+	return publicID + "." + signShareID(publicID)
+}
+
+// VerifyShareToken checks the token signature and returns the share ID it carries: a public
+// ID, or a serial ID for tokens issued before shares had public IDs
+func VerifyShareToken(token string) (string, error) {
+	id, sig, ok := strings.Cut(token, ".")
+	if !ok || id == "" || sig == "" {
+		return "", ErrInvalidShareToken
+	}
+
+	if !hmac.Equal([]byte(sig), []byte(signShareID(id))) {
+		return "", ErrInvalidShareToken
+	}
+
+	return id, nil
+}
+
+func signShareID(id string) string {
+	mac := hmac.New(sha256.New, []byte(config.Common.Auth.JWTSecret))
+	mac.Write([]byte("activity_share:" + id))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
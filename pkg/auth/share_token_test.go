@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valentinesamuel/activelog/internal/platform/config"
+)
+
+func setShareSecret(t *testing.T, secret string) {
+	t.Helper()
+	previous := config.Common
+	config.Common = &config.CommonConfig{Auth: config.AuthConfig{JWTSecret: secret}}
+	t.Cleanup(func() { config.Common = previous })
+}
+
+// tamper changes the first character of sig
+func tamper(sig string) string {
+	if sig[0] == 'A' {
+		return "B" + sig[1:]
+	}
+	return "A" + sig[1:]
+}
+
+func TestShareToken(t *testing.T) {
+	setShareSecret(t, "test-secret")
+	token := GenerateShareToken("01HZY3V5J6X7Q8R9S0T1V2W3X4")
+	id, sig, _ := strings.Cut(token, ".")
+
+	tests := []struct {
+		name    string
+		token   string
+		wantID  string
+		wantErr bool
+	}{
+		{"signed public id", token, "01HZY3V5J6X7Q8R9S0T1V2W3X4", false},
+		{"signed serial id", GenerateShareToken("42"), "42", false},
+		{"tampered signature", id + "." + tamper(sig), "", true},
+		{"signature of another id", "01HZY3V5J6X7Q8R9S0T1V2W3X5." + sig, "", true},
+		{"truncated signature", id + "." + sig[:len(sig)-1], "", true},
+		{"no signature", id + ".", "", true},
+		{"no id", "." + sig, "", true},
+		{"no separator", id, "", true},
+		{"empty", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := VerifyShareToken(tt.token)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidShareToken)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantID, got)
+		})
+	}
+}
+
+func TestShareToken_OtherSecret(t *testing.T) {
+	setShareSecret(t, "old-secret")
+	token := GenerateShareToken("42")
+
+	setShareSecret(t, "new-secret")
+	_, err := VerifyShareToken(token)
+	assert.ErrorIs(t, err, ErrInvalidShareToken)
+}
+
+// Tokens carry no expiry: whether a link has expired or was revoked is looked
+// up with its share (see ShareRepository.RecordView), so a token verifies as
+// long as the secret is unchanged
+func TestShareToken_Stable(t *testing.T) {
+	setShareSecret(t, "test-secret")
+	assert.Equal(t, GenerateShareToken("42"), GenerateShareToken("42"))
+	assert.NotEqual(t, GenerateShareToken("42"), GenerateShareToken("43"))
+}
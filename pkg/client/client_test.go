@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListActivities(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/activities", r.URL.Path)
+		assert.Equal(t, "running", r.URL.Query().Get("filter[activity_type]"))
+		assert.Equal(t, "50", r.URL.Query().Get("limit"))
+		assert.False(t, r.URL.Query().Has("page"))
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, acceptType, r.Header.Get("Accept"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"statusCode":200,"success":true,"result":{
+			"data":[{"id":1,"title":"Morning run","activityType":"running"}],
+			"meta":{"page":1,"limit":50,"count":1,"previousPage":null,"nextPage":2,"hasPrevious":false,"hasNext":true,"pageCount":2,"totalRecords":51}}}`))
+	}))
+	defer srv.Close()
+
+	page, err := New(srv.URL, WithToken("token")).ListActivities(context.Background(), &ListActivitiesParams{
+		FilterActivityType: Ptr("running"),
+		Limit:              Ptr(50),
+	})
+	require.NoError(t, err)
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, "Morning run", page.Data[0].Title)
+	assert.True(t, page.Meta.HasNext)
+	assert.Equal(t, 51, page.Meta.TotalRecords)
+}
+
+func TestClient_APIError(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		target  error
+		message string
+		errors  []ValidationError
+	}{
+		{
+			name:    "not found",
+			status:  http.StatusNotFound,
+			body:    `{"statusCode":404,"success":false,"message":"Activity not found","errors":[]}`,
+			target:  ErrNotFound,
+			message: "Activity not found",
+		},
+		{
+			name:    "validation",
+			status:  http.StatusBadRequest,
+			body:    `{"statusCode":400,"success":false,"message":"Bad Request","errors":[{"field":"title","errors":["required"]}]}`,
+			target:  ErrBadRequest,
+			message: "Bad Request",
+			errors:  []ValidationError{{Field: "title", Errors: []string{"required"}}},
+		},
+		{
+			name:    "not an envelope",
+			status:  http.StatusTooManyRequests,
+			body:    "slow down",
+			target:  ErrRateLimited,
+			message: "Too Many Requests",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			_, err := New(srv.URL).GetActivity(context.Background(), "01HZX", nil)
+
+			var apiErr *APIError
+			require.ErrorAs(t, err, &apiErr)
+			assert.ErrorIs(t, err, tt.target)
+			assert.Equal(t, tt.message, apiErr.Message)
+			assert.ElementsMatch(t, tt.errors, apiErr.Errors)
+		})
+	}
+
+	assert.False(t, errors.Is(&APIError{StatusCode: http.StatusNotFound}, ErrConflict))
+}
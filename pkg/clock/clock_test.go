@@ -0,0 +1,31 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	c := NewFake(start)
+	assert.Equal(t, start, c.Now())
+
+	c.Advance(36 * time.Hour)
+	assert.Equal(t, start.Add(36*time.Hour), c.Now())
+
+	c.Set(start.AddDate(0, 0, -7))
+	assert.Equal(t, start.AddDate(0, 0, -7), c.Now())
+}
+
+func TestOffset(t *testing.T) {
+	c := NewOffset(72 * time.Hour)
+
+	before := time.Now().Add(72 * time.Hour)
+	got := c.Now()
+	after := time.Now().Add(72 * time.Hour)
+
+	assert.False(t, got.Before(before), "Now() = %v, before %v", got, before)
+	assert.False(t, got.After(after), "Now() = %v, after %v", got, after)
+}
@@ -6,11 +6,17 @@ import (
 	"fmt"
 	"log"
 	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // LoggingDB wraps *sql.DB to log all queries
+// When created by Connect, the *sql.DB is a database/sql facade over a pgxpool.Pool
 type LoggingDB struct {
 	*sql.DB
+	pool   *pgxpool.Pool
+	stmts  *StmtCache
+	slow   *SlowQueryLog
 	logger *log.Logger
 }
 
@@ -31,6 +37,48 @@ func (db *LoggingDB) GetRawDB() *sql.DB {
 	return db.DB
 }
 
+// Pool returns the pgx pool backing the connection, or nil when LoggingDB
+// wraps a plain *sql.DB (e.g. in tests)
+func (db *LoggingDB) Pool() *pgxpool.Pool {
+	return db.pool
+}
+
+// EnableStmtCache turns on the prepared statement cache used by
+// QueryCachedContext and QueryRowCachedContext, holding at most capacity statements
+func (db *LoggingDB) EnableStmtCache(capacity int) {
+	db.stmts = NewStmtCache(db.DB, capacity)
+}
+
+// StmtCache returns the prepared statement cache, or nil when it is disabled
+func (db *LoggingDB) StmtCache() *StmtCache {
+	return db.stmts
+}
+
+// EnableSlowQueryLog starts recording statements that take longer than
+// threshold, e.g. for the index advisor
+func (db *LoggingDB) EnableSlowQueryLog(threshold time.Duration) {
+	db.slow = NewSlowQueryLog(threshold)
+}
+
+// SlowQueryLog returns the slow statements recorded so far, or nil when
+// recording is disabled
+func (db *LoggingDB) SlowQueryLog() *SlowQueryLog {
+	return db.slow
+}
+
+// Close closes the database/sql handle and then the pgx pool behind it
+// Closing a *sql.DB opened from a pool does not close the pool itself
+func (db *LoggingDB) Close() error {
+	if db.stmts != nil {
+		db.stmts.Close()
+	}
+	err := db.DB.Close()
+	if db.pool != nil {
+		db.pool.Close()
+	}
+	return err
+}
+
 // QueryContext wraps db.QueryContext with logging
 func (db *LoggingDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
 	start := time.Now()
@@ -61,6 +109,54 @@ func (db *LoggingDB) ExecContext(ctx context.Context, query string, args ...inte
 	return result, err
 }
 
+// QueryCachedContext is QueryContext through the prepared statement cache
+// Meant for builder-generated SQL that repeats with different arguments;
+// falls back to QueryContext when the cache is disabled
+func (db *LoggingDB) QueryCachedContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if db.stmts == nil {
+		return db.QueryContext(ctx, query, args...)
+	}
+
+	start := time.Now()
+	rows, err := db.stmts.QueryContext(ctx, query, args...)
+	duration := time.Since(start)
+
+	db.logQuery("CACHED QUERY", query, args, duration, err)
+	return rows, err
+}
+
+// QueryRowCachedContext is QueryRowContext through the prepared statement cache
+func (db *LoggingDB) QueryRowCachedContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if db.stmts == nil {
+		return db.QueryRowContext(ctx, query, args...)
+	}
+
+	start := time.Now()
+	row := db.stmts.QueryRowContext(ctx, query, args...)
+	duration := time.Since(start)
+
+	db.logQuery("CACHED QUERY ROW", query, args, duration, nil)
+	return row
+}
+
+// QueryRawContext is QueryCachedContext for hand-written SQL templates run
+// through repository.RunNamedQuery, logged as RAW QUERY so they stand out
+// from builder SQL when reading slow query logs
+func (db *LoggingDB) QueryRawContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	start := time.Now()
+	var rows *sql.Rows
+	var err error
+	if db.stmts != nil {
+		rows, err = db.stmts.QueryContext(ctx, query, args...)
+	} else {
+		rows, err = db.DB.QueryContext(ctx, query, args...)
+	}
+	duration := time.Since(start)
+
+	db.logQuery("RAW QUERY", query, args, duration, err)
+	return rows, err
+}
+
 // BeginTx wraps db.BeginTx with logging
 func (db *LoggingDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*LoggingTx, error) {
 	start := time.Now()
@@ -97,6 +193,9 @@ func (db *LoggingDB) logQuery(queryType, query string, args []interface{}, durat
 	if err != nil {
 		db.logger.Printf("   └─ Error: %v", err)
 	}
+	if db.slow != nil {
+		db.slow.Record(query, duration)
+	}
 }
 
 // LoggingTx wraps *sql.Tx to log transaction operations
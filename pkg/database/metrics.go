@@ -0,0 +1,139 @@
+package database
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolStatsCollector exposes pgxpool statistics as Prometheus metrics.
+// Values are read from pool.Stat() on every scrape.
+type PoolStatsCollector struct {
+	pool *pgxpool.Pool
+
+	acquiredConns     *prometheus.Desc
+	idleConns         *prometheus.Desc
+	constructingConns *prometheus.Desc
+	totalConns        *prometheus.Desc
+	maxConns          *prometheus.Desc
+	acquireCount      *prometheus.Desc
+	acquireDuration   *prometheus.Desc
+	emptyAcquireCount *prometheus.Desc
+	canceledAcquires  *prometheus.Desc
+	newConnsCount     *prometheus.Desc
+	lifetimeDestroyed *prometheus.Desc
+	idleTimeDestroyed *prometheus.Desc
+}
+
+// NewPoolStatsCollector creates a collector for pool
+// Register it with prometheus.MustRegister to publish it on /metrics
+func NewPoolStatsCollector(pool *pgxpool.Pool) *PoolStatsCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc("db_pool_"+name, help, nil, nil)
+	}
+
+	return &PoolStatsCollector{
+		pool:              pool,
+		acquiredConns:     desc("acquired_connections", "Connections currently checked out of the pool"),
+		idleConns:         desc("idle_connections", "Idle connections in the pool"),
+		constructingConns: desc("constructing_connections", "Connections currently being established"),
+		totalConns:        desc("total_connections", "Total connections in the pool"),
+		maxConns:          desc("max_connections", "Maximum size of the pool"),
+		acquireCount:      desc("acquire_total", "Total successful connection acquisitions"),
+		acquireDuration:   desc("acquire_duration_seconds_total", "Total time spent waiting to acquire connections"),
+		emptyAcquireCount: desc("empty_acquire_total", "Acquisitions that had to wait because the pool was empty"),
+		canceledAcquires:  desc("canceled_acquire_total", "Acquisitions canceled by their context"),
+		newConnsCount:     desc("new_connections_total", "Total connections opened"),
+		lifetimeDestroyed: desc("max_lifetime_destroy_total", "Connections closed for exceeding the max lifetime"),
+		idleTimeDestroyed: desc("max_idle_destroy_total", "Connections closed for exceeding the max idle time"),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *PoolStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.constructingConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquireCount
+	ch <- c.acquireDuration
+	ch <- c.emptyAcquireCount
+	ch <- c.canceledAcquires
+	ch <- c.newConnsCount
+	ch <- c.lifetimeDestroyed
+	ch <- c.idleTimeDestroyed
+}
+
+// Collect implements prometheus.Collector
+func (c *PoolStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	gauge := func(desc *prometheus.Desc, v float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, v)
+	}
+	counter := func(desc *prometheus.Desc, v float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, v)
+	}
+
+	gauge(c.acquiredConns, float64(stat.AcquiredConns()))
+	gauge(c.idleConns, float64(stat.IdleConns()))
+	gauge(c.constructingConns, float64(stat.ConstructingConns()))
+	gauge(c.totalConns, float64(stat.TotalConns()))
+	gauge(c.maxConns, float64(stat.MaxConns()))
+	counter(c.acquireCount, float64(stat.AcquireCount()))
+	counter(c.acquireDuration, stat.AcquireDuration().Seconds())
+	counter(c.emptyAcquireCount, float64(stat.EmptyAcquireCount()))
+	counter(c.canceledAcquires, float64(stat.CanceledAcquireCount()))
+	counter(c.newConnsCount, float64(stat.NewConnsCount()))
+	counter(c.lifetimeDestroyed, float64(stat.MaxLifetimeDestroyCount()))
+	counter(c.idleTimeDestroyed, float64(stat.MaxIdleDestroyCount()))
+}
+
+// StmtCacheCollector exposes StmtCache hit/miss counters as Prometheus metrics.
+// Hit rate is hits / (hits + misses), e.g.
+// rate(db_stmt_cache_hits_total[5m]) / (rate(db_stmt_cache_hits_total[5m]) + rate(db_stmt_cache_misses_total[5m]))
+type StmtCacheCollector struct {
+	cache *StmtCache
+
+	hits      *prometheus.Desc
+	misses    *prometheus.Desc
+	evictions *prometheus.Desc
+	size      *prometheus.Desc
+	capacity  *prometheus.Desc
+}
+
+// NewStmtCacheCollector creates a collector for cache
+func NewStmtCacheCollector(cache *StmtCache) *StmtCacheCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc("db_stmt_cache_"+name, help, nil, nil)
+	}
+
+	return &StmtCacheCollector{
+		cache:     cache,
+		hits:      desc("hits_total", "Queries served by an already prepared statement"),
+		misses:    desc("misses_total", "Queries that had to prepare a new statement"),
+		evictions: desc("evictions_total", "Statements evicted by the LRU cap"),
+		size:      desc("size", "Prepared statements currently cached"),
+		capacity:  desc("capacity", "Maximum number of cached statements"),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *StmtCacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.hits
+	ch <- c.misses
+	ch <- c.evictions
+	ch <- c.size
+	ch <- c.capacity
+}
+
+// Collect implements prometheus.Collector
+func (c *StmtCacheCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.cache.Stats()
+
+	ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(c.evictions, prometheus.CounterValue, float64(stats.Evictions))
+	ch <- prometheus.MustNewConstMetric(c.size, prometheus.GaugeValue, float64(stats.Size))
+	ch <- prometheus.MustNewConstMetric(c.capacity, prometheus.GaugeValue, float64(stats.Capacity))
+}
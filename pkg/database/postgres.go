@@ -1,36 +1,107 @@
 package database
 
 import (
-	"database/sql"
+	"context"
 	"fmt"
 	"log"
 	"os"
 	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
 )
 
-// Connect establishes a database connection and wraps it with logging
-func Connect(databaseUrl string) (*LoggingDB, error) {
-	db, err := sql.Open("postgres", databaseUrl)
+// PoolConfig tunes the pgx connection pool
+type PoolConfig struct {
+	MaxConns        int32
+	MinConns        int32
+	MaxConnLifetime time.Duration
+	MaxConnIdleTime time.Duration
+
+	// StatementCacheCapacity is the number of prepared statements cached per
+	// connection. 0 disables the cache and sends every query unprepared, which
+	// is required behind PgBouncer in transaction pooling mode.
+	StatementCacheCapacity int
+
+	// QueryCacheSize caps the LRU of prepared QueryBuilder statements shared
+	// across the pool (see StmtCache). 0 disables it; like the per-connection
+	// cache it relies on named prepared statements.
+	QueryCacheSize int
+
+	// SlowQueryThreshold enables the SlowQueryLog for statements slower than
+	// it. 0 disables it.
+	SlowQueryThreshold time.Duration
+}
+
+// DefaultPoolConfig returns the pool settings used when none are configured
+func DefaultPoolConfig() PoolConfig {
+	return PoolConfig{
+		MaxConns:               25,
+		MinConns:               5,
+		MaxConnLifetime:        5 * time.Minute,
+		MaxConnIdleTime:        2 * time.Minute,
+		StatementCacheCapacity: 512,
+		QueryCacheSize:         128,
+	}
+}
+
+// Connect opens a pgx connection pool and wraps it with logging.
+// Repositories keep using database/sql through the DBConn interface: the
+// returned LoggingDB's *sql.DB is backed by the pool via pgx's stdlib adapter.
+func Connect(databaseUrl string, poolConfig PoolConfig) (*LoggingDB, error) {
+	cfg, err := parsePoolConfig(databaseUrl, poolConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := pgxpool.NewWithConfig(context.Background(), cfg)
 	if err != nil {
 		return nil, fmt.Errorf("❌ Error opening a connection to the db: \n🛑 %w", err)
 	}
 
-	if err := db.Ping(); err != nil {
+	if err := pool.Ping(context.Background()); err != nil {
+		pool.Close()
 		return nil, fmt.Errorf("❌ Error connecting to the db: \n🛑 %w", err)
 	}
 
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(5 * time.Minute)
+	db := stdlib.OpenDBFromPool(pool)
 
 	// Always wrap with logging for consistency
 	logger := log.New(os.Stdout, "[SQL] ", log.LstdFlags)
 	loggingDB := NewLoggingDB(db, logger)
+	loggingDB.pool = pool
+	if poolConfig.QueryCacheSize > 0 {
+		loggingDB.EnableStmtCache(poolConfig.QueryCacheSize)
+	}
+	if poolConfig.SlowQueryThreshold > 0 {
+		loggingDB.EnableSlowQueryLog(poolConfig.SlowQueryThreshold)
+	}
 
-	log.Println("✅ Successfully connected to database")
+	log.Printf("✅ Successfully connected to database (pgx pool: max %d, min %d conns)", cfg.MaxConns, cfg.MinConns)
 	log.Println("🔍 Query logging enabled")
 
 	return loggingDB, nil
 }
+
+// parsePoolConfig parses databaseUrl and applies poolConfig to it
+func parsePoolConfig(databaseUrl string, poolConfig PoolConfig) (*pgxpool.Config, error) {
+	cfg, err := pgxpool.ParseConfig(databaseUrl)
+	if err != nil {
+		return nil, fmt.Errorf("❌ Error parsing the database URL: \n🛑 %w", err)
+	}
+
+	cfg.MaxConns = poolConfig.MaxConns
+	cfg.MinConns = poolConfig.MinConns
+	cfg.MaxConnLifetime = poolConfig.MaxConnLifetime
+	cfg.MaxConnIdleTime = poolConfig.MaxConnIdleTime
+
+	cfg.ConnConfig.StatementCacheCapacity = poolConfig.StatementCacheCapacity
+	if poolConfig.StatementCacheCapacity > 0 {
+		cfg.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeCacheStatement
+	} else {
+		cfg.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeExec
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,93 @@
+package database
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxSlowStatements caps the distinct statements a SlowQueryLog keeps; once
+// full, statements not seen before are dropped
+const maxSlowStatements = 500
+
+// SlowQuery aggregates the runs of one statement that took longer than the
+// SlowQueryLog threshold. Durations encode as nanoseconds.
+type SlowQuery struct {
+	SQL   string        `json:"sql"`
+	Count int           `json:"count"`
+	Total time.Duration `json:"total" swaggertype:"integer"`
+	Max   time.Duration `json:"max" swaggertype:"integer"`
+}
+
+// SlowQueryLog records statements slower than a threshold, by normalized SQL
+// text (see NormalizeSQL). Builder SQL uses placeholders, so runs of a list
+// query with different arguments share an entry.
+type SlowQueryLog struct {
+	threshold time.Duration
+
+	mu      sync.Mutex
+	queries map[string]*SlowQuery
+}
+
+// NewSlowQueryLog creates a SlowQueryLog for statements slower than threshold
+func NewSlowQueryLog(threshold time.Duration) *SlowQueryLog {
+	return &SlowQueryLog{
+		threshold: threshold,
+		queries:   make(map[string]*SlowQuery),
+	}
+}
+
+// Threshold returns the duration above which statements are recorded
+func (l *SlowQueryLog) Threshold() time.Duration {
+	return l.threshold
+}
+
+// Record adds a run of query if it took longer than the threshold
+func (l *SlowQueryLog) Record(query string, duration time.Duration) {
+	if duration <= l.threshold {
+		return
+	}
+
+	query = NormalizeSQL(query)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, ok := l.queries[query]
+	if !ok {
+		if len(l.queries) >= maxSlowStatements {
+			return
+		}
+		entry = &SlowQuery{SQL: query}
+		l.queries[query] = entry
+	}
+	entry.Count++
+	entry.Total += duration
+	if duration > entry.Max {
+		entry.Max = duration
+	}
+}
+
+// Queries returns the recorded statements, the most total time first
+func (l *SlowQueryLog) Queries() []SlowQuery {
+	l.mu.Lock()
+	queries := make([]SlowQuery, 0, len(l.queries))
+	for _, entry := range l.queries {
+		queries = append(queries, *entry)
+	}
+	l.mu.Unlock()
+
+	sort.Slice(queries, func(i, j int) bool {
+		if queries[i].Total != queries[j].Total {
+			return queries[i].Total > queries[j].Total
+		}
+		return queries[i].SQL < queries[j].SQL
+	})
+	return queries
+}
+
+// Reset forgets every recorded statement
+func (l *SlowQueryLog) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.queries = make(map[string]*SlowQuery)
+}
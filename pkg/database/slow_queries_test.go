@@ -0,0 +1,31 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlowQueryLog_Record(t *testing.T) {
+	slow := NewSlowQueryLog(100 * time.Millisecond)
+
+	slow.Record("SELECT 1", 50*time.Millisecond)
+	slow.Record("SELECT * FROM activities\n  WHERE user_id = $1", 200*time.Millisecond)
+	slow.Record("SELECT * FROM activities WHERE user_id = $1", 400*time.Millisecond)
+	slow.Record("SELECT * FROM tags", 300*time.Millisecond)
+
+	queries := slow.Queries()
+	require.Len(t, queries, 2, "fast statements are not recorded")
+	assert.Equal(t, SlowQuery{
+		SQL:   "SELECT * FROM activities WHERE user_id = $1",
+		Count: 2,
+		Total: 600 * time.Millisecond,
+		Max:   400 * time.Millisecond,
+	}, queries[0])
+	assert.Equal(t, "SELECT * FROM tags", queries[1].SQL)
+
+	slow.Reset()
+	assert.Empty(t, slow.Queries())
+}
@@ -0,0 +1,207 @@
+package database
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// StmtCache is an LRU of prepared statements keyed by normalized SQL.
+// Each *sql.Stmt is prepared lazily on every pool connection the first time it
+// runs there and reused afterwards, so hot QueryBuilder queries skip parsing
+// and planning on repeat calls.
+type StmtCache struct {
+	db       *sql.DB
+	capacity int
+
+	mu    sync.Mutex
+	lru   *list.List // front = most recently used
+	items map[string]*list.Element
+
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+}
+
+// stmtEntry is a cached statement with the number of callers currently using it.
+// Evicted entries are closed once the last caller releases them.
+type stmtEntry struct {
+	key     string
+	stmt    *sql.Stmt
+	refs    int
+	evicted bool
+}
+
+// StmtCacheStats is a snapshot of the cache counters
+type StmtCacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Size      int
+	Capacity  int
+}
+
+// HitRate returns hits / (hits + misses), or 0 before the first lookup
+func (s StmtCacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// NewStmtCache creates a cache holding at most capacity statements for db
+func NewStmtCache(db *sql.DB, capacity int) *StmtCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &StmtCache{
+		db:       db,
+		capacity: capacity,
+		lru:      list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// NormalizeSQL collapses whitespace so that formatting differences between
+// otherwise identical queries map to the same cache entry.
+// Builder output uses positional placeholders, so argument values never
+// leak into the key.
+func NormalizeSQL(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
+
+// acquire returns the prepared statement for query, preparing it on a miss.
+// The caller must pass the entry to release once the statement has been executed.
+func (c *StmtCache) acquire(ctx context.Context, query string) (*stmtEntry, error) {
+	key := NormalizeSQL(query)
+
+	c.mu.Lock()
+	if el, ok := c.items[key]; ok {
+		entry := el.Value.(*stmtEntry)
+		entry.refs++
+		c.lru.MoveToFront(el)
+		c.mu.Unlock()
+		c.hits.Add(1)
+		return entry, nil
+	}
+	c.mu.Unlock()
+	c.misses.Add(1)
+
+	// Prepare outside the lock so a slow prepare doesn't block cache hits
+	stmt, err := c.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Another caller may have prepared the same query in the meantime
+	if el, ok := c.items[key]; ok {
+		stmt.Close()
+		entry := el.Value.(*stmtEntry)
+		entry.refs++
+		c.lru.MoveToFront(el)
+		return entry, nil
+	}
+
+	entry := &stmtEntry{key: key, stmt: stmt, refs: 1}
+	c.items[key] = c.lru.PushFront(entry)
+
+	for c.lru.Len() > c.capacity {
+		c.evictOldest()
+	}
+
+	return entry, nil
+}
+
+// release marks the caller as done with entry, closing it if it was evicted meanwhile.
+// Rows already returned by the statement stay valid: database/sql defers the
+// final close until they are closed.
+func (c *StmtCache) release(entry *stmtEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry.refs--
+	if entry.evicted && entry.refs == 0 {
+		entry.stmt.Close()
+	}
+}
+
+// evictOldest drops the least recently used entry. Must be called with c.mu held.
+func (c *StmtCache) evictOldest() {
+	el := c.lru.Back()
+	if el == nil {
+		return
+	}
+	entry := el.Value.(*stmtEntry)
+	c.lru.Remove(el)
+	delete(c.items, entry.key)
+	c.evictions.Add(1)
+
+	entry.evicted = true
+	if entry.refs == 0 {
+		entry.stmt.Close()
+	}
+}
+
+// QueryContext runs query through its cached prepared statement
+func (c *StmtCache) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	entry, err := c.acquire(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release(entry)
+
+	return entry.stmt.QueryContext(ctx, args...)
+}
+
+// QueryRowContext runs query through its cached prepared statement.
+// If the statement cannot be prepared the query runs unprepared, so the
+// error surfaces from Scan as it would without the cache.
+func (c *StmtCache) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	entry, err := c.acquire(ctx, query)
+	if err != nil {
+		return c.db.QueryRowContext(ctx, query, args...)
+	}
+	defer c.release(entry)
+
+	return entry.stmt.QueryRowContext(ctx, args...)
+}
+
+// Stats returns a snapshot of the cache counters
+func (c *StmtCache) Stats() StmtCacheStats {
+	c.mu.Lock()
+	size := c.lru.Len()
+	c.mu.Unlock()
+
+	return StmtCacheStats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+		Size:      size,
+		Capacity:  c.capacity,
+	}
+}
+
+// Close closes every cached statement and empties the cache
+func (c *StmtCache) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for c.lru.Len() > 0 {
+		el := c.lru.Back()
+		entry := el.Value.(*stmtEntry)
+		c.lru.Remove(el)
+		delete(c.items, entry.key)
+
+		entry.evicted = true
+		if entry.refs == 0 {
+			entry.stmt.Close()
+		}
+	}
+}
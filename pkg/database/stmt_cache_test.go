@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeSQL(t *testing.T) {
+	assert.Equal(t,
+		"SELECT * FROM activities WHERE user_id = $1",
+		NormalizeSQL("SELECT *\n\tFROM activities   WHERE user_id = $1 "),
+	)
+}
+
+func TestStmtCache_PreparesOncePerQuery(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	query := "SELECT COUNT(*) FROM activities WHERE user_id = $1"
+	prep := mock.ExpectPrepare(regexp.QuoteMeta(query))
+	prep.ExpectQuery().WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	prep.ExpectQuery().WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+
+	cache := NewStmtCache(db, 4)
+	ctx := context.Background()
+
+	var count int
+	require.NoError(t, cache.QueryRowContext(ctx, query, 1).Scan(&count))
+	assert.Equal(t, 3, count)
+	require.NoError(t, cache.QueryRowContext(ctx, "SELECT COUNT(*)\n  FROM activities WHERE user_id = $1", 2).Scan(&count))
+	assert.Equal(t, 5, count)
+
+	stats := cache.Stats()
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+	assert.Equal(t, 1, stats.Size)
+	assert.InDelta(t, 0.5, stats.HitRate(), 0.001)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStmtCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	// With room for two statements, "SELECT 3" evicts "SELECT 1" and
+	// re-running "SELECT 1" evicts "SELECT 2"; evicted statements are closed
+	queries := []string{"SELECT 1", "SELECT 2", "SELECT 3", "SELECT 1"}
+	for i, q := range queries {
+		prep := mock.ExpectPrepare(regexp.QuoteMeta(q))
+		if i < 2 {
+			prep.WillBeClosed()
+		}
+		prep.ExpectQuery().WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(1))
+	}
+
+	cache := NewStmtCache(db, 2)
+	ctx := context.Background()
+
+	for _, q := range queries {
+		rows, err := cache.QueryContext(ctx, q)
+		require.NoError(t, err)
+		rows.Close()
+	}
+
+	stats := cache.Stats()
+	assert.Equal(t, uint64(0), stats.Hits)
+	assert.Equal(t, uint64(4), stats.Misses)
+	assert.Equal(t, uint64(2), stats.Evictions)
+	assert.Equal(t, 2, stats.Size)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
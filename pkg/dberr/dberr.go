@@ -0,0 +1,104 @@
+// Package dberr translates database driver errors into typed errors that the
+// rest of the application can match with errors.Is / errors.As without
+// knowing about PostgreSQL error codes.
+package dberr
+
+import (
+	"database/sql"
+	stderrors "errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/valentinesamuel/activelog/pkg/errors"
+)
+
+// PostgreSQL error codes (SQLSTATE)
+const (
+	pgUniqueViolation      = "23505"
+	pgForeignKeyViolation  = "23503"
+	pgNotNullViolation     = "23502"
+	pgCheckViolation       = "23514"
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+	pgQueryCanceled        = "57014"
+)
+
+var (
+	// ErrNotFound is returned when a query matched no rows. It is the
+	// application-wide errors.ErrNotFound, so existing checks keep working.
+	ErrNotFound = errors.ErrNotFound
+
+	// ErrSerializationFailure is returned when a transaction lost a
+	// serialization conflict or deadlock; retrying it may succeed
+	ErrSerializationFailure = stderrors.New("serialization failure")
+
+	// ErrStatementTimeout is returned when the database canceled a query,
+	// normally because it ran past its statement_timeout (see query.Hints)
+	ErrStatementTimeout = stderrors.New("statement timeout")
+)
+
+// ErrUniqueViolation is returned when a write would duplicate a unique key
+type ErrUniqueViolation struct {
+	Constraint string
+	Err        error
+}
+
+func (e *ErrUniqueViolation) Error() string {
+	return fmt.Sprintf("unique constraint %q violated: %v", e.Constraint, e.Err)
+}
+
+// Unwrap lets errors.Is(err, errors.ErrAlreadyExists) match unique violations
+// and errors.As still reach the driver error
+func (e *ErrUniqueViolation) Unwrap() []error {
+	return []error{errors.ErrAlreadyExists, e.Err}
+}
+
+// ErrForeignKeyViolation is returned when a write references a missing row or
+// a delete would orphan referencing rows
+type ErrForeignKeyViolation struct {
+	Constraint string
+	Err        error
+}
+
+func (e *ErrForeignKeyViolation) Error() string {
+	return fmt.Sprintf("foreign key constraint %q violated: %v", e.Constraint, e.Err)
+}
+
+// Unwrap lets errors.Is(err, errors.ErrInvalidInput) match foreign key violations
+func (e *ErrForeignKeyViolation) Unwrap() []error {
+	return []error{errors.ErrInvalidInput, e.Err}
+}
+
+// Translate converts err into one of the typed errors above. sql.ErrNoRows
+// becomes ErrNotFound; NOT NULL and CHECK violations wrap
+// errors.ErrInvalidInput; canceled queries wrap ErrStatementTimeout.
+// Anything else, including nil, is returned unchanged.
+// err may already be wrapped (e.g. in an errors.DatabaseError); the wrapper is
+// kept as the typed error's cause.
+func Translate(err error) error {
+	if err == nil {
+		return nil
+	}
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+
+	var pgErr *pgconn.PgError
+	if !stderrors.As(err, &pgErr) {
+		return err
+	}
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		return &ErrUniqueViolation{Constraint: pgErr.ConstraintName, Err: err}
+	case pgForeignKeyViolation:
+		return &ErrForeignKeyViolation{Constraint: pgErr.ConstraintName, Err: err}
+	case pgNotNullViolation, pgCheckViolation:
+		return fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	case pgSerializationFailure, pgDeadlockDetected:
+		return fmt.Errorf("%w: %w", ErrSerializationFailure, err)
+	case pgQueryCanceled:
+		return fmt.Errorf("%w: %w", ErrStatementTimeout, err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,75 @@
+package dberr
+
+import (
+	"database/sql"
+	stderrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/valentinesamuel/activelog/pkg/errors"
+)
+
+func TestTranslate(t *testing.T) {
+	plain := stderrors.New("connection reset")
+
+	t.Run("nil and unrelated errors pass through", func(t *testing.T) {
+		if err := Translate(nil); err != nil {
+			t.Errorf("Translate(nil) = %v", err)
+		}
+		if err := Translate(plain); err != plain {
+			t.Errorf("Translate(plain) = %v", err)
+		}
+	})
+
+	t.Run("no rows", func(t *testing.T) {
+		err := Translate(fmt.Errorf("scan: %w", sql.ErrNoRows))
+		if !stderrors.Is(err, ErrNotFound) || !stderrors.Is(err, errors.ErrNotFound) {
+			t.Errorf("got %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("unique violation behind a wrapper", func(t *testing.T) {
+		pgErr := &pgconn.PgError{Code: "23505", ConstraintName: "users_email_key"}
+		err := Translate(&errors.DatabaseError{Op: "INSERT", Table: "users", Err: pgErr})
+
+		var unique *ErrUniqueViolation
+		if !stderrors.As(err, &unique) || unique.Constraint != "users_email_key" {
+			t.Fatalf("got %v, want ErrUniqueViolation on users_email_key", err)
+		}
+		if !stderrors.Is(err, errors.ErrAlreadyExists) {
+			t.Error("unique violation should match ErrAlreadyExists")
+		}
+		var dbErr *errors.DatabaseError
+		if !stderrors.As(err, &dbErr) {
+			t.Error("wrapper should be kept as the cause")
+		}
+	})
+
+	t.Run("foreign key violation", func(t *testing.T) {
+		err := Translate(&pgconn.PgError{Code: "23503", ConstraintName: "activity_tags_tag_id_fkey"})
+
+		var fk *ErrForeignKeyViolation
+		if !stderrors.As(err, &fk) || fk.Constraint != "activity_tags_tag_id_fkey" {
+			t.Fatalf("got %v, want ErrForeignKeyViolation", err)
+		}
+		if !stderrors.Is(err, errors.ErrInvalidInput) {
+			t.Error("foreign key violation should match ErrInvalidInput")
+		}
+	})
+
+	t.Run("serialization failure and deadlock", func(t *testing.T) {
+		for _, code := range []string{"40001", "40P01"} {
+			if err := Translate(&pgconn.PgError{Code: code}); !stderrors.Is(err, ErrSerializationFailure) {
+				t.Errorf("code %s: got %v, want ErrSerializationFailure", code, err)
+			}
+		}
+	})
+
+	t.Run("statement timeout", func(t *testing.T) {
+		err := Translate(fmt.Errorf("failed to fetch records: %w", &pgconn.PgError{Code: "57014"}))
+		if !stderrors.Is(err, ErrStatementTimeout) {
+			t.Errorf("got %v, want ErrStatementTimeout", err)
+		}
+	})
+}
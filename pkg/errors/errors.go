@@ -13,10 +13,11 @@ type AppError struct {
 
 // Sentinel errors - predefined errors you can compare with errors.Is()
 var (
-	ErrNotFound      = errors.New("resource not found")
-	ErrUnauthorized  = errors.New("unauthorized")
-	ErrInvalidInput  = errors.New("invalid input")
-	ErrAlreadyExists = errors.New("resource already exists")
+	ErrNotFound        = errors.New("resource not found")
+	ErrUnauthorized    = errors.New("unauthorized")
+	ErrInvalidInput    = errors.New("invalid input")
+	ErrAlreadyExists   = errors.New("resource already exists")
+	ErrVersionConflict = errors.New("version conflict")
 )
 
 // Custom error type with context
@@ -52,6 +53,56 @@ func (e *DatabaseError) Unwrap() error {
 	return e.Err
 }
 
+// DuplicateError is returned when a create would duplicate an existing record
+// ExistingID and ExistingPublicID point at the record that was matched
+type DuplicateError struct {
+	Resource         string
+	ExistingID       int64
+	ExistingPublicID string
+}
+
+func (e *DuplicateError) Error() string {
+	return fmt.Sprintf("duplicate %s: matches existing id %d", e.Resource, e.ExistingID)
+}
+
+// Unwrap lets errors.Is(err, ErrAlreadyExists) match duplicate errors
+func (e *DuplicateError) Unwrap() error {
+	return ErrAlreadyExists
+}
+
+// BulkLimitError is returned when a filter-targeted mutation matches more rows than allowed
+type BulkLimitError struct {
+	Limit    int
+	Affected int64
+}
+
+func (e *BulkLimitError) Error() string {
+	return fmt.Sprintf("bulk operation would affect %d rows (limit %d)", e.Affected, e.Limit)
+}
+
+// Unwrap lets errors.Is(err, ErrInvalidInput) match bulk limit errors
+func (e *BulkLimitError) Unwrap() error {
+	return ErrInvalidInput
+}
+
+// VersionConflictError is returned when a write names a version that is no
+// longer the current one (optimistic concurrency)
+type VersionConflictError struct {
+	Resource        string
+	ID              int64
+	ExpectedVersion int
+	CurrentVersion  int
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("%s %d is at version %d, not %d", e.Resource, e.ID, e.CurrentVersion, e.ExpectedVersion)
+}
+
+// Unwrap lets errors.Is(err, ErrVersionConflict) match version conflicts
+func (e *VersionConflictError) Unwrap() error {
+	return ErrVersionConflict
+}
+
 func (e *AppError) Error() string {
 	if e.Err != nil {
 		return fmt.Sprintf("❌ %s: %v", e.Message, e.Err)
@@ -0,0 +1,111 @@
+// Package format writes activity measures for people to read: durations,
+// paces, distances and weights, in the user's units. Numbers follow the
+// conventions of the reader's language (see pkg/i18n), e.g. 21.5 km in
+// English and 21,5 km in French. Durations and paces read the same in every
+// language (1h 05m, 5:30 /km), as do the unit symbols. A nil localizer
+// formats numbers in English.
+package format
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/valentinesamuel/activelog/pkg/i18n"
+)
+
+// Units is a unit system, as in the user's units preference
+type Units string
+
+// The unit systems. Anything but Imperial, an unset preference included, is
+// metric.
+const (
+	Metric   Units = "metric"
+	Imperial Units = "imperial"
+)
+
+const (
+	kmPerMile = 1.609344
+	kgPerLb   = 0.45359237
+)
+
+// Duration formats d as hours and minutes from an hour on ("1h 05m"),
+// minutes and seconds under an hour ("5m 07s", or "45m" on the minute), and
+// seconds under a minute ("42s"). It rounds to the last unit shown; negative
+// durations are "0s".
+func Duration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	if d.Round(time.Second) >= time.Hour {
+		minutes := int64(d.Round(time.Minute) / time.Minute)
+		return fmt.Sprintf("%dh %02dm", minutes/60, minutes%60)
+	}
+	seconds := int64(d.Round(time.Second) / time.Second)
+	switch {
+	case seconds < 60:
+		return fmt.Sprintf("%ds", seconds)
+	case seconds%60 == 0:
+		return fmt.Sprintf("%dm", seconds/60)
+	default:
+		return fmt.Sprintf("%dm %02ds", seconds/60, seconds%60)
+	}
+}
+
+// Minutes formats a duration given in whole minutes (see Duration)
+func Minutes(minutes int) string {
+	return Duration(time.Duration(minutes) * time.Minute)
+}
+
+// Pace formats the time taken per kilometre, or per mile in imperial units,
+// to cover km in d: "5:30 /km", or "1:02:10 /mi" for an hour or more. It is
+// empty when there is no distance or time to divide.
+func Pace(d time.Duration, km float64, units Units) string {
+	if km <= 0 || d <= 0 {
+		return ""
+	}
+	distance, unit := km, "km"
+	if units == Imperial {
+		distance, unit = km/kmPerMile, "mi"
+	}
+
+	seconds := int64(math.Round(d.Seconds() / distance))
+	if seconds >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d /%s", seconds/3600, seconds/60%60, seconds%60, unit)
+	}
+	return fmt.Sprintf("%d:%02d /%s", seconds/60, seconds%60, unit)
+}
+
+// Distance formats km with one decimal in kilometres or, in imperial units,
+// miles: "21.5 km", "13.4 mi"
+func Distance(l *i18n.Localizer, km float64, units Units) string {
+	if units == Imperial {
+		return l.FormatNumber(km/kmPerMile, 1) + " mi"
+	}
+	return l.FormatNumber(km, 1) + " km"
+}
+
+// Weight formats kg with one decimal in kilograms or, in imperial units,
+// pounds: "71.2 kg", "157.0 lb"
+func Weight(l *i18n.Localizer, kg float64, units Units) string {
+	if units == Imperial {
+		return l.FormatNumber(kg/kgPerLb, 1) + " lb"
+	}
+	return l.FormatNumber(kg, 1) + " kg"
+}
+
+// WeightChange formats a change of weight like Weight, always signed:
+// "+1.2 kg", "-0.8 kg", "+0.0 kg"
+func WeightChange(l *i18n.Localizer, kg float64, units Units) string {
+	weight := Weight(l, kg, units)
+	if weight[0] != '-' {
+		weight = "+" + weight
+	}
+	return weight
+}
+
+// Percent formats a percentage (78.4 for 78.4%) rounded to a whole one:
+// "78%", or "78 %" in French
+func Percent(l *i18n.Localizer, pct float64) string {
+	return l.FormatPercent(pct / 100)
+}
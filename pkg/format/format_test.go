@@ -0,0 +1,199 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valentinesamuel/activelog/pkg/i18n"
+	"golang.org/x/text/language"
+)
+
+// localizers returns English, French and Spanish localizers; the formats
+// only need the languages, not their catalogs
+func localizers(t *testing.T) (en, fr, es *i18n.Localizer) {
+	t.Helper()
+	b := i18n.NewBundle(language.English)
+	require.NoError(t, b.AddMessages(language.French))
+	require.NoError(t, b.AddMessages(language.Spanish))
+	return i18n.NewLocalizer(b, "en"), i18n.NewLocalizer(b, "fr"), i18n.NewLocalizer(b, "es")
+}
+
+func TestDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{-time.Minute, "0s"},
+		{400 * time.Millisecond, "0s"},
+		{600 * time.Millisecond, "1s"},
+		{42 * time.Second, "42s"},
+		{59*time.Second + 400*time.Millisecond, "59s"},
+		{59*time.Second + 600*time.Millisecond, "1m"},
+		{time.Minute, "1m"},
+		{5*time.Minute + 7*time.Second, "5m 07s"},
+		{45 * time.Minute, "45m"},
+		{59*time.Minute + 59*time.Second, "59m 59s"},
+		{59*time.Minute + 59*time.Second + 600*time.Millisecond, "1h 00m"},
+		{time.Hour, "1h 00m"},
+		{time.Hour + 5*time.Minute, "1h 05m"},
+		{time.Hour + 5*time.Minute + 29*time.Second, "1h 05m"},
+		{time.Hour + 5*time.Minute + 30*time.Second, "1h 06m"},
+		{20*time.Hour + 50*time.Minute, "20h 50m"},
+		{100 * time.Hour, "100h 00m"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.d.String(), func(t *testing.T) {
+			assert.Equal(t, tt.want, Duration(tt.d))
+		})
+	}
+}
+
+func TestMinutes(t *testing.T) {
+	tests := []struct {
+		minutes int
+		want    string
+	}{
+		{0, "0s"},
+		{1, "1m"},
+		{45, "45m"},
+		{60, "1h 00m"},
+		{65, "1h 05m"},
+		{150, "2h 30m"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Minutes(tt.minutes), "%d minutes", tt.minutes)
+	}
+}
+
+func TestPace(t *testing.T) {
+	tests := []struct {
+		name  string
+		d     time.Duration
+		km    float64
+		units Units
+		want  string
+	}{
+		{"5:30 per km", 55 * time.Minute, 10, Metric, "5:30 /km"},
+		{"unset units are metric", 55 * time.Minute, 10, "", "5:30 /km"},
+		{"per mile", 55 * time.Minute, 10, Imperial, "8:51 /mi"},
+		{"whole minutes", 50 * time.Minute, 10, Metric, "5:00 /km"},
+		{"rounds to the second", 50*time.Minute + 4*time.Second, 10, Metric, "5:00 /km"},
+		{"rounds up into the minute", 59*time.Minute + 56*time.Second, 10, Metric, "6:00 /km"},
+		{"fast", 2 * time.Minute, 1, Metric, "2:00 /km"},
+		{"an hour or more", 2*time.Hour + 4*time.Minute + 20*time.Second, 2, Metric, "1:02:10 /km"},
+		{"partial distance", 3 * time.Minute, 0.5, Metric, "6:00 /km"},
+		{"no distance", time.Hour, 0, Metric, ""},
+		{"negative distance", time.Hour, -1, Metric, ""},
+		{"no time", 0, 5, Metric, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Pace(tt.d, tt.km, tt.units))
+		})
+	}
+}
+
+func TestDistance(t *testing.T) {
+	en, fr, es := localizers(t)
+
+	tests := []struct {
+		name  string
+		l     *i18n.Localizer
+		km    float64
+		units Units
+		want  string
+	}{
+		{"english metric", en, 21.5, Metric, "21.5 km"},
+		{"english imperial", en, 21.5, Imperial, "13.4 mi"},
+		{"unset units are metric", en, 21.5, "", "21.5 km"},
+		{"rounds to one decimal", en, 5.25, Metric, "5.2 km"},
+		{"whole", en, 10, Metric, "10.0 km"},
+		{"zero", en, 0, Metric, "0.0 km"},
+		{"thousands", en, 1234.56, Metric, "1,234.6 km"},
+		{"one mile", en, 1.609344, Imperial, "1.0 mi"},
+		{"french", fr, 21.5, Metric, "21,5 km"},
+		{"french imperial", fr, 1234.56, Imperial, "767,1 mi"},
+		{"french grouping", fr, 12345.6, Metric, "12\u00a0345,6 km"},
+		{"spanish", es, 1234.56, Metric, "1.234,6 km"},
+		{"no localizer", nil, 1234.56, Metric, "1,234.6 km"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Distance(tt.l, tt.km, tt.units))
+		})
+	}
+}
+
+func TestWeight(t *testing.T) {
+	en, fr, _ := localizers(t)
+
+	tests := []struct {
+		name  string
+		l     *i18n.Localizer
+		kg    float64
+		units Units
+		want  string
+	}{
+		{"metric", en, 71.2, Metric, "71.2 kg"},
+		{"imperial", en, 71.2, Imperial, "157.0 lb"},
+		{"unset units are metric", en, 71.2, "", "71.2 kg"},
+		{"french", fr, 71.25, Metric, "71,2 kg"},
+		{"no localizer", nil, 80, Imperial, "176.4 lb"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Weight(tt.l, tt.kg, tt.units))
+		})
+	}
+}
+
+func TestWeightChange(t *testing.T) {
+	en, fr, _ := localizers(t)
+
+	tests := []struct {
+		name  string
+		l     *i18n.Localizer
+		kg    float64
+		units Units
+		want  string
+	}{
+		{"gain", en, 1.2, Metric, "+1.2 kg"},
+		{"loss", en, -0.8, Metric, "-0.8 kg"},
+		{"loss imperial", en, -0.8, Imperial, "-1.8 lb"},
+		{"none", en, 0, Metric, "+0.0 kg"},
+		{"french loss", fr, -0.8, Metric, "-0,8 kg"},
+		{"french gain", fr, 2.5, Imperial, "+5,5 lb"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, WeightChange(tt.l, tt.kg, tt.units))
+		})
+	}
+}
+
+func TestPercent(t *testing.T) {
+	en, fr, es := localizers(t)
+
+	tests := []struct {
+		name string
+		l    *i18n.Localizer
+		pct  float64
+		want string
+	}{
+		{"english", en, 78.4, "78%"},
+		{"rounds", en, 78.6, "79%"},
+		{"zero", en, 0, "0%"},
+		{"all", en, 100, "100%"},
+		{"french", fr, 78.4, "78\u00a0%"},
+		{"spanish", es, 100, "100\u00a0%"},
+		{"no localizer", nil, 12, "12%"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Percent(tt.l, tt.pct))
+		})
+	}
+}
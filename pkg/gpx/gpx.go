@@ -0,0 +1,144 @@
+// Package gpx reads the track points of GPX files and cuts tracks into
+// fixed-distance splits.
+package gpx
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+// ErrNoTrack is returned by Parse when the file has no timed track points
+var ErrNoTrack = errors.New("gpx: no timed track points")
+
+// Point is a track point. HeartRate is 0 when the point has none.
+type Point struct {
+	Lat       float64
+	Lng       float64
+	Time      time.Time
+	HeartRate int
+}
+
+// Split is one fixed-distance part of a track. AvgHeartRate is 0 when no
+// point within it has a heart rate.
+type Split struct {
+	Index           int
+	DistanceKm      float64
+	DurationSeconds int
+	AvgHeartRate    int
+}
+
+type document struct {
+	Tracks []struct {
+		Segments []struct {
+			Points []struct {
+				Lat  float64 `xml:"lat,attr"`
+				Lng  float64 `xml:"lon,attr"`
+				Time string  `xml:"time"`
+				// Garmin's TrackPointExtension, matched in any namespace
+				HeartRate int `xml:"extensions>TrackPointExtension>hr"`
+			} `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+}
+
+// Parse returns the track points of a GPX document in time order. Points
+// without a time are skipped, since splits can't be timed from them.
+func Parse(r io.Reader) ([]Point, error) {
+	var doc document
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("gpx: %w", err)
+	}
+
+	var points []Point
+	for _, trk := range doc.Tracks {
+		for _, seg := range trk.Segments {
+			for _, p := range seg.Points {
+				if p.Time == "" {
+					continue
+				}
+				t, err := time.Parse(time.RFC3339, p.Time)
+				if err != nil {
+					return nil, fmt.Errorf("gpx: invalid time %q: %w", p.Time, err)
+				}
+				points = append(points, Point{Lat: p.Lat, Lng: p.Lng, Time: t, HeartRate: p.HeartRate})
+			}
+		}
+	}
+	if len(points) == 0 {
+		return nil, ErrNoTrack
+	}
+
+	sort.SliceStable(points, func(i, j int) bool { return points[i].Time.Before(points[j].Time) })
+	return points, nil
+}
+
+// Splits cuts the track into splits of splitKm. The time a split ends is
+// interpolated between the two points either side of its boundary. The last
+// split holds whatever is left, unless that is under 10 metres.
+func Splits(points []Point, splitKm float64) []Split {
+	var (
+		splits              []Split
+		covered, splitStart float64 // km
+		startTime           time.Time
+		hrSum, hrCount      int
+	)
+	if len(points) > 0 {
+		startTime = points[0].Time
+	}
+
+	flush := func(distance float64, end time.Time) {
+		split := Split{
+			Index:           len(splits) + 1,
+			DistanceKm:      math.Round(distance*1000) / 1000,
+			DurationSeconds: int(math.Round(end.Sub(startTime).Seconds())),
+		}
+		if hrCount > 0 {
+			split.AvgHeartRate = int(math.Round(float64(hrSum) / float64(hrCount)))
+		}
+		if split.DurationSeconds > 0 {
+			splits = append(splits, split)
+		}
+		startTime, hrSum, hrCount = end, 0, 0
+	}
+
+	for i, p := range points {
+		if i > 0 {
+			prev := points[i-1]
+			step := HaversineKm(prev.Lat, prev.Lng, p.Lat, p.Lng)
+			for step > 0 && covered+step >= splitStart+splitKm {
+				// Fraction of this step run before the boundary
+				frac := (splitStart + splitKm - covered) / step
+				boundary := prev.Time.Add(time.Duration(frac * float64(p.Time.Sub(prev.Time))))
+				flush(splitKm, boundary)
+				splitStart += splitKm
+			}
+			covered += step
+		}
+		if p.HeartRate > 0 {
+			hrSum += p.HeartRate
+			hrCount++
+		}
+	}
+
+	if rest := covered - splitStart; rest >= 0.01 {
+		flush(rest, points[len(points)-1].Time)
+	}
+	return splits
+}
+
+// HaversineKm returns the great-circle distance between two points in km
+func HaversineKm(lat1, lng1, lat2, lng2 float64) float64 {
+	const earthRadiusKm = 6371.0
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
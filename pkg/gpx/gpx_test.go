@@ -0,0 +1,85 @@
+package gpx
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// kmPerDegreeLat is the length of one degree of latitude used by HaversineKm
+const kmPerDegreeLat = 6371.0 * 3.141592653589793 / 180
+
+const sampleGPX = `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="test" xmlns="http://www.topografix.com/GPX/1/1"
+	xmlns:gpxtpx="http://www.garmin.com/xmlschemas/TrackPointExtension/v1">
+	<trk><trkseg>
+		<trkpt lat="51.5000" lon="-0.1200"><time>2026-05-01T07:00:30Z</time>
+			<extensions><gpxtpx:TrackPointExtension><gpxtpx:hr>150</gpxtpx:hr></gpxtpx:TrackPointExtension></extensions>
+		</trkpt>
+		<trkpt lat="51.4900" lon="-0.1200"><time>2026-05-01T07:00:00Z</time>
+			<extensions><gpxtpx:TrackPointExtension><gpxtpx:hr>140</gpxtpx:hr></gpxtpx:TrackPointExtension></extensions>
+		</trkpt>
+		<trkpt lat="51.5100" lon="-0.1200"></trkpt>
+	</trkseg></trk>
+</gpx>`
+
+func TestParse(t *testing.T) {
+	points, err := Parse(strings.NewReader(sampleGPX))
+	require.NoError(t, err)
+
+	// The untimed point is skipped and the rest are sorted by time
+	require.Len(t, points, 2)
+	assert.Equal(t, 51.49, points[0].Lat)
+	assert.Equal(t, -0.12, points[0].Lng)
+	assert.Equal(t, 140, points[0].HeartRate)
+	assert.Equal(t, time.Date(2026, 5, 1, 7, 0, 0, 0, time.UTC), points[0].Time)
+	assert.Equal(t, 150, points[1].HeartRate)
+}
+
+func TestParse_NoTrack(t *testing.T) {
+	_, err := Parse(strings.NewReader(`<gpx version="1.1"></gpx>`))
+	assert.ErrorIs(t, err, ErrNoTrack)
+}
+
+func TestParse_InvalidXML(t *testing.T) {
+	_, err := Parse(strings.NewReader(`<gpx><trk>`))
+	assert.Error(t, err)
+}
+
+func TestSplits(t *testing.T) {
+	start := time.Date(2026, 5, 1, 7, 0, 0, 0, time.UTC)
+	// 2.5 km due north at a steady 5:00/km
+	points := []Point{
+		{Lat: 0, Lng: 0, Time: start, HeartRate: 140},
+		{Lat: 1.5 / kmPerDegreeLat, Lng: 0, Time: start.Add(450 * time.Second), HeartRate: 160},
+		{Lat: 2.5 / kmPerDegreeLat, Lng: 0, Time: start.Add(750 * time.Second), HeartRate: 170},
+	}
+
+	splits := Splits(points, 1)
+
+	require.Len(t, splits, 3)
+	assert.Equal(t, Split{Index: 1, DistanceKm: 1, DurationSeconds: 300, AvgHeartRate: 140}, splits[0])
+	assert.Equal(t, Split{Index: 2, DistanceKm: 1, DurationSeconds: 300, AvgHeartRate: 160}, splits[1])
+	assert.Equal(t, Split{Index: 3, DistanceKm: 0.5, DurationSeconds: 150, AvgHeartRate: 170}, splits[2])
+}
+
+func TestSplits_DropsTinyRemainder(t *testing.T) {
+	start := time.Date(2026, 5, 1, 7, 0, 0, 0, time.UTC)
+	points := []Point{
+		{Lat: 0, Lng: 0, Time: start},
+		{Lat: 1.005 / kmPerDegreeLat, Lng: 0, Time: start.Add(301 * time.Second)},
+	}
+
+	splits := Splits(points, 1)
+
+	require.Len(t, splits, 1)
+	assert.Equal(t, 0, splits[0].AvgHeartRate)
+	assert.Equal(t, 300, splits[0].DurationSeconds)
+}
+
+func TestSplits_Empty(t *testing.T) {
+	assert.Empty(t, Splits(nil, 1))
+}
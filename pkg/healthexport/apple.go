@@ -0,0 +1,175 @@
+package healthexport
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// appleTimeLayout is the format of the dates in export.xml
+const appleTimeLayout = "2006-01-02 15:04:05 -0700"
+
+// appleTypePrefix starts every Apple Health workout type
+const appleTypePrefix = "HKWorkoutActivityType"
+
+// appleActivityTypes maps Apple Health workout types, less appleTypePrefix,
+// to activity types
+var appleActivityTypes = map[string]string{
+	"Running":                       "running",
+	"Walking":                       "walking",
+	"Hiking":                        "hiking",
+	"Cycling":                       "cycling",
+	"HandCycling":                   "cycling",
+	"Swimming":                      "swimming",
+	"Rowing":                        "rowing",
+	"Yoga":                          "yoga",
+	"TraditionalStrengthTraining":   "strength",
+	"FunctionalStrengthTraining":    "strength",
+	"HighIntensityIntervalTraining": "hiit",
+}
+
+// appleSourceIDKeys are the metadata keys of a workout that hold an ID given
+// by the app that recorded it
+var appleSourceIDKeys = []string{"HKExternalUUID", "HKMetadataKeyExternalUUID", "HKMetadataKeySyncIdentifier"}
+
+// appleWorkout is a <Workout> of export.xml. Numbers are kept as text so a
+// bad value fails the workout, not the file.
+type appleWorkout struct {
+	Type              string `xml:"workoutActivityType,attr"`
+	Duration          string `xml:"duration,attr"`
+	DurationUnit      string `xml:"durationUnit,attr"`
+	TotalDistance     string `xml:"totalDistance,attr"`
+	TotalDistanceUnit string `xml:"totalDistanceUnit,attr"`
+	TotalEnergy       string `xml:"totalEnergyBurned,attr"`
+	TotalEnergyUnit   string `xml:"totalEnergyBurnedUnit,attr"`
+	SourceName        string `xml:"sourceName,attr"`
+	StartDate         string `xml:"startDate,attr"`
+	EndDate           string `xml:"endDate,attr"`
+	Metadata          []struct {
+		Key   string `xml:"key,attr"`
+		Value string `xml:"value,attr"`
+	} `xml:"MetadataEntry"`
+
+	// Statistics hold the totals in exports from iOS 16 on, which leave the
+	// total attributes out
+	Statistics []struct {
+		Type string `xml:"type,attr"`
+		Sum  string `xml:"sum,attr"`
+		Unit string `xml:"unit,attr"`
+	} `xml:"WorkoutStatistics"`
+}
+
+// readAppleHealth reads the <Workout> elements of an export.xml. The rest of
+// the file (health records, activity summaries) is skipped.
+func readAppleHealth(r io.Reader, fn Handler) error {
+	dec := xml.NewDecoder(r)
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("healthexport: export.xml: %w", err)
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "Workout" {
+			continue
+		}
+		var raw appleWorkout
+		if err := dec.DecodeElement(&raw, &start); err != nil {
+			return fmt.Errorf("healthexport: export.xml: %w", err)
+		}
+		if err := fn(raw.workout()); err != nil {
+			return err
+		}
+	}
+}
+
+// workout converts the element into a Workout
+func (a *appleWorkout) workout() (Workout, error) {
+	kind := strings.TrimPrefix(a.Type, appleTypePrefix)
+	w := Workout{
+		Type:         a.Type,
+		Name:         spellOut(kind),
+		ActivityType: appleActivityTypes[kind],
+		Device:       a.SourceName,
+	}
+	if w.ActivityType == "" {
+		w.ActivityType = "other"
+	}
+
+	var err error
+	if w.Start, err = time.Parse(appleTimeLayout, a.StartDate); err != nil {
+		return w, fmt.Errorf("invalid startDate %q", a.StartDate)
+	}
+	if w.End, err = time.Parse(appleTimeLayout, a.EndDate); err != nil {
+		return w, fmt.Errorf("invalid endDate %q", a.EndDate)
+	}
+
+	w.Duration = w.End.Sub(w.Start)
+	if a.Duration != "" {
+		minutes, err := appleQuantity(a.Duration, a.DurationUnit, durationUnits)
+		if err != nil {
+			return w, fmt.Errorf("invalid duration: %w", err)
+		}
+		w.Duration = time.Duration(minutes * float64(time.Minute))
+	}
+
+	distance, distanceUnit := a.TotalDistance, a.TotalDistanceUnit
+	energy, energyUnit := a.TotalEnergy, a.TotalEnergyUnit
+	for _, stat := range a.Statistics {
+		switch {
+		case distance == "" && strings.HasPrefix(stat.Type, "HKQuantityTypeIdentifierDistance"):
+			distance, distanceUnit = stat.Sum, stat.Unit
+		case energy == "" && stat.Type == "HKQuantityTypeIdentifierActiveEnergyBurned":
+			energy, energyUnit = stat.Sum, stat.Unit
+		}
+	}
+	if distance != "" {
+		if w.DistanceKm, err = appleQuantity(distance, distanceUnit, distanceUnits); err != nil {
+			return w, fmt.Errorf("invalid distance: %w", err)
+		}
+	}
+	if energy != "" {
+		if w.Calories, err = appleQuantity(energy, energyUnit, energyUnits); err != nil {
+			return w, fmt.Errorf("invalid energy burned: %w", err)
+		}
+	}
+
+	for _, key := range appleSourceIDKeys {
+		for _, entry := range a.Metadata {
+			if entry.Key == key && entry.Value != "" && w.SourceID == "" {
+				w.SourceID = entry.Value
+			}
+		}
+	}
+	if w.SourceID == "" {
+		w.SourceID = derivedSourceID(AppleHealth, a.Type, a.StartDate, a.SourceName)
+	}
+	return w, nil
+}
+
+// Units of export.xml and their factor to minutes, kilometres and kcal
+var (
+	durationUnits = map[string]float64{"min": 1, "s": 1.0 / 60, "hr": 60}
+	distanceUnits = map[string]float64{"km": 1, "m": 0.001, "mi": 1.609344, "yd": 0.0009144}
+	energyUnits   = map[string]float64{"kcal": 1, "Cal": 1, "cal": 0.001, "kJ": 1 / 4.184}
+)
+
+// appleQuantity parses value in unit and converts it with units
+func appleQuantity(value, unit string, units map[string]float64) (float64, error) {
+	factor, ok := units[unit]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q", unit)
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("%q is not a quantity", value)
+	}
+	return v * factor, nil
+}
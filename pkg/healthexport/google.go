@@ -0,0 +1,113 @@
+package healthexport
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// googleActivityTypes maps Google Fit activities, less any ".variant"
+// suffix, to activity types
+var googleActivityTypes = map[string]string{
+	"running":           "running",
+	"walking":           "walking",
+	"hiking":            "hiking",
+	"biking":            "cycling",
+	"swimming":          "swimming",
+	"rowing":            "rowing",
+	"yoga":              "yoga",
+	"strength_training": "strength",
+	"weightlifting":     "strength",
+	"interval_training": "hiit",
+	"crossfit":          "hiit",
+}
+
+// Aggregate metrics of a Google Fit session
+const (
+	googleCalories = "com.google.calories.expended"
+	googleDistance = "com.google.distance.delta" // metres
+)
+
+// googleSession is a session file of a Takeout's Fit/All Sessions folder
+type googleSession struct {
+	ID              string `json:"id"`
+	FitnessActivity string `json:"fitnessActivity"`
+	StartTime       string `json:"startTime"`
+	EndTime         string `json:"endTime"`
+	Duration        string `json:"duration"` // e.g. "1800.5s"
+	Application     struct {
+		PackageName string `json:"packageName"`
+		Name        string `json:"name"`
+	} `json:"application"`
+	Aggregate []struct {
+		MetricName string  `json:"metricName"`
+		FloatValue float64 `json:"floatValue"`
+		IntValue   int64   `json:"intValue"`
+	} `json:"aggregate"`
+}
+
+// readGoogleFitSession reads a session file, which holds one workout. A file
+// that isn't a session is a workout that can't be read.
+func readGoogleFitSession(r io.Reader, fn Handler) error {
+	var s googleSession
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return fn(Workout{}, fmt.Errorf("invalid session file: %w", err))
+	}
+	return fn(s.workout())
+}
+
+// workout converts the session into a Workout
+func (s *googleSession) workout() (Workout, error) {
+	kind, _, _ := strings.Cut(s.FitnessActivity, ".")
+	w := Workout{
+		Type:         s.FitnessActivity,
+		Name:         spellOut(s.FitnessActivity),
+		ActivityType: googleActivityTypes[kind],
+		Device:       s.Application.Name,
+	}
+	if w.Device == "" {
+		w.Device = s.Application.PackageName
+	}
+	if w.ActivityType == "" {
+		w.ActivityType = "other"
+	}
+	if s.FitnessActivity == "" {
+		return w, fmt.Errorf("session has no fitnessActivity")
+	}
+
+	var err error
+	if w.Start, err = time.Parse(time.RFC3339, s.StartTime); err != nil {
+		return w, fmt.Errorf("invalid startTime %q", s.StartTime)
+	}
+	if w.End, err = time.Parse(time.RFC3339, s.EndTime); err != nil {
+		return w, fmt.Errorf("invalid endTime %q", s.EndTime)
+	}
+
+	// The duration is the active time, which leaves out pauses
+	w.Duration = w.End.Sub(w.Start)
+	if s.Duration != "" {
+		seconds, err := strconv.ParseFloat(strings.TrimSuffix(s.Duration, "s"), 64)
+		if err != nil || seconds < 0 {
+			return w, fmt.Errorf("invalid duration %q", s.Duration)
+		}
+		w.Duration = time.Duration(seconds * float64(time.Second))
+	}
+
+	for _, agg := range s.Aggregate {
+		switch agg.MetricName {
+		case googleCalories:
+			w.Calories = agg.FloatValue
+		case googleDistance:
+			w.DistanceKm = agg.FloatValue / 1000
+		}
+	}
+
+	w.SourceID = s.ID
+	if w.SourceID == "" {
+		w.SourceID = derivedSourceID(GoogleFit, s.FitnessActivity, w.Start.UTC().Format(time.RFC3339Nano))
+	}
+	return w, nil
+}
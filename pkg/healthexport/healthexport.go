@@ -0,0 +1,151 @@
+// Package healthexport reads the workouts of Apple Health exports and Google
+// Fit Takeout archives. Files are read as a stream, one workout at a time, so
+// exports of any size can be read.
+package healthexport
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+// Export formats
+const (
+	AppleHealth = "apple_health"
+	GoogleFit   = "google_fit"
+)
+
+// ErrNoWorkouts is returned by Read when an archive has no file of workouts
+var ErrNoWorkouts = errors.New("healthexport: no workouts in archive")
+
+// Workout is a workout of an export
+type Workout struct {
+	// SourceID identifies the workout in its app. It is the same in every
+	// export of the workout, so it tells workouts imported before.
+	SourceID string
+
+	// Type is the workout type as the app names it, e.g.
+	// HKWorkoutActivityTypeRunning or running.jogging; Name is it spelled out
+	// ("Running", "Running Jogging")
+	Type string
+	Name string
+
+	// ActivityType is the activity type matching Type: one of the default
+	// activity types, or "other"
+	ActivityType string
+
+	Start      time.Time
+	End        time.Time
+	Duration   time.Duration
+	DistanceKm float64
+	Calories   float64 // kcal
+
+	// Device is the app or device that recorded the workout, if known
+	Device string
+}
+
+// Handler is called with each workout of an export, in file order. err is
+// set when a workout could not be read; reading carries on with the next.
+// Returning an error stops Read with it.
+type Handler func(w Workout, err error) error
+
+// Read reads the workouts of an export of format. f is a zip archive (Apple
+// Health's export.zip, a Google Fit Takeout) or, for Apple Health,
+// export.xml itself.
+func Read(f io.ReaderAt, size int64, format string, fn Handler) error {
+	var magic [4]byte
+	n, err := f.ReadAt(magic[:], 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("healthexport: %w", err)
+	}
+
+	if bytes.Equal(magic[:n], []byte("PK\x03\x04")) {
+		zr, err := zip.NewReader(f, size)
+		if err != nil {
+			return fmt.Errorf("healthexport: %w", err)
+		}
+		return readArchive(zr, format, fn)
+	}
+
+	switch format {
+	case AppleHealth:
+		return readAppleHealth(io.NewSectionReader(f, 0, size), fn)
+	case GoogleFit:
+		return errors.New("healthexport: a Google Fit export must be a Takeout zip archive")
+	default:
+		return fmt.Errorf("healthexport: unknown format %q", format)
+	}
+}
+
+// readArchive reads the workout files of format in an archive: Apple
+// Health's export.xml, or the session files of Google Fit's All Sessions
+// folder
+func readArchive(zr *zip.Reader, format string, fn Handler) error {
+	found := false
+	for _, file := range zr.File {
+		dir, name := path.Split(file.Name)
+
+		var read func(io.Reader, Handler) error
+		switch {
+		case format == AppleHealth && name == "export.xml":
+			read = readAppleHealth
+		case format == GoogleFit && path.Base(dir) == "All Sessions" && path.Ext(name) == ".json":
+			read = readGoogleFitSession
+		default:
+			continue
+		}
+		found = true
+
+		r, err := file.Open()
+		if err != nil {
+			return fmt.Errorf("healthexport: %s: %w", file.Name, err)
+		}
+		err = read(r, fn)
+		r.Close()
+		if err != nil {
+			return err
+		}
+	}
+	if !found {
+		return ErrNoWorkouts
+	}
+	return nil
+}
+
+// derivedSourceID is the source ID of a workout its app gave no ID: a hash
+// of what identifies it
+func derivedSourceID(parts ...string) string {
+	sum := sha1.Sum([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// spellOut turns a type name into words: "TraditionalStrengthTraining" and
+// "strength_training" become "Traditional Strength Training" and "Strength
+// Training"
+func spellOut(name string) string {
+	var b strings.Builder
+	prev := ' '
+	for _, r := range name {
+		switch {
+		case r == '_' || r == '.' || r == '-':
+			r = ' '
+		case prev == ' ' && r >= 'a' && r <= 'z':
+			r -= 'a' - 'A'
+		case r >= 'A' && r <= 'Z' && prev >= 'a' && prev <= 'z':
+			b.WriteByte(' ')
+		}
+		if r == ' ' && prev == ' ' {
+			continue
+		}
+		b.WriteRune(r)
+		prev = r
+	}
+	return strings.TrimSpace(b.String())
+}
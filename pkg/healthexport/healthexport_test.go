@@ -0,0 +1,180 @@
+package healthexport
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleExportXML = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE HealthData [
+<!ELEMENT HealthData (ExportDate,Me,(Record|Workout)*)>
+<!ATTLIST HealthData locale CDATA #REQUIRED>
+]>
+<HealthData locale="en_GB">
+ <ExportDate value="2026-06-01 10:00:00 +0100"/>
+ <Record type="HKQuantityTypeIdentifierStepCount" sourceName="iPhone" unit="count" value="120" startDate="2026-05-01 07:00:00 +0100" endDate="2026-05-01 07:01:00 +0100"/>
+ <Workout workoutActivityType="HKWorkoutActivityTypeRunning" duration="30.5" durationUnit="min" totalDistance="5.2" totalDistanceUnit="km" totalEnergyBurned="320" totalEnergyBurnedUnit="kcal" sourceName="Sam's Apple Watch" startDate="2026-05-01 07:30:00 +0100" endDate="2026-05-01 08:01:00 +0100">
+  <MetadataEntry key="HKIndoorWorkout" value="0"/>
+ </Workout>
+ <Workout workoutActivityType="HKWorkoutActivityTypeTraditionalStrengthTraining" duration="45" durationUnit="min" sourceName="Strong" startDate="2026-05-02 18:00:00 +0100" endDate="2026-05-02 18:45:00 +0100">
+  <MetadataEntry key="HKExternalUUID" value="strong-1234"/>
+  <WorkoutStatistics type="HKQuantityTypeIdentifierActiveEnergyBurned" startDate="2026-05-02 18:00:00 +0100" endDate="2026-05-02 18:45:00 +0100" sum="836.8" unit="kJ"/>
+ </Workout>
+ <Workout workoutActivityType="HKWorkoutActivityTypeCycling" sourceName="Watch" startDate="yesterday" endDate="2026-05-03 09:00:00 +0100"/>
+ <Workout workoutActivityType="HKWorkoutActivityTypeWalking" sourceName="Watch" startDate="2026-05-04 12:00:00 +0100" endDate="2026-05-04 12:20:00 +0100">
+  <WorkoutStatistics type="HKQuantityTypeIdentifierDistanceWalkingRunning" sum="1.5" unit="mi"/>
+ </Workout>
+</HealthData>`
+
+// result is what a Handler was called with
+type result struct {
+	workout Workout
+	err     error
+}
+
+func readAll(t *testing.T, data []byte, format string) ([]result, error) {
+	t.Helper()
+	var results []result
+	err := Read(bytes.NewReader(data), int64(len(data)), format, func(w Workout, err error) error {
+		results = append(results, result{w, err})
+		return nil
+	})
+	return results, err
+}
+
+func zipOf(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestRead_AppleHealth(t *testing.T) {
+	results, err := readAll(t, []byte(sampleExportXML), AppleHealth)
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	run := results[0].workout
+	require.NoError(t, results[0].err)
+	assert.Equal(t, "running", run.ActivityType)
+	assert.Equal(t, "Running", run.Name)
+	assert.Equal(t, time.Date(2026, 5, 1, 6, 30, 0, 0, time.UTC), run.Start.UTC())
+	assert.Equal(t, 30*time.Minute+30*time.Second, run.Duration)
+	assert.Equal(t, 5.2, run.DistanceKm)
+	assert.Equal(t, 320.0, run.Calories)
+	assert.Equal(t, "Sam's Apple Watch", run.Device)
+	assert.Len(t, run.SourceID, 40, "derived from the workout")
+
+	strength := results[1].workout
+	require.NoError(t, results[1].err)
+	assert.Equal(t, "strength", strength.ActivityType)
+	assert.Equal(t, "Traditional Strength Training", strength.Name)
+	assert.Equal(t, "strong-1234", strength.SourceID)
+	assert.InDelta(t, 200, strength.Calories, 0.01, "kJ from the statistics")
+
+	assert.ErrorContains(t, results[2].err, "startDate")
+
+	walk := results[3].workout
+	require.NoError(t, results[3].err)
+	assert.Equal(t, 20*time.Minute, walk.Duration, "from the dates")
+	assert.InDelta(t, 2.414, walk.DistanceKm, 0.001)
+}
+
+func TestRead_AppleHealthSourceIDsAreStable(t *testing.T) {
+	first, err := readAll(t, []byte(sampleExportXML), AppleHealth)
+	require.NoError(t, err)
+	again, err := readAll(t, zipOf(t, map[string]string{"apple_health_export/export.xml": sampleExportXML}), AppleHealth)
+	require.NoError(t, err)
+
+	require.Len(t, again, len(first))
+	for i := range first {
+		assert.Equal(t, first[i].workout.SourceID, again[i].workout.SourceID)
+	}
+	assert.NotEqual(t, first[0].workout.SourceID, first[3].workout.SourceID)
+}
+
+func TestRead_AppleHealthInvalidXML(t *testing.T) {
+	_, err := readAll(t, []byte(`<HealthData><Workout startDate="`), AppleHealth)
+	assert.Error(t, err)
+}
+
+func TestRead_GoogleFitTakeout(t *testing.T) {
+	archive := zipOf(t, map[string]string{
+		"Takeout/Fit/All Sessions/2026-05-01T07_30_00.000+01_00_RUNNING.json": `{
+			"fitnessActivity": "running.jogging",
+			"startTime": "2026-05-01T06:30:00.000Z",
+			"endTime": "2026-05-01T07:05:00.000Z",
+			"duration": "1800.000s",
+			"application": {"packageName": "com.google.android.apps.fitness"},
+			"aggregate": [
+				{"metricName": "com.google.calories.expended", "floatValue": 301.4},
+				{"metricName": "com.google.distance.delta", "floatValue": 5012.5},
+				{"metricName": "com.google.step_count.delta", "intValue": 6100}
+			]
+		}`,
+		"Takeout/Fit/All Sessions/broken.json":     `{"fitnessActivity": `,
+		"Takeout/Fit/All Data/raw_com.google.json": `{"Data Source": "raw"}`,
+		"Takeout/Fit/Daily activity metrics/x.csv": "Date,Steps",
+	})
+
+	results, err := readAll(t, archive, GoogleFit)
+	require.NoError(t, err)
+	require.Len(t, results, 2, "only the session files")
+
+	var run Workout
+	var failed int
+	for _, r := range results {
+		if r.err != nil {
+			failed++
+			continue
+		}
+		run = r.workout
+	}
+	assert.Equal(t, 1, failed)
+	assert.Equal(t, "running", run.ActivityType)
+	assert.Equal(t, "Running Jogging", run.Name)
+	assert.Equal(t, 30*time.Minute, run.Duration, "the active time")
+	assert.Equal(t, 5.0125, run.DistanceKm)
+	assert.Equal(t, 301.4, run.Calories)
+	assert.Equal(t, "com.google.android.apps.fitness", run.Device)
+	assert.NotEmpty(t, run.SourceID)
+}
+
+func TestRead_ArchiveWithoutWorkouts(t *testing.T) {
+	_, err := readAll(t, zipOf(t, map[string]string{"Takeout/Mail/all.mbox": ""}), GoogleFit)
+	assert.ErrorIs(t, err, ErrNoWorkouts)
+
+	_, err = readAll(t, []byte(`{}`), GoogleFit)
+	assert.Error(t, err, "Google Fit exports are archives")
+}
+
+func TestRead_HandlerErrorStops(t *testing.T) {
+	stop := errors.New("stop")
+	calls := 0
+	err := Read(strings.NewReader(sampleExportXML), int64(len(sampleExportXML)), AppleHealth, func(Workout, error) error {
+		calls++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
+
+func TestSpellOut(t *testing.T) {
+	assert.Equal(t, "High Intensity Interval Training", spellOut("HighIntensityIntervalTraining"))
+	assert.Equal(t, "Interval Training High Intensity", spellOut("interval_training.high_intensity"))
+	assert.Equal(t, "Other", spellOut("Other"))
+}
@@ -0,0 +1,109 @@
+package httpclient
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling the host while its breaker is open
+var ErrCircuitOpen = errors.New("httpclient: circuit open")
+
+// breakerState is the state of one host's circuit breaker
+type breakerState int
+
+const (
+	stateClosed   breakerState = iota // calls go through
+	stateOpen                         // calls fail fast until openUntil
+	stateHalfOpen                     // one probe call decides
+)
+
+// breaker is a consecutive-failure circuit breaker for one host.
+//
+// FailureThreshold failed calls in a row open it; after OpenTimeout one probe
+// is let through (half-open), and its outcome closes the breaker again or
+// re-opens it for another OpenTimeout.
+type breaker struct {
+	mu        sync.Mutex
+	state     breakerState
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+// allow reports whether a call may go to the host now
+func (b *breaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case stateOpen:
+		if now.Before(b.openUntil) {
+			return false
+		}
+		b.state = stateHalfOpen
+		b.probing = true
+		return true
+	case stateHalfOpen:
+		// Only the probe goes through until it reports back
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// record reports the outcome of an allowed call, and whether it opened the
+// breaker
+func (b *breaker) record(success bool, now time.Time, threshold int, openTimeout time.Duration) (opened bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if success {
+		b.state = stateClosed
+		b.failures = 0
+		return false
+	}
+
+	b.failures++
+	if b.state == stateHalfOpen || b.failures >= threshold {
+		b.state = stateOpen
+		b.openUntil = now.Add(openTimeout)
+		return true
+	}
+	return false
+}
+
+// release ends an allowed call without an outcome (the caller gave up), so
+// that a half-open breaker lets the next probe through
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// breakers holds the breaker of every host a client has called
+type breakers struct {
+	mu    sync.Mutex
+	hosts map[string]*breaker
+}
+
+// get returns the breaker of host, creating it on first use
+func (bs *breakers) get(host string) *breaker {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	if bs.hosts == nil {
+		bs.hosts = make(map[string]*breaker)
+	}
+	b, ok := bs.hosts[host]
+	if !ok {
+		b = &breaker{}
+		bs.hosts[host] = b
+	}
+	return b
+}
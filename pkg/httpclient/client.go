@@ -0,0 +1,272 @@
+// Package httpclient builds the *http.Client every outbound integration
+// uses (weather, geocoding, OAuth providers, webhooks), so they share one
+// error budget instead of each picking its own:
+//
+//   - a timeout per attempt, covering the response body too
+//   - bounded retries with jittered backoff, only for idempotent requests
+//   - a circuit breaker per host that fails fast while a host is down
+//   - Prometheus metrics per client and host
+//
+// Example:
+//
+//	client := httpclient.New(httpclient.Config{Name: "weather", Timeout: cfg.Timeout})
+//	resp, err := client.Do(req)
+//	if errors.Is(err, httpclient.ErrCircuitOpen) {
+//	    // the host failed repeatedly; try again later
+//	}
+package httpclient
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Config is the error budget of one client. Zero fields take the defaults
+// of DefaultConfig.
+type Config struct {
+	// Name labels the client's metrics (e.g., "weather", "webhook")
+	Name string
+
+	// Timeout bounds each attempt, from sending the request to closing the
+	// response body
+	Timeout time.Duration
+
+	// MaxAttempts is the total number of attempts of an idempotent request,
+	// including the first; 1 disables retries
+	MaxAttempts int
+
+	// BaseDelay is the backoff before the first retry, doubled for each
+	// further retry up to MaxDelay, with jitter
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// FailureThreshold is the number of failed calls in a row that opens a
+	// host's circuit breaker; OpenTimeout is how long it stays open
+	FailureThreshold int
+	OpenTimeout      time.Duration
+
+	// Transport sends the requests; http.DefaultTransport when nil
+	Transport http.RoundTripper
+}
+
+// DefaultConfig returns the defaults applied to zero Config fields
+func DefaultConfig() Config {
+	return Config{
+		Name:             "default",
+		Timeout:          10 * time.Second,
+		MaxAttempts:      3,
+		BaseDelay:        200 * time.Millisecond,
+		MaxDelay:         2 * time.Second,
+		FailureThreshold: 5,
+		OpenTimeout:      30 * time.Second,
+	}
+}
+
+var (
+	requestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "httpclient_requests_total",
+			Help: "Total outbound HTTP attempts by outcome (2xx, 3xx, 4xx, 5xx, error, circuit_open)",
+		},
+		[]string{"client", "host", "outcome"},
+	)
+
+	requestDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "httpclient_request_duration_seconds",
+			Help:    "Outbound HTTP attempt latency until response headers",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"client", "host"},
+	)
+
+	retriesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "httpclient_retries_total",
+			Help: "Total outbound HTTP attempts that were retries",
+		},
+		[]string{"client", "host"},
+	)
+
+	circuitOpenedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "httpclient_circuit_opened_total",
+			Help: "Total times a host's circuit breaker opened",
+		},
+		[]string{"client", "host"},
+	)
+)
+
+// New returns an *http.Client applying cfg to every request
+func New(cfg Config) *http.Client {
+	defaults := DefaultConfig()
+	if cfg.Name == "" {
+		cfg.Name = defaults.Name
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaults.Timeout
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaults.MaxAttempts
+	}
+	if cfg.BaseDelay <= 0 {
+		cfg.BaseDelay = defaults.BaseDelay
+	}
+	if cfg.MaxDelay <= 0 {
+		cfg.MaxDelay = defaults.MaxDelay
+	}
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = defaults.FailureThreshold
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = defaults.OpenTimeout
+	}
+	if cfg.Transport == nil {
+		cfg.Transport = http.DefaultTransport
+	}
+
+	return &http.Client{Transport: &transport{cfg: cfg, now: time.Now}}
+}
+
+// transport is the http.RoundTripper behind clients made by New
+type transport struct {
+	cfg      Config
+	breakers breakers
+	now      func() time.Time
+}
+
+// RoundTrip sends req, retrying idempotent requests that failed with a
+// network error or a 429, 502, 503 or 504
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	breaker := t.breakers.get(host)
+	retryable := idempotent(req)
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 {
+			retriesTotal.WithLabelValues(t.cfg.Name, host).Inc()
+		}
+
+		if !breaker.allow(t.now()) {
+			requestsTotal.WithLabelValues(t.cfg.Name, host, "circuit_open").Inc()
+			return nil, ErrCircuitOpen
+		}
+
+		resp, err := t.attempt(req, attempt)
+		failed := err != nil || resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+		if err != nil && req.Context().Err() != nil {
+			// A canceled caller says nothing about the host
+			breaker.release()
+		} else if breaker.record(!failed, t.now(), t.cfg.FailureThreshold, t.cfg.OpenTimeout) {
+			circuitOpenedTotal.WithLabelValues(t.cfg.Name, host).Inc()
+		}
+
+		if !retryable || attempt >= t.cfg.MaxAttempts || !shouldRetry(resp, err) || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt, resp)
+		if resp != nil {
+			// Drain so the connection can be reused
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4<<10))
+			resp.Body.Close()
+		}
+		select {
+		case <-time.After(delay):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+// attempt sends one try of req under the per-attempt timeout
+func (t *transport) attempt(req *http.Request, attempt int) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.cfg.Timeout)
+	try := req.Clone(ctx)
+	if attempt > 1 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		try.Body = body
+	}
+
+	start := t.now()
+	resp, err := t.cfg.Transport.RoundTrip(try)
+	requestDuration.WithLabelValues(t.cfg.Name, req.URL.Host).Observe(time.Since(start).Seconds())
+	if err != nil {
+		cancel()
+		requestsTotal.WithLabelValues(t.cfg.Name, req.URL.Host, "error").Inc()
+		return nil, err
+	}
+	requestsTotal.WithLabelValues(t.cfg.Name, req.URL.Host, strconv.Itoa(resp.StatusCode/100)+"xx").Inc()
+
+	// The timeout keeps running while the caller reads the body
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// backoff returns the jittered delay before the retry after attempt, or the
+// server's Retry-After when that is longer (still capped by MaxDelay)
+func (t *transport) backoff(attempt int, resp *http.Response) time.Duration {
+	delay := t.cfg.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > t.cfg.MaxDelay {
+		delay = t.cfg.MaxDelay
+	}
+	// Jitter over the upper half so clients that failed together spread out
+	delay = delay/2 + rand.N(delay/2+1)
+
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			if after := time.Duration(seconds) * time.Second; after > delay {
+				delay = min(after, t.cfg.MaxDelay)
+			}
+		}
+	}
+	return delay
+}
+
+// idempotent reports whether req can be sent again: a safe or idempotent
+// method, or an Idempotency-Key header, and a body that can be replayed
+func idempotent(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+// shouldRetry reports whether an attempt failed in a way a retry can fix
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// cancelBody releases an attempt's timeout when its body is closed
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,135 @@
+package httpclient
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testClient(threshold int) *http.Client {
+	return New(Config{
+		Name:             "test",
+		Timeout:          time.Second,
+		MaxAttempts:      3,
+		BaseDelay:        time.Millisecond,
+		MaxDelay:         2 * time.Millisecond,
+		FailureThreshold: threshold,
+		OpenTimeout:      time.Hour,
+	})
+}
+
+func TestClient_RetriesIdempotentRequests(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	resp, err := testClient(10).Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || calls.Load() != 3 {
+		t.Errorf("status %d after %d calls, want 200 after 3", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestClient_DoesNotRetryPost(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := testClient(10)
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if calls.Load() != 1 {
+		t.Errorf("POST sent %d times, want 1", calls.Load())
+	}
+
+	// An Idempotency-Key makes a POST safe to send again
+	req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{}`))
+	req.Header.Set("Idempotency-Key", "abc")
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if calls.Load() != 4 {
+		t.Errorf("keyed POST sent %d times, want 3", calls.Load()-1)
+	}
+}
+
+func TestClient_CircuitOpensPerHost(t *testing.T) {
+	var calls atomic.Int32
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer down.Close()
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer up.Close()
+
+	client := testClient(2)
+	for i := 0; i < 2; i++ {
+		resp, err := client.Post(down.URL, "text/plain", nil)
+		if err != nil {
+			t.Fatalf("call %d: unexpected error: %v", i, err)
+		}
+		resp.Body.Close()
+	}
+
+	_, err := client.Get(down.URL)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("open circuit still reached the host (%d calls)", calls.Load())
+	}
+
+	resp, err := client.Get(up.URL)
+	if err != nil {
+		t.Fatalf("other host affected by the open circuit: %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestBreaker_HalfOpenProbe(t *testing.T) {
+	b := &breaker{}
+	now := time.Now()
+
+	if !b.record(false, now, 1, time.Minute) {
+		t.Fatal("expected the first failure to open the breaker")
+	}
+	if b.allow(now.Add(time.Second)) {
+		t.Fatal("open breaker allowed a call")
+	}
+
+	later := now.Add(2 * time.Minute)
+	if !b.allow(later) {
+		t.Fatal("expected a probe after OpenTimeout")
+	}
+	if b.allow(later) {
+		t.Fatal("half-open breaker allowed a second call before the probe finished")
+	}
+	b.record(true, later, 1, time.Minute)
+	if !b.allow(later) {
+		t.Fatal("expected a successful probe to close the breaker")
+	}
+}
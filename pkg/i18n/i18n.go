@@ -0,0 +1,158 @@
+// Package i18n translates messages from catalogs, in the style of go-i18n: a
+// Bundle holds the messages of every language, loaded from one JSON file per
+// language, and a Localizer picks the best of them for the languages a
+// client accepts. A message catalog maps message IDs to their text, either
+// directly or with one/other plural forms:
+//
+//	{
+//	  "Activity not found": "Activité introuvable",
+//	  "ActivityCount": {"one": "{{.Count}} activité", "other": "{{.Count}} activités"}
+//	}
+//
+// Texts are text/template templates. A message missing from a language falls
+// back to the bundle's default language, then to its ID, so IDs may be the
+// default-language text itself.
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"text/template"
+
+	"golang.org/x/text/language"
+)
+
+// Message is one translated message. One is used when the plural count is
+// in the "one" category of the language, Other in every other case.
+type Message struct {
+	ID          string `json:"-"`
+	Description string `json:"description,omitempty"`
+	One         string `json:"one,omitempty"`
+	Other       string `json:"other"`
+
+	one, other *template.Template
+}
+
+// UnmarshalJSON accepts a message either as its text or as an object with
+// plural forms
+func (m *Message) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		m.Other = text
+		return nil
+	}
+	type plain Message
+	return json.Unmarshal(data, (*plain)(m))
+}
+
+// parse compiles the message's texts
+func (m *Message) parse() error {
+	if m.Other == "" {
+		return fmt.Errorf("message %q has no text", m.ID)
+	}
+	var err error
+	if m.other, err = template.New(m.ID).Parse(m.Other); err != nil {
+		return err
+	}
+	if m.One != "" {
+		if m.one, err = template.New(m.ID).Parse(m.One); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Bundle holds the message catalogs of every supported language
+type Bundle struct {
+	defaultTag language.Tag
+	tags       []language.Tag
+	matcher    language.Matcher
+	messages   map[language.Tag]map[string]*Message
+}
+
+// NewBundle returns an empty bundle whose messages fall back to defaultTag
+func NewBundle(defaultTag language.Tag) *Bundle {
+	b := &Bundle{defaultTag: defaultTag, messages: map[language.Tag]map[string]*Message{}}
+	b.addTag(defaultTag)
+	return b
+}
+
+// DefaultLanguage is the language messages fall back to
+func (b *Bundle) DefaultLanguage() language.Tag {
+	return b.defaultTag
+}
+
+// LanguageTags lists the bundle's languages, the default first
+func (b *Bundle) LanguageTags() []language.Tag {
+	return append([]language.Tag(nil), b.tags...)
+}
+
+// AddMessages adds messages to the catalog of tag, replacing messages with
+// the same IDs
+func (b *Bundle) AddMessages(tag language.Tag, messages ...*Message) error {
+	for _, m := range messages {
+		if err := m.parse(); err != nil {
+			return fmt.Errorf("i18n: %s: %w", tag, err)
+		}
+	}
+	b.addTag(tag)
+	for _, m := range messages {
+		b.messages[tag][m.ID] = m
+	}
+	return nil
+}
+
+// ParseMessageFileBytes adds the messages of a JSON catalog. The language is
+// taken from the file name: the part before the extension, as in "fr.json"
+// or "active.fr.json".
+func (b *Bundle) ParseMessageFileBytes(buf []byte, filename string) error {
+	parts := strings.Split(path.Base(filename), ".")
+	if len(parts) < 2 {
+		return fmt.Errorf("i18n: %s: no language in file name", filename)
+	}
+	tag, err := language.Parse(parts[len(parts)-2])
+	if err != nil {
+		return fmt.Errorf("i18n: %s: %w", filename, err)
+	}
+
+	var catalog map[string]*Message
+	if err := json.Unmarshal(buf, &catalog); err != nil {
+		return fmt.Errorf("i18n: %s: %w", filename, err)
+	}
+	messages := make([]*Message, 0, len(catalog))
+	for id, m := range catalog {
+		m.ID = id
+		messages = append(messages, m)
+	}
+	return b.AddMessages(tag, messages...)
+}
+
+// LoadFS adds every catalog in fsys matching pattern (see fs.Glob)
+func (b *Bundle) LoadFS(fsys fs.FS, pattern string) error {
+	files, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		buf, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return err
+		}
+		if err := b.ParseMessageFileBytes(buf, file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *Bundle) addTag(tag language.Tag) {
+	if _, ok := b.messages[tag]; ok {
+		return
+	}
+	b.messages[tag] = map[string]*Message{}
+	b.tags = append(b.tags, tag)
+	b.matcher = language.NewMatcher(b.tags)
+}
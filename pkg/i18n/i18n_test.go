@@ -0,0 +1,123 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func testBundle(t *testing.T) *Bundle {
+	t.Helper()
+	b := NewBundle(language.English)
+	require.NoError(t, b.LoadFS(fstest.MapFS{
+		"catalogs/en.json": {Data: []byte(`{
+			"Greeting": "Hi {{.Name}}",
+			"ActivityCount": {"one": "{{.Count}} activity", "other": "{{.Count}} activities"},
+			"OnlyEnglish": "Only in English"
+		}`)},
+		"catalogs/active.fr.json": {Data: []byte(`{
+			"Greeting": "Bonjour {{.Name}}",
+			"ActivityCount": {"one": "{{.Count}} activité", "other": "{{.Count}} activités"},
+			"Activity not found": "Activité introuvable",
+			"March": "mars",
+			"Mar.": "mars",
+			"Monday": "lundi"
+		}`)},
+	}, "catalogs/*.json"))
+	return b
+}
+
+func TestBundle_LanguageTags(t *testing.T) {
+	b := testBundle(t)
+	assert.Equal(t, []language.Tag{language.English, language.French}, b.LanguageTags())
+}
+
+func TestBundle_ParseMessageFileBytes_Invalid(t *testing.T) {
+	b := NewBundle(language.English)
+	assert.Error(t, b.ParseMessageFileBytes([]byte(`{}`), "catalog.json"), "no language")
+	assert.Error(t, b.ParseMessageFileBytes([]byte(`{"A": "{{.Name"}`), "en.json"), "bad template")
+	assert.Error(t, b.ParseMessageFileBytes([]byte(`{"A": {"one": "x"}}`), "en.json"), "no other form")
+}
+
+func TestNewLocalizer(t *testing.T) {
+	b := testBundle(t)
+
+	tests := []struct {
+		name  string
+		langs []string
+		want  language.Tag
+	}{
+		{"accept-language", []string{"de;q=0.9, fr-CA;q=0.8"}, language.French},
+		{"first preference wins", []string{"fr", "en"}, language.French},
+		{"unparsable skipped", []string{"!!", "fr"}, language.French},
+		{"no match", []string{"de"}, language.English},
+		{"none", nil, language.English},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewLocalizer(b, tt.langs...).Language())
+		})
+	}
+}
+
+func TestLocalizer_Localize(t *testing.T) {
+	b := testBundle(t)
+	fr := NewLocalizer(b, "fr")
+
+	assert.Equal(t, "Bonjour Ada", fr.Localize("Greeting", map[string]any{"Name": "Ada"}))
+	assert.Equal(t, "Activité introuvable", fr.Localize("Activity not found", nil))
+	assert.Equal(t, "Only in English", fr.Localize("OnlyEnglish", nil), "falls back to the default language")
+	assert.Equal(t, "Activity not found", NewLocalizer(b, "en").Localize("Activity not found", nil), "falls back to the ID")
+
+	var none *Localizer
+	assert.Equal(t, "Activity not found", none.Localize("Activity not found", nil))
+}
+
+func TestLocalizer_LocalizePlural(t *testing.T) {
+	b := testBundle(t)
+	en, fr := NewLocalizer(b, "en"), NewLocalizer(b, "fr")
+
+	assert.Equal(t, "1 activity", en.LocalizePlural("ActivityCount", 1, nil))
+	assert.Equal(t, "0 activities", en.LocalizePlural("ActivityCount", 0, nil))
+	assert.Equal(t, "0 activité", fr.LocalizePlural("ActivityCount", 0, nil), "zero is singular in French")
+	assert.Equal(t, "2 activités", fr.LocalizePlural("ActivityCount", 2, nil))
+}
+
+func TestLocalizer_FormatNumber(t *testing.T) {
+	b := testBundle(t)
+
+	assert.Equal(t, "1,234.5", NewLocalizer(b, "en").FormatNumber(1234.5, 1))
+	assert.Equal(t, "1\u00a0234,50", NewLocalizer(b, "fr").FormatNumber(1234.5, 2))
+	var none *Localizer
+	assert.Equal(t, "12", none.FormatNumber(12.3, 0))
+}
+
+func TestLocalizer_FormatPercent(t *testing.T) {
+	b := testBundle(t)
+
+	assert.Equal(t, "78%", NewLocalizer(b, "en").FormatPercent(0.784))
+	assert.Equal(t, "100\u00a0%", NewLocalizer(b, "fr").FormatPercent(1))
+	var none *Localizer
+	assert.Equal(t, "5%", none.FormatPercent(0.05))
+}
+
+func TestLocalizer_FormatDate(t *testing.T) {
+	b := testBundle(t)
+	day := time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "lundi 3 mars 2025", NewLocalizer(b, "fr").FormatDate(day, "Monday 2 January 2006"))
+	assert.Equal(t, "Mon, Mar 3", NewLocalizer(b, "en").FormatDate(day, "Mon, Jan 2"))
+	assert.Equal(t, "Mon 3 mars", NewLocalizer(b, "fr").FormatDate(day, "Mon 2 Jan"), "missing names stay English")
+	assert.Equal(t, "2025-03-03", NewLocalizer(b, "fr").FormatDate(day, "2006-01-02"))
+}
+
+func TestContext(t *testing.T) {
+	l := NewLocalizer(testBundle(t), "fr")
+	assert.Same(t, l, FromContext(NewContext(context.Background(), l)))
+	assert.Nil(t, FromContext(context.Background()))
+}
@@ -0,0 +1,179 @@
+package i18n
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// Localizer translates messages and formats numbers and dates for one
+// language of a bundle. A nil Localizer leaves messages as their IDs and
+// formats like the bundle-less default, so code can localize whether or not
+// a request negotiated a language.
+type Localizer struct {
+	bundle  *Bundle
+	tag     language.Tag
+	printer *message.Printer
+}
+
+// NewLocalizer returns a localizer for the bundle language that best matches
+// langs, in order of preference. Each of langs may be a language tag or an
+// Accept-Language header value; unparsable ones are skipped. Without a match
+// the bundle's default language is used.
+func NewLocalizer(b *Bundle, langs ...string) *Localizer {
+	var wanted []language.Tag
+	for _, lang := range langs {
+		tags, _, err := language.ParseAcceptLanguage(lang)
+		if err != nil {
+			continue
+		}
+		wanted = append(wanted, tags...)
+	}
+
+	tag := b.defaultTag
+	if len(wanted) > 0 {
+		// The matched tag may carry extensions (e.g. a region); use the
+		// bundle's own tag so catalog lookups hit
+		if _, index, confidence := b.matcher.Match(wanted...); confidence != language.No {
+			tag = b.tags[index]
+		}
+	}
+	return &Localizer{bundle: b, tag: tag, printer: message.NewPrinter(tag)}
+}
+
+// Language is the language the localizer translates to
+func (l *Localizer) Language() language.Tag {
+	if l == nil {
+		return language.Und
+	}
+	return l.tag
+}
+
+// Localize translates the message id, filling in its template with data
+func (l *Localizer) Localize(id string, data map[string]any) string {
+	return l.localize(id, nil, data)
+}
+
+// LocalizePlural translates the message id in the plural form for count,
+// which is also available to its template as .Count
+func (l *Localizer) LocalizePlural(id string, count int, data map[string]any) string {
+	withCount := map[string]any{"Count": count}
+	for k, v := range data {
+		withCount[k] = v
+	}
+	return l.localize(id, &count, withCount)
+}
+
+func (l *Localizer) localize(id string, count *int, data map[string]any) string {
+	if text, ok := l.lookup(id, count, data); ok {
+		return text
+	}
+	return id
+}
+
+// lookup translates the message id, reporting whether any language has it
+func (l *Localizer) lookup(id string, count *int, data map[string]any) (string, bool) {
+	if l == nil {
+		return "", false
+	}
+	for _, tag := range []language.Tag{l.tag, l.bundle.defaultTag} {
+		m, ok := l.bundle.messages[tag][id]
+		if !ok {
+			continue
+		}
+		tmpl := m.other
+		if count != nil && m.one != nil && plural.Cardinal.MatchPlural(tag, *count, 0, 0, 0, 0) == plural.One {
+			tmpl = m.one
+		}
+		var b strings.Builder
+		if err := tmpl.Execute(&b, data); err != nil {
+			continue
+		}
+		return b.String(), true
+	}
+	return "", false
+}
+
+// FormatNumber formats v with exactly decimals fraction digits and the
+// language's separators, e.g. 1,234.5 in English and 1 234,5 (with a
+// no-break space) in French
+func (l *Localizer) FormatNumber(v float64, decimals int) string {
+	return l.sprint(number.Decimal(v, number.MinFractionDigits(decimals), number.MaxFractionDigits(decimals)))
+}
+
+// FormatPercent formats a fraction as a whole percentage with the
+// language's conventions, e.g. 78% in English and 78 % in French
+func (l *Localizer) FormatPercent(fraction float64) string {
+	return l.sprint(number.Percent(fraction, number.MaxFractionDigits(0)))
+}
+
+// sprint formats a number with the localizer's printer, or in English
+func (l *Localizer) sprint(n number.Formatter) string {
+	if l == nil {
+		return message.NewPrinter(language.English).Sprint(n)
+	}
+	return l.printer.Sprint(n)
+}
+
+// dateNames are the layout elements FormatDate translates, longest first so
+// "January" isn't read as "Jan", with the message ID of their name in t
+var dateNames = []struct {
+	element string
+	id      func(t time.Time) string
+}{
+	{"January", func(t time.Time) string { return t.Month().String() }},
+	{"Monday", func(t time.Time) string { return t.Weekday().String() }},
+	{"Jan", func(t time.Time) string { return t.Month().String()[:3] + "." }},
+	{"Mon", func(t time.Time) string { return t.Weekday().String()[:3] + "." }},
+}
+
+// FormatDate formats t like time.Format, with the month and weekday names
+// translated. Their English names are the message IDs: "March" and "Monday",
+// and "Mar." and "Mon." for the abbreviations. Names missing from the
+// catalogs stay English. The layout itself usually comes from the catalog
+// too, since languages order dates differently.
+func (l *Localizer) FormatDate(t time.Time, layout string) string {
+	var b strings.Builder
+	for layout != "" {
+		// Format the text up to the next name element as it is, then the
+		// element translated
+		next, element := len(layout), -1
+		for i, d := range dateNames {
+			if at := strings.Index(layout, d.element); at >= 0 && at < next {
+				next, element = at, i
+			}
+		}
+		if next > 0 {
+			b.WriteString(t.Format(layout[:next]))
+		}
+		if element < 0 {
+			break
+		}
+		d := dateNames[element]
+		name, ok := l.lookup(d.id(t), nil, nil)
+		if !ok {
+			name = t.Format(d.element)
+		}
+		b.WriteString(name)
+		layout = layout[next+len(d.element):]
+	}
+	return b.String()
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying l
+func NewContext(ctx context.Context, l *Localizer) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the localizer stored in ctx, or nil
+func FromContext(ctx context.Context) *Localizer {
+	l, _ := ctx.Value(contextKey{}).(*Localizer)
+	return l
+}
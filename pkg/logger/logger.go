@@ -7,10 +7,16 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-func Init() {
+// Init sets up the global logger at level (debug, info, warn or error).
+// Unknown levels fall back to info.
+func Init(level string) {
 	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
 
-	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	parsed, err := zerolog.ParseLevel(level)
+	if err != nil || parsed == zerolog.NoLevel {
+		parsed = zerolog.InfoLevel
+	}
+	zerolog.SetGlobalLevel(parsed)
 }
 
 func Info() *zerolog.Event {
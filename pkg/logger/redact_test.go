@@ -0,0 +1,48 @@
+package logger
+
+import "testing"
+
+func TestRedactJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+		ok   bool
+	}{
+		{
+			name: "top level",
+			in:   `{"email":"a@b.c","password":"hunter2"}`,
+			want: `{"email":"a@b.c","password":"[REDACTED]"}`,
+			ok:   true,
+		},
+		{
+			name: "nested and case-insensitive",
+			in:   `{"data":{"Refresh_Token":"x","items":[{"clientSecret":1,"id":12345678901234567890}]}}`,
+			want: `{"data":{"Refresh_Token":"[REDACTED]","items":[{"clientSecret":"[REDACTED]","id":12345678901234567890}]}}`,
+			ok:   true,
+		},
+		{
+			name: "not an object",
+			in:   `[1,"token"]`,
+			want: `[1,"token"]`,
+			ok:   true,
+		},
+		{
+			name: "invalid",
+			in:   `{"password":"hun`,
+			ok:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := RedactJSON([]byte(tt.in))
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
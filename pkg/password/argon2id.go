@@ -0,0 +1,87 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+)
+
+type argon2id struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+}
+
+func newArgon2id(params Params) (*argon2id, error) {
+	if params.Argon2Memory < 8*uint32(params.Argon2Parallelism) || params.Argon2Iterations < 1 || params.Argon2Parallelism < 1 {
+		return nil, fmt.Errorf("password: invalid argon2id parameters m=%d,t=%d,p=%d",
+			params.Argon2Memory, params.Argon2Iterations, params.Argon2Parallelism)
+	}
+	return &argon2id{
+		memory:      params.Argon2Memory,
+		iterations:  params.Argon2Iterations,
+		parallelism: params.Argon2Parallelism,
+	}, nil
+}
+
+func (a *argon2id) hash(password string) (string, error) {
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("password: generate salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, a.iterations, a.memory, a.parallelism, argon2KeyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, a.memory, a.iterations, a.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// verify recomputes the hash with the parameters stored in encoded, not the
+// configured ones, so hashes made before a parameter change still verify
+func (a *argon2id) verify(password, encoded string) (bool, bool, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		return false, false, ErrMalformedHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return false, false, ErrMalformedHash
+	}
+	if version != argon2.Version {
+		return false, false, fmt.Errorf("password: unsupported argon2 version %d", version)
+	}
+
+	var stored argon2id
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &stored.memory, &stored.iterations, &stored.parallelism); err != nil {
+		return false, false, ErrMalformedHash
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, false, ErrMalformedHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return false, false, ErrMalformedHash
+	}
+
+	challenge := argon2.IDKey([]byte(password), salt, stored.iterations, stored.memory, stored.parallelism, uint32(len(key)))
+	if subtle.ConstantTimeCompare(key, challenge) != 1 {
+		return false, false, nil
+	}
+
+	outdated := stored.memory < a.memory || stored.iterations < a.iterations ||
+		stored.parallelism < a.parallelism || len(key) < argon2KeyLength
+	return true, outdated, nil
+}
@@ -0,0 +1,45 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type bcryptScheme struct {
+	cost int
+}
+
+func newBcrypt(params Params) (*bcryptScheme, error) {
+	if params.BcryptCost < bcrypt.MinCost || params.BcryptCost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("password: bcrypt cost %d outside %d-%d", params.BcryptCost, bcrypt.MinCost, bcrypt.MaxCost)
+	}
+	return &bcryptScheme{cost: params.BcryptCost}, nil
+}
+
+// hash fails for passwords longer than 72 bytes, which bcrypt can't represent
+func (b *bcryptScheme) hash(password string) (string, error) {
+	encoded, err := bcrypt.GenerateFromPassword([]byte(password), b.cost)
+	if err != nil {
+		return "", fmt.Errorf("password: bcrypt: %w", err)
+	}
+	return string(encoded), nil
+}
+
+// verify uses bcrypt's own comparison, which runs in constant time
+func (b *bcryptScheme) verify(password, encoded string) (bool, bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, ErrMalformedHash
+	}
+
+	cost, err := bcrypt.Cost([]byte(encoded))
+	if err != nil {
+		return false, false, ErrMalformedHash
+	}
+	return true, cost < b.cost, nil
+}
@@ -0,0 +1,128 @@
+// Package password hashes and verifies user passwords.
+//
+// Hashes carry their scheme and parameters in a versioned prefix, so several
+// schemes can be verified side by side:
+//
+//	$argon2id$v=19$m=65536,t=2,p=4$<salt>$<hash>   (PHC string format)
+//	$2b$12$<salt+hash>                              (bcrypt)
+//
+// New hashes always use the configured scheme and cost. Verify reports when
+// a stored hash was made with another scheme or weaker parameters, so callers
+// can rehash the password while they have it in plain text (at login).
+package password
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Schemes supported by Hasher
+const (
+	SchemeArgon2id = "argon2id"
+	SchemeBcrypt   = "bcrypt"
+)
+
+// ErrUnknownScheme is returned for hashes whose prefix matches no supported scheme
+var ErrUnknownScheme = errors.New("password: unknown hash scheme")
+
+// ErrMalformedHash is returned for hashes that can't be parsed
+var ErrMalformedHash = errors.New("password: malformed hash")
+
+// Params configures new hashes. Only the parameters of Scheme are used.
+type Params struct {
+	Scheme string
+
+	// BcryptCost is the bcrypt work factor (4-31)
+	BcryptCost int
+
+	// Argon2id memory (KiB), passes and lanes
+	Argon2Memory      uint32
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+}
+
+// DefaultParams returns the parameters recommended by OWASP for argon2id
+func DefaultParams() Params {
+	return Params{
+		Scheme:            SchemeArgon2id,
+		BcryptCost:        12,
+		Argon2Memory:      64 * 1024,
+		Argon2Iterations:  2,
+		Argon2Parallelism: 4,
+	}
+}
+
+// scheme is one hashing algorithm
+type scheme interface {
+	// hash returns the encoded hash of password
+	hash(password string) (string, error)
+	// verify reports whether password matches encoded, comparing in constant
+	// time, and whether encoded is weaker than this scheme's parameters
+	verify(password, encoded string) (match, outdated bool, err error)
+}
+
+// Hasher hashes passwords with the configured scheme and verifies hashes
+// made with any supported scheme
+type Hasher struct {
+	current string
+	schemes map[string]scheme
+}
+
+// New creates a Hasher that makes new hashes with params
+func New(params Params) (*Hasher, error) {
+	argon, err := newArgon2id(params)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := newBcrypt(params)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &Hasher{
+		current: params.Scheme,
+		schemes: map[string]scheme{
+			SchemeArgon2id: argon,
+			SchemeBcrypt:   bc,
+		},
+	}
+	if _, ok := h.schemes[params.Scheme]; !ok {
+		return nil, fmt.Errorf("password: unsupported scheme %q", params.Scheme)
+	}
+	return h, nil
+}
+
+// Hash returns the encoded hash of password using the configured scheme
+func (h *Hasher) Hash(password string) (string, error) {
+	return h.schemes[h.current].hash(password)
+}
+
+// Verify reports whether password matches encoded. needsRehash is true when
+// the password matched but encoded was made with another scheme or weaker
+// parameters than the configured ones.
+func (h *Hasher) Verify(password, encoded string) (match, needsRehash bool, err error) {
+	name := schemeOf(encoded)
+	s, ok := h.schemes[name]
+	if !ok {
+		return false, false, ErrUnknownScheme
+	}
+
+	match, outdated, err := s.verify(password, encoded)
+	if err != nil || !match {
+		return false, false, err
+	}
+	return true, outdated || name != h.current, nil
+}
+
+// schemeOf identifies the scheme of an encoded hash from its prefix
+func schemeOf(encoded string) string {
+	switch {
+	case strings.HasPrefix(encoded, "$argon2id$"):
+		return SchemeArgon2id
+	case strings.HasPrefix(encoded, "$2a$"), strings.HasPrefix(encoded, "$2b$"), strings.HasPrefix(encoded, "$2y$"):
+		return SchemeBcrypt
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,105 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testParams keeps the work factors low so the tests run fast
+func testParams(scheme string) Params {
+	return Params{
+		Scheme:            scheme,
+		BcryptCost:        5,
+		Argon2Memory:      1024,
+		Argon2Iterations:  1,
+		Argon2Parallelism: 1,
+	}
+}
+
+func newTestHasher(t *testing.T, params Params) *Hasher {
+	t.Helper()
+	h, err := New(params)
+	require.NoError(t, err)
+	return h
+}
+
+func TestHasher_RoundTrip(t *testing.T) {
+	for _, scheme := range []string{SchemeArgon2id, SchemeBcrypt} {
+		t.Run(scheme, func(t *testing.T) {
+			h := newTestHasher(t, testParams(scheme))
+
+			encoded, err := h.Hash("correct horse")
+			require.NoError(t, err)
+
+			match, needsRehash, err := h.Verify("correct horse", encoded)
+			require.NoError(t, err)
+			assert.True(t, match)
+			assert.False(t, needsRehash)
+
+			match, _, err = h.Verify("wrong horse", encoded)
+			require.NoError(t, err)
+			assert.False(t, match)
+		})
+	}
+}
+
+func TestHasher_NeedsRehashOnSchemeChange(t *testing.T) {
+	encoded, err := newTestHasher(t, testParams(SchemeBcrypt)).Hash("correct horse")
+	require.NoError(t, err)
+
+	match, needsRehash, err := newTestHasher(t, testParams(SchemeArgon2id)).Verify("correct horse", encoded)
+	require.NoError(t, err)
+	assert.True(t, match, "hashes of other schemes still verify")
+	assert.True(t, needsRehash)
+}
+
+func TestHasher_NeedsRehashOnStrongerParams(t *testing.T) {
+	params := testParams(SchemeArgon2id)
+	encoded, err := newTestHasher(t, params).Hash("correct horse")
+	require.NoError(t, err)
+
+	params.Argon2Iterations = 2
+	match, needsRehash, err := newTestHasher(t, params).Verify("correct horse", encoded)
+	require.NoError(t, err)
+	assert.True(t, match, "verification uses the parameters stored in the hash")
+	assert.True(t, needsRehash)
+
+	// A wrong password never asks for a rehash
+	_, needsRehash, err = newTestHasher(t, params).Verify("wrong horse", encoded)
+	require.NoError(t, err)
+	assert.False(t, needsRehash)
+}
+
+func TestHasher_RejectsUnknownAndMalformedHashes(t *testing.T) {
+	h := newTestHasher(t, testParams(SchemeArgon2id))
+
+	_, _, err := h.Verify("pw", "plaintext")
+	assert.ErrorIs(t, err, ErrUnknownScheme)
+
+	_, _, err = h.Verify("pw", "$argon2id$v=19$m=1024$bad")
+	assert.ErrorIs(t, err, ErrMalformedHash)
+}
+
+func TestNew_ValidatesParams(t *testing.T) {
+	params := testParams("md5")
+	_, err := New(params)
+	assert.Error(t, err)
+
+	params = testParams(SchemeBcrypt)
+	params.BcryptCost = 40
+	_, err = New(params)
+	assert.Error(t, err)
+}
+
+func TestHash_Prefixes(t *testing.T) {
+	argon, err := newTestHasher(t, testParams(SchemeArgon2id)).Hash("pw")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(argon, "$argon2id$v=19$m=1024,t=1,p=1$"))
+
+	bc, err := newTestHasher(t, testParams(SchemeBcrypt)).Hash("pw")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(bc, "$2a$05$"))
+}
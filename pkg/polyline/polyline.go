@@ -0,0 +1,154 @@
+// Package polyline encodes tracks in Google's encoded polyline format and
+// simplifies them with the Douglas-Peucker algorithm.
+package polyline
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+// ErrInvalid is returned by Decode for a string that isn't an encoded polyline
+var ErrInvalid = errors.New("polyline: invalid encoding")
+
+// Point is a position in degrees
+type Point struct {
+	Lat float64
+	Lng float64
+}
+
+// precision is the number of decimal places kept (1e5 ≈ 1 m), the one map
+// libraries expect by default
+const precision = 1e5
+
+// Encode returns points in encoded polyline format
+func Encode(points []Point) string {
+	var b strings.Builder
+	var prevLat, prevLng int64
+	for _, p := range points {
+		lat := int64(math.Round(p.Lat * precision))
+		lng := int64(math.Round(p.Lng * precision))
+		encodeValue(&b, lat-prevLat)
+		encodeValue(&b, lng-prevLng)
+		prevLat, prevLng = lat, lng
+	}
+	return b.String()
+}
+
+func encodeValue(b *strings.Builder, v int64) {
+	u := uint64(v) << 1
+	if v < 0 {
+		u = ^u
+	}
+	for u >= 0x20 {
+		b.WriteByte(byte((0x20 | (u & 0x1f)) + 63))
+		u >>= 5
+	}
+	b.WriteByte(byte(u + 63))
+}
+
+// Decode returns the points of an encoded polyline
+func Decode(s string) ([]Point, error) {
+	var points []Point
+	var lat, lng int64
+	for i := 0; i < len(s); {
+		dLat, n, err := decodeValue(s[i:])
+		if err != nil {
+			return nil, err
+		}
+		i += n
+		dLng, n, err := decodeValue(s[i:])
+		if err != nil {
+			return nil, err
+		}
+		i += n
+
+		lat += dLat
+		lng += dLng
+		points = append(points, Point{Lat: float64(lat) / precision, Lng: float64(lng) / precision})
+	}
+	return points, nil
+}
+
+func decodeValue(s string) (int64, int, error) {
+	var u uint64
+	for i, shift := 0, uint(0); i < len(s) && shift < 64; i, shift = i+1, shift+5 {
+		c := s[i]
+		if c < 63 || c > 126 {
+			return 0, 0, ErrInvalid
+		}
+		chunk := uint64(c - 63)
+		u |= (chunk & 0x1f) << shift
+		if chunk < 0x20 {
+			v := int64(u >> 1)
+			if u&1 != 0 {
+				v = ^v
+			}
+			return v, i + 1, nil
+		}
+	}
+	return 0, 0, ErrInvalid
+}
+
+// Simplify returns the points of the track that the Douglas-Peucker
+// algorithm keeps with a tolerance of toleranceM metres: no point dropped
+// lies further than that from the simplified line. The first and last points
+// are always kept; a tolerance of 0 or less keeps every point.
+func Simplify(points []Point, toleranceM float64) []Point {
+	if toleranceM <= 0 || len(points) < 3 {
+		return points
+	}
+
+	// Project onto a plane in metres around the first point. Tracks span a
+	// few km, so the distortion is far below any useful tolerance.
+	const metresPerDegree = 6371000 * math.Pi / 180
+	lngScale := math.Cos(points[0].Lat * math.Pi / 180)
+	xy := make([][2]float64, len(points))
+	for i, p := range points {
+		xy[i] = [2]float64{
+			(p.Lng - points[0].Lng) * metresPerDegree * lngScale,
+			(p.Lat - points[0].Lat) * metresPerDegree,
+		}
+	}
+
+	keep := make([]bool, len(points))
+	keep[0], keep[len(points)-1] = true, true
+
+	// Iterative rather than recursive, so long tracks can't exhaust the stack
+	stack := [][2]int{{0, len(points) - 1}}
+	for len(stack) > 0 {
+		first, last := stack[len(stack)-1][0], stack[len(stack)-1][1]
+		stack = stack[:len(stack)-1]
+
+		farthest, maxDist := -1, toleranceM
+		for i := first + 1; i < last; i++ {
+			if d := segmentDistance(xy[i], xy[first], xy[last]); d > maxDist {
+				farthest, maxDist = i, d
+			}
+		}
+		if farthest < 0 {
+			continue
+		}
+		keep[farthest] = true
+		stack = append(stack, [2]int{first, farthest}, [2]int{farthest, last})
+	}
+
+	simplified := make([]Point, 0, len(points))
+	for i, p := range points {
+		if keep[i] {
+			simplified = append(simplified, p)
+		}
+	}
+	return simplified
+}
+
+// segmentDistance returns the distance from p to the segment a-b
+func segmentDistance(p, a, b [2]float64) float64 {
+	dx, dy := b[0]-a[0], b[1]-a[1]
+	if dx == 0 && dy == 0 {
+		return math.Hypot(p[0]-a[0], p[1]-a[1])
+	}
+	t := ((p[0]-a[0])*dx + (p[1]-a[1])*dy) / (dx*dx + dy*dy)
+	t = math.Max(0, math.Min(1, t))
+	return math.Hypot(p[0]-(a[0]+t*dx), p[1]-(a[1]+t*dy))
+}
@@ -0,0 +1,53 @@
+package polyline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The example from Google's polyline format documentation
+var googleExample = []Point{{38.5, -120.2}, {40.7, -120.95}, {43.252, -126.453}}
+
+const googleEncoded = "_p~iF~ps|U_ulLnnqC_mqNvxq`@"
+
+func TestEncode(t *testing.T) {
+	assert.Equal(t, googleEncoded, Encode(googleExample))
+	assert.Equal(t, "", Encode(nil))
+}
+
+func TestDecode(t *testing.T) {
+	points, err := Decode(googleEncoded)
+	require.NoError(t, err)
+	require.Len(t, points, 3)
+	for i, p := range points {
+		assert.InDelta(t, googleExample[i].Lat, p.Lat, 1e-9)
+		assert.InDelta(t, googleExample[i].Lng, p.Lng, 1e-9)
+	}
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	_, err := Decode("_p~iF~ps|U_ulL") // a latitude without its longitude
+	assert.ErrorIs(t, err, ErrInvalid)
+
+	_, err = Decode("_p~iF ~ps|U")
+	assert.ErrorIs(t, err, ErrInvalid)
+}
+
+func TestSimplify(t *testing.T) {
+	// ~0.00001° of latitude is ~1.1 m: a straight line north with a 5 m
+	// wobble in the middle and a 200 m detour east at the end
+	points := []Point{
+		{Lat: 51.5000, Lng: 0},
+		{Lat: 51.5010, Lng: 0.00007}, // ~5 m east
+		{Lat: 51.5020, Lng: 0},
+		{Lat: 51.5030, Lng: 0.0029}, // ~200 m east
+		{Lat: 51.5040, Lng: 0},
+	}
+
+	assert.Equal(t, []Point{points[0], points[2], points[3], points[4]}, Simplify(points, 10))
+	assert.Equal(t, []Point{points[0], points[4]}, Simplify(points, 500))
+	assert.Equal(t, points, Simplify(points, 0))
+	assert.Equal(t, points[:2], Simplify(points[:2], 10))
+}
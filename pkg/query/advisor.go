@@ -0,0 +1,335 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxAdvisorShapes caps the distinct query shapes an IndexAdvisor keeps
+const maxAdvisorShapes = 1000
+
+// QueryShape is what an index can do for a list query: the base table
+// columns it filters by equality (eq, IN, IS NULL), the ones it filters by
+// range (gt, gte, lt, lte) and its sort. Array, geo, search and ne filters,
+// and columns of joined tables, are left out.
+type QueryShape struct {
+	Table    string   `json:"table"`
+	Equality []string `json:"equality,omitempty"`
+	Range    []string `json:"range,omitempty"`
+	Order    Order    `json:"order,omitempty"`
+}
+
+// ShapeOf returns the QueryShape of a list query on table
+func ShapeOf(table string, opts *QueryOptions) QueryShape {
+	shape := QueryShape{Table: table}
+	equality := map[string]bool{}
+	ranged := map[string]bool{}
+
+	for column := range opts.Filter {
+		if indexableColumn(column) {
+			equality[column] = true
+		}
+	}
+	for _, condition := range opts.FilterConditions {
+		if !indexableColumn(condition.Column) {
+			continue
+		}
+		switch condition.Operator {
+		case "eq", "":
+			equality[condition.Column] = true
+		case "gt", "gte", "lt", "lte":
+			ranged[condition.Column] = true
+		}
+	}
+	for _, by := range opts.Order {
+		if !indexableColumn(by.Column) {
+			break
+		}
+		shape.Order = append(shape.Order, OrderBy{Column: by.Column, Direction: strings.ToUpper(by.Direction)})
+	}
+
+	for column := range equality {
+		shape.Equality = append(shape.Equality, column)
+		delete(ranged, column)
+	}
+	for column := range ranged {
+		shape.Range = append(shape.Range, column)
+	}
+	sort.Strings(shape.Equality)
+	sort.Strings(shape.Range)
+	return shape
+}
+
+// indexableColumn reports whether column is a plain column of the base
+// table, rather than a joined column or a JSON path expression
+func indexableColumn(column string) bool {
+	return !strings.Contains(column, ".") && ValidateColumnName(column) == nil
+}
+
+// key identifies the shape among the observed ones
+func (s QueryShape) key() string {
+	order := make([]string, len(s.Order))
+	for i, by := range s.Order {
+		order[i] = by.Column + " " + by.Direction
+	}
+	return fmt.Sprintf("%s|%s|%s|%s", s.Table,
+		strings.Join(s.Equality, ","), strings.Join(s.Range, ","), strings.Join(order, ","))
+}
+
+// IndexColumns returns the columns of the B-tree index that serves the
+// shape best: the equality columns, then the sort columns with their
+// direction, or, without a sort, the first range column. Nil when the shape
+// has nothing an index can use.
+func (s QueryShape) IndexColumns() []string {
+	columns := append([]string{}, s.Equality...)
+	if len(s.Order) > 0 {
+		for _, by := range s.Order {
+			if by.Direction == "DESC" {
+				columns = append(columns, by.Column+" DESC")
+			} else {
+				columns = append(columns, by.Column)
+			}
+		}
+	} else if len(s.Range) > 0 {
+		columns = append(columns, s.Range[0])
+	}
+	return columns
+}
+
+// ExistingIndex is an index already on a table, as listed by pg_indexes
+type ExistingIndex struct {
+	Table      string `json:"table"`
+	Name       string `json:"name"`
+	Definition string `json:"definition"`
+}
+
+// indexColumnsPattern captures the column list of a B-tree index definition
+var indexColumnsPattern = regexp.MustCompile(`USING btree \(([^)]*)\)`)
+
+// Columns returns the column names of a B-tree index, in order and without
+// their direction. Nil for other index types and for expression indexes,
+// which the advisor doesn't compare against.
+func (i ExistingIndex) Columns() []string {
+	match := indexColumnsPattern.FindStringSubmatch(i.Definition)
+	if match == nil {
+		return nil
+	}
+	var columns []string
+	for _, part := range strings.Split(match[1], ",") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 || !indexableColumn(strings.Trim(fields[0], `"`)) {
+			return nil
+		}
+		columns = append(columns, strings.Trim(fields[0], `"`))
+	}
+	return columns
+}
+
+// covers reports whether the index starts with columns, in any order for
+// the leading equality columns and then in order. Directions are ignored: a
+// B-tree can be scanned backwards, which serves single-column sorts.
+func (i ExistingIndex) covers(shape QueryShape, columns []string) bool {
+	existing := i.Columns()
+	if len(existing) < len(columns) {
+		return false
+	}
+	equality := map[string]bool{}
+	for _, column := range existing[:len(shape.Equality)] {
+		equality[column] = true
+	}
+	for _, column := range shape.Equality {
+		if !equality[column] {
+			return false
+		}
+	}
+	for n := len(shape.Equality); n < len(columns); n++ {
+		if existing[n] != strings.TrimSuffix(columns[n], " DESC") {
+			return false
+		}
+	}
+	return true
+}
+
+// IndexSuggestion is an index the advisor recommends for a query shape
+type IndexSuggestion struct {
+	Table   string     `json:"table"`
+	Columns []string   `json:"columns"`
+	SQL     string     `json:"sql"`
+	Shape   QueryShape `json:"shape"`
+
+	// Queries is how many list queries of the shape were observed; the slow
+	// ones are those the SlowQueryLog recorded, taking SlowQueryTime in total
+	// (nanoseconds)
+	Queries       int           `json:"queries"`
+	SlowQueries   int           `json:"slowQueries"`
+	SlowQueryTime time.Duration `json:"slowQueryTime" swaggertype:"integer"`
+}
+
+// SlowStatement is how often a statement was slow and for how long in
+// total, e.g. from database.SlowQueryLog
+type SlowStatement struct {
+	Count int
+	Total time.Duration
+}
+
+// IndexAdvisor collects the shapes of the list queries an application runs
+// and suggests composite indexes for those no existing index serves. It is
+// meant for development: enable it with WithIndexAdvisor and read the
+// Report after exercising the API.
+type IndexAdvisor struct {
+	mu     sync.Mutex
+	shapes map[string]*observedShape
+}
+
+// observedShape is a shape with the statements it was rendered to
+type observedShape struct {
+	shape      QueryShape
+	count      int
+	statements map[string]bool
+}
+
+// NewIndexAdvisor creates an empty IndexAdvisor
+func NewIndexAdvisor() *IndexAdvisor {
+	return &IndexAdvisor{shapes: make(map[string]*observedShape)}
+}
+
+// Observe records a list query on table that ran as statement (its SQL)
+func (a *IndexAdvisor) Observe(table, statement string, opts *QueryOptions) {
+	shape := ShapeOf(table, opts)
+	key := shape.key()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	observed, ok := a.shapes[key]
+	if !ok {
+		if len(a.shapes) >= maxAdvisorShapes {
+			return
+		}
+		observed = &observedShape{shape: shape, statements: map[string]bool{}}
+		a.shapes[key] = observed
+	}
+	observed.count++
+	if len(observed.statements) < 20 {
+		observed.statements[normalizeStatement(statement)] = true
+	}
+}
+
+// Tables returns the tables of the observed queries, sorted
+func (a *IndexAdvisor) Tables() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	seen := map[string]bool{}
+	var tables []string
+	for _, observed := range a.shapes {
+		if !seen[observed.shape.Table] {
+			seen[observed.shape.Table] = true
+			tables = append(tables, observed.shape.Table)
+		}
+	}
+	sort.Strings(tables)
+	return tables
+}
+
+// Report suggests an index for every observed shape that none of existing
+// serves, the most slow query time first, then the most queries. slow maps
+// normalized statements to their slow runs and may be nil.
+func (a *IndexAdvisor) Report(existing []ExistingIndex, slow map[string]SlowStatement) []IndexSuggestion {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	suggested := map[string]*IndexSuggestion{}
+	var order []string
+	for _, observed := range a.shapes {
+		columns := observed.shape.IndexColumns()
+		if len(columns) == 0 || coveredBy(existing, observed.shape, columns) {
+			continue
+		}
+
+		key := observed.shape.Table + "(" + strings.Join(columns, ", ") + ")"
+		suggestion, ok := suggested[key]
+		if !ok {
+			suggestion = &IndexSuggestion{
+				Table:   observed.shape.Table,
+				Columns: columns,
+				SQL:     createIndexSQL(observed.shape.Table, columns),
+				Shape:   observed.shape,
+			}
+			suggested[key] = suggestion
+			order = append(order, key)
+		}
+		suggestion.Queries += observed.count
+		for statement := range observed.statements {
+			suggestion.SlowQueries += slow[statement].Count
+			suggestion.SlowQueryTime += slow[statement].Total
+		}
+	}
+
+	suggestions := make([]IndexSuggestion, 0, len(order))
+	for _, key := range order {
+		suggestions = append(suggestions, *suggested[key])
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		x, y := suggestions[i], suggestions[j]
+		if x.SlowQueryTime != y.SlowQueryTime {
+			return x.SlowQueryTime > y.SlowQueryTime
+		}
+		if x.Queries != y.Queries {
+			return x.Queries > y.Queries
+		}
+		return x.SQL < y.SQL
+	})
+	return suggestions
+}
+
+// Reset forgets every observed query
+func (a *IndexAdvisor) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.shapes = make(map[string]*observedShape)
+}
+
+// coveredBy reports whether one of the indexes on the shape's table serves it
+func coveredBy(existing []ExistingIndex, shape QueryShape, columns []string) bool {
+	for _, index := range existing {
+		if index.Table == shape.Table && index.covers(shape, columns) {
+			return true
+		}
+	}
+	return false
+}
+
+// createIndexSQL returns the statement creating an index on columns of table
+func createIndexSQL(table string, columns []string) string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = strings.TrimSuffix(column, " DESC")
+	}
+	return fmt.Sprintf("CREATE INDEX CONCURRENTLY idx_%s_%s ON %s (%s);",
+		table, strings.Join(names, "_"), table, strings.Join(columns, ", "))
+}
+
+// normalizeStatement collapses whitespace the way database.NormalizeSQL
+// does, so statements match the SlowQueryLog's
+func normalizeStatement(statement string) string {
+	return strings.Join(strings.Fields(statement), " ")
+}
+
+// indexAdvisorKey is the context key of the IndexAdvisor
+type indexAdvisorKey struct{}
+
+// WithIndexAdvisor returns a context whose list queries are recorded by advisor
+func WithIndexAdvisor(ctx context.Context, advisor *IndexAdvisor) context.Context {
+	return context.WithValue(ctx, indexAdvisorKey{}, advisor)
+}
+
+// ObserveQuery records a list query with the IndexAdvisor of ctx, if any
+func ObserveQuery(ctx context.Context, table, statement string, opts *QueryOptions) {
+	if advisor, ok := ctx.Value(indexAdvisorKey{}).(*IndexAdvisor); ok {
+		advisor.Observe(table, statement, opts)
+	}
+}
@@ -0,0 +1,79 @@
+package query
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShapeOf(t *testing.T) {
+	opts := &QueryOptions{
+		Filter: map[string]interface{}{"user_id": 1, "tags.name": "cardio"},
+		FilterConditions: []FilterCondition{
+			{Column: "activity_type", Operator: "eq", Value: "running"},
+			{Column: "activity_date", Operator: "gte", Value: "2024-01-01"},
+			{Column: "distance_km", Operator: "ne", Value: 5},
+			{Column: "tags", Operator: "contains", Value: []interface{}{"cardio"}},
+			{Column: "metadata->>'shoe'", Operator: "eq", Value: "pegasus"},
+		},
+		Order: Order{{Column: "activity_date", Direction: "desc"}},
+	}
+
+	shape := ShapeOf("activities", opts)
+	assert.Equal(t, []string{"activity_type", "user_id"}, shape.Equality)
+	assert.Equal(t, []string{"activity_date"}, shape.Range)
+	assert.Equal(t, []string{"activity_type", "user_id", "activity_date DESC"}, shape.IndexColumns())
+}
+
+func TestExistingIndex_Columns(t *testing.T) {
+	index := ExistingIndex{Definition: "CREATE INDEX idx_activities_user_date ON public.activities USING btree (user_id, activity_date DESC)"}
+	assert.Equal(t, []string{"user_id", "activity_date"}, index.Columns())
+
+	gin := ExistingIndex{Definition: "CREATE INDEX idx_activities_tags ON public.activities USING gin (tags)"}
+	assert.Nil(t, gin.Columns())
+	expression := ExistingIndex{Definition: "CREATE INDEX idx_users_email ON public.users USING btree (lower((email)::text))"}
+	assert.Nil(t, expression.Columns())
+}
+
+func TestIndexAdvisor_Report(t *testing.T) {
+	advisor := NewIndexAdvisor()
+	ctx := WithIndexAdvisor(context.Background(), advisor)
+
+	byDate := &QueryOptions{
+		Filter: map[string]interface{}{"user_id": 1},
+		Order:  Order{{Column: "activity_date", Direction: "DESC"}},
+	}
+	byType := &QueryOptions{
+		Filter: map[string]interface{}{"user_id": 1, "activity_type": "running"},
+		Order:  Order{{Column: "created_at", Direction: "DESC"}},
+	}
+	for i := 0; i < 3; i++ {
+		ObserveQuery(ctx, "activities", "SELECT * FROM activities WHERE user_id = $1 ORDER BY activity_date DESC", byDate)
+	}
+	ObserveQuery(ctx, "activities", "SELECT * FROM activities\n WHERE activity_type = $1 AND user_id = $2 ORDER BY created_at DESC", byType)
+	ObserveQuery(context.Background(), "activities", "SELECT 1", byType)
+	assert.Equal(t, []string{"activities"}, advisor.Tables())
+
+	slow := map[string]SlowStatement{
+		"SELECT * FROM activities WHERE activity_type = $1 AND user_id = $2 ORDER BY created_at DESC": {Count: 1, Total: time.Second},
+	}
+
+	report := advisor.Report(nil, slow)
+	require.Len(t, report, 2)
+	assert.Equal(t, "CREATE INDEX CONCURRENTLY idx_activities_activity_type_user_id_created_at ON activities (activity_type, user_id, created_at DESC);", report[0].SQL)
+	assert.Equal(t, 1, report[0].SlowQueries, "slow queries come first")
+	assert.Equal(t, []string{"user_id", "activity_date DESC"}, report[1].Columns)
+	assert.Equal(t, 3, report[1].Queries)
+
+	existing := []ExistingIndex{
+		{Table: "activities", Definition: "CREATE INDEX idx_activities_user_date ON public.activities USING btree (user_id, activity_date)"},
+		{Table: "activities", Definition: "CREATE INDEX idx_x ON public.activities USING btree (user_id, activity_type, created_at)"},
+	}
+	assert.Empty(t, advisor.Report(existing, slow), "equality columns may come in any order")
+
+	advisor.Reset()
+	assert.Empty(t, advisor.Report(nil, nil))
+}
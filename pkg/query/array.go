@@ -0,0 +1,66 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ArrayCondition is the predicate of an array operator on an array column
+// (e.g., a denormalized tags text[]):
+//   - "contains" : column @> ARRAY[values], every value is in the array
+//   - "overlaps" : column && ARRAY[values], at least one value is
+//   - "any"      : value = ANY(column), the single value is
+//
+// contains and overlaps can use a GIN index on the column.
+//
+// Example: filter[tags][contains]=[cardio,outdoor] → tags @> ARRAY[$1,$2]
+type ArrayCondition struct {
+	Column   string
+	Operator string
+	Values   []interface{}
+}
+
+// ToSql implements squirrel.Sqlizer
+func (a ArrayCondition) ToSql() (string, []interface{}, error) {
+	if err := ValidateColumnName(a.Column); err != nil {
+		return "", nil, err
+	}
+
+	if a.Operator == "any" {
+		if len(a.Values) != 1 {
+			return "", nil, fmt.Errorf("operator 'any' on '%s' takes one value", a.Column)
+		}
+		return fmt.Sprintf("? = ANY(%s)", a.Column), a.Values, nil
+	}
+
+	var op string
+	switch a.Operator {
+	case "contains":
+		op = "@>"
+	case "overlaps":
+		op = "&&"
+	default:
+		return "", nil, fmt.Errorf("unknown array operator '%s'", a.Operator)
+	}
+
+	// An empty ARRAY[] has no type; '{}' takes the column's
+	if len(a.Values) == 0 {
+		return fmt.Sprintf("%s %s '{}'", a.Column, op), nil, nil
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(a.Values)), ",")
+	return fmt.Sprintf("%s %s ARRAY[%s]", a.Column, op, placeholders), a.Values, nil
+}
+
+// arrayCondition returns the predicate for an array operator condition. A
+// single value is treated as a list of one.
+func arrayCondition(condition FilterCondition) ArrayCondition {
+	values, ok := normalizeFilterValue(condition.Value).([]interface{})
+	if !ok {
+		values = []interface{}{condition.Value}
+	}
+	return ArrayCondition{
+		Column:   resolveColumnForSQL(condition.Column),
+		Operator: condition.Operator,
+		Values:   values,
+	}
+}
@@ -0,0 +1,42 @@
+package query
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArrayCondition(t *testing.T) {
+	tests := []struct {
+		params   url.Values
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{url.Values{"filter[tags][contains]": {"[cardio,outdoor]"}}, "tags @> ARRAY[$1,$2]", []interface{}{"cardio", "outdoor"}},
+		{url.Values{"filter[tags][overlaps]": {"[cardio,yoga]"}}, "tags && ARRAY[$1,$2]", []interface{}{"cardio", "yoga"}},
+		{url.Values{"filter[tags][contains]": {"cardio"}}, "tags @> ARRAY[$1]", []interface{}{"cardio"}},
+		{url.Values{"filter[tags][any]": {"cardio"}}, "$1 = ANY(tags)", []interface{}{"cardio"}},
+		{url.Values{"filter[tags][contains]": {"[]"}}, "tags @> '{}'", nil},
+	}
+
+	for _, tt := range tests {
+		opts, err := ParseQueryParams(tt.params)
+		require.NoError(t, err)
+		require.NoError(t, ValidateFilterConditions(opts, []string{"tags"}, OperatorWhitelist{"tags": ArrayOperators()}))
+
+		sql, args, err := NewQueryBuilder("activities", opts).ApplyFilterConditions().Build()
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT activities.* FROM activities WHERE "+tt.wantSQL, sql)
+		assert.Equal(t, tt.wantArgs, args)
+
+		sql, _, err = NewQueryBuilder("activities", opts).BuildCount()
+		require.NoError(t, err)
+		assert.Contains(t, sql, tt.wantSQL)
+	}
+
+	opts, _ := ParseQueryParams(url.Values{"filter[tags][any]": {"[cardio,yoga]"}})
+	_, _, err := NewQueryBuilder("activities", opts).ApplyFilterConditions().Build()
+	assert.ErrorContains(t, err, "takes one value")
+}
@@ -21,10 +21,11 @@ import (
 //	    ApplyPagination().
 //	    Build()
 type QueryBuilder struct {
-	baseQuery sq.SelectBuilder
-	options   *QueryOptions
-	tableName string
-	joins     []JoinConfig
+	baseQuery  sq.SelectBuilder
+	options    *QueryOptions
+	tableName  string
+	primaryKey string
+	joins      []JoinConfig
 }
 
 // resolveColumnForSQL translates a multi-level dot-notation path to a valid SQL column.
@@ -58,13 +59,37 @@ func NewQueryBuilder(tableName string, opts *QueryOptions) *QueryBuilder {
 	// This prevents issues when JOINs are added later
 	selectExpr := fmt.Sprintf("%s.*", tableName)
 	return &QueryBuilder{
-		baseQuery: sq.Select(selectExpr).From(tableName),
-		options:   opts,
-		tableName: tableName,
-		joins:     []JoinConfig{},
+		baseQuery:  sq.Select(selectExpr).From(tableName),
+		options:    opts,
+		tableName:  tableName,
+		primaryKey: DefaultPrimaryKey,
+		joins:      []JoinConfig{},
 	}
 }
 
+// WithPrimaryKey sets the unique column ApplyOrder sorts by last, for
+// tables whose primary key isn't "id"
+func (qb *QueryBuilder) WithPrimaryKey(column string) *QueryBuilder {
+	qb.primaryKey = column
+	return qb
+}
+
+// WithHints sets hints on the query's options, which the repository applies
+// when it runs the query (see Hints)
+//
+// Example:
+//
+//	builder.WithHints(WithStatementTimeout(2 * time.Second))
+func (qb *QueryBuilder) WithHints(opts ...HintOption) *QueryBuilder {
+	qb.options.Hints.Apply(opts...)
+	return qb
+}
+
+// Hints returns the hints the query runs with
+func (qb *QueryBuilder) Hints() Hints {
+	return qb.options.Hints
+}
+
 // WithJoins adds JOIN clauses to the query for relationship filtering.
 // This must be called before ApplyFilters if you want to filter on joined columns.
 //
@@ -84,7 +109,9 @@ func (qb *QueryBuilder) WithJoins(joins []JoinConfig) *QueryBuilder {
 }
 
 // ApplyFilterConditions applies WHERE conditions with operator support.
-// Handles comparison operators: eq, ne, gt, gte, lt, lte.
+// Handles comparison operators: eq, ne, gt, gte, lt, lte, plus within for
+// bounding boxes resolved by ResolveGeoFilters and contains, overlaps and any
+// for array columns (see ArrayCondition).
 // This is the NEW method (v1.1.0+) that enables date ranges and numeric comparisons.
 //
 // Examples:
@@ -118,6 +145,10 @@ func (qb *QueryBuilder) ApplyFilterConditions() *QueryBuilder {
 			qb.baseQuery = qb.baseQuery.Where(sq.Lt{column: value})
 		case "lte":
 			qb.baseQuery = qb.baseQuery.Where(sq.LtOrEq{column: value})
+		case "within":
+			qb.baseQuery = qb.baseQuery.Where(withinCondition(condition))
+		case "contains", "overlaps", "any":
+			qb.baseQuery = qb.baseQuery.Where(arrayCondition(condition))
 		default:
 			// Unknown operator - skip (validation should catch this earlier)
 			continue
@@ -131,13 +162,16 @@ func (qb *QueryBuilder) ApplyFilterConditions() *QueryBuilder {
 // For new code, FilterConditions with ApplyFilterConditions() is preferred.
 //
 // Handles single values, arrays (IN clause), and proper type conversion.
+// Columns are applied in sorted order (as in ApplyFiltersOr and ApplySearch),
+// so the same options always render the same SQL and arguments.
 //
 // Examples:
 //   - {"status": "active"} → WHERE status = $1
 //   - {"type": []string{"running", "cycling"}} → WHERE type IN ($1, $2)
 //   - {"user_id": 123, "status": "active"} → WHERE user_id = $1 AND status = $2
 func (qb *QueryBuilder) ApplyFilters() *QueryBuilder {
-	for rawColumn, value := range qb.options.Filter {
+	for _, rawColumn := range sortedKeys(qb.options.Filter) {
+		value := qb.options.Filter[rawColumn]
 		column := resolveColumnForSQL(rawColumn)
 		switch v := value.(type) {
 		case []interface{}:
@@ -176,7 +210,8 @@ func (qb *QueryBuilder) ApplyFiltersOr() *QueryBuilder {
 	}
 
 	orConditions := sq.Or{}
-	for rawColumn, value := range qb.options.FilterOr {
+	for _, rawColumn := range sortedKeys(qb.options.FilterOr) {
+		value := qb.options.FilterOr[rawColumn]
 		column := resolveColumnForSQL(rawColumn)
 		switch v := value.(type) {
 		case []interface{}:
@@ -213,7 +248,8 @@ func (qb *QueryBuilder) ApplySearch() *QueryBuilder {
 	}
 
 	searchConditions := sq.Or{}
-	for rawColumn, value := range qb.options.Search {
+	for _, rawColumn := range sortedKeys(qb.options.Search) {
+		value := qb.options.Search[rawColumn]
 		column := resolveColumnForSQL(rawColumn)
 		pattern := fmt.Sprintf("%%%v%%", value)
 		// Use ILike for PostgreSQL case-insensitive search
@@ -225,32 +261,26 @@ func (qb *QueryBuilder) ApplySearch() *QueryBuilder {
 }
 
 // ApplyOrder applies ORDER BY clause for sorting.
-// Multiple order columns are applied in the order specified.
+// Multiple order columns are applied in the order specified, and the primary
+// key is always appended as a tiebreaker: without it, rows sharing a
+// created_at can be skipped or repeated between pages.
 //
 // Examples:
-//   - {"created_at": "DESC"} → ORDER BY created_at DESC
-//   - {"amount": "ASC", "created_at": "DESC"} → ORDER BY amount ASC, created_at DESC
+//   - [created_at DESC] → ORDER BY created_at DESC, id DESC
+//   - [amount ASC, created_at DESC] → ORDER BY amount ASC, created_at DESC, id DESC
+//   - [id ASC] → ORDER BY id ASC
 //
-// If no order is specified, defaults to "created_at DESC".
+// If no order is specified, defaults to "created_at DESC, id DESC".
 func (qb *QueryBuilder) ApplyOrder() *QueryBuilder {
-	if len(qb.options.Order) == 0 {
-		// Default order - qualify with table name if there are JOINs
-		defaultColumn := "created_at"
-		if len(qb.joins) > 0 && !strings.Contains(defaultColumn, ".") {
-			defaultColumn = fmt.Sprintf("%s.%s", qb.tableName, defaultColumn)
-		}
-		qb.baseQuery = qb.baseQuery.OrderBy(fmt.Sprintf("%s DESC", defaultColumn))
-		return qb
+	order := qb.options.Order
+	if len(order) == 0 {
+		order = Order{{Column: "created_at", Direction: "DESC"}}
 	}
 
-	// Apply each order clause
-	// Note: map iteration order is not guaranteed in Go, but for sorting
-	// this usually doesn't matter. For strict ordering, consider using
-	// a slice of structs instead.
-	for rawColumn, direction := range qb.options.Order {
-		column := resolveColumnForSQL(rawColumn)
+	for _, by := range order.withTiebreaker(qb.primaryKey) {
+		column := resolveColumnForSQL(by.Column)
 		// Validate direction (should be done in validator, but double-check here)
-		upperDir := strings.ToUpper(direction)
+		upperDir := strings.ToUpper(by.Direction)
 		if upperDir != "ASC" && upperDir != "DESC" {
 			upperDir = "ASC" // Default to ASC if invalid
 		}
@@ -326,7 +356,28 @@ func (qb *QueryBuilder) Build() (string, []interface{}, error) {
 //	sql: "SELECT COUNT(*) FROM activities WHERE activity_type = $1 AND user_id = $2"
 //	args: []interface{}{"running", 123}
 func (qb *QueryBuilder) BuildCount() (string, []interface{}, error) {
-	countQuery := sq.Select("COUNT(*)").From(qb.tableName)
+	return qb.buildAggregate("COUNT(*)")
+}
+
+// BuildVersion generates a query returning the row count and the latest value of
+// versionColumn across every row matching the filters. Used to derive collection ETags:
+// any insert, delete or update to a matching row changes one of the two values.
+//
+// Example output:
+//
+//	sql: "SELECT COUNT(*), MAX(activities.updated_at) FROM activities WHERE user_id = $1"
+//	args: []interface{}{123}
+func (qb *QueryBuilder) BuildVersion(versionColumn string) (string, []interface{}, error) {
+	if err := ValidateColumnName(versionColumn); err != nil {
+		return "", nil, err
+	}
+	return qb.buildAggregate(fmt.Sprintf("COUNT(*), MAX(%s.%s)", qb.tableName, versionColumn))
+}
+
+// buildAggregate renders selectExpr over the table with the same JOINs and WHERE
+// conditions as the data query, but without ORDER BY, LIMIT or OFFSET
+func (qb *QueryBuilder) buildAggregate(selectExpr string) (string, []interface{}, error) {
+	countQuery := sq.Select(selectExpr).From(qb.tableName)
 
 	// Add JOINs if present (needed for filtering on joined tables)
 	for _, join := range qb.joins {
@@ -351,11 +402,16 @@ func (qb *QueryBuilder) BuildCount() (string, []interface{}, error) {
 			countQuery = countQuery.Where(sq.Lt{column: value})
 		case "lte":
 			countQuery = countQuery.Where(sq.LtOrEq{column: value})
+		case "within":
+			countQuery = countQuery.Where(withinCondition(condition))
+		case "contains", "overlaps", "any":
+			countQuery = countQuery.Where(arrayCondition(condition))
 		}
 	}
 
 	// Apply Filter (AND conditions - LEGACY, kept for backward compatibility)
-	for rawColumn, value := range qb.options.Filter {
+	for _, rawColumn := range sortedKeys(qb.options.Filter) {
+		value := qb.options.Filter[rawColumn]
 		column := resolveColumnForSQL(rawColumn)
 		switch v := value.(type) {
 		case []interface{}:
@@ -376,7 +432,8 @@ func (qb *QueryBuilder) BuildCount() (string, []interface{}, error) {
 	// Apply FilterOr (OR conditions)
 	if len(qb.options.FilterOr) > 0 {
 		orConditions := sq.Or{}
-		for rawColumn, value := range qb.options.FilterOr {
+		for _, rawColumn := range sortedKeys(qb.options.FilterOr) {
+			value := qb.options.FilterOr[rawColumn]
 			column := resolveColumnForSQL(rawColumn)
 			switch v := value.(type) {
 			case []interface{}:
@@ -399,7 +456,8 @@ func (qb *QueryBuilder) BuildCount() (string, []interface{}, error) {
 	// Apply Search conditions
 	if len(qb.options.Search) > 0 {
 		searchConditions := sq.Or{}
-		for rawColumn, value := range qb.options.Search {
+		for _, rawColumn := range sortedKeys(qb.options.Search) {
+			value := qb.options.Search[rawColumn]
 			column := resolveColumnForSQL(rawColumn)
 			pattern := fmt.Sprintf("%%%v%%", value)
 			searchConditions = append(searchConditions, sq.ILike{column: pattern})
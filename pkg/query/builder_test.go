@@ -1,6 +1,8 @@
 package query
 
 import (
+	"net/url"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -15,7 +17,7 @@ func TestNewQueryBuilder(t *testing.T) {
 		Filter:   map[string]interface{}{},
 		FilterOr: map[string]interface{}{},
 		Search:   map[string]interface{}{},
-		Order:    map[string]string{},
+		Order:    Order{},
 	}
 
 	builder := NewQueryBuilder("activities", opts)
@@ -184,27 +186,43 @@ func TestQueryBuilder_ApplySearch(t *testing.T) {
 func TestQueryBuilder_ApplyOrder(t *testing.T) {
 	tests := []struct {
 		name        string
-		order       map[string]string
+		order       Order
 		expectedSQL string
 	}{
 		{
 			name:        "no order specified - default",
-			order:       map[string]string{},
-			expectedSQL: "ORDER BY created_at DESC",
+			order:       Order{},
+			expectedSQL: "ORDER BY created_at DESC, id DESC",
 		},
 		{
 			name: "single order column",
-			order: map[string]string{
-				"activity_date": "DESC",
+			order: Order{
+				{Column: "activity_date", Direction: "DESC"},
 			},
-			expectedSQL: "ORDER BY activity_date DESC",
+			expectedSQL: "ORDER BY activity_date DESC, id DESC",
 		},
 		{
 			name: "ASC order",
-			order: map[string]string{
-				"amount": "ASC",
+			order: Order{
+				{Column: "amount", Direction: "ASC"},
 			},
-			expectedSQL: "ORDER BY amount ASC",
+			expectedSQL: "ORDER BY amount ASC, id ASC",
+		},
+		{
+			name: "columns keep their sequence",
+			order: Order{
+				{Column: "distance_km", Direction: "DESC"},
+				{Column: "activity_date", Direction: "ASC"},
+			},
+			expectedSQL: "ORDER BY distance_km DESC, activity_date ASC, id ASC",
+		},
+		{
+			name: "no tiebreaker when already ordered by id",
+			order: Order{
+				{Column: "id", Direction: "DESC"},
+				{Column: "title", Direction: "ASC"},
+			},
+			expectedSQL: "ORDER BY id DESC, title ASC",
 		},
 	}
 
@@ -225,6 +243,48 @@ func TestQueryBuilder_ApplyOrder(t *testing.T) {
 	}
 }
 
+func TestQueryBuilder_ApplyOrderTiebreaker(t *testing.T) {
+	opts := &QueryOptions{Page: 1, Limit: 10, Order: Order{{Column: "created_at", Direction: "DESC"}}}
+
+	sql, _, err := NewQueryBuilder("jobs", opts).WithPrimaryKey("job_id").ApplyOrder().Build()
+	require.NoError(t, err)
+	assert.Contains(t, sql, "ORDER BY created_at DESC, job_id DESC")
+
+	joins := []JoinConfig{{Table: "activity_tags", Condition: "activity_tags.activity_id = activities.id"}}
+	sql, _, err = NewQueryBuilder("activities", opts).WithJoins(joins).ApplyOrder().Build()
+	require.NoError(t, err)
+	assert.Contains(t, sql, "ORDER BY activities.created_at DESC, activities.id DESC")
+
+	// The tiebreaker isn't added to the caller's options
+	assert.Len(t, opts.Order, 1)
+}
+
+// Rows sharing created_at must come back in one total order, so that
+// consecutive pages neither repeat nor skip any of them
+func TestQueryBuilder_OrderIsStableAcrossPages(t *testing.T) {
+	values := url.Values{
+		"order[createdAt]": {"desc"},
+		"order[title]":     {"asc"},
+		"limit":            {"2"},
+	}
+
+	var orderBy string
+	for page := 1; page <= 20; page++ {
+		values.Set("page", strconv.Itoa(page))
+		opts, err := ParseQueryParamsWithFields(values, FieldMapping{})
+		require.NoError(t, err)
+
+		sql, _, err := NewQueryBuilder("activities", opts).ApplyOrder().ApplyPagination().Build()
+		require.NoError(t, err)
+		clause := sql[strings.Index(sql, "ORDER BY"):strings.Index(sql, " LIMIT")]
+		if page == 1 {
+			orderBy = clause
+		}
+		assert.Equal(t, orderBy, clause, "page %d", page)
+	}
+	assert.Equal(t, "ORDER BY created_at DESC, title ASC, id ASC", orderBy)
+}
+
 func TestQueryBuilder_ApplyPagination(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -347,8 +407,8 @@ func TestQueryBuilder_Build(t *testing.T) {
 				Search: map[string]interface{}{
 					"title": "morning",
 				},
-				Order: map[string]string{
-					"activity_date": "DESC",
+				Order: Order{
+					{Column: "activity_date", Direction: "DESC"},
 				},
 			},
 			expectedSQL: []string{
@@ -423,8 +483,8 @@ func TestQueryBuilder_BuildCount(t *testing.T) {
 			opts: &QueryOptions{
 				Page:  1,
 				Limit: 10,
-				Order: map[string]string{
-					"created_at": "DESC",
+				Order: Order{
+					{Column: "created_at", Direction: "DESC"},
 				},
 			},
 			expectedSQL: []string{
@@ -464,6 +524,26 @@ func TestQueryBuilder_BuildCount(t *testing.T) {
 	}
 }
 
+func TestQueryBuilder_BuildVersion(t *testing.T) {
+	opts := &QueryOptions{
+		Page:  3,
+		Limit: 10,
+		Filter: map[string]interface{}{
+			"user_id": 7,
+		},
+		Order: Order{{Column: "created_at", Direction: "DESC"}},
+	}
+
+	sql, args, err := NewQueryBuilder("activities", opts).ApplyFilters().BuildVersion("updated_at")
+	require.NoError(t, err)
+
+	assert.Equal(t, "SELECT COUNT(*), MAX(activities.updated_at) FROM activities WHERE user_id = $1", sql)
+	assert.Equal(t, []interface{}{7}, args)
+
+	_, _, err = NewQueryBuilder("activities", opts).BuildVersion("updated_at; DROP TABLE users")
+	assert.Error(t, err)
+}
+
 func TestQueryBuilder_WithJoins(t *testing.T) {
 	joins := []JoinConfig{
 		{
@@ -536,8 +616,8 @@ func TestQueryBuilder_ChainedOperations(t *testing.T) {
 		Search: map[string]interface{}{
 			"title": "morning",
 		},
-		Order: map[string]string{
-			"created_at": "DESC",
+		Order: Order{
+			{Column: "created_at", Direction: "DESC"},
 		},
 	}
 
@@ -571,7 +651,7 @@ func TestQueryBuilder_EmptyOptions(t *testing.T) {
 		Filter:   map[string]interface{}{},
 		FilterOr: map[string]interface{}{},
 		Search:   map[string]interface{}{},
-		Order:    map[string]string{},
+		Order:    Order{},
 	}
 
 	builder := NewQueryBuilder("activities", opts)
@@ -676,8 +756,8 @@ func TestQueryBuilder_TagFilteringWithJoins(t *testing.T) {
 				Search: map[string]interface{}{
 					"title": "morning",
 				},
-				Order: map[string]string{
-					"created_at": "DESC",
+				Order: Order{
+					{Column: "created_at", Direction: "DESC"},
 				},
 			},
 			joins: []JoinConfig{
@@ -0,0 +1,167 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidValue is wrapped by the errors of values that can't be coerced
+// to their column's type
+var ErrInvalidValue = errors.New("invalid query value")
+
+// ColumnType is the type filter and search values of a column are coerced
+// to. ParseQueryParams can only guess from the string ("0123" becomes the
+// int 123, dates stay strings); a declared type parses the value as sent.
+type ColumnType string
+
+const (
+	// TypeAuto keeps the value ParseQueryParams guessed
+	TypeAuto      ColumnType = ""
+	TypeString    ColumnType = "string"
+	TypeInt       ColumnType = "int"
+	TypeFloat     ColumnType = "float"
+	TypeBool      ColumnType = "bool"
+	TypeTimestamp ColumnType = "timestamp"
+	TypeDate      ColumnType = "date"
+	TypeUUID      ColumnType = "uuid"
+)
+
+// timestampLayouts are tried in order for TypeTimestamp and TypeDate values
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// Coerce parses raw as a value of type t:
+//   - "null" → nil, for every type (IS NULL)
+//   - "[a,b]" → []interface{} with each element coerced (IN lists)
+//   - TypeString → the string as sent
+//   - TypeInt, TypeFloat, TypeBool → int, float64, bool
+//   - TypeTimestamp → time.Time, from RFC 3339 or "2006-01-02 15:04:05"-like layouts
+//   - TypeDate → time.Time at midnight UTC of the day
+//   - TypeUUID → the lowercased UUID
+//
+// The error wraps ErrInvalidValue.
+func (t ColumnType) Coerce(raw string) (interface{}, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "null" {
+		return nil, nil
+	}
+
+	if strings.HasPrefix(raw, "[") && strings.HasSuffix(raw, "]") {
+		inner := strings.Trim(raw, "[]")
+		if inner == "" {
+			return []interface{}{}, nil
+		}
+		parts := strings.Split(inner, ",")
+		values := make([]interface{}, len(parts))
+		for i, part := range parts {
+			value, err := t.coerceOne(strings.TrimSpace(part))
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return values, nil
+	}
+
+	return t.coerceOne(raw)
+}
+
+func (t ColumnType) coerceOne(raw string) (interface{}, error) {
+	invalid := fmt.Errorf("%w: '%s' is not a valid %s", ErrInvalidValue, raw, t)
+
+	switch t {
+	case TypeString, TypeAuto:
+		return raw, nil
+	case TypeInt:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, invalid
+		}
+		return n, nil
+	case TypeFloat:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, invalid
+		}
+		return f, nil
+	case TypeBool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, invalid
+		}
+		return b, nil
+	case TypeTimestamp, TypeDate:
+		for _, layout := range timestampLayouts {
+			if ts, err := time.Parse(layout, raw); err == nil {
+				if t == TypeDate {
+					year, month, day := ts.Date()
+					return time.Date(year, month, day, 0, 0, 0, 0, time.UTC), nil
+				}
+				return ts, nil
+			}
+		}
+		return nil, invalid
+	case TypeUUID:
+		if !isUUID(raw) {
+			return nil, invalid
+		}
+		return strings.ToLower(raw), nil
+	}
+	return nil, fmt.Errorf("unknown column type '%s'", t)
+}
+
+// isUUID reports whether s is a hyphenated UUID (8-4-4-4-12 hex digits)
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, r := range s {
+		switch i {
+		case 8, 13, 18, 23:
+			if r != '-' {
+				return false
+			}
+		default:
+			if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// rawValue is a filter, filterOr or search value as it was sent
+type rawValue struct {
+	clause   string // filter, filterOr or search
+	column   string
+	operator string // filter only
+	value    string
+}
+
+// setValue replaces the parsed value of raw in opts
+func (opts *QueryOptions) setValue(raw rawValue, value interface{}) {
+	switch raw.clause {
+	case "filter":
+		if _, ok := opts.Filter[raw.column]; ok && raw.operator == "eq" {
+			opts.Filter[raw.column] = value
+		}
+		for i, condition := range opts.FilterConditions {
+			if condition.Column == raw.column && condition.Operator == raw.operator {
+				opts.FilterConditions[i].Value = value
+			}
+		}
+	case "filterOr":
+		opts.FilterOr[raw.column] = value
+	case "search":
+		opts.Search[raw.column] = value
+	}
+}
@@ -0,0 +1,85 @@
+package query
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnType_Coerce(t *testing.T) {
+	tests := []struct {
+		name     string
+		typ      ColumnType
+		raw      string
+		expected interface{}
+		wantErr  bool
+	}{
+		{name: "leading zero stays a string", typ: TypeString, raw: "0123", expected: "0123"},
+		{name: "int", typ: TypeInt, raw: "42", expected: 42},
+		{name: "int rejects text", typ: TypeInt, raw: "abc", wantErr: true},
+		{name: "float", typ: TypeFloat, raw: "5", expected: 5.0},
+		{name: "bool", typ: TypeBool, raw: "true", expected: true},
+		{name: "bool rejects text", typ: TypeBool, raw: "yes please", wantErr: true},
+		{name: "timestamp RFC 3339", typ: TypeTimestamp, raw: "2024-03-01T08:30:00Z", expected: time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)},
+		{name: "timestamp with space", typ: TypeTimestamp, raw: "2024-03-01 08:30:00", expected: time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)},
+		{name: "timestamp date only", typ: TypeTimestamp, raw: "2024-03-01", expected: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "timestamp rejects garbage", typ: TypeTimestamp, raw: "yesterday", wantErr: true},
+		{name: "date drops the time", typ: TypeDate, raw: "2024-03-01T23:10:00Z", expected: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "uuid lowercased", typ: TypeUUID, raw: "3F2504E0-4F89-11D3-9A0C-0305E82C3301", expected: "3f2504e0-4f89-11d3-9a0c-0305e82c3301"},
+		{name: "uuid rejects short", typ: TypeUUID, raw: "3f2504e0-4f89", wantErr: true},
+		{name: "null for any type", typ: TypeInt, raw: "null", expected: nil},
+		{name: "IN list coerced per value", typ: TypeInt, raw: "[1, 02,3]", expected: []interface{}{1, 2, 3}},
+		{name: "IN list with a bad value", typ: TypeInt, raw: "[1,x]", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := tt.typ.Coerce(tt.raw)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrInvalidValue), "expected ErrInvalidValue, got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestEntityValidation_CoercesFilterValues(t *testing.T) {
+	v := NewEntityValidation("activities")
+	v.Column("activity_type", ColumnRule{Filter: true, Type: TypeString})
+	v.Column("activity_date", ColumnRule{Filter: true, Type: TypeTimestamp})
+	v.Column("distance_km", ColumnRule{Filter: true, Type: TypeFloat})
+	v.Column("title", ColumnRule{Search: true, Type: TypeString})
+
+	opts, err := ParseQueryParams(url.Values{
+		"filter[activityType]":       {"0123"},
+		"filter[activity_date][gte]": {"2024-03-01"},
+		"filterOr[distance_km]":      {"10"},
+		"search[title]":              {"5"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, v.Validate(opts))
+
+	assert.Equal(t, "0123", opts.Filter["activity_type"])
+	assert.Equal(t, 10.0, opts.FilterOr["distance_km"])
+	assert.Equal(t, "5", opts.Search["title"])
+	for _, condition := range opts.FilterConditions {
+		switch condition.Column {
+		case "activity_type":
+			assert.Equal(t, "0123", condition.Value)
+		case "activity_date":
+			assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), condition.Value)
+		}
+	}
+
+	opts, err = ParseQueryParams(url.Values{"filter[distance_km][gt]": {"far"}})
+	require.NoError(t, err)
+	err = v.Validate(opts)
+	assert.True(t, errors.Is(err, ErrInvalidValue))
+	assert.EqualError(t, err, "invalid query value: 'far' is not a valid float for column 'distance_km'")
+}
@@ -0,0 +1,212 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrQueryTooExpensive is wrapped by every *CostError
+var ErrQueryTooExpensive = errors.New("query is too expensive")
+
+// CostLimits is the budget a list query may spend.
+//
+// EstimateCost scores a QueryOptions by what makes it expensive to run:
+// joined tables, ILIKE columns, IN list values, page size and a missing
+// user scope. EnforceCost rejects queries over the budget, or degrades them
+// by shrinking the page until they fit.
+//
+// Example:
+//
+//	limits := DefaultCostLimits()
+//	limits.Budget = 150
+//	degraded, err := EnforceCost(opts, limits)
+//	if errors.Is(err, ErrQueryTooExpensive) {
+//	    return http.StatusUnprocessableEntity, err
+//	}
+type CostLimits struct {
+	// Budget is the highest accepted total cost; 0 disables the budget
+	Budget int
+
+	// Weights of each part of the query
+	JoinCost     int // per joined relationship (tags, tags.parent, users, ...)
+	SearchCost   int // per searched column
+	InValueCost  int // per value in an IN list
+	RowCost      int // per row of the page (Limit)
+	UnscopedCost int // added when the query isn't filtered by user_id
+
+	// Hard caps, rejected whatever the budget; 0 disables a cap
+	MaxJoins    int
+	MaxInValues int // per IN list
+
+	// Degrade shrinks the page of an over-budget query instead of rejecting
+	// it, but never below MinLimit
+	Degrade  bool
+	MinLimit int
+}
+
+// DefaultCostLimits returns the weights and limits the API uses by default.
+// A scoped query with a few filters fits a full page of 100; two deep
+// relationship filters plus a wide search get their page reduced.
+func DefaultCostLimits() CostLimits {
+	return CostLimits{
+		Budget:       200,
+		JoinCost:     15,
+		SearchCost:   10,
+		InValueCost:  1,
+		RowCost:      1,
+		UnscopedCost: 100,
+		MaxJoins:     6,
+		MaxInValues:  100,
+		Degrade:      true,
+		MinLimit:     10,
+	}
+}
+
+// QueryCost is the estimated cost of a query, broken down by part
+type QueryCost struct {
+	Joins         int  `json:"joins"`
+	SearchColumns int  `json:"search_columns"`
+	InValues      int  `json:"in_values"`
+	LargestIn     int  `json:"largest_in"`
+	Rows          int  `json:"rows"`
+	Unscoped      bool `json:"unscoped"`
+	Total         int  `json:"total"`
+}
+
+// CostError explains why a query was rejected
+type CostError struct {
+	Cost   QueryCost
+	Reason string
+}
+
+// Error returns the reason along with the cost breakdown
+func (e *CostError) Error() string {
+	return fmt.Sprintf("%s: %s (cost %d: %d joins, %d search columns, %d IN values, %d rows%s)",
+		ErrQueryTooExpensive, e.Reason, e.Cost.Total, e.Cost.Joins, e.Cost.SearchColumns,
+		e.Cost.InValues, e.Cost.Rows, unscopedNote(e.Cost.Unscoped))
+}
+
+// Unwrap lets errors.Is match ErrQueryTooExpensive
+func (e *CostError) Unwrap() error {
+	return ErrQueryTooExpensive
+}
+
+func unscopedNote(unscoped bool) string {
+	if unscoped {
+		return ", not scoped to a user"
+	}
+	return ""
+}
+
+// EstimateCost scores opts with the weights of limits.
+//
+// Joins are counted per relationship path, so "tags.name" and "tags.id"
+// share one join while "tags.parent.name" needs two (tags, tags.parent).
+// The estimate is structural: it doesn't look at table sizes or indexes.
+func EstimateCost(opts *QueryOptions, limits CostLimits) QueryCost {
+	paths := make(map[string]bool)
+	addPaths := func(column string) {
+		parts := strings.Split(column, ".")
+		for i := 1; i < len(parts); i++ {
+			paths[strings.Join(parts[:i], ".")] = true
+		}
+	}
+
+	// IN list sizes by clause and column; the parser mirrors equality filters
+	// in Filter and FilterConditions, which build the same condition
+	cost := QueryCost{Rows: opts.Limit, Unscoped: true}
+	inLists := make(map[string]int)
+	addValues := func(clause, column string, value interface{}) {
+		n := inListSize(value)
+		if strings.EqualFold(column, "user_id") && n == 1 {
+			cost.Unscoped = false
+		}
+		if n > 1 {
+			inLists[clause+":"+column] = n
+		}
+	}
+
+	for column, value := range opts.Filter {
+		addPaths(column)
+		addValues("and", column, value)
+	}
+	for _, condition := range opts.FilterConditions {
+		addPaths(condition.Column)
+		if condition.Operator == "eq" {
+			addValues("and", condition.Column, condition.Value)
+		} else if contains(ArrayOperators(), condition.Operator) {
+			// Array operator lists are capped like IN lists
+			addValues(condition.Operator, condition.Column, condition.Value)
+		}
+	}
+	for column, value := range opts.FilterOr {
+		addPaths(column)
+		addValues("or", column, value)
+	}
+	for _, n := range inLists {
+		cost.InValues += n
+		cost.LargestIn = max(cost.LargestIn, n)
+	}
+	for column := range opts.Search {
+		addPaths(column)
+		cost.SearchColumns++
+	}
+	for _, by := range opts.Order {
+		addPaths(by.Column)
+	}
+	cost.Joins = len(paths)
+
+	cost.Total = cost.Joins*limits.JoinCost +
+		cost.SearchColumns*limits.SearchCost +
+		cost.InValues*limits.InValueCost +
+		cost.Rows*limits.RowCost
+	if cost.Unscoped {
+		cost.Total += limits.UnscopedCost
+	}
+	return cost
+}
+
+// EnforceCost checks opts against limits. A query breaking a hard cap is
+// rejected. A query over the budget has its Limit reduced until it fits when
+// limits.Degrade is set, and degraded reports that it was; otherwise, or if
+// even MinLimit rows don't fit, it is rejected. Rejections are *CostError.
+func EnforceCost(opts *QueryOptions, limits CostLimits) (degraded bool, err error) {
+	cost := EstimateCost(opts, limits)
+
+	if limits.MaxJoins > 0 && cost.Joins > limits.MaxJoins {
+		return false, &CostError{Cost: cost, Reason: fmt.Sprintf("filters, search and order join %d relationships, at most %d are allowed", cost.Joins, limits.MaxJoins)}
+	}
+	if limits.MaxInValues > 0 && cost.LargestIn > limits.MaxInValues {
+		return false, &CostError{Cost: cost, Reason: fmt.Sprintf("a filter lists %d values, at most %d are allowed", cost.LargestIn, limits.MaxInValues)}
+	}
+	if limits.Budget <= 0 || cost.Total <= limits.Budget {
+		return false, nil
+	}
+
+	reason := fmt.Sprintf("exceeds the budget of %d; use fewer relationship filters, search columns or filter values", limits.Budget)
+	if !limits.Degrade || limits.RowCost <= 0 {
+		return false, &CostError{Cost: cost, Reason: reason}
+	}
+
+	// Everything but the page is fixed; find the largest page that fits
+	fixed := cost.Total - cost.Rows*limits.RowCost
+	fits := (limits.Budget - fixed) / limits.RowCost
+	if fits < max(limits.MinLimit, 1) {
+		return false, &CostError{Cost: cost, Reason: reason}
+	}
+	opts.Limit = fits
+	return true, nil
+}
+
+// inListSize returns the number of values of an IN list filter, or 1 for a
+// single value
+func inListSize(value interface{}) int {
+	switch v := value.(type) {
+	case []interface{}:
+		return len(v)
+	case []string:
+		return len(v)
+	}
+	return 1
+}
@@ -0,0 +1,72 @@
+package query
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateCost(t *testing.T) {
+	opts, err := ParseQueryParams(url.Values{
+		"filter[tags.name]":        {"run"},
+		"filter[tags.parent.name]": {"sport"},
+		"filter[activity_type]":    {"[running,cycling,swimming]"},
+		"search[title]":            {"morning"},
+		"search[users.username]":   {"sam"},
+		"limit":                    {"50"},
+	})
+	require.NoError(t, err)
+
+	limits := DefaultCostLimits()
+	cost := EstimateCost(opts, limits)
+	assert.Equal(t, 3, cost.Joins, "tags, tags.parent and users")
+	assert.Equal(t, 2, cost.SearchColumns)
+	assert.Equal(t, 3, cost.InValues)
+	assert.True(t, cost.Unscoped)
+	assert.Equal(t, 3*15+2*10+3+50+100, cost.Total)
+
+	opts.Filter["user_id"] = 7
+	cost = EstimateCost(opts, limits)
+	assert.False(t, cost.Unscoped)
+	assert.Equal(t, 3*15+2*10+3+50, cost.Total)
+}
+
+func TestEnforceCost(t *testing.T) {
+	limits := DefaultCostLimits()
+
+	opts := NewQueryOptions()
+	opts.Filter["user_id"] = 7
+	opts.Limit = 100
+	degraded, err := EnforceCost(opts, limits)
+	require.NoError(t, err)
+	assert.False(t, degraded)
+	assert.Equal(t, 100, opts.Limit)
+
+	// Over budget: the page shrinks until the query fits
+	opts.Search = map[string]interface{}{"title": "a", "description": "b", "notes": "c", "tags.name": "d", "tags.parent.name": "e", "users.username": "f"}
+	degraded, err = EnforceCost(opts, limits)
+	require.NoError(t, err)
+	assert.True(t, degraded)
+	assert.Equal(t, 200-3*15-6*10, opts.Limit)
+
+	// Without degrading it is rejected
+	opts.Limit = 100
+	limits.Degrade = false
+	_, err = EnforceCost(opts, limits)
+	var costErr *CostError
+	require.True(t, errors.As(err, &costErr))
+	assert.ErrorIs(t, err, ErrQueryTooExpensive)
+	assert.Contains(t, err.Error(), "exceeds the budget of 200")
+
+	// Hard caps reject whatever the budget
+	limits = DefaultCostLimits()
+	limits.Budget = 0
+	opts = NewQueryOptions()
+	opts.Filter["users.id"] = make([]interface{}, 101)
+	_, err = EnforceCost(opts, limits)
+	assert.ErrorIs(t, err, ErrQueryTooExpensive)
+	assert.Contains(t, err.Error(), "lists 101 values")
+}
@@ -0,0 +1,111 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// CountMode says how a list query computes PaginationMeta.TotalRecords.
+//
+// COUNT(*) over the filters doubles the work of every page and gets slow on
+// tables with millions of rows, so clients that only page forward can skip
+// it with count=none, and clients that show an approximate total can ask for
+// count=estimated.
+type CountMode string
+
+const (
+	// CountExact runs COUNT(*) with the list's filters (the default)
+	CountExact CountMode = "exact"
+
+	// CountEstimated reads the planner's row estimate: pg_class.reltuples for
+	// an unfiltered table, the EXPLAIN row estimate otherwise
+	CountEstimated CountMode = "estimated"
+
+	// CountNone skips counting; one extra row is fetched to tell whether
+	// there is a next page, and totalRecords and pageCount are left out
+	CountNone CountMode = "none"
+)
+
+// ParseCountMode parses the count= query parameter; an empty value is
+// CountExact
+func ParseCountMode(value string) (CountMode, error) {
+	switch mode := CountMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "", CountExact:
+		return CountExact, nil
+	case CountEstimated, CountNone:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid count mode '%s' (allowed: exact, estimated, none)", value)
+	}
+}
+
+// BuildEstimate generates an EXPLAIN of the rows matching the filters, whose
+// top plan node estimates how many there are (see ParseEstimate). It has the
+// same JOINs and WHERE conditions as BuildCount.
+//
+// Example output:
+//
+//	sql: "EXPLAIN (FORMAT JSON) SELECT 1 FROM activities WHERE user_id = $1"
+//	args: []interface{}{123}
+func (qb *QueryBuilder) BuildEstimate() (string, []interface{}, error) {
+	sql, args, err := qb.buildAggregate("1")
+	if err != nil {
+		return "", nil, err
+	}
+	return "EXPLAIN (FORMAT JSON) " + sql, args, nil
+}
+
+// BuildTableEstimate generates a query for the planner's row count of a whole
+// table. It returns -1 for tables that were never analyzed, and for
+// partitioned tables on older PostgreSQL versions.
+func BuildTableEstimate(tableName string) (string, []interface{}, error) {
+	if err := ValidateColumnName(tableName); err != nil {
+		return "", nil, err
+	}
+	return "SELECT reltuples::bigint FROM pg_class WHERE oid = to_regclass($1)", []interface{}{tableName}, nil
+}
+
+// ParseEstimate reads the estimated row count from the output of an
+// EXPLAIN (FORMAT JSON) query
+func ParseEstimate(plan []byte) (int, error) {
+	var explained []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal(plan, &explained); err != nil {
+		return 0, fmt.Errorf("failed to parse query plan: %w", err)
+	}
+	if len(explained) == 0 {
+		return 0, fmt.Errorf("failed to parse query plan: no plan returned")
+	}
+	return int(explained[0].Plan.Rows), nil
+}
+
+// ApplyPaginationLookahead is ApplyPagination fetching one more row than
+// the page holds, so that a CountNone caller can tell whether there is a
+// next page without counting
+func (qb *QueryBuilder) ApplyPaginationLookahead() *QueryBuilder {
+	qb.ApplyPagination()
+	limit := qb.options.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+	qb.baseQuery = qb.baseQuery.Limit(uint64(limit + 1))
+	return qb
+}
+
+// MarshalJSON leaves totalRecords and pageCount out of CountNone metadata,
+// where they aren't known
+func (m PaginationMeta) MarshalJSON() ([]byte, error) {
+	type meta PaginationMeta
+	if m.CountMode != CountNone {
+		return json.Marshal(meta(m))
+	}
+	return json.Marshal(struct {
+		meta
+		PageCount    *int `json:"pageCount,omitempty"`
+		TotalRecords *int `json:"totalRecords,omitempty"`
+	}{meta: meta(m)})
+}
@@ -0,0 +1,86 @@
+package query
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCountMode(t *testing.T) {
+	for value, expected := range map[string]CountMode{"": CountExact, "exact": CountExact, "Estimated": CountEstimated, "none": CountNone} {
+		mode, err := ParseCountMode(value)
+		require.NoError(t, err)
+		assert.Equal(t, expected, mode)
+	}
+
+	_, err := ParseCountMode("approximate")
+	assert.EqualError(t, err, "invalid count mode 'approximate' (allowed: exact, estimated, none)")
+}
+
+func TestCountModeFromQueryParams(t *testing.T) {
+	v := NewEntityValidation("activities")
+	v.DefaultCount = CountEstimated
+
+	opts, err := ParseQueryParams(url.Values{"count": {"NONE"}})
+	require.NoError(t, err)
+	require.NoError(t, v.Validate(opts))
+	assert.Equal(t, CountNone, opts.Count)
+
+	opts, err = ParseQueryParams(url.Values{})
+	require.NoError(t, err)
+	require.NoError(t, v.Validate(opts))
+	assert.Equal(t, CountEstimated, opts.Count)
+
+	opts, err = ParseQueryParams(url.Values{"count": {"sometimes"}})
+	require.NoError(t, err)
+	assert.Error(t, v.Validate(opts))
+	assert.Error(t, ValidateQueryOptions(opts, nil, nil, nil))
+}
+
+func TestQueryBuilder_BuildEstimate(t *testing.T) {
+	opts := NewQueryOptions()
+	opts.Filter["user_id"] = 7
+
+	sql, args, err := NewQueryBuilder("activities", opts).ApplyFilters().BuildEstimate()
+	require.NoError(t, err)
+	assert.Equal(t, "EXPLAIN (FORMAT JSON) SELECT 1 FROM activities WHERE user_id = $1", sql)
+	assert.Equal(t, []interface{}{7}, args)
+
+	_, _, err = BuildTableEstimate("activities; DROP TABLE users")
+	assert.Error(t, err)
+}
+
+func TestParseEstimate(t *testing.T) {
+	rows, err := ParseEstimate([]byte(`[{"Plan": {"Node Type": "Seq Scan", "Plan Rows": 1250, "Plan Width": 4}}]`))
+	require.NoError(t, err)
+	assert.Equal(t, 1250, rows)
+
+	_, err = ParseEstimate([]byte(`[]`))
+	assert.Error(t, err)
+}
+
+func TestQueryBuilder_ApplyPaginationLookahead(t *testing.T) {
+	opts := NewQueryOptions()
+	opts.Page = 3
+	opts.Limit = 20
+
+	sql, _, err := NewQueryBuilder("activities", opts).ApplyPaginationLookahead().Build()
+	require.NoError(t, err)
+	assert.Contains(t, sql, "LIMIT 21 OFFSET 40")
+}
+
+func TestPaginationMeta_MarshalJSON(t *testing.T) {
+	meta := PaginationMeta{Page: 2, Limit: 10, Count: 10, CountMode: CountNone}
+	meta.SetPreviousPage(1)
+	meta.SetNextPage(3)
+	data, err := json.Marshal(meta)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"page":2,"limit":10,"count":10,"previousPage":1,"nextPage":3,"hasPrevious":true,"hasNext":true,"countMode":"none"}`, string(data))
+
+	data, err = json.Marshal(PaginationMeta{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"page":1,"limit":10,"count":0,"previousPage":null,"nextPage":null,"hasPrevious":false,"hasNext":false,"pageCount":0,"totalRecords":0}`, string(data))
+}
@@ -0,0 +1,210 @@
+package query
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// The fast path renders the data query of the simplest and hottest lists —
+// equality filters, at most one order column and a page — without squirrel.
+// Squirrel allocates a builder value per chained call and re-renders every
+// clause; here the SQL up to ORDER BY is rendered once per shape (table,
+// filtered columns, order) and cached, and each call only appends LIMIT and
+// OFFSET into a pooled buffer.
+
+// fastTemplate is the cached SQL of one list shape: SELECT ... ORDER BY ...,
+// with the filter columns bound to $1, $2, ... in sorted order
+type fastTemplate struct {
+	sql string
+}
+
+var (
+	fastTemplates sync.Map // shape signature -> *fastTemplate
+
+	// fastBuffers holds *[]byte scratch buffers; the final string is the only
+	// allocation made from them
+	fastBuffers = sync.Pool{New: func() interface{} {
+		buf := make([]byte, 0, 256)
+		return &buf
+	}}
+)
+
+// fastFilter is one equality filter of a fast path query
+type fastFilter struct {
+	column string
+	value  interface{}
+}
+
+// FastListQuery returns the SELECT of one page of tableName for opts
+// without squirrel, and false when opts needs the full QueryBuilder: any
+// JOIN, OR filter, search, non-eq operator, NULL or IN value, or more than
+// one order column. The SQL is equivalent to
+//
+//	NewQueryBuilder(tableName, opts).WithPrimaryKey(primaryKey).
+//	    ApplyFilterConditions().ApplyFilters().ApplyOrder().ApplyPagination().Build()
+//
+// with equality conditions repeated between Filter and FilterConditions (as
+// the parser produces them) bound once. lookahead fetches one more row, as
+// ApplyPaginationLookahead does.
+func FastListQuery(tableName, primaryKey string, opts *QueryOptions, lookahead bool) (string, []interface{}, bool) {
+	if len(opts.FilterOr) > 0 || len(opts.Search) > 0 || len(opts.Order) > 1 {
+		return "", nil, false
+	}
+	if len(opts.Order) == 1 && strings.Contains(opts.Order[0].Column, ".") {
+		return "", nil, false
+	}
+
+	// Equality filters from both maps, sorted by column; a column filtered
+	// twice must agree
+	filters := make([]fastFilter, 0, len(opts.Filter)+len(opts.FilterConditions))
+	add := func(column string, value interface{}) bool {
+		if !fastValue(value) || !fastColumn(column) {
+			return false
+		}
+		i := sort.Search(len(filters), func(i int) bool { return filters[i].column >= column })
+		if i < len(filters) && filters[i].column == column {
+			return filters[i].value == value
+		}
+		filters = append(filters, fastFilter{})
+		copy(filters[i+1:], filters[i:])
+		filters[i] = fastFilter{column: column, value: value}
+		return true
+	}
+	for _, condition := range opts.FilterConditions {
+		if condition.Operator != "eq" || !add(condition.Column, condition.Value) {
+			return "", nil, false
+		}
+	}
+	for column, value := range opts.Filter {
+		if !add(column, value) {
+			return "", nil, false
+		}
+	}
+
+	tmpl := fastTemplateFor(tableName, primaryKey, filters, opts.Order)
+	var args []interface{}
+	if len(filters) > 0 {
+		args = make([]interface{}, len(filters))
+		for i, filter := range filters {
+			args[i] = filter.value
+		}
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+	page := opts.Page
+	if page < 1 {
+		page = 1
+	}
+	offset := (page - 1) * limit
+	if lookahead {
+		limit++
+	}
+
+	buf := fastBuffers.Get().(*[]byte)
+	b := append((*buf)[:0], tmpl.sql...)
+	b = append(b, " LIMIT "...)
+	b = strconv.AppendInt(b, int64(limit), 10)
+	b = append(b, " OFFSET "...)
+	b = strconv.AppendInt(b, int64(offset), 10)
+	sql := string(b)
+	*buf = b
+	fastBuffers.Put(buf)
+
+	return sql, args, true
+}
+
+// fastTemplateFor returns the cached template of a list shape, rendering it
+// on first use
+func fastTemplateFor(tableName, primaryKey string, filters []fastFilter, order Order) *fastTemplate {
+	signature := buildFastSignature(tableName, primaryKey, filters, order)
+	if cached, ok := fastTemplates.Load(signature); ok {
+		return cached.(*fastTemplate)
+	}
+
+	var sql strings.Builder
+	sql.WriteString("SELECT ")
+	sql.WriteString(tableName)
+	sql.WriteString(".* FROM ")
+	sql.WriteString(tableName)
+	for i, filter := range filters {
+		if i == 0 {
+			sql.WriteString(" WHERE ")
+		} else {
+			sql.WriteString(" AND ")
+		}
+		sql.WriteString(filter.column)
+		sql.WriteString(" = $")
+		sql.WriteString(strconv.Itoa(i + 1))
+	}
+
+	if len(order) == 0 {
+		order = Order{{Column: "created_at", Direction: "DESC"}}
+	}
+	sql.WriteString(" ORDER BY ")
+	for i, by := range order.withTiebreaker(primaryKey) {
+		if i > 0 {
+			sql.WriteString(", ")
+		}
+		direction := strings.ToUpper(by.Direction)
+		if direction != "ASC" && direction != "DESC" {
+			direction = "ASC"
+		}
+		sql.WriteString(by.Column)
+		sql.WriteString(" ")
+		sql.WriteString(direction)
+	}
+
+	tmpl := &fastTemplate{sql: sql.String()}
+	actual, _ := fastTemplates.LoadOrStore(signature, tmpl)
+	return actual.(*fastTemplate)
+}
+
+// buildFastSignature identifies a list shape: everything in its SQL except
+// the argument values and the page
+func buildFastSignature(tableName, primaryKey string, filters []fastFilter, order Order) string {
+	buf := fastBuffers.Get().(*[]byte)
+	b := append((*buf)[:0], tableName...)
+	b = append(b, '|')
+	b = append(b, primaryKey...)
+	for _, filter := range filters {
+		b = append(b, '|')
+		b = append(b, filter.column...)
+	}
+	for _, by := range order {
+		b = append(b, "|order:"...)
+		b = append(b, by.Column...)
+		b = append(b, ' ')
+		b = append(b, by.Direction...)
+	}
+	signature := string(b)
+	*buf = b
+	fastBuffers.Put(buf)
+	return signature
+}
+
+// fastColumn reports whether column is a plain column of the main table
+func fastColumn(column string) bool {
+	return column != "" && !strings.Contains(column, ".") && ValidateColumnName(column) == nil
+}
+
+// fastValue reports whether value binds as a single "= $n" argument: not
+// NULL, which needs IS NULL, and not a list, which squirrel turns into IN.
+// Values must be comparable, to check a column filtered twice.
+func fastValue(value interface{}) bool {
+	if value == nil {
+		return false
+	}
+	switch value.(type) {
+	case string, int, int64, float64, bool:
+		return true
+	}
+	t := reflect.TypeOf(value)
+	kind := t.Kind()
+	return t.Comparable() && kind != reflect.Slice && kind != reflect.Array && kind != reflect.Map
+}
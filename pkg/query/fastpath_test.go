@@ -0,0 +1,123 @@
+package query
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFastListQuery_MatchesBuilder(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter map[string]interface{}
+		order  Order
+	}{
+		{name: "default order", filter: map[string]interface{}{"user_id": 7}},
+		{name: "single order", filter: map[string]interface{}{"user_id": 7}, order: Order{{Column: "activity_date", Direction: "ASC"}}},
+		{name: "ordered by the primary key", order: Order{{Column: "id", Direction: "DESC"}}},
+		{name: "timestamp value", filter: map[string]interface{}{"created_at": time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := NewQueryOptions()
+			opts.Page = 3
+			opts.Limit = 25
+			opts.Order = tt.order
+			for column, value := range tt.filter {
+				opts.Filter[column] = value
+			}
+
+			expectedSQL, expectedArgs, err := NewQueryBuilder("activities", opts).
+				ApplyFilterConditions().ApplyFilters().ApplyOrder().ApplyPagination().Build()
+			require.NoError(t, err)
+
+			sql, args, ok := FastListQuery("activities", DefaultPrimaryKey, opts, false)
+			require.True(t, ok)
+			assert.Equal(t, expectedSQL, sql)
+			assert.Equal(t, expectedArgs, args)
+		})
+	}
+}
+
+func TestFastListQuery_ParsedFilters(t *testing.T) {
+	opts, err := ParseQueryParams(url.Values{
+		"filter[user_id]":       {"7"},
+		"filter[activity_type]": {"running"},
+		"order[distance_km]":    {"desc"},
+		"page":                  {"2"},
+		"limit":                 {"10"},
+	})
+	require.NoError(t, err)
+
+	// The parser puts eq filters in both Filter and FilterConditions; each
+	// is bound once, in column order
+	sql, args, ok := FastListQuery("activities", DefaultPrimaryKey, opts, true)
+	require.True(t, ok)
+	assert.Equal(t, "SELECT activities.* FROM activities WHERE activity_type = $1 AND user_id = $2 ORDER BY distance_km DESC, id DESC LIMIT 11 OFFSET 10", sql)
+	assert.Equal(t, []interface{}{"running", 7}, args)
+}
+
+func TestFastListQuery_FallsBack(t *testing.T) {
+	tests := map[string]func(opts *QueryOptions){
+		"search":    func(opts *QueryOptions) { opts.Search["title"] = "run" },
+		"or filter": func(opts *QueryOptions) { opts.FilterOr["activity_type"] = "run" },
+		"range operator": func(opts *QueryOptions) {
+			opts.FilterConditions = []FilterCondition{{Column: "distance_km", Operator: "gt", Value: 5}}
+		},
+		"IN list":      func(opts *QueryOptions) { opts.Filter["activity_type"] = []interface{}{"run", "ride"} },
+		"NULL":         func(opts *QueryOptions) { opts.Filter["deleted_at"] = nil },
+		"relationship": func(opts *QueryOptions) { opts.Filter["tags.name"] = "cardio" },
+		"two order columns": func(opts *QueryOptions) {
+			opts.Order = Order{{Column: "activity_date", Direction: "DESC"}, {Column: "title", Direction: "ASC"}}
+		},
+		"conflicting filters": func(opts *QueryOptions) {
+			opts.Filter["user_id"] = 7
+			opts.FilterConditions = []FilterCondition{{Column: "user_id", Operator: "eq", Value: 8}}
+		},
+	}
+
+	for name, configure := range tests {
+		t.Run(name, func(t *testing.T) {
+			opts := NewQueryOptions()
+			configure(opts)
+			_, _, ok := FastListQuery("activities", DefaultPrimaryKey, opts, false)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func benchmarkListOptions() *QueryOptions {
+	opts := NewQueryOptions()
+	opts.Page = 2
+	opts.Limit = 20
+	opts.Filter["user_id"] = 7
+	opts.Filter["activity_type"] = "running"
+	opts.FilterConditions = []FilterCondition{
+		{Column: "user_id", Operator: "eq", Value: 7},
+		{Column: "activity_type", Operator: "eq", Value: "running"},
+	}
+	opts.Order = Order{{Column: "activity_date", Direction: "DESC"}}
+	return opts
+}
+
+func BenchmarkListQuery_Squirrel(b *testing.B) {
+	opts := benchmarkListOptions()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = NewQueryBuilder("activities", opts).
+			ApplyFilterConditions().ApplyFilters().ApplyFiltersOr().ApplySearch().
+			ApplyOrder().ApplyPagination().Build()
+	}
+}
+
+func BenchmarkListQuery_FastPath(b *testing.B) {
+	opts := benchmarkListOptions()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = FastListQuery("activities", DefaultPrimaryKey, opts, false)
+	}
+}
@@ -0,0 +1,44 @@
+package query
+
+import "strings"
+
+// FieldMapping maps API field names to the columns they are stored in, for
+// fields whose name isn't simply the camelCase of the column (e.g., "date"
+// for "activity_date"). Other fields are converted with NormalizeColumnName,
+// so JSON names like "activityDate" need no entry.
+//
+// Example:
+//
+//	fields := FieldMapping{
+//	    "date": "activity_date",
+//	    "tag":  "tags.name",
+//	}
+//	fields.Column("date")           // "activity_date"
+//	fields.Column("caloriesBurned") // "calories_burned"
+type FieldMapping map[string]string
+
+// Column returns the column of field: its mapped column (matched exactly,
+// then case-insensitively), or the snake_case of field. A nil mapping
+// returns field unchanged, which is how ParseQueryParams keeps names as sent.
+func (m FieldMapping) Column(field string) string {
+	if m == nil {
+		return field
+	}
+	if column, ok := m.lookup(field); ok {
+		return column
+	}
+	return NormalizeColumnName(field)
+}
+
+// lookup returns the mapped column of field, if there is one
+func (m FieldMapping) lookup(field string) (string, bool) {
+	if column, ok := m[field]; ok {
+		return column, true
+	}
+	for name, column := range m {
+		if strings.EqualFold(name, field) {
+			return column, true
+		}
+	}
+	return "", false
+}
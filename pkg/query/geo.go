@@ -0,0 +1,129 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// GeoPoint names the latitude and longitude columns that together form a
+// point, so the pair can be filtered as one virtual column.
+//
+// Example: filter[location][within]=51.45,-0.25,51.55,0.05 with
+//
+//	points := map[string]GeoPoint{"location": {Lat: "start_lat", Lng: "start_lng"}}
+type GeoPoint struct {
+	Lat string
+	Lng string
+}
+
+// BoundingBox is a latitude/longitude rectangle. Boxes crossing the
+// antimeridian are not supported.
+type BoundingBox struct {
+	MinLat float64 `json:"minLat"`
+	MinLng float64 `json:"minLng"`
+	MaxLat float64 `json:"maxLat"`
+	MaxLng float64 `json:"maxLng"`
+}
+
+// ParseBoundingBox parses "lat1,lng1,lat2,lng2", two opposite corners in
+// either order.
+func ParseBoundingBox(value string) (BoundingBox, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 4 {
+		return BoundingBox{}, fmt.Errorf("bounding box must be lat1,lng1,lat2,lng2")
+	}
+
+	coords := make([]float64, 4)
+	for i, part := range parts {
+		coord, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return BoundingBox{}, fmt.Errorf("invalid bounding box coordinate '%s'", part)
+		}
+		coords[i] = coord
+	}
+
+	lat1, lng1, lat2, lng2 := coords[0], coords[1], coords[2], coords[3]
+	for _, lat := range []float64{lat1, lat2} {
+		if lat < -90 || lat > 90 {
+			return BoundingBox{}, fmt.Errorf("bounding box latitude %v is out of range", lat)
+		}
+	}
+	for _, lng := range []float64{lng1, lng2} {
+		if lng < -180 || lng > 180 {
+			return BoundingBox{}, fmt.Errorf("bounding box longitude %v is out of range", lng)
+		}
+	}
+
+	return BoundingBox{
+		MinLat: min(lat1, lat2),
+		MinLng: min(lng1, lng2),
+		MaxLat: max(lat1, lat2),
+		MaxLng: max(lng1, lng2),
+	}, nil
+}
+
+// GeoWithin is the value of a resolved "within" condition: Point lies inside Box.
+// It renders as point(lng, lat) <@ box(...), which a GiST index on the same
+// point expression can serve.
+type GeoWithin struct {
+	Point GeoPoint    `json:"point"`
+	Box   BoundingBox `json:"box"`
+}
+
+// ToSql implements squirrel.Sqlizer
+func (g GeoWithin) ToSql() (string, []interface{}, error) {
+	for _, column := range []string{g.Point.Lat, g.Point.Lng} {
+		if err := ValidateColumnName(column); err != nil {
+			return "", nil, err
+		}
+	}
+	sql := fmt.Sprintf("point(%s, %s) <@ box(point(?, ?), point(?, ?))", g.Point.Lng, g.Point.Lat)
+	return sql, []interface{}{g.Box.MinLng, g.Box.MinLat, g.Box.MaxLng, g.Box.MaxLat}, nil
+}
+
+// ResolveGeoFilters turns the "within" conditions on the virtual columns in
+// points into GeoWithin values the builder can render. Call it after
+// ValidateFilterConditions; it fails on malformed bounding boxes and on
+// "within" conditions for columns that are not in points.
+func ResolveGeoFilters(opts *QueryOptions, points map[string]GeoPoint) error {
+	for i, condition := range opts.FilterConditions {
+		if condition.Operator != "within" {
+			continue
+		}
+		point, ok := points[condition.Column]
+		if !ok {
+			return fmt.Errorf("column '%s' does not support 'within'", condition.Column)
+		}
+		raw, ok := condition.Value.(string)
+		if !ok {
+			return fmt.Errorf("bounding box must be lat1,lng1,lat2,lng2")
+		}
+		box, err := ParseBoundingBox(raw)
+		if err != nil {
+			return err
+		}
+		opts.FilterConditions[i].Value = GeoWithin{Point: point, Box: box}
+	}
+	return nil
+}
+
+// withinCondition returns the predicate for a "within" condition. Values that
+// were not resolved by ResolveGeoFilters fail the query rather than being
+// silently ignored.
+func withinCondition(condition FilterCondition) sq.Sqlizer {
+	if geo, ok := condition.Value.(GeoWithin); ok {
+		return geo
+	}
+	return unresolvedWithin{column: condition.Column}
+}
+
+type unresolvedWithin struct {
+	column string
+}
+
+func (u unresolvedWithin) ToSql() (string, []interface{}, error) {
+	return "", nil, fmt.Errorf("'within' filter on '%s' was not resolved (see ResolveGeoFilters)", u.column)
+}
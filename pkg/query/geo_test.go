@@ -0,0 +1,39 @@
+package query
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBoundingBox(t *testing.T) {
+	box, err := ParseBoundingBox("51.55, 0.05,51.45,-0.25")
+	require.NoError(t, err)
+	assert.Equal(t, BoundingBox{MinLat: 51.45, MinLng: -0.25, MaxLat: 51.55, MaxLng: 0.05}, box)
+
+	for _, value := range []string{"51.5,-0.1,51.6", "a,b,c,d", "91,0,0,0", "0,0,0,181"} {
+		_, err := ParseBoundingBox(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestResolveGeoFilters(t *testing.T) {
+	points := map[string]GeoPoint{"location": {Lat: "start_lat", Lng: "start_lng"}}
+
+	opts, err := ParseQueryParams(url.Values{"filter[location][within]": {"51.45,-0.25,51.55,0.05"}})
+	require.NoError(t, err)
+	require.NoError(t, ResolveGeoFilters(opts, points))
+
+	sql, args, err := NewQueryBuilder("activities", opts).ApplyFilterConditions().Build()
+	require.NoError(t, err)
+	assert.Contains(t, sql, "WHERE point(start_lng, start_lat) <@ box(point($1, $2), point($3, $4))")
+	assert.Equal(t, []interface{}{-0.25, 51.45, 0.05, 51.55}, args)
+
+	opts, _ = ParseQueryParams(url.Values{"filter[distance_km][within]": {"0,0,1,1"}})
+	assert.Error(t, ResolveGeoFilters(opts, points))
+
+	_, _, err = NewQueryBuilder("activities", opts).ApplyFilterConditions().Build()
+	assert.Error(t, err, "unresolved within conditions fail the query")
+}
@@ -0,0 +1,112 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// plannerSettings are the planner method settings hints may turn on or off
+var plannerSettings = map[string]bool{
+	"enable_bitmapscan":        true,
+	"enable_hashagg":           true,
+	"enable_hashjoin":          true,
+	"enable_indexonlyscan":     true,
+	"enable_indexscan":         true,
+	"enable_material":          true,
+	"enable_mergejoin":         true,
+	"enable_nestloop":          true,
+	"enable_partition_pruning": true,
+	"enable_seqscan":           true,
+	"enable_sort":              true,
+}
+
+// Hints are session settings applied to a query for the duration of one
+// call: a statement timeout, so a pathological query is canceled by the
+// database instead of holding the request, and planner settings for
+// diagnostics. PostgreSQL has no index hints; turning enable_seqscan off is
+// the closest to forcing an index.
+//
+// The repository runs hinted queries in a read-only transaction with SET
+// LOCAL, so the settings end with it and never leak to pooled connections.
+type Hints struct {
+	// StatementTimeout cancels the query after this long; 0 keeps the
+	// server's statement_timeout
+	StatementTimeout time.Duration
+
+	// Planner turns planner method settings (enable_seqscan, ...) on or off
+	Planner map[string]bool
+}
+
+// HintOption sets one hint, see WithStatementTimeout and WithPlannerSetting
+type HintOption func(*Hints)
+
+// WithStatementTimeout cancels the query when it runs longer than timeout
+func WithStatementTimeout(timeout time.Duration) HintOption {
+	return func(h *Hints) {
+		h.StatementTimeout = timeout
+	}
+}
+
+// WithPlannerSetting turns a planner method setting on or off, e.g.
+// WithPlannerSetting("enable_seqscan", false) to see the plan the query gets
+// when sequential scans are avoided. Unknown settings fail Statements.
+func WithPlannerSetting(setting string, on bool) HintOption {
+	return func(h *Hints) {
+		if h.Planner == nil {
+			h.Planner = map[string]bool{}
+		}
+		h.Planner[setting] = on
+	}
+}
+
+// NewHints returns the Hints set by opts
+func NewHints(opts ...HintOption) Hints {
+	var h Hints
+	h.Apply(opts...)
+	return h
+}
+
+// Apply sets more hints, replacing the ones already set
+func (h *Hints) Apply(opts ...HintOption) {
+	for _, opt := range opts {
+		opt(h)
+	}
+}
+
+// IsZero reports whether no hint is set
+func (h Hints) IsZero() bool {
+	return h.StatementTimeout <= 0 && len(h.Planner) == 0
+}
+
+// Statements returns the SET LOCAL statements applying the hints, in a
+// stable order. It fails on planner settings that aren't in the whitelist.
+//
+//	NewHints(WithStatementTimeout(5*time.Second), WithPlannerSetting("enable_seqscan", false)).Statements()
+//	→ SET LOCAL statement_timeout = 5000
+//	  SET LOCAL enable_seqscan = off
+func (h Hints) Statements() ([]string, error) {
+	var statements []string
+	if h.StatementTimeout > 0 {
+		// Round up: a sub-millisecond timeout must not become 0 (no timeout)
+		ms := (h.StatementTimeout + time.Millisecond - 1) / time.Millisecond
+		statements = append(statements, fmt.Sprintf("SET LOCAL statement_timeout = %d", ms))
+	}
+
+	settings := make([]string, 0, len(h.Planner))
+	for setting := range h.Planner {
+		if !plannerSettings[setting] {
+			return nil, fmt.Errorf("unsupported planner setting '%s'", setting)
+		}
+		settings = append(settings, setting)
+	}
+	sort.Strings(settings)
+	for _, setting := range settings {
+		value := "off"
+		if h.Planner[setting] {
+			value = "on"
+		}
+		statements = append(statements, fmt.Sprintf("SET LOCAL %s = %s", setting, value))
+	}
+	return statements, nil
+}
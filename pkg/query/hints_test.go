@@ -0,0 +1,38 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHints_Statements(t *testing.T) {
+	assert.True(t, NewHints().IsZero())
+	assert.True(t, NewHints(WithStatementTimeout(0)).IsZero())
+
+	hints := NewHints(
+		WithStatementTimeout(1500*time.Microsecond),
+		WithPlannerSetting("enable_seqscan", false),
+		WithPlannerSetting("enable_bitmapscan", true),
+	)
+	statements, err := hints.Statements()
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"SET LOCAL statement_timeout = 2",
+		"SET LOCAL enable_bitmapscan = on",
+		"SET LOCAL enable_seqscan = off",
+	}, statements)
+
+	_, err = NewHints(WithPlannerSetting("work_mem = '1GB'; --", true)).Statements()
+	assert.Error(t, err, "only whitelisted planner settings")
+}
+
+func TestQueryBuilder_WithHints(t *testing.T) {
+	opts := &QueryOptions{Hints: NewHints(WithStatementTimeout(time.Second))}
+	builder := NewQueryBuilder("activities", opts).WithHints(WithPlannerSetting("enable_seqscan", false))
+
+	assert.Equal(t, time.Second, builder.Hints().StatementTimeout)
+	assert.Equal(t, map[string]bool{"enable_seqscan": false}, opts.Hints.Planner, "hints are kept on the options")
+}
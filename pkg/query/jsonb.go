@@ -0,0 +1,93 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxJSONKeyLength is the longest key ValidateJSONKey accepts
+const MaxJSONKeyLength = 64
+
+// ValidateJSONKey checks that key, one segment of a JSON path, is safe to
+// write into SQL: letters, digits and underscores, at most MaxJSONKeyLength
+// characters. Keys are matched case-sensitively.
+func ValidateJSONKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("JSON key cannot be empty")
+	}
+	if len(key) > MaxJSONKeyLength {
+		return fmt.Errorf("JSON key too long (max %d characters)", MaxJSONKeyLength)
+	}
+	for _, r := range key {
+		valid := (r >= 'a' && r <= 'z') ||
+			(r >= 'A' && r <= 'Z') ||
+			(r >= '0' && r <= '9') ||
+			r == '_'
+		if !valid {
+			return fmt.Errorf("JSON key contains invalid character '%c': %s", r, key)
+		}
+	}
+	return nil
+}
+
+// JSONPath returns the SQL expression for the text at path in the JSONB
+// column, which compares like a text column:
+//
+//	JSONPath("metadata", "shoe")          → metadata->>'shoe'
+//	JSONPath("metadata", "bike", "brand") → metadata#>>'{bike,brand}'
+//
+// Missing keys read as NULL, so they match no eq or ne filter.
+func JSONPath(column string, path ...string) (string, error) {
+	if err := ValidateColumnName(column); err != nil {
+		return "", err
+	}
+	if len(path) == 0 {
+		return "", fmt.Errorf("JSON path of '%s' cannot be empty", column)
+	}
+	for _, key := range path {
+		if err := ValidateJSONKey(key); err != nil {
+			return "", err
+		}
+	}
+
+	if len(path) == 1 {
+		return fmt.Sprintf("%s->>'%s'", column, path[0]), nil
+	}
+	return fmt.Sprintf("%s#>>'{%s}'", column, strings.Join(path, ",")), nil
+}
+
+// ResolveJSONFilters rewrites the filter, search and order columns of opts
+// that point into one of the JSONB columns ("metadata.shoe") to their
+// JSONPath expression, which the builder then compares like any column. Call
+// it after validation (see EntityValidation.JSON), as with ResolveGeoFilters;
+// it fails on keys ValidateJSONKey rejects.
+//
+// Example: filter[metadata.shoe]=pegasus becomes metadata->>'shoe' = $1.
+func ResolveJSONFilters(opts *QueryOptions, columns ...string) error {
+	var err error
+	resolve := func(name string) string {
+		column, rest, ok := strings.Cut(name, ".")
+		if !ok || !contains(columns, column) {
+			return name
+		}
+		expr, pathErr := JSONPath(column, strings.Split(rest, ".")...)
+		if pathErr != nil {
+			if err == nil {
+				err = fmt.Errorf("invalid path '%s': %w", name, pathErr)
+			}
+			return name
+		}
+		return expr
+	}
+
+	opts.Filter = renameKeys(opts.Filter, resolve)
+	opts.FilterOr = renameKeys(opts.FilterOr, resolve)
+	opts.Search = renameKeys(opts.Search, resolve)
+	for i := range opts.FilterConditions {
+		opts.FilterConditions[i].Column = resolve(opts.FilterConditions[i].Column)
+	}
+	for i := range opts.Order {
+		opts.Order[i].Column = resolve(opts.Order[i].Column)
+	}
+	return err
+}
@@ -0,0 +1,47 @@
+package query
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONPath(t *testing.T) {
+	expr, err := JSONPath("metadata", "shoe")
+	require.NoError(t, err)
+	assert.Equal(t, "metadata->>'shoe'", expr)
+
+	expr, err = JSONPath("metadata", "bike", "brand")
+	require.NoError(t, err)
+	assert.Equal(t, "metadata#>>'{bike,brand}'", expr)
+
+	for _, path := range [][]string{nil, {""}, {"shoe'; DROP TABLE users--"}, {"bike", "front wheel"}} {
+		_, err := JSONPath("metadata", path...)
+		assert.Error(t, err, path)
+	}
+}
+
+func TestResolveJSONFilters(t *testing.T) {
+	v := NewEntityValidation("activities")
+	v.Column("activity_type", ColumnRule{Filter: true, Operators: EqualityOperators()})
+	v.JSON("metadata", ColumnRule{Filter: true, Operators: EqualityOperators(), Type: TypeString})
+
+	opts, err := ParseQueryParams(url.Values{"filter[Metadata.shoeModel]": {"42"}, "filter[activity_type]": {"running"}})
+	require.NoError(t, err)
+	require.NoError(t, v.Validate(opts))
+	require.NoError(t, ResolveJSONFilters(opts, "metadata"))
+
+	sql, args, err := NewQueryBuilder("activities", opts).ApplyFilters().Build()
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT activities.* FROM activities WHERE activity_type = $1 AND metadata->>'shoeModel' = $2", sql)
+	assert.Equal(t, []interface{}{"running", "42"}, args, "key case is kept and values compare as text")
+
+	opts, _ = ParseQueryParams(url.Values{"filter[metadata.shoe][gt]": {"1"}})
+	assert.ErrorContains(t, v.Validate(opts), "operator 'gt' is not allowed")
+
+	opts, _ = ParseQueryParams(url.Values{"filter[metadata.shoe-size]": {"9"}})
+	require.NoError(t, v.Validate(opts))
+	assert.ErrorContains(t, ResolveJSONFilters(opts, "metadata"), "invalid path 'metadata.shoe-size'")
+}
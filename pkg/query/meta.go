@@ -0,0 +1,115 @@
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// MetaVersion selects the JSON form of PaginationMeta in responses
+type MetaVersion int
+
+const (
+	// MetaV1 is the original form, where previousPage and nextPage hold a
+	// page number or false. Clients get it unless they ask for MetaV2.
+	MetaV1 MetaVersion = iota + 1
+
+	// MetaV2 is the typed form: previousPage and nextPage hold a page number
+	// or null, and hasPrevious and hasNext say which
+	MetaV2
+)
+
+// SetPreviousPage records page as the previous page
+func (m *PaginationMeta) SetPreviousPage(page int) {
+	m.PreviousPage = &page
+	m.HasPrevious = true
+}
+
+// SetNextPage records page as the next page
+func (m *PaginationMeta) SetNextPage(page int) {
+	m.NextPage = &page
+	m.HasNext = true
+}
+
+// Versioned returns m in the JSON form of version
+func (m PaginationMeta) Versioned(version MetaVersion) interface{} {
+	if version == MetaV2 {
+		return m
+	}
+	return metaV1(m)
+}
+
+// metaV1 marshals PaginationMeta in the MetaV1 form
+type metaV1 PaginationMeta
+
+func (m metaV1) MarshalJSON() ([]byte, error) {
+	v1 := struct {
+		Page         int         `json:"page"`
+		Limit        int         `json:"limit"`
+		Count        int         `json:"count"`
+		PreviousPage interface{} `json:"previousPage"`
+		NextPage     interface{} `json:"nextPage"`
+		PageCount    *int        `json:"pageCount,omitempty"`
+		TotalRecords *int        `json:"totalRecords,omitempty"`
+		CountMode    CountMode   `json:"countMode,omitempty"`
+	}{
+		Page:         m.Page,
+		Limit:        m.Limit,
+		Count:        m.Count,
+		PreviousPage: pageOrFalse(m.PreviousPage),
+		NextPage:     pageOrFalse(m.NextPage),
+		CountMode:    m.CountMode,
+	}
+	if m.CountMode != CountNone {
+		v1.PageCount = &m.PageCount
+		v1.TotalRecords = &m.TotalRecords
+	}
+	return json.Marshal(v1)
+}
+
+func pageOrFalse(page *int) interface{} {
+	if page == nil {
+		return false
+	}
+	return *page
+}
+
+// UnmarshalJSON reads both forms, so metadata cached in the MetaV1 form
+// still decodes
+func (m *PaginationMeta) UnmarshalJSON(data []byte) error {
+	type meta PaginationMeta
+	var decoded struct {
+		meta
+		PreviousPage json.RawMessage `json:"previousPage"`
+		NextPage     json.RawMessage `json:"nextPage"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*m = PaginationMeta(decoded.meta)
+	m.PreviousPage, m.HasPrevious = nil, false
+	m.NextPage, m.HasNext = nil, false
+	if page, ok, err := decodePage(decoded.PreviousPage); err != nil {
+		return err
+	} else if ok {
+		m.SetPreviousPage(page)
+	}
+	if page, ok, err := decodePage(decoded.NextPage); err != nil {
+		return err
+	} else if ok {
+		m.SetNextPage(page)
+	}
+	return nil
+}
+
+// decodePage reads a page number; null, false and a missing value are none
+func decodePage(data json.RawMessage) (int, bool, error) {
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) || bytes.Equal(data, []byte("false")) {
+		return 0, false, nil
+	}
+	var page int
+	if err := json.Unmarshal(data, &page); err != nil {
+		return 0, false, err
+	}
+	return page, true, nil
+}
@@ -0,0 +1,47 @@
+package query
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginationMeta_VersionedV1(t *testing.T) {
+	meta := PaginationMeta{Page: 2, Limit: 10, Count: 10, PageCount: 2, TotalRecords: 20}
+	meta.SetPreviousPage(1)
+
+	data, err := json.Marshal(meta.Versioned(MetaV1))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"page":2,"limit":10,"count":10,"previousPage":1,"nextPage":false,"pageCount":2,"totalRecords":20}`, string(data))
+
+	meta.CountMode = CountNone
+	data, err = json.Marshal(meta.Versioned(MetaV1))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"page":2,"limit":10,"count":10,"previousPage":1,"nextPage":false,"countMode":"none"}`, string(data))
+}
+
+func TestPaginationMeta_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+	}{
+		{"v1", `{"page":2,"limit":10,"previousPage":1,"nextPage":false}`},
+		{"v2", `{"page":2,"limit":10,"previousPage":1,"nextPage":null,"hasPrevious":true,"hasNext":false}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var meta PaginationMeta
+			require.NoError(t, json.Unmarshal([]byte(tt.json), &meta))
+
+			assert.Equal(t, 2, meta.Page)
+			require.NotNil(t, meta.PreviousPage)
+			assert.Equal(t, 1, *meta.PreviousPage)
+			assert.True(t, meta.HasPrevious)
+			assert.Nil(t, meta.NextPage)
+			assert.False(t, meta.HasNext)
+		})
+	}
+}
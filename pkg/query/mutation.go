@@ -0,0 +1,435 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// CurrentTimestamp can be used as a SET value in BuildUpdate to stamp the database's current time
+var CurrentTimestamp interface{} = sq.Expr("CURRENT_TIMESTAMP")
+
+// Increment returns a SET value for BuildUpdate that adds n to column's current value.
+// column is written into the statement as-is and must be whitelisted by the caller.
+func Increment(column string, n int) interface{} {
+	return sq.Expr(column+" + ?", n)
+}
+
+// BuildUpdate generates a single UPDATE ... WHERE statement targeting every row matched by opts.
+// Only the filtering parts of opts (Filter, FilterConditions, FilterOr, Search) are used;
+// ordering and pagination are ignored. JOINs are not supported, so columns must belong to tableName.
+//
+// Callers are responsible for whitelisting both the filter columns (see ValidateQueryOptions)
+// and the keys of set, which are written into the statement as column names.
+//
+// Example:
+//
+//	opts.Filter["user_id"] = 42
+//	opts.Filter["deleted_at"] = nil
+//	sql, args, err := BuildUpdate("activities", opts, map[string]interface{}{
+//	    "activity_type": "running",
+//	    "updated_at":    CurrentTimestamp,
+//	})
+//	// sql: UPDATE activities SET activity_type = $1, updated_at = CURRENT_TIMESTAMP
+//	//      WHERE deleted_at IS NULL AND user_id = $2
+func BuildUpdate(tableName string, opts *QueryOptions, set map[string]interface{}) (string, []interface{}, error) {
+	update := sq.Update(tableName).SetMap(set)
+	for _, condition := range whereConditions(opts) {
+		update = update.Where(condition)
+	}
+	return update.PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+// whereConditions converts the filtering parts of QueryOptions into squirrel predicates
+// Map-based options are emitted in sorted column order so the generated SQL is stable
+func whereConditions(opts *QueryOptions) []sq.Sqlizer {
+	conditions := []sq.Sqlizer{}
+
+	for _, condition := range opts.FilterConditions {
+		if predicate, ok := conditionPredicate(condition); ok {
+			conditions = append(conditions, predicate)
+		}
+	}
+
+	for _, rawColumn := range sortedKeys(opts.Filter) {
+		value := opts.Filter[rawColumn]
+		conditions = append(conditions, sq.Eq{resolveColumnForSQL(rawColumn): normalizeFilterValue(value)})
+	}
+
+	if len(opts.FilterOr) > 0 {
+		orConditions := sq.Or{}
+		for _, rawColumn := range sortedKeys(opts.FilterOr) {
+			value := opts.FilterOr[rawColumn]
+			orConditions = append(orConditions, sq.Eq{resolveColumnForSQL(rawColumn): normalizeFilterValue(value)})
+		}
+		conditions = append(conditions, orConditions)
+	}
+
+	if len(opts.Search) > 0 {
+		searchConditions := sq.Or{}
+		for _, rawColumn := range sortedKeys(opts.Search) {
+			pattern := "%" + SanitizeSearchTerm(fmt.Sprintf("%v", opts.Search[rawColumn])) + "%"
+			searchConditions = append(searchConditions, sq.ILike{resolveColumnForSQL(rawColumn): pattern})
+		}
+		conditions = append(conditions, searchConditions)
+	}
+
+	return conditions
+}
+
+// conditionPredicate converts one operator condition into a squirrel predicate,
+// and false for an unknown operator
+func conditionPredicate(condition FilterCondition) (sq.Sqlizer, bool) {
+	column := resolveColumnForSQL(condition.Column)
+	switch condition.Operator {
+	case "eq":
+		return sq.Eq{column: condition.Value}, true
+	case "ne":
+		return sq.NotEq{column: condition.Value}, true
+	case "gt":
+		return sq.Gt{column: condition.Value}, true
+	case "gte":
+		return sq.GtOrEq{column: condition.Value}, true
+	case "lt":
+		return sq.Lt{column: condition.Value}, true
+	case "lte":
+		return sq.LtOrEq{column: condition.Value}, true
+	case "within":
+		return withinCondition(condition), true
+	case "contains", "overlaps", "any":
+		return arrayCondition(condition), true
+	}
+	return nil, false
+}
+
+// normalizeFilterValue converts []string to []interface{} so squirrel renders an IN clause
+func normalizeFilterValue(value interface{}) interface{} {
+	if v, ok := value.([]string); ok {
+		vals := make([]interface{}, len(v))
+		for i, s := range v {
+			vals[i] = s
+		}
+		return vals
+	}
+	return value
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// UpdateBuilder builds a single-table UPDATE that can only write whitelisted
+// columns and can't run unfiltered. The updated-at and version columns are
+// maintained by the builder, so callers don't repeat them per statement.
+//
+// Example:
+//
+//	sql, args, err := NewUpdateBuilder("activities", "title", "notes").
+//	    Set("title", "Renamed").
+//	    Where(FilterCondition{Column: "id", Operator: "eq", Value: 7}).
+//	    UpdatedAt("updated_at").
+//	    Version("version").
+//	    Returning("id", "version").
+//	    Build()
+//	// sql: UPDATE activities SET title = $1, updated_at = CURRENT_TIMESTAMP,
+//	//      version = version + 1 WHERE id = $2 RETURNING id, version
+type UpdateBuilder struct {
+	tableName string
+	settable  map[string]bool
+	set       map[string]interface{}
+	where     []FilterCondition
+	updatedAt string
+	version   string
+	returning []string
+	err       error
+}
+
+// NewUpdateBuilder creates an UpdateBuilder for tableName that may SET only
+// the settable columns
+func NewUpdateBuilder(tableName string, settable ...string) *UpdateBuilder {
+	allowed := make(map[string]bool, len(settable))
+	for _, column := range settable {
+		allowed[column] = true
+	}
+	return &UpdateBuilder{
+		tableName: tableName,
+		settable:  allowed,
+		set:       make(map[string]interface{}),
+	}
+}
+
+// Set assigns value to column. Setting a column outside the whitelist fails
+// the build.
+func (b *UpdateBuilder) Set(column string, value interface{}) *UpdateBuilder {
+	if !b.settable[column] {
+		b.fail(fmt.Errorf("column '%s' cannot be updated", column))
+		return b
+	}
+	b.set[column] = value
+	return b
+}
+
+// SetMap assigns every value of values to its column, as Set does
+func (b *UpdateBuilder) SetMap(values map[string]interface{}) *UpdateBuilder {
+	for _, column := range sortedKeys(values) {
+		b.Set(column, values[column])
+	}
+	return b
+}
+
+// Where restricts the update to the rows matching every condition. The
+// operators are those of ApplyFilterConditions; an unknown one fails the
+// build rather than being skipped, which would widen the update.
+func (b *UpdateBuilder) Where(conditions ...FilterCondition) *UpdateBuilder {
+	b.where = append(b.where, conditions...)
+	return b
+}
+
+// UpdatedAt stamps column with the database's current time on every update
+func (b *UpdateBuilder) UpdatedAt(column string) *UpdateBuilder {
+	b.updatedAt = column
+	return b
+}
+
+// Version increments column on every update, for optimistic concurrency and
+// change feeds
+func (b *UpdateBuilder) Version(column string) *UpdateBuilder {
+	b.version = column
+	return b
+}
+
+// Returning adds a RETURNING clause with columns
+func (b *UpdateBuilder) Returning(columns ...string) *UpdateBuilder {
+	b.returning = append(b.returning, columns...)
+	return b
+}
+
+// Build generates the UPDATE with PostgreSQL-style placeholders. It fails
+// when nothing is set, no condition is given, or a column is not whitelisted
+// or not a valid identifier.
+func (b *UpdateBuilder) Build() (string, []interface{}, error) {
+	if b.err != nil {
+		return "", nil, b.err
+	}
+	if len(b.set) == 0 {
+		return "", nil, fmt.Errorf("update of %s sets no columns", b.tableName)
+	}
+	if len(b.where) == 0 {
+		return "", nil, fmt.Errorf("update of %s has no conditions", b.tableName)
+	}
+
+	set := make(map[string]interface{}, len(b.set)+2)
+	for column, value := range b.set {
+		set[column] = value
+	}
+	if b.updatedAt != "" {
+		set[b.updatedAt] = CurrentTimestamp
+	}
+	if b.version != "" {
+		set[b.version] = Increment(b.version, 1)
+	}
+	for column := range set {
+		if err := ValidateColumnName(column); err != nil {
+			return "", nil, err
+		}
+	}
+
+	update := sq.Update(b.tableName).SetMap(set)
+	for _, condition := range b.where {
+		if err := ValidateColumnName(condition.Column); err != nil {
+			return "", nil, err
+		}
+		predicate, ok := conditionPredicate(condition)
+		if !ok {
+			return "", nil, fmt.Errorf("unsupported operator '%s' on '%s'", condition.Operator, condition.Column)
+		}
+		update = update.Where(predicate)
+	}
+
+	if len(b.returning) > 0 {
+		for _, column := range b.returning {
+			if err := ValidateColumnName(column); err != nil {
+				return "", nil, err
+			}
+		}
+		update = update.Suffix("RETURNING " + strings.Join(b.returning, ", "))
+	}
+
+	return update.PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+// fail records the first error, which Build returns
+func (b *UpdateBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// InsertBuilder builds an INSERT of one or more rows, optionally as an upsert
+// (ON CONFLICT ... DO UPDATE / DO NOTHING) with a RETURNING clause. Table and
+// column names are written into the statement and must not come from input;
+// they are checked to be valid identifiers.
+//
+// Example:
+//
+//	sql, args, err := NewInsertBuilder("tags", "name").
+//	    Values("running").
+//	    OnConflict("name").DoUpdate("name").
+//	    Returning("id").
+//	    Build()
+//	// sql: INSERT INTO tags (name) VALUES ($1)
+//	//      ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name RETURNING id
+type InsertBuilder struct {
+	tableName string
+	columns   []string
+	rows      [][]interface{}
+	conflict  []string
+	doNothing bool
+	updates   []conflictUpdate
+	matching  []string
+	returning []string
+	err       error
+}
+
+// conflictUpdate is one assignment of ON CONFLICT DO UPDATE SET
+type conflictUpdate struct {
+	column string
+	value  interface{}
+}
+
+// NewInsertBuilder creates an InsertBuilder for rows of columns in tableName
+func NewInsertBuilder(tableName string, columns ...string) *InsertBuilder {
+	return &InsertBuilder{tableName: tableName, columns: columns}
+}
+
+// Values adds a row, one value per column. Values may be expressions such as
+// CurrentTimestamp.
+func (b *InsertBuilder) Values(values ...interface{}) *InsertBuilder {
+	if len(values) != len(b.columns) {
+		b.fail(fmt.Errorf("insert into %s: %d values for %d columns", b.tableName, len(values), len(b.columns)))
+		return b
+	}
+	b.rows = append(b.rows, values)
+	return b
+}
+
+// OnConflict makes the insert an upsert on the unique key of columns. It must
+// be followed by DoNothing or DoUpdate.
+func (b *InsertBuilder) OnConflict(columns ...string) *InsertBuilder {
+	b.conflict = columns
+	return b
+}
+
+// DoNothing skips rows that conflict. RETURNING yields no row for them.
+func (b *InsertBuilder) DoNothing() *InsertBuilder {
+	b.doNothing = true
+	return b
+}
+
+// DoUpdate overwrites columns of the existing row with the proposed row's
+// values (column = EXCLUDED.column)
+func (b *InsertBuilder) DoUpdate(columns ...string) *InsertBuilder {
+	for _, column := range columns {
+		b.updates = append(b.updates, conflictUpdate{column: column, value: sq.Expr("EXCLUDED." + column)})
+	}
+	return b
+}
+
+// DoUpdateSet assigns value to column of the existing row. value may be an
+// expression such as CurrentTimestamp.
+func (b *InsertBuilder) DoUpdateSet(column string, value interface{}) *InsertBuilder {
+	b.updates = append(b.updates, conflictUpdate{column: column, value: value})
+	return b
+}
+
+// MatchingExcluded restricts DoUpdate to existing rows whose columns equal the
+// proposed row's, e.g. rows of the same owner. Other conflicting rows are left
+// alone and RETURNING yields no row for them.
+func (b *InsertBuilder) MatchingExcluded(columns ...string) *InsertBuilder {
+	b.matching = append(b.matching, columns...)
+	return b
+}
+
+// Returning adds a RETURNING clause with columns
+func (b *InsertBuilder) Returning(columns ...string) *InsertBuilder {
+	b.returning = append(b.returning, columns...)
+	return b
+}
+
+// Build generates the INSERT with PostgreSQL-style placeholders. It fails
+// without rows, with an incomplete ON CONFLICT clause, or when a name is not
+// a valid identifier.
+func (b *InsertBuilder) Build() (string, []interface{}, error) {
+	if b.err != nil {
+		return "", nil, b.err
+	}
+	if len(b.rows) == 0 {
+		return "", nil, fmt.Errorf("insert into %s has no rows", b.tableName)
+	}
+
+	names := []string{b.tableName}
+	names = append(names, b.columns...)
+	names = append(names, b.conflict...)
+	names = append(names, b.matching...)
+	names = append(names, b.returning...)
+	for _, update := range b.updates {
+		names = append(names, update.column)
+	}
+	for _, name := range names {
+		if err := ValidateColumnName(name); err != nil {
+			return "", nil, err
+		}
+	}
+
+	insert := sq.Insert(b.tableName).Columns(b.columns...)
+	for _, row := range b.rows {
+		insert = insert.Values(row...)
+	}
+
+	if len(b.conflict) > 0 {
+		switch {
+		case b.doNothing && len(b.updates) == 0:
+			insert = insert.Suffix(fmt.Sprintf("ON CONFLICT (%s) DO NOTHING", strings.Join(b.conflict, ", ")))
+		case !b.doNothing && len(b.updates) > 0:
+			assignments := make([]string, len(b.updates))
+			args := make([]interface{}, len(b.updates))
+			for i, update := range b.updates {
+				assignments[i] = update.column + " = ?"
+				args[i] = update.value
+			}
+			clause := fmt.Sprintf("ON CONFLICT (%s) DO UPDATE SET %s", strings.Join(b.conflict, ", "), strings.Join(assignments, ", "))
+			if len(b.matching) > 0 {
+				matches := make([]string, len(b.matching))
+				for i, column := range b.matching {
+					matches[i] = fmt.Sprintf("%s.%s = EXCLUDED.%s", b.tableName, column, column)
+				}
+				clause += " WHERE " + strings.Join(matches, " AND ")
+			}
+			insert = insert.Suffix(clause, args...)
+		default:
+			return "", nil, fmt.Errorf("insert into %s: ON CONFLICT needs exactly one of DoNothing or DoUpdate", b.tableName)
+		}
+	} else if b.doNothing || len(b.updates) > 0 || len(b.matching) > 0 {
+		return "", nil, fmt.Errorf("insert into %s: conflict action without OnConflict", b.tableName)
+	}
+
+	if len(b.returning) > 0 {
+		insert = insert.Suffix("RETURNING " + strings.Join(b.returning, ", "))
+	}
+
+	return insert.PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+// fail records the first error, which Build returns
+func (b *InsertBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
@@ -0,0 +1,162 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildUpdate(t *testing.T) {
+	opts := NewQueryOptions()
+	opts.Filter["user_id"] = 42
+	opts.Filter["deleted_at"] = nil
+	opts.Filter["activity_type"] = []string{"running", "cycling"}
+	opts.FilterConditions = []FilterCondition{
+		{Column: "distance_km", Operator: "gte", Value: 5.0},
+	}
+	// Pagination and ordering must not leak into a mutation
+	opts.Order.Set("created_at", "DESC")
+
+	sql, args, err := BuildUpdate("activities", opts, map[string]interface{}{
+		"title":      "Renamed",
+		"updated_at": CurrentTimestamp,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		"UPDATE activities SET title = $1, updated_at = CURRENT_TIMESTAMP "+
+			"WHERE distance_km >= $2 AND activity_type IN ($3,$4) AND deleted_at IS NULL AND user_id = $5",
+		sql,
+	)
+	assert.Equal(t, []interface{}{"Renamed", 5.0, "running", "cycling", 42}, args)
+}
+
+func TestBuildUpdate_SearchIsEscaped(t *testing.T) {
+	opts := NewQueryOptions()
+	opts.Search["title"] = "100%"
+
+	sql, args, err := BuildUpdate("activities", opts, map[string]interface{}{"notes": "x"})
+	require.NoError(t, err)
+
+	assert.Contains(t, sql, "WHERE (title ILIKE $2)")
+	assert.Equal(t, `%100\%%`, args[1])
+}
+
+func TestBuildUpdate_Increment(t *testing.T) {
+	opts := NewQueryOptions()
+	opts.Filter["user_id"] = 42
+
+	sql, args, err := BuildUpdate("activities", opts, map[string]interface{}{
+		"title":   "Renamed",
+		"version": Increment("version", 1),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "UPDATE activities SET title = $1, version = version + $2 WHERE user_id = $3", sql)
+	assert.Equal(t, []interface{}{"Renamed", 1, 42}, args)
+}
+
+func TestUpdateBuilder(t *testing.T) {
+	sql, args, err := NewUpdateBuilder("activities", "title", "notes", "rpe").
+		SetMap(map[string]interface{}{"title": "Renamed", "notes": nil}).
+		Where(
+			FilterCondition{Column: "id", Operator: "eq", Value: 7},
+			FilterCondition{Column: "user_id", Operator: "eq", Value: 42},
+		).
+		UpdatedAt("updated_at").
+		Version("version").
+		Returning("id", "version").
+		Build()
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		"UPDATE activities SET notes = $1, title = $2, updated_at = CURRENT_TIMESTAMP, version = version + $3 "+
+			"WHERE id = $4 AND user_id = $5 RETURNING id, version",
+		sql,
+	)
+	assert.Equal(t, []interface{}{nil, "Renamed", 1, 7, 42}, args)
+}
+
+func TestUpdateBuilder_Rejects(t *testing.T) {
+	byID := FilterCondition{Column: "id", Operator: "eq", Value: 7}
+	tests := []struct {
+		name    string
+		builder *UpdateBuilder
+		wantErr string
+	}{
+		{
+			name:    "column outside the whitelist",
+			builder: NewUpdateBuilder("activities", "title").Set("user_id", 2).Where(byID),
+			wantErr: "column 'user_id' cannot be updated",
+		},
+		{
+			name:    "no conditions",
+			builder: NewUpdateBuilder("activities", "title").Set("title", "x"),
+			wantErr: "has no conditions",
+		},
+		{
+			name:    "nothing set",
+			builder: NewUpdateBuilder("activities", "title").Where(byID).Version("version"),
+			wantErr: "sets no columns",
+		},
+		{
+			name: "unknown operator",
+			builder: NewUpdateBuilder("activities", "title").Set("title", "x").
+				Where(FilterCondition{Column: "id", Operator: "like", Value: 7}),
+			wantErr: "unsupported operator 'like'",
+		},
+		{
+			name:    "invalid returning column",
+			builder: NewUpdateBuilder("activities", "title").Set("title", "x").Where(byID).Returning("id; DROP TABLE users"),
+			wantErr: "invalid character",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := tt.builder.Build()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestInsertBuilder_Upsert(t *testing.T) {
+	sql, args, err := NewInsertBuilder("user_identities", "user_id", "provider", "subject", "email", "last_login_at").
+		Values(7, "github", "123", "a@example.com", CurrentTimestamp).
+		OnConflict("provider", "subject").
+		DoUpdate("email").
+		DoUpdateSet("last_login_at", CurrentTimestamp).
+		MatchingExcluded("user_id").
+		Returning("id", "created_at").
+		Build()
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		"INSERT INTO user_identities (user_id,provider,subject,email,last_login_at) VALUES ($1,$2,$3,$4,CURRENT_TIMESTAMP) "+
+			"ON CONFLICT (provider, subject) DO UPDATE SET email = EXCLUDED.email, last_login_at = CURRENT_TIMESTAMP "+
+			"WHERE user_identities.user_id = EXCLUDED.user_id RETURNING id, created_at",
+		sql,
+	)
+	assert.Equal(t, []interface{}{7, "github", "123", "a@example.com"}, args)
+}
+
+func TestInsertBuilder_DoNothing(t *testing.T) {
+	sql, args, err := NewInsertBuilder("activity_tags", "tag_id", "activity_id").
+		Values(1, 10).
+		Values(2, 10).
+		OnConflict("tag_id", "activity_id").DoNothing().
+		Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, "INSERT INTO activity_tags (tag_id,activity_id) VALUES ($1,$2),($3,$4) ON CONFLICT (tag_id, activity_id) DO NOTHING", sql)
+	assert.Equal(t, []interface{}{1, 10, 2, 10}, args)
+
+	_, _, err = NewInsertBuilder("tags", "name").Values("a", "b").Build()
+	assert.ErrorContains(t, err, "2 values for 1 columns")
+	_, _, err = NewInsertBuilder("tags", "name").Values("a").OnConflict("name").Build()
+	assert.ErrorContains(t, err, "exactly one of DoNothing or DoUpdate")
+	_, _, err = NewInsertBuilder("tags", "name").Values("a").DoNothing().Build()
+	assert.ErrorContains(t, err, "without OnConflict")
+}
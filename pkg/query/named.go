@@ -0,0 +1,257 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnsafeQuery is wrapped by the errors of raw SQL templates that fail the
+// checks of ParseNamed and NamedTemplate.Bind
+var ErrUnsafeQuery = errors.New("unsafe raw query")
+
+// DefaultNamedQueryLimit is the LIMIT appended to named queries that have none
+const DefaultNamedQueryLimit = 1000
+
+// ScopeParam is the parameter every named query must be filtered by
+const ScopeParam = "user_id"
+
+// NamedTemplate is a hand-written SELECT for what the QueryBuilder can't
+// express (CTEs, LATERAL joins, window functions), parsed once and bound to
+// parameters per call.
+//
+// Parameters are written :name and become $1, $2, ... in the order they first
+// appear; a name used twice binds one argument. Casts (::int), string
+// literals, quoted identifiers and comments are left alone.
+//
+// ParseNamed rejects templates that could leak or overload: anything but a
+// single SELECT or WITH statement, $n placeholders, and templates that don't
+// compare a user_id column with :user_id. The scope check is static, so
+// "user_id = :user_id OR true" still passes; it catches forgotten scoping,
+// not hostile templates, which only ever come from code.
+//
+// Example:
+//
+//	tmpl, err := ParseNamed(`
+//	    SELECT a.id, t.name
+//	    FROM activities a
+//	    CROSS JOIN LATERAL (
+//	        SELECT name FROM tags WHERE tags.id = ANY(:tag_ids) LIMIT 1
+//	    ) t
+//	    WHERE a.user_id = :user_id AND a.activity_date >= :since`)
+//	sql, args, err := tmpl.Bind(map[string]interface{}{"user_id": 7, "tag_ids": ids, "since": since}, 0)
+//	// ... WHERE a.user_id = $2 AND a.activity_date >= $3 LIMIT 1000
+type NamedTemplate struct {
+	sql      string   // template with $n placeholders
+	names    []string // parameter name of $1, $2, ...
+	hasLimit bool     // the outer statement has its own LIMIT
+}
+
+// namedToken is a word, operator or parameter of a template outside string
+// literals and comments
+type namedToken struct {
+	text  string
+	param bool
+	depth int
+}
+
+// ParseNamed parses and checks a raw SQL template (see NamedTemplate)
+func ParseNamed(template string) (*NamedTemplate, error) {
+	var (
+		out    strings.Builder
+		tokens []namedToken
+		names  []string
+		index  = make(map[string]int)
+		depth  int
+		ended  bool // a ';' was seen
+	)
+
+	unsafe := func(format string, args ...interface{}) error {
+		return fmt.Errorf("%w: %s", ErrUnsafeQuery, fmt.Sprintf(format, args...))
+	}
+
+	for i := 0; i < len(template); {
+		c := template[i]
+		if ended && !isSpace(c) {
+			if !strings.HasPrefix(template[i:], "--") && !strings.HasPrefix(template[i:], "/*") {
+				return nil, unsafe("only a single statement is allowed")
+			}
+		}
+
+		switch {
+		case c == '\'' || c == '"':
+			// String literal or quoted identifier; a doubled quote escapes it
+			end := i + 1
+			for end < len(template) {
+				if template[end] == c {
+					if end+1 < len(template) && template[end+1] == c {
+						end += 2
+						continue
+					}
+					break
+				}
+				end++
+			}
+			if end >= len(template) {
+				return nil, unsafe("unterminated %c", c)
+			}
+			out.WriteString(template[i : end+1])
+			if c == '"' {
+				tokens = append(tokens, namedToken{text: template[i : end+1], depth: depth})
+			}
+			i = end + 1
+
+		case strings.HasPrefix(template[i:], "--"):
+			end := strings.IndexByte(template[i:], '\n')
+			if end < 0 {
+				end = len(template) - i
+			}
+			out.WriteString(template[i : i+end])
+			i += end
+
+		case strings.HasPrefix(template[i:], "/*"):
+			end := strings.Index(template[i+2:], "*/")
+			if end < 0 {
+				return nil, unsafe("unterminated comment")
+			}
+			out.WriteString(template[i : i+end+4])
+			i += end + 4
+
+		case c == '$':
+			return nil, unsafe("use :named parameters instead of $ placeholders and dollar quoting")
+
+		case c == ':' && i+1 < len(template) && template[i+1] == ':':
+			out.WriteString("::")
+			tokens = append(tokens, namedToken{text: "::", depth: depth})
+			i += 2
+
+		case c == ':' && i+1 < len(template) && isIdentStart(template[i+1]):
+			end := i + 1
+			for end < len(template) && isIdentPart(template[end]) {
+				end++
+			}
+			name := template[i+1 : end]
+			n, ok := index[name]
+			if !ok {
+				names = append(names, name)
+				n = len(names)
+				index[name] = n
+			}
+			fmt.Fprintf(&out, "$%d", n)
+			tokens = append(tokens, namedToken{text: name, param: true, depth: depth})
+			i = end
+
+		case c == ';':
+			ended = true
+			i++
+
+		case isIdentPart(c) || c == '.':
+			end := i
+			for end < len(template) && (isIdentPart(template[end]) || template[end] == '.') {
+				end++
+			}
+			out.WriteString(template[i:end])
+			tokens = append(tokens, namedToken{text: strings.ToLower(template[i:end]), depth: depth})
+			i = end
+
+		default:
+			switch c {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			}
+			out.WriteByte(c)
+			if !isSpace(c) {
+				tokens = append(tokens, namedToken{text: string(c), depth: depth})
+			}
+			i++
+		}
+	}
+
+	if len(tokens) == 0 || (tokens[0].text != "select" && tokens[0].text != "with") {
+		return nil, unsafe("only SELECT and WITH queries can be run")
+	}
+	if depth != 0 {
+		return nil, unsafe("unbalanced parentheses")
+	}
+	if !scopedToUser(tokens) {
+		return nil, unsafe("the query must filter a user_id column by :%s", ScopeParam)
+	}
+
+	tmpl := &NamedTemplate{sql: strings.TrimSpace(out.String()), names: names}
+	for _, token := range tokens {
+		if token.depth == 0 && !token.param && token.text == "limit" {
+			tmpl.hasLimit = true
+		}
+	}
+	return tmpl, nil
+}
+
+// Bind returns the template's SQL and its arguments in placeholder order.
+// Every parameter must be given, and only those; user_id can't be nil or
+// zero. A LIMIT of maxRows (DefaultNamedQueryLimit when 0) is appended
+// unless the outer statement has one.
+func (t *NamedTemplate) Bind(params map[string]interface{}, maxRows int) (string, []interface{}, error) {
+	args := make([]interface{}, len(t.names))
+	for i, name := range t.names {
+		value, ok := params[name]
+		if !ok {
+			return "", nil, fmt.Errorf("%w: missing parameter :%s", ErrUnsafeQuery, name)
+		}
+		args[i] = value
+	}
+	for name := range params {
+		if !contains(t.names, name) {
+			return "", nil, fmt.Errorf("%w: parameter :%s is not used by the query", ErrUnsafeQuery, name)
+		}
+	}
+	if scope := params[ScopeParam]; scope == nil || scope == 0 || scope == int64(0) || scope == "" {
+		return "", nil, fmt.Errorf("%w: :%s must be set", ErrUnsafeQuery, ScopeParam)
+	}
+
+	if t.hasLimit {
+		return t.sql, args, nil
+	}
+	if maxRows <= 0 {
+		maxRows = DefaultNamedQueryLimit
+	}
+	return fmt.Sprintf("%s LIMIT %d", t.sql, maxRows), args, nil
+}
+
+// Params returns the template's parameter names in placeholder order
+func (t *NamedTemplate) Params() []string {
+	return append([]string(nil), t.names...)
+}
+
+// scopedToUser reports whether tokens compare a user_id column with the
+// user_id parameter, in either order
+func scopedToUser(tokens []namedToken) bool {
+	isColumn := func(t namedToken) bool {
+		return !t.param && (t.text == ScopeParam || strings.HasSuffix(t.text, "."+ScopeParam))
+	}
+	isParam := func(t namedToken) bool {
+		return t.param && t.text == ScopeParam
+	}
+	for i := 0; i+2 < len(tokens); i++ {
+		if tokens[i+1].text != "=" {
+			continue
+		}
+		if (isColumn(tokens[i]) && isParam(tokens[i+2])) || (isParam(tokens[i]) && isColumn(tokens[i+2])) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
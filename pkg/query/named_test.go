@@ -0,0 +1,77 @@
+package query
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNamed_Bind(t *testing.T) {
+	tmpl, err := ParseNamed(`
+		SELECT a.id, a.created_at::date, t.name
+		FROM activities a
+		CROSS JOIN LATERAL (
+			SELECT name FROM tags WHERE tags.id = ANY(:tag_ids) LIMIT 1
+		) t
+		WHERE a.user_id = :user_id -- :not_a_param
+		  AND a.title <> ':literal'
+		  AND (a.activity_date >= :since OR a.created_at >= :since);`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tag_ids", "user_id", "since"}, tmpl.Params())
+
+	sql, args, err := tmpl.Bind(map[string]interface{}{"user_id": 7, "tag_ids": []int{1, 2}, "since": "2024-01-01"}, 50)
+	require.NoError(t, err)
+	assert.Contains(t, sql, "a.created_at::date")
+	assert.Contains(t, sql, "ANY($1)")
+	assert.Contains(t, sql, "WHERE a.user_id = $2 -- :not_a_param")
+	assert.Contains(t, sql, "a.title <> ':literal'")
+	assert.Contains(t, sql, "(a.activity_date >= $3 OR a.created_at >= $3)")
+	assert.Regexp(t, `\) LIMIT 50$`, sql, "the LATERAL LIMIT doesn't count as the outer one")
+	assert.Equal(t, []interface{}{[]int{1, 2}, 7, "2024-01-01"}, args)
+}
+
+func TestParseNamed_KeepsOuterLimit(t *testing.T) {
+	tmpl, err := ParseNamed(`SELECT id FROM jobs WHERE :user_id = user_id ORDER BY id LIMIT :limit`)
+	require.NoError(t, err)
+
+	sql, _, err := tmpl.Bind(map[string]interface{}{"user_id": int64(3), "limit": 10}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM jobs WHERE $1 = user_id ORDER BY id LIMIT $2", sql)
+}
+
+func TestParseNamed_Rejects(t *testing.T) {
+	tests := map[string]string{
+		"not scoped":           `SELECT * FROM activities WHERE id = :id`,
+		"scoped by a literal":  `SELECT * FROM activities WHERE user_id = 1`,
+		"not a SELECT":         `DELETE FROM activities WHERE user_id = :user_id`,
+		"two statements":       `SELECT 1 FROM activities WHERE user_id = :user_id; DROP TABLE users`,
+		"positional parameter": `SELECT * FROM activities WHERE user_id = $1`,
+		"unterminated literal": `SELECT * FROM activities WHERE user_id = :user_id AND title = 'x`,
+	}
+	for name, template := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseNamed(template)
+			assert.True(t, errors.Is(err, ErrUnsafeQuery), "got %v", err)
+		})
+	}
+}
+
+func TestNamedTemplate_BindChecksParams(t *testing.T) {
+	tmpl, err := ParseNamed(`WITH mine AS (SELECT * FROM activities WHERE user_id = :user_id) SELECT * FROM mine WHERE distance_km > :km`)
+	require.NoError(t, err)
+
+	_, _, err = tmpl.Bind(map[string]interface{}{"user_id": 1}, 0)
+	assert.EqualError(t, err, "unsafe raw query: missing parameter :km")
+
+	_, _, err = tmpl.Bind(map[string]interface{}{"user_id": 1, "km": 5, "kn": 5}, 0)
+	assert.EqualError(t, err, "unsafe raw query: parameter :kn is not used by the query")
+
+	_, _, err = tmpl.Bind(map[string]interface{}{"user_id": 0, "km": 5}, 0)
+	assert.EqualError(t, err, "unsafe raw query: :user_id must be set")
+
+	sql, _, err := tmpl.Bind(map[string]interface{}{"user_id": 1, "km": 5}, 0)
+	require.NoError(t, err)
+	assert.Regexp(t, `LIMIT 1000$`, sql)
+}
@@ -0,0 +1,74 @@
+package query
+
+import "strings"
+
+// DefaultPrimaryKey is the tiebreaker column ApplyOrder appends unless the
+// builder is given another one with WithPrimaryKey
+const DefaultPrimaryKey = "id"
+
+// OrderBy is one column of an ORDER BY clause
+type OrderBy struct {
+	// Column is the column to sort by (e.g., "created_at", "tags.name")
+	Column string `json:"column"`
+
+	// Direction is ASC or DESC
+	Direction string `json:"direction"`
+}
+
+// Order is an ORDER BY clause: its columns in the order they sort by, which
+// a map can't keep.
+//
+// Example:
+//
+//	var order Order
+//	order.Set("activity_date", "DESC")
+//	order.Set("title", "ASC")
+//	// ORDER BY activity_date DESC, title ASC, id DESC (see ApplyOrder)
+type Order []OrderBy
+
+// Get returns the direction column is sorted in, if it is sorted by
+func (o Order) Get(column string) (string, bool) {
+	for _, by := range o {
+		if by.Column == column {
+			return by.Direction, true
+		}
+	}
+	return "", false
+}
+
+// Set sorts by column in direction, keeping its position if it is already
+// sorted by and appending it otherwise
+func (o *Order) Set(column, direction string) {
+	for i := range *o {
+		if (*o)[i].Column == column {
+			(*o)[i].Direction = direction
+			return
+		}
+	}
+	*o = append(*o, OrderBy{Column: column, Direction: direction})
+}
+
+// Delete stops sorting by column
+func (o *Order) Delete(column string) {
+	for i := range *o {
+		if (*o)[i].Column == column {
+			*o = append((*o)[:i], (*o)[i+1:]...)
+			return
+		}
+	}
+}
+
+// withTiebreaker returns o ending in primaryKey, so rows that tie on every
+// other column still come back in the same order on every page. The
+// tiebreaker sorts in the direction of the last column; o is returned as is
+// when it already sorts by primaryKey.
+func (o Order) withTiebreaker(primaryKey string) Order {
+	direction := "ASC"
+	for _, by := range o {
+		if by.Column == primaryKey {
+			return o
+		}
+		direction = strings.ToUpper(by.Direction)
+	}
+	return append(o[:len(o):len(o)], OrderBy{Column: primaryKey, Direction: direction})
+}
@@ -2,8 +2,10 @@ package query
 
 import (
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
+	"unicode"
 )
 
 // ParseQueryParams parses HTTP query parameters into a QueryOptions struct.
@@ -30,8 +32,26 @@ import (
 //
 //	/activities?page=1&limit=20&filter[created_at][gte]=2024-01-01&filter[distance][lt]=10&order[created_at]=DESC
 //
+// Values are typed by convertValue's guess; the raw strings are kept, so an
+// EntityValidation with column types can coerce them properly (see Coerce).
+//
 // Returns QueryOptions with all parameters parsed and typed correctly.
+// Column names are kept as sent; see ParseQueryParamsWithFields.
 func ParseQueryParams(values url.Values) (*QueryOptions, error) {
+	return ParseQueryParamsWithFields(values, nil)
+}
+
+// ParseQueryParamsWithFields parses like ParseQueryParams, translating every
+// filter, filterOr, search and order column with fields, so API field names
+// (e.g., the JSON names "activityDate" or "date") can be used in place of
+// column names.
+//
+// Example:
+//
+//	fields := FieldMapping{"date": "activity_date"}
+//	opts, _ := ParseQueryParamsWithFields(url.Values{"order[date]": {"DESC"}, "filter[activityType]": {"run"}}, fields)
+//	// opts.Order = [activity_date DESC], opts.Filter = {"activity_type": "run"}
+func ParseQueryParamsWithFields(values url.Values, fields FieldMapping) (*QueryOptions, error) {
 	opts := &QueryOptions{
 		Page:             1,  // Default page
 		Limit:            10, // Default limit
@@ -39,10 +59,19 @@ func ParseQueryParams(values url.Values) (*QueryOptions, error) {
 		FilterConditions: []FilterCondition{},
 		FilterOr:         make(map[string]interface{}),
 		Search:           make(map[string]interface{}),
-		Order:            make(map[string]string),
+		Order:            Order{},
+	}
+
+	// url.Values is a map; walk it in key order so that several order[]
+	// columns always come out in the same (alphabetical) sequence
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
 
-	for key, vals := range values {
+	for _, key := range keys {
+		vals := values[key]
 		if len(vals) == 0 {
 			continue
 		}
@@ -57,6 +86,9 @@ func ParseQueryParams(values url.Values) (*QueryOptions, error) {
 			if l, err := strconv.Atoi(vals[0]); err == nil && l > 0 {
 				opts.Limit = l
 			}
+		case "count":
+			// Checked by the validators, so an unknown mode is reported
+			opts.Count = CountMode(strings.ToLower(vals[0]))
 		default:
 			// Handle nested params: filter[status], order[createdAt], filter[date][gte]
 			if strings.Contains(key, "[") && strings.Contains(key, "]") {
@@ -65,7 +97,7 @@ func ParseQueryParams(values url.Values) (*QueryOptions, error) {
 				// Detect operator-based filtering (3+ levels)
 				if len(levels) == 3 && levels[0] == "filter" {
 					// Operator-based: filter[column][operator]=value
-					column := levels[1]
+					column := fields.Column(levels[1])
 					operator := levels[2]
 					value := convertValue(vals[0])
 
@@ -75,6 +107,7 @@ func ParseQueryParams(values url.Values) (*QueryOptions, error) {
 						Operator: operator,
 						Value:    value,
 					})
+					opts.raw = append(opts.raw, rawValue{clause: "filter", column: column, operator: operator, value: vals[0]})
 
 					// Also add to legacy Filter map for backward compatibility (as equality)
 					// This ensures existing code that only checks Filter still works
@@ -84,7 +117,7 @@ func ParseQueryParams(values url.Values) (*QueryOptions, error) {
 
 				} else if len(levels) == 2 {
 					// Legacy 2-level syntax: filter[column]=value
-					prefix, column := levels[0], levels[1]
+					prefix, column := levels[0], fields.Column(levels[1])
 					value := convertValue(vals[0])
 
 					switch prefix {
@@ -96,13 +129,16 @@ func ParseQueryParams(values url.Values) (*QueryOptions, error) {
 							Operator: "eq",
 							Value:    value,
 						})
+						opts.raw = append(opts.raw, rawValue{clause: "filter", column: column, operator: "eq", value: vals[0]})
 					case "filterOr":
 						opts.FilterOr[column] = value
+						opts.raw = append(opts.raw, rawValue{clause: "filterOr", column: column, value: vals[0]})
 					case "search":
 						opts.Search[column] = value
+						opts.raw = append(opts.raw, rawValue{clause: "search", column: column, value: vals[0]})
 					case "order":
 						// Order values should stay as strings (ASC/DESC)
-						opts.Order[column] = strings.ToUpper(vals[0])
+						opts.Order.Set(column, strings.ToUpper(vals[0]))
 					}
 				}
 			}
@@ -284,23 +320,50 @@ func ParseArrayValue(val string) []string {
 }
 
 // NormalizeColumnName converts user-friendly column names to database column names.
-// Handles common cases like camelCase → snake_case conversion.
+// Handles camelCase and PascalCase → snake_case, keeping acronyms together,
+// and converts each part of a relationship path on its own.
 //
 // Examples:
 //   - "activityType" → "activity_type"
 //   - "createdAt" → "created_at"
-//   - "userId" → "user_id"
+//   - "userID" → "user_id"
+//   - "HTTPStatus" → "http_status"
+//   - "STATUS" → "status"
+//   - "tags.parentName" → "tags.parent_name"
 //
 // Note: This is optional and can be skipped if your API uses snake_case throughout.
 func NormalizeColumnName(name string) string {
-	var result []rune
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = snakeCase(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// snakeCase converts one camelCase word. A word boundary is an upper case
+// letter after a lower case letter or digit ("userId"), or the last letter
+// of an acronym followed by a lower case letter ("HTTPStatus"), except a
+// plural "s" ("userIDs").
+func snakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+
+	for i, r := range runes {
+		if !unicode.IsUpper(r) {
+			b.WriteRune(r)
+			continue
+		}
 
-	for i, r := range name {
-		if i > 0 && r >= 'A' && r <= 'Z' {
-			result = append(result, '_')
+		if i > 0 && runes[i-1] != '_' {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			pluralS := i+2 == len(runes) && runes[i+1] == 's'
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower && !pluralS) {
+				b.WriteRune('_')
+			}
 		}
-		result = append(result, r)
+		b.WriteRune(unicode.ToLower(r))
 	}
 
-	return strings.ToLower(string(result))
+	return b.String()
 }
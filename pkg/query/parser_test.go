@@ -24,7 +24,7 @@ func TestParseQueryParams(t *testing.T) {
 				Filter:   map[string]interface{}{},
 				FilterOr: map[string]interface{}{},
 				Search:   map[string]interface{}{},
-				Order:    map[string]string{},
+				Order:    Order{},
 			},
 			wantErr: false,
 		},
@@ -40,7 +40,7 @@ func TestParseQueryParams(t *testing.T) {
 				Filter:   map[string]interface{}{},
 				FilterOr: map[string]interface{}{},
 				Search:   map[string]interface{}{},
-				Order:    map[string]string{},
+				Order:    Order{},
 			},
 			wantErr: false,
 		},
@@ -57,7 +57,7 @@ func TestParseQueryParams(t *testing.T) {
 				},
 				FilterOr: map[string]interface{}{},
 				Search:   map[string]interface{}{},
-				Order:    map[string]string{},
+				Order:    Order{},
 			},
 			wantErr: false,
 		},
@@ -78,7 +78,7 @@ func TestParseQueryParams(t *testing.T) {
 				},
 				FilterOr: map[string]interface{}{},
 				Search:   map[string]interface{}{},
-				Order:    map[string]string{},
+				Order:    Order{},
 			},
 			wantErr: false,
 		},
@@ -97,7 +97,7 @@ func TestParseQueryParams(t *testing.T) {
 				},
 				FilterOr: map[string]interface{}{},
 				Search:   map[string]interface{}{},
-				Order:    map[string]string{},
+				Order:    Order{},
 			},
 			wantErr: false,
 		},
@@ -114,7 +114,7 @@ func TestParseQueryParams(t *testing.T) {
 				},
 				FilterOr: map[string]interface{}{},
 				Search:   map[string]interface{}{},
-				Order:    map[string]string{},
+				Order:    Order{},
 			},
 			wantErr: false,
 		},
@@ -131,7 +131,7 @@ func TestParseQueryParams(t *testing.T) {
 				},
 				FilterOr: map[string]interface{}{},
 				Search:   map[string]interface{}{},
-				Order:    map[string]string{},
+				Order:    Order{},
 			},
 			wantErr: false,
 		},
@@ -148,7 +148,7 @@ func TestParseQueryParams(t *testing.T) {
 				},
 				FilterOr: map[string]interface{}{},
 				Search:   map[string]interface{}{},
-				Order:    map[string]string{},
+				Order:    Order{},
 			},
 			wantErr: false,
 		},
@@ -167,7 +167,7 @@ func TestParseQueryParams(t *testing.T) {
 					"status": "active",
 				},
 				Search: map[string]interface{}{},
-				Order:  map[string]string{},
+				Order:  Order{},
 			},
 			wantErr: false,
 		},
@@ -186,7 +186,7 @@ func TestParseQueryParams(t *testing.T) {
 					"title":       "morning",
 					"description": "run",
 				},
-				Order: map[string]string{},
+				Order: Order{},
 			},
 			wantErr: false,
 		},
@@ -202,9 +202,10 @@ func TestParseQueryParams(t *testing.T) {
 				Filter:   map[string]interface{}{},
 				FilterOr: map[string]interface{}{},
 				Search:   map[string]interface{}{},
-				Order: map[string]string{
-					"created_at": "DESC",
-					"amount":     "ASC", // Converted to uppercase
+				// Several order[] params sort alphabetically; url.Values has no order
+				Order: Order{
+					{Column: "amount", Direction: "ASC"}, // Converted to uppercase
+					{Column: "created_at", Direction: "DESC"},
 				},
 			},
 			wantErr: false,
@@ -233,8 +234,8 @@ func TestParseQueryParams(t *testing.T) {
 				Search: map[string]interface{}{
 					"title": "morning",
 				},
-				Order: map[string]string{
-					"activity_date": "DESC",
+				Order: Order{
+					{Column: "activity_date", Direction: "DESC"},
 				},
 			},
 			wantErr: false,
@@ -250,7 +251,7 @@ func TestParseQueryParams(t *testing.T) {
 				Filter:   map[string]interface{}{},
 				FilterOr: map[string]interface{}{},
 				Search:   map[string]interface{}{},
-				Order:    map[string]string{},
+				Order:    Order{},
 			},
 			wantErr: false,
 		},
@@ -265,7 +266,7 @@ func TestParseQueryParams(t *testing.T) {
 				Filter:   map[string]interface{}{},
 				FilterOr: map[string]interface{}{},
 				Search:   map[string]interface{}{},
-				Order:    map[string]string{},
+				Order:    Order{},
 			},
 			wantErr: false,
 		},
@@ -280,7 +281,7 @@ func TestParseQueryParams(t *testing.T) {
 				Filter:   map[string]interface{}{},
 				FilterOr: map[string]interface{}{},
 				Search:   map[string]interface{}{},
-				Order:    map[string]string{},
+				Order:    Order{},
 			},
 			wantErr: false,
 		},
@@ -297,7 +298,7 @@ func TestParseQueryParams(t *testing.T) {
 				},
 				FilterOr: map[string]interface{}{},
 				Search:   map[string]interface{}{},
-				Order:    map[string]string{},
+				Order:    Order{},
 			},
 			wantErr: false,
 		},
@@ -565,7 +566,32 @@ func TestNormalizeColumnName(t *testing.T) {
 		{
 			name:     "single word uppercase",
 			input:    "STATUS",
-			expected: "s_t_a_t_u_s", // Each uppercase letter gets underscore
+			expected: "status",
+		},
+		{
+			name:     "trailing acronym",
+			input:    "userID",
+			expected: "user_id",
+		},
+		{
+			name:     "leading acronym",
+			input:    "HTTPStatus",
+			expected: "http_status",
+		},
+		{
+			name:     "plural acronym",
+			input:    "userIDs",
+			expected: "user_ids",
+		},
+		{
+			name:     "digits",
+			input:    "address2Line",
+			expected: "address2_line",
+		},
+		{
+			name:     "relationship path",
+			input:    "tags.parentName",
+			expected: "tags.parent_name",
 		},
 	}
 
@@ -577,6 +603,32 @@ func TestNormalizeColumnName(t *testing.T) {
 	}
 }
 
+func TestParseQueryParamsWithFields(t *testing.T) {
+	fields := FieldMapping{"date": "activity_date", "tag": "tags.name"}
+
+	opts, err := ParseQueryParamsWithFields(url.Values{
+		"filter[activityType]": {"running"},
+		"filter[date][gte]":    {"2024-01-01"},
+		"filterOr[TAG]":        {"cardio"},
+		"search[Title]":        {"morning"},
+		"order[createdAt]":     {"desc"},
+	}, fields)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{"activity_type": "running"}, opts.Filter)
+	assert.Equal(t, map[string]interface{}{"tags.name": "cardio"}, opts.FilterOr)
+	assert.Equal(t, map[string]interface{}{"title": "morning"}, opts.Search)
+	assert.Equal(t, Order{{Column: "created_at", Direction: "DESC"}}, opts.Order)
+	for _, condition := range opts.FilterConditions {
+		assert.Contains(t, []string{"activity_type", "activity_date"}, condition.Column)
+	}
+
+	// Without a mapping names are kept as sent
+	opts, err = ParseQueryParams(url.Values{"filter[activityType]": {"running"}})
+	require.NoError(t, err)
+	assert.Contains(t, opts.Filter, "activityType")
+}
+
 func TestNewQueryOptions(t *testing.T) {
 	opts := NewQueryOptions()
 
@@ -0,0 +1,243 @@
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// ErrInvalidInclude is wrapped by the errors of include= values that aren't allowed
+var ErrInvalidInclude = errors.New("invalid include")
+
+// PreloadKey is the column of a preload query holding the id of the parent
+// row each related row belongs to
+const PreloadKey = "__parent_id"
+
+// DefaultMaxIncludeDepth is how deep include= paths go unless
+// EntityValidation.MaxIncludeDepth says otherwise ("tags.parent" is 2)
+const DefaultMaxIncludeDepth = 2
+
+// Include is one relation requested with include=, e.g. "tags" or
+// "tags.parent", and the columns requested for it with fields[path]=
+type Include struct {
+	Path    string
+	Columns []string
+}
+
+// ParseIncludes reads the relations to preload from include=tags,photos,user
+// and their columns from fields[tags]=id,name. The parents of nested paths
+// are included too ("tags.parent" needs "tags"), and parents come first.
+// Paths and columns are normalized with fields.
+func ParseIncludes(values url.Values, fields FieldMapping) []Include {
+	columns := make(map[string][]string)
+	for key, vals := range values {
+		levels := extractBracketLevels(key)
+		if len(levels) == 2 && levels[0] == "fields" && len(vals) > 0 {
+			path := fields.Column(levels[1])
+			for _, column := range ParseArrayValue(vals[0]) {
+				columns[path] = append(columns[path], NormalizeColumnName(column))
+			}
+		}
+	}
+
+	paths := make(map[string]bool)
+	for _, path := range ParseArrayValue(values.Get("include")) {
+		parts := strings.Split(fields.Column(path), ".")
+		for i := 1; i <= len(parts); i++ {
+			paths[strings.Join(parts[:i], ".")] = true
+		}
+	}
+
+	includes := make([]Include, 0, len(paths))
+	for path := range paths {
+		includes = append(includes, Include{Path: path, Columns: columns[path]})
+	}
+	sort.Slice(includes, func(i, j int) bool {
+		return includes[i].Path < includes[j].Path
+	})
+	return includes
+}
+
+// Include allows include=path, e.g. "tags" or "tags.parent", returning
+// columns. fields[path]= can select a subset; without it all of columns are
+// returned. The related table's primary key is always returned as "id".
+func (v *EntityValidation) Include(path string, columns ...string) {
+	v.includes[strings.ToLower(path)] = columns
+}
+
+// ValidateIncludes checks includes against the allowed paths, their columns
+// and the depth limit, and fills in the default columns of includes without
+// fields[path]=. Errors wrap ErrInvalidInclude.
+func (v *EntityValidation) ValidateIncludes(includes []Include) error {
+	maxDepth := v.MaxIncludeDepth
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxIncludeDepth
+	}
+
+	for i, include := range includes {
+		if depth := strings.Count(include.Path, ".") + 1; depth > maxDepth {
+			return fmt.Errorf("%w: '%s' is nested %d levels, at most %d are allowed", ErrInvalidInclude, include.Path, depth, maxDepth)
+		}
+		allowed, ok := v.includes[include.Path]
+		if !ok {
+			return fmt.Errorf("%w: including '%s' is not allowed", ErrInvalidInclude, include.Path)
+		}
+		if len(include.Columns) == 0 {
+			includes[i].Columns = allowed
+			continue
+		}
+		for _, column := range include.Columns {
+			if !contains(allowed, column) {
+				return fmt.Errorf("%w: column '%s' of '%s' is not allowed", ErrInvalidInclude, column, include.Path)
+			}
+		}
+	}
+	return nil
+}
+
+// ToOne reports whether relationship name returns one row per parent
+// (many-to-one and self-referential) rather than a list
+func (rr *RelationshipRegistry) ToOne(name string) bool {
+	rel, ok := rr.Relationships[name]
+	return ok && (rel.Type == ManyToOne || rel.Type == SelfReferential)
+}
+
+// TargetRegistry returns the registry of the table relationship name points
+// to, for preloading nested relations; other tables are found through the
+// RegistryManager
+func (rr *RelationshipRegistry) TargetRegistry(name string) (*RelationshipRegistry, bool) {
+	rel, ok := rr.Relationships[name]
+	if !ok {
+		return nil, false
+	}
+	if rel.Type == SelfReferential || rel.TargetTable == rr.ParentTable {
+		return rr, true
+	}
+	if rr.manager == nil {
+		return nil, false
+	}
+	return rr.manager.GetRegistry(rel.TargetTable)
+}
+
+// PreloadQuery builds the batch query loading relationship name for the
+// parent rows with parentIDs: one row per related row, with the parent's id
+// as PreloadKey, the related row's primary key as "id" and columns.
+//
+// Example (activities → tags, many-to-many):
+//
+//	SELECT activity_tags.activity_id AS __parent_id, tags.id AS id, tags.name AS name
+//	FROM tags JOIN activity_tags ON activity_tags.tag_id = tags.id
+//	WHERE activity_tags.activity_id IN ($1,$2) AND tags.deleted_at IS NULL ...
+func (rr *RelationshipRegistry) PreloadQuery(name string, columns []string, parentIDs []int64) (string, []interface{}, error) {
+	rel, ok := rr.Relationships[name]
+	if !ok {
+		return "", nil, fmt.Errorf("%w: unknown relationship '%s'", ErrInvalidInclude, name)
+	}
+	for _, column := range columns {
+		if err := ValidateColumnName(column); err != nil || strings.Contains(column, ".") {
+			return "", nil, fmt.Errorf("%w: invalid column '%s'", ErrInvalidInclude, column)
+		}
+	}
+
+	// The table (or alias) the related rows are read from
+	source := rel.TargetTable
+	selectColumns := func(parentKey, pk string) []string {
+		selected := []string{parentKey + " AS " + PreloadKey, source + "." + pk + " AS id"}
+		for _, column := range columns {
+			if column != "id" {
+				selected = append(selected, source+"."+column+" AS "+column)
+			}
+		}
+		return selected
+	}
+
+	var query sq.SelectBuilder
+	switch rel.Type {
+	case ManyToMany:
+		pk := orDefault(rel.TargetPrimaryKey, "id")
+		parentKey := rel.JunctionTable + "." + rel.JunctionForeignKey
+		query = sq.Select(selectColumns(parentKey, pk)...).
+			From(rel.TargetTable).
+			Join(fmt.Sprintf("%s ON %s.%s = %s.%s", rel.JunctionTable, rel.JunctionTable, rel.JunctionTargetKey, rel.TargetTable, pk)).
+			Where(sq.Eq{parentKey: parentIDs})
+
+	case OneToMany:
+		parentKey := rel.TargetTable + "." + rel.ForeignKey
+		query = sq.Select(selectColumns(parentKey, orDefault(rel.PrimaryKey, "id"))...).
+			From(rel.TargetTable).
+			Where(sq.Eq{parentKey: parentIDs})
+
+	case ManyToOne:
+		pk := orDefault(rel.PrimaryKey, "id")
+		parentKey := rr.ParentTable + ".id"
+		query = sq.Select(selectColumns(parentKey, pk)...).
+			From(rel.TargetTable).
+			Join(fmt.Sprintf("%s ON %s.%s = %s.%s", rr.ParentTable, rr.ParentTable, rel.ForeignKey, rel.TargetTable, pk)).
+			Where(sq.Eq{parentKey: parentIDs})
+
+	case SelfReferential:
+		// Read the related row through its alias, joined from the child rows
+		source = orDefault(rel.Alias, rel.Name)
+		pk := orDefault(rel.PrimaryKey, "id")
+		parentKey := "child.id"
+		query = sq.Select(selectColumns(parentKey, pk)...).
+			From(fmt.Sprintf("%s AS %s", rel.TargetTable, source)).
+			Join(fmt.Sprintf("%s AS child ON child.%s = %s.%s", rel.TargetTable, rel.ForeignKey, source, pk)).
+			Where(sq.Eq{parentKey: parentIDs})
+
+	default:
+		return "", nil, fmt.Errorf("%w: relationship '%s' can't be preloaded", ErrInvalidInclude, name)
+	}
+
+	for _, condition := range rel.JoinConditions {
+		switch condition.Operator {
+		case "eq":
+			query = query.Where(sq.Eq{condition.Column: condition.Value})
+		case "ne":
+			query = query.Where(sq.NotEq{condition.Column: condition.Value})
+		}
+	}
+
+	return query.OrderBy(PreloadKey, "id").PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// Preloaded is a resource with its preloaded relations, serialized as the
+// resource's JSON object with one more key per relation
+type Preloaded struct {
+	Resource  interface{}
+	Relations map[string]interface{}
+}
+
+// MarshalJSON merges Relations into the resource's object; a relation
+// replaces a field of the same name (e.g., an activity's "tags")
+func (p Preloaded) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(p.Resource)
+	if err != nil || len(p.Relations) == 0 {
+		return data, err
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, fmt.Errorf("preloaded resource is not a JSON object: %w", err)
+	}
+	for name, relation := range p.Relations {
+		raw, err := json.Marshal(relation)
+		if err != nil {
+			return nil, err
+		}
+		object[name] = raw
+	}
+	return json.Marshal(object)
+}
@@ -0,0 +1,126 @@
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIncludes(t *testing.T) {
+	includes := ParseIncludes(url.Values{
+		"include":        {"photos,tags.parent,user"},
+		"fields[tags]":   {"name"},
+		"fields[photos]": {"contentType,fileSize"},
+	}, FieldMapping{"user": "users"})
+
+	assert.Equal(t, []Include{
+		{Path: "photos", Columns: []string{"content_type", "file_size"}},
+		{Path: "tags", Columns: []string{"name"}},
+		{Path: "tags.parent"},
+		{Path: "users"},
+	}, includes)
+
+	assert.Empty(t, ParseIncludes(url.Values{"fields[tags]": {"name"}}, nil))
+}
+
+func TestEntityValidation_ValidateIncludes(t *testing.T) {
+	v := NewEntityValidation("activities")
+	v.Include("tags", "id", "name")
+	v.Include("tags.parent", "id", "name")
+
+	includes := []Include{{Path: "tags"}, {Path: "tags.parent", Columns: []string{"name"}}}
+	require.NoError(t, v.ValidateIncludes(includes))
+	assert.Equal(t, []string{"id", "name"}, includes[0].Columns)
+	assert.Equal(t, []string{"name"}, includes[1].Columns)
+
+	tests := []struct {
+		name     string
+		includes []Include
+		expected string
+	}{
+		{name: "unknown relation", includes: []Include{{Path: "comments"}}, expected: "invalid include: including 'comments' is not allowed"},
+		{name: "column not allowed", includes: []Include{{Path: "tags", Columns: []string{"deleted_at"}}}, expected: "invalid include: column 'deleted_at' of 'tags' is not allowed"},
+		{name: "too deep", includes: []Include{{Path: "tags.parent.parent"}}, expected: "invalid include: 'tags.parent.parent' is nested 3 levels, at most 2 are allowed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateIncludes(tt.includes)
+			assert.True(t, errors.Is(err, ErrInvalidInclude))
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+func TestRelationshipRegistry_PreloadQuery(t *testing.T) {
+	activities := NewRelationshipRegistry("activities")
+	activities.Register(ManyToManyRelationship("tags", "tags", "activity_tags", "activity_id", "tag_id").WithConditions(
+		AdditionalCondition{Column: "tags.deleted_at", Operator: "eq", Value: nil},
+	))
+	activities.Register(ManyToOneRelationship("users", "users", "user_id"))
+	activities.Register(OneToManyRelationship("photos", "activity_photos", "activity_id"))
+
+	tags := NewRelationshipRegistry("tags")
+	tags.Register(SelfReferentialRelationship("parent", "tags", "parent_tag_id", 0))
+
+	tests := []struct {
+		name     string
+		registry *RelationshipRegistry
+		relation string
+		columns  []string
+		expected string
+	}{
+		{
+			name:     "many-to-many through the junction table",
+			registry: activities, relation: "tags", columns: []string{"id", "name"},
+			expected: "SELECT activity_tags.activity_id AS __parent_id, tags.id AS id, tags.name AS name FROM tags JOIN activity_tags ON activity_tags.tag_id = tags.id WHERE activity_tags.activity_id IN ($1,$2) AND tags.deleted_at IS NULL ORDER BY __parent_id, id",
+		},
+		{
+			name:     "many-to-one joined from the parent table",
+			registry: activities, relation: "users", columns: []string{"username"},
+			expected: "SELECT activities.id AS __parent_id, users.id AS id, users.username AS username FROM users JOIN activities ON activities.user_id = users.id WHERE activities.id IN ($1,$2) ORDER BY __parent_id, id",
+		},
+		{
+			name:     "one-to-many by foreign key",
+			registry: activities, relation: "photos", columns: []string{"file_size"},
+			expected: "SELECT activity_photos.activity_id AS __parent_id, activity_photos.id AS id, activity_photos.file_size AS file_size FROM activity_photos WHERE activity_photos.activity_id IN ($1,$2) ORDER BY __parent_id, id",
+		},
+		{
+			name:     "self-referential through an alias",
+			registry: tags, relation: "parent", columns: []string{"name"},
+			expected: "SELECT child.id AS __parent_id, parent.id AS id, parent.name AS name FROM tags AS parent JOIN tags AS child ON child.parent_tag_id = parent.id WHERE child.id IN ($1,$2) ORDER BY __parent_id, id",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := tt.registry.PreloadQuery(tt.relation, tt.columns, []int64{1, 2})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, sql)
+			assert.Equal(t, []interface{}{int64(1), int64(2)}, args)
+		})
+	}
+
+	_, _, err := activities.PreloadQuery("tags", []string{"name; DROP TABLE tags"}, []int64{1})
+	assert.True(t, errors.Is(err, ErrInvalidInclude))
+}
+
+func TestPreloaded_MarshalJSON(t *testing.T) {
+	resource := struct {
+		ID   int      `json:"id"`
+		Tags []string `json:"tags,omitempty"`
+	}{ID: 7, Tags: []string{"old"}}
+
+	data, err := json.Marshal(Preloaded{
+		Resource:  resource,
+		Relations: map[string]interface{}{"tags": []map[string]interface{}{{"name": "run"}}, "users": nil},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":7,"tags":[{"name":"run"}],"users":null}`, string(data))
+
+	data, err = json.Marshal(Preloaded{Resource: resource})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":7,"tags":["old"]}`, string(data))
+}
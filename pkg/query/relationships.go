@@ -2,6 +2,7 @@ package query
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -237,15 +238,21 @@ func (rr *RelationshipRegistry) GenerateJoins(opts *QueryOptions) []JoinConfig {
 		}
 	}
 
-	for column := range opts.Order {
-		if path := rr.extractPath(column); path != "" {
+	for _, by := range opts.Order {
+		if path := rr.extractPath(by.Column); path != "" {
 			neededPaths[path] = true
 		}
 	}
 
-	// Generate JOINs for each unique path
-	seenTables := make(map[string]bool) // Track which tables are already joined
+	// Generate JOINs for each unique path, in sorted order so the SQL is stable
+	paths := make([]string, 0, len(neededPaths))
 	for path := range neededPaths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	seenTables := make(map[string]bool) // Track which tables are already joined
+	for _, path := range paths {
 		pathJoins := rr.resolvePathToJoins(path, opts, seenTables)
 		joins = append(joins, pathJoins...)
 	}
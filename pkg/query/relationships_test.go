@@ -135,8 +135,8 @@ func TestRelationshipRegistry_SearchAndOrder(t *testing.T) {
 		Search: map[string]interface{}{
 			"tags.name": "run",
 		},
-		Order: map[string]string{
-			"tags.name": "ASC",
+		Order: Order{
+			{Column: "tags.name", Direction: "ASC"},
 		},
 	}
 
@@ -182,8 +182,8 @@ func TestRelationshipRegistry_NoJoinsNeeded(t *testing.T) {
 		Filter: map[string]interface{}{
 			"activity_type": "running",
 		},
-		Order: map[string]string{
-			"created_at": "DESC",
+		Order: Order{
+			{Column: "created_at", Direction: "DESC"},
 		},
 	}
 
@@ -304,8 +304,8 @@ func TestRelationshipRegistry_DuplicateJoinsAvoided(t *testing.T) {
 		Search: map[string]interface{}{
 			"tags.name": "run",
 		},
-		Order: map[string]string{
-			"tags.name": "ASC",
+		Order: Order{
+			{Column: "tags.name", Direction: "ASC"},
 		},
 	}
 
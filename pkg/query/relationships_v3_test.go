@@ -219,8 +219,8 @@ func TestRelationshipRegistry_CycleDetection_v3(t *testing.T) {
 		Search: map[string]interface{}{
 			"tags.description": "running",
 		},
-		Order: map[string]string{
-			"tags.name": "ASC",
+		Order: query.Order{
+			{Column: "tags.name", Direction: "ASC"},
 		},
 	}
 
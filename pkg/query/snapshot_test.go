@@ -0,0 +1,165 @@
+package query
+
+import (
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// update rewrites the golden files instead of comparing against them:
+//
+//	go test ./pkg/query -run TestSQLSnapshots -update
+var update = flag.Bool("update", false, "rewrite the SQL golden files in testdata/sql")
+
+// snapshotRegistries are the relationships of the repositories that use them
+var snapshotRegistries = map[string]func() *RelationshipRegistry{
+	"activities": func() *RelationshipRegistry {
+		registry := NewRelationshipRegistry("activities")
+		registry.Register(ManyToManyRelationship("tags", "tags", "activity_tags", "activity_id", "tag_id").WithConditions(
+			AdditionalCondition{Column: "tags.deleted_at", Operator: "eq", Value: nil},
+			AdditionalCondition{Column: "activity_tags.deleted_at", Operator: "eq", Value: nil},
+		))
+		registry.Register(ManyToOneRelationship("users", "users", "user_id"))
+		return registry
+	},
+	"tags": func() *RelationshipRegistry {
+		registry := NewRelationshipRegistry("tags")
+		registry.Register(SelfReferentialRelationship("parent", "tags", "parent_tag_id", 3))
+		return registry
+	},
+	"comments": func() *RelationshipRegistry {
+		registry := NewRelationshipRegistry("comments")
+		registry.Register(PolymorphicRelationship("commentable", "commentable_type", "commentable_id", map[string]string{
+			"Activity": "activities",
+			"Tag":      "tags",
+		}))
+		return registry
+	},
+}
+
+// sqlSnapshots is the catalog of list queries whose SQL is pinned in
+// testdata/sql/<name>.golden. Add a case when the builder learns something new.
+var sqlSnapshots = []struct {
+	name  string
+	table string
+	query string // as sent by a client
+}{
+	{"defaults", "activities", ""},
+	{"filter_eq", "activities", "filter[activity_type]=running"},
+	{"filter_eq_multiple", "activities", "filter[activity_type]=running&filter[user_id]=1&filter[is_public]=true"},
+	{"filter_in", "activities", "filter[activity_type]=[running,cycling]"},
+	{"filter_null", "activities", "filter[description]=null"},
+	{"operators", "activities", "filter[distance_km][gte]=5&filter[distance_km][lt]=10.5&filter[activity_date][gte]=2024-01-01&filter[activity_type][ne]=yoga"},
+	{"filter_or", "activities", "filterOr[activity_type]=running&filterOr[distance_km]=5"},
+	{"search", "activities", "search[title]=morning&search[description]=run"},
+	{"order_single", "activities", "order[activity_date]=desc"},
+	{"order_multiple", "activities", "order[distance_km]=desc&order[activity_date]=asc"},
+	{"pagination", "activities", "page=3&limit=25"},
+	{"count_none", "activities", "filter[activity_type]=running&count=none&limit=20"},
+	{"count_estimated", "activities", "filter[distance_km][gt]=10&count=estimated"},
+	{"geo_within", "activities", "filter[start][within]=51.4,-0.2,51.6,0.1"},
+	{"array_contains", "activities", "filter[tags][contains]=[cardio,outdoor]&filter[user_id]=1"},
+	{"array_overlaps", "activities", "filter[tags][overlaps]=[cardio,yoga]&order[activity_date]=desc"},
+	{"json_path", "activities", "filter[metadata.shoe]=pegasus&filter[metadata.bike.brand][ne]=trek"},
+	{"json_path_with_join", "activities", "filter[metadata.shoe]=pegasus&filter[tags.name]=cardio&order[activity_date]=desc"},
+	{"join_many_to_many", "activities", "filter[tags.name]=cardio&order[activity_date]=desc"},
+	{"join_many_to_one", "activities", "filter[users.username]=alice"},
+	{"join_multiple", "activities", "filter[tags.name]=cardio&search[users.username]=ali&order[tags.name]=asc"},
+	{"join_self_referential", "tags", "filter[tags.parent.name]=sport"},
+	{"join_polymorphic", "comments", "filter[commentable_type]=Activity&search[commentable.title]=run"},
+	{"combined", "activities", "filter[activity_type]=running&filter[distance_km][gte]=5&filterOr[is_public]=true&filterOr[user_id]=1&search[title]=park&order[distance_km]=desc&page=2&limit=50"},
+}
+
+// TestSQLSnapshots renders each catalog query the way the repositories do
+// (see repository.FindAndPaginate) and compares the SQL and arguments with
+// its golden file, so any change to the generated SQL shows up in review
+func TestSQLSnapshots(t *testing.T) {
+	for _, tc := range sqlSnapshots {
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tc.query)
+			require.NoError(t, err)
+			opts, err := ParseQueryParams(values)
+			require.NoError(t, err)
+
+			got := renderSnapshot(t, tc.table, tc.query, opts)
+			golden := filepath.Join("testdata", "sql", tc.name+".golden")
+			if *update {
+				require.NoError(t, os.MkdirAll(filepath.Dir(golden), 0o755))
+				require.NoError(t, os.WriteFile(golden, []byte(got), 0o644))
+				return
+			}
+
+			want, err := os.ReadFile(golden)
+			require.NoError(t, err, "run with -update to create the golden file")
+			require.Equal(t, string(want), got, "generated SQL changed; if intended, run with -update and review the diff")
+		})
+	}
+}
+
+func renderSnapshot(t *testing.T, table, rawQuery string, opts *QueryOptions) string {
+	t.Helper()
+
+	require.NoError(t, ResolveGeoFilters(opts, map[string]GeoPoint{
+		"start": {Lat: "start_lat", Lng: "start_lng"},
+	}))
+	require.NoError(t, ResolveJSONFilters(opts, "metadata"))
+	var joins []JoinConfig
+	if newRegistry, ok := snapshotRegistries[table]; ok {
+		joins = newRegistry().GenerateJoins(opts)
+	}
+	newBuilder := func() *QueryBuilder {
+		builder := NewQueryBuilder(table, opts)
+		if len(joins) > 0 {
+			builder = builder.WithJoins(joins)
+		}
+		return builder.ApplyFilterConditions().ApplyFilters().ApplyFiltersOr().ApplySearch()
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "-- query: %s\n", rawQuery)
+	section := func(name, sql string, args []interface{}, err error) {
+		require.NoError(t, err, name)
+		fmt.Fprintf(&out, "\n-- %s\n%s\n", name, sql)
+		for i, arg := range args {
+			fmt.Fprintf(&out, "$%d = %T(%#v)\n", i+1, arg, arg)
+		}
+	}
+
+	lookahead := opts.Count == CountNone
+	data := newBuilder().ApplyOrder()
+	if lookahead {
+		data = data.ApplyPaginationLookahead()
+	} else {
+		data = data.ApplyPagination()
+	}
+	sql, args, err := data.Build()
+	section("data", sql, args, err)
+
+	// The repositories take the fast path when it applies; it must match
+	if len(joins) == 0 {
+		if sql, args, ok := FastListQuery(table, DefaultPrimaryKey, opts, lookahead); ok {
+			section("data (fast path)", sql, args, nil)
+		}
+	}
+
+	switch opts.Count {
+	case CountNone:
+	case CountEstimated:
+		sql, args, err = newBuilder().BuildEstimate()
+		section("estimate", sql, args, err)
+	default:
+		sql, args, err = newBuilder().BuildCount()
+		section("count", sql, args, err)
+	}
+
+	sql, args, err = newBuilder().BuildVersion("updated_at")
+	section("version", sql, args, err)
+
+	return out.String()
+}
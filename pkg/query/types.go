@@ -10,6 +10,8 @@ package query
 //   - "gte" : Greater Than or Equal (>=)
 //   - "lt"  : Less Than (<)
 //   - "lte" : Less Than or Equal (<=)
+//   - "within" : Inside a bounding box (see GeoWithin)
+//   - "contains", "overlaps", "any" : Array column operators (see ArrayCondition)
 //
 // Example usage:
 //
@@ -23,7 +25,8 @@ type FilterCondition struct {
 	// Column is the database column name
 	Column string `json:"column"`
 
-	// Operator is the comparison operator (eq, ne, gt, gte, lt, lte)
+	// Operator is the comparison operator (eq, ne, gt, gte, lt, lte, within,
+	// contains, overlaps, any)
 	Operator string `json:"operator"`
 
 	// Value is the value to compare against
@@ -45,8 +48,8 @@ type FilterCondition struct {
 //	    Search: map[string]interface{}{
 //	        "title": "morning",
 //	    },
-//	    Order: map[string]string{
-//	        "created_at": "DESC",
+//	    Order: Order{
+//	        {Column: "created_at", Direction: "DESC"},
 //	    },
 //	}
 //
@@ -89,13 +92,25 @@ type QueryOptions struct {
 	// SQL: WHERE (title ILIKE '%morning%' OR description ILIKE '%run%')
 	Search map[string]interface{} `json:"search"`
 
-	// Order contains column → direction mappings for ORDER BY
-	// Example: {"created_at": "DESC", "amount": "ASC"}
-	// SQL: ORDER BY created_at DESC, amount ASC
-	Order map[string]string `json:"order"`
+	// Order contains the ORDER BY columns and directions, in sort order
+	// Example: Order{{Column: "created_at", Direction: "DESC"}, {Column: "amount", Direction: "ASC"}}
+	// SQL: ORDER BY created_at DESC, amount ASC, id DESC
+	Order Order `json:"order"`
+
+	// Count is how TotalRecords is computed (count=exact|estimated|none);
+	// empty means CountExact
+	Count CountMode `json:"count,omitempty"`
+
+	// Hints are the statement timeout and planner settings the query runs
+	// with (see Hints); set by the server, never parsed from requests
+	Hints Hints `json:"-"`
+
+	// raw holds the filter, filterOr and search values as they were sent,
+	// before convertValue guessed their type
+	raw []rawValue
 }
 
-// PaginatedResult represents paginated data with metadata.
+// Page is one page of T with its pagination metadata.
 // This is the standard response structure for all list endpoints.
 //
 // Example JSON response:
@@ -112,9 +127,9 @@ type QueryOptions struct {
 //	        "totalRecords": 95
 //	    }
 //	}
-type PaginatedResult struct {
-	// Data contains the actual result items (type varies by entity)
-	Data interface{} `json:"data"`
+type Page[T any] struct {
+	// Data contains the items of the page
+	Data []*T `json:"data"`
 
 	// Meta contains pagination metadata
 	Meta PaginationMeta `json:"meta"`
@@ -131,19 +146,25 @@ type PaginationMeta struct {
 	// Count is the number of items in the current page
 	Count int `json:"count"`
 
-	// PreviousPage is the previous page number, or false if on first page
-	// Type: int or bool (false)
-	PreviousPage interface{} `json:"previousPage"`
+	// PreviousPage is the previous page number, or nil on the first page
+	PreviousPage *int `json:"previousPage"`
 
-	// NextPage is the next page number, or false if on last page
-	// Type: int or bool (false)
-	NextPage interface{} `json:"nextPage"`
+	// NextPage is the next page number, or nil on the last page
+	NextPage *int `json:"nextPage"`
+
+	// HasPrevious and HasNext report whether PreviousPage and NextPage are set
+	HasPrevious bool `json:"hasPrevious"`
+	HasNext     bool `json:"hasNext"`
 
 	// PageCount is the total number of pages
 	PageCount int `json:"pageCount"`
 
 	// TotalRecords is the total number of records across all pages
 	TotalRecords int `json:"totalRecords"`
+
+	// CountMode is set when TotalRecords and PageCount aren't exact:
+	// estimated, or none (both left out of the JSON)
+	CountMode CountMode `json:"countMode,omitempty"`
 }
 
 // JoinConfig defines a table join configuration for relationship filtering.
@@ -187,6 +208,6 @@ func NewQueryOptions() *QueryOptions {
 		FilterConditions: []FilterCondition{},
 		FilterOr:         make(map[string]interface{}),
 		Search:           make(map[string]interface{}),
-		Order:            make(map[string]string),
+		Order:            Order{},
 	}
 }
@@ -0,0 +1,354 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ColumnRule says what a client may do with one column of an entity.
+//
+// Operators restricts filter[column][op]; nil allows AllOperators(), which
+// matches ValidateFilterConditions for columns without a whitelist. Type
+// coerces the column's filter values (see ColumnType.Coerce); TypeAuto keeps
+// the parser's guess.
+type ColumnRule struct {
+	Filter    bool
+	Search    bool
+	Order     bool
+	Operators []string
+	Type      ColumnType
+}
+
+// EntityValidation is the query whitelist of one table: which columns can be
+// filtered, searched and ordered, with which operators, and under which API
+// field names.
+//
+// Relationship columns ("tags.name", "users.email") are allowed one by one
+// with Column, or all at once for a relationship with Relationship. Deny
+// always wins, so a sensitive column stays closed when its relationship is
+// opened.
+//
+// Example:
+//
+//	v := NewEntityValidation("activities")
+//	v.Column("activity_date", ColumnRule{Filter: true, Order: true, Operators: ComparisonOperators()})
+//	v.Column("title", ColumnRule{Search: true})
+//	v.Alias("date", "activity_date")
+//	v.Relationship("users", ColumnRule{Filter: true, Operators: StrictEqualityOnly()})
+//	v.Deny("users.password_hash")
+type EntityValidation struct {
+	// Table is the entity's main table (e.g., "activities")
+	Table string
+
+	// MaxPageSize caps limit; 0 means 100, the ValidateQueryOptions cap
+	MaxPageSize int
+
+	// MaxIncludeDepth caps include= paths; 0 means DefaultMaxIncludeDepth
+	MaxIncludeDepth int
+
+	// DefaultCount is the count mode of lists without count=; empty means
+	// CountExact. Large tables can default to CountEstimated or CountNone.
+	DefaultCount CountMode
+
+	columns       map[string]ColumnRule
+	relationships map[string]ColumnRule // path prefix -> rule for every column under it
+	json          map[string]ColumnRule // JSONB column -> rule for every key under it
+	fields        FieldMapping          // API field -> column
+	denied        map[string]bool       // columns or path prefixes
+	includes      map[string][]string   // include= path -> columns it may return
+}
+
+// NewEntityValidation creates an empty whitelist for table; nothing is
+// allowed until columns are added
+func NewEntityValidation(table string) *EntityValidation {
+	return &EntityValidation{
+		Table:         table,
+		columns:       make(map[string]ColumnRule),
+		relationships: make(map[string]ColumnRule),
+		json:          make(map[string]ColumnRule),
+		fields:        make(FieldMapping),
+		denied:        make(map[string]bool),
+		includes:      make(map[string][]string),
+	}
+}
+
+// Column allows column as described by rule, replacing an earlier rule.
+// A column of a relationship (e.g., "tags.parent.name") overrides the
+// relationship's rule.
+func (v *EntityValidation) Column(column string, rule ColumnRule) {
+	v.columns[strings.ToLower(column)] = rule
+}
+
+// Relationship allows every column under the relationship path (e.g.,
+// "users" allows "users.username", "users.email", ...) as described by rule
+func (v *EntityValidation) Relationship(path string, rule ColumnRule) {
+	v.relationships[strings.ToLower(path)] = rule
+}
+
+// JSON allows the keys of the JSONB column (e.g., "metadata" allows
+// "metadata.shoe" and "metadata.bike.brand") as described by rule. Unlike
+// column names, keys keep their case. Filters on keys compare text; resolve
+// them with ResolveJSONFilters before building the query.
+func (v *EntityValidation) JSON(column string, rule ColumnRule) {
+	v.json[strings.ToLower(column)] = rule
+}
+
+// Deny rejects columns, or every column under a relationship path, whatever
+// Column and Relationship allow
+func (v *EntityValidation) Deny(columns ...string) {
+	for _, column := range columns {
+		v.denied[strings.ToLower(column)] = true
+	}
+}
+
+// Alias accepts alias as another name of column (e.g., "date" for
+// "activity_date"). camelCase names ("activityDate", "userID") don't need an
+// alias; they are matched to their snake_case column.
+func (v *EntityValidation) Alias(alias, column string) {
+	v.fields[alias] = strings.ToLower(column)
+}
+
+// Fields returns the entity's field mapping, for ParseQueryParamsWithFields
+func (v *EntityValidation) Fields() FieldMapping {
+	fields := make(FieldMapping, len(v.fields))
+	for field, column := range v.fields {
+		fields[field] = column
+	}
+	return fields
+}
+
+// Rule returns the rule of column and whether the column is allowed at all.
+// Aliases aren't resolved; see Normalize.
+func (v *EntityValidation) Rule(column string) (ColumnRule, bool) {
+	column = strings.ToLower(column)
+
+	// Denied columns and everything under a denied path
+	parts := strings.Split(column, ".")
+	for i := 1; i <= len(parts); i++ {
+		if v.denied[strings.Join(parts[:i], ".")] {
+			return ColumnRule{}, false
+		}
+	}
+
+	if rule, ok := v.columns[column]; ok {
+		return rule, true
+	}
+	if rule, ok := v.json[parts[0]]; ok && len(parts) > 1 {
+		return rule, true
+	}
+
+	// The longest relationship path containing the column
+	for i := len(parts) - 1; i >= 1; i-- {
+		if rule, ok := v.relationships[strings.Join(parts[:i], ".")]; ok {
+			return rule, true
+		}
+	}
+	return ColumnRule{}, false
+}
+
+// Normalize rewrites the columns of opts to their canonical names: aliases
+// to their column, and camelCase or mixed-case names to the snake_case
+// column they match. Unknown names are left as they are, so Validate
+// reports them as sent.
+func (v *EntityValidation) Normalize(opts *QueryOptions) {
+	opts.Filter = renameKeys(opts.Filter, v.canonical)
+	opts.FilterOr = renameKeys(opts.FilterOr, v.canonical)
+	opts.Search = renameKeys(opts.Search, v.canonical)
+	for i := range opts.Order {
+		opts.Order[i].Column = v.canonical(opts.Order[i].Column)
+	}
+	for i := range opts.FilterConditions {
+		opts.FilterConditions[i].Column = v.canonical(opts.FilterConditions[i].Column)
+	}
+}
+
+// Validate coerces the values of typed columns (see Coerce), normalizes opts
+// (see Normalize) and checks it against the whitelist, with the same errors
+// as ValidateQueryOptions and ValidateFilterConditions. Lists without
+// count= get DefaultCount.
+func (v *EntityValidation) Validate(opts *QueryOptions) error {
+	if err := v.Coerce(opts); err != nil {
+		return err
+	}
+	v.Normalize(opts)
+
+	for column := range opts.Filter {
+		if rule, ok := v.Rule(column); !ok || !rule.Filter {
+			return fmt.Errorf("filtering on column '%s' is not allowed", column)
+		}
+	}
+	for column := range opts.FilterOr {
+		if rule, ok := v.Rule(column); !ok || !rule.Filter {
+			return fmt.Errorf("filtering on column '%s' is not allowed", column)
+		}
+	}
+	for column := range opts.Search {
+		if rule, ok := v.Rule(column); !ok || !rule.Search {
+			return fmt.Errorf("searching on column '%s' is not allowed", column)
+		}
+	}
+	for _, by := range opts.Order {
+		if rule, ok := v.Rule(by.Column); !ok || !rule.Order {
+			return fmt.Errorf("ordering by column '%s' is not allowed", by.Column)
+		}
+		if err := ValidateOrderDirection(by.Direction); err != nil {
+			return fmt.Errorf("invalid order direction for column '%s': %w", by.Column, err)
+		}
+	}
+
+	if opts.Page < 1 {
+		return fmt.Errorf("page must be at least 1")
+	}
+	if opts.Limit < 1 {
+		return fmt.Errorf("limit must be at least 1")
+	}
+	maxPageSize := v.MaxPageSize
+	if maxPageSize <= 0 {
+		maxPageSize = 100
+	}
+	if opts.Limit > maxPageSize {
+		return fmt.Errorf("limit cannot exceed %d", maxPageSize)
+	}
+
+	if opts.Count == "" {
+		opts.Count = v.DefaultCount
+	}
+	if _, err := ParseCountMode(string(opts.Count)); err != nil {
+		return err
+	}
+
+	for _, condition := range opts.FilterConditions {
+		rule, ok := v.Rule(condition.Column)
+		if !ok || !rule.Filter {
+			return fmt.Errorf("filtering on column '%s' is not allowed", condition.Column)
+		}
+		allowedOperators := rule.Operators
+		if allowedOperators == nil {
+			allowedOperators = AllOperators()
+		}
+		if !contains(allowedOperators, condition.Operator) {
+			return fmt.Errorf(
+				"operator '%s' is not allowed for column '%s' (allowed: %v)",
+				condition.Operator,
+				condition.Column,
+				allowedOperators,
+			)
+		}
+		validOperators := []string{"eq", "ne", "gt", "gte", "lt", "lte", "within", "contains", "overlaps", "any"}
+		if !contains(validOperators, condition.Operator) {
+			return fmt.Errorf("unknown operator '%s'", condition.Operator)
+		}
+	}
+	return nil
+}
+
+// Coerce re-parses the filter, filterOr and search values of opts that were
+// parsed by ParseQueryParams with their column's Type. Search values of typed
+// columns stay strings, since they are matched with ILIKE. Columns without a
+// type, and columns that aren't allowed, are left for Validate.
+func (v *EntityValidation) Coerce(opts *QueryOptions) error {
+	for _, raw := range opts.raw {
+		rule, ok := v.Rule(v.canonical(raw.column))
+		if !ok || rule.Type == TypeAuto {
+			continue
+		}
+		if raw.clause == "search" {
+			opts.setValue(raw, strings.TrimSpace(raw.value))
+			continue
+		}
+		value, err := rule.Type.Coerce(raw.value)
+		if err != nil {
+			return fmt.Errorf("%w for column '%s'", err, raw.column)
+		}
+		opts.setValue(raw, value)
+	}
+	return nil
+}
+
+// canonical returns the column name is an alias or spelling of, or name
+func (v *EntityValidation) canonical(name string) string {
+	if column, ok := v.fields.lookup(name); ok {
+		return column
+	}
+	if column, key, ok := strings.Cut(name, "."); ok {
+		if _, isJSON := v.json[strings.ToLower(column)]; isJSON {
+			return strings.ToLower(column) + "." + key
+		}
+	}
+	lower := strings.ToLower(name)
+	if _, ok := v.Rule(lower); ok {
+		return lower
+	}
+	if snake := NormalizeColumnName(name); snake != lower {
+		if _, ok := v.Rule(snake); ok {
+			return snake
+		}
+	}
+	return name
+}
+
+// renameKeys returns m with its keys passed through rename
+func renameKeys(m map[string]interface{}, rename func(string) string) map[string]interface{} {
+	for key, value := range m {
+		if renamed := rename(key); renamed != key {
+			delete(m, key)
+			m[renamed] = value
+		}
+	}
+	return m
+}
+
+// ValidationConfigRegistry holds the EntityValidation of every table that
+// can be listed with QueryOptions. Repositories register theirs next to
+// their RelationshipRegistry, so the whitelist of an entity lives with its
+// relationships instead of in each handler.
+type ValidationConfigRegistry struct {
+	mu       sync.RWMutex
+	entities map[string]*EntityValidation // table name -> validation
+}
+
+// NewValidationConfigRegistry creates an empty registry
+func NewValidationConfigRegistry() *ValidationConfigRegistry {
+	return &ValidationConfigRegistry{
+		entities: make(map[string]*EntityValidation),
+	}
+}
+
+// Register adds or replaces the validation of validation.Table
+func (r *ValidationConfigRegistry) Register(validation *EntityValidation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entities[validation.Table] = validation
+}
+
+// Get retrieves the validation of a table
+func (r *ValidationConfigRegistry) Get(table string) (*EntityValidation, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	validation, exists := r.entities[table]
+	return validation, exists
+}
+
+// Tables returns the registered table names, sorted
+func (r *ValidationConfigRegistry) Tables() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tables := make([]string, 0, len(r.entities))
+	for table := range r.entities {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	return tables
+}
+
+// Validate validates opts against the validation of table. A table without
+// a registered validation allows nothing.
+func (r *ValidationConfigRegistry) Validate(table string, opts *QueryOptions) error {
+	validation, exists := r.Get(table)
+	if !exists {
+		return fmt.Errorf("no query validation registered for table '%s'", table)
+	}
+	return validation.Validate(opts)
+}
@@ -0,0 +1,121 @@
+package query
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testActivityValidation() *EntityValidation {
+	v := NewEntityValidation("activities")
+	v.Column("activity_type", ColumnRule{Filter: true, Operators: EqualityOperators()})
+	v.Column("activity_date", ColumnRule{Filter: true, Order: true, Operators: ComparisonOperators()})
+	v.Column("title", ColumnRule{Search: true})
+	v.Column("tags.name", ColumnRule{Filter: true, Search: true, Operators: EqualityOperators()})
+	v.Relationship("users", ColumnRule{Filter: true, Operators: StrictEqualityOnly()})
+	v.Deny("users.password_hash", "tags.parent")
+	v.Alias("date", "activity_date")
+	return v
+}
+
+func TestEntityValidation_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  url.Values
+		wantErr string
+	}{
+		{
+			name:   "allowed columns and operators",
+			params: url.Values{"filter[activity_date][gte]": {"2024-01-01"}, "search[title]": {"run"}, "order[activity_date]": {"DESC"}},
+		},
+		{
+			name:   "relationship whitelist",
+			params: url.Values{"filter[users.email]": {"a@b.c"}, "filter[tags.name]": {"cardio"}},
+		},
+		{
+			name:    "denied column of an allowed relationship",
+			params:  url.Values{"filter[users.password_hash]": {"x"}},
+			wantErr: "filtering on column 'users.password_hash' is not allowed",
+		},
+		{
+			name:    "denied relationship path",
+			params:  url.Values{"filter[tags.parent.name]": {"sport"}},
+			wantErr: "filtering on column 'tags.parent.name' is not allowed",
+		},
+		{
+			name:    "column not searchable",
+			params:  url.Values{"search[activity_type]": {"run"}},
+			wantErr: "searching on column 'activity_type' is not allowed",
+		},
+		{
+			name:    "operator not allowed",
+			params:  url.Values{"filter[activity_type][gt]": {"a"}},
+			wantErr: "operator 'gt' is not allowed for column 'activity_type' (allowed: [eq ne])",
+		},
+		{
+			name:    "relationship operator not allowed",
+			params:  url.Values{"filter[users.id][ne]": {"1"}},
+			wantErr: "operator 'ne' is not allowed for column 'users.id' (allowed: [eq])",
+		},
+		{
+			name:    "limit over the page size",
+			params:  url.Values{"limit": {"101"}},
+			wantErr: "limit cannot exceed 100",
+		},
+	}
+
+	v := testActivityValidation()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := ParseQueryParams(tt.params)
+			require.NoError(t, err)
+
+			err = v.Validate(opts)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEntityValidation_NormalizesAliases(t *testing.T) {
+	opts, err := ParseQueryParams(url.Values{
+		"filter[activityType]":  {"running"},
+		"filter[date][gte]":     {"2024-01-01"},
+		"order[activityDate]":   {"ASC"},
+		"search[Title]":         {"morning"},
+		"filter[unknownColumn]": {"x"},
+	})
+	require.NoError(t, err)
+
+	v := testActivityValidation()
+	assert.EqualError(t, v.Validate(opts), "filtering on column 'unknownColumn' is not allowed")
+
+	assert.Contains(t, opts.Filter, "activity_type")
+	assert.Contains(t, opts.Order, OrderBy{Column: "activity_date", Direction: "ASC"})
+	assert.Contains(t, opts.Search, "title")
+	for _, condition := range opts.FilterConditions {
+		assert.NotEqual(t, "date", condition.Column)
+	}
+}
+
+func TestValidationConfigRegistry(t *testing.T) {
+	registry := NewValidationConfigRegistry()
+	registry.Register(testActivityValidation())
+
+	tags := NewEntityValidation("tags")
+	tags.Column("name", ColumnRule{Filter: true})
+	registry.Register(tags)
+
+	assert.Equal(t, []string{"activities", "tags"}, registry.Tables())
+
+	opts := NewQueryOptions()
+	opts.Filter["name"] = "cardio"
+	assert.NoError(t, registry.Validate("tags", opts))
+	assert.EqualError(t, registry.Validate("activities", opts), "filtering on column 'name' is not allowed")
+	assert.EqualError(t, registry.Validate("users", opts), "no query validation registered for table 'users'")
+}
@@ -42,6 +42,18 @@ func EqualityOperators() []string {
 	return []string{"eq", "ne"}
 }
 
+// GeoOperators returns the operators for virtual point columns (see GeoPoint).
+// within takes a bounding box: filter[location][within]=lat1,lng1,lat2,lng2
+func GeoOperators() []string {
+	return []string{"within"}
+}
+
+// ArrayOperators returns the operators for array columns (see ArrayCondition).
+// filter[tags][contains]=[cardio,outdoor] matches rows having both tags.
+func ArrayOperators() []string {
+	return []string{"contains", "overlaps", "any"}
+}
+
 // StrictEqualityOnly returns only the equality operator.
 // Useful for ID columns where only exact matches are meaningful.
 func StrictEqualityOnly() []string {
@@ -96,7 +108,7 @@ func ValidateFilterConditions(
 		}
 
 		// Validate that the operator is a known/supported operator
-		validOperators := []string{"eq", "ne", "gt", "gte", "lt", "lte"}
+		validOperators := []string{"eq", "ne", "gt", "gte", "lt", "lte", "within", "contains", "overlaps", "any"}
 		if !contains(validOperators, condition.Operator) {
 			return fmt.Errorf("unknown operator '%s'", condition.Operator)
 		}
@@ -151,16 +163,16 @@ func ValidateQueryOptions(
 	}
 
 	// Validate order columns
-	for column := range opts.Order {
-		if !contains(allowedOrder, column) {
-			return fmt.Errorf("ordering by column '%s' is not allowed", column)
+	for _, by := range opts.Order {
+		if !contains(allowedOrder, by.Column) {
+			return fmt.Errorf("ordering by column '%s' is not allowed", by.Column)
 		}
 	}
 
 	// Validate order directions
-	for column, direction := range opts.Order {
-		if err := ValidateOrderDirection(direction); err != nil {
-			return fmt.Errorf("invalid order direction for column '%s': %w", column, err)
+	for _, by := range opts.Order {
+		if err := ValidateOrderDirection(by.Direction); err != nil {
+			return fmt.Errorf("invalid order direction for column '%s': %w", by.Column, err)
 		}
 	}
 
@@ -179,6 +191,10 @@ func ValidateQueryOptions(
 		return fmt.Errorf("limit cannot exceed %d", MaxPageSize)
 	}
 
+	if _, err := ParseCountMode(string(opts.Count)); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -28,8 +28,8 @@ func TestValidateQueryOptions(t *testing.T) {
 				Search: map[string]interface{}{
 					"title": "morning",
 				},
-				Order: map[string]string{
-					"created_at": "DESC",
+				Order: Order{
+					{Column: "created_at", Direction: "DESC"},
 				},
 			},
 			wantErr: false,
@@ -75,8 +75,8 @@ func TestValidateQueryOptions(t *testing.T) {
 			opts: &QueryOptions{
 				Page:  1,
 				Limit: 10,
-				Order: map[string]string{
-					"password": "ASC", // Not in allowedOrder
+				Order: Order{
+					{Column: "password", Direction: "ASC"}, // Not in allowedOrder
 				},
 			},
 			wantErr: true,
@@ -87,8 +87,8 @@ func TestValidateQueryOptions(t *testing.T) {
 			opts: &QueryOptions{
 				Page:  1,
 				Limit: 10,
-				Order: map[string]string{
-					"created_at": "RANDOM", // Not ASC or DESC
+				Order: Order{
+					{Column: "created_at", Direction: "RANDOM"}, // Not ASC or DESC
 				},
 			},
 			wantErr: true,
@@ -142,7 +142,7 @@ func TestValidateQueryOptions(t *testing.T) {
 				Filter:   map[string]interface{}{},
 				FilterOr: map[string]interface{}{},
 				Search:   map[string]interface{}{},
-				Order:    map[string]string{},
+				Order:    Order{},
 			},
 			wantErr: false,
 		},
@@ -255,7 +255,7 @@ func TestValidateWithConfig(t *testing.T) {
 				Page:   1,
 				Limit:  10,
 				Filter: map[string]interface{}{"user_id": 123},
-				Order:  map[string]string{"created_at": "DESC"},
+				Order:  Order{{Column: "created_at", Direction: "DESC"}},
 			},
 			config: &ValidationConfig{
 				AllowedFilters:   []string{"user_id"},
@@ -589,8 +589,8 @@ func TestValidation_SecurityScenarios(t *testing.T) {
 			opts: &QueryOptions{
 				Page:  1,
 				Limit: 10,
-				Order: map[string]string{
-					"password_hash": "ASC",
+				Order: Order{
+					{Column: "password_hash", Direction: "ASC"},
 				},
 			},
 			wantErr: true,
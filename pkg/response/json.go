@@ -3,9 +3,12 @@ package response
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"reflect"
 	"time"
+
+	"github.com/valentinesamuel/activelog/pkg/i18n"
 )
 
 type contextKey int
@@ -28,7 +31,7 @@ func Success(w http.ResponseWriter, r *http.Request, statusCode int, result inte
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"statusCode": statusCode,
 		"success":    true,
-		"message":    "Request successful",
+		"message":    i18n.FromContext(r.Context()).Localize("Request successful", nil),
 		"result":     normalizeResult(result),
 		"path":       r.URL.RequestURI(),
 		"duration":   duration,
@@ -76,14 +79,23 @@ func normalizeValue(v reflect.Value) interface{} {
 	}
 }
 
+// Fail writes an error response. A 500 caused by the request's context
+// deadline passing (e.g. a query cancelled mid-flight) is reported as 504.
+// message is translated to the request's language when it is in the
+// catalogs (see pkg/i18n), and sent as is otherwise.
 func Fail(w http.ResponseWriter, r *http.Request, statusCode int, message string) {
+	if statusCode == http.StatusInternalServerError && errors.Is(r.Context().Err(), context.DeadlineExceeded) {
+		statusCode = http.StatusGatewayTimeout
+		message = "Request timed out"
+	}
+
 	duration := computeDuration(r.Context())
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"statusCode": statusCode,
 		"success":    false,
-		"message":    message,
+		"message":    i18n.FromContext(r.Context()).Localize(message, nil),
 		"errors":     []interface{}{},
 		"path":       r.URL.RequestURI(),
 		"duration":   duration,
@@ -97,7 +109,7 @@ func ValidationFail(w http.ResponseWriter, r *http.Request, errs []ValidationErr
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"statusCode": http.StatusBadRequest,
 		"success":    false,
-		"message":    "Bad Request",
+		"message":    i18n.FromContext(r.Context()).Localize("Bad Request", nil),
 		"errors":     errs,
 		"path":       r.URL.RequestURI(),
 		"duration":   duration,
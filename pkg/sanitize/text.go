@@ -0,0 +1,48 @@
+// Package sanitize cleans user-supplied free text before it is stored
+package sanitize
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Text returns s as valid UTF-8 with control characters removed.
+// Invalid byte sequences are dropped, line endings are normalised to \n,
+// tabs and newlines are kept, and other control characters (C0, DEL, C1)
+// and bidirectional overrides, which can disguise text, are removed.
+// Leading and trailing whitespace is trimmed.
+func Text(s string) string {
+	if !utf8.ValidString(s) {
+		s = strings.ToValidUTF8(s, "")
+	}
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r == '\n' || r == '\t':
+			return r
+		case r == '\r':
+			return '\n'
+		case unicode.IsControl(r), isBidiControl(r):
+			return -1
+		}
+		return r
+	}, s)
+
+	return strings.TrimSpace(s)
+}
+
+// TextPtr sanitizes *s in a new string, leaving nil (field not sent) as nil
+func TextPtr(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := Text(*s)
+	return &v
+}
+
+// isBidiControl reports whether r is a bidirectional embedding, override or isolate
+func isBidiControl(r rune) bool {
+	return (r >= '\u202a' && r <= '\u202e') || (r >= '\u2066' && r <= '\u2069')
+}
@@ -0,0 +1,40 @@
+package sanitize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain text unchanged", "Morning run", "Morning run"},
+		{"unicode kept", "Café 10km 🏃", "Café 10km 🏃"},
+		{"trims whitespace", "  easy pace \n", "easy pace"},
+		{"keeps newlines and tabs", "lap 1\tfast\nlap 2", "lap 1\tfast\nlap 2"},
+		{"normalises line endings", "a\r\nb\rc", "a\nb\nc"},
+		{"drops control characters", "ti\x00tle\x1b[31m\x7f", "title[31m"},
+		{"drops C1 controls", "a\u0085b", "ab"},
+		{"drops bidi overrides", "file\u202egpj.exe", "filegpj.exe"},
+		{"drops invalid utf-8", "ok\xff\xfe!", "ok!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Text(tt.in))
+		})
+	}
+}
+
+func TestTextPtr(t *testing.T) {
+	assert.Nil(t, TextPtr(nil))
+
+	in := " notes\x00 "
+	out := TextPtr(&in)
+	assert.Equal(t, "notes", *out)
+	assert.Equal(t, " notes\x00 ", in, "input must not be modified")
+}
@@ -0,0 +1,126 @@
+// Package staticmap renders GPS tracks as small PNG map thumbnails. It
+// renders offline: the track is drawn on a plain background in Web Mercator,
+// the projection map tiles use, so thumbnails keep the shape the track has
+// on a map without fetching tiles.
+package staticmap
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+
+	"github.com/valentinesamuel/activelog/pkg/polyline"
+)
+
+var (
+	background = color.RGBA{R: 0xf2, G: 0xef, B: 0xe9, A: 0xff}
+	trackColor = color.RGBA{R: 0xfc, G: 0x4c, B: 0x02, A: 0xff}
+	startColor = color.RGBA{R: 0x2e, G: 0x9e, B: 0x44, A: 0xff}
+	endColor   = color.RGBA{R: 0x20, G: 0x20, B: 0x20, A: 0xff}
+)
+
+// Style of the drawing, in pixels
+const (
+	padding      = 12
+	lineRadius   = 1.5
+	markerRadius = 4
+)
+
+// Render draws a track of one or more segments on a size×size image, scaled
+// to fit with the same scale on both axes. A gap between segments (e.g. a
+// part of the track left out for privacy) is left undrawn. The first point
+// is marked green and the last dark.
+func Render(segments [][]polyline.Point, size int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(img, img.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+
+	var projected [][][2]float64
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, segment := range segments {
+		var xy [][2]float64
+		for _, p := range segment {
+			x, y := mercator(p)
+			xy = append(xy, [2]float64{x, y})
+			minX, maxX = math.Min(minX, x), math.Max(maxX, x)
+			minY, maxY = math.Min(minY, y), math.Max(maxY, y)
+		}
+		if len(xy) > 0 {
+			projected = append(projected, xy)
+		}
+	}
+	if len(projected) == 0 {
+		return img
+	}
+
+	// A track with no extent (a single point) is drawn at the centre
+	span := math.Max(maxX-minX, maxY-minY)
+	scale := 0.0
+	if span > 0 {
+		scale = float64(size-2*padding) / span
+	}
+	offsetX := (float64(size) - (maxX-minX)*scale) / 2
+	offsetY := (float64(size) - (maxY-minY)*scale) / 2
+	toPixel := func(p [2]float64) [2]float64 {
+		return [2]float64{offsetX + (p[0]-minX)*scale, offsetY + (p[1]-minY)*scale}
+	}
+
+	for _, segment := range projected {
+		for i := 1; i < len(segment); i++ {
+			drawLine(img, toPixel(segment[i-1]), toPixel(segment[i]), lineRadius, trackColor)
+		}
+	}
+	first := projected[0][0]
+	last := projected[len(projected)-1][len(projected[len(projected)-1])-1]
+	drawDisc(img, toPixel(last), markerRadius, endColor)
+	drawDisc(img, toPixel(first), markerRadius, startColor)
+	return img
+}
+
+// RenderPNG renders a track (see Render) and encodes it as PNG
+func RenderPNG(segments [][]polyline.Point, size int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, Render(segments, size)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// mercator projects a point to Web Mercator, with x and y in [0, 1] and y
+// growing southwards as on screen
+func mercator(p polyline.Point) (x, y float64) {
+	lat := math.Max(-85.05112878, math.Min(85.05112878, p.Lat)) * math.Pi / 180
+	x = (p.Lng + 180) / 360
+	y = (1 - math.Log(math.Tan(lat)+1/math.Cos(lat))/math.Pi) / 2
+	return x, y
+}
+
+// drawLine draws a line of the given radius by stamping discs along it
+func drawLine(img *image.RGBA, a, b [2]float64, radius float64, c color.RGBA) {
+	steps := int(math.Ceil(math.Hypot(b[0]-a[0], b[1]-a[1]) * 2))
+	for i := 0; i <= steps; i++ {
+		t := 0.0
+		if steps > 0 {
+			t = float64(i) / float64(steps)
+		}
+		drawDisc(img, [2]float64{a[0] + t*(b[0]-a[0]), a[1] + t*(b[1]-a[1])}, radius, c)
+	}
+}
+
+// drawDisc fills the pixels whose centres lie within radius of centre
+func drawDisc(img *image.RGBA, centre [2]float64, radius float64, c color.RGBA) {
+	bounds := img.Bounds()
+	for y := int(math.Floor(centre[1] - radius)); y <= int(math.Ceil(centre[1]+radius)); y++ {
+		for x := int(math.Floor(centre[0] - radius)); x <= int(math.Ceil(centre[0]+radius)); x++ {
+			if !image.Pt(x, y).In(bounds) {
+				continue
+			}
+			if math.Hypot(float64(x)+0.5-centre[0], float64(y)+0.5-centre[1]) <= radius {
+				img.SetRGBA(x, y, c)
+			}
+		}
+	}
+}
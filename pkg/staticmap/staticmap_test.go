@@ -0,0 +1,47 @@
+package staticmap
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valentinesamuel/activelog/pkg/polyline"
+)
+
+func TestRender(t *testing.T) {
+	// Due east, then a gap, then on east: the track is wider than tall, so
+	// it is drawn across the middle row
+	segments := [][]polyline.Point{
+		{{Lat: 51.5, Lng: -0.12}, {Lat: 51.5, Lng: -0.11}},
+		{{Lat: 51.5, Lng: -0.09}, {Lat: 51.5, Lng: -0.08}},
+	}
+	img := Render(segments, 100)
+
+	assert.Equal(t, 100, img.Bounds().Dx())
+	assert.Equal(t, startColor, img.RGBAAt(padding, 50))
+	assert.Equal(t, endColor, img.RGBAAt(100-padding-1, 50))
+	assert.Equal(t, trackColor, img.RGBAAt(30, 50))
+	assert.Equal(t, background, img.RGBAAt(50, 50), "the gap is left undrawn")
+	assert.Equal(t, background, img.RGBAAt(30, 10))
+}
+
+func TestRender_Empty(t *testing.T) {
+	img := Render(nil, 10)
+	assert.Equal(t, background, img.RGBAAt(5, 5))
+}
+
+func TestRender_SinglePoint(t *testing.T) {
+	img := Render([][]polyline.Point{{{Lat: 51.5, Lng: -0.12}}}, 20)
+	assert.Equal(t, startColor, img.RGBAAt(10, 10))
+}
+
+func TestRenderPNG(t *testing.T) {
+	data, err := RenderPNG([][]polyline.Point{{{Lat: 0, Lng: 0}, {Lat: 0.01, Lng: 0.01}}}, 64)
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, 64, img.Bounds().Dx())
+}
@@ -0,0 +1,53 @@
+package tabular
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// csvFlushEvery bounds how many rows sit in the csv.Writer buffer before being sent
+const csvFlushEvery = 100
+
+// CSVEncoder streams rows as RFC 4180 CSV
+type CSVEncoder struct {
+	writer *csv.Writer
+	rows   int
+	record []string
+}
+
+// NewCSVEncoder creates a CSV encoder writing to w
+func NewCSVEncoder(w io.Writer) *CSVEncoder {
+	return &CSVEncoder{writer: csv.NewWriter(w)}
+}
+
+// WriteHeader writes the column names as the first row
+func (e *CSVEncoder) WriteHeader(columns []string) error {
+	return e.writer.Write(columns)
+}
+
+// WriteRow writes one record, flushing periodically so large pages stream
+func (e *CSVEncoder) WriteRow(values []interface{}) error {
+	if cap(e.record) < len(values) {
+		e.record = make([]string, len(values))
+	}
+	e.record = e.record[:len(values)]
+	for i, value := range values {
+		e.record[i] = formatValue(value)
+	}
+	if err := e.writer.Write(e.record); err != nil {
+		return err
+	}
+
+	e.rows++
+	if e.rows%csvFlushEvery == 0 {
+		e.writer.Flush()
+		return e.writer.Error()
+	}
+	return nil
+}
+
+// Close flushes any buffered rows
+func (e *CSVEncoder) Close() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
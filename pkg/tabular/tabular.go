@@ -0,0 +1,204 @@
+// Package tabular encodes list results as CSV or XLSX for spreadsheet-friendly exports.
+//
+// Rows are written to the underlying writer as they are encoded, so a handler
+// can stream a page of results straight into the http.ResponseWriter.
+package tabular
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// Format identifies a response encoding for list endpoints
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+// Content types used for negotiation
+const (
+	ContentTypeCSV  = "text/csv"
+	ContentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// ContentType returns the MIME type written for f
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return ContentTypeCSV + "; charset=utf-8"
+	case FormatXLSX:
+		return ContentTypeXLSX
+	default:
+		return "application/json"
+	}
+}
+
+// Negotiate picks the response format for a list request.
+// An explicit ?format= wins over the Accept header; anything unrecognised falls back to JSON.
+//
+// Examples:
+//   - ?format=csv                          → FormatCSV
+//   - Accept: text/csv                     → FormatCSV
+//   - Accept: application/vnd.openxml...   → FormatXLSX
+//   - Accept: application/json, */*        → FormatJSON
+func Negotiate(r *http.Request) Format {
+	switch Format(strings.ToLower(r.URL.Query().Get("format"))) {
+	case FormatCSV:
+		return FormatCSV
+	case FormatXLSX:
+		return FormatXLSX
+	case FormatJSON:
+		return FormatJSON
+	}
+
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case ContentTypeCSV:
+			return FormatCSV
+		case ContentTypeXLSX:
+			return FormatXLSX
+		case "application/json":
+			return FormatJSON
+		}
+	}
+
+	return FormatJSON
+}
+
+// Encoder writes a header followed by rows in a tabular format.
+// Close must be called to flush buffered output and finish the document.
+type Encoder interface {
+	WriteHeader(columns []string) error
+	WriteRow(values []interface{}) error
+	Close() error
+}
+
+// NewEncoder returns an encoder for f writing to w
+func NewEncoder(f Format, w io.Writer) (Encoder, error) {
+	switch f {
+	case FormatCSV:
+		return NewCSVEncoder(w), nil
+	case FormatXLSX:
+		return NewXLSXEncoder(w), nil
+	default:
+		return nil, fmt.Errorf("unsupported tabular format '%s'", f)
+	}
+}
+
+// WriteRecords encodes a slice of records (structs, pointers to structs or maps)
+// as rows, picking the values for columns by their JSON field names.
+//
+// Going through JSON means the column names match the API's JSON output exactly,
+// and results served from cache (already decoded into maps) encode the same way
+// as freshly scanned models. Missing fields become empty cells.
+func WriteRecords(enc Encoder, columns []string, records interface{}) error {
+	if err := enc.WriteHeader(columns); err != nil {
+		return err
+	}
+
+	v := reflect.ValueOf(records)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return fmt.Errorf("records must be a slice, got %s", v.Kind())
+	}
+
+	values := make([]interface{}, len(columns))
+	for i := 0; i < v.Len(); i++ {
+		fields, err := toFieldMap(v.Index(i).Interface())
+		if err != nil {
+			return fmt.Errorf("failed to encode record %d: %w", i, err)
+		}
+		for c, column := range columns {
+			values[c] = fields[column]
+		}
+		if err := enc.WriteRow(values); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SelectColumns resolves a comma-separated sparse fieldset (e.g. "id,title") against
+// the columns a resource exposes. An empty selection returns all available columns.
+func SelectColumns(fields string, available []string) ([]string, error) {
+	if strings.TrimSpace(fields) == "" {
+		return available, nil
+	}
+
+	allowed := make(map[string]bool, len(available))
+	for _, column := range available {
+		allowed[column] = true
+	}
+
+	selected := []string{}
+	seen := map[string]bool{}
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" || seen[field] {
+			continue
+		}
+		if !allowed[field] {
+			return nil, fmt.Errorf("field '%s' is not available (allowed: %s)", field, strings.Join(available, ", "))
+		}
+		seen[field] = true
+		selected = append(selected, field)
+	}
+
+	return selected, nil
+}
+
+func toFieldMap(record interface{}) (map[string]interface{}, error) {
+	if m, ok := record.(map[string]interface{}); ok {
+		return m, nil
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// formatValue renders a JSON-decoded value as text
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return fmt.Sprintf("%v", v)
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(data)
+	}
+}
@@ -0,0 +1,127 @@
+package tabular
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sampleRecord struct {
+	ID    int64   `json:"id"`
+	Title string  `json:"title"`
+	Km    float64 `json:"distanceKm,omitempty"`
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		accept string
+		want   Format
+	}{
+		{name: "default is json", target: "/activities", want: FormatJSON},
+		{name: "format param csv", target: "/activities?format=csv", want: FormatCSV},
+		{name: "format param wins over accept", target: "/activities?format=json", accept: ContentTypeCSV, want: FormatJSON},
+		{name: "accept csv", target: "/activities", accept: "text/csv", want: FormatCSV},
+		{name: "accept xlsx with params", target: "/activities", accept: ContentTypeXLSX + "; q=0.9", want: FormatXLSX},
+		{name: "first supported accept entry wins", target: "/activities", accept: "application/json, text/csv", want: FormatJSON},
+		{name: "unknown falls back to json", target: "/activities?format=yaml", accept: "text/html", want: FormatJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.target, nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			assert.Equal(t, tt.want, Negotiate(r))
+		})
+	}
+}
+
+func TestSelectColumns(t *testing.T) {
+	available := []string{"id", "title", "distanceKm"}
+
+	all, err := SelectColumns("", available)
+	require.NoError(t, err)
+	assert.Equal(t, available, all)
+
+	selected, err := SelectColumns("title, id,title", available)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"title", "id"}, selected)
+
+	_, err = SelectColumns("id,userId", available)
+	assert.Error(t, err)
+}
+
+func TestWriteRecords_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewCSVEncoder(&buf)
+
+	records := []*sampleRecord{
+		{ID: 1, Title: "Morning, run", Km: 5.5},
+		{ID: 2, Title: "Yoga"},
+	}
+	require.NoError(t, WriteRecords(enc, []string{"id", "title", "distanceKm"}, records))
+	require.NoError(t, enc.Close())
+
+	assert.Equal(t, "id,title,distanceKm\n1,\"Morning, run\",5.5\n2,Yoga,\n", buf.String())
+}
+
+func TestWriteRecords_CachedMaps(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewCSVEncoder(&buf)
+
+	// Cached list results come back as decoded JSON maps
+	records := []interface{}{
+		map[string]interface{}{"id": float64(7), "title": "Swim"},
+	}
+	require.NoError(t, WriteRecords(enc, []string{"title", "id"}, records))
+	require.NoError(t, enc.Close())
+
+	assert.Equal(t, "title,id\nSwim,7\n", buf.String())
+}
+
+func TestWriteRecords_XLSX(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewXLSXEncoder(&buf)
+
+	records := []sampleRecord{{ID: 1, Title: "Ride <hills> & valleys", Km: 42}}
+	require.NoError(t, WriteRecords(enc, []string{"id", "title"}, records))
+	require.NoError(t, enc.Close())
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	names := []string{}
+	var sheet string
+	for _, f := range archive.File {
+		names = append(names, f.Name)
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			rc, err := f.Open()
+			require.NoError(t, err)
+			data, err := io.ReadAll(rc)
+			require.NoError(t, err)
+			rc.Close()
+			sheet = string(data)
+		}
+	}
+
+	assert.Contains(t, names, "[Content_Types].xml")
+	assert.Contains(t, names, "xl/workbook.xml")
+	assert.Contains(t, sheet, `<c r="A1" t="inlineStr"><is><t xml:space="preserve">id</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="A2"><v>1</v></c>`)
+	assert.Contains(t, sheet, `Ride &lt;hills&gt; &amp; valleys`)
+}
+
+func TestColumnName(t *testing.T) {
+	assert.Equal(t, "A", columnName(0))
+	assert.Equal(t, "Z", columnName(25))
+	assert.Equal(t, "AA", columnName(26))
+	assert.Equal(t, "AB", columnName(27))
+}
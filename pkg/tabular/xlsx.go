@@ -0,0 +1,145 @@
+package tabular
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// XLSXEncoder streams rows into a single-sheet Office Open XML workbook.
+//
+// The workbook scaffolding is written up front and the worksheet is the last
+// zip entry, so rows go out as they are encoded instead of being held in memory.
+// Strings are stored inline (no shared string table) to keep the writer single-pass.
+type XLSXEncoder struct {
+	zip    *zip.Writer
+	sheet  *bufio.Writer
+	row    int
+	err    error
+	opened bool
+}
+
+// NewXLSXEncoder creates an XLSX encoder writing to w
+func NewXLSXEncoder(w io.Writer) *XLSXEncoder {
+	return &XLSXEncoder{zip: zip.NewWriter(w)}
+}
+
+var xlsxParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+// open writes the workbook scaffolding and starts the worksheet entry
+func (e *XLSXEncoder) open() error {
+	if e.opened {
+		return e.err
+	}
+	e.opened = true
+
+	for _, part := range xlsxParts {
+		f, err := e.zip.Create(part.name)
+		if err != nil {
+			e.err = err
+			return err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			e.err = err
+			return err
+		}
+	}
+
+	f, err := e.zip.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		e.err = err
+		return err
+	}
+	e.sheet = bufio.NewWriter(f)
+	_, e.err = e.sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	return e.err
+}
+
+// WriteHeader writes the column names as the first row
+func (e *XLSXEncoder) WriteHeader(columns []string) error {
+	values := make([]interface{}, len(columns))
+	for i, column := range columns {
+		values[i] = column
+	}
+	return e.WriteRow(values)
+}
+
+// WriteRow appends one row to the worksheet
+// float64 values become numeric cells; everything else is written as text
+func (e *XLSXEncoder) WriteRow(values []interface{}) error {
+	if err := e.open(); err != nil {
+		return err
+	}
+
+	e.row++
+	fmt.Fprintf(e.sheet, `<row r="%d">`, e.row)
+	for i, value := range values {
+		ref := columnName(i) + strconv.Itoa(e.row)
+		switch v := value.(type) {
+		case nil:
+			continue
+		case float64:
+			fmt.Fprintf(e.sheet, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(v, 'f', -1, 64))
+		default:
+			fmt.Fprintf(e.sheet, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+			if err := xml.EscapeText(e.sheet, []byte(formatValue(v))); err != nil {
+				e.err = err
+				return err
+			}
+			e.sheet.WriteString(`</t></is></c>`)
+		}
+	}
+	_, e.err = e.sheet.WriteString(`</row>`)
+	return e.err
+}
+
+// Close finishes the worksheet and the zip archive
+func (e *XLSXEncoder) Close() error {
+	if err := e.open(); err != nil {
+		return err
+	}
+	if _, err := e.sheet.WriteString(`</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	if err := e.sheet.Flush(); err != nil {
+		return err
+	}
+	return e.zip.Close()
+}
+
+// columnName converts a zero-based column index to its spreadsheet letters (0 → A, 27 → AB)
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
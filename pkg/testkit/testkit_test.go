@@ -0,0 +1,41 @@
+package testkit
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+func TestKit(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts a PostgreSQL container")
+	}
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	kit := New(t, Options{})
+	alice, bob := kit.CreateUser(), kit.CreateUser()
+	id := kit.CreateActivity(alice, map[string]interface{}{"activityType": "cycling"})
+
+	rec := kit.Do(http.MethodGet, "/api/v1/activities", nil, alice.Token)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var list struct {
+		Data []struct {
+			ID           int64  `json:"id"`
+			ActivityType string `json:"activityType"`
+		} `json:"data"`
+	}
+	DecodeResult(t, rec, &list)
+	require.Len(t, list.Data, 1)
+	assert.Equal(t, id, list.Data[0].ID)
+	assert.Equal(t, "cycling", list.Data[0].ActivityType)
+
+	rec = kit.Do(http.MethodGet, "/api/v1/activities", nil, kit.Token(bob.ID, bob.Email))
+	require.Equal(t, http.StatusOK, rec.Code)
+	DecodeResult(t, rec, &list)
+	assert.Empty(t, list.Data, "activities are per user")
+
+	assert.Equal(t, http.StatusUnauthorized, kit.Do(http.MethodGet, "/api/v1/activities", nil, "").Code)
+}
@@ -0,0 +1,90 @@
+// Package ulid generates ULIDs: 26-character, lexicographically sortable
+// identifiers made of a millisecond timestamp and 80 random bits, written in
+// Crockford's base32. They serve as public identifiers that, unlike serial
+// IDs, reveal neither how many rows a table holds nor their neighbours.
+package ulid
+
+import (
+	"crypto/rand"
+	"strings"
+	"time"
+)
+
+// Length is the length of a ULID string
+const Length = 26
+
+// alphabet is Crockford's base32, without I, L, O and U
+const alphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// New returns a ULID for the current time
+func New() string {
+	return NewAt(time.Now())
+}
+
+// NewAt returns a ULID for t
+func NewAt(t time.Time) string {
+	var id [16]byte
+	ms := uint64(t.UnixMilli())
+	for i := 5; i >= 0; i-- {
+		id[i] = byte(ms)
+		ms >>= 8
+	}
+	// crypto/rand.Read never returns an error
+	_, _ = rand.Read(id[6:])
+	return encode(id)
+}
+
+// encode writes the 128 bits of id as 26 base32 characters, the first of
+// which carries only 3 bits
+func encode(id [16]byte) string {
+	var b strings.Builder
+	b.Grow(Length)
+	for i := 0; i < Length; i++ {
+		// bit offset of the character, counting the 2 padding bits in front
+		bit := i*5 - 2
+		var v uint16
+		for j := 0; j < 5; j++ {
+			v <<= 1
+			if pos := bit + j; pos >= 0 {
+				v |= uint16(id[pos/8]>>(7-pos%8)) & 1
+			}
+		}
+		b.WriteByte(alphabet[v])
+	}
+	return b.String()
+}
+
+// Valid reports whether s is a ULID: 26 characters of Crockford's base32,
+// in either case, the first no greater than 7 so the value fits in 128 bits
+func Valid(s string) bool {
+	if len(s) != Length || s[0] > '7' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(alphabet, toUpper(s[i])) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Normalize returns the canonical, upper-case form of a valid ULID
+func Normalize(s string) string {
+	return strings.ToUpper(s)
+}
+
+// Time returns the timestamp of a valid ULID
+func Time(s string) time.Time {
+	var ms int64
+	for i := 0; i < 10; i++ {
+		ms = ms<<5 | int64(strings.IndexByte(alphabet, toUpper(s[i])))
+	}
+	return time.UnixMilli(ms)
+}
+
+func toUpper(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+	return c
+}
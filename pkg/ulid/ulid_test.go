@@ -0,0 +1,56 @@
+package ulid
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAt_EncodesTimestamp(t *testing.T) {
+	at := time.Date(2026, 3, 14, 15, 9, 26, 535_000_000, time.UTC)
+
+	id := NewAt(at)
+
+	assert.Len(t, id, Length)
+	assert.True(t, Valid(id))
+	assert.True(t, Time(id).Equal(at))
+}
+
+func TestNewAt_KnownTimestamp(t *testing.T) {
+	// 1469918176385 ms is 01ARYZ6S41 in the ULID spec's example
+	id := NewAt(time.UnixMilli(1469918176385))
+
+	assert.Equal(t, "01ARYZ6S41", id[:10])
+}
+
+func TestNew_SortsByTime(t *testing.T) {
+	earlier := NewAt(time.Unix(1_700_000_000, 0))
+	later := NewAt(time.Unix(1_700_000_001, 0))
+
+	assert.Less(t, earlier, later)
+	assert.NotEqual(t, New(), New())
+}
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		want bool
+	}{
+		{"canonical", "01ARZ3NDEKTSV4RRFFQ69G5FAV", true},
+		{"lower case", "01arz3ndektsv4rrffq69g5fav", true},
+		{"too short", "01ARZ3NDEKTSV4RRFFQ69G5FA", false},
+		{"serial id", "42", false},
+		{"excluded letter", "01ARZ3NDEKTSV4RRFFQ69G5FAU", false},
+		{"overflows 128 bits", "81ARZ3NDEKTSV4RRFFQ69G5FAV", false},
+		{"26 digits", strings.Repeat("1", 26), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Valid(tt.id))
+		})
+	}
+}